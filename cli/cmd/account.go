@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// accountEntry is one signed-in account as listed by account list --json
+type accountEntry struct {
+	Handle  string `json:"handle"`
+	Did     string `json:"did"`
+	Service string `json:"service"`
+	Auth    string `json:"auth"` // "oauth" or "password"
+	Default bool   `json:"default"`
+	InUse   bool   `json:"inUse"` // the account this run acts as, which --account can change
+}
+
+// AccountCommand returns the account command with subcommands to list, switch between, and sign out of the
+// accounts signed in with 'skycli login'
+func AccountCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "account",
+		Usage: "List, switch between, and sign out of signed-in accounts",
+		Description: `Each 'skycli login' adds an account and makes it the default, keeping the others signed in.
+   Commands act as the default account; the global --account flag (or SKYCLI_ACCOUNT) picks another
+   for one run, e.g. 'skycli --account alt.bsky.social followers list'.`,
+		Commands: []*cli.Command{
+			{
+				Name:      "list",
+				Aliases:   []string{"ls"},
+				Usage:     "List signed-in accounts, marking the default",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output as JSON",
+					},
+				},
+				Action: withRegistry(AccountListAction),
+			},
+			{
+				Name:      "switch",
+				Usage:     "Make another signed-in account the default",
+				ArgsUsage: "<handle|did>",
+				Action:    withRegistry(AccountSwitchAction),
+			},
+			{
+				Name:      "remove",
+				Aliases:   []string{"rm"},
+				Usage:     "Sign out of an account, forgetting its session on this device",
+				ArgsUsage: "<handle|did>",
+				Action:    withRegistry(AccountRemoveAction),
+			},
+		},
+		Action: withRegistry(AccountListAction),
+	}
+}
+
+// AccountListAction lists the signed-in accounts, the default first
+func AccountListAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	sessionRepo, err := reg.GetSessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+	entries, err := listAccounts(ctx, sessionRepo)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(entries)
+	}
+	if len(entries) == 0 {
+		ui.Infoln("Not signed in. Run 'skycli login' to add an account.")
+		return nil
+	}
+
+	rows := make([][]string, len(entries))
+	for i, entry := range entries {
+		mark := ""
+		switch {
+		case entry.Default:
+			mark = "default"
+		case entry.InUse:
+			mark = "in use"
+		}
+		rows[i] = []string{entry.Handle, entry.Did, entry.Auth, mark}
+	}
+	ui.Titleln("Accounts")
+	fmt.Println(stripedTable([]string{"Handle", "DID", "Sign-in", ""}, rows))
+	ui.Successln("Total: %d account(s)", len(entries))
+	return nil
+}
+
+// listAccounts describes the signed-in accounts, the default first
+func listAccounts(ctx context.Context, sessionRepo *bsky.SessionRepository) ([]accountEntry, error) {
+	models, err := sessionRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	inUse, _ := sessionRepo.GetDid(ctx)
+
+	entries := make([]accountEntry, 0, len(models))
+	for i, model := range models {
+		session, ok := model.(*bsky.SessionModel)
+		if !ok {
+			continue
+		}
+		entry := accountEntry{
+			Handle:  session.Handle,
+			Did:     session.ID(),
+			Service: session.ServiceURL,
+			Auth:    "password",
+			Default: i == 0,
+			InUse:   session.ID() == inUse,
+		}
+		if session.OAuth != nil {
+			entry.Auth = "oauth"
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// AccountSwitchAction makes the account given as the argument the default for later runs
+func AccountSwitchAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	account := cmd.Args().First()
+	if account == "" {
+		return fmt.Errorf("handle or DID required")
+	}
+	sessionRepo, err := reg.GetSessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+
+	if err := sessionRepo.Switch(ctx, account); err != nil {
+		return accountError(err)
+	}
+	handle, _ := sessionRepo.GetHandle(ctx)
+	ui.Successln("Switched to %s", handle)
+	return nil
+}
+
+// AccountRemoveAction signs out of the account given as the argument after confirming
+func AccountRemoveAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	account := cmd.Args().First()
+	if account == "" {
+		return fmt.Errorf("handle or DID required")
+	}
+	sessionRepo, err := reg.GetSessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+	model, err := sessionRepo.Get(ctx, account)
+	if err != nil {
+		return accountError(fmt.Errorf("no signed-in account %q: %w", account, bsky.ErrNotFound))
+	}
+	session := model.(*bsky.SessionModel)
+
+	if ok, err := confirm(cmd, fmt.Sprintf("Sign out of %s on this device?", session.Handle)); !ok {
+		return err
+	}
+	if err := sessionRepo.Delete(ctx, session.ID()); err != nil {
+		return fmt.Errorf("failed to sign out: %w", err)
+	}
+
+	ui.Successln("Signed out of %s", session.Handle)
+	if handle, err := sessionRepo.GetHandle(ctx); err == nil {
+		ui.Infoln("Default account: %s", handle)
+	}
+	return nil
+}
+
+// accountError points at the list of signed-in accounts when an account isn't one of them
+func accountError(err error) error {
+	if errors.Is(err, bsky.ErrNotFound) {
+		return fmt.Errorf("%w; run 'skycli account list' to see signed-in accounts, or 'skycli login' to add one", err)
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestAccounts(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	sessions, err := bsky.NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+	for _, name := range []string{"main", "alt"} {
+		session := &bsky.SessionModel{Handle: name + ".bsky.social", Token: name + "_access|" + name + "_refresh", IsValid: true}
+		session.SetID("did:plc:" + name)
+		if err := sessions.Save(ctx, session); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	if err := sessions.Switch(ctx, "main.bsky.social"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+
+	service := bsky.NewBlueskyService("")
+	service.SetTokens("main_access", "main_refresh")
+	service.SetDid("did:plc:main")
+	reg := registry.New(registry.Dependencies{Service: service, SessionRepo: sessions})
+
+	// --account signs the service in as another account for this run only
+	if err := reg.UseAccount(ctx, "@alt.bsky.social"); err != nil {
+		t.Fatalf("UseAccount failed: %v", err)
+	}
+	if service.GetDid() != "did:plc:alt" || service.GetHandle() != "alt.bsky.social" || service.GetAccessToken() != "alt_access" {
+		t.Errorf("expected the service signed in as alt, got %s %s", service.GetDid(), service.GetAccessToken())
+	}
+	if err := reg.UseAccount(ctx, "nobody.bsky.social"); !errors.Is(err, bsky.ErrNotFound) {
+		t.Errorf("UseAccount of an unknown account: got %v, want ErrNotFound", err)
+	}
+
+	accounts, err := listAccounts(ctx, sessions)
+	if err != nil {
+		t.Fatalf("listAccounts failed: %v", err)
+	}
+	if len(accounts) != 2 || accounts[0].Handle != "main.bsky.social" || !accounts[0].Default || accounts[0].InUse ||
+		accounts[1].Handle != "alt.bsky.social" || accounts[1].Default || !accounts[1].InUse {
+		t.Errorf("unexpected accounts %+v", accounts)
+	}
+
+	if _, err := runSubcommand(t, AccountCommand(), "switch", AccountSwitchAction, reg, "did:plc:alt"); err != nil {
+		t.Fatalf("AccountSwitchAction failed: %v", err)
+	}
+	reloaded, err := bsky.NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+	if handle, _ := reloaded.GetHandle(ctx); handle != "alt.bsky.social" {
+		t.Errorf("expected alt to be the default after switching, got %s", handle)
+	}
+}
+
+func TestAccounts_UseWithoutSession(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	sessions, err := bsky.NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+	stale := &bsky.SessionModel{Handle: "stale.bsky.social"}
+	stale.SetID("did:plc:stale")
+	signedIn := &bsky.SessionModel{Handle: "main.bsky.social", Token: "main_access|main_refresh", IsValid: true}
+	signedIn.SetID("did:plc:main")
+	for _, session := range []*bsky.SessionModel{stale, signedIn} {
+		if err := sessions.Save(ctx, session); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	if err := sessions.Switch(ctx, "main.bsky.social"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+
+	service := bsky.NewBlueskyService("")
+	service.SetTokens("main_access", "main_refresh")
+	service.SetDid("did:plc:main")
+	reg := registry.New(registry.Dependencies{Service: service, SessionRepo: sessions})
+
+	if err := reg.UseAccount(ctx, "stale.bsky.social"); err == nil {
+		t.Fatal("expected UseAccount of an account without a session to fail")
+	}
+	if did, _ := sessions.GetDid(ctx); did != "did:plc:main" {
+		t.Errorf("expected the session repository back on main, got %s", did)
+	}
+	if service.GetDid() != "did:plc:main" || service.GetAccessToken() != "main_access" {
+		t.Errorf("expected the service still signed in as main, got %s %s", service.GetDid(), service.GetAccessToken())
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// parseActorArg validates an actor given as a handle (with or without @), a DID, or a bsky.app profile link and
+// returns the DID or lowercased handle. what names the argument in errors, such as "--user" or "actor".
+func parseActorArg(what, input string) (string, error) {
+	actor, err := aturi.ParseActor(input)
+	if err != nil {
+		var parseErr *aturi.Error
+		if errors.As(err, &parseErr) {
+			return "", fmt.Errorf("invalid %s %q: %s", what, input, parseErr.Reason)
+		}
+		return "", err
+	}
+	return actor, nil
+}
+
+// resolveActor validates an actor with [parseActorArg] and resolves a handle to its DID. The lookup goes through
+// the profile fetcher, which remembers each actor for the rest of the process, so a handle is resolved once.
+func resolveActor(ctx context.Context, profiles bsky.ProfileFetcher, what, input string) (string, error) {
+	actor, err := parseActorArg(what, input)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(actor, "did:") {
+		return actor, nil
+	}
+
+	profile, err := profiles.GetProfile(ctx, actor)
+	if err != nil {
+		return "", profileError(what, input, err)
+	}
+	if profile.Did == "" {
+		return "", fmt.Errorf("failed to resolve %s %q: profile has no DID", what, input)
+	}
+	return profile.Did, nil
+}
+
+// profileError explains a failed profile lookup for the actor given as input, calling out unknown handles and DIDs
+// as likely typos
+func profileError(what, input string, err error) error {
+	// getProfile answers an unknown actor with a 400 saying "Profile not found" or "Unable to resolve"
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "not found") || strings.Contains(msg, "unable to resolve") {
+		return fmt.Errorf("%s %q: no such account; check the spelling", what, input)
+	}
+	return fmt.Errorf("failed to look up %s %q: %w", what, input, err)
+}
+
+// userActor returns the DID of the account named by --user, or of the authenticated account when --user is unset
+func userActor(ctx context.Context, cmd *cli.Command, reg *registry.Registry, fetcher bsky.FollowerFetcher) (string, error) {
+	input := cmd.String("user")
+	if input == "" {
+		return fetcher.GetDid(), nil
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return "", fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+	return resolveActor(ctx, profiles, "--user", input)
+}
+
+// account is an actor named on the command line, with the DID it resolved to
+type account struct {
+	Name string // the normalized handle or DID as given, for headings and file names
+	Did  string
+}
+
+// usersFlag is a repeatable --user for commands that can report on several accounts in one run; pair it with
+// [usersFileFlag] and read it with [userAccounts]
+func usersFlag() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:    "user",
+		Aliases: []string{"u"},
+		Usage:   "User handle or DID; repeat for several accounts (defaults to authenticated user)",
+	}
+}
+
+// usersFileFlag is --users-file, a file of handles or DIDs one per line, with blank lines and # comments ignored
+func usersFileFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "users-file",
+		Usage: "Read handles or DIDs from a file, one per line (# starts a comment)",
+	}
+}
+
+// userAccounts returns the accounts named by --user and --users-file, in order and without duplicates, or the
+// authenticated account when neither is given. Every name is validated before any handle is resolved.
+func userAccounts(ctx context.Context, cmd *cli.Command, reg *registry.Registry, fetcher bsky.FollowerFetcher) ([]account, error) {
+	inputs := cmd.StringSlice("user")
+	if path := cmd.String("users-file"); path != "" {
+		names, err := readActorFile(path)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, names...)
+	}
+	if len(inputs) == 0 {
+		did := fetcher.GetDid()
+		return []account{{Name: did, Did: did}}, nil
+	}
+
+	names := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		name, err := parseActorArg("--user", input)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	accounts := make([]account, 0, len(names))
+	for _, name := range names {
+		did, err := resolveActor(ctx, profiles, "--user", name)
+		if err != nil {
+			return nil, err
+		}
+		// A handle and its DID given together name the same account
+		if slices.ContainsFunc(accounts, func(a account) bool { return a.Did == did }) {
+			continue
+		}
+		accounts = append(accounts, account{Name: name, Did: did})
+	}
+	return accounts, nil
+}
+
+// readActorFile reads the handles or DIDs listed in a --users-file
+func readActorFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --users-file: %w", err)
+	}
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// Windows PowerShell 5 saves UTF-8 files with a byte order mark, which TrimSpace keeps
+		line, _, _ := strings.Cut(strings.TrimPrefix(scanner.Text(), "\ufeff"), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read --users-file: %w", err)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("--users-file %s lists no accounts", path)
+	}
+	return names, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// handleDirectory resolves handles from a fixed map, answering anything else the way getProfile does
+type handleDirectory struct {
+	bsky.ProfileFetcher
+	dids    map[string]string
+	lookups int
+}
+
+func (d *handleDirectory) GetProfile(ctx context.Context, actor string) (*bsky.ActorProfile, error) {
+	d.lookups++
+	did, ok := d.dids[actor]
+	if !ok {
+		return nil, errors.New(`getProfile failed: 400 - {"error":"InvalidRequest","message":"Profile not found"}`)
+	}
+	return &bsky.ActorProfile{Did: did, Handle: actor}, nil
+}
+
+func TestParseActorArg(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"@Alice.bsky.social", "alice.bsky.social"},
+		{" alice.bsky.social ", "alice.bsky.social"},
+		{"did:plc:abc123", "did:plc:abc123"},
+		{"https://bsky.app/profile/alice.com", "alice.com"},
+	}
+	for _, tt := range tests {
+		got, err := parseActorArg("--user", tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("parseActorArg(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	for input, reason := range map[string]string{
+		"alice":                               "needs a domain",
+		"@alice.bsky.social,":                 "invalid character",
+		"did:PLC:abc":                         "lowercase",
+		"https://bsky.app/profile/a.b/post/1": "expected an account",
+	} {
+		_, err := parseActorArg("--user", input)
+		if err == nil || !strings.HasPrefix(err.Error(), "invalid --user") || !strings.Contains(err.Error(), reason) {
+			t.Errorf("parseActorArg(%q) error = %v, want an invalid --user error mentioning %q", input, err, reason)
+		}
+	}
+}
+
+func TestResolveActor(t *testing.T) {
+	ctx := context.Background()
+	dir := &handleDirectory{dids: map[string]string{"alice.bsky.social": "did:plc:alice"}}
+
+	did, err := resolveActor(ctx, dir, "--user", "@Alice.bsky.social")
+	if err != nil || did != "did:plc:alice" {
+		t.Fatalf("resolveActor = %q, %v; want did:plc:alice", did, err)
+	}
+
+	if did, err := resolveActor(ctx, dir, "--user", "did:plc:bob"); err != nil || did != "did:plc:bob" {
+		t.Errorf("resolveActor(DID) = %q, %v", did, err)
+	}
+	if dir.lookups != 1 {
+		t.Errorf("expected DIDs to skip the lookup, got %d lookups", dir.lookups)
+	}
+
+	_, err = resolveActor(ctx, dir, "--user", "alcie.bsky.social")
+	if err == nil || !strings.Contains(err.Error(), "no such account; check the spelling") {
+		t.Errorf("expected a typo hint for an unknown handle, got %v", err)
+	}
+
+	if _, err := resolveActor(ctx, dir, "--user", "alice"); err == nil || dir.lookups != 2 {
+		t.Errorf("expected an invalid handle to fail before any lookup, got %v after %d lookups", err, dir.lookups)
+	}
+}
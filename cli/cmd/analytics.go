@@ -0,0 +1,692 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/analytics"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// duplicateGroup is one cluster of repeated posts in the duplicates report
+type duplicateGroup struct {
+	Text       string    `json:"text"`
+	Posts      int       `json:"posts"`
+	Authors    int       `json:"authors"`
+	Similarity float64   `json:"similarity"`
+	FirstSeen  time.Time `json:"firstSeen"`
+	LastSeen   time.Time `json:"lastSeen"`
+	URIs       []string  `json:"uris"`
+}
+
+// AnalyticsDuplicatesAction finds repeated and near-duplicate posts in the local archive or an author's live feed
+func AnalyticsDuplicatesAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	similarity := cmd.Float("similarity")
+	if similarity <= 0 || similarity > 1 {
+		return fmt.Errorf("--similarity must be greater than 0 and at most 1, got %v", similarity)
+	}
+
+	var docs []analytics.Document
+	var err error
+	if actor := cmd.String("fetch"); actor != "" {
+		docs, err = authorFeedDocuments(ctx, reg, "--fetch", actor, cmd.Int("limit"))
+	} else {
+		docs, err = storedDocuments(ctx, cmd, reg, "author")
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("Detecting duplicates", "posts", len(docs), "similarity", similarity, "min_length", cmd.Int("min-length"))
+
+	groups := analytics.FindDuplicates(docs, analytics.DuplicateOptions{
+		Similarity: similarity,
+		MinLength:  cmd.Int("min-length"),
+	})
+
+	results := make([]duplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		if cmd.Bool("cross-posts") && group.Authors < 2 {
+			continue
+		}
+		result := duplicateGroup{
+			Text:       group.Text,
+			Posts:      len(group.Documents),
+			Authors:    group.Authors,
+			Similarity: group.Similarity,
+			FirstSeen:  group.Documents[0].Time,
+			LastSeen:   group.Documents[len(group.Documents)-1].Time,
+		}
+		for _, doc := range group.Documents {
+			result.URIs = append(result.URIs, doc.ID)
+		}
+		results = append(results, result)
+	}
+	if top := cmd.Int("top"); top > 0 && len(results) > top {
+		results = results[:top]
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(results)
+	}
+
+	if len(results) == 0 {
+		ui.Successln("No duplicate posts found among %d post(s).", len(docs))
+		return nil
+	}
+
+	displayDuplicateGroups(results, len(docs))
+	return nil
+}
+
+// storedDocuments loads stored posts matching the --source, --since, and --tag filters, by the author named in the flag
+// authorFlag when one is given
+func storedDocuments(ctx context.Context, cmd *cli.Command, reg *registry.Registry, authorFlag string) ([]analytics.Document, error) {
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	since, err := parseSince(cmd.String("since"), userNow())
+	if err != nil {
+		return nil, err
+	}
+
+	query := bsky.PostQuery{Since: since}
+	if source := cmd.String("source"); source != "" {
+		if query.FeedIDs, err = sourceFeedIDs(ctx, reg, source); err != nil {
+			return nil, err
+		}
+	}
+	if query.Tagged, err = taggedSet(ctx, cmd, reg); err != nil {
+		return nil, err
+	}
+	if author := cmd.String(authorFlag); author != "" {
+		if query.Author, err = resolveAuthorDID(ctx, reg, "--"+authorFlag, author); err != nil {
+			return nil, err
+		}
+	}
+
+	posts, err := postRepo.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query posts: %w", err)
+	}
+
+	docs := make([]analytics.Document, len(posts))
+	for i, post := range posts {
+		docs[i] = analytics.Document{ID: post.URI, Author: post.AuthorDID, Text: post.Text, Time: post.IndexedAt.In(ui.Location())}
+	}
+	return docs, nil
+}
+
+// authorFeedDocuments fetches up to limit of an actor's own posts from the API, skipping reposts. what names the
+// flag the actor came from in errors.
+func authorFeedDocuments(ctx context.Context, reg *registry.Registry, what, actor string, limit int) ([]analytics.Document, error) {
+	service, err := reg.GetService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	if !service.Authenticated() {
+		return nil, fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	did, err := resolveActor(ctx, service, what, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := collectFeed(ctx, bsky.AuthorFeedPages(service, did), limit, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch author feed: %w", err)
+	}
+
+	docs := make([]analytics.Document, 0, len(items))
+	for _, item := range items {
+		if item.Post == nil || item.Reason != nil {
+			continue
+		}
+		post := bsky.NewPostModel("", item.Post)
+		docs = append(docs, analytics.Document{ID: post.URI, Author: post.AuthorDID, Text: post.Text, Time: post.IndexedAt.In(ui.Location())})
+	}
+	return docs, nil
+}
+
+// displayDuplicateGroups renders duplicate clusters as a table, largest first
+func displayDuplicateGroups(groups []duplicateGroup, scanned int) {
+	rows := make([][]string, 0, len(groups))
+	repeated := 0
+	for _, group := range groups {
+		repeated += group.Posts
+		text := strings.Join(strings.Fields(group.Text), " ")
+		if runes := []rune(text); len(runes) > 60 {
+			text = string(runes[:57]) + "..."
+		}
+		match := "exact"
+		if group.Similarity < 1 {
+			match = fmt.Sprintf("%.0f%%", group.Similarity*100)
+		}
+		rows = append(rows, []string{
+			text,
+			fmt.Sprintf("%d", group.Posts),
+			fmt.Sprintf("%d", group.Authors),
+			match,
+			group.FirstSeen.In(ui.Location()).Format("2006-01-02"),
+			group.LastSeen.In(ui.Location()).Format("2006-01-02"),
+		})
+	}
+
+	t := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(ui.TableBorderStyle).
+		Headers("Text", "Posts", "Authors", "Match", "First", "Last").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == lgtable.HeaderRow {
+				return ui.TableHeaderStyle
+			}
+			if row%2 == 0 {
+				return ui.TableRowEvenStyle
+			}
+			return ui.TableRowOddStyle
+		})
+
+	ui.Titleln("Duplicate Posts")
+	fmt.Println(t)
+	ui.Successln("%d group(s) covering %d of %d post(s)", len(groups), repeated, scanned)
+}
+
+// sentimentPost is a scored post in the sentiment report
+type sentimentPost struct {
+	URI       string    `json:"uri"`
+	Text      string    `json:"text"`
+	IndexedAt time.Time `json:"indexedAt"`
+	Score     int       `json:"score"`
+	Words     []string  `json:"words"`
+}
+
+// sentimentPeriod is one bucket of the sentiment trend
+type sentimentPeriod struct {
+	Start    time.Time `json:"start"`
+	Posts    int       `json:"posts"`
+	Average  float64   `json:"average"`
+	Positive int       `json:"positive"`
+	Negative int       `json:"negative"`
+	Neutral  int       `json:"neutral"`
+}
+
+// emojiUsage is how often an emoji appears across the analyzed posts
+type emojiUsage struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
+// sentimentReport is the full output of analytics sentiment
+type sentimentReport struct {
+	Author      string            `json:"author,omitempty"`
+	Posts       int               `json:"posts"`
+	Average     float64           `json:"average"`
+	Positive    int               `json:"positive"`
+	Negative    int               `json:"negative"`
+	Neutral     int               `json:"neutral"`
+	Trend       []sentimentPeriod `json:"trend"`
+	TopPositive []sentimentPost   `json:"topPositive"`
+	TopNegative []sentimentPost   `json:"topNegative"`
+	Emojis      []emojiUsage      `json:"emojis"`
+}
+
+// AnalyticsSentimentAction scores archived posts with the local sentiment lexicon and reports the trend,
+// the strongest posts either way, and emoji usage
+func AnalyticsSentimentAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	period := analytics.Period(cmd.String("by"))
+	switch period {
+	case analytics.PeriodDay, analytics.PeriodWeek, analytics.PeriodMonth:
+	default:
+		return fmt.Errorf("invalid --by %q: expected day, week, or month", period)
+	}
+
+	user := cmd.String("user")
+	docs, err := storedDocuments(ctx, cmd, reg, "user")
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("Scoring sentiment", "posts", len(docs), "user", user, "period", period)
+
+	scored := analytics.ScoreDocuments(docs)
+	report := sentimentReport{
+		Author:      user,
+		Posts:       len(scored),
+		Trend:       []sentimentPeriod{},
+		TopPositive: []sentimentPost{},
+		TopNegative: []sentimentPost{},
+		Emojis:      []emojiUsage{},
+	}
+	texts := make([]string, len(scored))
+	for i, doc := range scored {
+		texts[i] = doc.Text
+		report.Average += float64(doc.Sentiment.Score)
+		switch doc.Sentiment.Label() {
+		case "positive":
+			report.Positive++
+		case "negative":
+			report.Negative++
+		default:
+			report.Neutral++
+		}
+	}
+	if report.Posts > 0 {
+		report.Average /= float64(report.Posts)
+	}
+
+	for _, bucket := range analytics.SentimentTrend(scored, period) {
+		report.Trend = append(report.Trend, sentimentPeriod(bucket))
+	}
+
+	positive, negative := analytics.Extremes(scored, cmd.Int("top"))
+	for _, doc := range positive {
+		report.TopPositive = append(report.TopPositive, sentimentPost{URI: doc.ID, Text: doc.Text, IndexedAt: doc.Time, Score: doc.Sentiment.Score, Words: doc.Sentiment.Positive})
+	}
+	for _, doc := range negative {
+		report.TopNegative = append(report.TopNegative, sentimentPost{URI: doc.ID, Text: doc.Text, IndexedAt: doc.Time, Score: doc.Sentiment.Score, Words: doc.Sentiment.Negative})
+	}
+
+	for _, count := range analytics.TopEmojis(texts, cmd.Int("emoji")) {
+		report.Emojis = append(report.Emojis, emojiUsage{Emoji: count.Token, Count: count.Count})
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(report)
+	}
+
+	if report.Posts == 0 {
+		ui.Infoln("No stored posts match.")
+		return nil
+	}
+
+	displaySentimentReport(report)
+	return nil
+}
+
+// displaySentimentReport renders the trend table followed by the top posts and emoji
+func displaySentimentReport(report sentimentReport) {
+	rows := make([][]string, 0, len(report.Trend))
+	for _, bucket := range report.Trend {
+		rows = append(rows, []string{
+			bucket.Start.In(ui.Location()).Format("2006-01-02"),
+			fmt.Sprintf("%d", bucket.Posts),
+			fmt.Sprintf("%+.2f", bucket.Average),
+			fmt.Sprintf("%d", bucket.Positive),
+			fmt.Sprintf("%d", bucket.Negative),
+			fmt.Sprintf("%d", bucket.Neutral),
+		})
+	}
+
+	t := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(ui.TableBorderStyle).
+		Headers("Period", "Posts", "Average", "Positive", "Negative", "Neutral").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == lgtable.HeaderRow {
+				return ui.TableHeaderStyle
+			}
+			if row%2 == 0 {
+				return ui.TableRowEvenStyle
+			}
+			return ui.TableRowOddStyle
+		})
+
+	title := "Sentiment"
+	if report.Author != "" {
+		title += " for " + report.Author
+	}
+	ui.Titleln("%s", title)
+	fmt.Println(t)
+	ui.Infoln("%d post(s): %d positive, %d negative, %d neutral (average %+.2f)", report.Posts, report.Positive, report.Negative, report.Neutral, report.Average)
+
+	for _, section := range []struct {
+		title string
+		posts []sentimentPost
+	}{{"Most Positive", report.TopPositive}, {"Most Negative", report.TopNegative}} {
+		if len(section.posts) == 0 {
+			continue
+		}
+		fmt.Println()
+		ui.Subtitleln("%s", section.title)
+		for _, post := range section.posts {
+			text := strings.Join(strings.Fields(post.Text), " ")
+			if runes := []rune(text); len(runes) > 80 {
+				text = string(runes[:77]) + "..."
+			}
+			ui.Infoln("  %+d  %s  %s", post.Score, post.IndexedAt.In(ui.Location()).Format("2006-01-02"), text)
+		}
+	}
+
+	if len(report.Emojis) > 0 {
+		fmt.Println()
+		ui.Subtitleln("Top Emoji")
+		parts := make([]string, len(report.Emojis))
+		for i, usage := range report.Emojis {
+			parts[i] = fmt.Sprintf("%s %d", usage.Emoji, usage.Count)
+		}
+		ui.Infoln("  %s", strings.Join(parts, "  "))
+	}
+}
+
+// wordTerm is one ranked term in the words report
+type wordTerm struct {
+	Term  string `json:"term"`
+	NGram int    `json:"ngram"`
+	Count int    `json:"count"`
+}
+
+// AnalyticsWordsAction reports the most frequent words and word pairs in archived or freshly fetched posts
+func AnalyticsWordsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	outputFormat := cmd.String("output")
+	if outputFormat != "table" && outputFormat != "json" && outputFormat != "csv" {
+		return fmt.Errorf("invalid output format: %s (must be table, json, or csv)", outputFormat)
+	}
+
+	user := cmd.String("user")
+	var docs []analytics.Document
+	var err error
+	if cmd.Bool("fetch") {
+		if user == "" {
+			service, err := reg.GetService()
+			if err != nil {
+				return fmt.Errorf("failed to get service: %w", err)
+			}
+			user = service.GetDid()
+		}
+		docs, err = authorFeedDocuments(ctx, reg, "--user", user, cmd.Int("limit"))
+	} else {
+		docs, err = storedDocuments(ctx, cmd, reg, "user")
+	}
+	if err != nil {
+		return err
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.Text
+	}
+
+	exclude := make(map[string]bool)
+	for _, word := range cmd.StringSlice("exclude") {
+		exclude[strings.ToLower(strings.TrimSpace(word))] = true
+	}
+
+	logger.Debug("Counting terms", "posts", len(texts), "user", user, "excluded", len(exclude))
+
+	var terms []wordTerm
+	for n := 1; n <= 2; n++ {
+		counts := analytics.TopTerms(texts, analytics.TermOptions{
+			N:        n,
+			Limit:    cmd.Int("top"),
+			MinCount: cmd.Int("min-count"),
+			Exclude:  exclude,
+		})
+		for _, count := range counts {
+			terms = append(terms, wordTerm{Term: count.Token, NGram: n, Count: count.Count})
+		}
+	}
+
+	switch outputFormat {
+	case "json":
+		if terms == nil {
+			terms = []wordTerm{}
+		}
+		return ui.DisplayJSON(terms)
+	case "csv":
+		return outputWordsCSV(cmd.Root().Writer, terms)
+	}
+
+	if len(terms) == 0 {
+		ui.Infoln("No terms found among %d post(s).", len(texts))
+		return nil
+	}
+
+	displayWordTerms(terms, len(texts))
+	return nil
+}
+
+// outputWordsCSV writes terms with their counts, a layout most word-cloud generators import directly
+func outputWordsCSV(w io.Writer, terms []wordTerm) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"term", "count", "ngram"}); err != nil {
+		return err
+	}
+	for _, term := range terms {
+		if err := writer.Write([]string{term.Term, strconv.Itoa(term.Count), strconv.Itoa(term.NGram)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// displayWordTerms renders unigrams and bigrams side by side, ranked by frequency
+func displayWordTerms(terms []wordTerm, scanned int) {
+	var unigrams, bigrams []wordTerm
+	for _, term := range terms {
+		if term.NGram == 1 {
+			unigrams = append(unigrams, term)
+		} else {
+			bigrams = append(bigrams, term)
+		}
+	}
+
+	rows := make([][]string, max(len(unigrams), len(bigrams)))
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("%d", i+1), "", "", "", ""}
+		if i < len(unigrams) {
+			rows[i][1], rows[i][2] = unigrams[i].Term, strconv.Itoa(unigrams[i].Count)
+		}
+		if i < len(bigrams) {
+			rows[i][3], rows[i][4] = bigrams[i].Term, strconv.Itoa(bigrams[i].Count)
+		}
+	}
+
+	t := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(ui.TableBorderStyle).
+		Headers("#", "Word", "Count", "Phrase", "Count").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == lgtable.HeaderRow {
+				return ui.TableHeaderStyle
+			}
+			if row%2 == 0 {
+				return ui.TableRowEvenStyle
+			}
+			return ui.TableRowOddStyle
+		})
+
+	ui.Titleln("Top Words")
+	fmt.Println(t)
+	ui.Successln("Counted terms across %d post(s)", scanned)
+}
+
+// AnalyticsCommand returns the analytics command with subcommands for archive analysis
+func AnalyticsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "analytics",
+		Usage: "Analyze posts in the local archive",
+		Commands: []*cli.Command{
+			{
+				Name:      "duplicates",
+				Usage:     "Find repeated and near-duplicate posts",
+				UsageText: "Groups posts whose text matches after normalization (case, punctuation, and links ignored) or whose trigram similarity reaches --similarity.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "source",
+						Aliases: []string{"s"},
+						Usage:   "Only posts from saved feeds with this ID or whose source contains this text",
+					},
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only posts indexed since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+					},
+					tagFlag(tagPostsUsage),
+					&cli.StringFlag{
+						Name:    "author",
+						Aliases: []string{"a"},
+						Usage:   "Only stored posts by this author (DID, or handle when logged in)",
+					},
+					&cli.StringFlag{
+						Name:    "fetch",
+						Aliases: []string{"f"},
+						Usage:   "Analyze this author's live feed instead of the local archive",
+					},
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum number of posts to fetch with --fetch",
+						Value:   500,
+					},
+					&cli.FloatFlag{
+						Name:  "similarity",
+						Usage: "Minimum trigram similarity (0-1] for near-duplicates; 1 reports exact repeats only",
+						Value: analytics.DefaultSimilarity,
+					},
+					&cli.IntFlag{
+						Name:  "min-length",
+						Usage: "Ignore posts shorter than this many characters after normalization",
+						Value: analytics.DefaultMinLength,
+					},
+					&cli.BoolFlag{
+						Name:  "cross-posts",
+						Usage: "Only show groups posted by more than one author",
+					},
+					&cli.IntFlag{
+						Name:    "top",
+						Aliases: []string{"t"},
+						Usage:   "Maximum number of groups to show (0 for all)",
+						Value:   20,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output results as JSON",
+					},
+				},
+				Action: withRegistry(AnalyticsDuplicatesAction),
+			},
+			{
+				Name:      "sentiment",
+				Usage:     "Score archived posts with a local sentiment lexicon",
+				UsageText: "Scores stored posts against a built-in English word and emoji lexicon. Scoring runs only when requested and never sends text over the network.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "user",
+						Aliases: []string{"u"},
+						Usage:   "Only posts by this author (DID, or handle when logged in); defaults to the whole archive",
+					},
+					&cli.StringFlag{
+						Name:    "source",
+						Aliases: []string{"s"},
+						Usage:   "Only posts from saved feeds with this ID or whose source contains this text",
+					},
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only posts indexed since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+					},
+					tagFlag(tagPostsUsage),
+					&cli.StringFlag{
+						Name:  "by",
+						Usage: "Trend period: day, week, or month",
+						Value: string(analytics.PeriodWeek),
+					},
+					&cli.IntFlag{
+						Name:    "top",
+						Aliases: []string{"t"},
+						Usage:   "Number of most positive and most negative posts to show",
+						Value:   5,
+					},
+					&cli.IntFlag{
+						Name:  "emoji",
+						Usage: "Number of most used emoji to show (0 for all)",
+						Value: 10,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output results as JSON",
+					},
+				},
+				Action: withRegistry(AnalyticsSentimentAction),
+			},
+			{
+				Name:      "words",
+				Usage:     "Report the most frequent words and two-word phrases",
+				UsageText: "Common English stopwords, links, and @mentions are ignored. Use --output csv to feed a word-cloud generator.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "user",
+						Aliases: []string{"u"},
+						Usage:   "Only posts by this author (DID, or handle when logged in); defaults to the whole archive",
+					},
+					&cli.BoolFlag{
+						Name:    "fetch",
+						Aliases: []string{"f"},
+						Usage:   "Fetch the user's recent posts from the API instead of reading the archive (defaults to you)",
+					},
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum number of posts to fetch with --fetch",
+						Value:   500,
+					},
+					&cli.StringFlag{
+						Name:    "source",
+						Aliases: []string{"s"},
+						Usage:   "Only posts from saved feeds with this ID or whose source contains this text",
+					},
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only posts indexed since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+					},
+					tagFlag(tagPostsUsage),
+					&cli.StringSliceFlag{
+						Name:    "exclude",
+						Aliases: []string{"x"},
+						Usage:   "Additional words to ignore (repeatable)",
+					},
+					&cli.IntFlag{
+						Name:    "top",
+						Aliases: []string{"t"},
+						Usage:   "Number of words and of phrases to show (0 for all)",
+						Value:   25,
+					},
+					&cli.IntFlag{
+						Name:  "min-count",
+						Usage: "Ignore terms used fewer times",
+						Value: 2,
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Output format: table, json, csv",
+						Value:   "table",
+					},
+				},
+				Action: withRegistry(AnalyticsWordsAction),
+			},
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestOutputWordsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	terms := []wordTerm{
+		{Term: "open", NGram: 1, Count: 3},
+		{Term: "open source", NGram: 2, Count: 2},
+	}
+	if err := outputWordsCSV(&buf, terms); err != nil {
+		t.Fatalf("outputWordsCSV failed: %v", err)
+	}
+
+	want := "term,count,ngram\nopen,3,1\nopen source,2,2\n"
+	if buf.String() != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
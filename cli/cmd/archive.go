@@ -0,0 +1,446 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/remote"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// archiveKeyPrefix is the remote key prefix under which archive copies are stored
+const archiveKeyPrefix = "archive/"
+
+// ArchivePushAction uploads a snapshot of the local archive to the configured remote storage
+func ArchivePushAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	archiveRepo, err := reg.GetArchiveRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get archive repository: %w", err)
+	}
+
+	backend, err := loadStorageBackend()
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "skycli-archive-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dumpPath := filepath.Join(tmpDir, "cache.db")
+	logger.Debug("Dumping local archive", "path", dumpPath)
+	if err := archiveRepo.Dump(ctx, dumpPath); err != nil {
+		return fmt.Errorf("failed to dump archive: %w", err)
+	}
+
+	file, err := os.Open(dumpPath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive dump: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat archive dump: %w", err)
+	}
+
+	key := cmd.String("key")
+	if key == "" {
+		key = archiveKeyPrefix + "cache-" + time.Now().UTC().Format("20060102T150405Z") + ".db"
+	} else if exists, err := remoteKeyExists(ctx, backend, key); err != nil {
+		return fmt.Errorf("failed to list remote archives: %w", err)
+	} else if exists {
+		if ok, err := confirm(cmd, fmt.Sprintf("Overwrite %s in %s storage?", key, backend.Name())); !ok {
+			return err
+		}
+	}
+
+	logger.Infof("Uploading %d bytes to %s backend...", info.Size(), backend.Name())
+	if err := backend.Put(ctx, key, file, info.Size()); err != nil {
+		return fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	ui.Successln("Pushed archive to %s (%s)", key, backend.Name())
+	return nil
+}
+
+// ArchivePullAction downloads an archive copy from remote storage and merges it into the local database
+func ArchivePullAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	archiveRepo, err := reg.GetArchiveRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get archive repository: %w", err)
+	}
+
+	backend, err := loadStorageBackend()
+	if err != nil {
+		return err
+	}
+
+	key := cmd.String("key")
+	if key == "" {
+		latest, err := remote.Latest(ctx, backend, archiveKeyPrefix)
+		if err != nil {
+			return fmt.Errorf("failed to list remote archives: %w", err)
+		}
+		if latest == nil {
+			ui.Infoln("No archives found in remote storage. Run 'skycli archive push' first.")
+			return nil
+		}
+		key = latest.Key
+	}
+
+	logger.Infof("Downloading %s from %s backend...", key, backend.Name())
+	body, err := backend.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer body.Close()
+
+	tmpDir, err := os.MkdirTemp("", "skycli-archive-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	downloadPath := filepath.Join(tmpDir, "incoming.db")
+	file, err := os.Create(downloadPath)
+	if err != nil {
+		return fmt.Errorf("failed to create download file: %w", err)
+	}
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	result, err := archiveRepo.Merge(ctx, downloadPath)
+	if err != nil {
+		return fmt.Errorf("failed to merge archive: %w", err)
+	}
+
+	ui.Successln("Pulled %s", key)
+	ui.Infoln("Feeds added: %d", result.Feeds)
+	ui.Infoln("Posts added: %d", result.Posts)
+	ui.Infoln("Snapshots added: %d (%d entries)", result.Snapshots, result.SnapshotEntries)
+	return nil
+}
+
+// ArchiveListAction lists archive copies available in remote storage
+func ArchiveListAction(ctx context.Context, cmd *cli.Command) error {
+	backend, err := loadStorageBackend()
+	if err != nil {
+		return err
+	}
+
+	objects, err := backend.List(ctx, archiveKeyPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to list remote archives: %w", err)
+	}
+
+	if len(objects) == 0 {
+		ui.Infoln("No archives found in remote storage.")
+		return nil
+	}
+
+	ui.Titleln("Remote Archives (%s)", backend.Name())
+	for _, obj := range objects {
+		ui.Infoln("  %s  %s  %d bytes", strings.TrimPrefix(obj.Key, archiveKeyPrefix), obj.LastModified.Format("2006-01-02 15:04"), obj.Size)
+	}
+	return nil
+}
+
+// authorSummary is one row of the archive authors report, optionally enriched with a live profile
+type authorSummary struct {
+	DID           string    `json:"did"`
+	Handle        string    `json:"handle,omitempty"`
+	DisplayName   string    `json:"displayName,omitempty"`
+	Followers     int       `json:"followersCount,omitempty"`
+	Posts         int       `json:"posts"`
+	FirstPost     time.Time `json:"firstPost"`
+	LastPost      time.Time `json:"lastPost"`
+	AvgTextLength float64   `json:"avgTextLength"`
+}
+
+// ArchiveAuthorsAction aggregates stored posts by author and optionally enriches the top authors with live profiles
+func ArchiveAuthorsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	since, err := parseSince(cmd.String("since"), userNow())
+	if err != nil {
+		return err
+	}
+
+	query := bsky.PostQuery{Since: since, Limit: cmd.Int("limit")}
+	if source := cmd.String("source"); source != "" {
+		if query.FeedIDs, err = sourceFeedIDs(ctx, reg, source); err != nil {
+			return err
+		}
+	}
+	if query.Tagged, err = taggedSet(ctx, cmd, reg); err != nil {
+		return err
+	}
+
+	stats, err := postRepo.AuthorStats(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate authors: %w", err)
+	}
+
+	summaries := make([]authorSummary, len(stats))
+	for i, s := range stats {
+		summaries[i] = authorSummary{
+			DID:           s.AuthorDID,
+			Posts:         s.Posts,
+			FirstPost:     s.FirstPost,
+			LastPost:      s.LastPost,
+			AvgTextLength: s.AvgTextLength,
+		}
+	}
+
+	if enrich := min(cmd.Int("enrich"), len(summaries)); enrich > 0 {
+		if err := enrichAuthors(ctx, reg, summaries[:enrich]); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(summaries)
+	}
+
+	if len(summaries) == 0 {
+		ui.Infoln("No stored posts match.")
+		return nil
+	}
+
+	displayAuthorSummaries(summaries)
+	return nil
+}
+
+// enrichAuthors fills handle, display name, and follower count from live profiles; authors whose
+// profile cannot be fetched keep their DID only
+func enrichAuthors(ctx context.Context, reg *registry.Registry, summaries []authorSummary) error {
+	service, err := reg.GetService()
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+	if !service.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first to use --enrich")
+	}
+
+	fetcher, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	dids := make([]string, len(summaries))
+	for i, s := range summaries {
+		dids[i] = s.DID
+	}
+
+	ctx, report := bsky.WithBatchReport(ctx)
+	profiles := fetcher.BatchGetProfiles(ctx, dids, 0)
+	warnBatchReport(logger, "Profiles", report)
+	for i := range summaries {
+		profile, ok := profiles[summaries[i].DID]
+		if !ok {
+			logger.Warn("Failed to fetch profile", "did", summaries[i].DID)
+			continue
+		}
+		summaries[i].Handle = profile.Handle
+		summaries[i].DisplayName = profile.DisplayName
+		summaries[i].Followers = profile.FollowersCount
+	}
+	return nil
+}
+
+// displayAuthorSummaries renders the author report as a table, adding profile columns when any row is enriched
+func displayAuthorSummaries(summaries []authorSummary) {
+	enriched := false
+	for _, s := range summaries {
+		if s.Handle != "" {
+			enriched = true
+			break
+		}
+	}
+
+	headers := []string{"Author", "Posts", "First", "Last", "Avg Length"}
+	if enriched {
+		headers = append(headers, "Name", "Followers")
+	}
+
+	totalPosts := 0
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		totalPosts += s.Posts
+		author := s.DID
+		if s.Handle != "" {
+			author = "@" + s.Handle
+		}
+		row := []string{
+			author,
+			fmt.Sprintf("%d", s.Posts),
+			s.FirstPost.In(ui.Location()).Format("2006-01-02"),
+			s.LastPost.In(ui.Location()).Format("2006-01-02"),
+			fmt.Sprintf("%.0f", s.AvgTextLength),
+		}
+		if enriched {
+			followers := ""
+			if s.Handle != "" {
+				followers = fmt.Sprintf("%d", s.Followers)
+			}
+			row = append(row, s.DisplayName, followers)
+		}
+		rows = append(rows, row)
+	}
+
+	t := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(ui.TableBorderStyle).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == lgtable.HeaderRow {
+				return ui.TableHeaderStyle
+			}
+			if row%2 == 0 {
+				return ui.TableRowEvenStyle
+			}
+			return ui.TableRowOddStyle
+		})
+
+	ui.Titleln("Archive Authors")
+	fmt.Println(t)
+	ui.Successln("%d author(s), %d post(s)", len(summaries), totalPosts)
+}
+
+// remoteKeyExists reports whether an object is stored under exactly key
+func remoteKeyExists(ctx context.Context, backend remote.Backend, key string) (bool, error) {
+	objects, err := backend.List(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	for _, obj := range objects {
+		if obj.Key == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// loadStorageBackend builds the remote backend from the storage section of the config file
+func loadStorageBackend() (remote.Backend, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	backend, err := remote.New(cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure remote storage: %w", err)
+	}
+	return backend, nil
+}
+
+// ArchiveCommand returns the archive command with subcommands for remote sync
+func ArchiveCommand() *cli.Command {
+	keyFlag := &cli.StringFlag{
+		Name:    "key",
+		Aliases: []string{"k"},
+		Usage:   "Remote object key (defaults to a timestamped key on push and the latest archive on pull)",
+	}
+
+	return &cli.Command{
+		Name:  "archive",
+		Usage: "Manage the local archive of feeds, posts, and snapshots",
+		Description: `Sync the local archive with remote object storage.
+
+   Configure a backend in the "storage" section of ~/.skycli/.config.json:
+
+      "storage": {
+        "backend": "s3",
+        "endpoint": "https://s3.us-east-1.amazonaws.com",
+        "region": "us-east-1",
+        "bucket": "my-skycli-backups",
+        "prefix": "laptop"
+      }
+
+   Credentials are read from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+   (optionally) AWS_SESSION_TOKEN. Any S3-compatible service works (MinIO, R2, B2).
+   Use "backend": "file" with a "path" to sync to a mounted directory instead.`,
+		Commands: []*cli.Command{
+			{
+				Name:      "push",
+				Usage:     "Upload a copy of the local archive to remote storage",
+				ArgsUsage: " ",
+				Flags:     []cli.Flag{keyFlag},
+				Action:    withRegistry(ArchivePushAction),
+			},
+			{
+				Name:      "pull",
+				Usage:     "Download an archive and merge it into the local database",
+				UsageText: "Merges feeds, posts, and snapshots from the remote archive. Local records are never overwritten.",
+				ArgsUsage: " ",
+				Flags:     []cli.Flag{keyFlag},
+				Action:    withRegistry(ArchivePullAction),
+			},
+			{
+				Name:      "authors",
+				Usage:     "Summarize stored posts per author",
+				UsageText: "Counts posts, date range, and average text length per author from the local posts table.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "source",
+						Aliases: []string{"s"},
+						Usage:   "Only posts from saved feeds with this ID or whose source contains this text",
+					},
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only posts indexed since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+					},
+					tagFlag(tagPostsUsage),
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum number of authors to show (0 for all)",
+						Value:   20,
+					},
+					&cli.IntFlag{
+						Name:    "enrich",
+						Aliases: []string{"e"},
+						Usage:   "Fetch live profiles for the top N authors (requires login)",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output results as JSON",
+					},
+				},
+				Action: withRegistry(ArchiveAuthorsAction),
+			},
+			{
+				Name:      "remote",
+				Usage:     "List archive copies in remote storage",
+				ArgsUsage: " ",
+				Action:    ArchiveListAction,
+			},
+		},
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/daemon"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// backupSourcePrefix marks the Source of local feeds filled by the backup task, e.g. backup:posts
+const backupSourcePrefix = "backup:"
+
+// backupResult counts the posts one backup run saved into a backup feed
+type backupResult struct {
+	Kind  string
+	Saved int
+}
+
+// backupTask returns the daemon task that copies the user's new posts, and likes and reposts when configured,
+// into local feeds
+func backupTask(reg *registry.Registry, cfg *config.DaemonConfig) (daemon.Task, bool) {
+	if cfg == nil || cfg.Backup == nil {
+		return daemon.Task{}, false
+	}
+
+	return daemon.Task{Name: "backup", Interval: 6 * time.Hour, Run: func(ctx context.Context) error {
+		results, err := runBackup(ctx, reg, *cfg.Backup)
+		for _, result := range results {
+			logger.Info("Backed up", "kind", result.Kind, "new", result.Saved)
+		}
+		return err
+	}}, true
+}
+
+// runBackup saves everything posted, reposted, or liked since the last run. Own posts and reposts come from one
+// pass over the author feed; likes need a second pass over the likes feed.
+func runBackup(ctx context.Context, reg *registry.Registry, cfg config.BackupConfig) ([]backupResult, error) {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+	if !fetcher.Authenticated() {
+		return nil, fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	engagement, err := reg.GetEngagementFetcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get engagement fetcher: %w", err)
+	}
+
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed repository: %w", err)
+	}
+
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	me := fetcher.GetDid()
+	feeds := make(map[string]*bsky.FeedModel)
+	for _, kind := range []string{"posts", "reposts", "likes"} {
+		if (kind == "reposts" && !cfg.Reposts) || (kind == "likes" && !cfg.Likes) {
+			continue
+		}
+		feed, created, err := localFeed(ctx, feedRepo, backupSourcePrefix+kind, "Backup: "+kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s backup feed: %w", kind, err)
+		}
+		if created {
+			logger.Info("Created backup feed", "kind", kind, "feed", feed.ID())
+		}
+		feeds[kind] = feed
+	}
+
+	saved := make(map[string]int)
+	err = backupPages(ctx, postRepo, bsky.AuthorFeedPages(engagement, me), saved, func(item bsky.FeedViewPost) *bsky.FeedModel {
+		switch {
+		case item.Reason != nil && item.Reason.By != nil:
+			if item.Reason.By.Did == me {
+				return feeds["reposts"]
+			}
+			return nil
+		case item.Post.Author != nil && item.Post.Author.Did == me:
+			return feeds["posts"]
+		}
+		return nil
+	})
+	if err == nil && feeds["likes"] != nil {
+		err = backupPages(ctx, postRepo, bsky.ActorLikesPages(engagement, me), saved, func(bsky.FeedViewPost) *bsky.FeedModel {
+			return feeds["likes"]
+		})
+	}
+
+	var results []backupResult
+	for _, kind := range []string{"posts", "reposts", "likes"} {
+		if feed := feeds[kind]; feed != nil {
+			results = append(results, backupResult{Kind: kind, Saved: saved[feed.ID()]})
+		}
+	}
+	return results, err
+}
+
+// backupPages walks pages newest first, saving each item into the feed route picks for it (nil skips it), and
+// stops after the first page holding an item already in its feed, since older items were saved by an earlier run.
+// Pinned posts show up out of order so they never stop the walk. Posts already archived in another feed are left
+// there. saved counts the posts saved per feed ID.
+func backupPages(ctx context.Context, postRepo *bsky.PostRepository, pages bsky.PageFunc[bsky.FeedViewPost], saved map[string]int, route func(bsky.FeedViewPost) *bsky.FeedModel) error {
+	paginator := bsky.NewPaginator(pages, bsky.PaginatorOptions{})
+	for paginator.HasNext() {
+		items, err := paginator.Next(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch posts: %w", err)
+		}
+
+		var models []*bsky.PostModel
+		var uris []string
+		pinned := make(map[string]bool)
+		for _, item := range items {
+			// A pinned post can also turn up again in order
+			if item.Post == nil || slices.Contains(uris, item.Post.Uri) {
+				continue
+			}
+			feed := route(item)
+			if feed == nil {
+				continue
+			}
+			models = append(models, bsky.NewPostModel(feed.ID(), item.Post))
+			uris = append(uris, item.Post.Uri)
+			if item.Reason != nil && item.Reason.By == nil {
+				pinned[item.Post.Uri] = true
+			}
+		}
+
+		stored, err := postRepo.StoredFeedIDs(ctx, uris)
+		if err != nil {
+			return err
+		}
+
+		caughtUp := false
+		fresh := make([]*bsky.PostModel, 0, len(models))
+		for _, model := range models {
+			feedID, ok := stored[model.URI]
+			switch {
+			case !ok:
+				fresh = append(fresh, model)
+			case feedID == model.FeedID && !pinned[model.URI]:
+				caughtUp = true
+			}
+		}
+
+		if err := postRepo.BatchSave(ctx, fresh); err != nil {
+			return fmt.Errorf("failed to save posts: %w", err)
+		}
+		for _, model := range fresh {
+			saved[model.FeedID]++
+		}
+		if caughtUp {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func feedItem(author, rkey string, reason *bsky.ReasonView) bsky.FeedViewPost {
+	return bsky.FeedViewPost{
+		Post: &bsky.PostView{
+			Uri:    "at://" + author + "/app.bsky.feed.post/" + rkey,
+			Author: &bsky.ActorProfile{Did: author},
+			Record: map[string]any{"text": "post " + rkey},
+		},
+		Reason: reason,
+	}
+}
+
+func TestRunBackup(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	me := &bsky.ActorProfile{Did: "did:plc:me"}
+	graph := &fakeGraph{
+		authenticated: true,
+		did:           "did:plc:me",
+		feed: []bsky.FeedViewPost{
+			feedItem("did:plc:me", "pinned", &bsky.ReasonView{Type: "app.bsky.feed.defs#reasonPin"}),
+			feedItem("did:plc:me", "2", nil),
+			feedItem("did:plc:friend", "1", &bsky.ReasonView{Type: "app.bsky.feed.defs#reasonRepost", By: me}),
+			feedItem("did:plc:me", "1", nil),
+		},
+		liked: []bsky.FeedViewPost{feedItem("did:plc:friend", "2", nil)},
+	}
+	reg := registry.New(registry.Dependencies{FollowerFetcher: graph, Engagement: graph, FeedRepo: feedRepo, PostRepo: postRepo})
+
+	results, err := runBackup(ctx, reg, config.BackupConfig{Reposts: true, Likes: true})
+	if err != nil {
+		t.Fatalf("runBackup failed: %v", err)
+	}
+	want := []backupResult{{Kind: "posts", Saved: 3}, {Kind: "reposts", Saved: 1}, {Kind: "likes", Saved: 1}}
+	if len(results) != len(want) {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("result %d = %+v, want %+v", i, results[i], want[i])
+		}
+	}
+
+	// A second run finds only the new post, and stops at the first page holding one saved before
+	graph.feed = append([]bsky.FeedViewPost{feedItem("did:plc:me", "3", nil)}, graph.feed...)
+	results, err = runBackup(ctx, reg, config.BackupConfig{})
+	if err != nil {
+		t.Fatalf("runBackup failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Saved != 1 {
+		t.Errorf("expected one new post, got %+v", results)
+	}
+
+	posts, err := postRepo.Query(ctx, bsky.PostQuery{Author: "did:plc:me"})
+	if err != nil || len(posts) != 4 {
+		t.Errorf("expected 4 of my posts backed up, got %d (%v)", len(posts), err)
+	}
+}
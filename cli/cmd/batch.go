@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/batch"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// batchExcluded are commands a batch job can't run: the shell needs a terminal and nested batches could recurse
+var batchExcluded = map[string]bool{"shell": true, "batch": true}
+
+// BatchCommand returns the batch command
+func BatchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "batch",
+		Usage: "Run declarative lists of skycli commands",
+		Commands: []*cli.Command{
+			{
+				Name:      "run",
+				Usage:     "Run the jobs in a batch file",
+				UsageText: "Run the jobs listed in a YAML file, e.g.\n\njobs:\n  - name: alice\n    run: fetch author alice.bsky.social\n  - name: export\n    run: [export, profile, alice.bsky.social, --out, alice.json]\n    needs: [alice]\n\nEach job's run is a skycli command line, as a string or a list of arguments. A job starts once every job in its needs has succeeded and is skipped if one fails; other jobs keep going. Jobs share the open database and session, so global flags that change the session, such as --account, --timeout, or --dates, go before 'batch run' rather than in a job.",
+				ArgsUsage: "<file>",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    "jobs",
+						Aliases: []string{"j"},
+						Usage:   "Maximum number of jobs to run at once",
+						Value:   4,
+					},
+				},
+				Action: withRegistry(BatchRunAction),
+			},
+		},
+	}
+}
+
+// BatchRunAction runs a batch file's jobs in dependency order, reporting each as it finishes
+func BatchRunAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("batch file required")
+	}
+	parallel := cmd.Int("jobs")
+	if parallel < 1 {
+		return fmt.Errorf("--jobs must be at least 1")
+	}
+
+	jobs, err := batch.Load(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if batchExcluded[job.Args[0]] {
+			return fmt.Errorf("job %q: %s can't run in a batch", job.Name, job.Args[0])
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	results := batch.Run(ctx, jobs, func(ctx context.Context, job batch.Job) error {
+		logger.Debug("Starting job", "job", job.Name, "args", job.Args)
+		// A job exporting to stdout turns quiet mode on; it doesn't carry over to the jobs after it
+		ui.SetQuiet(false)
+		return runArgs(ctx, reg, job.Args, "batch run")
+	}, batch.Options{
+		Parallel: parallel,
+		Done:     reportBatchResult,
+	})
+
+	ui.SetQuiet(false)
+	var failed, skipped int
+	for _, result := range results {
+		switch result.Status {
+		case batch.StatusFailed:
+			failed++
+		case batch.StatusSkipped:
+			skipped++
+		}
+	}
+
+	if failed > 0 || skipped > 0 {
+		return fmt.Errorf("%d of %d jobs failed, %d skipped", failed, len(results), skipped)
+	}
+	ui.Successln("All %d jobs succeeded", len(results))
+	return nil
+}
+
+// reportBatchResult prints one job's outcome
+func reportBatchResult(result batch.Result) {
+	switch result.Status {
+	case batch.StatusSucceeded:
+		ui.Successln("%s finished in %s", result.Job.Name, result.Elapsed.Round(time.Millisecond))
+	case batch.StatusFailed:
+		ui.Errorln("%s failed after %s: %v", result.Job.Name, result.Elapsed.Round(time.Millisecond), result.Err)
+	case batch.StatusSkipped:
+		ui.Warningln("%s skipped: %v", result.Job.Name, result.Err)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeBatchFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jobs.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write batch file: %v", err)
+	}
+	return path
+}
+
+func TestBatchRunAction(t *testing.T) {
+	reg := newFakeRegistry(&fakeGraph{})
+
+	t.Run("RunsJobs", func(t *testing.T) {
+		path := writeBatchFile(t, "jobs:\n  - {name: first, run: help}\n  - {name: second, run: [help], needs: [first]}\n")
+		if _, err := runSubcommand(t, BatchCommand(), "run", BatchRunAction, reg, path); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("ReportsFailures", func(t *testing.T) {
+		path := writeBatchFile(t, "jobs:\n  - {name: bad, run: 'followers list --limit nope'}\n  - {name: after, run: help, needs: [bad]}\n")
+		_, err := runSubcommand(t, BatchCommand(), "run", BatchRunAction, reg, path)
+		if err == nil || !strings.Contains(err.Error(), "1 of 2 jobs failed, 1 skipped") {
+			t.Fatalf("expected failure summary, got %v", err)
+		}
+	})
+
+	t.Run("RejectsShell", func(t *testing.T) {
+		path := writeBatchFile(t, "jobs:\n  - {name: nested, run: shell}\n")
+		_, err := runSubcommand(t, BatchCommand(), "run", BatchRunAction, reg, path)
+		if err == nil || !strings.Contains(err.Error(), "can't run in a batch") {
+			t.Fatalf("expected rejection, got %v", err)
+		}
+	})
+
+	t.Run("RequiresFile", func(t *testing.T) {
+		if _, err := runSubcommand(t, BatchCommand(), "run", BatchRunAction, reg); err == nil {
+			t.Fatal("expected error without a batch file")
+		}
+	})
+}
+
+// TestBatchRunAction_Parallel runs jobs side by side; under go test -race it catches jobs writing session state
+func TestBatchRunAction_Parallel(t *testing.T) {
+	reg := newFakeRegistry(&fakeGraph{})
+
+	t.Run("SharesSession", func(t *testing.T) {
+		path := writeBatchFile(t, "jobs:\n  - {name: a, run: help}\n  - {name: b, run: help}\n  - {name: c, run: help}\n  - {name: d, run: help}\n")
+		for range 10 {
+			if _, err := runSubcommand(t, BatchCommand(), "run", BatchRunAction, reg, "--jobs", "4", path); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	t.Run("RejectsSessionFlags", func(t *testing.T) {
+		path := writeBatchFile(t, "jobs:\n  - {name: a, run: 'help --account alice.test'}\n  - {name: b, run: '--dates=iso help'}\n  - {name: c, run: help}\n")
+		_, err := runSubcommand(t, BatchCommand(), "run", BatchRunAction, reg, "--jobs", "4", path)
+		if err == nil || !strings.Contains(err.Error(), "2 of 3 jobs failed") {
+			t.Fatalf("expected the jobs with session flags to fail, got %v", err)
+		}
+	})
+}
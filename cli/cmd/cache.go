@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+// CacheLimitAction shows the cache size limit next to the cache's estimated usage
+func CacheLimitAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	cacheRepo, err := reg.GetCacheRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get cache repository: %w", err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	usage, err := cacheRepo.Usage(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to measure cache: %w", err)
+	}
+
+	ui.Titleln("Cache")
+	if limit := cfg.CacheMaxSize(); limit > 0 {
+		ui.Infoln("Limit: %s", utils.FormatSize(limit))
+	} else {
+		ui.Infoln("Limit: none")
+	}
+	ui.Infoln("Usage: about %s (%d post rates, %d activity entries)", utils.FormatSize(usage.Bytes), usage.PostRates, usage.Activities)
+	return nil
+}
+
+// CacheLimitSetAction saves a new cache size limit and evicts down to it right away
+func CacheLimitSetAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("size required (e.g. 200MB, or off to remove the limit)")
+	}
+	limit, err := parseCacheLimit(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	cacheRepo, err := reg.GetCacheRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get cache repository: %w", err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfg.Cache = nil
+	if limit > 0 {
+		cfg.Cache = &config.CacheConfig{MaxSize: limit}
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	cacheRepo.SetMaxSize(limit)
+
+	if limit == 0 {
+		ui.Successln("Cache limit removed")
+		return nil
+	}
+
+	removed, err := cacheRepo.Evict(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("failed to evict cache entries: %w", err)
+	}
+	ui.Successln("Cache limit set to %s", utils.FormatSize(limit))
+	if removed > 0 {
+		ui.Infoln("Evicted %d cache entries", removed)
+	}
+	return nil
+}
+
+// parseCacheLimit parses a size limit, where "off" or "none" mean unlimited
+func parseCacheLimit(value string) (int64, error) {
+	switch strings.ToLower(value) {
+	case "off", "none":
+		return 0, nil
+	}
+	return utils.ParseSize(value)
+}
+
+// CacheCommand returns the cache command
+func CacheCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "Manage the post rate and activity caches",
+		Commands: []*cli.Command{
+			{
+				Name:      "limit",
+				Usage:     "Show the cache size limit and current usage",
+				ArgsUsage: " ",
+				Action:    withRegistry(CacheLimitAction),
+				Commands: []*cli.Command{
+					{
+						Name:      "set",
+						Usage:     "Bound the cache's size, evicting the least recently fetched entries past it",
+						UsageText: "Sizes take B, KB, MB, or GB suffixes (powers of 1024), e.g. skycli cache limit set 200MB. Use off to remove the limit.",
+						ArgsUsage: "<size>",
+						Action:    withRegistry(CacheLimitSetAction),
+					},
+				},
+			},
+		},
+	}
+}
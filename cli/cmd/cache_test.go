@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestCacheLimitSetAction(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	cacheRepo, err := bsky.NewCacheRepository()
+	if err != nil {
+		t.Fatalf("NewCacheRepository failed: %v", err)
+	}
+	t.Cleanup(func() { cacheRepo.Close() })
+	if err := cacheRepo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	var activities []*bsky.ActivityCacheModel
+	for i := range 20 {
+		activities = append(activities, &bsky.ActivityCacheModel{
+			ActorDid:  fmt.Sprintf("did:plc:actor%02d", i),
+			FetchedAt: time.Now().Add(time.Duration(i) * time.Minute),
+		})
+	}
+	if err := cacheRepo.SaveActivities(ctx, activities); err != nil {
+		t.Fatalf("SaveActivities failed: %v", err)
+	}
+
+	reg := registry.New(registry.Dependencies{CacheRepo: cacheRepo})
+	limitCmd := CacheCommand().Command("limit")
+
+	if _, err := runSubcommand(t, limitCmd, "set", CacheLimitSetAction, reg, "1KB"); err != nil {
+		t.Fatalf("limit set failed: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.CacheMaxSize(); got != 1024 {
+		t.Errorf("saved limit = %d, want 1024", got)
+	}
+	usage, err := cacheRepo.Usage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Bytes > 1024 || usage.Activities == 0 {
+		t.Errorf("expected eviction to just under the limit, got %+v", usage)
+	}
+	if newest, err := cacheRepo.GetActivity(ctx, "did:plc:actor19"); err != nil || newest == nil {
+		t.Errorf("expected the most recently fetched entry to survive (%v)", err)
+	}
+
+	if _, err := runSubcommand(t, limitCmd, "set", CacheLimitSetAction, reg, "off"); err != nil {
+		t.Fatalf("limit set off failed: %v", err)
+	}
+	if cfg, err = config.Load(); err != nil || cfg.Cache != nil {
+		t.Errorf("expected the limit to be removed, got %+v (%v)", cfg.Cache, err)
+	}
+	if cacheRepo.MaxSize() != 0 {
+		t.Errorf("expected the open repository to drop its limit, got %d", cacheRepo.MaxSize())
+	}
+
+	if _, err := runSubcommand(t, limitCmd, "set", CacheLimitSetAction, reg, "lots"); err == nil {
+		t.Error("expected an invalid size to fail")
+	}
+}
@@ -0,0 +1,547 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// composeKeyHelp is the key reminder at the bottom of the compose screen
+const composeKeyHelp = "ctrl+p post · ctrl+s save draft · ctrl+o attach image · ctrl+x remove image · esc close"
+
+// pickerKeyHelp is the key reminder at the bottom of the image picker
+const pickerKeyHelp = "j/k move · enter open or attach · backspace parent folder · esc cancel"
+
+// composeWarnAt is how close to the length limit the counter turns from plain to a warning
+const composeWarnAt = bsky.MaxPostGraphemes - 20
+
+// imageExtensions are the file types the image picker offers
+var imageExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+
+// Styles for the compose screen's text: facets as they will appear once posted, text past the limit, and the cursor
+var (
+	composeMentionStyle = ui.PrimaryStyle.Bold(true)
+	composeLinkStyle    = ui.AccentStyle.Underline(true)
+	composeTagStyle     = ui.PrimaryStyle
+	composeCursorStyle  = lipgloss.NewStyle().Reverse(true)
+)
+
+// composeSession is the compose screen: the post being written, its attached images, and the picker used to attach
+// them. It publishes through publish and keeps drafts in drafts when that is set.
+type composeSession struct {
+	text    string
+	cursor  int // byte offset into text, always at a grapheme boundary
+	top     int // first text line on screen
+	images  []bsky.DraftImage
+	draft   *bsky.DraftModel // the draft being edited, once there is one
+	drafts  *bsky.DraftRepository
+	dirty   bool // changed since it was opened or last saved
+	picker  *imagePicker
+	dir     string // folder the picker opens in
+	status  string
+	notes   []string // what flags add to the post, such as the post it replies to
+	publish func(ctx context.Context, text string, images []bsky.DraftImage) (*bsky.CreateRecordResponse, error)
+	posted  *bsky.CreateRecordResponse
+}
+
+// imagePicker browses folders for an image to attach
+type imagePicker struct {
+	dir      string
+	entries  []pickerEntry
+	selected int
+	top      int
+}
+
+// pickerEntry is a folder or image file in the picker
+type pickerEntry struct {
+	name string
+	dir  bool
+}
+
+// openDraft loads a saved draft into the session
+func (s *composeSession) openDraft(draft *bsky.DraftModel) {
+	s.draft = draft
+	s.text = draft.Text
+	s.cursor = len(draft.Text)
+	s.images = slices.Clone(draft.Images)
+	s.status = "Editing a draft saved " + ui.FormatDate(draft.UpdatedAt, ui.DatesRelative)
+}
+
+// handle applies one key press and reports whether the compose screen should close
+func (s *composeSession) handle(ctx context.Context, key ui.Key, prompt func(string) (string, error)) (bool, error) {
+	if s.picker != nil {
+		return false, s.handlePicker(key, prompt)
+	}
+	s.status = ""
+
+	switch key {
+	case ui.KeyCtrlC:
+		return true, nil
+	case ui.KeyEscape:
+		return s.close(ctx, prompt)
+	case "ctrl+p":
+		return s.post(ctx), nil
+	case "ctrl+s":
+		s.saveDraft(ctx)
+	case "ctrl+o":
+		s.openPicker()
+	case "ctrl+x":
+		if len(s.images) == 0 {
+			s.status = "No images to remove"
+			break
+		}
+		removed := s.images[len(s.images)-1]
+		s.images = s.images[:len(s.images)-1]
+		s.dirty = true
+		s.status = "Removed " + filepath.Base(removed.Path)
+	case ui.KeyEnter:
+		s.insert("\n")
+	case ui.KeyBack:
+		if start := previousGrapheme(s.text, s.cursor); start < s.cursor {
+			s.text = s.text[:start] + s.text[s.cursor:]
+			s.cursor = start
+			s.dirty = true
+		}
+	case ui.KeyDelete:
+		if end := nextGrapheme(s.text, s.cursor); end > s.cursor {
+			s.text = s.text[:s.cursor] + s.text[end:]
+			s.dirty = true
+		}
+	case ui.KeyLeft:
+		s.cursor = previousGrapheme(s.text, s.cursor)
+	case ui.KeyRight:
+		s.cursor = nextGrapheme(s.text, s.cursor)
+	case ui.KeyUp:
+		s.moveLine(-1)
+	case ui.KeyDown:
+		s.moveLine(1)
+	case ui.KeyHome:
+		s.cursor = strings.LastIndex(s.text[:s.cursor], "\n") + 1
+	case ui.KeyEnd:
+		if end := strings.Index(s.text[s.cursor:], "\n"); end >= 0 {
+			s.cursor += end
+		} else {
+			s.cursor = len(s.text)
+		}
+	default:
+		// Anything else that is a single printable character is typed text
+		if r, size := utf8.DecodeRuneInString(string(key)); size == len(key) && r >= ' ' {
+			s.insert(string(key))
+		}
+	}
+	return false, nil
+}
+
+// insert types text at the cursor
+func (s *composeSession) insert(text string) {
+	s.text = s.text[:s.cursor] + text + s.text[s.cursor:]
+	s.cursor += len(text)
+	s.dirty = true
+}
+
+// moveLine moves the cursor up or down a line of text, keeping its column where the line is long enough
+func (s *composeSession) moveLine(delta int) {
+	start := strings.LastIndex(s.text[:s.cursor], "\n") + 1
+	column := uniseg.GraphemeClusterCount(s.text[start:s.cursor])
+
+	if delta < 0 {
+		if start == 0 {
+			s.cursor = 0
+			return
+		}
+		start = strings.LastIndex(s.text[:start-1], "\n") + 1
+	} else {
+		next := strings.Index(s.text[s.cursor:], "\n")
+		if next < 0 {
+			s.cursor = len(s.text)
+			return
+		}
+		start = s.cursor + next + 1
+	}
+
+	s.cursor = start
+	for ; column > 0 && s.cursor < len(s.text) && s.text[s.cursor] != '\n'; column-- {
+		s.cursor = nextGrapheme(s.text, s.cursor)
+	}
+}
+
+// close leaves the compose screen, first offering to keep unsaved changes as a draft. An empty answer goes back to
+// writing.
+func (s *composeSession) close(ctx context.Context, prompt func(string) (string, error)) (bool, error) {
+	if !s.dirty || strings.TrimSpace(s.text) == "" && len(s.images) == 0 {
+		return true, nil
+	}
+	answer, err := prompt("Save as a draft before closing? (y/n, empty to keep writing):")
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		s.saveDraft(ctx)
+		return !s.dirty, nil
+	case "n", "no":
+		return true, nil
+	}
+	return false, nil
+}
+
+// post publishes the text and images and reports whether it worked; on failure the reason is shown and writing
+// continues
+func (s *composeSession) post(ctx context.Context) bool {
+	record, err := s.publish(ctx, s.text, s.images)
+	if err != nil {
+		s.status = "Not posted: " + err.Error()
+		return false
+	}
+	s.posted = record
+
+	if s.draft != nil && s.drafts != nil {
+		if err := s.drafts.Delete(ctx, s.draft.ID); err != nil {
+			logger.Warn("Failed to delete the published draft", "id", s.draft.ID, "error", err)
+		}
+	}
+	return true
+}
+
+// saveDraft stores the text and images as a draft, updating the one being edited if there is one
+func (s *composeSession) saveDraft(ctx context.Context) {
+	if s.drafts == nil {
+		s.status = "Drafts are unavailable"
+		return
+	}
+	draft := s.draft
+	if draft == nil {
+		draft = &bsky.DraftModel{}
+	}
+	draft.Text = s.text
+	draft.Images = slices.Clone(s.images)
+	if err := s.drafts.Save(ctx, draft); err != nil {
+		s.status = fmt.Sprintf("Failed to save the draft: %v", err)
+		return
+	}
+	s.draft = draft
+	s.dirty = false
+	s.status = "Draft saved as " + shortID(draft.ID)
+}
+
+// openPicker shows the image picker in the last folder used, or the working directory
+func (s *composeSession) openPicker() {
+	if len(s.images) >= bsky.MaxPostImages {
+		s.status = fmt.Sprintf("A post can have at most %d images", bsky.MaxPostImages)
+		return
+	}
+	dir := s.dir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	picker := &imagePicker{}
+	if err := picker.load(dir); err != nil {
+		s.status = fmt.Sprintf("Can't open %s: %v", dir, err)
+		return
+	}
+	s.picker = picker
+	s.status = ""
+}
+
+// handlePicker applies a key press while the image picker is open
+func (s *composeSession) handlePicker(key ui.Key, prompt func(string) (string, error)) error {
+	p := s.picker
+	switch key {
+	case ui.KeyEscape, ui.KeyCtrlC, "q":
+		s.picker = nil
+		s.status = "No image attached"
+	case "j", ui.KeyDown:
+		p.selected = min(p.selected+1, max(len(p.entries)-1, 0))
+	case "k", ui.KeyUp:
+		p.selected = max(p.selected-1, 0)
+	case ui.KeyPageDown:
+		p.selected = min(p.selected+10, max(len(p.entries)-1, 0))
+	case ui.KeyPageUp:
+		p.selected = max(p.selected-10, 0)
+	case ui.KeyBack, "h", ui.KeyLeft:
+		if err := p.load(filepath.Dir(p.dir)); err != nil {
+			s.status = err.Error()
+		}
+	case ui.KeyEnter, "l", ui.KeyRight:
+		if len(p.entries) == 0 {
+			break
+		}
+		entry := p.entries[p.selected]
+		path := filepath.Join(p.dir, entry.name)
+		if entry.dir {
+			if err := p.load(path); err != nil {
+				s.status = fmt.Sprintf("Can't open %s: %v", entry.name, err)
+			}
+			break
+		}
+		return s.attach(path, prompt)
+	}
+	return nil
+}
+
+// attach checks an image against Bluesky's limits, asks for its alt text, and adds it to the post
+func (s *composeSession) attach(path string, prompt func(string) (string, error)) error {
+	if _, err := readPostImage(path); err != nil {
+		s.status = err.Error()
+		return nil
+	}
+	alt, err := prompt(fmt.Sprintf("Alt text for %s (describe it for people who can't see it):", filepath.Base(path)))
+	if err != nil {
+		return err
+	}
+	s.images = append(s.images, bsky.DraftImage{Path: path, Alt: alt})
+	s.dirty = true
+	s.dir = s.picker.dir
+	s.picker = nil
+	s.status = "Attached " + filepath.Base(path)
+	if alt == "" {
+		s.status += " without alt text"
+	}
+	return nil
+}
+
+// load lists dir's subfolders and image files, folders first, skipping hidden ones
+func (p *imagePicker) load(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	items, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var entries []pickerEntry
+	if parent := filepath.Dir(dir); parent != dir {
+		entries = append(entries, pickerEntry{name: "..", dir: true})
+	}
+	var files []pickerEntry
+	for _, item := range items {
+		name := item.Name()
+		switch {
+		case strings.HasPrefix(name, "."):
+		case item.IsDir():
+			entries = append(entries, pickerEntry{name: name, dir: true})
+		case slices.Contains(imageExtensions, strings.ToLower(filepath.Ext(name))):
+			files = append(files, pickerEntry{name: name})
+		}
+	}
+
+	p.dir = dir
+	p.entries = append(entries, files...)
+	p.selected, p.top = 0, 0
+	return nil
+}
+
+// render draws the compose screen, or the image picker while it is open, into exactly height lines
+func (s *composeSession) render(width, height int) []string {
+	if s.picker != nil {
+		return s.renderPicker(width, height)
+	}
+
+	title := "New post"
+	if s.draft != nil {
+		title = "Draft " + shortID(s.draft.ID)
+	}
+	count := bsky.GraphemeLength(s.text)
+	counterStyle := ui.InfoStyle
+	switch {
+	case count > bsky.MaxPostGraphemes:
+		counterStyle = ui.ErrorStyle.Bold(true)
+	case count > composeWarnAt:
+		counterStyle = ui.WarningStyle
+	}
+	lines := []string{ui.TitleStyle.Render(title) + " " + counterStyle.Render(fmt.Sprintf("%d/%d", count, bsky.MaxPostGraphemes))}
+
+	var footer []string
+	for _, note := range s.notes {
+		footer = append(footer, ui.InfoStyle.MaxWidth(width).Render(note))
+	}
+	spans := bsky.FindTextSpans(s.text)
+	if len(spans) > 0 {
+		values := make([]string, len(spans))
+		for i, span := range spans {
+			values[i] = s.text[span.Start:span.End]
+		}
+		footer = append(footer, ui.InfoStyle.MaxWidth(width).Render("Links to "+strings.Join(values, " · ")))
+	}
+	for i, image := range s.images {
+		alt := "no alt text"
+		if image.Alt != "" {
+			alt = "alt: " + image.Alt
+		}
+		footer = append(footer, ui.InfoStyle.MaxWidth(width).Render(fmt.Sprintf("[image %d] %s · %s", i+1, filepath.Base(image.Path), alt)))
+	}
+
+	body := max(height-3-len(footer), 1)
+	text, cursorLine := renderComposeText(s.text, s.cursor, spans, max(width-2, 10))
+	s.top = min(s.top, cursorLine)
+	if cursorLine >= s.top+body {
+		s.top = cursorLine - body + 1
+	}
+	rows := text[min(s.top, len(text)):]
+	rows = rows[:min(len(rows), body)]
+	for i := range rows {
+		rows[i] = "  " + rows[i]
+	}
+	for len(rows) < body {
+		rows = append(rows, "")
+	}
+	lines = append(lines, rows...)
+	lines = append(lines, footer...)
+
+	lines = append(lines, ui.AccentStyle.Render(s.status))
+	return append(lines, ui.InfoStyle.MaxWidth(width).Render(composeKeyHelp))
+}
+
+// renderPicker draws the image picker: the folder, its entries scrolled to keep the selected one in view, the
+// status, and the key help
+func (s *composeSession) renderPicker(width, height int) []string {
+	p := s.picker
+	lines := []string{ui.TitleStyle.MaxWidth(width).Render("Attach an image · " + p.dir)}
+
+	body := max(height-3, 1)
+	p.top = min(p.top, p.selected)
+	if p.selected >= p.top+body {
+		p.top = p.selected - body + 1
+	}
+	var rows []string
+	if len(p.entries) == 0 {
+		rows = append(rows, ui.InfoStyle.Render("  No folders or images here."))
+	}
+	for i := p.top; i < len(p.entries) && len(rows) < body; i++ {
+		entry := p.entries[i]
+		name, style := entry.name, ui.TextStyle
+		if entry.dir {
+			name, style = name+"/", ui.SubtitleStyle
+		}
+		marker := "  "
+		if i == p.selected {
+			marker, style = "▌ ", ui.AccentStyle.Bold(true)
+		}
+		rows = append(rows, marker+style.Render(name))
+	}
+	for len(rows) < body {
+		rows = append(rows, "")
+	}
+	lines = append(lines, rows...)
+
+	lines = append(lines, ui.AccentStyle.Render(s.status))
+	return append(lines, ui.InfoStyle.MaxWidth(width).Render(pickerKeyHelp))
+}
+
+// renderComposeText wraps text to width, styling facets and the text past the length limit and showing the
+// cursor. It returns the lines and which of them holds the cursor.
+func renderComposeText(text string, cursor int, spans []bsky.TextSpan, width int) ([]string, int) {
+	var lines []string
+	var line strings.Builder
+	column, cursorLine, count := 0, 0, 0
+	flush := func() {
+		lines = append(lines, line.String())
+		line.Reset()
+		column = 0
+	}
+
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		from, _ := graphemes.Positions()
+		cluster := graphemes.Str()
+		count++
+
+		if cluster == "\n" || cluster == "\r\n" {
+			if from == cursor {
+				cursorLine = len(lines)
+				line.WriteString(composeCursorStyle.Render(" "))
+			}
+			flush()
+			continue
+		}
+
+		w := graphemes.Width()
+		if column > 0 && column+w > width {
+			flush()
+		}
+		style, styled := composeStyleAt(from, spans)
+		if count > bsky.MaxPostGraphemes {
+			style, styled = ui.ErrorStyle, true
+		}
+		if from == cursor {
+			cursorLine = len(lines)
+			style, styled = composeCursorStyle, true
+		}
+		if styled {
+			line.WriteString(style.Render(cluster))
+		} else {
+			line.WriteString(cluster)
+		}
+		column += w
+	}
+
+	if cursor >= len(text) {
+		if column > 0 && column+1 > width {
+			flush()
+		}
+		cursorLine = len(lines)
+		line.WriteString(composeCursorStyle.Render(" "))
+	}
+	flush()
+	return lines, cursorLine
+}
+
+// composeStyleAt returns the style for text at byte offset i: its facet's, if it is inside one
+func composeStyleAt(i int, spans []bsky.TextSpan) (lipgloss.Style, bool) {
+	for _, span := range spans {
+		if i < span.Start || i >= span.End {
+			continue
+		}
+		switch span.Type {
+		case bsky.FacetMention:
+			return composeMentionStyle, true
+		case bsky.FacetLink:
+			return composeLinkStyle, true
+		default:
+			return composeTagStyle, true
+		}
+	}
+	return lipgloss.Style{}, false
+}
+
+// previousGrapheme returns where the grapheme before byte offset i starts, or 0 at the start of text
+func previousGrapheme(text string, i int) int {
+	start, rest, state := 0, text, -1
+	for len(rest) > 0 {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if start+len(cluster) >= i {
+			return start
+		}
+		start += len(cluster)
+	}
+	return start
+}
+
+// nextGrapheme returns where the grapheme after the one at byte offset i starts, or len(text) at the end
+func nextGrapheme(text string, i int) int {
+	if i >= len(text) {
+		return len(text)
+	}
+	cluster, _, _, _ := uniseg.FirstGraphemeClusterInString(text[i:], -1)
+	return i + len(cluster)
+}
+
+// shortID shortens a draft ID for display; commands accept any unique prefix of one
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
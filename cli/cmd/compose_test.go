@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestComposeSession(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	drafts, err := bsky.NewDraftRepository()
+	if err != nil {
+		t.Fatalf("NewDraftRepository failed: %v", err)
+	}
+	t.Cleanup(func() { drafts.Close() })
+	if err := drafts.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	photo, err := os.Create(filepath.Join(dir, "photo.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(photo, image.NewRGBA(image.Rect(0, 0, 4, 3))); err != nil {
+		t.Fatal(err)
+	}
+	photo.Close()
+	os.WriteFile(filepath.Join(dir, "fake.jpg"), []byte("not an image"), 0o644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("skip me"), 0o644)
+	os.Mkdir(filepath.Join(dir, "sub"), 0o755)
+
+	feed := newFakeFeed()
+	resolve := func(ctx context.Context, handle string) (string, error) {
+		if handle == "alice.bsky.social" {
+			return "did:plc:alice", nil
+		}
+		return "", errors.New("no such handle")
+	}
+	newSession := func() *composeSession {
+		return &composeSession{drafts: drafts, dir: dir, publish: func(ctx context.Context, text string, images []bsky.DraftImage) (*bsky.CreateRecordResponse, error) {
+			return publishPost(ctx, feed, resolve, text, images, postOptions{})
+		}}
+	}
+
+	var answers []string
+	prompt := func(string) (string, error) {
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, nil
+	}
+	press := func(s *composeSession, keys ...ui.Key) {
+		t.Helper()
+		for _, key := range keys {
+			if done, err := s.handle(ctx, key, prompt); err != nil || done {
+				t.Fatalf("key %q: done %v, err %v", key, done, err)
+			}
+		}
+	}
+	typeText := func(s *composeSession, text string) {
+		t.Helper()
+		for _, r := range text {
+			key := ui.Key(string(r))
+			if r == '\n' {
+				key = ui.KeyEnter
+			}
+			press(s, key)
+		}
+	}
+
+	s := newSession()
+	typeText(s, "hi @alice.bsky.social 🇳🇿\nsecond line #go")
+
+	// Backspace takes a whole grapheme, here a flag made of two code points
+	press(s, ui.KeyUp, ui.KeyEnd, ui.KeyBack)
+	typeText(s, "🎉")
+	if want := "hi @alice.bsky.social 🎉\nsecond line #go"; s.text != want {
+		t.Fatalf("text = %q, want %q", s.text, want)
+	}
+	press(s, ui.KeyDown)
+	if s.cursor != len(s.text) {
+		t.Errorf("down from the end of the first line should stop at the end of the shorter second, got %d", s.cursor)
+	}
+
+	screen := s.render(60, 12)
+	if len(screen) != 12 {
+		t.Errorf("render returned %d lines, want 12", len(screen))
+	}
+	joined := strings.Join(screen, "\n")
+	for _, text := range []string{"New post", "39/300", "second line #go", "Links to @alice.bsky.social · #go"} {
+		if !strings.Contains(joined, text) {
+			t.Errorf("compose screen is missing %q:\n%s", text, joined)
+		}
+	}
+
+	// The picker lists folders and images only, and refuses files that aren't images
+	press(s, "ctrl+o")
+	if s.picker == nil || len(s.picker.entries) != 4 || s.picker.entries[1].name != "sub" || s.picker.entries[3].name != "photo.png" {
+		t.Fatalf("unexpected picker %+v", s.picker)
+	}
+	press(s, "j", "j", ui.KeyEnter)
+	if s.picker == nil || !strings.Contains(s.status, "not a JPEG, PNG, GIF, or WebP") {
+		t.Errorf("expected fake.jpg to be refused, got status %q", s.status)
+	}
+	answers = []string{"a tiny photo"}
+	press(s, "j", ui.KeyEnter)
+	if s.picker != nil || len(s.images) != 1 || s.images[0].Alt != "a tiny photo" {
+		t.Fatalf("expected photo.png attached, got %+v (status %q)", s.images, s.status)
+	}
+	if !strings.Contains(strings.Join(s.render(60, 12), "\n"), "[image 1] photo.png · alt: a tiny photo") {
+		t.Error("compose screen should list the attached image")
+	}
+
+	press(s, "ctrl+s")
+	saved, err := drafts.List(ctx)
+	if err != nil || len(saved) != 1 || saved[0].Text != s.text || len(saved[0].Images) != 1 {
+		t.Fatalf("expected the draft saved, got %+v (err %v)", saved, err)
+	}
+
+	// Closing with unsaved changes asks first; an empty answer keeps writing
+	typeText(s, "!")
+	answers = []string{""}
+	press(s, ui.KeyEscape)
+	press(s, ui.KeyBack)
+
+	if done, err := s.handle(ctx, "ctrl+p", prompt); err != nil || !done {
+		t.Fatalf("ctrl+p: done %v, err %v (status %q)", done, err, s.status)
+	}
+	if len(feed.posts) != 1 || len(feed.uploads) != 1 || feed.uploads[0] != "image/png" {
+		t.Fatalf("expected one post with one upload, got %+v and %v", feed.posts, feed.uploads)
+	}
+	post := feed.posts[0]
+	if post.Text != "hi @alice.bsky.social 🎉\nsecond line #go" || len(post.Facets) != 2 || post.Facets[0].Features[0].Did != "did:plc:alice" {
+		t.Errorf("unexpected post %+v", post)
+	}
+	if image := post.Images[0]; image.Alt != "a tiny photo" || image.Width != 4 || image.Height != 3 {
+		t.Errorf("unexpected image %+v", image)
+	}
+	if saved, _ := drafts.List(ctx); len(saved) != 0 {
+		t.Errorf("publishing should delete the draft, have %+v", saved)
+	}
+
+	// Posts over the limit stay on screen with the reason
+	long := newSession()
+	typeText(long, strings.Repeat("a", bsky.MaxPostGraphemes+1))
+	press(long, "ctrl+p")
+	if !strings.Contains(long.status, "post is 301 characters") || !strings.Contains(long.render(60, 8)[0], "301/300") {
+		t.Errorf("expected the post refused as too long, got status %q", long.status)
+	}
+
+	answers = []string{"y"}
+	if done, err := long.handle(ctx, ui.KeyEscape, prompt); err != nil || !done || long.draft == nil {
+		t.Errorf("esc then y should save a draft and close: done %v, err %v", done, err)
+	}
+}
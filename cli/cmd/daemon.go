@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/daemon"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/urfave/cli/v3"
+)
+
+// DaemonCommand returns the daemon command
+func DaemonCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "daemon",
+		Usage:     "Run background checks on a schedule",
+		UsageText: "Run periodic tasks in the foreground until interrupted. Intervals can be changed per task in the \"daemon\" section of the config file, e.g. {\"intervals\": {\"label-check\": \"30m\"}}, or set to \"off\". Archive rules in the same section ({\"archiveRules\": [{\"name\": \"rust\", \"keywords\": [\"rustlang\"]}]}) enable the stream task, which saves matching posts from the firehose into a local feed per rule. With \"trackFollowers\": true the stream task also keeps your followers current from follow events, so 'followers diff' needs no API fetch, and with \"syncProfiles\": true cached profiles follow handle changes, PDS migrations, and deactivations. Stream subscriptions resume from where they stopped; 'stream backfill' catches up without running the daemon. \"backup\": {\"likes\": true, \"reposts\": true} enables the backup task, which every 6 hours saves your posts since the last run into the local \"Backup: posts\" feed, and your likes and reposts into feeds of their own; run it now with 'daemon --once --only backup'.",
+		ArgsUsage: " ",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "only",
+				Usage: "Run only the named tasks",
+			},
+			&cli.BoolFlag{
+				Name:  "once",
+				Usage: "Run each task once and exit",
+			},
+		},
+		Action: withRegistry(DaemonAction),
+	}
+}
+
+// DaemonAction runs the configured daemon tasks until interrupted, or once with --once
+func DaemonAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var intervals map[string]string
+	if cfg.Daemon != nil {
+		intervals = cfg.Daemon.Intervals
+	}
+
+	tasks, err := daemon.Configure(daemonTasks(reg, cfg.Daemon), intervals, cmd.StringSlice("only"))
+	if err != nil {
+		return err
+	}
+	scheduler := daemon.NewScheduler(tasks, logger)
+
+	if cmd.Bool("once") {
+		return scheduler.RunOnce(ctx)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for _, task := range tasks {
+		if task.Continuous {
+			logger.Info("Started task", "task", task.Name)
+			continue
+		}
+		logger.Info("Scheduled task", "task", task.Name, "every", task.Interval)
+	}
+	err = scheduler.Run(ctx)
+	logger.Info("Daemon stopped")
+	return err
+}
+
+// daemonTasks returns every task the daemon can run with cfg, at its default interval
+func daemonTasks(reg *registry.Registry, cfg *config.DaemonConfig) []daemon.Task {
+	tasks := []daemon.Task{
+		{Name: "label-check", Interval: time.Hour, Run: func(ctx context.Context) error {
+			findings, err := checkOwnLabels(ctx, reg, 100)
+			if err != nil {
+				return err
+			}
+			for _, finding := range findings {
+				if finding.New {
+					logger.Warn("New moderation label", "label", finding.Label, "on", finding.Subject, "uri", finding.Uri, "labeler", finding.Labeler)
+				}
+			}
+			logger.Debug("Label check finished", "labels", len(findings))
+			return nil
+		}},
+	}
+
+	if task, ok := streamTask(reg, cfg); ok {
+		tasks = append(tasks, task)
+	}
+	if task, ok := backupTask(reg, cfg); ok {
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
@@ -0,0 +1,419 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/seed"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// DevSeedAction fills the local store with synthetic profiles, posts, follower snapshots, and cache entries
+func DevSeedAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if reg.TeamMode() {
+		return fmt.Errorf("team mode is enabled; seeding would write synthetic snapshots to the shared database (run 'skycli team disable' first)")
+	}
+
+	data, err := seed.Generate(seed.Options{
+		UserDID:   cmd.String("user"),
+		Followers: int(cmd.Int("followers")),
+		Posts:     int(cmd.Int("posts")),
+		Snapshots: int(cmd.Int("snapshots")),
+		Seed:      uint64(cmd.Int("seed")),
+	})
+	if err != nil {
+		return err
+	}
+
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get feed repository: %w", err)
+	}
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get post repository: %w", err)
+	}
+	profileRepo, err := reg.GetProfileRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get profile repository: %w", err)
+	}
+	snapshotRepo, err := reg.GetSnapshotRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+	cacheRepo, err := reg.GetCacheRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get cache repository: %w", err)
+	}
+
+	profiles := make([]*bsky.ProfileModel, len(data.Profiles))
+	for i, profile := range data.Profiles {
+		profileJSON, err := json.Marshal(profile)
+		if err != nil {
+			return fmt.Errorf("failed to encode profile: %w", err)
+		}
+		profiles[i] = &bsky.ProfileModel{Did: profile.Did, Handle: profile.Handle, DataJSON: string(profileJSON)}
+	}
+	if err := profileRepo.BatchSave(ctx, profiles); err != nil {
+		return fmt.Errorf("failed to save profiles: %w", err)
+	}
+	ui.Successln("Saved %d profiles", len(profiles))
+
+	if len(data.Posts) > 0 {
+		feed, err := seedFeed(ctx, feedRepo)
+		if err != nil {
+			return err
+		}
+		for _, post := range data.Posts {
+			post.FeedID = feed.ID()
+		}
+		if err := postRepo.BatchSave(ctx, data.Posts); err != nil {
+			return fmt.Errorf("failed to save posts: %w", err)
+		}
+		ui.Successln("Saved %d posts to feed %s", len(data.Posts), feed.ID())
+	}
+
+	imported := 0
+	for _, snapshot := range data.Snapshots {
+		if existing, err := snapshotRepo.Get(ctx, snapshot.Model.ID()); err == nil && existing != nil {
+			logger.Debug("Seed snapshot already exists", "snapshot", snapshot.Model.ID())
+			continue
+		}
+		if err := snapshotRepo.Import(ctx, snapshot.Model, snapshot.Entries); err != nil {
+			return fmt.Errorf("failed to save snapshot %s: %w", snapshot.Model.ID(), err)
+		}
+		imported++
+	}
+	if len(data.Snapshots) > 0 {
+		ui.Successln("Saved %d follower snapshots for %s (%d already present)", imported, data.Snapshots[0].Model.UserDid, len(data.Snapshots)-imported)
+	}
+
+	if err := cacheRepo.SaveActivities(ctx, data.Activities); err != nil {
+		return fmt.Errorf("failed to save activity cache: %w", err)
+	}
+	if err := cacheRepo.SavePostRates(ctx, data.PostRates); err != nil {
+		return fmt.Errorf("failed to save post rate cache: %w", err)
+	}
+	ui.Successln("Cached activity and post rates for %d followers", len(data.Activities))
+
+	ui.Infoln("Seeded accounts use DIDs starting with %s", seed.DIDPrefix)
+	if len(data.Posts) > 0 {
+		ui.Infoln("Try: skycli analytics words --source %s", seed.FeedSource)
+	}
+	if n := len(data.Snapshots); n > 1 {
+		ui.Infoln("Try: skycli followers diff --user %s --since %s --until %s (requires login)", data.Snapshots[0].Model.UserDid, data.Snapshots[0].Model.ID(), data.Snapshots[n-1].Model.ID())
+	}
+	return nil
+}
+
+// seedFeed returns the local feed seeded posts are saved into, creating it if needed
+func seedFeed(ctx context.Context, feedRepo *bsky.FeedRepository) (*bsky.FeedModel, error) {
+	models, err := feedRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feeds: %w", err)
+	}
+	for _, model := range models {
+		if feed, ok := model.(*bsky.FeedModel); ok && feed.IsLocal && feed.Source == seed.FeedSource {
+			return feed, nil
+		}
+	}
+
+	feed := &bsky.FeedModel{Name: "Seed data", Source: seed.FeedSource, IsLocal: true}
+	if err := feedRepo.Save(ctx, feed); err != nil {
+		return nil, fmt.Errorf("failed to create seed feed: %w", err)
+	}
+	return feed, nil
+}
+
+// benchReport is the JSON document `dev bench` emits; compare reports from different versions run on the same machine
+type benchReport struct {
+	Version   string        `json:"version"`
+	GoVersion string        `json:"goVersion"`
+	OS        string        `json:"os"`
+	Arch      string        `json:"arch"`
+	CPUs      int           `json:"cpus"`
+	StartedAt time.Time     `json:"startedAt"`
+	Results   []benchResult `json:"results"`
+}
+
+// benchResult summarizes the timed iterations of one benchmark
+type benchResult struct {
+	Name        string  `json:"name"`
+	Size        int     `json:"size"`
+	Iterations  int     `json:"iterations"`
+	MinNs       int64   `json:"minNs"`
+	MedianNs    int64   `json:"medianNs"`
+	MaxNs       int64   `json:"maxNs"`
+	ItemsPerSec float64 `json:"itemsPerSec"` // size divided by the median duration
+}
+
+// benchCase is a benchmark over size items. setup runs untimed before every iteration; run is timed.
+type benchCase struct {
+	name  string
+	size  int
+	setup func(ctx context.Context, iteration int) error
+	run   func(ctx context.Context) error
+}
+
+// DevBenchAction runs the standard local benchmarks against a throwaway database and prints the results as JSON
+func DevBenchAction(ctx context.Context, cmd *cli.Command) error {
+	posts, entries, rows := int(cmd.Int("posts")), int(cmd.Int("entries")), int(cmd.Int("rows"))
+	iterations := int(cmd.Int("iterations"))
+	if posts < 1 || entries < 1 || rows < 1 || iterations < 1 {
+		return fmt.Errorf("--posts, --entries, --rows, and --iterations must be at least 1")
+	}
+
+	dir, err := os.MkdirTemp("", "skycli-bench-*")
+	if err != nil {
+		return fmt.Errorf("failed to create benchmark directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	data, err := seed.Generate(seed.Options{
+		Followers: max(entries, rows),
+		Posts:     posts,
+		Snapshots: 2,
+		Seed:      uint64(cmd.Int("seed")),
+		Now:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		return err
+	}
+
+	cases, cleanup, err := benchCases(dir, data, entries, rows)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	report := benchReport{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+		StartedAt: time.Now().UTC(),
+	}
+	for _, c := range cases {
+		logger.Debug("Running benchmark", "name", c.name, "size", c.size, "iterations", iterations)
+		result, err := runBench(ctx, c, iterations)
+		if err != nil {
+			return fmt.Errorf("benchmark %s failed: %w", c.name, err)
+		}
+		report.Results = append(report.Results, result)
+	}
+	encoder := json.NewEncoder(cmd.Root().Writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// benchCases prepares the benchmarks over the generated data. Each batch-save iteration writes into a fresh database
+// file under dir so it measures inserts rather than upserts. cleanup closes the repositories and is always safe to call.
+func benchCases(dir string, data *seed.Data, entries, rows int) ([]benchCase, func(), error) {
+	var closers []func() error
+	cleanup := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+
+	var postRepo *bsky.PostRepository
+	saveSetup := func(ctx context.Context, iteration int) error {
+		if postRepo != nil {
+			postRepo.Close()
+		}
+		repo, err := bsky.NewSQLitePostRepository(filepath.Join(dir, fmt.Sprintf("posts-%d.db", iteration)))
+		if err != nil {
+			return err
+		}
+		postRepo = repo
+		return postRepo.Init(ctx)
+	}
+	closers = append(closers, func() error {
+		if postRepo == nil {
+			return nil
+		}
+		return postRepo.Close()
+	})
+	for _, post := range data.Posts {
+		post.FeedID = "bench"
+	}
+
+	snapshotRepo, err := bsky.NewSQLiteSnapshotRepository(filepath.Join(dir, "snapshots.db"))
+	if err != nil {
+		return nil, cleanup, err
+	}
+	closers = append(closers, snapshotRepo.Close)
+	if err := snapshotRepo.Init(context.Background()); err != nil {
+		return nil, cleanup, err
+	}
+	var snapshotIDs []string
+	for _, snapshot := range data.Snapshots {
+		model, kept := snapshot.Model, snapshot.Entries[:min(entries, len(snapshot.Entries))]
+		model.TotalCount = len(kept)
+		if err := snapshotRepo.Import(context.Background(), model, kept); err != nil {
+			return nil, cleanup, err
+		}
+		snapshotIDs = append(snapshotIDs, model.ID())
+	}
+
+	followers := make([]followerInfo, rows)
+	for i := range followers {
+		followers[i] = followerInfo{Profile: &data.Profiles[i]}
+	}
+
+	cases := []benchCase{
+		{
+			name:  "post_batch_save",
+			size:  len(data.Posts),
+			setup: saveSetup,
+			run: func(ctx context.Context) error {
+				return postRepo.BatchSave(ctx, data.Posts)
+			},
+		},
+		{
+			name: "snapshot_diff",
+			size: entries,
+			run: func(ctx context.Context) error {
+				baseline, err := snapshotRepo.GetActorDids(ctx, snapshotIDs[0])
+				if err != nil {
+					return err
+				}
+				comparison, err := snapshotRepo.GetActorDids(ctx, snapshotIDs[1])
+				if err != nil {
+					return err
+				}
+				followerDiff(baseline, comparison)
+				return nil
+			},
+		},
+		{
+			name: "table_render",
+			size: rows,
+			run: func(ctx context.Context) error {
+				renderFollowersTable(followers, false)
+				return nil
+			},
+		},
+	}
+	return cases, cleanup, nil
+}
+
+// runBench times iterations of a benchmark case
+func runBench(ctx context.Context, c benchCase, iterations int) (benchResult, error) {
+	durations := make([]time.Duration, iterations)
+	for i := range durations {
+		if c.setup != nil {
+			if err := c.setup(ctx, i); err != nil {
+				return benchResult{}, err
+			}
+		}
+		start := time.Now()
+		if err := c.run(ctx); err != nil {
+			return benchResult{}, err
+		}
+		durations[i] = time.Since(start)
+	}
+
+	slices.Sort(durations)
+	median := durations[len(durations)/2]
+	result := benchResult{
+		Name:       c.name,
+		Size:       c.size,
+		Iterations: iterations,
+		MinNs:      durations[0].Nanoseconds(),
+		MedianNs:   median.Nanoseconds(),
+		MaxNs:      durations[len(durations)-1].Nanoseconds(),
+	}
+	if median > 0 {
+		result.ItemsPerSec = float64(c.size) / median.Seconds()
+	}
+	return result, nil
+}
+
+// DevCommand returns the dev command with tools for working on skycli itself
+func DevCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "dev",
+		Usage: "Tools for developing and testing skycli",
+		Commands: []*cli.Command{
+			{
+				Name:      "seed",
+				Usage:     "Fill the local store with synthetic followers, posts, and snapshots",
+				UsageText: "Generates accounts, posts, weekly follower snapshots with churn, and matching cache entries so rendering, pagination, and analytics can be exercised without a real account. The same --seed regenerates the same data, so rerunning updates rows instead of duplicating them.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "followers",
+						Usage: "Followers in the latest snapshot",
+						Value: 5000,
+					},
+					&cli.IntFlag{
+						Name:  "posts",
+						Usage: "Posts authored by the followers",
+						Value: 20000,
+					},
+					&cli.IntFlag{
+						Name:  "snapshots",
+						Usage: "Weekly follower snapshots ending today",
+						Value: 4,
+					},
+					&cli.StringFlag{
+						Name:  "user",
+						Usage: "DID the snapshots belong to (defaults to a synthetic account)",
+						Value: seed.UserDID,
+					},
+					&cli.IntFlag{
+						Name:  "seed",
+						Usage: "Random seed for reproducible data",
+						Value: 1,
+					},
+				},
+				Action: withRegistry(DevSeedAction),
+			},
+			{
+				Name:      "bench",
+				Usage:     "Run standard local benchmarks and print the results as JSON",
+				UsageText: "Times batch post saves, a follower snapshot diff, and a followers table render against a throwaway database, so nothing in your store is touched. Results include the version and platform; save them and compare across releases on the same machine.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "posts",
+						Usage: "Posts written per batch save",
+						Value: 10000,
+					},
+					&cli.IntFlag{
+						Name:  "entries",
+						Usage: "Followers in each diffed snapshot",
+						Value: 10000,
+					},
+					&cli.IntFlag{
+						Name:  "rows",
+						Usage: "Rows in the rendered table",
+						Value: 1000,
+					},
+					&cli.IntFlag{
+						Name:  "iterations",
+						Usage: "Timed runs per benchmark; the median is reported",
+						Value: 5,
+					},
+					&cli.IntFlag{
+						Name:  "seed",
+						Usage: "Random seed for the generated data",
+						Value: 1,
+					},
+				},
+				Action: DevBenchAction,
+			},
+		},
+	}
+}
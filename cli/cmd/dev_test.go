@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/seed"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+func TestDevSeedAction(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	profileRepo, err := bsky.NewProfileRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { profileRepo.Close() })
+	snapshotRepo, err := bsky.NewSnapshotRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { snapshotRepo.Close() })
+	cacheRepo, err := bsky.NewCacheRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cacheRepo.Close() })
+	for _, repo := range []interface{ Init(context.Context) error }{postRepo, profileRepo, snapshotRepo, cacheRepo} {
+		if err := repo.Init(ctx); err != nil {
+			t.Fatalf("Init failed: %v", err)
+		}
+	}
+
+	reg := registry.New(registry.Dependencies{
+		FeedRepo:     feedRepo,
+		PostRepo:     postRepo,
+		ProfileRepo:  profileRepo,
+		SnapshotRepo: snapshotRepo,
+		CacheRepo:    cacheRepo,
+	})
+
+	for range 2 {
+		if _, err := runSubcommand(t, DevCommand(), "seed", DevSeedAction, reg, "--followers", "50", "--posts", "120", "--snapshots", "3"); err != nil {
+			t.Fatalf("seed failed: %v", err)
+		}
+	}
+
+	feeds, err := sourceFeedIDs(ctx, reg, seed.FeedSource)
+	if err != nil || len(feeds) != 1 {
+		t.Fatalf("expected one seed feed after two runs, got %v (%v)", feeds, err)
+	}
+	if count, err := postRepo.CountByFeedID(ctx, feeds[0]); err != nil || count != 120 {
+		t.Errorf("expected 120 posts after two runs, got %d (%v)", count, err)
+	}
+
+	snapshots, err := snapshotRepo.List(ctx)
+	if err != nil || len(snapshots) != 3 {
+		t.Fatalf("expected 3 snapshots after two runs, got %d (%v)", len(snapshots), err)
+	}
+	latest, err := snapshotRepo.FindByUserAndType(ctx, seed.UserDID, "followers")
+	if err != nil || latest == nil || latest.TotalCount != 50 {
+		t.Errorf("expected the latest snapshot to hold 50 followers, got %+v (%v)", latest, err)
+	}
+
+	if profile, err := profileRepo.GetByDid(ctx, seed.DID(0)); err != nil || profile == nil {
+		t.Errorf("expected seeded profiles to be saved (%v)", err)
+	}
+	usage, err := cacheRepo.Usage(ctx)
+	if err != nil || usage.Activities != 50 || usage.PostRates != 50 {
+		t.Errorf("expected cache entries for 50 followers, got %+v (%v)", usage, err)
+	}
+}
+
+func TestDevBenchAction(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	defer cleanup()
+	bench := func(ctx context.Context, cmd *cli.Command, _ *registry.Registry) error {
+		return DevBenchAction(ctx, cmd)
+	}
+
+	out, err := runSubcommand(t, DevCommand(), "bench", bench, nil, "--posts", "50", "--entries", "40", "--rows", "20", "--iterations", "2")
+	if err != nil {
+		t.Fatalf("bench failed: %v", err)
+	}
+
+	var report benchReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("expected a JSON report, got %q: %v", out, err)
+	}
+	if report.Version != version || report.GoVersion == "" {
+		t.Errorf("expected version details, got %+v", report)
+	}
+	sizes := map[string]int{"post_batch_save": 50, "snapshot_diff": 40, "table_render": 20}
+	if len(report.Results) != len(sizes) {
+		t.Fatalf("expected %d results, got %+v", len(sizes), report.Results)
+	}
+	for _, result := range report.Results {
+		if result.Size != sizes[result.Name] || result.Iterations != 2 {
+			t.Errorf("unexpected result %+v", result)
+		}
+		if result.MinNs <= 0 || result.MinNs > result.MedianNs || result.MedianNs > result.MaxNs {
+			t.Errorf("expected ordered positive timings, got %+v", result)
+		}
+	}
+
+	if _, err := runSubcommand(t, DevCommand(), "bench", bench, nil, "--iterations", "0"); err == nil {
+		t.Error("expected zero iterations to fail")
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// diffReviewActions is the prompt shown for each account in an interactive diff review
+const diffReviewActions = "[v]iew profile, [n]ote, [t]ag, [b]lock, Enter for next, [q]uit:"
+
+// diffReview counts what an interactive diff review did
+type diffReview struct {
+	Reviewed int
+	Noted    int
+	Tagged   int
+	Blocked  int
+}
+
+// reviewDiff steps through new followers and then unfollows, offering to view, note, tag, or block each account.
+// It stops early when the user quits or input closes.
+func reviewDiff(ctx context.Context, reg *registry.Registry, prompter *ui.Prompter, newFollowers, unfollows []string) (diffReview, error) {
+	var review diffReview
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return review, fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+	profileRepo, err := reg.GetProfileRepo()
+	if err != nil {
+		logger.Debug("Profile cache unavailable", "error", err)
+	}
+
+	type change struct {
+		did  string
+		sign string
+		kind string
+	}
+	changes := make([]change, 0, len(newFollowers)+len(unfollows))
+	for _, did := range newFollowers {
+		changes = append(changes, change{did, "+", "new follower"})
+	}
+	for _, did := range unfollows {
+		changes = append(changes, change{did, "-", "unfollowed"})
+	}
+
+	for i, item := range changes {
+		profile, err := cachedProfile(ctx, profileRepo, profiles, item.did, false)
+		if err != nil {
+			logger.Debug("Failed to load profile", "did", item.did, "error", err)
+			profile = nil
+		}
+
+		fmt.Println()
+		ui.Subtitleln("[%d/%d] %s %s (%s)", i+1, len(changes), item.sign, reviewName(profile, item.did), item.kind)
+		if profile != nil && profile.DisplayName != "" {
+			ui.Infoln("  %s", profile.DisplayName)
+		}
+		review.Reviewed++
+
+		for next := false; !next; {
+			answer, err := prompter.Ask(diffReviewActions)
+			if errors.Is(err, io.EOF) {
+				return review, nil
+			}
+			if err != nil {
+				return review, err
+			}
+
+			switch strings.ToLower(answer) {
+			case "", "next":
+				next = true
+			case "q", "quit":
+				return review, nil
+			case "v", "view":
+				if profile == nil {
+					ui.Infoln("Profile unavailable; the account may be deleted or deactivated.")
+					continue
+				}
+				ui.DisplayProfileHeader(profile)
+				displayProfileNote(ctx, reg, item.did)
+			case "n", "note":
+				if done, err := reviewNote(ctx, reg, prompter, item.did, profile); err != nil {
+					return review, err
+				} else if done {
+					review.Noted++
+				}
+			case "t", "tag":
+				if done, err := reviewTag(ctx, reg, prompter, item.did); err != nil {
+					return review, err
+				} else if done {
+					review.Tagged++
+				}
+			case "b", "block":
+				if done, err := reviewBlock(ctx, reg, prompter, item.did, reviewName(profile, item.did)); err != nil {
+					return review, err
+				} else if done {
+					review.Blocked++
+					next = true
+				}
+			default:
+				ui.Infoln("Unknown choice %q", answer)
+			}
+		}
+	}
+	return review, nil
+}
+
+// reviewName labels an account by handle, or by DID when its profile couldn't be loaded
+func reviewName(profile *bsky.ActorProfile, did string) string {
+	if profile == nil || profile.Handle == "" {
+		return did
+	}
+	return "@" + profile.Handle
+}
+
+// reviewNote asks for a line and adds it to the account's note
+func reviewNote(ctx context.Context, reg *registry.Registry, prompter *ui.Prompter, did string, profile *bsky.ActorProfile) (bool, error) {
+	noteRepo, err := reg.GetNoteRepo()
+	if err != nil {
+		return false, fmt.Errorf("failed to get note repository: %w", err)
+	}
+
+	text, err := prompter.Ask("Add to note (empty to cancel):")
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	if text == "" {
+		return false, nil
+	}
+
+	handle := ""
+	if profile != nil {
+		handle = profile.Handle
+	}
+	if err := noteRepo.Append(ctx, did, handle, text); err != nil {
+		return false, fmt.Errorf("failed to save note: %w", err)
+	}
+	ui.Successln("Saved note")
+	return true, nil
+}
+
+// reviewTag asks for tags and adds each to the account
+func reviewTag(ctx context.Context, reg *registry.Registry, prompter *ui.Prompter, did string) (bool, error) {
+	tagRepo, err := reg.GetTagRepo()
+	if err != nil {
+		return false, fmt.Errorf("failed to get tag repository: %w", err)
+	}
+
+	answer, err := prompter.Ask("Tags, separated by spaces (empty to cancel):")
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+
+	var tags []string
+	for _, field := range strings.Fields(answer) {
+		tag, err := bsky.NormalizeTag(field)
+		if err != nil {
+			ui.Infoln("Skipping %q: %v", field, err)
+			continue
+		}
+		if _, err := tagRepo.Add(ctx, tag, bsky.TagActor, did); err != nil {
+			return false, fmt.Errorf("failed to tag account: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		return false, nil
+	}
+	ui.Successln("Tagged %s", strings.Join(tags, ", "))
+	return true, nil
+}
+
+// reviewBlock blocks the account after confirmation, recording the block in the undo log
+func reviewBlock(ctx context.Context, reg *registry.Registry, prompter *ui.Prompter, did, name string) (bool, error) {
+	writer, err := reg.GetGraphWriter()
+	if err != nil {
+		return false, fmt.Errorf("failed to get graph writer: %w", err)
+	}
+	actions, err := reg.GetActionRepo()
+	if err != nil {
+		return false, fmt.Errorf("failed to get action log: %w", err)
+	}
+
+	if ok, err := prompter.Confirm(fmt.Sprintf("Block %s?", name)); err != nil || !ok {
+		return false, err
+	}
+	record, err := writer.Block(ctx, did)
+	if err != nil {
+		logger.Warn("Failed to block", "did", did, "error", err)
+		return false, nil
+	}
+	recordAction(ctx, actions, bsky.ActionBlock, did, record.Uri)
+	ui.Successln("Blocked %s; run 'skycli undo' to revert", name)
+	return true, nil
+}
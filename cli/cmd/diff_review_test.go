@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestReviewDiff(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	noteRepo, err := bsky.NewNoteRepository()
+	if err != nil {
+		t.Fatalf("NewNoteRepository failed: %v", err)
+	}
+	t.Cleanup(func() { noteRepo.Close() })
+	if err := noteRepo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	tagRepo, err := bsky.NewTagRepository()
+	if err != nil {
+		t.Fatalf("NewTagRepository failed: %v", err)
+	}
+	t.Cleanup(func() { tagRepo.Close() })
+	actions, err := bsky.NewActionRepository()
+	if err != nil {
+		t.Fatalf("NewActionRepository failed: %v", err)
+	}
+	t.Cleanup(func() { actions.Close() })
+	if err := actions.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me"}
+	reg := registry.New(registry.Dependencies{
+		FollowerFetcher: graph,
+		ProfileFetcher:  graph,
+		GraphWriter:     graph,
+		ActionRepo:      actions,
+		NoteRepo:        noteRepo,
+		TagRepo:         tagRepo,
+	})
+
+	answers := strings.Join([]string{
+		"n", "met at the meetup", "t", "#Local vip", "", // new follower: note, tag, next
+		"x", "b", "n", "b", "y", // unfollow: unknown choice, declined block, then block (moves on)
+		"q", // quit before the last account
+	}, "\n") + "\n"
+	prompter, err := ui.NewPrompter(ui.ConfirmOptions{In: strings.NewReader(answers), Out: &bytes.Buffer{}})
+	if err != nil {
+		t.Fatalf("NewPrompter failed: %v", err)
+	}
+
+	review, err := reviewDiff(ctx, reg, prompter, []string{"did:plc:new"}, []string{"did:plc:gone", "did:plc:skipped"})
+	if err != nil {
+		t.Fatalf("reviewDiff failed: %v", err)
+	}
+	if want := (diffReview{Reviewed: 3, Noted: 1, Tagged: 1, Blocked: 1}); review != want {
+		t.Errorf("review = %+v, want %+v", review, want)
+	}
+
+	note, err := noteRepo.Get(ctx, "did:plc:new")
+	if err != nil || note.Note != "met at the meetup" {
+		t.Errorf("note = %+v, %v", note, err)
+	}
+	if tags, err := tagRepo.TagsOf(ctx, bsky.TagActor, "did:plc:new"); err != nil || !slices.Equal(tags, []string{"local", "vip"}) {
+		t.Errorf("tags = %v, %v; want [local vip]", tags, err)
+	}
+	if !slices.Equal(graph.blocked, []string{"did:plc:gone"}) {
+		t.Errorf("blocked %v, want only did:plc:gone", graph.blocked)
+	}
+
+	logged, err := actions.Recent(ctx, 0, false)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(logged) != 1 || logged[0].Action != bsky.ActionBlock || logged[0].SubjectDid != "did:plc:gone" {
+		t.Errorf("unexpected undo log %+v", logged)
+	}
+}
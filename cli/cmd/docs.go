@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/packaging"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// DocsCommand returns the docs command with subcommands generating material for distributing skycli
+func DocsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "docs",
+		Usage: "Generate distribution metadata for skycli",
+		Commands: []*cli.Command{
+			{
+				Name:  "packaging",
+				Usage: "Render a Homebrew formula and Scoop manifest for a release",
+				UsageText: `Renders skycli.rb (Homebrew) and skycli.json (Scoop) from this build's version and description.
+   Both download archives named skycli_<version>_<os>_<arch>, .tar.gz on macOS and Linux and
+   .zip on Windows. Pass the release's sha256sum output with --checksums to fill in hashes;
+   archives missing from it get a placeholder.`,
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"f"},
+						Usage:   "What to render: homebrew, scoop, or all",
+						Value:   "all",
+					},
+					&cli.StringFlag{
+						Name:  "version",
+						Usage: "Release version (defaults to this build's version)",
+					},
+					&cli.StringFlag{
+						Name:    "checksums",
+						Aliases: []string{"c"},
+						Usage:   "sha256sum output listing the release archives",
+					},
+					&cli.StringFlag{
+						Name:  "base-url",
+						Usage: "URL of the directory holding the archives, with " + packaging.VersionPlaceholder + " for the version",
+						Value: packaging.DefaultBaseURL,
+					},
+					&cli.StringFlag{
+						Name:    "out",
+						Aliases: []string{"o"},
+						Usage:   "Directory to write into, or - for stdout with a single --format",
+						Value:   ".",
+					},
+				},
+				Before: quietWhenPiped,
+				Action: DocsPackagingAction,
+			},
+		},
+	}
+}
+
+// DocsPackagingAction renders package manager metadata for the release described by the root command and flags
+func DocsPackagingAction(ctx context.Context, cmd *cli.Command) error {
+	format := strings.ToLower(cmd.String("format"))
+	if format != "all" && format != "homebrew" && format != "scoop" {
+		return fmt.Errorf("invalid format: %s (must be homebrew, scoop, or all)", format)
+	}
+	out := cmd.String("out")
+	if out == "-" && format == "all" {
+		return fmt.Errorf("--out - needs --format homebrew or scoop")
+	}
+
+	release, err := packagingRelease(cmd)
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{}
+	var platforms []string
+	if format == "all" || format == "homebrew" {
+		platforms = append(platforms, "darwin", "linux")
+		formula, err := packaging.Homebrew(release)
+		if err != nil {
+			return err
+		}
+		files[release.Name+".rb"] = []byte(formula)
+	}
+	if format == "all" || format == "scoop" {
+		platforms = append(platforms, "windows")
+		manifest, err := packaging.Scoop(release)
+		if err != nil {
+			return err
+		}
+		files[release.Name+".json"] = manifest
+	}
+
+	if out == "-" {
+		for _, data := range files {
+			if _, err := cmd.Root().Writer.Write(data); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := os.MkdirAll(out, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		for _, name := range []string{release.Name + ".rb", release.Name + ".json"} {
+			data, ok := files[name]
+			if !ok {
+				continue
+			}
+			path := filepath.Join(out, name)
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", name, err)
+			}
+			ui.Successln("Wrote %s", path)
+		}
+	}
+
+	if missing := release.Missing(platforms...); len(missing) > 0 {
+		if cmd.String("checksums") == "" {
+			ui.Warningln("No --checksums given; replace %s before publishing", packaging.MissingChecksum)
+		} else {
+			ui.Warningln("No checksum for %s; replace %s before publishing", strings.Join(missing, ", "), packaging.MissingChecksum)
+		}
+	}
+	return nil
+}
+
+// packagingRelease describes the release to package from the root command's name, usage, and version, and the
+// packaging flags
+func packagingRelease(cmd *cli.Command) (*packaging.Release, error) {
+	root := cmd.Root()
+	release := &packaging.Release{
+		Name:        root.Name,
+		Description: root.Usage,
+		Homepage:    "https://stormlightlabs.github.io/skypanel/",
+		Repository:  "https://github.com/stormlightlabs/skypanel",
+		License:     "MIT",
+		Version:     strings.TrimPrefix(root.Version, "v"),
+		BaseURL:     cmd.String("base-url"),
+	}
+	if v := cmd.String("version"); v != "" {
+		release.Version = strings.TrimPrefix(v, "v")
+	}
+
+	if path := cmd.String("checksums"); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --checksums: %w", err)
+		}
+		defer file.Close()
+		if release.Checksums, err = packaging.ParseChecksums(file); err != nil {
+			return nil, fmt.Errorf("failed to read --checksums: %w", err)
+		}
+	}
+	return release, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// commandError is the JSON form of a failed command, written to stderr with --error-format json
+type commandError struct {
+	Error    string `json:"error"`
+	Code     string `json:"code"`
+	Entity   string `json:"entity,omitempty"`
+	ID       string `json:"id,omitempty"`
+	SQLState string `json:"sqlState,omitempty"`
+}
+
+// newCommandError describes err for machine output. Repository failures carry their code, the record involved,
+// and the SQLSTATE; anything else has the code "error".
+func newCommandError(err error) commandError {
+	out := commandError{Error: describeError(err), Code: "error"}
+	var repoErr *bsky.RepositoryError
+	if errors.As(err, &repoErr) {
+		out.Code = string(repoErr.Code())
+		out.Entity = repoErr.Entity
+		out.ID = repoErr.ID
+		out.SQLState = repoErr.SQLState()
+	}
+	return out
+}
+
+// describeError explains err for people, telling apart a record that is already archived or missing from a
+// database that is locked or damaged, which need different fixes
+func describeError(err error) string {
+	var repoErr *bsky.RepositoryError
+	if !errors.As(err, &repoErr) {
+		return err.Error()
+	}
+
+	subject := repoErr.Entity
+	if subject == "" {
+		subject = "record"
+	}
+	if repoErr.ID != "" {
+		subject += " " + repoErr.ID
+	}
+
+	switch repoErr.Code() {
+	case bsky.CodeAlreadyExists:
+		return fmt.Sprintf("%s already archived (%v)", subject, err)
+	case bsky.CodeConstraint:
+		return fmt.Sprintf("%s conflicts with archived data (%v)", subject, err)
+	case bsky.CodeBusy:
+		return fmt.Sprintf("the local database is locked by another skycli process; try again when it finishes (%v)", err)
+	case bsky.CodeCorrupt:
+		return fmt.Sprintf("the local database is corrupt; restore it with 'skycli archive pull', or move cache.db aside and run 'skycli setup' (%v)", err)
+	default:
+		return err.Error()
+	}
+}
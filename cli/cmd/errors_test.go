@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestDescribeError(t *testing.T) {
+	dup := fmt.Errorf("failed to save: %w", &bsky.RepositoryError{Op: "Save", Entity: "post", ID: "abc", Err: &pq.Error{Code: bsky.StateUniqueViolation}})
+	if msg := describeError(dup); !strings.HasPrefix(msg, "post abc already archived") {
+		t.Errorf("unexpected duplicate message %q", msg)
+	}
+
+	corrupt := &bsky.RepositoryError{Op: "List", Entity: "feed", Err: &pq.Error{Code: bsky.StateDataCorrupted}}
+	if msg := describeError(corrupt); !strings.HasPrefix(msg, "the local database is corrupt") {
+		t.Errorf("unexpected corrupt message %q", msg)
+	}
+
+	out := newCommandError(dup)
+	if out.Code != string(bsky.CodeAlreadyExists) || out.Entity != "post" || out.ID != "abc" || out.SQLState != bsky.StateUniqueViolation {
+		t.Errorf("unexpected command error %+v", out)
+	}
+
+	plain := newCommandError(errors.New("feed ID required"))
+	if plain.Code != "error" || plain.Error != "feed ID required" || plain.Entity != "" {
+		t.Errorf("unexpected command error %+v", plain)
+	}
+}
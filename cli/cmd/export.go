@@ -2,25 +2,29 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
 	"github.com/stormlightlabs/skypanel/cli/internal/export"
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
-	"github.com/stormlightlabs/skypanel/cli/internal/setup"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
 // ExportFeedAction exports posts from a feed to file
-func ExportFeedAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
+func ExportFeedAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Bool("schema") {
+		return printExportSchema(cmd, export.SchemaFeed)
 	}
 
-	reg := registry.Get()
-
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("feed ID required")
 	}
@@ -33,6 +37,11 @@ func ExportFeedAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("invalid format: %s (must be json, csv, or txt)", format)
 	}
 
+	enc, err := encryptOptionsFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
 	feedRepo, err := reg.GetFeedRepo()
 	if err != nil {
 		return fmt.Errorf("failed to get feed repository: %w", err)
@@ -61,15 +70,17 @@ func ExportFeedAction(ctx context.Context, cmd *cli.Command) error {
 
 	filename := fmt.Sprintf("feed_%s_%s.%s", feedID, time.Now().Format("2006-01-02"), format)
 
-	switch format {
-	case "json":
-		err = export.ToJSON(filename, posts)
-	case "csv":
-		err = export.ToCSV(filename, posts)
-	case "txt":
-		err = export.ToTXT(filename, posts)
-	}
-
+	filename, err = writeExport(cmd, filename, enc, func(w io.Writer) error {
+		w = lineEndings(cmd, format, w)
+		switch format {
+		case "csv":
+			return export.WriteCSV(w, posts)
+		case "txt":
+			return export.WriteTXT(w, posts)
+		default:
+			return export.WriteJSON(w, posts)
+		}
+	})
 	if err != nil {
 		logger.Error("Failed to export", "error", err)
 		return err
@@ -80,24 +91,35 @@ func ExportFeedAction(ctx context.Context, cmd *cli.Command) error {
 }
 
 // ExportProfileAction exports an actor profile to file
-func ExportProfileAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
+func ExportProfileAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Bool("schema") {
+		return printExportSchema(cmd, export.SchemaProfile)
 	}
 
-	reg := registry.Get()
-
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("actor handle or DID required")
 	}
 
-	actor := cmd.Args().First()
+	actor, err := parseActorArg("actor", cmd.Args().First())
+	if err != nil {
+		return err
+	}
 	format := strings.ToLower(cmd.String("format"))
 
 	if format != "json" && format != "txt" {
 		return fmt.Errorf("invalid format for profile: %s (must be json or txt)", format)
 	}
 
+	enc, err := encryptOptionsFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	redactor, err := redactorFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
 	service, err := reg.GetService()
 	if err != nil {
 		return fmt.Errorf("failed to get service: %w", err)
@@ -111,35 +133,40 @@ func ExportProfileAction(ctx context.Context, cmd *cli.Command) error {
 
 	profile, err := service.GetProfile(ctx, actor)
 	if err != nil {
-		return fmt.Errorf("failed to fetch profile: %w", err)
+		return profileError("actor", cmd.Args().First(), err)
 	}
 
-	filename := fmt.Sprintf("profile_%s_%s.%s", profile.Handle, time.Now().Format("2006-01-02"), format)
-
-	switch format {
-	case "json":
-		err = export.ProfileToJSON(filename, profile)
-	case "txt":
-		err = export.ProfileToTXT(filename, profile)
+	handle := profile.Handle
+	filenameHandle := handle
+	if redactor.Redacts("handle") {
+		filenameHandle = "redacted"
 	}
+	profile = redactor.Profile(profile)
+
+	filename := fmt.Sprintf("profile_%s_%s.%s", filenameHandle, time.Now().Format("2006-01-02"), format)
 
+	filename, err = writeExport(cmd, filename, enc, func(w io.Writer) error {
+		w = lineEndings(cmd, format, w)
+		if format == "txt" {
+			return export.WriteProfileTXT(w, profile)
+		}
+		return export.WriteProfileJSON(w, profile)
+	})
 	if err != nil {
 		logger.Error("Failed to export", "error", err)
 		return err
 	}
 
-	ui.Successln("Exported profile @%s to %s", profile.Handle, filename)
+	ui.Successln("Exported profile @%s to %s", handle, filename)
 	return nil
 }
 
 // ExportPostAction exports a single post to file
-func ExportPostAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
+func ExportPostAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Bool("schema") {
+		return printExportSchema(cmd, export.SchemaPost)
 	}
 
-	reg := registry.Get()
-
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("post URI or URL required")
 	}
@@ -151,6 +178,11 @@ func ExportPostAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("invalid format for post: %s (must be json or txt)", format)
 	}
 
+	enc, err := encryptOptionsFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
 	service, err := reg.GetService()
 	if err != nil {
 		return fmt.Errorf("failed to get service: %w", err)
@@ -160,10 +192,11 @@ func ExportPostAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("not authenticated: run 'skycli login' first")
 	}
 
-	postURI, err := parsePostURI(postIdentifier)
+	ref, err := aturi.ParsePost(postIdentifier)
 	if err != nil {
-		return fmt.Errorf("failed to parse post identifier: %w", err)
+		return err
 	}
+	postURI := ref.String()
 
 	logger.Debug("Fetching post for export", "uri", postURI)
 
@@ -178,29 +211,153 @@ func ExportPostAction(ctx context.Context, cmd *cli.Command) error {
 
 	post := &response.Posts[0]
 
-	filename := fmt.Sprintf("post_%s_%s.%s", extractRkey(postURI), time.Now().Format("2006-01-02"), format)
+	filename := fmt.Sprintf("post_%s_%s.%s", ref.Rkey, time.Now().Format("2006-01-02"), format)
+
+	filename, err = writeExport(cmd, filename, enc, func(w io.Writer) error {
+		w = lineEndings(cmd, format, w)
+		if format == "txt" {
+			return export.WriteFeedViewPostTXT(w, post)
+		}
+		return export.WriteFeedViewPostJSON(w, post)
+	})
+	if err != nil {
+		logger.Error("Failed to export", "error", err)
+		return err
+	}
+
+	ui.Successln("Exported post to %s", filename)
+	return nil
+}
+
+// getPostsBatch is the most URIs app.bsky.feed.getPosts accepts per call
+const getPostsBatch = 25
+
+// ExportLikesAction exports the posts the user liked, with when each like was made
+func ExportLikesAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	return exportRecordedPosts(ctx, cmd, reg, "app.bsky.feed.like", export.SchemaLikes, "liked")
+}
+
+// ExportRepostsAction exports the posts the user reposted, with when each repost was made
+func ExportRepostsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	return exportRecordedPosts(ctx, cmd, reg, "app.bsky.feed.repost", export.SchemaReposts, "reposted")
+}
+
+// exportRecordedPosts lists the user's like or repost records in collection, loads the posts they point at, and
+// writes both out. Records are read from the repository rather than the AppView, so their timestamps are the
+// user's own and posts that have since been deleted still appear.
+func exportRecordedPosts(ctx context.Context, cmd *cli.Command, reg *registry.Registry, collection, kind, verb string) error {
+	if cmd.Bool("schema") {
+		return printExportSchema(cmd, kind)
+	}
+
+	format := strings.ToLower(cmd.String("format"))
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("invalid format for %s: %s (must be json or csv)", kind, format)
+	}
+
+	enc, err := encryptOptionsFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	fetcher, err := reg.GetRecordFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get record fetcher: %w", err)
+	}
 
-	switch format {
-	case "json":
-		err = export.FeedViewPostToJSON(filename, post)
-	case "txt":
-		err = export.FeedViewPostToTXT(filename, post)
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
 	}
 
+	logger.Debug("Listing records for export", "collection", collection)
+
+	paginator := bsky.NewPaginator(bsky.RecordPages(fetcher, fetcher.GetDid(), collection), bsky.PaginatorOptions{MaxItems: cmd.Int("limit")})
+	records, err := paginator.All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+
+	posts, err := recordedPosts(ctx, fetcher, records)
+	if err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("%s_%s.%s", kind, time.Now().Format("2006-01-02"), format)
+
+	filename, err = writeExport(cmd, filename, enc, func(w io.Writer) error {
+		w = lineEndings(cmd, format, w)
+		if format == "csv" {
+			return export.WriteRecordedPostsCSV(w, posts)
+		}
+		return export.WriteRecordedPostsJSON(w, collection, posts)
+	})
 	if err != nil {
 		logger.Error("Failed to export", "error", err)
 		return err
 	}
 
-	ui.Successln("Exported post to %s", filename)
+	missing := 0
+	for _, post := range posts {
+		if post.Post == nil {
+			missing++
+		}
+	}
+	if missing > 0 {
+		ui.Warningln("%d %s post(s) are deleted or hidden; exported with their URI only", missing, verb)
+	}
+
+	ui.Successln("Exported %d %s post(s) to %s", len(posts), verb, filename)
 	return nil
 }
 
-// ExportCommand returns the export command with subcommands for feed, profile, and post
+// recordedPosts pairs like or repost records with the posts they point at, fetched in batches.
+// Records that aren't likes or reposts of a post are skipped.
+func recordedPosts(ctx context.Context, fetcher bsky.RecordFetcher, records []bsky.Record) ([]export.RecordedPost, error) {
+	posts := make([]export.RecordedPost, 0, len(records))
+	var uris []string
+	for _, record := range records {
+		var value struct {
+			Subject struct {
+				Uri string `json:"uri"`
+			} `json:"subject"`
+			CreatedAt string `json:"createdAt"`
+		}
+		if err := json.Unmarshal(record.Value, &value); err != nil || value.Subject.Uri == "" {
+			logger.Warn("Skipping record without a subject post", "uri", record.Uri)
+			continue
+		}
+
+		createdAt, _ := time.Parse(time.RFC3339, value.CreatedAt)
+		posts = append(posts, export.RecordedPost{RecordURI: record.Uri, CreatedAt: createdAt, SubjectURI: value.Subject.Uri})
+		if !slices.Contains(uris, value.Subject.Uri) {
+			uris = append(uris, value.Subject.Uri)
+		}
+	}
+
+	found := make(map[string]*bsky.PostView, len(uris))
+	for batch := range slices.Chunk(uris, getPostsBatch) {
+		response, err := fetcher.GetPosts(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch posts: %w", err)
+		}
+		for _, post := range response.Posts {
+			if post.Post != nil {
+				found[post.Post.Uri] = post.Post
+			}
+		}
+	}
+
+	for i := range posts {
+		posts[i].Post = found[posts[i].SubjectURI]
+	}
+	return posts, nil
+}
+
+// ExportCommand returns the export command with subcommands for feed, profile, post, likes, and reposts
 func ExportCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "export",
-		Usage: "Export feeds, profiles, or posts to file",
+		Usage: "Export feeds, profiles, posts, likes, or reposts to file",
 		Commands: []*cli.Command{
 			{
 				Name:      "feed",
@@ -219,8 +376,15 @@ func ExportCommand() *cli.Command {
 						Usage:   "Number of posts to export",
 						Value:   25,
 					},
+					encryptFlag(),
+					recipientFlag(),
+					outFlag(),
+					compressFlag(),
+					crlfFlag(),
+					schemaFlag(),
 				},
-				Action: ExportFeedAction,
+				Before: quietWhenPiped,
+				Action: withRegistry(ExportFeedAction),
 			},
 			{
 				Name:      "profile",
@@ -233,8 +397,17 @@ func ExportCommand() *cli.Command {
 						Usage:   "Export format: json or txt",
 						Value:   "json",
 					},
+					encryptFlag(),
+					recipientFlag(),
+					redactFlag(),
+					redactModeFlag(),
+					outFlag(),
+					compressFlag(),
+					crlfFlag(),
+					schemaFlag(),
 				},
-				Action: ExportProfileAction,
+				Before: quietWhenPiped,
+				Action: withRegistry(ExportProfileAction),
 			},
 			{
 				Name:      "post",
@@ -247,16 +420,37 @@ func ExportCommand() *cli.Command {
 						Usage:   "Export format: json or txt",
 						Value:   "json",
 					},
+					encryptFlag(),
+					recipientFlag(),
+					outFlag(),
+					compressFlag(),
+					crlfFlag(),
+					schemaFlag(),
 				},
-				Action: ExportPostAction,
+				Before: quietWhenPiped,
+				Action: withRegistry(ExportPostAction),
+			},
+			{
+				Name:   "likes",
+				Usage:  "Export the posts you liked, with when you liked them",
+				Flags:  recordedPostsFlags(),
+				Before: quietWhenPiped,
+				Action: withRegistry(ExportLikesAction),
+			},
+			{
+				Name:   "reposts",
+				Usage:  "Export the posts you reposted, with when you reposted them",
+				Flags:  recordedPostsFlags(),
+				Before: quietWhenPiped,
+				Action: withRegistry(ExportRepostsAction),
 			},
 		},
-		Action: func(ctx context.Context, cmd *cli.Command) error {
-			if cmd.Args().Len() == 0 {
-				return fmt.Errorf("please use: export feed|profile|post <identifier>")
+		Action: withRegistry(func(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+			if cmd.Args().Len() == 0 && !cmd.Bool("schema") {
+				return fmt.Errorf("please use: export feed|profile|post <identifier>, or export likes|reposts")
 			}
-			return ExportFeedAction(ctx, cmd)
-		},
+			return ExportFeedAction(ctx, cmd, reg)
+		}),
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "format",
@@ -270,35 +464,237 @@ func ExportCommand() *cli.Command {
 				Usage:   "Number of posts to export",
 				Value:   25,
 			},
+			encryptFlag(),
+			recipientFlag(),
+			outFlag(),
+			compressFlag(),
+			crlfFlag(),
+			schemaFlag(),
+		},
+		Before: quietWhenPiped,
+	}
+}
+
+// recordedPostsFlags returns the flags of the likes and reposts exports
+func recordedPostsFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "Export format: json or csv",
+			Value:   "json",
+		},
+		&cli.IntFlag{
+			Name:    "limit",
+			Aliases: []string{"l"},
+			Usage:   "Export only the N most recent (0 = all)",
 		},
+		encryptFlag(),
+		recipientFlag(),
+		outFlag(),
+		compressFlag(),
+		crlfFlag(),
+		schemaFlag(),
 	}
 }
 
-// parsePostURI converts a bsky.app URL or AT URI to an AT URI
-func parsePostURI(identifier string) (string, error) {
-	if strings.HasPrefix(identifier, "at://") {
-		return identifier, nil
+// schemaFlag prints the JSON Schema of the selected format instead of exporting
+func schemaFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "schema",
+		Usage: "Print the JSON Schema for --format and exit, for validating exports downstream",
 	}
+}
 
-	if strings.HasPrefix(identifier, "https://bsky.app/profile/") ||
-		strings.HasPrefix(identifier, "http://bsky.app/profile/") {
-		parts := strings.Split(identifier, "/")
-		if len(parts) < 7 || parts[5] != "post" {
-			return "", fmt.Errorf("invalid bsky.app URL format")
+// printExportSchema writes the JSON Schema of kind exports in the command's --format
+func printExportSchema(cmd *cli.Command, kind string) error {
+	schema, err := export.Schema(kind, strings.ToLower(cmd.String("format")))
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(cmd.Root().Writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(schema)
+}
+
+// exportPassphraseEnv names the environment variable holding the passphrase for encrypted exports
+const exportPassphraseEnv = "SKYCLI_EXPORT_PASSPHRASE"
+
+// encryptFlag enables age encryption of export output
+func encryptFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "encrypt",
+		Usage: "Encrypt output with age using --recipient keys or the $" + exportPassphraseEnv + " passphrase",
+	}
+}
+
+// recipientFlag collects age public keys for encrypted exports
+func recipientFlag() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:    "recipient",
+		Aliases: []string{"r"},
+		Usage:   "age public key (age1...) to encrypt to; may be repeated",
+	}
+}
+
+// encryptOptionsFromCmd returns encryption options when --encrypt is set, or nil for plaintext output
+func encryptOptionsFromCmd(cmd *cli.Command) (*export.EncryptOptions, error) {
+	if !cmd.Bool("encrypt") {
+		if len(cmd.StringSlice("recipient")) > 0 {
+			return nil, fmt.Errorf("--recipient requires --encrypt")
 		}
-		handle := parts[4]
-		rkey := parts[6]
-		return fmt.Sprintf("at://%s/app.bsky.feed.post/%s", handle, rkey), nil
+		return nil, nil
 	}
 
-	return "", fmt.Errorf("identifier must be an AT URI (at://...) or bsky.app URL")
+	opts := &export.EncryptOptions{
+		Recipients: cmd.StringSlice("recipient"),
+		Passphrase: os.Getenv(exportPassphraseEnv),
+	}
+	if len(opts.Recipients) == 0 && opts.Passphrase == "" {
+		return nil, fmt.Errorf("--encrypt requires --recipient or the %s environment variable", exportPassphraseEnv)
+	}
+	return opts, nil
 }
 
-// extractRkey extracts the record key from an AT URI
-func extractRkey(uri string) string {
-	parts := strings.Split(uri, "/")
-	if len(parts) > 0 {
-		return parts[len(parts)-1]
+// redactFlag selects profile fields to strip from shareable exports
+func redactFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "redact",
+		Usage: "Comma-separated fields to redact: " + strings.Join(export.RedactableFields(), ", "),
+	}
+}
+
+// redactModeFlag chooses whether redacted fields are omitted or hashed
+func redactModeFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "redact-mode",
+		Usage: "How to redact fields: omit or hash (salted per export, keeps rows distinguishable)",
+		Value: string(export.RedactOmit),
+	}
+}
+
+// redactorFromCmd builds a redactor from --redact/--redact-mode, or nil when nothing is redacted
+func redactorFromCmd(cmd *cli.Command) (*export.Redactor, error) {
+	return export.NewRedactor(cmd.String("redact"), export.RedactMode(strings.ToLower(cmd.String("redact-mode"))))
+}
+
+// outFlag chooses the export destination, or stdout with "-"
+func outFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "out",
+		Aliases: []string{"o"},
+		Usage:   "Output file, or - to write to stdout (defaults to a generated filename)",
+	}
+}
+
+// quietWhenPiped suppresses decorative output when stdout is piped or carries the export itself
+func quietWhenPiped(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	if cmd.String("out") == "-" || !ui.StdoutIsTerminal() {
+		ui.SetQuiet(true)
+	}
+	return ctx, nil
+}
+
+// compressFlag gzips export output
+func compressFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "compress",
+		Aliases: []string{"z"},
+		Usage:   "Gzip the output (adds " + export.CompressedExt + " to the filename)",
+	}
+}
+
+// crlfFlag ends CSV and TXT lines with CRLF, and is on by default on Windows
+func crlfFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "crlf",
+		Usage: "End CSV and TXT lines with CRLF for Windows tools (default on Windows; --crlf=false for LF)",
+		Value: runtime.GOOS == "windows",
+	}
+}
+
+// lineEndings wraps w to end lines with CRLF when --crlf is set and format is CSV or TXT; JSON and workbooks are
+// written as they are
+func lineEndings(cmd *cli.Command, format string, w io.Writer) io.Writer {
+	if cmd.Bool("crlf") && (format == "csv" || format == "txt") {
+		return export.NewCRLFWriter(w)
+	}
+	return w
+}
+
+// writeExport calls write with the export destination: the command's writer for --out -, otherwise the --out
+// path or filename. With --compress output is gzipped to path.gz; file output is encrypted to path.age when enc
+// is set, while stdout output is armored instead. Returns the path written, or "-" for stdout.
+func writeExport(cmd *cli.Command, filename string, enc *export.EncryptOptions, write func(w io.Writer) error) (string, error) {
+	out := cmd.String("out")
+	compress := cmd.Bool("compress")
+	if out == "-" {
+		if compress && enc == nil && ui.StdoutIsTerminal() {
+			return "", fmt.Errorf("refusing to write compressed output to a terminal; redirect stdout or drop --compress")
+		}
+		return out, streamExport(cmd.Root().Writer, enc, true, compress, write)
+	}
+	if out != "" {
+		filename = out
+	}
+	return writeExportFile(filename, enc, compress, write)
+}
+
+// writeExportFile calls write with a new file at filename, gzipped to filename.gz with compress and encrypted to
+// filename.age when enc is set. Returns the path written.
+func writeExportFile(filename string, enc *export.EncryptOptions, compress bool, write func(w io.Writer) error) (string, error) {
+	if compress && !strings.HasSuffix(filename, export.CompressedExt) && !strings.HasSuffix(filename, export.CompressedExt+export.EncryptedExt) {
+		filename += export.CompressedExt
+	}
+
+	flag, perm := os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(0644)
+	if enc != nil {
+		if !strings.HasSuffix(filename, export.EncryptedExt) {
+			filename += export.EncryptedExt
+		}
+		flag, perm = os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600
+	}
+
+	file, err := os.OpenFile(filename, flag, perm)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	if err := streamExport(file, enc, false, compress, write); err != nil {
+		file.Close()
+		os.Remove(filename)
+		return "", err
+	}
+	return filename, file.Close()
+}
+
+// streamExport calls write with dst, gzipping and then encrypting on the way when asked.
+// Each layer streams, so plaintext never touches disk and large exports never sit in memory.
+func streamExport(dst io.Writer, enc *export.EncryptOptions, armor, compress bool, write func(w io.Writer) error) error {
+	var layers []io.WriteCloser
+	w := dst
+	if enc != nil {
+		opts := *enc
+		opts.Armor = armor
+		encWriter, err := export.NewEncryptWriter(w, opts)
+		if err != nil {
+			return err
+		}
+		layers = append(layers, encWriter)
+		w = encWriter
+	}
+	if compress {
+		gzWriter := export.NewCompressWriter(w)
+		layers = append(layers, gzWriter)
+		w = gzWriter
+	}
+
+	err := write(w)
+	// Close innermost first so each layer flushes into the one below it
+	for i := len(layers) - 1; i >= 0; i-- {
+		if closeErr := layers[i].Close(); err == nil {
+			err = closeErr
+		}
 	}
-	return "unknown"
+	return err
 }
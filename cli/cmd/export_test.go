@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/export"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// runWriteExport runs writeExport inside a command parsed from args, returning what reached the command's writer
+func runWriteExport(t *testing.T, filename string, enc *export.EncryptOptions, args ...string) (string, string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	var written string
+	cmd := &cli.Command{
+		Name:   "export",
+		Writer: &out,
+		Flags:  []cli.Flag{outFlag(), compressFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			var err error
+			written, err = writeExport(cmd, filename, enc, func(w io.Writer) error {
+				_, err := io.WriteString(w, "payload\n")
+				return err
+			})
+			return err
+		},
+	}
+	err := cmd.Run(context.Background(), append([]string{"export"}, args...))
+	return written, out.String(), err
+}
+
+func TestWriteExport(t *testing.T) {
+	dir := t.TempDir()
+	generated := filepath.Join(dir, "feed.json")
+
+	t.Run("generated filename", func(t *testing.T) {
+		written, stdout, err := runWriteExport(t, generated, nil)
+		if err != nil || written != generated || stdout != "" {
+			t.Fatalf("expected %s, got %q (stdout %q, err %v)", generated, written, stdout, err)
+		}
+		if data, _ := os.ReadFile(generated); string(data) != "payload\n" {
+			t.Errorf("unexpected file contents %q", data)
+		}
+	})
+
+	t.Run("out path", func(t *testing.T) {
+		path := filepath.Join(dir, "custom.json")
+		written, _, err := runWriteExport(t, generated, nil, "--out", path)
+		if err != nil || written != path {
+			t.Fatalf("expected %s, got %q (err %v)", path, written, err)
+		}
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		written, stdout, err := runWriteExport(t, generated, nil, "--out", "-")
+		if err != nil || written != "-" || stdout != "payload\n" {
+			t.Fatalf("expected payload on stdout, got %q (written %q, err %v)", stdout, written, err)
+		}
+	})
+
+	t.Run("encrypted stdout is armored", func(t *testing.T) {
+		enc := &export.EncryptOptions{Passphrase: "correct horse battery staple"}
+		_, stdout, err := runWriteExport(t, generated, enc, "-o", "-")
+		if err != nil || !bytes.HasPrefix([]byte(stdout), []byte("-----BEGIN AGE ENCRYPTED FILE-----")) {
+			t.Fatalf("expected armored ciphertext, got %q (err %v)", stdout, err)
+		}
+	})
+
+	t.Run("encrypted file", func(t *testing.T) {
+		enc := &export.EncryptOptions{Passphrase: "correct horse battery staple"}
+		path := filepath.Join(dir, "secret.json")
+		written, _, err := runWriteExport(t, generated, enc, "--out", path)
+		if err != nil || written != path+export.EncryptedExt {
+			t.Fatalf("expected %s, got %q (err %v)", path+export.EncryptedExt, written, err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Error("expected no plaintext file")
+		}
+		if _, _, err := runWriteExport(t, generated, enc, "--out", path); err == nil {
+			t.Error("expected existing encrypted file to be kept")
+		}
+	})
+	t.Run("compressed", func(t *testing.T) {
+		written, _, err := runWriteExport(t, generated, nil, "--compress")
+		if err != nil || written != generated+export.CompressedExt {
+			t.Fatalf("expected %s, got %q (err %v)", generated+export.CompressedExt, written, err)
+		}
+		file, err := os.Open(written)
+		if err != nil {
+			t.Fatalf("failed to open compressed export: %v", err)
+		}
+		defer file.Close()
+		if got := gunzip(t, file); got != "payload\n" {
+			t.Errorf("unexpected decompressed contents %q", got)
+		}
+	})
+
+	t.Run("compressed stdout", func(t *testing.T) {
+		_, stdout, err := runWriteExport(t, generated, nil, "-z", "-o", "-")
+		if err != nil {
+			t.Fatalf("writeExport failed: %v", err)
+		}
+		if got := gunzip(t, bytes.NewReader([]byte(stdout))); got != "payload\n" {
+			t.Errorf("unexpected decompressed stdout %q", got)
+		}
+	})
+
+	t.Run("compressed and encrypted", func(t *testing.T) {
+		enc := &export.EncryptOptions{Passphrase: "correct horse battery staple"}
+		path := filepath.Join(dir, "both.csv")
+		written, _, err := runWriteExport(t, generated, enc, "--compress", "--out", path)
+		want := path + export.CompressedExt + export.EncryptedExt
+		if err != nil || written != want {
+			t.Fatalf("expected %s, got %q (err %v)", want, written, err)
+		}
+	})
+}
+
+func gunzip(t *testing.T, r io.Reader) string {
+	t.Helper()
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatalf("output is not gzip: %v", err)
+	}
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("failed to decompress: %v", err)
+	}
+	return string(data)
+}
+
+func TestExportLikesAction(t *testing.T) {
+	like := func(rkey, subject, createdAt string) bsky.Record {
+		value, _ := json.Marshal(map[string]any{
+			"$type":     "app.bsky.feed.like",
+			"subject":   map[string]string{"uri": subject, "cid": "bafy"},
+			"createdAt": createdAt,
+		})
+		return bsky.Record{Uri: "at://did:plc:me/app.bsky.feed.like/" + rkey, Value: value}
+	}
+	graph := &fakeGraph{
+		authenticated: true,
+		did:           "did:plc:me",
+		records: map[string][]bsky.Record{"did:plc:me/app.bsky.feed.like": {
+			like("2", "at://did:plc:gone/app.bsky.feed.post/1", "2026-10-02T08:00:00.000Z"),
+			like("1", "at://did:plc:author/app.bsky.feed.post/1", "2026-10-01T08:00:00.000Z"),
+			{Uri: "at://did:plc:me/app.bsky.feed.like/bad", Value: json.RawMessage(`{}`)},
+		}},
+		posts: []bsky.PostView{{Uri: "at://did:plc:author/app.bsky.feed.post/1", Author: &bsky.ActorProfile{Did: "did:plc:author"}}},
+	}
+	reg := registry.New(registry.Dependencies{Records: graph})
+
+	out, err := runSubcommand(t, ExportCommand(), "likes", ExportLikesAction, reg, "--out", "-")
+	if err != nil {
+		t.Fatalf("export likes failed: %v", err)
+	}
+
+	var doc export.RecordedPostsDocument
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("failed to decode export %q: %v", out, err)
+	}
+	if doc.Collection != "app.bsky.feed.like" || len(doc.Posts) != 2 {
+		t.Fatalf("unexpected export %+v", doc)
+	}
+	if doc.Posts[0].Post != nil || doc.Posts[0].SubjectURI != "at://did:plc:gone/app.bsky.feed.post/1" {
+		t.Errorf("expected the deleted post to keep only its URI, got %+v", doc.Posts[0])
+	}
+	if doc.Posts[1].Post == nil || !doc.Posts[1].CreatedAt.Equal(time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the liked post with its like time, got %+v", doc.Posts[1])
+	}
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/analytics"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// FeedCommand returns the feed command with subcommands that analyze one locally archived feed
+func FeedCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "feed",
+		Usage: "Analyze the posts archived for a local feed",
+		Commands: []*cli.Command{
+			{
+				Name:      "stats",
+				Usage:     "Summarize a feed's archived posts",
+				UsageText: "Reports the dates the feed's stored posts cover, how many were posted per day or week, its most frequent authors, and how engagement is spread. Engagement is the likes, reposts, replies, and quotes a post had when it was fetched. Everything is read from the local archive; nothing is fetched.",
+				ArgsUsage: "<feed-id>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only posts indexed since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+					},
+					&cli.StringFlag{
+						Name:  "by",
+						Usage: "Trend period: day, week, or month",
+						Value: string(analytics.PeriodWeek),
+					},
+					&cli.IntFlag{
+						Name:    "top",
+						Aliases: []string{"t"},
+						Usage:   "Number of most frequent authors to list",
+						Value:   10,
+					},
+					&cli.BoolFlag{
+						Name:  "chart",
+						Usage: "Draw the trend and engagement distribution as bar charts",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output statistics as JSON",
+					},
+				},
+				Action: withRegistry(FeedStatsAction),
+			},
+			{
+				Name:      "authors",
+				Usage:     "Rank the authors of a feed's archived posts",
+				UsageText: "Ranks the authors of the feed's stored posts by how many they posted or by their mean engagement per post, to show who dominates a feed. Engagement is the likes, reposts, replies, and quotes a post had when it was fetched. Handles come from the local profile cache; authors without a cached profile are shown by DID.",
+				ArgsUsage: "<feed-id>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only posts indexed since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+					},
+					&cli.IntFlag{
+						Name:    "top",
+						Aliases: []string{"t"},
+						Usage:   "Number of authors to list (0 for all)",
+						Value:   20,
+					},
+					&cli.StringFlag{
+						Name:  "sort",
+						Usage: "Rank by posts or engagement (mean per post)",
+						Value: string(analytics.AuthorsByPosts),
+					},
+					&cli.IntFlag{
+						Name:  "min-posts",
+						Usage: "Leave out authors with fewer posts, so one popular post doesn't top the engagement ranking",
+						Value: 1,
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Output format: table, json, csv",
+						Value:   "table",
+					},
+				},
+				Action: withRegistry(FeedAuthorsAction),
+			},
+		},
+	}
+}
+
+// feedStatsOutput is the JSON form of feed stats
+type feedStatsOutput struct {
+	FeedID string `json:"feedId"`
+	Name   string `json:"name"`
+	analytics.FeedStats
+}
+
+// FeedStatsAction summarizes the archived posts of a local feed
+func FeedStatsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("feed ID required")
+	}
+
+	period := analytics.Period(cmd.String("by"))
+	switch period {
+	case analytics.PeriodDay, analytics.PeriodWeek, analytics.PeriodMonth:
+	default:
+		return fmt.Errorf("invalid --by %q: expected day, week, or month", period)
+	}
+
+	since, err := parseSince(cmd.String("since"), userNow())
+	if err != nil {
+		return err
+	}
+
+	feed, posts, err := archivedFeedPosts(ctx, reg, cmd.Args().First(), since)
+	if err != nil {
+		return err
+	}
+
+	output := feedStatsOutput{
+		FeedID:    feed.ID(),
+		Name:      feed.Name,
+		FeedStats: analytics.SummarizeFeed(feedPosts(posts), analytics.FeedStatsOptions{Period: period, TopAuthors: cmd.Int("top")}),
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(output)
+	}
+
+	if output.Posts == 0 {
+		ui.Infoln("No stored posts in feed %s. Run 'skycli fetch feed' to archive some.", feed.Name)
+		return nil
+	}
+
+	displayFeedStats(output, period, cmd.Bool("chart"))
+	return nil
+}
+
+// feedAuthor is one ranked author of a feed, with their handle when the profile is cached
+type feedAuthor struct {
+	Handle string `json:"handle,omitempty"`
+	analytics.AuthorRank
+}
+
+// feedAuthorsOutput is the JSON form of feed authors
+type feedAuthorsOutput struct {
+	FeedID  string       `json:"feedId"`
+	Name    string       `json:"name"`
+	Posts   int          `json:"posts"`
+	Authors []feedAuthor `json:"authors"`
+}
+
+// FeedAuthorsAction ranks the authors of a local feed's archived posts by post count or mean engagement
+func FeedAuthorsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("feed ID required")
+	}
+
+	outputFormat := cmd.String("output")
+	if outputFormat != "table" && outputFormat != "json" && outputFormat != "csv" {
+		return fmt.Errorf("invalid output format: %s (must be table, json, or csv)", outputFormat)
+	}
+
+	order := analytics.AuthorOrder(cmd.String("sort"))
+	if order != analytics.AuthorsByPosts && order != analytics.AuthorsByEngagement {
+		return fmt.Errorf("invalid --sort %q: expected posts or engagement", order)
+	}
+
+	since, err := parseSince(cmd.String("since"), userNow())
+	if err != nil {
+		return err
+	}
+
+	feed, posts, err := archivedFeedPosts(ctx, reg, cmd.Args().First(), since)
+	if err != nil {
+		return err
+	}
+
+	ranks := analytics.RankAuthors(feedPosts(posts), analytics.AuthorRankOptions{
+		By:       order,
+		MinPosts: cmd.Int("min-posts"),
+		Top:      cmd.Int("top"),
+	})
+
+	profileRepo, err := reg.GetProfileRepo()
+	if err != nil {
+		logger.Debug("Profile cache unavailable", "error", err)
+		profileRepo = nil
+	}
+	output := feedAuthorsOutput{FeedID: feed.ID(), Name: feed.Name, Posts: len(posts), Authors: make([]feedAuthor, len(ranks))}
+	for i, rank := range ranks {
+		output.Authors[i] = feedAuthor{AuthorRank: rank}
+		if profileRepo == nil {
+			continue
+		}
+		if profile, err := profileRepo.GetByDid(ctx, rank.Author); err != nil {
+			logger.Debug("Failed to check profile cache", "did", rank.Author, "error", err)
+		} else if profile != nil {
+			output.Authors[i].Handle = profile.Handle
+		}
+	}
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(cmd.Root().Writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	case "csv":
+		return outputFeedAuthorsCSV(cmd.Root().Writer, output.Authors)
+	}
+
+	if len(output.Authors) == 0 {
+		ui.Infoln("No authors to rank in feed %s. Run 'skycli fetch feed' to archive some posts.", feed.Name)
+		return nil
+	}
+
+	rows := make([][]string, len(output.Authors))
+	for i, author := range output.Authors {
+		name := author.Author
+		if author.Handle != "" {
+			name = "@" + author.Handle
+		}
+		mean := "—"
+		if author.MeanEngagement != nil {
+			mean = fmt.Sprintf("%.1f", *author.MeanEngagement)
+		}
+		rows[i] = []string{strconv.Itoa(i + 1), name, strconv.Itoa(author.Posts), fmt.Sprintf("%.1f%%", author.Share), mean, strconv.Itoa(author.Engagement)}
+	}
+	ui.Titleln("Authors in %s (%d posts, by %s)", feed.Name, len(posts), order)
+	fmt.Println(stripedTable([]string{"#", "Author", "Posts", "Share", "Avg Engagement", "Total Engagement"}, rows))
+	return nil
+}
+
+// outputFeedAuthorsCSV writes ranked authors with their post counts and engagement, leaving mean_engagement empty
+// for authors without recorded engagement
+func outputFeedAuthorsCSV(w io.Writer, authors []feedAuthor) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"rank", "did", "handle", "posts", "share", "recorded", "engagement", "mean_engagement"}); err != nil {
+		return err
+	}
+	for i, author := range authors {
+		mean := ""
+		if author.MeanEngagement != nil {
+			mean = strconv.FormatFloat(*author.MeanEngagement, 'f', 2, 64)
+		}
+		record := []string{
+			strconv.Itoa(i + 1),
+			author.Author,
+			author.Handle,
+			strconv.Itoa(author.Posts),
+			strconv.FormatFloat(author.Share, 'f', 2, 64),
+			strconv.Itoa(author.Recorded),
+			strconv.Itoa(author.Engagement),
+			mean,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// archivedFeedPosts looks up a local feed by ID and returns it with its stored posts indexed since since
+func archivedFeedPosts(ctx context.Context, reg *registry.Registry, feedID string, since time.Time) (*bsky.FeedModel, []*bsky.PostModel, error) {
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get feed repository: %w", err)
+	}
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	model, err := feedRepo.Get(ctx, feedID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("feed not found: %w", err)
+	}
+	feed := model.(*bsky.FeedModel)
+
+	posts, err := postRepo.Query(ctx, bsky.PostQuery{FeedIDs: []string{feed.ID()}, Since: since})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query posts: %w", err)
+	}
+	return feed, posts, nil
+}
+
+// feedPosts converts stored posts for the feed analytics, with times in the user's zone
+func feedPosts(posts []*bsky.PostModel) []analytics.FeedPost {
+	converted := make([]analytics.FeedPost, len(posts))
+	for i, post := range posts {
+		converted[i] = analytics.FeedPost{Author: post.AuthorDID, Time: post.IndexedAt.In(ui.Location())}
+		if post.Engagement != nil {
+			total := post.Engagement.Total()
+			converted[i].Engagement = &total
+		}
+	}
+	return converted
+}
+
+// displayFeedStats prints the coverage, trend, top authors, and engagement of a feed
+func displayFeedStats(output feedStatsOutput, period analytics.Period, showChart bool) {
+	ui.Titleln("Feed Statistics: %s", output.Name)
+	coverage := output.Coverage
+	fmt.Printf("Posts: %d by %d author(s)\n", output.Posts, output.Authors)
+	fmt.Printf("Coverage: %s to %s, %d day(s), %d with posts, longest gap %d day(s)\n",
+		coverage.First.Format("2006-01-02"), coverage.Last.Format("2006-01-02"), coverage.Days, coverage.ActiveDays, coverage.LongestGap)
+	rate := fmt.Sprintf("Posts/day: %.1f", output.PostsPerDay)
+	if output.Change != nil {
+		rate += fmt.Sprintf(" (%+.0f%% in the second half vs the first)", *output.Change)
+	}
+	fmt.Println(rate)
+
+	fmt.Println()
+	ui.Subtitleln("Posts by %s", period)
+	if showChart {
+		peak := 1
+		for _, bucket := range output.Trend {
+			peak = max(peak, bucket.Posts)
+		}
+		for _, bucket := range output.Trend {
+			fmt.Printf("%s %-*s %5d  %.1f/day\n", bucket.Start.Format("2006-01-02"), feedChartWidth, strings.Repeat("█", bucket.Posts*feedChartWidth/peak), bucket.Posts, bucket.PostsPerDay)
+		}
+	} else {
+		rows := make([][]string, len(output.Trend))
+		for i, bucket := range output.Trend {
+			rows[i] = []string{bucket.Start.Format("2006-01-02"), fmt.Sprintf("%d", bucket.Posts), fmt.Sprintf("%.1f", bucket.PostsPerDay)}
+		}
+		fmt.Println(stripedTable([]string{"Period", "Posts", "Posts/Day"}, rows))
+	}
+
+	fmt.Println()
+	ui.Subtitleln("Top Authors")
+	rows := make([][]string, len(output.TopAuthors))
+	for i, author := range output.TopAuthors {
+		rows[i] = []string{author.Author, fmt.Sprintf("%d", author.Posts), fmt.Sprintf("%.1f%%", author.Share)}
+	}
+	fmt.Println(stripedTable([]string{"Author", "Posts", "Share"}, rows))
+
+	fmt.Println()
+	ui.Subtitleln("Engagement")
+	engagement := output.Engagement
+	if engagement.Recorded == 0 {
+		ui.Infoln("No engagement recorded; posts fetched before engagement was kept, or imported from a repository export, have none.")
+		return
+	}
+	fmt.Printf("Likes, reposts, replies, and quotes per post: mean %.1f, median %.1f, 90th percentile %d, max %d\n",
+		engagement.Mean, engagement.Median, engagement.P90, engagement.Max)
+	if missing := output.Posts - engagement.Recorded; missing > 0 {
+		ui.Infoln("%d post(s) without recorded engagement are left out.", missing)
+	}
+
+	peak := 1
+	for _, bucket := range engagement.Buckets {
+		peak = max(peak, bucket.Posts)
+	}
+	rows = make([][]string, len(engagement.Buckets))
+	for i, bucket := range engagement.Buckets {
+		label := fmt.Sprintf("%d–%d", bucket.Min, bucket.Max)
+		switch {
+		case bucket.Max < 0:
+			label = fmt.Sprintf("%d+", bucket.Min)
+		case bucket.Min == bucket.Max:
+			label = fmt.Sprintf("%d", bucket.Min)
+		}
+		if showChart {
+			fmt.Printf("%-8s %-*s %5d\n", label, feedChartWidth, strings.Repeat("█", bucket.Posts*feedChartWidth/peak), bucket.Posts)
+			continue
+		}
+		rows[i] = []string{label, fmt.Sprintf("%d", bucket.Posts), fmt.Sprintf("%.1f%%", 100*float64(bucket.Posts)/float64(engagement.Recorded))}
+	}
+	if !showChart {
+		fmt.Println(stripedTable([]string{"Engagement", "Posts", "Share"}, rows))
+	}
+}
+
+// feedChartWidth is the length of the longest bar in feed charts
+const feedChartWidth = 30
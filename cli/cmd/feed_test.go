@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/analytics"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestFeedStatsAction(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	feed, _, err := localFeed(ctx, feedRepo, "at://did:plc:gardener/app.bsky.feed.generator/plants", "Plants")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var posts []*bsky.PostModel
+	for i, author := range []string{"did:plc:alice", "did:plc:alice", "did:plc:bob"} {
+		item := feedItem(author, string(rune('a'+i)), nil)
+		item.Post.IndexedAt = time.Now().AddDate(0, 0, -i).UTC().Format(time.RFC3339)
+		item.Post.LikeCount = 10 * i
+		posts = append(posts, bsky.NewPostModel(feed.ID(), item.Post))
+	}
+	if err := postRepo.BatchSave(ctx, posts); err != nil {
+		t.Fatal(err)
+	}
+	reg := registry.New(registry.Dependencies{FeedRepo: feedRepo, PostRepo: postRepo})
+
+	for _, args := range [][]string{{feed.ID()}, {"--by", "day", "--chart", feed.ID()}} {
+		if _, err := runSubcommand(t, FeedCommand(), "stats", FeedStatsAction, reg, args...); err != nil {
+			t.Errorf("FeedStatsAction %v failed: %v", args, err)
+		}
+	}
+
+	for _, args := range [][]string{{}, {"--by", "year", feed.ID()}, {"00000000-0000-0000-0000-000000000000"}} {
+		if _, err := runSubcommand(t, FeedCommand(), "stats", FeedStatsAction, reg, args...); err == nil {
+			t.Errorf("expected FeedStatsAction %v to fail", args)
+		}
+	}
+
+	stored, posts, err := archivedFeedPosts(ctx, reg, feed.ID(), time.Now().Add(-36*time.Hour))
+	if err != nil {
+		t.Fatalf("archivedFeedPosts failed: %v", err)
+	}
+	if stored.Name != "Plants" || len(posts) != 2 {
+		t.Errorf("expected the 2 posts of the last 36 hours from Plants, got %d from %q", len(posts), stored.Name)
+	}
+	for _, post := range posts {
+		if post.Engagement == nil {
+			t.Errorf("expected engagement stored with %s", post.URI)
+		}
+	}
+}
+
+func TestFeedAuthorsAction(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	profileRepo, err := bsky.NewProfileRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := profileRepo.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { profileRepo.Close() })
+	if err := profileRepo.Save(ctx, &bsky.ProfileModel{Did: "did:plc:bob", Handle: "bob.bsky.social", DataJSON: `{"did":"did:plc:bob"}`}); err != nil {
+		t.Fatal(err)
+	}
+
+	feed, _, err := localFeed(ctx, feedRepo, "at://did:plc:gardener/app.bsky.feed.generator/plants", "Plants")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var posts []*bsky.PostModel
+	for i, author := range []string{"did:plc:alice", "did:plc:alice", "did:plc:bob"} {
+		item := feedItem(author, string(rune('a'+i)), nil)
+		item.Post.LikeCount = 10 * i
+		posts = append(posts, bsky.NewPostModel(feed.ID(), item.Post))
+	}
+	if err := postRepo.BatchSave(ctx, posts); err != nil {
+		t.Fatal(err)
+	}
+	reg := registry.New(registry.Dependencies{FeedRepo: feedRepo, PostRepo: postRepo, ProfileRepo: profileRepo})
+
+	out, err := runSubcommand(t, FeedCommand(), "authors", FeedAuthorsAction, reg, "--sort", "engagement", "--output", "json", feed.ID())
+	if err != nil {
+		t.Fatalf("FeedAuthorsAction failed: %v", err)
+	}
+	var output feedAuthorsOutput
+	if err := json.Unmarshal([]byte(out), &output); err != nil {
+		t.Fatalf("failed to decode %q: %v", out, err)
+	}
+	if output.Posts != 3 || len(output.Authors) != 2 {
+		t.Fatalf("expected 2 authors of 3 posts, got %+v", output)
+	}
+	if bob := output.Authors[0]; bob.Author != "did:plc:bob" || bob.Handle != "bob.bsky.social" || bob.MeanEngagement == nil || *bob.MeanEngagement != 20 {
+		t.Errorf("expected bob first with a mean of 20, got %+v", bob)
+	}
+	if alice := output.Authors[1]; alice.Handle != "" || alice.Posts != 2 {
+		t.Errorf("expected alice without a cached handle, got %+v", alice)
+	}
+
+	out, err = runSubcommand(t, FeedCommand(), "authors", FeedAuthorsAction, reg, "--output", "csv", "--top", "1", feed.ID())
+	if err != nil {
+		t.Fatalf("FeedAuthorsAction failed: %v", err)
+	}
+	want := "rank,did,handle,posts,share,recorded,engagement,mean_engagement\n1,did:plc:alice,,2,66.67,2,10,5.00\n"
+	if out != want {
+		t.Errorf("expected CSV %q, got %q", want, out)
+	}
+
+	if _, err := runSubcommand(t, FeedCommand(), "authors", FeedAuthorsAction, reg, feed.ID()); err != nil {
+		t.Errorf("FeedAuthorsAction table failed: %v", err)
+	}
+	for _, args := range [][]string{{}, {"--sort", "likes", feed.ID()}, {"--output", "xml", feed.ID()}} {
+		if _, err := runSubcommand(t, FeedCommand(), "authors", FeedAuthorsAction, reg, args...); err == nil {
+			t.Errorf("expected FeedAuthorsAction %v to fail", args)
+		}
+	}
+}
+
+func TestOutputFeedAuthorsCSV_WriteError(t *testing.T) {
+	if err := outputFeedAuthorsCSV(failingWriter{}, []feedAuthor{{AuthorRank: analytics.AuthorRank{Author: "did:plc:a", Posts: 1}}}); err == nil {
+		t.Error("expected the failed write to be reported")
+	}
+}
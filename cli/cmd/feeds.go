@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// FeedsCommand returns the feeds command with subcommands for the saved and pinned feeds kept in account preferences
+func FeedsCommand() *cli.Command {
+	feedArg := "Takes a feed or list as an AT URI or bsky.app link, or 'following' for the Following timeline."
+	return &cli.Command{
+		Name:  "feeds",
+		Usage: "Manage the saved and pinned feeds shared with the Bluesky app",
+		Commands: []*cli.Command{
+			{
+				Name:      "list",
+				Usage:     "List saved feeds from account preferences",
+				UsageText: "List the feeds saved in your account preferences in the app's order. Pinned feeds are the tabs on the app's home screen. For feeds cached locally, see 'skycli list feeds'.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "pinned",
+						Aliases: []string{"p"},
+						Usage:   "Only pinned feeds, as the app's home screen tabs show them",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output saved feeds as JSON",
+					},
+				},
+				Action: withRegistry(FeedsListAction),
+			},
+			{
+				Name:        "pin",
+				Usage:       "Pin a feed to the app's home screen, saving it if needed",
+				Description: feedArg,
+				ArgsUsage:   "<feed-uri-or-url>",
+				Action:      withRegistry(FeedsPinAction(true)),
+			},
+			{
+				Name:        "unpin",
+				Usage:       "Unpin a feed, keeping it saved",
+				Description: feedArg,
+				ArgsUsage:   "<feed-uri-or-url>",
+				Action:      withRegistry(FeedsPinAction(false)),
+			},
+		},
+	}
+}
+
+// FeedsListAction lists the saved feeds in account preferences, or with --pinned only the pinned ones
+func FeedsListAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	prefs, err := preferencesClient(reg)
+	if err != nil {
+		return err
+	}
+
+	response, err := prefs.GetPreferences(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch preferences: %w", err)
+	}
+
+	feeds := response.SavedFeeds()
+	if cmd.Bool("pinned") {
+		pinned := make([]bsky.SavedFeed, 0, len(feeds))
+		for _, feed := range feeds {
+			if feed.Pinned {
+				pinned = append(pinned, feed)
+			}
+		}
+		feeds = pinned
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(feeds)
+	}
+
+	if len(feeds) == 0 {
+		ui.Infoln("No saved feeds.")
+		return nil
+	}
+
+	rows := make([][]string, len(feeds))
+	for i, feed := range feeds {
+		pinned := ""
+		if feed.Pinned {
+			pinned = "yes"
+		}
+		rows[i] = []string{strconv.Itoa(i + 1), feed.Type, feed.Value, pinned}
+	}
+
+	headers := []string{"#", "Type", "Feed", "Pinned"}
+	if ui.ListAsLines() {
+		ui.Titleln("Saved Feeds")
+		fmt.Println(ui.FormatLines(headers, rows))
+		fmt.Println()
+		ui.Successln("Total: %d feed(s)", len(feeds))
+		return nil
+	}
+
+	fit := ui.FitTable(headers, rows, map[string]int{"Pinned": 2, "Type": 1})
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers(fit.Headers...).Rows(fit.Rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	ui.Titleln("Saved Feeds")
+	fmt.Println(re.NewStyle().Render(t.String()))
+	fit.HiddenNote()
+	ui.Successln("Total: %d feed(s)", len(feeds))
+	return nil
+}
+
+// FeedsPinAction returns the action that pins (or unpins) the feed given as argument
+func FeedsPinAction(pinned bool) registryAction {
+	return func(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+		if cmd.Args().Len() == 0 {
+			return fmt.Errorf("feed URI or URL required")
+		}
+
+		prefs, err := preferencesClient(reg)
+		if err != nil {
+			return err
+		}
+		profiles, err := reg.GetProfileFetcher()
+		if err != nil {
+			return fmt.Errorf("failed to get profile fetcher: %w", err)
+		}
+
+		value, err := resolveSavedFeed(ctx, profiles, cmd.Args().First())
+		if err != nil {
+			return err
+		}
+
+		changed, err := pinSavedFeed(ctx, prefs, value, pinned, time.Now())
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !changed && pinned:
+			ui.Infoln("Already pinned: %s", value)
+		case !changed:
+			ui.Infoln("Not pinned: %s", value)
+		case pinned:
+			ui.Successln("Pinned %s", value)
+		default:
+			ui.Successln("Unpinned %s; it stays saved", value)
+		}
+		return nil
+	}
+}
+
+// preferencesClient returns the preferences client after checking there is a session to read preferences with
+func preferencesClient(reg *registry.Registry) (bsky.PreferencesWriter, error) {
+	service, err := reg.GetService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+	if !service.Authenticated() {
+		return nil, fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	prefs, err := reg.GetPreferencesWriter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences client: %w", err)
+	}
+	return prefs, nil
+}
+
+// resolveSavedFeed turns a feed argument into the value stored in preferences: "following", or the AT URI of a
+// feed generator or list with its owner's handle resolved to a DID
+func resolveSavedFeed(ctx context.Context, profiles bsky.ProfileFetcher, input string) (string, error) {
+	if input == "following" {
+		return input, nil
+	}
+
+	ref, err := aturi.Parse(input)
+	if err != nil {
+		return "", err
+	}
+	if ref.Collection != aturi.CollectionFeed && ref.Collection != aturi.CollectionList {
+		return "", fmt.Errorf("%q is not a feed or list", input)
+	}
+
+	authority, err := resolveActor(ctx, profiles, "feed owner", ref.Authority)
+	if err != nil {
+		return "", err
+	}
+	return "at://" + authority + "/" + ref.Collection + "/" + ref.Rkey, nil
+}
+
+// pinSavedFeed sets whether value is pinned and writes preferences back when that changes anything. Pinning a feed
+// that isn't saved adds it after the others; unpinning one that isn't saved is an error. Items read from the older
+// preference get IDs, which the current one requires.
+func pinSavedFeed(ctx context.Context, prefs bsky.PreferencesWriter, value string, pinned bool, now time.Time) (bool, error) {
+	response, err := prefs.GetPreferences(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch preferences: %w", err)
+	}
+
+	feeds := response.SavedFeeds()
+	found := false
+	for i := range feeds {
+		if feeds[i].Value != value {
+			continue
+		}
+		if feeds[i].Pinned == pinned {
+			return false, nil
+		}
+		feeds[i].Pinned = pinned
+		found = true
+	}
+	if !found {
+		if !pinned {
+			return false, fmt.Errorf("%s is not among your saved feeds", value)
+		}
+		feeds = append(feeds, bsky.SavedFeed{Type: bsky.SavedFeedType(value), Value: value, Pinned: true})
+	}
+
+	clockID := rand.UintN(1024)
+	for i := range feeds {
+		if feeds[i].ID == "" {
+			feeds[i].ID = aturi.NewTID(now, clockID+uint(i))
+		}
+	}
+
+	response.SetSavedFeeds(feeds)
+	logger.Debug("Updating saved feeds", "feed", value, "pinned", pinned, "feeds", len(feeds))
+	if err := prefs.PutPreferences(ctx, response.Preferences); err != nil {
+		return false, fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return true, nil
+}
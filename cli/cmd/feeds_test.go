@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// fakePrefs keeps preferences in memory and counts writes
+type fakePrefs struct {
+	prefs  []map[string]any
+	writes int
+}
+
+func (f *fakePrefs) GetPreferences(ctx context.Context) (*bsky.GetPreferencesResponse, error) {
+	return &bsky.GetPreferencesResponse{Preferences: f.prefs}, nil
+}
+
+func (f *fakePrefs) PutPreferences(ctx context.Context, preferences []map[string]any) error {
+	f.prefs = preferences
+	f.writes++
+	return nil
+}
+
+func TestPinSavedFeed(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const discover = "at://did:plc:feeds/app.bsky.feed.generator/whats-hot"
+	const art = "at://did:plc:feeds/app.bsky.feed.generator/art"
+
+	prefs := &fakePrefs{prefs: []map[string]any{
+		{"$type": "app.bsky.actor.defs#adultContentPref", "enabled": false},
+		{"$type": "app.bsky.actor.defs#savedFeedsPref", "pinned": []any{discover}, "saved": []any{discover}},
+	}}
+
+	changed, err := pinSavedFeed(ctx, prefs, discover, true, now)
+	if err != nil || changed || prefs.writes != 0 {
+		t.Fatalf("pinning a pinned feed: changed=%v writes=%d err=%v", changed, prefs.writes, err)
+	}
+
+	if changed, err = pinSavedFeed(ctx, prefs, art, true, now); err != nil || !changed {
+		t.Fatalf("pinning a new feed: changed=%v err=%v", changed, err)
+	}
+	feeds := (&bsky.GetPreferencesResponse{Preferences: prefs.prefs}).SavedFeeds()
+	if len(feeds) != 2 || feeds[1].Value != art || !feeds[1].Pinned || feeds[0].ID == "" || feeds[0].ID == feeds[1].ID {
+		t.Fatalf("unexpected saved feeds %+v", feeds)
+	}
+	if prefs.prefs[0]["enabled"] != false {
+		t.Errorf("expected other preferences kept, got %v", prefs.prefs)
+	}
+
+	if changed, err = pinSavedFeed(ctx, prefs, discover, false, now); err != nil || !changed {
+		t.Fatalf("unpinning: changed=%v err=%v", changed, err)
+	}
+	feeds = (&bsky.GetPreferencesResponse{Preferences: prefs.prefs}).SavedFeeds()
+	if len(feeds) != 2 || feeds[0].Pinned {
+		t.Errorf("expected %s saved but unpinned, got %+v", discover, feeds)
+	}
+
+	if _, err := pinSavedFeed(ctx, prefs, "at://did:plc:feeds/app.bsky.feed.generator/nope", false, now); err == nil || !strings.Contains(err.Error(), "not among your saved feeds") {
+		t.Errorf("expected unpinning an unsaved feed to fail, got %v", err)
+	}
+	if prefs.writes != 2 {
+		t.Errorf("expected 2 writes, got %d", prefs.writes)
+	}
+}
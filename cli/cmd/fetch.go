@@ -4,24 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
 	"github.com/google/uuid"
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
-	"github.com/stormlightlabs/skypanel/cli/internal/setup"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
 // FetchTimelineAction fetches and displays the authenticated user's home timeline
-func FetchTimelineAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
+func FetchTimelineAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	service, err := reg.GetService()
 	if err != nil {
 		return fmt.Errorf("failed to get service: %w", err)
@@ -37,10 +36,11 @@ func FetchTimelineAction(ctx context.Context, cmd *cli.Command) error {
 
 	logger.Debug("Fetching timeline", "limit", limit, "cursor", cursor)
 
-	response, err := service.GetTimeline(ctx, limit, cursor)
+	posts, next, err := collectFeed(ctx, bsky.TimelinePages(service), limit, cursor)
 	if err != nil {
 		return fmt.Errorf("failed to fetch timeline: %w", err)
 	}
+	response := &bsky.GetTimelineResponse{Feed: posts, Cursor: next}
 
 	if asJSON {
 		return ui.DisplayJSON(response)
@@ -50,16 +50,14 @@ func FetchTimelineAction(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-// FetchFeedAction fetches and displays posts from a specific feed
-func FetchFeedAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
+// FetchFeedAction fetches and displays posts from a specific feed.
+// With several feed IDs or --all it refreshes saved feeds instead; see [RefreshFeedsAction].
+func FetchFeedAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Bool("all") || cmd.Args().Len() > 1 {
+		return RefreshFeedsAction(ctx, cmd, reg)
 	}
-
-	reg := registry.Get()
-
 	if cmd.Args().Len() == 0 {
-		return fmt.Errorf("feed URI or local feed ID required")
+		return fmt.Errorf("feed URI or local feed ID required (or --all to refresh every saved feed)")
 	}
 
 	feedIdentifier := cmd.Args().First()
@@ -88,7 +86,7 @@ func FetchFeedAction(ctx context.Context, cmd *cli.Command) error {
 		if err != nil {
 			return fmt.Errorf("failed to get local feed: %w", err)
 		}
-		if feedModel, ok := feed.(*store.FeedModel); ok {
+		if feedModel, ok := feed.(*bsky.FeedModel); ok {
 			feedURI = feedModel.Source
 			logger.Debug("Resolved local feed ID to URI", "id", feedIdentifier, "uri", feedURI)
 		}
@@ -98,10 +96,11 @@ func FetchFeedAction(ctx context.Context, cmd *cli.Command) error {
 
 	logger.Debug("Fetching feed", "uri", feedURI, "limit", limit, "cursor", cursor)
 
-	response, err := service.GetAuthorFeed(ctx, feedURI, limit, cursor)
+	posts, next, err := collectFeed(ctx, bsky.AuthorFeedPages(service, feedURI), limit, cursor)
 	if err != nil {
 		return fmt.Errorf("failed to fetch feed: %w", err)
 	}
+	response := &bsky.GetAuthorFeedResponse{Feed: posts, Cursor: next}
 
 	if asJSON {
 		return ui.DisplayJSON(response)
@@ -112,22 +111,28 @@ func FetchFeedAction(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-// FetchAuthorAction fetches and displays posts from a specific author with profile caching
-func FetchAuthorAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
-	if cmd.Args().Len() == 0 {
-		return fmt.Errorf("actor handle or DID required")
-	}
+// feedRefresh is the outcome of refreshing one saved feed
+type feedRefresh struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Source  string `json:"source"`
+	Fetched int    `json:"fetched"`
+	New     int    `json:"new"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
 
-	actor := cmd.Args().First()
+// RefreshFeedsAction fetches the latest posts of several saved feeds concurrently and stores them locally
+func RefreshFeedsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	limit := cmd.Int("limit")
-	cursor := cmd.String("cursor")
+	parallel := cmd.Int("parallel")
 	asJSON := cmd.Bool("json")
+	if limit <= 0 {
+		return fmt.Errorf("limit must be greater than zero")
+	}
+	if parallel <= 0 {
+		return fmt.Errorf("parallel must be greater than zero")
+	}
 
 	service, err := reg.GetService()
 	if err != nil {
@@ -138,56 +143,264 @@ func FetchAuthorAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("not authenticated: run 'skycli login' first")
 	}
 
-	profileRepo, err := reg.GetProfileRepo()
+	feedRepo, err := reg.GetFeedRepo()
 	if err != nil {
-		return fmt.Errorf("failed to get profile repository: %w", err)
+		return fmt.Errorf("failed to get feed repository: %w", err)
 	}
 
-	cachedProfile, err := profileRepo.GetByDid(ctx, actor)
+	postRepo, err := reg.GetPostRepo()
 	if err != nil {
-		logger.Warn("Failed to check profile cache", "error", err)
+		return fmt.Errorf("failed to get post repository: %w", err)
 	}
 
-	var profile *store.ActorProfile
-	if cachedProfile != nil && cachedProfile.IsFresh(time.Hour) {
-		logger.Debug("Using cached profile", "did", actor)
-		if err := json.Unmarshal([]byte(cachedProfile.DataJSON), &profile); err != nil {
-			logger.Warn("Failed to unmarshal cached profile", "error", err)
-			cachedProfile = nil
+	feeds, err := resolveSavedFeeds(ctx, feedRepo, cmd.Args().Slice(), cmd.Bool("all"))
+	if err != nil {
+		return err
+	}
+	if len(feeds) == 0 {
+		ui.Infoln("No saved feeds to refresh.")
+		return nil
+	}
+
+	logger.Debug("Refreshing feeds", "count", len(feeds), "parallel", parallel, "limit", limit)
+
+	results := make([]feedRefresh, len(feeds))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, feed := range feeds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = refreshFeed(ctx, service, postRepo, feed, limit)
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
 		}
 	}
 
-	if cachedProfile == nil || !cachedProfile.IsFresh(time.Hour) {
-		logger.Debug("Fetching profile from API", "actor", actor)
-		profile, err = service.GetProfile(ctx, actor)
-		if err != nil {
-			return fmt.Errorf("failed to fetch profile: %w", err)
+	if asJSON {
+		if err := ui.DisplayJSON(results); err != nil {
+			return err
+		}
+	} else {
+		displayRefreshTable(results)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d feed(s) failed to refresh", failed, len(feeds))
+	}
+	return nil
+}
+
+// resolveSavedFeeds looks up saved feeds by ID or source URI, or returns every saved feed when all is set
+func resolveSavedFeeds(ctx context.Context, feedRepo *bsky.FeedRepository, identifiers []string, all bool) ([]*bsky.FeedModel, error) {
+	models, err := feedRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feeds: %w", err)
+	}
+
+	var saved []*bsky.FeedModel
+	for _, model := range models {
+		if feed, ok := model.(*bsky.FeedModel); ok {
+			saved = append(saved, feed)
 		}
+	}
+	if all {
+		// Archive rule and backup feeds are filled by the daemon, and search feeds by 'search posts --save-as', so
+		// they have nothing to fetch
+		return slices.DeleteFunc(saved, func(feed *bsky.FeedModel) bool {
+			return strings.HasPrefix(feed.Source, archiveSourcePrefix) || strings.HasPrefix(feed.Source, backupSourcePrefix) ||
+				strings.HasPrefix(feed.Source, searchSourcePrefix)
+		}), nil
+	}
 
-		profileJSON, err := json.Marshal(profile)
-		if err != nil {
-			logger.Warn("Failed to marshal profile for caching", "error", err)
-		} else {
-			profileModel := &store.ProfileModel{
-				Did:       profile.Did,
-				Handle:    profile.Handle,
-				DataJSON:  string(profileJSON),
-				FetchedAt: time.Now(),
+	feeds := make([]*bsky.FeedModel, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		idx := slices.IndexFunc(saved, func(feed *bsky.FeedModel) bool {
+			return feed.ID() == identifier || feed.Source == identifier
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("no saved feed matches %q: refreshing requires a saved feed ID or source", identifier)
+		}
+		feeds = append(feeds, saved[idx])
+	}
+	return feeds, nil
+}
+
+// refreshFeed fetches up to limit posts for feed and saves them, counting posts not stored before
+func refreshFeed(ctx context.Context, service *bsky.BlueskyService, postRepo *bsky.PostRepository, feed *bsky.FeedModel, limit int) feedRefresh {
+	result := feedRefresh{ID: feed.ID(), Name: feed.Name, Source: feed.Source}
+
+	posts, _, err := collectFeed(ctx, bsky.AuthorFeedPages(service, feed.Source), limit, "")
+	if err != nil {
+		result.Error = err.Error()
+		logger.Warn("Failed to refresh feed", "feed", feed.Name, "error", err)
+		return result
+	}
+	result.Fetched = len(posts)
+
+	models := make([]*bsky.PostModel, 0, len(posts))
+	uris := make([]string, 0, len(posts))
+	for _, item := range posts {
+		if item.Post == nil {
+			continue
+		}
+		models = append(models, bsky.NewPostModel(feed.ID(), item.Post))
+		uris = append(uris, item.Post.Uri)
+	}
+
+	existing, err := postRepo.ExistingURIs(ctx, uris)
+	if err != nil {
+		result.Error, result.Code = describeError(err), string(bsky.ErrorCodeOf(err))
+		return result
+	}
+	for _, uri := range uris {
+		if !existing[uri] {
+			result.New++
+		}
+	}
+
+	if err := postRepo.BatchSave(ctx, models); err != nil {
+		result.Error, result.Code = describeError(err), string(bsky.ErrorCodeOf(err))
+		result.New = 0
+		return result
+	}
+
+	logger.Debug("Refreshed feed", "feed", feed.Name, "fetched", result.Fetched, "new", result.New)
+	return result
+}
+
+// displayRefreshTable prints one row per refreshed feed with its fetched and new post counts
+func displayRefreshTable(results []feedRefresh) {
+	rows := make([][]string, len(results))
+	totalNew := 0
+	for i, result := range results {
+		status := "ok"
+		if result.Error != "" {
+			status = "failed: " + result.Error
+		}
+		rows[i] = []string{result.Name, strconv.Itoa(result.Fetched), strconv.Itoa(result.New), status}
+		totalNew += result.New
+	}
+
+	t := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(ui.TableBorderStyle).
+		Headers("Feed", "Fetched", "New", "Status").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == lgtable.HeaderRow {
+				return ui.TableHeaderStyle
 			}
-			if err := profileRepo.Save(ctx, profileModel); err != nil {
-				logger.Warn("Failed to cache profile", "error", err)
-			} else {
-				logger.Debug("Cached profile", "did", profile.Did)
+			if row%2 == 0 {
+				return ui.TableRowEvenStyle
+			}
+			return ui.TableRowOddStyle
+		})
+
+	ui.Titleln("Feed Refresh")
+	fmt.Println(t)
+	ui.Successln("%d new post(s) across %d feed(s)", totalNew, len(results))
+}
+
+// profileCacheAge is how long a cached profile is used before it is fetched again
+const profileCacheAge = time.Hour
+
+// cachedProfile returns did's profile from the profile cache when it was fetched within profileCacheAge, and
+// otherwise, or with refresh, fetches it and caches it. Cache failures only warn; a nil profileRepo skips the cache.
+func cachedProfile(ctx context.Context, profileRepo *bsky.ProfileRepository, profiles bsky.ProfileFetcher, did string, refresh bool) (*bsky.ActorProfile, error) {
+	if profileRepo == nil {
+		return profiles.GetProfile(ctx, did)
+	}
+
+	if !refresh {
+		cached, err := profileRepo.GetByDid(ctx, did)
+		if err != nil {
+			logger.Warn("Failed to check profile cache", "error", err)
+		}
+		if cached != nil && cached.IsFresh(profileCacheAge) {
+			var profile *bsky.ActorProfile
+			err := json.Unmarshal([]byte(cached.DataJSON), &profile)
+			if err == nil {
+				logger.Debug("Using cached profile", "did", did)
+				return profile, nil
 			}
+			logger.Warn("Failed to unmarshal cached profile", "error", err)
 		}
 	}
 
+	logger.Debug("Fetching profile from API", "actor", did)
+	profile, err := profiles.GetProfile(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+
+	profileJSON, err := json.Marshal(profile)
+	if err != nil {
+		logger.Warn("Failed to marshal profile for caching", "error", err)
+		return profile, nil
+	}
+	model := &bsky.ProfileModel{Did: profile.Did, Handle: profile.Handle, DataJSON: string(profileJSON), FetchedAt: time.Now()}
+	if err := profileRepo.Save(ctx, model); err != nil {
+		logger.Warn("Failed to cache profile", "error", err)
+	} else {
+		logger.Debug("Cached profile", "did", profile.Did)
+	}
+	return profile, nil
+}
+
+// FetchAuthorAction fetches and displays posts from a specific author with profile caching
+func FetchAuthorAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("actor handle or DID required")
+	}
+
+	if _, err := parseActorArg("actor", cmd.Args().First()); err != nil {
+		return err
+	}
+	limit := cmd.Int("limit")
+	cursor := cmd.String("cursor")
+	asJSON := cmd.Bool("json")
+
+	service, err := reg.GetService()
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+
+	if !service.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	// The profile cache is keyed by DID, so resolve handles first
+	actor, err := resolveActor(ctx, service, "actor", cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	profileRepo, err := reg.GetProfileRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get profile repository: %w", err)
+	}
+
+	profile, err := cachedProfile(ctx, profileRepo, service, actor, false)
+	if err != nil {
+		return profileError("actor", cmd.Args().First(), err)
+	}
+
 	logger.Debug("Fetching author feed", "actor", actor, "limit", limit, "cursor", cursor)
 
-	response, err := service.GetAuthorFeed(ctx, actor, limit, cursor)
+	posts, next, err := collectFeed(ctx, bsky.AuthorFeedPages(service, actor), limit, cursor)
 	if err != nil {
 		return fmt.Errorf("failed to fetch author feed: %w", err)
 	}
+	response := &bsky.GetAuthorFeedResponse{Feed: posts, Cursor: next}
 
 	if asJSON {
 		return ui.DisplayJSON(response)
@@ -201,6 +414,20 @@ func FetchAuthorAction(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+// collectFeed gathers up to limit posts starting at cursor, spanning as many page requests as needed
+func collectFeed(ctx context.Context, pages bsky.PageFunc[bsky.FeedViewPost], limit int, cursor string) ([]bsky.FeedViewPost, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("limit must be greater than zero")
+	}
+
+	paginator := bsky.NewPaginator(pages, bsky.PaginatorOptions{Cursor: cursor, MaxItems: limit})
+	posts, err := paginator.All(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return posts, paginator.Cursor(), nil
+}
+
 // FetchCommand returns the fetch command with subcommands for timeline, feed, and author
 func FetchCommand() *cli.Command {
 	commonFlags := []cli.Flag{
@@ -231,26 +458,37 @@ func FetchCommand() *cli.Command {
 				Usage:     "Fetch authenticated user's home timeline",
 				ArgsUsage: " ",
 				Flags:     commonFlags,
-				Action:    FetchTimelineAction,
+				Action:    withRegistry(FetchTimelineAction),
 			},
 			{
 				Name:      "feed",
 				Usage:     "Fetch posts from a specific feed by URI or local feed ID",
-				ArgsUsage: "<feed-uri-or-id>",
-				Flags:     commonFlags,
-				Action:    FetchFeedAction,
+				UsageText: "With several saved feed IDs or --all, refreshes each feed concurrently, stores the posts, and prints a summary of new posts per feed.",
+				ArgsUsage: "<feed-uri-or-id> [feed-id...]",
+				Flags: append(slices.Clone(commonFlags),
+					&cli.BoolFlag{
+						Name:    "all",
+						Aliases: []string{"a"},
+						Usage:   "Refresh every saved feed",
+					},
+					&cli.IntFlag{
+						Name:    "parallel",
+						Aliases: []string{"p"},
+						Usage:   "Maximum number of feeds refreshed at once",
+						Value:   4,
+					},
+				),
+				Action: withRegistry(FetchFeedAction),
 			},
 			{
 				Name:      "author",
 				Usage:     "Fetch posts from a specific author (with profile caching)",
 				ArgsUsage: "<actor-handle-or-did>",
 				Flags:     commonFlags,
-				Action:    FetchAuthorAction,
+				Action:    withRegistry(FetchAuthorAction),
 			},
 		},
-		Action: func(ctx context.Context, cmd *cli.Command) error {
-			return FetchTimelineAction(ctx, cmd)
-		},
-		Flags: commonFlags,
+		Action: withRegistry(FetchTimelineAction),
+		Flags:  commonFlags,
 	}
 }
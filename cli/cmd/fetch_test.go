@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// newFeedServer serves getAuthorFeed with posts named after the requested actor, failing for "broken"
+func newFeedServer(t *testing.T) *bsky.BlueskyService {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := r.URL.Query().Get("actor")
+		if actor == "broken" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var items []string
+		for i := range 3 {
+			items = append(items, fmt.Sprintf(`{"post":{"uri":"at://%s/app.bsky.feed.post/%d","author":{"did":"did:plc:%s"},"record":{"text":"post %d"},"indexedAt":"2025-01-01T00:00:00Z"}}`, actor, i, actor, i))
+		}
+		fmt.Fprintf(w, `{"feed":[%s]}`, strings.Join(items, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	service := bsky.NewBlueskyService(server.URL)
+	service.SetTokens("access", "refresh")
+	return service
+}
+
+// newFeedRepos opens feed and post repositories in a temporary config directory
+func newFeedRepos(t *testing.T) (*bsky.FeedRepository, *bsky.PostRepository) {
+	t.Helper()
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+
+	feedRepo, err := bsky.NewFeedRepository()
+	if err != nil {
+		t.Fatalf("NewFeedRepository failed: %v", err)
+	}
+	t.Cleanup(func() { feedRepo.Close() })
+	postRepo, err := bsky.NewPostRepository()
+	if err != nil {
+		t.Fatalf("NewPostRepository failed: %v", err)
+	}
+	t.Cleanup(func() { postRepo.Close() })
+
+	if err := feedRepo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	return feedRepo, postRepo
+}
+
+func TestResolveSavedFeeds(t *testing.T) {
+	feedRepo, _ := newFeedRepos(t)
+	ctx := context.Background()
+
+	art := &bsky.FeedModel{Name: "Art", Source: "art.bsky.social"}
+	news := &bsky.FeedModel{Name: "News", Source: "news.bsky.social"}
+	for _, feed := range []*bsky.FeedModel{art, news} {
+		if err := feedRepo.Save(ctx, feed); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	all, err := resolveSavedFeeds(ctx, feedRepo, nil, true)
+	if err != nil || len(all) != 2 {
+		t.Fatalf("expected both saved feeds with --all, got %d (err %v)", len(all), err)
+	}
+
+	picked, err := resolveSavedFeeds(ctx, feedRepo, []string{news.ID(), "art.bsky.social"}, false)
+	if err != nil {
+		t.Fatalf("resolveSavedFeeds failed: %v", err)
+	}
+	if len(picked) != 2 || picked[0].Name != "News" || picked[1].Name != "Art" {
+		t.Errorf("expected feeds resolved by ID and source in order, got %+v", picked)
+	}
+
+	if _, err := resolveSavedFeeds(ctx, feedRepo, []string{"unknown"}, false); err == nil {
+		t.Error("expected error for an unsaved feed")
+	}
+}
+
+func TestRefreshFeed(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	service := newFeedServer(t)
+	ctx := context.Background()
+
+	feed := &bsky.FeedModel{Name: "Art", Source: "art"}
+	if err := feedRepo.Save(ctx, feed); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	first := refreshFeed(ctx, service, postRepo, feed, 10)
+	if first.Error != "" || first.Fetched != 3 || first.New != 3 {
+		t.Errorf("expected 3 new posts on first refresh, got %+v", first)
+	}
+
+	second := refreshFeed(ctx, service, postRepo, feed, 10)
+	if second.Error != "" || second.Fetched != 3 || second.New != 0 {
+		t.Errorf("expected no new posts on second refresh, got %+v", second)
+	}
+
+	count, err := postRepo.CountByFeedID(ctx, feed.ID())
+	if err != nil || count != 3 {
+		t.Errorf("expected 3 stored posts, got %d (err %v)", count, err)
+	}
+
+	broken := refreshFeed(ctx, service, postRepo, &bsky.FeedModel{Name: "Broken", Source: "broken"}, 10)
+	if broken.Error == "" {
+		t.Error("expected failed fetch to be reported")
+	}
+}
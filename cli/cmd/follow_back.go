@@ -0,0 +1,267 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// followBackCandidate is a follower the user does not follow yet, with the signals behind its score
+type followBackCandidate struct {
+	Did         string    `json:"did"`
+	Handle      string    `json:"handle"`
+	DisplayName string    `json:"displayName,omitempty"`
+	Followers   int       `json:"followers"`
+	Follows     int       `json:"follows"`
+	Posts       int       `json:"posts"`
+	LastPost    time.Time `json:"lastPost,omitzero"`
+	Score       int       `json:"score"`
+}
+
+// FollowBackAction lists followers the user doesn't follow, filtered by score and activity, and follows them
+// after confirmation. Each follow is recorded in the undo log.
+func FollowBackAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	rateCache, err := reg.GetRateCache()
+	if err != nil {
+		return fmt.Errorf("failed to get rate cache: %w", err)
+	}
+
+	outputFormat := cmd.String("output")
+	if outputFormat != "table" && outputFormat != "json" {
+		return fmt.Errorf("output format must be 'table' or 'json'")
+	}
+	minScore := cmd.Int("min-score")
+	if minScore < 0 || minScore > 100 {
+		return fmt.Errorf("--min-score must be between 0 and 100")
+	}
+
+	me := fetcher.GetDid()
+
+	pageOpts, err := graphPageOptions(cmd, "followers")
+	if err != nil {
+		return err
+	}
+	followers, err := bsky.NewPaginator(bsky.FollowerPages(fetcher, me), pageOpts).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch followers: %w", capError(cmd, "followers", err))
+	}
+
+	following, err := bsky.NewPaginator(bsky.FollowPages(fetcher, me), bsky.PaginatorOptions{
+		Progress: logPageProgress("following"),
+	}).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch following: %w", err)
+	}
+
+	skip := make(map[string]bool, len(following)+1)
+	skip[me] = true
+	for _, follow := range following {
+		skip[follow.Did] = true
+	}
+
+	var pending []bsky.ActorProfile
+	for _, follower := range followers {
+		if skip[follower.Did] {
+			continue
+		}
+		skip[follower.Did] = true
+		pending = append(pending, follower)
+	}
+
+	logger.Infof("%d of %d followers are not followed back", len(pending), len(followers))
+
+	infos, actors := enrichFollowerProfiles(ctx, profiles, pending, logger)
+	lastPosts := bsky.BatchGetLastPostDatesCached(ctx, profiles, rateCache, actors, 0, cmd.Bool("refresh"))
+
+	now := time.Now()
+	activeDays := cmd.Int("active")
+	var candidates []followBackCandidate
+	for _, info := range infos {
+		lastPost := lastPosts[info.Profile.Did]
+		if activeDays > 0 && (lastPost.IsZero() || now.Sub(lastPost) > time.Duration(activeDays)*24*time.Hour) {
+			continue
+		}
+
+		candidate := followBackCandidate{
+			Did:         info.Profile.Did,
+			Handle:      info.Profile.Handle,
+			DisplayName: info.Profile.DisplayName,
+			Followers:   info.Profile.FollowersCount,
+			Follows:     info.Profile.FollowsCount,
+			Posts:       info.Profile.PostsCount,
+			LastPost:    lastPost,
+			Score:       followBackScore(info.Profile, lastPost, now),
+		}
+		if candidate.Score >= minScore {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b followBackCandidate) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	if limit := cmd.Int("max"); limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(cmd.Root().Writer)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(candidates); err != nil {
+			return err
+		}
+	} else {
+		displayFollowBackCandidates(candidates)
+	}
+
+	if cmd.Bool("dry-run") || len(candidates) == 0 {
+		return nil
+	}
+
+	writer, err := reg.GetGraphWriter()
+	if err != nil {
+		return fmt.Errorf("failed to get graph writer: %w", err)
+	}
+	actions, err := reg.GetActionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get action log: %w", err)
+	}
+
+	if ok, err := confirm(cmd, fmt.Sprintf("Follow %d account(s)?", len(candidates))); !ok {
+		return err
+	}
+
+	followed, failed := 0, 0
+	for i, candidate := range candidates {
+		if i > 0 {
+			if err := waitPace(ctx, cmd.Duration("pace")); err != nil {
+				return err
+			}
+		}
+
+		record, err := writer.Follow(ctx, candidate.Did)
+		if err != nil {
+			logger.Warn("Failed to follow", "did", candidate.Did, "error", err)
+			failed++
+			continue
+		}
+		followed++
+		recordAction(ctx, actions, bsky.ActionFollow, candidate.Did, record.Uri)
+		logger.Debugf("Followed %d/%d", i+1, len(candidates))
+	}
+
+	report := ui.Successln
+	if outputFormat == "json" {
+		report = logger.Infof
+	}
+	report("Followed %d account(s) (%d failed); run 'skycli undo' to revert", followed, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to follow %d account(s)", failed)
+	}
+	return nil
+}
+
+// followBackScore rates how likely an account is a real, active person on a 0-100 scale:
+// up to 20 for a filled-in profile, 30 for posting history, 30 for a healthy followers-to-follows ratio
+// (mass-follow accounts score low), and 20 for posting in the last 30 days
+func followBackScore(profile *bsky.ActorProfile, lastPost, now time.Time) int {
+	score := 0.0
+	if profile.Avatar != "" {
+		score += 10
+	}
+	if profile.Description != "" {
+		score += 10
+	}
+
+	score += 30 * float64(min(profile.PostsCount, 100)) / 100
+
+	if profile.FollowsCount == 0 {
+		if profile.FollowersCount > 0 {
+			score += 30
+		}
+	} else {
+		score += 30 * min(float64(profile.FollowersCount)/float64(profile.FollowsCount), 1)
+	}
+
+	if !lastPost.IsZero() && now.Sub(lastPost) <= 30*24*time.Hour {
+		score += 20
+	}
+	return int(score + 0.5)
+}
+
+// displayFollowBackCandidates renders candidates as a table, highest score first
+func displayFollowBackCandidates(candidates []followBackCandidate) {
+	if len(candidates) == 0 {
+		ui.Infoln("No followers to follow back")
+		return
+	}
+
+	ui.Titleln("Follow-back candidates (%d)", len(candidates))
+	fmt.Println()
+
+	rows := make([][]string, len(candidates))
+	for i, candidate := range candidates {
+		displayName := candidate.DisplayName
+		if displayName == "" {
+			displayName = candidate.Handle
+		}
+		rows[i] = []string{
+			"@" + candidate.Handle,
+			displayName,
+			fmt.Sprintf("%d", candidate.Score),
+			fmt.Sprintf("%d", candidate.Followers),
+			fmt.Sprintf("%d", candidate.Posts),
+			formatLastPost(candidate.LastPost),
+		}
+	}
+
+	headers := []string{"Handle", "Display Name", "Score", "Followers", "Posts", "Last Post"}
+	if ui.ListAsLines() {
+		fmt.Println(ui.FormatLines(headers, rows))
+		fmt.Println()
+		return
+	}
+
+	fit := ui.FitTable(headers, rows, map[string]int{"Display Name": 3, "Posts": 2, "Followers": 1}, "Display Name")
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers(fit.Headers...).Rows(fit.Rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	fmt.Println(re.NewStyle().Render(t.String()))
+	fit.HiddenNote()
+	fmt.Println()
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestFollowBackAction(t *testing.T) {
+	newGraph := func() *fakeGraph {
+		return &fakeGraph{
+			authenticated: true,
+			did:           "did:plc:me",
+			pageSize:      2,
+			followers:     testProfiles("did:plc:a", "did:plc:b", "did:plc:c", "did:plc:d", "did:plc:me"),
+			follows:       testProfiles("did:plc:b"),
+			lastPostDates: map[string]time.Time{
+				"did:plc:a": time.Now().Add(-24 * time.Hour),
+				"did:plc:c": time.Now().Add(-90 * 24 * time.Hour),
+			},
+		}
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		graph := newGraph()
+		reg, _ := newActionRegistry(t, graph)
+		out, err := runSubcommand(t, FollowersCommand(), "follow-back", FollowBackAction, reg, "--output", "json", "--dry-run")
+		if err != nil {
+			t.Fatalf("FollowBackAction failed: %v", err)
+		}
+
+		var candidates []followBackCandidate
+		if err := json.Unmarshal([]byte(out), &candidates); err != nil {
+			t.Fatalf("failed to decode JSON output %q: %v", out, err)
+		}
+		var dids []string
+		for _, candidate := range candidates {
+			dids = append(dids, candidate.Did)
+		}
+		slices.Sort(dids)
+		if want := []string{"did:plc:a", "did:plc:c", "did:plc:d"}; !slices.Equal(dids, want) {
+			t.Errorf("candidates %v, want %v", dids, want)
+		}
+		if len(graph.followed) != 0 {
+			t.Errorf("dry run followed %v", graph.followed)
+		}
+	})
+
+	t.Run("FollowsActive", func(t *testing.T) {
+		graph := newGraph()
+		reg, actions := newActionRegistry(t, graph)
+		parent := FollowersCommand()
+		parent.Reader = strings.NewReader("y\n")
+		if _, err := runSubcommand(t, parent, "follow-back", FollowBackAction, reg, "--output", "json", "--active", "30", "--pace", "0"); err != nil {
+			t.Fatalf("FollowBackAction failed: %v", err)
+		}
+		if !slices.Equal(graph.followed, []string{"did:plc:a"}) {
+			t.Errorf("followed %v, want only the active follower", graph.followed)
+		}
+
+		logged, err := actions.Recent(context.Background(), 0, false)
+		if err != nil {
+			t.Fatalf("Recent failed: %v", err)
+		}
+		if len(logged) != 1 || logged[0].Action != bsky.ActionFollow || logged[0].SubjectDid != "did:plc:a" {
+			t.Errorf("unexpected undo log %+v", logged)
+		}
+	})
+}
+
+func TestFollowBackScore(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		profile  bsky.ActorProfile
+		lastPost time.Time
+		want     int
+	}{
+		{"Empty", bsky.ActorProfile{}, time.Time{}, 0},
+		{
+			name:     "Complete",
+			profile:  bsky.ActorProfile{Avatar: "a", Description: "d", PostsCount: 500, FollowersCount: 300, FollowsCount: 100},
+			lastPost: now.Add(-time.Hour),
+			want:     100,
+		},
+		{
+			name:    "MassFollower",
+			profile: bsky.ActorProfile{PostsCount: 50, FollowersCount: 10, FollowsCount: 5000},
+			want:    15,
+		},
+		{
+			name:     "StalePoster",
+			profile:  bsky.ActorProfile{Description: "d", PostsCount: 20, FollowersCount: 50, FollowsCount: 100},
+			lastPost: now.Add(-60 * 24 * time.Hour),
+			want:     31,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := followBackScore(&tt.profile, tt.lastPost, now); got != tt.want {
+				t.Errorf("followBackScore = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// Sources of followerInfo.FollowedAt
+const (
+	followDateRecord   = "record"   // the follow record's createdAt
+	followDateSnapshot = "snapshot" // the oldest snapshot holding the account
+	followDateUnknown  = "unknown"
+)
+
+// followCollection holds follow records in a repository
+const followCollection = "app.bsky.graph.follow"
+
+// followRecordPages caps how many pages of a follower's follow records are searched for their follow of the user
+const followRecordPages = 10
+
+// followRecordWorkers caps how many followers' repositories are searched at once
+const followRecordWorkers = 8
+
+// followDatesFlag adds when each follow began to follower and following output
+func followDatesFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "follow-dates",
+		Usage: "Add when each follow began and sort oldest first: from the follow record, or when a snapshot first saw the account",
+	}
+}
+
+// parseFollowRecord decodes a follow record, reporting false when it has no subject or timestamp
+func parseFollowRecord(record bsky.Record) (followRecord, time.Time, bool) {
+	var follow followRecord
+	if err := json.Unmarshal(record.Value, &follow); err != nil || follow.Subject == "" {
+		return follow, time.Time{}, false
+	}
+	createdAt, err := time.Parse(time.RFC3339, follow.CreatedAt)
+	return follow, createdAt, err == nil
+}
+
+// addFollowerDates sets when each of actor's followers followed them. Each follower's own repository is searched
+// for their follow record; followers whose repository can't be read, or whose record isn't among their newest
+// follows, fall back to the oldest of actor's follower snapshots that holds them.
+func addFollowerDates(ctx context.Context, reg *registry.Registry, actor string, followers []followerInfo) {
+	records, err := reg.GetRecordFetcher()
+	if err != nil {
+		logger.Warn("Follow records unavailable; using snapshots only", "error", err)
+	}
+
+	if records != nil {
+		sem := make(chan struct{}, followRecordWorkers)
+		var wg sync.WaitGroup
+		for i := range followers {
+			wg.Add(1)
+			go func(info *followerInfo) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				if createdAt, ok := findFollowRecord(ctx, records, info.Profile.Did, actor); ok {
+					info.FollowedAt, info.FollowedAtSource = createdAt, followDateRecord
+				}
+			}(&followers[i])
+		}
+		wg.Wait()
+	}
+
+	addSnapshotDates(ctx, reg, actor, "followers", followers)
+}
+
+// findFollowRecord searches the newest follow records in follower's repository for their follow of subject
+func findFollowRecord(ctx context.Context, records bsky.RecordFetcher, follower, subject string) (time.Time, bool) {
+	paginator := bsky.NewPaginator(bsky.RecordPages(records, follower, followCollection), bsky.PaginatorOptions{})
+	for page := 0; page < followRecordPages && paginator.HasNext(); page++ {
+		items, err := paginator.Next(ctx)
+		if err != nil {
+			logger.Debug("Failed to read follow records", "repo", follower, "error", err)
+			return time.Time{}, false
+		}
+		for _, record := range items {
+			if follow, createdAt, ok := parseFollowRecord(record); ok && follow.Subject == subject {
+				return createdAt, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// addFollowingDates sets when actor followed each account, from the follow records in actor's repository,
+// falling back to actor's following snapshots
+func addFollowingDates(ctx context.Context, reg *registry.Registry, actor string, follows []followerInfo) {
+	records, err := reg.GetRecordFetcher()
+	if err != nil {
+		logger.Warn("Follow records unavailable; using snapshots only", "error", err)
+	}
+
+	if records != nil {
+		items, err := bsky.NewPaginator(bsky.RecordPages(records, actor, followCollection), bsky.PaginatorOptions{}).All(ctx)
+		if err != nil {
+			logger.Warn("Failed to read follow records; using snapshots only", "error", err)
+		}
+
+		// A repeated follow leaves several records; the relationship began with the oldest
+		followedAt := make(map[string]time.Time, len(items))
+		for _, record := range items {
+			follow, createdAt, ok := parseFollowRecord(record)
+			if !ok {
+				continue
+			}
+			if seen, ok := followedAt[follow.Subject]; !ok || createdAt.Before(seen) {
+				followedAt[follow.Subject] = createdAt
+			}
+		}
+		for i := range follows {
+			if createdAt, ok := followedAt[follows[i].Profile.Did]; ok {
+				follows[i].FollowedAt, follows[i].FollowedAtSource = createdAt, followDateRecord
+			}
+		}
+	}
+
+	addSnapshotDates(ctx, reg, actor, "following", follows)
+}
+
+// addSnapshotDates dates accounts still missing a follow date by the oldest of actor's snapshots of snapshotType
+// holding them, and marks the rest unknown
+func addSnapshotDates(ctx context.Context, reg *registry.Registry, actor, snapshotType string, accounts []followerInfo) {
+	var firstSeen map[string]time.Time
+	if snapshotRepo, err := reg.GetSnapshotRepo(); err == nil {
+		if firstSeen, err = snapshotRepo.FirstSeen(ctx, actor, snapshotType); err != nil {
+			logger.Warn("Failed to read snapshots for follow dates", "error", err)
+		}
+	}
+
+	for i := range accounts {
+		if accounts[i].FollowedAtSource != "" {
+			continue
+		}
+		if seen, ok := firstSeen[accounts[i].Profile.Did]; ok {
+			accounts[i].FollowedAt, accounts[i].FollowedAtSource = seen, followDateSnapshot
+		} else {
+			accounts[i].FollowedAtSource = followDateUnknown
+		}
+	}
+}
+
+// sortByFollowDate orders accounts by when the follow began, oldest first, with undated accounts last
+func sortByFollowDate(accounts []followerInfo) {
+	slices.SortStableFunc(accounts, func(a, b followerInfo) int {
+		switch {
+		case a.FollowedAt.IsZero() && b.FollowedAt.IsZero():
+			return 0
+		case a.FollowedAt.IsZero():
+			return 1
+		case b.FollowedAt.IsZero():
+			return -1
+		}
+		return a.FollowedAt.Compare(b.FollowedAt)
+	})
+}
+
+// followDateRows reports whether accounts carry follow dates, i.e. were run through addFollowerDates or
+// addFollowingDates
+func followDateRows(accounts []followerInfo) bool {
+	return slices.ContainsFunc(accounts, func(f followerInfo) bool { return f.FollowedAtSource != "" })
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// recentInteractions caps the interactions listed in a follower's detail
+const recentInteractions = 5
+
+// followerDetail is everything known about one account, as shown by followers show
+type followerDetail struct {
+	Profile      *bsky.ActorProfile   `json:"profile"`
+	FollowsYou   bool                 `json:"followsYou"`
+	YouFollow    bool                 `json:"youFollow"`
+	PostsPerDay  float64              `json:"postsPerDay"`
+	LastPostDate time.Time            `json:"lastPostDate,omitzero"`
+	FirstSeen    time.Time            `json:"firstSeen,omitzero"` // when a follower snapshot first held the account
+	Interactions followerInteractions `json:"interactions"`
+	Note         string               `json:"note,omitempty"`
+	Tags         []string             `json:"tags"`
+	FetchFailed  []string             `json:"fetchFailed,omitempty"` // steps that failed, whose fields are left empty
+}
+
+// followerInteractions is what an account did with your posts and account, from your notifications
+type followerInteractions struct {
+	Checked int            `json:"checked"` // notifications searched
+	Counts  map[string]int `json:"counts"`  // by notification reason: like, repost, reply, quote, mention, follow
+	Recent  []interaction  `json:"recent"`
+}
+
+// interaction is one notification from an account
+type interaction struct {
+	Reason  string    `json:"reason"`
+	URI     string    `json:"uri"`
+	Subject string    `json:"subject,omitempty"` // your post it concerns, for likes, reposts, and quotes
+	At      time.Time `json:"at"`
+}
+
+// Detail steps recorded in followerDetail.FetchFailed, besides fetchPostRate
+const fetchNotifications = "notifications"
+
+// followersShowCommand returns the followers show subcommand
+func followersShowCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "show",
+		Usage: "Show everything known about one follower",
+		UsageText: "Combines the cached profile, post rate, when a follower snapshot first saw the account, its recent interactions " +
+			"with your posts (from your notifications), and your local note and tags on it.",
+		ArgsUsage: "<@handle|DID>",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "notifications",
+				Usage: "Maximum number of your notifications to search for interactions (0 = no limit)",
+				Value: 1000,
+			},
+			&cli.BoolFlag{
+				Name:  "refresh",
+				Usage: "Force refresh the cached profile and post rate",
+			},
+			&cli.BoolFlag{
+				Name:    "json",
+				Aliases: []string{"j"},
+				Usage:   "Output as JSON",
+			},
+		},
+		Action: withRegistry(FollowersShowAction),
+	}
+}
+
+// FollowersShowAction prints a detailed panel on one account
+func FollowersShowAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("account handle or DID required")
+	}
+
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	did, err := resolveActor(ctx, profiles, "account", cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	detail, err := followerDetails(ctx, cmd, reg, profiles, fetcher.GetDid(), did)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(detail)
+	}
+	displayFollowerDetail(detail)
+	return nil
+}
+
+// followerDetails gathers what is known about did. Only the profile is required; the other parts are left empty
+// and recorded in FetchFailed when they can't be read.
+func followerDetails(ctx context.Context, cmd *cli.Command, reg *registry.Registry, profiles bsky.ProfileFetcher, me, did string) (*followerDetail, error) {
+	refresh := cmd.Bool("refresh")
+
+	profileRepo, err := reg.GetProfileRepo()
+	if err != nil {
+		logger.Debug("Profile cache unavailable", "error", err)
+	}
+	profile, err := cachedProfile(ctx, profileRepo, profiles, did, refresh)
+	if err != nil {
+		return nil, profileError("account", cmd.Args().First(), err)
+	}
+
+	detail := &followerDetail{Profile: profile, Tags: []string{}}
+	if profile.Viewer != nil {
+		detail.FollowsYou = profile.Viewer.FollowedBy != ""
+		detail.YouFollow = profile.Viewer.Following != ""
+	}
+
+	if rateCache, err := reg.GetRateCache(); err == nil {
+		rates := bsky.BatchGetPostRatesCached(ctx, profiles, rateCache, []string{did}, 30, 30, 0, refresh, nil)
+		if rate, ok := rates[did]; ok {
+			detail.PostsPerDay, detail.LastPostDate = rate.PostsPerDay, rate.LastPostDate
+		} else {
+			detail.FetchFailed = append(detail.FetchFailed, fetchPostRate)
+		}
+	} else {
+		detail.FetchFailed = append(detail.FetchFailed, fetchPostRate)
+	}
+
+	if snapshotRepo, err := reg.GetSnapshotRepo(); err == nil {
+		firstSeen, err := snapshotRepo.FirstSeen(ctx, me, "followers")
+		if err != nil {
+			logger.Warn("Failed to read snapshots", "error", err)
+		}
+		detail.FirstSeen = firstSeen[did]
+	}
+
+	if notifications, err := reg.GetNotificationFetcher(); err == nil {
+		items, err := notificationsSince(ctx, notifications, time.Time{}, cmd.Int("notifications"))
+		if err != nil {
+			logger.Warn("Failed to read notifications", "error", err)
+			detail.FetchFailed = append(detail.FetchFailed, fetchNotifications)
+		}
+		detail.Interactions = interactionsFrom(items, did)
+	} else {
+		detail.FetchFailed = append(detail.FetchFailed, fetchNotifications)
+	}
+	if detail.Interactions.Counts == nil {
+		detail.Interactions = followerInteractions{Counts: map[string]int{}, Recent: []interaction{}}
+	}
+
+	if noteRepo, err := reg.GetNoteRepo(); err == nil {
+		note, err := noteRepo.Get(ctx, did)
+		if err == nil {
+			detail.Note = note.Note
+		} else if !errors.Is(err, bsky.ErrNotFound) {
+			logger.Warn("Failed to read note", "error", err)
+		}
+	}
+
+	if tagRepo, err := reg.GetTagRepo(); err == nil {
+		tags, err := tagRepo.TagsOf(ctx, bsky.TagActor, did)
+		if err != nil {
+			logger.Warn("Failed to read tags", "error", err)
+		}
+		if tags != nil {
+			detail.Tags = tags
+		}
+	}
+
+	return detail, nil
+}
+
+// interactionsFrom counts the notifications from did by reason and keeps the newest few
+func interactionsFrom(notifications []bsky.Notification, did string) followerInteractions {
+	result := followerInteractions{Checked: len(notifications), Counts: map[string]int{}, Recent: []interaction{}}
+	for _, notification := range notifications {
+		if notification.Author.Did != did {
+			continue
+		}
+		result.Counts[notification.Reason]++
+		if len(result.Recent) < recentInteractions {
+			at, _ := time.Parse(time.RFC3339, notification.IndexedAt)
+			result.Recent = append(result.Recent, interaction{
+				Reason:  notification.Reason,
+				URI:     notification.Uri,
+				Subject: notification.ReasonSubject,
+				At:      at,
+			})
+		}
+	}
+	return result
+}
+
+// displayFollowerDetail prints the detail panel
+func displayFollowerDetail(detail *followerDetail) {
+	ui.DisplayProfileHeader(detail.Profile)
+
+	var relationship []string
+	if detail.FollowsYou {
+		relationship = append(relationship, "follows you")
+	}
+	if detail.YouFollow {
+		relationship = append(relationship, "you follow")
+	}
+	if len(relationship) == 0 {
+		relationship = append(relationship, "not connected")
+	}
+	ui.Infoln("  Relationship: %s", strings.Join(relationship, ", "))
+
+	if slices.Contains(detail.FetchFailed, fetchPostRate) {
+		ui.Infoln("  Posting: %s", fetchFailedCell)
+	} else {
+		ui.Infoln("  Posting: %.2f posts/day, last post %s", detail.PostsPerDay, formatLastPost(detail.LastPostDate))
+	}
+
+	if detail.FirstSeen.IsZero() {
+		ui.Infoln("  First seen: not in any follower snapshot")
+	} else {
+		ui.Infoln("  First seen: %s (follower snapshot)", ui.FormatDate(detail.FirstSeen, ui.DatesLocal))
+	}
+
+	if len(detail.Tags) > 0 {
+		ui.Infoln("  Tags: %s", strings.Join(detail.Tags, ", "))
+	}
+	fmt.Println()
+
+	interactions := detail.Interactions
+	ui.Subtitleln("Interactions (last %d notifications)", interactions.Checked)
+	switch {
+	case slices.Contains(detail.FetchFailed, fetchNotifications) && interactions.Checked == 0:
+		fmt.Printf("  %s\n", fetchFailedCell)
+	case len(interactions.Counts) == 0:
+		fmt.Println("  None")
+	default:
+		reasons := make([]string, 0, len(interactions.Counts))
+		for reason := range interactions.Counts {
+			reasons = append(reasons, reason)
+		}
+		slices.SortFunc(reasons, func(a, b string) int {
+			return cmp.Or(cmp.Compare(interactions.Counts[b], interactions.Counts[a]), cmp.Compare(a, b))
+		})
+
+		parts := make([]string, len(reasons))
+		for i, reason := range reasons {
+			parts[i] = interactionLabel(reason, interactions.Counts[reason])
+		}
+		fmt.Printf("  %s\n", strings.Join(parts, ", "))
+
+		rows := make([][]string, len(interactions.Recent))
+		for i, item := range interactions.Recent {
+			target := item.Subject
+			if target == "" {
+				target = item.URI
+			}
+			rows[i] = []string{item.Reason, ui.FormatDate(item.At, ui.DatesLocal), target}
+		}
+		fmt.Println(stripedTable([]string{"Reason", "When", "Post"}, rows))
+	}
+
+	if detail.Note != "" {
+		fmt.Println()
+		ui.Subtitleln("Note")
+		printNote(detail.Note)
+	}
+}
+
+// interactionLabel counts a notification reason, such as "3 likes", using the notification summary's names
+func interactionLabel(reason string, count int) string {
+	if reason == "follow" {
+		if count == 1 {
+			return "followed you"
+		}
+		return fmt.Sprintf("followed you %d times", count)
+	}
+	label, ok := notificationLabels[reason]
+	if !ok {
+		return fmt.Sprintf("%d %s", count, reason)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, label[0])
+	}
+	return fmt.Sprintf("%d %s", count, label[1])
+}
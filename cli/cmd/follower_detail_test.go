@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+func TestFollowerDetails(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	noteRepo, err := bsky.NewNoteRepository()
+	if err != nil {
+		t.Fatalf("NewNoteRepository failed: %v", err)
+	}
+	t.Cleanup(func() { noteRepo.Close() })
+	if err := noteRepo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	tagRepo, err := bsky.NewTagRepository()
+	if err != nil {
+		t.Fatalf("NewTagRepository failed: %v", err)
+	}
+	t.Cleanup(func() { tagRepo.Close() })
+
+	if err := noteRepo.Set(ctx, "did:plc:b", "", "met at conf"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, err := tagRepo.Add(ctx, "vip", bsky.TagActor, "did:plc:b"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	notification := func(did, reason, subject, at string) bsky.Notification {
+		return bsky.Notification{Author: bsky.ActorProfile{Did: did}, Reason: reason, ReasonSubject: subject, Uri: "at://" + did + "/x/" + at, IndexedAt: at}
+	}
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 2, notifications: []bsky.Notification{
+		notification("did:plc:b", "like", "at://did:plc:me/app.bsky.feed.post/2", "2026-10-03T00:00:00Z"),
+		notification("did:plc:a", "like", "at://did:plc:me/app.bsky.feed.post/2", "2026-10-02T00:00:00Z"),
+		notification("did:plc:b", "reply", "", "2026-10-02T00:00:00Z"),
+		notification("did:plc:b", "like", "at://did:plc:me/app.bsky.feed.post/1", "2026-10-01T00:00:00Z"),
+	}}
+	reg := registry.New(registry.Dependencies{
+		FollowerFetcher: graph,
+		ProfileFetcher:  graph,
+		Notifications:   graph,
+		RateCache:       memoryRateCache{},
+		NoteRepo:        noteRepo,
+		TagRepo:         tagRepo,
+	})
+
+	var detail *followerDetail
+	show := func(ctx context.Context, cmd *cli.Command, reg *registry.Registry) (err error) {
+		detail, err = followerDetails(ctx, cmd, reg, graph, graph.did, "did:plc:b")
+		return err
+	}
+	if _, err := runSubcommand(t, FollowersCommand(), "show", show, reg, "did:plc:b"); err != nil {
+		t.Fatalf("followerDetails failed: %v", err)
+	}
+
+	if detail.Profile == nil || detail.Profile.Did != "did:plc:b" {
+		t.Errorf("unexpected profile %+v", detail.Profile)
+	}
+	if detail.Interactions.Checked != 4 || detail.Interactions.Counts["like"] != 2 || detail.Interactions.Counts["reply"] != 1 {
+		t.Errorf("unexpected interactions %+v", detail.Interactions)
+	}
+	if len(detail.Interactions.Recent) != 3 || detail.Interactions.Recent[0].Subject != "at://did:plc:me/app.bsky.feed.post/2" {
+		t.Errorf("unexpected recent interactions %+v", detail.Interactions.Recent)
+	}
+	if detail.Note != "met at conf" || !slices.Equal(detail.Tags, []string{"vip"}) {
+		t.Errorf("note %q and tags %v, want the local note and tag", detail.Note, detail.Tags)
+	}
+	// The fake has no post rates, which shows as a failed step rather than zero
+	if !slices.Equal(detail.FetchFailed, []string{fetchPostRate}) {
+		t.Errorf("FetchFailed = %v, want only the post rate", detail.FetchFailed)
+	}
+
+	if _, err := runSubcommand(t, FollowersCommand(), "show", FollowersShowAction, reg, "did:plc:b"); err != nil {
+		t.Errorf("FollowersShowAction failed: %v", err)
+	}
+}
@@ -5,28 +5,135 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	lgtable "github.com/charmbracelet/lipgloss/table"
 	"github.com/charmbracelet/log"
+	"github.com/stormlightlabs/skypanel/cli/internal/analytics"
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/dateparse"
+	"github.com/stormlightlabs/skypanel/cli/internal/export"
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
-	"github.com/stormlightlabs/skypanel/cli/internal/setup"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
 // followerInfo holds enriched follower data for display and export
 type followerInfo struct {
-	Profile       *store.ActorProfile
-	LastPostDate  time.Time
-	DaysSincePost int
-	IsInactive    bool
-	PostsPerDay   float64
-	IsQuiet       bool
+	Profile          *bsky.ActorProfile
+	LastPostDate     time.Time
+	DaysSincePost    int
+	IsInactive       bool
+	PostsPerDay      float64
+	PostsByDay       []int `json:",omitempty"` // posts in each of the last 7 days, oldest first, with the post rate; -1 when unknown
+	IsQuiet          bool
+	FollowedAt       time.Time `json:",omitzero"`  // when the follow began, with --follow-dates
+	FollowedAtSource string    `json:",omitempty"` // where FollowedAt came from: record, snapshot, or unknown
+	FetchFailed      []string  `json:",omitempty"` // enrichment steps that failed, whose fields hold fallback values
+	Note             string    `json:",omitempty"` // local note from followers note
+}
+
+// Enrichment steps recorded in followerInfo.FetchFailed
+const (
+	fetchProfile  = "profile"
+	fetchActivity = "activity"
+	fetchPostRate = "post rate"
+)
+
+// fetchFailedCell replaces table values that couldn't be fetched
+const fetchFailedCell = "(fetch failed)"
+
+// failed reports whether the enrichment step failed for this account
+func (f followerInfo) failed(step string) bool {
+	return slices.Contains(f.FetchFailed, step)
+}
+
+// quietRows reports whether followers carry post rates, i.e. were run through the quiet filter
+func quietRows(followers []followerInfo) bool {
+	return slices.ContainsFunc(followers, func(f followerInfo) bool { return f.IsQuiet || f.failed(fetchPostRate) })
+}
+
+// countFetchFailures returns how many accounts are missing some enriched data
+func countFetchFailures(followers []followerInfo) int {
+	n := 0
+	for _, f := range followers {
+		if len(f.FetchFailed) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// checkFetchFailures fails with --strict when any of the accounts couldn't be fully fetched
+func checkFetchFailures(cmd *cli.Command, followers []followerInfo) error {
+	if n := countFetchFailures(followers); n > 0 && cmd.Bool("strict") {
+		return fmt.Errorf("couldn't fetch details for %d of %d accounts (--strict); rerun without it to see partial results", n, len(followers))
+	}
+	return nil
+}
+
+// warnFetchFailures reports at the end of a run how many accounts are shown with fallback values
+func warnFetchFailures(followers []followerInfo) {
+	if n := countFetchFailures(followers); n > 0 {
+		ui.Warningln("Couldn't fetch details for %d of %d accounts; affected values are marked %s (use --strict to fail instead)", n, len(followers), fetchFailedCell)
+	}
+}
+
+// strictFlag makes commands that enrich accounts fail when any lookup fails
+func strictFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "strict",
+		Usage: "Fail if any profile, activity, or post rate lookup fails instead of marking the affected rows",
+	}
+}
+
+// followerActivity counts followers by whether they posted recently
+type followerActivity struct {
+	Active       int `json:"active"`
+	Inactive     int `json:"inactive"`
+	InactiveDays int `json:"inactiveDays"`
+}
+
+// followerStatsOutput is the JSON form of followers stats
+type followerStatsOutput struct {
+	Actor          string                 `json:"actor,omitempty"` // set when several accounts are reported
+	TotalFollowers int                    `json:"totalFollowers"`
+	Activity       *followerActivity      `json:"activity,omitempty"`
+	Growth         analytics.GrowthReport `json:"growth"`
+}
+
+// retentionEntry marks which of the three follower sets of a retention diff contain an account
+type retentionEntry struct {
+	Did     string `json:"did"`
+	Before  bool   `json:"before"`
+	After   bool   `json:"after"`
+	Against bool   `json:"against"`
+}
+
+// retentionOutput is a three-way diff: followers gained and lost between two points in time, and whether
+// each was still following (or following again) at a third
+type retentionOutput struct {
+	Gained  []retentionEntry `json:"gained"`
+	Lost    []retentionEntry `json:"lost"`
+	Summary struct {
+		BeforeCount   int     `json:"beforeCount"`
+		AfterCount    int     `json:"afterCount"`
+		AgainstCount  int     `json:"againstCount"`
+		GainedCount   int     `json:"gainedCount"`
+		RetainedCount int     `json:"retainedCount"`
+		RetentionRate float64 `json:"retentionRate"` // Share of gained followers still following at the third point
+		LostCount     int     `json:"lostCount"`
+		ReturnedCount int     `json:"returnedCount"`
+	} `json:"summary"`
 }
 
 type diffOutput struct {
@@ -58,15 +165,11 @@ func FollowersCommand() *cli.Command {
 						Aliases: []string{"u"},
 						Usage:   "User handle or DID (defaults to authenticated user)",
 					},
-					&cli.IntFlag{
-						Name:    "limit",
-						Aliases: []string{"l"},
-						Usage:   "Maximum number of followers to fetch (0 = all)",
-						Value:   0,
-					},
+					limitFlag("followers"),
+					noLimitFlag("followers"),
 					&cli.StringFlag{
 						Name:  "since",
-						Usage: "Filter followers created after date (YYYY-MM-DD)",
+						Usage: "Filter followers created after a date (YYYY-MM-DD), time, lookback (7d), or expression (last monday)",
 					},
 					&cli.IntFlag{
 						Name:  "inactive",
@@ -77,6 +180,7 @@ func FollowersCommand() *cli.Command {
 						Name:  "quiet",
 						Usage: "Show only quiet posters (low posting frequency)",
 					},
+					tagFlag("Show only followers with this local tag"),
 					&cli.FloatFlag{
 						Name:  "threshold",
 						Usage: "Posts per day threshold for quiet posters (used with --quiet)",
@@ -92,40 +196,63 @@ func FollowersCommand() *cli.Command {
 						Name:  "refresh",
 						Usage: "Force refresh cached data (bypasses 24-hour cache)",
 					},
+					&cli.StringFlag{
+						Name:  "add-to-list",
+						Usage: "Add the filtered followers to a list you own (at:// URI or bsky.app link)",
+					},
+					&cli.DurationFlag{
+						Name:  "pace",
+						Usage: "Delay between list additions (used with --add-to-list)",
+						Value: time.Second,
+					},
+					strictFlag(),
 				},
-				Action: ListFollowersAction,
+				Action: withRegistry(ListFollowersAction),
 			},
 			{
 				Name:      "stats",
 				Usage:     "Show aggregate follower statistics",
-				UsageText: "Calculate aggregate statistics including active/inactive counts, growth metrics, and optional ASCII chart.",
+				UsageText: "Calculate aggregate statistics including active/inactive counts, growth metrics, and optional ASCII chart. Daily growth comes from when current followers followed, with a rolling average, percent growth, and days whose gains are unusually high or low (by z-score) flagged as spikes or drops.",
 				ArgsUsage: " ",
 				Flags: []cli.Flag{
-					&cli.StringFlag{
-						Name:    "user",
-						Aliases: []string{"u"},
-						Usage:   "User handle or DID (defaults to authenticated user)",
-					},
+					usersFlag(),
+					usersFileFlag(),
+					noLimitFlag("followers"),
 					&cli.StringFlag{
 						Name:  "since",
-						Usage: "Calculate growth since date (YYYY-MM-DD)",
+						Usage: "Calculate growth since a date (YYYY-MM-DD), time, lookback (7d), or expression (last monday); defaults to the last 30 days",
 					},
 					&cli.IntFlag{
 						Name:  "inactive",
 						Usage: "Threshold for inactive status (days)",
 						Value: 60,
 					},
+					&cli.IntFlag{
+						Name:  "window",
+						Usage: "Days in the rolling average of daily growth",
+						Value: 7,
+					},
+					&cli.FloatFlag{
+						Name:  "threshold",
+						Usage: "Z-score at which a day's growth is flagged as a spike or drop",
+						Value: 2,
+					},
 					&cli.BoolFlag{
 						Name:  "chart",
 						Usage: "Display ASCII bar chart",
 					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output as JSON",
+					},
 				},
-				Action: FollowersStatsAction,
+				Action: withRegistry(FollowersStatsAction),
 			},
 			{
 				Name:      "diff",
 				Usage:     "Compare follower lists between two dates",
-				UsageText: "Compare follower lists to identify new followers and unfollows. Without --until, compares snapshot to current live data.",
+				UsageText: "Compare follower lists to identify new followers and unfollows. Without --until, compares snapshot to current live data, read from the daemon's follower tracking when it is running. With --against, reports whether followers gained or lost between --since and --until were still following at a third point, e.g. before a campaign, after it, and now.",
 				ArgsUsage: " ",
 				Flags: []cli.Flag{
 					&cli.StringFlag{
@@ -133,14 +260,23 @@ func FollowersCommand() *cli.Command {
 						Aliases: []string{"u"},
 						Usage:   "User handle or DID (defaults to authenticated user)",
 					},
+					noLimitFlag("followers"),
 					&cli.StringFlag{
 						Name:     "since",
-						Usage:    "Start date (YYYY-MM-DD) or snapshot ID",
+						Usage:    "Start date (YYYY-MM-DD), time, lookback (7d), expression (last monday), or snapshot ID",
 						Required: true,
 					},
 					&cli.StringFlag{
 						Name:  "until",
-						Usage: "End date (YYYY-MM-DD) or snapshot ID (omit to compare with live data)",
+						Usage: "End date (YYYY-MM-DD), time, lookback, expression, or snapshot ID (omit to compare with live data)",
+					},
+					&cli.StringFlag{
+						Name:  "against",
+						Usage: "Date (YYYY-MM-DD), snapshot ID, or \"now\" to check followers gained between --since and --until against",
+					},
+					&cli.BoolFlag{
+						Name:  "refresh",
+						Usage: "Fetch current followers from the API even when the daemon is tracking them",
 					},
 					&cli.StringFlag{
 						Name:    "output",
@@ -148,8 +284,13 @@ func FollowersCommand() *cli.Command {
 						Usage:   "Output format: table, json, csv",
 						Value:   "table",
 					},
+					&cli.BoolFlag{
+						Name:    "interactive",
+						Aliases: []string{"i"},
+						Usage:   "Step through each new follower and unfollow to view, note, tag, or block them",
+					},
 				},
-				Action: FollowersDiffAction,
+				Action: withRegistry(FollowersDiffAction),
 			},
 			{
 				Name:      "export",
@@ -157,11 +298,14 @@ func FollowersCommand() *cli.Command {
 				UsageText: "Export follower list to CSV or JSON for external analysis, archival, or backup purposes.",
 				ArgsUsage: " ",
 				Flags: []cli.Flag{
+					usersFlag(),
+					usersFileFlag(),
 					&cli.StringFlag{
-						Name:    "user",
-						Aliases: []string{"u"},
-						Usage:   "User handle or DID (defaults to authenticated user)",
+						Name:  "dir",
+						Usage: "Write one file per account to this directory, with an index.json (required for several accounts)",
 					},
+					limitFlag("followers"),
+					noLimitFlag("followers"),
 					&cli.IntFlag{
 						Name:  "inactive",
 						Usage: "Export only followers with no posts in N days",
@@ -171,6 +315,7 @@ func FollowersCommand() *cli.Command {
 						Name:  "quiet",
 						Usage: "Export only quiet posters (low posting frequency)",
 					},
+					tagFlag("Export only followers with this local tag"),
 					&cli.FloatFlag{
 						Name:  "threshold",
 						Usage: "Posts per day threshold for quiet posters (used with --quiet)",
@@ -179,7 +324,7 @@ func FollowersCommand() *cli.Command {
 					&cli.StringFlag{
 						Name:     "output",
 						Aliases:  []string{"o"},
-						Usage:    "Output format: json, csv",
+						Usage:    "Output format: json, csv, or xlsx (followers, summary, and diff sheets)",
 						Value:    "csv",
 						Required: true,
 					},
@@ -187,40 +332,192 @@ func FollowersCommand() *cli.Command {
 						Name:  "refresh",
 						Usage: "Force refresh cached data (bypasses 24-hour cache)",
 					},
+					encryptFlag(),
+					recipientFlag(),
+					redactFlag(),
+					redactModeFlag(),
+					followDatesFlag(),
+					compressFlag(),
+					crlfFlag(),
+					strictFlag(),
+				},
+				Action: withRegistry(FollowersExportAction),
+			},
+			{
+				Name:      "interests",
+				Usage:     "Cluster common keywords in follower bios",
+				UsageText: "Tokenize follower bios and group keywords that share an audience, with counts and example accounts.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "user",
+						Aliases: []string{"u"},
+						Usage:   "User handle or DID (defaults to authenticated user)",
+					},
+					limitFlag("followers"),
+					noLimitFlag("followers"),
+					&cli.IntFlag{
+						Name:    "top",
+						Aliases: []string{"t"},
+						Usage:   "Maximum number of clusters to show (0 = all)",
+						Value:   15,
+					},
+					&cli.IntFlag{
+						Name:  "min-count",
+						Usage: "Ignore keywords found in fewer bios",
+						Value: 3,
+					},
+					&cli.IntFlag{
+						Name:  "examples",
+						Usage: "Example accounts to list per cluster",
+						Value: 3,
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Output format: table, json",
+						Value:   "table",
+					},
+				},
+				Action: withRegistry(FollowersInterestsAction),
+			},
+			{
+				Name:      "in-list",
+				Usage:     "Check which followers are members of a list",
+				UsageText: "Compare your followers with a list's members. Accepts an at:// list URI or a bsky.app list link.",
+				ArgsUsage: "<list-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "user",
+						Aliases: []string{"u"},
+						Usage:   "User handle or DID whose followers to check (defaults to authenticated user)",
+					},
+					noLimitFlag("followers"),
+					&cli.BoolFlag{
+						Name:  "missing",
+						Usage: "Show only followers who are not on the list",
+					},
+					&cli.BoolFlag{
+						Name:  "members",
+						Usage: "Show only followers who are on the list",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Output format: table, json, csv",
+						Value:   "table",
+					},
+				},
+				Action: withRegistry(FollowersInListAction),
+			},
+			{
+				Name:      "follow-back",
+				Usage:     "Follow followers you don't follow yet",
+				UsageText: "List followers you don't follow, filtered by score and recent activity, then follow them after confirmation (or with --yes). Follows are recorded in the undo log; revert them with 'skycli undo'.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					limitFlag("followers"),
+					noLimitFlag("followers"),
+					&cli.IntFlag{
+						Name:  "min-score",
+						Usage: "Only include accounts scoring at least N (0-100; profile, posts, follow ratio, activity)",
+						Value: 0,
+					},
+					&cli.IntFlag{
+						Name:  "active",
+						Usage: "Only include accounts that posted in the last N days (0 = any)",
+						Value: 0,
+					},
+					&cli.IntFlag{
+						Name:  "max",
+						Usage: "Maximum accounts to follow in one run (0 = no limit)",
+						Value: 50,
+					},
+					&cli.DurationFlag{
+						Name:  "pace",
+						Usage: "Delay between follows",
+						Value: time.Second,
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "List candidates without following anyone",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Output format: table, json",
+						Value:   "table",
+					},
+					&cli.BoolFlag{
+						Name:  "refresh",
+						Usage: "Force refresh cached data (bypasses 24-hour cache)",
+					},
+				},
+				Action: withRegistry(FollowBackAction),
+			},
+			{
+				Name:      "ghosts",
+				Usage:     "Find followers who never engage with your posts",
+				UsageText: "List followers who haven't liked or reposted any of your recent posts. Nothing is changed unless --add-to-list is given.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "posts",
+						Usage: "Number of your recent posts to check for likes and reposts",
+						Value: 25,
+					},
+					limitFlag("followers"),
+					noLimitFlag("followers"),
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Output format: table, json, csv",
+						Value:   "table",
+					},
+					&cli.StringFlag{
+						Name:  "add-to-list",
+						Usage: "Add the ghost followers to a list you own (at:// URI or bsky.app link)",
+					},
+					&cli.DurationFlag{
+						Name:  "pace",
+						Usage: "Delay between list additions (used with --add-to-list)",
+						Value: time.Second,
+					},
+					strictFlag(),
 				},
-				Action: FollowersExportAction,
+				Action: withRegistry(FollowersGhostsAction),
 			},
+			followersNoteCommand(),
+			followersShowCommand(),
 		},
 	}
 }
 
 // ListFollowersAction fetches and displays followers for a user with optional filtering
-func ListFollowersAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
-	service, err := reg.GetService()
+func ListFollowersAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fetcher, err := reg.GetFollowerFetcher()
 	if err != nil {
-		return fmt.Errorf("failed to get service: %w", err)
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
 	}
 
-	if !service.Authenticated() {
+	if !fetcher.Authenticated() {
 		return fmt.Errorf("not authenticated: run 'skycli login' first")
 	}
 
-	cacheRepo, err := reg.GetCacheRepo()
+	profiles, err := reg.GetProfileFetcher()
 	if err != nil {
-		return fmt.Errorf("failed to get cache repository: %w", err)
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
 	}
 
-	actor := cmd.String("user")
-	if actor == "" {
-		actor = service.GetDid()
+	rateCache, err := reg.GetRateCache()
+	if err != nil {
+		return fmt.Errorf("failed to get rate cache: %w", err)
+	}
+
+	actor, err := userActor(ctx, cmd, reg, fetcher)
+	if err != nil {
+		return err
 	}
-	limit := cmd.Int("limit")
 	sinceStr := cmd.String("since")
 	inactiveDays := cmd.Int("inactive")
 	quietPosters := cmd.Bool("quiet")
@@ -228,47 +525,30 @@ func ListFollowersAction(ctx context.Context, cmd *cli.Command) error {
 	outputFormat := cmd.String("output")
 	refresh := cmd.Bool("refresh")
 
-	if limit == 0 {
+	pageOpts, err := graphPageOptions(cmd, "followers")
+	if err != nil {
+		return err
+	}
+	if pageOpts.MaxItems == 0 {
 		logger.Debugf("Fetching all followers for %v", actor)
 	} else {
-		logger.Debugf("Fetching %v followers for %v", actor, limit)
+		logger.Debugf("Fetching %v followers for %v", pageOpts.MaxItems, actor)
 	}
 
-	var allFollowers []store.ActorProfile
-	cursor := ""
-	page := 0
-	for {
-		page++
-		response, err := service.GetFollowers(ctx, actor, 100, cursor)
-		if err != nil {
-			return fmt.Errorf("failed to fetch followers: %w", err)
-		}
-
-		allFollowers = append(allFollowers, response.Followers...)
-
-		if response.Cursor != "" {
-			logger.Infof("Fetched page %d (%d followers so far)...", page, len(allFollowers))
-		}
-
-		if response.Cursor == "" || (limit > 0 && len(allFollowers) >= limit) {
-			break
-		}
-		cursor = response.Cursor
+	allFollowers, err := bsky.NewPaginator(bsky.FollowerPages(fetcher, actor), pageOpts).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch followers: %w", capError(cmd, "followers", err))
 	}
 
 	logger.Infof("Fetched %d total followers", len(allFollowers))
 
-	if limit > 0 && len(allFollowers) > limit {
-		allFollowers = allFollowers[:limit]
-	}
-
 	if sinceStr != "" {
-		since, err := time.Parse("2006-01-02", sinceStr)
+		since, err := parseSince(sinceStr, userNow())
 		if err != nil {
-			return fmt.Errorf("invalid date format (use YYYY-MM-DD): %w", err)
+			return err
 		}
 
-		var filtered []store.ActorProfile
+		var filtered []bsky.ActorProfile
 		for _, follower := range allFollowers {
 			if follower.IndexedAt == "" {
 				continue
@@ -285,75 +565,218 @@ func ListFollowersAction(ctx context.Context, cmd *cli.Command) error {
 		allFollowers = filtered
 	}
 
-	followerInfos, actors := enrichFollowerProfiles(ctx, service, allFollowers, logger)
+	if allFollowers, err = filterTagged(ctx, cmd, reg, allFollowers); err != nil {
+		return err
+	}
+
+	followerInfos, actors := enrichFollowerProfiles(ctx, profiles, allFollowers, logger)
+	enriched := followerInfos
 
 	if inactiveDays > 0 {
-		followerInfos = filterInactive(ctx, service, cacheRepo, followerInfos, actors, inactiveDays, refresh, logger)
+		followerInfos = filterInactive(ctx, profiles, rateCache, followerInfos, actors, inactiveDays, refresh, logger)
 	}
 
 	if quietPosters {
-		followerInfos = filterQuiet(ctx, service, cacheRepo, followerInfos, actors, quietThreshold, refresh, logger)
+		followerInfos = filterQuiet(ctx, profiles, rateCache, followerInfos, actors, quietThreshold, refresh, logger)
+	}
+
+	if err := checkFetchFailures(cmd, enriched); err != nil {
+		return err
 	}
+	addFollowerNotes(ctx, reg, followerInfos)
 
 	switch outputFormat {
 	case "json":
-		return outputFollowersJSON(followerInfos)
+		err = outputFollowersJSON(cmd.Root().Writer, followerInfos)
 	case "csv":
-		return outputFollowersCSV(followerInfos, inactiveDays > 0 || quietPosters)
+		err = outputFollowersCSV(cmd.Root().Writer, followerInfos, inactiveDays > 0 || quietPosters, nil)
 	default:
 		displayFollowersTable(followerInfos, inactiveDays > 0 || quietPosters)
 	}
+	warnFetchFailures(enriched)
+	if err != nil || cmd.String("add-to-list") == "" {
+		return err
+	}
 
-	return nil
+	return addFollowersToList(ctx, cmd, reg, followerInfos)
 }
 
-// FollowersStatsAction displays aggregate statistics about followers
-func FollowersStatsAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
+// addFollowersToList appends followers to the --add-to-list list, skipping accounts already on it and
+// waiting --pace between writes. Progress is logged so JSON and CSV output stay clean.
+func addFollowersToList(ctx context.Context, cmd *cli.Command, reg *registry.Registry, followers []followerInfo) error {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+	writer, err := reg.GetGraphWriter()
+	if err != nil {
+		return fmt.Errorf("failed to get graph writer: %w", err)
+	}
+	actions, err := reg.GetActionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get action log: %w", err)
+	}
+
+	listURI, err := resolveListURI(ctx, profiles, cmd.String("add-to-list"))
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(listURI, "at://"+fetcher.GetDid()+"/") {
+		return fmt.Errorf("you can only add to lists you own")
+	}
+
+	list, err := fetcher.GetList(ctx, listURI, 1, "")
+	if err != nil {
+		return fmt.Errorf("failed to fetch list: %w", err)
+	}
+
+	members, err := bsky.NewPaginator(bsky.ListMemberPages(fetcher, listURI), bsky.PaginatorOptions{
+		Progress: logPageProgress("list members"),
+	}).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch list members: %w", err)
+	}
+
+	seen := make(map[string]bool, len(members)+len(followers))
+	for _, member := range members {
+		seen[member.Did] = true
+	}
+
+	var targets []string
+	skipped := 0
+	for _, follower := range followers {
+		did := follower.Profile.Did
+		if seen[did] {
+			skipped++
+			continue
+		}
+		seen[did] = true
+		targets = append(targets, did)
+	}
+
+	// JSON and CSV go to stdout, so summaries there are logged instead
+	report, note := ui.Successln, ui.Infoln
+	if cmd.String("output") != "table" {
+		report, note = logger.Infof, logger.Infof
+	}
+
+	if len(targets) == 0 {
+		note("All %d follower(s) are already on %s", skipped, list.List.Name)
+		return nil
+	}
+
+	if ok, err := confirm(cmd, fmt.Sprintf("Add %d follower(s) to %s?", len(targets), list.List.Name)); !ok {
+		return err
+	}
+
+	pace := cmd.Duration("pace")
+	added, failed := 0, 0
+	for i, did := range targets {
+		if i > 0 {
+			if err := waitPace(ctx, pace); err != nil {
+				return err
+			}
+		}
+
+		record, err := writer.AddToList(ctx, listURI, did)
+		if err != nil {
+			logger.Warn("Failed to add to list", "did", did, "error", err)
+			failed++
+			continue
+		}
+		added++
+		recordAction(ctx, actions, bsky.ActionListAdd, did, record.Uri)
+		logger.Debugf("Added %d/%d to list", i+1, len(targets))
 	}
 
-	reg := registry.Get()
+	report("Added %d follower(s) to %s (%d already on list, %d failed)", added, list.List.Name, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to add %d follower(s) to list", failed)
+	}
+	return nil
+}
 
-	service, err := reg.GetService()
+// FollowersStatsAction displays aggregate statistics about followers, in one section per account when several
+// are named
+func FollowersStatsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fetcher, err := reg.GetFollowerFetcher()
 	if err != nil {
-		return fmt.Errorf("failed to get service: %w", err)
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
 	}
 
-	if !service.Authenticated() {
+	if !fetcher.Authenticated() {
 		return fmt.Errorf("not authenticated: run 'skycli login' first")
 	}
 
-	actor := cmd.String("user")
-	if actor == "" {
-		actor = service.GetDid()
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
 	}
-	sinceStr := cmd.String("since")
-	inactiveDays := cmd.Int("inactive")
-	showChart := cmd.Bool("chart")
 
-	logger.Debugf("Fetching followers stats for actor %v", actor)
+	accounts, err := userAccounts(ctx, cmd, reg, fetcher)
+	if err != nil {
+		return err
+	}
 
-	var allFollowers []store.ActorProfile
-	cursor := ""
-	page := 0
-	for {
-		page++
-		response, err := service.GetFollowers(ctx, actor, 100, cursor)
+	now := userNow()
+	sinceDate := now.AddDate(0, 0, -30)
+	if sinceStr := cmd.String("since"); sinceStr != "" {
+		since, err := parseSince(sinceStr, now)
 		if err != nil {
-			return fmt.Errorf("failed to fetch followers: %w", err)
+			return err
 		}
+		sinceDate = since
+	}
 
-		allFollowers = append(allFollowers, response.Followers...)
+	outputs := make([]followerStatsOutput, len(accounts))
+	for i, acct := range accounts {
+		output, err := followerStats(ctx, cmd, fetcher, profiles, acct.Did, sinceDate, now)
+		if err != nil {
+			if len(accounts) > 1 {
+				return fmt.Errorf("%s: %w", acct.Name, err)
+			}
+			return err
+		}
+		if len(accounts) > 1 {
+			output.Actor = acct.Name
+		}
+		outputs[i] = output
+	}
 
-		if response.Cursor != "" {
-			logger.Infof("Fetched page %d (%d followers so far)...", page, len(allFollowers))
+	if cmd.Bool("json") {
+		if len(outputs) == 1 {
+			return ui.DisplayJSON(outputs[0])
 		}
+		return ui.DisplayJSON(outputs)
+	}
 
-		if response.Cursor == "" {
-			break
+	for i, output := range outputs {
+		if i > 0 {
+			fmt.Println()
 		}
-		cursor = response.Cursor
+		displayFollowerStats(output, cmd.Bool("chart"))
+	}
+	return nil
+}
+
+// followerStats fetches an account's followers and computes growth since sinceDate, plus activity when --inactive
+// is set
+func followerStats(ctx context.Context, cmd *cli.Command, fetcher bsky.FollowerFetcher, profiles bsky.ProfileFetcher, actor string, sinceDate, now time.Time) (followerStatsOutput, error) {
+	inactiveDays := cmd.Int("inactive")
+
+	logger.Debugf("Fetching followers stats for actor %v", actor)
+
+	pageOpts, err := graphPageOptions(cmd, "followers")
+	if err != nil {
+		return followerStatsOutput{}, err
+	}
+	allFollowers, err := bsky.NewPaginator(bsky.FollowerPages(fetcher, actor), pageOpts).All(ctx)
+	if err != nil {
+		return followerStatsOutput{}, fmt.Errorf("failed to fetch followers: %w", capError(cmd, "followers", err))
 	}
 
 	logger.Infof("Fetched %d total followers", len(allFollowers))
@@ -367,91 +790,86 @@ func FollowersStatsAction(ctx context.Context, cmd *cli.Command) error {
 		actors[i] = follower.Did
 	}
 
-	fullProfiles := service.BatchGetProfiles(ctx, actors, 10)
+	profilesCtx, report := bsky.WithBatchReport(ctx)
+	fullProfiles := profiles.BatchGetProfiles(profilesCtx, actors, 0)
 	logger.Infof("Fetched %d detailed profiles", len(fullProfiles))
+	warnBatchReport(logger, "Profiles", report)
 
-	var growth int
-	var sinceDate time.Time
-	if sinceStr != "" {
-		since, err := time.Parse("2006-01-02", sinceStr)
-		if err != nil {
-			return fmt.Errorf("invalid date format (use YYYY-MM-DD): %w", err)
-		}
-		sinceDate = since
-
-		for _, follower := range allFollowers {
-			if follower.IndexedAt == "" {
-				continue
-			}
-			indexedAt, err := time.Parse(time.RFC3339, follower.IndexedAt)
-			if err != nil {
-				continue
-			}
-			if indexedAt.After(since) {
-				growth++
-			}
+	followedAt := make([]time.Time, 0, len(allFollowers))
+	for _, follower := range allFollowers {
+		if indexedAt, err := time.Parse(time.RFC3339, follower.IndexedAt); err == nil {
+			followedAt = append(followedAt, indexedAt)
 		}
 	}
+	growth := analytics.FollowerGrowth(followedAt, totalFollowers, sinceDate, now, analytics.GrowthOptions{
+		Window:    cmd.Int("window"),
+		Threshold: cmd.Float("threshold"),
+	})
+	output := followerStatsOutput{TotalFollowers: totalFollowers, Growth: growth}
 
-	var activeCount, inactiveCount int
 	if inactiveDays > 0 {
 		logger.Infof("Checking activity status (threshold: %d days)...", inactiveDays)
 
-		actors := make([]string, len(allFollowers))
-		for i, follower := range allFollowers {
-			actors[i] = follower.Did
-		}
-
-		lastPostDates := service.BatchGetLastPostDates(ctx, actors, 10)
+		ctx, report := bsky.WithBatchReport(ctx)
+		lastPostDates := profiles.BatchGetLastPostDates(ctx, actors, 0)
+		warnBatchReport(logger, "Activity", report)
 
+		activity := &followerActivity{InactiveDays: inactiveDays}
 		for _, actor := range actors {
 			lastPost, ok := lastPostDates[actor]
 			if !ok || lastPost.IsZero() {
-				inactiveCount++
+				activity.Inactive++
 			} else {
 				daysSince := int(time.Since(lastPost).Hours() / 24)
 				if daysSince > inactiveDays {
-					inactiveCount++
+					activity.Inactive++
 				} else {
-					activeCount++
+					activity.Active++
 				}
 			}
 		}
+		output.Activity = activity
 	}
+	return output, nil
+}
 
-	ui.Titleln("Follower Statistics")
-	fmt.Printf("Total followers: %d\n", totalFollowers)
-
-	if inactiveDays > 0 {
-		fmt.Printf("Active: %d\n", activeCount)
-		fmt.Printf("Inactive: %d (no post > %d days)\n", inactiveCount, inactiveDays)
+// displayFollowerStats prints one account's follower statistics, titled with the account when there are several
+func displayFollowerStats(output followerStatsOutput, showChart bool) {
+	if output.Actor != "" {
+		ui.Titleln("Follower Statistics: %s", output.Actor)
+	} else {
+		ui.Titleln("Follower Statistics")
 	}
+	fmt.Printf("Total followers: %d\n", output.TotalFollowers)
 
-	if sinceStr != "" {
-		fmt.Printf("\nGrowth since %s: +%d\n", sinceDate.Format("2006-01-02"), growth)
+	if output.Activity != nil {
+		fmt.Printf("Active: %d\n", output.Activity.Active)
+		fmt.Printf("Inactive: %d (no post > %d days)\n", output.Activity.Inactive, output.Activity.InactiveDays)
 	}
 
-	if showChart && inactiveDays > 0 {
-		displayActivityChart(activeCount, inactiveCount)
+	growth := output.Growth
+	fmt.Printf("\nGrowth since %s: +%d (%+.1f%%)\n", growth.Start.Format("2006-01-02"), growth.Gained, growth.PercentGrowth)
+	fmt.Printf("Daily: %.1f ± %.1f new followers\n", growth.MeanDelta, growth.StdDev)
+	for _, day := range growth.Anomalies() {
+		ui.Warningln("%s on %s: +%d (z = %.1f)", strings.ToUpper(day.Anomaly[:1])+day.Anomaly[1:], day.Date.Format("2006-01-02"), day.Delta, day.ZScore)
 	}
 
-	return nil
-}
-
-// FollowersDiffAction compares follower lists between two dates
-func FollowersDiffAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
+	if showChart && output.Activity != nil {
+		displayActivityChart(output.Activity.Active, output.Activity.Inactive)
 	}
+	if showChart {
+		displayGrowthChart(growth)
+	}
+}
 
-	reg := registry.Get()
-
-	service, err := reg.GetService()
+// FollowersDiffAction compares follower lists between two dates, or across three with --against
+func FollowersDiffAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fetcher, err := reg.GetFollowerFetcher()
 	if err != nil {
-		return fmt.Errorf("failed to get service: %w", err)
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
 	}
 
-	if !service.Authenticated() {
+	if !fetcher.Authenticated() {
 		return fmt.Errorf("not authenticated: run 'skycli login' first")
 	}
 
@@ -460,38 +878,34 @@ func FollowersDiffAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("failed to get snapshot repository: %w", err)
 	}
 
-	actor := cmd.String("user")
-	if actor == "" {
-		actor = service.GetDid()
+	actor, err := userActor(ctx, cmd, reg, fetcher)
+	if err != nil {
+		return err
 	}
-	sinceStr := cmd.String("since")
 	untilStr := cmd.String("until")
+	againstStr := cmd.String("against")
 	outputFormat := cmd.String("output")
 
-	// Parse since parameter (date or snapshot ID)
-	sinceDate, err := time.Parse("2006-01-02", sinceStr)
-	var baselineSnapshot *store.SnapshotModel
-	if err != nil {
-		// Not a date, try as snapshot ID
-		model, err := snapshotRepo.Get(ctx, sinceStr)
-		if err != nil {
-			return fmt.Errorf("invalid --since parameter (not a date or snapshot ID): %w", err)
-		}
-		if model == nil {
-			return fmt.Errorf("snapshot not found: %s", sinceStr)
+	if againstStr != "" && untilStr == "" {
+		return fmt.Errorf("--against needs --until to close the window whose new followers are checked")
+	}
+
+	var prompter *ui.Prompter
+	if cmd.Bool("interactive") {
+		if againstStr != "" || outputFormat != "table" {
+			return fmt.Errorf("--interactive works only with table output and without --against")
 		}
-		baselineSnapshot = model.(*store.SnapshotModel)
-	} else {
-		// Find snapshot by date
-		baselineSnapshot, err = snapshotRepo.FindByUserTypeAndDate(ctx, actor, "followers", sinceDate)
+		prompter, err = ui.NewPrompter(ui.ConfirmOptions{NoInput: cmd.Bool("no-input"), In: cmd.Root().Reader, Out: cmd.Root().ErrWriter})
 		if err != nil {
-			return fmt.Errorf("failed to find snapshot: %w", err)
-		}
-		if baselineSnapshot == nil {
-			return fmt.Errorf("no snapshot found for %s on or before %s", actor, sinceStr)
+			return err
 		}
 	}
 
+	baselineSnapshot, err := findFollowerSnapshot(ctx, snapshotRepo, actor, "since", cmd.String("since"))
+	if err != nil {
+		return err
+	}
+
 	logger.Infof("Using baseline snapshot from %s (%d followers)", baselineSnapshot.CreatedAt().Format("2006-01-02 15:04"), baselineSnapshot.TotalCount)
 
 	// Get baseline follower DIDs
@@ -500,141 +914,236 @@ func FollowersDiffAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("failed to get baseline followers: %w", err)
 	}
 
-	var comparisonDids []string
-	var comparisonLabel string
+	comparisonDids, comparisonLabel, err := diffFollowerSide(ctx, cmd, reg, snapshotRepo, fetcher, actor, "until", untilStr)
+	if err != nil {
+		return err
+	}
 
-	if untilStr != "" {
-		// Snapshot-to-snapshot comparison
-		untilDate, err := time.Parse("2006-01-02", untilStr)
-		var comparisonSnapshot *store.SnapshotModel
+	if againstStr != "" {
+		againstDids, againstLabel, err := diffFollowerSide(ctx, cmd, reg, snapshotRepo, fetcher, actor, "against", againstStr)
 		if err != nil {
-			// Not a date, try as snapshot ID
-			model, err := snapshotRepo.Get(ctx, untilStr)
-			if err != nil {
-				return fmt.Errorf("invalid --until parameter (not a date or snapshot ID): %w", err)
-			}
-			if model == nil {
-				return fmt.Errorf("snapshot not found: %s", untilStr)
-			}
-			comparisonSnapshot = model.(*store.SnapshotModel)
-		} else {
-			// Find snapshot by date
-			comparisonSnapshot, err = snapshotRepo.FindByUserTypeAndDate(ctx, actor, "followers", untilDate)
-			if err != nil {
-				return fmt.Errorf("failed to find snapshot: %w", err)
-			}
-			if comparisonSnapshot == nil {
-				return fmt.Errorf("no snapshot found for %s on or before %s", actor, untilStr)
-			}
+			return err
+		}
+
+		labels := [3]string{baselineSnapshot.CreatedAt().Format("2006-01-02 15:04"), comparisonLabel, againstLabel}
+		report := retentionDiff(baselineDids, comparisonDids, againstDids)
+		switch outputFormat {
+		case "json":
+			return ui.DisplayJSON(report)
+		case "csv":
+			return outputRetentionCSV(report)
+		default:
+			displayRetentionTable(labels, report)
 		}
+		return nil
+	}
 
-		logger.Infof("Comparing with snapshot from %s (%d followers)", comparisonSnapshot.CreatedAt().Format("2006-01-02 15:04"), comparisonSnapshot.TotalCount)
-		comparisonLabel = comparisonSnapshot.CreatedAt().Format("2006-01-02 15:04")
+	newFollowers, unfollows := followerDiff(baselineDids, comparisonDids)
 
-		comparisonDids, err = snapshotRepo.GetActorDids(ctx, comparisonSnapshot.ID())
-		if err != nil {
-			return fmt.Errorf("failed to get comparison followers: %w", err)
+	if prompter != nil {
+		ui.Titleln("Follower Diff: %s → %s", baselineSnapshot.CreatedAt().Format("2006-01-02 15:04"), comparisonLabel)
+		ui.Infoln("%d new follower(s), %d unfollow(s)", len(newFollowers), len(unfollows))
+		if len(newFollowers) == 0 && len(unfollows) == 0 {
+			return nil
 		}
-	} else {
-		// Snapshot-to-live comparison
-		logger.Infof("Fetching current followers for comparison...")
-		comparisonLabel = "now"
-
-		var allFollowers []store.ActorProfile
-		cursor := ""
-		page := 0
-		for {
-			page++
-			response, err := service.GetFollowers(ctx, actor, 100, cursor)
-			if err != nil {
-				return fmt.Errorf("failed to fetch followers: %w", err)
-			}
 
-			allFollowers = append(allFollowers, response.Followers...)
+		review, err := reviewDiff(ctx, reg, prompter, newFollowers, unfollows)
+		fmt.Println()
+		ui.Successln("Reviewed %d of %d: %d noted, %d tagged, %d blocked", review.Reviewed, len(newFollowers)+len(unfollows), review.Noted, review.Tagged, review.Blocked)
+		return err
+	}
 
-			if response.Cursor != "" {
-				logger.Infof("Fetched page %d (%d followers so far)...", page, len(allFollowers))
-			}
+	// Output results
+	switch outputFormat {
+	case "json":
+		return outputDiffJSON(newFollowers, unfollows)
+	case "csv":
+		return outputDiffCSV(newFollowers, unfollows)
+	default:
+		displayDiffTable(baselineSnapshot.CreatedAt().Format("2006-01-02 15:04"), comparisonLabel, len(baselineDids), len(comparisonDids), newFollowers, unfollows)
+	}
 
-			if response.Cursor == "" {
-				break
-			}
-			cursor = response.Cursor
+	return nil
+}
+
+// findFollowerSnapshot resolves a diff flag value given as a time (the latest snapshot at or before it) or a snapshot
+// ID. A whole day such as 2024-06-01 or yesterday includes snapshots taken during that day.
+func findFollowerSnapshot(ctx context.Context, snapshotRepo bsky.SnapshotStore, actor, flag, value string) (*bsky.SnapshotModel, error) {
+	date, err := dateparse.ParseEnd(value, userNow())
+	if err != nil {
+		// Not a date, try as snapshot ID
+		model, err := snapshotRepo.Get(ctx, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s parameter (not a date or snapshot ID): %w", flag, err)
 		}
+		if model == nil {
+			return nil, fmt.Errorf("snapshot not found: %s", value)
+		}
+		return model.(*bsky.SnapshotModel), nil
+	}
 
-		logger.Infof("Fetched %d current followers", len(allFollowers))
+	snapshot, err := snapshotRepo.FindByUserTypeAndDate(ctx, actor, "followers", date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find snapshot: %w", err)
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("no snapshot found for %s on or before %s", actor, value)
+	}
+	return snapshot, nil
+}
 
-		for _, follower := range allFollowers {
-			comparisonDids = append(comparisonDids, follower.Did)
+// diffFollowerSide returns the followers a diff compares against: a snapshot, or live followers when value is
+// empty or "now". Live followers come from the daemon's tracking when it is running, else from the API.
+func diffFollowerSide(ctx context.Context, cmd *cli.Command, reg *registry.Registry, snapshotRepo bsky.SnapshotStore, fetcher bsky.FollowerFetcher, actor, flag, value string) ([]string, string, error) {
+	if value != "" && value != "now" {
+		snapshot, err := findFollowerSnapshot(ctx, snapshotRepo, actor, flag, value)
+		if err != nil {
+			return nil, "", err
+		}
+
+		logger.Infof("Comparing with snapshot from %s (%d followers)", snapshot.CreatedAt().Format("2006-01-02 15:04"), snapshot.TotalCount)
+		dids, err := snapshotRepo.GetActorDids(ctx, snapshot.ID())
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get comparison followers: %w", err)
 		}
+		return dids, snapshot.CreatedAt().Format("2006-01-02 15:04"), nil
 	}
 
-	// Calculate diff
-	baselineSet := make(map[string]bool)
-	for _, did := range baselineDids {
-		baselineSet[did] = true
+	if tracked, ok := trackedFollowers(ctx, reg, actor, cmd.Bool("refresh")); ok {
+		logger.Infof("Using %d followers tracked live by the daemon", len(tracked))
+		return tracked, "now", nil
 	}
 
-	comparisonSet := make(map[string]bool)
-	for _, did := range comparisonDids {
-		comparisonSet[did] = true
+	logger.Infof("Fetching current followers for comparison...")
+	pageOpts, err := graphPageOptions(cmd, "followers")
+	if err != nil {
+		return nil, "", err
+	}
+	allFollowers, err := bsky.NewPaginator(bsky.FollowerPages(fetcher, actor), pageOpts).All(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch followers: %w", capError(cmd, "followers", err))
 	}
 
-	// New followers: in comparison but not in baseline
-	var newFollowers []string
-	for _, did := range comparisonDids {
-		if !baselineSet[did] {
-			newFollowers = append(newFollowers, did)
-		}
+	logger.Infof("Fetched %d current followers", len(allFollowers))
+	dids := make([]string, len(allFollowers))
+	for i, follower := range allFollowers {
+		dids[i] = follower.Did
 	}
+	return dids, "now", nil
+}
 
-	// Unfollows: in baseline but not in comparison
-	var unfollows []string
-	for _, did := range baselineDids {
-		if !comparisonSet[did] {
-			unfollows = append(unfollows, did)
-		}
+// trackedFollowers returns actor's followers as kept current by the daemon's stream task, when that tracking
+// is live and refresh is not set
+func trackedFollowers(ctx context.Context, reg *registry.Registry, actor string, refresh bool) ([]string, bool) {
+	if refresh {
+		return nil, false
 	}
 
-	// Output results
-	switch outputFormat {
-	case "json":
-		return outputDiffJSON(newFollowers, unfollows)
-	case "csv":
-		return outputDiffCSV(newFollowers, unfollows)
-	default:
-		displayDiffTable(baselineSnapshot.CreatedAt().Format("2006-01-02 15:04"), comparisonLabel, len(baselineDids), len(comparisonDids), newFollowers, unfollows)
+	states, err := reg.GetFollowerStateRepo()
+	if err != nil {
+		return nil, false
 	}
 
-	return nil
+	tracking, err := states.Tracking(ctx, actor)
+	if err != nil || !tracking.Live(time.Now(), followerTrackingMaxAge) {
+		return nil, false
+	}
+
+	dids, err := states.Followers(ctx, actor)
+	if err != nil {
+		logger.Warn("Failed to read tracked followers", "error", err)
+		return nil, false
+	}
+	return dids, true
 }
 
-// FollowersExportAction exports followers to CSV or JSON
-func FollowersExportAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
+// FollowersExportAction exports followers to CSV, JSON, or XLSX on stdout, or with --dir to one file per account
+// alongside an index.json
+func FollowersExportAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
 	}
 
-	reg := registry.Get()
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
 
-	service, err := reg.GetService()
+	accounts, err := userAccounts(ctx, cmd, reg, fetcher)
 	if err != nil {
-		return fmt.Errorf("failed to get service: %w", err)
+		return err
 	}
+	outputFormat := cmd.String("output")
+	dir := cmd.String("dir")
 
-	if !service.Authenticated() {
-		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	if outputFormat != "json" && outputFormat != "csv" && outputFormat != "xlsx" {
+		return fmt.Errorf("output format must be 'json', 'csv', or 'xlsx'")
+	}
+	if len(accounts) > 1 && dir == "" {
+		return fmt.Errorf("exporting %d accounts needs --dir to write one file each", len(accounts))
+	}
+
+	enc, err := encryptOptionsFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	compress := cmd.Bool("compress")
+	if dir == "" {
+		if outputFormat == "xlsx" && enc == nil && ui.StdoutIsTerminal() {
+			return fmt.Errorf("refusing to write a workbook to a terminal; redirect stdout, e.g. > followers.xlsx")
+		}
+		if compress && enc == nil && ui.StdoutIsTerminal() {
+			return fmt.Errorf("refusing to write compressed output to a terminal; redirect stdout, e.g. > followers.%s%s", outputFormat, export.CompressedExt)
+		}
+	}
+
+	redactor, err := redactorFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	if dir != "" {
+		return exportFollowerAccounts(ctx, cmd, reg, accounts, dir, enc, redactor)
+	}
+
+	job, err := prepareFollowerExport(ctx, cmd, reg, accounts[0].Did, redactor)
+	if err != nil {
+		return err
+	}
+
+	// Encrypted output is armored so the ciphertext is safe to print or pipe
+	if err := streamExport(cmd.Root().Writer, enc, true, compress, job.write); err != nil {
+		return err
+	}
+	warnFetchFailures(job.enriched)
+	return nil
+}
+
+// followerExportJob is one account's followers after --inactive and --quiet, ready to write in the --output format
+type followerExportJob struct {
+	followers []followerInfo
+	enriched  []followerInfo // before filtering, for fetch failure warnings
+	write     func(w io.Writer) error
+}
+
+// prepareFollowerExport fetches, enriches, filters, and redacts an account's followers for export
+func prepareFollowerExport(ctx context.Context, cmd *cli.Command, reg *registry.Registry, actor string, redactor *export.Redactor) (*followerExportJob, error) {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follower fetcher: %w", err)
 	}
 
-	cacheRepo, err := reg.GetCacheRepo()
+	profiles, err := reg.GetProfileFetcher()
 	if err != nil {
-		return fmt.Errorf("failed to get cache repository: %w", err)
+		return nil, fmt.Errorf("failed to get profile fetcher: %w", err)
 	}
 
-	actor := cmd.String("user")
-	if actor == "" {
-		actor = service.GetDid()
+	rateCache, err := reg.GetRateCache()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate cache: %w", err)
 	}
+
 	inactiveDays := cmd.Int("inactive")
 	quietPosters := cmd.Bool("quiet")
 	quietThreshold := cmd.Float("threshold")
@@ -643,94 +1152,526 @@ func FollowersExportAction(ctx context.Context, cmd *cli.Command) error {
 
 	logger.Debugf("Exporting followers for actor %v with fmt %v", actor, outputFormat)
 
-	var allFollowers []store.ActorProfile
-	cursor := ""
-	page := 0
-	for {
-		page++
-		response, err := service.GetFollowers(ctx, actor, 100, cursor)
-		if err != nil {
-			return fmt.Errorf("failed to fetch followers: %w", err)
+	pageOpts, err := graphPageOptions(cmd, "followers")
+	if err != nil {
+		return nil, err
+	}
+	allFollowers, err := bsky.NewPaginator(bsky.FollowerPages(fetcher, actor), pageOpts).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch followers: %w", capError(cmd, "followers", err))
+	}
+
+	logger.Infof("Fetched %d total followers", len(allFollowers))
+
+	if allFollowers, err = filterTagged(ctx, cmd, reg, allFollowers); err != nil {
+		return nil, err
+	}
+
+	followerInfos, actors := enrichFollowerProfiles(ctx, profiles, allFollowers, logger)
+	enriched := followerInfos
+
+	if inactiveDays > 0 {
+		followerInfos = filterInactive(ctx, profiles, rateCache, followerInfos, actors, inactiveDays, refresh, logger)
+	}
+
+	if quietPosters {
+		followerInfos = filterQuiet(ctx, profiles, rateCache, followerInfos, actors, quietThreshold, refresh, logger)
+	}
+
+	if err := checkFetchFailures(cmd, enriched); err != nil {
+		return nil, err
+	}
+
+	if cmd.Bool("follow-dates") {
+		addFollowerDates(ctx, reg, actor, followerInfos)
+		sortByFollowDate(followerInfos)
+	}
+
+	addFollowerNotes(ctx, reg, followerInfos)
+	if redactor != nil {
+		for i := range followerInfos {
+			followerInfos[i].Profile = redactor.Profile(followerInfos[i].Profile)
+			followerInfos[i].Note = redactor.Value("note", followerInfos[i].Note)
+		}
+	}
+
+	writeFollowers := func(w io.Writer) error {
+		w = lineEndings(cmd, outputFormat, w)
+		switch outputFormat {
+		case "json":
+			return outputFollowersJSON(w, followerInfos)
+		case "xlsx":
+			report := followerExportReport{
+				Actor:           actor,
+				Followers:       followerInfos,
+				IncludeInactive: inactiveDays > 0 || quietPosters,
+				InactiveDays:    inactiveDays,
+				QuietThreshold:  quietThreshold,
+				Fetched:         len(allFollowers),
+			}
+			report.Baseline, report.NewFollowers, report.Unfollows = diffLatestSnapshot(ctx, reg, actor, allFollowers)
+			return export.WriteXLSX(w, followerSheets(report, quietPosters, redactor))
+		default:
+			return outputFollowersCSV(w, followerInfos, inactiveDays > 0 || quietPosters, redactor)
+		}
+	}
+	return &followerExportJob{followers: followerInfos, enriched: enriched, write: writeFollowers}, nil
+}
+
+// followerExportIndex is the index.json written next to per-account follower exports
+type followerExportIndex struct {
+	ExportedAt time.Time                  `json:"exportedAt"`
+	Format     string                     `json:"format"`
+	Accounts   []followerExportIndexEntry `json:"accounts"`
+}
+
+// followerExportIndexEntry names the file holding one account's followers
+type followerExportIndexEntry struct {
+	Actor     string `json:"actor"`
+	Did       string `json:"did"`
+	File      string `json:"file"`
+	Followers int    `json:"followers"`
+}
+
+// exportFollowerAccounts writes each account's followers to its own file in dir, then an index.json listing them
+func exportFollowerAccounts(ctx context.Context, cmd *cli.Command, reg *registry.Registry, accounts []account, dir string, enc *export.EncryptOptions, redactor *export.Redactor) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create --dir: %w", err)
+	}
+
+	outputFormat := cmd.String("output")
+	index := followerExportIndex{ExportedAt: time.Now().UTC(), Format: outputFormat, Accounts: []followerExportIndexEntry{}}
+	for _, acct := range accounts {
+		job, err := prepareFollowerExport(ctx, cmd, reg, acct.Did, redactor)
+		if err != nil {
+			return fmt.Errorf("%s: %w", acct.Name, err)
+		}
+
+		filename := filepath.Join(dir, fmt.Sprintf("followers_%s.%s", strings.ReplaceAll(acct.Name, ":", "_"), outputFormat))
+		path, err := writeExportFile(filename, enc, cmd.Bool("compress"), job.write)
+		if err != nil {
+			return fmt.Errorf("%s: %w", acct.Name, err)
+		}
+		warnFetchFailures(job.enriched)
+
+		index.Accounts = append(index.Accounts, followerExportIndexEntry{
+			Actor:     acct.Name,
+			Did:       acct.Did,
+			File:      filepath.Base(path),
+			Followers: len(job.followers),
+		})
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.json"), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	ui.Successln("Exported followers of %d account(s) to %s", len(accounts), dir)
+	return nil
+}
+
+// interestCluster is one group of related bio keywords in the interests report
+type interestCluster struct {
+	Keywords []string `json:"keywords"`
+	Accounts int      `json:"accounts"`
+	Share    float64  `json:"share"`
+	Examples []string `json:"examples"`
+}
+
+// FollowersInterestsAction clusters keywords from follower bios to summarize what the audience cares about
+func FollowersInterestsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	outputFormat := cmd.String("output")
+	if outputFormat != "table" && outputFormat != "json" {
+		return fmt.Errorf("output format must be 'table' or 'json'")
+	}
+
+	actor, err := userActor(ctx, cmd, reg, fetcher)
+	if err != nil {
+		return err
+	}
+
+	pageOpts, err := graphPageOptions(cmd, "followers")
+	if err != nil {
+		return err
+	}
+	allFollowers, err := bsky.NewPaginator(bsky.FollowerPages(fetcher, actor), pageOpts).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch followers: %w", capError(cmd, "followers", err))
+	}
+
+	followerInfos, _ := enrichFollowerProfiles(ctx, profiles, allFollowers, logger)
+
+	bios := make([]analytics.Bio, 0, len(followerInfos))
+	for _, info := range followerInfos {
+		if strings.TrimSpace(info.Profile.Description) == "" {
+			continue
+		}
+		account := info.Profile.Handle
+		if account == "" {
+			account = info.Profile.Did
+		}
+		bios = append(bios, analytics.Bio{Account: account, Text: info.Profile.Description, Reach: info.Profile.FollowersCount})
+	}
+
+	logger.Debug("Clustering follower bios", "followers", len(followerInfos), "bios", len(bios))
+
+	clusters := analytics.ClusterInterests(bios, analytics.InterestOptions{
+		MinAccounts: cmd.Int("min-count"),
+		Examples:    cmd.Int("examples"),
+		Limit:       cmd.Int("top"),
+	})
+
+	results := make([]interestCluster, len(clusters))
+	for i, cluster := range clusters {
+		results[i] = interestCluster{
+			Keywords: cluster.Keywords,
+			Accounts: cluster.Accounts,
+			Share:    float64(cluster.Accounts) / float64(len(bios)),
+			Examples: cluster.Examples,
+		}
+	}
+
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(cmd.Root().Writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	if len(results) == 0 {
+		ui.Infoln("No common keywords found in %d follower bio(s).", len(bios))
+		return nil
+	}
+
+	displayInterestClusters(results, len(followerInfos), len(bios))
+	return nil
+}
+
+// displayInterestClusters renders interest clusters as a table, largest audience first
+func displayInterestClusters(clusters []interestCluster, followers, bios int) {
+	rows := make([][]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		keywords := cluster.Keywords
+		if len(keywords) > 6 {
+			keywords = append(keywords[:6:6], fmt.Sprintf("+%d", len(cluster.Keywords)-6))
+		}
+		rows = append(rows, []string{
+			strings.Join(keywords, ", "),
+			fmt.Sprintf("%d", cluster.Accounts),
+			fmt.Sprintf("%.0f%%", cluster.Share*100),
+			strings.Join(cluster.Examples, ", "),
+		})
+	}
+
+	t := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(ui.TableBorderStyle).
+		Headers("Keywords", "Followers", "Share", "Examples").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == lgtable.HeaderRow {
+				return ui.TableHeaderStyle
+			}
+			if row%2 == 0 {
+				return ui.TableRowEvenStyle
+			}
+			return ui.TableRowOddStyle
+		})
+
+	ui.Titleln("Follower Interests")
+	fmt.Println(t)
+	ui.Successln("%d cluster(s) from %d bio(s) across %d follower(s)", len(clusters), bios, followers)
+}
+
+// listMembership records whether a follower is on the checked list
+type listMembership struct {
+	Did         string `json:"did"`
+	Handle      string `json:"handle"`
+	DisplayName string `json:"displayName,omitempty"`
+	InList      bool   `json:"inList"`
+}
+
+// listMembershipReport is the JSON output of followers in-list
+type listMembershipReport struct {
+	List         string           `json:"list"`
+	Name         string           `json:"name"`
+	Followers    int              `json:"followers"`
+	Members      int              `json:"members"`
+	NonFollowers int              `json:"nonFollowerMembers"`
+	Memberships  []listMembership `json:"memberships"`
+}
+
+// FollowersInListAction reports which followers are and are not members of a list
+func FollowersInListAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("list URI required")
+	}
+
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	outputFormat := cmd.String("output")
+	if outputFormat != "table" && outputFormat != "json" && outputFormat != "csv" {
+		return fmt.Errorf("output format must be 'table', 'json', or 'csv'")
+	}
+	if cmd.Bool("missing") && cmd.Bool("members") {
+		return fmt.Errorf("--missing and --members cannot be combined")
+	}
+
+	listURI, err := resolveListURI(ctx, profiles, cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	list, err := fetcher.GetList(ctx, listURI, 1, "")
+	if err != nil {
+		return fmt.Errorf("failed to fetch list: %w", err)
+	}
+
+	members, err := bsky.NewPaginator(bsky.ListMemberPages(fetcher, listURI), bsky.PaginatorOptions{
+		Progress: logPageProgress("list members"),
+	}).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch list members: %w", err)
+	}
+
+	actor, err := userActor(ctx, cmd, reg, fetcher)
+	if err != nil {
+		return err
+	}
+
+	pageOpts, err := graphPageOptions(cmd, "followers")
+	if err != nil {
+		return err
+	}
+	followers, err := bsky.NewPaginator(bsky.FollowerPages(fetcher, actor), pageOpts).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch followers: %w", capError(cmd, "followers", err))
+	}
+
+	onList := make(map[string]bool, len(members))
+	for _, member := range members {
+		onList[member.Did] = true
+	}
+
+	report := listMembershipReport{
+		List:        listURI,
+		Name:        list.List.Name,
+		Followers:   len(followers),
+		Memberships: []listMembership{},
+	}
+	for _, follower := range followers {
+		inList := onList[follower.Did]
+		if inList {
+			report.Members++
+			delete(onList, follower.Did)
+		}
+		if (cmd.Bool("missing") && inList) || (cmd.Bool("members") && !inList) {
+			continue
+		}
+		report.Memberships = append(report.Memberships, listMembership{
+			Did:         follower.Did,
+			Handle:      follower.Handle,
+			DisplayName: follower.DisplayName,
+			InList:      inList,
+		})
+	}
+	report.NonFollowers = len(onList)
+
+	logger.Debug("Checked list membership", "list", listURI, "members", len(members), "followers", len(followers))
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(cmd.Root().Writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "csv":
+		return outputListMembershipCSV(cmd.Root().Writer, report.Memberships)
+	default:
+		displayListMembership(report)
+	}
+	return nil
+}
+
+// resolveListURI accepts an at:// list URI or a https://bsky.app/profile/<actor>/lists/<rkey> link and
+// returns an at:// URI whose authority is a DID, resolving handles through profiles
+func resolveListURI(ctx context.Context, profiles bsky.ProfileFetcher, input string) (string, error) {
+	ref, err := aturi.ParseList(input)
+	if err != nil {
+		return "", err
+	}
+
+	authority, err := resolveActor(ctx, profiles, "list owner", ref.Authority)
+	if err != nil {
+		return "", err
+	}
+	return "at://" + authority + "/" + aturi.CollectionList + "/" + ref.Rkey, nil
+}
+
+// outputListMembershipCSV writes one row per follower with its membership flag
+func outputListMembershipCSV(w io.Writer, memberships []listMembership) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"handle", "did", "displayName", "inList"}); err != nil {
+		return err
+	}
+	for _, m := range memberships {
+		if err := writer.Write([]string{m.Handle, m.Did, m.DisplayName, fmt.Sprintf("%t", m.InList)}); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		allFollowers = append(allFollowers, response.Followers...)
+// displayListMembership renders followers with their list membership and a summary
+func displayListMembership(report listMembershipReport) {
+	name := report.Name
+	if name == "" {
+		name = report.List
+	}
 
-		if response.Cursor != "" {
-			logger.Infof("Fetched page %d (%d followers so far)...", page, len(allFollowers))
+	if len(report.Memberships) > 0 {
+		// Screen readers announce the check marks poorly, so lines spell them out
+		yes, no := "✓", "✗"
+		if ui.ListAsLines() {
+			yes, no = "yes", "no"
+		}
+		rows := make([][]string, 0, len(report.Memberships))
+		for _, m := range report.Memberships {
+			status := no
+			if m.InList {
+				status = yes
+			}
+			rows = append(rows, []string{"@" + m.Handle, m.DisplayName, status})
 		}
 
-		if response.Cursor == "" {
-			break
+		headers := []string{"Handle", "Display Name", "In List"}
+		ui.Titleln("Followers in %s", name)
+		if ui.ListAsLines() {
+			fmt.Println(ui.FormatLines(headers, rows))
+			fmt.Println()
+		} else {
+			t := lgtable.New().
+				Border(lipgloss.NormalBorder()).
+				BorderStyle(ui.TableBorderStyle).
+				Headers(headers...).
+				Rows(rows...).
+				StyleFunc(func(row, col int) lipgloss.Style {
+					if row == lgtable.HeaderRow {
+						return ui.TableHeaderStyle
+					}
+					if row%2 == 0 {
+						return ui.TableRowEvenStyle
+					}
+					return ui.TableRowOddStyle
+				})
+			fmt.Println(t)
 		}
-		cursor = response.Cursor
 	}
 
-	logger.Infof("Fetched %d total followers", len(allFollowers))
-
-	followerInfos, actors := enrichFollowerProfiles(ctx, service, allFollowers, logger)
-
-	if inactiveDays > 0 {
-		followerInfos = filterInactive(ctx, service, cacheRepo, followerInfos, actors, inactiveDays, refresh, logger)
+	ui.Successln("%d of %d follower(s) are on %s", report.Members, report.Followers, name)
+	if report.NonFollowers > 0 {
+		ui.Infoln("%d list member(s) are not followers", report.NonFollowers)
 	}
+}
 
-	if quietPosters {
-		followerInfos = filterQuiet(ctx, service, cacheRepo, followerInfos, actors, quietThreshold, refresh, logger)
+// logPageProgress returns a paginator progress callback that logs the running total of noun
+func logPageProgress(noun string) func(page, fetched int) {
+	return func(page, fetched int) {
+		logger.Infof("Fetched page %d (%d %s so far)...", page, fetched, noun)
 	}
+}
 
-	switch outputFormat {
-	case "json":
-		return outputFollowersJSON(followerInfos)
-	case "csv":
-		return outputFollowersCSV(followerInfos, inactiveDays > 0 || quietPosters)
-	default:
-		return fmt.Errorf("output format must be 'json' or 'csv'")
+// warnBatchReport warns when fetching noun for a batch of accounts needed retries or left some out
+func warnBatchReport(logger *log.Logger, noun string, report *bsky.BatchReport) {
+	if report.Retries() == 0 && len(report.Failed()) == 0 {
+		return
 	}
+	logger.Warnf("%s: %s", noun, report)
 }
 
 // enrichFollowerProfiles fetches full profiles and merges them with lightweight profiles
-func enrichFollowerProfiles(ctx context.Context, service *store.BlueskyService, profiles []store.ActorProfile, logger *log.Logger) ([]followerInfo, []string) {
+func enrichFollowerProfiles(ctx context.Context, fetcher bsky.ProfileFetcher, profiles []bsky.ActorProfile, logger *log.Logger) ([]followerInfo, []string) {
 	logger.Infof("Fetching detailed profiles for %d accounts...", len(profiles))
 	actors := make([]string, len(profiles))
 	for i, profile := range profiles {
 		actors[i] = profile.Did
 	}
 
-	fullProfiles := service.BatchGetProfiles(ctx, actors, 10)
+	ctx, report := bsky.WithBatchReport(ctx)
+	fullProfiles := fetcher.BatchGetProfiles(ctx, actors, 0)
 	logger.Infof("Fetched %d detailed profiles", len(fullProfiles))
+	warnBatchReport(logger, "Profiles", report)
 
 	followerInfos := make([]followerInfo, len(profiles))
 	for i, profile := range profiles {
 		if fullProfile, ok := fullProfiles[profile.Did]; ok {
 			followerInfos[i] = followerInfo{Profile: fullProfile}
 		} else {
-			followerInfos[i] = followerInfo{Profile: &profile}
+			followerInfos[i] = followerInfo{Profile: &profile, FetchFailed: []string{fetchProfile}}
 		}
 	}
 
 	return followerInfos, actors
 }
 
-// filterInactive filters follower infos to only include accounts inactive for N days
-func filterInactive(ctx context.Context, service *store.BlueskyService, cacheRepo *store.CacheRepository, followerInfos []followerInfo, actors []string, inactiveDays int, refresh bool, logger *log.Logger) []followerInfo {
+// filterInactive filters follower infos to only include accounts inactive for N days.
+// Accounts whose activity couldn't be fetched are kept and marked, since they can't be ruled out.
+func filterInactive(ctx context.Context, fetcher bsky.ProfileFetcher, cache bsky.RateCache, followerInfos []followerInfo, actors []string, inactiveDays int, refresh bool, logger *log.Logger) []followerInfo {
 	logger.Infof("Checking activity status (threshold: %d days)...", inactiveDays)
 
-	lastPostDates := service.BatchGetLastPostDatesCached(ctx, cacheRepo, actors, 10, refresh)
+	ctx, report := bsky.WithBatchReport(ctx)
+	lastPostDates := bsky.BatchGetLastPostDatesCached(ctx, fetcher, cache, actors, 0, refresh)
+	warnBatchReport(logger, "Activity", report)
 
 	var filtered []followerInfo
 	for i, info := range followerInfos {
 		lastPost, ok := lastPostDates[actors[i]]
 		info.LastPostDate = lastPost
 
-		if !ok || lastPost.IsZero() {
+		switch {
+		case !ok:
+			info.FetchFailed = append(info.FetchFailed, fetchActivity)
+			info.DaysSincePost = -1
+		case lastPost.IsZero():
 			info.IsInactive = true
 			info.DaysSincePost = -1
-		} else {
+		default:
 			daysSince := int(time.Since(lastPost).Hours() / 24)
 			info.DaysSincePost = daysSince
 			info.IsInactive = daysSince > inactiveDays
 		}
 
-		if info.IsInactive {
+		if info.IsInactive || !ok {
 			filtered = append(filtered, info)
 		}
 		followerInfos[i] = info
@@ -739,37 +1680,62 @@ func filterInactive(ctx context.Context, service *store.BlueskyService, cacheRep
 	return filtered
 }
 
-// filterQuiet filters follower infos to only include quiet posters
-func filterQuiet(ctx context.Context, service *store.BlueskyService, cacheRepo *store.CacheRepository, followerInfos []followerInfo, actors []string, threshold float64, refresh bool, logger *log.Logger) []followerInfo {
+// filterQuiet filters follower infos to only include quiet posters.
+// Accounts whose post rate couldn't be fetched are kept and marked, since they can't be ruled out.
+func filterQuiet(ctx context.Context, fetcher bsky.ProfileFetcher, cache bsky.RateCache, followerInfos []followerInfo, actors []string, threshold float64, refresh bool, logger *log.Logger) []followerInfo {
 	logger.Infof("Computing post rates (threshold: %.2f posts/day)...", threshold)
 	if refresh {
 		logger.Infof("Refreshing cache (this may take a while)...")
 	}
 
-	postRates := service.BatchGetPostRatesCached(ctx, cacheRepo, actors, 30, 30, 10, refresh, func(current, total int) {
+	ctx, report := bsky.WithBatchReport(ctx)
+	postRates := bsky.BatchGetPostRatesCached(ctx, fetcher, cache, actors, 30, 30, 0, refresh, func(current, total int) {
 		if current%10 == 0 || current == total {
 			logger.Infof("Progress: %d/%d accounts analyzed", current, total)
 		}
 	})
+	warnBatchReport(logger, "Post rates", report)
 
 	var filtered []followerInfo
+	quiet := 0
 	for i, info := range followerInfos {
-		if rate, ok := postRates[actors[i]]; ok {
+		rate, ok := postRates[actors[i]]
+		if ok {
 			info.PostsPerDay = rate.PostsPerDay
+			info.PostsByDay = rate.Daily
 			info.LastPostDate = rate.LastPostDate
 			info.IsQuiet = rate.PostsPerDay <= threshold
+		} else {
+			info.FetchFailed = append(info.FetchFailed, fetchPostRate)
 		}
 
 		if info.IsQuiet {
+			quiet++
+		}
+		if info.IsQuiet || !ok {
 			filtered = append(filtered, info)
 		}
 		followerInfos[i] = info
 	}
 
-	logger.Infof("Found %d quiet posters (posting <= %.2f times/day)", len(filtered), threshold)
+	logger.Infof("Found %d quiet posters (posting <= %.2f times/day)", quiet, threshold)
 	return filtered
 }
 
+// filterTagged keeps the followers carrying the --tag value, or all of them when --tag is unset
+func filterTagged(ctx context.Context, cmd *cli.Command, reg *registry.Registry, followers []bsky.ActorProfile) ([]bsky.ActorProfile, error) {
+	tagged, err := taggedSet(ctx, cmd, reg)
+	if err != nil || tagged == nil {
+		return followers, err
+	}
+
+	filtered := slices.DeleteFunc(followers, func(follower bsky.ActorProfile) bool {
+		return !slices.Contains(tagged.Actors, follower.Did)
+	})
+	logger.Infof("%d follower(s) tagged %s", len(filtered), cmd.String("tag"))
+	return filtered, nil
+}
+
 func displayDiffTable(baselineLabel, comparisonLabel string, baselineCount, comparisonCount int, newFollowers, unfollows []string) {
 	ui.Titleln("Follower Diff: %s → %s", baselineLabel, comparisonLabel)
 	fmt.Println()
@@ -800,6 +1766,149 @@ func displayDiffTable(baselineLabel, comparisonLabel string, baselineCount, comp
 	}
 }
 
+// followerDiff returns the accounts in comparison but not baseline (new followers) and those in baseline but not
+// comparison (unfollows), each in input order
+func followerDiff(baseline, comparison []string) (newFollowers, unfollows []string) {
+	baselineSet := make(map[string]bool, len(baseline))
+	for _, did := range baseline {
+		baselineSet[did] = true
+	}
+	comparisonSet := make(map[string]bool, len(comparison))
+	for _, did := range comparison {
+		comparisonSet[did] = true
+	}
+
+	for _, did := range comparison {
+		if !baselineSet[did] {
+			newFollowers = append(newFollowers, did)
+		}
+	}
+	for _, did := range baseline {
+		if !comparisonSet[did] {
+			unfollows = append(unfollows, did)
+		}
+	}
+	return newFollowers, unfollows
+}
+
+// retentionDiff compares three follower sets: accounts gained between before and after, and accounts lost
+// between them, each marked with whether they follow in against
+func retentionDiff(before, after, against []string) retentionOutput {
+	beforeSet := make(map[string]bool, len(before))
+	for _, did := range before {
+		beforeSet[did] = true
+	}
+	afterSet := make(map[string]bool, len(after))
+	for _, did := range after {
+		afterSet[did] = true
+	}
+	againstSet := make(map[string]bool, len(against))
+	for _, did := range against {
+		againstSet[did] = true
+	}
+
+	report := retentionOutput{Gained: []retentionEntry{}, Lost: []retentionEntry{}}
+	for _, did := range after {
+		if beforeSet[did] {
+			continue
+		}
+		report.Gained = append(report.Gained, retentionEntry{Did: did, After: true, Against: againstSet[did]})
+		if againstSet[did] {
+			report.Summary.RetainedCount++
+		}
+	}
+	for _, did := range before {
+		if afterSet[did] {
+			continue
+		}
+		report.Lost = append(report.Lost, retentionEntry{Did: did, Before: true, Against: againstSet[did]})
+		if againstSet[did] {
+			report.Summary.ReturnedCount++
+		}
+	}
+
+	report.Summary.BeforeCount = len(beforeSet)
+	report.Summary.AfterCount = len(afterSet)
+	report.Summary.AgainstCount = len(againstSet)
+	report.Summary.GainedCount = len(report.Gained)
+	report.Summary.LostCount = len(report.Lost)
+	if report.Summary.GainedCount > 0 {
+		report.Summary.RetentionRate = float64(report.Summary.RetainedCount) / float64(report.Summary.GainedCount)
+	}
+	return report
+}
+
+// displayRetentionTable renders a three-way diff with one presence column per compared point
+func displayRetentionTable(labels [3]string, report retentionOutput) {
+	ui.Titleln("Follower Retention: %s → %s → %s", labels[0], labels[1], labels[2])
+	fmt.Println()
+
+	summary := report.Summary
+	fmt.Printf("Followers:  %d → %d → %d\n", summary.BeforeCount, summary.AfterCount, summary.AgainstCount)
+	fmt.Printf("Gained:     %d, %d still following (%.0f%% retained)\n", summary.GainedCount, summary.RetainedCount, summary.RetentionRate*100)
+	fmt.Printf("Lost:       %d, %d following again\n", summary.LostCount, summary.ReturnedCount)
+	fmt.Println()
+
+	if len(report.Gained) == 0 && len(report.Lost) == 0 {
+		ui.Infoln("No changes detected")
+		return
+	}
+
+	mark := func(present bool) string {
+		if present {
+			return "✓"
+		}
+		return "·"
+	}
+
+	rows := make([][]string, 0, len(report.Gained)+len(report.Lost))
+	for _, entry := range report.Gained {
+		rows = append(rows, []string{entry.Did, "gained", mark(entry.Before), mark(entry.After), mark(entry.Against)})
+	}
+	for _, entry := range report.Lost {
+		rows = append(rows, []string{entry.Did, "lost", mark(entry.Before), mark(entry.After), mark(entry.Against)})
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("DID", "Change", labels[0], labels[1], labels[2]).Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	fmt.Println(re.NewStyle().Render(t.String()))
+	fmt.Println()
+}
+
+// outputRetentionCSV writes a three-way diff as CSV with one presence column per compared point
+func outputRetentionCSV(report retentionOutput) error {
+	writer := csv.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"change", "did", "before", "after", "against"}); err != nil {
+		return err
+	}
+
+	for _, group := range []struct {
+		change  string
+		entries []retentionEntry
+	}{{"gained", report.Gained}, {"lost", report.Lost}} {
+		for _, entry := range group.entries {
+			record := []string{group.change, entry.Did, strconv.FormatBool(entry.Before), strconv.FormatBool(entry.After), strconv.FormatBool(entry.Against)}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func outputDiffJSON(newFollowers, unfollows []string) error {
 	output := diffOutput{
 		NewFollowers: newFollowers,
@@ -842,31 +1951,13 @@ func outputDiffCSV(newFollowers, unfollows []string) error {
 	return nil
 }
 
-// formatTimeSince formats a time duration into a human-readable string.
-//
-// Returns
-//   - "< 1 hour ago" for durations under 1 hour
-//   - "X hours ago" for under 24 hours
-//   - "X days ago" for longer durations.
-func formatTimeSince(since time.Time) string {
-	if since.IsZero() {
+// formatLastPost renders a last-post date for tables: relative unless --dates says otherwise, and "never" when the
+// account hasn't posted
+func formatLastPost(t time.Time) string {
+	if t.IsZero() {
 		return "never"
 	}
-
-	duration := time.Since(since)
-	hours := duration.Hours()
-
-	if hours < 1 {
-		return "< 1 hour ago"
-	} else if hours < 24 {
-		return fmt.Sprintf("%d hours ago", int(hours))
-	} else {
-		days := int(hours / 24)
-		if days == 1 {
-			return "1 day ago"
-		}
-		return fmt.Sprintf("%d days ago", days)
-	}
+	return ui.FormatDate(t, ui.DatesRelative)
 }
 
 func displayFollowersTable(followers []followerInfo, showInactive bool) {
@@ -877,16 +1968,105 @@ func displayFollowersTable(followers []followerInfo, showInactive bool) {
 
 	ui.Titleln("Followers (%d)", len(followers))
 	fmt.Println()
+	if ui.ListAsLines() {
+		headers, rows := followerRows(followers, showInactive)
+		fmt.Println(ui.FormatLines(headers, rows))
+		fmt.Println()
+	} else {
+		table, fit := renderFollowersTable(followers, showInactive)
+		fmt.Println(table)
+		fit.HiddenNote()
+	}
+	fmt.Println(summaryFooter(summarizeFollowers(followers)))
+	fmt.Println()
+}
+
+// followerListSummary aggregates the rows a followers or following list displays.
+// Accounts whose profile or post rate couldn't be fetched are left out of the averages they would skew.
+type followerListSummary struct {
+	Total           int      `json:"total"`
+	MeanFollowers   float64  `json:"meanFollowers"`
+	MedianFollowers float64  `json:"medianFollowers"`
+	MeanPostsPerDay *float64 `json:"meanPostsPerDay,omitempty"` // set only when post rates were fetched
+}
+
+// followerListOutput is the JSON form of a followers or following list
+type followerListOutput struct {
+	Accounts []followerInfo      `json:"accounts"`
+	Summary  followerListSummary `json:"summary"`
+}
+
+// summarizeFollowers computes the total, follower count mean and median, and mean posting rate of followers
+func summarizeFollowers(followers []followerInfo) followerListSummary {
+	summary := followerListSummary{Total: len(followers)}
+
+	var counts []int
+	for _, f := range followers {
+		if !f.failed(fetchProfile) {
+			counts = append(counts, f.Profile.FollowersCount)
+		}
+	}
+	if len(counts) > 0 {
+		slices.Sort(counts)
+		sum := 0
+		for _, c := range counts {
+			sum += c
+		}
+		summary.MeanFollowers = math.Round(float64(sum)/float64(len(counts))*100) / 100
+		mid := len(counts) / 2
+		if len(counts)%2 == 0 {
+			summary.MedianFollowers = float64(counts[mid-1]+counts[mid]) / 2
+		} else {
+			summary.MedianFollowers = float64(counts[mid])
+		}
+	}
+
+	if quietRows(followers) {
+		var sum float64
+		n := 0
+		for _, f := range followers {
+			if !f.failed(fetchPostRate) {
+				sum += f.PostsPerDay
+				n++
+			}
+		}
+		if n > 0 {
+			mean := math.Round(sum/float64(n)*100) / 100
+			summary.MeanPostsPerDay = &mean
+		}
+	}
+	return summary
+}
+
+// summaryFooter formats a list summary as the line shown under a table
+func summaryFooter(summary followerListSummary) string {
+	footer := fmt.Sprintf("Total: %d · Followers: mean %.1f, median %.1f", summary.Total, summary.MeanFollowers, summary.MedianFollowers)
+	if summary.MeanPostsPerDay != nil {
+		footer += fmt.Sprintf(" · Posts/day: mean %.2f", *summary.MeanPostsPerDay)
+	}
+	return footer
+}
 
+// followerRows returns the headers and cells of a followers listing, with optional columns only when some
+// follower has a value for them
+func followerRows(followers []followerInfo, showInactive bool) ([]string, [][]string) {
 	headers := []string{"Handle", "Display Name", "Followers", "Posts"}
 
-	if showInactive && len(followers) > 0 && followers[0].IsQuiet {
-		headers = append(headers, "Posts/Day", "Last Post")
-	} else if len(followers) > 0 && followers[0].IsQuiet {
-		headers = append(headers, "Posts/Day")
-	} else if showInactive {
+	quiet := quietRows(followers)
+	if quiet {
+		headers = append(headers, "Posts/Day", "Last 7 Days")
+	}
+	if showInactive {
 		headers = append(headers, "Last Post")
 	}
+	followDates := followDateRows(followers)
+	if followDates {
+		headers = append(headers, "Followed")
+	}
+	notes := noteRows(followers)
+	if notes {
+		headers = append(headers, "Note")
+	}
 	headers = append(headers, "Profile URL")
 
 	data := make([][]string, len(followers))
@@ -896,120 +2076,389 @@ func displayFollowersTable(followers []followerInfo, showInactive bool) {
 			displayName = info.Profile.Handle
 		}
 
-		profileURL := fmt.Sprintf("https://bsky.app/profile/%s", info.Profile.Handle)
-
 		row := []string{
 			"@" + info.Profile.Handle,
 			displayName,
 			fmt.Sprintf("%d", info.Profile.FollowersCount),
 			fmt.Sprintf("%d", info.Profile.PostsCount),
 		}
+		if info.failed(fetchProfile) {
+			row[2], row[3] = fetchFailedCell, fetchFailedCell
+		}
 
-		if showInactive && info.IsQuiet {
-			row = append(row, fmt.Sprintf("%.2f", info.PostsPerDay))
-			row = append(row, formatTimeSince(info.LastPostDate))
-		} else if info.IsQuiet {
-			row = append(row, fmt.Sprintf("%.2f", info.PostsPerDay))
-		} else if showInactive {
-			row = append(row, formatTimeSince(info.LastPostDate))
+		if quiet {
+			if info.failed(fetchPostRate) {
+				row = append(row, fetchFailedCell, "")
+			} else {
+				row = append(row, fmt.Sprintf("%.2f", info.PostsPerDay), postsByDayCell(info.PostsByDay))
+			}
+		}
+		if showInactive {
+			if info.LastPostDate.IsZero() && (info.failed(fetchActivity) || info.failed(fetchPostRate)) {
+				row = append(row, fetchFailedCell)
+			} else {
+				row = append(row, formatLastPost(info.LastPostDate))
+			}
+		}
+		if followDates {
+			followed := "unknown"
+			if !info.FollowedAt.IsZero() {
+				followed = ui.FormatDate(info.FollowedAt, ui.DatesLocal)
+				if info.FollowedAtSource == followDateSnapshot {
+					followed = "by " + followed
+				}
+			}
+			row = append(row, followed)
+		}
+		if notes {
+			row = append(row, noteCell(info.Note))
 		}
 
-		row = append(row, profileURL)
+		row = append(row, profileURL(info.Profile.Handle))
 		data[i] = row
 	}
+	return headers, data
+}
+
+// postsByDayCell draws daily post counts as a sparkline, or in lines mode spells them out for screen readers,
+// with ? for days the sample didn't reach
+func postsByDayCell(daily []int) string {
+	if !ui.ListAsLines() {
+		return ui.Sparkline(daily)
+	}
+	counts := make([]string, len(daily))
+	for i, n := range daily {
+		counts[i] = "?"
+		if n >= 0 {
+			counts[i] = strconv.Itoa(n)
+		}
+	}
+	return strings.Join(counts, " ")
+}
+
+// followerColumnPriority orders the columns a followers table drops to fit the terminal, the profile URL first.
+// The handle, follower count, and the posting rate or last post a filter adds are always shown.
+var followerColumnPriority = map[string]int{"Profile URL": 6, "Note": 5, "Followed": 4, "Last 7 Days": 3, "Posts": 2, "Display Name": 1}
 
-	lastColIdx := len(headers) - 1
+// renderFollowersTable lays out followers as a styled table fit to the terminal, returning the fitted columns. On a
+// terminal, handles and last post dates are colored by how recently the account posted, and verified handles are
+// bold.
+func renderFollowersTable(followers []followerInfo, showInactive bool) (string, ui.FittedTable) {
+	headers, data := followerRows(followers, showInactive)
+	fit := ui.FitTable(headers, data, followerColumnPriority, "Display Name", "Note")
+	urlCol := fit.Column("Profile URL")
+	lastPostCol := fit.Column("Last Post")
+
+	indicators := ui.Indicators()
+	now := time.Now()
 
 	re := lipgloss.NewRenderer(os.Stdout)
-	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).Headers(headers...).Rows(data...)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).Headers(fit.Headers...).Rows(fit.Rows...)
 	t = t.StyleFunc(func(row, col int) lipgloss.Style {
 		if row == lgtable.HeaderRow {
 			return ui.TableHeaderStyle
 		}
 
-		if col == 0 {
-			even := row%2 == 0
-			if even {
-				return ui.TableRowEvenStyle.Foreground(lipgloss.Color("#f6c177"))
-			}
-			return ui.TableRowOddStyle.Foreground(lipgloss.Color("#f6c177"))
+		baseStyle := ui.TableRowEvenStyle
+		if row%2 != 0 {
+			baseStyle = ui.TableRowOddStyle
 		}
 
-		if col == lastColIdx {
-			even := row%2 == 0
-			baseStyle := ui.TableRowEvenStyle
-			if !even {
-				baseStyle = ui.TableRowOddStyle
+		switch col {
+		case 0:
+			style := baseStyle.Foreground(lipgloss.Color("#f6c177"))
+			if indicators {
+				style = ui.ActivityStyle(style, activityIndicator(followers[row], showInactive, now))
+				if isVerified(followers[row].Profile) {
+					style = style.Bold(true)
+				}
+			}
+			return style
+		case lastPostCol:
+			if indicators {
+				return ui.ActivityStyle(baseStyle, activityIndicator(followers[row], showInactive, now))
 			}
+		case urlCol:
 			return baseStyle.Foreground(lipgloss.Color("#e0def4"))
 		}
-
-		if row%2 == 0 {
-			return ui.TableRowEvenStyle
-		}
-		return ui.TableRowOddStyle
+		return baseStyle
 	})
 
-	fmt.Println(re.NewStyle().Render(t.String()))
-	fmt.Println()
+	return re.NewStyle().Render(t.String()), fit
 }
 
-func outputFollowersJSON(followers []followerInfo) error {
-	encoder := json.NewEncoder(os.Stdout)
+// activityIndicator buckets how recently a follower posted, or [ui.ActivityUnknown] when their last post wasn't
+// fetched
+func activityIndicator(info followerInfo, fetched bool, now time.Time) ui.Activity {
+	if !fetched || (info.LastPostDate.IsZero() && (info.failed(fetchActivity) || info.failed(fetchPostRate))) {
+		return ui.ActivityUnknown
+	}
+	return ui.ActivityOf(info.LastPostDate, now)
+}
+
+// isVerified reports whether Bluesky shows the account as verified
+func isVerified(profile *bsky.ActorProfile) bool {
+	return profile != nil && profile.Verification != nil && profile.Verification.VerifiedStatus == "valid"
+}
+
+// outputFollowersJSON writes followers with their summary as JSON
+func outputFollowersJSON(w io.Writer, followers []followerInfo) error {
+	if followers == nil {
+		followers = []followerInfo{}
+	}
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(followers)
+	return encoder.Encode(followerListOutput{Accounts: followers, Summary: summarizeFollowers(followers)})
 }
 
-func outputFollowersCSV(followers []followerInfo, includeInactive bool) error {
-	writer := csv.NewWriter(os.Stdout)
+// outputFollowersCSV writes followers as CSV, dropping any columns the redactor omits (redactor may be nil)
+func outputFollowersCSV(w io.Writer, followers []followerInfo, includeInactive bool, redactor *export.Redactor) error {
+	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
-	hasQuiet := len(followers) > 0 && followers[0].IsQuiet
+	header, rows := followerTable(followers, includeInactive, redactor)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = csvCell(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// followerTable returns the export columns and typed rows for followers, leaving out columns the redactor omits
+// (redactor may be nil). Quiet and inactivity columns appear when those filters ran.
+func followerTable(followers []followerInfo, includeInactive bool, redactor *export.Redactor) ([]string, [][]any) {
+	hasQuiet := quietRows(followers)
+	hasFollowDates := followDateRows(followers)
+	hasFailures := countFetchFailures(followers) > 0
+	hasNotes := noteRows(followers)
 
-	header := []string{"handle", "displayName", "did", "followersCount", "postsCount", "profileURL"}
+	header := []string{"handle", "displayName", "did", "followersCount", "postsCount"}
 	if hasQuiet {
 		header = append(header, "postsPerDay")
 	}
 	if includeInactive {
 		header = append(header, "daysSincePost", "lastPostDate")
 	}
-	if err := writer.Write(header); err != nil {
-		return err
+	if hasFollowDates {
+		header = append(header, "followedAt", "followedAtSource")
+	}
+	if hasFailures {
+		header = append(header, "fetchFailed")
+	}
+	if hasNotes {
+		header = append(header, "note")
+	}
+	keep := make([]bool, len(header))
+	for i, column := range header {
+		keep[i] = !redactor.Omits(column)
 	}
 
+	rows := make([][]any, 0, len(followers))
 	for _, info := range followers {
-		// profileURL := fmt.Sprintf("https://bsky.app/profile/%s", info.Profile.Handle)
-		row := []string{
+		row := []any{
 			info.Profile.Handle,
 			info.Profile.DisplayName,
 			info.Profile.Did,
-			fmt.Sprintf("%d", info.Profile.FollowersCount),
-			fmt.Sprintf("%d", info.Profile.PostsCount),
-			// profileURL,
+			info.Profile.FollowersCount,
+			info.Profile.PostsCount,
+		}
+		if info.failed(fetchProfile) {
+			row[3], row[4] = "", ""
 		}
 
 		if hasQuiet {
-			row = append(row, fmt.Sprintf("%.2f", info.PostsPerDay))
+			if info.failed(fetchPostRate) {
+				row = append(row, "")
+			} else {
+				row = append(row, math.Round(info.PostsPerDay*100)/100)
+			}
 		}
 
 		if includeInactive {
-			daysSince := "N/A"
+			var daysSince any = "N/A"
 			if info.DaysSincePost >= 0 {
-				daysSince = fmt.Sprintf("%d", info.DaysSincePost)
+				daysSince = info.DaysSincePost
 			}
-			lastPost := ""
-			if !info.LastPostDate.IsZero() {
-				lastPost = info.LastPostDate.Format(time.RFC3339)
-			}
-			row = append(row, daysSince, lastPost)
+			row = append(row, daysSince, ui.FormatDate(info.LastPostDate, ui.DatesISO))
+		}
+		if hasFollowDates {
+			row = append(row, ui.FormatDate(info.FollowedAt, ui.DatesISO), info.FollowedAtSource)
+		}
+		if hasFailures {
+			row = append(row, strings.Join(info.FetchFailed, ";"))
+		}
+		if hasNotes {
+			row = append(row, info.Note)
 		}
 
-		if err := writer.Write(row); err != nil {
-			return err
+		rows = append(rows, filterColumns(row, keep))
+	}
+
+	return filterColumns(header, keep), rows
+}
+
+// csvCell formats a typed table value for CSV, with two decimals for rates
+func csvCell(value any) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', 2, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// filterColumns returns the values whose column is marked to keep
+func filterColumns[T any](values []T, keep []bool) []T {
+	filtered := make([]T, 0, len(values))
+	for i, v := range values {
+		if i >= len(keep) || keep[i] {
+			filtered = append(filtered, v)
 		}
 	}
+	return filtered
+}
 
-	return nil
+// followerExportReport gathers what a follower workbook summarizes
+type followerExportReport struct {
+	Actor           string
+	Followers       []followerInfo // followers exported, after filters
+	IncludeInactive bool
+	InactiveDays    int
+	QuietThreshold  float64
+	Fetched         int                 // followers fetched before filters
+	Baseline        *bsky.SnapshotModel // latest follower snapshot, nil without one
+	NewFollowers    []string            // followers since Baseline
+	Unfollows       []string            // followers lost since Baseline
+}
+
+// diffLatestSnapshot compares current followers with actor's latest follower snapshot.
+// Returns a nil snapshot when there is none to compare with.
+func diffLatestSnapshot(ctx context.Context, reg *registry.Registry, actor string, current []bsky.ActorProfile) (*bsky.SnapshotModel, []string, []string) {
+	snapshotRepo, err := reg.GetSnapshotRepo()
+	if err != nil {
+		logger.Debug("No snapshot repository for export diff", "error", err)
+		return nil, nil, nil
+	}
+
+	snapshot, err := snapshotRepo.FindByUserAndType(ctx, actor, "followers")
+	if err != nil || snapshot == nil {
+		return nil, nil, nil
+	}
+
+	baselineDids, err := snapshotRepo.GetActorDids(ctx, snapshot.ID())
+	if err != nil {
+		logger.Warn("Failed to read snapshot for export diff", "snapshot", snapshot.ID(), "error", err)
+		return nil, nil, nil
+	}
+
+	baseline := make(map[string]bool, len(baselineDids))
+	for _, did := range baselineDids {
+		baseline[did] = true
+	}
+	currentSet := make(map[string]bool, len(current))
+	var newFollowers, unfollows []string
+	for _, follower := range current {
+		currentSet[follower.Did] = true
+		if !baseline[follower.Did] {
+			newFollowers = append(newFollowers, follower.Did)
+		}
+	}
+	for _, did := range baselineDids {
+		if !currentSet[did] {
+			unfollows = append(unfollows, did)
+		}
+	}
+	return snapshot, newFollowers, unfollows
+}
+
+// followerSheets lays out a follower export as Followers, Summary, and Diff worksheets
+func followerSheets(report followerExportReport, quietPosters bool, redactor *export.Redactor) []export.Sheet {
+	header, rows := followerTable(report.Followers, report.IncludeInactive, redactor)
+
+	summary := [][]any{
+		{"Account", redactor.Value("did", report.Actor)},
+		{"Exported at", time.Now().Format(time.RFC3339)},
+		{"Followers fetched", report.Fetched},
+		{"Followers exported", len(report.Followers)},
+	}
+	if report.InactiveDays > 0 {
+		summary = append(summary, []any{"Filter", fmt.Sprintf("no posts in %d days", report.InactiveDays)})
+	}
+	if quietPosters {
+		summary = append(summary, []any{"Filter", fmt.Sprintf("fewer than %.2f posts per day", report.QuietThreshold)})
+	}
+	if len(report.Followers) > 0 && !redactor.Omits("followersCount") {
+		total := 0
+		for _, info := range report.Followers {
+			total += info.Profile.FollowersCount
+		}
+		summary = append(summary, []any{"Mean followers per follower", math.Round(float64(total)/float64(len(report.Followers))*100) / 100})
+	}
+	if report.Baseline != nil {
+		summary = append(summary,
+			[]any{"Compared with snapshot", report.Baseline.CreatedAt().Format(time.RFC3339)},
+			[]any{"Followers in snapshot", report.Baseline.TotalCount},
+			[]any{"New followers", len(report.NewFollowers)},
+			[]any{"Unfollows", len(report.Unfollows)},
+		)
+	} else {
+		summary = append(summary, []any{"Compared with snapshot", "none (run 'followers list' to take one)"})
+	}
+
+	diffHeader := []string{"change", "did"}
+	if redactor.Omits("did") {
+		diffHeader = diffHeader[:1]
+	}
+	var diffRows [][]any
+	for _, group := range []struct {
+		change string
+		dids   []string
+	}{{"new_follower", report.NewFollowers}, {"unfollow", report.Unfollows}} {
+		for _, did := range group.dids {
+			row := []any{group.change, redactor.Value("did", did)}
+			diffRows = append(diffRows, row[:len(diffHeader)])
+		}
+	}
+
+	return []export.Sheet{
+		{Name: "Followers", Header: header, Rows: rows},
+		{Name: "Summary", Header: []string{"Metric", "Value"}, Rows: summary},
+		{Name: "Diff", Header: diffHeader, Rows: diffRows},
+	}
+}
+
+// displayGrowthChart draws a bar per day of growth, marking spikes and drops
+func displayGrowthChart(growth analytics.GrowthReport) {
+	if len(growth.Days) == 0 {
+		return
+	}
+
+	peak := 1
+	for _, day := range growth.Days {
+		peak = max(peak, day.Delta)
+	}
+
+	fmt.Println()
+	chartWidth := 30
+	for _, day := range growth.Days {
+		line := fmt.Sprintf("%s %-*s %3d  avg %.1f", day.Date.Format("01-02"), chartWidth, strings.Repeat("█", day.Delta*chartWidth/peak), day.Delta, day.RollingMean)
+		switch day.Anomaly {
+		case analytics.AnomalySpike:
+			fmt.Println(ui.WarningStyle.Render(line + "  ▲ spike"))
+		case analytics.AnomalyDrop:
+			fmt.Println(ui.WarningStyle.Render(line + "  ▼ drop"))
+		default:
+			fmt.Println(line)
+		}
+	}
 }
 
 func displayActivityChart(active, inactive int) {
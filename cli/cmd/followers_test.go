@@ -0,0 +1,985 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/export"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+	"github.com/xuri/excelize/v2"
+)
+
+// fakeGraph serves canned followers, follows, and activity in place of the Bluesky API
+type fakeGraph struct {
+	authenticated bool
+	did           string
+	pageSize      int
+	followers     []bsky.ActorProfile
+	follows       []bsky.ActorProfile
+	lists         map[string][]bsky.ActorProfile
+	feed          []bsky.FeedViewPost
+	liked         []bsky.FeedViewPost
+	likes         map[string][]bsky.ActorProfile
+	reposts       map[string][]bsky.ActorProfile
+	notifications []bsky.Notification
+	records       map[string][]bsky.Record // keyed by repo and collection, as "did/collection"
+	posts         []bsky.PostView
+	reports       []map[string]any
+	profileLabels []bsky.Label
+	labelers      []string
+	lastPostDates map[string]time.Time
+	failProfiles  map[string]bool // accounts whose detailed profile lookup fails
+	added         []string
+	followed      []string
+	blocked       []string
+	deleted       []string
+	err           error
+}
+
+func (g *fakeGraph) Authenticated() bool { return g.authenticated }
+func (g *fakeGraph) GetDid() string      { return g.did }
+
+func (g *fakeGraph) page(profiles []bsky.ActorProfile, cursor string) ([]bsky.ActorProfile, string) {
+	start := 0
+	if cursor != "" {
+		start = len(cursor)
+	}
+	end := min(start+g.pageSize, len(profiles))
+	next := ""
+	if end < len(profiles) {
+		next = strings.Repeat("c", end)
+	}
+	return profiles[start:end], next
+}
+
+func (g *fakeGraph) GetFollowers(ctx context.Context, actor string, limit int, cursor string) (*bsky.GetFollowersResponse, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	page, next := g.page(g.followers, cursor)
+	return &bsky.GetFollowersResponse{Followers: page, Cursor: next}, nil
+}
+
+func (g *fakeGraph) GetFollows(ctx context.Context, actor string, limit int, cursor string) (*bsky.GetFollowsResponse, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	page, next := g.page(g.follows, cursor)
+	return &bsky.GetFollowsResponse{Follows: page, Cursor: next}, nil
+}
+
+func (g *fakeGraph) GetList(ctx context.Context, list string, limit int, cursor string) (*bsky.GetListResponse, error) {
+	members, ok := g.lists[list]
+	if !ok {
+		return nil, errors.New("list not found")
+	}
+	page, next := g.page(members, cursor)
+	items := make([]bsky.ListItemView, len(page))
+	for i, member := range page {
+		items[i] = bsky.ListItemView{Subject: member}
+	}
+	return &bsky.GetListResponse{List: bsky.ListView{Uri: list, Name: "VIP"}, Items: items, Cursor: next}, nil
+}
+
+func (g *fakeGraph) GetRelationships(ctx context.Context, actor string, others []string) (*bsky.GetRelationshipsResponse, error) {
+	relationships := make([]bsky.Relationship, len(others))
+	for i, other := range others {
+		relationships[i] = bsky.Relationship{Did: other}
+		if slices.ContainsFunc(g.followers, func(p bsky.ActorProfile) bool { return p.Did == other }) {
+			relationships[i].FollowedBy = "at://" + other + "/app.bsky.graph.follow/self"
+		}
+	}
+	return &bsky.GetRelationshipsResponse{Actor: actor, Relationships: relationships}, nil
+}
+
+func (g *fakeGraph) AddToList(ctx context.Context, list, subject string) (*bsky.CreateRecordResponse, error) {
+	g.added = append(g.added, subject)
+	return &bsky.CreateRecordResponse{Uri: "at://" + g.did + "/app.bsky.graph.listitem/" + subject}, nil
+}
+
+func (g *fakeGraph) Follow(ctx context.Context, subject string) (*bsky.CreateRecordResponse, error) {
+	g.followed = append(g.followed, subject)
+	return &bsky.CreateRecordResponse{Uri: "at://" + g.did + "/app.bsky.graph.follow/" + subject}, nil
+}
+
+func (g *fakeGraph) Block(ctx context.Context, subject string) (*bsky.CreateRecordResponse, error) {
+	g.blocked = append(g.blocked, subject)
+	return &bsky.CreateRecordResponse{Uri: "at://" + g.did + "/app.bsky.graph.block/" + subject}, nil
+}
+
+func (g *fakeGraph) DeleteRecord(ctx context.Context, uri string) error {
+	g.deleted = append(g.deleted, uri)
+	return nil
+}
+
+func (g *fakeGraph) CreateReport(ctx context.Context, service, reasonType, reason string, subject map[string]any) (*bsky.CreateReportResponse, error) {
+	g.reports = append(g.reports, subject)
+	return &bsky.CreateReportResponse{ID: int64(len(g.reports)), ReasonType: reasonType, Reason: reason, Subject: subject}, nil
+}
+
+func (g *fakeGraph) MuteThread(ctx context.Context, root string) error   { return nil }
+func (g *fakeGraph) UnmuteThread(ctx context.Context, root string) error { return nil }
+
+func (g *fakeGraph) GetAuthorFeed(ctx context.Context, actor string, limit int, cursor string) (*bsky.GetAuthorFeedResponse, error) {
+	return &bsky.GetAuthorFeedResponse{Feed: g.feed}, nil
+}
+
+func (g *fakeGraph) GetActorLikes(ctx context.Context, actor string, limit int, cursor string) (*bsky.GetActorLikesResponse, error) {
+	return &bsky.GetActorLikesResponse{Feed: g.liked}, nil
+}
+
+func (g *fakeGraph) ListRecords(ctx context.Context, repo, collection string, limit int, cursor string) (*bsky.ListRecordsResponse, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	return &bsky.ListRecordsResponse{Records: g.records[repo+"/"+collection]}, nil
+}
+
+func (g *fakeGraph) GetRecord(ctx context.Context, repo, collection, rkey string) (*bsky.Record, error) {
+	uri := "at://" + repo + "/" + collection + "/" + rkey
+	for _, record := range g.records[repo+"/"+collection] {
+		if record.Uri == uri {
+			return &record, nil
+		}
+	}
+	return nil, errors.New("getRecord failed: 400 Bad Request - RecordNotFound")
+}
+
+func (g *fakeGraph) GetPosts(ctx context.Context, uris []string) (*bsky.GetPostsResponse, error) {
+	response := &bsky.GetPostsResponse{}
+	for i := range g.posts {
+		if slices.Contains(uris, g.posts[i].Uri) {
+			response.Posts = append(response.Posts, bsky.FeedViewPost{Post: &g.posts[i]})
+		}
+	}
+	return response, nil
+}
+
+func (g *fakeGraph) GetLikes(ctx context.Context, uri string, limit int, cursor string) (*bsky.GetLikesResponse, error) {
+	page, next := g.page(g.likes[uri], cursor)
+	likes := make([]bsky.Like, len(page))
+	for i, actor := range page {
+		likes[i] = bsky.Like{Actor: actor}
+	}
+	return &bsky.GetLikesResponse{Uri: uri, Cursor: next, Likes: likes}, nil
+}
+
+func (g *fakeGraph) GetRepostedBy(ctx context.Context, uri string, limit int, cursor string) (*bsky.GetRepostedByResponse, error) {
+	page, next := g.page(g.reposts[uri], cursor)
+	return &bsky.GetRepostedByResponse{Uri: uri, Cursor: next, RepostedBy: page}, nil
+}
+
+func (g *fakeGraph) ListNotifications(ctx context.Context, limit int, cursor string) (*bsky.ListNotificationsResponse, error) {
+	start := len(cursor)
+	end := min(start+g.pageSize, len(g.notifications))
+	next := ""
+	if end < len(g.notifications) {
+		next = strings.Repeat("c", end)
+	}
+	return &bsky.ListNotificationsResponse{Notifications: g.notifications[start:end], Cursor: next}, nil
+}
+
+func (g *fakeGraph) GetProfile(ctx context.Context, actor string) (*bsky.ActorProfile, error) {
+	return &bsky.ActorProfile{Did: actor, Labels: g.profileLabels}, nil
+}
+
+func (g *fakeGraph) GetPreferences(ctx context.Context) (*bsky.GetPreferencesResponse, error) {
+	labelers := make([]any, len(g.labelers))
+	for i, did := range g.labelers {
+		labelers[i] = map[string]any{"did": did}
+	}
+	return &bsky.GetPreferencesResponse{Preferences: []map[string]any{
+		{"$type": "app.bsky.actor.defs#labelersPref", "labelers": labelers},
+	}}, nil
+}
+
+func (g *fakeGraph) BatchGetProfiles(ctx context.Context, actors []string, maxConcurrent int) map[string]*bsky.ActorProfile {
+	results := make(map[string]*bsky.ActorProfile)
+	for _, profile := range slices.Concat(g.followers, g.follows) {
+		if slices.Contains(actors, profile.Did) && !g.failProfiles[profile.Did] {
+			results[profile.Did] = &profile
+		}
+	}
+	return results
+}
+
+func (g *fakeGraph) BatchGetLastPostDates(ctx context.Context, actors []string, maxConcurrent int) map[string]time.Time {
+	results := make(map[string]time.Time)
+	for _, actor := range actors {
+		if date, ok := g.lastPostDates[actor]; ok {
+			results[actor] = date
+		}
+	}
+	return results
+}
+
+func (g *fakeGraph) BatchGetPostRates(ctx context.Context, actors []string, sampleSize int, lookbackDays int, maxConcurrent int, progressFn func(current, total int)) map[string]*bsky.PostRate {
+	return map[string]*bsky.PostRate{}
+}
+
+// memoryRateCache is a [bsky.RateCache] that never persists anything
+type memoryRateCache struct{}
+
+func (memoryRateCache) GetPostRates(ctx context.Context, actorDids []string) (map[string]*bsky.PostRateCacheModel, error) {
+	return map[string]*bsky.PostRateCacheModel{}, nil
+}
+
+func (memoryRateCache) SavePostRates(ctx context.Context, caches []*bsky.PostRateCacheModel) error {
+	return nil
+}
+
+func (memoryRateCache) GetActivities(ctx context.Context, actorDids []string) (map[string]*bsky.ActivityCacheModel, error) {
+	return map[string]*bsky.ActivityCacheModel{}, nil
+}
+
+func (memoryRateCache) SaveActivities(ctx context.Context, caches []*bsky.ActivityCacheModel) error {
+	return nil
+}
+
+func newFakeRegistry(graph *fakeGraph) *registry.Registry {
+	return registry.New(registry.Dependencies{
+		FollowerFetcher: graph,
+		ProfileFetcher:  graph,
+		GraphWriter:     graph,
+		Engagement:      graph,
+		Records:         graph,
+		RateCache:       memoryRateCache{},
+	})
+}
+
+// newActionRegistry is [newFakeRegistry] plus an undo log in a temporary config directory
+func newActionRegistry(t *testing.T, graph *fakeGraph) (*registry.Registry, *bsky.ActionRepository) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	actions, err := bsky.NewActionRepository()
+	if err != nil {
+		t.Fatalf("NewActionRepository failed: %v", err)
+	}
+	if err := actions.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	t.Cleanup(func() { actions.Close() })
+
+	return registry.New(registry.Dependencies{
+		FollowerFetcher: graph,
+		ProfileFetcher:  graph,
+		GraphWriter:     graph,
+		Engagement:      graph,
+		ActionRepo:      actions,
+		RateCache:       memoryRateCache{},
+	}), actions
+}
+
+// runSubcommand runs parent's named subcommand with action bound to reg and returns what it wrote
+func runSubcommand(t *testing.T, parent *cli.Command, name string, action registryAction, reg *registry.Registry, args ...string) (string, error) {
+	t.Helper()
+	sub := parent.Command(name)
+	if sub == nil {
+		t.Fatalf("subcommand %q not found", name)
+	}
+	sub.Action = func(ctx context.Context, cmd *cli.Command) error {
+		return action(ctx, cmd, reg)
+	}
+
+	var out bytes.Buffer
+	parent.Writer = &out
+	parent.ErrWriter = &bytes.Buffer{}
+	err := parent.Run(context.Background(), append([]string{parent.Name, name}, args...))
+	return out.String(), err
+}
+
+func decodeFollowers(t *testing.T, out string) []followerInfo {
+	t.Helper()
+	var output followerListOutput
+	if err := json.Unmarshal([]byte(out), &output); err != nil {
+		t.Fatalf("failed to decode JSON output %q: %v", out, err)
+	}
+	if output.Summary.Total != len(output.Accounts) {
+		t.Fatalf("summary total %d doesn't match %d accounts", output.Summary.Total, len(output.Accounts))
+	}
+	return output.Accounts
+}
+
+func testProfiles(dids ...string) []bsky.ActorProfile {
+	profiles := make([]bsky.ActorProfile, len(dids))
+	for i, did := range dids {
+		profiles[i] = bsky.ActorProfile{Did: did, Handle: strings.TrimPrefix(did, "did:plc:") + ".bsky.social"}
+	}
+	return profiles
+}
+
+func TestListFollowersAction(t *testing.T) {
+	recent := time.Now().Add(-24 * time.Hour)
+	stale := time.Now().Add(-90 * 24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		graph   *fakeGraph
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "NotAuthenticated",
+			graph:   &fakeGraph{},
+			args:    []string{"--output", "json"},
+			wantErr: "not authenticated",
+		},
+		{
+			name:  "FollowsCursorAcrossPages",
+			graph: &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 2, followers: testProfiles("did:plc:a", "did:plc:b", "did:plc:c")},
+			args:  []string{"--output", "json"},
+			want:  []string{"did:plc:a", "did:plc:b", "did:plc:c"},
+		},
+		{
+			name:  "Limit",
+			graph: &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 2, followers: testProfiles("did:plc:a", "did:plc:b", "did:plc:c")},
+			args:  []string{"--output", "json", "--limit", "1"},
+			want:  []string{"did:plc:a"},
+		},
+		{
+			name: "Inactive",
+			graph: &fakeGraph{
+				authenticated: true,
+				did:           "did:plc:me",
+				pageSize:      10,
+				followers:     testProfiles("did:plc:active", "did:plc:stale", "did:plc:silent"),
+				lastPostDates: map[string]time.Time{"did:plc:active": recent, "did:plc:stale": stale, "did:plc:silent": {}},
+			},
+			args: []string{"--output", "json", "--inactive", "30"},
+			want: []string{"did:plc:stale", "did:plc:silent"},
+		},
+		{
+			name: "InactiveKeepsUnknownActivity",
+			graph: &fakeGraph{
+				authenticated: true,
+				did:           "did:plc:me",
+				pageSize:      10,
+				followers:     testProfiles("did:plc:active", "did:plc:unknown"),
+				lastPostDates: map[string]time.Time{"did:plc:active": recent},
+			},
+			args: []string{"--output", "json", "--inactive", "30"},
+			want: []string{"did:plc:unknown"},
+		},
+		{
+			name: "Strict",
+			graph: &fakeGraph{
+				authenticated: true,
+				did:           "did:plc:me",
+				pageSize:      10,
+				followers:     testProfiles("did:plc:a", "did:plc:b"),
+				failProfiles:  map[string]bool{"did:plc:b": true},
+			},
+			args:    []string{"--output", "json", "--strict"},
+			wantErr: "couldn't fetch details for 1 of 2 accounts",
+		},
+		{
+			name:    "FetchError",
+			graph:   &fakeGraph{authenticated: true, did: "did:plc:me", err: errors.New("rate limited")},
+			args:    []string{"--output", "json"},
+			wantErr: "failed to fetch followers: rate limited",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, newFakeRegistry(tt.graph), tt.args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListFollowersAction failed: %v", err)
+			}
+
+			infos := decodeFollowers(t, out)
+			if len(infos) != len(tt.want) {
+				t.Fatalf("expected %d followers, got %d", len(tt.want), len(infos))
+			}
+			for i, did := range tt.want {
+				if infos[i].Profile.Did != did {
+					t.Errorf("follower %d: expected %s, got %s", i, did, infos[i].Profile.Did)
+				}
+			}
+		})
+	}
+}
+
+func TestListFollowersAction_SafetyCap(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	if err := (&config.Config{Limits: &config.LimitsConfig{MaxAccounts: 2}}).Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    int
+		wantErr string
+	}{
+		{name: "OverCap", wantErr: "more than 2 followers, over the safety cap; pass --limit N"},
+		{name: "NoLimit", args: []string{"--no-limit"}, want: 3},
+		{name: "ExplicitLimit", args: []string{"--limit", "3"}, want: 3},
+		{name: "Conflict", args: []string{"--limit", "1", "--no-limit"}, wantErr: "can't be combined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 2, followers: testProfiles("did:plc:a", "did:plc:b", "did:plc:c")}
+			out, err := runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, newFakeRegistry(graph), append([]string{"--output", "json"}, tt.args...)...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListFollowersAction failed: %v", err)
+			}
+			if infos := decodeFollowers(t, out); len(infos) != tt.want {
+				t.Errorf("expected %d followers, got %d", tt.want, len(infos))
+			}
+		})
+	}
+
+	// Stats has no --limit, so the hint only offers --no-limit
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 2, followers: testProfiles("did:plc:a", "did:plc:b", "did:plc:c")}
+	_, err := runSubcommand(t, FollowersCommand(), "stats", FollowersStatsAction, newFakeRegistry(graph))
+	if err == nil || !strings.Contains(err.Error(), "pass --no-limit to fetch them all") {
+		t.Errorf("expected a --no-limit hint, got %v", err)
+	}
+}
+
+func TestListFollowersAction_CSV(t *testing.T) {
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 10, followers: testProfiles("did:plc:a")}
+
+	out, err := runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, newFakeRegistry(graph), "--output", "csv")
+	if err != nil {
+		t.Fatalf("ListFollowersAction failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got %q", out)
+	}
+	if !strings.HasPrefix(lines[1], "a.bsky.social,") {
+		t.Errorf("expected row for a.bsky.social, got %q", lines[1])
+	}
+	if strings.Contains(lines[0], "fetchFailed") {
+		t.Errorf("expected no fetchFailed column when every lookup succeeded, got %q", lines[0])
+	}
+}
+
+func TestListFollowersAction_FetchFailed(t *testing.T) {
+	graph := &fakeGraph{
+		authenticated: true,
+		did:           "did:plc:me",
+		pageSize:      10,
+		followers:     testProfiles("did:plc:a", "did:plc:b"),
+		failProfiles:  map[string]bool{"did:plc:b": true},
+	}
+
+	out, err := runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, newFakeRegistry(graph), "--output", "json")
+	if err != nil {
+		t.Fatalf("ListFollowersAction failed: %v", err)
+	}
+	infos := decodeFollowers(t, out)
+	if len(infos) != 2 || len(infos[0].FetchFailed) != 0 || !slices.Equal(infos[1].FetchFailed, []string{fetchProfile}) {
+		t.Errorf("expected only the second follower to be marked, got %+v", infos)
+	}
+
+	out, err = runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, newFakeRegistry(graph), "--output", "csv")
+	if err != nil {
+		t.Fatalf("ListFollowersAction failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[0], ",fetchFailed") {
+		t.Fatalf("expected a fetchFailed column, got %q", out)
+	}
+	if lines[1] != "a.bsky.social,,did:plc:a,0,0," || lines[2] != "b.bsky.social,,did:plc:b,,,profile" {
+		t.Errorf("expected blank counts for the failed row, got %q", lines[1:])
+	}
+}
+
+func TestRenderFollowersTable_FetchFailed(t *testing.T) {
+	followers := []followerInfo{
+		{Profile: &bsky.ActorProfile{Handle: "ok.bsky.social"}, IsQuiet: true, PostsPerDay: 0.25, PostsByDay: []int{-1, 0, 2, 0, 1, 0, 4}},
+		{Profile: &bsky.ActorProfile{Handle: "broken.bsky.social"}, FetchFailed: []string{fetchProfile, fetchPostRate}},
+	}
+
+	table, _ := renderFollowersTable(followers, true)
+	if !strings.Contains(table, "Posts/Day") || !strings.Contains(table, "0.25") {
+		t.Errorf("expected the quiet columns, got\n%s", table)
+	}
+	if !strings.Contains(table, "Last 7 Days") || !strings.Contains(table, " ▁▅▁▃▁█") {
+		t.Errorf("expected a sparkline of the last 7 days, got\n%s", table)
+	}
+	if got := strings.Count(table, fetchFailedCell); got != 4 {
+		t.Errorf("expected followers, posts, posts/day, and last post to be marked for the failed row, got %d markers\n%s", got, table)
+	}
+}
+
+func TestFollowerRows_Lines(t *testing.T) {
+	followers := []followerInfo{
+		{Profile: &bsky.ActorProfile{Handle: "alice.bsky.social", DisplayName: "Alice", FollowersCount: 12, PostsCount: 40}},
+		{Profile: &bsky.ActorProfile{Handle: "bob.bsky.social"}, FetchFailed: []string{fetchProfile}},
+	}
+
+	blocks := strings.Split(ui.FormatLines(followerRows(followers, false)), "\n\n")
+	if len(blocks) != 2 {
+		t.Fatalf("expected one block per follower, got %q", blocks)
+	}
+	want := "Handle: @alice.bsky.social\nDisplay Name: Alice\nFollowers: 12\nPosts: 40\nProfile URL: " + profileURL("alice.bsky.social")
+	if blocks[0] != want {
+		t.Errorf("first block =\n%s\nwant\n%s", blocks[0], want)
+	}
+	if !strings.Contains(blocks[1], "Followers: "+fetchFailedCell) {
+		t.Errorf("expected the failed fetch labeled in the second block, got\n%s", blocks[1])
+	}
+
+	ui.SetListFormat(ui.ListLines)
+	defer ui.SetListFormat(ui.ListTable)
+	quiet := []followerInfo{{Profile: &bsky.ActorProfile{Handle: "carol.bsky.social"}, IsQuiet: true, PostsPerDay: 0.5, PostsByDay: []int{-1, 0, 2, 0, 1, 0, 4}}}
+	if out := ui.FormatLines(followerRows(quiet, false)); !strings.Contains(out, "Last 7 Days: ? 0 2 0 1 0 4") {
+		t.Errorf("expected daily counts spelled out in lines mode, got\n%s", out)
+	}
+}
+
+func TestFollowersExportAction_XLSX(t *testing.T) {
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 10, followers: testProfiles("did:plc:a", "did:plc:b")}
+
+	out, err := runSubcommand(t, FollowersCommand(), "export", FollowersExportAction, newFakeRegistry(graph), "--output", "xlsx")
+	if err != nil {
+		t.Fatalf("FollowersExportAction failed: %v", err)
+	}
+
+	f, err := excelize.OpenReader(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("output is not a workbook: %v", err)
+	}
+	defer f.Close()
+
+	if sheets := f.GetSheetList(); len(sheets) != 3 || sheets[0] != "Followers" || sheets[1] != "Summary" || sheets[2] != "Diff" {
+		t.Fatalf("unexpected sheets %v", sheets)
+	}
+	rows, _ := f.GetRows("Followers")
+	if len(rows) != 3 || rows[0][2] != "did" || rows[1][2] != "did:plc:a" {
+		t.Errorf("unexpected follower rows %v", rows)
+	}
+}
+
+func TestFollowersExportAction_FollowDates(t *testing.T) {
+	newFeedRepos(t)
+	ctx := context.Background()
+	snapshotRepo, err := bsky.NewSnapshotRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { snapshotRepo.Close() })
+	if err := snapshotRepo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	seenAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := &bsky.SnapshotModel{UserDid: "did:plc:me", SnapshotType: "followers", TotalCount: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	snapshot.SetID(bsky.GenerateUUID())
+	snapshot.SetCreatedAt(seenAt)
+	if err := snapshotRepo.Save(ctx, snapshot); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := snapshotRepo.SaveEntry(ctx, &bsky.SnapshotEntry{SnapshotID: snapshot.ID(), ActorDid: "did:plc:b"}); err != nil {
+		t.Fatalf("SaveEntry failed: %v", err)
+	}
+
+	follow := func(subject, createdAt string) bsky.Record {
+		value, _ := json.Marshal(map[string]string{"$type": "app.bsky.graph.follow", "subject": subject, "createdAt": createdAt})
+		return bsky.Record{Value: value}
+	}
+	graph := &fakeGraph{
+		authenticated: true,
+		did:           "did:plc:me",
+		pageSize:      10,
+		followers:     testProfiles("did:plc:c", "did:plc:b", "did:plc:a"),
+		records: map[string][]bsky.Record{"did:plc:a/app.bsky.graph.follow": {
+			follow("did:plc:other", "2026-05-01T00:00:00Z"),
+			follow("did:plc:me", "2026-01-01T00:00:00Z"),
+		}},
+	}
+	reg := registry.New(registry.Dependencies{
+		FollowerFetcher: graph,
+		ProfileFetcher:  graph,
+		Records:         graph,
+		SnapshotRepo:    snapshotRepo,
+		RateCache:       memoryRateCache{},
+	})
+
+	out, err := runSubcommand(t, FollowersCommand(), "export", FollowersExportAction, reg, "--output", "csv", "--follow-dates")
+	if err != nil {
+		t.Fatalf("FollowersExportAction failed: %v", err)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV %q: %v", out, err)
+	}
+	want := [][]string{
+		{"did:plc:a", "2026-01-01T00:00:00Z", "record"},
+		{"did:plc:b", "2026-03-01T00:00:00Z", "snapshot"},
+		{"did:plc:c", "", "unknown"},
+	}
+	if len(rows) != len(want)+1 || !slices.Equal(rows[0][len(rows[0])-2:], []string{"followedAt", "followedAtSource"}) {
+		t.Fatalf("unexpected rows %v", rows)
+	}
+	for i, row := range rows[1:] {
+		if got := []string{row[2], row[len(row)-2], row[len(row)-1]}; !slices.Equal(got, want[i]) {
+			t.Errorf("row %d = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestFollowersExportAction_Accounts(t *testing.T) {
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 10, followers: testProfiles("did:plc:a", "did:plc:b")}
+	users := filepath.Join(t.TempDir(), "users.txt")
+	if err := os.WriteFile(users, []byte("# managed accounts\ndid:plc:two\n\ndid:plc:one # again\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := runSubcommand(t, FollowersCommand(), "export", FollowersExportAction, newFakeRegistry(graph), "--output", "csv", "--user", "did:plc:one", "--users-file", users)
+	if err == nil || !strings.Contains(err.Error(), "needs --dir") {
+		t.Fatalf("expected several accounts to require --dir, got %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "exports")
+	_, err = runSubcommand(t, FollowersCommand(), "export", FollowersExportAction, newFakeRegistry(graph), "--output", "csv", "--user", "did:plc:one", "--users-file", users, "--dir", dir)
+	if err != nil {
+		t.Fatalf("FollowersExportAction failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		t.Fatalf("missing index: %v", err)
+	}
+	var index followerExportIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("invalid index: %v", err)
+	}
+	if len(index.Accounts) != 2 || index.Accounts[0].Did != "did:plc:one" || index.Accounts[1].File != "followers_did_plc_two.csv" {
+		t.Fatalf("unexpected index %+v", index.Accounts)
+	}
+	for _, entry := range index.Accounts {
+		rows, err := os.ReadFile(filepath.Join(dir, entry.File))
+		if err != nil || entry.Followers != 2 || strings.Count(string(rows), "did:plc:") != 2 {
+			t.Errorf("unexpected export %s with %d followers: %q (%v)", entry.File, entry.Followers, rows, err)
+		}
+	}
+}
+
+func TestFollowerSheets(t *testing.T) {
+	baseline := &bsky.SnapshotModel{TotalCount: 2}
+	report := followerExportReport{
+		Actor:        "did:plc:me",
+		Followers:    []followerInfo{{Profile: &testProfiles("did:plc:a")[0]}},
+		Fetched:      1,
+		Baseline:     baseline,
+		NewFollowers: []string{"did:plc:a"},
+		Unfollows:    []string{"did:plc:gone", "did:plc:left"},
+	}
+
+	sheets := followerSheets(report, false, nil)
+	diff := sheets[2]
+	if len(diff.Rows) != 3 || diff.Rows[0][0] != "new_follower" || diff.Rows[2][1] != "did:plc:left" {
+		t.Errorf("unexpected diff rows %v", diff.Rows)
+	}
+
+	redactor, err := export.NewRedactor("did", export.RedactOmit)
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+	sheets = followerSheets(report, false, redactor)
+	for _, column := range sheets[0].Header {
+		if column == "did" {
+			t.Error("expected redacted did column to be dropped")
+		}
+	}
+	if len(sheets[2].Header) != 1 || len(sheets[2].Rows[0]) != 1 {
+		t.Errorf("expected diff without DIDs, got %v %v", sheets[2].Header, sheets[2].Rows)
+	}
+}
+
+func TestFollowersInterestsAction(t *testing.T) {
+	followers := testProfiles("did:plc:a", "did:plc:b", "did:plc:c", "did:plc:d")
+	followers[0].Description = "Photographer and birder"
+	followers[1].Description = "Bird photography, #birding"
+	followers[2].Description = "Amateur photographer"
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 10, followers: followers}
+
+	out, err := runSubcommand(t, FollowersCommand(), "interests", FollowersInterestsAction, newFakeRegistry(graph), "--output", "json", "--min-count", "2")
+	if err != nil {
+		t.Fatalf("FollowersInterestsAction failed: %v", err)
+	}
+
+	var clusters []interestCluster
+	if err := json.Unmarshal([]byte(out), &clusters); err != nil {
+		t.Fatalf("failed to decode JSON output %q: %v", out, err)
+	}
+	if len(clusters) != 1 {
+		t.Fatalf("expected one cluster, got %+v", clusters)
+	}
+	if clusters[0].Keywords[0] != "photographer" || clusters[0].Accounts != 2 {
+		t.Errorf("expected photographer cluster of 2 accounts, got %+v", clusters[0])
+	}
+	if clusters[0].Share != 2.0/3 {
+		t.Errorf("expected share relative to the 3 bios, got %v", clusters[0].Share)
+	}
+}
+
+func TestFollowersInListAction(t *testing.T) {
+	const list = "at://did:plc:me/app.bsky.graph.list/vip"
+	graph := &fakeGraph{
+		authenticated: true,
+		did:           "did:plc:me",
+		pageSize:      2,
+		followers:     testProfiles("did:plc:a", "did:plc:b", "did:plc:c"),
+		lists:         map[string][]bsky.ActorProfile{list: testProfiles("did:plc:b", "did:plc:outsider")},
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    map[string]bool
+		wantErr string
+	}{
+		{
+			name: "All",
+			args: []string{list},
+			want: map[string]bool{"did:plc:a": false, "did:plc:b": true, "did:plc:c": false},
+		},
+		{
+			name: "Missing",
+			args: []string{"--missing", list},
+			want: map[string]bool{"did:plc:a": false, "did:plc:c": false},
+		},
+		{
+			name: "BskyAppLink",
+			args: []string{"--members", "https://bsky.app/profile/did:plc:me/lists/vip"},
+			want: map[string]bool{"did:plc:b": true},
+		},
+		{
+			name:    "InvalidURI",
+			args:    []string{"at://did:plc:me/app.bsky.feed.post/vip"},
+			wantErr: "expected a list but got a record in app.bsky.feed.post",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"--output", "json"}, tt.args...)
+			out, err := runSubcommand(t, FollowersCommand(), "in-list", FollowersInListAction, newFakeRegistry(graph), args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FollowersInListAction failed: %v", err)
+			}
+
+			var report listMembershipReport
+			if err := json.Unmarshal([]byte(out), &report); err != nil {
+				t.Fatalf("failed to decode JSON output %q: %v", out, err)
+			}
+			if report.Members != 1 || report.Followers != 3 || report.NonFollowers != 1 || report.Name != "VIP" {
+				t.Errorf("unexpected summary %+v", report)
+			}
+			if len(report.Memberships) != len(tt.want) {
+				t.Fatalf("expected %d rows, got %+v", len(tt.want), report.Memberships)
+			}
+			for _, m := range report.Memberships {
+				if inList, ok := tt.want[m.Did]; !ok || inList != m.InList {
+					t.Errorf("unexpected membership %+v", m)
+				}
+			}
+		})
+	}
+}
+
+func TestListFollowingAction(t *testing.T) {
+	mutual := testProfiles("did:plc:mutual")
+	mutual[0].Viewer = &bsky.ViewerState{FollowedBy: "at://did:plc:mutual/app.bsky.graph.follow/1"}
+	follows := append(mutual, testProfiles("did:plc:oneway")...)
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "All", args: []string{"--output", "json"}, want: []string{"did:plc:mutual", "did:plc:oneway"}},
+		{name: "Mutual", args: []string{"--output", "json", "--mutual"}, want: []string{"did:plc:mutual"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 1, follows: follows}
+
+			out, err := runSubcommand(t, FollowingCommand(), "list", ListFollowingAction, newFakeRegistry(graph), tt.args...)
+			if err != nil {
+				t.Fatalf("ListFollowingAction failed: %v", err)
+			}
+
+			infos := decodeFollowers(t, out)
+			if len(infos) != len(tt.want) {
+				t.Fatalf("expected %d accounts, got %d", len(tt.want), len(infos))
+			}
+			for i, did := range tt.want {
+				if infos[i].Profile.Did != did {
+					t.Errorf("account %d: expected %s, got %s", i, did, infos[i].Profile.Did)
+				}
+			}
+		})
+	}
+}
+
+func TestListFollowersAction_AddToList(t *testing.T) {
+	const list = "at://did:plc:me/app.bsky.graph.list/vip"
+	newGraph := func() *fakeGraph {
+		return &fakeGraph{
+			authenticated: true,
+			did:           "did:plc:me",
+			pageSize:      2,
+			followers:     testProfiles("did:plc:a", "did:plc:b", "did:plc:c", "did:plc:a"),
+			lists: map[string][]bsky.ActorProfile{
+				list: testProfiles("did:plc:b"),
+				"at://did:plc:other/app.bsky.graph.list/theirs": nil,
+			},
+		}
+	}
+
+	t.Run("AddsMissingOnce", func(t *testing.T) {
+		graph := newGraph()
+		reg, actions := newActionRegistry(t, graph)
+		parent := FollowersCommand()
+		parent.Reader = strings.NewReader("y\n")
+		_, err := runSubcommand(t, parent, "list", ListFollowersAction, reg,
+			"--output", "json", "--pace", "0", "--add-to-list", list)
+		if err != nil {
+			t.Fatalf("ListFollowersAction failed: %v", err)
+		}
+		if want := []string{"did:plc:a", "did:plc:c"}; !slices.Equal(graph.added, want) {
+			t.Errorf("added %v, want %v", graph.added, want)
+		}
+
+		logged, err := actions.Recent(context.Background(), 0, false)
+		if err != nil {
+			t.Fatalf("Recent failed: %v", err)
+		}
+		if len(logged) != 2 || logged[0].Action != bsky.ActionListAdd {
+			t.Errorf("expected 2 list additions in the undo log, got %+v", logged)
+		}
+	})
+
+	t.Run("Declined", func(t *testing.T) {
+		graph := newGraph()
+		reg, _ := newActionRegistry(t, graph)
+		parent := FollowersCommand()
+		parent.Reader = strings.NewReader("n\n")
+		if _, err := runSubcommand(t, parent, "list", ListFollowersAction, reg,
+			"--output", "json", "--add-to-list", list); err != nil {
+			t.Fatalf("ListFollowersAction failed: %v", err)
+		}
+		if len(graph.added) != 0 {
+			t.Errorf("expected nothing added after declining, got %v", graph.added)
+		}
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		graph := newGraph()
+		reg, _ := newActionRegistry(t, graph)
+		_, err := runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, reg,
+			"--output", "json", "--add-to-list", "at://did:plc:other/app.bsky.graph.list/theirs")
+		if err == nil || !strings.Contains(err.Error(), "lists you own") {
+			t.Fatalf("expected ownership error, got %v", err)
+		}
+	})
+}
+
+func TestRetentionDiff(t *testing.T) {
+	before := []string{"did:plc:a", "did:plc:b", "did:plc:c"}
+	after := []string{"did:plc:a", "did:plc:c", "did:plc:d", "did:plc:e", "did:plc:f"}
+	now := []string{"did:plc:a", "did:plc:b", "did:plc:d", "did:plc:f", "did:plc:g"}
+
+	report := retentionDiff(before, after, now)
+
+	gained := make([]string, len(report.Gained))
+	for i, entry := range report.Gained {
+		gained[i] = entry.Did
+		if entry.Before || !entry.After {
+			t.Errorf("gained follower %s marked as before=%v after=%v", entry.Did, entry.Before, entry.After)
+		}
+	}
+	if strings.Join(gained, ",") != "did:plc:d,did:plc:e,did:plc:f" {
+		t.Errorf("unexpected gained followers %v", gained)
+	}
+	if len(report.Lost) != 1 || report.Lost[0].Did != "did:plc:b" || !report.Lost[0].Against {
+		t.Errorf("expected b to be lost and following again, got %+v", report.Lost)
+	}
+
+	summary := report.Summary
+	if summary.BeforeCount != 3 || summary.AfterCount != 5 || summary.AgainstCount != 5 {
+		t.Errorf("unexpected counts %+v", summary)
+	}
+	if summary.GainedCount != 3 || summary.RetainedCount != 2 || summary.LostCount != 1 || summary.ReturnedCount != 1 {
+		t.Errorf("unexpected retention %+v", summary)
+	}
+	if summary.RetentionRate < 0.66 || summary.RetentionRate > 0.67 {
+		t.Errorf("expected 2/3 retention, got %v", summary.RetentionRate)
+	}
+
+	if empty := retentionDiff(before, before, nil); empty.Summary.RetentionRate != 0 || len(empty.Gained) != 0 {
+		t.Errorf("expected no gains without changes, got %+v", empty)
+	}
+}
+
+func TestSummarizeFollowers(t *testing.T) {
+	profile := func(followers int) *bsky.ActorProfile { return &bsky.ActorProfile{FollowersCount: followers} }
+	followers := []followerInfo{
+		{Profile: profile(10), IsQuiet: true, PostsPerDay: 0.5},
+		{Profile: profile(30), IsQuiet: true, PostsPerDay: 0.25},
+		{Profile: profile(200), IsQuiet: true, PostsPerDay: 0},
+		{Profile: profile(20)},
+		{Profile: profile(0), FetchFailed: []string{fetchProfile, fetchPostRate}},
+	}
+
+	summary := summarizeFollowers(followers)
+	if summary.Total != 5 || summary.MeanFollowers != 65 || summary.MedianFollowers != 25 {
+		t.Errorf("unexpected follower aggregates %+v", summary)
+	}
+	if summary.MeanPostsPerDay == nil || *summary.MeanPostsPerDay != 0.19 {
+		t.Errorf("expected mean posts/day over the four fetched rates, got %v", summary.MeanPostsPerDay)
+	}
+	if footer := summaryFooter(summary); footer != "Total: 5 · Followers: mean 65.0, median 25.0 · Posts/day: mean 0.19" {
+		t.Errorf("unexpected footer %q", footer)
+	}
+
+	if plain := summarizeFollowers(followers[3:4]); plain.MeanPostsPerDay != nil || plain.MedianFollowers != 20 {
+		t.Errorf("expected no posts/day without post rates, got %+v", plain)
+	}
+}
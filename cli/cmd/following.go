@@ -5,36 +5,34 @@ import (
 	"fmt"
 
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
-	"github.com/stormlightlabs/skypanel/cli/internal/setup"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
 // ListFollowingAction fetches and displays accounts the user follows
-func ListFollowingAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
-	service, err := reg.GetService()
+func ListFollowingAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fetcher, err := reg.GetFollowerFetcher()
 	if err != nil {
-		return fmt.Errorf("failed to get service: %w", err)
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
 	}
 
-	if !service.Authenticated() {
+	if !fetcher.Authenticated() {
 		return fmt.Errorf("not authenticated: run 'skycli login' first")
 	}
 
-	cacheRepo, err := reg.GetCacheRepo()
+	profiles, err := reg.GetProfileFetcher()
 	if err != nil {
-		return fmt.Errorf("failed to get cache repository: %w", err)
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
 	}
 
-	actor := cmd.String("user")
-	if actor == "" {
-		actor = service.GetDid()
+	rateCache, err := reg.GetRateCache()
+	if err != nil {
+		return fmt.Errorf("failed to get rate cache: %w", err)
+	}
+
+	actor, err := userActor(ctx, cmd, reg, fetcher)
+	if err != nil {
+		return err
 	}
 	inactiveDays := cmd.Int("inactive")
 	mutual := cmd.Bool("mutual")
@@ -45,32 +43,19 @@ func ListFollowingAction(ctx context.Context, cmd *cli.Command) error {
 
 	logger.Debugf("Fetching following for actor %v", actor)
 
-	var allFollowing []store.ActorProfile
-	cursor := ""
-	page := 0
-	for {
-		page++
-		response, err := service.GetFollows(ctx, actor, 100, cursor)
-		if err != nil {
-			return fmt.Errorf("failed to fetch following: %w", err)
-		}
-
-		allFollowing = append(allFollowing, response.Follows...)
-
-		if response.Cursor != "" {
-			logger.Infof("Fetched page %d (%d following so far)...", page, len(allFollowing))
-		}
-
-		if response.Cursor == "" {
-			break
-		}
-		cursor = response.Cursor
+	pageOpts, err := graphPageOptions(cmd, "follows")
+	if err != nil {
+		return err
+	}
+	allFollowing, err := bsky.NewPaginator(bsky.FollowPages(fetcher, actor), pageOpts).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch following: %w", capError(cmd, "follows", err))
 	}
 
 	logger.Infof("Fetched %d total following", len(allFollowing))
 
 	if mutual {
-		var mutualFollows []store.ActorProfile
+		var mutualFollows []bsky.ActorProfile
 		for _, follow := range allFollowing {
 			if follow.Viewer != nil && follow.Viewer.FollowedBy != "" {
 				mutualFollows = append(mutualFollows, follow)
@@ -79,25 +64,39 @@ func ListFollowingAction(ctx context.Context, cmd *cli.Command) error {
 		allFollowing = mutualFollows
 	}
 
-	followerInfos, actors := enrichFollowerProfiles(ctx, service, allFollowing, logger)
+	followerInfos, actors := enrichFollowerProfiles(ctx, profiles, allFollowing, logger)
+	enriched := followerInfos
 
 	if inactiveDays > 0 {
-		followerInfos = filterInactive(ctx, service, cacheRepo, followerInfos, actors, inactiveDays, refresh, logger)
+		followerInfos = filterInactive(ctx, profiles, rateCache, followerInfos, actors, inactiveDays, refresh, logger)
 	}
 
 	if quietPosters {
-		followerInfos = filterQuiet(ctx, service, cacheRepo, followerInfos, actors, quietThreshold, refresh, logger)
+		followerInfos = filterQuiet(ctx, profiles, rateCache, followerInfos, actors, quietThreshold, refresh, logger)
+	}
+
+	if err := checkFetchFailures(cmd, enriched); err != nil {
+		return err
+	}
+
+	if cmd.Bool("follow-dates") {
+		addFollowingDates(ctx, reg, actor, followerInfos)
+		sortByFollowDate(followerInfos)
 	}
 
 	switch outputFormat {
 	case "json":
-		return outputFollowersJSON(followerInfos)
+		err = outputFollowersJSON(cmd.Root().Writer, followerInfos)
 	case "csv":
-		return outputFollowersCSV(followerInfos, inactiveDays > 0 || quietPosters)
+		err = outputFollowersCSV(cmd.Root().Writer, followerInfos, inactiveDays > 0 || quietPosters, nil)
 	default:
 		displayFollowersTable(followerInfos, inactiveDays > 0 || quietPosters)
 	}
+	if err != nil {
+		return err
+	}
 
+	warnFetchFailures(enriched)
 	return nil
 }
 
@@ -118,6 +117,8 @@ func FollowingCommand() *cli.Command {
 						Aliases: []string{"u"},
 						Usage:   "User handle or DID (defaults to authenticated user)",
 					},
+					limitFlag("follows"),
+					noLimitFlag("follows"),
 					&cli.IntFlag{
 						Name:  "inactive",
 						Usage: "Show only accounts with no posts in N days",
@@ -146,8 +147,10 @@ func FollowingCommand() *cli.Command {
 						Name:  "refresh",
 						Usage: "Force refresh cached data (bypasses 24-hour cache)",
 					},
+					followDatesFlag(),
+					strictFlag(),
 				},
-				Action: ListFollowingAction,
+				Action: withRegistry(ListFollowingAction),
 			},
 		},
 	}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// FollowersGhostsAction lists followers who haven't liked or reposted any of the user's recent posts.
+// It only reports; --add-to-list is the one opt-in action.
+func FollowersGhostsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	engagement, err := reg.GetEngagementFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get engagement fetcher: %w", err)
+	}
+
+	outputFormat := cmd.String("output")
+	if outputFormat != "table" && outputFormat != "json" && outputFormat != "csv" {
+		return fmt.Errorf("output format must be 'table', 'json', or 'csv'")
+	}
+
+	if cmd.Int("posts") < 1 {
+		return fmt.Errorf("--posts must be at least 1")
+	}
+
+	me := fetcher.GetDid()
+
+	posts, err := recentOwnPosts(ctx, engagement, me, cmd.Int("posts"))
+	if err != nil {
+		return err
+	}
+	if len(posts) == 0 {
+		return fmt.Errorf("no recent posts to check engagement against")
+	}
+
+	engaged, err := engagedAccounts(ctx, engagement, posts)
+	if err != nil {
+		return err
+	}
+
+	pageOpts, err := graphPageOptions(cmd, "followers")
+	if err != nil {
+		return err
+	}
+	followers, err := bsky.NewPaginator(bsky.FollowerPages(fetcher, me), pageOpts).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch followers: %w", capError(cmd, "followers", err))
+	}
+
+	seen := make(map[string]bool, len(followers))
+	var ghosts []bsky.ActorProfile
+	for _, follower := range followers {
+		if seen[follower.Did] || engaged[follower.Did] {
+			continue
+		}
+		seen[follower.Did] = true
+		ghosts = append(ghosts, follower)
+	}
+
+	summary := fmt.Sprintf("%d of %d followers haven't liked or reposted your last %d posts", len(ghosts), len(followers), len(posts))
+	logger.Debug("Checked follower engagement", "posts", len(posts), "engaged", len(engaged), "ghosts", len(ghosts))
+
+	ghostInfos, _ := enrichFollowerProfiles(ctx, profiles, ghosts, logger)
+	if err := checkFetchFailures(cmd, ghostInfos); err != nil {
+		return err
+	}
+
+	switch outputFormat {
+	case "json":
+		logger.Info(summary)
+		err = outputFollowersJSON(cmd.Root().Writer, ghostInfos)
+	case "csv":
+		logger.Info(summary)
+		err = outputFollowersCSV(cmd.Root().Writer, ghostInfos, false, nil)
+	default:
+		ui.Infoln("%s", summary)
+		fmt.Println()
+		displayFollowersTable(ghostInfos, false)
+	}
+	warnFetchFailures(ghostInfos)
+	if err != nil || cmd.String("add-to-list") == "" {
+		return err
+	}
+
+	return addFollowersToList(ctx, cmd, reg, ghostInfos)
+}
+
+// recentOwnPosts returns up to limit of actor's own posts, newest first, skipping reposts of others
+func recentOwnPosts(ctx context.Context, fetcher bsky.EngagementFetcher, actor string, limit int) ([]*bsky.PostView, error) {
+	var posts []*bsky.PostView
+	paginator := bsky.NewPaginator(bsky.AuthorFeedPages(fetcher, actor), bsky.PaginatorOptions{})
+	for paginator.HasNext() && len(posts) < limit {
+		items, err := paginator.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch recent posts: %w", err)
+		}
+		for _, item := range items {
+			if item.Post == nil || item.Reason != nil || (item.Post.Author != nil && item.Post.Author.Did != actor) {
+				continue
+			}
+			if len(posts) < limit {
+				posts = append(posts, item.Post)
+			}
+		}
+	}
+	return posts, nil
+}
+
+// engagedAccounts returns the DIDs of everyone who liked or reposted any of posts
+func engagedAccounts(ctx context.Context, fetcher bsky.EngagementFetcher, posts []*bsky.PostView) (map[string]bool, error) {
+	engaged := make(map[string]bool)
+	for i, post := range posts {
+		var sources []bsky.PageFunc[bsky.ActorProfile]
+		if post.LikeCount > 0 {
+			sources = append(sources, bsky.LikerPages(fetcher, post.Uri))
+		}
+		if post.RepostCount > 0 {
+			sources = append(sources, bsky.RepostPages(fetcher, post.Uri))
+		}
+
+		for _, pages := range sources {
+			actors, err := bsky.NewPaginator(pages, bsky.PaginatorOptions{}).All(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch engagement for %s: %w", post.Uri, err)
+			}
+			for _, actor := range actors {
+				engaged[actor.Did] = true
+			}
+		}
+		logger.Debugf("Checked engagement on %d/%d posts", i+1, len(posts))
+	}
+	return engaged, nil
+}
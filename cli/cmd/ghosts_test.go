@@ -0,0 +1,72 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestFollowersGhostsAction(t *testing.T) {
+	me := &bsky.ActorProfile{Did: "did:plc:me"}
+	post := func(uri string, likes, reposts int) bsky.FeedViewPost {
+		return bsky.FeedViewPost{Post: &bsky.PostView{Uri: uri, Author: me, LikeCount: likes, RepostCount: reposts}}
+	}
+	repost := post("at://did:plc:other/app.bsky.feed.post/3", 1, 0)
+	repost.Post.Author = &bsky.ActorProfile{Did: "did:plc:other"}
+	repost.Reason = &bsky.ReasonView{}
+
+	graph := &fakeGraph{
+		authenticated: true,
+		did:           "did:plc:me",
+		pageSize:      2,
+		followers:     testProfiles("did:plc:a", "did:plc:b", "did:plc:c", "did:plc:d", "did:plc:e"),
+		feed: []bsky.FeedViewPost{
+			post("at://did:plc:me/app.bsky.feed.post/1", 3, 0),
+			repost,
+			post("at://did:plc:me/app.bsky.feed.post/2", 0, 1),
+		},
+		likes: map[string][]bsky.ActorProfile{
+			"at://did:plc:me/app.bsky.feed.post/1":    testProfiles("did:plc:a", "did:plc:stranger", "did:plc:b"),
+			"at://did:plc:other/app.bsky.feed.post/3": testProfiles("did:plc:d"),
+		},
+		reposts: map[string][]bsky.ActorProfile{
+			"at://did:plc:me/app.bsky.feed.post/2": testProfiles("did:plc:c"),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{name: "AllPosts", args: []string{"--output", "json"}, want: []string{"did:plc:d", "did:plc:e"}},
+		{name: "LatestPostOnly", args: []string{"--output", "json", "--posts", "1"}, want: []string{"did:plc:c", "did:plc:d", "did:plc:e"}},
+		{name: "InvalidPosts", args: []string{"--posts", "0"}, wantErr: "--posts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runSubcommand(t, FollowersCommand(), "ghosts", FollowersGhostsAction, newFakeRegistry(graph), tt.args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FollowersGhostsAction failed: %v", err)
+			}
+
+			var dids []string
+			for _, ghost := range decodeFollowers(t, out) {
+				dids = append(dids, ghost.Profile.Did)
+			}
+			if !slices.Equal(dids, tt.want) {
+				t.Errorf("ghosts %v, want %v", dids, tt.want)
+			}
+		})
+	}
+}
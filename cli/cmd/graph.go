@@ -0,0 +1,331 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// graphSnapshotTTL is how long a snapshot taken by a graph command is reused by later runs
+const graphSnapshotTTL = 24 * time.Hour
+
+// graphRelation is one way of comparing your followers with your follows
+type graphRelation struct {
+	name  string
+	title string
+	// keep reports whether an account belongs in the result given whether it follows you and whether you follow it
+	keep func(follower, followed bool) bool
+}
+
+var (
+	graphMutuals = graphRelation{"mutuals", "Mutuals", func(follower, followed bool) bool {
+		return follower && followed
+	}}
+	graphNotFollowingBack = graphRelation{"not-following-back", "Not Following You Back", func(follower, followed bool) bool {
+		return followed && !follower
+	}}
+	graphNotFollowedBack = graphRelation{"not-followed-back", "Not Followed Back", func(follower, followed bool) bool {
+		return follower && !followed
+	}}
+)
+
+// GraphCommand returns the graph command with subcommands that compare your followers with your follows
+func GraphCommand() *cli.Command {
+	subcommand := func(relation graphRelation, action registryAction, usage, usageText string) *cli.Command {
+		return &cli.Command{
+			Name:      relation.name,
+			Usage:     usage,
+			UsageText: usageText + " Followers and follows are read from snapshots taken in the last 24 hours, by an earlier run or 'snapshots daemon', and fetched and saved as new snapshots otherwise.",
+			ArgsUsage: " ",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "refresh",
+					Usage: "Fetch followers and follows even when a recent snapshot exists",
+				},
+				&cli.BoolFlag{
+					Name:  "enrich",
+					Usage: "Fetch each account's profile for its display name and follower count",
+				},
+				noLimitFlag("followers or follows"),
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format: table, json, csv",
+					Value:   "table",
+				},
+			},
+			Action: withRegistry(action),
+		}
+	}
+
+	return &cli.Command{
+		Name:  "graph",
+		Usage: "Compare your followers with the accounts you follow",
+		Commands: []*cli.Command{
+			subcommand(graphMutuals, GraphMutualsAction, "List accounts you follow that follow you back",
+				"Lists accounts that follow you and that you follow."),
+			subcommand(graphNotFollowingBack, GraphNotFollowingBackAction, "List accounts you follow that don't follow you",
+				"Lists accounts you follow that don't follow you back."),
+			subcommand(graphNotFollowedBack, GraphNotFollowedBackAction, "List followers you don't follow",
+				"Lists accounts that follow you that you don't follow back."),
+		},
+	}
+}
+
+// GraphMutualsAction lists the accounts that follow the signed-in account and that it follows
+func GraphMutualsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	return graphRelationAction(ctx, cmd, reg, graphMutuals)
+}
+
+// GraphNotFollowingBackAction lists the accounts the signed-in account follows that don't follow it
+func GraphNotFollowingBackAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	return graphRelationAction(ctx, cmd, reg, graphNotFollowingBack)
+}
+
+// GraphNotFollowedBackAction lists the accounts that follow the signed-in account that it doesn't follow
+func GraphNotFollowedBackAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	return graphRelationAction(ctx, cmd, reg, graphNotFollowedBack)
+}
+
+// graphAccount is one account in a graph comparison; profile fields are empty when the profile isn't known
+type graphAccount struct {
+	Did         string `json:"did"`
+	Handle      string `json:"handle,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Followers   *int   `json:"followers,omitempty"`
+}
+
+// graphOutput is the JSON form of a graph comparison
+type graphOutput struct {
+	Relation  string         `json:"relation"`
+	Followers int            `json:"followers"`
+	Following int            `json:"following"`
+	Accounts  []graphAccount `json:"accounts"`
+}
+
+// graphRelationAction compares the signed-in account's followers with its follows and lists the accounts in relation
+func graphRelationAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry, relation graphRelation) error {
+	outputFormat := cmd.String("output")
+	if outputFormat != "table" && outputFormat != "json" && outputFormat != "csv" {
+		return fmt.Errorf("invalid output format: %s (must be table, json, or csv)", outputFormat)
+	}
+
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+	snapshotRepo, err := reg.GetSnapshotRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+
+	followers, err := graphSnapshotDids(ctx, cmd, fetcher, snapshotRepo, "followers")
+	if err != nil {
+		return err
+	}
+	following, err := graphSnapshotDids(ctx, cmd, fetcher, snapshotRepo, "following")
+	if err != nil {
+		return err
+	}
+
+	isFollower := make(map[string]bool, len(followers))
+	for _, did := range followers {
+		isFollower[did] = true
+	}
+	isFollowed := make(map[string]bool, len(following))
+	for _, did := range following {
+		isFollowed[did] = true
+	}
+
+	output := graphOutput{Relation: relation.name, Followers: len(followers), Following: len(following), Accounts: []graphAccount{}}
+	seen := make(map[string]bool, len(followers)+len(following))
+	for _, did := range append(slices.Clip(followers), following...) {
+		if seen[did] {
+			continue
+		}
+		seen[did] = true
+		if relation.keep(isFollower[did], isFollowed[did]) {
+			output.Accounts = append(output.Accounts, graphAccount{Did: did})
+		}
+	}
+
+	if cmd.Bool("enrich") {
+		enrichGraphAccounts(ctx, reg, output.Accounts)
+	} else {
+		cachedGraphHandles(ctx, reg, output.Accounts)
+	}
+	slices.SortFunc(output.Accounts, compareGraphAccounts)
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(cmd.Root().Writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	case "csv":
+		return outputGraphCSV(cmd.Root().Writer, output.Accounts)
+	}
+
+	ui.Titleln("%s (%d)", relation.title, len(output.Accounts))
+	if len(output.Accounts) == 0 {
+		ui.Infoln("No accounts among %d followers and %d follows.", output.Followers, output.Following)
+		return nil
+	}
+	displayGraphAccounts(output.Accounts)
+	ui.Infoln("Compared %d followers with %d follows.", output.Followers, output.Following)
+	return nil
+}
+
+// graphSnapshotDids returns the signed-in account's followers or follows from its latest fresh snapshot, or fetches
+// them and saves a new snapshot when there is none or --refresh is set
+func graphSnapshotDids(ctx context.Context, cmd *cli.Command, fetcher bsky.FollowerFetcher, snapshotRepo bsky.SnapshotStore, snapshotType string) ([]string, error) {
+	me := fetcher.GetDid()
+	if !cmd.Bool("refresh") {
+		snapshot, err := snapshotRepo.FindByUserAndType(ctx, me, snapshotType)
+		if err != nil {
+			logger.Warn("Failed to check for a recent snapshot", "type", snapshotType, "error", err)
+		}
+		if snapshot != nil {
+			dids, err := snapshotRepo.GetActorDids(ctx, snapshot.ID())
+			if err == nil {
+				logger.Infof("Using %s snapshot from %s (%d accounts)", snapshotType, snapshot.CreatedAt().In(ui.Location()).Format("2006-01-02 15:04"), snapshot.TotalCount)
+				return dids, nil
+			}
+			logger.Warn("Failed to read snapshot", "snapshot", snapshot.ID(), "error", err)
+		}
+	}
+
+	maxAccounts := 0
+	if !cmd.Bool("no-limit") {
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		maxAccounts = cfg.MaxAccounts()
+	}
+
+	logger.Infof("Fetching %s", snapshotType)
+	snapshot, err := takeSnapshot(ctx, fetcher, snapshotRepo, snapshotType, maxAccounts, graphSnapshotTTL)
+	if err != nil {
+		return nil, err
+	}
+	return snapshotRepo.GetActorDids(ctx, snapshot.ID())
+}
+
+// cachedGraphHandles fills in handles from the local profile cache, without fetching anything
+func cachedGraphHandles(ctx context.Context, reg *registry.Registry, accounts []graphAccount) {
+	profileRepo, err := reg.GetProfileRepo()
+	if err != nil {
+		logger.Debug("Profile cache unavailable", "error", err)
+		return
+	}
+	for i := range accounts {
+		profile, err := profileRepo.GetByDid(ctx, accounts[i].Did)
+		if err != nil {
+			logger.Debug("Failed to check profile cache", "did", accounts[i].Did, "error", err)
+			continue
+		}
+		if profile != nil {
+			accounts[i].Handle = profile.Handle
+		}
+	}
+}
+
+// enrichGraphAccounts fills in handles, display names, and follower counts from live profiles; accounts whose
+// profile can't be fetched keep their DID only
+func enrichGraphAccounts(ctx context.Context, reg *registry.Registry, accounts []graphAccount) {
+	fetcher, err := reg.GetProfileFetcher()
+	if err != nil {
+		logger.Warn("Failed to get profile fetcher", "error", err)
+		return
+	}
+
+	dids := make([]string, len(accounts))
+	for i, account := range accounts {
+		dids[i] = account.Did
+	}
+
+	ctx, report := bsky.WithBatchReport(ctx)
+	profiles := fetcher.BatchGetProfiles(ctx, dids, 0)
+	warnBatchReport(logger, "Profiles", report)
+	for i := range accounts {
+		profile, ok := profiles[accounts[i].Did]
+		if !ok {
+			continue
+		}
+		accounts[i].Handle = profile.Handle
+		accounts[i].DisplayName = profile.DisplayName
+		followers := int(profile.FollowersCount)
+		accounts[i].Followers = &followers
+	}
+}
+
+// compareGraphAccounts orders accounts by handle, with accounts without a known handle last by DID
+func compareGraphAccounts(a, b graphAccount) int {
+	switch {
+	case a.Handle == "" && b.Handle != "":
+		return 1
+	case a.Handle != "" && b.Handle == "":
+		return -1
+	}
+	return cmp.Or(cmp.Compare(a.Handle, b.Handle), cmp.Compare(a.Did, b.Did))
+}
+
+// displayGraphAccounts renders accounts as a table, adding profile columns when any were fetched
+func displayGraphAccounts(accounts []graphAccount) {
+	enriched := slices.ContainsFunc(accounts, func(account graphAccount) bool { return account.Followers != nil })
+
+	headers := []string{"Account", "DID"}
+	if enriched {
+		headers = append(headers, "Display Name", "Followers")
+	}
+	rows := make([][]string, len(accounts))
+	for i, account := range accounts {
+		name := account.Did
+		if account.Handle != "" {
+			name = "@" + account.Handle
+		}
+		rows[i] = []string{name, account.Did}
+		if enriched {
+			followers := ""
+			if account.Followers != nil {
+				followers = strconv.Itoa(*account.Followers)
+			}
+			rows[i] = append(rows[i], account.DisplayName, followers)
+		}
+	}
+	fmt.Println(stripedTable(headers, rows))
+}
+
+// outputGraphCSV writes accounts with whatever profile fields are known
+func outputGraphCSV(w io.Writer, accounts []graphAccount) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"did", "handle", "display_name", "followers"}); err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		followers := ""
+		if account.Followers != nil {
+			followers = strconv.Itoa(*account.Followers)
+		}
+		if err := writer.Write([]string{account.Did, account.Handle, account.DisplayName, followers}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestGraphRelationAction(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	snapshotRepo, err := bsky.NewSnapshotRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { snapshotRepo.Close() })
+	if err := snapshotRepo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	graph := &fakeGraph{
+		authenticated: true,
+		did:           "did:plc:me",
+		pageSize:      2,
+		followers:     testProfiles("did:plc:a", "did:plc:b", "did:plc:c"),
+		follows:       testProfiles("did:plc:c", "did:plc:b", "did:plc:d"),
+	}
+	reg := registry.New(registry.Dependencies{FollowerFetcher: graph, ProfileFetcher: graph, SnapshotRepo: snapshotRepo})
+
+	run := func(name string, args ...string) graphOutput {
+		t.Helper()
+		actions := map[string]registryAction{
+			"mutuals":            GraphMutualsAction,
+			"not-following-back": GraphNotFollowingBackAction,
+			"not-followed-back":  GraphNotFollowedBackAction,
+		}
+		out, err := runSubcommand(t, GraphCommand(), name, actions[name], reg, append([]string{"--output", "json"}, args...)...)
+		if err != nil {
+			t.Fatalf("graph %s failed: %v", name, err)
+		}
+		var output graphOutput
+		if err := json.Unmarshal([]byte(out), &output); err != nil {
+			t.Fatalf("failed to decode %q: %v", out, err)
+		}
+		return output
+	}
+	dids := func(output graphOutput) []string {
+		var dids []string
+		for _, account := range output.Accounts {
+			dids = append(dids, account.Did)
+		}
+		return dids
+	}
+
+	for name, want := range map[string][]string{
+		"mutuals":            {"did:plc:b", "did:plc:c"},
+		"not-following-back": {"did:plc:d"},
+		"not-followed-back":  {"did:plc:a"},
+	} {
+		if got := dids(run(name)); !slices.Equal(got, want) {
+			t.Errorf("graph %s: expected %v, got %v", name, want, got)
+		}
+	}
+
+	// Later runs read the snapshots instead of fetching again, until --refresh
+	graph.followers = testProfiles("did:plc:a", "did:plc:b", "did:plc:c", "did:plc:d")
+	if got := dids(run("mutuals")); !slices.Equal(got, []string{"did:plc:b", "did:plc:c"}) {
+		t.Errorf("expected mutuals from the snapshot, got %v", got)
+	}
+	output := run("mutuals", "--refresh", "--enrich")
+	if got := dids(output); !slices.Equal(got, []string{"did:plc:b", "did:plc:c", "did:plc:d"}) {
+		t.Errorf("expected refreshed mutuals, got %v", got)
+	}
+	if output.Followers != 4 || output.Following != 3 || output.Accounts[0].Handle != "b.bsky.social" || output.Accounts[0].Followers == nil {
+		t.Errorf("expected enriched counts and profiles, got %+v", output)
+	}
+
+	out, err := runSubcommand(t, GraphCommand(), "not-followed-back", GraphNotFollowedBackAction, reg, "--output", "csv")
+	if err != nil {
+		t.Fatalf("graph not-followed-back failed: %v", err)
+	}
+	if want := "did,handle,display_name,followers\ndid:plc:a,,,\n"; out != want {
+		t.Errorf("expected CSV %q, got %q", want, out)
+	}
+
+	if _, err := runSubcommand(t, GraphCommand(), "mutuals", GraphMutualsAction, reg, "--output", "xml"); err == nil {
+		t.Error("expected an invalid output format to fail")
+	}
+}
+
+// failingWriter rejects every write, like a closed pipe
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestOutputGraphCSV_WriteError(t *testing.T) {
+	if err := outputGraphCSV(failingWriter{}, []graphAccount{{Did: "did:plc:a"}}); err == nil {
+		t.Error("expected the failed write to be reported")
+	}
+}
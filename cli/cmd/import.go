@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/imports"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// importSourcePrefix marks the Source of local feeds holding imported posts, e.g. import:did:plc:abc
+const importSourcePrefix = "import:"
+
+// importBatchSize caps how many posts are checked and saved per query
+const importBatchSize = 500
+
+// importResult counts what one import saved
+type importResult struct {
+	Posts, Existing int
+	Follows, Blocks int
+	Skipped         []string // snapshot types already imported from the same export
+}
+
+// ImportCommand returns the import command with subcommands for loading data exported elsewhere
+func ImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "import",
+		Usage: "Load data exported from other tools into the local archive",
+		Commands: []*cli.Command{
+			{
+				Name:      "bluesky-export",
+				Usage:     "Import an official Bluesky account export",
+				UsageText: "Read the repository export from Bluesky's Settings → Account → Export my data (a .car file, or a zip holding one) and load its posts into a local feed, its follows into a following snapshot, and its blocks into a blocks snapshot. No login is needed.",
+				ArgsUsage: "<car-or-zip>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Read the export and report what it holds without saving anything",
+					},
+				},
+				Action: withRegistry(ImportBlueskyExportAction),
+			},
+		},
+	}
+}
+
+// ImportBlueskyExportAction loads posts, follows, and blocks from an account export into the local archive
+func ImportBlueskyExportAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("export file required: the .car or .zip downloaded from Bluesky")
+	}
+
+	export, err := imports.ParseBlueskyExport(cmd.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to read export: %w", err)
+	}
+	logger.Debug("Read export", "did", export.DID, "rev", export.Rev, "posts", len(export.Posts), "follows", len(export.Follows), "blocks", len(export.Blocks), "other", export.Other)
+
+	if cmd.Bool("dry-run") {
+		ui.Titleln("Export of %s", export.DID)
+		ui.Infoln("Exported: %s", ui.FormatDate(export.ExportedAt, ui.DatesISO))
+		ui.Infoln("Posts: %d, follows: %d, blocks: %d, other records: %d", len(export.Posts), len(export.Follows), len(export.Blocks), export.Other)
+		ui.Infoln("Dry run: nothing saved")
+		return nil
+	}
+
+	result, err := importBlueskyExport(ctx, reg, export)
+	if err != nil {
+		return err
+	}
+
+	ui.Successln("Imported the export of %s from %s", export.DID, ui.FormatDate(export.ExportedAt, ui.DatesISO))
+	ui.Infoln("Posts: %d new, %d already archived", result.Posts, result.Existing)
+	ui.Infoln("Follows: %d accounts, blocks: %d accounts", result.Follows, result.Blocks)
+	for _, snapshotType := range result.Skipped {
+		ui.Infoln("The %s snapshot from this export was already imported", snapshotType)
+	}
+	if result.Posts > 0 {
+		ui.Infoln("Browse the posts with: skycli list stored --source %s", importSourcePrefix+export.DID)
+	}
+	return nil
+}
+
+// importBlueskyExport saves an export's posts into the account's import feed, skipping posts already archived in
+// any feed, and its follows and blocks as snapshots dated at export time
+func importBlueskyExport(ctx context.Context, reg *registry.Registry, export *imports.BlueskyExport) (importResult, error) {
+	var result importResult
+
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return result, fmt.Errorf("failed to get feed repository: %w", err)
+	}
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return result, fmt.Errorf("failed to get post repository: %w", err)
+	}
+	snapshotRepo, err := reg.GetSnapshotRepo()
+	if err != nil {
+		return result, fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+
+	if len(export.Posts) > 0 {
+		feed, created, err := localFeed(ctx, feedRepo, importSourcePrefix+export.DID, "Import: "+export.DID)
+		if err != nil {
+			return result, fmt.Errorf("failed to create import feed: %w", err)
+		}
+		if created {
+			logger.Info("Created import feed", "feed", feed.ID())
+		}
+
+		for batch := range slices.Chunk(export.Posts, importBatchSize) {
+			uris := make([]string, len(batch))
+			for i, post := range batch {
+				uris[i] = post.Uri
+			}
+			stored, err := postRepo.StoredFeedIDs(ctx, uris)
+			if err != nil {
+				return result, fmt.Errorf("failed to check archived posts: %w", err)
+			}
+
+			models := make([]*bsky.PostModel, 0, len(batch))
+			for _, post := range batch {
+				if _, ok := stored[post.Uri]; ok {
+					result.Existing++
+					continue
+				}
+				model := bsky.NewPostModel(feed.ID(), post)
+				model.Engagement = nil // a repository export has records, not engagement counts
+				models = append(models, model)
+			}
+			if err := postRepo.BatchSave(ctx, models); err != nil {
+				return result, fmt.Errorf("failed to save posts: %w", err)
+			}
+			result.Posts += len(models)
+		}
+	}
+
+	for _, graph := range []struct {
+		snapshotType string
+		records      []imports.GraphRecord
+		count        *int
+	}{
+		{"following", export.Follows, &result.Follows},
+		{"blocks", export.Blocks, &result.Blocks},
+	} {
+		snapshot, entries := export.Snapshot(graph.snapshotType, graph.records)
+		*graph.count = len(entries)
+		if existing, err := snapshotRepo.Get(ctx, snapshot.ID()); err == nil && existing != nil {
+			result.Skipped = append(result.Skipped, graph.snapshotType)
+			continue
+		}
+		if err := snapshotRepo.Import(ctx, snapshot, entries); err != nil {
+			return result, fmt.Errorf("failed to save %s snapshot: %w", graph.snapshotType, err)
+		}
+	}
+	return result, nil
+}
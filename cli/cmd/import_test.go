@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/imports"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestImportBlueskyExport(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+	snapshotRepo, err := bsky.NewSnapshotRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { snapshotRepo.Close() })
+	if err := snapshotRepo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	reg := registry.New(registry.Dependencies{FeedRepo: feedRepo, PostRepo: postRepo, SnapshotRepo: snapshotRepo})
+
+	// One post is already archived by the backup task and stays in its feed
+	backup, _, err := localFeed(ctx, feedRepo, backupSourcePrefix+"posts", "Backup: posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived := feedItem("did:plc:me", "1", nil).Post
+	if err := postRepo.BatchSave(ctx, []*bsky.PostModel{bsky.NewPostModel(backup.ID(), archived)}); err != nil {
+		t.Fatal(err)
+	}
+
+	export := &imports.BlueskyExport{
+		DID:        "did:plc:me",
+		Rev:        "3khuwc44c222b",
+		ExportedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Posts:      []*bsky.PostView{archived, feedItem("did:plc:me", "2", nil).Post},
+		Follows:    []imports.GraphRecord{{Subject: "did:plc:friend", CreatedAt: "2023-06-01T00:00:00Z"}},
+		Blocks:     []imports.GraphRecord{{Subject: "did:plc:troll"}, {Subject: "did:plc:spam"}},
+	}
+
+	result, err := importBlueskyExport(ctx, reg, export)
+	if err != nil {
+		t.Fatalf("importBlueskyExport failed: %v", err)
+	}
+	if result.Posts != 1 || result.Existing != 1 || result.Follows != 1 || result.Blocks != 2 || len(result.Skipped) != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	posts, err := postRepo.Query(ctx, bsky.PostQuery{Author: "did:plc:me"})
+	if err != nil || len(posts) != 2 {
+		t.Fatalf("expected 2 archived posts, got %d (%v)", len(posts), err)
+	}
+
+	firstSeen, err := snapshotRepo.FirstSeen(ctx, "did:plc:me", "blocks")
+	if err != nil || len(firstSeen) != 2 || !firstSeen["did:plc:spam"].Equal(export.ExportedAt) {
+		t.Errorf("unexpected blocks snapshot %v (%v)", firstSeen, err)
+	}
+
+	// Importing the same export again adds nothing
+	result, err = importBlueskyExport(ctx, reg, export)
+	if err != nil {
+		t.Fatalf("second import failed: %v", err)
+	}
+	if result.Posts != 0 || result.Existing != 2 || len(result.Skipped) != 2 {
+		t.Errorf("unexpected second result %+v", result)
+	}
+}
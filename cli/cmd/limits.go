@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// limitFlag is --limit for commands that can work with the first N of an account's followers or follows; pair it
+// with [noLimitFlag]
+func limitFlag(noun string) cli.Flag {
+	return &cli.IntFlag{
+		Name:    "limit",
+		Aliases: []string{"l"},
+		Usage:   fmt.Sprintf("Maximum number of %s to fetch (0 = all, up to the safety cap)", noun),
+	}
+}
+
+// noLimitFlag is --no-limit, which lifts the safety cap; commands that need every follower or follow to give a
+// correct answer offer it without [limitFlag]
+func noLimitFlag(noun string) cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "no-limit",
+		Usage: fmt.Sprintf("Fetch all %s even past the safety cap (limits.maxAccounts in the config, default %d)", noun, config.DefaultMaxAccounts),
+	}
+}
+
+// graphPageOptions returns paginator options for paging through an account's followers or follows. A positive
+// --limit fetches that many; otherwise everything is fetched, failing past the configured safety cap unless
+// --no-limit is given. Commands without a --limit flag read it as 0.
+func graphPageOptions(cmd *cli.Command, noun string) (bsky.PaginatorOptions, error) {
+	opts := bsky.PaginatorOptions{Progress: logPageProgress(noun)}
+	limit := cmd.Int("limit")
+	noLimit := cmd.Bool("no-limit")
+
+	switch {
+	case limit < 0:
+		return opts, fmt.Errorf("invalid --limit %d: must be 0 or more", limit)
+	case limit > 0 && noLimit:
+		return opts, errors.New("--limit and --no-limit can't be combined")
+	case limit > 0:
+		opts.MaxItems = limit
+	case !noLimit:
+		cfg, err := config.Load()
+		if err != nil {
+			return opts, fmt.Errorf("failed to load config: %w", err)
+		}
+		opts.Cap = cfg.MaxAccounts()
+	}
+	return opts, nil
+}
+
+// capError explains how to get past a [bsky.CapError] from a paginator built by [graphPageOptions]
+func capError(cmd *cli.Command, noun string, err error) error {
+	var capErr *bsky.CapError
+	if !errors.As(err, &capErr) {
+		return err
+	}
+
+	hint := "pass --no-limit to fetch them all"
+	if hasFlag(cmd, "limit") {
+		hint = "pass --limit N to fetch the first N, or --no-limit to fetch them all"
+	}
+	return fmt.Errorf("more than %d %s, over the safety cap; %s, or raise limits.maxAccounts in the config", capErr.Cap, noun, hint)
+}
+
+// hasFlag reports whether cmd defines the flag name
+func hasFlag(cmd *cli.Command, name string) bool {
+	return slices.ContainsFunc(cmd.Flags, func(flag cli.Flag) bool { return slices.Contains(flag.Names(), name) })
+}
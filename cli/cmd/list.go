@@ -3,23 +3,21 @@ package main
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/dateparse"
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
-	"github.com/stormlightlabs/skypanel/cli/internal/setup"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
 // ListPostsAction lists the authenticated user's own posts
-func ListPostsAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
+func ListPostsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	service, err := reg.GetService()
 	if err != nil {
 		return fmt.Errorf("failed to get service: %w", err)
@@ -59,13 +57,7 @@ func ListPostsAction(ctx context.Context, cmd *cli.Command) error {
 }
 
 // ListFeedsAction lists user's feeds (from local cache or refetch from API)
-func ListFeedsAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
+func ListFeedsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	refetch := cmd.Bool("refetch")
 	asJSON := cmd.Bool("json")
 
@@ -101,12 +93,31 @@ func ListFeedsAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("failed to get feed repository: %w", err)
 	}
 
-	feeds, err := feedRepo.List(ctx)
+	since, err := parseSince(cmd.String("since"), userNow())
+	if err != nil {
+		return err
+	}
+
+	models, err := feedRepo.List(ctx)
 	if err != nil {
 		logger.Error("Failed to list feeds", "error", err)
 		return err
 	}
 
+	feeds := filterFeeds(models, cmd.String("source"), cmd.String("contains"), since)
+
+	tagged, err := taggedSet(ctx, cmd, reg)
+	if err != nil {
+		return err
+	}
+	if tagged != nil {
+		feeds = slices.DeleteFunc(feeds, func(feed *bsky.FeedModel) bool { return !slices.Contains(tagged.Feeds, feed.ID()) })
+	}
+
+	if cmd.Bool("count") {
+		return displayCount(len(feeds), asJSON)
+	}
+
 	if len(feeds) == 0 {
 		ui.Infoln("No feeds found.")
 		return nil
@@ -119,22 +130,232 @@ func ListFeedsAction(ctx context.Context, cmd *cli.Command) error {
 	ui.Titleln("Your Feeds")
 	fmt.Println()
 
-	for i, model := range feeds {
-		if feed, ok := model.(*store.FeedModel); ok {
-			ui.Subtitleln("[%d] %s", i+1, feed.Name)
-			ui.Infoln("  ID: %s", feed.ID())
-			ui.Infoln("  Source: %s", feed.Source)
-			ui.Infoln("  Local: %t", feed.IsLocal)
-			ui.Infoln("  Created: %s", feed.CreatedAt().Format(time.RFC3339))
-			fmt.Println()
-		}
+	for i, feed := range feeds {
+		ui.Subtitleln("[%d] %s", i+1, feed.Name)
+		ui.Infoln("  ID: %s", feed.ID())
+		ui.Infoln("  Source: %s", feed.Source)
+		ui.Infoln("  Local: %t", feed.IsLocal)
+		ui.Infoln("  Created: %s", feed.CreatedAt().Format(time.RFC3339))
+		fmt.Println()
 	}
 
 	ui.Successln("Total: %d feed(s)", len(feeds))
 	return nil
 }
 
-// ListCommand returns the list command with subcommands for posts and feeds
+// ListStoredAction lists posts saved in the local database, filtered by feed, author, date, and text
+func ListStoredAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	since, err := parseSince(cmd.String("since"), userNow())
+	if err != nil {
+		return err
+	}
+
+	query := bsky.PostQuery{
+		Since:    since,
+		Contains: cmd.String("contains"),
+		Terms:    cmd.Args().Slice(),
+		Limit:    cmd.Int("limit"),
+	}
+	asJSON := cmd.Bool("json")
+
+	if source := cmd.String("source"); source != "" {
+		if query.FeedIDs, err = sourceFeedIDs(ctx, reg, source); err != nil {
+			return err
+		}
+	}
+
+	if author := cmd.String("author"); author != "" {
+		did, err := resolveAuthorDID(ctx, reg, "--author", author)
+		if err != nil {
+			return err
+		}
+		query.Author = did
+	}
+
+	if query.Tagged, err = taggedSet(ctx, cmd, reg); err != nil {
+		return err
+	}
+
+	logger.Debug("Querying stored posts", "feeds", len(query.FeedIDs), "author", query.Author, "since", query.Since, "contains", query.Contains, "terms", query.Terms)
+
+	if cmd.Bool("count") {
+		count, err := postRepo.Count(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to count posts: %w", err)
+		}
+		return displayCount(count, asJSON)
+	}
+
+	posts, err := postRepo.Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query posts: %w", err)
+	}
+
+	if asJSON {
+		return ui.DisplayJSON(posts)
+	}
+
+	if len(posts) == 0 {
+		ui.Infoln("No stored posts match.")
+		return nil
+	}
+
+	displayStoredPosts(posts)
+	return nil
+}
+
+// parseSince reads a --since value with [dateparse.Parse]; the empty string means no lower bound
+func parseSince(value string, now time.Time) (time.Time, error) {
+	t, err := dateparse.Parse(value, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q: %w", value, err)
+	}
+	return t, nil
+}
+
+// parseUntil reads an --until value with [dateparse.ParseEnd], so a whole day is included; the empty string means
+// no upper bound
+func parseUntil(value string, now time.Time) (time.Time, error) {
+	t, err := dateparse.ParseEnd(value, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --until %q: %w", value, err)
+	}
+	return t, nil
+}
+
+// userNow returns the current time in the user's time zone, which date flags are read in
+func userNow() time.Time {
+	return time.Now().In(ui.Location())
+}
+
+// filterFeeds keeps feeds whose ID equals source or whose source contains it, whose name contains text,
+// and that were created at or after since; empty filters match every feed
+func filterFeeds(models []bsky.Model, source, text string, since time.Time) []*bsky.FeedModel {
+	source = strings.ToLower(source)
+	text = strings.ToLower(text)
+
+	var feeds []*bsky.FeedModel
+	for _, model := range models {
+		feed, ok := model.(*bsky.FeedModel)
+		if !ok {
+			continue
+		}
+		if source != "" && feed.ID() != source && !strings.Contains(strings.ToLower(feed.Source), source) {
+			continue
+		}
+		if text != "" && !strings.Contains(strings.ToLower(feed.Name), text) {
+			continue
+		}
+		if !since.IsZero() && feed.CreatedAt().Before(since) {
+			continue
+		}
+		feeds = append(feeds, feed)
+	}
+	return feeds
+}
+
+// sourceFeedIDs returns the IDs of saved feeds matching a --source value, failing when none match
+func sourceFeedIDs(ctx context.Context, reg *registry.Registry, source string) ([]string, error) {
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed repository: %w", err)
+	}
+
+	models, err := feedRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feeds: %w", err)
+	}
+
+	feeds := filterFeeds(models, source, "", time.Time{})
+	if len(feeds) == 0 {
+		return nil, fmt.Errorf("no saved feed matches --source %q", source)
+	}
+
+	ids := make([]string, len(feeds))
+	for i, feed := range feeds {
+		ids[i] = feed.ID()
+	}
+	return ids, nil
+}
+
+// resolveAuthorDID validates an author flag and returns it unchanged when it is a DID, otherwise looks up the
+// handle's DID. what names the flag in errors.
+func resolveAuthorDID(ctx context.Context, reg *registry.Registry, what, author string) (string, error) {
+	actor, err := parseActorArg(what, author)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(actor, "did:") {
+		return actor, nil
+	}
+
+	service, err := reg.GetService()
+	if err != nil {
+		return "", fmt.Errorf("failed to get service: %w", err)
+	}
+	if !service.Authenticated() {
+		return "", fmt.Errorf("%s %q is a handle: pass a DID or run 'skycli login' to resolve it", what, author)
+	}
+	return resolveActor(ctx, service, what, actor)
+}
+
+// displayCount prints a bare match count, as {"count": n} with --json
+func displayCount(count int, asJSON bool) error {
+	if asJSON {
+		return ui.DisplayJSON(map[string]int{"count": count})
+	}
+	fmt.Println(count)
+	return nil
+}
+
+// displayStoredPosts renders stored posts as a table, newest first
+func displayStoredPosts(posts []*bsky.PostModel) {
+	rows := make([][]string, 0, len(posts))
+	for _, post := range posts {
+		text := strings.Join(strings.Fields(post.Text), " ")
+		if runes := []rune(text); len(runes) > 80 {
+			text = string(runes[:77]) + "..."
+		}
+		rows = append(rows, []string{ui.FormatDate(post.IndexedAt, ui.DatesLocal), post.AuthorDID, text})
+	}
+
+	headers := []string{"Indexed", "Author", "Text"}
+	if ui.ListAsLines() {
+		ui.Titleln("Stored Posts")
+		fmt.Println(ui.FormatLines(headers, rows))
+		fmt.Println()
+		ui.Successln("Showing %d post(s)", len(posts))
+		return
+	}
+
+	fit := ui.FitTable(headers, rows, nil, "Text")
+	t := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(ui.TableBorderStyle).
+		Headers(fit.Headers...).
+		Rows(fit.Rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == lgtable.HeaderRow {
+				return ui.TableHeaderStyle
+			}
+			if row%2 == 0 {
+				return ui.TableRowEvenStyle
+			}
+			return ui.TableRowOddStyle
+		})
+
+	ui.Titleln("Stored Posts")
+	fmt.Println(t)
+	fit.HiddenNote()
+	ui.Successln("Showing %d post(s)", len(posts))
+}
+
+// ListCommand returns the list command with subcommands for posts, feeds, and stored posts
 func ListCommand() *cli.Command {
 	commonFlags := []cli.Flag{
 		&cli.BoolFlag{
@@ -151,7 +372,7 @@ func ListCommand() *cli.Command {
 
 	return &cli.Command{
 		Name:  "list",
-		Usage: "List user's posts or feeds",
+		Usage: "List user's posts, feeds, or stored posts",
 		Commands: []*cli.Command{
 			{
 				Name:      "posts",
@@ -170,21 +391,87 @@ func ListCommand() *cli.Command {
 						Usage:   "Output raw JSON response",
 					},
 				},
-				Action: ListPostsAction,
+				Action: withRegistry(ListPostsAction),
 			},
 			{
 				Name:      "feeds",
 				Usage:     "List user's feeds (local cache or refetch with -r)",
 				ArgsUsage: " ",
-				Flags:     commonFlags,
-				Action:    ListFeedsAction,
+				Flags: append(commonFlags,
+					&cli.StringFlag{
+						Name:    "source",
+						Aliases: []string{"s"},
+						Usage:   "Only feeds with this ID or whose source contains this text",
+					},
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only feeds saved since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+					},
+					&cli.StringFlag{
+						Name:  "contains",
+						Usage: "Only feeds whose name contains this text",
+					},
+					tagFlag("Only feeds with this local tag"),
+					&cli.BoolFlag{
+						Name:    "count",
+						Aliases: []string{"c"},
+						Usage:   "Print only the number of matching feeds",
+					},
+				),
+				Action: withRegistry(ListFeedsAction),
+			},
+			{
+				Name:      "stored",
+				Aliases:   []string{"local"},
+				Usage:     "Query posts stored in the local database",
+				ArgsUsage: "[search terms...]",
+				Description: "Search terms match post text case-insensitively and must all appear, in any order.\n" +
+					"Filters combine, e.g. 'skycli list stored --source news --since 7d election results'.",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "source",
+						Aliases: []string{"s"},
+						Usage:   "Only posts from saved feeds with this ID or whose source contains this text",
+					},
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only posts indexed since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+					},
+					&cli.StringFlag{
+						Name:    "author",
+						Aliases: []string{"a"},
+						Usage:   "Only posts by this author (DID, or handle when logged in)",
+					},
+					&cli.StringFlag{
+						Name:  "contains",
+						Usage: "Only posts whose text contains this exact phrase",
+					},
+					tagFlag(tagPostsUsage),
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum number of posts to list (0 for all)",
+						Value:   25,
+					},
+					&cli.BoolFlag{
+						Name:    "count",
+						Aliases: []string{"c"},
+						Usage:   "Print only the number of matching posts",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output results as JSON",
+					},
+				},
+				Action: withRegistry(ListStoredAction),
 			},
 		},
 		// Default action when no subcommand is provided
-		Action: func(ctx context.Context, cmd *cli.Command) error {
+		Action: withRegistry(func(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 			// Default to posts
-			return ListPostsAction(ctx, cmd)
-		},
+			return ListPostsAction(ctx, cmd, reg)
+		}),
 		Flags: []cli.Flag{
 			&cli.IntFlag{
 				Name:    "limit",
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"", time.Time{}, false},
+		{"2025-03-01", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2025-03-01T08:30:00Z", time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC), false},
+		{"36h", now.Add(-36 * time.Hour), false},
+		{"7d", now.Add(-7 * 24 * time.Hour), false},
+		{"2w", now.Add(-14 * 24 * time.Hour), false},
+		{"yesterday", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), false},
+		{"someday", time.Time{}, true},
+		{"-3d", time.Time{}, true},
+		{"-1h", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFilterFeeds(t *testing.T) {
+	old := &bsky.FeedModel{Name: "Art Daily", Source: "art.bsky.social"}
+	old.SetID("feed-art")
+	old.SetCreatedAt(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	recent := &bsky.FeedModel{Name: "World News", Source: "news.bsky.social"}
+	recent.SetID("feed-news")
+	recent.SetCreatedAt(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	models := []bsky.Model{old, recent}
+
+	tests := []struct {
+		name   string
+		source string
+		text   string
+		since  time.Time
+		want   []string
+	}{
+		{"no filters", "", "", time.Time{}, []string{"feed-art", "feed-news"}},
+		{"source substring", "NEWS.bsky", "", time.Time{}, []string{"feed-news"}},
+		{"source by ID", "feed-art", "", time.Time{}, []string{"feed-art"}},
+		{"name contains", "", "daily", time.Time{}, []string{"feed-art"}},
+		{"since", "", "", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), []string{"feed-news"}},
+		{"no match", "sports", "", time.Time{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterFeeds(models, tt.source, tt.text, tt.since)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d feeds, got %d", len(tt.want), len(got))
+			}
+			for i, feed := range got {
+				if feed.ID() != tt.want[i] {
+					t.Errorf("feed %d: expected %s, got %s", i, tt.want[i], feed.ID())
+				}
+			}
+		})
+	}
+}
@@ -3,12 +3,17 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/stormlightlabs/skypanel/cli/internal/handoff"
 	"github.com/stormlightlabs/skypanel/cli/internal/imports"
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
-	"github.com/stormlightlabs/skypanel/cli/internal/setup"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/verify"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
@@ -16,19 +21,33 @@ func LoginCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "login",
 		Usage: "Authenticate with Bluesky",
-		Description: `Authenticate with Bluesky using one of two methods:
+		Description: `Authenticate with Bluesky using one of three methods:
 
-   1. Direct credentials via flags:
+   1. OAuth in a browser, without an app password:
+      skycli login --oauth --handle @user.bsky.social
+
+   2. Direct credentials via flags:
       skycli login --handle @user.bsky.social --password your-app-password
 
-   2. Credentials from an env file:
+   3. Credentials from an env file:
       skycli login --file /path/to/.env
 
    The env file should contain:
       BLUESKY_HANDLE=your.handle.bsky.social
       BLUESKY_PASSWORD=your-app-password
 
-   File paths can be relative or absolute.`,
+   and may name the app password so 'skycli status sessions' can flag it:
+      BLUESKY_APP_PASSWORD_NAME=skycli
+
+   File paths can be relative or absolute.
+
+   Signing in to another account keeps the accounts already signed in and
+   makes the new one the default. See 'skycli account' to switch between
+   them, and the global --account flag to run one command as another.
+
+   To sign in a headless machine without typing a password there, run
+   'skycli login --handoff' on it and follow the pairing instructions on a
+   device where skycli is already signed in.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "file",
@@ -45,19 +64,55 @@ func LoginCommand() *cli.Command {
 				Aliases: []string{"p"},
 				Usage:   "Your app password",
 			},
+			&cli.BoolFlag{
+				Name:  "oauth",
+				Usage: "Sign in through your account's authorization server in a browser instead of with an app password",
+			},
+			&cli.DurationFlag{
+				Name:  "oauth-timeout",
+				Usage: "How long to wait for the browser sign-in to be approved",
+				Value: 5 * time.Minute,
+			},
+			&cli.StringFlag{
+				Name:  "app-password-name",
+				Usage: "Name the app password was created under, so 'status sessions' can tell it apart",
+			},
+			&cli.BoolFlag{
+				Name:  "handoff",
+				Usage: "Wait for a session handed over from another device, showing a one-time pairing code",
+			},
+			&cli.StringFlag{
+				Name:  "handoff-send",
+				Usage: "Hand this device's session to the device showing the pairing `host:port/CODE`, signing out here",
+			},
+			&cli.StringFlag{
+				Name:  "handoff-listen",
+				Usage: "Address to wait for a handoff on",
+				Value: ":0",
+			},
+			&cli.DurationFlag{
+				Name:  "handoff-timeout",
+				Usage: "How long the pairing code stays valid",
+				Value: 5 * time.Minute,
+			},
 		},
-		Action: LoginAction,
+		Action: withRegistry(LoginAction),
 	}
 }
 
-func LoginAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
+func LoginAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Bool("handoff") {
+		return receiveHandoff(ctx, cmd, reg)
+	}
+	if pairing := cmd.String("handoff-send"); pairing != "" {
+		return sendHandoff(ctx, cmd, reg, pairing)
+	}
+	if cmd.Bool("oauth") {
+		return oauthLogin(ctx, cmd, reg)
 	}
-
-	reg := registry.Get()
 
 	var handle, password string
+	appPassword := cmd.String("app-password-name")
 	filePath := cmd.String("file")
 
 	if filePath != "" {
@@ -68,6 +123,9 @@ func LoginAction(ctx context.Context, cmd *cli.Command) error {
 
 		handle = env["BLUESKY_HANDLE"]
 		password = env["BLUESKY_PASSWORD"]
+		if appPassword == "" {
+			appPassword = env["BLUESKY_APP_PASSWORD_NAME"]
+		}
 
 		if handle == "" {
 			return fmt.Errorf("BLUESKY_HANDLE not found in env file")
@@ -110,6 +168,7 @@ func LoginAction(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
+	session.AppPassword = appPassword
 
 	if err := sessionRepo.Save(ctx, session); err != nil {
 		logger.Error("Failed to save session", "error", err)
@@ -118,11 +177,110 @@ func LoginAction(ctx context.Context, cmd *cli.Command) error {
 
 	logger.Debug("Session saved successfully", "did", session.ID(), "handle", handle)
 	ui.Successln("Successfully authenticated as %s", handle)
+	noteOtherAccounts(ctx, sessionRepo)
+	return nil
+}
+
+// oauthLogin signs in with atproto OAuth: it resolves the handle to its DID and PDS, sends the user to the PDS's
+// authorization server in a browser, and saves the DPoP-bound session that comes back
+func oauthLogin(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.String("password") != "" || cmd.String("file") != "" {
+		return fmt.Errorf("--oauth doesn't take a password or env file")
+	}
+	handle := strings.TrimPrefix(cmd.String("handle"), "@")
+	if handle == "" {
+		return fmt.Errorf("--oauth needs --handle to find your account's authorization server")
+	}
+
+	identity := verify.NewVerifier("")
+	did := handle
+	if !strings.HasPrefix(handle, "did:") {
+		resolved, err := identity.ResolveHandle(ctx, handle)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", handle, err)
+		}
+		did = resolved
+	}
+	doc, err := identity.ResolveDID(ctx, did)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", did, err)
+	}
+	if did != handle && !slices.Contains(doc.AlsoKnownAs, "at://"+handle) {
+		return fmt.Errorf("%s points to %s, but that account doesn't claim the handle", handle, did)
+	}
+	pds, err := verify.PDSEndpoint(doc)
+	if err != nil {
+		return err
+	}
+	if did == handle {
+		for _, aka := range doc.AlsoKnownAs {
+			if name, ok := strings.CutPrefix(aka, "at://"); ok {
+				handle = name
+				break
+			}
+		}
+	}
+
+	service, err := reg.GetService()
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+	sessionRepo, err := reg.GetSessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+
+	timeout := cmd.Duration("oauth-timeout")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	logger.Info("Signing in with OAuth", "handle", handle, "did", did, "pds", pds)
+	err = service.Authenticate(ctx, bsky.OAuthCredentials{
+		Handle: handle,
+		DID:    did,
+		PDSURL: pds,
+		Authorize: func(authURL string) error {
+			ui.Titleln("Approve skycli in your browser")
+			if err := ui.OpenURL(authURL); err != nil {
+				logger.Debug("Failed to open browser", "error", err)
+				ui.Infoln("Open this link to continue (valid for %s):", timeout)
+			} else {
+				ui.Infoln("If the browser didn't open, visit this link (valid for %s):", timeout)
+			}
+			fmt.Printf("  %s\n", authURL)
+			return nil
+		},
+	})
+	if err != nil {
+		logger.Error("Authentication failed", "error", err)
+		return err
+	}
+
+	session, err := createSessionFromService(service, handle)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	if err := sessionRepo.Save(ctx, session); err != nil {
+		return fmt.Errorf("authentication succeeded but failed to save session: %w", err)
+	}
+
+	logger.Debug("OAuth session saved", "did", session.ID(), "handle", handle)
+	ui.Successln("Successfully authenticated as %s with OAuth", handle)
+	noteOtherAccounts(ctx, sessionRepo)
 	return nil
 }
 
+// noteOtherAccounts mentions the accounts that stay signed in alongside a new login
+func noteOtherAccounts(ctx context.Context, sessionRepo *bsky.SessionRepository) {
+	models, err := sessionRepo.List(ctx)
+	if err != nil || len(models) < 2 {
+		return
+	}
+	ui.Infoln("%d other account(s) stay signed in; see 'skycli account list'", len(models)-1)
+}
+
 // createSessionFromService creates a SessionModel from an authenticated service
-func createSessionFromService(service *store.BlueskyService, handle string) (*store.SessionModel, error) {
+func createSessionFromService(service *bsky.BlueskyService, handle string) (*bsky.SessionModel, error) {
 	did := service.GetDid()
 	if did == "" {
 		return nil, fmt.Errorf("no DID available from authenticated service")
@@ -131,13 +289,130 @@ func createSessionFromService(service *store.BlueskyService, handle string) (*st
 	accessToken := service.GetAccessToken()
 	refreshToken := service.GetRefreshToken()
 
-	session := &store.SessionModel{
+	session := &bsky.SessionModel{
 		Handle:     handle,
 		Token:      accessToken + "|" + refreshToken,
 		ServiceURL: service.BaseURL(),
 		IsValid:    true,
+		OAuth:      service.OAuthSession(),
 	}
 	session.SetID(did)
 
 	return session, nil
 }
+
+// receiveHandoff waits for another device to hand over its session and saves it as this device's session
+func receiveHandoff(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	sessionRepo, err := reg.GetSessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+
+	receiver, err := handoff.Listen(cmd.String("handoff-listen"))
+	if err != nil {
+		return fmt.Errorf("failed to start handoff: %w", err)
+	}
+	timeout := cmd.Duration("handoff-timeout")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pairings := receiver.Pairings()
+	ui.Titleln("Waiting for a session")
+	ui.Infoln("Pairing code: %s (valid for %s)", receiver.Code(), timeout)
+	ui.Infoln("On a device where skycli is signed in, run one of:")
+	for _, pairing := range pairings {
+		fmt.Printf("  skycli login --handoff-send %s\n", pairing)
+	}
+	if qr, err := ui.QRCode(pairings[0]); err == nil {
+		fmt.Println()
+		fmt.Print(qr)
+	}
+	ui.Infoln("The session moves here and the other device is signed out.")
+
+	session, err := receiver.Receive(ctx)
+	if err != nil {
+		return fmt.Errorf("no session received: %w", err)
+	}
+	if session.DID == "" || session.AccessToken == "" || session.RefreshToken == "" {
+		return fmt.Errorf("received an incomplete session")
+	}
+	if info, err := bsky.ParseToken(session.AccessToken); err == nil && info.Subject != "" && info.Subject != session.DID {
+		return fmt.Errorf("received session for %s carries a token for %s", session.DID, info.Subject)
+	}
+
+	model := &bsky.SessionModel{
+		Handle:      session.Handle,
+		Token:       session.AccessToken + "|" + session.RefreshToken,
+		ServiceURL:  session.ServiceURL,
+		IsValid:     true,
+		AppPassword: session.AppPassword,
+	}
+	model.SetID(session.DID)
+	if err := sessionRepo.Save(ctx, model); err != nil {
+		return fmt.Errorf("received a session but failed to save it: %w", err)
+	}
+
+	logger.Debug("Session received by handoff", "did", session.DID, "handle", session.Handle)
+	ui.Successln("Successfully authenticated as %s by handoff", session.Handle)
+	noteOtherAccounts(ctx, sessionRepo)
+	return nil
+}
+
+// sendHandoff hands this device's session to the receiver named by pairing, then signs out here, since both devices
+// refreshing the same session would lock each other out
+func sendHandoff(ctx context.Context, cmd *cli.Command, reg *registry.Registry, pairing string) error {
+	sessionRepo, err := reg.GetSessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+	if !sessionRepo.HasValidSession(ctx) {
+		return fmt.Errorf("not authenticated: there is no session here to hand over")
+	}
+
+	model, err := sessionRepo.Get(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to read session: %w", err)
+	}
+	current := model.(*bsky.SessionModel)
+	if current.OAuth != nil {
+		return fmt.Errorf("OAuth sessions are bound to this device's key and can't be handed over; run 'skycli login --oauth' on the other device")
+	}
+	accessToken, err := sessionRepo.GetAccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read access token: %w", err)
+	}
+	refreshToken, err := sessionRepo.GetRefreshToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read refresh token: %w", err)
+	}
+
+	addr, _, err := handoff.ParsePairing(pairing)
+	if err != nil {
+		return err
+	}
+	if ok, err := confirm(cmd, fmt.Sprintf("Move the session for %s to %s? It will be signed out on this device.", current.Handle, addr)); !ok {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	// The receiver is on the local network, so skip any proxy configured for API traffic
+	client := &http.Client{Transport: &http.Transport{}}
+	err = handoff.Send(ctx, client, pairing, &handoff.Session{
+		Handle:       current.Handle,
+		DID:          current.ID(),
+		ServiceURL:   current.ServiceURL,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		AppPassword:  current.AppPassword,
+	})
+	if err != nil {
+		return fmt.Errorf("handoff failed: %w", err)
+	}
+
+	if err := sessionRepo.Delete(ctx, current.ID()); err != nil {
+		return fmt.Errorf("session handed over, but failed to sign out here: %w", err)
+	}
+	ui.Successln("Handed the session for %s to %s; it is signed out on this device", current.Handle, addr)
+	return nil
+}
@@ -2,49 +2,468 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/setup"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
 	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
 var logger *log.Logger
 
+// version is the build version; release builds set it with -ldflags "-X main.version=1.2.3"
+var version = "0.1.0"
+
 func init() {
 	utils.InitLogger(log.InfoLevel)
 	logger = utils.GetLogger()
 }
 
+// registryAction is a command action that receives its dependencies through the registry
+type registryAction func(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error
+
+// withRegistry adapts a [registryAction] into a [cli.ActionFunc] backed by the global registry
+func withRegistry(action registryAction) cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		if err := setup.EnsurePersistenceReady(ctx); err != nil {
+			return fmt.Errorf("persistence layer not ready: %w", err)
+		}
+		ctx, span := telemetry.StartCommand(ctx, cmd.FullName())
+		err := action(ctx, cmd, registry.Get())
+		telemetry.EndSpan(span, err)
+		return err
+	}
+}
+
+// confirm asks before a destructive action, honoring the global --yes and --no-input flags.
+// It reports false after printing a notice when the user declines; a run that cannot prompt is an error.
+func confirm(cmd *cli.Command, prompt string) (bool, error) {
+	err := ui.Confirm(prompt, ui.ConfirmOptions{
+		Yes:     cmd.Bool("yes"),
+		NoInput: cmd.Bool("no-input"),
+		In:      cmd.Root().Reader,
+		Out:     cmd.Root().ErrWriter,
+	})
+	if errors.Is(err, ui.ErrDeclined) {
+		ui.Infoln("Aborted.")
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// applyLogFlags reconfigures the global logger from the --log-level, --log-format, --log-file, and --verbose flags
+func applyLogFlags(cmd *cli.Command) error {
+	opts := utils.LogOptions{
+		Level:  cmd.String("log-level"),
+		Format: cmd.String("log-format"),
+		File:   cmd.String("log-file"),
+	}
+	if cmd.Bool("verbose") {
+		opts.Level = "debug"
+	}
+	return utils.ConfigureLogger(opts)
+}
+
+// applyAccount signs the API client in as the account named by the global --account flag, when given
+func applyAccount(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	account := cmd.String("account")
+	if account == "" {
+		return nil
+	}
+	if err := reg.UseAccount(ctx, account); err != nil {
+		return fmt.Errorf("invalid --account: %w", accountError(err))
+	}
+	return nil
+}
+
+// applyNetworkFlags reconfigures the API client from the global --timeout, --proxy, and --debug-http flags
+func applyNetworkFlags(cmd *cli.Command, reg *registry.Registry) error {
+	timeout := cmd.Duration("timeout")
+	proxy := cmd.String("proxy")
+	tracePath := cmd.String("debug-http-file")
+	debugHTTP := cmd.Bool("debug-http") || tracePath != ""
+	if timeout <= 0 && proxy == "" && !debugHTTP {
+		return nil
+	}
+
+	service, err := reg.GetService()
+	if err != nil {
+		return err
+	}
+
+	if timeout > 0 {
+		service.SetTimeouts(bsky.UniformTimeouts(timeout))
+	}
+
+	if proxy != "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		opts, err := bsky.TransportOptionsFromConfig(cfg.Network)
+		if err != nil {
+			return err
+		}
+		opts.Proxy = proxy
+
+		transport, err := bsky.NewTransport(opts)
+		if err != nil {
+			return err
+		}
+		bsky.SetSharedTransport(transport)
+		service.SetTransport(transport)
+	}
+
+	if debugHTTP {
+		service.SetTransport(bsky.NewTracingTransport(service.Transport(), logger, tracePath))
+	}
+
+	return nil
+}
+
+// applyTimezone sets the user's time zone from the global --timezone flag, else the config file, else the system zone
+func applyTimezone(cmd *cli.Command) error {
+	loc := time.Local
+	if name := cmd.String("timezone"); name != "" {
+		var err error
+		if loc, err = time.LoadLocation(name); err != nil {
+			return fmt.Errorf("invalid --timezone %q: %w", name, err)
+		}
+	} else if cfg, err := config.Load(); err == nil {
+		if loc, err = cfg.Location(); err != nil {
+			return fmt.Errorf("invalid timezone in config: %w", err)
+		}
+	}
+	ui.SetLocation(loc)
+	return nil
+}
+
+// applyTheme sets the table indicator colors from the config file
+func applyTheme() error {
+	cfg, err := config.Load()
+	if err != nil || cfg.Theme == nil {
+		return nil
+	}
+	theme := ui.DefaultTheme()
+	theme.Plain = cfg.Theme.Plain
+	for _, c := range []struct {
+		value string
+		color *lipgloss.Color
+	}{
+		{cfg.Theme.Active, &theme.Active},
+		{cfg.Theme.Recent, &theme.Recent},
+		{cfg.Theme.Inactive, &theme.Inactive},
+	} {
+		if c.value == "" {
+			continue
+		}
+		color, err := ui.ParseColor(c.value)
+		if err != nil {
+			return fmt.Errorf("invalid theme in config: %w", err)
+		}
+		*c.color = color
+	}
+	ui.SetTheme(theme)
+	return nil
+}
+
+// sessionFlags are the global flags that reconfigure state every command in the process shares: the logger, the
+// API client and its account, and how output is laid out
+var sessionFlags = []string{
+	"log-level", "log-format", "log-file", "verbose",
+	"timeout", "proxy", "debug-http", "debug-http-file", "account",
+	"dates", "list-format", "wide", "timezone",
+}
+
+// applyGlobalFlags returns the root Before hook, which validates the global flags and applies them to the logger,
+// the API client, and the ui package
+func applyGlobalFlags(reg *registry.Registry) cli.BeforeFunc {
+	return func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		if err := validateErrorFormat(cmd); err != nil {
+			return ctx, err
+		}
+		if err := applyLogFlags(cmd); err != nil {
+			return ctx, err
+		}
+		if err := applyAccount(ctx, cmd, reg); err != nil {
+			return ctx, err
+		}
+		if err := applyNetworkFlags(cmd, reg); err != nil {
+			return ctx, err
+		}
+		if err := applyTimezone(cmd); err != nil {
+			return ctx, err
+		}
+		if err := applyTheme(); err != nil {
+			return ctx, err
+		}
+		dates, err := ui.ParseDateStyle(cmd.String("dates"))
+		if err != nil {
+			return ctx, err
+		}
+		ui.SetDateStyle(dates)
+		listFormat, err := ui.ParseListFormat(cmd.String("list-format"))
+		if err != nil {
+			return ctx, err
+		}
+		ui.SetListFormat(listFormat)
+		ui.SetWideTables(cmd.Bool("wide"))
+		// status shows the full quota gauge instead
+		if cmd.Args().First() != "status" {
+			warnLowQuota(ctx, reg, time.Now())
+		}
+		return ctx, nil
+	}
+}
+
+// keepGlobalFlags returns the root Before hook of a command line run inside another command, such as a shell line
+// or batch job. It leaves the settings from [sessionFlags] as the outer command applied them, since changing them
+// would leak into later lines or race with jobs running alongside.
+func keepGlobalFlags(reg *registry.Registry) cli.BeforeFunc {
+	return func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		if err := validateErrorFormat(cmd); err != nil {
+			return ctx, err
+		}
+		if cmd.Args().First() != "status" {
+			warnLowQuota(ctx, reg, time.Now())
+		}
+		return ctx, nil
+	}
+}
+
+// validateErrorFormat checks the global --error-format flag
+func validateErrorFormat(cmd *cli.Command) error {
+	if format := cmd.String("error-format"); format != "text" && format != "json" {
+		return fmt.Errorf("invalid --error-format %q: must be text or json", format)
+	}
+	return nil
+}
+
+// checkSessionFlags rejects [sessionFlags] in args, a command line run inside another command; outer names the
+// command they belong before instead
+func checkSessionFlags(app *cli.Command, args []string, outer string) error {
+	names := make(map[string]string)
+	for _, flag := range app.Flags {
+		if slices.Contains(sessionFlags, flag.Names()[0]) {
+			for _, name := range flag.Names() {
+				names[name] = flag.Names()[0]
+			}
+		}
+	}
+
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if flag, ok := names[name]; ok {
+			return fmt.Errorf("--%s applies to the whole session: give it before '%s' instead", flag, outer)
+		}
+	}
+	return nil
+}
+
+// newApp builds the skycli command tree on top of reg. The shell builds a fresh tree per line, since parsed flag
+// values stay attached to their commands.
+func newApp(reg *registry.Registry) *cli.Command {
+	return &cli.Command{
+		Name:    "skycli",
+		Usage:   "A companion CLI tool for your Bluesky feed ecosystem",
+		Version: version,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Answer yes to confirmation prompts before destructive actions",
+			},
+			&cli.BoolFlag{
+				Name:    "no-input",
+				Usage:   "Never prompt; destructive actions fail unless --yes is also given",
+				Sources: cli.EnvVars("SKYCLI_NO_INPUT"),
+			},
+			&cli.StringFlag{
+				Name:    "log-level",
+				Usage:   "Minimum log level: debug, info, warn, or error",
+				Value:   "info",
+				Sources: cli.EnvVars("SKYCLI_LOG_LEVEL"),
+			},
+			&cli.StringFlag{
+				Name:    "log-format",
+				Usage:   "Log output format: text, json, or logfmt",
+				Value:   "text",
+				Sources: cli.EnvVars("SKYCLI_LOG_FORMAT"),
+			},
+			&cli.StringFlag{
+				Name:    "log-file",
+				Usage:   "Append logs to this file instead of stderr",
+				Sources: cli.EnvVars("SKYCLI_LOG_FILE"),
+			},
+			&cli.BoolFlag{
+				Name:  "verbose",
+				Usage: "Shorthand for --log-level debug; shows batching and cache details",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Per-request API timeout applied to every endpoint (e.g. 45s); overrides configured timeouts",
+			},
+			&cli.StringFlag{
+				Name:  "proxy",
+				Usage: "Route API traffic through a proxy (e.g. socks5://127.0.0.1:9050 for Tor); overrides the configured proxy",
+			},
+			&cli.BoolFlag{
+				Name:  "debug-http",
+				Usage: "Log each API request with status, duration, and rate-limit headers (tokens redacted)",
+			},
+			&cli.StringFlag{
+				Name:  "debug-http-file",
+				Usage: "Append sanitized request and response bodies to this trace file (implies --debug-http)",
+			},
+			&cli.StringFlag{
+				Name:    "dates",
+				Usage:   "How tables and exports show post dates: relative, iso, or local (default: relative in tables, iso in exports)",
+				Sources: cli.EnvVars("SKYCLI_DATES"),
+			},
+			&cli.StringFlag{
+				Name:    "list-format",
+				Usage:   "How follower and feed listings are laid out: table, or lines (one labeled line per field, for screen readers and narrow terminals)",
+				Value:   "table",
+				Sources: cli.EnvVars("SKYCLI_LIST_FORMAT"),
+			},
+			&cli.BoolFlag{
+				Name:    "wide",
+				Usage:   "Print every table column at full width instead of fitting tables to the terminal",
+				Sources: cli.EnvVars("SKYCLI_WIDE"),
+			},
+			&cli.StringFlag{
+				Name:    "error-format",
+				Usage:   "How a failed command reports its error on stderr: text, or json with an error code for scripts",
+				Value:   "text",
+				Sources: cli.EnvVars("SKYCLI_ERROR_FORMAT"),
+			},
+			&cli.StringFlag{
+				Name:    "account",
+				Usage:   "Run as this signed-in account (handle or DID) instead of the default; see 'skycli account list'",
+				Sources: cli.EnvVars("SKYCLI_ACCOUNT"),
+			},
+			&cli.StringFlag{
+				Name:    "timezone",
+				Usage:   "IANA time zone (e.g. Europe/Berlin) for reading date flags and showing local dates; overrides the configured timezone",
+				Sources: cli.EnvVars("SKYCLI_TIMEZONE"),
+			},
+		},
+		Before: applyGlobalFlags(reg),
+		Commands: []*cli.Command{
+			SetupCommand(), LoginCommand(), AccountCommand(), StatusCommand(),
+			FetchCommand(), SearchCommand(), ListCommand(), FeedsCommand(), FeedCommand(), GraphCommand(), PrefsCommand(), ViewCommand(), ReadCommand(), PostCommand(), RecordCommand(), ExportCommand(),
+			FollowersCommand(), FollowingCommand(), SnapshotsCommand(), ArchiveCommand(), ImportCommand(), AnalyticsCommand(), SyncCommand(), TeamCommand(), CacheCommand(),
+			NotificationsCommand(), MuteCommand(), ModerationCommand(), UndoCommand(), DaemonCommand(), StreamCommand(),
+			PlanCommand(), BatchCommand(), ShellCommand(), DevCommand(), DocsCommand(), TrashCommand(), TagCommand(),
+		},
+	}
+}
+
+// nestedSetup is held while a nested run parses its line: cli sets up every command tree against shared package
+// state such as its help flag, so parallel batch jobs may only overlap once their actions start
+var nestedSetup sync.Mutex
+
+// runArgs runs a command line without the program name on a fresh command tree sharing reg, returning errors
+// instead of exiting. The line keeps the outer command's session settings, and outer names that command in the
+// error for a line that tries to change them.
+func runArgs(ctx context.Context, reg *registry.Registry, args []string, outer string) error {
+	app := newApp(reg)
+	if err := checkSessionFlags(app, args, outer); err != nil {
+		return err
+	}
+	app.Before = keepGlobalFlags(reg)
+
+	nestedSetup.Lock()
+	release := sync.OnceFunc(nestedSetup.Unlock)
+	defer release()
+	releaseBeforeActions(app, release)
+	return runApp(ctx, app, args)
+}
+
+// releaseBeforeActions makes every action in the tree under cmd call release before it starts
+func releaseBeforeActions(cmd *cli.Command, release func()) {
+	if action := cmd.Action; action != nil {
+		cmd.Action = func(ctx context.Context, cmd *cli.Command) error {
+			release()
+			return action(ctx, cmd)
+		}
+	}
+	for _, sub := range cmd.Commands {
+		releaseBeforeActions(sub, release)
+	}
+}
+
+// runApp runs app with args from inside another command's action. The app gets a context canceled with ctx but
+// without its values, since cli attaches an app run with a command in its context beneath that command.
+func runApp(ctx context.Context, app *cli.Command, args []string) error {
+	detached, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	app.ExitErrHandler = func(context.Context, *cli.Command, error) {}
+	return app.Run(detached, append([]string{app.Name}, args...))
+}
+
 func main() {
+	restoreConsole := ui.EnableANSI()
+	defer restoreConsole()
+
 	ctx := context.Background()
 	reg := registry.Get()
 
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Fatalf("Failed to load config %v", err)
+	}
+	shutdownTelemetry, err := telemetry.Setup(ctx, cfg.Telemetry, version)
+	if err != nil {
+		logger.Fatalf("Failed to initialize telemetry %v", err)
+	}
+	defer func() {
+		if err := shutdownTelemetry(ctx); err != nil {
+			logger.Warn("failed to flush traces", "error", err)
+		}
+	}()
+
 	if err := reg.Init(ctx); err != nil {
 		logger.Fatalf("Failed to initialize registry %v", err)
 	}
 	defer reg.Close()
+	defer utils.CloseLogFile()
 
 	cli.HelpPrinter = ui.StyledHelpPrinter
 	cli.RootCommandHelpTemplate = ui.RootCommandHelpTemplate
 	cli.CommandHelpTemplate = ui.CommandHelpTemplate
 	cli.SubcommandHelpTemplate = ui.SubcommandHelpTemplate
 
-	app := &cli.Command{
-		Name:    "skycli",
-		Usage:   "A companion CLI tool for your Bluesky feed ecosystem",
-		Version: "0.1.0",
-		Commands: []*cli.Command{
-			SetupCommand(), LoginCommand(), StatusCommand(),
-			FetchCommand(), SearchCommand(), ListCommand(), ViewCommand(), ExportCommand(),
-			FollowersCommand(), FollowingCommand(),
-		},
-	}
-
+	app := newApp(reg)
 	if err := app.Run(ctx, os.Args); err != nil {
-		logger.Fatalf("Command failed with error: %v", err)
+		if app.String("error-format") == "json" {
+			json.NewEncoder(os.Stderr).Encode(newCommandError(err))
+			os.Exit(1)
+		}
+		logger.Fatalf("Command failed with error: %s", describeError(err))
 	}
 }
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// reportReasons maps --reason values to com.atproto.moderation.defs reason tokens
+var reportReasons = map[string]string{
+	"spam":       "com.atproto.moderation.defs#reasonSpam",
+	"violation":  "com.atproto.moderation.defs#reasonViolation",
+	"misleading": "com.atproto.moderation.defs#reasonMisleading",
+	"sexual":     "com.atproto.moderation.defs#reasonSexual",
+	"rude":       "com.atproto.moderation.defs#reasonRude",
+	"other":      "com.atproto.moderation.defs#reasonOther",
+	"appeal":     "com.atproto.moderation.defs#reasonAppeal",
+}
+
+// filedReport is a report in the moderation log as shown to the user
+type filedReport struct {
+	ReportID    int64  `json:"reportId"`
+	CreatedAt   string `json:"createdAt"`
+	SubjectType string `json:"subjectType"`
+	Subject     string `json:"subject"`
+	Reason      string `json:"reason"`
+	Details     string `json:"details,omitempty"`
+	Service     string `json:"service"`
+}
+
+// ModerationCommand returns the moderation command with its subcommands
+func ModerationCommand() *cli.Command {
+	reasons := make([]string, 0, len(reportReasons))
+	for reason := range reportReasons {
+		reasons = append(reasons, reason)
+	}
+	slices.Sort(reasons)
+
+	return &cli.Command{
+		Name:  "moderation",
+		Usage: "File and review moderation reports",
+		Commands: []*cli.Command{
+			{
+				Name:      "report",
+				Usage:     "Report a post or account to a moderation service",
+				UsageText: "File a report about a post (AT URI or bsky.app URL) or an account (DID, handle, or profile URL). Filed reports are kept in a local log; see 'moderation log'.",
+				ArgsUsage: "<uri|did|handle>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "reason",
+						Aliases:  []string{"r"},
+						Usage:    "Report reason: " + strings.Join(reasons, ", "),
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "details",
+						Aliases: []string{"d"},
+						Usage:   "Additional context for moderators",
+					},
+					&cli.StringFlag{
+						Name:  "service",
+						Usage: "DID of the moderation service to report to",
+						Value: bsky.BlueskyModerationDID,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output the filed report as JSON",
+					},
+				},
+				Action: withRegistry(ModerationReportAction),
+			},
+			{
+				Name:      "log",
+				Usage:     "List reports you have filed",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum reports to show (0 = all)",
+						Value:   20,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output as JSON",
+					},
+				},
+				Action: withRegistry(ModerationLogAction),
+			},
+			{
+				Name:      "self-check",
+				Usage:     "Check your profile and recent posts for moderation labels",
+				UsageText: "List labels applied to your profile and recent posts by Bluesky and the labelers you subscribe to. Labels not seen by an earlier check are flagged as new; 'skycli daemon' runs this check periodically.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "posts",
+						Usage: "Recent posts to check",
+						Value: 100,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output as JSON",
+					},
+				},
+				Action: withRegistry(ModerationSelfCheckAction),
+			},
+		},
+	}
+}
+
+// ModerationReportAction files a moderation report and records it in the local report log
+func ModerationReportAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("post URI, DID, or handle required")
+	}
+
+	reasonType, ok := reportReasons[strings.ToLower(cmd.String("reason"))]
+	if !ok {
+		return fmt.Errorf("unknown --reason %q", cmd.String("reason"))
+	}
+
+	reporter, err := reg.GetReporter()
+	if err != nil {
+		return fmt.Errorf("failed to get reporter: %w", err)
+	}
+
+	reports, err := reg.GetReportRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get report log: %w", err)
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	subjectType, subject, ref, err := resolveReportSubject(ctx, reg, profiles, cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	if ok, err := confirm(cmd, fmt.Sprintf("Report %s %s for %s?", subjectType, subject, cmd.String("reason"))); !ok {
+		return err
+	}
+
+	service := cmd.String("service")
+	response, err := reporter.CreateReport(ctx, service, reasonType, cmd.String("details"), ref)
+	if err != nil {
+		return fmt.Errorf("failed to file report: %w", err)
+	}
+
+	model := &bsky.ReportModel{
+		ReportID:    response.ID,
+		SubjectType: subjectType,
+		Subject:     subject,
+		ReasonType:  reasonType,
+		Details:     cmd.String("details"),
+		Service:     service,
+	}
+	if err := reports.Record(ctx, model); err != nil {
+		logger.Warn("Failed to record report in local log", "report", response.ID, "error", err)
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(toFiledReport(model))
+	}
+
+	ui.Successln("Filed report #%d about %s %s", response.ID, subjectType, subject)
+	return nil
+}
+
+// ModerationLogAction lists reports filed from this machine, newest first
+func ModerationLogAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	reports, err := reg.GetReportRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get report log: %w", err)
+	}
+
+	models, err := reports.List(ctx, cmd.Int("limit"))
+	if err != nil {
+		return fmt.Errorf("failed to read report log: %w", err)
+	}
+
+	filed := make([]filedReport, len(models))
+	for i, model := range models {
+		filed[i] = toFiledReport(model)
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(filed)
+	}
+
+	displayFiledReports(filed)
+	return nil
+}
+
+// resolveReportSubject identifies what input refers to and builds the createReport subject for it.
+// Posts become strong references (their CID is looked up); DIDs, handles, and profile URLs become account references.
+func resolveReportSubject(ctx context.Context, reg *registry.Registry, profiles bsky.ProfileFetcher, input string) (subjectType, subject string, ref map[string]any, err error) {
+	target, err := aturi.Parse(input)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid report subject: %w", err)
+	}
+
+	if target.Collection == aturi.CollectionPost {
+		postURI := target.String()
+
+		service, err := reg.GetService()
+		if err != nil {
+			return "", "", nil, fmt.Errorf("failed to get service: %w", err)
+		}
+
+		response, err := service.GetPosts(ctx, []string{postURI})
+		if err != nil {
+			return "", "", nil, fmt.Errorf("failed to fetch post: %w", err)
+		}
+		if len(response.Posts) == 0 || response.Posts[0].Post == nil {
+			return "", "", nil, fmt.Errorf("post not found: %s", postURI)
+		}
+
+		post := response.Posts[0].Post
+		return "post", post.Uri, bsky.RecordSubject(post.Uri, post.Cid), nil
+	}
+
+	if target.IsRecord() {
+		return "", "", nil, fmt.Errorf("invalid report subject %q: pass a post URI, DID, handle, or profile URL", input)
+	}
+
+	actor, err := resolveActor(ctx, profiles, "report subject", target.Authority)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return "account", actor, bsky.AccountSubject(actor), nil
+}
+
+// toFiledReport converts a logged report for display, shortening the reason token to its --reason name
+func toFiledReport(model *bsky.ReportModel) filedReport {
+	reason := model.ReasonType
+	for name, token := range reportReasons {
+		if token == model.ReasonType {
+			reason = name
+		}
+	}
+
+	return filedReport{
+		ReportID:    model.ReportID,
+		CreatedAt:   model.CreatedAt().In(ui.Location()).Format("2006-01-02 15:04"),
+		SubjectType: model.SubjectType,
+		Subject:     model.Subject,
+		Reason:      reason,
+		Details:     model.Details,
+		Service:     model.Service,
+	}
+}
+
+// displayFiledReports renders the report log as a table
+func displayFiledReports(reports []filedReport) {
+	if len(reports) == 0 {
+		ui.Infoln("No reports filed")
+		return
+	}
+
+	ui.Titleln("Filed reports (%d)", len(reports))
+	fmt.Println()
+
+	rows := make([][]string, len(reports))
+	for i, report := range reports {
+		details := report.Details
+		if len(details) > 40 {
+			details = details[:40] + "..."
+		}
+		rows[i] = []string{
+			report.CreatedAt,
+			fmt.Sprintf("#%d", report.ReportID),
+			report.Reason,
+			report.SubjectType,
+			report.Subject,
+			details,
+		}
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("When", "Report", "Reason", "Type", "Subject", "Details").Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	fmt.Println(re.NewStyle().Render(t.String()))
+	fmt.Println()
+}
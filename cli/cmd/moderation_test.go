@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestModerationReportAction(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	reports, err := bsky.NewReportRepository()
+	if err != nil {
+		t.Fatalf("NewReportRepository failed: %v", err)
+	}
+	if err := reports.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	t.Cleanup(func() { reports.Close() })
+
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me"}
+	reg := registry.New(registry.Dependencies{
+		ProfileFetcher: graph,
+		Reporter:       graph,
+		ReportRepo:     reports,
+	})
+
+	tests := []struct {
+		name    string
+		args    []string
+		subject string
+		wantErr string
+	}{
+		{name: "DID", args: []string{"--reason", "spam", "did:plc:spammer"}, subject: "did:plc:spammer"},
+		{name: "ProfileURL", args: []string{"--reason", "rude", "--details", "harassment", "https://bsky.app/profile/troll.bsky.social"}, subject: "troll.bsky.social"},
+		{name: "UnknownReason", args: []string{"--reason", "boring", "did:plc:x"}, wantErr: "unknown --reason"},
+		{name: "InvalidSubject", args: []string{"--reason", "spam", "https://bsky.app/profile/x/lists/y"}, wantErr: "invalid report subject"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := ModerationCommand()
+			parent.Reader = strings.NewReader("y\n")
+
+			before := len(graph.reports)
+			_, err := runSubcommand(t, parent, "report", ModerationReportAction, reg, tt.args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ModerationReportAction failed: %v", err)
+			}
+
+			if len(graph.reports) != before+1 {
+				t.Fatalf("expected one report filed, got %d", len(graph.reports)-before)
+			}
+			subject := graph.reports[len(graph.reports)-1]
+			if subject["$type"] != "com.atproto.admin.defs#repoRef" || subject["did"] != tt.subject {
+				t.Errorf("unexpected subject %v", subject)
+			}
+		})
+	}
+
+	logged, err := reports.List(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(logged) != 2 || logged[0].Details != "harassment" || logged[0].ReasonType != reportReasons["rude"] {
+		t.Errorf("unexpected report log %+v", logged)
+	}
+}
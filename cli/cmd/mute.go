@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// MuteCommand returns the mute command with its subcommands
+func MuteCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "mute",
+		Usage: "Silence notifications from noisy threads",
+		Commands: []*cli.Command{
+			{
+				Name:      "thread",
+				Usage:     "Mute notifications from a thread",
+				UsageText: "Mute the thread containing a post (AT URI or bsky.app URL). Replies resolve to their thread root.",
+				ArgsUsage: "<post-uri-or-url>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "unmute",
+						Usage: "Unmute the thread instead",
+					},
+				},
+				Action: withRegistry(MuteThreadAction),
+			},
+		},
+	}
+}
+
+// MuteThreadAction mutes (or with --unmute, unmutes) the thread containing a post
+func MuteThreadAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("post URI or URL required")
+	}
+
+	service, err := reg.GetService()
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+
+	if !service.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	writer, err := reg.GetGraphWriter()
+	if err != nil {
+		return fmt.Errorf("failed to get graph writer: %w", err)
+	}
+
+	ref, err := aturi.ParsePost(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	postURI := ref.String()
+
+	response, err := service.GetPosts(ctx, []string{postURI})
+	if err != nil {
+		return fmt.Errorf("failed to fetch post: %w", err)
+	}
+	if len(response.Posts) == 0 || response.Posts[0].Post == nil {
+		return fmt.Errorf("post not found: %s", postURI)
+	}
+
+	root := threadRoot(response.Posts[0].Post)
+	logger.Debug("Resolved thread root", "post", postURI, "root", root)
+
+	if cmd.Bool("unmute") {
+		if err := writer.UnmuteThread(ctx, root); err != nil {
+			return fmt.Errorf("failed to unmute thread: %w", err)
+		}
+		ui.Successln("Unmuted thread %s", root)
+		return nil
+	}
+
+	if err := writer.MuteThread(ctx, root); err != nil {
+		return fmt.Errorf("failed to mute thread: %w", err)
+	}
+	ui.Successln("Muted thread %s", root)
+	return nil
+}
+
+// threadRoot returns the URI of the first post in post's thread: the reply root for replies, otherwise the post itself
+func threadRoot(post *bsky.PostView) string {
+	record, ok := post.Record.(map[string]any)
+	if !ok {
+		return post.Uri
+	}
+	reply, ok := record["reply"].(map[string]any)
+	if !ok {
+		return post.Uri
+	}
+	root, ok := reply["root"].(map[string]any)
+	if !ok {
+		return post.Uri
+	}
+	if uri, ok := root["uri"].(string); ok && uri != "" {
+		return uri
+	}
+	return post.Uri
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestThreadRoot(t *testing.T) {
+	const post = "at://did:plc:a/app.bsky.feed.post/reply"
+	tests := []struct {
+		name   string
+		record any
+		want   string
+	}{
+		{"TopLevel", map[string]any{"text": "hello"}, post},
+		{
+			name: "Reply",
+			record: map[string]any{
+				"text": "replying",
+				"reply": map[string]any{
+					"root":   map[string]any{"uri": "at://did:plc:b/app.bsky.feed.post/root"},
+					"parent": map[string]any{"uri": "at://did:plc:c/app.bsky.feed.post/parent"},
+				},
+			},
+			want: "at://did:plc:b/app.bsky.feed.post/root",
+		},
+		{"MissingRecord", nil, post},
+		{"MalformedReply", map[string]any{"reply": "nope"}, post},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := threadRoot(&bsky.PostView{Uri: post, Record: tt.record}); got != tt.want {
+				t.Errorf("threadRoot = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
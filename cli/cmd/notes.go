@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// noteCellWidth caps how much of a note a table cell shows
+const noteCellWidth = 40
+
+// noteEntry is one account's note as listed by followers note --json
+type noteEntry struct {
+	DID       string    `json:"did"`
+	Handle    string    `json:"handle,omitempty"`
+	Note      string    `json:"note"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// followersNoteCommand returns the followers note subcommand
+func followersNoteCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "note",
+		Usage: "Keep private notes on followers and other accounts",
+		UsageText: "With text, replaces the account's note, or adds a line to it with --append. With only an account, shows its note. " +
+			"With no arguments, lists every note. Notes stay in the local database and appear in 'followers list', 'followers export', and 'view profile'.",
+		ArgsUsage: "[@handle|DID] [text...]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "append",
+				Aliases: []string{"a"},
+				Usage:   "Add the text as a new line instead of replacing the note",
+			},
+			&cli.BoolFlag{
+				Name:  "clear",
+				Usage: "Remove the account's note",
+			},
+			&cli.BoolFlag{
+				Name:    "json",
+				Aliases: []string{"j"},
+				Usage:   "Output notes as JSON",
+			},
+		},
+		Action: withRegistry(FollowersNoteAction),
+	}
+}
+
+// FollowersNoteAction sets, appends to, shows, clears, or lists notes on accounts
+func FollowersNoteAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	noteRepo, err := reg.GetNoteRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get note repository: %w", err)
+	}
+
+	if cmd.Args().Len() == 0 {
+		if cmd.Bool("clear") || cmd.Bool("append") {
+			return fmt.Errorf("account required")
+		}
+		return listNotes(ctx, noteRepo, cmd.Bool("json"))
+	}
+
+	input := cmd.Args().First()
+	did, err := resolveAuthorDID(ctx, reg, "account", input)
+	if err != nil {
+		return err
+	}
+	handle := ""
+	if actor, _ := parseActorArg("account", input); actor != did {
+		handle = actor
+	}
+	name := input
+	if handle != "" {
+		name = "@" + handle
+	}
+
+	text := strings.Join(cmd.Args().Tail(), " ")
+	switch {
+	case cmd.Bool("clear"):
+		if text != "" {
+			return fmt.Errorf("--clear doesn't take note text")
+		}
+		if err := noteRepo.Delete(ctx, did); err != nil {
+			if errors.Is(err, bsky.ErrNotFound) {
+				return fmt.Errorf("no note on %s: %w", name, err)
+			}
+			return fmt.Errorf("failed to clear note: %w", err)
+		}
+		ui.Successln("Cleared the note on %s", name)
+		return nil
+	case text == "" && cmd.Bool("append"):
+		return fmt.Errorf("note text required with --append")
+	case text == "":
+		return showNote(ctx, noteRepo, did, name, cmd.Bool("json"))
+	case cmd.Bool("append"):
+		err = noteRepo.Append(ctx, did, handle, text)
+	default:
+		err = noteRepo.Set(ctx, did, handle, text)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save note: %w", err)
+	}
+	ui.Successln("Saved note on %s", name)
+	return nil
+}
+
+// showNote prints the note on one account
+func showNote(ctx context.Context, noteRepo *bsky.NoteRepository, did, name string, asJSON bool) error {
+	note, err := noteRepo.Get(ctx, did)
+	if errors.Is(err, bsky.ErrNotFound) {
+		if asJSON {
+			return ui.DisplayJSON(nil)
+		}
+		ui.Infoln("No note on %s.", name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read note: %w", err)
+	}
+
+	if asJSON {
+		return ui.DisplayJSON(noteEntry{DID: note.DID, Handle: note.Handle, Note: note.Note, UpdatedAt: note.UpdatedAt})
+	}
+	ui.Titleln("Note on %s", name)
+	printNote(note.Note)
+	ui.Infoln("  Updated: %s", ui.FormatDate(note.UpdatedAt, ui.DatesLocal))
+	return nil
+}
+
+// listNotes prints every note, most recently updated first
+func listNotes(ctx context.Context, noteRepo *bsky.NoteRepository, asJSON bool) error {
+	notes, err := noteRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	entries := make([]noteEntry, len(notes))
+	for i, note := range notes {
+		entries[i] = noteEntry{DID: note.DID, Handle: note.Handle, Note: note.Note, UpdatedAt: note.UpdatedAt}
+	}
+	if asJSON {
+		return ui.DisplayJSON(entries)
+	}
+	if len(entries) == 0 {
+		ui.Infoln("No notes yet. Add one with 'skycli followers note @handle \"text\"'.")
+		return nil
+	}
+
+	rows := make([][]string, len(entries))
+	for i, entry := range entries {
+		account := entry.DID
+		if entry.Handle != "" {
+			account = "@" + entry.Handle
+		}
+		rows[i] = []string{account, noteCell(entry.Note), ui.FormatDate(entry.UpdatedAt, ui.DatesLocal)}
+	}
+	ui.Titleln("Notes")
+	fmt.Println(stripedTable([]string{"Account", "Note", "Updated"}, rows))
+	ui.Successln("Total: %d note(s)", len(entries))
+	return nil
+}
+
+// printNote prints a note's lines indented under a heading
+func printNote(note string) {
+	for _, line := range strings.Split(note, "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+// noteCell fits a note on one table line, joining its lines and shortening it to noteCellWidth
+func noteCell(note string) string {
+	text := strings.Join(strings.Fields(note), " ")
+	if runes := []rune(text); len(runes) > noteCellWidth {
+		text = string(runes[:noteCellWidth-3]) + "..."
+	}
+	return text
+}
+
+// noteRows reports whether any follower carries a note
+func noteRows(followers []followerInfo) bool {
+	return slices.ContainsFunc(followers, func(f followerInfo) bool { return f.Note != "" })
+}
+
+// addFollowerNotes fills in each follower's local note. Notes are optional, so a missing repository is skipped
+// and a failed read only warns.
+func addFollowerNotes(ctx context.Context, reg *registry.Registry, followers []followerInfo) {
+	noteRepo, err := reg.GetNoteRepo()
+	if err != nil {
+		logger.Debug("Notes unavailable", "error", err)
+		return
+	}
+
+	notes, err := noteRepo.List(ctx)
+	if err != nil {
+		logger.Warn("Failed to read notes", "error", err)
+		return
+	}
+	if len(notes) == 0 {
+		return
+	}
+
+	byDID := make(map[string]string, len(notes))
+	for _, note := range notes {
+		byDID[note.DID] = note.Note
+	}
+	for i := range followers {
+		followers[i].Note = byDID[followers[i].Profile.Did]
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestFollowersNote(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	noteRepo, err := bsky.NewNoteRepository()
+	if err != nil {
+		t.Fatalf("NewNoteRepository failed: %v", err)
+	}
+	t.Cleanup(func() { noteRepo.Close() })
+	if err := noteRepo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 10, followers: testProfiles("did:plc:a", "did:plc:b")}
+	reg := registry.New(registry.Dependencies{
+		FollowerFetcher: graph,
+		ProfileFetcher:  graph,
+		RateCache:       memoryRateCache{},
+		NoteRepo:        noteRepo,
+	})
+
+	if _, err := runSubcommand(t, FollowersCommand(), "note", FollowersNoteAction, reg, "did:plc:b", "met", "at", "conf"); err != nil {
+		t.Fatalf("FollowersNoteAction failed: %v", err)
+	}
+	if _, err := runSubcommand(t, FollowersCommand(), "note", FollowersNoteAction, reg, "--append", "did:plc:b", "speaker, talk on feeds"); err != nil {
+		t.Fatalf("FollowersNoteAction failed: %v", err)
+	}
+
+	note, err := noteRepo.Get(ctx, "did:plc:b")
+	if err != nil || note.Note != "met at conf\nspeaker, talk on feeds" {
+		t.Fatalf("unexpected note %+v (%v)", note, err)
+	}
+
+	out, err := runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, reg, "--output", "json")
+	if err != nil {
+		t.Fatalf("ListFollowersAction failed: %v", err)
+	}
+	infos := decodeFollowers(t, out)
+	if len(infos) != 2 || infos[0].Note != "" || infos[1].Note != note.Note {
+		t.Errorf("expected the note on the second follower only, got %+v", infos)
+	}
+
+	out, err = runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, reg, "--output", "csv")
+	if err != nil {
+		t.Fatalf("ListFollowersAction failed: %v", err)
+	}
+	if header, _, _ := strings.Cut(out, "\n"); !strings.HasSuffix(header, ",note") {
+		t.Errorf("expected a note column, got header %q", header)
+	}
+
+	if _, err := runSubcommand(t, FollowersCommand(), "note", FollowersNoteAction, reg, "--clear", "did:plc:b"); err != nil {
+		t.Fatalf("clearing the note failed: %v", err)
+	}
+	_, err = runSubcommand(t, FollowersCommand(), "note", FollowersNoteAction, reg, "--clear", "did:plc:b")
+	if !errors.Is(err, bsky.ErrNotFound) {
+		t.Errorf("clearing a missing note: got %v, want ErrNotFound", err)
+	}
+}
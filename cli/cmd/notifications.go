@@ -0,0 +1,272 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// notificationLabels names each notification reason as (singular, plural) for summaries
+var notificationLabels = map[string][2]string{
+	"like":    {"like", "likes"},
+	"repost":  {"repost", "reposts"},
+	"follow":  {"new follower", "new followers"},
+	"reply":   {"reply", "replies"},
+	"mention": {"mention", "mentions"},
+	"quote":   {"quote", "quotes"},
+}
+
+// notificationActor is one account's share of a notification group
+type notificationActor struct {
+	Did    string `json:"did"`
+	Handle string `json:"handle"`
+	Count  int    `json:"count"`
+}
+
+// notificationGroup totals the notifications of one reason
+type notificationGroup struct {
+	Reason string              `json:"reason"`
+	Count  int                 `json:"count"`
+	Actors int                 `json:"actors"`
+	Unread int                 `json:"unread"`
+	Top    []notificationActor `json:"top"`
+}
+
+// notificationSummary is the notifications summary report
+type notificationSummary struct {
+	Since  time.Time           `json:"since"`
+	Total  int                 `json:"total"`
+	Unread int                 `json:"unread"`
+	Groups []notificationGroup `json:"groups"`
+}
+
+// NotificationsCommand returns the notifications command with its subcommands
+func NotificationsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "notifications",
+		Usage: "Summarize your Bluesky notifications",
+		Commands: []*cli.Command{
+			{
+				Name:      "summary",
+				Usage:     "Group recent notifications by type and account",
+				UsageText: "Summarize notifications as counts per type with the most active accounts, e.g. \"5 likes from @x, 3 new followers, 2 replies\".",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only notifications since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+						Value: "24h",
+					},
+					&cli.IntFlag{
+						Name:  "top",
+						Usage: "Accounts listed per notification type",
+						Value: 3,
+					},
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum notifications to fetch (0 = no limit)",
+						Value:   1000,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output as JSON",
+					},
+				},
+				Action: withRegistry(NotificationsSummaryAction),
+			},
+		},
+	}
+}
+
+// NotificationsSummaryAction fetches notifications back to --since and prints them grouped by type and account
+func NotificationsSummaryAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fetcher, err := reg.GetNotificationFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get notification fetcher: %w", err)
+	}
+
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	now := userNow()
+	since, err := parseSince(cmd.String("since"), now)
+	if err != nil {
+		return err
+	}
+
+	notifications, err := notificationsSince(ctx, fetcher, since, cmd.Int("limit"))
+	if err != nil {
+		return err
+	}
+
+	summary := summarizeNotifications(notifications, cmd.Int("top"))
+	summary.Since = since
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(summary)
+	}
+
+	displayNotificationSummary(summary)
+	return nil
+}
+
+// notificationsSince pages through notifications until one is older than since or limit are collected
+func notificationsSince(ctx context.Context, fetcher bsky.NotificationFetcher, since time.Time, limit int) ([]bsky.Notification, error) {
+	paginator := bsky.NewPaginator(bsky.NotificationPages(fetcher), bsky.PaginatorOptions{
+		MaxItems: limit,
+		Progress: logPageProgress("notifications"),
+	})
+
+	var notifications []bsky.Notification
+	for paginator.HasNext() {
+		page, err := paginator.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch notifications: %w", err)
+		}
+
+		for _, notification := range page {
+			indexedAt, err := time.Parse(time.RFC3339, notification.IndexedAt)
+			if err != nil {
+				logger.Warn("Failed to parse indexedAt", "uri", notification.Uri, "error", err)
+				continue
+			}
+			if indexedAt.Before(since) {
+				return notifications, nil
+			}
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications, nil
+}
+
+// summarizeNotifications groups notifications by reason, largest group first, listing up to top accounts per group
+func summarizeNotifications(notifications []bsky.Notification, top int) notificationSummary {
+	summary := notificationSummary{Total: len(notifications), Groups: []notificationGroup{}}
+
+	groups := make(map[string]*notificationGroup)
+	actors := make(map[string]map[string]*notificationActor)
+	for _, notification := range notifications {
+		group, ok := groups[notification.Reason]
+		if !ok {
+			group = &notificationGroup{Reason: notification.Reason}
+			groups[notification.Reason] = group
+			actors[notification.Reason] = make(map[string]*notificationActor)
+		}
+		group.Count++
+		if !notification.IsRead {
+			group.Unread++
+			summary.Unread++
+		}
+
+		actor, ok := actors[notification.Reason][notification.Author.Did]
+		if !ok {
+			actor = &notificationActor{Did: notification.Author.Did, Handle: notification.Author.Handle}
+			actors[notification.Reason][notification.Author.Did] = actor
+		}
+		actor.Count++
+	}
+
+	for reason, group := range groups {
+		ranked := make([]notificationActor, 0, len(actors[reason]))
+		for _, actor := range actors[reason] {
+			ranked = append(ranked, *actor)
+		}
+		slices.SortFunc(ranked, func(a, b notificationActor) int {
+			return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Handle, b.Handle))
+		})
+
+		group.Actors = len(ranked)
+		group.Top = ranked[:min(max(top, 0), len(ranked))]
+		summary.Groups = append(summary.Groups, *group)
+	}
+
+	slices.SortFunc(summary.Groups, func(a, b notificationGroup) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Reason, b.Reason))
+	})
+	return summary
+}
+
+// describeNotificationGroup phrases a group as e.g. "5 likes from @x" or "3 new followers"
+func describeNotificationGroup(group notificationGroup) string {
+	label, ok := notificationLabels[group.Reason]
+	if !ok {
+		label = [2]string{group.Reason, group.Reason}
+	}
+
+	noun := label[1]
+	if group.Count == 1 {
+		noun = label[0]
+	}
+	phrase := fmt.Sprintf("%d %s", group.Count, noun)
+
+	if group.Actors == 1 && len(group.Top) == 1 {
+		phrase += " from @" + group.Top[0].Handle
+	}
+	return phrase
+}
+
+// displayNotificationSummary prints a one-line digest followed by a per-type table
+func displayNotificationSummary(summary notificationSummary) {
+	if summary.Total == 0 {
+		ui.Infoln("No notifications since %s", summary.Since.In(ui.Location()).Format("2006-01-02 15:04"))
+		return
+	}
+
+	phrases := make([]string, len(summary.Groups))
+	for i, group := range summary.Groups {
+		phrases[i] = describeNotificationGroup(group)
+	}
+
+	ui.Titleln("Notifications since %s", summary.Since.In(ui.Location()).Format("2006-01-02 15:04"))
+	ui.Infoln("%s", strings.Join(phrases, ", "))
+	ui.Subtitleln("%d total, %d unread", summary.Total, summary.Unread)
+	fmt.Println()
+
+	rows := make([][]string, len(summary.Groups))
+	for i, group := range summary.Groups {
+		accounts := make([]string, len(group.Top))
+		for j, actor := range group.Top {
+			accounts[j] = fmt.Sprintf("@%s (%d)", actor.Handle, actor.Count)
+		}
+		if more := group.Actors - len(group.Top); more > 0 {
+			accounts = append(accounts, fmt.Sprintf("+%d more", more))
+		}
+		rows[i] = []string{
+			group.Reason,
+			fmt.Sprintf("%d", group.Count),
+			fmt.Sprintf("%d", group.Unread),
+			fmt.Sprintf("%d", group.Actors),
+			strings.Join(accounts, ", "),
+		}
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("Type", "Count", "Unread", "Accounts", "Most Active").Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	fmt.Println(re.NewStyle().Render(t.String()))
+	fmt.Println()
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func testNotification(reason, did string, at time.Time, read bool) bsky.Notification {
+	return bsky.Notification{
+		Uri:       "at://" + did + "/app.bsky.feed.like/" + reason,
+		Author:    bsky.ActorProfile{Did: did, Handle: did[len("did:plc:"):] + ".bsky.social"},
+		Reason:    reason,
+		IsRead:    read,
+		IndexedAt: at.UTC().Format(time.RFC3339),
+	}
+}
+
+func TestNotificationsSince(t *testing.T) {
+	now := time.Now()
+	graph := &fakeGraph{
+		pageSize: 2,
+		notifications: []bsky.Notification{
+			testNotification("like", "did:plc:a", now.Add(-time.Hour), false),
+			testNotification("follow", "did:plc:b", now.Add(-2*time.Hour), false),
+			testNotification("reply", "did:plc:c", now.Add(-3*time.Hour), true),
+			testNotification("like", "did:plc:d", now.Add(-48*time.Hour), true),
+			testNotification("like", "did:plc:e", now.Add(-72*time.Hour), true),
+		},
+	}
+
+	got, err := notificationsSince(context.Background(), graph, now.Add(-24*time.Hour), 0)
+	if err != nil {
+		t.Fatalf("notificationsSince failed: %v", err)
+	}
+	if len(got) != 3 || got[2].Author.Did != "did:plc:c" {
+		t.Errorf("expected the 3 notifications from the last day, got %+v", got)
+	}
+
+	limited, err := notificationsSince(context.Background(), graph, time.Time{}, 4)
+	if err != nil {
+		t.Fatalf("notificationsSince failed: %v", err)
+	}
+	if len(limited) != 4 {
+		t.Errorf("expected limit to cap notifications at 4, got %d", len(limited))
+	}
+}
+
+func TestSummarizeNotifications(t *testing.T) {
+	now := time.Now()
+	var notifications []bsky.Notification
+	for range 3 {
+		notifications = append(notifications, testNotification("like", "did:plc:x", now, false))
+	}
+	notifications = append(notifications,
+		testNotification("like", "did:plc:y", now, true),
+		testNotification("like", "did:plc:z", now, true),
+		testNotification("follow", "did:plc:a", now, false),
+		testNotification("follow", "did:plc:b", now, false),
+		testNotification("follow", "did:plc:c", now, false),
+		testNotification("reply", "did:plc:x", now, true),
+		testNotification("reply", "did:plc:x", now, true),
+	)
+
+	summary := summarizeNotifications(notifications, 2)
+	if summary.Total != 10 || summary.Unread != 6 {
+		t.Errorf("unexpected totals %+v", summary)
+	}
+	if len(summary.Groups) != 3 {
+		t.Fatalf("expected 3 groups, got %+v", summary.Groups)
+	}
+
+	likes := summary.Groups[0]
+	if likes.Reason != "like" || likes.Count != 5 || likes.Actors != 3 || likes.Unread != 3 {
+		t.Errorf("unexpected like group %+v", likes)
+	}
+	if len(likes.Top) != 2 || likes.Top[0].Did != "did:plc:x" || likes.Top[0].Count != 3 {
+		t.Errorf("expected @x to lead likes, got %+v", likes.Top)
+	}
+
+	want := []string{"5 likes", "3 new followers", "2 replies from @x.bsky.social"}
+	for i, group := range summary.Groups {
+		if got := describeNotificationGroup(group); got != want[i] {
+			t.Errorf("describeNotificationGroup(%s) = %q, want %q", group.Reason, got, want[i])
+		}
+	}
+
+	single := describeNotificationGroup(notificationGroup{Reason: "follow", Count: 1, Actors: 1, Top: []notificationActor{{Handle: "a.bsky.social", Count: 1}}})
+	if single != "1 new follower from @a.bsky.social" {
+		t.Errorf("unexpected singular phrase %q", single)
+	}
+}
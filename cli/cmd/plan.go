@@ -0,0 +1,454 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/plan"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// planInputs is what a planner knows about the account a command targets
+type planInputs struct {
+	Actor       string
+	Followers   int     // follower count from the actor's profile
+	Follows     int     // following count from the actor's profile
+	Known       int     // followers known locally, from stream tracking or the latest snapshot
+	ActivityHit float64 // share of known followers with a fresh last-post cache entry
+	RateHit     float64 // share of known followers with a fresh post rate cache entry
+}
+
+// planner lists the requests a parsed command would make
+type planner func(cmd *cli.Command, in planInputs) ([]plan.Step, []string)
+
+// planners estimate commands by their full name
+var planners = map[string]planner{
+	"followers list":        planFollowerList,
+	"followers export":      planFollowerList,
+	"followers stats":       planFollowerStats,
+	"followers follow-back": planFollowBack,
+	"followers ghosts":      planGhosts,
+	"following list":        planFollowingList,
+}
+
+// PlanCommand returns the plan command
+func PlanCommand() *cli.Command {
+	return &cli.Command{
+		Name:            "plan",
+		Usage:           "Estimate the API calls and time a command will take",
+		UsageText:       "Estimate how many API requests a command would make and how long they would take under the AppView rate limit (3000 requests per 5 minutes), without running it, e.g. 'skycli plan followers list --inactive 30'. Estimates use the current follower and following counts, which costs one profile lookup, and how much of the activity cache is fresh for followers known from stream tracking or the latest snapshot.",
+		ArgsUsage:       "<command...>",
+		SkipFlagParsing: true,
+		Action:          withRegistry(PlanAction),
+	}
+}
+
+// PlanAction parses the command to plan without running it and prints its estimated cost
+func PlanAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	args := cmd.Args().Slice()
+	if len(args) == 0 {
+		return fmt.Errorf("command to plan required (supported: %s)", strings.Join(slices.Sorted(maps.Keys(planners)), ", "))
+	}
+
+	planned, err := parsePlannedCommand(ctx, reg, args)
+	if err != nil {
+		return err
+	}
+	name := plannedName(planned)
+	estimateSteps, ok := planners[name]
+	if !ok {
+		return fmt.Errorf("no estimate for %q (supported: %s)", name, strings.Join(slices.Sorted(maps.Keys(planners)), ", "))
+	}
+
+	in, err := gatherPlanInputs(ctx, reg, planned)
+	if err != nil {
+		return err
+	}
+
+	steps, notes := estimateSteps(planned, in)
+	if planned.Bool("refresh") {
+		notes = append(notes, "--refresh bypasses the activity cache, so every lookup hits the API")
+	}
+	if in.Known == 0 {
+		notes = append(notes, "No followers are known locally, so cache hits can't be estimated and lookups assume a cold cache")
+	}
+	if users := plannedUsers(planned); len(users) > 1 {
+		notes = append(notes, fmt.Sprintf("Estimated for %s only; each of the other %d accounts costs about as much again", users[0], len(users)-1))
+	}
+	if note, ok := capNote(planned, in); ok {
+		notes = append(notes, note)
+	}
+	estimate := plan.DefaultLimits.Estimate("skycli "+strings.Join(args, " "), steps, notes...)
+	displayEstimate(estimate)
+	compareQuota(ctx, reg, estimate, time.Now())
+	return nil
+}
+
+// capNote warns when the planned command would page past the safety cap and stop with an error
+func capNote(cmd *cli.Command, in planInputs) (string, bool) {
+	if !hasFlag(cmd, "no-limit") {
+		return "", false
+	}
+	count, noun := in.Followers, "followers"
+	if strings.HasPrefix(plannedName(cmd), "following ") {
+		count, noun = in.Follows, "follows"
+	}
+	opts, err := graphPageOptions(cmd, noun)
+	if err != nil || opts.Cap == 0 || count <= opts.Cap {
+		return "", false
+	}
+	return fmt.Sprintf("%d %s is over the safety cap of %d, so the command will stop with an error; pass --limit or --no-limit", count, noun, opts.Cap), true
+}
+
+// compareQuota checks the estimate against the budget each API host last reported
+func compareQuota(ctx context.Context, reg *registry.Registry, estimate plan.Estimate, now time.Time) {
+	quotaRepo, err := reg.GetQuotaRepo()
+	if err != nil {
+		return
+	}
+	quotas, err := quotaRepo.List(ctx)
+	if err != nil {
+		logger.Debug("Failed to read API quota", "error", err)
+		return
+	}
+
+	for _, quota := range quotas {
+		remaining := quota.RemainingAt(now)
+		if estimate.Calls > remaining {
+			ui.Warningln("Only %d requests left on %s until %s; consider waiting for the reset",
+				remaining, quota.Host, quota.ResetAt.In(ui.Location()).Format(time.Kitchen))
+		} else {
+			ui.Infoln("Quota: %d requests left on %s", remaining, quota.Host)
+		}
+	}
+}
+
+// parsePlannedCommand parses args against a fresh command tree whose actions only record which command was chosen,
+// so flags are validated exactly as a real run would without making requests
+func parsePlannedCommand(ctx context.Context, reg *registry.Registry, args []string) (*cli.Command, error) {
+	var planned *cli.Command
+	record := func(ctx context.Context, cmd *cli.Command) error {
+		planned = cmd
+		return nil
+	}
+
+	app := newApp(reg)
+	var capture func(cmd *cli.Command)
+	capture = func(cmd *cli.Command) {
+		if cmd.Action != nil {
+			cmd.Action = record
+		}
+		for _, sub := range cmd.Commands {
+			capture(sub)
+		}
+	}
+	capture(app)
+
+	if err := runApp(ctx, app, args); err != nil {
+		return nil, err
+	}
+	if planned == nil || planned == app {
+		return nil, fmt.Errorf("no command to plan in %q", strings.Join(args, " "))
+	}
+	return planned, nil
+}
+
+// plannedName returns cmd's name below the root, e.g. "followers list"
+func plannedName(cmd *cli.Command) string {
+	var names []string
+	for _, c := range cmd.Lineage() {
+		if c.Root() == c {
+			break
+		}
+		names = append(names, c.Name)
+	}
+	slices.Reverse(names)
+	return strings.Join(names, " ")
+}
+
+// plannedUsers returns the --user values given to the planned command, which repeats the flag when it reports on
+// several accounts
+func plannedUsers(planned *cli.Command) []string {
+	if !slices.Contains(planned.FlagNames(), "user") {
+		return nil
+	}
+	switch users := planned.Value("user").(type) {
+	case []string:
+		return users
+	case string:
+		if users != "" {
+			return []string{users}
+		}
+	}
+	return nil
+}
+
+// gatherPlanInputs looks up the target account's counts and how much of the cache covers its followers
+func gatherPlanInputs(ctx context.Context, reg *registry.Registry, planned *cli.Command) (planInputs, error) {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return planInputs{}, fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+	if !fetcher.Authenticated() {
+		return planInputs{}, fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return planInputs{}, fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	in := planInputs{Actor: fetcher.GetDid()}
+	if users := plannedUsers(planned); len(users) > 0 {
+		if in.Actor, err = parseActorArg("--user", users[0]); err != nil {
+			return planInputs{}, err
+		}
+	}
+
+	profile, err := profiles.GetProfile(ctx, in.Actor)
+	if err != nil {
+		return planInputs{}, profileError("--user", in.Actor, err)
+	}
+	in.Actor = profile.Did
+	in.Followers, in.Follows = profile.FollowersCount, profile.FollowsCount
+
+	known := knownFollowers(ctx, reg, in.Actor)
+	in.Known = len(known)
+	if in.Known == 0 {
+		return in, nil
+	}
+
+	rateCache, err := reg.GetRateCache()
+	if err != nil {
+		logger.Debug("No rate cache for plan", "error", err)
+		return in, nil
+	}
+	if activities, err := rateCache.GetActivities(ctx, known); err == nil {
+		in.ActivityHit = float64(countFresh(activities)) / float64(in.Known)
+	}
+	if rates, err := rateCache.GetPostRates(ctx, known); err == nil {
+		in.RateHit = float64(countFresh(rates)) / float64(in.Known)
+	}
+	return in, nil
+}
+
+// knownFollowers returns the actor's followers as tracked from the stream, falling back to the latest snapshot
+func knownFollowers(ctx context.Context, reg *registry.Registry, actor string) []string {
+	if states, err := reg.GetFollowerStateRepo(); err == nil {
+		if dids, err := states.Followers(ctx, actor); err == nil && len(dids) > 0 {
+			return dids
+		}
+	}
+
+	snapshots, err := reg.GetSnapshotRepo()
+	if err != nil {
+		return nil
+	}
+	snapshot, err := snapshots.FindByUserAndType(ctx, actor, "followers")
+	if err != nil || snapshot == nil {
+		return nil
+	}
+	dids, err := snapshots.GetActorDids(ctx, snapshot.ID())
+	if err != nil {
+		return nil
+	}
+	return dids
+}
+
+// countFresh counts cache entries that are still fresh
+func countFresh[T interface{ IsFresh() bool }](entries map[string]T) int {
+	var fresh int
+	for _, entry := range entries {
+		if entry.IsFresh() {
+			fresh++
+		}
+	}
+	return fresh
+}
+
+// cacheShare returns the share of lookups the cache is expected to answer, none with --refresh
+func cacheShare(cmd *cli.Command, share float64) float64 {
+	if cmd.Bool("refresh") {
+		return 0
+	}
+	return share
+}
+
+// cappedCount applies a --limit style cap to n, where 0 means no cap
+func cappedCount(n, limit int) int {
+	if limit > 0 {
+		return min(n, limit)
+	}
+	return n
+}
+
+// pageStep is sequential pagination through items
+func pageStep(endpoint string, items int) plan.Step {
+	return plan.Step{Endpoint: endpoint, Calls: plan.Pages(items), Concurrency: 1}
+}
+
+// profileStep is the per-account profile lookup done for every listed account
+func profileStep(accounts int) plan.Step {
+	return plan.Step{Endpoint: "app.bsky.actor.getProfile", Calls: accounts, Concurrency: bsky.InitialConcurrency}
+}
+
+// activitySteps are the cached per-account feed lookups behind --inactive and --quiet
+func activitySteps(cmd *cli.Command, in planInputs, accounts int) []plan.Step {
+	var steps []plan.Step
+	if cmd.Int("inactive") > 0 {
+		hits, misses := plan.Hits(accounts, cacheShare(cmd, in.ActivityHit))
+		steps = append(steps, plan.Step{Endpoint: "app.bsky.feed.getAuthorFeed", Calls: misses, Cached: hits, Concurrency: bsky.InitialConcurrency, Note: "last post dates (--inactive)"})
+	}
+	if cmd.Bool("quiet") {
+		hits, misses := plan.Hits(accounts, cacheShare(cmd, in.RateHit))
+		steps = append(steps, plan.Step{Endpoint: "app.bsky.feed.getAuthorFeed", Calls: misses, Cached: hits, Concurrency: bsky.InitialConcurrency, Note: "post rates (--quiet)"})
+	}
+	return steps
+}
+
+// listAddStep is the upper bound of list additions made by --add-to-list
+func listAddStep(cmd *cli.Command, accounts int) (plan.Step, string, bool) {
+	if !slices.Contains(cmd.FlagNames(), "add-to-list") || cmd.String("add-to-list") == "" {
+		return plan.Step{}, "", false
+	}
+	step := plan.Step{Endpoint: "com.atproto.repo.createRecord", Calls: accounts, Concurrency: 1, Interval: cmd.Duration("pace"), Note: "at most; members already on the list are skipped"}
+	return step, "List additions are writes, which also count against your PDS's hourly write limit", true
+}
+
+func planFollowerList(cmd *cli.Command, in planInputs) ([]plan.Step, []string) {
+	var limit int
+	if slices.Contains(cmd.FlagNames(), "limit") {
+		limit = cmd.Int("limit")
+	}
+	followers := cappedCount(in.Followers, limit)
+
+	steps := []plan.Step{pageStep("app.bsky.graph.getFollowers", followers), profileStep(followers)}
+	steps = append(steps, activitySteps(cmd, in, followers)...)
+
+	var notes []string
+	if slices.Contains(cmd.FlagNames(), "since") && cmd.String("since") != "" {
+		notes = append(notes, "--since filters after paging, so profile lookups are an upper bound")
+	}
+	if step, note, ok := listAddStep(cmd, followers); ok {
+		steps = append(steps, step)
+		notes = append(notes, note)
+	}
+	if hasFlag(cmd, "follow-dates") && cmd.Bool("follow-dates") {
+		steps = append(steps, plan.Step{Endpoint: "com.atproto.repo.listRecords", Calls: followers, Concurrency: followRecordWorkers, Note: "follow records (--follow-dates)"})
+		notes = append(notes, fmt.Sprintf("Follow dates search up to %d pages of each follower's follows, so listRecords calls can reach %d", followRecordPages, followers*followRecordPages))
+	}
+	return steps, notes
+}
+
+func planFollowerStats(cmd *cli.Command, in planInputs) ([]plan.Step, []string) {
+	steps := []plan.Step{pageStep("app.bsky.graph.getFollowers", in.Followers), profileStep(in.Followers)}
+	if cmd.Int("inactive") > 0 {
+		steps = append(steps, plan.Step{Endpoint: "app.bsky.feed.getAuthorFeed", Calls: in.Followers, Concurrency: bsky.InitialConcurrency, Note: "last post dates (--inactive, uncached)"})
+	}
+	return steps, nil
+}
+
+func planFollowingList(cmd *cli.Command, in planInputs) ([]plan.Step, []string) {
+	steps := []plan.Step{pageStep("app.bsky.graph.getFollows", in.Follows), profileStep(in.Follows)}
+	steps = append(steps, activitySteps(cmd, in, in.Follows)...)
+
+	var notes []string
+	if cmd.Bool("mutual") {
+		notes = append(notes, "--mutual filters after paging, so profile and activity lookups are an upper bound")
+	}
+	if in.Known > 0 && (cmd.Int("inactive") > 0 || cmd.Bool("quiet")) {
+		notes = append(notes, "Cache hits for accounts you follow are estimated from your followers")
+	}
+	if cmd.Bool("follow-dates") {
+		steps = append(steps, plan.Step{Endpoint: "com.atproto.repo.listRecords", Calls: plan.Pages(in.Follows), Concurrency: 1, Note: "follow records (--follow-dates)"})
+	}
+	return steps, notes
+}
+
+func planFollowBack(cmd *cli.Command, in planInputs) ([]plan.Step, []string) {
+	followers := cappedCount(in.Followers, cmd.Int("limit"))
+	// Followers already followed back are skipped, but which ones isn't known without paging
+	pending := followers
+	hits, misses := plan.Hits(pending, cacheShare(cmd, in.ActivityHit))
+
+	steps := []plan.Step{
+		pageStep("app.bsky.graph.getFollowers", followers),
+		pageStep("app.bsky.graph.getFollows", in.Follows),
+		profileStep(pending),
+		{Endpoint: "app.bsky.feed.getAuthorFeed", Calls: misses, Cached: hits, Concurrency: bsky.InitialConcurrency, Note: "last post dates"},
+	}
+	notes := []string{"Profile and activity lookups assume no follower is followed back yet, so they are an upper bound"}
+	if !cmd.Bool("dry-run") {
+		follows := cappedCount(pending, cmd.Int("max"))
+		steps = append(steps, plan.Step{Endpoint: "com.atproto.repo.createRecord", Calls: follows, Concurrency: 1, Interval: cmd.Duration("pace"), Note: "at most (--max)"})
+		notes = append(notes, "Follows are writes, which also count against your PDS's hourly write limit")
+	}
+	return steps, notes
+}
+
+func planGhosts(cmd *cli.Command, in planInputs) ([]plan.Step, []string) {
+	posts := cmd.Int("posts")
+	followers := cappedCount(in.Followers, cmd.Int("limit"))
+
+	steps := []plan.Step{
+		pageStep("app.bsky.feed.getAuthorFeed", posts),
+		{Endpoint: "app.bsky.feed.getLikes", Calls: posts, Concurrency: 1, Note: "at least one page per post with likes"},
+		{Endpoint: "app.bsky.feed.getRepostedBy", Calls: posts, Concurrency: 1, Note: "at least one page per post with reposts"},
+		pageStep("app.bsky.graph.getFollowers", followers),
+		profileStep(followers),
+	}
+	notes := []string{
+		"Engagement lookups add a page per 100 likes or reposts; posts without any are skipped",
+		"Profile lookups assume every follower is a ghost, so they are an upper bound",
+	}
+	if step, note, ok := listAddStep(cmd, followers); ok {
+		steps = append(steps, step)
+		notes = append(notes, note)
+	}
+	return steps, notes
+}
+
+// displayEstimate prints an estimate's steps, totals, and caveats
+func displayEstimate(estimate plan.Estimate) {
+	ui.Titleln("Plan: %s", estimate.Command)
+	for _, step := range estimate.Steps {
+		line := fmt.Sprintf("%-32s %6d calls", step.Endpoint, step.Calls)
+		if step.Cached > 0 {
+			line += fmt.Sprintf(" (%d cached)", step.Cached)
+		}
+		if step.Note != "" {
+			line += " - " + step.Note
+		}
+		ui.Infoln("%s", line)
+	}
+	fmt.Println()
+
+	ui.Infoln("Total: %d API calls, %d answered from cache", estimate.Calls, estimate.Cached)
+	ui.Infoln("Expected duration: about %s", formatPlanDuration(estimate.Duration))
+	if estimate.Throttled() {
+		ui.Warningln("Exceeds the rate limit: spans %d windows of %s", estimate.Windows, plan.DefaultLimits.Window)
+	} else {
+		ui.Successln("Fits within one rate-limit window (%d requests per %s)", plan.DefaultLimits.Requests, plan.DefaultLimits.Window)
+	}
+	for _, note := range estimate.Notes {
+		ui.Infoln("Note: %s", note)
+	}
+}
+
+// formatPlanDuration rounds d to a readable precision
+func formatPlanDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "under a second"
+	case d < time.Minute:
+		return d.Round(time.Second).String()
+	default:
+		return d.Round(time.Minute).String()
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/plan"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestParsePlannedCommand(t *testing.T) {
+	reg := newFakeRegistry(&fakeGraph{})
+
+	planned, err := parsePlannedCommand(context.Background(), reg, []string{"followers", "list", "--inactive", "30", "--limit", "250"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name := plannedName(planned); name != "followers list" {
+		t.Errorf("name = %q, want %q", name, "followers list")
+	}
+	if planned.Int("inactive") != 30 || planned.Int("limit") != 250 {
+		t.Errorf("flags not parsed: inactive=%d limit=%d", planned.Int("inactive"), planned.Int("limit"))
+	}
+
+	if _, err := parsePlannedCommand(context.Background(), reg, []string{"followers", "list", "--limit", "many"}); err == nil {
+		t.Error("expected invalid flag value to fail parsing")
+	}
+}
+
+func TestPlanFollowerList(t *testing.T) {
+	reg := newFakeRegistry(&fakeGraph{})
+	planned, err := parsePlannedCommand(context.Background(), reg, []string{"followers", "list", "--inactive", "30", "--limit", "250", "--add-to-list", "at://did:plc:me/app.bsky.graph.list/x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	steps, notes := planFollowerList(planned, planInputs{Followers: 1000, Known: 100, ActivityHit: 0.4})
+	if len(steps) != 4 {
+		t.Fatalf("expected pages, profiles, activity, and list steps, got %+v", steps)
+	}
+
+	want := []plan.Step{
+		{Endpoint: "app.bsky.graph.getFollowers", Calls: 3},
+		{Endpoint: "app.bsky.actor.getProfile", Calls: 250},
+		{Endpoint: "app.bsky.feed.getAuthorFeed", Calls: 150, Cached: 100},
+		{Endpoint: "com.atproto.repo.createRecord", Calls: 250},
+	}
+	for i, step := range steps {
+		if step.Endpoint != want[i].Endpoint || step.Calls != want[i].Calls || step.Cached != want[i].Cached {
+			t.Errorf("step %d = %s %d calls (%d cached), want %s %d calls (%d cached)",
+				i, step.Endpoint, step.Calls, step.Cached, want[i].Endpoint, want[i].Calls, want[i].Cached)
+		}
+	}
+	if len(notes) == 0 || !strings.Contains(notes[0], "write limit") {
+		t.Errorf("expected a note about write limits, got %v", notes)
+	}
+
+	refreshed, err := parsePlannedCommand(context.Background(), reg, []string{"followers", "list", "--inactive", "30", "--refresh"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	steps, _ = planFollowerList(refreshed, planInputs{Followers: 100, Known: 100, ActivityHit: 1})
+	if steps[2].Cached != 0 || steps[2].Calls != 100 {
+		t.Errorf("expected --refresh to skip the cache, got %+v", steps[2])
+	}
+}
+
+func TestPlanAction(t *testing.T) {
+	reg := newFakeRegistry(&fakeGraph{authenticated: true, did: "did:plc:me"})
+
+	if _, err := runSubcommand(t, newApp(reg), "plan", PlanAction, reg, "followers", "list"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := runSubcommand(t, newApp(reg), "plan", PlanAction, reg, "fetch", "timeline")
+	if err == nil || !strings.Contains(err.Error(), "no estimate") {
+		t.Fatalf("expected unsupported command error, got %v", err)
+	}
+}
+
+func TestCapNote(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	reg := newFakeRegistry(&fakeGraph{})
+	big := planInputs{Followers: config.DefaultMaxAccounts + 1, Follows: 10}
+
+	for _, tc := range []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"followers", "list"}, true},
+		{[]string{"followers", "list", "--limit", "100"}, false},
+		{[]string{"followers", "stats", "--no-limit"}, false},
+		{[]string{"following", "list"}, false},
+	} {
+		planned, err := parsePlannedCommand(context.Background(), reg, tc.args)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if note, ok := capNote(planned, big); ok != tc.want {
+			t.Errorf("capNote(%v) = %q, %v; want %v", tc.args, note, ok, tc.want)
+		}
+	}
+}
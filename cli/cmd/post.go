@@ -0,0 +1,514 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/linkcard"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// postImageTypes are the image formats Bluesky accepts
+var postImageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// PostCommand returns the post command, which publishes text given on the command line or opens the compose screen
+func PostCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "post",
+		Usage:     "Publish a post, or write one in the full-screen compose screen",
+		ArgsUsage: "[text...]",
+		Description: `With text, publishes it right away. Without, opens the compose screen: a live count of the 300-character
+   limit, mentions, links, and hashtags highlighted as they will appear, image attachments, and drafts.
+
+   In the compose screen, ctrl+p posts, ctrl+s saves a draft, ctrl+o picks an image to attach (up to 4, under
+   1 MB each), ctrl+x removes the last image, and esc closes, offering to save unsaved changes first.
+
+   --reply, --lang, --link, and --image work either way: with the compose screen, they apply to the post it
+   publishes, and --image files start out attached.
+
+   'skycli undo' deletes posts made here.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "draft",
+				Aliases: []string{"d"},
+				Usage:   "Open a saved draft in the compose screen (ID or unique prefix from 'skycli post drafts')",
+			},
+			&cli.StringFlag{
+				Name:    "reply",
+				Aliases: []string{"r"},
+				Usage:   "Post as a reply to this post (AT URI or bsky.app URL)",
+			},
+			&cli.StringSliceFlag{
+				Name:    "lang",
+				Aliases: []string{"l"},
+				Usage:   "Language of the post as a tag like en or pt-BR; repeat for up to 3",
+			},
+			&cli.StringFlag{
+				Name:  "link",
+				Usage: "Attach a link card for this URL, with the page's title, description, and preview image",
+			},
+			&cli.StringFlag{
+				Name:  "link-title",
+				Usage: "Title for the --link card instead of the page's",
+			},
+			&cli.StringFlag{
+				Name:  "link-description",
+				Usage: "Description for the --link card instead of the page's",
+			},
+			&cli.StringSliceFlag{
+				Name:    "image",
+				Aliases: []string{"i"},
+				Usage:   "Attach a JPEG, PNG, GIF, or WebP image under 1 MB; repeat for up to 4",
+			},
+			&cli.StringSliceFlag{
+				Name:  "alt",
+				Usage: "Alt text for the --image in the same position; repeat once per image",
+			},
+		},
+		Commands: []*cli.Command{postDraftsCommand()},
+		Action:   withRegistry(PostAction),
+	}
+}
+
+// postDraftsCommand returns the post drafts subcommand
+func postDraftsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "drafts",
+		Usage:     "List the drafts saved from the compose screen",
+		UsageText: "Open one with 'skycli post --draft <id>'.",
+		ArgsUsage: " ",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "delete",
+				Usage: "Delete the draft with this ID or unique prefix",
+			},
+		},
+		Action: withRegistry(PostDraftsAction),
+	}
+}
+
+// PostAction publishes the text given as arguments, or runs the compose screen until the post is published or
+// the screen is closed
+func PostAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	service, err := reg.GetService()
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+	if !service.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+	writer, err := reg.GetPostWriter()
+	if err != nil {
+		return fmt.Errorf("failed to get post writer: %w", err)
+	}
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+	images, err := flagImages(cmd)
+	if err != nil {
+		return err
+	}
+	opts, notes, err := postFlags(ctx, cmd, reg)
+	if err != nil {
+		return err
+	}
+	publish := func(ctx context.Context, text string, images []bsky.DraftImage) (*bsky.CreateRecordResponse, error) {
+		return publishPost(ctx, writer, mentionResolver(profiles), text, images, opts)
+	}
+
+	text := strings.Join(cmd.Args().Slice(), " ")
+	if text != "" {
+		if cmd.String("draft") != "" {
+			return fmt.Errorf("--draft opens the compose screen: leave out the post text")
+		}
+		record, err := publish(ctx, text, images)
+		if err != nil {
+			return fmt.Errorf("failed to post: %w", err)
+		}
+		recordPost(ctx, reg, record.Uri)
+		ui.Successln("Posted %s", record.Uri)
+		return nil
+	}
+
+	if cmd.Bool("no-input") {
+		return ui.ErrNotInteractive
+	}
+	session := &composeSession{publish: publish}
+	if session.drafts, err = reg.GetDraftRepo(); err != nil {
+		logger.Debug("Drafts unavailable", "error", err)
+		session.drafts = nil
+	}
+	if id := cmd.String("draft"); id != "" {
+		if session.drafts == nil {
+			return fmt.Errorf("drafts are unavailable: %w", err)
+		}
+		draft, err := findDraft(ctx, session.drafts, id)
+		if err != nil {
+			return err
+		}
+		session.openDraft(draft)
+	}
+	session.images = append(session.images, images...)
+	session.notes = notes
+
+	if err := runCompose(ctx, session); err != nil {
+		return err
+	}
+
+	switch {
+	case session.posted != nil:
+		recordPost(ctx, reg, session.posted.Uri)
+		ui.Successln("Posted %s", session.posted.Uri)
+	case session.draft != nil && !session.dirty:
+		ui.Infoln("Draft %s saved. Reopen it with 'skycli post --draft %s'.", shortID(session.draft.ID), shortID(session.draft.ID))
+	}
+	return nil
+}
+
+// runCompose shows the compose screen until it closes, restoring the terminal before returning
+func runCompose(ctx context.Context, session *composeSession) error {
+	screen, err := ui.OpenScreen(os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer screen.Close()
+
+	for {
+		width, height := screen.Size()
+		screen.Draw(session.render(width, height))
+
+		key, err := screen.ReadKey()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		done, err := session.handle(ctx, key, screen.Prompt)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// PostDraftsAction lists saved drafts, or deletes one
+func PostDraftsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	drafts, err := reg.GetDraftRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get draft repository: %w", err)
+	}
+
+	if id := cmd.String("delete"); id != "" {
+		draft, err := findDraft(ctx, drafts, id)
+		if err != nil {
+			return err
+		}
+		if err := drafts.Delete(ctx, draft.ID); err != nil {
+			return fmt.Errorf("failed to delete draft: %w", err)
+		}
+		ui.Successln("Deleted draft %s", shortID(draft.ID))
+		return nil
+	}
+
+	saved, err := drafts.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list drafts: %w", err)
+	}
+	if len(saved) == 0 {
+		ui.Infoln("No drafts. Save one from the compose screen ('skycli post') with ctrl+s.")
+		return nil
+	}
+
+	rows := make([][]string, len(saved))
+	for i, draft := range saved {
+		rows[i] = []string{shortID(draft.ID), noteCell(draft.Text), fmt.Sprint(len(draft.Images)), ui.FormatDate(draft.UpdatedAt, ui.DatesLocal)}
+	}
+	ui.Titleln("Drafts")
+	fmt.Println(stripedTable([]string{"ID", "Text", "Images", "Saved"}, rows))
+	ui.Successln("Total: %d draft(s)", len(saved))
+	return nil
+}
+
+// findDraft looks up a draft by its ID or a prefix matching only one draft
+func findDraft(ctx context.Context, drafts *bsky.DraftRepository, id string) (*bsky.DraftModel, error) {
+	saved, err := drafts.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+	var found *bsky.DraftModel
+	for _, draft := range saved {
+		if !strings.HasPrefix(draft.ID, id) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("draft ID %q is ambiguous: give more of it", id)
+		}
+		found = draft
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no draft with ID %q: %w", id, bsky.ErrNotFound)
+	}
+	return found, nil
+}
+
+// postOptions are the parts of a post set with flags rather than written: the post it replies to, its languages,
+// and a link card
+type postOptions struct {
+	reply *bsky.ReplyRefs
+	langs []string
+	card  *linkCard
+}
+
+// linkCard is a link card whose preview image is not uploaded yet
+type linkCard struct {
+	uri, title, description string
+	thumb                   *postImage // nil when the page has no usable preview image
+}
+
+// postLangPattern matches a BCP-47 language tag: a 2 or 3 letter language, then optional subtags
+var postLangPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// postFlags reads --reply, --lang, and --link, looking up the post replied to and the linked page. It also
+// returns a line describing each for the compose screen.
+func postFlags(ctx context.Context, cmd *cli.Command, reg *registry.Registry) (postOptions, []string, error) {
+	var opts postOptions
+	var notes []string
+
+	if target := cmd.String("reply"); target != "" {
+		fetcher, err := reg.GetRecordFetcher()
+		if err != nil {
+			return opts, nil, fmt.Errorf("failed to get record fetcher: %w", err)
+		}
+		parent, err := fetchReplyParent(ctx, fetcher, target)
+		if err != nil {
+			return opts, nil, err
+		}
+		root := replyRoot(parent)
+		opts.reply = &bsky.ReplyRefs{Root: &root, Parent: &bsky.PostRef{Uri: parent.Uri, Cid: parent.Cid}}
+		if parent.Author != nil {
+			notes = append(notes, fmt.Sprintf("Replying to @%s: %s", parent.Author.Handle, noteCell(postText(parent))))
+		}
+	}
+
+	for _, lang := range cmd.StringSlice("lang") {
+		if !postLangPattern.MatchString(lang) {
+			return opts, nil, fmt.Errorf("invalid --lang %q: expected a language tag like en or pt-BR", lang)
+		}
+		if !slices.Contains(opts.langs, lang) {
+			opts.langs = append(opts.langs, lang)
+		}
+	}
+	if len(opts.langs) > bsky.MaxPostLangs {
+		return opts, nil, fmt.Errorf("a post can have at most %d languages", bsky.MaxPostLangs)
+	}
+
+	if cmd.String("link") == "" {
+		if cmd.IsSet("link-title") || cmd.IsSet("link-description") {
+			return opts, nil, fmt.Errorf("--link-title and --link-description need --link")
+		}
+		return opts, notes, nil
+	}
+	client := &http.Client{Transport: bsky.SharedTransport(), Timeout: 15 * time.Second}
+	card, err := fetchLinkCard(ctx, client, cmd.String("link"), cmd.String("link-title"), cmd.String("link-description"))
+	if err != nil {
+		return opts, nil, err
+	}
+	opts.card = card
+	notes = append(notes, fmt.Sprintf("Link card: %s · %s", card.title, card.uri))
+	return opts, notes, nil
+}
+
+// fetchReplyParent looks up the post a reply answers
+func fetchReplyParent(ctx context.Context, fetcher bsky.RecordFetcher, target string) (*bsky.PostView, error) {
+	ref, err := aturi.ParsePost(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --reply: %w", err)
+	}
+	response, err := fetcher.GetPosts(ctx, []string{ref.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the post to reply to: %w", err)
+	}
+	if len(response.Posts) == 0 || response.Posts[0].Post == nil {
+		return nil, fmt.Errorf("post to reply to not found: %s", ref)
+	}
+	return response.Posts[0].Post, nil
+}
+
+// fetchLinkCard reads the title, description, and preview image of the page at uri for a link card; title and
+// description replace the page's when set. A page that can't be read still gets a card, titled with its URL.
+func fetchLinkCard(ctx context.Context, client *http.Client, uri, title, description string) (*linkCard, error) {
+	page, err := linkcard.Fetch(ctx, client, uri)
+	if err != nil {
+		if errors.Is(err, linkcard.ErrInvalidLink) {
+			return nil, fmt.Errorf("invalid --link: %w", err)
+		}
+		logger.Warn("Couldn't read the linked page; the card has only its URL", "error", err)
+		page = &linkcard.Card{URL: uri}
+	}
+
+	card := &linkCard{uri: uri, title: cmp.Or(title, page.Title, uri), description: cmp.Or(description, page.Description)}
+	if page.Image == "" {
+		return card, nil
+	}
+	data, err := linkcard.Download(ctx, client, page.Image, bsky.MaxImageBytes)
+	if err == nil {
+		card.thumb, err = decodePostImage(path.Base(page.Image), data)
+	}
+	if err != nil {
+		logger.Debug("Link card image skipped", "image", page.Image, "error", err)
+		card.thumb = nil
+	}
+	return card, nil
+}
+
+// flagImages pairs the --image files with their --alt texts, checking that each can be attached
+func flagImages(cmd *cli.Command) ([]bsky.DraftImage, error) {
+	paths, alts := cmd.StringSlice("image"), cmd.StringSlice("alt")
+	if len(alts) > len(paths) {
+		return nil, fmt.Errorf("%d --alt texts for %d --image files: give one --alt per image, in the same order", len(alts), len(paths))
+	}
+	if len(paths) > bsky.MaxPostImages {
+		return nil, fmt.Errorf("a post can have at most %d images", bsky.MaxPostImages)
+	}
+
+	images := make([]bsky.DraftImage, len(paths))
+	for i, file := range paths {
+		if _, err := readPostImage(file); err != nil {
+			return nil, err
+		}
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+		images[i] = bsky.DraftImage{Path: file}
+		if i < len(alts) {
+			images[i].Alt = alts[i]
+		}
+	}
+	return images, nil
+}
+
+// publishPost checks a post against Bluesky's limits, turns its mentions, links, and hashtags into facets, uploads
+// its images or link card image, and publishes it with opts
+func publishPost(ctx context.Context, writer bsky.PostWriter, resolve func(context.Context, string) (string, error), text string, images []bsky.DraftImage, opts postOptions) (*bsky.CreateRecordResponse, error) {
+	text = strings.TrimSpace(text)
+	if text == "" && len(images) == 0 && opts.card == nil {
+		return nil, fmt.Errorf("nothing to post")
+	}
+	if n := bsky.GraphemeLength(text); n > bsky.MaxPostGraphemes {
+		return nil, fmt.Errorf("post is %d characters; the limit is %d", n, bsky.MaxPostGraphemes)
+	}
+	if len(images) > bsky.MaxPostImages {
+		return nil, fmt.Errorf("a post can have at most %d images", bsky.MaxPostImages)
+	}
+	if len(images) > 0 && opts.card != nil {
+		return nil, fmt.Errorf("a post can have images or a link card, not both")
+	}
+
+	post := bsky.NewPost{
+		Text:   text,
+		Facets: bsky.Facets(ctx, bsky.FindTextSpans(text), resolve),
+		Reply:  opts.reply,
+		Langs:  opts.langs,
+	}
+	if card := opts.card; card != nil {
+		post.External = &bsky.ExternalEmbed{URI: card.uri, Title: card.title, Description: card.description}
+		if card.thumb != nil {
+			blob, err := writer.UploadBlob(ctx, card.thumb.data, card.thumb.mimeType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload the link card image: %w", err)
+			}
+			post.External.Thumb = blob
+		}
+	}
+	for _, attached := range images {
+		img, err := readPostImage(attached.Path)
+		if err != nil {
+			return nil, err
+		}
+		blob, err := writer.UploadBlob(ctx, img.data, img.mimeType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload %s: %w", filepath.Base(attached.Path), err)
+		}
+		post.Images = append(post.Images, bsky.PostImage{Blob: blob, Alt: attached.Alt, Width: img.width, Height: img.height})
+	}
+	return writer.CreatePost(ctx, post)
+}
+
+// mentionResolver looks up the DID behind a mentioned handle through its profile
+func mentionResolver(profiles bsky.ProfileFetcher) func(context.Context, string) (string, error) {
+	return func(ctx context.Context, handle string) (string, error) {
+		profile, err := profiles.GetProfile(ctx, handle)
+		if err != nil {
+			logger.Debug("Mention not linked", "handle", handle, "error", err)
+			return "", err
+		}
+		return profile.Did, nil
+	}
+}
+
+// recordPost adds a published post to the undo log, when the log is available
+func recordPost(ctx context.Context, reg *registry.Registry, uri string) {
+	actions, err := reg.GetActionRepo()
+	if err != nil {
+		logger.Debug("Undo log unavailable", "error", err)
+		return
+	}
+	recordAction(ctx, actions, bsky.ActionPost, "", uri)
+}
+
+// postImage is an image file read for upload
+type postImage struct {
+	data          []byte
+	mimeType      string
+	width, height int // 0 when the format's size can't be read, as for WebP
+}
+
+// readPostImage reads an image to attach to a post, checking its format and size against Bluesky's limits
+func readPostImage(path string) (*postImage, error) {
+	name := filepath.Base(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s: %w", name, err)
+	}
+	return decodePostImage(name, data)
+}
+
+// decodePostImage checks the image data of the file name against Bluesky's limits and reads its size
+func decodePostImage(name string, data []byte) (*postImage, error) {
+	if len(data) > bsky.MaxImageBytes {
+		return nil, fmt.Errorf("%s is %.1f MB; images must be under 1 MB", name, float64(len(data))/1_000_000)
+	}
+
+	img := &postImage{data: data, mimeType: http.DetectContentType(data)}
+	if !slices.Contains(postImageTypes, img.mimeType) {
+		return nil, fmt.Errorf("%s is not a JPEG, PNG, GIF, or WebP image", name)
+	}
+	if config, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		img.width, img.height = config.Width, config.Height
+	}
+	return img, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestPostOptions(t *testing.T) {
+	ctx := context.Background()
+
+	var thumb bytes.Buffer
+	if err := png.Encode(&thumb, image.NewRGBA(image.Rect(0, 0, 8, 5))); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/article":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<head><title>Article</title><meta property="og:description" content="Read all about it"><meta property="og:image" content="/thumb.png"></head>`))
+		case "/thumb.png":
+			w.Write(thumb.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// A reply points at the parent and at the root of the parent's thread
+	graph := &fakeGraph{posts: []bsky.PostView{{
+		Uri:    "at://did:plc:bob/app.bsky.feed.post/2",
+		Cid:    "cid2",
+		Author: &bsky.ActorProfile{Did: "did:plc:bob", Handle: "bob.test"},
+		Record: map[string]any{
+			"text":  "a reply",
+			"reply": map[string]any{"root": map[string]any{"uri": "at://did:plc:alice/app.bsky.feed.post/1", "cid": "cid1"}},
+		},
+	}}}
+	parent, err := fetchReplyParent(ctx, graph, "https://bsky.app/profile/did:plc:bob/post/2")
+	if err != nil {
+		t.Fatalf("fetchReplyParent failed: %v", err)
+	}
+	if root := replyRoot(parent); root.Uri != "at://did:plc:alice/app.bsky.feed.post/1" {
+		t.Errorf("unexpected root %+v", root)
+	}
+	if _, err := fetchReplyParent(ctx, graph, "at://did:plc:bob/app.bsky.feed.post/404"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected a missing post to be refused, got %v", err)
+	}
+
+	card, err := fetchLinkCard(ctx, server.Client(), server.URL+"/article", "", "")
+	if err != nil {
+		t.Fatalf("fetchLinkCard failed: %v", err)
+	}
+	if card.title != "Article" || card.description != "Read all about it" || card.thumb == nil || card.thumb.width != 8 {
+		t.Errorf("unexpected card %+v", card)
+	}
+	if missing, err := fetchLinkCard(ctx, server.Client(), server.URL+"/gone", "Own title", ""); err != nil || missing.title != "Own title" || missing.thumb != nil {
+		t.Errorf("a page that can't be read should still get a card, got %+v (err %v)", missing, err)
+	}
+	if _, err := fetchLinkCard(ctx, server.Client(), "example.com", "", ""); err == nil {
+		t.Error("expected a link without a scheme to be refused")
+	}
+
+	feed := newFakeFeed()
+	noMentions := func(context.Context, string) (string, error) { return "", nil }
+	opts := postOptions{
+		reply: &bsky.ReplyRefs{Root: &bsky.PostRef{Uri: "at://root", Cid: "r"}, Parent: &bsky.PostRef{Uri: parent.Uri, Cid: parent.Cid}},
+		langs: []string{"en"},
+		card:  card,
+	}
+	if _, err := publishPost(ctx, feed, noMentions, "see this", nil, opts); err != nil {
+		t.Fatalf("publishPost failed: %v", err)
+	}
+	post := feed.posts[0]
+	if post.Reply.Parent.Uri != parent.Uri || len(post.Langs) != 1 || post.External == nil || post.External.Thumb == nil || post.External.Title != "Article" {
+		t.Errorf("unexpected post %+v", post)
+	}
+	if len(feed.uploads) != 1 || feed.uploads[0] != "image/png" {
+		t.Errorf("expected the card image uploaded, got %v", feed.uploads)
+	}
+
+	if _, err := publishPost(ctx, feed, noMentions, "both", []bsky.DraftImage{{Path: "x.png"}}, opts); err == nil {
+		t.Error("expected a post with images and a link card to be refused")
+	}
+}
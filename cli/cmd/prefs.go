@@ -0,0 +1,484 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// prefTypePrefix namespaces the preference types named by their short form, e.g. feed-view
+const prefTypePrefix = "app.bsky.actor.defs#"
+
+// prefKeys lists the fields that tell apart the entries of preference types that may occur more than once
+var prefKeys = map[string][]string{
+	prefTypePrefix + "feedViewPref":     {"feed"},
+	prefTypePrefix + "contentLabelPref": {"labelerDid", "label"},
+}
+
+// PrefsCommand returns the prefs command for reading and editing raw account preferences
+func PrefsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "prefs",
+		Usage: "Read and edit account preferences, including ones the app doesn't expose",
+		Description: "Preference types are given in short form (interests, feed-view, thread-view, adult-content) or as a full\n" +
+			"$type such as app.bsky.actor.defs#interestsPref.",
+		Commands: []*cli.Command{
+			{
+				Name:      "get",
+				Usage:     "Print preferences as JSON, optionally only those of one type",
+				ArgsUsage: "[type]",
+				Action:    withRegistry(PrefsGetAction),
+			},
+			{
+				Name:      "set",
+				Usage:     "Change fields of one preference, previewing the change before writing it",
+				ArgsUsage: "<type> <field=value>...",
+				Description: "Values are read as JSON when they parse (true, 3, [\"art\",\"music\"]) and as text otherwise.\n" +
+					"For feed-view, feed=<uri or home> picks the feed the settings apply to and defaults to home.\n" +
+					"Example: skycli prefs set feed-view hideReposts=true hideRepliesByLikeCount=2",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:  "unset",
+						Usage: "Remove a field from the preference (repeatable)",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the change without writing it",
+					},
+				},
+				Action: withRegistry(PrefsSetAction),
+			},
+			{
+				Name:      "notifications",
+				Usage:     "Show or change which notifications you receive and which are pushed",
+				ArgsUsage: "[category.field=value...]",
+				Description: "Without arguments, lists each notification category's settings: include (all or follows), list (shown\n" +
+					"in the app), and push. Arguments change them, e.g. 'like.push=false reply.include=follows'.",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "priority",
+						Usage: "Only get notifications from accounts you follow (--priority=false to turn off)",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the change without writing it",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output settings as JSON",
+					},
+				},
+				Action: withRegistry(PrefsNotificationsAction),
+			},
+		},
+	}
+}
+
+// PrefsGetAction prints the user's preferences, or only those of the type given as argument
+func PrefsGetAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	prefs, err := preferencesClient(reg)
+	if err != nil {
+		return err
+	}
+
+	response, err := prefs.GetPreferences(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch preferences: %w", err)
+	}
+
+	if cmd.Args().Len() == 0 {
+		return ui.DisplayJSON(response.Preferences)
+	}
+
+	prefType := preferenceType(cmd.Args().First())
+	matches := []map[string]any{}
+	for _, pref := range response.Preferences {
+		if pref["$type"] == prefType {
+			matches = append(matches, pref)
+		}
+	}
+	if len(matches) == 0 {
+		ui.Infoln("No %s preference set", prefType)
+		return nil
+	}
+	return ui.DisplayJSON(matches)
+}
+
+// PrefsSetAction changes fields of one preference, shows the change, and writes it after confirmation
+func PrefsSetAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("preference type required, e.g. interests or feed-view")
+	}
+	prefType := preferenceType(cmd.Args().First())
+
+	fields, err := parseAssignments(cmd.Args().Tail())
+	if err != nil {
+		return err
+	}
+	unset := cmd.StringSlice("unset")
+	if len(fields) == 0 && len(unset) == 0 {
+		return fmt.Errorf("nothing to change: give field=value pairs or --unset")
+	}
+
+	prefs, err := preferencesClient(reg)
+	if err != nil {
+		return err
+	}
+	response, err := prefs.GetPreferences(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch preferences: %w", err)
+	}
+
+	updated, before, after, err := setPreference(response.Preferences, prefType, fields, unset)
+	if err != nil {
+		return err
+	}
+
+	diff := preferenceDiff(before, after)
+	if diff == nil {
+		ui.Infoln("No changes to %s", prefType)
+		return nil
+	}
+
+	ui.Titleln("%s", prefType)
+	displayPreferenceDiff(diff)
+
+	if cmd.Bool("dry-run") {
+		ui.Infoln("Dry run: preferences not written")
+		return nil
+	}
+	if ok, err := confirm(cmd, "Write these preferences?"); !ok {
+		return err
+	}
+
+	logger.Debug("Writing preferences", "type", prefType, "set", len(fields), "unset", len(unset))
+	if err := prefs.PutPreferences(ctx, updated); err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+	ui.Successln("Updated %s", prefType)
+	return nil
+}
+
+// notificationSettingsOutput is the JSON form of notification settings
+type notificationSettingsOutput struct {
+	Priority    bool                      `json:"priority"`
+	Preferences map[string]map[string]any `json:"preferences"`
+}
+
+// PrefsNotificationsAction shows notification settings, or changes the categories and priority mode given
+func PrefsNotificationsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	fields, err := parseAssignments(cmd.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	service, err := reg.GetService()
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+	if !service.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+	settings, err := reg.GetNotificationSettings()
+	if err != nil {
+		return fmt.Errorf("failed to get notification settings client: %w", err)
+	}
+	notifications, err := reg.GetNotificationFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get notification fetcher: %w", err)
+	}
+
+	current, err := settings.GetNotificationPreferences(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch notification preferences: %w", err)
+	}
+	// Priority mode can only be read back from a page of notifications
+	page, err := notifications.ListNotifications(ctx, 1, "")
+	if err != nil {
+		return fmt.Errorf("failed to read priority mode: %w", err)
+	}
+	priority := page.Priority
+
+	if len(fields) == 0 && !cmd.IsSet("priority") {
+		if cmd.Bool("json") {
+			return ui.DisplayJSON(notificationSettingsOutput{Priority: priority, Preferences: current.Preferences})
+		}
+		displayNotificationSettings(priority, current.Preferences)
+		return nil
+	}
+
+	changes, err := notificationChanges(current.Preferences, fields)
+	if err != nil {
+		return err
+	}
+	setPriority := cmd.IsSet("priority") && cmd.Bool("priority") != priority
+	if len(changes) == 0 && !setPriority {
+		ui.Infoln("No changes to notification settings")
+		return nil
+	}
+
+	if setPriority {
+		ui.Titleln("priority")
+		displayPreferenceDiff([]string{fmt.Sprintf("- %t", priority), fmt.Sprintf("+ %t", cmd.Bool("priority"))})
+	}
+	for _, category := range slices.Sorted(maps.Keys(changes)) {
+		ui.Titleln("%s", category)
+		displayPreferenceDiff(preferenceDiff(current.Preferences[category], changes[category]))
+	}
+
+	if cmd.Bool("dry-run") {
+		ui.Infoln("Dry run: notification settings not written")
+		return nil
+	}
+	if ok, err := confirm(cmd, "Write these notification settings?"); !ok {
+		return err
+	}
+
+	if len(changes) > 0 {
+		logger.Debug("Writing notification preferences", "categories", len(changes))
+		if _, err := settings.PutNotificationPreferences(ctx, changes); err != nil {
+			return fmt.Errorf("failed to save notification preferences: %w", err)
+		}
+	}
+	if setPriority {
+		if err := settings.PutNotificationPriority(ctx, cmd.Bool("priority")); err != nil {
+			return fmt.Errorf("failed to set priority mode: %w", err)
+		}
+	}
+	ui.Successln("Updated notification settings")
+	return nil
+}
+
+// notificationChanges applies category.field assignments to the current notification settings and returns the
+// full settings of each category that changed. Unknown categories and fields are refused, as are values whose type
+// differs from the current one, so typos don't reach the server.
+func notificationChanges(current map[string]map[string]any, fields map[string]any) (map[string]map[string]any, error) {
+	changes := make(map[string]map[string]any)
+	for _, assignment := range slices.Sorted(maps.Keys(fields)) {
+		category, field, ok := strings.Cut(assignment, ".")
+		if !ok {
+			return nil, fmt.Errorf("invalid setting %q: expected category.field, e.g. like.push", assignment)
+		}
+
+		settings, ok := current[category]
+		if !ok {
+			return nil, fmt.Errorf("unknown notification category %q; known: %s", category, strings.Join(slices.Sorted(maps.Keys(current)), ", "))
+		}
+		old, ok := settings[field]
+		if !ok {
+			return nil, fmt.Errorf("%s has no %q setting; it has: %s", category, field, strings.Join(slices.Sorted(maps.Keys(settings)), ", "))
+		}
+		value := fields[assignment]
+		if fmt.Sprintf("%T", old) != fmt.Sprintf("%T", value) {
+			return nil, fmt.Errorf("%s: expected a value like %s, got %s", assignment, compactJSON(old), compactJSON(value))
+		}
+		if compactJSON(old) == compactJSON(value) {
+			continue
+		}
+
+		if changes[category] == nil {
+			changes[category] = maps.Clone(settings)
+		}
+		changes[category][field] = value
+	}
+	return changes, nil
+}
+
+// displayNotificationSettings prints the priority mode and a row per notification category
+func displayNotificationSettings(priority bool, preferences map[string]map[string]any) {
+	rows := make([][]string, 0, len(preferences))
+	for _, category := range slices.Sorted(maps.Keys(preferences)) {
+		settings := preferences[category]
+		row := []string{category}
+		for _, field := range []string{"include", "list", "push"} {
+			value := ""
+			if v, ok := settings[field]; ok {
+				value = fmt.Sprint(v)
+			}
+			row = append(row, value)
+		}
+		rows = append(rows, row)
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("Category", "Include", "List", "Push").Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	ui.Titleln("Notification Settings")
+	fmt.Println(re.NewStyle().Render(t.String()))
+	if priority {
+		ui.Infoln("Priority mode: on (only accounts you follow notify you)")
+	} else {
+		ui.Infoln("Priority mode: off")
+	}
+}
+
+// preferenceType expands a short preference name such as feed-view to its full $type, feedViewPref in
+// app.bsky.actor.defs; full types pass through
+func preferenceType(name string) string {
+	if strings.ContainsAny(name, "#.") {
+		return name
+	}
+	var b strings.Builder
+	for i, part := range strings.Split(strings.TrimSuffix(name, "-pref"), "-") {
+		if i > 0 && part != "" {
+			part = strings.ToUpper(part[:1]) + part[1:]
+		}
+		b.WriteString(part)
+	}
+	return prefTypePrefix + b.String() + "Pref"
+}
+
+// parseAssignments reads field=value arguments, decoding values as JSON when they parse
+func parseAssignments(args []string) (map[string]any, error) {
+	fields := make(map[string]any, len(args))
+	for _, arg := range args {
+		key, raw, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid assignment %q: expected field=value", arg)
+		}
+		if key == "$type" {
+			return nil, fmt.Errorf("$type is set by the preference type argument")
+		}
+
+		var value any
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			value = raw
+		}
+		fields[key] = value
+	}
+	return fields, nil
+}
+
+// setPreference applies fields and unset to the preference of prefType, adding it when the user has none, and
+// returns the new preference list along with the preference before (nil when added) and after the change. For
+// types with several entries, the entry is picked by its key fields; feed-view defaults to the home feed.
+func setPreference(prefs []map[string]any, prefType string, fields map[string]any, unset []string) ([]map[string]any, map[string]any, map[string]any, error) {
+	keys := prefKeys[prefType]
+	fields = maps.Clone(fields)
+	if prefType == prefTypePrefix+"feedViewPref" {
+		if _, ok := fields["feed"]; !ok {
+			fields["feed"] = "home"
+		}
+	}
+	for _, key := range keys {
+		if _, ok := fields[key]; !ok {
+			return nil, nil, nil, fmt.Errorf("%s needs %s=... to pick which entry to change", prefType, key)
+		}
+		if slices.Contains(unset, key) {
+			return nil, nil, nil, fmt.Errorf("%s identifies the entry and can't be unset", key)
+		}
+	}
+
+	index := slices.IndexFunc(prefs, func(pref map[string]any) bool {
+		if pref["$type"] != prefType {
+			return false
+		}
+		for _, key := range keys {
+			if compactJSON(pref[key]) != compactJSON(fields[key]) {
+				return false
+			}
+		}
+		return true
+	})
+
+	var before map[string]any
+	after := map[string]any{"$type": prefType}
+	if index >= 0 {
+		before = prefs[index]
+		after = maps.Clone(before)
+	}
+	maps.Copy(after, fields)
+	for _, key := range unset {
+		delete(after, key)
+	}
+
+	updated := slices.Clone(prefs)
+	if index >= 0 {
+		updated[index] = after
+	} else {
+		updated = append(updated, after)
+	}
+	return updated, before, after, nil
+}
+
+// preferenceDiff lists the fields of a preference before and after a change, one per line: unchanged fields are
+// indented, removed values start with "-" and new ones with "+". It returns nil when nothing changed.
+func preferenceDiff(before, after map[string]any) []string {
+	keys := slices.Collect(maps.Keys(after))
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var lines []string
+	changed := false
+	for _, key := range keys {
+		if key == "$type" {
+			continue
+		}
+		old, hadOld := before[key]
+		value, hasNew := after[key]
+		oldJSON, newJSON := compactJSON(old), compactJSON(value)
+		switch {
+		case hadOld && hasNew && oldJSON == newJSON:
+			lines = append(lines, "  "+key+": "+newJSON)
+			continue
+		case hadOld:
+			lines = append(lines, "- "+key+": "+oldJSON)
+		}
+		if hasNew {
+			lines = append(lines, "+ "+key+": "+newJSON)
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return lines
+}
+
+// displayPreferenceDiff prints diff lines from [preferenceDiff], coloring removals and additions
+func displayPreferenceDiff(lines []string) {
+	for _, line := range lines {
+		switch line[0] {
+		case '-':
+			fmt.Println(ui.ErrorStyle.Render(line))
+		case '+':
+			fmt.Println(ui.SuccessStyle.Render(line))
+		default:
+			fmt.Println(ui.TextStyle.Render(line))
+		}
+	}
+}
+
+// compactJSON renders a preference value on one line
+func compactJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPreferenceType(t *testing.T) {
+	for input, want := range map[string]string{
+		"interests":                           "app.bsky.actor.defs#interestsPref",
+		"feed-view":                           "app.bsky.actor.defs#feedViewPref",
+		"adult-content-pref":                  "app.bsky.actor.defs#adultContentPref",
+		"app.bsky.actor.defs#threadViewPref":  "app.bsky.actor.defs#threadViewPref",
+		"com.example.custom#experimentalPref": "com.example.custom#experimentalPref",
+	} {
+		if got := preferenceType(input); got != want {
+			t.Errorf("preferenceType(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSetPreference(t *testing.T) {
+	prefs := []map[string]any{
+		{"$type": "app.bsky.actor.defs#interestsPref", "tags": []any{"art"}},
+		{"$type": "app.bsky.actor.defs#feedViewPref", "feed": "home", "hideReplies": false, "hideReposts": true},
+	}
+
+	fields, err := parseAssignments([]string{`tags=["art","music"]`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, before, after, err := setPreference(prefs, preferenceType("interests"), fields, nil)
+	if err != nil {
+		t.Fatalf("setPreference failed: %v", err)
+	}
+	if len(updated) != 2 || len(updated[0]["tags"].([]any)) != 2 || len(prefs[0]["tags"].([]any)) != 1 {
+		t.Fatalf("expected a changed copy, got %v (original %v)", updated, prefs)
+	}
+	if diff := preferenceDiff(before, after); !slices.Equal(diff, []string{`- tags: ["art"]`, `+ tags: ["art","music"]`}) {
+		t.Errorf("unexpected diff %q", diff)
+	}
+
+	// feed-view entries are picked by feed, home by default
+	fields, _ = parseAssignments([]string{"hideReplies=true"})
+	_, before, after, err = setPreference(prefs, preferenceType("feed-view"), fields, []string{"hideReposts"})
+	if err != nil {
+		t.Fatalf("setPreference failed: %v", err)
+	}
+	want := []string{"  feed: \"home\"", "- hideReplies: false", "+ hideReplies: true", "- hideReposts: true"}
+	if diff := preferenceDiff(before, after); !slices.Equal(diff, want) {
+		t.Errorf("diff = %q, want %q", diff, want)
+	}
+
+	fields, _ = parseAssignments([]string{"feed=at://did:plc:feeds/app.bsky.feed.generator/art", "hideReposts=true"})
+	updated, before, _, err = setPreference(prefs, preferenceType("feed-view"), fields, nil)
+	if err != nil || before != nil || len(updated) != 3 {
+		t.Errorf("expected a new feed-view entry, got %v (%v)", updated, err)
+	}
+
+	fields, _ = parseAssignments([]string{"hideReposts=true"})
+	_, before, after, _ = setPreference(prefs, preferenceType("feed-view"), fields, nil)
+	if diff := preferenceDiff(before, after); diff != nil {
+		t.Errorf("expected no diff for an unchanged value, got %q", diff)
+	}
+
+	if _, err := parseAssignments([]string{"noequals"}); err == nil {
+		t.Error("expected an assignment without = to be refused")
+	}
+	if _, _, _, err := setPreference(prefs, preferenceType("content-label"), map[string]any{"visibility": "hide"}, nil); err == nil {
+		t.Error("expected content-label without its key fields to be refused")
+	}
+}
+
+func TestNotificationChanges(t *testing.T) {
+	current := map[string]map[string]any{
+		"like":   {"include": "all", "list": true, "push": true},
+		"follow": {"include": "all", "list": true, "push": true},
+		"chat":   {"include": "all", "push": true},
+	}
+
+	fields, _ := parseAssignments([]string{"like.push=false", "like.include=follows", "follow.push=true"})
+	changes, err := notificationChanges(current, fields)
+	if err != nil {
+		t.Fatalf("notificationChanges failed: %v", err)
+	}
+	if len(changes) != 1 || changes["like"]["push"] != false || changes["like"]["include"] != "follows" || changes["like"]["list"] != true {
+		t.Errorf("expected only like to change, keeping its other settings, got %v", changes)
+	}
+	if current["like"]["push"] != true {
+		t.Error("expected current settings left untouched")
+	}
+
+	for _, bad := range []string{"likes.push=false", "chat.list=true", "like.push=yes", "push=false"} {
+		fields, _ := parseAssignments([]string{bad})
+		if _, err := notificationChanges(current, fields); err == nil {
+			t.Errorf("expected %q to be refused", bad)
+		}
+	}
+}
@@ -0,0 +1,633 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// readThreadDepth is how many levels of replies an expanded thread shows
+const readThreadDepth = 6
+
+// readTextLines caps how many lines of a post's text the reader shows before cutting it off
+const readTextLines = 6
+
+// readKeyHelp is the key reminder at the bottom of the reader
+const readKeyHelp = "j/k move · n/p page · enter thread · esc back · l like · t repost · r reply · g newest · q quit"
+
+// ReadCommand returns the read command, a full-screen reader for the home timeline and custom feeds
+func ReadCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "read",
+		Usage:     "Read your timeline or a custom feed in a full-screen reader",
+		ArgsUsage: "[feed-uri-or-url]",
+		Description: `Opens the home timeline, or the custom feed given as an AT URI or bsky.app link, one page at a time.
+
+   j/k or the arrow keys move between posts, running on to the next or previous page at either end.
+   n/p (or Page Down/Page Up) change pages, and g goes back to the newest posts. Enter expands the
+   selected post's thread and Esc closes it. l likes, t reposts, and r replies to the selected post;
+   'skycli undo' reverts them. q quits.
+
+   The reader remembers the page and post it was on for each feed and resumes there next time.`,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"l"},
+				Usage:   "Posts per page (max 100)",
+				Value:   25,
+			},
+			&cli.BoolFlag{
+				Name:  "from-top",
+				Usage: "Start at the newest posts instead of where the last session left off",
+			},
+		},
+		Action: withRegistry(ReadAction),
+	}
+}
+
+// ReadAction opens the reader on the timeline or a custom feed and runs it until the user quits
+func ReadAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Bool("no-input") {
+		return ui.ErrNotInteractive
+	}
+	limit := cmd.Int("limit")
+	if limit < 1 || limit > 100 {
+		return fmt.Errorf("--limit must be between 1 and 100")
+	}
+
+	feeds, err := reg.GetFeedReader()
+	if err != nil {
+		return fmt.Errorf("failed to get feed reader: %w", err)
+	}
+	if !feeds.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+	writer, err := reg.GetPostWriter()
+	if err != nil {
+		return fmt.Errorf("failed to get post writer: %w", err)
+	}
+
+	session := &readSession{
+		feed:     bsky.ReadPositionTimeline,
+		title:    "Timeline",
+		pages:    bsky.TimelinePages(feeds),
+		threads:  feeds,
+		writer:   writer,
+		pageSize: limit,
+	}
+	if cmd.Args().Len() > 0 {
+		uri, err := resolveFeedURI(ctx, reg, cmd.Args().First())
+		if err != nil {
+			return err
+		}
+		session.feed = uri
+		session.title = "Feed " + uri
+		session.pages = bsky.CustomFeedPages(feeds, uri)
+	}
+
+	if session.actions, err = reg.GetActionRepo(); err != nil {
+		logger.Debug("Undo log unavailable", "error", err)
+		session.actions = nil
+	}
+	var resume *bsky.ReadPosition
+	if session.positions, err = reg.GetReadPositionRepo(); err != nil {
+		logger.Debug("Read positions unavailable", "error", err)
+		session.positions = nil
+	} else if !cmd.Bool("from-top") {
+		if resume, err = session.positions.Get(ctx, session.feed); err != nil {
+			logger.Warn("Failed to read the saved position", "error", err)
+		}
+	}
+
+	// Load before taking over the screen so a failure prints like any other error
+	if err := session.open(ctx, resume); err != nil {
+		return fmt.Errorf("failed to load feed: %w", err)
+	}
+
+	screen, err := ui.OpenScreen(os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer screen.Close()
+
+	for {
+		width, height := screen.Size()
+		screen.Draw(session.render(width, height))
+
+		key, err := screen.ReadKey()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		quit, err := session.handle(ctx, key, screen.Prompt)
+		if err != nil {
+			return err
+		}
+		if quit {
+			break
+		}
+	}
+
+	session.savePosition(ctx)
+	return nil
+}
+
+// resolveFeedURI turns a feed generator AT URI or bsky.app link into an AT URI with a DID, as getFeed requires
+func resolveFeedURI(ctx context.Context, reg *registry.Registry, input string) (string, error) {
+	ref, err := aturi.ParseFeed(input)
+	if err != nil {
+		return "", err
+	}
+	if !ref.HasDID() {
+		profiles, err := reg.GetProfileFetcher()
+		if err != nil {
+			return "", fmt.Errorf("failed to get profile fetcher: %w", err)
+		}
+		profile, err := profiles.GetProfile(ctx, ref.Authority)
+		if err != nil {
+			return "", profileError("feed owner", ref.Authority, err)
+		}
+		ref.Authority = profile.Did
+	}
+	return ref.String(), nil
+}
+
+// readItem is one post on the reader's screen
+type readItem struct {
+	post    *bsky.PostView
+	reason  string // why a feed shows the post, such as a repost
+	depth   int    // reply depth in an expanded thread
+	missing string // placeholder for a thread post that was deleted or is hidden by a block
+}
+
+// readList is a scrolling list of posts with one selected
+type readList struct {
+	items    []readItem
+	selected int
+	top      int // first item drawn
+}
+
+// readSession is the state of one reader run: the page of the feed being read, a thread expanded over it, and a
+// status line. It draws into lines and reacts to keys, leaving the terminal to its caller.
+type readSession struct {
+	feed      string // key of the saved position: [bsky.ReadPositionTimeline] or the feed URI
+	title     string
+	pages     bsky.PageFunc[bsky.FeedViewPost]
+	threads   bsky.FeedReader
+	writer    bsky.PostWriter
+	actions   *bsky.ActionRepository       // undo log, when available
+	positions *bsky.ReadPositionRepository // saved positions, when available
+	pageSize  int
+
+	cursor  string   // cursor the current page was fetched with; "" for the newest posts
+	next    string   // cursor of the following page; "" at the end of the feed
+	history []string // cursors of the pages read before this one, for paging back
+	list    readList
+	thread  *readList // expanded thread, shown over the feed until closed
+	status  string
+}
+
+// open loads the page the last session left off on and selects the post it was on, falling back to the newest
+// posts when there's no saved position or it no longer loads
+func (s *readSession) open(ctx context.Context, resume *bsky.ReadPosition) error {
+	if resume == nil {
+		return s.load(ctx, "")
+	}
+	if err := s.load(ctx, resume.Cursor); err != nil {
+		logger.Debug("Failed to resume", "cursor", resume.Cursor, "error", err)
+		s.status = "Couldn't resume where you left off; showing the newest posts"
+		return s.load(ctx, "")
+	}
+	for i, item := range s.list.items {
+		if item.post.Uri == resume.PostURI {
+			s.list.selected = i
+		}
+	}
+	s.status = "Resumed where you left off; g for the newest posts"
+	return nil
+}
+
+// load replaces the feed list with the page at cursor, leaving it untouched when the fetch fails
+func (s *readSession) load(ctx context.Context, cursor string) error {
+	posts, next, err := s.pages(ctx, s.pageSize, cursor)
+	if err != nil {
+		return err
+	}
+
+	items := make([]readItem, 0, len(posts))
+	for _, post := range posts {
+		if post.Post == nil {
+			continue
+		}
+		item := readItem{post: post.Post}
+		if post.Reason != nil && post.Reason.By != nil {
+			item.reason = "reposted by @" + post.Reason.By.Handle
+		}
+		items = append(items, item)
+	}
+
+	s.cursor, s.next = cursor, next
+	s.list = readList{items: items}
+	return nil
+}
+
+// current returns the list the keys act on: the open thread, or else the feed
+func (s *readSession) current() *readList {
+	if s.thread != nil {
+		return s.thread
+	}
+	return &s.list
+}
+
+// selectedPost returns the post under the selection, or nil when there is none or it's a placeholder
+func (s *readSession) selectedPost() *bsky.PostView {
+	list := s.current()
+	if list.selected >= len(list.items) {
+		return nil
+	}
+	return list.items[list.selected].post
+}
+
+// handle reacts to one key and reports whether the reader should quit. Failed requests are shown in the status
+// line; only a failure to read a reply from the terminal is returned.
+func (s *readSession) handle(ctx context.Context, key ui.Key, prompt func(string) (string, error)) (bool, error) {
+	s.status = ""
+	list := s.current()
+
+	switch key {
+	case "q", ui.KeyCtrlC:
+		return true, nil
+	case "j", ui.KeyDown:
+		if list.selected < len(list.items)-1 {
+			list.selected++
+		} else if s.thread == nil {
+			s.nextPage(ctx)
+		}
+	case "k", ui.KeyUp:
+		if list.selected > 0 {
+			list.selected--
+		} else if s.thread == nil {
+			s.previousPage(ctx, true)
+		}
+	case "n", " ", ui.KeyPageDown:
+		if s.thread == nil {
+			s.nextPage(ctx)
+		}
+	case "p", ui.KeyPageUp:
+		if s.thread == nil {
+			s.previousPage(ctx, false)
+		}
+	case "g", ui.KeyHome:
+		if s.thread != nil {
+			s.thread.selected = 0
+			break
+		}
+		if err := s.load(ctx, ""); err != nil {
+			s.status = fmt.Sprintf("Failed to load the newest posts: %v", err)
+			break
+		}
+		s.history = nil
+	case ui.KeyEnter, "o":
+		s.openThread(ctx)
+	case ui.KeyEscape, ui.KeyBack, "h":
+		s.thread = nil
+	case "l":
+		s.like(ctx)
+	case "t":
+		s.repost(ctx)
+	case "r":
+		return false, s.reply(ctx, prompt)
+	}
+	return false, nil
+}
+
+// nextPage moves on to the following page of the feed
+func (s *readSession) nextPage(ctx context.Context) {
+	if s.next == "" {
+		s.status = "End of the feed"
+		return
+	}
+	previous := s.cursor
+	if err := s.load(ctx, s.next); err != nil {
+		s.status = fmt.Sprintf("Failed to load the next page: %v", err)
+		return
+	}
+	s.history = append(s.history, previous)
+	s.savePosition(ctx)
+}
+
+// previousPage goes back to the page read before this one, selecting its last post when moving up past the top
+func (s *readSession) previousPage(ctx context.Context, selectLast bool) {
+	if len(s.history) == 0 {
+		if s.cursor == "" {
+			s.status = "Already at the newest posts"
+		} else {
+			s.status = "No earlier page this session; g for the newest posts"
+		}
+		return
+	}
+	if err := s.load(ctx, s.history[len(s.history)-1]); err != nil {
+		s.status = fmt.Sprintf("Failed to load the previous page: %v", err)
+		return
+	}
+	s.history = s.history[:len(s.history)-1]
+	if selectLast && len(s.list.items) > 0 {
+		s.list.selected = len(s.list.items) - 1
+	}
+	s.savePosition(ctx)
+}
+
+// openThread expands the selected post's thread: its parents, the post itself, and replies below it
+func (s *readSession) openThread(ctx context.Context) {
+	post := s.selectedPost()
+	if post == nil {
+		return
+	}
+	response, err := s.threads.GetPostThread(ctx, post.Uri, readThreadDepth)
+	if err != nil {
+		s.status = fmt.Sprintf("Failed to load the thread: %v", err)
+		return
+	}
+	if response.Thread == nil {
+		s.status = "Thread not found"
+		return
+	}
+
+	var parents []*bsky.ThreadViewPost
+	for parent := response.Thread.Parent; parent != nil; parent = parent.Parent {
+		parents = append(parents, parent)
+	}
+	thread := &readList{}
+	for i := len(parents) - 1; i >= 0; i-- {
+		thread.items = append(thread.items, threadItem(parents[i], 0))
+	}
+	thread.selected = len(thread.items)
+	thread.items = append(thread.items, threadItem(response.Thread, 0))
+	thread.items = appendReplies(thread.items, response.Thread.Replies, 1)
+	s.thread = thread
+}
+
+// threadItem turns a node of a thread into a reader item, with a placeholder for posts that can't be shown
+func threadItem(node *bsky.ThreadViewPost, depth int) readItem {
+	item := readItem{post: node.Post, depth: depth}
+	if node.Post == nil {
+		switch {
+		case node.NotFound:
+			item.missing = "deleted post"
+		case node.Blocked:
+			item.missing = "blocked post"
+		default:
+			item.missing = "unavailable post"
+		}
+	}
+	return item
+}
+
+// appendReplies adds replies and their own replies depth first, indented one level per step
+func appendReplies(items []readItem, replies []*bsky.ThreadViewPost, depth int) []readItem {
+	for _, reply := range replies {
+		items = append(items, threadItem(reply, depth))
+		items = appendReplies(items, reply.Replies, depth+1)
+	}
+	return items
+}
+
+// like likes the selected post
+func (s *readSession) like(ctx context.Context) {
+	post := s.selectedPost()
+	if post == nil {
+		return
+	}
+	if post.Viewer != nil && post.Viewer.Like != "" {
+		s.status = "Already liked"
+		return
+	}
+	record, err := s.writer.Like(ctx, post.Uri, post.Cid)
+	if err != nil {
+		s.status = fmt.Sprintf("Failed to like: %v", err)
+		return
+	}
+	if post.Viewer == nil {
+		post.Viewer = &bsky.ViewerState{}
+	}
+	post.Viewer.Like = record.Uri
+	post.LikeCount++
+	s.recordAction(ctx, bsky.ActionLike, post, record.Uri)
+	s.status = "Liked post by @" + post.Author.Handle
+}
+
+// repost reposts the selected post
+func (s *readSession) repost(ctx context.Context) {
+	post := s.selectedPost()
+	if post == nil {
+		return
+	}
+	if post.Viewer != nil && post.Viewer.Repost != "" {
+		s.status = "Already reposted"
+		return
+	}
+	record, err := s.writer.Repost(ctx, post.Uri, post.Cid)
+	if err != nil {
+		s.status = fmt.Sprintf("Failed to repost: %v", err)
+		return
+	}
+	if post.Viewer == nil {
+		post.Viewer = &bsky.ViewerState{}
+	}
+	post.Viewer.Repost = record.Uri
+	post.RepostCount++
+	s.recordAction(ctx, bsky.ActionRepost, post, record.Uri)
+	s.status = "Reposted post by @" + post.Author.Handle
+}
+
+// reply asks for text and posts it as a reply to the selected post
+func (s *readSession) reply(ctx context.Context, prompt func(string) (string, error)) error {
+	post := s.selectedPost()
+	if post == nil {
+		return nil
+	}
+	text, err := prompt(fmt.Sprintf("Reply to @%s (empty to cancel):", post.Author.Handle))
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		s.status = "Reply cancelled"
+		return nil
+	}
+	if bsky.GraphemeLength(text) > bsky.MaxPostGraphemes {
+		s.status = fmt.Sprintf("Reply not sent: it's over %d characters", bsky.MaxPostGraphemes)
+		return nil
+	}
+
+	parent := bsky.PostRef{Uri: post.Uri, Cid: post.Cid}
+	record, err := s.writer.Reply(ctx, text, parent, replyRoot(post))
+	if err != nil {
+		s.status = fmt.Sprintf("Failed to reply: %v", err)
+		return nil
+	}
+	post.ReplyCount++
+	s.recordAction(ctx, bsky.ActionReply, post, record.Uri)
+	s.status = "Replied to @" + post.Author.Handle
+	return nil
+}
+
+// recordAction adds a like, repost, or reply to the undo log when one is available
+func (s *readSession) recordAction(ctx context.Context, kind string, post *bsky.PostView, uri string) {
+	if s.actions == nil {
+		return
+	}
+	subject := ""
+	if post.Author != nil {
+		subject = post.Author.Did
+	}
+	recordAction(ctx, s.actions, kind, subject, uri)
+}
+
+// replyRoot returns the thread root a reply to post must point at: the root post's own reply points at, or post
+// itself when it starts the thread. Unlike [threadRoot] it needs the root's CID too.
+func replyRoot(post *bsky.PostView) bsky.PostRef {
+	if record, ok := post.Record.(map[string]any); ok {
+		if reply, ok := record["reply"].(map[string]any); ok {
+			if root, ok := reply["root"].(map[string]any); ok {
+				uri, _ := root["uri"].(string)
+				cid, _ := root["cid"].(string)
+				if uri != "" && cid != "" {
+					return bsky.PostRef{Uri: uri, Cid: cid}
+				}
+			}
+		}
+	}
+	return bsky.PostRef{Uri: post.Uri, Cid: post.Cid}
+}
+
+// savePosition stores the feed page and post being read so the next session resumes there
+func (s *readSession) savePosition(ctx context.Context) {
+	if s.positions == nil {
+		return
+	}
+	uri := ""
+	if s.list.selected < len(s.list.items) {
+		uri = s.list.items[s.list.selected].post.Uri
+	}
+	if err := s.positions.Save(ctx, s.feed, s.cursor, uri); err != nil {
+		logger.Warn("Failed to save the read position", "error", err)
+	}
+}
+
+// render draws the reader into exactly height lines: a title, the posts scrolled to keep the selected one in
+// view, a status line, and the key help
+func (s *readSession) render(width, height int) []string {
+	title := s.title
+	switch {
+	case s.thread != nil:
+		title += " · thread"
+	case s.cursor == "":
+		title += " · newest posts"
+	default:
+		title += " · older posts"
+	}
+	lines := []string{ui.TitleStyle.Render(title)}
+
+	body := max(height-3, 1)
+	list := s.current()
+	var rows []string
+	if len(list.items) == 0 {
+		rows = []string{ui.InfoStyle.Render("  No posts here.")}
+	} else {
+		blocks := make([][]string, len(list.items))
+		for i, item := range list.items {
+			blocks[i] = renderReadItem(item, i == list.selected, width)
+		}
+		list.top = min(list.top, list.selected)
+		for list.top < list.selected && blockLines(blocks[list.top:list.selected+1]) > body {
+			list.top++
+		}
+		for _, block := range blocks[list.top:] {
+			rows = append(rows, block...)
+			if len(rows) >= body {
+				break
+			}
+		}
+	}
+	rows = rows[:min(len(rows), body)]
+	for len(rows) < body {
+		rows = append(rows, "")
+	}
+	lines = append(lines, rows...)
+
+	lines = append(lines, ui.AccentStyle.Render(s.status))
+	return append(lines, ui.InfoStyle.MaxWidth(width).Render(readKeyHelp))
+}
+
+// blockLines counts the lines in a run of rendered posts
+func blockLines(blocks [][]string) int {
+	n := 0
+	for _, block := range blocks {
+		n += len(block)
+	}
+	return n
+}
+
+// renderReadItem renders one post as a header with author and age, an optional reason, its text, its counts, and
+// a blank separator line
+func renderReadItem(item readItem, selected bool, width int) []string {
+	marker := "  "
+	if selected {
+		marker = "▌ "
+	}
+	indent := strings.Repeat("  ", item.depth)
+	if item.post == nil {
+		return []string{marker + indent + ui.InfoStyle.Render("["+item.missing+"]"), ""}
+	}
+	post := item.post
+
+	author := "unknown"
+	if post.Author != nil {
+		author = "@" + post.Author.Handle
+		if post.Author.DisplayName != "" {
+			author += " · " + post.Author.DisplayName
+		}
+	}
+	headerStyle := ui.SubtitleStyle
+	if selected {
+		headerStyle = ui.AccentStyle.Bold(true)
+	}
+	age := ui.FormatDateString(post.IndexedAt, ui.DatesRelative)
+	lines := []string{marker + indent + headerStyle.Render(author) + ui.InfoStyle.Render("  "+age)}
+	if item.reason != "" {
+		lines = append(lines, "  "+indent+ui.InfoStyle.Render("↻ "+item.reason))
+	}
+
+	if text := postText(post); text != "" {
+		wrapped := strings.Split(lipgloss.NewStyle().Width(max(width-len(indent)-4, 20)).Render(text), "\n")
+		if len(wrapped) > readTextLines {
+			wrapped = wrapped[:readTextLines]
+			wrapped[readTextLines-1] = strings.TrimRight(wrapped[readTextLines-1], " ") + " …"
+		}
+		for _, line := range wrapped {
+			lines = append(lines, "  "+indent+ui.TextStyle.Render(strings.TrimRight(line, " ")))
+		}
+	}
+
+	liked, reposted := "♡", "↻"
+	if post.Viewer != nil && post.Viewer.Like != "" {
+		liked = "♥"
+	}
+	if post.Viewer != nil && post.Viewer.Repost != "" {
+		reposted = "↻✓"
+	}
+	counts := fmt.Sprintf("%s %d   %s %d   ↩ %d", liked, post.LikeCount, reposted, post.RepostCount, post.ReplyCount)
+	return append(lines, "  "+indent+ui.InfoStyle.Render(counts), "")
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// fakeFeed serves a two-page timeline and records the posts, likes, reposts, and replies made through it
+type fakeFeed struct {
+	pages   map[string]*bsky.GetTimelineResponse
+	posts   []bsky.NewPost
+	uploads []string // MIME types of uploaded blobs
+	liked   []string
+	reposts []string
+	replies []string // "text -> parent (root)"
+}
+
+func newFakeFeed() *fakeFeed {
+	post := func(n int, record map[string]any) bsky.FeedViewPost {
+		return bsky.FeedViewPost{Post: &bsky.PostView{
+			Uri:    fmt.Sprintf("at://did:plc:author%d/app.bsky.feed.post/%d", n, n),
+			Cid:    fmt.Sprintf("cid%d", n),
+			Author: &bsky.ActorProfile{Did: fmt.Sprintf("did:plc:author%d", n), Handle: fmt.Sprintf("author%d.test", n)},
+			Record: record,
+		}}
+	}
+	reply := map[string]any{
+		"text": "second",
+		"reply": map[string]any{
+			"root":   map[string]any{"uri": "at://did:plc:root/app.bsky.feed.post/0", "cid": "cid0"},
+			"parent": map[string]any{"uri": "at://did:plc:root/app.bsky.feed.post/0", "cid": "cid0"},
+		},
+	}
+	return &fakeFeed{pages: map[string]*bsky.GetTimelineResponse{
+		"":       {Cursor: "page-2", Feed: []bsky.FeedViewPost{post(1, map[string]any{"text": "first"}), post(2, reply)}},
+		"page-2": {Feed: []bsky.FeedViewPost{post(3, map[string]any{"text": "third"})}},
+	}}
+}
+
+func (f *fakeFeed) Authenticated() bool { return true }
+
+func (f *fakeFeed) GetTimeline(ctx context.Context, limit int, cursor string) (*bsky.GetTimelineResponse, error) {
+	page, ok := f.pages[cursor]
+	if !ok {
+		return nil, fmt.Errorf("unknown cursor %q", cursor)
+	}
+	return page, nil
+}
+
+func (f *fakeFeed) GetFeed(ctx context.Context, feed string, limit int, cursor string) (*bsky.GetFeedResponse, error) {
+	return nil, fmt.Errorf("no custom feeds")
+}
+
+func (f *fakeFeed) GetPostThread(ctx context.Context, uri string, depth int) (*bsky.GetPostThreadResponse, error) {
+	return &bsky.GetPostThreadResponse{Thread: &bsky.ThreadViewPost{
+		Post:   &bsky.PostView{Uri: uri, Author: &bsky.ActorProfile{Handle: "author2.test"}},
+		Parent: &bsky.ThreadViewPost{Uri: "at://did:plc:root/app.bsky.feed.post/0", NotFound: true},
+		Replies: []*bsky.ThreadViewPost{{
+			Post:    &bsky.PostView{Uri: "at://did:plc:x/app.bsky.feed.post/r1", Author: &bsky.ActorProfile{Handle: "x.test"}},
+			Replies: []*bsky.ThreadViewPost{{Blocked: true}},
+		}},
+	}}, nil
+}
+
+func (f *fakeFeed) CreatePost(ctx context.Context, post bsky.NewPost) (*bsky.CreateRecordResponse, error) {
+	f.posts = append(f.posts, post)
+	return &bsky.CreateRecordResponse{Uri: fmt.Sprintf("at://did:plc:me/app.bsky.feed.post/new%d", len(f.posts))}, nil
+}
+
+func (f *fakeFeed) UploadBlob(ctx context.Context, data []byte, mimeType string) (*bsky.Blob, error) {
+	f.uploads = append(f.uploads, mimeType)
+	return &bsky.Blob{Type: "blob", Ref: bsky.BlobRef{Link: "bafkblob"}, MimeType: mimeType, Size: len(data)}, nil
+}
+
+func (f *fakeFeed) Like(ctx context.Context, uri, cid string) (*bsky.CreateRecordResponse, error) {
+	f.liked = append(f.liked, uri)
+	return &bsky.CreateRecordResponse{Uri: "at://did:plc:me/app.bsky.feed.like/1"}, nil
+}
+
+func (f *fakeFeed) Repost(ctx context.Context, uri, cid string) (*bsky.CreateRecordResponse, error) {
+	f.reposts = append(f.reposts, uri)
+	return &bsky.CreateRecordResponse{Uri: "at://did:plc:me/app.bsky.feed.repost/1"}, nil
+}
+
+func (f *fakeFeed) Reply(ctx context.Context, text string, parent, root bsky.PostRef) (*bsky.CreateRecordResponse, error) {
+	f.replies = append(f.replies, fmt.Sprintf("%s -> %s (%s %s)", text, parent.Uri, root.Uri, root.Cid))
+	return &bsky.CreateRecordResponse{Uri: "at://did:plc:me/app.bsky.feed.post/1"}, nil
+}
+
+func TestReadSession(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	positions, err := bsky.NewReadPositionRepository()
+	if err != nil {
+		t.Fatalf("NewReadPositionRepository failed: %v", err)
+	}
+	t.Cleanup(func() { positions.Close() })
+	if err := positions.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	actions, err := bsky.NewActionRepository()
+	if err != nil {
+		t.Fatalf("NewActionRepository failed: %v", err)
+	}
+	t.Cleanup(func() { actions.Close() })
+	if err := actions.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	feed := newFakeFeed()
+	newSession := func() *readSession {
+		return &readSession{
+			feed: bsky.ReadPositionTimeline, title: "Timeline", pages: bsky.TimelinePages(feed),
+			threads: feed, writer: feed, actions: actions, positions: positions, pageSize: 10,
+		}
+	}
+	answers := []string{"agreed", ""}
+	prompt := func(string) (string, error) {
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, nil
+	}
+	press := func(s *readSession, keys ...ui.Key) {
+		t.Helper()
+		for _, key := range keys {
+			if quit, err := s.handle(ctx, key, prompt); err != nil || quit {
+				t.Fatalf("key %q: quit %v, err %v", key, quit, err)
+			}
+		}
+	}
+	selected := func(s *readSession) string {
+		if post := s.selectedPost(); post != nil {
+			return post.Uri
+		}
+		return ""
+	}
+
+	s := newSession()
+	if err := s.open(ctx, nil); err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+
+	// Moving down past the last post turns the page, and up past the first turns it back
+	press(s, "j", "j")
+	if s.cursor != "page-2" || selected(s) != "at://did:plc:author3/app.bsky.feed.post/3" {
+		t.Fatalf("expected the second page, got cursor %q and %s", s.cursor, selected(s))
+	}
+	press(s, "l", "l", "t")
+	if len(feed.liked) != 1 || len(feed.reposts) != 1 || !strings.Contains(s.status, "Reposted") {
+		t.Errorf("expected one like and one repost, got %v %v (status %q)", feed.liked, feed.reposts, s.status)
+	}
+	press(s, "j")
+	if s.status != "End of the feed" {
+		t.Errorf("status = %q, want the end of the feed", s.status)
+	}
+
+	press(s, ui.KeyUp)
+	if s.cursor != "" || selected(s) != "at://did:plc:author2/app.bsky.feed.post/2" {
+		t.Fatalf("expected the last post of the first page, got cursor %q and %s", s.cursor, selected(s))
+	}
+
+	// Replies keep the thread root of the post they answer; an empty answer cancels
+	press(s, "r", "r")
+	want := "agreed -> at://did:plc:author2/app.bsky.feed.post/2 (at://did:plc:root/app.bsky.feed.post/0 cid0)"
+	if len(feed.replies) != 1 || feed.replies[0] != want || s.status != "Reply cancelled" {
+		t.Errorf("replies = %v (status %q), want [%s]", feed.replies, s.status, want)
+	}
+
+	logged, err := actions.Recent(ctx, 0, false)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(logged) != 3 {
+		t.Errorf("expected the like, repost, and reply in the undo log, got %+v", logged)
+	}
+
+	press(s, ui.KeyEnter)
+	if s.thread == nil || len(s.thread.items) != 4 || s.thread.selected != 1 {
+		t.Fatalf("unexpected thread %+v", s.thread)
+	}
+	screen := strings.Join(s.render(80, 30), "\n")
+	for _, text := range []string{"Timeline · thread", "[deleted post]", "@x.test", "[blocked post]"} {
+		if !strings.Contains(screen, text) {
+			t.Errorf("thread view is missing %q:\n%s", text, screen)
+		}
+	}
+	press(s, ui.KeyEscape)
+	if s.thread != nil {
+		t.Error("Esc should close the thread")
+	}
+
+	if lines := s.render(80, 8); len(lines) != 8 || !strings.Contains(strings.Join(lines, "\n"), "@author2.test") {
+		t.Errorf("render should fill the screen and keep the selected post visible:\n%s", strings.Join(lines, "\n"))
+	}
+
+	if quit, _ := s.handle(ctx, "q", prompt); !quit {
+		t.Fatal("q should quit")
+	}
+	s.savePosition(ctx)
+
+	// The next session picks up on the same page and post
+	resume, err := positions.Get(ctx, bsky.ReadPositionTimeline)
+	if err != nil || resume == nil {
+		t.Fatalf("expected a saved position, got %+v (err %v)", resume, err)
+	}
+	next := newSession()
+	if err := next.open(ctx, resume); err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if next.cursor != "" || selected(next) != "at://did:plc:author2/app.bsky.feed.post/2" {
+		t.Errorf("expected to resume at the second post, got cursor %q and %s", next.cursor, selected(next))
+	}
+
+	stale := newSession()
+	if err := stale.open(ctx, &bsky.ReadPosition{Cursor: "expired"}); err != nil || stale.cursor != "" || !strings.Contains(stale.status, "Couldn't resume") {
+		t.Errorf("expected a stale cursor to fall back to the newest posts, got cursor %q, status %q, err %v", stale.cursor, stale.status, err)
+	}
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/verify"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// recordSummaryFields are the record fields tried, in order, to sum a record up in one line
+var recordSummaryFields = []string{"text", "displayName", "name", "subject", "description"}
+
+// RecordCommand returns the record command with subcommands for reading raw repository records
+func RecordCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "record",
+		Usage: "Read raw records from any repository",
+		Commands: []*cli.Command{
+			{
+				Name:      "get",
+				Usage:     "Show one record",
+				UsageText: "Fetch a record of any type by AT URI (or bsky.app link) with com.atproto.repo.getRecord and print its fields.",
+				ArgsUsage: "<at-uri>",
+				Flags: append([]cli.Flag{
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output the record as returned by the PDS",
+					},
+				}, verifyFlags()...),
+				Action: withRegistry(RecordGetAction),
+			},
+			{
+				Name:      "list",
+				Usage:     "List the records in a collection",
+				UsageText: "List records in one collection of an account's repository with com.atproto.repo.listRecords, newest first.",
+				ArgsUsage: "<handle-or-did> <collection>",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum number of records to list",
+						Value:   50,
+					},
+					&cli.StringFlag{
+						Name:    "cursor",
+						Aliases: []string{"c"},
+						Usage:   "Pagination cursor for listing additional records",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output raw JSON response",
+					},
+				},
+				Action: withRegistry(RecordListAction),
+			},
+		},
+	}
+}
+
+// RecordGetAction fetches and prints the record at an AT URI
+func RecordGetAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("record AT URI required")
+	}
+
+	ref, err := aturi.Parse(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	if !ref.IsRecord() {
+		return fmt.Errorf("%q names an account, not a record; use 'skycli record list %s <collection>'", cmd.Args().First(), ref.Authority)
+	}
+
+	fetcher, err := reg.GetRecordFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get record fetcher: %w", err)
+	}
+
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	logger.Debug("Fetching record", "uri", ref.String())
+
+	record, err := fetcher.GetRecord(ctx, ref.Authority, ref.Collection, ref.Rkey)
+	if err != nil {
+		return fmt.Errorf("failed to fetch record %s: %w", ref.String(), err)
+	}
+
+	var verification *verify.Result
+	if cmd.Bool("verify") {
+		verification = verifyRecord(ctx, cmd, ref.Authority, ref.Collection, ref.Rkey, *record)
+	}
+
+	if cmd.Bool("json") {
+		if verification != nil {
+			if err := ui.DisplayJSON(verifiedRecord{Record: record, Verification: verification}); err != nil {
+				return err
+			}
+			return verificationError(verification)
+		}
+		return ui.DisplayJSON(record)
+	}
+
+	value, err := decodeRecordValue(record.Value)
+	if err != nil {
+		return fmt.Errorf("failed to decode record %s: %w", record.Uri, err)
+	}
+
+	recordType := ref.Collection
+	if fields, ok := value.(map[string]any); ok {
+		if t, ok := fields["$type"].(string); ok {
+			recordType = t
+		}
+	}
+
+	ui.Titleln("%s", recordType)
+	ui.Infoln("URI: %s", record.Uri)
+	if record.Cid != "" {
+		ui.Infoln("CID: %s", record.Cid)
+	}
+	fmt.Println()
+	fmt.Print(formatRecordValue(value))
+
+	if verification != nil {
+		displayVerification(verification)
+		return verificationError(verification)
+	}
+	return nil
+}
+
+// RecordListAction lists the records in one collection of an account's repository
+func RecordListAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() < 2 {
+		return fmt.Errorf("account and collection required, e.g. 'skycli record list alice.bsky.social app.bsky.feed.like'")
+	}
+
+	repo, err := parseActorArg("account", cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	collection := cmd.Args().Get(1)
+	if err := aturi.ValidateNSID(collection); err != nil {
+		return err
+	}
+
+	limit := cmd.Int("limit")
+	if limit <= 0 {
+		return fmt.Errorf("limit must be greater than zero")
+	}
+
+	fetcher, err := reg.GetRecordFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get record fetcher: %w", err)
+	}
+
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	logger.Debug("Listing records", "repo", repo, "collection", collection, "limit", limit)
+
+	paginator := bsky.NewPaginator(bsky.RecordPages(fetcher, repo, collection), bsky.PaginatorOptions{Cursor: cmd.String("cursor"), MaxItems: limit})
+	records, err := paginator.All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list records: %w", err)
+	}
+	response := &bsky.ListRecordsResponse{Records: records, Cursor: paginator.Cursor()}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(response)
+	}
+
+	displayRecords(collection, response)
+	return nil
+}
+
+// displayRecords renders a page of records as a table of record keys, creation times, and one-line summaries
+func displayRecords(collection string, response *bsky.ListRecordsResponse) {
+	if len(response.Records) == 0 {
+		ui.Infoln("No %s records found", collection)
+		return
+	}
+
+	ui.Titleln("%s (%d)", collection, len(response.Records))
+	fmt.Println()
+
+	rows := make([][]string, len(response.Records))
+	for i, record := range response.Records {
+		rkey := record.Uri[strings.LastIndex(record.Uri, "/")+1:]
+		var createdAt, summary string
+		if value, err := decodeRecordValue(record.Value); err == nil {
+			createdAt, summary = summarizeRecord(value)
+		}
+		rows[i] = []string{rkey, ui.FormatDateString(createdAt, ui.DatesISO), summary}
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("Record key", "Created", "Summary").Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	fmt.Println(re.NewStyle().Render(t.String()))
+	if response.Cursor != "" {
+		ui.Infoln("Next cursor: %s", response.Cursor)
+	}
+	fmt.Println()
+}
+
+// decodeRecordValue decodes a raw record value, keeping numbers as written
+func decodeRecordValue(raw json.RawMessage) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// summarizeRecord returns a record's createdAt and the first of [recordSummaryFields] it has, cut to one short line
+func summarizeRecord(value any) (string, string) {
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return "", ""
+	}
+
+	createdAt, _ := fields["createdAt"].(string)
+	for _, name := range recordSummaryFields {
+		var summary string
+		switch field := fields[name].(type) {
+		case string:
+			summary = field
+		case map[string]any:
+			// Strong references such as a like's subject
+			summary, _ = field["uri"].(string)
+		}
+		if summary = strings.Join(strings.Fields(summary), " "); summary != "" {
+			if runes := []rune(summary); len(runes) > 80 {
+				summary = string(runes[:80]) + "..."
+			}
+			return createdAt, summary
+		}
+	}
+	return createdAt, ""
+}
+
+// formatRecordValue renders a decoded record value as indented "key: value" lines, with $type first and the other
+// keys sorted. Lists of plain values share a line; lists of objects get one "-" item each.
+func formatRecordValue(value any) string {
+	var b strings.Builder
+	writeRecordValue(&b, value, "")
+	return b.String()
+}
+
+// writeRecordValue writes value to b at the given indent; see [formatRecordValue]
+func writeRecordValue(b *strings.Builder, value any, indent string) {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.SortFunc(keys, func(x, y string) int {
+			switch {
+			case x == "$type":
+				return -1
+			case y == "$type":
+				return 1
+			}
+			return strings.Compare(x, y)
+		})
+
+		for _, key := range keys {
+			if scalar, ok := recordScalar(v[key]); ok {
+				fmt.Fprintf(b, "%s%s: %s\n", indent, key, indentLines(scalar, indent+"  "))
+				continue
+			}
+			fmt.Fprintf(b, "%s%s:\n", indent, key)
+			writeRecordValue(b, v[key], indent+"  ")
+		}
+	case []any:
+		for _, item := range v {
+			if scalar, ok := recordScalar(item); ok {
+				fmt.Fprintf(b, "%s- %s\n", indent, indentLines(scalar, indent+"  "))
+				continue
+			}
+			fmt.Fprintf(b, "%s-\n", indent)
+			writeRecordValue(b, item, indent+"  ")
+		}
+	default:
+		scalar, _ := recordScalar(v)
+		fmt.Fprintf(b, "%s%s\n", indent, scalar)
+	}
+}
+
+// recordScalar formats values that fit after a key on one line: strings, numbers, booleans, null, empty containers,
+// and lists of plain values. It reports false for anything that needs lines of its own.
+func recordScalar(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "null", true
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return fmt.Sprint(v), true
+	case map[string]any:
+		if len(v) == 0 {
+			return "{}", true
+		}
+		return "", false
+	case []any:
+		if len(v) == 0 {
+			return "[]", true
+		}
+		items := make([]string, len(v))
+		for i, item := range v {
+			scalar, ok := recordScalar(item)
+			if !ok || strings.Contains(scalar, "\n") {
+				return "", false
+			}
+			items[i] = scalar
+		}
+		return "[" + strings.Join(items, ", ") + "]", true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// indentLines indents every line of s after the first, so multi-line text stays under its key
+func indentLines(s, indent string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+indent)
+}
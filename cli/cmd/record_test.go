@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestFormatRecordValue(t *testing.T) {
+	value, err := decodeRecordValue(json.RawMessage(`{
+		"text": "two\nlines",
+		"$type": "app.bsky.feed.post",
+		"langs": ["en", "de"],
+		"reply": {"root": {"uri": "at://did:plc:a/app.bsky.feed.post/1", "cid": "bafy"}},
+		"facets": [{"index": {"byteStart": 0, "byteEnd": 3}}],
+		"labels": [],
+		"count": 12345678901234
+	}`))
+	if err != nil {
+		t.Fatalf("decodeRecordValue failed: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"$type: app.bsky.feed.post",
+		"count: 12345678901234",
+		"facets:",
+		"  -",
+		"    index:",
+		"      byteEnd: 3",
+		"      byteStart: 0",
+		"labels: []",
+		"langs: [en, de]",
+		"reply:",
+		"  root:",
+		"    cid: bafy",
+		"    uri: at://did:plc:a/app.bsky.feed.post/1",
+		"text: two",
+		"  lines",
+	}, "\n") + "\n"
+	if got := formatRecordValue(value); got != want {
+		t.Errorf("formatRecordValue =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSummarizeRecord(t *testing.T) {
+	for _, tc := range []struct {
+		raw, createdAt, summary string
+	}{
+		{`{"text": "hello\n  world", "createdAt": "2026-10-01T08:00:00Z"}`, "2026-10-01T08:00:00Z", "hello world"},
+		{`{"subject": {"uri": "at://did:plc:a/app.bsky.feed.post/1", "cid": "bafy"}}`, "", "at://did:plc:a/app.bsky.feed.post/1"},
+		{`{"subject": "did:plc:a", "createdAt": "2026-10-01T08:00:00Z"}`, "2026-10-01T08:00:00Z", "did:plc:a"},
+		{`{"list": "at://did:plc:a/app.bsky.graph.list/1"}`, "", ""},
+	} {
+		value, err := decodeRecordValue(json.RawMessage(tc.raw))
+		if err != nil {
+			t.Fatalf("decodeRecordValue(%s) failed: %v", tc.raw, err)
+		}
+		if createdAt, summary := summarizeRecord(value); createdAt != tc.createdAt || summary != tc.summary {
+			t.Errorf("summarizeRecord(%s) = %q, %q; want %q, %q", tc.raw, createdAt, summary, tc.createdAt, tc.summary)
+		}
+	}
+}
+
+func TestRecordGetAction_Errors(t *testing.T) {
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", records: map[string][]bsky.Record{}}
+
+	_, err := runSubcommand(t, RecordCommand(), "get", RecordGetAction, newFakeRegistry(graph), "at://did:plc:me")
+	if err == nil || !strings.Contains(err.Error(), "names an account") {
+		t.Errorf("expected an account URI to be refused, got %v", err)
+	}
+
+	_, err = runSubcommand(t, RecordCommand(), "get", RecordGetAction, newFakeRegistry(graph), "at://did:plc:me/app.bsky.feed.post/3k")
+	if err == nil || !strings.Contains(err.Error(), "RecordNotFound") {
+		t.Errorf("expected the missing record to fail, got %v", err)
+	}
+
+	_, err = runSubcommand(t, RecordCommand(), "list", RecordListAction, newFakeRegistry(graph), "did:plc:me", "likes")
+	if err == nil || !strings.Contains(err.Error(), "must be an NSID") {
+		t.Errorf("expected a bad collection to be refused, got %v", err)
+	}
+}
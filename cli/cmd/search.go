@@ -3,23 +3,21 @@ package main
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
-	"github.com/stormlightlabs/skypanel/cli/internal/setup"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
 // SearchUsersAction searches for users (actors) by query string
-func SearchUsersAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
+func SearchUsersAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("search query required")
 	}
@@ -84,13 +82,7 @@ func SearchUsersAction(ctx context.Context, cmd *cli.Command) error {
 }
 
 // SearchPostsAction searches for posts by query string
-func SearchPostsAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
+func SearchPostsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("search query required")
 	}
@@ -99,6 +91,14 @@ func SearchPostsAction(ctx context.Context, cmd *cli.Command) error {
 	limit := cmd.Int("limit")
 	cursor := cmd.String("cursor")
 	asJSON := cmd.Bool("json")
+	localFirst := cmd.Bool("local-first")
+
+	if localFirst && cmd.String("author") == "" {
+		return fmt.Errorf("--local-first needs --author, e.g. --author me")
+	}
+	if localFirst && cursor != "" {
+		return fmt.Errorf("--local-first can't resume from a --cursor")
+	}
 
 	service, err := reg.GetService()
 	if err != nil {
@@ -109,13 +109,75 @@ func SearchPostsAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("not authenticated: run 'skycli login' first")
 	}
 
-	logger.Debug("Searching posts", "query", query, "limit", limit, "cursor", cursor)
+	now := userNow()
+	since, err := parseSince(cmd.String("since"), now)
+	if err != nil {
+		return err
+	}
+	until, err := parseUntil(cmd.String("until"), now)
+	if err != nil {
+		return err
+	}
+
+	var author string
+	switch value := cmd.String("author"); value {
+	case "":
+	case "me":
+		author = service.GetDid()
+	default:
+		if author, err = resolveAuthorDID(ctx, reg, "--author", value); err != nil {
+			return err
+		}
+	}
+
+	logger.Debug("Searching posts", "query", query, "author", author, "limit", limit, "cursor", cursor, "since", since, "until", until, "localFirst", localFirst)
 
-	result, err := service.SearchPosts(ctx, query, limit, cursor)
+	if localFirst {
+		postRepo, err := reg.GetPostRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get post repository: %w", err)
+		}
+
+		search := func(ctx context.Context, since, until time.Time, limit int) ([]bsky.FeedViewPost, error) {
+			result, err := service.SearchPosts(ctx, query, bsky.SearchPostsOptions{Author: author, Limit: limit, Since: since, Until: until})
+			if err != nil {
+				return nil, err
+			}
+			return result.Posts, nil
+		}
+		result, err := localFirstSearch(ctx, postRepo, author, query, since, until, limit, search)
+		if err != nil {
+			return err
+		}
+
+		if name := cmd.String("save-as"); name != "" {
+			posts := make([]bsky.FeedViewPost, len(result.Posts))
+			for i, hit := range result.Posts {
+				posts[i] = hit.Post
+			}
+			if err := saveSearchFeed(ctx, cmd, reg, name, searchFeedParams(cmd, query, author), posts); err != nil {
+				return err
+			}
+		}
+
+		if asJSON {
+			return ui.DisplayJSON(result)
+		}
+		displaySearchHits(query, result)
+		return nil
+	}
+
+	result, err := service.SearchPosts(ctx, query, bsky.SearchPostsOptions{Author: author, Limit: limit, Cursor: cursor, Since: since, Until: until})
 	if err != nil {
 		return fmt.Errorf("failed to search posts: %w", err)
 	}
 
+	if name := cmd.String("save-as"); name != "" {
+		if err := saveSearchFeed(ctx, cmd, reg, name, searchFeedParams(cmd, query, author), result.Posts); err != nil {
+			return err
+		}
+	}
+
 	if asJSON {
 		return ui.DisplayJSON(result)
 	}
@@ -131,14 +193,365 @@ func SearchPostsAction(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-// SearchFeedsAction searches for feeds in the local database by name or source
-func SearchFeedsAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
+// searchSourcePrefix marks the Source of local feeds holding saved search results, e.g. search:rust jobs
+const searchSourcePrefix = "search:"
+
+// searchFeedSave counts what saving search results into a local feed did
+type searchFeedSave struct {
+	Saved     int // posts stored in the feed, including ones it already held
+	New       int // posts the feed didn't hold before
+	Elsewhere int // posts left in the other local feed already holding them
+}
+
+// searchFeedParams records the search a saved search feed was filled by, so it can be run again
+func searchFeedParams(cmd *cli.Command, query, author string) map[string]string {
+	params := map[string]string{"query": query}
+	if author != "" {
+		params["author"] = author
+	}
+	for _, flag := range []string{"since", "until"} {
+		if value := cmd.String(flag); value != "" {
+			params[flag] = value
+		}
+	}
+	return params
+}
+
+// saveSearchFeed stores search results in the local feed named name, creating it on first use, and reports what
+// was saved. Running the same search with the same name again adds new results and refreshes the stored ones.
+func saveSearchFeed(ctx context.Context, cmd *cli.Command, reg *registry.Registry, name string, params map[string]string, posts []bsky.FeedViewPost) error {
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get feed repository: %w", err)
+	}
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	feed, result, err := storeSearchResults(ctx, feedRepo, postRepo, name, params, posts)
+	if err != nil {
+		return err
 	}
 
-	reg := registry.Get()
+	report := ui.Successln
+	if cmd.Bool("json") {
+		report = logger.Infof
+	}
+	report("Saved %d post(s) to feed %s (%d new): %s", result.Saved, feed.Name, result.New, feed.ID())
+	if result.Elsewhere > 0 {
+		logger.Infof("%d post(s) were already archived in another feed and stay there", result.Elsewhere)
+	}
+	return nil
+}
+
+// storeSearchResults saves posts into the search feed named name with the search's params. Posts already stored
+// under another local feed are left in it, since a post belongs to a single feed.
+func storeSearchResults(ctx context.Context, feedRepo *bsky.FeedRepository, postRepo *bsky.PostRepository, name string, params map[string]string, posts []bsky.FeedViewPost) (*bsky.FeedModel, searchFeedSave, error) {
+	var result searchFeedSave
+	if strings.TrimSpace(name) == "" {
+		return nil, result, fmt.Errorf("--save-as needs a feed name")
+	}
 
+	feed, created, err := localFeed(ctx, feedRepo, searchSourcePrefix+name, name)
+	if err != nil {
+		return nil, result, fmt.Errorf("failed to create search feed: %w", err)
+	}
+	if created {
+		logger.Info("Created search feed", "name", name, "feed", feed.ID())
+	}
+	if !maps.Equal(feed.Params, params) {
+		feed.Params = params
+		feed.TouchUpdatedAt()
+		if err := feedRepo.Save(ctx, feed); err != nil {
+			return nil, result, fmt.Errorf("failed to update search feed: %w", err)
+		}
+	}
+
+	uris := make([]string, 0, len(posts))
+	for _, item := range posts {
+		if item.Post != nil {
+			uris = append(uris, item.Post.Uri)
+		}
+	}
+	stored, err := postRepo.StoredFeedIDs(ctx, uris)
+	if err != nil {
+		return nil, result, fmt.Errorf("failed to check archived posts: %w", err)
+	}
+
+	models := make([]*bsky.PostModel, 0, len(uris))
+	for _, item := range posts {
+		if item.Post == nil {
+			continue
+		}
+		switch feedID, ok := stored[item.Post.Uri]; {
+		case !ok:
+			result.New++
+		case feedID != feed.ID():
+			result.Elsewhere++
+			continue
+		}
+		models = append(models, bsky.NewPostModel(feed.ID(), item.Post))
+	}
+	if err := postRepo.BatchSave(ctx, models); err != nil {
+		return nil, result, fmt.Errorf("failed to save posts: %w", err)
+	}
+	result.Saved = len(models)
+	return feed, result, nil
+}
+
+// Provenance of a local-first search hit
+const (
+	searchSourceLocal = "local"
+	searchSourceAPI   = "api"
+)
+
+// searchHit is one post found by a local-first search, with where it came from
+type searchHit struct {
+	Source string            `json:"source"`
+	Post   bsky.FeedViewPost `json:"post"`
+}
+
+// localSearchResult holds the merged hits of a local-first search and the API calls it took
+type localSearchResult struct {
+	Posts    []searchHit `json:"posts"`
+	APICalls int         `json:"apiCalls"`
+}
+
+// postSearchFunc searches the API for the author's matching posts created in [since, until); zero bounds are open
+type postSearchFunc func(ctx context.Context, since, until time.Time, limit int) ([]bsky.FeedViewPost, error)
+
+// localFirstSearch finds author's posts matching query's words in the local archive, then asks the API only for
+// the spans the archive doesn't cover: after its newest post by author, and before its oldest when the archive
+// alone can't fill limit. The archive is taken to hold every post by author between those two, as it does when
+// the backup task or an account import filled it. Results are merged newest first and marked with their source.
+func localFirstSearch(ctx context.Context, postRepo *bsky.PostRepository, author, query string, since, until time.Time, limit int, search postSearchFunc) (*localSearchResult, error) {
+	stats, err := postRepo.AuthorStats(ctx, bsky.PostQuery{Author: author})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive coverage: %w", err)
+	}
+
+	stored, err := postRepo.Query(ctx, bsky.PostQuery{Author: author, Since: since, Terms: strings.Fields(query)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search archive: %w", err)
+	}
+
+	result := &localSearchResult{}
+	seen := make(map[string]bool)
+	for _, post := range stored {
+		if !until.IsZero() && post.IndexedAt.After(until) {
+			continue
+		}
+		seen[post.URI] = true
+		result.Posts = append(result.Posts, searchHit{Source: searchSourceLocal, Post: storedFeedPost(post)})
+	}
+
+	fetch := func(since, until time.Time) error {
+		result.APICalls++
+		logger.Debug("Searching API for archive gap", "since", since, "until", until)
+		posts, err := search(ctx, since, until, limit)
+		if err != nil {
+			return fmt.Errorf("failed to search posts: %w", err)
+		}
+		for _, post := range posts {
+			if post.Post == nil || seen[post.Post.Uri] {
+				continue
+			}
+			seen[post.Post.Uri] = true
+			result.Posts = append(result.Posts, searchHit{Source: searchSourceAPI, Post: post})
+		}
+		return nil
+	}
+
+	if len(stats) == 0 {
+		if err := fetch(since, until); err != nil {
+			return nil, err
+		}
+	} else {
+		first, last := stats[0].FirstPost, stats[0].LastPost
+		if until.IsZero() || until.After(last) {
+			if err := fetch(latest(since, last), until); err != nil {
+				return nil, err
+			}
+		}
+		if len(result.Posts) < limit && (since.IsZero() || since.Before(first)) {
+			gapEnd := first
+			if !until.IsZero() && until.Before(first) {
+				gapEnd = until
+			}
+			if err := fetch(since, gapEnd); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	slices.SortStableFunc(result.Posts, func(a, b searchHit) int {
+		return strings.Compare(hitTime(b), hitTime(a))
+	})
+	if limit > 0 && len(result.Posts) > limit {
+		result.Posts = result.Posts[:limit]
+	}
+	return result, nil
+}
+
+// latest returns the later of two times
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// hitTime returns a hit's indexed time normalized to UTC RFC3339 so hits sort as strings
+func hitTime(hit searchHit) string {
+	indexedAt, err := time.Parse(time.RFC3339, hit.Post.Post.IndexedAt)
+	if err != nil {
+		return hit.Post.Post.IndexedAt
+	}
+	return indexedAt.UTC().Format(time.RFC3339Nano)
+}
+
+// storedFeedPost presents an archived post as a feed item, with the fields the archive keeps
+func storedFeedPost(post *bsky.PostModel) bsky.FeedViewPost {
+	return bsky.FeedViewPost{Post: &bsky.PostView{
+		Uri:       post.URI,
+		Author:    &bsky.ActorProfile{Did: post.AuthorDID},
+		Record:    map[string]any{"text": post.Text},
+		IndexedAt: post.IndexedAt.UTC().Format(time.RFC3339),
+	}}
+}
+
+// displaySearchHits renders local-first search results as a table marking where each post came from
+func displaySearchHits(query string, result *localSearchResult) {
+	if len(result.Posts) == 0 {
+		ui.Infoln("No posts found matching query: %s", query)
+		ui.Infoln("API calls: %d", result.APICalls)
+		return
+	}
+
+	local := 0
+	rows := make([][]string, 0, len(result.Posts))
+	for _, hit := range result.Posts {
+		if hit.Source == searchSourceLocal {
+			local++
+		}
+		text := strings.Join(strings.Fields(postText(hit.Post.Post)), " ")
+		if runes := []rune(text); len(runes) > 80 {
+			text = string(runes[:77]) + "..."
+		}
+		rows = append(rows, []string{hit.Source, ui.FormatDateString(hit.Post.Post.IndexedAt, ui.DatesISO), text, hit.Post.Post.Uri})
+	}
+
+	fit := ui.FitTable([]string{"Source", "Indexed", "Text", "URI"}, rows, map[string]int{"URI": 2, "Source": 1}, "Text")
+	t := lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(ui.TableBorderStyle).
+		Headers(fit.Headers...).
+		Rows(fit.Rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == lgtable.HeaderRow {
+				return ui.TableHeaderStyle
+			}
+			if row%2 == 0 {
+				return ui.TableRowEvenStyle
+			}
+			return ui.TableRowOddStyle
+		})
+
+	ui.Titleln("Search Results: %s", query)
+	fmt.Println(t)
+	fit.HiddenNote()
+	ui.Successln("Showing %d post(s): %d from the archive, %d from the API (%d API call(s))", len(result.Posts), local, len(result.Posts)-local, result.APICalls)
+}
+
+// localMatch is a stored post found by a local search, as written by --json
+type localMatch struct {
+	URI       string    `json:"uri"`
+	AuthorDID string    `json:"authorDid"`
+	FeedID    string    `json:"feedId"`
+	IndexedAt time.Time `json:"indexedAt"`
+	Text      string    `json:"text"`
+	Snippet   string    `json:"snippet"` // matched words in **bold**
+}
+
+// SearchLocalAction searches the text of posts stored in the local cache, without network access
+func SearchLocalAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("search query required")
+	}
+	query := strings.Join(cmd.Args().Slice(), " ")
+	limit := cmd.Int("limit")
+	offset := cmd.Int("offset")
+	if limit < 0 || offset < 0 {
+		return fmt.Errorf("--limit and --offset must be 0 or more")
+	}
+
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	logger.Debug("Searching stored posts", "query", query, "limit", limit, "offset", offset)
+	matches, err := postRepo.SearchText(ctx, query, limit, offset)
+	if err != nil {
+		return fmt.Errorf("failed to search stored posts: %w", err)
+	}
+
+	if cmd.Bool("json") {
+		output := make([]localMatch, len(matches))
+		for i, match := range matches {
+			output[i] = localMatch{
+				URI:       match.Post.URI,
+				AuthorDID: match.Post.AuthorDID,
+				FeedID:    match.Post.FeedID,
+				IndexedAt: match.Post.IndexedAt,
+				Text:      match.Post.Text,
+				Snippet:   markSnippet(match.Snippet, func(word string) string { return "**" + word + "**" }),
+			}
+		}
+		return ui.DisplayJSON(output)
+	}
+
+	if len(matches) == 0 {
+		ui.Infoln("No stored posts found matching query: %s", query)
+		return nil
+	}
+
+	highlight := ui.AccentStyle.Bold(true)
+	ui.Titleln("Local Search Results: %s", query)
+	fmt.Println()
+	for i, match := range matches {
+		ui.Subtitleln("[%d] %s · %s", offset+i+1, match.Post.AuthorDID, ui.FormatDate(match.Post.IndexedAt, ui.DatesLocal))
+		fmt.Println("  " + markSnippet(match.Snippet, func(word string) string { return highlight.Render(word) }))
+		ui.Infoln("  %s", match.Post.URI)
+		fmt.Println()
+	}
+
+	ui.Successln("Showing %d post(s)", len(matches))
+	if limit > 0 && len(matches) == limit {
+		ui.Infoln("More may match: rerun with --offset %d", offset+limit)
+	}
+	return nil
+}
+
+// markSnippet collapses a search snippet's whitespace onto one line and passes each matched word through mark
+func markSnippet(snippet string, mark func(string) string) string {
+	snippet = strings.Join(strings.Fields(snippet), " ")
+	var b strings.Builder
+	for i, part := range strings.Split(snippet, bsky.SnippetStart) {
+		if i == 0 {
+			b.WriteString(part)
+			continue
+		}
+		word, rest, _ := strings.Cut(part, bsky.SnippetEnd)
+		b.WriteString(mark(word))
+		b.WriteString(rest)
+	}
+	return b.String()
+}
+
+// SearchFeedsAction searches for feeds in the local database by name or source
+func SearchFeedsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("search query required")
 	}
@@ -159,11 +572,11 @@ func SearchFeedsAction(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	var matchingFeeds []store.Model
+	var matchingFeeds []bsky.Model
 	queryLower := strings.ToLower(query)
 
 	for _, model := range allFeeds {
-		if feed, ok := model.(*store.FeedModel); ok {
+		if feed, ok := model.(*bsky.FeedModel); ok {
 			nameLower := strings.ToLower(feed.Name)
 			sourceLower := strings.ToLower(feed.Source)
 			if strings.Contains(nameLower, queryLower) || strings.Contains(sourceLower, queryLower) {
@@ -185,7 +598,7 @@ func SearchFeedsAction(ctx context.Context, cmd *cli.Command) error {
 	fmt.Println()
 
 	for i, model := range matchingFeeds {
-		if feed, ok := model.(*store.FeedModel); ok {
+		if feed, ok := model.(*bsky.FeedModel); ok {
 			ui.Subtitleln("[%d] %s", i+1, feed.Name)
 			ui.Infoln("  ID: %s", feed.ID())
 			ui.Infoln("  Source: %s", feed.Source)
@@ -198,7 +611,7 @@ func SearchFeedsAction(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-// SearchCommand returns the search command with subcommands for users, posts, and feeds
+// SearchCommand returns the search command with subcommands for users, posts, stored posts, and feeds
 func SearchCommand() *cli.Command {
 	commonFlags := []cli.Flag{
 		&cli.IntFlag{
@@ -236,21 +649,67 @@ func SearchCommand() *cli.Command {
 				Usage:     "Search for users by handle or name",
 				ArgsUsage: "<query>",
 				Flags:     commonFlags,
-				Action:    SearchUsersAction,
+				Action:    withRegistry(SearchUsersAction),
 			},
 			{
 				Name:      "posts",
 				Usage:     "Search for posts by text content",
 				ArgsUsage: "<query>",
-				Flags:     commonFlags,
-				Action:    SearchPostsAction,
+				Flags: append(slices.Clone(commonFlags),
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only posts created since a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 7d), or expression (yesterday, last monday)",
+					},
+					&cli.StringFlag{
+						Name:  "until",
+						Usage: "Only posts created up to a date (inclusive), time, lookback, or expression",
+					},
+					&cli.StringFlag{
+						Name:    "author",
+						Aliases: []string{"a"},
+						Usage:   "Only posts by this account (handle, DID, or me)",
+					},
+					&cli.BoolFlag{
+						Name:  "local-first",
+						Usage: "With --author, search the local archive first and ask the API only for spans it doesn't cover",
+					},
+					&cli.StringFlag{
+						Name:  "save-as",
+						Usage: "Store the results in a local feed with this name, created on first use; run the search again to add to it",
+					},
+				),
+				Action: withRegistry(SearchPostsAction),
+			},
+			{
+				Name:      "local",
+				Usage:     "Search the text of posts stored in the local cache, offline",
+				UsageText: "Find stored posts containing every word of the query, newest first, with the matched words highlighted. Matching ignores case and accents. Put \"double quotes\" around a phrase, and end a word with * to match its prefix, as in deploy*. Posts from fetch, archive, import, and the backup task are searched; posts in the trash are not.",
+				ArgsUsage: "<query>",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum number of results to return (0 for all)",
+						Value:   25,
+					},
+					&cli.IntFlag{
+						Name:  "offset",
+						Usage: "Skip this many results, for the next page",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output as JSON",
+					},
+				},
+				Action: withRegistry(SearchLocalAction),
 			},
 			{
 				Name:      "feeds",
 				Usage:     "Search local feeds by name or source (local search only)",
 				ArgsUsage: "<query>",
 				Flags:     feedFlags,
-				Action:    SearchFeedsAction,
+				Action:    withRegistry(SearchFeedsAction),
 			},
 		},
 	}
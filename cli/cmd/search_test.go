@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestLocalFirstSearch(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	feed, _, err := localFeed(ctx, feedRepo, backupSourcePrefix+"posts", "Backup: posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The archive covers Feb through Apr; only two of its posts mention gardens
+	post := func(rkey, text string, at time.Time) bsky.FeedViewPost {
+		item := feedItem("did:plc:me", rkey, nil)
+		item.Post.Record = map[string]any{"text": text}
+		item.Post.IndexedAt = at.Format(time.RFC3339)
+		return item
+	}
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC) }
+	var models []*bsky.PostModel
+	for _, item := range []bsky.FeedViewPost{
+		post("feb", "Garden planning", day(time.February, 1)),
+		post("mar", "Nothing to see", day(time.March, 1)),
+		post("apr", "the garden is blooming", day(time.April, 1)),
+	} {
+		models = append(models, bsky.NewPostModel(feed.ID(), item.Post))
+	}
+	if err := postRepo.BatchSave(ctx, models); err != nil {
+		t.Fatal(err)
+	}
+
+	type call struct{ since, until time.Time }
+	var calls []call
+	api := map[string]bsky.FeedViewPost{
+		"jan": post("jan", "winter garden", day(time.January, 1)),
+		"apr": post("apr", "the garden is blooming", day(time.April, 1)),
+		"may": post("may", "garden harvest", day(time.May, 1)),
+	}
+	search := func(ctx context.Context, since, until time.Time, limit int) ([]bsky.FeedViewPost, error) {
+		calls = append(calls, call{since, until})
+		var posts []bsky.FeedViewPost
+		for _, item := range api {
+			at, _ := time.Parse(time.RFC3339, item.Post.IndexedAt)
+			if (since.IsZero() || !at.Before(since)) && (until.IsZero() || at.Before(until)) {
+				posts = append(posts, item)
+			}
+		}
+		return posts, nil
+	}
+
+	t.Run("newer gap only", func(t *testing.T) {
+		calls = nil
+		result, err := localFirstSearch(ctx, postRepo, "did:plc:me", "garden", time.Time{}, time.Time{}, 3, search)
+		if err != nil {
+			t.Fatalf("localFirstSearch failed: %v", err)
+		}
+		if len(calls) != 1 || result.APICalls != 1 || !calls[0].since.Equal(day(time.April, 1)) {
+			t.Fatalf("expected one API call after the archive, got %+v", calls)
+		}
+
+		var got []string
+		for _, hit := range result.Posts {
+			got = append(got, hit.Source+":"+hit.Post.Post.Uri[len(hit.Post.Post.Uri)-3:])
+		}
+		want := []string{"api:may", "local:apr", "local:feb"}
+		if len(got) != len(want) {
+			t.Fatalf("hits = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("hits = %v, want %v", got, want)
+			}
+		}
+	})
+
+	t.Run("older gap when short", func(t *testing.T) {
+		calls = nil
+		result, err := localFirstSearch(ctx, postRepo, "did:plc:me", "garden", time.Time{}, time.Time{}, 10, search)
+		if err != nil {
+			t.Fatalf("localFirstSearch failed: %v", err)
+		}
+		if len(calls) != 2 || !calls[1].until.Equal(day(time.February, 1)) {
+			t.Fatalf("expected a second call before the archive, got %+v", calls)
+		}
+		if len(result.Posts) != 4 || result.Posts[3].Source != searchSourceAPI {
+			t.Errorf("unexpected hits %+v", result.Posts)
+		}
+	})
+
+	t.Run("window inside archive", func(t *testing.T) {
+		calls = nil
+		result, err := localFirstSearch(ctx, postRepo, "did:plc:me", "garden", day(time.February, 1), day(time.March, 15), 10, search)
+		if err != nil {
+			t.Fatalf("localFirstSearch failed: %v", err)
+		}
+		if len(calls) != 0 || len(result.Posts) != 1 || result.Posts[0].Source != searchSourceLocal {
+			t.Errorf("expected one local hit and no API calls, got %+v with calls %+v", result.Posts, calls)
+		}
+	})
+
+	t.Run("empty archive", func(t *testing.T) {
+		calls = nil
+		result, err := localFirstSearch(ctx, postRepo, "did:plc:other", "garden", time.Time{}, time.Time{}, 10, search)
+		if err != nil {
+			t.Fatalf("localFirstSearch failed: %v", err)
+		}
+		if len(calls) != 1 || !calls[0].since.IsZero() || !calls[0].until.IsZero() || result.APICalls != 1 {
+			t.Errorf("expected one unbounded API call, got %+v", calls)
+		}
+	})
+}
+
+func TestSearchLocalAction(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	feed, _, err := localFeed(ctx, feedRepo, backupSourcePrefix+"posts", "Backup: posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := feedItem("did:plc:me", "1", nil)
+	item.Post.Record = map[string]any{"text": "The garden\nis blooming"}
+	if err := postRepo.BatchSave(ctx, []*bsky.PostModel{bsky.NewPostModel(feed.ID(), item.Post)}); err != nil {
+		t.Fatal(err)
+	}
+	reg := registry.New(registry.Dependencies{FeedRepo: feedRepo, PostRepo: postRepo})
+
+	if _, err := runSubcommand(t, SearchCommand(), "local", SearchLocalAction, reg, "garden"); err != nil {
+		t.Fatalf("SearchLocalAction failed: %v", err)
+	}
+	if _, err := runSubcommand(t, SearchCommand(), "local", SearchLocalAction, reg, "--"); err == nil {
+		t.Error("expected a search without a query to be refused")
+	}
+	if _, err := runSubcommand(t, SearchCommand(), "local", SearchLocalAction, reg, "*"); err == nil {
+		t.Error("expected a query without words to be refused")
+	}
+
+	matches, err := postRepo.SearchText(ctx, "BLOOM*", 0, 0)
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected one match, got %v (%v)", matches, err)
+	}
+	bold := func(word string) string { return "**" + word + "**" }
+	if got := markSnippet(matches[0].Snippet, bold); got != "The garden is **blooming**" {
+		t.Errorf("unexpected snippet %q", got)
+	}
+}
+
+func TestStoreSearchResults(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	other, _, err := localFeed(ctx, feedRepo, backupSourcePrefix+"posts", "Backup: posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := postRepo.Save(ctx, bsky.NewPostModel(other.ID(), feedItem("did:plc:me", "c", nil).Post)); err != nil {
+		t.Fatal(err)
+	}
+
+	params := map[string]string{"query": "rust jobs"}
+	first := []bsky.FeedViewPost{feedItem("did:plc:alice", "a", nil), feedItem("did:plc:me", "c", nil), {}}
+	feed, result, err := storeSearchResults(ctx, feedRepo, postRepo, "Rust jobs", params, first)
+	if err != nil {
+		t.Fatalf("storeSearchResults failed: %v", err)
+	}
+	if feed.Name != "Rust jobs" || feed.Source != searchSourcePrefix+"Rust jobs" || !feed.IsLocal {
+		t.Errorf("unexpected search feed %+v", feed)
+	}
+	if result != (searchFeedSave{Saved: 1, New: 1, Elsewhere: 1}) {
+		t.Errorf("expected one new post and one left in the backup feed, got %+v", result)
+	}
+
+	// Running the search again adds to the same feed and records the latest params
+	params = map[string]string{"query": "rust jobs", "since": "7d"}
+	again, result, err := storeSearchResults(ctx, feedRepo, postRepo, "Rust jobs", params, []bsky.FeedViewPost{feedItem("did:plc:alice", "a", nil), feedItem("did:plc:bob", "b", nil)})
+	if err != nil {
+		t.Fatalf("storeSearchResults failed: %v", err)
+	}
+	if again.ID() != feed.ID() || result != (searchFeedSave{Saved: 2, New: 1}) {
+		t.Errorf("expected the second run to add one post to %s, got %+v in %s", feed.ID(), result, again.ID())
+	}
+
+	stored, err := feedRepo.Get(ctx, feed.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stored.(*bsky.FeedModel).Params; got["since"] != "7d" || got["query"] != "rust jobs" {
+		t.Errorf("expected the latest search params stored, got %v", got)
+	}
+	posts, err := postRepo.QueryByFeedID(ctx, feed.ID(), 10, 0)
+	if err != nil || len(posts) != 2 {
+		t.Errorf("expected 2 posts in the search feed, got %d (err %v)", len(posts), err)
+	}
+
+	if all, err := resolveSavedFeeds(ctx, feedRepo, nil, true); err != nil || len(all) != 0 {
+		t.Errorf("expected search and backup feeds left out of --all, got %d (err %v)", len(all), err)
+	}
+
+	if _, _, err := storeSearchResults(ctx, feedRepo, postRepo, " ", params, first); err == nil {
+		t.Error("expected a blank feed name to fail")
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// labelFinding is a moderation label applied to the user's profile or one of their posts by a labeler
+type labelFinding struct {
+	Subject   string `json:"subject"` // "profile" or "post"
+	Uri       string `json:"uri"`
+	Label     string `json:"label"`
+	Labeler   string `json:"labeler"`
+	AppliedAt string `json:"appliedAt,omitempty"`
+	New       bool   `json:"new"`
+}
+
+// ModerationSelfCheckAction lists labels on the user's own profile and recent posts, flagging ones not seen by
+// an earlier check
+func ModerationSelfCheckAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Int("posts") < 0 {
+		return fmt.Errorf("--posts must not be negative")
+	}
+
+	findings, err := checkOwnLabels(ctx, reg, cmd.Int("posts"))
+	if err != nil {
+		return err
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(findings)
+	}
+
+	displayLabelFindings(findings)
+	return nil
+}
+
+// checkOwnLabels fetches the user's profile and up to posts recent posts as seen through their subscribed
+// labelers (plus Bluesky's own), and records the labels found so later checks can tell which are new.
+// Self-labels are skipped.
+func checkOwnLabels(ctx context.Context, reg *registry.Registry, posts int) ([]labelFinding, error) {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+
+	if !fetcher.Authenticated() {
+		return nil, fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile fetcher: %w", err)
+	}
+
+	engagement, err := reg.GetEngagementFetcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get engagement fetcher: %w", err)
+	}
+
+	preferences, err := reg.GetPreferencesFetcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences fetcher: %w", err)
+	}
+
+	labelRepo, err := reg.GetLabelRepo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label log: %w", err)
+	}
+
+	prefs, err := preferences.GetPreferences(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch preferences: %w", err)
+	}
+
+	labelers := prefs.Labelers()
+	if !slices.Contains(labelers, bsky.BlueskyModerationDID) {
+		labelers = append(labelers, bsky.BlueskyModerationDID)
+	}
+	logger.Debug("Checking labels", "labelers", len(labelers))
+	labelCtx := bsky.WithAcceptLabelers(ctx, labelers)
+
+	me := fetcher.GetDid()
+
+	profile, err := profiles.GetProfile(labelCtx, me)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch profile: %w", err)
+	}
+
+	var recent []*bsky.PostView
+	if posts > 0 {
+		if recent, err = recentOwnPosts(labelCtx, engagement, me, posts); err != nil {
+			return nil, err
+		}
+	}
+
+	var labels []bsky.Label
+	var findings []labelFinding
+	collect := func(subject string, applied []bsky.Label) {
+		for _, label := range applied {
+			if label.Src == me {
+				continue
+			}
+			labels = append(labels, label)
+			findings = append(findings, labelFinding{
+				Subject:   subject,
+				Uri:       label.Uri,
+				Label:     label.Val,
+				Labeler:   label.Src,
+				AppliedAt: label.Cts,
+			})
+		}
+	}
+	collect("profile", profile.Labels)
+	for _, post := range recent {
+		collect("post", post.Labels)
+	}
+
+	fresh, err := labelRepo.Observe(ctx, labels, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to record labels: %w", err)
+	}
+
+	isNew := make(map[string]bool, len(fresh))
+	for _, label := range fresh {
+		isNew[label.Src+" "+label.Uri+" "+label.Val] = true
+	}
+	for i := range findings {
+		findings[i].New = isNew[findings[i].Labeler+" "+findings[i].Uri+" "+findings[i].Label]
+	}
+	return findings, nil
+}
+
+// displayLabelFindings warns about new labels and renders every current label as a table
+func displayLabelFindings(findings []labelFinding) {
+	if len(findings) == 0 {
+		ui.Successln("No moderation labels on your profile or recent posts")
+		return
+	}
+
+	newCount := 0
+	for _, finding := range findings {
+		if finding.New {
+			newCount++
+			ui.Warningln("New label %q on your %s from %s", finding.Label, finding.Subject, finding.Labeler)
+		}
+	}
+	if newCount > 0 {
+		fmt.Println()
+	}
+
+	ui.Titleln("Labels on your content (%d, %d new)", len(findings), newCount)
+	fmt.Println()
+
+	rows := make([][]string, len(findings))
+	for i, finding := range findings {
+		marker := ""
+		if finding.New {
+			marker = "new"
+		}
+		rows[i] = []string{
+			finding.Label,
+			finding.Subject,
+			finding.Uri,
+			finding.Labeler,
+			strings.TrimSuffix(finding.AppliedAt, "Z"),
+			marker,
+		}
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("Label", "On", "Subject", "Labeler", "Applied", "").Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	fmt.Println(re.NewStyle().Render(t.String()))
+	fmt.Println()
+}
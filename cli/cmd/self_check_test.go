@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestCheckOwnLabels(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	labels, err := bsky.NewLabelRepository()
+	if err != nil {
+		t.Fatalf("NewLabelRepository failed: %v", err)
+	}
+	if err := labels.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	t.Cleanup(func() { labels.Close() })
+
+	me := &bsky.ActorProfile{Did: "did:plc:me"}
+	post := &bsky.PostView{Uri: "at://did:plc:me/app.bsky.feed.post/1", Author: me, Labels: []bsky.Label{
+		{Src: "did:plc:me", Uri: "at://did:plc:me/app.bsky.feed.post/1", Val: "graphic-media"},
+		{Src: "did:plc:labeler", Uri: "at://did:plc:me/app.bsky.feed.post/1", Val: "rude"},
+	}}
+	graph := &fakeGraph{
+		authenticated: true,
+		did:           "did:plc:me",
+		labelers:      []string{"did:plc:labeler"},
+		profileLabels: []bsky.Label{{Src: bsky.BlueskyModerationDID, Uri: "did:plc:me", Val: "spam"}},
+		feed:          []bsky.FeedViewPost{{Post: post}},
+	}
+	reg := registry.New(registry.Dependencies{
+		FollowerFetcher: graph,
+		ProfileFetcher:  graph,
+		Engagement:      graph,
+		Preferences:     graph,
+		LabelRepo:       labels,
+	})
+
+	findings, err := checkOwnLabels(context.Background(), reg, 10)
+	if err != nil {
+		t.Fatalf("checkOwnLabels failed: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected self-labels to be skipped, got %+v", findings)
+	}
+	if findings[0].Subject != "profile" || findings[0].Label != "spam" || findings[1].Subject != "post" || findings[1].Label != "rude" {
+		t.Errorf("unexpected findings %+v", findings)
+	}
+	for _, finding := range findings {
+		if !finding.New {
+			t.Errorf("expected %q to be new on the first check", finding.Label)
+		}
+	}
+
+	post.Labels = append(post.Labels, bsky.Label{Src: "did:plc:labeler", Uri: post.Uri, Val: "misleading"})
+	findings, err = checkOwnLabels(context.Background(), reg, 10)
+	if err != nil {
+		t.Fatalf("checkOwnLabels failed: %v", err)
+	}
+	for _, finding := range findings {
+		if finding.New != (finding.Label == "misleading") {
+			t.Errorf("label %q: expected new=%v", finding.Label, !finding.New)
+		}
+	}
+}
@@ -7,8 +7,8 @@ import (
 	"os"
 
 	"github.com/stormlightlabs/skypanel/cli/internal/config"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
@@ -59,10 +59,10 @@ func SetupAction(ctx context.Context, cmd *cli.Command) error {
 	}
 	defer db.Close()
 
-	statusBefore, err := store.GetMigrationStatus(db)
+	statusBefore, err := bsky.GetMigrationStatus(db)
 	if err != nil && !dbExists {
 		logger.Debug("Migration status check returned error (expected for new database)", "error", err)
-		statusBefore = &store.MigrationStatus{CurrentVersion: 0, LatestVersion: 0, PendingCount: 0}
+		statusBefore = &bsky.MigrationStatus{CurrentVersion: 0, LatestVersion: 0, PendingCount: 0}
 	} else if err != nil {
 		logger.Error("Failed to check migration status", "error", err)
 		return err
@@ -74,12 +74,12 @@ func SetupAction(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	ui.Infoln("Running migrations...")
-	if err := store.RunMigrations(db); err != nil {
+	if err := bsky.RunMigrations(db); err != nil {
 		logger.Error("Failed to run migrations", "error", err)
 		return err
 	}
 
-	statusAfter, err := store.GetMigrationStatus(db)
+	statusAfter, err := bsky.GetMigrationStatus(db)
 	if err != nil {
 		logger.Error("Failed to verify migration status", "error", err)
 		return err
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/peterh/liner"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/urfave/cli/v3"
+)
+
+// shellHistoryFile is the name of the shell's history file in the config directory
+const shellHistoryFile = "shell_history"
+
+// shellExitWords end the shell when entered on their own
+var shellExitWords = []string{"exit", "quit"}
+
+// ShellCommand returns the shell command
+func ShellCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "shell",
+		Usage:     "Run commands interactively with the cache and session kept open",
+		UsageText: "Start a prompt that runs skycli commands without the 'skycli' prefix, e.g. 'followers list --limit 10'. The database, session, and caches stay open between commands, so each one skips startup; global flags that change the session, such as --account, --timeout, or --dates, go before 'shell'. Tab completes commands and flags, and history is kept across sessions. Type 'exit' or press Ctrl-D to leave.",
+		ArgsUsage: " ",
+		Action:    withRegistry(ShellAction),
+	}
+}
+
+// ShellAction reads command lines until exit, running each against reg
+func ShellAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	line := liner.NewLiner()
+	defer line.Close()
+
+	line.SetCtrlCAborts(true)
+	line.SetTabCompletionStyle(liner.TabPrints)
+	line.SetWordCompleter(shellCompleter(newApp(reg)))
+
+	historyPath, err := shellHistoryPath()
+	if err != nil {
+		logger.Warn("Shell history disabled", "error", err)
+	} else if file, err := os.Open(historyPath); err == nil {
+		line.ReadHistory(file)
+		file.Close()
+	}
+
+	ui.Infoln("skycli %s shell. Type 'help' for commands, 'exit' to leave.", version)
+	for {
+		input, err := line.Prompt("skycli> ")
+		if errors.Is(err, liner.ErrPromptAborted) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			fmt.Println()
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		args, err := utils.SplitArgs(input)
+		if err != nil {
+			ui.Errorln("%v", err)
+			continue
+		}
+		if len(args) == 0 {
+			continue
+		}
+		line.AppendHistory(input)
+
+		if len(args) == 1 && slices.Contains(shellExitWords, args[0]) {
+			break
+		}
+		if err := runShellLine(ctx, reg, args); err != nil {
+			ui.Errorln("%v", err)
+		}
+	}
+
+	if historyPath != "" {
+		if err := saveShellHistory(line, historyPath); err != nil {
+			logger.Warn("Failed to save shell history", "error", err)
+		}
+	}
+	return nil
+}
+
+// runShellLine runs one shell command line, returning its error so the shell keeps going
+func runShellLine(ctx context.Context, reg *registry.Registry, args []string) error {
+	if args[0] == "shell" {
+		return fmt.Errorf("already in the shell")
+	}
+
+	// Output settings like quiet mode are per command, not per session
+	ui.SetQuiet(false)
+	return runArgs(ctx, reg, args, "shell")
+}
+
+// shellHistoryPath returns where shell history is kept
+func shellHistoryPath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, shellHistoryFile), nil
+}
+
+// saveShellHistory writes the session's history, readable only by the owner since lines may hold identifiers
+func saveShellHistory(line *liner.State, path string) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := line.WriteHistory(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// shellCompleter completes the word at the cursor with the subcommands or flags of the command typed so far
+func shellCompleter(app *cli.Command) liner.WordCompleter {
+	return func(line string, pos int) (string, []string, string) {
+		before := line[:pos]
+		words := strings.Fields(before)
+		partial := ""
+		if len(words) > 0 && !strings.HasSuffix(before, " ") {
+			partial = words[len(words)-1]
+			words = words[:len(words)-1]
+		}
+
+		current := app
+		for _, word := range words {
+			if sub := current.Command(word); sub != nil {
+				current = sub
+			}
+		}
+
+		var candidates []string
+		if strings.HasPrefix(partial, "-") {
+			for _, flag := range current.VisibleFlags() {
+				for _, name := range flag.Names() {
+					if len(name) > 1 {
+						candidates = append(candidates, "--"+name)
+					}
+				}
+			}
+		} else {
+			for _, sub := range current.VisibleCommands() {
+				candidates = append(candidates, sub.Name)
+			}
+			if current == app {
+				candidates = append(candidates, shellExitWords...)
+			}
+		}
+
+		var completions []string
+		for _, candidate := range candidates {
+			if strings.HasPrefix(candidate, partial) && candidate != "shell" {
+				completions = append(completions, candidate+" ")
+			}
+		}
+		slices.Sort(completions)
+		return before[:len(before)-len(partial)], completions, line[pos:]
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestShellCompleter(t *testing.T) {
+	app := &cli.Command{
+		Name: "skycli",
+		Commands: []*cli.Command{
+			{Name: "followers", Commands: []*cli.Command{
+				{Name: "list", Flags: []cli.Flag{
+					&cli.IntFlag{Name: "limit", Aliases: []string{"l"}},
+					&cli.BoolFlag{Name: "inactive"},
+				}},
+				{Name: "export"},
+			}},
+			{Name: "fetch"},
+			{Name: "shell"},
+			{Name: "secret", Hidden: true},
+		},
+	}
+	complete := shellCompleter(app)
+
+	tests := []struct {
+		name     string
+		line     string
+		wantHead string
+		want     []string
+	}{
+		{name: "root", line: "f", wantHead: "", want: []string{"fetch ", "followers "}},
+		{name: "exit words", line: "qu", wantHead: "", want: []string{"quit "}},
+		{name: "hides shell and hidden", line: "s", wantHead: "", want: nil},
+		{name: "subcommands", line: "followers ", wantHead: "followers ", want: []string{"export ", "list "}},
+		{name: "subcommand prefix", line: "followers l", wantHead: "followers ", want: []string{"list "}},
+		{name: "flags", line: "followers list --", wantHead: "followers list ", want: []string{"--inactive ", "--limit "}},
+		{name: "flag prefix", line: "followers list --li", wantHead: "followers list ", want: []string{"--limit "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, got, tail := complete(tt.line, len(tt.line))
+			if head != tt.wantHead {
+				t.Errorf("head = %q, want %q", head, tt.wantHead)
+			}
+			if tail != "" {
+				t.Errorf("tail = %q, want empty", tail)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("completions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunShellLine(t *testing.T) {
+	reg := newFakeRegistry(&fakeGraph{})
+
+	if err := runShellLine(context.Background(), reg, []string{"help"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, args := range [][]string{{"--timeout", "1s", "help"}, {"help", "--proxy=socks5://127.0.0.1:9050"}, {"--debug-http", "help"}} {
+		err := runShellLine(context.Background(), reg, args)
+		if err == nil || !strings.Contains(err.Error(), "before 'shell'") {
+			t.Errorf("%v: expected the session flag to be rejected, got %v", args, err)
+		}
+	}
+	if err := runShellLine(context.Background(), reg, []string{"shell"}); err == nil {
+		t.Error("expected a nested shell to be rejected")
+	}
+}
+
+func TestCheckSessionFlags(t *testing.T) {
+	app := newApp(newFakeRegistry(&fakeGraph{}))
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"followers", "list", "--limit", "10"}, ""},
+		{[]string{"--yes", "trash", "empty"}, ""},
+		{[]string{"followers", "list", "--account", "alice.test"}, "--account"},
+		{[]string{"--wide=true", "followers", "list"}, "--wide"},
+		{[]string{"-timezone", "UTC", "help"}, "--timezone"},
+		{[]string{"post", "create", "--", "--verbose"}, ""},
+	}
+	for _, tt := range tests {
+		err := checkSessionFlags(app, tt.args, "shell")
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%v: unexpected error %v", tt.args, err)
+		case tt.want != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.want+" ")):
+			t.Errorf("%v: expected %s to be rejected, got %v", tt.args, tt.want, err)
+		}
+	}
+}
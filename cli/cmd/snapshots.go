@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/daemon"
+	"github.com/stormlightlabs/skypanel/cli/internal/export"
+	"github.com/stormlightlabs/skypanel/cli/internal/imports"
+	"github.com/stormlightlabs/skypanel/cli/internal/lockfile"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// ListSnapshotsAction lists stored follower/following snapshots
+func ListSnapshotsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	snapshotRepo, err := reg.GetSnapshotRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+
+	models, err := snapshotRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	if cmd.Bool("json") {
+		metas := make([]export.SnapshotMeta, 0, len(models))
+		for _, model := range models {
+			snapshot := model.(*bsky.SnapshotModel)
+			metas = append(metas, export.SnapshotMeta{
+				ID:           snapshot.ID(),
+				CreatedAt:    snapshot.CreatedAt(),
+				ExpiresAt:    snapshot.ExpiresAt,
+				UserDid:      snapshot.UserDid,
+				SnapshotType: snapshot.SnapshotType,
+				TotalCount:   snapshot.TotalCount,
+			})
+		}
+		return ui.DisplayJSON(metas)
+	}
+
+	if len(models) == 0 {
+		ui.Infoln("No snapshots stored.")
+		return nil
+	}
+
+	ui.Titleln("Snapshots")
+	for _, model := range models {
+		snapshot := model.(*bsky.SnapshotModel)
+		ui.Infoln("%s  %s  %-9s  %5d  %s", snapshot.ID(), snapshot.CreatedAt().Format("2006-01-02 15:04"), snapshot.SnapshotType, snapshot.TotalCount, snapshot.UserDid)
+	}
+	return nil
+}
+
+// ExportSnapshotAction writes a snapshot and its entries to a portable file
+func ExportSnapshotAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Bool("schema") {
+		return printExportSchema(cmd, export.SchemaSnapshot)
+	}
+
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("snapshot ID required")
+	}
+
+	snapshotID := cmd.Args().First()
+	format := strings.ToLower(cmd.String("format"))
+
+	if format != "json" {
+		return fmt.Errorf("invalid format for snapshot: %s (must be json)", format)
+	}
+
+	snapshotRepo, err := reg.GetSnapshotRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+
+	model, err := snapshotRepo.Get(ctx, snapshotID)
+	if err != nil {
+		return fmt.Errorf("snapshot not found: %w", err)
+	}
+	snapshot := model.(*bsky.SnapshotModel)
+
+	entries, err := snapshotRepo.GetEntries(ctx, snapshotID)
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot entries: %w", err)
+	}
+
+	filename := fmt.Sprintf("snapshot_%s_%s.%s", snapshotID, time.Now().Format("2006-01-02"), format)
+	filename, err = writeExport(cmd, filename, nil, func(w io.Writer) error {
+		return export.WriteSnapshotJSON(w, snapshot, entries)
+	})
+	if err != nil {
+		logger.Error("Failed to export", "error", err)
+		return err
+	}
+
+	ui.Successln("Exported snapshot %s (%d entries) to %s", snapshotID, len(entries), filename)
+	return nil
+}
+
+// ImportSnapshotAction loads a snapshot file exported on another machine, keeping its original ID
+func ImportSnapshotAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("snapshot file required")
+	}
+
+	snapshotRepo, err := reg.GetSnapshotRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+
+	snapshot, entries, err := imports.ParseSnapshotFile(cmd.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	if err := snapshotRepo.Import(ctx, snapshot, entries); err != nil {
+		return fmt.Errorf("failed to import snapshot: %w", err)
+	}
+
+	ui.Successln("Imported %s snapshot %s for %s (%d entries)", snapshot.SnapshotType, snapshot.ID(), snapshot.UserDid, len(entries))
+	ui.Infoln("Use it as a baseline with: skycli followers diff --since %s", snapshot.ID())
+	return nil
+}
+
+// minSnapshotInterval keeps the snapshot daemon from paging through the follower graph too often
+const minSnapshotInterval = 5 * time.Minute
+
+// snapshotLockFile is the lock in the config directory that keeps a second snapshot daemon from starting
+const snapshotLockFile = "snapshot-daemon.lock"
+
+// SnapshotDaemonAction takes follower and following snapshots every --interval until interrupted, or once with
+// --once, so 'followers diff' always has a recent baseline. A lockfile keeps a second daemon from running.
+func SnapshotDaemonAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	interval := cmd.Duration("interval")
+	if interval < minSnapshotInterval {
+		return fmt.Errorf("invalid --interval %s: must be at least %s", interval, minSnapshotInterval)
+	}
+	types := cmd.StringSlice("type")
+	for _, snapshotType := range types {
+		if snapshotType != "followers" && snapshotType != "following" {
+			return fmt.Errorf("invalid --type %q: must be followers or following", snapshotType)
+		}
+	}
+
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+	if !fetcher.Authenticated() {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+	snapshotRepo, err := reg.GetSnapshotRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lock, err := acquireSnapshotLock()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := lock.Release(); err != nil {
+			logger.Warn("Failed to remove lockfile", "path", lock.Path(), "error", err)
+		}
+	}()
+
+	tasks := make([]daemon.Task, len(types))
+	for i, snapshotType := range types {
+		tasks[i] = daemon.Task{Name: snapshotType + "-snapshot", Interval: interval, Run: func(ctx context.Context) error {
+			snapshot, err := takeSnapshot(ctx, fetcher, snapshotRepo, snapshotType, cfg.MaxAccounts(), interval)
+			if err != nil {
+				return err
+			}
+			logger.Info("Saved snapshot", "type", snapshotType, "accounts", snapshot.TotalCount, "id", snapshot.ID())
+			return nil
+		}}
+	}
+	scheduler := daemon.NewScheduler(tasks, logger)
+
+	if cmd.Bool("once") {
+		return scheduler.RunOnce(ctx)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("Taking snapshots", "of", strings.Join(types, ", "), "every", interval, "account", fetcher.GetDid())
+	err = scheduler.Run(ctx)
+	logger.Info("Snapshot daemon stopped")
+	return err
+}
+
+// acquireSnapshotLock takes the snapshot daemon's lockfile, explaining how to recover when it's held
+func acquireSnapshotLock() (*lockfile.Lock, error) {
+	if err := config.EnsureConfigDir(); err != nil {
+		return nil, err
+	}
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, snapshotLockFile)
+
+	lock, err := lockfile.Acquire(path)
+	var lockErr *lockfile.LockError
+	switch {
+	case err == nil:
+		return lock, nil
+	case errors.As(err, &lockErr) && errors.Is(err, lockfile.ErrLocked) && lockErr.PID > 0:
+		return nil, fmt.Errorf("another snapshot daemon is already running (pid %d)", lockErr.PID)
+	case errors.Is(err, lockfile.ErrLocked):
+		return nil, fmt.Errorf("another snapshot daemon may be running: %s can't be read; delete it if none is", path)
+	default:
+		return nil, fmt.Errorf("failed to take lockfile: %w", err)
+	}
+}
+
+// takeSnapshot saves the account's current followers or follows as a snapshot, fresh until the next one is due.
+// It pages through at most maxAccounts, 0 meaning all, and saves nothing when the graph can't be read in full.
+func takeSnapshot(ctx context.Context, fetcher bsky.FollowerFetcher, snapshotRepo bsky.SnapshotStore, snapshotType string, maxAccounts int, interval time.Duration) (*bsky.SnapshotModel, error) {
+	me := fetcher.GetDid()
+	pages := bsky.FollowerPages(fetcher, me)
+	if snapshotType == "following" {
+		pages = bsky.FollowPages(fetcher, me)
+	}
+	accounts, err := bsky.NewPaginator(pages, bsky.PaginatorOptions{Cap: maxAccounts}).All(ctx)
+	var capErr *bsky.CapError
+	if errors.As(err, &capErr) {
+		return nil, fmt.Errorf("more than %d %s, over the safety cap; raise limits.maxAccounts in the config, or set it to -1", capErr.Cap, snapshotType)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", snapshotType, err)
+	}
+
+	now := time.Now()
+	snapshot := &bsky.SnapshotModel{UserDid: me, SnapshotType: snapshotType, ExpiresAt: now.Add(interval)}
+	snapshot.SetID(bsky.GenerateUUID())
+	snapshot.SetCreatedAt(now)
+
+	seen := make(map[string]bool, len(accounts))
+	entries := make([]*bsky.SnapshotEntry, 0, len(accounts))
+	for _, account := range accounts {
+		if seen[account.Did] {
+			continue
+		}
+		seen[account.Did] = true
+		entries = append(entries, &bsky.SnapshotEntry{SnapshotID: snapshot.ID(), ActorDid: account.Did})
+	}
+	snapshot.TotalCount = len(entries)
+
+	if err := snapshotRepo.Import(ctx, snapshot, entries); err != nil {
+		return nil, fmt.Errorf("failed to save %s snapshot: %w", snapshotType, err)
+	}
+	return snapshot, nil
+}
+
+// SnapshotsCommand returns the snapshots command with list, export, import, and daemon subcommands
+func SnapshotsCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "snapshots",
+		Aliases: []string{"snapshot"},
+		Usage:   "Manage stored follower/following snapshots",
+		Commands: []*cli.Command{
+			{
+				Name:      "list",
+				Usage:     "List stored snapshots",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output as JSON",
+					},
+				},
+				Action: withRegistry(ListSnapshotsAction),
+			},
+			{
+				Name:      "export",
+				Usage:     "Export a snapshot to a portable file",
+				UsageText: "Export a snapshot with its original ID and timestamps so it can be imported on another machine.",
+				ArgsUsage: "<snapshot-id>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"f"},
+						Usage:   "Export format: json",
+						Value:   "json",
+					},
+					&cli.StringFlag{
+						Name:    "out",
+						Aliases: []string{"o", "file"},
+						Usage:   "Output file, or - to write to stdout (defaults to snapshot_<id>_<date>.json)",
+					},
+					compressFlag(),
+					schemaFlag(),
+				},
+				Before: quietWhenPiped,
+				Action: withRegistry(ExportSnapshotAction),
+			},
+			{
+				Name:      "import",
+				Usage:     "Import a snapshot exported from another install",
+				ArgsUsage: "<file.json>",
+				Action:    withRegistry(ImportSnapshotAction),
+			},
+			{
+				Name:      "daemon",
+				Usage:     "Take follower and following snapshots on a schedule",
+				UsageText: "Take snapshots of your followers and follows every --interval, in the foreground until interrupted, so 'followers diff --since' always has a baseline to compare with. Only one snapshot daemon runs at a time; a second one exits with an error while the first holds its lockfile in the config directory.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:  "interval",
+						Usage: "Time between snapshots, at least 5m",
+						Value: 6 * time.Hour,
+					},
+					&cli.StringSliceFlag{
+						Name:  "type",
+						Usage: "Snapshot types to take: followers, following",
+						Value: []string{"followers", "following"},
+					},
+					&cli.BoolFlag{
+						Name:  "once",
+						Usage: "Take each snapshot once and exit",
+					},
+				},
+				Action: withRegistry(SnapshotDaemonAction),
+			},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/lockfile"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestSnapshotDaemonAction(t *testing.T) {
+	configDir, cleanup := utils.SetupTestConfig(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	snapshotRepo, err := bsky.NewSnapshotRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { snapshotRepo.Close() })
+	if err := snapshotRepo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	graph := &fakeGraph{
+		authenticated: true,
+		did:           "did:plc:me",
+		pageSize:      2,
+		followers:     testProfiles("did:plc:a", "did:plc:b", "did:plc:c"),
+		follows:       testProfiles("did:plc:b"),
+	}
+	reg := registry.New(registry.Dependencies{FollowerFetcher: graph, SnapshotRepo: snapshotRepo})
+
+	if _, err := runSubcommand(t, SnapshotsCommand(), "daemon", SnapshotDaemonAction, reg, "--once"); err != nil {
+		t.Fatalf("SnapshotDaemonAction failed: %v", err)
+	}
+	for snapshotType, want := range map[string][]string{
+		"followers": {"did:plc:a", "did:plc:b", "did:plc:c"},
+		"following": {"did:plc:b"},
+	} {
+		snapshot, err := snapshotRepo.FindByUserAndType(ctx, "did:plc:me", snapshotType)
+		if err != nil || snapshot == nil {
+			t.Fatalf("expected a fresh %s snapshot, got %v", snapshotType, err)
+		}
+		dids, err := snapshotRepo.GetActorDids(ctx, snapshot.ID())
+		slices.Sort(dids)
+		if err != nil || snapshot.TotalCount != len(want) || !slices.Equal(dids, want) {
+			t.Errorf("unexpected %s snapshot %+v with %v (%v)", snapshotType, snapshot, dids, err)
+		}
+	}
+
+	lock, err := lockfile.Acquire(filepath.Join(configDir, snapshotLockFile))
+	if err != nil {
+		t.Fatalf("expected the daemon to release its lock, got %v", err)
+	}
+	defer lock.Release()
+	if _, err := runSubcommand(t, SnapshotsCommand(), "daemon", SnapshotDaemonAction, reg, "--once"); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("expected a second daemon to be refused, got %v", err)
+	}
+
+	if _, err := runSubcommand(t, SnapshotsCommand(), "daemon", SnapshotDaemonAction, reg, "--interval", "1m"); err == nil {
+		t.Error("expected a too short interval to be refused")
+	}
+}
@@ -3,11 +3,17 @@ package main
 import (
 	"context"
 	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
-	"github.com/stormlightlabs/skypanel/cli/internal/setup"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
@@ -15,17 +21,47 @@ func StatusCommand() *cli.Command {
 	return &cli.Command{
 		Name:   "status",
 		Usage:  "Show current session status",
-		Action: StatusAction,
+		Action: withRegistry(StatusAction),
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "token",
+				Usage: "Show access and refresh token expiry, scope, and audience",
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "sessions",
+				Usage: "List the account's app passwords, flag the one skycli uses, and revoke others",
+				Description: "The API lists app passwords but not individual sessions; revoking an app password ends every session\n" +
+					"created with it. skycli knows which one it uses when it was named at login (--app-password-name) or\n" +
+					"with --mark-current.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:  "revoke",
+						Usage: "Revoke the app password with this name (repeatable)",
+					},
+					&cli.BoolFlag{
+						Name:  "revoke-others",
+						Usage: "Revoke every app password except the one skycli uses",
+					},
+					&cli.StringFlag{
+						Name:  "mark-current",
+						Usage: "Record the name of the app password skycli logged in with",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output app passwords as JSON",
+					},
+				},
+				Action: withRegistry(StatusSessionsAction),
+			},
+		},
 	}
 }
 
-func StatusAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
+func StatusAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	sessionRepo, err := reg.GetSessionRepo()
 	if err != nil {
 		return fmt.Errorf("failed to get session repository: %w", err)
@@ -36,20 +72,353 @@ func StatusAction(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	session, err := sessionRepo.List(ctx)
+	accounts, err := listAccounts(ctx, sessionRepo)
 	if err != nil {
 		logger.Error("Failed to get session", "error", err)
 		return err
 	}
 
-	if len(session) > 0 {
-		if s, ok := session[0].(*store.SessionModel); ok {
-			ui.Titleln("Session Status")
-			ui.Infoln("Handle: %s", s.Handle)
-			ui.Infoln("Service: %s", s.ServiceURL)
-			ui.Successln("Authenticated")
+	var others []string
+	for _, account := range accounts {
+		if !account.InUse {
+			others = append(others, account.Handle)
+			continue
+		}
+		ui.Titleln("Session Status")
+		ui.Infoln("Handle: %s", account.Handle)
+		ui.Infoln("Service: %s", account.Service)
+		if !account.Default {
+			ui.Infoln("Chosen with --account; the default is %s", accounts[0].Handle)
+		}
+		ui.Successln("Authenticated")
+	}
+	if len(others) > 0 {
+		ui.Infoln("Also signed in: %s (switch with 'skycli account switch <handle>')", strings.Join(others, ", "))
+	}
+
+	showQuotas(ctx, reg, time.Now())
+
+	if cmd.Bool("token") {
+		return showTokens(ctx, sessionRepo, time.Now())
+	}
+
+	return nil
+}
+
+// lowQuotaShare is the fraction of a host's rate-limit budget below which commands warn before running
+const lowQuotaShare = 0.1
+
+// quotaGaugeWidth is the number of cells in the quota gauge
+const quotaGaugeWidth = 20
+
+// showQuotas prints a gauge of each API host's last reported rate-limit budget
+func showQuotas(ctx context.Context, reg *registry.Registry, now time.Time) {
+	quotaRepo, err := reg.GetQuotaRepo()
+	if err != nil {
+		return
+	}
+	quotas, err := quotaRepo.List(ctx)
+	if err != nil {
+		logger.Warn("Failed to read API quota", "error", err)
+		return
+	}
+	if len(quotas) == 0 {
+		return
+	}
+
+	ui.Titleln("API Quota")
+	for _, quota := range quotas {
+		remaining := quota.RemainingAt(now)
+		line := fmt.Sprintf("%s %s %d/%d left", quota.Host, quotaGauge(quota.ShareAt(now), quotaGaugeWidth), remaining, quota.Limit)
+		if quota.Window > 0 {
+			line += fmt.Sprintf(" per %s", quota.Window)
+		}
+		if now.Before(quota.ResetAt) {
+			line += fmt.Sprintf(", resets in %s", quota.ResetAt.Sub(now).Round(time.Second))
+		} else {
+			line += " (window has reset)"
+		}
+		line += fmt.Sprintf(", seen %s ago", now.Sub(quota.ObservedAt).Round(time.Second))
+
+		if quota.ShareAt(now) < lowQuotaShare {
+			ui.Warningln("%s", line)
+		} else {
+			ui.Infoln("%s", line)
+		}
+	}
+}
+
+// quotaGauge renders share as a bar of width cells
+func quotaGauge(share float64, width int) string {
+	filled := int(math.Round(min(max(share, 0), 1) * float64(width)))
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// warnLowQuota warns before a command runs when a host's last reported budget is nearly spent and hasn't reset
+func warnLowQuota(ctx context.Context, reg *registry.Registry, now time.Time) {
+	quotaRepo, err := reg.GetQuotaRepo()
+	if err != nil {
+		return
+	}
+	quotas, err := quotaRepo.List(ctx)
+	if err != nil {
+		logger.Debug("Failed to read API quota", "error", err)
+		return
+	}
+
+	for _, quota := range quotas {
+		if quota.ShareAt(now) < lowQuotaShare {
+			ui.Warningln("API quota low on %s: %d of %d requests left until %s; requests may be rate limited",
+				quota.Host, quota.RemainingAt(now), quota.Limit, quota.ResetAt.In(ui.Location()).Format(time.Kitchen))
+		}
+	}
+}
+
+// showTokens prints the decoded claims of the stored access and refresh tokens
+func showTokens(ctx context.Context, sessionRepo *bsky.SessionRepository, now time.Time) error {
+	accessToken, err := sessionRepo.GetAccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read access token: %w", err)
+	}
+	refreshToken, err := sessionRepo.GetRefreshToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read refresh token: %w", err)
+	}
+
+	ui.Titleln("Access Token")
+	access, err := bsky.ParseToken(accessToken)
+	if err != nil {
+		ui.Warningln("Unable to decode: %v", err)
+	} else {
+		printToken(access, now)
+		switch err := access.ValidateAccess(now); {
+		case err != nil:
+			ui.Warningln("Invalid: %v", err)
+		case !now.Before(access.RefreshAt()):
+			ui.Warningln("Due for refresh; it will be renewed on the next request")
+		default:
+			ui.Successln("Valid")
 		}
 	}
 
+	ui.Titleln("Refresh Token")
+	refresh, err := bsky.ParseToken(refreshToken)
+	if err != nil {
+		ui.Warningln("Unable to decode: %v", err)
+		return nil
+	}
+	printToken(refresh, now)
+	if refresh.Expired(now) {
+		ui.Errorln("Expired. Run 'skycli login' to authenticate again.")
+	} else {
+		ui.Successln("Valid")
+	}
+
 	return nil
 }
+
+// sessionsOutput is the JSON form of status sessions
+type sessionsOutput struct {
+	SignedInWith string             `json:"signedInWith"`      // "app password", "privileged app password", "account password", or "OAuth"
+	Current      string             `json:"current,omitempty"` // name of the app password skycli uses, when known
+	AppPasswords []bsky.AppPassword `json:"appPasswords"`
+	Revoked      []string           `json:"revoked,omitempty"`
+}
+
+// StatusSessionsAction lists the account's app passwords, flagging the one skycli signed in with, and revokes
+// those named by --revoke or --revoke-others
+func StatusSessionsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	sessionRepo, err := reg.GetSessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+	if !sessionRepo.HasValidSession(ctx) {
+		return fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+	manager, err := reg.GetAppPasswordManager()
+	if err != nil {
+		return fmt.Errorf("failed to get app password manager: %w", err)
+	}
+
+	model, err := sessionRepo.Get(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to read session: %w", err)
+	}
+	session := model.(*bsky.SessionModel)
+
+	output := sessionsOutput{SignedInWith: "unknown"}
+	if accessToken, err := sessionRepo.GetAccessToken(ctx); err == nil {
+		if info, err := bsky.ParseToken(accessToken); err == nil {
+			output.SignedInWith = signedInWith(info.Scope)
+		}
+	}
+
+	response, err := manager.ListAppPasswords(ctx)
+	if err != nil {
+		if output.SignedInWith != "account password" {
+			return fmt.Errorf("failed to list app passwords: %w (the PDS may only allow this when logged in with the account password)", err)
+		}
+		return fmt.Errorf("failed to list app passwords: %w", err)
+	}
+	output.AppPasswords = response.Passwords
+
+	if name := cmd.String("mark-current"); name != "" {
+		if !slices.ContainsFunc(response.Passwords, func(p bsky.AppPassword) bool { return p.Name == name }) {
+			return fmt.Errorf("no app password named %q", name)
+		}
+		if err := sessionRepo.UpdateAppPassword(ctx, name); err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
+		session.AppPassword = name
+	}
+	if usesAppPassword(output.SignedInWith) {
+		output.Current = session.AppPassword
+	}
+
+	targets, err := revokeTargets(response.Passwords, output.Current, output.SignedInWith, cmd.StringSlice("revoke"), cmd.Bool("revoke-others"))
+	if err != nil {
+		return err
+	}
+
+	if len(targets) > 0 {
+		if ok, err := confirm(cmd, fmt.Sprintf("Revoke %d app password(s): %s?", len(targets), strings.Join(targets, ", "))); !ok {
+			return err
+		}
+		for _, name := range targets {
+			if err := manager.RevokeAppPassword(ctx, name); err != nil {
+				return fmt.Errorf("failed to revoke %q (revoked so far: %v): %w", name, output.Revoked, err)
+			}
+			logger.Info("Revoked app password", "name", name)
+			output.Revoked = append(output.Revoked, name)
+		}
+		output.AppPasswords = slices.DeleteFunc(output.AppPasswords, func(p bsky.AppPassword) bool {
+			return slices.Contains(output.Revoked, p.Name)
+		})
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(output)
+	}
+	displaySessions(output)
+	return nil
+}
+
+// signedInWith describes the kind of credential a session's access token scope was issued for
+func signedInWith(scope string) string {
+	switch scope {
+	case bsky.ScopeAppPass:
+		return "app password"
+	case bsky.ScopeAppPassPrivilege:
+		return "privileged app password"
+	case bsky.ScopeAccess:
+		return "account password"
+	default:
+		if slices.Contains(strings.Fields(scope), bsky.ScopeOAuth) {
+			return "OAuth"
+		}
+		return "unknown"
+	}
+}
+
+// usesAppPassword reports whether a session signed in with the given credential runs on one of the app passwords;
+// account password and OAuth sessions don't
+func usesAppPassword(signedInWith string) bool {
+	return signedInWith != "account password" && signedInWith != "OAuth"
+}
+
+// revokeTargets returns the app passwords to revoke: those named, plus with others every one but current.
+// Revoking the app password skycli uses would sign it out, so that is refused, as is --revoke-others while skycli
+// doesn't know which one it uses.
+func revokeTargets(passwords []bsky.AppPassword, current, signedInWith string, names []string, others bool) ([]string, error) {
+	var targets []string
+	for _, name := range names {
+		if !slices.ContainsFunc(passwords, func(p bsky.AppPassword) bool { return p.Name == name }) {
+			return nil, fmt.Errorf("no app password named %q", name)
+		}
+		if name == current {
+			return nil, fmt.Errorf("%q is the app password skycli is signed in with; revoking it would sign skycli out", name)
+		}
+		if !slices.Contains(targets, name) {
+			targets = append(targets, name)
+		}
+	}
+
+	if others {
+		if current == "" && usesAppPassword(signedInWith) {
+			return nil, fmt.Errorf("skycli doesn't know which app password it signed in with; name it with --mark-current first")
+		}
+		for _, password := range passwords {
+			if password.Name != current && !slices.Contains(targets, password.Name) {
+				targets = append(targets, password.Name)
+			}
+		}
+	}
+	return targets, nil
+}
+
+// displaySessions prints the app password table with skycli's own marked
+func displaySessions(output sessionsOutput) {
+	for _, name := range output.Revoked {
+		ui.Successln("Revoked %s", name)
+	}
+
+	ui.Titleln("App Passwords")
+	ui.Infoln("skycli is signed in with: %s", output.SignedInWith)
+	if len(output.AppPasswords) == 0 {
+		ui.Infoln("No app passwords.")
+		return
+	}
+
+	rows := make([][]string, len(output.AppPasswords))
+	for i, password := range output.AppPasswords {
+		privileged, inUse := "", ""
+		if password.Privileged {
+			privileged = "yes"
+		}
+		if password.Name == output.Current {
+			inUse = "skycli"
+		}
+		rows[i] = []string{password.Name, ui.FormatDateString(password.CreatedAt, ui.DatesLocal), privileged, inUse}
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("Name", "Created", "Privileged", "In use").Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+	fmt.Println(re.NewStyle().Render(t.String()))
+
+	if output.Current == "" && usesAppPassword(output.SignedInWith) {
+		ui.Infoln("skycli doesn't know which of these it uses; record it with --mark-current <name>")
+	}
+}
+
+// printToken prints the claims of one token with expiry relative to now
+func printToken(info *bsky.TokenInfo, now time.Time) {
+	if info.Subject != "" {
+		ui.Infoln("Subject: %s", info.Subject)
+	}
+	if len(info.Audience) > 0 {
+		ui.Infoln("Audience: %s", strings.Join(info.Audience, ", "))
+	}
+	if info.Scope != "" {
+		ui.Infoln("Scope: %s", info.Scope)
+	}
+	if !info.IssuedAt.IsZero() {
+		ui.Infoln("Issued: %s", info.IssuedAt.In(ui.Location()).Format(time.RFC1123))
+	}
+
+	remaining := info.Expiry.Sub(now).Round(time.Second)
+	if remaining >= 0 {
+		ui.Infoln("Expires: %s (in %s)", info.Expiry.In(ui.Location()).Format(time.RFC1123), remaining)
+	} else {
+		ui.Infoln("Expires: %s (%s ago)", info.Expiry.In(ui.Location()).Format(time.RFC1123), -remaining)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestQuotaGauge(t *testing.T) {
+	tests := []struct {
+		share float64
+		want  string
+	}{
+		{share: 1, want: "[██████████]"},
+		{share: 0.25, want: "[███░░░░░░░]"},
+		{share: 0, want: "[░░░░░░░░░░]"},
+		{share: 1.5, want: "[██████████]"},
+		{share: -1, want: "[░░░░░░░░░░]"},
+	}
+	for _, tt := range tests {
+		if got := quotaGauge(tt.share, 10); got != tt.want {
+			t.Errorf("quotaGauge(%v) = %q, want %q", tt.share, got, tt.want)
+		}
+	}
+}
+
+func TestRevokeTargets(t *testing.T) {
+	passwords := []bsky.AppPassword{{Name: "skycli"}, {Name: "old-laptop"}, {Name: "bot", Privileged: true}}
+
+	tests := []struct {
+		name         string
+		current      string
+		signedInWith string
+		revoke       []string
+		others       bool
+		want         []string
+		wantErr      string
+	}{
+		{name: "named", current: "skycli", signedInWith: "app password", revoke: []string{"bot", "bot"}, want: []string{"bot"}},
+		{name: "others", current: "skycli", signedInWith: "app password", others: true, want: []string{"old-laptop", "bot"}},
+		{name: "others with account password", signedInWith: "account password", others: true, want: []string{"skycli", "old-laptop", "bot"}},
+		{name: "others with OAuth", signedInWith: "OAuth", others: true, want: []string{"skycli", "old-laptop", "bot"}},
+		{name: "current refused", current: "skycli", signedInWith: "app password", revoke: []string{"skycli"}, wantErr: "sign skycli out"},
+		{name: "unknown name", current: "skycli", signedInWith: "app password", revoke: []string{"phone"}, wantErr: "no app password"},
+		{name: "others without current", signedInWith: "app password", others: true, wantErr: "--mark-current"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := revokeTargets(passwords, tt.current, tt.signedInWith, tt.revoke, tt.others)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("revokeTargets = %v, %v; want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,976 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/daemon"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/stream"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// archiveSourcePrefix marks the Source of local feeds filled by archive rules rather than fetched from the API
+const archiveSourcePrefix = "archive:"
+
+// streamHandler processes one firehose event, reporting whether it acted on it so the event is recorded and
+// skipped if delivered again; returning an error ends the subscription until the daemon reconnects
+type streamHandler func(ctx context.Context, event stream.Event) (bool, error)
+
+// postRecord is the part of an app.bsky.feed.post record read from the stream
+type postRecord struct {
+	Text      string `json:"text"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// archiveMatcher is a compiled [config.ArchiveRule] bound to the local feed it saves into
+type archiveMatcher struct {
+	name     string
+	feedID   string
+	keywords *regexp.Regexp // nil when the rule has no keywords
+	authors  map[string]bool
+}
+
+// matches reports whether a post by author with the given text satisfies any of the rule's criteria
+func (m *archiveMatcher) matches(author, text string) bool {
+	return m.authors[author] || (m.keywords != nil && m.keywords.MatchString(text))
+}
+
+// followerTrackingMaxAge is how recently the stream must have confirmed the tracked followers for them to be
+// used in place of fetching followers from the API
+const followerTrackingMaxAge = 5 * time.Minute
+
+// streamConsumer is one firehose subscription of the stream task, with its own filter and handler
+type streamConsumer struct {
+	name   string
+	opts   stream.Options
+	handle streamHandler
+}
+
+// followRecord is the part of an app.bsky.graph.follow record read from the stream and from repositories
+type followRecord struct {
+	Subject   string `json:"subject"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// streamCursorInfo is a stored stream cursor as shown to the user
+type streamCursorInfo struct {
+	Name      string `json:"name"`
+	Cursor    int64  `json:"cursor"`
+	EventTime string `json:"eventTime"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// streamEventInfo is a processed stream event as shown to the user
+type streamEventInfo struct {
+	Seq         int64  `json:"seq"`
+	Consumer    string `json:"consumer"`
+	Kind        string `json:"kind"`
+	Did         string `json:"did"`
+	URI         string `json:"uri,omitempty"`
+	EventTime   string `json:"eventTime"`
+	ProcessedAt string `json:"processedAt"`
+}
+
+// StreamCommand returns the stream command with its subcommands
+func StreamCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "stream",
+		Usage: "Manage the daemon's firehose subscriptions",
+		Commands: []*cli.Command{
+			{
+				Name:      "backfill",
+				Usage:     "Replay firehose events missed while the daemon was down",
+				UsageText: "Resume each stream subscription configured in the daemon section from its stored cursor, or from --since, and apply the events to archive rules, follower tracking, and cached profiles until caught up with the live stream. Jetstream keeps roughly a day of events, so older gaps can't be filled.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Cursor (time_us) or time to replay from: YYYY-MM-DD, RFC3339, a duration like 6h, or yesterday",
+					},
+					&cli.StringSliceFlag{
+						Name:  "only",
+						Usage: "Replay only the named subscriptions (archive, followers, identity)",
+					},
+					&cli.DurationFlag{
+						Name:  "idle",
+						Usage: "Stop a subscription when no event arrives for this long",
+						Value: 15 * time.Second,
+					},
+				},
+				Action: withRegistry(StreamBackfillAction),
+			},
+			{
+				Name:      "cursors",
+				Usage:     "Show how far each stream subscription got",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:  "reset",
+						Usage: "Forget the named subscriptions' cursors so they start from live events",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output as JSON",
+					},
+				},
+				Action: withRegistry(StreamCursorsAction),
+			},
+			{
+				Name:      "events",
+				Usage:     "List firehose events the daemon acted on, in processing order",
+				UsageText: "Events are numbered as they are processed and each is handled once per subscription, even when replayed by a restart or backfill. Pass the last seq seen to --after to read only newer events. Events are kept for three days.",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "consumer",
+						Usage: "Only events of this subscription (archive, followers, identity)",
+					},
+					&cli.Int64Flag{
+						Name:  "after",
+						Usage: "Only events with a greater seq",
+					},
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum number of events",
+						Value:   50,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output as JSON",
+					},
+				},
+				Action: withRegistry(StreamEventsAction),
+			},
+		},
+	}
+}
+
+// StreamBackfillAction replays the configured stream subscriptions from their stored cursors, or --since, up to now
+func StreamBackfillAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if !streamConfigured(cfg.Daemon) {
+		return fmt.Errorf("no stream subscriptions configured: add archiveRules, trackFollowers, or syncProfiles to the daemon section of the config file")
+	}
+
+	daemonCfg := *cfg.Daemon
+	if only := cmd.StringSlice("only"); len(only) > 0 {
+		for _, name := range only {
+			if name != "archive" && name != "followers" && name != "identity" {
+				return fmt.Errorf("unknown subscription %q: expected archive, followers, or identity", name)
+			}
+		}
+		if !slices.Contains(only, "archive") {
+			daemonCfg.ArchiveRules = nil
+		}
+		if !slices.Contains(only, "followers") {
+			daemonCfg.TrackFollowers = false
+		}
+		if !slices.Contains(only, "identity") {
+			daemonCfg.SyncProfiles = false
+		}
+	}
+
+	now := userNow()
+	since, err := parseStreamCursor(cmd.String("since"), now)
+	if err != nil {
+		return err
+	}
+
+	state, err := openStreamState(reg)
+	if err != nil {
+		return err
+	}
+
+	consumers, err := streamConsumers(ctx, reg, &daemonCfg, state.cursors, true)
+	if err != nil {
+		return err
+	}
+	if len(consumers) == 0 {
+		return fmt.Errorf("none of the selected subscriptions are configured")
+	}
+
+	if since == 0 {
+		for _, consumer := range consumers {
+			stored, err := state.cursors.Get(ctx, consumer.name)
+			if err != nil {
+				return fmt.Errorf("failed to read %s cursor: %w", consumer.name, err)
+			}
+			if stored == nil {
+				return fmt.Errorf("no stored cursor for %s: pass --since to choose where to replay from", consumer.name)
+			}
+			logger.Info("Backfilling", "consumer", consumer.name, "from", stored.Time().In(ui.Location()).Format(time.DateTime))
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	replay := replayOptions{since: since, until: now.UnixMicro(), idle: cmd.Duration("idle")}
+	if err := runConsumers(ctx, daemonCfg.Jetstream, consumers, state, replay); err != nil {
+		return fmt.Errorf("backfill failed: %w", err)
+	}
+	if ctx.Err() != nil {
+		ui.Warningln("Backfill interrupted; run it again without --since to continue")
+		return nil
+	}
+
+	ui.Successln("Caught up with the live stream")
+	return nil
+}
+
+// StreamCursorsAction lists stored stream cursors, after forgetting those named by --reset
+func StreamCursorsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	cursors, err := reg.GetStreamCursorRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get stream cursors: %w", err)
+	}
+
+	for _, name := range cmd.StringSlice("reset") {
+		deleted, err := cursors.Delete(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to reset %s cursor: %w", name, err)
+		}
+		if !deleted {
+			ui.Warningln("No cursor stored for %s", name)
+			continue
+		}
+		ui.Successln("Reset %s cursor", name)
+	}
+
+	stored, err := cursors.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list stream cursors: %w", err)
+	}
+
+	infos := make([]streamCursorInfo, len(stored))
+	for i, cursor := range stored {
+		infos[i] = streamCursorInfo{
+			Name:      cursor.Name,
+			Cursor:    cursor.Cursor,
+			EventTime: cursor.Time().In(ui.Location()).Format(time.DateTime),
+			UpdatedAt: cursor.UpdatedAt.In(ui.Location()).Format(time.DateTime),
+		}
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(infos)
+	}
+
+	displayStreamCursors(infos)
+	return nil
+}
+
+// StreamEventsAction lists processed stream events in sequence order
+func StreamEventsAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	events, err := reg.GetStreamEventRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get stream events: %w", err)
+	}
+
+	models, err := events.After(ctx, cmd.String("consumer"), cmd.Int64("after"), cmd.Int("limit"))
+	if err != nil {
+		return fmt.Errorf("failed to list stream events: %w", err)
+	}
+
+	infos := make([]streamEventInfo, len(models))
+	for i, model := range models {
+		infos[i] = streamEventInfo{
+			Seq:         model.Seq,
+			Consumer:    model.Consumer,
+			Kind:        model.Kind,
+			Did:         model.Did,
+			URI:         model.URI,
+			EventTime:   time.UnixMicro(model.TimeUS).In(ui.Location()).Format(time.DateTime),
+			ProcessedAt: model.ProcessedAt.In(ui.Location()).Format(time.DateTime),
+		}
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(infos)
+	}
+
+	displayStreamEvents(infos)
+	return nil
+}
+
+// parseStreamCursor reads --since as a Jetstream cursor in microseconds or as a time accepted by [parseSince].
+// An empty value returns 0.
+func parseStreamCursor(value string, now time.Time) (int64, error) {
+	if cursor, err := strconv.ParseInt(value, 10, 64); err == nil && cursor > 0 {
+		return cursor, nil
+	}
+
+	since, err := parseSince(value, now)
+	if err != nil {
+		return 0, err
+	}
+	if since.IsZero() {
+		return 0, nil
+	}
+	return since.UnixMicro(), nil
+}
+
+// displayStreamEvents renders processed stream events as a table
+func displayStreamEvents(events []streamEventInfo) {
+	if len(events) == 0 {
+		ui.Infoln("No stream events processed")
+		return
+	}
+
+	ui.Titleln("Stream events (%d)", len(events))
+	fmt.Println()
+
+	rows := make([][]string, len(events))
+	for i, event := range events {
+		subject := event.URI
+		if subject == "" {
+			subject = event.Did
+		}
+		rows[i] = []string{strconv.FormatInt(event.Seq, 10), event.Consumer, event.Kind, subject, event.EventTime}
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("Seq", "Subscription", "Kind", "Subject", "Event time").Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	fmt.Println(re.NewStyle().Render(t.String()))
+	fmt.Println()
+}
+
+// displayStreamCursors renders stored stream cursors as a table
+func displayStreamCursors(cursors []streamCursorInfo) {
+	if len(cursors) == 0 {
+		ui.Infoln("No stream cursors stored; 'skycli daemon' stores them as it follows the firehose")
+		return
+	}
+
+	ui.Titleln("Stream cursors (%d)", len(cursors))
+	fmt.Println()
+
+	rows := make([][]string, len(cursors))
+	for i, cursor := range cursors {
+		rows[i] = []string{cursor.Name, strconv.FormatInt(cursor.Cursor, 10), cursor.EventTime, cursor.UpdatedAt}
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("Subscription", "Cursor", "Event time", "Saved").Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	fmt.Println(re.NewStyle().Render(t.String()))
+	fmt.Println()
+}
+
+// streamCursorSaveInterval is how often a running consumer stores how far it got
+const streamCursorSaveInterval = 5 * time.Second
+
+// jetstreamRetention is how far back Jetstream replays events; a follower set last confirmed earlier than this
+// can't be caught up from the stream and is reloaded from the API
+const jetstreamRetention = 24 * time.Hour
+
+// streamEventRetention is how long processed events are remembered; longer than Jetstream replays so any
+// event delivered again is still recognized
+const streamEventRetention = 3 * jetstreamRetention
+
+// errCaughtUp ends a bounded replay once it reaches events that were live when it started
+var errCaughtUp = errors.New("caught up")
+
+// replayOptions bound a run of the stream consumers; the zero value follows the live stream indefinitely
+// from each consumer's stored cursor
+type replayOptions struct {
+	since int64         // start cursor for every consumer, in place of their stored cursors
+	until int64         // stop at the first event at or after this time_us
+	idle  time.Duration // stop when no event arrives for this long
+}
+
+// streamState is where stream consumers keep their progress: a cursor per consumer and the events they acted on
+type streamState struct {
+	cursors *bsky.StreamCursorRepository
+	events  *bsky.StreamEventRepository
+}
+
+// openStreamState returns the stream state kept in the registry's cache database
+func openStreamState(reg *registry.Registry) (streamState, error) {
+	cursors, err := reg.GetStreamCursorRepo()
+	if err != nil {
+		return streamState{}, fmt.Errorf("failed to get stream cursors: %w", err)
+	}
+	events, err := reg.GetStreamEventRepo()
+	if err != nil {
+		return streamState{}, fmt.Errorf("failed to get stream events: %w", err)
+	}
+	return streamState{cursors: cursors, events: events}, nil
+}
+
+// streamTask returns the daemon task that follows the Jetstream firehose to apply archive rules and track followers.
+// The second result is false when nothing is configured that needs the stream.
+func streamTask(reg *registry.Registry, cfg *config.DaemonConfig) (daemon.Task, bool) {
+	if !streamConfigured(cfg) {
+		return daemon.Task{}, false
+	}
+
+	return daemon.Task{
+		Name:       "stream",
+		Interval:   30 * time.Second,
+		Continuous: true,
+		Run: func(ctx context.Context) error {
+			state, err := openStreamState(reg)
+			if err != nil {
+				return err
+			}
+
+			pruned, err := state.events.Prune(ctx, time.Now().Add(-streamEventRetention).UnixMicro())
+			if err != nil {
+				return fmt.Errorf("failed to prune stream events: %w", err)
+			}
+			logger.Debug("Pruned stream events", "events", pruned)
+
+			consumers, err := streamConsumers(ctx, reg, cfg, state.cursors, false)
+			if err != nil {
+				return err
+			}
+			return runConsumers(ctx, cfg.Jetstream, consumers, state, replayOptions{})
+		},
+	}, true
+}
+
+// streamConfigured reports whether cfg enables anything that needs the stream
+func streamConfigured(cfg *config.DaemonConfig) bool {
+	return cfg != nil && (len(cfg.ArchiveRules) > 0 || cfg.TrackFollowers || cfg.SyncProfiles)
+}
+
+// streamConsumers builds the subscriptions cfg asks for. The follower set is reloaded from the API unless resume
+// is set or its stored cursor is recent enough for the stream to replay what was missed.
+func streamConsumers(ctx context.Context, reg *registry.Registry, cfg *config.DaemonConfig, cursors *bsky.StreamCursorRepository, resume bool) ([]streamConsumer, error) {
+	var consumers []streamConsumer
+	if len(cfg.ArchiveRules) > 0 {
+		consumer, err := archiveConsumer(ctx, reg, cfg.ArchiveRules)
+		if err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, consumer)
+	}
+
+	if cfg.TrackFollowers {
+		if !resume {
+			stored, err := cursors.Get(ctx, "followers")
+			if err != nil {
+				return nil, fmt.Errorf("failed to read followers cursor: %w", err)
+			}
+			resume = stored != nil && time.Since(stored.Time()) < jetstreamRetention
+		}
+
+		consumer, err := followerConsumer(ctx, reg, resume)
+		if err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, consumer)
+	}
+
+	if cfg.SyncProfiles {
+		consumer, err := identityConsumer(reg)
+		if err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, consumer)
+	}
+	return consumers, nil
+}
+
+// runConsumers subscribes every consumer concurrently until ctx is done, each reaches the end of the replay, or
+// one of them fails; a failure stops the others so the task restarts them together
+func runConsumers(ctx context.Context, endpoint string, consumers []streamConsumer, state streamState, replay replayOptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(consumers))
+	for _, consumer := range consumers {
+		go func() {
+			err := runConsumer(ctx, endpoint, consumer, state, replay)
+			if err != nil {
+				cancel()
+			}
+			errs <- err
+		}()
+	}
+
+	var failures []error
+	for range consumers {
+		if err := <-errs; err != nil {
+			failures = append(failures, err)
+		}
+	}
+	return errors.Join(failures...)
+}
+
+// runConsumer subscribes one consumer, starting from the replay's start, the consumer's own starting point, or its
+// stored cursor in that order. Events the consumer already acted on are skipped, and progress is stored as events
+// are handled and when the subscription ends.
+func runConsumer(ctx context.Context, endpoint string, consumer streamConsumer, state streamState, replay replayOptions) error {
+	opts := consumer.opts
+	opts.Endpoint = endpoint
+	switch {
+	case replay.since > 0:
+		opts.Cursor = replay.since
+	case opts.Cursor == 0:
+		stored, err := state.cursors.Get(ctx, consumer.name)
+		if err != nil {
+			return fmt.Errorf("failed to read %s cursor: %w", consumer.name, err)
+		}
+		if stored != nil {
+			opts.Cursor = stored.Cursor
+		}
+	}
+
+	subCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	var idle *time.Timer
+	if replay.idle > 0 {
+		idle = time.AfterFunc(replay.idle, stop)
+		defer idle.Stop()
+	}
+
+	var saved time.Time
+	handle := func(ctx context.Context, event stream.Event) error {
+		if replay.until > 0 && event.TimeUS >= replay.until {
+			return errCaughtUp
+		}
+		if idle != nil {
+			idle.Reset(replay.idle)
+		}
+
+		if err := handleOnce(ctx, consumer, state.events, event); err != nil {
+			return err
+		}
+		if time.Since(saved) >= streamCursorSaveInterval {
+			if err := state.cursors.Save(ctx, consumer.name, event.TimeUS); err != nil {
+				return err
+			}
+			saved = time.Now()
+		}
+		return nil
+	}
+
+	logger.Info("Subscribing to firehose", "consumer", consumer.name, "dids", len(opts.Dids), "resume", opts.Cursor > 0)
+	cursor, err := stream.Subscribe(subCtx, opts, handle)
+	if errors.Is(err, errCaughtUp) {
+		err = nil
+	}
+	if saveErr := state.cursors.Save(context.WithoutCancel(ctx), consumer.name, cursor); saveErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to store %s cursor: %w", consumer.name, saveErr))
+	}
+	return err
+}
+
+// handleOnce passes event to the consumer unless it already acted on it, and records the event if it does
+func handleOnce(ctx context.Context, consumer streamConsumer, events *bsky.StreamEventRepository, event stream.Event) error {
+	key := event.Key()
+	processed, err := events.Processed(ctx, consumer.name, key)
+	if err != nil {
+		return err
+	}
+	if processed {
+		logger.Debug("Skipping processed event", "consumer", consumer.name, "event", key)
+		return nil
+	}
+
+	acted, err := consumer.handle(ctx, event)
+	if err != nil || !acted {
+		return err
+	}
+
+	_, err = events.Record(ctx, &bsky.StreamEventModel{
+		Consumer: consumer.name,
+		Key:      key,
+		Did:      event.Did,
+		Kind:     event.Kind,
+		URI:      event.URI(),
+		TimeUS:   event.TimeUS,
+	})
+	return err
+}
+
+// archiveConsumer subscribes to the posts archive rules need
+func archiveConsumer(ctx context.Context, reg *registry.Registry, rules []config.ArchiveRule) (streamConsumer, error) {
+	matchers, err := prepareArchiveRules(ctx, reg, rules)
+	if err != nil {
+		return streamConsumer{}, err
+	}
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return streamConsumer{}, fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	return streamConsumer{
+		name:   "archive",
+		opts:   stream.Options{Collections: []string{"app.bsky.feed.post"}, Dids: archiveDids(matchers)},
+		handle: archiveHandler(postRepo, matchers),
+	}, nil
+}
+
+// prepareArchiveRules compiles rules, expanding list members into authors and creating each rule's local feed
+// on first use. Lists are re-read on every call so membership changes apply after a reconnect.
+func prepareArchiveRules(ctx context.Context, reg *registry.Registry, rules []config.ArchiveRule) ([]*archiveMatcher, error) {
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed repository: %w", err)
+	}
+
+	var fetcher bsky.FollowerFetcher
+	matchers := make([]*archiveMatcher, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("archive rule without a name")
+		}
+		if len(rule.Keywords) == 0 && len(rule.Authors) == 0 && len(rule.Lists) == 0 {
+			return nil, fmt.Errorf("archive rule %q needs keywords, authors, or lists", rule.Name)
+		}
+
+		matcher := &archiveMatcher{name: rule.Name, authors: make(map[string]bool)}
+		if len(rule.Keywords) > 0 {
+			terms := make([]string, 0, len(rule.Keywords))
+			for _, keyword := range rule.Keywords {
+				if keyword = strings.TrimSpace(keyword); keyword != "" {
+					terms = append(terms, regexp.QuoteMeta(keyword))
+				}
+			}
+			if len(terms) == 0 {
+				return nil, fmt.Errorf("archive rule %q has only blank keywords", rule.Name)
+			}
+			matcher.keywords = regexp.MustCompile(`(?i)\b(?:` + strings.Join(terms, "|") + `)\b`)
+		}
+		for _, author := range rule.Authors {
+			matcher.authors[author] = true
+		}
+
+		for _, list := range rule.Lists {
+			if fetcher == nil {
+				if fetcher, err = reg.GetFollowerFetcher(); err != nil {
+					return nil, fmt.Errorf("failed to get follower fetcher: %w", err)
+				}
+			}
+			members, err := bsky.NewPaginator(bsky.ListMemberPages(fetcher, list), bsky.PaginatorOptions{}).All(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch members of %s for archive rule %q: %w", list, rule.Name, err)
+			}
+			for _, member := range members {
+				matcher.authors[member.Did] = true
+			}
+		}
+
+		feed, err := archiveFeed(ctx, feedRepo, rule.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher.feedID = feed.ID()
+		matchers = append(matchers, matcher)
+	}
+	return matchers, nil
+}
+
+// archiveFeed returns the local feed an archive rule saves into, creating it if needed
+func archiveFeed(ctx context.Context, feedRepo *bsky.FeedRepository, rule string) (*bsky.FeedModel, error) {
+	feed, created, err := localFeed(ctx, feedRepo, archiveSourcePrefix+rule, "Archive: "+rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feed for archive rule %q: %w", rule, err)
+	}
+	if created {
+		logger.Info("Created archive feed", "rule", rule, "feed", feed.ID())
+	}
+	return feed, nil
+}
+
+// localFeed returns the local feed with source, creating it under name if there is none, and whether it was created
+func localFeed(ctx context.Context, feedRepo *bsky.FeedRepository, source, name string) (*bsky.FeedModel, bool, error) {
+	models, err := feedRepo.List(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list feeds: %w", err)
+	}
+	for _, model := range models {
+		if feed, ok := model.(*bsky.FeedModel); ok && feed.IsLocal && feed.Source == source {
+			return feed, false, nil
+		}
+	}
+
+	feed := &bsky.FeedModel{Name: name, Source: source, IsLocal: true}
+	if err := feedRepo.Save(ctx, feed); err != nil {
+		return nil, false, err
+	}
+	return feed, true, nil
+}
+
+// archiveDids returns the repos the stream can be narrowed to, or nil when a keyword rule needs every post
+func archiveDids(matchers []*archiveMatcher) []string {
+	dids := make(map[string]bool)
+	for _, matcher := range matchers {
+		if matcher.keywords != nil {
+			return nil
+		}
+		maps.Copy(dids, matcher.authors)
+	}
+	return slices.Sorted(maps.Keys(dids))
+}
+
+// archiveHandler saves newly created posts that match an archive rule into that rule's feed.
+// A post matching several rules is kept in the first one, since posts belong to a single feed.
+func archiveHandler(postRepo *bsky.PostRepository, matchers []*archiveMatcher) streamHandler {
+	return func(ctx context.Context, event stream.Event) (bool, error) {
+		if event.Commit == nil || event.Commit.Operation != "create" || event.Commit.Collection != "app.bsky.feed.post" {
+			return false, nil
+		}
+
+		var record postRecord
+		if err := json.Unmarshal(event.Commit.Record, &record); err != nil {
+			logger.Debug("Skipping undecodable post", "uri", event.URI(), "error", err)
+			return false, nil
+		}
+
+		for _, matcher := range matchers {
+			if !matcher.matches(event.Did, record.Text) {
+				continue
+			}
+
+			post := &bsky.PostModel{
+				URI:       event.URI(),
+				AuthorDID: event.Did,
+				Text:      record.Text,
+				FeedID:    matcher.feedID,
+				IndexedAt: time.UnixMicro(event.TimeUS),
+			}
+			if err := postRepo.Save(ctx, post); err != nil {
+				return false, fmt.Errorf("failed to archive %s: %w", post.URI, err)
+			}
+			logger.Debug("Archived post", "rule", matcher.name, "uri", post.URI)
+			return true, nil
+		}
+		return false, nil
+	}
+}
+
+// followerConsumer subscribes to follow records across the network to keep the user's follower set current.
+// Unless resuming from a stored cursor, the follower set is first reloaded from the API and the stream replays
+// from the moment loading started so no follow is missed in between.
+func followerConsumer(ctx context.Context, reg *registry.Registry, resume bool) (streamConsumer, error) {
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		return streamConsumer{}, fmt.Errorf("failed to get follower fetcher: %w", err)
+	}
+	if !fetcher.Authenticated() {
+		return streamConsumer{}, fmt.Errorf("not authenticated: run 'skycli login' first")
+	}
+
+	states, err := reg.GetFollowerStateRepo()
+	if err != nil {
+		return streamConsumer{}, fmt.Errorf("failed to get follower state: %w", err)
+	}
+
+	me := fetcher.GetDid()
+	opts := stream.Options{Collections: []string{"app.bsky.graph.follow"}}
+	if !resume {
+		start := time.Now()
+		followers, err := bsky.NewPaginator(bsky.FollowerPages(fetcher, me), bsky.PaginatorOptions{
+			Progress: logPageProgress("followers"),
+		}).All(ctx)
+		if err != nil {
+			return streamConsumer{}, fmt.Errorf("failed to fetch followers: %w", err)
+		}
+
+		dids := make([]string, len(followers))
+		for i, follower := range followers {
+			dids[i] = follower.Did
+		}
+		if err := states.Seed(ctx, me, dids, start); err != nil {
+			return streamConsumer{}, fmt.Errorf("failed to store followers: %w", err)
+		}
+		opts.Cursor = start.UnixMicro()
+		logger.Info("Tracking followers live", "followers", len(dids))
+	}
+
+	return streamConsumer{name: "followers", opts: opts, handle: followerHandler(fetcher, states, me)}, nil
+}
+
+// followerHandler applies follows of the user and deletions of follow records to the tracked follower set.
+// A deleted follow record is matched by URI; for followers loaded from the API, whose record is unknown, the
+// relationship is looked up to tell an unfollow of the user from an unfollow of someone else.
+func followerHandler(fetcher bsky.FollowerFetcher, states *bsky.FollowerStateRepository, me string) streamHandler {
+	var heartbeat time.Time
+	return func(ctx context.Context, event stream.Event) (bool, error) {
+		// Heartbeats carry event time so a replay of old events doesn't mark the follower set as current
+		if at := time.UnixMicro(event.TimeUS); at.Sub(heartbeat) >= time.Minute {
+			if err := states.Heartbeat(ctx, me, at); err != nil {
+				return false, err
+			}
+			heartbeat = at
+		}
+
+		if event.Commit == nil || event.Commit.Collection != "app.bsky.graph.follow" {
+			return false, nil
+		}
+
+		switch event.Commit.Operation {
+		case "create":
+			var record followRecord
+			if err := json.Unmarshal(event.Commit.Record, &record); err != nil || record.Subject != me {
+				return false, nil
+			}
+			added, err := states.AddFollower(ctx, me, event.Did, event.URI(), time.UnixMicro(event.TimeUS))
+			if err != nil {
+				return false, err
+			}
+			if added {
+				logger.Info("New follower", "did", event.Did)
+			}
+			return true, nil
+
+		case "delete":
+			did, err := states.RemoveFollowRecord(ctx, me, event.URI())
+			if err != nil {
+				return false, err
+			}
+			if did != "" {
+				logger.Info("Lost follower", "did", did)
+				return true, nil
+			}
+
+			uri, ok, err := states.FollowURI(ctx, me, event.Did)
+			if err != nil || !ok || uri != "" {
+				return false, err
+			}
+
+			response, err := fetcher.GetRelationships(ctx, me, []string{event.Did})
+			if err != nil {
+				logger.Warn("Failed to check relationship", "did", event.Did, "error", err)
+				return false, nil
+			}
+			if len(response.Relationships) == 1 && response.Relationships[0].FollowedBy != "" {
+				_, err := states.AddFollower(ctx, me, event.Did, response.Relationships[0].FollowedBy, time.Now())
+				return err == nil, err
+			}
+			if err := states.RemoveFollower(ctx, me, event.Did); err != nil {
+				return false, err
+			}
+			logger.Info("Lost follower", "did", event.Did)
+			return true, nil
+		}
+		return false, nil
+	}
+}
+
+// invalidHandle is what Bluesky shows for accounts whose handle no longer verifies
+const invalidHandle = "handle.invalid"
+
+// identityConsumer subscribes to identity and account events, and to profile record changes, to keep cached
+// profiles and the session's own handle current. Profile records are the only collection requested, since
+// identity and account events arrive regardless of collection and profile updates are rare.
+func identityConsumer(reg *registry.Registry) (streamConsumer, error) {
+	profiles, err := reg.GetProfileRepo()
+	if err != nil {
+		return streamConsumer{}, fmt.Errorf("failed to get profile repository: %w", err)
+	}
+	sessions, err := reg.GetSessionRepo()
+	if err != nil {
+		return streamConsumer{}, fmt.Errorf("failed to get session repository: %w", err)
+	}
+
+	return streamConsumer{
+		name:   "identity",
+		opts:   stream.Options{Collections: []string{"app.bsky.actor.profile"}},
+		handle: identityHandler(profiles, sessions),
+	}, nil
+}
+
+// identityHandler applies handle changes to cached profiles and the session, and drops cached profiles of
+// accounts that went inactive or edited their profile so they're fetched again when next shown
+func identityHandler(profiles *bsky.ProfileRepository, sessions *bsky.SessionRepository) streamHandler {
+	return func(ctx context.Context, event stream.Event) (bool, error) {
+		switch {
+		case event.Identity != nil:
+			handle := event.Identity.Handle
+			if handle == "" {
+				handle = invalidHandle
+			}
+
+			acted := false
+			if me, err := sessions.GetDid(ctx); err == nil && me == event.Did {
+				if current, _ := sessions.GetHandle(ctx); current != handle {
+					if err := sessions.UpdateHandle(ctx, handle); err != nil {
+						return false, fmt.Errorf("failed to update session handle: %w", err)
+					}
+					logger.Warn("Your handle changed", "from", current, "to", handle)
+					acted = true
+				}
+			}
+
+			updated, err := profiles.UpdateHandle(ctx, event.Did, handle)
+			if err != nil {
+				return false, err
+			}
+			if updated {
+				logger.Info("Updated cached handle", "did", event.Did, "handle", handle)
+			}
+			return acted || updated, nil
+
+		case event.Account != nil && !event.Account.Active:
+			return dropCachedProfile(ctx, profiles, event.Did, "account "+event.Account.Status)
+
+		case event.Commit != nil && event.Commit.Collection == "app.bsky.actor.profile":
+			return dropCachedProfile(ctx, profiles, event.Did, "profile "+event.Commit.Operation)
+		}
+		return false, nil
+	}
+}
+
+// dropCachedProfile removes did's cached profile, reporting false when it wasn't cached
+func dropCachedProfile(ctx context.Context, profiles *bsky.ProfileRepository, did, reason string) (bool, error) {
+	cached, err := profiles.GetByDid(ctx, did)
+	if err != nil || cached == nil {
+		return false, err
+	}
+	if err := profiles.DeleteByDid(ctx, did); err != nil {
+		return false, err
+	}
+	logger.Info("Dropped cached profile", "did", did, "handle", cached.Handle, "reason", reason)
+	return true, nil
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/stream"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func postEvent(did, rkey, text string) stream.Event {
+	record, _ := json.Marshal(postRecord{Text: text})
+	return stream.Event{Did: did, TimeUS: 1700000000000000, Kind: "commit", Commit: &stream.Commit{
+		Operation: "create", Collection: "app.bsky.feed.post", Rkey: rkey, Record: record,
+	}}
+}
+
+func TestArchiveRules(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	graph := &fakeGraph{pageSize: 10, lists: map[string][]bsky.ActorProfile{
+		"at://did:plc:me/app.bsky.graph.list/crew": testProfiles("did:plc:crew"),
+	}}
+	reg := registry.New(registry.Dependencies{FollowerFetcher: graph, FeedRepo: feedRepo, PostRepo: postRepo})
+
+	rules := []config.ArchiveRule{
+		{Name: "rust", Keywords: []string{"rustlang", " borrow checker "}},
+		{Name: "friends", Authors: []string{"did:plc:friend"}, Lists: []string{"at://did:plc:me/app.bsky.graph.list/crew"}},
+	}
+	matchers, err := prepareArchiveRules(ctx, reg, rules)
+	if err != nil {
+		t.Fatalf("prepareArchiveRules failed: %v", err)
+	}
+	if archiveDids(matchers) != nil {
+		t.Error("expected keyword rules to need the unfiltered stream")
+	}
+	if dids := archiveDids(matchers[1:]); strings.Join(dids, ",") != "did:plc:crew,did:plc:friend" {
+		t.Errorf("unexpected author DIDs %v", dids)
+	}
+
+	again, err := prepareArchiveRules(ctx, reg, rules)
+	if err != nil {
+		t.Fatalf("prepareArchiveRules failed: %v", err)
+	}
+	if again[0].feedID != matchers[0].feedID {
+		t.Error("expected archive feeds to be reused")
+	}
+
+	handle := archiveHandler(postRepo, matchers)
+	events := []stream.Event{
+		postEvent("did:plc:x", "1", "Fighting the Borrow Checker again"),
+		postEvent("did:plc:x", "2", "trustlangs are not a thing"),
+		postEvent("did:plc:crew", "3", "lunch?"),
+		postEvent("did:plc:friend", "4", "#rustlang rocks"),
+		{Did: "did:plc:friend", Kind: "commit", Commit: &stream.Commit{Operation: "delete", Collection: "app.bsky.feed.post", Rkey: "5"}},
+	}
+	acted := 0
+	for _, event := range events {
+		ok, err := handle(ctx, event)
+		if err != nil {
+			t.Fatalf("handler failed: %v", err)
+		}
+		if ok {
+			acted++
+		}
+	}
+	if acted != 3 {
+		t.Errorf("expected 3 events to be archived, got %d", acted)
+	}
+
+	rust, err := postRepo.QueryByFeedID(ctx, matchers[0].feedID, 10, 0)
+	if err != nil {
+		t.Fatalf("QueryByFeedID failed: %v", err)
+	}
+	friends, err := postRepo.QueryByFeedID(ctx, matchers[1].feedID, 10, 0)
+	if err != nil {
+		t.Fatalf("QueryByFeedID failed: %v", err)
+	}
+	if len(rust) != 2 || len(friends) != 1 || friends[0].URI != "at://did:plc:crew/app.bsky.feed.post/3" {
+		t.Errorf("unexpected archived posts: rust=%d friends=%+v", len(rust), friends)
+	}
+
+	feeds, err := resolveSavedFeeds(ctx, feedRepo, nil, true)
+	if err != nil || len(feeds) != 0 {
+		t.Errorf("expected archive feeds to be skipped by refresh --all, got %d (err %v)", len(feeds), err)
+	}
+
+	if _, err := prepareArchiveRules(ctx, reg, []config.ArchiveRule{{Name: "empty"}}); err == nil {
+		t.Error("expected error for a rule without criteria")
+	}
+}
+
+func followEvent(did, rkey, operation, subject string) stream.Event {
+	event := stream.Event{Did: did, TimeUS: time.Now().UnixMicro(), Kind: "commit", Commit: &stream.Commit{
+		Operation: operation, Collection: "app.bsky.graph.follow", Rkey: rkey,
+	}}
+	if subject != "" {
+		event.Commit.Record, _ = json.Marshal(followRecord{Subject: subject})
+	}
+	return event
+}
+
+func TestFollowerTracking(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	states, err := bsky.NewFollowerStateRepository()
+	if err != nil {
+		t.Fatalf("NewFollowerStateRepository failed: %v", err)
+	}
+	if err := states.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	t.Cleanup(func() { states.Close() })
+
+	ctx := context.Background()
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 10, followers: testProfiles("did:plc:a", "did:plc:b")}
+	reg := registry.New(registry.Dependencies{FollowerFetcher: graph, StateRepo: states})
+
+	if _, ok := trackedFollowers(ctx, reg, "did:plc:me", false); ok {
+		t.Fatal("expected no tracked followers before the stream starts")
+	}
+
+	consumer, err := followerConsumer(ctx, reg, false)
+	if err != nil {
+		t.Fatalf("followerConsumer failed: %v", err)
+	}
+	if consumer.opts.Cursor == 0 {
+		t.Error("expected the stream to replay from when followers were loaded")
+	}
+
+	graph.followers = testProfiles("did:plc:a")
+	events := []stream.Event{
+		followEvent("did:plc:new", "1", "create", "did:plc:me"),
+		followEvent("did:plc:other", "2", "create", "did:plc:someone"),
+		followEvent("did:plc:a", "3", "delete", ""),   // a unfollowed someone else
+		followEvent("did:plc:b", "4", "delete", ""),   // b unfollowed the user
+		followEvent("did:plc:new", "9", "delete", ""), // unrelated record of a known follower
+	}
+	for _, event := range events {
+		if _, err := consumer.handle(ctx, event); err != nil {
+			t.Fatalf("handler failed: %v", err)
+		}
+	}
+
+	followers, ok := trackedFollowers(ctx, reg, "did:plc:me", false)
+	if !ok {
+		t.Fatal("expected tracked followers while the stream is live")
+	}
+	slices.Sort(followers)
+	if strings.Join(followers, ",") != "did:plc:a,did:plc:new" {
+		t.Errorf("unexpected tracked followers %v", followers)
+	}
+
+	if acted, err := consumer.handle(ctx, followEvent("did:plc:new", "1", "delete", "")); err != nil || !acted {
+		t.Fatalf("handler failed: %v", err)
+	}
+	followers, _ = trackedFollowers(ctx, reg, "did:plc:me", false)
+	if strings.Join(followers, ",") != "did:plc:a" {
+		t.Errorf("expected follow record deletion to remove the follower, got %v", followers)
+	}
+
+	if _, ok := trackedFollowers(ctx, reg, "did:plc:me", true); ok {
+		t.Error("expected --refresh to bypass tracked followers")
+	}
+}
+
+// holdingJetstream serves events with the given time_us values and keeps the connection open until the client
+// leaves, recording the cursor each subscription asked for
+func holdingJetstream(t *testing.T, times ...int64) (string, *[]string) {
+	t.Helper()
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Query().Get("cursor"))
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Errorf("accept failed: %v", err)
+			return
+		}
+		defer conn.CloseNow()
+		for _, us := range times {
+			message := fmt.Sprintf(`{"did":"did:plc:a","time_us":%d,"kind":"identity"}`, us)
+			if err := conn.Write(r.Context(), websocket.MessageText, []byte(message)); err != nil {
+				return
+			}
+		}
+		conn.Read(r.Context())
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http"), &requested
+}
+
+// newStreamState returns stream cursors and events backed by a fresh cache database
+func newStreamState(t *testing.T) streamState {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	cursors, err := bsky.NewStreamCursorRepository()
+	if err != nil {
+		t.Fatalf("NewStreamCursorRepository failed: %v", err)
+	}
+	if err := cursors.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	t.Cleanup(func() { cursors.Close() })
+
+	events, err := bsky.NewStreamEventRepository()
+	if err != nil {
+		t.Fatalf("NewStreamEventRepository failed: %v", err)
+	}
+	if err := events.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	t.Cleanup(func() { events.Close() })
+	return streamState{cursors: cursors, events: events}
+}
+
+func TestRunConsumersReplay(t *testing.T) {
+	state := newStreamState(t)
+	cursors := state.cursors
+	ctx := context.Background()
+	endpoint, requested := holdingJetstream(t, 10, 20, 30)
+
+	var handled []int64
+	consumer := streamConsumer{name: "archive", handle: func(ctx context.Context, event stream.Event) (bool, error) {
+		handled = append(handled, event.TimeUS)
+		return true, nil
+	}}
+
+	if err := runConsumers(ctx, endpoint, []streamConsumer{consumer}, state, replayOptions{since: 5, until: 30}); err != nil {
+		t.Fatalf("runConsumers failed: %v", err)
+	}
+	if !slices.Equal(handled, []int64{10, 20}) {
+		t.Errorf("expected replay to stop before live events, handled %v", handled)
+	}
+	stored, err := cursors.Get(ctx, "archive")
+	if err != nil || stored == nil || stored.Cursor != 20 {
+		t.Fatalf("expected cursor 20 to be stored, got %+v (err %v)", stored, err)
+	}
+
+	handled = nil
+	if err := runConsumers(ctx, endpoint, []streamConsumer{consumer}, state, replayOptions{idle: 100 * time.Millisecond}); err != nil {
+		t.Fatalf("runConsumers failed: %v", err)
+	}
+	if !slices.Equal(handled, []int64{30}) {
+		t.Errorf("expected only the event not yet processed before going idle, handled %v", handled)
+	}
+	if strings.Join(*requested, ",") != "5,20" {
+		t.Errorf("expected to resume from --since and then the stored cursor, requested %v", *requested)
+	}
+	if stored, _ := cursors.Get(ctx, "archive"); stored == nil || stored.Cursor != 30 {
+		t.Errorf("expected cursor 30 to be stored, got %+v", stored)
+	}
+
+	handled = nil
+	if err := runConsumers(ctx, endpoint, []streamConsumer{consumer}, state, replayOptions{since: 5, idle: 100 * time.Millisecond}); err != nil {
+		t.Fatalf("runConsumers failed: %v", err)
+	}
+	if len(handled) != 0 {
+		t.Errorf("expected replayed events to be skipped, handled %v", handled)
+	}
+	processed, err := state.events.After(ctx, "archive", 0, 10)
+	if err != nil || len(processed) != 3 {
+		t.Errorf("expected 3 processed events in order, got %d (err %v)", len(processed), err)
+	}
+}
+
+func TestParseStreamCursor(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  int64
+	}{
+		{"", 0},
+		{"1717200000000000", 1717200000000000},
+		{"6h", now.Add(-6 * time.Hour).UnixMicro()},
+		{"2024-06-01", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).UnixMicro()},
+	}
+	for _, tt := range tests {
+		got, err := parseStreamCursor(tt.value, now)
+		if err != nil || got != tt.want {
+			t.Errorf("parseStreamCursor(%q) = %d, %v; want %d", tt.value, got, err, tt.want)
+		}
+	}
+	if _, err := parseStreamCursor("someday", now); err == nil {
+		t.Error("expected error for unparseable --since")
+	}
+}
+
+func TestIdentityHandler(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+
+	profiles, err := bsky.NewProfileRepository()
+	if err != nil {
+		t.Fatalf("NewProfileRepository failed: %v", err)
+	}
+	if err := profiles.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	t.Cleanup(func() { profiles.Close() })
+
+	sessions, err := bsky.NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+	session := &bsky.SessionModel{Handle: "me.bsky.social", Token: "access|refresh", ServiceURL: "https://bsky.social", IsValid: true}
+	session.SetID("did:plc:me")
+	if err := sessions.Save(ctx, session); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	for _, did := range []string{"did:plc:a", "did:plc:b", "did:plc:c"} {
+		model := &bsky.ProfileModel{Did: did, Handle: strings.TrimPrefix(did, "did:plc:") + ".bsky.social", DataJSON: `{"did":"` + did + `"}`}
+		if err := profiles.Save(ctx, model); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	handle := identityHandler(profiles, sessions)
+	events := []struct {
+		event stream.Event
+		acted bool
+	}{
+		{stream.Event{Did: "did:plc:a", Kind: "identity", Identity: &stream.Identity{Did: "did:plc:a", Handle: "a.example.com"}}, true},
+		{stream.Event{Did: "did:plc:a", Kind: "identity", Identity: &stream.Identity{Did: "did:plc:a", Handle: "a.example.com"}}, false},
+		{stream.Event{Did: "did:plc:me", Kind: "identity", Identity: &stream.Identity{Did: "did:plc:me", Handle: "me.example.com"}}, true},
+		{stream.Event{Did: "did:plc:x", Kind: "identity", Identity: &stream.Identity{Did: "did:plc:x"}}, false},
+		{stream.Event{Did: "did:plc:b", Kind: "account", Account: &stream.Account{Did: "did:plc:b", Status: "deactivated"}}, true},
+		{stream.Event{Did: "did:plc:c", Kind: "account", Account: &stream.Account{Did: "did:plc:c", Active: true}}, false},
+		{stream.Event{Did: "did:plc:c", Kind: "commit", Commit: &stream.Commit{Operation: "update", Collection: "app.bsky.actor.profile", Rkey: "self"}}, true},
+	}
+	for i, tt := range events {
+		acted, err := handle(ctx, tt.event)
+		if err != nil {
+			t.Fatalf("event %d: handler failed: %v", i, err)
+		}
+		if acted != tt.acted {
+			t.Errorf("event %d: acted = %v, want %v", i, acted, tt.acted)
+		}
+	}
+
+	if cached, _ := profiles.GetByDid(ctx, "did:plc:a"); cached == nil || cached.Handle != "a.example.com" {
+		t.Errorf("expected cached handle to follow the identity event, got %+v", cached)
+	}
+	for _, did := range []string{"did:plc:b", "did:plc:c"} {
+		if cached, _ := profiles.GetByDid(ctx, did); cached != nil {
+			t.Errorf("expected cached profile of %s to be dropped", did)
+		}
+	}
+	if own, _ := sessions.GetHandle(ctx); own != "me.example.com" {
+		t.Errorf("expected session handle to be updated, got %s", own)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/remote"
+	"github.com/stormlightlabs/skypanel/cli/internal/statesync"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// SyncStatePushAction merges local state into the remote state document and uploads it
+func SyncStatePushAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	backend, stores, err := prepareStateSync(reg)
+	if err != nil {
+		return err
+	}
+
+	local, err := statesync.Collect(ctx, stores)
+	if err != nil {
+		return err
+	}
+
+	remoteState, err := fetchRemoteState(ctx, backend)
+	if err != nil {
+		return err
+	}
+
+	merged := statesync.Merge(local, remoteState)
+
+	var buf bytes.Buffer
+	if err := merged.Encode(&buf); err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	logger.Infof("Uploading state to %s backend...", backend.Name())
+	if err := backend.Put(ctx, statesync.StateKey, &buf, int64(buf.Len())); err != nil {
+		return fmt.Errorf("failed to upload state: %w", err)
+	}
+
+	ui.Successln("Pushed state: %d feed(s), %d snapshot(s), %d read position(s), %d tag(s), %d note(s)",
+		len(merged.Feeds), len(merged.Snapshots), len(merged.ReadPositions), len(merged.Tags), len(merged.Notes))
+	return nil
+}
+
+// SyncStatePullAction downloads the remote state document and applies newer records locally
+func SyncStatePullAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	backend, stores, err := prepareStateSync(reg)
+	if err != nil {
+		return err
+	}
+
+	remoteState, err := fetchRemoteState(ctx, backend)
+	if err != nil {
+		return err
+	}
+	if remoteState == nil {
+		ui.Infoln("No remote state found. Run 'skycli sync state push' on another machine first.")
+		return nil
+	}
+
+	if ok, err := confirm(cmd, fmt.Sprintf("Apply remote state from %s? Newer remote feed definitions replace local ones.", backend.Name())); !ok {
+		return err
+	}
+
+	result, err := statesync.Apply(ctx, remoteState, stores)
+	if err != nil {
+		return fmt.Errorf("failed to apply state: %w", err)
+	}
+
+	ui.Successln("Pulled state from %s", backend.Name())
+	ui.Infoln("Feeds added: %d, updated: %d", result.FeedsAdded, result.FeedsUpdated)
+	if result.FeedsUndeleted > 0 || result.FeedsTrashed > 0 {
+		ui.Infoln("Feeds in the trash: %d restored after changes elsewhere, %d left there", result.FeedsUndeleted, result.FeedsTrashed)
+	}
+	ui.Infoln("Snapshots added: %d", result.SnapshotsAdded)
+	ui.Infoln("Read positions updated: %d", result.ReadPositionsUpdated)
+	ui.Infoln("Tags added: %d", result.TagsAdded)
+	ui.Infoln("Notes added: %d, updated: %d", result.NotesAdded, result.NotesUpdated)
+	return nil
+}
+
+// prepareStateSync loads the sync backend and the repositories holding syncable state
+func prepareStateSync(reg *registry.Registry) (remote.Backend, statesync.Stores, error) {
+	var stores statesync.Stores
+	var err error
+	if stores.Feeds, err = reg.GetFeedRepo(); err != nil {
+		return nil, stores, fmt.Errorf("failed to get feed repository: %w", err)
+	}
+	if stores.Snapshots, err = reg.GetSnapshotRepo(); err != nil {
+		return nil, stores, fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+	if stores.ReadPositions, err = reg.GetReadPositionRepo(); err != nil {
+		return nil, stores, fmt.Errorf("failed to get read position repository: %w", err)
+	}
+	if stores.Tags, err = reg.GetTagRepo(); err != nil {
+		return nil, stores, fmt.Errorf("failed to get tag repository: %w", err)
+	}
+	if stores.Notes, err = reg.GetNoteRepo(); err != nil {
+		return nil, stores, fmt.Errorf("failed to get note repository: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, stores, fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Sync == nil {
+		return nil, stores, fmt.Errorf("no sync backend configured: add a \"sync\" section to the config file (see 'skycli sync --help')")
+	}
+
+	backend, err := remote.New(cfg.Sync)
+	if err != nil {
+		return nil, stores, fmt.Errorf("failed to configure sync backend: %w", err)
+	}
+
+	return backend, stores, nil
+}
+
+// fetchRemoteState downloads and decodes the remote state document, returning nil if none exists yet
+func fetchRemoteState(ctx context.Context, backend remote.Backend) (*statesync.State, error) {
+	body, err := backend.Get(ctx, statesync.StateKey)
+	if err != nil {
+		if errors.Is(err, remote.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to download state: %w", err)
+	}
+	defer body.Close()
+
+	return statesync.Decode(body)
+}
+
+// SyncCommand returns the sync command for sharing local state across machines
+func SyncCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "sync",
+		Usage: "Sync local state across machines",
+		Description: `Sync non-secret local state (feed definitions, follower snapshots, feed reader
+   positions, tags, and notes on accounts) through a git repository or WebDAV server. Sessions and tokens are never synced.
+
+   Configure a backend in the "sync" section of ~/.skycli/.config.json:
+
+      "sync": { "backend": "git", "endpoint": "git@github.com:me/skycli-state.git", "branch": "main" }
+      "sync": { "backend": "webdav", "endpoint": "https://cloud.example.com/remote.php/dav/files/me/skycli", "username": "me" }
+
+   The WebDAV password is read from SKYCLI_WEBDAV_PASSWORD; git uses your existing SSH or
+   credential-helper setup. Conflicts are resolved per record: the most recently updated feed, read
+   position, and note win, and snapshots and tags are merged. Deletions are not synced: a feed in the local trash stays there on
+   pull unless it was changed on another machine after it was deleted.`,
+		Commands: []*cli.Command{
+			{
+				Name:  "state",
+				Usage: "Push or pull feeds, snapshots, read positions, tags, and notes",
+				Commands: []*cli.Command{
+					{
+						Name:      "push",
+						Usage:     "Merge local state into the remote copy and upload it",
+						ArgsUsage: " ",
+						Action:    withRegistry(SyncStatePushAction),
+					},
+					{
+						Name:      "pull",
+						Usage:     "Apply newer remote state to the local database",
+						ArgsUsage: " ",
+						Action:    withRegistry(SyncStatePullAction),
+					},
+				},
+			},
+		},
+	}
+}
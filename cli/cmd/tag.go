@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// tagTargets explains the arguments tag add and tag remove take
+const tagTargets = "Each target is a saved feed ID, a post as an AT URI or bsky.app link, or an account as @handle or DID. Handles need a login to resolve."
+
+// tagEntry is one tagged entity as listed by tag list --json
+type tagEntry struct {
+	Kind   bsky.TagKind `json:"kind"`
+	ID     string       `json:"id"`
+	Name   string       `json:"name,omitempty"` // feed name, for feeds
+	Tagged time.Time    `json:"tagged"`
+}
+
+// TagCommand returns the tag command with subcommands for local tags on feeds, stored posts, and accounts
+func TagCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "tag",
+		Usage: "Tag feeds, stored posts, and accounts locally to build segments for --tag filters",
+		Commands: []*cli.Command{
+			{
+				Name:        "add",
+				Usage:       "Tag feeds, posts, or accounts",
+				Description: tagTargets,
+				ArgsUsage:   "<tag> <target> [target...]",
+				Action:      withRegistry(TagAddAction),
+			},
+			{
+				Name:        "remove",
+				Aliases:     []string{"rm"},
+				Usage:       "Remove a tag from feeds, posts, or accounts",
+				Description: tagTargets,
+				ArgsUsage:   "<tag> <target> [target...]",
+				Action:      withRegistry(TagRemoveAction),
+			},
+			{
+				Name:      "list",
+				Usage:     "List tags in use, or what carries one tag",
+				ArgsUsage: "[tag]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output as JSON",
+					},
+				},
+				Action: withRegistry(TagListAction),
+			},
+		},
+	}
+}
+
+// TagAddAction tags each target with the tag given as the first argument
+func TagAddAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	tag, targets, err := tagArgs(ctx, cmd, reg)
+	if err != nil {
+		return err
+	}
+	tagRepo, err := reg.GetTagRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get tag repository: %w", err)
+	}
+
+	var added, total int64
+	for kind, ids := range targets {
+		n, err := tagRepo.Add(ctx, tag, kind, ids...)
+		if err != nil {
+			return fmt.Errorf("failed to tag %ss: %w", kind, err)
+		}
+		added += n
+		total += int64(len(ids))
+	}
+
+	ui.Successln("Tagged %d with %s", added, tag)
+	if added < total {
+		ui.Infoln("%d already had it", total-added)
+	}
+	return nil
+}
+
+// TagRemoveAction removes the tag given as the first argument from each target
+func TagRemoveAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	tag, targets, err := tagArgs(ctx, cmd, reg)
+	if err != nil {
+		return err
+	}
+	tagRepo, err := reg.GetTagRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get tag repository: %w", err)
+	}
+
+	var removed int64
+	for kind, ids := range targets {
+		n, err := tagRepo.Remove(ctx, tag, kind, ids...)
+		if err != nil {
+			return fmt.Errorf("failed to untag %ss: %w", kind, err)
+		}
+		removed += n
+	}
+
+	if removed == 0 {
+		ui.Infoln("None of them were tagged %s", tag)
+		return nil
+	}
+	ui.Successln("Removed %s from %d", tag, removed)
+	return nil
+}
+
+// tagArgs reads the tag and its targets from the arguments, resolving each target and grouping them by kind
+func tagArgs(ctx context.Context, cmd *cli.Command, reg *registry.Registry) (string, map[bsky.TagKind][]string, error) {
+	if cmd.Args().Len() < 2 {
+		return "", nil, fmt.Errorf("tag and at least one target required")
+	}
+	tag, err := bsky.NormalizeTag(cmd.Args().First())
+	if err != nil {
+		return "", nil, err
+	}
+
+	targets := make(map[bsky.TagKind][]string)
+	for _, input := range cmd.Args().Tail() {
+		kind, id, err := resolveTagTarget(ctx, reg, input)
+		if err != nil {
+			return "", nil, err
+		}
+		targets[kind] = append(targets[kind], id)
+	}
+	return tag, targets, nil
+}
+
+// resolveTagTarget works out what input names: a saved feed by ID, a post by AT URI or link (stored under its
+// author's DID), or an account, resolved to its DID
+func resolveTagTarget(ctx context.Context, reg *registry.Registry, input string) (bsky.TagKind, string, error) {
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get feed repository: %w", err)
+	}
+	if _, err := feedRepo.Get(ctx, input); err == nil {
+		return bsky.TagFeed, input, nil
+	} else if !errors.Is(err, bsky.ErrNotFound) {
+		return "", "", fmt.Errorf("failed to look up feed %s: %w", input, err)
+	}
+
+	ref, err := aturi.Parse(input)
+	if err != nil {
+		return "", "", fmt.Errorf("%q is not a saved feed ID, post, or account", input)
+	}
+	if !ref.IsRecord() {
+		did, err := resolveAuthorDID(ctx, reg, "account", ref.Authority)
+		if err != nil {
+			return "", "", err
+		}
+		return bsky.TagActor, did, nil
+	}
+	if ref.Collection != aturi.CollectionPost {
+		return "", "", fmt.Errorf("%q is a record in %s; only posts can be tagged", input, ref.Collection)
+	}
+	if !ref.HasDID() {
+		if ref.Authority, err = resolveAuthorDID(ctx, reg, "post author", ref.Authority); err != nil {
+			return "", "", err
+		}
+	}
+	return bsky.TagPost, ref.String(), nil
+}
+
+// TagListAction lists tags in use with their counts, or with a tag argument what carries that tag
+func TagListAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	tagRepo, err := reg.GetTagRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get tag repository: %w", err)
+	}
+	asJSON := cmd.Bool("json")
+
+	if cmd.Args().Len() == 0 {
+		counts, err := tagRepo.Counts(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list tags: %w", err)
+		}
+		if asJSON {
+			if counts == nil {
+				counts = []*bsky.TagCount{}
+			}
+			return ui.DisplayJSON(counts)
+		}
+		if len(counts) == 0 {
+			ui.Infoln("No tags yet. Add one with 'skycli tag add <tag> <target>'.")
+			return nil
+		}
+
+		rows := make([][]string, len(counts))
+		for i, count := range counts {
+			rows[i] = []string{count.Tag, fmt.Sprint(count.Feeds), fmt.Sprint(count.Posts), fmt.Sprint(count.Actors)}
+		}
+		ui.Titleln("Tags")
+		fmt.Println(stripedTable([]string{"Tag", "Feeds", "Posts", "Accounts"}, rows))
+		ui.Successln("Total: %d tag(s)", len(counts))
+		return nil
+	}
+
+	tag, err := bsky.NormalizeTag(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	models, err := tagRepo.List(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("failed to list tag %s: %w", tag, err)
+	}
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get feed repository: %w", err)
+	}
+
+	entries := make([]tagEntry, len(models))
+	for i, model := range models {
+		entries[i] = tagEntry{Kind: model.Kind, ID: model.EntityID, Tagged: model.CreatedAt}
+		if model.Kind == bsky.TagFeed {
+			if model, err := feedRepo.Get(ctx, model.EntityID); err == nil {
+				if feed, ok := model.(*bsky.FeedModel); ok {
+					entries[i].Name = feed.Name
+				}
+			}
+		}
+	}
+
+	if asJSON {
+		return ui.DisplayJSON(entries)
+	}
+	if len(entries) == 0 {
+		ui.Infoln("Nothing is tagged %s.", tag)
+		return nil
+	}
+
+	rows := make([][]string, len(entries))
+	for i, entry := range entries {
+		id := entry.ID
+		if entry.Name != "" {
+			id += " (" + entry.Name + ")"
+		}
+		rows[i] = []string{string(entry.Kind), id, ui.FormatDate(entry.Tagged, ui.DatesLocal)}
+	}
+	ui.Titleln("Tagged %s", tag)
+	fmt.Println(stripedTable([]string{"Kind", "Target", "Tagged"}, rows))
+	ui.Successln("Total: %d", len(entries))
+	return nil
+}
+
+// tagFlag returns the --tag flag limiting what a command reads to what carries a local tag
+func tagFlag(usage string) cli.Flag {
+	return &cli.StringFlag{
+		Name:  "tag",
+		Usage: usage + " (see 'skycli tag')",
+	}
+}
+
+// tagPostsUsage is the --tag usage for commands reading stored posts
+const tagPostsUsage = "Only posts with this local tag, stored under a feed with it, or by an account with it"
+
+// taggedSet returns what carries the --tag value, or nil when --tag is unset
+func taggedSet(ctx context.Context, cmd *cli.Command, reg *registry.Registry) (*bsky.TagSet, error) {
+	tag := cmd.String("tag")
+	if tag == "" {
+		return nil, nil
+	}
+	tagRepo, err := reg.GetTagRepo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag repository: %w", err)
+	}
+	set, err := tagRepo.Tagged(ctx, tag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --tag: %w", err)
+	}
+	return set, nil
+}
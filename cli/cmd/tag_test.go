@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestTagSegments(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	tagRepo, err := bsky.NewTagRepository()
+	if err != nil {
+		t.Fatalf("NewTagRepository failed: %v", err)
+	}
+	t.Cleanup(func() { tagRepo.Close() })
+
+	feed := &bsky.FeedModel{Name: "News", Source: "news.bsky.social", IsLocal: true}
+	if err := feedRepo.Save(ctx, feed); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	posts := []*bsky.PostModel{
+		{URI: "at://did:plc:a/app.bsky.feed.post/1", AuthorDID: "did:plc:a", Text: "in the feed", FeedID: feed.ID(), IndexedAt: time.Now()},
+		{URI: "at://did:plc:b/app.bsky.feed.post/2", AuthorDID: "did:plc:b", Text: "by a tagged account", FeedID: "other", IndexedAt: time.Now()},
+		{URI: "at://did:plc:c/app.bsky.feed.post/3", AuthorDID: "did:plc:c", Text: "tagged itself", FeedID: "other", IndexedAt: time.Now()},
+		{URI: "at://did:plc:c/app.bsky.feed.post/4", AuthorDID: "did:plc:c", Text: "untagged", FeedID: "other", IndexedAt: time.Now()},
+	}
+	if err := postRepo.BatchSave(ctx, posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me", pageSize: 10, followers: testProfiles("did:plc:a", "did:plc:b", "did:plc:c")}
+	reg := registry.New(registry.Dependencies{
+		FollowerFetcher: graph,
+		ProfileFetcher:  graph,
+		RateCache:       memoryRateCache{},
+		FeedRepo:        feedRepo,
+		PostRepo:        postRepo,
+		TagRepo:         tagRepo,
+	})
+
+	if _, err := runSubcommand(t, TagCommand(), "add", TagAddAction, reg,
+		"#VIP", feed.ID(), "did:plc:b", "at://did:plc:c/app.bsky.feed.post/3"); err != nil {
+		t.Fatalf("TagAddAction failed: %v", err)
+	}
+	if _, err := runSubcommand(t, TagCommand(), "add", TagAddAction, reg, "vip", "at://did:plc:c/app.bsky.graph.follow/1"); err == nil {
+		t.Error("expected an error tagging a record that isn't a post")
+	}
+
+	set, err := tagRepo.Tagged(ctx, "vip")
+	if err != nil {
+		t.Fatalf("Tagged failed: %v", err)
+	}
+	got, err := postRepo.Query(ctx, bsky.PostQuery{Tagged: set})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	var uris []string
+	for _, post := range got {
+		uris = append(uris, post.URI)
+	}
+	slices.Sort(uris)
+	want := []string{posts[0].URI, posts[1].URI, posts[2].URI}
+	if !slices.Equal(uris, want) {
+		t.Errorf("tagged posts = %v, want %v", uris, want)
+	}
+
+	out, err := runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, reg, "--output", "json", "--tag", "vip")
+	if err != nil {
+		t.Fatalf("ListFollowersAction failed: %v", err)
+	}
+	if infos := decodeFollowers(t, out); len(infos) != 1 || infos[0].Profile.Did != "did:plc:b" {
+		t.Errorf("expected only the tagged follower, got %+v", infos)
+	}
+
+	if _, err := runSubcommand(t, TagCommand(), "remove", TagRemoveAction, reg, "vip", "did:plc:b"); err != nil {
+		t.Fatalf("TagRemoveAction failed: %v", err)
+	}
+	out, err = runSubcommand(t, FollowersCommand(), "list", ListFollowersAction, reg, "--output", "json", "--tag", "vip")
+	if err != nil {
+		t.Fatalf("ListFollowersAction failed: %v", err)
+	}
+	if infos := decodeFollowers(t, out); len(infos) != 0 {
+		t.Errorf("expected no tagged followers after removing the tag, got %+v", infos)
+	}
+}
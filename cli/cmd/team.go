@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// TeamEnableAction verifies a shared Postgres database and switches snapshots and analytics to it
+func TeamEnableAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("connection string required (e.g. postgres://user@db.example.com/skycli)")
+	}
+	dsn := cmd.Args().First()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Team != nil {
+		if ok, err := confirm(cmd, "Team mode is already enabled. Replace the shared database connection?"); !ok {
+			return err
+		}
+	}
+
+	logger.Infof("Connecting to team database...")
+	snapshotRepo, err := bsky.NewPostgresSnapshotRepository(dsn)
+	if err != nil {
+		return fmt.Errorf("invalid connection string: %w", err)
+	}
+	defer snapshotRepo.Close()
+
+	if err := snapshotRepo.Init(ctx); err != nil {
+		return err
+	}
+
+	if cmd.Bool("copy-local") {
+		localRepo, err := reg.GetSnapshotRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get snapshot repository: %w", err)
+		}
+
+		copied, err := copySnapshots(ctx, localRepo, snapshotRepo)
+		if err != nil {
+			return err
+		}
+		ui.Infoln("Copied %d local snapshot(s) to the team database", copied)
+	}
+
+	cfg.Team = &config.TeamConfig{}
+	if err := cfg.Team.SetDatabaseURL(dsn); err != nil {
+		return fmt.Errorf("failed to encrypt connection string: %w", err)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	ui.Successln("Team mode enabled: snapshots and analytics now use the shared database")
+	return nil
+}
+
+// TeamDisableAction switches snapshots and analytics back to the local database
+func TeamDisableAction(ctx context.Context, cmd *cli.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.Team == nil {
+		ui.Infoln("Team mode is not enabled.")
+		return nil
+	}
+
+	if ok, err := confirm(cmd, "Disable team mode? Snapshots in the shared database will no longer be visible from this machine."); !ok {
+		return err
+	}
+
+	cfg.Team = nil
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	ui.Successln("Team mode disabled: snapshots and analytics now use the local database")
+	return nil
+}
+
+// TeamStatusAction reports whether team mode is active and summarizes the shared history
+func TeamStatusAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if !reg.TeamMode() {
+		ui.Infoln("Team mode is not enabled. Run 'skycli team enable <connection-string>' to share history.")
+		return nil
+	}
+
+	snapshotRepo, err := reg.GetSnapshotRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+
+	snapshots, err := snapshotRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	ui.Titleln("Team Mode")
+	ui.Successln("Connected to shared database")
+	ui.Infoln("Snapshots: %d", len(snapshots))
+	if len(snapshots) > 0 {
+		ui.Infoln("Latest: %s", snapshots[0].CreatedAt().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+// copySnapshots imports every snapshot from src into dst, skipping those dst already has
+func copySnapshots(ctx context.Context, src, dst bsky.SnapshotStore) (int, error) {
+	models, err := src.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	copied := 0
+	for _, model := range models {
+		if existing, err := dst.Get(ctx, model.ID()); err == nil && existing != nil {
+			continue
+		}
+
+		entries, err := src.GetEntries(ctx, model.ID())
+		if err != nil {
+			return copied, fmt.Errorf("failed to get snapshot entries: %w", err)
+		}
+		if err := dst.Import(ctx, model.(*bsky.SnapshotModel), entries); err != nil {
+			return copied, fmt.Errorf("failed to copy snapshot %s: %w", model.ID(), err)
+		}
+		copied++
+	}
+	return copied, nil
+}
+
+// TeamCommand returns the team command for shared-audience mode
+func TeamCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "team",
+		Usage: "Share snapshot history and analytics through a Postgres database",
+		Description: `Team mode stores follower snapshots and post-rate/activity analytics in a shared
+   Postgres database so everyone managing the same account sees one history. Sessions, feeds,
+   and posts stay local.
+
+   The connection string is encrypted in the config file. To keep the password out of it entirely,
+   omit it and set PGPASSWORD, or set ` + config.TeamDatabaseURLEnv + ` to override the stored value.`,
+		Commands: []*cli.Command{
+			{
+				Name:      "enable",
+				Usage:     "Verify the shared database and switch to team mode",
+				ArgsUsage: "<connection-string>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "copy-local",
+						Usage: "Copy existing local snapshots into the shared database",
+					},
+				},
+				Action: withRegistry(TeamEnableAction),
+			},
+			{
+				Name:      "disable",
+				Usage:     "Return to local-only storage",
+				ArgsUsage: " ",
+				Action:    TeamDisableAction,
+			},
+			{
+				Name:      "status",
+				Usage:     "Show team mode status",
+				ArgsUsage: " ",
+				Action:    withRegistry(TeamStatusAction),
+			},
+		},
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/dateparse"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// trashedFeed is a feed in the trash as listed by trash list --json
+type trashedFeed struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Source    string    `json:"source"`
+	Posts     int       `json:"posts"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// trashedPost is a post trashed apart from its feed as listed by trash list --json
+type trashedPost struct {
+	ID        string    `json:"id"`
+	URI       string    `json:"uri"`
+	FeedID    string    `json:"feed_id"`
+	AuthorDID string    `json:"author_did"`
+	Text      string    `json:"text"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TrashCommand returns the trash command with subcommands for recovering deleted feeds and posts
+func TrashCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "trash",
+		Usage: "Recover or permanently remove deleted feeds and posts",
+		Commands: []*cli.Command{
+			{
+				Name:      "list",
+				Usage:     "List the feeds and posts in the trash",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Usage:   "Maximum number of posts to show (0 for all)",
+						Value:   20,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output the trash as JSON",
+					},
+				},
+				Action: withRegistry(TrashListAction),
+			},
+			{
+				Name:      "restore",
+				Usage:     "Take feeds or posts back out of the trash",
+				UsageText: "Takes the IDs shown by 'skycli trash list'. Restoring a feed also restores the posts stored under it.",
+				ArgsUsage: "<id> [id...]",
+				Action:    withRegistry(TrashRestoreAction),
+			},
+			{
+				Name:      "empty",
+				Usage:     "Permanently remove what is in the trash",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "before",
+						Usage: "Only what was deleted before a date (YYYY-MM-DD), RFC3339 time, lookback (24h, 30d), or expression (last monday)",
+					},
+				},
+				Action: withRegistry(TrashEmptyAction),
+			},
+		},
+	}
+}
+
+// TrashListAction lists trashed feeds with how many of their posts are trashed, then the most recently trashed posts
+// of feeds not in the trash
+func TrashListAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get feed repository: %w", err)
+	}
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	feedModels, err := feedRepo.Trash(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list trashed feeds: %w", err)
+	}
+	counts, err := postRepo.CountTrash(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count trashed posts: %w", err)
+	}
+
+	loose := 0
+	for _, count := range counts {
+		loose += count
+	}
+	feeds := make([]trashedFeed, len(feedModels))
+	feedIDs := make([]string, len(feedModels))
+	for i, feed := range feedModels {
+		feeds[i] = trashedFeed{ID: feed.ID(), Name: feed.Name, Source: feed.Source, Posts: counts[feed.ID()], DeletedAt: feed.DeletedAt}
+		feedIDs[i] = feed.ID()
+		loose -= counts[feed.ID()]
+	}
+
+	postModels, err := postRepo.Trash(ctx, cmd.Int("limit"), feedIDs...)
+	if err != nil {
+		return fmt.Errorf("failed to list trashed posts: %w", err)
+	}
+	posts := make([]trashedPost, len(postModels))
+	for i, post := range postModels {
+		posts[i] = trashedPost{ID: post.ID(), URI: post.URI, FeedID: post.FeedID, AuthorDID: post.AuthorDID, Text: post.Text, DeletedAt: post.DeletedAt}
+	}
+
+	if cmd.Bool("json") {
+		return ui.DisplayJSON(map[string]any{"feeds": feeds, "posts": posts})
+	}
+
+	if len(feeds) == 0 && len(posts) == 0 {
+		ui.Infoln("Trash is empty.")
+		return nil
+	}
+
+	if len(feeds) > 0 {
+		rows := make([][]string, len(feeds))
+		for i, feed := range feeds {
+			rows[i] = []string{feed.ID, feed.Name, feed.Source, fmt.Sprint(feed.Posts), ui.FormatDate(feed.DeletedAt, ui.DatesLocal)}
+		}
+		ui.Titleln("Trashed Feeds")
+		fmt.Println(stripedTable([]string{"ID", "Name", "Source", "Posts", "Deleted"}, rows))
+		ui.Successln("Total: %d feed(s)", len(feeds))
+	}
+
+	if len(posts) > 0 {
+		if len(feeds) > 0 {
+			fmt.Println()
+		}
+		rows := make([][]string, len(posts))
+		for i, post := range posts {
+			text := strings.Join(strings.Fields(post.Text), " ")
+			if runes := []rune(text); len(runes) > 60 {
+				text = string(runes[:57]) + "..."
+			}
+			rows[i] = []string{post.ID, post.FeedID, post.AuthorDID, text, ui.FormatDate(post.DeletedAt, ui.DatesLocal)}
+		}
+		ui.Titleln("Trashed Posts")
+		fmt.Println(stripedTable([]string{"ID", "Feed", "Author", "Text", "Deleted"}, rows))
+		ui.Successln("Showing %d of %d post(s)", len(posts), loose)
+	}
+	return nil
+}
+
+// stripedTable renders rows in the striped table style of the other listings
+func stripedTable(headers []string, rows [][]string) string {
+	return lgtable.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(ui.TableBorderStyle).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == lgtable.HeaderRow {
+				return ui.TableHeaderStyle
+			}
+			if row%2 == 0 {
+				return ui.TableRowEvenStyle
+			}
+			return ui.TableRowOddStyle
+		}).
+		String()
+}
+
+// TrashRestoreAction takes each feed or post given by ID out of the trash, along with a restored feed's posts
+func TrashRestoreAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("feed or post ID required")
+	}
+
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get feed repository: %w", err)
+	}
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	for _, id := range cmd.Args().Slice() {
+		if err := restoreTrashed(ctx, feedRepo, postRepo, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreTrashed restores id as a feed, with its posts, or failing that as a single post
+func restoreTrashed(ctx context.Context, feedRepo *bsky.FeedRepository, postRepo *bsky.PostRepository, id string) error {
+	err := feedRepo.Undelete(ctx, id)
+	if err == nil {
+		restored, err := postRepo.UndeleteByFeedID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("restored feed %s but not its posts: %w", id, err)
+		}
+		ui.Successln("Restored feed %s with %d post(s)", id, restored)
+		return nil
+	}
+	if !errors.Is(err, bsky.ErrNotFound) {
+		return fmt.Errorf("failed to restore feed %s: %w", id, err)
+	}
+
+	if err := postRepo.Undelete(ctx, id); err != nil {
+		if errors.Is(err, bsky.ErrNotFound) {
+			return fmt.Errorf("no feed or post with ID %s in the trash: %w", id, err)
+		}
+		return fmt.Errorf("failed to restore post %s: %w", id, err)
+	}
+	ui.Successln("Restored post %s", id)
+	return nil
+}
+
+// TrashEmptyAction permanently removes trashed feeds and posts, or with --before only those deleted before then
+func TrashEmptyAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	before, err := dateparse.Parse(cmd.String("before"), userNow())
+	if err != nil {
+		return fmt.Errorf("invalid --before %q: %w", cmd.String("before"), err)
+	}
+
+	feedRepo, err := reg.GetFeedRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get feed repository: %w", err)
+	}
+	postRepo, err := reg.GetPostRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get post repository: %w", err)
+	}
+
+	prompt := "Permanently remove everything in the trash?"
+	if !before.IsZero() {
+		prompt = fmt.Sprintf("Permanently remove everything deleted before %s?", ui.FormatDate(before, ui.DatesLocal))
+	}
+	if ok, err := confirm(cmd, prompt); !ok {
+		return err
+	}
+
+	posts, err := postRepo.EmptyTrash(ctx, before)
+	if err != nil {
+		return fmt.Errorf("failed to empty trashed posts: %w", err)
+	}
+	feeds, err := feedRepo.EmptyTrash(ctx, before)
+	if err != nil {
+		return fmt.Errorf("failed to empty trashed feeds: %w", err)
+	}
+
+	if feeds == 0 && posts == 0 {
+		ui.Infoln("Nothing to remove.")
+		return nil
+	}
+	ui.Successln("Removed %d feed(s) and %d post(s)", feeds, posts)
+	return nil
+}
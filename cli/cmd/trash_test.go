@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestRestoreTrashed(t *testing.T) {
+	feedRepo, postRepo := newFeedRepos(t)
+	ctx := context.Background()
+
+	feed := &bsky.FeedModel{Name: "Archive", Source: "archive.bsky.social", IsLocal: true}
+	if err := feedRepo.Save(ctx, feed); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	posts := []*bsky.PostModel{
+		{URI: "at://did:plc:a/app.bsky.feed.post/1", AuthorDID: "did:plc:a", Text: "one", FeedID: feed.ID(), IndexedAt: time.Now()},
+		{URI: "at://did:plc:a/app.bsky.feed.post/2", AuthorDID: "did:plc:a", Text: "two", FeedID: feed.ID(), IndexedAt: time.Now()},
+		{URI: "at://did:plc:b/app.bsky.feed.post/3", AuthorDID: "did:plc:b", Text: "three", FeedID: "other", IndexedAt: time.Now()},
+	}
+	if err := postRepo.BatchSave(ctx, posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	if err := feedRepo.Delete(ctx, feed.ID()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := postRepo.DeleteByFeedID(ctx, feed.ID()); err != nil {
+		t.Fatalf("DeleteByFeedID failed: %v", err)
+	}
+	if err := postRepo.Delete(ctx, posts[2].ID()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if err := restoreTrashed(ctx, feedRepo, postRepo, feed.ID()); err != nil {
+		t.Fatalf("restoring the feed failed: %v", err)
+	}
+	if _, err := feedRepo.Get(ctx, feed.ID()); err != nil {
+		t.Errorf("feed still missing after restore: %v", err)
+	}
+	if count, err := postRepo.CountByFeedID(ctx, feed.ID()); err != nil || count != 2 {
+		t.Errorf("expected the feed's 2 posts restored with it, got %d (%v)", count, err)
+	}
+
+	if err := restoreTrashed(ctx, feedRepo, postRepo, posts[2].ID()); err != nil {
+		t.Fatalf("restoring the post failed: %v", err)
+	}
+	if _, err := postRepo.Get(ctx, posts[2].ID()); err != nil {
+		t.Errorf("post still missing after restore: %v", err)
+	}
+
+	err := restoreTrashed(ctx, feedRepo, postRepo, feed.ID())
+	if !errors.Is(err, bsky.ErrNotFound) {
+		t.Errorf("restoring something not in the trash: got %v, want ErrNotFound", err)
+	}
+}
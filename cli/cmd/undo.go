@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	lgtable "github.com/charmbracelet/lipgloss/table"
+	"github.com/stormlightlabs/skypanel/cli/internal/registry"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// UndoCommand returns the undo command for reverting follows, blocks, list additions, posts, likes, reposts, and replies
+// made by skycli
+func UndoCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "undo",
+		Usage:     "Revert recent follows, blocks, list additions, posts, likes, reposts, and replies made by skycli",
+		UsageText: "Delete the records created by the most recent actions in the undo log, newest first.",
+		ArgsUsage: " ",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    "last",
+				Aliases: []string{"n"},
+				Usage:   "Number of recent actions to revert (0 = all)",
+				Value:   1,
+			},
+			&cli.BoolFlag{
+				Name:  "list",
+				Usage: "Show the undo log instead of reverting anything",
+			},
+			&cli.DurationFlag{
+				Name:  "pace",
+				Usage: "Delay between record deletions",
+				Value: time.Second,
+			},
+		},
+		Action: withRegistry(UndoAction),
+	}
+}
+
+// UndoAction deletes the records behind the most recent logged actions and marks them as undone
+func UndoAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
+	actions, err := reg.GetActionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get action log: %w", err)
+	}
+
+	if cmd.Bool("list") {
+		logged, err := actions.Recent(ctx, cmd.Int("last"), true)
+		if err != nil {
+			return fmt.Errorf("failed to read action log: %w", err)
+		}
+		displayActions(logged)
+		return nil
+	}
+
+	writer, err := reg.GetGraphWriter()
+	if err != nil {
+		return fmt.Errorf("failed to get graph writer: %w", err)
+	}
+
+	pending, err := actions.Recent(ctx, cmd.Int("last"), false)
+	if err != nil {
+		return fmt.Errorf("failed to read action log: %w", err)
+	}
+	if len(pending) == 0 {
+		ui.Infoln("Nothing to undo")
+		return nil
+	}
+
+	displayActions(pending)
+	if ok, err := confirm(cmd, fmt.Sprintf("Revert %d action(s)?", len(pending))); !ok {
+		return err
+	}
+
+	reverted, failed := 0, 0
+	for i, action := range pending {
+		if i > 0 {
+			if err := waitPace(ctx, cmd.Duration("pace")); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.DeleteRecord(ctx, action.RecordURI); err != nil {
+			logger.Warn("Failed to revert action", "action", action.Action, "did", action.SubjectDid, "error", err)
+			failed++
+			continue
+		}
+		if err := actions.MarkUndone(ctx, action.ID(), time.Now()); err != nil {
+			logger.Warn("Failed to update action log", "id", action.ID(), "error", err)
+		}
+		reverted++
+	}
+
+	ui.Successln("Reverted %d action(s) (%d failed)", reverted, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to revert %d action(s)", failed)
+	}
+	return nil
+}
+
+// recordAction appends a completed action to the undo log; failures are logged since the change itself succeeded
+func recordAction(ctx context.Context, actions *bsky.ActionRepository, kind, subject, uri string) {
+	if err := actions.Record(ctx, &bsky.ActionModel{Action: kind, SubjectDid: subject, RecordURI: uri}); err != nil {
+		logger.Warn("Failed to record action in undo log", "action", kind, "did", subject, "error", err)
+	}
+}
+
+// waitPace sleeps for pace unless ctx is cancelled first
+func waitPace(ctx context.Context, pace time.Duration) error {
+	if pace <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(pace)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// displayActions renders undo log entries as a table, newest first
+func displayActions(actions []*bsky.ActionModel) {
+	if len(actions) == 0 {
+		ui.Infoln("The undo log is empty")
+		return
+	}
+
+	ui.Titleln("Actions (%d)", len(actions))
+	fmt.Println()
+
+	rows := make([][]string, len(actions))
+	for i, action := range actions {
+		status := "active"
+		if action.Undone() {
+			status = "undone " + action.UndoneAt.In(ui.Location()).Format("2006-01-02 15:04")
+		}
+		rows[i] = []string{
+			action.CreatedAt().In(ui.Location()).Format("2006-01-02 15:04"),
+			action.Action,
+			action.SubjectDid,
+			status,
+		}
+	}
+
+	re := lipgloss.NewRenderer(os.Stdout)
+	t := lgtable.New().Border(lipgloss.NormalBorder()).BorderStyle(ui.TableBorderStyle).
+		Headers("When", "Action", "Account", "Status").Rows(rows...)
+	t = t.StyleFunc(func(row, col int) lipgloss.Style {
+		if row == lgtable.HeaderRow {
+			return ui.TableHeaderStyle
+		}
+		if row%2 == 0 {
+			return ui.TableRowEvenStyle
+		}
+		return ui.TableRowOddStyle
+	})
+
+	fmt.Println(re.NewStyle().Render(t.String()))
+	fmt.Println()
+}
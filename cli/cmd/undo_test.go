@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+func TestUndoAction(t *testing.T) {
+	graph := &fakeGraph{authenticated: true, did: "did:plc:me"}
+	reg, actions := newActionRegistry(t, graph)
+	ctx := context.Background()
+
+	for _, uri := range []string{"at://did:plc:me/app.bsky.graph.follow/1", "at://did:plc:me/app.bsky.graph.follow/2"} {
+		if err := actions.Record(ctx, &bsky.ActionModel{Action: bsky.ActionFollow, SubjectDid: "did:plc:x", RecordURI: uri}); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	parent := &cli.Command{Name: "skycli", Commands: []*cli.Command{UndoCommand()}}
+	parent.Reader = strings.NewReader("y\n")
+	if _, err := runSubcommand(t, parent, "undo", UndoAction, reg, "--last", "0", "--pace", "0"); err != nil {
+		t.Fatalf("UndoAction failed: %v", err)
+	}
+
+	slices.Sort(graph.deleted)
+	if want := []string{"at://did:plc:me/app.bsky.graph.follow/1", "at://did:plc:me/app.bsky.graph.follow/2"}; !slices.Equal(graph.deleted, want) {
+		t.Errorf("deleted %v, want %v", graph.deleted, want)
+	}
+
+	pending, err := actions.Recent(ctx, 0, false)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("expected every action marked undone, got %+v", pending)
+	}
+}
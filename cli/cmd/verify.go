@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/verify"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+	"github.com/urfave/cli/v3"
+)
+
+// verifyFlags returns the flags that check a fetched record against its owner's signed repository
+func verifyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "verify",
+			Usage: "Check the record against a signed proof from the owner's PDS: block hashes, commit signature, and CID",
+		},
+		&cli.StringFlag{
+			Name:  "plc-directory",
+			Usage: "PLC directory used to look up did:plc signing keys with --verify",
+			Value: verify.DefaultPLCDirectory,
+		},
+	}
+}
+
+// verifiedRecord pairs a record with its verification for JSON output
+type verifiedRecord struct {
+	Record       any            `json:"record"`
+	Verification *verify.Result `json:"verification"`
+}
+
+// verifyRecord checks a served record against the repository of the account at authority
+func verifyRecord(ctx context.Context, cmd *cli.Command, authority, collection, rkey string, served bsky.Record) *verify.Result {
+	logger.Debug("Verifying record", "authority", authority, "collection", collection, "rkey", rkey)
+	result := verify.NewVerifier(cmd.String("plc-directory")).Verify(ctx, authority, collection, rkey, served)
+	logger.Debug("Verified record", "uri", result.URI, "status", result.Status)
+	return result
+}
+
+// verifyPost checks a post, as the AppView served it, against its author's repository
+func verifyPost(ctx context.Context, cmd *cli.Command, post *bsky.PostView) (*verify.Result, error) {
+	ref, err := aturi.Parse(post.Uri)
+	if err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(post.Record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode post record: %w", err)
+	}
+	return verifyRecord(ctx, cmd, ref.Authority, ref.Collection, ref.Rkey, bsky.Record{Uri: post.Uri, Cid: post.Cid, Value: value}), nil
+}
+
+// displayVerification prints each verification check and the overall status
+func displayVerification(result *verify.Result) {
+	fmt.Println()
+	ui.Titleln("Verification")
+	for _, check := range result.Checks {
+		mark := "ok  "
+		if !check.OK {
+			mark = "FAIL"
+		}
+		ui.Infoln("%s %-10s %s", mark, check.Name, check.Detail)
+	}
+
+	switch result.Status {
+	case verify.StatusVerified:
+		ui.Successln("Verified against commit %s", result.Commit)
+	case verify.StatusUnverifiable:
+		ui.Warningln("Could not verify this record")
+	default:
+		ui.Errorln("Record is %s", result.Status)
+	}
+}
+
+// verificationError fails the command when a record didn't hold up, so scripts can rely on the exit status.
+// An unverifiable record is reported but isn't an error.
+func verificationError(result *verify.Result) error {
+	switch result.Status {
+	case verify.StatusTampered, verify.StatusInconsistent:
+		return fmt.Errorf("verification failed for %s: record is %s", result.URI, result.Status)
+	}
+	return nil
+}
@@ -2,26 +2,20 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"regexp"
-	"strings"
 
 	"github.com/google/uuid"
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
 	"github.com/stormlightlabs/skypanel/cli/internal/registry"
-	"github.com/stormlightlabs/skypanel/cli/internal/setup"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
 	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/internal/verify"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 	"github.com/urfave/cli/v3"
 )
 
 // ViewFeedAction views posts from a feed (fetches from API)
-func ViewFeedAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
+func ViewFeedAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("feed URI or local feed ID required")
 	}
@@ -52,7 +46,7 @@ func ViewFeedAction(ctx context.Context, cmd *cli.Command) error {
 		if err != nil {
 			return fmt.Errorf("failed to get local feed: %w", err)
 		}
-		if feedModel, ok := feed.(*store.FeedModel); ok {
+		if feedModel, ok := feed.(*bsky.FeedModel); ok {
 			feedURI = feedModel.Source
 			logger.Debug("Resolved local feed ID to URI", "id", feedIdentifier, "uri", feedURI)
 		}
@@ -62,28 +56,26 @@ func ViewFeedAction(ctx context.Context, cmd *cli.Command) error {
 
 	logger.Debug("Fetching feed from API", "uri", feedURI, "limit", limit, "cursor", cursor)
 
-	response, err := service.GetAuthorFeed(ctx, feedURI, limit, cursor)
+	posts, next, err := collectFeed(ctx, bsky.AuthorFeedPages(service, feedURI), limit, cursor)
 	if err != nil {
 		return fmt.Errorf("failed to fetch feed: %w", err)
 	}
+	response := &bsky.GetAuthorFeedResponse{Feed: posts, Cursor: next}
 
 	if asJSON {
-		return ui.DisplayJSON(response)
+		if err := ui.DisplayJSON(response); err != nil {
+			return err
+		}
+	} else {
+		ui.Titleln("Feed: %s", feedURI)
+		ui.DisplayFeed(response.Feed, response.Cursor)
 	}
 
-	ui.Titleln("Feed: %s", feedURI)
-	ui.DisplayFeed(response.Feed, response.Cursor)
-	return nil
+	return copyOutput(cmd, "copy", "feed URI", feedURI)
 }
 
 // ViewPostAction views a single post by URI or URL
-func ViewPostAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
+func ViewPostAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("post URI or URL required")
 	}
@@ -100,10 +92,11 @@ func ViewPostAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("not authenticated: run 'skycli login' first")
 	}
 
-	postURI, err := parsePostIdentifier(postIdentifier)
+	ref, err := aturi.ParsePost(postIdentifier)
 	if err != nil {
-		return fmt.Errorf("failed to parse post identifier: %w", err)
+		return err
 	}
+	postURI := ref.String()
 
 	logger.Debug("Fetching post", "uri", postURI)
 
@@ -116,29 +109,52 @@ func ViewPostAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("post not found: %s", postURI)
 	}
 
-	if asJSON {
-		return ui.DisplayJSON(response.Posts[0])
+	var verification *verify.Result
+	if cmd.Bool("verify") {
+		if verification, err = verifyPost(ctx, cmd, response.Posts[0].Post); err != nil {
+			return err
+		}
 	}
 
-	ui.Titleln("Post View")
-	ui.DisplayFeed([]store.FeedViewPost{response.Posts[0]}, "")
+	switch {
+	case asJSON && verification != nil:
+		if err := ui.DisplayJSON(verifiedRecord{Record: response.Posts[0], Verification: verification}); err != nil {
+			return err
+		}
+	case asJSON:
+		if err := ui.DisplayJSON(response.Posts[0]); err != nil {
+			return err
+		}
+	default:
+		ui.Titleln("Post View")
+		ui.DisplayFeed([]bsky.FeedViewPost{response.Posts[0]}, "")
+		if verification != nil {
+			displayVerification(verification)
+		}
+	}
 
+	if err := copyOutput(cmd, "copy-uri", "post URI", response.Posts[0].Post.Uri); err != nil {
+		return err
+	}
+	if err := copyOutput(cmd, "copy", "post text", postText(response.Posts[0].Post)); err != nil {
+		return err
+	}
+	if verification != nil {
+		return verificationError(verification)
+	}
 	return nil
 }
 
 // ViewProfileAction views an actor's profile with stats
-func ViewProfileAction(ctx context.Context, cmd *cli.Command) error {
-	if err := setup.EnsurePersistenceReady(ctx); err != nil {
-		return fmt.Errorf("persistence layer not ready: %w", err)
-	}
-
-	reg := registry.Get()
-
+func ViewProfileAction(ctx context.Context, cmd *cli.Command, reg *registry.Registry) error {
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("actor handle or DID required")
 	}
 
-	actor := cmd.Args().First()
+	actor, err := parseActorArg("actor", cmd.Args().First())
+	if err != nil {
+		return err
+	}
 	showPosts := cmd.Bool("with-posts")
 	asJSON := cmd.Bool("json")
 
@@ -155,14 +171,18 @@ func ViewProfileAction(ctx context.Context, cmd *cli.Command) error {
 
 	profile, err := service.GetProfile(ctx, actor)
 	if err != nil {
-		return fmt.Errorf("failed to fetch profile: %w", err)
+		return profileError("actor", cmd.Args().First(), err)
 	}
 
 	if asJSON {
-		return ui.DisplayJSON(profile)
+		if err := ui.DisplayJSON(profile); err != nil {
+			return err
+		}
+		return copyOutput(cmd, "copy", "profile URL", profileURL(profile.Handle))
 	}
 
 	ui.DisplayProfileHeader(profile)
+	displayProfileNote(ctx, reg, profile.Did)
 
 	if showPosts {
 		logger.Debug("Fetching recent posts", "actor", actor)
@@ -176,9 +196,61 @@ func ViewProfileAction(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
+	return copyOutput(cmd, "copy", "profile URL", profileURL(profile.Handle))
+}
+
+// displayProfileNote prints the local note on an account under its profile, if there is one
+func displayProfileNote(ctx context.Context, reg *registry.Registry, did string) {
+	noteRepo, err := reg.GetNoteRepo()
+	if err != nil {
+		return
+	}
+	note, err := noteRepo.Get(ctx, did)
+	if err != nil {
+		if !errors.Is(err, bsky.ErrNotFound) {
+			logger.Warn("Failed to read note", "error", err)
+		}
+		return
+	}
+
+	ui.Subtitleln("Note")
+	printNote(note.Note)
+	fmt.Println()
+}
+
+// copyOutput places value on the clipboard when the named flag is set
+func copyOutput(cmd *cli.Command, flag, what, value string) error {
+	if !cmd.Bool(flag) {
+		return nil
+	}
+	if value == "" {
+		return fmt.Errorf("nothing to copy: %s is empty", what)
+	}
+	if err := ui.CopyToClipboard(value); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", what, err)
+	}
+	ui.Successln("Copied %s to clipboard", what)
 	return nil
 }
 
+// postText returns the text of a post's record, or "" when it has none
+func postText(post *bsky.PostView) string {
+	if post == nil {
+		return ""
+	}
+	if record, ok := post.Record.(map[string]any); ok {
+		if text, ok := record["text"].(string); ok {
+			return text
+		}
+	}
+	return ""
+}
+
+// profileURL returns the bsky.app URL of a profile
+func profileURL(handle string) string {
+	return "https://bsky.app/profile/" + handle
+}
+
 // ViewCommand returns the view command with subcommands for feed, post, and profile
 func ViewCommand() *cli.Command {
 	return &cli.Command{
@@ -206,21 +278,33 @@ func ViewCommand() *cli.Command {
 						Aliases: []string{"j"},
 						Usage:   "Output raw JSON response",
 					},
+					&cli.BoolFlag{
+						Name:  "copy",
+						Usage: "Copy the feed URI to the clipboard",
+					},
 				},
-				Action: ViewFeedAction,
+				Action: withRegistry(ViewFeedAction),
 			},
 			{
 				Name:      "post",
 				Usage:     "View a single post by URI or bsky.app URL",
 				ArgsUsage: "<post-uri-or-url>",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					&cli.BoolFlag{
 						Name:    "json",
 						Aliases: []string{"j"},
 						Usage:   "Output raw JSON response",
 					},
-				},
-				Action: ViewPostAction,
+					&cli.BoolFlag{
+						Name:  "copy",
+						Usage: "Copy the post text to the clipboard",
+					},
+					&cli.BoolFlag{
+						Name:  "copy-uri",
+						Usage: "Copy the post's AT URI to the clipboard",
+					},
+				}, verifyFlags()...),
+				Action: withRegistry(ViewPostAction),
 			},
 			{
 				Name:      "profile",
@@ -237,35 +321,13 @@ func ViewCommand() *cli.Command {
 						Aliases: []string{"j"},
 						Usage:   "Output raw JSON response",
 					},
+					&cli.BoolFlag{
+						Name:  "copy",
+						Usage: "Copy the profile URL to the clipboard",
+					},
 				},
-				Action: ViewProfileAction,
+				Action: withRegistry(ViewProfileAction),
 			},
 		},
 	}
 }
-
-// parsePostIdentifier converts a bsky.app URL or AT URI to an AT URI
-// Examples:
-// - https://bsky.app/profile/alice.bsky.social/post/abc123
-// - at://did:plc:xyz/app.bsky.feed.post/abc123
-func parsePostIdentifier(identifier string) (string, error) {
-	if strings.HasPrefix(identifier, "at://") {
-		return identifier, nil
-	}
-
-	if strings.HasPrefix(identifier, "https://bsky.app/profile/") ||
-		strings.HasPrefix(identifier, "http://bsky.app/profile/") {
-		re := regexp.MustCompile(`^https?://bsky\.app/profile/([^/]+)/post/([^/]+)`)
-		matches := re.FindStringSubmatch(identifier)
-		if len(matches) != 3 {
-			return "", fmt.Errorf("invalid bsky.app URL format")
-		}
-
-		handle := matches[1]
-		rkey := matches[2]
-
-		return fmt.Sprintf("at://%s/app.bsky.feed.post/%s", handle, rkey), nil
-	}
-
-	return "", fmt.Errorf("identifier must be an AT URI (at://...) or bsky.app URL")
-}
@@ -0,0 +1,225 @@
+package analytics
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultSimilarity is the trigram Jaccard similarity at which two posts count as near-duplicates
+const DefaultSimilarity = 0.8
+
+// DefaultMinLength skips short posts ("gm", "this!") whose repetition is not meaningful
+const DefaultMinLength = 20
+
+// Document is a post considered for duplicate detection
+type Document struct {
+	ID     string // post URI
+	Author string // author DID
+	Text   string
+	Time   time.Time
+}
+
+// DuplicateOptions tunes [FindDuplicates]; zero values select the defaults
+type DuplicateOptions struct {
+	Similarity float64 // minimum trigram Jaccard similarity in (0, 1]
+	MinLength  int     // minimum normalized text length in characters
+}
+
+// DuplicateGroup is a set of posts whose texts are identical or nearly so after normalization
+type DuplicateGroup struct {
+	Text       string     // text of the earliest post in the group
+	Documents  []Document // members ordered oldest first
+	Authors    int        // distinct authors; more than one suggests cross-posting
+	Similarity float64    // weakest similarity among the links that formed the group; 1 for exact repeats
+}
+
+// Exact reports whether every member has the same normalized text
+func (g DuplicateGroup) Exact() bool {
+	return g.Similarity == 1
+}
+
+var urlPattern = regexp.MustCompile(`(?i)\bhttps?://\S+|\bwww\.\S+`)
+
+// Normalize lowercases text, drops links, and collapses punctuation and whitespace so reposted
+// promotions with different tracking links or spacing compare equal
+func Normalize(text string) string {
+	text = urlPattern.ReplaceAllString(strings.ToLower(text), " ")
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '@' && r != '#'
+	})
+	return strings.Join(fields, " ")
+}
+
+// Trigrams returns the distinct character trigrams of normalized text, padded so short words still contribute
+func Trigrams(normalized string) []string {
+	runes := []rune(" " + normalized + " ")
+	if len(runes) < 3 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(runes))
+	grams := make([]string, 0, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		g := string(runes[i : i+3])
+		if !seen[g] {
+			seen[g] = true
+			grams = append(grams, g)
+		}
+	}
+	return grams
+}
+
+// Jaccard returns |a ∩ b| / |a ∪ b| for two sets of distinct trigrams
+func Jaccard(a, b []string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	set := make(map[string]bool, len(a))
+	for _, g := range a {
+		set[g] = true
+	}
+	shared := 0
+	for _, g := range b {
+		if set[g] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// FindDuplicates groups documents whose normalized texts are identical or whose trigram similarity reaches
+// opts.Similarity, largest groups first. Exact repeats are bucketed by normalized text; near-duplicates are
+// found with a prefix-filtered trigram index so only texts sharing a rare trigram are compared.
+func FindDuplicates(docs []Document, opts DuplicateOptions) []DuplicateGroup {
+	if opts.Similarity <= 0 || opts.Similarity > 1 {
+		opts.Similarity = DefaultSimilarity
+	}
+	if opts.MinLength <= 0 {
+		opts.MinLength = DefaultMinLength
+	}
+
+	// Bucket documents by normalized text so each distinct text is compared once
+	var texts []string
+	members := make(map[string][]int)
+	for i, doc := range docs {
+		norm := Normalize(doc.Text)
+		if len([]rune(norm)) < opts.MinLength {
+			continue
+		}
+		if _, ok := members[norm]; !ok {
+			texts = append(texts, norm)
+		}
+		members[norm] = append(members[norm], i)
+	}
+
+	grams := make([][]string, len(texts))
+	freq := make(map[string]int)
+	for i, text := range texts {
+		grams[i] = Trigrams(text)
+		for _, g := range grams[i] {
+			freq[g]++
+		}
+	}
+	// Order each set rarest first so prefixes hold the most selective trigrams
+	for _, set := range grams {
+		slices.SortFunc(set, func(a, b string) int {
+			return cmp.Or(cmp.Compare(freq[a], freq[b]), cmp.Compare(a, b))
+		})
+	}
+
+	links := newUnionFind(len(texts))
+	weakest := make([]float64, len(texts))
+	for i := range weakest {
+		weakest[i] = 1
+	}
+
+	index := make(map[string][]int)
+	for i, set := range grams {
+		// Two sets with Jaccard >= t must share a trigram within the first |A| - ceil(t|A|) + 1 of each
+		prefix := len(set) - int(math.Ceil(opts.Similarity*float64(len(set)))) + 1
+		prefix = min(max(prefix, 1), len(set))
+
+		checked := make(map[int]bool)
+		for _, g := range set[:prefix] {
+			for _, j := range index[g] {
+				if checked[j] {
+					continue
+				}
+				checked[j] = true
+				ri, rj := links.find(i), links.find(j)
+				if ri == rj {
+					continue
+				}
+				if sim := Jaccard(set, grams[j]); sim >= opts.Similarity {
+					weakest[ri] = min(weakest[ri], weakest[rj], sim)
+					links.parent[rj] = ri
+				}
+			}
+			index[g] = append(index[g], i)
+		}
+	}
+
+	clusters := make(map[int][]int)
+	for i := range texts {
+		root := links.find(i)
+		clusters[root] = append(clusters[root], i)
+	}
+
+	var groups []DuplicateGroup
+	for root, textIDs := range clusters {
+		var group DuplicateGroup
+		authors := make(map[string]bool)
+		for _, t := range textIDs {
+			for _, d := range members[texts[t]] {
+				group.Documents = append(group.Documents, docs[d])
+				authors[docs[d].Author] = true
+			}
+		}
+		if len(group.Documents) < 2 {
+			continue
+		}
+
+		slices.SortStableFunc(group.Documents, func(a, b Document) int {
+			return a.Time.Compare(b.Time)
+		})
+		group.Text = group.Documents[0].Text
+		group.Authors = len(authors)
+		group.Similarity = weakest[root]
+		groups = append(groups, group)
+	}
+
+	slices.SortFunc(groups, func(a, b DuplicateGroup) int {
+		return cmp.Or(
+			cmp.Compare(len(b.Documents), len(a.Documents)),
+			a.Documents[0].Time.Compare(b.Documents[0].Time),
+			cmp.Compare(a.Text, b.Text),
+		)
+	})
+	return groups
+}
+
+// unionFind tracks which distinct texts have been linked into the same group
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+	return i
+}
@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Hello,   World!", "hello world"},
+		{"Big SALE today https://shop.example/?ref=abc123", "big sale today"},
+		{"Visit www.example.com now!!", "visit now"},
+		{"Thanks @alice.bsky.social #golang", "thanks @alice bsky social #golang"},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	same := Trigrams("spring sale")
+	if got := Jaccard(same, same); got != 1 {
+		t.Errorf("expected identical sets to score 1, got %v", got)
+	}
+	if got := Jaccard(Trigrams("abc"), Trigrams("xyz")); got != 0 {
+		t.Errorf("expected disjoint sets to score 0, got %v", got)
+	}
+	if got := Jaccard(nil, nil); got != 1 {
+		t.Errorf("expected empty sets to score 1, got %v", got)
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	base := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	promo := "Spring sale! Everything in the shop is 30% off this week only"
+	docs := []Document{
+		{ID: "a1", Author: "did:plc:shop", Text: promo + " https://shop.example/?utm=1", Time: base.Add(2 * time.Hour)},
+		{ID: "a2", Author: "did:plc:shop", Text: promo + " https://shop.example/?utm=2", Time: base},
+		{ID: "b1", Author: "did:plc:mirror", Text: "Spring sale: everything in the shop is 30% off this week only!!", Time: base.Add(time.Hour)},
+		{ID: "c1", Author: "did:plc:shop", Text: "Spring sale! Everything in the shop is 30% off this weekend only", Time: base.Add(3 * time.Hour)},
+		{ID: "d1", Author: "did:plc:other", Text: "A completely unrelated thought about gardening in the rain", Time: base},
+		{ID: "e1", Author: "did:plc:other", Text: "gm", Time: base},
+		{ID: "e2", Author: "did:plc:other", Text: "gm", Time: base.Add(time.Hour)},
+	}
+
+	groups := FindDuplicates(docs, DuplicateOptions{})
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group, got %d: %+v", len(groups), groups)
+	}
+
+	group := groups[0]
+	if len(group.Documents) != 4 {
+		t.Fatalf("expected 4 posts in the group, got %d", len(group.Documents))
+	}
+	if group.Documents[0].ID != "a2" || group.Text != docs[1].Text {
+		t.Errorf("expected the earliest post first, got %s", group.Documents[0].ID)
+	}
+	if group.Authors != 2 {
+		t.Errorf("expected 2 authors, got %d", group.Authors)
+	}
+	if group.Exact() || group.Similarity < DefaultSimilarity {
+		t.Errorf("expected a near-duplicate similarity at or above %v, got %v", DefaultSimilarity, group.Similarity)
+	}
+
+	strict := FindDuplicates(docs, DuplicateOptions{Similarity: 1})
+	if len(strict) != 1 || len(strict[0].Documents) != 3 || !strict[0].Exact() {
+		t.Errorf("expected only the 3 exact repeats at similarity 1, got %+v", strict)
+	}
+
+	short := FindDuplicates(docs, DuplicateOptions{MinLength: 1, Similarity: 1})
+	if len(short) != 2 {
+		t.Errorf("expected short posts to group once MinLength allows them, got %d groups", len(short))
+	}
+}
+
+func TestFindDuplicates_MatchesPairwise(t *testing.T) {
+	// The prefix-filtered index must find the same groups as comparing every pair
+	var docs []Document
+	for i := range 40 {
+		docs = append(docs, Document{
+			ID:   fmt.Sprintf("p%d", i),
+			Text: fmt.Sprintf("limited offer number %d for our lovely subscribers %d", i%7, i%3),
+		})
+	}
+
+	for _, threshold := range []float64{0.5, 0.7, 0.9} {
+		groups := FindDuplicates(docs, DuplicateOptions{Similarity: threshold})
+
+		grouped := make(map[string]int)
+		for g, group := range groups {
+			for _, doc := range group.Documents {
+				grouped[doc.ID] = g
+			}
+		}
+
+		for i := range docs {
+			for j := i + 1; j < len(docs); j++ {
+				sim := Jaccard(Trigrams(Normalize(docs[i].Text)), Trigrams(Normalize(docs[j].Text)))
+				if sim < threshold {
+					continue
+				}
+				gi, iok := grouped[docs[i].ID]
+				gj, jok := grouped[docs[j].ID]
+				if !iok || !jok || gi != gj {
+					t.Errorf("threshold %v: %s and %s (similarity %.2f) not grouped together", threshold, docs[i].ID, docs[j].ID, sim)
+				}
+			}
+		}
+	}
+}
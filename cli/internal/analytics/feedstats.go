@@ -0,0 +1,313 @@
+package analytics
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+// FeedPost is an archived post as [SummarizeFeed] sees it
+type FeedPost struct {
+	Author     string
+	Time       time.Time
+	Engagement *int // likes, reposts, replies, and quotes together; nil when they weren't recorded
+}
+
+// FeedStatsOptions tunes [SummarizeFeed]; zero values select the defaults
+type FeedStatsOptions struct {
+	Period     Period // bucket size of the trend; defaults to a day
+	TopAuthors int    // authors listed; defaults to 10
+}
+
+// FeedStats summarizes the archived posts of one feed
+type FeedStats struct {
+	Posts       int             `json:"posts"`
+	Authors     int             `json:"authors"`
+	Coverage    FeedCoverage    `json:"coverage"`
+	PostsPerDay float64         `json:"postsPerDay"` // over the covered days
+	Change      *float64        `json:"change,omitempty"`
+	Trend       []FeedPeriod    `json:"trend"`
+	TopAuthors  []AuthorShare   `json:"topAuthors"`
+	Engagement  EngagementStats `json:"engagement"`
+}
+
+// FeedCoverage is the span of days a feed's posts cover
+type FeedCoverage struct {
+	First      time.Time `json:"first"`
+	Last       time.Time `json:"last"`
+	Days       int       `json:"days"`       // calendar days from the first post through the last
+	ActiveDays int       `json:"activeDays"` // days with at least one post
+	LongestGap int       `json:"longestGap"` // most days in a row without a post
+}
+
+// FeedPeriod is one bucket of the posting trend
+type FeedPeriod struct {
+	Start       time.Time `json:"start"`
+	Posts       int       `json:"posts"`
+	PostsPerDay float64   `json:"postsPerDay"` // over the period's days within the coverage
+}
+
+// AuthorShare is one author's part of a feed
+type AuthorShare struct {
+	Author string  `json:"author"`
+	Posts  int     `json:"posts"`
+	Share  float64 `json:"share"` // percent of the feed's posts
+}
+
+// EngagementStats describes how engagement is spread over the posts that recorded it
+type EngagementStats struct {
+	Recorded int                `json:"recorded"` // posts with counts; the rest were stored without them
+	Mean     float64            `json:"mean"`
+	Median   float64            `json:"median"`
+	P90      int                `json:"p90"`
+	Max      int                `json:"max"`
+	Buckets  []EngagementBucket `json:"buckets"`
+}
+
+// EngagementBucket counts the posts whose engagement falls in [Min, Max]; Max is -1 for the open last bucket
+type EngagementBucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max"`
+	Posts int `json:"posts"`
+}
+
+// engagementBounds are the lower bounds of the engagement buckets
+var engagementBounds = []int{0, 1, 5, 25, 100, 500}
+
+// SummarizeFeed computes a feed's coverage, posting trend, top authors, and engagement distribution. Days are
+// calendar days in the location of the post times. Posts without a time count toward the totals only.
+//
+// Change is the percent difference in posts per day between the second half of the covered days and the first;
+// it is left out under two days of coverage or when the first half has no posts.
+func SummarizeFeed(posts []FeedPost, opts FeedStatsOptions) FeedStats {
+	if opts.Period == "" {
+		opts.Period = PeriodDay
+	}
+	if opts.TopAuthors <= 0 {
+		opts.TopAuthors = 10
+	}
+
+	stats := FeedStats{Posts: len(posts), Trend: []FeedPeriod{}, TopAuthors: []AuthorShare{}}
+
+	authors := make(map[string]int)
+	daily := make(map[time.Time]int)
+	var engagement []int
+	for _, post := range posts {
+		authors[post.Author]++
+		if post.Engagement != nil {
+			engagement = append(engagement, *post.Engagement)
+		}
+		if post.Time.IsZero() {
+			continue
+		}
+		daily[PeriodDay.Start(post.Time)]++
+		if stats.Coverage.First.IsZero() || post.Time.Before(stats.Coverage.First) {
+			stats.Coverage.First = post.Time
+		}
+		if post.Time.After(stats.Coverage.Last) {
+			stats.Coverage.Last = post.Time
+		}
+	}
+
+	stats.Authors = len(authors)
+	for author, n := range authors {
+		stats.TopAuthors = append(stats.TopAuthors, AuthorShare{Author: author, Posts: n, Share: 100 * float64(n) / float64(len(posts))})
+	}
+	slices.SortFunc(stats.TopAuthors, func(a, b AuthorShare) int {
+		return cmp.Or(cmp.Compare(b.Posts, a.Posts), cmp.Compare(a.Author, b.Author))
+	})
+	if len(stats.TopAuthors) > opts.TopAuthors {
+		stats.TopAuthors = stats.TopAuthors[:opts.TopAuthors]
+	}
+
+	stats.Engagement = summarizeEngagement(engagement)
+
+	if len(daily) == 0 {
+		return stats
+	}
+
+	// Walk every covered day, so days without posts count toward gaps and rates
+	first, last := PeriodDay.Start(stats.Coverage.First), PeriodDay.Start(stats.Coverage.Last)
+	var counts []int
+	gap := 0
+	periods := make(map[time.Time]*FeedPeriod)
+	periodDays := make(map[time.Time]int)
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		n := daily[day]
+		counts = append(counts, n)
+		if n > 0 {
+			stats.Coverage.ActiveDays++
+			gap = 0
+		} else {
+			gap++
+			stats.Coverage.LongestGap = max(stats.Coverage.LongestGap, gap)
+		}
+
+		start := opts.Period.Start(day)
+		period, ok := periods[start]
+		if !ok {
+			period = &FeedPeriod{Start: start}
+			periods[start] = period
+		}
+		period.Posts += n
+		periodDays[start]++
+	}
+	stats.Coverage.Days = len(counts)
+	stats.PostsPerDay = float64(stats.Posts-countUntimed(posts)) / float64(len(counts))
+
+	for start, period := range periods {
+		period.PostsPerDay = float64(period.Posts) / float64(periodDays[start])
+		stats.Trend = append(stats.Trend, *period)
+	}
+	slices.SortFunc(stats.Trend, func(a, b FeedPeriod) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	if half := len(counts) / 2; half > 0 {
+		before, after := sum(counts[:half]), sum(counts[len(counts)-half:])
+		if before > 0 {
+			change := 100 * float64(after-before) / float64(before)
+			stats.Change = &change
+		}
+	}
+	return stats
+}
+
+// summarizeEngagement describes the spread of engagement totals
+func summarizeEngagement(engagement []int) EngagementStats {
+	stats := EngagementStats{Recorded: len(engagement), Buckets: make([]EngagementBucket, len(engagementBounds))}
+	for i, bound := range engagementBounds {
+		stats.Buckets[i] = EngagementBucket{Min: bound, Max: -1}
+		if i+1 < len(engagementBounds) {
+			stats.Buckets[i].Max = engagementBounds[i+1] - 1
+		}
+	}
+	if len(engagement) == 0 {
+		return stats
+	}
+
+	sorted := slices.Sorted(slices.Values(engagement))
+	for _, n := range sorted {
+		i, found := slices.BinarySearch(engagementBounds, n)
+		if !found {
+			i--
+		}
+		stats.Buckets[i].Posts++
+	}
+
+	stats.Mean = float64(sum(sorted)) / float64(len(sorted))
+	if mid := len(sorted) / 2; len(sorted)%2 == 0 {
+		stats.Median = float64(sorted[mid-1]+sorted[mid]) / 2
+	} else {
+		stats.Median = float64(sorted[mid])
+	}
+	stats.P90 = sorted[int(math.Ceil(0.9*float64(len(sorted))))-1]
+	stats.Max = sorted[len(sorted)-1]
+	return stats
+}
+
+// countUntimed counts the posts without a time
+func countUntimed(posts []FeedPost) int {
+	n := 0
+	for _, post := range posts {
+		if post.Time.IsZero() {
+			n++
+		}
+	}
+	return n
+}
+
+// sum adds up values
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// AuthorOrder is what [RankAuthors] ranks by
+type AuthorOrder string
+
+const (
+	AuthorsByPosts      AuthorOrder = "posts"
+	AuthorsByEngagement AuthorOrder = "engagement"
+)
+
+// AuthorRank is one author's posts and engagement within a feed
+type AuthorRank struct {
+	Author         string   `json:"author"`
+	Posts          int      `json:"posts"`
+	Share          float64  `json:"share"`    // percent of the feed's posts
+	Recorded       int      `json:"recorded"` // posts with engagement counts
+	Engagement     int      `json:"engagement"`
+	MeanEngagement *float64 `json:"meanEngagement,omitempty"` // per recorded post; nil when none were recorded
+}
+
+// AuthorRankOptions tunes [RankAuthors]; zero values select the defaults
+type AuthorRankOptions struct {
+	By       AuthorOrder // defaults to posts
+	MinPosts int         // authors with fewer posts are left out
+	Top      int         // authors returned; 0 returns all
+}
+
+// RankAuthors ranks the authors of a feed's posts by post count or by mean engagement per post. Ranking by
+// engagement puts authors without recorded engagement last; ties go to the author with more posts.
+func RankAuthors(posts []FeedPost, opts AuthorRankOptions) []AuthorRank {
+	if opts.By == "" {
+		opts.By = AuthorsByPosts
+	}
+
+	byAuthor := make(map[string]*AuthorRank)
+	for _, post := range posts {
+		rank, ok := byAuthor[post.Author]
+		if !ok {
+			rank = &AuthorRank{Author: post.Author}
+			byAuthor[post.Author] = rank
+		}
+		rank.Posts++
+		if post.Engagement != nil {
+			rank.Recorded++
+			rank.Engagement += *post.Engagement
+		}
+	}
+
+	ranks := []AuthorRank{}
+	for _, rank := range byAuthor {
+		if rank.Posts < opts.MinPosts {
+			continue
+		}
+		rank.Share = 100 * float64(rank.Posts) / float64(len(posts))
+		if rank.Recorded > 0 {
+			mean := float64(rank.Engagement) / float64(rank.Recorded)
+			rank.MeanEngagement = &mean
+		}
+		ranks = append(ranks, *rank)
+	}
+
+	slices.SortFunc(ranks, func(a, b AuthorRank) int {
+		byPosts := cmp.Compare(b.Posts, a.Posts)
+		if opts.By == AuthorsByEngagement {
+			return cmp.Or(compareMean(a.MeanEngagement, b.MeanEngagement), byPosts, cmp.Compare(a.Author, b.Author))
+		}
+		return cmp.Or(byPosts, compareMean(a.MeanEngagement, b.MeanEngagement), cmp.Compare(a.Author, b.Author))
+	})
+	if opts.Top > 0 && len(ranks) > opts.Top {
+		ranks = ranks[:opts.Top]
+	}
+	return ranks
+}
+
+// compareMean orders higher means first and missing means last
+func compareMean(a, b *float64) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return cmp.Compare(*b, *a)
+}
@@ -0,0 +1,123 @@
+package analytics
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSummarizeFeed(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2025, 3, d, hour, 0, 0, 0, time.UTC) }
+	engagement := func(n int) *int { return &n }
+	posts := []FeedPost{
+		{Author: "alice", Time: day(10, 9), Engagement: engagement(0)},
+		{Author: "alice", Time: day(10, 18), Engagement: engagement(3)},
+		{Author: "bob", Time: day(12, 12), Engagement: engagement(30)},
+		{Author: "alice", Time: day(16, 8), Engagement: engagement(1)},
+		{Author: "carol", Time: day(16, 9), Engagement: engagement(600)},
+		{Author: "carol", Time: day(16, 10)},
+		{Author: "carol", Time: day(16, 23), Engagement: engagement(120)},
+		{Author: "dave", Engagement: engagement(7)},
+	}
+
+	stats := SummarizeFeed(posts, FeedStatsOptions{TopAuthors: 2})
+
+	if stats.Posts != 8 || stats.Authors != 4 {
+		t.Errorf("expected 8 posts by 4 authors, got %d by %d", stats.Posts, stats.Authors)
+	}
+	coverage := stats.Coverage
+	if !coverage.First.Equal(day(10, 9)) || !coverage.Last.Equal(day(16, 23)) || coverage.Days != 7 || coverage.ActiveDays != 3 || coverage.LongestGap != 3 {
+		t.Errorf("unexpected coverage %+v", coverage)
+	}
+	if stats.PostsPerDay != 1 {
+		t.Errorf("expected 7 dated posts over 7 days, got %v per day", stats.PostsPerDay)
+	}
+	if stats.Change == nil || math.Abs(*stats.Change-100.0/3) > 0.01 {
+		t.Errorf("expected the last 3 days to have a third more posts than the first 3, got %v", stats.Change)
+	}
+	if len(stats.Trend) != 7 || stats.Trend[0].Posts != 2 || stats.Trend[1].Posts != 0 || stats.Trend[6].Posts != 4 {
+		t.Errorf("expected a daily trend with empty days filled in, got %+v", stats.Trend)
+	}
+
+	if len(stats.TopAuthors) != 2 || stats.TopAuthors[0].Author != "alice" || stats.TopAuthors[1].Author != "carol" || stats.TopAuthors[0].Share != 37.5 {
+		t.Errorf("expected alice then carol at 37.5%% each, got %+v", stats.TopAuthors)
+	}
+
+	e := stats.Engagement
+	if e.Recorded != 7 || e.Median != 7 || e.P90 != 600 || e.Max != 600 || math.Abs(e.Mean-761.0/7) > 0.01 {
+		t.Errorf("unexpected engagement %+v", e)
+	}
+	wantBuckets := []int{1, 2, 1, 1, 1, 1}
+	for i, bucket := range e.Buckets {
+		if bucket.Posts != wantBuckets[i] {
+			t.Errorf("bucket %d-%d has %d posts, want %d", bucket.Min, bucket.Max, bucket.Posts, wantBuckets[i])
+		}
+	}
+	if last := e.Buckets[len(e.Buckets)-1]; last.Min != 500 || last.Max != -1 {
+		t.Errorf("expected an open last bucket from 500, got %+v", last)
+	}
+
+	weekly := SummarizeFeed(posts, FeedStatsOptions{Period: PeriodWeek})
+	if len(weekly.Trend) != 1 || weekly.Trend[0].Posts != 7 || weekly.Trend[0].PostsPerDay != 1 {
+		t.Errorf("expected one weekly bucket of 7 posts, got %+v", weekly.Trend)
+	}
+
+	empty := SummarizeFeed(nil, FeedStatsOptions{})
+	if empty.Posts != 0 || empty.Change != nil || len(empty.Trend) != 0 || empty.Engagement.Recorded != 0 {
+		t.Errorf("unexpected stats for no posts %+v", empty)
+	}
+}
+
+func TestRankAuthors(t *testing.T) {
+	engagement := func(n int) *int { return &n }
+	posts := []FeedPost{
+		{Author: "alice", Engagement: engagement(0)},
+		{Author: "alice", Engagement: engagement(3)},
+		{Author: "alice", Engagement: engagement(1)},
+		{Author: "bob", Engagement: engagement(30)},
+		{Author: "carol", Engagement: engagement(600)},
+		{Author: "carol"},
+		{Author: "carol", Engagement: engagement(120)},
+		{Author: "dave", Engagement: engagement(7)},
+		{Author: "erin"},
+	}
+
+	authors := func(ranks []AuthorRank) []string {
+		names := make([]string, len(ranks))
+		for i, rank := range ranks {
+			names[i] = rank.Author
+		}
+		return names
+	}
+
+	tests := []struct {
+		name string
+		opts AuthorRankOptions
+		want []string
+	}{
+		{"posts", AuthorRankOptions{}, []string{"carol", "alice", "bob", "dave", "erin"}},
+		{"engagement", AuthorRankOptions{By: AuthorsByEngagement}, []string{"carol", "bob", "dave", "alice", "erin"}},
+		{"min posts", AuthorRankOptions{By: AuthorsByEngagement, MinPosts: 2}, []string{"carol", "alice"}},
+		{"top", AuthorRankOptions{Top: 2}, []string{"carol", "alice"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authors(RankAuthors(posts, tt.opts)); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	ranks := RankAuthors(posts, AuthorRankOptions{})
+	carol := ranks[0]
+	if carol.Posts != 3 || carol.Recorded != 2 || carol.Engagement != 720 || *carol.MeanEngagement != 360 || math.Abs(carol.Share-100.0/3) > 0.01 {
+		t.Errorf("unexpected rank for carol: %+v", carol)
+	}
+	if erin := ranks[4]; erin.Recorded != 0 || erin.MeanEngagement != nil {
+		t.Errorf("expected no engagement for erin, got %+v", erin)
+	}
+	if got := RankAuthors(nil, AuthorRankOptions{}); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty ranking, got %v", got)
+	}
+}
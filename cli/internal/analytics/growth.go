@@ -0,0 +1,131 @@
+package analytics
+
+import (
+	"math"
+	"time"
+)
+
+// GrowthOptions tunes [FollowerGrowth]
+type GrowthOptions struct {
+	Window    int     // days averaged by the rolling mean; defaults to 7
+	Threshold float64 // |z-score| at which a day is flagged; defaults to 2
+}
+
+// GrowthDay is one day of follower growth
+type GrowthDay struct {
+	Date        time.Time `json:"date"`
+	Delta       int       `json:"delta"`       // followers gained that day
+	Total       int       `json:"total"`       // followers at the end of the day
+	RollingMean float64   `json:"rollingMean"` // mean delta over the window ending that day
+	ZScore      float64   `json:"zScore"`      // distance of delta from the period's mean, in standard deviations
+	Anomaly     string    `json:"anomaly,omitempty"`
+}
+
+// Anomaly values flag days whose delta is unusually high or low for the period
+const (
+	AnomalySpike = "spike"
+	AnomalyDrop  = "drop"
+)
+
+// GrowthReport summarizes follower growth over a period, day by day
+type GrowthReport struct {
+	Start         time.Time   `json:"start"`
+	End           time.Time   `json:"end"`
+	StartTotal    int         `json:"startTotal"`
+	EndTotal      int         `json:"endTotal"`
+	Gained        int         `json:"gained"`
+	PercentGrowth float64     `json:"percentGrowth"` // Gained relative to StartTotal; 0 without followers at the start
+	MeanDelta     float64     `json:"meanDelta"`
+	StdDev        float64     `json:"stdDev"`
+	Days          []GrowthDay `json:"days"`
+}
+
+// Anomalies returns the days flagged as spikes or drops
+func (r GrowthReport) Anomalies() []GrowthDay {
+	var days []GrowthDay
+	for _, day := range r.Days {
+		if day.Anomaly != "" {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// FollowerGrowth builds daily growth from start to end out of the times current followers followed, with total the
+// current follower count. Days are calendar days in start's location. Only current followers are known, so
+// deltas count gains that lasted and unfollows don't appear.
+func FollowerGrowth(followedAt []time.Time, total int, start, end time.Time, opts GrowthOptions) GrowthReport {
+	if opts.Window <= 0 {
+		opts.Window = 7
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = 2
+	}
+
+	first := PeriodDay.Start(start)
+	last := PeriodDay.Start(end.In(start.Location()))
+	report := GrowthReport{Start: first, End: last, EndTotal: total}
+	if last.Before(first) {
+		return report
+	}
+
+	var days []GrowthDay
+	index := make(map[time.Time]int)
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		index[day] = len(days)
+		days = append(days, GrowthDay{Date: day})
+	}
+
+	for _, t := range followedAt {
+		if t.IsZero() || t.Before(first) {
+			continue
+		}
+		if i, ok := index[PeriodDay.Start(t.In(start.Location()))]; ok {
+			days[i].Delta++
+			report.Gained++
+		}
+	}
+
+	report.StartTotal = total - report.Gained
+	if report.StartTotal > 0 {
+		report.PercentGrowth = float64(report.Gained) / float64(report.StartTotal) * 100
+	}
+
+	var sum float64
+	for _, day := range days {
+		sum += float64(day.Delta)
+	}
+	report.MeanDelta = sum / float64(len(days))
+
+	var squares float64
+	for _, day := range days {
+		diff := float64(day.Delta) - report.MeanDelta
+		squares += diff * diff
+	}
+	report.StdDev = math.Sqrt(squares / float64(len(days)))
+
+	running, windowSum := report.StartTotal, 0
+	for i := range days {
+		running += days[i].Delta
+		days[i].Total = running
+
+		windowSum += days[i].Delta
+		if i >= opts.Window {
+			windowSum -= days[i-opts.Window].Delta
+		}
+		days[i].RollingMean = float64(windowSum) / float64(min(i+1, opts.Window))
+
+		if report.StdDev > 0 {
+			days[i].ZScore = (float64(days[i].Delta) - report.MeanDelta) / report.StdDev
+		}
+		switch {
+		case days[i].ZScore >= opts.Threshold:
+			days[i].Anomaly = AnomalySpike
+		case days[i].ZScore <= -opts.Threshold:
+			days[i].Anomaly = AnomalyDrop
+		}
+	}
+
+	report.Days = days
+	return report
+}
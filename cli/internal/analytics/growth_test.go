@@ -0,0 +1,58 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFollowerGrowth(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 9).Add(12 * time.Hour)
+
+	// One follow a day, except ten on day 5, plus a follow before the period
+	followedAt := []time.Time{start.AddDate(0, 0, -3)}
+	for day := 0; day < 10; day++ {
+		count := 1
+		if day == 5 {
+			count = 10
+		}
+		for range count {
+			followedAt = append(followedAt, start.AddDate(0, 0, day).Add(time.Hour))
+		}
+	}
+
+	report := FollowerGrowth(followedAt, 119, start, end, GrowthOptions{Window: 3})
+	if len(report.Days) != 10 {
+		t.Fatalf("expected 10 days, got %d", len(report.Days))
+	}
+	if report.Gained != 19 || report.StartTotal != 100 || report.EndTotal != 119 {
+		t.Errorf("unexpected totals: gained %d, %d → %d", report.Gained, report.StartTotal, report.EndTotal)
+	}
+	if math.Abs(report.PercentGrowth-19) > 1e-9 {
+		t.Errorf("expected 19%% growth, got %v", report.PercentGrowth)
+	}
+	if report.Days[9].Total != 119 || report.Days[0].Total != 101 {
+		t.Errorf("unexpected running totals %d and %d", report.Days[0].Total, report.Days[9].Total)
+	}
+	if got := report.Days[6].RollingMean; math.Abs(got-4) > 1e-9 {
+		t.Errorf("expected 3-day rolling mean of 4 after the spike, got %v", got)
+	}
+
+	anomalies := report.Anomalies()
+	if len(anomalies) != 1 || anomalies[0].Anomaly != AnomalySpike || !anomalies[0].Date.Equal(start.AddDate(0, 0, 5)) {
+		t.Errorf("expected a single spike on day 5, got %+v", anomalies)
+	}
+}
+
+func TestFollowerGrowth_Flat(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	report := FollowerGrowth(nil, 0, start, start.AddDate(0, 0, 2), GrowthOptions{})
+	if len(report.Days) != 3 || report.StdDev != 0 || report.PercentGrowth != 0 || len(report.Anomalies()) != 0 {
+		t.Errorf("expected a flat report without anomalies, got %+v", report)
+	}
+
+	if empty := FollowerGrowth(nil, 5, start, start.AddDate(0, 0, -1), GrowthOptions{}); len(empty.Days) != 0 {
+		t.Errorf("expected no days when end precedes start, got %d", len(empty.Days))
+	}
+}
@@ -0,0 +1,156 @@
+package analytics
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// DefaultAffinity is how strongly two keywords' audiences must overlap (Jaccard) to share a cluster
+const DefaultAffinity = 0.25
+
+// Bio is an account description considered for interest clustering
+type Bio struct {
+	Account string // handle or DID shown as an example
+	Text    string
+	Reach   int // follower count, used to prefer well-known examples
+}
+
+// InterestOptions tunes [ClusterInterests]; zero values select the defaults
+type InterestOptions struct {
+	MinAccounts int     // drop keywords used by fewer accounts (default 2)
+	Affinity    float64 // minimum audience overlap for a keyword to join a cluster
+	Examples    int     // example accounts per cluster (default 3)
+	Limit       int     // maximum clusters returned; 0 returns all
+}
+
+// InterestCluster is a group of bio keywords that tend to appear together
+type InterestCluster struct {
+	Keywords []string // most common first
+	Accounts int      // accounts whose bio mentions any keyword
+	Examples []string // accounts matching the most keywords, then with the widest reach
+}
+
+// ClusterInterests extracts keywords from bios and groups keywords whose audiences overlap, largest first.
+// Each account counts once per keyword; hashtags fold into the plain word and plurals into the singular
+// when both appear.
+func ClusterInterests(bios []Bio, opts InterestOptions) []InterestCluster {
+	if opts.MinAccounts <= 0 {
+		opts.MinAccounts = 2
+	}
+	if opts.Affinity <= 0 || opts.Affinity > 1 {
+		opts.Affinity = DefaultAffinity
+	}
+	if opts.Examples <= 0 {
+		opts.Examples = 3
+	}
+
+	postings := make(map[string]map[int]bool)
+	for i, bio := range bios {
+		for _, token := range Tokenize(bio.Text) {
+			word := strings.TrimPrefix(token, "#")
+			if len([]rune(word)) < 3 || stopwords[word] || bioStopwords[word] {
+				continue
+			}
+			if postings[word] == nil {
+				postings[word] = make(map[int]bool)
+			}
+			postings[word][i] = true
+		}
+	}
+
+	for word, accounts := range postings {
+		singular := strings.TrimSuffix(word, "s")
+		if singular == word || postings[singular] == nil {
+			continue
+		}
+		for i := range accounts {
+			postings[singular][i] = true
+		}
+		delete(postings, word)
+	}
+
+	var keywords []string
+	for word, accounts := range postings {
+		if len(accounts) >= opts.MinAccounts {
+			keywords = append(keywords, word)
+		}
+	}
+	slices.SortFunc(keywords, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(postings[b]), len(postings[a])), cmp.Compare(a, b))
+	})
+
+	assigned := make(map[string]bool)
+	var clusters []InterestCluster
+	for _, seed := range keywords {
+		if assigned[seed] {
+			continue
+		}
+		assigned[seed] = true
+		members := []string{seed}
+		for _, word := range keywords {
+			if !assigned[word] && overlap(postings[seed], postings[word]) >= opts.Affinity {
+				assigned[word] = true
+				members = append(members, word)
+			}
+		}
+		clusters = append(clusters, buildCluster(bios, postings, members, opts.Examples))
+	}
+
+	slices.SortStableFunc(clusters, func(a, b InterestCluster) int {
+		return cmp.Compare(b.Accounts, a.Accounts)
+	})
+	if opts.Limit > 0 && len(clusters) > opts.Limit {
+		clusters = clusters[:opts.Limit]
+	}
+	return clusters
+}
+
+// overlap is the Jaccard similarity of two account sets
+func overlap(a, b map[int]bool) float64 {
+	shared := 0
+	for i := range a {
+		if b[i] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// buildCluster counts the accounts behind keywords and picks the most representative examples
+func buildCluster(bios []Bio, postings map[string]map[int]bool, keywords []string, examples int) InterestCluster {
+	matches := make(map[int]int)
+	for _, word := range keywords {
+		for i := range postings[word] {
+			matches[i]++
+		}
+	}
+
+	accounts := make([]int, 0, len(matches))
+	for i := range matches {
+		accounts = append(accounts, i)
+	}
+	slices.SortFunc(accounts, func(a, b int) int {
+		return cmp.Or(cmp.Compare(matches[b], matches[a]), cmp.Compare(bios[b].Reach, bios[a].Reach), cmp.Compare(a, b))
+	})
+
+	cluster := InterestCluster{Keywords: keywords, Accounts: len(accounts)}
+	for _, i := range accounts[:min(examples, len(accounts))] {
+		cluster.Examples = append(cluster.Examples, bios[i].Account)
+	}
+	return cluster
+}
+
+// bioStopwords are words common in profile descriptions that say nothing about interests
+var bioStopwords = func() map[string]bool {
+	words := strings.Fields(`
+		he him his she her hers they them theirs pronouns account bluesky bsky profile posts posting
+		post views opinions mine own here new follow followers welcome always sometimes stuff things
+		thing lover enjoyer person human based living life love guy girl dad mom
+	`)
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}()
@@ -0,0 +1,57 @@
+package analytics
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClusterInterests(t *testing.T) {
+	bios := []Bio{
+		{Account: "painter.bsky.social", Text: "Painter and illustrator. #art", Reach: 900},
+		{Account: "sketch.bsky.social", Text: "Illustrator, comics, and art", Reach: 50},
+		{Account: "gallery.bsky.social", Text: "Contemporary art gallery", Reach: 5000},
+		{Account: "dev.bsky.social", Text: "Rust and Go developer. Open source maintainer", Reach: 300},
+		{Account: "gopher.bsky.social", Text: "Go developer, open source fan", Reach: 100},
+		{Account: "ops.bsky.social", Text: "Developers' developer. she/her", Reach: 10},
+		{Account: "quiet.bsky.social", Text: "", Reach: 0},
+	}
+
+	clusters := ClusterInterests(bios, InterestOptions{})
+	if len(clusters) < 2 {
+		t.Fatalf("expected at least 2 clusters, got %+v", clusters)
+	}
+
+	bySeed := make(map[string]InterestCluster)
+	for _, cluster := range clusters {
+		bySeed[cluster.Keywords[0]] = cluster
+	}
+
+	dev := bySeed["developer"]
+	if dev.Accounts != 3 {
+		t.Errorf("expected a developer cluster with 3 accounts, got %+v", dev)
+	}
+	if !slices.Contains(dev.Keywords, "open") || !slices.Contains(dev.Keywords, "source") {
+		t.Errorf("expected open source to cluster with developer, got %v", dev.Keywords)
+	}
+	if !slices.Equal(dev.Examples, []string{"dev.bsky.social", "gopher.bsky.social", "ops.bsky.social"}) {
+		t.Errorf("unexpected examples %v", dev.Examples)
+	}
+
+	art := bySeed["art"]
+	if art.Accounts != 3 || !slices.Contains(art.Keywords, "illustrator") {
+		t.Errorf("expected an art cluster including illustrator, got %+v", art)
+	}
+
+	for _, cluster := range clusters {
+		for _, word := range cluster.Keywords {
+			if word == "she" || word == "her" || word == "and" || word == "developers" {
+				t.Errorf("unexpected keyword %q in %v", word, cluster.Keywords)
+			}
+		}
+	}
+
+	limited := ClusterInterests(bios, InterestOptions{Limit: 1, Examples: 1})
+	if len(limited) != 1 || len(limited[0].Examples) != 1 {
+		t.Errorf("expected one cluster with one example, got %+v", limited)
+	}
+}
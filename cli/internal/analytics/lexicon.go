@@ -0,0 +1,46 @@
+package analytics
+
+// negators flip the score of the next sentiment word
+var negators = map[string]bool{
+	"not": true, "no": true, "never": true, "nothing": true, "hardly": true, "without": true,
+	"don't": true, "dont": true, "doesn't": true, "didn't": true, "isn't": true, "wasn't": true,
+	"aren't": true, "weren't": true, "can't": true, "cant": true, "won't": true, "wouldn't": true,
+	"shouldn't": true, "couldn't": true, "haven't": true, "hasn't": true, "ain't": true,
+}
+
+// wordScores rates common English sentiment words from -5 (very negative) to 5 (very positive),
+// in the style of the AFINN lexicon
+var wordScores = map[string]int{
+	// positive
+	"amazing": 4, "awesome": 4, "beautiful": 3, "best": 3, "better": 2, "blessed": 3, "brilliant": 4,
+	"calm": 2, "celebrate": 3, "cheerful": 2, "congrats": 3, "congratulations": 3, "cool": 1, "cute": 2,
+	"delight": 3, "delighted": 3, "delightful": 3, "enjoy": 2, "enjoyed": 2, "excellent": 3, "excited": 3,
+	"exciting": 3, "fabulous": 4, "fantastic": 4, "favorite": 2, "favourite": 2, "fine": 1, "fun": 2,
+	"glad": 3, "good": 3, "gorgeous": 3, "grateful": 3, "great": 3, "happy": 3, "helpful": 2, "hope": 2,
+	"hopeful": 2, "impressive": 3, "incredible": 4, "inspiring": 3, "joy": 3, "kind": 2, "laugh": 1,
+	"like": 2, "liked": 2, "lol": 2, "love": 3, "loved": 3, "lovely": 3, "loves": 3, "lucky": 3,
+	"nice": 3, "perfect": 3, "pleased": 3, "proud": 2, "recommend": 2, "relief": 1, "respect": 2,
+	"success": 2, "successful": 3, "super": 3, "support": 2, "sweet": 2, "thank": 2, "thanks": 2,
+	"thankful": 2, "thrilled": 5, "win": 4, "winner": 4, "wins": 4, "won": 3, "wonderful": 4, "wow": 4,
+	"yay": 2, "yes": 1,
+	// negative
+	"afraid": -2, "angry": -3, "annoyed": -2, "annoying": -2, "anxious": -2, "awful": -3, "bad": -3,
+	"boring": -3, "broke": -1, "broken": -1, "cry": -1, "crying": -2, "damn": -2, "dead": -3,
+	"depressed": -2, "depressing": -2, "disappointed": -2, "disappointing": -2, "disaster": -2,
+	"disgusting": -3, "dislike": -2, "dumb": -3, "fail": -2, "failed": -2, "failure": -2, "fear": -2,
+	"frustrated": -2, "frustrating": -2, "furious": -3, "hate": -3, "hated": -3, "hates": -3, "horrible": -3,
+	"hurt": -2, "idiot": -3, "ill": -2, "lonely": -2, "lose": -3, "loss": -3, "lost": -3, "mad": -3,
+	"mess": -2, "miserable": -3, "miss": -2, "nasty": -3, "pain": -2, "pathetic": -2, "poor": -2,
+	"problem": -2, "sad": -2, "scared": -2, "sick": -2, "sorry": -1, "stupid": -2, "suck": -3,
+	"sucks": -3, "terrible": -3, "tired": -2, "ugly": -3, "unfair": -2, "unhappy": -2, "upset": -2,
+	"useless": -2, "worried": -3, "worse": -3, "worst": -3, "wrong": -2,
+}
+
+// emojiScores rates common emoji on the same scale as wordScores
+var emojiScores = map[string]int{
+	"😀": 2, "😃": 2, "😄": 2, "😁": 2, "😆": 2, "😂": 2, "🤣": 2, "😊": 3, "🙂": 1, "😍": 3, "🥰": 3,
+	"😘": 3, "🤩": 3, "🥳": 3, "😎": 2, "👍": 2, "👏": 2, "🙌": 2, "🎉": 3, "✨": 1, "💯": 2, "🔥": 1,
+	"❤": 3, "💕": 3, "💖": 3, "💙": 3, "💜": 3, "💚": 3, "🧡": 3, "💛": 3, "🙏": 1, "⭐": 1,
+	"😢": -2, "😭": -2, "😞": -2, "😔": -2, "😟": -2, "😕": -1, "🙁": -1, "☹": -2, "😠": -3, "😡": -3,
+	"🤬": -3, "😤": -2, "😩": -2, "😫": -2, "😱": -2, "👎": -2, "💔": -3, "🤮": -3, "🙄": -1,
+}
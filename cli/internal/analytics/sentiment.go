@@ -0,0 +1,257 @@
+package analytics
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// negationWindow is how many following words a negator such as "not" flips
+const negationWindow = 3
+
+// Sentiment is the lexicon score of a single text
+type Sentiment struct {
+	Score       int      // sum of word and emoji scores; positive is favorable
+	Comparative float64  // Score divided by the number of words, comparable across post lengths
+	Positive    []string // words and emoji that raised the score
+	Negative    []string // words and emoji that lowered the score
+}
+
+// Label classifies the sentiment as "positive", "negative", or "neutral"
+func (s Sentiment) Label() string {
+	switch {
+	case s.Score > 0:
+		return "positive"
+	case s.Score < 0:
+		return "negative"
+	default:
+		return "neutral"
+	}
+}
+
+// ScoreSentiment rates text against a built-in English word and emoji lexicon without any network access.
+// A negator ("not", "never", "don't", ...) flips the next scored word within a few words.
+func ScoreSentiment(text string) Sentiment {
+	var s Sentiment
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	negated := 0
+	for _, word := range words {
+		word = strings.Trim(word, "'")
+		if negators[word] {
+			negated = negationWindow
+			continue
+		}
+		score, ok := wordScores[word]
+		if negated > 0 {
+			negated--
+			if ok {
+				score, negated = -score, 0
+			}
+		}
+		if ok {
+			s.add(word, score)
+		}
+	}
+
+	for _, emoji := range Emojis(text) {
+		if score, ok := emojiScores[emoji]; ok {
+			s.add(emoji, score)
+		}
+	}
+
+	if len(words) > 0 {
+		s.Comparative = float64(s.Score) / float64(len(words))
+	}
+	return s
+}
+
+func (s *Sentiment) add(token string, score int) {
+	s.Score += score
+	if score > 0 {
+		s.Positive = append(s.Positive, token)
+	} else if score < 0 {
+		s.Negative = append(s.Negative, token)
+	}
+}
+
+// Emojis returns the emoji in text in order of appearance. Skin tone modifiers, variation selectors,
+// and zero-width joiners are dropped so "👍🏽" counts as "👍"; regional indicator pairs stay together as flags.
+func Emojis(text string) []string {
+	var emojis []string
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case isRegionalIndicator(r):
+			if i+1 < len(runes) && isRegionalIndicator(runes[i+1]) {
+				emojis = append(emojis, string(runes[i:i+2]))
+				i++
+			}
+		case isEmoji(r):
+			emojis = append(emojis, string(r))
+		}
+	}
+	return emojis
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F3FB && r <= 0x1F3FF: // skin tone modifiers
+		return false
+	case r >= 0x1F300 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r == 0x2B50 || r == 0x2B55 || r == 0x203C || r == 0x2049:
+		return true
+	}
+	return false
+}
+
+// Count is a token and how often it occurred
+type Count struct {
+	Token string
+	Count int
+}
+
+// TopEmojis counts emoji across texts and returns the n most used, most frequent first; n <= 0 returns all
+func TopEmojis(texts []string, n int) []Count {
+	counts := make(map[string]int)
+	for _, text := range texts {
+		for _, emoji := range Emojis(text) {
+			counts[emoji]++
+		}
+	}
+
+	top := make([]Count, 0, len(counts))
+	for token, count := range counts {
+		top = append(top, Count{Token: token, Count: count})
+	}
+	slices.SortFunc(top, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Token, b.Token))
+	})
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// Period is the bucket size of a sentiment trend
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+// Start returns the beginning of the period containing t, in t's location; weeks start on Monday
+func (p Period) Start(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch p {
+	case PeriodMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case PeriodWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// SentimentBucket aggregates sentiment over one period
+type SentimentBucket struct {
+	Start    time.Time
+	Posts    int
+	Average  float64 // mean Score per post
+	Positive int
+	Negative int
+	Neutral  int
+}
+
+// ScoredDocument pairs a document with its sentiment
+type ScoredDocument struct {
+	Document
+	Sentiment Sentiment
+}
+
+// ScoreDocuments scores every document
+func ScoreDocuments(docs []Document) []ScoredDocument {
+	scored := make([]ScoredDocument, len(docs))
+	for i, doc := range docs {
+		scored[i] = ScoredDocument{Document: doc, Sentiment: ScoreSentiment(doc.Text)}
+	}
+	return scored
+}
+
+// SentimentTrend buckets scored documents by period, oldest first; documents without a time are skipped
+func SentimentTrend(scored []ScoredDocument, period Period) []SentimentBucket {
+	buckets := make(map[time.Time]*SentimentBucket)
+	for _, doc := range scored {
+		if doc.Time.IsZero() {
+			continue
+		}
+		start := period.Start(doc.Time)
+		bucket, ok := buckets[start]
+		if !ok {
+			bucket = &SentimentBucket{Start: start}
+			buckets[start] = bucket
+		}
+		bucket.Posts++
+		bucket.Average += float64(doc.Sentiment.Score)
+		switch doc.Sentiment.Label() {
+		case "positive":
+			bucket.Positive++
+		case "negative":
+			bucket.Negative++
+		default:
+			bucket.Neutral++
+		}
+	}
+
+	trend := make([]SentimentBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		bucket.Average /= float64(bucket.Posts)
+		trend = append(trend, *bucket)
+	}
+	slices.SortFunc(trend, func(a, b SentimentBucket) int {
+		return a.Start.Compare(b.Start)
+	})
+	return trend
+}
+
+// Extremes returns up to n of the most positive and most negative documents, strongest first.
+// Ties are broken by comparative score so short emphatic posts rank above long mixed ones.
+func Extremes(scored []ScoredDocument, n int) (positive, negative []ScoredDocument) {
+	for _, doc := range scored {
+		if doc.Sentiment.Score > 0 {
+			positive = append(positive, doc)
+		} else if doc.Sentiment.Score < 0 {
+			negative = append(negative, doc)
+		}
+	}
+
+	slices.SortStableFunc(positive, func(a, b ScoredDocument) int {
+		return cmp.Or(cmp.Compare(b.Sentiment.Score, a.Sentiment.Score), cmp.Compare(b.Sentiment.Comparative, a.Sentiment.Comparative))
+	})
+	slices.SortStableFunc(negative, func(a, b ScoredDocument) int {
+		return cmp.Or(cmp.Compare(a.Sentiment.Score, b.Sentiment.Score), cmp.Compare(a.Sentiment.Comparative, b.Sentiment.Comparative))
+	})
+
+	if len(positive) > n {
+		positive = positive[:n]
+	}
+	if len(negative) > n {
+		negative = negative[:n]
+	}
+	return positive, negative
+}
@@ -0,0 +1,128 @@
+package analytics
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestScoreSentiment(t *testing.T) {
+	tests := []struct {
+		text  string
+		score int
+		label string
+	}{
+		{"What a wonderful day, I love it", 7, "positive"},
+		{"This is terrible and I hate it", -6, "negative"},
+		{"The meeting is at noon", 0, "neutral"},
+		{"This is not good", -3, "negative"},
+		{"No problem at all", 2, "positive"},
+		{"Honestly this isn't bad", 3, "positive"},
+		{"I don't think this is really that bad", -3, "negative"},
+		{"Shipped it 🎉🎉", 6, "positive"},
+		{"Lost my keys again 😭", -5, "negative"},
+	}
+
+	for _, tt := range tests {
+		got := ScoreSentiment(tt.text)
+		if got.Score != tt.score || got.Label() != tt.label {
+			t.Errorf("ScoreSentiment(%q) = %d (%s), want %d (%s)", tt.text, got.Score, got.Label(), tt.score, tt.label)
+		}
+	}
+
+	s := ScoreSentiment("good but sad")
+	if !slices.Equal(s.Positive, []string{"good"}) || !slices.Equal(s.Negative, []string{"sad"}) {
+		t.Errorf("unexpected contributing words: %v / %v", s.Positive, s.Negative)
+	}
+	if s.Comparative != 1.0/3 {
+		t.Errorf("expected comparative 1/3, got %v", s.Comparative)
+	}
+}
+
+func TestEmojis(t *testing.T) {
+	got := Emojis("nice 👍🏽 trip 🇯🇵 ❤️ 👨‍💻")
+	want := []string{"👍", "🇯🇵", "❤", "👨", "💻"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Emojis = %q, want %q", got, want)
+	}
+}
+
+func TestTopEmojis(t *testing.T) {
+	top := TopEmojis([]string{"🎉 🎉 🔥", "🔥🎉", "😭"}, 2)
+	want := []Count{{"🎉", 3}, {"🔥", 2}}
+	if !slices.Equal(top, want) {
+		t.Errorf("TopEmojis = %v, want %v", top, want)
+	}
+}
+
+func TestPeriod_Start(t *testing.T) {
+	// Thursday
+	at := time.Date(2025, 3, 13, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		period Period
+		want   time.Time
+	}{
+		{PeriodDay, time.Date(2025, 3, 13, 0, 0, 0, 0, time.UTC)},
+		{PeriodWeek, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{PeriodMonth, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := tt.period.Start(at); !got.Equal(tt.want) {
+			t.Errorf("%s start = %v, want %v", tt.period, got, tt.want)
+		}
+	}
+
+	sunday := time.Date(2025, 3, 16, 23, 0, 0, 0, time.UTC)
+	if got := PeriodWeek.Start(sunday); !got.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Sunday to belong to the week starting Monday, got %v", got)
+	}
+}
+
+func TestSentimentTrend(t *testing.T) {
+	jan := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	scored := ScoreDocuments([]Document{
+		{ID: "1", Text: "great news", Time: feb},
+		{ID: "2", Text: "awful news", Time: jan},
+		{ID: "3", Text: "happy happy", Time: jan},
+		{ID: "4", Text: "plain update", Time: jan},
+		{ID: "5", Text: "love it"},
+	})
+
+	trend := SentimentTrend(scored, PeriodMonth)
+	if len(trend) != 2 {
+		t.Fatalf("expected 2 monthly buckets, got %d", len(trend))
+	}
+
+	first := trend[0]
+	if !first.Start.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) || first.Posts != 3 {
+		t.Errorf("unexpected first bucket %+v", first)
+	}
+	if first.Positive != 1 || first.Negative != 1 || first.Neutral != 1 || first.Average != 1 {
+		t.Errorf("expected one of each label averaging 1, got %+v", first)
+	}
+	if trend[1].Posts != 1 || trend[1].Average != 3 {
+		t.Errorf("unexpected second bucket %+v", trend[1])
+	}
+}
+
+func TestExtremes(t *testing.T) {
+	scored := ScoreDocuments([]Document{
+		{ID: "mild", Text: "nice enough I suppose, it was a long and winding afternoon"},
+		{ID: "best", Text: "amazing amazing"},
+		{ID: "worst", Text: "worst day, awful"},
+		{ID: "meh", Text: "sorry"},
+		{ID: "neutral", Text: "on my way"},
+		{ID: "short", Text: "nice"},
+	})
+
+	positive, negative := Extremes(scored, 2)
+	if len(positive) != 2 || positive[0].ID != "best" || positive[1].ID != "short" {
+		t.Errorf("unexpected positive ranking: %+v", positive)
+	}
+	if len(negative) != 2 || negative[0].ID != "worst" || negative[1].ID != "meh" {
+		t.Errorf("unexpected negative ranking: %+v", negative)
+	}
+}
@@ -0,0 +1,112 @@
+package analytics
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var mentionPattern = regexp.MustCompile(`@[\w.-]+`)
+
+// Tokenize lowercases text and splits it into words, dropping links, @mentions, and bare numbers.
+// Hashtags keep their '#' and contractions keep their apostrophe.
+func Tokenize(text string) []string {
+	text = urlPattern.ReplaceAllString(strings.ToLower(text), " ")
+	text = mentionPattern.ReplaceAllString(text, " ")
+	text = strings.ReplaceAll(text, "’", "'")
+
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\'' && r != '#'
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, "'")
+		if !strings.ContainsFunc(field, unicode.IsLetter) {
+			continue
+		}
+		if len([]rune(field)) < 2 {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// IsStopword reports whether word is a common English function word that carries little meaning alone
+func IsStopword(word string) bool {
+	return stopwords[word]
+}
+
+// TermOptions tunes [TopTerms]
+type TermOptions struct {
+	N        int             // words per term: 1 for unigrams, 2 for bigrams
+	Limit    int             // maximum terms returned; 0 returns all
+	MinCount int             // drop terms seen fewer times
+	Exclude  map[string]bool // extra words to treat as stopwords
+}
+
+// TopTerms counts unigrams or bigrams across texts, most frequent first. A term is skipped when any of its
+// words is a stopword or excluded, so bigrams never bridge over "the" or "and".
+func TopTerms(texts []string, opts TermOptions) []Count {
+	if opts.N < 1 {
+		opts.N = 1
+	}
+
+	skip := func(word string) bool {
+		return stopwords[word] || opts.Exclude[word]
+	}
+
+	counts := make(map[string]int)
+	for _, text := range texts {
+		tokens := Tokenize(text)
+	terms:
+		for i := 0; i+opts.N <= len(tokens); i++ {
+			words := tokens[i : i+opts.N]
+			for _, word := range words {
+				if skip(word) {
+					continue terms
+				}
+			}
+			counts[strings.Join(words, " ")]++
+		}
+	}
+
+	top := make([]Count, 0, len(counts))
+	for term, count := range counts {
+		if count >= opts.MinCount {
+			top = append(top, Count{Token: term, Count: count})
+		}
+	}
+	slices.SortFunc(top, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Token, b.Token))
+	})
+	if opts.Limit > 0 && len(top) > opts.Limit {
+		top = top[:opts.Limit]
+	}
+	return top
+}
+
+// stopwords are skipped by [TopTerms]
+var stopwords = func() map[string]bool {
+	words := strings.Fields(`
+		a about above after again against all also am an and any are aren't as at be because been before
+		being below between both but by can can't cannot could couldn't did didn't do does doesn't doing
+		don't down during each even few for from further get got had hadn't has hasn't have haven't having
+		he he'd he'll he's her here here's hers herself him himself his how how's i i'd i'll i'm i've if in
+		into is isn't it it's its itself just let's like me more most much mustn't my myself no nor not now
+		of off on once one only or other ought our ours ourselves out over own really same shan't she she'd
+		she'll she's should shouldn't so some still such than that that's the their theirs them themselves
+		then there there's these they they'd they'll they're they've this those through to too under until
+		up us very was wasn't way we we'd we'll we're we've were weren't what what's when when's where
+		where's which while who who's whom why why's will with won't would wouldn't yeah you you'd you'll
+		you're you've your yours yourself yourselves im ive dont cant amp rt via lol oh ok okay
+	`)
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}()
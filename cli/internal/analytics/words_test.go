@@ -0,0 +1,49 @@
+package analytics
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Loving the new #GoLang release! Thanks @alice.bsky.social — read it at https://go.dev/blog 2025 x")
+	want := []string{"loving", "the", "new", "#golang", "release", "thanks", "read", "it", "at"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Tokenize = %q, want %q", got, want)
+	}
+
+	if got := Tokenize("It’s 'quoted' don't"); !slices.Equal(got, []string{"it's", "quoted", "don't"}) {
+		t.Errorf("expected apostrophes normalized and trimmed, got %q", got)
+	}
+}
+
+func TestTopTerms(t *testing.T) {
+	texts := []string{
+		"The open source community is great",
+		"Open source maintainers need support",
+		"I love open source and the community",
+		"Coffee first",
+	}
+
+	unigrams := TopTerms(texts, TermOptions{N: 1, Limit: 3})
+	want := []Count{{"open", 3}, {"source", 3}, {"community", 2}}
+	if !slices.Equal(unigrams, want) {
+		t.Errorf("unigrams = %v, want %v", unigrams, want)
+	}
+
+	bigrams := TopTerms(texts, TermOptions{N: 2, MinCount: 2})
+	if !slices.Equal(bigrams, []Count{{"open source", 3}}) {
+		t.Errorf("bigrams = %v, want only \"open source\"", bigrams)
+	}
+
+	for _, term := range TopTerms(texts, TermOptions{N: 2}) {
+		if term.Token == "source community" && term.Count != 1 {
+			t.Errorf("bigram bridged a stopword: %v", term)
+		}
+	}
+
+	excluded := TopTerms(texts, TermOptions{N: 1, Exclude: map[string]bool{"open": true, "source": true}, Limit: 1})
+	if len(excluded) != 1 || excluded[0].Token != "community" {
+		t.Errorf("expected excluded words to be skipped, got %v", excluded)
+	}
+}
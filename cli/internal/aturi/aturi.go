@@ -0,0 +1,295 @@
+package aturi
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Collections that bsky.app links point at
+const (
+	CollectionPost        = "app.bsky.feed.post"
+	CollectionList        = "app.bsky.graph.list"
+	CollectionFeed        = "app.bsky.feed.generator"
+	CollectionStarterPack = "app.bsky.graph.starterpack"
+)
+
+// webHosts are the bsky.app web client hosts whose links can be mapped to records
+var webHosts = map[string]bool{
+	"bsky.app":         true,
+	"www.bsky.app":     true,
+	"staging.bsky.app": true,
+	"main.bsky.dev":    true,
+}
+
+// postViews are bsky.app pages nested under a post that still identify that post
+var postViews = map[string]bool{"likes": true, "liked-by": true, "reposts": true, "reposted-by": true, "quotes": true}
+
+// Ref is a parsed reference to an account or to a record in an account's repository
+type Ref struct {
+	Authority  string // DID or handle, lowercased when it is a handle
+	Collection string // record NSID; empty when the reference is to the account itself
+	Rkey       string // record key; empty when the reference is to the account itself
+	Photo      int    // 1-based image index from a /photo/<n> post link; 0 when absent
+}
+
+// IsRecord reports whether the reference names a record rather than an account
+func (r Ref) IsRecord() bool {
+	return r.Collection != ""
+}
+
+// HasDID reports whether the authority is a DID, so no handle resolution is needed
+func (r Ref) HasDID() bool {
+	return strings.HasPrefix(r.Authority, "did:")
+}
+
+// String formats the reference as an AT URI
+func (r Ref) String() string {
+	if !r.IsRecord() {
+		return "at://" + r.Authority
+	}
+	return "at://" + r.Authority + "/" + r.Collection + "/" + r.Rkey
+}
+
+// Error describes why an input isn't a valid identifier
+type Error struct {
+	Input  string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid identifier %q: %s", e.Input, e.Reason)
+}
+
+func parseErr(input, format string, args ...any) error {
+	return &Error{Input: input, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Parse accepts an AT URI, a bsky.app link, a DID, or a handle (optionally prefixed with @) and returns what it refers to
+func Parse(input string) (Ref, error) {
+	s := strings.TrimSpace(input)
+	switch {
+	case s == "":
+		return Ref{}, parseErr(input, "empty input")
+	case strings.HasPrefix(s, "at://"):
+		return ParseAtURI(s)
+	case strings.HasPrefix(s, "https://"), strings.HasPrefix(s, "http://"):
+		return ParseURL(s)
+	case strings.Contains(s, "://"):
+		return Ref{}, parseErr(input, "unsupported scheme; use at:// or https://")
+	case strings.HasPrefix(s, "did:"):
+		if err := ValidateDID(s); err != nil {
+			return Ref{}, parseErr(input, "%v", err)
+		}
+		return Ref{Authority: s}, nil
+	}
+
+	handle := strings.TrimPrefix(s, "@")
+	if err := ValidateHandle(handle); err != nil {
+		return Ref{}, parseErr(input, "%v", err)
+	}
+	return Ref{Authority: strings.ToLower(handle)}, nil
+}
+
+// ParseAtURI parses at://<authority>[/<collection>/<rkey>]. Query strings and fragments are not allowed.
+func ParseAtURI(input string) (Ref, error) {
+	rest, ok := strings.CutPrefix(input, "at://")
+	if !ok {
+		return Ref{}, parseErr(input, "AT URIs start with at://")
+	}
+	if strings.ContainsAny(rest, "?#") {
+		return Ref{}, parseErr(input, "AT URIs can't carry a query or fragment")
+	}
+
+	parts := strings.Split(strings.TrimSuffix(rest, "/"), "/")
+	authority, err := parseAuthority(input, parts[0])
+	if err != nil {
+		return Ref{}, err
+	}
+
+	switch len(parts) {
+	case 1:
+		return Ref{Authority: authority}, nil
+	case 2:
+		return Ref{}, parseErr(input, "missing record key after collection %q", parts[1])
+	case 3:
+		if err := ValidateNSID(parts[1]); err != nil {
+			return Ref{}, parseErr(input, "%v", err)
+		}
+		if err := ValidateRkey(parts[2]); err != nil {
+			return Ref{}, parseErr(input, "%v", err)
+		}
+		return Ref{Authority: authority, Collection: parts[1], Rkey: parts[2]}, nil
+	default:
+		return Ref{}, parseErr(input, "too many path segments; expected at://<actor>/<collection>/<rkey>")
+	}
+}
+
+// ParseURL maps a bsky.app link to the account or record it shows. Supported paths are /profile/<actor> with
+// optional /post/<rkey>[/photo/<n>], /lists/<rkey>, or /feed/<rkey>, and starter packs at /starter-pack/<actor>/<rkey>
+// or /start/<actor>/<rkey>. Query strings, fragments, and trailing slashes are ignored.
+func ParseURL(input string) (Ref, error) {
+	u, err := url.Parse(strings.TrimSpace(input))
+	if err != nil {
+		return Ref{}, parseErr(input, "malformed URL")
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return Ref{}, parseErr(input, "unsupported scheme %q", u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "go.bsky.app" {
+		return Ref{}, parseErr(input, "go.bsky.app short links must be opened in a browser first; pass the bsky.app link they redirect to")
+	}
+	if !webHosts[host] {
+		return Ref{}, parseErr(input, "unsupported host %q; expected a bsky.app link", u.Hostname())
+	}
+
+	path, err := url.PathUnescape(u.EscapedPath())
+	if err != nil {
+		return Ref{}, parseErr(input, "malformed URL path")
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 1 && segments[0] == "" {
+		return Ref{}, parseErr(input, "link has no path; expected https://bsky.app/profile/<actor>/...")
+	}
+
+	switch segments[0] {
+	case "profile":
+		return parseProfilePath(input, segments[1:])
+	case "starter-pack", "start":
+		if len(segments) != 3 {
+			return Ref{}, parseErr(input, "expected https://bsky.app/%s/<actor>/<rkey>", segments[0])
+		}
+		return recordRef(input, segments[1], CollectionStarterPack, segments[2])
+	default:
+		return Ref{}, parseErr(input, "unsupported bsky.app page /%s", segments[0])
+	}
+}
+
+// parseProfilePath handles the segments after /profile/
+func parseProfilePath(input string, segments []string) (Ref, error) {
+	if len(segments) == 0 || segments[0] == "" {
+		return Ref{}, parseErr(input, "missing actor after /profile/")
+	}
+	if len(segments) == 1 {
+		authority, err := parseAuthority(input, segments[0])
+		if err != nil {
+			return Ref{}, err
+		}
+		return Ref{Authority: authority}, nil
+	}
+
+	kind := segments[1]
+	var collection string
+	switch kind {
+	case "post":
+		collection = CollectionPost
+	case "lists":
+		collection = CollectionList
+	case "feed":
+		collection = CollectionFeed
+	default:
+		return Ref{}, parseErr(input, "unsupported profile page %q; expected post, lists, or feed", kind)
+	}
+	if len(segments) < 3 || segments[2] == "" {
+		return Ref{}, parseErr(input, "missing record key after /%s/", kind)
+	}
+
+	ref, err := recordRef(input, segments[0], collection, segments[2])
+	if err != nil {
+		return Ref{}, err
+	}
+
+	extra := segments[3:]
+	switch {
+	case len(extra) == 0:
+		return ref, nil
+	case kind == "post" && extra[0] == "photo":
+		if len(extra) != 2 {
+			return Ref{}, parseErr(input, "expected /photo/<n> after the post key")
+		}
+		n, err := strconv.Atoi(extra[1])
+		if err != nil || n < 1 || n > 4 {
+			return Ref{}, parseErr(input, "photo index %q must be between 1 and 4", extra[1])
+		}
+		ref.Photo = n
+		return ref, nil
+	case kind == "post" && len(extra) == 1 && postViews[extra[0]]:
+		return ref, nil
+	default:
+		return Ref{}, parseErr(input, "unexpected path after the record key: /%s", strings.Join(extra, "/"))
+	}
+}
+
+// recordRef validates the parts of a record reference taken from a link
+func recordRef(input, actor, collection, rkey string) (Ref, error) {
+	authority, err := parseAuthority(input, actor)
+	if err != nil {
+		return Ref{}, err
+	}
+	if err := ValidateRkey(rkey); err != nil {
+		return Ref{}, parseErr(input, "%v", err)
+	}
+	return Ref{Authority: authority, Collection: collection, Rkey: rkey}, nil
+}
+
+// parseAuthority validates a DID or handle and lowercases handles
+func parseAuthority(input, authority string) (string, error) {
+	if authority == "" {
+		return "", parseErr(input, "missing actor")
+	}
+	if strings.HasPrefix(authority, "did:") {
+		if err := ValidateDID(authority); err != nil {
+			return "", parseErr(input, "%v", err)
+		}
+		return authority, nil
+	}
+	if err := ValidateHandle(authority); err != nil {
+		return "", parseErr(input, "%v", err)
+	}
+	return strings.ToLower(authority), nil
+}
+
+// ParsePost parses input and requires it to reference a post
+func ParsePost(input string) (Ref, error) {
+	return parseCollection(input, CollectionPost, "a post")
+}
+
+// ParseList parses input and requires it to reference a list
+func ParseList(input string) (Ref, error) {
+	return parseCollection(input, CollectionList, "a list")
+}
+
+// ParseFeed parses input and requires it to reference a feed generator
+func ParseFeed(input string) (Ref, error) {
+	return parseCollection(input, CollectionFeed, "a feed")
+}
+
+func parseCollection(input, collection, what string) (Ref, error) {
+	ref, err := Parse(input)
+	if err != nil {
+		return Ref{}, err
+	}
+	if ref.Collection != collection {
+		got := "an account"
+		if ref.IsRecord() {
+			got = "a record in " + ref.Collection
+		}
+		return Ref{}, parseErr(input, "expected %s but got %s", what, got)
+	}
+	return ref, nil
+}
+
+// ParseActor parses input and requires it to reference an account, returning its DID or handle
+func ParseActor(input string) (string, error) {
+	ref, err := Parse(input)
+	if err != nil {
+		return "", err
+	}
+	if ref.IsRecord() {
+		return "", parseErr(input, "expected an account but got a record in %s", ref.Collection)
+	}
+	return ref.Authority, nil
+}
@@ -0,0 +1,197 @@
+package aturi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Ref
+	}{
+		{"DID", "did:plc:abc123", Ref{Authority: "did:plc:abc123"}},
+		{"WebDID", "did:web:example.com", Ref{Authority: "did:web:example.com"}},
+		{"Handle", "@Alice.bsky.social", Ref{Authority: "alice.bsky.social"}},
+		{"CustomDomain", "jay.example.co.uk", Ref{Authority: "jay.example.co.uk"}},
+		{"AtAccount", "at://did:plc:abc123", Ref{Authority: "did:plc:abc123"}},
+		{"AtPost", "at://did:plc:abc/app.bsky.feed.post/3k2a", Ref{Authority: "did:plc:abc", Collection: CollectionPost, Rkey: "3k2a"}},
+		{"AtHandle", "at://alice.com/app.bsky.graph.list/vip", Ref{Authority: "alice.com", Collection: CollectionList, Rkey: "vip"}},
+		{"Profile", "https://bsky.app/profile/alice.com/", Ref{Authority: "alice.com"}},
+		{"Post", "https://bsky.app/profile/alice.bsky.social/post/3k2a", Ref{Authority: "alice.bsky.social", Collection: CollectionPost, Rkey: "3k2a"}},
+		{"PostDID", "http://www.bsky.app/profile/did:plc:abc/post/3k2a?ref=share#top", Ref{Authority: "did:plc:abc", Collection: CollectionPost, Rkey: "3k2a"}},
+		{"Photo", "https://bsky.app/profile/alice.com/post/3k2a/photo/2", Ref{Authority: "alice.com", Collection: CollectionPost, Rkey: "3k2a", Photo: 2}},
+		{"Quotes", "https://bsky.app/profile/alice.com/post/3k2a/quotes", Ref{Authority: "alice.com", Collection: CollectionPost, Rkey: "3k2a"}},
+		{"List", "https://bsky.app/profile/did:plc:me/lists/vip", Ref{Authority: "did:plc:me", Collection: CollectionList, Rkey: "vip"}},
+		{"Feed", "https://bsky.app/profile/alice.com/feed/cats", Ref{Authority: "alice.com", Collection: CollectionFeed, Rkey: "cats"}},
+		{"StarterPack", "https://bsky.app/starter-pack/alice.com/3kpack", Ref{Authority: "alice.com", Collection: CollectionStarterPack, Rkey: "3kpack"}},
+		{"StarterPackShort", "https://bsky.app/start/did:plc:abc/3kpack", Ref{Authority: "did:plc:abc", Collection: CollectionStarterPack, Rkey: "3kpack"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		reason string
+	}{
+		{"Empty", "  ", "empty input"},
+		{"Scheme", "ftp://bsky.app/profile/alice.com", "unsupported scheme"},
+		{"Host", "https://example.com/profile/alice.com/post/1", "unsupported host"},
+		{"ShortLink", "https://go.bsky.app/abc", "short links"},
+		{"NoPath", "https://bsky.app", "no path"},
+		{"Page", "https://bsky.app/settings", "unsupported bsky.app page"},
+		{"MissingActor", "https://bsky.app/profile/", "missing actor"},
+		{"ProfilePage", "https://bsky.app/profile/alice.com/media", "unsupported profile page"},
+		{"MissingRkey", "https://bsky.app/profile/alice.com/post/", "missing record key"},
+		{"PhotoIndex", "https://bsky.app/profile/alice.com/post/1/photo/5", "between 1 and 4"},
+		{"PhotoMissing", "https://bsky.app/profile/alice.com/post/1/photo", "expected /photo/<n>"},
+		{"TrailingPath", "https://bsky.app/profile/alice.com/lists/vip/members", "unexpected path"},
+		{"StarterPack", "https://bsky.app/starter-pack/alice.com", "starter-pack/<actor>/<rkey>"},
+		{"BareName", "alice", "needs a domain"},
+		{"HandleHyphen", "-alice.bsky.social", "hyphen"},
+		{"HandleTLD", "alice.123", "starting with a digit"},
+		{"DIDMethod", "did:PLC:abc", "lowercase"},
+		{"DIDEnd", "did:plc:abc:", "can't end"},
+		{"AtQuery", "at://did:plc:abc/app.bsky.feed.post/1?x=1", "query or fragment"},
+		{"AtCollectionOnly", "at://did:plc:abc/app.bsky.feed.post", "missing record key"},
+		{"AtNSID", "at://did:plc:abc/post/1", "NSID"},
+		{"AtTooLong", "at://did:plc:abc/app.bsky.feed.post/1/2", "too many path segments"},
+		{"Rkey", "at://did:plc:abc/app.bsky.feed.post/a b", "invalid character"},
+		{"RkeyReserved", "at://did:plc:abc/app.bsky.feed.post/..", "reserved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var parseErr *Error
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse(%q) = %v, want an *Error", tt.input, err)
+			}
+			if !strings.Contains(parseErr.Reason, tt.reason) {
+				t.Errorf("Parse(%q) reason = %q, want it to mention %q", tt.input, parseErr.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestParsePost(t *testing.T) {
+	ref, err := ParsePost("https://bsky.app/profile/alice.com/post/3k2a")
+	if err != nil || ref.String() != "at://alice.com/app.bsky.feed.post/3k2a" {
+		t.Errorf("ParsePost = %v (%v)", ref, err)
+	}
+	if _, err := ParsePost("https://bsky.app/profile/alice.com/lists/vip"); err == nil || !strings.Contains(err.Error(), "expected a post but got a record in app.bsky.graph.list") {
+		t.Errorf("expected a list link to be rejected, got %v", err)
+	}
+	if _, err := ParsePost("alice.com"); err == nil || !strings.Contains(err.Error(), "got an account") {
+		t.Errorf("expected an account to be rejected, got %v", err)
+	}
+}
+
+func TestParseFeed(t *testing.T) {
+	ref, err := ParseFeed("https://bsky.app/profile/did:plc:abc/feed/whats-hot")
+	if err != nil || ref.String() != "at://did:plc:abc/app.bsky.feed.generator/whats-hot" {
+		t.Errorf("ParseFeed = %v (%v)", ref, err)
+	}
+	if _, err := ParseFeed("at://did:plc:abc/app.bsky.feed.post/3k2a"); err == nil || !strings.Contains(err.Error(), "expected a feed") {
+		t.Errorf("expected a post to be rejected, got %v", err)
+	}
+}
+
+func TestParseActor(t *testing.T) {
+	actor, err := ParseActor("https://bsky.app/profile/Alice.com")
+	if err != nil || actor != "alice.com" {
+		t.Errorf("ParseActor = %q (%v)", actor, err)
+	}
+	if _, err := ParseActor("at://did:plc:abc/app.bsky.feed.post/1"); err == nil {
+		t.Error("expected a post URI to be rejected")
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"did:plc:abc123",
+		"@alice.bsky.social",
+		"at://did:plc:abc/app.bsky.feed.post/3k2a",
+		"at://alice.com",
+		"https://bsky.app/profile/alice.com/post/3k2a/photo/1",
+		"https://bsky.app/starter-pack/did:plc:abc/3kpack",
+		"https://bsky.app/profile/did:plc:me/lists/vip?x=1",
+		"https://bsky.app/profile/%2e%2e/post/1",
+		"http://[::1]/profile",
+		"at:///",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ref, err := Parse(input)
+		if err != nil {
+			var parseErr *Error
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse(%q) returned %T, want *Error", input, err)
+			}
+			return
+		}
+
+		if ref.Authority == "" || (ref.Collection == "") != (ref.Rkey == "") {
+			t.Fatalf("Parse(%q) returned an incomplete reference %+v", input, ref)
+		}
+		// Every accepted input formats to an AT URI that parses back to the same reference
+		again, err := ParseAtURI(ref.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) formatted to %q which doesn't parse: %v", input, ref.String(), err)
+		}
+		ref.Photo = 0
+		if again != ref {
+			t.Fatalf("round trip of %q changed %+v into %+v", input, ref, again)
+		}
+	})
+}
+
+func TestTIDTime(t *testing.T) {
+	got, ok := TIDTime("3khuwc44c222b")
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("TIDTime = %v, %v; want %v", got, ok, want)
+	}
+
+	if tid := NewTID(got, 7); tid != "3khuwc44c222b" {
+		t.Errorf("NewTID = %q, want 3khuwc44c222b", tid)
+	}
+
+	for _, input := range []string{"", "self", "3khuwc44c222", "zkhuwc44c222b", "3khuwc44c222!"} {
+		if _, ok := TIDTime(input); ok {
+			t.Errorf("TIDTime(%q) accepted a non-TID", input)
+		}
+	}
+}
+
+func FuzzValidate(f *testing.F) {
+	for _, seed := range []string{"did:plc:abc", "alice.bsky.social", "app.bsky.feed.post", "3k2a", "", ".", "a-.b"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		// Validators must not panic, and anything valid must be free of separators that would break an AT URI
+		for _, validate := range []func(string) error{ValidateDID, ValidateHandle, ValidateNSID, ValidateRkey} {
+			if validate(s) == nil && strings.ContainsAny(s, "/?# ") {
+				t.Fatalf("accepted %q containing a URI separator", s)
+			}
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package aturi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	maxDIDLength    = 2048
+	maxHandleLength = 253
+	maxLabelLength  = 63
+	maxNSIDLength   = 317
+	maxRkeyLength   = 512
+	tidLength       = 13
+)
+
+// tidAlphabet is the sortable base32 alphabet timestamp identifiers are written in
+const tidAlphabet = "234567abcdefghijklmnopqrstuvwxyz"
+
+// ValidateDID checks did:<method>:<identifier> syntax: a lowercase method and an identifier of letters, digits,
+// and ._:%- that doesn't end in : or %
+func ValidateDID(did string) error {
+	if len(did) > maxDIDLength {
+		return fmt.Errorf("DID is longer than %d characters", maxDIDLength)
+	}
+	rest, ok := strings.CutPrefix(did, "did:")
+	if !ok {
+		return errors.New("DID must start with did:")
+	}
+	method, id, ok := strings.Cut(rest, ":")
+	if !ok || method == "" {
+		return fmt.Errorf("DID %q is missing its method", did)
+	}
+	for _, c := range method {
+		if c < 'a' || c > 'z' {
+			return fmt.Errorf("DID method %q must be lowercase letters", method)
+		}
+	}
+	if id == "" {
+		return fmt.Errorf("DID %q is missing its identifier", did)
+	}
+	for _, c := range id {
+		if !isAlnum(c) && !strings.ContainsRune("._:%-", c) {
+			return fmt.Errorf("DID %q contains invalid character %q", did, c)
+		}
+	}
+	if strings.HasSuffix(id, ":") || strings.HasSuffix(id, "%") {
+		return fmt.Errorf("DID %q can't end with %q", did, id[len(id)-1:])
+	}
+	return nil
+}
+
+// ValidateHandle checks handle syntax: two or more dot-separated labels of letters, digits, and hyphens, none starting
+// or ending with a hyphen, and a final label that doesn't start with a digit. Custom domains follow the same rules.
+func ValidateHandle(handle string) error {
+	if handle == "" {
+		return errors.New("handle is empty")
+	}
+	if len(handle) > maxHandleLength {
+		return fmt.Errorf("handle is longer than %d characters", maxHandleLength)
+	}
+	labels := strings.Split(handle, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("handle %q needs a domain, like %s.bsky.social", handle, handle)
+	}
+	for _, label := range labels {
+		if label == "" {
+			return fmt.Errorf("handle %q has an empty label", handle)
+		}
+		if len(label) > maxLabelLength {
+			return fmt.Errorf("handle %q has a label longer than %d characters", handle, maxLabelLength)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("handle %q has a label starting or ending with a hyphen", handle)
+		}
+		for _, c := range label {
+			if !isAlnum(c) && c != '-' {
+				return fmt.Errorf("handle %q contains invalid character %q", handle, c)
+			}
+		}
+	}
+	if last := labels[len(labels)-1]; last[0] >= '0' && last[0] <= '9' {
+		return fmt.Errorf("handle %q has a top-level domain starting with a digit", handle)
+	}
+	return nil
+}
+
+// ValidateNSID checks collection syntax: three or more dot-separated segments, the last of which is letters and digits
+func ValidateNSID(nsid string) error {
+	if len(nsid) > maxNSIDLength {
+		return fmt.Errorf("collection is longer than %d characters", maxNSIDLength)
+	}
+	segments := strings.Split(nsid, ".")
+	if len(segments) < 3 {
+		return fmt.Errorf("collection %q must be an NSID like app.bsky.feed.post", nsid)
+	}
+	for i, segment := range segments {
+		if segment == "" || len(segment) > maxLabelLength {
+			return fmt.Errorf("collection %q has an empty or overlong segment", nsid)
+		}
+		for _, c := range segment {
+			if !isAlnum(c) && (c != '-' || i == len(segments)-1) {
+				return fmt.Errorf("collection %q contains invalid character %q", nsid, c)
+			}
+		}
+		if segment[0] == '-' || segment[len(segment)-1] == '-' {
+			return fmt.Errorf("collection %q has a segment starting or ending with a hyphen", nsid)
+		}
+	}
+	if first := segments[len(segments)-1][0]; first >= '0' && first <= '9' {
+		return fmt.Errorf("collection %q has a name starting with a digit", nsid)
+	}
+	return nil
+}
+
+// ValidateRkey checks record key syntax: 1-512 characters of letters, digits, and ._:~-, other than . and ..
+func ValidateRkey(rkey string) error {
+	if rkey == "" {
+		return errors.New("record key is empty")
+	}
+	if len(rkey) > maxRkeyLength {
+		return fmt.Errorf("record key is longer than %d characters", maxRkeyLength)
+	}
+	if rkey == "." || rkey == ".." {
+		return fmt.Errorf("record key %q is reserved", rkey)
+	}
+	for _, c := range rkey {
+		if !isAlnum(c) && !strings.ContainsRune("._:~-", c) {
+			return fmt.Errorf("record key %q contains invalid character %q", rkey, c)
+		}
+	}
+	return nil
+}
+
+// TIDTime returns the time encoded in a timestamp identifier, the form of repository revisions and most record keys.
+// It reports false for anything that isn't a TID.
+func TIDTime(tid string) (time.Time, bool) {
+	if len(tid) != tidLength {
+		return time.Time{}, false
+	}
+
+	var value uint64
+	for n, c := range tid {
+		// 13 characters carry 65 bits, so the first one only has room for 4
+		i := strings.IndexRune(tidAlphabet, c)
+		if i < 0 || n == 0 && i >= 16 {
+			return time.Time{}, false
+		}
+		value = value<<5 | uint64(i)
+	}
+
+	// The top bit is always zero; the next 53 are microseconds since the epoch and the last 10 a clock ID
+	if value>>63 != 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMicro(int64(value >> 10)).UTC(), true
+}
+
+// NewTID returns the timestamp identifier for t and a clock ID, of which the low 10 bits are used
+func NewTID(t time.Time, clockID uint) string {
+	value := uint64(t.UnixMicro())<<10&(1<<63-1) | uint64(clockID&0x3ff)
+
+	tid := make([]byte, tidLength)
+	for i := tidLength - 1; i >= 0; i-- {
+		tid[i] = tidAlphabet[value&0x1f]
+		value >>= 5
+	}
+	return string(tid)
+}
+
+func isAlnum(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
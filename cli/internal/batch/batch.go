@@ -0,0 +1,244 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Job is one skycli command line in a batch file, run once every job it needs has succeeded
+type Job struct {
+	Name  string   `yaml:"name"`
+	Args  Args     `yaml:"run"`
+	Needs []string `yaml:"needs"`
+}
+
+// Args is a job's command line without the "skycli" prefix. In YAML it is either a list of arguments or a
+// single string split like a shell would.
+type Args []string
+
+// UnmarshalYAML accepts a string or a sequence of strings
+func (a *Args) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		args, err := utils.SplitArgs(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		*a = args
+		return nil
+	}
+
+	var args []string
+	if err := node.Decode(&args); err != nil {
+		return err
+	}
+	*a = args
+	return nil
+}
+
+// File is the layout of a batch file
+type File struct {
+	Jobs []Job `yaml:"jobs"`
+}
+
+// Status is the outcome of a job
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusSkipped   Status = "skipped" // a needed job failed or the batch was canceled
+)
+
+// Result is the outcome of running one job
+type Result struct {
+	Job     Job
+	Status  Status
+	Err     error
+	Elapsed time.Duration
+}
+
+// BatchError reports an unreadable or invalid batch file
+type BatchError struct {
+	Op  string
+	Err error
+}
+
+func (e *BatchError) Error() string {
+	return "batch." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// Load reads and validates the batch file at path
+func Load(path string) ([]Job, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &BatchError{Op: "Load", Err: err}
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a batch file. Unknown keys are an error so misspelled fields aren't ignored.
+func Parse(data []byte) ([]Job, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var file File
+	if err := decoder.Decode(&file); err != nil {
+		return nil, &BatchError{Op: "Parse", Err: err}
+	}
+	if err := Validate(file.Jobs); err != nil {
+		return nil, err
+	}
+	return file.Jobs, nil
+}
+
+// Validate checks that jobs have unique names and command lines, and that their needs name other jobs
+// without forming a cycle
+func Validate(jobs []Job) error {
+	if len(jobs) == 0 {
+		return &BatchError{Op: "Validate", Err: errors.New("no jobs defined")}
+	}
+
+	index := make(map[string]int, len(jobs))
+	for i, job := range jobs {
+		if job.Name == "" {
+			return &BatchError{Op: "Validate", Err: fmt.Errorf("job %d has no name", i+1)}
+		}
+		if _, ok := index[job.Name]; ok {
+			return &BatchError{Op: "Validate", Err: fmt.Errorf("duplicate job name %q", job.Name)}
+		}
+		if len(job.Args) == 0 {
+			return &BatchError{Op: "Validate", Err: fmt.Errorf("job %q has nothing to run", job.Name)}
+		}
+		index[job.Name] = i
+	}
+
+	for _, job := range jobs {
+		for _, need := range job.Needs {
+			if _, ok := index[need]; !ok {
+				return &BatchError{Op: "Validate", Err: fmt.Errorf("job %q needs unknown job %q", job.Name, need)}
+			}
+		}
+	}
+
+	// Depth-first search; a job reached again while still on the path closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(jobs))
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		path = append(path, jobs[i].Name)
+		switch state[i] {
+		case visiting:
+			return &BatchError{Op: "Validate", Err: fmt.Errorf("dependency cycle: %s", strings.Join(path, " -> "))}
+		case visited:
+			return nil
+		}
+		state[i] = visiting
+		for _, need := range jobs[i].Needs {
+			if err := visit(index[need], path); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		return nil
+	}
+	for i := range jobs {
+		if err := visit(i, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Options tunes [Run]
+type Options struct {
+	Parallel int          // jobs run at once; defaults to 1
+	Done     func(Result) // called as each job finishes or is skipped, one call at a time
+}
+
+// Run executes validated jobs with exec, starting each once the jobs it needs have succeeded and keeping at most
+// opts.Parallel running. Jobs needing a failed or skipped job are skipped, as are jobs not yet started when ctx is
+// done. Results are returned in the order of jobs.
+func Run(ctx context.Context, jobs []Job, exec func(ctx context.Context, job Job) error, opts Options) []Result {
+	if opts.Parallel <= 0 {
+		opts.Parallel = 1
+	}
+
+	index := make(map[string]int, len(jobs))
+	for i, job := range jobs {
+		index[job.Name] = i
+	}
+
+	results := make([]Result, len(jobs))
+	done := make([]chan struct{}, len(jobs))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	slots := make(chan struct{}, opts.Parallel)
+
+	var report sync.Mutex
+	finish := func(i int, result Result) {
+		results[i] = result
+		if opts.Done != nil {
+			report.Lock()
+			opts.Done(result)
+			report.Unlock()
+		}
+		close(done[i])
+	}
+
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for _, need := range job.Needs {
+				<-done[index[need]]
+				if dep := results[index[need]]; dep.Status != StatusSucceeded {
+					finish(i, Result{Job: job, Status: StatusSkipped, Err: fmt.Errorf("needed job %q %s", need, dep.Status)})
+					return
+				}
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				finish(i, Result{Job: job, Status: StatusSkipped, Err: ctx.Err()})
+				return
+			}
+			defer func() { <-slots }()
+
+			if err := ctx.Err(); err != nil {
+				finish(i, Result{Job: job, Status: StatusSkipped, Err: err})
+				return
+			}
+
+			start := time.Now()
+			err := exec(ctx, job)
+			result := Result{Job: job, Status: StatusSucceeded, Elapsed: time.Since(start)}
+			if err != nil {
+				result.Status, result.Err = StatusFailed, err
+			}
+			finish(i, result)
+		}()
+	}
+	wg.Wait()
+	return results
+}
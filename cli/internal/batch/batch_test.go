@@ -0,0 +1,165 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestParse verifies both run forms and the validation of names and needs
+func TestParse(t *testing.T) {
+	t.Run("RunForms", func(t *testing.T) {
+		jobs, err := Parse([]byte(`
+jobs:
+  - name: snapshot
+    run: followers snapshot --actor "alice.bsky.social"
+  - name: export
+    run: [snapshots, export, --out, "alice snapshot.json"]
+    needs: [snapshot]
+`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(jobs) != 2 {
+			t.Fatalf("expected 2 jobs, got %d", len(jobs))
+		}
+		if want := []string{"followers", "snapshot", "--actor", "alice.bsky.social"}; !slices.Equal(jobs[0].Args, want) {
+			t.Errorf("string run = %q, want %q", jobs[0].Args, want)
+		}
+		if want := []string{"snapshots", "export", "--out", "alice snapshot.json"}; !slices.Equal(jobs[1].Args, want) {
+			t.Errorf("list run = %q, want %q", jobs[1].Args, want)
+		}
+		if !slices.Equal(jobs[1].Needs, []string{"snapshot"}) {
+			t.Errorf("needs = %q", jobs[1].Needs)
+		}
+	})
+
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{name: "Empty", file: "jobs: []", want: "no jobs"},
+		{name: "UnknownField", file: "jobs:\n  - name: a\n    run: fetch\n    after: [b]", want: "not found"},
+		{name: "MissingName", file: "jobs:\n  - run: fetch", want: "no name"},
+		{name: "MissingRun", file: "jobs:\n  - name: a", want: "nothing to run"},
+		{name: "Duplicate", file: "jobs:\n  - {name: a, run: fetch}\n  - {name: a, run: fetch}", want: "duplicate"},
+		{name: "UnknownNeed", file: "jobs:\n  - {name: a, run: fetch, needs: [b]}", want: "unknown job"},
+		{name: "Unterminated", file: "jobs:\n  - {name: a, run: 'fetch \"x'}", want: "unterminated"},
+		{name: "Cycle", file: "jobs:\n  - {name: a, run: fetch, needs: [c]}\n  - {name: b, run: fetch, needs: [a]}\n  - {name: c, run: fetch, needs: [b]}", want: "cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.file))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestRun verifies dependency ordering, skipping after failures, and the parallelism bound
+func TestRun(t *testing.T) {
+	t.Run("Order", func(t *testing.T) {
+		jobs := []Job{
+			{Name: "export", Args: Args{"x"}, Needs: []string{"alice", "bob"}},
+			{Name: "alice", Args: Args{"x"}},
+			{Name: "bob", Args: Args{"x"}},
+		}
+
+		var mu sync.Mutex
+		var order []string
+		results := Run(context.Background(), jobs, func(ctx context.Context, job Job) error {
+			mu.Lock()
+			order = append(order, job.Name)
+			mu.Unlock()
+			return nil
+		}, Options{Parallel: 2})
+
+		if len(order) != 3 || order[2] != "export" {
+			t.Errorf("expected export last, got %v", order)
+		}
+		for _, result := range results {
+			if result.Status != StatusSucceeded {
+				t.Errorf("job %s: status %s", result.Job.Name, result.Status)
+			}
+		}
+		if results[0].Job.Name != "export" {
+			t.Errorf("expected results in job order, got %s first", results[0].Job.Name)
+		}
+	})
+
+	t.Run("SkipsDependentsOfFailures", func(t *testing.T) {
+		jobs := []Job{
+			{Name: "a", Args: Args{"x"}},
+			{Name: "b", Args: Args{"x"}, Needs: []string{"a"}},
+			{Name: "c", Args: Args{"x"}, Needs: []string{"b"}},
+			{Name: "d", Args: Args{"x"}},
+		}
+		failure := errors.New("boom")
+
+		var reported []string
+		results := Run(context.Background(), jobs, func(ctx context.Context, job Job) error {
+			if job.Name == "a" {
+				return failure
+			}
+			return nil
+		}, Options{Parallel: 4, Done: func(r Result) { reported = append(reported, r.Job.Name) }})
+
+		want := []Status{StatusFailed, StatusSkipped, StatusSkipped, StatusSucceeded}
+		for i, result := range results {
+			if result.Status != want[i] {
+				t.Errorf("job %s: status %s, want %s", result.Job.Name, result.Status, want[i])
+			}
+		}
+		if !errors.Is(results[0].Err, failure) {
+			t.Errorf("expected failure to wrap exec error, got %v", results[0].Err)
+		}
+		if len(reported) != len(jobs) {
+			t.Errorf("expected Done for every job, got %v", reported)
+		}
+	})
+
+	t.Run("Parallelism", func(t *testing.T) {
+		var jobs []Job
+		for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			jobs = append(jobs, Job{Name: name, Args: Args{"x"}})
+		}
+
+		var running, peak atomic.Int32
+		Run(context.Background(), jobs, func(ctx context.Context, job Job) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		}, Options{Parallel: 2})
+
+		if peak.Load() > 2 {
+			t.Errorf("expected at most 2 jobs at once, saw %d", peak.Load())
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results := Run(ctx, []Job{{Name: "a", Args: Args{"x"}}}, func(ctx context.Context, job Job) error {
+			t.Error("job ran after cancel")
+			return nil
+		}, Options{})
+		if results[0].Status != StatusSkipped {
+			t.Errorf("expected skipped, got %s", results[0].Status)
+		}
+	})
+}
@@ -4,22 +4,158 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
+	"time"
 )
 
 // Config represents the application configuration stored in ~/.skycli/.config.json
 // Tokens are encrypted at rest using AES-256-GCM
 type Config struct {
-	Session *SessionConfig `json:"session,omitempty"`
+	Session   *SessionConfig   `json:"session,omitempty"`  // The default account
+	Accounts  []*SessionConfig `json:"accounts,omitempty"` // Other signed-in accounts, most recently used first
+	Storage   *StorageConfig   `json:"storage,omitempty"`
+	Sync      *StorageConfig   `json:"sync,omitempty"` // Backend for `skycli sync state`
+	Team      *TeamConfig      `json:"team,omitempty"`
+	Database  *DatabaseConfig  `json:"database,omitempty"`
+	Timeouts  *TimeoutConfig   `json:"timeouts,omitempty"`
+	Network   *NetworkConfig   `json:"network,omitempty"`
+	Telemetry *TelemetryConfig `json:"telemetry,omitempty"`
+	Daemon    *DaemonConfig    `json:"daemon,omitempty"`
+	Cache     *CacheConfig     `json:"cache,omitempty"`
+	Limits    *LimitsConfig    `json:"limits,omitempty"`
+	Theme     *ThemeConfig     `json:"theme,omitempty"`
+	Timezone  string           `json:"timezone,omitempty"` // IANA zone such as Europe/Berlin; defaults to the system zone
 }
 
-// SessionConfig holds the current session information with encrypted tokens
+// ThemeConfig styles the activity and verification indicators in follower and following tables. Colors are hex
+// codes such as #9bce8a or ANSI color numbers; unset ones keep their defaults.
+type ThemeConfig struct {
+	Plain    bool   `json:"plain,omitempty"`    // Turn the indicators off
+	Active   string `json:"active,omitempty"`   // Accounts that posted in the last 7 days; defaults to green
+	Recent   string `json:"recent,omitempty"`   // Posted in the last 30 days; defaults to yellow
+	Inactive string `json:"inactive,omitempty"` // Posted longer ago, or never; defaults to dim red
+}
+
+// DefaultMaxAccounts is the safety cap on followers or follows a command pages through when none is configured
+const DefaultMaxAccounts = 50000
+
+// LimitsConfig guards against accidentally paging through very large follower graphs
+type LimitsConfig struct {
+	MaxAccounts int `json:"maxAccounts,omitempty"` // Safety cap when --limit is 0; defaults to DefaultMaxAccounts, -1 disables it
+}
+
+// CacheConfig bounds the post rate and activity caches
+type CacheConfig struct {
+	MaxSize int64 `json:"maxSize,omitempty"` // Estimated bytes before the least recently fetched entries are evicted; 0 means unlimited
+}
+
+// DaemonConfig tunes the background tasks run by `skycli daemon`
+type DaemonConfig struct {
+	Intervals      map[string]string `json:"intervals,omitempty"`      // Per-task Go duration such as "30m", or "off" to disable
+	Jetstream      string            `json:"jetstream,omitempty"`      // Jetstream subscribe URL for the stream task
+	ArchiveRules   []ArchiveRule     `json:"archiveRules,omitempty"`   // Posts from the stream to keep locally
+	TrackFollowers bool              `json:"trackFollowers,omitempty"` // Follow the network's follow events to keep followers current
+	SyncProfiles   bool              `json:"syncProfiles,omitempty"`   // Follow identity, account, and profile events to keep cached profiles current
+	Backup         *BackupConfig     `json:"backup,omitempty"`         // Copy your own new posts into the local archive
+}
+
+// BackupConfig enables the backup task, which saves your new posts into a local feed, and optionally your likes
+// and reposts into feeds of their own
+type BackupConfig struct {
+	Likes   bool `json:"likes,omitempty"`
+	Reposts bool `json:"reposts,omitempty"`
+}
+
+// ArchiveRule saves streamed posts matching any of its criteria into a local feed named after the rule
+type ArchiveRule struct {
+	Name     string   `json:"name"`
+	Keywords []string `json:"keywords,omitempty"` // Case-insensitive words or phrases in the post text
+	Authors  []string `json:"authors,omitempty"`  // Author DIDs
+	Lists    []string `json:"lists,omitempty"`    // List AT URIs whose members' posts are kept
+}
+
+// TelemetryConfig exports OpenTelemetry traces over OTLP/HTTP. The standard OTEL_EXPORTER_OTLP_* variables
+// also enable export and take precedence over these fields.
+type TelemetryConfig struct {
+	Endpoint    string            `json:"endpoint,omitempty"`    // Collector URL, e.g. http://localhost:4318
+	Headers     map[string]string `json:"headers,omitempty"`     // Extra headers such as vendor API keys
+	SampleRatio float64           `json:"sampleRatio,omitempty"` // Fraction of traces kept; defaults to 1
+}
+
+// NetworkConfig tunes the HTTP transport used for API traffic, mainly for corporate networks.
+// Without a proxy set here, the standard HTTPS_PROXY, HTTP_PROXY, and NO_PROXY variables apply.
+type NetworkConfig struct {
+	Proxy               string `json:"proxy,omitempty"`               // http(s):// or socks5:// proxy for all API requests
+	CAFile              string `json:"caFile,omitempty"`              // Extra PEM root certificates, e.g. for TLS-inspecting proxies
+	InsecureSkipVerify  bool   `json:"insecureSkipVerify,omitempty"`  // Disable certificate verification (debugging only)
+	MinTLSVersion       string `json:"minTlsVersion,omitempty"`       // "1.2" (default) or "1.3"
+	DisableHTTP2        bool   `json:"disableHttp2,omitempty"`        // Force HTTP/1.1, for proxies that mishandle HTTP/2
+	MaxIdleConnsPerHost int    `json:"maxIdleConnsPerHost,omitempty"` // Defaults to the maximum batch request concurrency
+}
+
+// TimeoutConfig overrides API request timeouts per endpoint class. Values are Go durations such as "45s";
+// empty fields keep the built-in defaults.
+type TimeoutConfig struct {
+	Default string `json:"default,omitempty"`
+	Auth    string `json:"auth,omitempty"`    // createSession, refreshSession
+	Health  string `json:"health,omitempty"`  // _health
+	Feed    string `json:"feed,omitempty"`    // getTimeline, getAuthorFeed, getPosts
+	Graph   string `json:"graph,omitempty"`   // getFollowers, getFollows
+	Profile string `json:"profile,omitempty"` // getProfile
+	Search  string `json:"search,omitempty"`  // searchActors, searchPosts
+}
+
+// DatabaseURLEnv overrides the configured database connection string
+const DatabaseURLEnv = "SKYCLI_DATABASE_URL"
+
+// DatabaseConfig selects the SQL backend for posts, snapshots, and caches.
+// Feeds, profiles, and the session always stay in the local SQLite cache.
+type DatabaseConfig struct {
+	Driver string `json:"driver"`        // "sqlite" (default) or "postgres"
+	URL    string `json:"url,omitempty"` // Connection string; keep the password in PGPASSWORD rather than here
+}
+
+// TeamDatabaseURLEnv overrides the configured team database connection string
+const TeamDatabaseURLEnv = "SKYCLI_TEAM_DATABASE_URL"
+
+// TeamConfig enables shared-audience mode: snapshots and analytics are stored in a Postgres database
+// shared by everyone managing the same account. The connection string may contain a password, so it is
+// encrypted at rest like session tokens.
+type TeamConfig struct {
+	EncryptedDatabaseURL string `json:"encryptedDatabaseUrl"`
+}
+
+// SessionConfig holds one account's session information with encrypted tokens
 type SessionConfig struct {
-	Handle           string `json:"handle"`
-	Did              string `json:"did"`
-	ServiceURL       string `json:"serviceUrl"`
-	EncryptedAccess  string `json:"encryptedAccessToken"`
-	EncryptedRefresh string `json:"encryptedRefreshToken"`
-	Email            string `json:"email,omitempty"`
+	Handle           string       `json:"handle"`
+	Did              string       `json:"did"`
+	ServiceURL       string       `json:"serviceUrl"`
+	EncryptedAccess  string       `json:"encryptedAccessToken"`
+	EncryptedRefresh string       `json:"encryptedRefreshToken"`
+	Email            string       `json:"email,omitempty"`
+	AppPassword      string       `json:"appPassword,omitempty"` // name of the app password the session was created with, when known
+	OAuth            *OAuthConfig `json:"oauth,omitempty"`       // set when the session was created by OAuth rather than a password
+}
+
+// OAuthConfig holds what an OAuth session needs besides its tokens. The DPoP key the tokens are bound to is
+// encrypted like them.
+type OAuthConfig struct {
+	Issuer           string `json:"issuer"`
+	TokenEndpoint    string `json:"tokenEndpoint"`
+	ClientID         string `json:"clientId"`
+	EncryptedDPoPKey string `json:"encryptedDpopKey"`
+}
+
+// StorageConfig describes the remote object storage used by `skycli archive push/pull` and `skycli sync state`.
+// Credentials are never stored here; they are read from the environment at runtime.
+type StorageConfig struct {
+	Backend  string `json:"backend"`            // "s3", "file", "webdav", or "git"
+	Endpoint string `json:"endpoint,omitempty"` // S3-compatible endpoint (defaults to AWS), WebDAV URL, or git remote
+	Region   string `json:"region,omitempty"`
+	Bucket   string `json:"bucket,omitempty"`
+	Prefix   string `json:"prefix,omitempty"`   // Key prefix applied to every object
+	Path     string `json:"path,omitempty"`     // Root directory for the file backend, or local clone for git
+	Username string `json:"username,omitempty"` // WebDAV user; the password comes from SKYCLI_WEBDAV_PASSWORD
+	Branch   string `json:"branch,omitempty"`   // Git branch, defaults to main
 }
 
 // Load reads and decrypts the configuration from ~/.skycli/.config.json
@@ -106,6 +242,86 @@ func (s *SessionConfig) SetRefreshToken(token string) error {
 	return nil
 }
 
+// GetDPoPKey decrypts and returns the DPoP private key
+func (o *OAuthConfig) GetDPoPKey() (string, error) {
+	if o == nil || o.EncryptedDPoPKey == "" {
+		return "", nil
+	}
+	return DecryptToken(o.EncryptedDPoPKey)
+}
+
+// SetDPoPKey encrypts and stores the DPoP private key
+func (o *OAuthConfig) SetDPoPKey(key string) error {
+	encrypted, err := EncryptToken(key)
+	if err != nil {
+		return err
+	}
+	o.EncryptedDPoPKey = encrypted
+	return nil
+}
+
+// TeamDatabaseURL returns the shared Postgres connection string, preferring [TeamDatabaseURLEnv].
+// Returns an empty string when team mode is not configured.
+func (c *Config) TeamDatabaseURL() (string, error) {
+	if url := os.Getenv(TeamDatabaseURLEnv); url != "" {
+		return url, nil
+	}
+	if c.Team == nil || c.Team.EncryptedDatabaseURL == "" {
+		return "", nil
+	}
+	return DecryptToken(c.Team.EncryptedDatabaseURL)
+}
+
+// DatabaseDriver returns the configured database driver and connection string, preferring [DatabaseURLEnv]
+// for the connection string. An empty driver means the local SQLite cache.
+func (c *Config) DatabaseDriver() (driver, url string) {
+	if c.Database != nil {
+		driver, url = c.Database.Driver, c.Database.URL
+	}
+	if env := os.Getenv(DatabaseURLEnv); env != "" {
+		url = env
+	}
+	return driver, url
+}
+
+// CacheMaxSize returns the configured cache bound in bytes, or 0 when unlimited
+func (c *Config) CacheMaxSize() int64 {
+	if c.Cache == nil {
+		return 0
+	}
+	return c.Cache.MaxSize
+}
+
+// MaxAccounts returns the safety cap on followers or follows a command pages through, or 0 when disabled
+func (c *Config) MaxAccounts() int {
+	if c.Limits == nil || c.Limits.MaxAccounts == 0 {
+		return DefaultMaxAccounts
+	}
+	return max(c.Limits.MaxAccounts, 0)
+}
+
+// Location returns the configured time zone, or the system zone when none is set
+func (c *Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, &ConfigError{Op: "Location", Err: err}
+	}
+	return loc, nil
+}
+
+// SetDatabaseURL encrypts and stores the shared Postgres connection string
+func (t *TeamConfig) SetDatabaseURL(url string) error {
+	encrypted, err := EncryptToken(url)
+	if err != nil {
+		return err
+	}
+	t.EncryptedDatabaseURL = encrypted
+	return nil
+}
+
 // ConfigError represents an error that occurred during config operations
 type ConfigError struct {
 	Op  string
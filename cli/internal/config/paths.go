@@ -8,28 +8,38 @@ import (
 
 const appName = "skycli"
 
+// ConfigDirEnv names the environment variable that overrides the configuration directory on every platform
+const ConfigDirEnv = "SKYCLI_CONFIG_DIR"
+
 // GetConfigDir returns the platform-specific configuration directory path.
-// On Unix-like systems: ~/.skycli or Windows: %APPDATA%/skycli
+// On Unix-like systems (including WSL): ~/.skycli or Windows: %APPDATA%/skycli, unless SKYCLI_CONFIG_DIR is set.
 func GetConfigDir() (string, error) {
-	var baseDir string
+	return configDir(runtime.GOOS, os.Getenv, os.UserHomeDir)
+}
 
-	if runtime.GOOS == "windows" {
-		baseDir = os.Getenv("APPDATA")
-		if baseDir == "" {
-			return "", &PathError{Op: "GetConfigDir", Err: "APPDATA environment variable not set"}
+// configDir resolves the configuration directory for goos, reading the environment with getenv. On Windows it falls
+// back from APPDATA to the roaming folder under USERPROFILE, which APPDATA normally points at but which some
+// services and CI shells leave unset.
+func configDir(goos string, getenv func(string) string, home func() (string, error)) (string, error) {
+	if dir := getenv(ConfigDirEnv); dir != "" {
+		return filepath.Clean(dir), nil
+	}
+
+	if goos == "windows" {
+		if appData := getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, appName), nil
 		}
-	} else {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return "", &PathError{Op: "GetConfigDir", Err: err.Error()}
+		if profile := getenv("USERPROFILE"); profile != "" {
+			return filepath.Join(profile, "AppData", "Roaming", appName), nil
 		}
-		baseDir = homeDir
+		return "", &PathError{Op: "GetConfigDir", Err: "neither APPDATA nor USERPROFILE is set; set " + ConfigDirEnv}
 	}
 
-	if runtime.GOOS == "windows" {
-		return filepath.Join(baseDir, appName), nil
+	homeDir, err := home()
+	if err != nil {
+		return "", &PathError{Op: "GetConfigDir", Err: err.Error() + "; set " + ConfigDirEnv}
 	}
-	return filepath.Join(baseDir, "."+appName), nil
+	return filepath.Join(homeDir, "."+appName), nil
 }
 
 // GetConfigFile returns the full path to the configuration file.
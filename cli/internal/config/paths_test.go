@@ -0,0 +1,44 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigDir(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	home := func() (string, error) { return "/home/me", nil }
+	noHome := func() (string, error) { return "", errors.New("$HOME is not defined") }
+
+	tests := []struct {
+		name string
+		goos string
+		vars map[string]string
+		home func() (string, error)
+		want string
+	}{
+		{"unix home", "linux", nil, home, filepath.Join("/home/me", ".skycli")},
+		{"override", "linux", map[string]string{ConfigDirEnv: "/srv/skycli/"}, noHome, filepath.Clean("/srv/skycli")},
+		{"windows appdata", "windows", map[string]string{"APPDATA": `C:\Users\me\AppData\Roaming`}, noHome, filepath.Join(`C:\Users\me\AppData\Roaming`, "skycli")},
+		{"windows profile", "windows", map[string]string{"USERPROFILE": `C:\Users\me`}, noHome, filepath.Join(`C:\Users\me`, "AppData", "Roaming", "skycli")},
+		{"windows override", "windows", map[string]string{ConfigDirEnv: "D:/skycli", "APPDATA": `C:\x`}, noHome, filepath.Clean("D:/skycli")},
+	}
+	for _, tt := range tests {
+		got, err := configDir(tt.goos, env(tt.vars), tt.home)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		} else if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	for _, goos := range []string{"linux", "windows"} {
+		var pathErr *PathError
+		if _, err := configDir(goos, env(nil), noHome); !errors.As(err, &pathErr) {
+			t.Errorf("%s: expected a PathError without any directory, got %v", goos, err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// Task is a unit of background work run by a [Scheduler] every Interval.
+// A Continuous task runs until its context is done, such as a stream subscription; Interval is then the
+// delay before restarting it after it returns.
+type Task struct {
+	Name       string
+	Interval   time.Duration
+	Continuous bool
+	Run        func(ctx context.Context) error
+}
+
+// DaemonError reports a misconfigured or failed daemon task
+type DaemonError struct {
+	Op  string
+	Err error
+}
+
+func (e *DaemonError) Error() string {
+	return "daemon." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *DaemonError) Unwrap() error {
+	return e.Err
+}
+
+// Scheduler runs tasks on their intervals until its context is canceled
+type Scheduler struct {
+	tasks  []Task
+	logger *log.Logger
+}
+
+// NewScheduler creates a scheduler for tasks, logging task failures to logger
+func NewScheduler(tasks []Task, logger *log.Logger) *Scheduler {
+	return &Scheduler{tasks: tasks, logger: logger}
+}
+
+// Tasks returns the scheduled tasks
+func (s *Scheduler) Tasks() []Task {
+	return s.tasks
+}
+
+// Run starts every task immediately and again each interval, one goroutine per task, and blocks until ctx is done.
+// A failed run is logged and retried at the next interval; runs of the same task never overlap.
+func (s *Scheduler) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, task := range s.tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, task)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// RunOnce runs every periodic task a single time in order and returns their failures joined.
+// Continuous tasks are skipped since they would never finish.
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	var errs []error
+	for _, task := range s.tasks {
+		if task.Continuous {
+			s.logger.Debug("Skipping continuous task", "task", task.Name)
+			continue
+		}
+		if err := s.runTask(ctx, task); err != nil {
+			errs = append(errs, &DaemonError{Op: task.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Scheduler) loop(ctx context.Context, task Task) {
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.runTask(ctx, task); err != nil && ctx.Err() == nil {
+			s.logger.Error("Task failed", "task", task.Name, "error", err)
+		}
+
+		// Continuous tasks wait a full interval after stopping instead of catching up on missed ticks
+		next := ticker.C
+		if task.Continuous {
+			next = time.After(task.Interval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-next:
+		}
+	}
+}
+
+func (s *Scheduler) runTask(ctx context.Context, task Task) error {
+	start := time.Now()
+	s.logger.Debug("Running task", "task", task.Name)
+	err := task.Run(ctx)
+	s.logger.Debug("Task finished", "task", task.Name, "elapsed", time.Since(start).Round(time.Millisecond))
+	return err
+}
+
+// Configure selects and tunes the available tasks. intervals overrides default intervals by task name
+// with Go durations, or "off" to disable a task; only, when non-empty, restricts the result to the named tasks.
+// Unknown task names are an error so typos don't silently disable a check.
+func Configure(available []Task, intervals map[string]string, only []string) ([]Task, error) {
+	known := make(map[string]bool, len(available))
+	for _, task := range available {
+		known[task.Name] = true
+	}
+
+	for name := range intervals {
+		if !known[name] {
+			return nil, &DaemonError{Op: "Configure", Err: fmt.Errorf("unknown task %q (available: %s)", name, taskNames(available))}
+		}
+	}
+	selected := make(map[string]bool, len(only))
+	for _, name := range only {
+		if !known[name] {
+			return nil, &DaemonError{Op: "Configure", Err: fmt.Errorf("unknown task %q (available: %s)", name, taskNames(available))}
+		}
+		selected[name] = true
+	}
+
+	var tasks []Task
+	for _, task := range available {
+		if len(selected) > 0 && !selected[task.Name] {
+			continue
+		}
+
+		if value, ok := intervals[task.Name]; ok {
+			if value == "off" {
+				continue
+			}
+			interval, err := time.ParseDuration(value)
+			if err != nil || interval <= 0 {
+				return nil, &DaemonError{Op: "Configure", Err: fmt.Errorf("invalid interval %q for task %s", value, task.Name)}
+			}
+			task.Interval = interval
+		}
+		tasks = append(tasks, task)
+	}
+
+	if len(tasks) == 0 {
+		return nil, &DaemonError{Op: "Configure", Err: errors.New("no tasks enabled")}
+	}
+	return tasks, nil
+}
+
+func taskNames(tasks []Task) string {
+	names := make([]string, len(tasks))
+	for i, task := range tasks {
+		names[i] = task.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+func noop(context.Context) error { return nil }
+
+func TestConfigure(t *testing.T) {
+	available := []Task{
+		{Name: "a", Interval: time.Hour, Run: noop},
+		{Name: "b", Interval: time.Hour, Run: noop},
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		tasks, err := Configure(available, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(tasks) != 2 {
+			t.Fatalf("expected 2 tasks, got %d", len(tasks))
+		}
+	})
+
+	t.Run("IntervalOverride", func(t *testing.T) {
+		tasks, err := Configure(available, map[string]string{"a": "15m", "b": "off"}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(tasks) != 1 || tasks[0].Name != "a" || tasks[0].Interval != 15*time.Minute {
+			t.Errorf("unexpected tasks: %+v", tasks)
+		}
+		if available[0].Interval != time.Hour {
+			t.Error("expected available tasks to be left unchanged")
+		}
+	})
+
+	t.Run("Only", func(t *testing.T) {
+		tasks, err := Configure(available, nil, []string{"b"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(tasks) != 1 || tasks[0].Name != "b" {
+			t.Errorf("unexpected tasks: %+v", tasks)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		cases := []struct {
+			intervals map[string]string
+			only      []string
+		}{
+			{intervals: map[string]string{"c": "1h"}},
+			{only: []string{"c"}},
+			{intervals: map[string]string{"a": "soon"}},
+			{intervals: map[string]string{"a": "-1m"}},
+			{intervals: map[string]string{"a": "off", "b": "off"}},
+		}
+		for _, tc := range cases {
+			var daemonErr *DaemonError
+			if _, err := Configure(available, tc.intervals, tc.only); !errors.As(err, &daemonErr) {
+				t.Errorf("Configure(%v, %v): expected DaemonError, got %v", tc.intervals, tc.only, err)
+			}
+		}
+	})
+}
+
+func TestSchedulerRun(t *testing.T) {
+	var runs atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	task := Task{Name: "tick", Interval: 10 * time.Millisecond, Run: func(context.Context) error {
+		if runs.Add(1) == 3 {
+			cancel()
+		}
+		return errors.New("keeps going")
+	}}
+
+	done := make(chan error)
+	go func() { done <- NewScheduler([]Task{task}, log.New(io.Discard)).Run(ctx) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not stop after cancel")
+	}
+	if runs.Load() < 3 {
+		t.Errorf("expected failed runs to be retried, got %d runs", runs.Load())
+	}
+}
+
+func TestSchedulerRunOnce(t *testing.T) {
+	var order []string
+	tasks := []Task{
+		{Name: "first", Interval: time.Hour, Run: func(context.Context) error { order = append(order, "first"); return errors.New("boom") }},
+		{Name: "second", Interval: time.Hour, Run: func(context.Context) error { order = append(order, "second"); return nil }},
+		{Name: "stream", Interval: time.Minute, Continuous: true, Run: func(context.Context) error { order = append(order, "stream"); return nil }},
+	}
+
+	err := NewScheduler(tasks, log.New(io.Discard)).RunOnce(context.Background())
+	if err == nil {
+		t.Fatal("expected error from failed task")
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("expected both periodic tasks to run in order, got %v", order)
+	}
+}
@@ -0,0 +1,148 @@
+package dateparse
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSyntax is returned for values that match none of the accepted forms
+var ErrSyntax = errors.New("expected YYYY-MM-DD, RFC3339, a duration like 24h, 7d, 2w, or an expression like yesterday, last monday, 3 days ago")
+
+// dayLayouts are calendar days, read at midnight in the caller's time zone
+var dayLayouts = []string{"2006-01-02"}
+
+// clockLayouts are wall-clock times without an offset, read in the caller's time zone
+var clockLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// Parse reads a point in time relative to now. Calendar days and wall-clock times without an offset are taken in
+// now's location, so pass the current time in the user's time zone. Accepted forms:
+//
+//   - YYYY-MM-DD, at midnight
+//   - YYYY-MM-DD HH:MM[:SS] or YYYY-MM-DDTHH:MM[:SS]
+//   - RFC3339 timestamps, which carry their own offset
+//   - lookbacks such as 90m, 12h, 7d, or 2w
+//   - now, today, yesterday, last <weekday>, and "<n> <unit>s ago" for minutes, hours, days, weeks, months, or years
+//
+// The empty string returns the zero time.
+func Parse(value string, now time.Time) (time.Time, error) {
+	t, _, err := parse(value, now)
+	return t, err
+}
+
+// ParseEnd is [Parse] for the end of a range: values naming a whole day (YYYY-MM-DD, today, yesterday, last
+// <weekday>) return the last instant of that day instead of its start.
+func ParseEnd(value string, now time.Time) (time.Time, error) {
+	t, day, err := parse(value, now)
+	if err != nil || !day {
+		return t, err
+	}
+	return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+// parse returns the parsed time and whether the value named a whole day
+func parse(value string, now time.Time) (time.Time, bool, error) {
+	s := strings.ToLower(strings.Join(strings.Fields(value), " "))
+	if s == "" {
+		return time.Time{}, false, nil
+	}
+
+	loc := now.Location()
+	for _, layout := range dayLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, true, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return t, false, nil
+	}
+	for _, layout := range clockLayouts {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(s), loc); err == nil {
+			return t, false, nil
+		}
+	}
+
+	if t, day, ok := parseWords(s, now); ok {
+		return t, day, nil
+	}
+	if d, ok := parseLookback(s); ok {
+		return now.Add(-d), false, nil
+	}
+	return time.Time{}, false, ErrSyntax
+}
+
+// parseWords handles now, today, yesterday, last <weekday>, and "<n> <unit>s ago"
+func parseWords(s string, now time.Time) (time.Time, bool, bool) {
+	today := startOfDay(now)
+	switch s {
+	case "now":
+		return now, false, true
+	case "today":
+		return today, true, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true, true
+	}
+
+	if name, ok := strings.CutPrefix(s, "last "); ok {
+		weekday, ok := weekdays[name]
+		if !ok {
+			return time.Time{}, false, false
+		}
+		// Strictly before today, so "last monday" on a Monday is a week ago
+		back := (int(now.Weekday())-int(weekday)+6)%7 + 1
+		return today.AddDate(0, 0, -back), true, true
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) != 3 || fields[2] != "ago" {
+		return time.Time{}, false, false
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 0 {
+		return time.Time{}, false, false
+	}
+	switch strings.TrimSuffix(fields[1], "s") {
+	case "minute", "min":
+		return now.Add(-time.Duration(n) * time.Minute), false, true
+	case "hour":
+		return now.Add(-time.Duration(n) * time.Hour), false, true
+	case "day":
+		return now.AddDate(0, 0, -n), false, true
+	case "week":
+		return now.AddDate(0, 0, -7*n), false, true
+	case "month":
+		return now.AddDate(0, -n, 0), false, true
+	case "year":
+		return now.AddDate(-n, 0, 0), false, true
+	}
+	return time.Time{}, false, false
+}
+
+// parseLookback reads a non-negative Go duration, or a whole number of days (7d) or weeks (2w)
+func parseLookback(s string) (time.Duration, bool) {
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit != 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		return time.Duration(n) * unit, err == nil && n >= 0
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil && d >= 0
+}
+
+// startOfDay returns midnight of t's day in t's location
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,81 @@
+package dateparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// Wednesday afternoon in Berlin
+	now := time.Date(2025, 3, 12, 15, 30, 0, 0, berlin)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"", time.Time{}},
+		{"2025-03-01", time.Date(2025, 3, 1, 0, 0, 0, 0, berlin)},
+		{"2025-03-01 08:30", time.Date(2025, 3, 1, 8, 30, 0, 0, berlin)},
+		{"2025-03-01T08:30:15", time.Date(2025, 3, 1, 8, 30, 15, 0, berlin)},
+		{"2025-03-01T08:30:00Z", time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{"2025-03-01T08:30:00-05:00", time.Date(2025, 3, 1, 13, 30, 0, 0, time.UTC)},
+		{"36h", now.Add(-36 * time.Hour)},
+		{"7d", now.Add(-7 * 24 * time.Hour)},
+		{"2w", now.Add(-14 * 24 * time.Hour)},
+		{"now", now},
+		{"Today", time.Date(2025, 3, 12, 0, 0, 0, 0, berlin)},
+		{"yesterday", time.Date(2025, 3, 11, 0, 0, 0, 0, berlin)},
+		{"last monday", time.Date(2025, 3, 10, 0, 0, 0, 0, berlin)},
+		{"last  Wednesday", time.Date(2025, 3, 5, 0, 0, 0, 0, berlin)},
+		{"last thursday", time.Date(2025, 3, 6, 0, 0, 0, 0, berlin)},
+		{"3 days ago", time.Date(2025, 3, 9, 15, 30, 0, 0, berlin)},
+		{"1 month ago", time.Date(2025, 2, 12, 15, 30, 0, 0, berlin)},
+		{"90 minutes ago", now.Add(-90 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.value, now)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+	for _, value := range []string{"someday", "-3d", "-1h", "last week", "3 fortnights ago", "2025-13-01", "in 3 days"} {
+		if _, err := Parse(value, now); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Parse(%q) error = %v, want ErrSyntax", value, err)
+		}
+	}
+}
+
+func TestParseEnd(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2025-03-01", time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"yesterday", time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"2025-03-01 08:30", time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{"7d", now.Add(-7 * 24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEnd(tt.value, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseEnd(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package export
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// CompressedExt is appended to the filename of compressed exports
+const CompressedExt = ".gz"
+
+// NewCompressWriter returns a writer that gzips everything written to it into dst as it arrives, so large
+// exports never sit uncompressed in memory or on disk. The caller must Close the writer to flush the gzip
+// trailer; dst itself is not closed.
+func NewCompressWriter(dst io.Writer) io.WriteCloser {
+	return gzip.NewWriter(dst)
+}
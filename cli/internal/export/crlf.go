@@ -0,0 +1,37 @@
+package export
+
+import "io"
+
+// NewCRLFWriter returns a writer that ends lines written to w with CRLF, as Notepad, Excel, and other Windows tools
+// expect. Line feeds that already follow a carriage return are passed through unchanged.
+func NewCRLFWriter(w io.Writer) io.Writer {
+	return &crlfWriter{w: w}
+}
+
+// crlfWriter rewrites bare line feeds to CRLF, remembering whether the previous write ended in a carriage return
+type crlfWriter struct {
+	w      io.Writer
+	lastCR bool
+}
+
+func (c *crlfWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	out := make([]byte, 0, len(p)+len(p)/32)
+	prevCR := c.lastCR
+	for _, b := range p {
+		if b == '\n' && !prevCR {
+			out = append(out, '\r')
+		}
+		out = append(out, b)
+		prevCR = b == '\r'
+	}
+
+	if _, err := c.w.Write(out); err != nil {
+		return 0, err
+	}
+	c.lastCR = prevCR
+	return len(p), nil
+}
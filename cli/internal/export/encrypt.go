@@ -0,0 +1,120 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// EncryptedExt is appended to the filename of encrypted exports
+const EncryptedExt = ".age"
+
+// EncryptOptions configures age encryption of exported data.
+// Either Recipients or Passphrase must be set; recipients take precedence.
+type EncryptOptions struct {
+	Recipients []string // age public keys (age1...)
+	Passphrase string   // scrypt passphrase used when no recipients are given
+	Armor      bool     // PEM-style ASCII armor, useful when writing to a terminal
+}
+
+// recipients parses the configured age recipients or derives a scrypt recipient from the passphrase
+func (o EncryptOptions) recipients() ([]age.Recipient, error) {
+	if len(o.Recipients) > 0 {
+		recipients := make([]age.Recipient, 0, len(o.Recipients))
+		for _, key := range o.Recipients {
+			r, err := age.ParseX25519Recipient(strings.TrimSpace(key))
+			if err != nil {
+				return nil, fmt.Errorf("invalid recipient %q: %w", key, err)
+			}
+			recipients = append(recipients, r)
+		}
+		return recipients, nil
+	}
+
+	if o.Passphrase == "" {
+		return nil, errors.New("encryption requires a recipient or a passphrase")
+	}
+
+	r, err := age.NewScryptRecipient(o.Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid passphrase: %w", err)
+	}
+	return []age.Recipient{r}, nil
+}
+
+// armoredWriter closes the age stream before the armor so both trailers are flushed
+type armoredWriter struct {
+	io.WriteCloser
+	armor io.WriteCloser
+}
+
+func (w *armoredWriter) Close() error {
+	if err := w.WriteCloser.Close(); err != nil {
+		return err
+	}
+	return w.armor.Close()
+}
+
+// NewEncryptWriter returns a writer that encrypts everything written to it into dst.
+// The caller must Close the writer to flush the final chunk; dst itself is not closed.
+func NewEncryptWriter(dst io.Writer, opts EncryptOptions) (io.WriteCloser, error) {
+	recipients, err := opts.recipients()
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.Armor {
+		w, err := age.Encrypt(dst, recipients...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize encryption: %w", err)
+		}
+		return w, nil
+	}
+
+	armorWriter := armor.NewWriter(dst)
+	w, err := age.Encrypt(armorWriter, recipients...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize encryption: %w", err)
+	}
+	return &armoredWriter{WriteCloser: w, armor: armorWriter}, nil
+}
+
+// EncryptFile encrypts the file at src into dst, which must not already exist
+func EncryptFile(src, dst string, opts EncryptOptions) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	w, err := NewEncryptWriter(out, opts)
+	if err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+
+	if _, err := io.Copy(w, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("failed to encrypt file: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("failed to encrypt file: %w", err)
+	}
+
+	return out.Close()
+}
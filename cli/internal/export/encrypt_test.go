@@ -0,0 +1,135 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// decryptFile decrypts an age file with the given identity and returns the plaintext
+func decryptFile(t *testing.T, path string, identity age.Identity) string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open encrypted file: %v", err)
+	}
+	defer f.Close()
+
+	r, err := age.Decrypt(f, identity)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read plaintext: %v", err)
+	}
+	return string(data)
+}
+
+// TestEncryptFile_Recipient verifies export files round-trip through an X25519 recipient
+func TestEncryptFile_Recipient(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("failed to generate identity: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "posts.json")
+	dst := src + EncryptedExt
+
+	if err := ToJSON(src, createTestPosts()); err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	opts := EncryptOptions{Recipients: []string{identity.Recipient().String()}}
+	if err := EncryptFile(src, dst, opts); err != nil {
+		t.Fatalf("EncryptFile failed: %v", err)
+	}
+
+	ciphertext, _ := os.ReadFile(dst)
+	if bytes.Contains(ciphertext, []byte("First test post")) {
+		t.Error("encrypted file contains plaintext")
+	}
+
+	plaintext := decryptFile(t, dst, identity)
+	if !strings.Contains(plaintext, "First test post") {
+		t.Errorf("decrypted content missing post text: %s", plaintext)
+	}
+
+	if err := EncryptFile(src, dst, opts); err == nil {
+		t.Error("expected error when destination already exists")
+	}
+}
+
+// TestEncryptFile_Passphrase verifies passphrase-based encryption
+func TestEncryptFile_Passphrase(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "profile.txt")
+	dst := src + EncryptedExt
+
+	if err := os.WriteFile(src, []byte("handle: test.bsky.social"), 0600); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	if err := EncryptFile(src, dst, EncryptOptions{Passphrase: "correct horse battery staple"}); err != nil {
+		t.Fatalf("EncryptFile failed: %v", err)
+	}
+
+	identity, err := age.NewScryptIdentity("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("failed to create scrypt identity: %v", err)
+	}
+
+	if got := decryptFile(t, dst, identity); got != "handle: test.bsky.social" {
+		t.Errorf("unexpected plaintext: %q", got)
+	}
+}
+
+// TestNewEncryptWriter_Armor verifies armored output can be decrypted
+func TestNewEncryptWriter_Armor(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("failed to generate identity: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w, err := NewEncryptWriter(&buf, EncryptOptions{Recipients: []string{identity.Recipient().String()}, Armor: true})
+	if err != nil {
+		t.Fatalf("NewEncryptWriter failed: %v", err)
+	}
+	io.WriteString(w, "handle,did\n")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), armor.Header) {
+		t.Fatalf("expected armored output, got %q", buf.String())
+	}
+
+	r, err := age.Decrypt(armor.NewReader(&buf), identity)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	if string(data) != "handle,did\n" {
+		t.Errorf("unexpected plaintext: %q", data)
+	}
+}
+
+// TestNewEncryptWriter_Errors verifies missing or invalid keys are rejected
+func TestNewEncryptWriter_Errors(t *testing.T) {
+	if _, err := NewEncryptWriter(io.Discard, EncryptOptions{}); err == nil {
+		t.Error("expected error without recipient or passphrase")
+	}
+
+	if _, err := NewEncryptWriter(io.Discard, EncryptOptions{Recipients: []string{"not-a-key"}}); err == nil {
+		t.Error("expected error for invalid recipient")
+	}
+}
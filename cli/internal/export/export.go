@@ -4,11 +4,14 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
+	"github.com/stormlightlabs/skypanel/cli/internal/ui"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 )
 
 // ExportPost represents a post structure for export operations
@@ -22,38 +25,53 @@ type ExportPost struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// PostsDocument is the JSON form of a feed export
+type PostsDocument struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	Posts         []ExportPost `json:"posts"`
+}
+
+// ProfileDocument is the JSON form of a profile export; the profile's fields sit beside the schema version
+type ProfileDocument struct {
+	SchemaVersion int `json:"schemaVersion"`
+	*bsky.ActorProfile
+}
+
+// PostDocument is the JSON form of a single post export; the post's fields sit beside the schema version
+type PostDocument struct {
+	SchemaVersion int `json:"schemaVersion"`
+	*bsky.FeedViewPost
+}
+
 // ToJSON exports posts to JSON format with pretty printing
-func ToJSON(filename string, posts []*store.PostModel) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
+func ToJSON(filename string, posts []*bsky.PostModel) error {
+	return writeFile(filename, func(w io.Writer) error { return WriteJSON(w, posts) })
+}
 
-	encoder := json.NewEncoder(file)
+// WriteJSON writes posts to w as a pretty-printed [PostsDocument]
+func WriteJSON(w io.Writer, posts []*bsky.PostModel) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 
-	exportPosts := convertPosts(posts)
-	if err := encoder.Encode(exportPosts); err != nil {
+	doc := PostsDocument{SchemaVersion: SchemaVersion, Posts: convertPosts(posts)}
+	if err := encoder.Encode(doc); err != nil {
 		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
 
 	return nil
 }
 
-// ToCSV exports posts to CSV format with headers
-func ToCSV(filename string, posts []*store.PostModel) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
+// ToCSV exports posts to CSV format with headers, in the column order of [PostColumns]
+func ToCSV(filename string, posts []*bsky.PostModel) error {
+	return writeFile(filename, func(w io.Writer) error { return WriteCSV(w, posts) })
+}
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+// WriteCSV writes posts to w as CSV with headers, in the column order of [PostColumns]
+func WriteCSV(w io.Writer, posts []*bsky.PostModel) error {
+	writer := csv.NewWriter(w)
 
 	// Write header
-	if err := writer.Write([]string{"ID", "URI", "AuthorDID", "Text", "FeedID", "IndexedAt", "CreatedAt"}); err != nil {
+	if err := writer.Write(ColumnNames(PostColumns)); err != nil {
 		return fmt.Errorf("failed to write CSV header: %w", err)
 	}
 
@@ -67,40 +85,55 @@ func ToCSV(filename string, posts []*store.PostModel) error {
 			post.FeedID,
 			post.IndexedAt.Format(time.RFC3339),
 			post.CreatedAt().Format(time.RFC3339),
+			strconv.Itoa(SchemaVersion),
 		}
 		if err := writer.Write(record); err != nil {
 			return fmt.Errorf("failed to write CSV record: %w", err)
 		}
 	}
 
-	return nil
+	writer.Flush()
+	return writer.Error()
 }
 
 // ToTXT exports posts to plain text format with readable formatting
-func ToTXT(filename string, posts []*store.PostModel) error {
+func ToTXT(filename string, posts []*bsky.PostModel) error {
+	return writeFile(filename, func(w io.Writer) error { return WriteTXT(w, posts) })
+}
+
+// WriteTXT writes posts to w as readable plain text
+func WriteTXT(w io.Writer, posts []*bsky.PostModel) error {
+	for i, post := range posts {
+		fmt.Fprintf(w, "Post #%d\n", i+1)
+		fmt.Fprintf(w, "ID: %s\n", post.ID())
+		fmt.Fprintf(w, "URI: %s\n", post.URI)
+		fmt.Fprintf(w, "Author DID: %s\n", post.AuthorDID)
+		fmt.Fprintf(w, "Feed ID: %s\n", post.FeedID)
+		fmt.Fprintf(w, "Indexed At: %s\n", ui.FormatDate(post.IndexedAt, ui.DatesISO))
+		fmt.Fprintf(w, "Created At: %s\n", ui.FormatDate(post.CreatedAt(), ui.DatesISO))
+		fmt.Fprintf(w, "\nText:\n%s\n", post.Text)
+		fmt.Fprintf(w, "\n%s\n\n", strings.Repeat("-", 80))
+	}
+
+	return nil
+}
+
+// writeFile creates filename and calls write with it, reporting errors from closing the file
+func writeFile(filename string, write func(w io.Writer) error) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
-	defer file.Close()
 
-	for i, post := range posts {
-		fmt.Fprintf(file, "Post #%d\n", i+1)
-		fmt.Fprintf(file, "ID: %s\n", post.ID())
-		fmt.Fprintf(file, "URI: %s\n", post.URI)
-		fmt.Fprintf(file, "Author DID: %s\n", post.AuthorDID)
-		fmt.Fprintf(file, "Feed ID: %s\n", post.FeedID)
-		fmt.Fprintf(file, "Indexed At: %s\n", post.IndexedAt.Format(time.RFC3339))
-		fmt.Fprintf(file, "Created At: %s\n", post.CreatedAt().Format(time.RFC3339))
-		fmt.Fprintf(file, "\nText:\n%s\n", post.Text)
-		fmt.Fprintf(file, "\n%s\n\n", strings.Repeat("-", 80))
+	if err := write(file); err != nil {
+		file.Close()
+		return err
 	}
-
-	return nil
+	return file.Close()
 }
 
 // convertPosts transforms PostModel slice to ExportPost slice
-func convertPosts(posts []*store.PostModel) []ExportPost {
+func convertPosts(posts []*bsky.PostModel) []ExportPost {
 	exportPosts := make([]ExportPost, len(posts))
 	for i, post := range posts {
 		exportPosts[i] = ExportPost{
@@ -117,17 +150,16 @@ func convertPosts(posts []*store.PostModel) []ExportPost {
 }
 
 // ProfileToJSON exports an ActorProfile to JSON format
-func ProfileToJSON(filename string, profile *store.ActorProfile) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
+func ProfileToJSON(filename string, profile *bsky.ActorProfile) error {
+	return writeFile(filename, func(w io.Writer) error { return WriteProfileJSON(w, profile) })
+}
 
-	encoder := json.NewEncoder(file)
+// WriteProfileJSON writes profile to w as a pretty-printed [ProfileDocument]
+func WriteProfileJSON(w io.Writer, profile *bsky.ActorProfile) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 
-	if err := encoder.Encode(profile); err != nil {
+	if err := encoder.Encode(ProfileDocument{SchemaVersion: SchemaVersion, ActorProfile: profile}); err != nil {
 		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
 
@@ -135,50 +167,48 @@ func ProfileToJSON(filename string, profile *store.ActorProfile) error {
 }
 
 // ProfileToTXT exports an ActorProfile to plain text format
-func ProfileToTXT(filename string, profile *store.ActorProfile) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
+func ProfileToTXT(filename string, profile *bsky.ActorProfile) error {
+	return writeFile(filename, func(w io.Writer) error { return WriteProfileTXT(w, profile) })
+}
 
-	fmt.Fprintf(file, "Profile: @%s\n", profile.Handle)
-	fmt.Fprintf(file, "%s\n\n", strings.Repeat("=", 80))
+// WriteProfileTXT writes profile to w as readable plain text
+func WriteProfileTXT(w io.Writer, profile *bsky.ActorProfile) error {
+	fmt.Fprintf(w, "Profile: @%s\n", profile.Handle)
+	fmt.Fprintf(w, "%s\n\n", strings.Repeat("=", 80))
 
 	if profile.DisplayName != "" {
-		fmt.Fprintf(file, "Display Name: %s\n", profile.DisplayName)
+		fmt.Fprintf(w, "Display Name: %s\n", profile.DisplayName)
 	}
-	fmt.Fprintf(file, "DID: %s\n", profile.Did)
-	fmt.Fprintf(file, "Handle: @%s\n", profile.Handle)
+	fmt.Fprintf(w, "DID: %s\n", profile.Did)
+	fmt.Fprintf(w, "Handle: @%s\n", profile.Handle)
 
 	if profile.Description != "" {
-		fmt.Fprintf(file, "\nDescription:\n%s\n", profile.Description)
+		fmt.Fprintf(w, "\nDescription:\n%s\n", profile.Description)
 	}
 
-	fmt.Fprintf(file, "\nStats:\n")
-	fmt.Fprintf(file, "  Followers: %d\n", profile.FollowersCount)
-	fmt.Fprintf(file, "  Following: %d\n", profile.FollowsCount)
-	fmt.Fprintf(file, "  Posts: %d\n", profile.PostsCount)
+	fmt.Fprintf(w, "\nStats:\n")
+	fmt.Fprintf(w, "  Followers: %d\n", profile.FollowersCount)
+	fmt.Fprintf(w, "  Following: %d\n", profile.FollowsCount)
+	fmt.Fprintf(w, "  Posts: %d\n", profile.PostsCount)
 
 	if profile.CreatedAt != "" {
-		fmt.Fprintf(file, "\nCreated: %s\n", profile.CreatedAt)
+		fmt.Fprintf(w, "\nCreated: %s\n", profile.CreatedAt)
 	}
 
 	return nil
 }
 
 // FeedViewPostToJSON exports a single FeedViewPost to JSON format
-func FeedViewPostToJSON(filename string, post *store.FeedViewPost) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
+func FeedViewPostToJSON(filename string, post *bsky.FeedViewPost) error {
+	return writeFile(filename, func(w io.Writer) error { return WriteFeedViewPostJSON(w, post) })
+}
 
-	encoder := json.NewEncoder(file)
+// WriteFeedViewPostJSON writes post to w as a pretty-printed [PostDocument]
+func WriteFeedViewPostJSON(w io.Writer, post *bsky.FeedViewPost) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 
-	if err := encoder.Encode(post); err != nil {
+	if err := encoder.Encode(PostDocument{SchemaVersion: SchemaVersion, FeedViewPost: post}); err != nil {
 		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
 
@@ -186,46 +216,173 @@ func FeedViewPostToJSON(filename string, post *store.FeedViewPost) error {
 }
 
 // FeedViewPostToTXT exports a single FeedViewPost to plain text format
-func FeedViewPostToTXT(filename string, post *store.FeedViewPost) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
+func FeedViewPostToTXT(filename string, post *bsky.FeedViewPost) error {
+	return writeFile(filename, func(w io.Writer) error { return WriteFeedViewPostTXT(w, post) })
+}
 
+// WriteFeedViewPostTXT writes post to w as readable plain text
+func WriteFeedViewPostTXT(w io.Writer, post *bsky.FeedViewPost) error {
 	if post.Post != nil {
 		p := post.Post
-		fmt.Fprintf(file, "Post by @%s\n", p.Author.Handle)
-		fmt.Fprintf(file, "%s\n\n", strings.Repeat("=", 80))
+		fmt.Fprintf(w, "Post by @%s\n", p.Author.Handle)
+		fmt.Fprintf(w, "%s\n\n", strings.Repeat("=", 80))
 
-		fmt.Fprintf(file, "URI: %s\n", p.Uri)
-		fmt.Fprintf(file, "CID: %s\n", p.Cid)
+		fmt.Fprintf(w, "URI: %s\n", p.Uri)
+		fmt.Fprintf(w, "CID: %s\n", p.Cid)
 
 		if p.Author.DisplayName != "" {
-			fmt.Fprintf(file, "Author: %s (@%s)\n", p.Author.DisplayName, p.Author.Handle)
+			fmt.Fprintf(w, "Author: %s (@%s)\n", p.Author.DisplayName, p.Author.Handle)
 		} else {
-			fmt.Fprintf(file, "Author: @%s\n", p.Author.Handle)
+			fmt.Fprintf(w, "Author: @%s\n", p.Author.Handle)
 		}
 
 		// Extract text from record
 		if recordMap, ok := p.Record.(map[string]any); ok {
 			if text, ok := recordMap["text"].(string); ok {
-				fmt.Fprintf(file, "\nText:\n%s\n", text)
+				fmt.Fprintf(w, "\nText:\n%s\n", text)
 			}
 		}
 
-		fmt.Fprintf(file, "\nEngagement:\n")
-		fmt.Fprintf(file, "  Likes: %d\n", p.LikeCount)
-		fmt.Fprintf(file, "  Reposts: %d\n", p.RepostCount)
-		fmt.Fprintf(file, "  Replies: %d\n", p.ReplyCount)
-		fmt.Fprintf(file, "  Quotes: %d\n", p.QuoteCount)
+		fmt.Fprintf(w, "\nEngagement:\n")
+		fmt.Fprintf(w, "  Likes: %d\n", p.LikeCount)
+		fmt.Fprintf(w, "  Reposts: %d\n", p.RepostCount)
+		fmt.Fprintf(w, "  Replies: %d\n", p.ReplyCount)
+		fmt.Fprintf(w, "  Quotes: %d\n", p.QuoteCount)
 
-		fmt.Fprintf(file, "\nIndexed: %s\n", p.IndexedAt)
+		fmt.Fprintf(w, "\nIndexed: %s\n", ui.FormatDateString(p.IndexedAt, ui.DatesISO))
 
 		if post.Reason != nil && post.Reason.By != nil {
-			fmt.Fprintf(file, "\nReposted by: @%s\n", post.Reason.By.Handle)
+			fmt.Fprintf(w, "\nReposted by: @%s\n", post.Reason.By.Handle)
 		}
 	}
 
 	return nil
 }
+
+// SnapshotFormatVersion is the current version of the snapshot export document
+const SnapshotFormatVersion = 1
+
+// SnapshotDocument is the portable JSON form of a follower/following snapshot.
+// IDs and timestamps are preserved so snapshots can be diffed across installs.
+type SnapshotDocument struct {
+	Version  int                     `json:"version"`
+	Snapshot SnapshotMeta            `json:"snapshot"`
+	Entries  []SnapshotDocumentEntry `json:"entries"`
+}
+
+// SnapshotMeta holds snapshot metadata within a [SnapshotDocument]
+type SnapshotMeta struct {
+	ID           string    `json:"id"`
+	CreatedAt    time.Time `json:"createdAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	UserDid      string    `json:"userDid"`
+	SnapshotType string    `json:"snapshotType"`
+	TotalCount   int       `json:"totalCount"`
+}
+
+// SnapshotDocumentEntry is a single actor in a [SnapshotDocument]
+type SnapshotDocumentEntry struct {
+	ActorDid  string `json:"actorDid"`
+	IndexedAt string `json:"indexedAt,omitempty"`
+}
+
+// NewSnapshotDocument builds the portable document for a snapshot and its entries
+func NewSnapshotDocument(snapshot *bsky.SnapshotModel, entries []*bsky.SnapshotEntry) SnapshotDocument {
+	doc := SnapshotDocument{
+		Version: SnapshotFormatVersion,
+		Snapshot: SnapshotMeta{
+			ID:           snapshot.ID(),
+			CreatedAt:    snapshot.CreatedAt(),
+			ExpiresAt:    snapshot.ExpiresAt,
+			UserDid:      snapshot.UserDid,
+			SnapshotType: snapshot.SnapshotType,
+			TotalCount:   snapshot.TotalCount,
+		},
+		Entries: make([]SnapshotDocumentEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		doc.Entries = append(doc.Entries, SnapshotDocumentEntry{ActorDid: entry.ActorDid, IndexedAt: entry.IndexedAt})
+	}
+	return doc
+}
+
+// SnapshotToJSON exports a snapshot and its entries to a portable JSON document
+func SnapshotToJSON(filename string, snapshot *bsky.SnapshotModel, entries []*bsky.SnapshotEntry) error {
+	return writeFile(filename, func(w io.Writer) error { return WriteSnapshotJSON(w, snapshot, entries) })
+}
+
+// WriteSnapshotJSON writes a snapshot and its entries to w as a portable [SnapshotDocument]
+func WriteSnapshotJSON(w io.Writer, snapshot *bsky.SnapshotModel, entries []*bsky.SnapshotEntry) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(NewSnapshotDocument(snapshot, entries)); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return nil
+}
+
+// RecordedPost is a post the user liked or reposted, with when the like or repost record was created
+type RecordedPost struct {
+	RecordURI  string         `json:"recordUri"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	SubjectURI string         `json:"subjectUri"`
+	Post       *bsky.PostView `json:"post"` // nil when the post was deleted or can't be seen
+}
+
+// RecordedPostsDocument is the JSON form of a likes or reposts export, newest record first
+type RecordedPostsDocument struct {
+	SchemaVersion int            `json:"schemaVersion"`
+	Collection    string         `json:"collection"`
+	Posts         []RecordedPost `json:"posts"`
+}
+
+// WriteRecordedPostsJSON writes posts from collection to w as a pretty-printed [RecordedPostsDocument]
+func WriteRecordedPostsJSON(w io.Writer, collection string, posts []RecordedPost) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	doc := RecordedPostsDocument{SchemaVersion: SchemaVersion, Collection: collection, Posts: posts}
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return nil
+}
+
+// WriteRecordedPostsCSV writes posts to w as CSV with headers, in the column order of [RecordedPostColumns].
+// Author and text are blank for posts that are gone.
+func WriteRecordedPostsCSV(w io.Writer, posts []RecordedPost) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(ColumnNames(RecordedPostColumns)); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, post := range posts {
+		var authorDID, authorHandle, text string
+		if post.Post != nil {
+			model := bsky.NewPostModel("", post.Post)
+			authorDID, text = model.AuthorDID, model.Text
+			if post.Post.Author != nil {
+				authorHandle = post.Post.Author.Handle
+			}
+		}
+		record := []string{
+			post.RecordURI,
+			post.CreatedAt.Format(time.RFC3339),
+			post.SubjectURI,
+			authorDID,
+			authorHandle,
+			text,
+			strconv.Itoa(SchemaVersion),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -9,13 +9,13 @@ import (
 	"testing"
 	"time"
 
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 )
 
 // createTestPosts generates sample posts for testing
-func createTestPosts() []*store.PostModel {
+func createTestPosts() []*bsky.PostModel {
 	now := time.Now()
-	posts := []*store.PostModel{
+	posts := []*bsky.PostModel{
 		{
 			URI:       "at://did:plc:test1/app.bsky.feed.post/1",
 			AuthorDID: "did:plc:author1",
@@ -69,10 +69,14 @@ func TestToJSON_Success(t *testing.T) {
 		t.Fatalf("failed to read exported file: %v", err)
 	}
 
-	var exportedPosts []ExportPost
-	if err := json.Unmarshal(data, &exportedPosts); err != nil {
+	var doc PostsDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
 		t.Fatalf("failed to parse JSON: %v", err)
 	}
+	if doc.SchemaVersion != SchemaVersion {
+		t.Errorf("expected schema version %d, got %d", SchemaVersion, doc.SchemaVersion)
+	}
+	exportedPosts := doc.Posts
 
 	if len(exportedPosts) != 3 {
 		t.Errorf("expected 3 posts, got %d", len(exportedPosts))
@@ -91,7 +95,7 @@ func TestToJSON_EmptyPosts(t *testing.T) {
 	tmpDir := t.TempDir()
 	filename := filepath.Join(tmpDir, "empty.json")
 
-	err := ToJSON(filename, []*store.PostModel{})
+	err := ToJSON(filename, []*bsky.PostModel{})
 	if err != nil {
 		t.Fatalf("ToJSON failed: %v", err)
 	}
@@ -101,10 +105,14 @@ func TestToJSON_EmptyPosts(t *testing.T) {
 		t.Fatalf("failed to read exported file: %v", err)
 	}
 
-	var exportedPosts []ExportPost
-	if err := json.Unmarshal(data, &exportedPosts); err != nil {
+	var doc PostsDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
 		t.Fatalf("failed to parse JSON: %v", err)
 	}
+	if doc.SchemaVersion != SchemaVersion {
+		t.Errorf("expected schema version %d, got %d", SchemaVersion, doc.SchemaVersion)
+	}
+	exportedPosts := doc.Posts
 
 	if len(exportedPosts) != 0 {
 		t.Errorf("expected 0 posts, got %d", len(exportedPosts))
@@ -153,12 +161,15 @@ func TestToCSV_Success(t *testing.T) {
 		t.Errorf("expected 4 rows (header + 3 data), got %d", len(records))
 	}
 
-	expectedHeader := []string{"ID", "URI", "AuthorDID", "Text", "FeedID", "IndexedAt", "CreatedAt"}
+	expectedHeader := []string{"ID", "URI", "AuthorDID", "Text", "FeedID", "IndexedAt", "CreatedAt", "SchemaVersion"}
 	for i, col := range expectedHeader {
 		if records[0][i] != col {
 			t.Errorf("header column %d: expected %s, got %s", i, col, records[0][i])
 		}
 	}
+	if records[1][7] != "1" {
+		t.Errorf("unexpected schema version in row 1: %s", records[1][7])
+	}
 
 	if records[1][1] != "at://did:plc:test1/app.bsky.feed.post/1" {
 		t.Errorf("unexpected URI in row 1: %s", records[1][1])
@@ -206,7 +217,7 @@ func TestToCSV_EmptyPosts(t *testing.T) {
 	tmpDir := t.TempDir()
 	filename := filepath.Join(tmpDir, "empty.csv")
 
-	err := ToCSV(filename, []*store.PostModel{})
+	err := ToCSV(filename, []*bsky.PostModel{})
 	if err != nil {
 		t.Fatalf("ToCSV failed: %v", err)
 	}
@@ -283,7 +294,7 @@ func TestToTXT_EmptyPosts(t *testing.T) {
 	tmpDir := t.TempDir()
 	filename := filepath.Join(tmpDir, "empty.txt")
 
-	err := ToTXT(filename, []*store.PostModel{})
+	err := ToTXT(filename, []*bsky.PostModel{})
 	if err != nil {
 		t.Fatalf("ToTXT failed: %v", err)
 	}
@@ -308,6 +319,37 @@ func TestToTXT_InvalidPath(t *testing.T) {
 	}
 }
 
+// TestCRLFWriter verifies CSV written through a CRLF writer ends every line with CRLF and still parses back
+func TestCRLFWriter(t *testing.T) {
+	posts := createTestPosts()
+
+	var buf strings.Builder
+	if err := WriteCSV(NewCRLFWriter(&buf), posts); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	out := buf.String()
+	if strings.Count(out, "\n") != strings.Count(out, "\r\n") {
+		t.Errorf("expected only CRLF line endings, got %q", out)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CRLF CSV: %v", err)
+	}
+	if len(records) != len(posts)+1 || records[3][3] != posts[2].Text {
+		t.Errorf("unexpected records %q", records)
+	}
+
+	// A CRLF split across writes is not doubled
+	buf.Reset()
+	w := NewCRLFWriter(&buf)
+	w.Write([]byte("a\r"))
+	w.Write([]byte("\nb\n"))
+	if buf.String() != "a\r\nb\r\n" {
+		t.Errorf("got %q", buf.String())
+	}
+}
+
 // TestToTXT_MultiplePostsFormatting verifies proper formatting of multiple posts
 func TestToTXT_MultiplePostsFormatting(t *testing.T) {
 	posts := createTestPosts()
@@ -409,7 +451,7 @@ func TestExportPost_JSONTags(t *testing.T) {
 // TestToJSON_SinglePost verifies export with single post
 func TestToJSON_SinglePost(t *testing.T) {
 	now := time.Now()
-	post := &store.PostModel{
+	post := &bsky.PostModel{
 		URI:       "at://test/single",
 		AuthorDID: "did:plc:single",
 		Text:      "Single post",
@@ -422,7 +464,7 @@ func TestToJSON_SinglePost(t *testing.T) {
 	tmpDir := t.TempDir()
 	filename := filepath.Join(tmpDir, "single.json")
 
-	err := ToJSON(filename, []*store.PostModel{post})
+	err := ToJSON(filename, []*bsky.PostModel{post})
 	if err != nil {
 		t.Fatalf("ToJSON failed: %v", err)
 	}
@@ -432,10 +474,14 @@ func TestToJSON_SinglePost(t *testing.T) {
 		t.Fatalf("failed to read file: %v", err)
 	}
 
-	var exportedPosts []ExportPost
-	if err := json.Unmarshal(data, &exportedPosts); err != nil {
+	var doc PostsDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
 		t.Fatalf("failed to parse JSON: %v", err)
 	}
+	if doc.SchemaVersion != SchemaVersion {
+		t.Errorf("expected schema version %d, got %d", SchemaVersion, doc.SchemaVersion)
+	}
+	exportedPosts := doc.Posts
 
 	if len(exportedPosts) != 1 {
 		t.Errorf("expected 1 post, got %d", len(exportedPosts))
@@ -447,8 +493,8 @@ func TestToJSON_SinglePost(t *testing.T) {
 }
 
 // createTestProfile generates a sample profile for testing
-func createTestProfile() *store.ActorProfile {
-	return &store.ActorProfile{
+func createTestProfile() *bsky.ActorProfile {
+	return &bsky.ActorProfile{
 		Did:            "did:plc:test123",
 		Handle:         "testuser.bsky.social",
 		DisplayName:    "Test User",
@@ -481,10 +527,13 @@ func TestProfileToJSON_Success(t *testing.T) {
 		t.Fatalf("failed to read exported file: %v", err)
 	}
 
-	var exportedProfile store.ActorProfile
+	var exportedProfile bsky.ActorProfile
 	if err := json.Unmarshal(data, &exportedProfile); err != nil {
 		t.Fatalf("failed to parse JSON: %v", err)
 	}
+	if !strings.Contains(string(data), `"schemaVersion": 1`) {
+		t.Errorf("expected schemaVersion beside the profile fields, got %s", data)
+	}
 
 	if exportedProfile.Did != profile.Did {
 		t.Errorf("expected DID %s, got %s", profile.Did, exportedProfile.Did)
@@ -561,7 +610,7 @@ func TestProfileToTXT_Success(t *testing.T) {
 
 // TestProfileToTXT_MinimalProfile verifies TXT export with minimal profile data
 func TestProfileToTXT_MinimalProfile(t *testing.T) {
-	profile := &store.ActorProfile{
+	profile := &bsky.ActorProfile{
 		Did:    "did:plc:minimal",
 		Handle: "minimal.bsky.social",
 	}
@@ -604,12 +653,12 @@ func TestProfileToTXT_InvalidPath(t *testing.T) {
 }
 
 // createTestFeedViewPost generates a sample FeedViewPost for testing
-func createTestFeedViewPost() *store.FeedViewPost {
-	return &store.FeedViewPost{
-		Post: &store.PostView{
+func createTestFeedViewPost() *bsky.FeedViewPost {
+	return &bsky.FeedViewPost{
+		Post: &bsky.PostView{
 			Uri: "at://did:plc:test123/app.bsky.feed.post/abc123",
 			Cid: "bafyreic3test",
-			Author: &store.ActorProfile{
+			Author: &bsky.ActorProfile{
 				Did:         "did:plc:author123",
 				Handle:      "testauthor.bsky.social",
 				DisplayName: "Test Author",
@@ -648,7 +697,7 @@ func TestFeedViewPostToJSON_Success(t *testing.T) {
 		t.Fatalf("failed to read exported file: %v", err)
 	}
 
-	var exportedPost store.FeedViewPost
+	var exportedPost bsky.FeedViewPost
 	if err := json.Unmarshal(data, &exportedPost); err != nil {
 		t.Fatalf("failed to parse JSON: %v", err)
 	}
@@ -732,9 +781,9 @@ func TestFeedViewPostToTXT_Success(t *testing.T) {
 // TestFeedViewPostToTXT_WithRepost verifies TXT export with repost reason
 func TestFeedViewPostToTXT_WithRepost(t *testing.T) {
 	post := createTestFeedViewPost()
-	post.Reason = &store.ReasonView{
+	post.Reason = &bsky.ReasonView{
 		Type: "app.bsky.feed.defs#reasonRepost",
-		By: &store.ActorProfile{
+		By: &bsky.ActorProfile{
 			Did:         "did:plc:reposter",
 			Handle:      "reposter.bsky.social",
 			DisplayName: "Reposter",
@@ -764,11 +813,11 @@ func TestFeedViewPostToTXT_WithRepost(t *testing.T) {
 
 // TestFeedViewPostToTXT_MinimalPost verifies TXT export with minimal post data
 func TestFeedViewPostToTXT_MinimalPost(t *testing.T) {
-	post := &store.FeedViewPost{
-		Post: &store.PostView{
+	post := &bsky.FeedViewPost{
+		Post: &bsky.PostView{
 			Uri: "at://did:plc:minimal/app.bsky.feed.post/xyz",
 			Cid: "bafyreicminimal",
-			Author: &store.ActorProfile{
+			Author: &bsky.ActorProfile{
 				Did:    "did:plc:minimal",
 				Handle: "minimal.bsky.social",
 			},
@@ -809,3 +858,40 @@ func TestFeedViewPostToTXT_InvalidPath(t *testing.T) {
 		t.Error("expected error for invalid path, got nil")
 	}
 }
+
+// TestWriteRecordedPostsCSV verifies likes exports keep the record time and blank out posts that are gone
+func TestWriteRecordedPostsCSV(t *testing.T) {
+	likedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	posts := []RecordedPost{
+		{
+			RecordURI:  "at://did:plc:me/app.bsky.feed.like/1",
+			CreatedAt:  likedAt,
+			SubjectURI: "at://did:plc:author/app.bsky.feed.post/1",
+			Post: &bsky.PostView{
+				Uri:    "at://did:plc:author/app.bsky.feed.post/1",
+				Author: &bsky.ActorProfile{Did: "did:plc:author", Handle: "author.bsky.social"},
+				Record: map[string]any{"text": "hello"},
+			},
+		},
+		{RecordURI: "at://did:plc:me/app.bsky.feed.like/2", CreatedAt: likedAt, SubjectURI: "at://did:plc:gone/app.bsky.feed.post/1"},
+	}
+
+	var out strings.Builder
+	if err := WriteRecordedPostsCSV(&out, posts); err != nil {
+		t.Fatalf("WriteRecordedPostsCSV failed: %v", err)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(rows) != 3 || len(rows[0]) != len(RecordedPostColumns) {
+		t.Fatalf("unexpected rows %v", rows)
+	}
+	if rows[1][1] != "2026-10-01T12:00:00Z" || rows[1][4] != "author.bsky.social" || rows[1][5] != "hello" {
+		t.Errorf("unexpected row %v", rows[1])
+	}
+	if rows[2][2] != "at://did:plc:gone/app.bsky.feed.post/1" || rows[2][3] != "" || rows[2][5] != "" {
+		t.Errorf("expected a blank post for the missing subject, got %v", rows[2])
+	}
+}
@@ -0,0 +1,156 @@
+package export
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// RedactMode controls how redacted fields are written
+type RedactMode string
+
+const (
+	RedactOmit RedactMode = "omit" // Drop the field (blank in JSON/TXT, column removed in CSV)
+	RedactHash RedactMode = "hash" // Replace the value with a salted hash, preserving joins within one export
+)
+
+// redactableFields maps lowercase field names to their canonical (JSON/CSV) names
+var redactableFields = map[string]string{
+	"did":            "did",
+	"handle":         "handle",
+	"displayname":    "displayName",
+	"description":    "description",
+	"avatar":         "avatar",
+	"banner":         "banner",
+	"followerscount": "followersCount",
+	"followscount":   "followsCount",
+	"postscount":     "postsCount",
+	"createdat":      "createdAt",
+	"indexedat":      "indexedAt",
+	"note":           "note",
+}
+
+// RedactableFields returns the canonical names of fields that can be redacted
+func RedactableFields() []string {
+	fields := make([]string, 0, len(redactableFields))
+	for _, name := range redactableFields {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Redactor removes or pseudonymizes selected profile fields before export
+type Redactor struct {
+	fields map[string]bool
+	mode   RedactMode
+	salt   []byte
+}
+
+// NewRedactor parses a comma-separated field list (e.g. "did,description").
+// Returns nil when spec is empty so callers can treat a nil Redactor as a no-op.
+// Hash mode uses a random salt per Redactor so hashes of public identifiers cannot be reversed by lookup.
+func NewRedactor(spec string, mode RedactMode) (*Redactor, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	switch mode {
+	case "":
+		mode = RedactOmit
+	case RedactOmit, RedactHash:
+	default:
+		return nil, fmt.Errorf("invalid redact mode: %s (must be omit or hash)", mode)
+	}
+
+	r := &Redactor{fields: make(map[string]bool), mode: mode}
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		name, ok := redactableFields[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown redact field: %s (valid: %s)", field, strings.Join(RedactableFields(), ", "))
+		}
+		r.fields[name] = true
+	}
+
+	if mode == RedactHash {
+		r.salt = make([]byte, 16)
+		if _, err := rand.Read(r.salt); err != nil {
+			return nil, fmt.Errorf("failed to generate salt: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+// Redacts reports whether the named field is redacted
+func (r *Redactor) Redacts(field string) bool {
+	return r != nil && r.fields[field]
+}
+
+// Omits reports whether the named field should be dropped entirely (e.g. as a CSV column).
+// Count fields cannot be meaningfully hashed, so they are always omitted.
+func (r *Redactor) Omits(field string) bool {
+	if !r.Redacts(field) {
+		return false
+	}
+	return r.mode == RedactOmit || strings.HasSuffix(field, "Count")
+}
+
+// Profile returns a redacted copy of profile; the original is left untouched
+func (r *Redactor) Profile(profile *bsky.ActorProfile) *bsky.ActorProfile {
+	if r == nil || profile == nil {
+		return profile
+	}
+
+	redacted := *profile
+	redacted.Did = r.Value("did", profile.Did)
+	redacted.Handle = r.Value("handle", profile.Handle)
+	redacted.DisplayName = r.Value("displayName", profile.DisplayName)
+	redacted.Description = r.Value("description", profile.Description)
+	redacted.Avatar = r.Value("avatar", profile.Avatar)
+	redacted.Banner = r.Value("banner", profile.Banner)
+	redacted.CreatedAt = r.Value("createdAt", profile.CreatedAt)
+	redacted.IndexedAt = r.Value("indexedAt", profile.IndexedAt)
+
+	if r.Redacts("followersCount") {
+		redacted.FollowersCount = 0
+	}
+	if r.Redacts("followsCount") {
+		redacted.FollowsCount = 0
+	}
+	if r.Redacts("postsCount") {
+		redacted.PostsCount = 0
+	}
+
+	// Viewer state and labels can reveal relationships, so drop them whenever the identity is redacted
+	if r.Redacts("did") || r.Redacts("handle") {
+		redacted.Viewer = nil
+		redacted.Labels = nil
+	}
+
+	return &redacted
+}
+
+// Value returns the redacted form of a single string field; a nil Redactor returns v unchanged
+func (r *Redactor) Value(field, v string) string {
+	if !r.Redacts(field) || v == "" {
+		return v
+	}
+	if r.mode == RedactOmit {
+		return ""
+	}
+
+	h := sha256.New()
+	h.Write(r.salt)
+	h.Write([]byte(v))
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,103 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// TestNewRedactor verifies field list and mode parsing
+func TestNewRedactor(t *testing.T) {
+	r, err := NewRedactor("", RedactOmit)
+	if err != nil || r != nil {
+		t.Errorf("expected nil redactor for empty spec, got %v, %v", r, err)
+	}
+
+	if _, err := NewRedactor("did,email", RedactOmit); err == nil {
+		t.Error("expected error for unknown field")
+	}
+
+	if _, err := NewRedactor("did", "scramble"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+
+	r, err = NewRedactor(" DID , DisplayName ", "")
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+	if !r.Redacts("did") || !r.Redacts("displayName") || r.Redacts("handle") {
+		t.Error("field names should be matched case-insensitively")
+	}
+}
+
+// TestRedactor_ProfileOmit verifies omitted fields are cleared on a copy of the profile
+func TestRedactor_ProfileOmit(t *testing.T) {
+	r, err := NewRedactor("did,description,followersCount", RedactOmit)
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+
+	original := createTestProfile()
+	original.Viewer = &bsky.ViewerState{Following: "at://did:plc:me/app.bsky.graph.follow/1"}
+	redacted := r.Profile(original)
+
+	if redacted.Did != "" || redacted.Description != "" || redacted.FollowersCount != 0 {
+		t.Errorf("expected fields to be cleared, got %+v", redacted)
+	}
+	if redacted.Handle != "testuser.bsky.social" {
+		t.Errorf("unredacted field changed: %s", redacted.Handle)
+	}
+	if redacted.Viewer != nil {
+		t.Error("expected viewer state to be dropped when did is redacted")
+	}
+	if original.Did != "did:plc:test123" {
+		t.Error("original profile should not be modified")
+	}
+
+	if !r.Omits("did") || r.Omits("handle") {
+		t.Error("unexpected Omits result")
+	}
+}
+
+// TestRedactor_ProfileHash verifies hashed fields are salted and stable within one export
+func TestRedactor_ProfileHash(t *testing.T) {
+	r, err := NewRedactor("did,followersCount", RedactHash)
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+
+	first := r.Profile(createTestProfile())
+	second := r.Profile(createTestProfile())
+
+	if !strings.HasPrefix(first.Did, "sha256:") || first.Did == "did:plc:test123" {
+		t.Errorf("expected hashed did, got %s", first.Did)
+	}
+	if first.Did != second.Did {
+		t.Error("hashes should be stable within one redactor")
+	}
+	if r.Omits("did") {
+		t.Error("hashed fields should not be omitted")
+	}
+	if !r.Omits("followersCount") {
+		t.Error("count fields should always be omitted")
+	}
+
+	other, _ := NewRedactor("did", RedactHash)
+	if other.Profile(createTestProfile()).Did == first.Did {
+		t.Error("separate redactors should use different salts")
+	}
+}
+
+// TestRedactor_Nil verifies a nil redactor is a no-op
+func TestRedactor_Nil(t *testing.T) {
+	var r *Redactor
+	profile := createTestProfile()
+
+	if r.Profile(profile) != profile {
+		t.Error("nil redactor should return the profile unchanged")
+	}
+	if r.Redacts("did") || r.Omits("did") {
+		t.Error("nil redactor should not redact anything")
+	}
+}
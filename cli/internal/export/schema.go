@@ -0,0 +1,226 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the current version of the feed, profile, and post export formats. It is written into every
+// JSON document and CSV row, and bumped whenever a field or column is renamed, removed, or moved.
+const SchemaVersion = 1
+
+// jsonSchemaDialect identifies the JSON Schema draft generated schemas follow
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Export kinds accepted by [Schema]
+const (
+	SchemaFeed     = "feed"
+	SchemaProfile  = "profile"
+	SchemaPost     = "post"
+	SchemaSnapshot = "snapshot"
+	SchemaLikes    = "likes"
+	SchemaReposts  = "reposts"
+)
+
+// Column documents one column of a CSV export
+type Column struct {
+	Name        string
+	Description string
+	Format      string // JSON Schema string format of the values, if any
+}
+
+// PostColumns lists the columns of feed CSV exports in the order they are written.
+// New columns are only ever appended, so readers that index columns by position keep working.
+var PostColumns = []Column{
+	{Name: "ID", Description: "Local cache ID of the post"},
+	{Name: "URI", Description: "AT URI of the post"},
+	{Name: "AuthorDID", Description: "DID of the post's author"},
+	{Name: "Text", Description: "Post text"},
+	{Name: "FeedID", Description: "ID of the feed the post was fetched for"},
+	{Name: "IndexedAt", Description: "When the AppView indexed the post", Format: "date-time"},
+	{Name: "CreatedAt", Description: "When the post was cached", Format: "date-time"},
+	{Name: "SchemaVersion", Description: "Export schema version"},
+}
+
+// RecordedPostColumns lists the columns of likes and reposts CSV exports in the order they are written
+var RecordedPostColumns = []Column{
+	{Name: "RecordURI", Description: "AT URI of the like or repost record"},
+	{Name: "CreatedAt", Description: "When the post was liked or reposted", Format: "date-time"},
+	{Name: "PostURI", Description: "AT URI of the liked or reposted post"},
+	{Name: "AuthorDID", Description: "DID of the post's author; blank if the post is gone"},
+	{Name: "AuthorHandle", Description: "Handle of the post's author; blank if the post is gone"},
+	{Name: "Text", Description: "Post text; blank if the post is gone"},
+	{Name: "SchemaVersion", Description: "Export schema version"},
+}
+
+// ColumnNames returns the header row for columns
+func ColumnNames(columns []Column) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// Schema returns the JSON Schema of kind exports (feed, profile, post, snapshot, likes, or reposts) written in format.
+// CSV schemas describe one data row as an array of strings in column order; column names are the item titles.
+func Schema(kind, format string) (map[string]any, error) {
+	switch {
+	case kind == SchemaFeed && format == "csv":
+		return csvSchema("skycli feed export (CSV row)", PostColumns), nil
+	case (kind == SchemaLikes || kind == SchemaReposts) && format == "csv":
+		return csvSchema("skycli "+kind+" export (CSV row)", RecordedPostColumns), nil
+	case format != "json":
+		return nil, fmt.Errorf("no schema for %s exports in %s format", kind, format)
+	}
+
+	switch kind {
+	case SchemaFeed:
+		return documentSchema("skycli feed export", reflect.TypeOf(PostsDocument{}), "schemaVersion", SchemaVersion), nil
+	case SchemaProfile:
+		return documentSchema("skycli profile export", reflect.TypeOf(ProfileDocument{}), "schemaVersion", SchemaVersion), nil
+	case SchemaPost:
+		return documentSchema("skycli post export", reflect.TypeOf(PostDocument{}), "schemaVersion", SchemaVersion), nil
+	case SchemaSnapshot:
+		return documentSchema("skycli snapshot export", reflect.TypeOf(SnapshotDocument{}), "version", SnapshotFormatVersion), nil
+	case SchemaLikes, SchemaReposts:
+		return documentSchema("skycli "+kind+" export", reflect.TypeOf(RecordedPostsDocument{}), "schemaVersion", SchemaVersion), nil
+	default:
+		return nil, fmt.Errorf("unknown export kind: %s", kind)
+	}
+}
+
+// documentSchema describes the JSON encoding of t, pinning versionField to version
+func documentSchema(title string, t reflect.Type, versionField string, version int) map[string]any {
+	b := &schemaBuilder{defs: make(map[string]any)}
+	schema := b.object(t)
+	schema["$schema"] = jsonSchemaDialect
+	schema["title"] = title
+	schema["properties"].(map[string]any)[versionField] = map[string]any{"type": "integer", "const": version}
+	if len(b.defs) > 0 {
+		schema["$defs"] = b.defs
+	}
+	return schema
+}
+
+// csvSchema describes a CSV data row with the given columns
+func csvSchema(title string, columns []Column) map[string]any {
+	items := make([]any, len(columns))
+	for i, column := range columns {
+		item := map[string]any{"title": column.Name, "description": column.Description, "type": "string"}
+		if column.Format != "" {
+			item["format"] = column.Format
+		}
+		if column.Name == "SchemaVersion" {
+			item["const"] = strconv.Itoa(SchemaVersion)
+		}
+		items[i] = item
+	}
+	return map[string]any{
+		"$schema":     jsonSchemaDialect,
+		"title":       title,
+		"type":        "array",
+		"prefixItems": items,
+		"minItems":    len(columns),
+		"items":       false,
+	}
+}
+
+// schemaBuilder derives JSON Schemas from Go types using their json tags.
+// Named structs are collected in defs and referenced, so recursive types terminate.
+type schemaBuilder struct {
+	defs map[string]any
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schema returns the schema of values of type t
+func (b *schemaBuilder) schema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.schema(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+		if _, ok := b.defs[t.Name()]; !ok {
+			b.defs[t.Name()] = nil
+			b.defs[t.Name()] = b.object(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	default:
+		return map[string]any{}
+	}
+}
+
+// object returns the schema of struct type t, with fields of embedded structs inlined as encoding/json does.
+// Fields without omitempty are required; those that may encode as null also accept null.
+func (b *schemaBuilder) object(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+
+			fieldType := field.Type
+			if field.Anonymous && name == "" {
+				if fieldType.Kind() == reflect.Pointer {
+					fieldType = fieldType.Elem()
+				}
+				if fieldType.Kind() == reflect.Struct {
+					collect(fieldType)
+					continue
+				}
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			schema := b.schema(fieldType)
+			omitEmpty := strings.Contains(opts, "omitempty")
+			switch fieldType.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map:
+				if !omitEmpty {
+					schema = map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+				}
+			}
+			properties[name] = schema
+			if !omitEmpty {
+				required = append(required, name)
+			}
+		}
+	}
+	collect(t)
+
+	return map[string]any{"type": "object", "properties": properties, "required": required}
+}
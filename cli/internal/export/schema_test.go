@@ -0,0 +1,108 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSchema_JSONDocuments verifies every JSON export kind has a pinned, serializable schema
+func TestSchema_JSONDocuments(t *testing.T) {
+	for _, kind := range []string{SchemaFeed, SchemaProfile, SchemaPost, SchemaSnapshot, SchemaLikes, SchemaReposts} {
+		schema, err := Schema(kind, "json")
+		if err != nil {
+			t.Fatalf("Schema(%s) failed: %v", kind, err)
+		}
+		if _, err := json.Marshal(schema); err != nil {
+			t.Fatalf("Schema(%s) is not serializable: %v", kind, err)
+		}
+		if schema["$schema"] != jsonSchemaDialect || schema["type"] != "object" {
+			t.Errorf("Schema(%s): unexpected root %v", kind, schema)
+		}
+	}
+
+	schema, _ := Schema(SchemaProfile, "json")
+	properties := schema["properties"].(map[string]any)
+	version := properties["schemaVersion"].(map[string]any)
+	if version["const"] != SchemaVersion {
+		t.Errorf("expected schemaVersion pinned to %d, got %v", SchemaVersion, version)
+	}
+	if _, ok := properties["did"]; !ok {
+		t.Error("expected embedded profile fields to be inlined")
+	}
+	if _, ok := properties["ActorProfile"]; ok {
+		t.Error("embedded struct should not appear as a property")
+	}
+
+	schema, _ = Schema(SchemaSnapshot, "json")
+	if schema["properties"].(map[string]any)["version"].(map[string]any)["const"] != SnapshotFormatVersion {
+		t.Error("expected snapshot version pinned")
+	}
+}
+
+// TestSchema_RequiredMatchesOutput verifies exported documents carry every required field
+func TestSchema_RequiredMatchesOutput(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "feed.json")
+	if err := ToJSON(filename, createTestPosts()); err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("failed to parse export: %v", err)
+	}
+
+	schema, _ := Schema(SchemaFeed, "json")
+	for _, name := range schema["required"].([]string) {
+		if _, ok := doc[name]; !ok {
+			t.Errorf("export is missing required field %q", name)
+		}
+	}
+
+	post := schema["$defs"].(map[string]any)["ExportPost"].(map[string]any)
+	first := doc["posts"].([]any)[0].(map[string]any)
+	for _, name := range post["required"].([]string) {
+		if _, ok := first[name]; !ok {
+			t.Errorf("exported post is missing required field %q", name)
+		}
+	}
+}
+
+// TestSchema_CSVColumnOrder verifies the CSV schema follows PostColumns
+func TestSchema_CSVColumnOrder(t *testing.T) {
+	schema, err := Schema(SchemaFeed, "csv")
+	if err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	items := schema["prefixItems"].([]any)
+	if len(items) != len(PostColumns) || schema["items"] != false {
+		t.Fatalf("expected %d closed columns, got %v", len(PostColumns), schema)
+	}
+	for i, column := range PostColumns {
+		if items[i].(map[string]any)["title"] != column.Name {
+			t.Errorf("column %d: expected %s, got %v", i, column.Name, items[i])
+		}
+	}
+	if PostColumns[len(PostColumns)-1].Name != "SchemaVersion" {
+		t.Error("expected SchemaVersion to stay the last column")
+	}
+}
+
+// TestSchema_Unsupported verifies formats without a schema are rejected
+func TestSchema_Unsupported(t *testing.T) {
+	for _, tc := range []struct{ kind, format string }{
+		{SchemaFeed, "txt"},
+		{SchemaProfile, "csv"},
+		{SchemaSnapshot, "txt"},
+		{"followers", "json"},
+	} {
+		if _, err := Schema(tc.kind, tc.format); err == nil {
+			t.Errorf("expected error for %s/%s", tc.kind, tc.format)
+		}
+	}
+}
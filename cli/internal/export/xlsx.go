@@ -0,0 +1,89 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Sheet is one worksheet of an XLSX workbook. Cells keep their Go types, so numbers stay numeric in Excel.
+type Sheet struct {
+	Name   string
+	Header []string
+	Rows   [][]any
+}
+
+// ToXLSX exports sheets to an XLSX workbook
+func ToXLSX(filename string, sheets []Sheet) error {
+	return writeFile(filename, func(w io.Writer) error { return WriteXLSX(w, sheets) })
+}
+
+// WriteXLSX writes sheets to w as an XLSX workbook, one worksheet per sheet in order, with bold frozen headers.
+// Rows are streamed into the workbook so large sheets don't hold a cell object per value.
+func WriteXLSX(w io.Writer, sheets []Sheet) error {
+	if len(sheets) == 0 {
+		return errors.New("workbook needs at least one sheet")
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %w", err)
+	}
+
+	for i, sheet := range sheets {
+		if i == 0 {
+			err = f.SetSheetName(f.GetSheetName(0), sheet.Name)
+		} else {
+			_, err = f.NewSheet(sheet.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add sheet %q: %w", sheet.Name, err)
+		}
+
+		if err := writeSheet(f, sheet, bold); err != nil {
+			return fmt.Errorf("failed to write sheet %q: %w", sheet.Name, err)
+		}
+	}
+
+	if _, err := f.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return nil
+}
+
+// writeSheet streams a sheet's header and rows into its worksheet
+func writeSheet(f *excelize.File, sheet Sheet, headerStyle int) error {
+	sw, err := f.NewStreamWriter(sheet.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := sw.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+
+	header := make([]any, len(sheet.Header))
+	for i, name := range sheet.Header {
+		header[i] = excelize.Cell{StyleID: headerStyle, Value: name}
+	}
+	if err := sw.SetRow("A1", header); err != nil {
+		return err
+	}
+
+	for i, row := range sheet.Rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetRow(cell, row); err != nil {
+			return err
+		}
+	}
+
+	return sw.Flush()
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// TestToXLSX verifies sheets are written in order with typed cells
+func TestToXLSX(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "workbook.xlsx")
+	sheets := []Sheet{
+		{Name: "Followers", Header: []string{"handle", "followersCount"}, Rows: [][]any{{"a.bsky.social", 12}, {"b.bsky.social", 3}}},
+		{Name: "Summary", Header: []string{"Metric", "Value"}, Rows: [][]any{{"Followers exported", 2}}},
+		{Name: "Diff", Header: []string{"change", "did"}},
+	}
+
+	if err := ToXLSX(filename, sheets); err != nil {
+		t.Fatalf("ToXLSX failed: %v", err)
+	}
+
+	f, err := excelize.OpenFile(filename)
+	if err != nil {
+		t.Fatalf("failed to open workbook: %v", err)
+	}
+	defer f.Close()
+
+	names := f.GetSheetList()
+	if len(names) != 3 || names[0] != "Followers" || names[1] != "Summary" || names[2] != "Diff" {
+		t.Fatalf("unexpected sheets %v", names)
+	}
+
+	rows, err := f.GetRows("Followers")
+	if err != nil {
+		t.Fatalf("GetRows failed: %v", err)
+	}
+	if len(rows) != 3 || rows[0][0] != "handle" || rows[2][0] != "b.bsky.social" {
+		t.Errorf("unexpected followers rows %v", rows)
+	}
+
+	cellType, err := f.GetCellType("Followers", "B2")
+	if err != nil || cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString {
+		t.Errorf("expected numeric count cell, got type %v (err %v)", cellType, err)
+	}
+
+	if rows, _ := f.GetRows("Diff"); len(rows) != 1 {
+		t.Errorf("expected only a header on the empty diff sheet, got %v", rows)
+	}
+}
+
+// TestToXLSX_NoSheets verifies an empty workbook is rejected
+func TestToXLSX_NoSheets(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "empty.xlsx")
+	if err := ToXLSX(filename, nil); err == nil {
+		t.Error("expected error for a workbook without sheets")
+	}
+}
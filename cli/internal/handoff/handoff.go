@@ -0,0 +1,381 @@
+// Package handoff moves a signed-in skycli session from one device to another over the local network, so a
+// headless server can be signed in without typing an app password on it.
+//
+// The receiving device listens on a port and shows a one-time pairing code. The sending device fetches the
+// receiver's ephemeral X25519 key, checks it against an HMAC keyed by the code, and sends the session sealed with
+// AES-GCM under a key derived from both the key exchange and the code. An eavesdropper sees only ciphertext, and
+// someone who doesn't know the code can neither swap in their own key nor get a session accepted; the receiver
+// gives up after [MaxAttempts] bad tries.
+package handoff
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxAttempts is how many sessions that fail to open a receiver accepts before giving up
+const MaxAttempts = 3
+
+// secretSize is the length of the pairing code's secret in bytes: 80 bits, written as 16 base32 characters
+const secretSize = 10
+
+// Protocol labels keep the MAC and derived key from being reused for anything else
+const (
+	keyMACLabel = "skycli handoff key v1"
+	sealLabel   = "skycli handoff session v1"
+)
+
+const (
+	keyPath     = "/handoff/key"
+	sessionPath = "/handoff/session"
+)
+
+// maxBodySize caps the sealed session a receiver reads
+const maxBodySize = 64 << 10
+
+// codeEncoding writes secrets in uppercase base32 without padding
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ErrTooManyAttempts is returned by [Receiver.Receive] after [MaxAttempts] sessions failed to open
+var ErrTooManyAttempts = errors.New("too many failed attempts; start a new handoff")
+
+// Session is what moves between devices: the stored session of the sending device
+type Session struct {
+	Handle       string `json:"handle"`
+	DID          string `json:"did"`
+	ServiceURL   string `json:"serviceUrl"`
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	AppPassword  string `json:"appPassword,omitempty"`
+}
+
+// HandoffError wraps a failed step of a handoff
+type HandoffError struct {
+	Op  string
+	Err error
+}
+
+func (e *HandoffError) Error() string {
+	return "handoff." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *HandoffError) Unwrap() error {
+	return e.Err
+}
+
+// keyMessage is the receiver's answer to a key request
+type keyMessage struct {
+	Key []byte `json:"key"`
+	MAC []byte `json:"mac"`
+}
+
+// sealedMessage carries the sender's key and the encrypted session
+type sealedMessage struct {
+	Key   []byte `json:"key"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// Receiver waits for one session on a listening socket
+type Receiver struct {
+	listener net.Listener
+	secret   []byte
+	key      *ecdh.PrivateKey
+
+	mu       sync.Mutex
+	failures int
+	done     bool
+	result   chan *Session
+	failed   chan error
+}
+
+// Listen opens a receiver on addr (host:port; port 0 picks a free one) with a fresh key and pairing code
+func Listen(addr string) (*Receiver, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, &HandoffError{Op: "Listen", Err: err}
+	}
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, &HandoffError{Op: "Listen", Err: err}
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, &HandoffError{Op: "Listen", Err: err}
+	}
+	return &Receiver{
+		listener: listener,
+		secret:   secret,
+		key:      key,
+		result:   make(chan *Session, 1),
+		failed:   make(chan error, 1),
+	}, nil
+}
+
+// Code returns the pairing code, e.g. K7QX-M2PA-9TRB-XH4E
+func (r *Receiver) Code() string {
+	return formatCode(r.secret)
+}
+
+// Addr returns the address the receiver listens on
+func (r *Receiver) Addr() net.Addr {
+	return r.listener.Addr()
+}
+
+// Pairings returns the pairing strings a sender can use, host:port/CODE, one per address the receiver can be
+// reached on. A receiver listening on all interfaces lists each non-loopback address, falling back to loopback.
+func (r *Receiver) Pairings() []string {
+	tcp, ok := r.listener.Addr().(*net.TCPAddr)
+	if !ok {
+		return []string{r.listener.Addr().String() + "/" + r.Code()}
+	}
+	if !tcp.IP.IsUnspecified() {
+		return []string{net.JoinHostPort(tcp.IP.String(), fmt.Sprint(tcp.Port)) + "/" + r.Code()}
+	}
+
+	var pairings []string
+	addrs, _ := net.InterfaceAddrs()
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		pairings = append(pairings, net.JoinHostPort(ipNet.IP.String(), fmt.Sprint(tcp.Port))+"/"+r.Code())
+	}
+	if len(pairings) == 0 {
+		pairings = append(pairings, net.JoinHostPort("127.0.0.1", fmt.Sprint(tcp.Port))+"/"+r.Code())
+	}
+	return pairings
+}
+
+// Receive serves the handoff until a session arrives, [MaxAttempts] attempts fail, or ctx ends, then closes the
+// listener. Bound ctx with a timeout to keep the pairing code short-lived.
+func (r *Receiver) Receive(ctx context.Context) (*Session, error) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+keyPath, r.serveKey)
+	mux.HandleFunc("POST "+sessionPath, r.serveSession)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go server.Serve(r.listener)
+	defer server.Close()
+
+	select {
+	case session := <-r.result:
+		return session, nil
+	case err := <-r.failed:
+		return nil, &HandoffError{Op: "Receive", Err: err}
+	case <-ctx.Done():
+		return nil, &HandoffError{Op: "Receive", Err: ctx.Err()}
+	}
+}
+
+func (r *Receiver) serveKey(w http.ResponseWriter, req *http.Request) {
+	public := r.key.PublicKey().Bytes()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keyMessage{Key: public, MAC: keyMAC(r.secret, public)})
+}
+
+func (r *Receiver) serveSession(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.done {
+		http.Error(w, "handoff finished", http.StatusGone)
+		return
+	}
+
+	session, err := r.open(req)
+	if err != nil {
+		r.failures++
+		if r.failures >= MaxAttempts {
+			r.done = true
+			r.failed <- ErrTooManyAttempts
+		}
+		http.Error(w, "session rejected", http.StatusForbidden)
+		return
+	}
+
+	r.done = true
+	r.result <- session
+	w.WriteHeader(http.StatusOK)
+}
+
+// open decrypts and decodes a sealed session sent by a sender
+func (r *Receiver) open(req *http.Request) (*Session, error) {
+	var msg sealedMessage
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxBodySize)).Decode(&msg); err != nil {
+		return nil, err
+	}
+	peer, err := ecdh.X25519().NewPublicKey(msg.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := sessionCipher(r.key, peer, r.secret)
+	if err != nil {
+		return nil, err
+	}
+	if len(msg.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	plain, err := aead.Open(nil, msg.Nonce, msg.Data, transcript(r.key.PublicKey().Bytes(), msg.Key))
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(plain, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Send delivers session to the receiver named by pairing (host:port/CODE)
+func Send(ctx context.Context, client *http.Client, pairing string, session *Session) error {
+	addr, secret, err := ParsePairing(pairing)
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	base := "http://" + addr
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+keyPath, nil)
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+	var keyMsg keyMessage
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxBodySize)).Decode(&keyMsg)
+	resp.Body.Close()
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: fmt.Errorf("unexpected answer from %s: %w", addr, err)}
+	}
+	if !hmac.Equal(keyMsg.MAC, keyMAC(secret, keyMsg.Key)) {
+		return &HandoffError{Op: "Send", Err: errors.New("the receiver's key doesn't match the pairing code; check the code, or someone else is answering on that address")}
+	}
+	peer, err := ecdh.X25519().NewPublicKey(keyMsg.Key)
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+	aead, err := sessionCipher(key, peer, secret)
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+
+	plain, err := json.Marshal(session)
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+	public := key.PublicKey().Bytes()
+	body, err := json.Marshal(sealedMessage{Key: public, Nonce: nonce, Data: aead.Seal(nil, nonce, plain, transcript(keyMsg.Key, public))})
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, base+sessionPath, bytes.NewReader(body))
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil {
+		return &HandoffError{Op: "Send", Err: err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		text, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &HandoffError{Op: "Send", Err: fmt.Errorf("receiver refused the session: %s - %s", resp.Status, strings.TrimSpace(string(text)))}
+	}
+	return nil
+}
+
+// ParsePairing splits a pairing string into the receiver's address and the code's secret. Codes are read without
+// regard to case, dashes, or spaces.
+func ParsePairing(pairing string) (string, []byte, error) {
+	addr, code, ok := strings.Cut(strings.TrimSpace(pairing), "/")
+	if !ok || addr == "" {
+		return "", nil, fmt.Errorf("invalid pairing %q: expected host:port/CODE", pairing)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return "", nil, fmt.Errorf("invalid pairing address %q: %w", addr, err)
+	}
+
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	secret, err := codeEncoding.DecodeString(code)
+	if err != nil || len(secret) != secretSize {
+		return "", nil, fmt.Errorf("invalid pairing code %q", code)
+	}
+	return addr, secret, nil
+}
+
+// formatCode writes a secret as dash-separated groups of four characters
+func formatCode(secret []byte) string {
+	encoded := codeEncoding.EncodeToString(secret)
+	var groups []string
+	for len(encoded) > 4 {
+		groups = append(groups, encoded[:4])
+		encoded = encoded[4:]
+	}
+	return strings.Join(append(groups, encoded), "-")
+}
+
+// keyMAC authenticates a receiver's public key with the pairing secret
+func keyMAC(secret, public []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(keyMACLabel))
+	mac.Write(public)
+	return mac.Sum(nil)
+}
+
+// sessionCipher derives the AES-GCM cipher both sides seal the session with from their key exchange and the secret
+func sessionCipher(own *ecdh.PrivateKey, peer *ecdh.PublicKey, secret []byte) (cipher.AEAD, error) {
+	shared, err := own.ECDH(peer)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hkdf.Key(sha256.New, shared, secret, sealLabel, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// transcript binds a sealed session to both public keys
+func transcript(receiver, sender []byte) []byte {
+	return append(append([]byte{}, receiver...), sender...)
+}
@@ -0,0 +1,97 @@
+package handoff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandoff(t *testing.T) {
+	session := &Session{Handle: "me.bsky.social", DID: "did:plc:me", ServiceURL: "https://bsky.social", AccessToken: "access", RefreshToken: "refresh", AppPassword: "skycli"}
+
+	t.Run("round trip", func(t *testing.T) {
+		receiver, err := Listen("127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Listen failed: %v", err)
+		}
+		pairings := receiver.Pairings()
+		if len(pairings) != 1 || !strings.HasSuffix(pairings[0], "/"+receiver.Code()) {
+			t.Fatalf("unexpected pairings %v", pairings)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		received := make(chan *Session, 1)
+		go func() {
+			got, err := receiver.Receive(ctx)
+			if err != nil {
+				t.Errorf("Receive failed: %v", err)
+			}
+			received <- got
+		}()
+
+		// Codes are accepted in lowercase and without dashes
+		addr, _, _ := strings.Cut(pairings[0], "/")
+		code := strings.ToLower(strings.ReplaceAll(receiver.Code(), "-", ""))
+		if err := Send(ctx, nil, addr+"/"+code, session); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+		if got := <-received; got == nil || *got != *session {
+			t.Errorf("received %+v, want %+v", got, session)
+		}
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		receiver, err := Listen("127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Listen failed: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		go receiver.Receive(ctx)
+
+		wrong := receiver.Addr().String() + "/AAAA-AAAA-AAAA-AAAA"
+		if err := Send(ctx, nil, wrong, session); err == nil || !strings.Contains(err.Error(), "doesn't match the pairing code") {
+			t.Errorf("expected the key check to fail, got %v", err)
+		}
+	})
+
+	t.Run("too many attempts", func(t *testing.T) {
+		receiver, err := Listen("127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Listen failed: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		failed := make(chan error, 1)
+		go func() {
+			_, err := receiver.Receive(ctx)
+			failed <- err
+		}()
+
+		for range MaxAttempts {
+			resp, err := http.Post("http://"+receiver.Addr().String()+sessionPath, "application/json", strings.NewReader(`{"key":"","nonce":"","data":""}`))
+			if err != nil {
+				t.Fatalf("POST failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("expected 403, got %s", resp.Status)
+			}
+		}
+		if err := <-failed; !errors.Is(err, ErrTooManyAttempts) {
+			t.Errorf("expected ErrTooManyAttempts, got %v", err)
+		}
+	})
+}
+
+func TestParsePairing(t *testing.T) {
+	for _, input := range []string{"", "127.0.0.1:80", "127.0.0.1/ABCD", "127.0.0.1:80/ABCD", "127.0.0.1:80/!!!!-AAAA-AAAA-AAAA"} {
+		if _, _, err := ParsePairing(input); err == nil {
+			t.Errorf("ParsePairing(%q) accepted an invalid pairing", input)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package imports
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/verify"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// Collections read from an account export
+const (
+	collectionPost   = "app.bsky.feed.post"
+	collectionFollow = "app.bsky.graph.follow"
+	collectionBlock  = "app.bsky.graph.block"
+)
+
+// snapshotTTL matches the default lifetime of follower snapshots
+const snapshotTTL = 24 * time.Hour
+
+// BlueskyExport is the content of an account export downloaded from Bluesky (Settings → Account → Export my data):
+// the account's whole repository as a CAR file, alone or inside a zip
+type BlueskyExport struct {
+	DID        string
+	Rev        string    // repository revision at export time
+	ExportedAt time.Time // from the revision, or the time of reading when it isn't a TID
+	Posts      []*bsky.PostView
+	Follows    []GraphRecord
+	Blocks     []GraphRecord
+	Other      int // records of collections that aren't imported
+}
+
+// GraphRecord is a follow or block record
+type GraphRecord struct {
+	URI       string
+	Subject   string // DID of the followed or blocked account
+	CreatedAt string
+}
+
+// ParseBlueskyExport reads an account export from a .car file, or from a zip holding one
+func ParseBlueskyExport(filePath string) (*BlueskyExport, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		if data, err = carFromZip(data); err != nil {
+			return nil, err
+		}
+	}
+	return ReadBlueskyExport(data)
+}
+
+// carFromZip returns the repository CAR inside a zipped export
+func carFromZip(data []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip: %w", err)
+	}
+
+	for _, file := range archive.File {
+		if !strings.EqualFold(path.Ext(file.Name), ".car") {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("zip holds no .car repository file")
+}
+
+// ReadBlueskyExport parses a repository CAR, checking every block against its CID, and collects its posts,
+// follows, and blocks
+func ReadBlueskyExport(data []byte) (*BlueskyExport, error) {
+	car, err := verify.ParseCAR(data)
+	if err != nil {
+		return nil, fmt.Errorf("not a repository export: %w", err)
+	}
+	if err := car.Check(); err != nil {
+		return nil, fmt.Errorf("export is corrupt: %w", err)
+	}
+
+	commit, err := car.Node(car.Roots[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit: %w", err)
+	}
+	did, _ := commit["did"].(string)
+	root, ok := commit["data"].(verify.CID)
+	if did == "" || !ok {
+		return nil, fmt.Errorf("export root is not a repository commit")
+	}
+
+	export := &BlueskyExport{DID: did, ExportedAt: time.Now().UTC()}
+	export.Rev, _ = commit["rev"].(string)
+	if at, ok := aturi.TIDTime(export.Rev); ok {
+		export.ExportedAt = at
+	}
+
+	err = verify.WalkMST(car, root, func(key string, cid verify.CID) error {
+		collection, _, _ := strings.Cut(key, "/")
+		if collection != collectionPost && collection != collectionFollow && collection != collectionBlock {
+			export.Other++
+			return nil
+		}
+
+		record, err := car.Node(cid)
+		if err != nil {
+			return fmt.Errorf("record %s: %w", key, err)
+		}
+		uri := "at://" + did + "/" + key
+		createdAt, _ := record["createdAt"].(string)
+
+		switch collection {
+		case collectionPost:
+			export.Posts = append(export.Posts, &bsky.PostView{
+				Uri:       uri,
+				Cid:       cid.String(),
+				Author:    &bsky.ActorProfile{Did: did},
+				Record:    verify.ToJSON(record),
+				IndexedAt: createdAt,
+			})
+		default:
+			subject, _ := record["subject"].(string)
+			if subject == "" {
+				return nil
+			}
+			graph := GraphRecord{URI: uri, Subject: subject, CreatedAt: createdAt}
+			if collection == collectionFollow {
+				export.Follows = append(export.Follows, graph)
+			} else {
+				export.Blocks = append(export.Blocks, graph)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repository: %w", err)
+	}
+	return export, nil
+}
+
+// Snapshot converts follows or blocks into a snapshot of snapshotType ("following" or "blocks") dated at export
+// time. Its ID is derived from the account and revision, so importing the same export twice yields the same ID.
+func (e *BlueskyExport) Snapshot(snapshotType string, records []GraphRecord) (*bsky.SnapshotModel, []*bsky.SnapshotEntry) {
+	id := uuid.NewSHA1(uuid.NameSpaceURL, []byte("at://"+e.DID+"#"+snapshotType+"@"+e.Rev)).String()
+
+	snapshot := &bsky.SnapshotModel{
+		UserDid:      e.DID,
+		SnapshotType: snapshotType,
+		ExpiresAt:    e.ExportedAt.Add(snapshotTTL),
+	}
+	snapshot.SetID(id)
+	snapshot.SetCreatedAt(e.ExportedAt)
+
+	// A repeated follow or block leaves several records; records come in key order, so the oldest is kept
+	seen := make(map[string]bool, len(records))
+	entries := make([]*bsky.SnapshotEntry, 0, len(records))
+	for _, record := range records {
+		if seen[record.Subject] {
+			continue
+		}
+		seen[record.Subject] = true
+		entries = append(entries, &bsky.SnapshotEntry{SnapshotID: id, ActorDid: record.Subject, IndexedAt: record.CreatedAt})
+	}
+	snapshot.TotalCount = len(entries)
+	return snapshot, entries
+}
@@ -0,0 +1,118 @@
+package imports
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/verify"
+)
+
+const exportDID = "did:plc:exporter"
+
+// testExportCAR builds a repository CAR with one tree node holding a like, a post, a block, and two follows of
+// the same account
+func testExportCAR(t *testing.T) []byte {
+	t.Helper()
+	encode := func(value any) ([]byte, verify.CID) {
+		data, err := verify.EncodeCBOR(value)
+		if err != nil {
+			t.Fatalf("EncodeCBOR failed: %v", err)
+		}
+		return data, verify.SumCID(verify.CodecDagCBOR, data)
+	}
+
+	blocks := map[string][]byte{}
+	records := []struct {
+		key   string
+		value map[string]any
+	}{
+		{"app.bsky.feed.like/3kaaaaaaaaa22", map[string]any{"$type": "app.bsky.feed.like", "createdAt": "2024-01-01T00:00:00Z"}},
+		{"app.bsky.feed.post/3kbbbbbbbbb22", map[string]any{"$type": "app.bsky.feed.post", "text": "hello from the export", "createdAt": "2024-01-02T00:00:00.000Z", "langs": []any{"en"}}},
+		{"app.bsky.graph.block/3kccccccccc22", map[string]any{"$type": "app.bsky.graph.block", "subject": "did:plc:blocked", "createdAt": "2024-01-03T00:00:00Z"}},
+		{"app.bsky.graph.follow/3kddddddddd22", map[string]any{"$type": "app.bsky.graph.follow", "subject": "did:plc:friend", "createdAt": "2024-01-04T00:00:00Z"}},
+		{"app.bsky.graph.follow/3keeeeeeeee22", map[string]any{"$type": "app.bsky.graph.follow", "subject": "did:plc:friend", "createdAt": "2024-01-05T00:00:00Z"}},
+	}
+
+	var entries []any
+	for _, record := range records {
+		data, cid := encode(record.value)
+		blocks[cid.String()] = data
+		entries = append(entries, map[string]any{"p": int64(0), "k": []byte(record.key), "v": cid, "t": nil})
+	}
+	nodeData, nodeCID := encode(map[string]any{"l": nil, "e": entries})
+	blocks[nodeCID.String()] = nodeData
+
+	commitData, commitCID := encode(map[string]any{"did": exportDID, "version": int64(3), "data": nodeCID, "rev": "3khuwc44c222b", "prev": nil, "sig": []byte("unchecked")})
+	blocks[commitCID.String()] = commitData
+
+	section := func(data []byte) []byte {
+		return append(binary.AppendUvarint(nil, uint64(len(data))), data...)
+	}
+	header, _ := encode(map[string]any{"version": int64(1), "roots": []any{commitCID}})
+	out := section(header)
+	for key, data := range blocks {
+		cid, _ := verify.ParseCID(key)
+		out = append(out, section(append(cid.Bytes(), data...))...)
+	}
+	return out
+}
+
+func TestParseBlueskyExport(t *testing.T) {
+	dir := t.TempDir()
+	car := testExportCAR(t)
+
+	carPath := filepath.Join(dir, "repo.car")
+	if err := os.WriteFile(carPath, car, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var zipped bytes.Buffer
+	w := zip.NewWriter(&zipped)
+	f, _ := w.Create("export/repo.car")
+	f.Write(car)
+	w.Close()
+	zipPath := filepath.Join(dir, "export.zip")
+	if err := os.WriteFile(zipPath, zipped.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{carPath, zipPath} {
+		export, err := ParseBlueskyExport(path)
+		if err != nil {
+			t.Fatalf("ParseBlueskyExport(%s) failed: %v", filepath.Base(path), err)
+		}
+
+		if export.DID != exportDID || !export.ExportedAt.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected export header %+v", export)
+		}
+		if len(export.Posts) != 1 || len(export.Follows) != 2 || len(export.Blocks) != 1 || export.Other != 1 {
+			t.Fatalf("unexpected contents: %d posts, %d follows, %d blocks, %d other", len(export.Posts), len(export.Follows), len(export.Blocks), export.Other)
+		}
+
+		post := export.Posts[0]
+		if post.Uri != "at://"+exportDID+"/app.bsky.feed.post/3kbbbbbbbbb22" || post.Author.Did != exportDID || post.IndexedAt != "2024-01-02T00:00:00.000Z" {
+			t.Errorf("unexpected post %+v", post)
+		}
+		if text := post.Record.(map[string]any)["text"]; text != "hello from the export" {
+			t.Errorf("post text = %v", text)
+		}
+
+		snapshot, entries := export.Snapshot("following", export.Follows)
+		if snapshot.UserDid != exportDID || snapshot.TotalCount != 1 || len(entries) != 1 || entries[0].IndexedAt != "2024-01-04T00:00:00Z" {
+			t.Errorf("unexpected following snapshot %+v %+v", snapshot, entries)
+		}
+		if again, _ := export.Snapshot("following", export.Follows); again.ID() != snapshot.ID() {
+			t.Error("expected the same export to give the same snapshot ID")
+		}
+	}
+
+	corrupt := bytes.Replace(car, []byte("hello from the export"), []byte("hello from elsewhere!"), 1)
+	if _, err := ReadBlueskyExport(corrupt); err == nil {
+		t.Error("expected an edited block to be refused")
+	}
+}
@@ -2,10 +2,14 @@ package imports
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/export"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 )
 
 // ParseEnvFile reads (relative and absolute file paths) an env file and returns a map of key-value pairs.
@@ -26,7 +30,8 @@ func ParseEnvFile(path string) (map[string]string, error) {
 	scanner := bufio.NewScanner(file)
 
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+		// Windows PowerShell 5 saves UTF-8 files with a byte order mark, which TrimSpace keeps
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
 
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -48,3 +53,55 @@ func ParseEnvFile(path string) (map[string]string, error) {
 
 	return env, nil
 }
+
+// ParseSnapshotFile reads a snapshot document written by [export.SnapshotToJSON] and converts it back into store models.
+// The original snapshot ID and timestamps are kept.
+func ParseSnapshotFile(path string) (*bsky.SnapshotModel, []*bsky.SnapshotEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var doc export.SnapshotDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse snapshot JSON: %w", err)
+	}
+
+	return SnapshotFromDocument(doc)
+}
+
+// SnapshotFromDocument validates a snapshot document and converts it into store models
+func SnapshotFromDocument(doc export.SnapshotDocument) (*bsky.SnapshotModel, []*bsky.SnapshotEntry, error) {
+	if doc.Version == 0 || doc.Version > export.SnapshotFormatVersion {
+		return nil, nil, fmt.Errorf("unsupported snapshot format version: %d", doc.Version)
+	}
+	if doc.Snapshot.ID == "" || doc.Snapshot.UserDid == "" {
+		return nil, nil, fmt.Errorf("snapshot document is missing id or userDid")
+	}
+	if doc.Snapshot.SnapshotType != "followers" && doc.Snapshot.SnapshotType != "following" && doc.Snapshot.SnapshotType != "blocks" {
+		return nil, nil, fmt.Errorf("invalid snapshot type: %s", doc.Snapshot.SnapshotType)
+	}
+
+	snapshot := &bsky.SnapshotModel{
+		UserDid:      doc.Snapshot.UserDid,
+		SnapshotType: doc.Snapshot.SnapshotType,
+		TotalCount:   doc.Snapshot.TotalCount,
+		ExpiresAt:    doc.Snapshot.ExpiresAt,
+	}
+	snapshot.SetID(doc.Snapshot.ID)
+	snapshot.SetCreatedAt(doc.Snapshot.CreatedAt)
+
+	entries := make([]*bsky.SnapshotEntry, 0, len(doc.Entries))
+	for _, entry := range doc.Entries {
+		if entry.ActorDid == "" {
+			continue
+		}
+		entries = append(entries, &bsky.SnapshotEntry{
+			SnapshotID: doc.Snapshot.ID,
+			ActorDid:   entry.ActorDid,
+			IndexedAt:  entry.IndexedAt,
+		})
+	}
+
+	return snapshot, entries, nil
+}
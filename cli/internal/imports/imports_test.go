@@ -4,6 +4,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/export"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 )
 
 func TestParseEnvFile(t *testing.T) {
@@ -32,6 +36,26 @@ BLUESKY_PASSWORD=secret123`
 		}
 	})
 
+	t.Run("reads files saved by Windows PowerShell", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		envPath := filepath.Join(tmpDir, ".env")
+
+		content := "\ufeffBLUESKY_HANDLE=test.bsky.social\r\nBLUESKY_PASSWORD=secret123\r\n"
+
+		if err := os.WriteFile(envPath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		env, err := ParseEnvFile(envPath)
+		if err != nil {
+			t.Fatalf("ParseEnvFile failed: %v", err)
+		}
+
+		if env["BLUESKY_HANDLE"] != "test.bsky.social" || env["BLUESKY_PASSWORD"] != "secret123" {
+			t.Errorf("unexpected entries %q", env)
+		}
+	})
+
 	t.Run("ignores comments and empty lines", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		envPath := filepath.Join(tmpDir, ".env")
@@ -231,3 +255,80 @@ BLUESKY_PASSWORD=secret123`
 		}
 	})
 }
+
+func TestParseSnapshotFile(t *testing.T) {
+	t.Run("round-trips an exported snapshot", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+		snapshot := &bsky.SnapshotModel{
+			UserDid:      "did:plc:testuser",
+			SnapshotType: "followers",
+			TotalCount:   2,
+			ExpiresAt:    createdAt.Add(24 * time.Hour),
+		}
+		snapshot.SetID("snap-123")
+		snapshot.SetCreatedAt(createdAt)
+
+		entries := []*bsky.SnapshotEntry{
+			{SnapshotID: "snap-123", ActorDid: "did:plc:follower1", IndexedAt: "2024-01-15T10:00:00Z"},
+			{SnapshotID: "snap-123", ActorDid: "did:plc:follower2"},
+		}
+
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		if err := export.SnapshotToJSON(path, snapshot, entries); err != nil {
+			t.Fatalf("SnapshotToJSON failed: %v", err)
+		}
+
+		imported, importedEntries, err := ParseSnapshotFile(path)
+		if err != nil {
+			t.Fatalf("ParseSnapshotFile failed: %v", err)
+		}
+
+		if imported.ID() != "snap-123" {
+			t.Errorf("expected ID snap-123, got %s", imported.ID())
+		}
+		if !imported.CreatedAt().Equal(createdAt) {
+			t.Errorf("expected created at %v, got %v", createdAt, imported.CreatedAt())
+		}
+		if imported.UserDid != "did:plc:testuser" || imported.SnapshotType != "followers" || imported.TotalCount != 2 {
+			t.Errorf("unexpected snapshot metadata: %+v", imported)
+		}
+		if len(importedEntries) != 2 || importedEntries[0].SnapshotID != "snap-123" || importedEntries[0].IndexedAt != "2024-01-15T10:00:00Z" {
+			t.Errorf("unexpected entries: %+v", importedEntries)
+		}
+	})
+
+	t.Run("rejects unsupported versions", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		content := `{"version": 99, "snapshot": {"id": "x", "userDid": "did:plc:a", "snapshotType": "followers"}}`
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		if _, _, err := ParseSnapshotFile(path); err == nil {
+			t.Error("expected error for unsupported version")
+		}
+	})
+
+	t.Run("rejects invalid snapshot type", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		content := `{"version": 1, "snapshot": {"id": "x", "userDid": "did:plc:a", "snapshotType": "likes"}}`
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		if _, _, err := ParseSnapshotFile(path); err == nil {
+			t.Error("expected error for invalid snapshot type")
+		}
+	})
+
+	t.Run("returns error for invalid JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		if _, _, err := ParseSnapshotFile(path); err == nil {
+			t.Error("expected error for invalid JSON")
+		}
+	})
+}
@@ -0,0 +1,149 @@
+// Package linkcard reads the preview of a web page, its title, description, and image, from the Open Graph and
+// HTML meta tags that apps use to draw link cards.
+package linkcard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxPageSize caps how much of a page is read looking for its meta tags, which belong in the head
+const maxPageSize = 1 << 20
+
+// ErrInvalidLink is returned for links that aren't http or https URLs
+var ErrInvalidLink = errors.New("expected an http or https URL")
+
+// Card is the preview of a web page
+type Card struct {
+	URL         string
+	Title       string
+	Description string
+	Image       string // absolute URL of the preview image, or empty
+}
+
+var (
+	metaTag   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attribute = regexp.MustCompile(`(?is)([a-z][a-z0-9:_-]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	titleTag  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	headEnd   = regexp.MustCompile(`(?i)</head>`)
+)
+
+// Fetch reads the card of the page at target. Pages that aren't HTML give a card with only the URL.
+func Fetch(ctx context.Context, client *http.Client, target string) (*Card, error) {
+	base, err := url.Parse(target)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("invalid link %q: %w", target, ErrInvalidLink)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", target, resp.Status)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" && !strings.Contains(contentType, "html") {
+		return &Card{URL: target}, nil
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", target, err)
+	}
+	card := Parse(resp.Request.URL, string(page))
+	card.URL = target
+	return card, nil
+}
+
+// Parse reads a card from the head of an HTML page served from base, preferring Open Graph tags, then Twitter
+// card tags, then the page's title and description
+func Parse(base *url.URL, page string) *Card {
+	if end := headEnd.FindStringIndex(page); end != nil {
+		page = page[:end[0]]
+	}
+
+	meta := make(map[string]string)
+	for _, tag := range metaTag.FindAllString(page, -1) {
+		attrs := make(map[string]string)
+		for _, match := range attribute.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(match[1])] = match[2] + match[3] + match[4]
+		}
+		key := strings.ToLower(attrs["property"])
+		if key == "" {
+			key = strings.ToLower(attrs["name"])
+		}
+		if _, seen := meta[key]; key != "" && !seen {
+			meta[key] = clean(attrs["content"])
+		}
+	}
+
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if value := meta[key]; value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	card := &Card{
+		URL:         base.String(),
+		Title:       first("og:title", "twitter:title"),
+		Description: first("og:description", "twitter:description", "description"),
+	}
+	if card.Title == "" {
+		if match := titleTag.FindStringSubmatch(page); match != nil {
+			card.Title = clean(match[1])
+		}
+	}
+	if image := first("og:image:secure_url", "og:image", "og:image:url", "twitter:image", "twitter:image:src"); image != "" {
+		if ref, err := base.Parse(image); err == nil && (ref.Scheme == "http" || ref.Scheme == "https") {
+			card.Image = ref.String()
+		}
+	}
+	return card
+}
+
+// Download reads the file at target, failing when it is larger than maxBytes
+func Download(ctx context.Context, client *http.Client, target string, maxBytes int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", target, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxBytes)+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", target, err)
+	}
+	if len(data) > maxBytes {
+		return nil, fmt.Errorf("%s is larger than %d bytes", target, maxBytes)
+	}
+	return data, nil
+}
+
+// clean decodes HTML entities and collapses runs of whitespace
+func clean(text string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
@@ -0,0 +1,83 @@
+package linkcard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	base, _ := url.Parse("https://example.com/blog/post")
+
+	t.Run("OpenGraph", func(t *testing.T) {
+		card := Parse(base, `<html><head>
+			<title>Fallback</title>
+			<meta name="description" content="Plain description">
+			<meta property="og:title" content="Tom &amp; Jerry's
+				big day">
+			<meta content='An "episode"' property='og:description'>
+			<meta property="og:image" content="/img/cover.png">
+			</head><body><meta property="og:title" content="Ignored"></body></html>`)
+
+		if card.Title != "Tom & Jerry's big day" || card.Description != `An "episode"` {
+			t.Errorf("unexpected card %+v", card)
+		}
+		if card.Image != "https://example.com/img/cover.png" {
+			t.Errorf("image = %q, want it resolved against the page", card.Image)
+		}
+	})
+
+	t.Run("Fallbacks", func(t *testing.T) {
+		card := Parse(base, `<title> Just a
+			title </title><meta name="description" content="Plain description"><meta name="twitter:image" content="javascript:alert(1)">`)
+		if card.Title != "Just a title" || card.Description != "Plain description" || card.Image != "" {
+			t.Errorf("unexpected card %+v", card)
+		}
+	})
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<meta property="og:title" content="A page"><meta property="og:image" content="thumb.png">`))
+		case "/file.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.7"))
+		case "/thumb.png":
+			w.Write(make([]byte, 64))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	card, err := Fetch(ctx, server.Client(), server.URL+"/page")
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if card.URL != server.URL+"/page" || card.Title != "A page" || card.Image != server.URL+"/thumb.png" {
+		t.Errorf("unexpected card %+v", card)
+	}
+
+	if card, err := Fetch(ctx, server.Client(), server.URL+"/file.pdf"); err != nil || card.Title != "" {
+		t.Errorf("a PDF should give a bare card, got %+v (err %v)", card, err)
+	}
+	if _, err := Fetch(ctx, server.Client(), server.URL+"/missing"); err == nil {
+		t.Error("expected an error for a 404")
+	}
+	if _, err := Fetch(ctx, server.Client(), "ftp://example.com"); err == nil {
+		t.Error("expected an error for a non-HTTP link")
+	}
+
+	if data, err := Download(ctx, server.Client(), card.Image, 64); err != nil || len(data) != 64 {
+		t.Errorf("Download: got %d bytes, err %v", len(data), err)
+	}
+	if _, err := Download(ctx, server.Client(), card.Image, 63); err == nil {
+		t.Error("expected Download to refuse a file over the limit")
+	}
+}
@@ -0,0 +1,111 @@
+// Package lockfile keeps two copies of a long-running command from running at once. A lock is a file holding its
+// owner's process ID, created only if it doesn't exist yet; one left behind by a process that has exited is
+// taken over.
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrLocked is returned when another running process holds the lock
+var ErrLocked = errors.New("locked by another process")
+
+// LockError reports a lock that couldn't be taken or released. PID is the holder's process ID when another
+// process holds the lock, or 0 when it couldn't be read.
+type LockError struct {
+	Op   string
+	Path string
+	PID  int
+	Err  error
+}
+
+func (e *LockError) Error() string {
+	if e.PID > 0 {
+		return fmt.Sprintf("lockfile.%s %s: %s (pid %d)", e.Op, e.Path, e.Err, e.PID)
+	}
+	return fmt.Sprintf("lockfile.%s %s: %s", e.Op, e.Path, e.Err)
+}
+
+func (e *LockError) Unwrap() error {
+	return e.Err
+}
+
+// Lock is a held lockfile
+type Lock struct {
+	path string
+	pid  int
+}
+
+// Acquire takes the lock at path, failing with [ErrLocked] while a running process holds it. A lock whose holder
+// has exited is removed and taken. A lock file that can't be read is treated as held, since its owner may still
+// be writing it.
+func Acquire(path string) (*Lock, error) {
+	pid := os.Getpid()
+	for range 2 {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_, err = file.WriteString(strconv.Itoa(pid) + "\n")
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, &LockError{Op: "Acquire", Path: path, Err: err}
+			}
+			return &Lock{path: path, pid: pid}, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, &LockError{Op: "Acquire", Path: path, Err: err}
+		}
+
+		holder, err := readPID(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue // released in the meantime
+			}
+			return nil, &LockError{Op: "Acquire", Path: path, Err: ErrLocked}
+		}
+		if processRunning(holder) {
+			return nil, &LockError{Op: "Acquire", Path: path, PID: holder, Err: ErrLocked}
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, &LockError{Op: "Acquire", Path: path, Err: err}
+		}
+	}
+	return nil, &LockError{Op: "Acquire", Path: path, Err: ErrLocked}
+}
+
+// Release removes the lock, unless another process has taken it over since
+func (l *Lock) Release() error {
+	holder, err := readPID(l.path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && holder != l.pid) {
+		return nil
+	}
+	if err := os.Remove(l.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return &LockError{Op: "Release", Path: l.path, Err: err}
+	}
+	return nil
+}
+
+// Path returns where the lock file is
+func (l *Lock) Path() string {
+	return l.path
+}
+
+// readPID reads the process ID stored in a lock file
+func readPID(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid process ID %q", strings.TrimSpace(string(data)))
+	}
+	return pid, nil
+}
@@ -0,0 +1,58 @@
+package lockfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.lock")
+
+	lock, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if _, err := Acquire(path); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected a held lock to be refused, got %v", err)
+	} else if lockErr := (*LockError)(nil); !errors.As(err, &lockErr) || lockErr.PID != os.Getpid() {
+		t.Errorf("expected the holder's pid in %v", err)
+	}
+
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the lock file removed, got %v", err)
+	}
+
+	relocked, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire after release failed: %v", err)
+	}
+	defer relocked.Release()
+}
+
+func TestAcquireStale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.lock")
+
+	// No process can have this ID, so the lock was left behind
+	if err := os.WriteFile(path, []byte("999999999\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("expected a stale lock to be taken over, got %v", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Acquire(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("expected an unreadable lock to be treated as held, got %v", err)
+	}
+}
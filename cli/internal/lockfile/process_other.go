@@ -0,0 +1,15 @@
+//go:build !windows
+
+package lockfile
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processRunning reports whether a process with pid exists. Signal 0 checks without signaling; EPERM means it
+// exists but belongs to another user.
+func processRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,308 @@
+// Package packaging renders package manager metadata for skycli releases: a Homebrew formula and a Scoop manifest.
+//
+// Both point at release archives named name_version_os_arch, .tar.gz on macOS and Linux and .zip on Windows, each
+// holding the binary at its root. Checksums come from a sha256sum-style file published with the release; archives
+// missing from it get a placeholder to fill in before publishing.
+package packaging
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// MissingChecksum stands in for the checksum of an archive not listed in the checksums file
+const MissingChecksum = "REPLACE_WITH_SHA256"
+
+// VersionPlaceholder marks where the version goes in a release base URL
+const VersionPlaceholder = "{version}"
+
+// DefaultBaseURL is where GitHub serves the assets of a release tagged v<version>
+const DefaultBaseURL = "https://github.com/stormlightlabs/skypanel/releases/download/v" + VersionPlaceholder
+
+// Release describes a build to package
+type Release struct {
+	Name        string
+	Description string
+	Homepage    string
+	Repository  string
+	License     string
+	Version     string
+	// BaseURL is the URL of the directory holding the release archives, with [VersionPlaceholder] for the version
+	BaseURL string
+	// Checksums maps archive file names to their SHA-256 in hex
+	Checksums map[string]string
+}
+
+// Target is a platform release archives are built for
+type Target struct {
+	OS   string
+	Arch string
+}
+
+// Targets are the platforms with release archives
+var Targets = []Target{
+	{"darwin", "amd64"},
+	{"darwin", "arm64"},
+	{"linux", "amd64"},
+	{"linux", "arm64"},
+	{"windows", "amd64"},
+	{"windows", "arm64"},
+}
+
+// Archive returns the file name of the release archive for t at version
+func (r *Release) Archive(t Target, version string) string {
+	ext := ".tar.gz"
+	if t.OS == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s%s", r.Name, version, t.OS, t.Arch, ext)
+}
+
+// URL returns the download URL of the release archive for t at version
+func (r *Release) URL(t Target, version string) string {
+	base := strings.ReplaceAll(r.BaseURL, VersionPlaceholder, version)
+	return strings.TrimSuffix(base, "/") + "/" + r.Archive(t, version)
+}
+
+// Checksum returns the SHA-256 of the archive for t, or [MissingChecksum]
+func (r *Release) Checksum(t Target) string {
+	if sum, ok := r.Checksums[r.Archive(t, r.Version)]; ok {
+		return sum
+	}
+	return MissingChecksum
+}
+
+// Missing lists the archives for the operating systems goos (all of them when empty) without a checksum, in
+// [Targets] order
+func (r *Release) Missing(goos ...string) []string {
+	var missing []string
+	for _, t := range Targets {
+		if len(goos) > 0 && !slices.Contains(goos, t.OS) {
+			continue
+		}
+		if r.Checksum(t) == MissingChecksum {
+			missing = append(missing, r.Archive(t, r.Version))
+		}
+	}
+	return missing
+}
+
+// ParseChecksums reads sha256sum output: a hex digest and a file name per line, the name optionally marked binary
+// with a leading *. Paths are reduced to their file name.
+func ParseChecksums(r io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 || !isSHA256(fields[0]) {
+			return nil, &PackagingError{Op: "ParseChecksums", Err: fmt.Errorf("line %d is not a SHA-256 checksum and file name", line)}
+		}
+		name := strings.TrimPrefix(fields[1], "*")
+		name = name[strings.LastIndexAny(name, `/\`)+1:]
+		sums[name] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &PackagingError{Op: "ParseChecksums", Err: err}
+	}
+	return sums, nil
+}
+
+func isSHA256(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if !unicode.Is(unicode.ASCII_Hex_Digit, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// formulaTemplate is the Homebrew formula; archives are picked per OS and CPU with on_macos/on_linux and
+// on_arm/on_intel blocks
+var formulaTemplate = template.Must(template.New("formula").Funcs(template.FuncMap{"ruby": rubyString}).Parse(`class {{.Class}} < Formula
+  desc {{ruby .Description}}
+  homepage {{ruby .Homepage}}
+  version {{ruby .Version}}
+  license {{ruby .License}}
+{{range .Platforms}}
+  on_{{.Name}} do
+{{- range .Archives}}
+    on_{{.CPU}} do
+      url {{ruby .URL}}
+      sha256 {{ruby .SHA256}}
+    end
+{{- end}}
+  end
+{{end}}
+  def install
+    bin.install {{ruby .Binary}}
+  end
+
+  test do
+    assert_match version.to_s, shell_output("#{bin}/{{.Binary}} --version")
+  end
+end
+`))
+
+type formulaArchive struct {
+	CPU    string
+	URL    string
+	SHA256 string
+}
+
+type formulaPlatform struct {
+	Name     string
+	Archives []formulaArchive
+}
+
+// Homebrew renders a Homebrew formula for the macOS and Linux archives of r
+func Homebrew(r *Release) (string, error) {
+	platforms := []formulaPlatform{{Name: "macos"}, {Name: "linux"}}
+	for _, t := range Targets {
+		cpu := map[string]string{"amd64": "intel", "arm64": "arm"}[t.Arch]
+		archive := formulaArchive{CPU: cpu, URL: r.URL(t, r.Version), SHA256: r.Checksum(t)}
+		switch t.OS {
+		case "darwin":
+			platforms[0].Archives = append(platforms[0].Archives, archive)
+		case "linux":
+			platforms[1].Archives = append(platforms[1].Archives, archive)
+		}
+	}
+
+	var out strings.Builder
+	err := formulaTemplate.Execute(&out, map[string]any{
+		"Class":       formulaClass(r.Name),
+		"Description": formulaDescription(r.Description),
+		"Homepage":    r.Homepage,
+		"Version":     r.Version,
+		"License":     r.License,
+		"Platforms":   platforms,
+		"Binary":      r.Name,
+	})
+	if err != nil {
+		return "", &PackagingError{Op: "Homebrew", Err: err}
+	}
+	return out.String(), nil
+}
+
+// formulaClass turns a formula name into its Ruby class name, as Homebrew does: sky-cli becomes SkyCli
+func formulaClass(name string) string {
+	var class strings.Builder
+	upper := true
+	for _, c := range name {
+		if c == '-' || c == '_' || c == '.' {
+			upper = true
+			continue
+		}
+		if upper {
+			c = unicode.ToUpper(c)
+		}
+		class.WriteRune(c)
+		upper = false
+	}
+	return class.String()
+}
+
+// formulaDescription drops a leading article and capitalizes what remains, which brew audit asks of descriptions
+func formulaDescription(desc string) string {
+	for _, article := range []string{"A ", "An ", "The "} {
+		if rest, ok := strings.CutPrefix(desc, article); ok {
+			desc = rest
+			break
+		}
+	}
+	if desc == "" {
+		return desc
+	}
+	return strings.ToUpper(desc[:1]) + strings.TrimSuffix(desc[1:], ".")
+}
+
+// rubyString quotes s as a Ruby string literal that interpolates nothing
+func rubyString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "#", `\#`).Replace(s) + `"`
+}
+
+// scoopManifest is a Scoop app manifest; field order follows the Scoop documentation
+type scoopManifest struct {
+	Version      string                       `json:"version"`
+	Description  string                       `json:"description"`
+	Homepage     string                       `json:"homepage"`
+	License      string                       `json:"license"`
+	Architecture map[string]scoopArchitecture `json:"architecture"`
+	Bin          string                       `json:"bin"`
+	Checkver     map[string]string            `json:"checkver,omitempty"`
+	Autoupdate   *scoopAutoupdate             `json:"autoupdate,omitempty"`
+}
+
+type scoopArchitecture struct {
+	URL  string `json:"url"`
+	Hash string `json:"hash,omitempty"`
+}
+
+type scoopAutoupdate struct {
+	Architecture map[string]scoopArchitecture `json:"architecture"`
+	Hash         map[string]string            `json:"hash"`
+}
+
+// scoopArch maps Go architectures to Scoop's names for them
+var scoopArch = map[string]string{"amd64": "64bit", "arm64": "arm64"}
+
+// Scoop renders a Scoop manifest for the Windows archives of r. With a repository on GitHub, the manifest can
+// update itself: Scoop checks the latest release and reads new hashes from the checksums file beside the archives.
+func Scoop(r *Release) ([]byte, error) {
+	manifest := scoopManifest{
+		Version:      r.Version,
+		Description:  r.Description,
+		Homepage:     r.Homepage,
+		License:      r.License,
+		Architecture: map[string]scoopArchitecture{},
+		Bin:          r.Name + ".exe",
+	}
+
+	autoupdate := &scoopAutoupdate{Architecture: map[string]scoopArchitecture{}}
+	for _, t := range Targets {
+		if t.OS != "windows" {
+			continue
+		}
+		arch := scoopArch[t.Arch]
+		manifest.Architecture[arch] = scoopArchitecture{URL: r.URL(t, r.Version), Hash: r.Checksum(t)}
+		autoupdate.Architecture[arch] = scoopArchitecture{URL: r.URL(t, "$version")}
+	}
+
+	if strings.HasPrefix(r.Repository, "https://github.com/") {
+		manifest.Checkver = map[string]string{"github": r.Repository}
+		autoupdate.Hash = map[string]string{"url": "$baseurl/checksums.txt"}
+		manifest.Autoupdate = autoupdate
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return nil, &PackagingError{Op: "Scoop", Err: err}
+	}
+	return append(data, '\n'), nil
+}
+
+// PackagingError reports a failure rendering package metadata
+type PackagingError struct {
+	Op  string
+	Err error
+}
+
+func (e *PackagingError) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+func (e *PackagingError) Unwrap() error {
+	return e.Err
+}
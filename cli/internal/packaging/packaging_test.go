@@ -0,0 +1,95 @@
+package packaging
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testRelease(t *testing.T) *Release {
+	t.Helper()
+	sums, err := ParseChecksums(strings.NewReader(strings.Repeat("a", 64) + "  skycli_1.2.0_darwin_arm64.tar.gz\n\n" +
+		strings.Repeat("B", 64) + " *dist/skycli_1.2.0_windows_amd64.zip\n"))
+	if err != nil {
+		t.Fatalf("ParseChecksums failed: %v", err)
+	}
+	return &Release{
+		Name:        "skycli",
+		Description: "A companion CLI tool for your Bluesky feed ecosystem",
+		Homepage:    "https://example.com/skycli",
+		Repository:  "https://github.com/example/skycli",
+		License:     "MIT",
+		Version:     "1.2.0",
+		BaseURL:     "https://example.com/releases/v" + VersionPlaceholder + "/",
+		Checksums:   sums,
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	release := testRelease(t)
+	if len(release.Checksums) != 2 || release.Checksums["skycli_1.2.0_windows_amd64.zip"] != strings.Repeat("b", 64) {
+		t.Errorf("unexpected checksums %v", release.Checksums)
+	}
+
+	if _, err := ParseChecksums(strings.NewReader("not-a-sum skycli.zip\n")); err == nil {
+		t.Error("expected a malformed line to be refused")
+	}
+}
+
+func TestHomebrew(t *testing.T) {
+	release := testRelease(t)
+	formula, err := Homebrew(release)
+	if err != nil {
+		t.Fatalf("Homebrew failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"class Skycli < Formula",
+		`desc "Companion CLI tool for your Bluesky feed ecosystem"`,
+		`url "https://example.com/releases/v1.2.0/skycli_1.2.0_darwin_arm64.tar.gz"`,
+		`sha256 "` + strings.Repeat("a", 64) + `"`,
+		`sha256 "` + MissingChecksum + `"`,
+		`bin.install "skycli"`,
+	} {
+		if !strings.Contains(formula, want) {
+			t.Errorf("formula lacks %q:\n%s", want, formula)
+		}
+	}
+	if strings.Contains(formula, "windows") {
+		t.Errorf("formula mentions Windows archives:\n%s", formula)
+	}
+
+	if missing := release.Missing("darwin", "linux"); len(missing) != 3 {
+		t.Errorf("expected 3 archives without checksums, got %v", missing)
+	}
+}
+
+func TestScoop(t *testing.T) {
+	data, err := Scoop(testRelease(t))
+	if err != nil {
+		t.Fatalf("Scoop failed: %v", err)
+	}
+
+	var manifest scoopManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("manifest is not JSON: %v", err)
+	}
+	x64 := manifest.Architecture["64bit"]
+	if x64.URL != "https://example.com/releases/v1.2.0/skycli_1.2.0_windows_amd64.zip" || x64.Hash != strings.Repeat("b", 64) {
+		t.Errorf("unexpected 64bit entry %+v", x64)
+	}
+	if manifest.Bin != "skycli.exe" || manifest.Checkver["github"] != "https://github.com/example/skycli" {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+	if manifest.Autoupdate == nil || manifest.Autoupdate.Architecture["arm64"].URL != "https://example.com/releases/v$version/skycli_$version_windows_arm64.zip" {
+		t.Errorf("unexpected autoupdate %+v", manifest.Autoupdate)
+	}
+}
+
+func TestFormulaClass(t *testing.T) {
+	for name, want := range map[string]string{"skycli": "Skycli", "sky-cli": "SkyCli", "sky_panel.cli": "SkyPanelCli"} {
+		if got := formulaClass(name); got != want {
+			t.Errorf("formulaClass(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
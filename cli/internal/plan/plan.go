@@ -0,0 +1,82 @@
+package plan
+
+import (
+	"math"
+	"time"
+)
+
+// PageSize is the number of items cursor-paginated endpoints return per request
+const PageSize = 100
+
+// Limits describes the request budget commands run under
+type Limits struct {
+	Requests int           // requests allowed per window
+	Window   time.Duration // length of a rate-limit window
+	Latency  time.Duration // typical round trip of one request
+}
+
+// DefaultLimits are the AppView's published per-IP limit of 3000 requests per 5 minutes and a typical round trip
+var DefaultLimits = Limits{Requests: 3000, Window: 5 * time.Minute, Latency: 250 * time.Millisecond}
+
+// Step is one kind of request a command makes
+type Step struct {
+	Endpoint    string        `json:"endpoint"`           // XRPC method
+	Calls       int           `json:"calls"`              // requests expected after cache hits
+	Cached      int           `json:"cached,omitempty"`   // requests avoided by fresh cache entries
+	Concurrency int           `json:"concurrency"`        // requests in flight at once; 1 for pagination
+	Interval    time.Duration `json:"interval,omitempty"` // minimum delay between requests
+	Note        string        `json:"note,omitempty"`
+}
+
+// Estimate is the expected cost of running a command
+type Estimate struct {
+	Command  string        `json:"command"`
+	Steps    []Step        `json:"steps"`
+	Calls    int           `json:"calls"`
+	Cached   int           `json:"cached"`
+	Duration time.Duration `json:"duration"`
+	Windows  int           `json:"windows"` // rate-limit windows the requests span
+	Notes    []string      `json:"notes,omitempty"`
+}
+
+// Throttled reports whether the command needs more requests than one window allows
+func (e Estimate) Throttled() bool {
+	return e.Windows > 1
+}
+
+// Pages returns the requests needed to page through items
+func Pages(items int) int {
+	if items <= 0 {
+		return 1
+	}
+	return (items + PageSize - 1) / PageSize
+}
+
+// Hits splits n lookups into cache hits and misses given the share of fresh cache entries
+func Hits(n int, share float64) (hits, misses int) {
+	hits = int(math.Round(float64(n) * min(max(share, 0), 1)))
+	return hits, n - hits
+}
+
+// Estimate totals steps and predicts how long they take. Steps run one after another, each taking its calls
+// divided among its concurrent requests times the latency (or interval, when longer). Once the calls exceed a
+// window's budget, every further window adds at least its full length.
+func (l Limits) Estimate(command string, steps []Step, notes ...string) Estimate {
+	estimate := Estimate{Command: command, Steps: steps, Notes: notes}
+
+	for _, step := range steps {
+		estimate.Calls += step.Calls
+		estimate.Cached += step.Cached
+
+		concurrency := max(step.Concurrency, 1)
+		rounds := (step.Calls + concurrency - 1) / concurrency
+		estimate.Duration += time.Duration(rounds) * max(l.Latency, step.Interval)
+	}
+
+	estimate.Windows = 1
+	if l.Requests > 0 && estimate.Calls > 0 {
+		estimate.Windows = (estimate.Calls + l.Requests - 1) / l.Requests
+		estimate.Duration = max(estimate.Duration, time.Duration(estimate.Windows-1)*l.Window)
+	}
+	return estimate
+}
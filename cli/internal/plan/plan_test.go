@@ -0,0 +1,62 @@
+package plan
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPages verifies page counts round up and never drop below one request
+func TestPages(t *testing.T) {
+	tests := map[int]int{0: 1, 1: 1, 100: 1, 101: 2, 2500: 25}
+	for items, want := range tests {
+		if got := Pages(items); got != want {
+			t.Errorf("Pages(%d) = %d, want %d", items, got, want)
+		}
+	}
+}
+
+// TestHits verifies lookups split by cache share, clamped to [0, 1]
+func TestHits(t *testing.T) {
+	if hits, misses := Hits(200, 0.25); hits != 50 || misses != 150 {
+		t.Errorf("Hits(200, 0.25) = %d, %d", hits, misses)
+	}
+	if hits, misses := Hits(10, 1.5); hits != 10 || misses != 0 {
+		t.Errorf("Hits(10, 1.5) = %d, %d", hits, misses)
+	}
+	if hits, misses := Hits(10, -1); hits != 0 || misses != 10 {
+		t.Errorf("Hits(10, -1) = %d, %d", hits, misses)
+	}
+}
+
+// TestEstimate verifies totals, per-step timing, and rate-limit windows
+func TestEstimate(t *testing.T) {
+	limits := Limits{Requests: 100, Window: time.Minute, Latency: 100 * time.Millisecond}
+
+	t.Run("WithinWindow", func(t *testing.T) {
+		estimate := limits.Estimate("x", []Step{
+			{Endpoint: "pages", Calls: 5, Concurrency: 1},
+			{Endpoint: "lookups", Calls: 40, Cached: 10, Concurrency: 10},
+			{Endpoint: "writes", Calls: 2, Concurrency: 1, Interval: time.Second},
+		})
+		if estimate.Calls != 47 || estimate.Cached != 10 {
+			t.Errorf("calls = %d, cached = %d", estimate.Calls, estimate.Cached)
+		}
+		// 5 sequential pages, 4 rounds of lookups, and 2 paced writes
+		if want := 900*time.Millisecond + 2*time.Second; estimate.Duration != want {
+			t.Errorf("duration = %s, want %s", estimate.Duration, want)
+		}
+		if estimate.Throttled() {
+			t.Error("expected estimate to fit in one window")
+		}
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		estimate := limits.Estimate("x", []Step{{Endpoint: "lookups", Calls: 250, Concurrency: 50}})
+		if estimate.Windows != 3 || !estimate.Throttled() {
+			t.Errorf("windows = %d", estimate.Windows)
+		}
+		if estimate.Duration != 2*time.Minute {
+			t.Errorf("duration = %s, want the two extra windows", estimate.Duration)
+		}
+	})
+}
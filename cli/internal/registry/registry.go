@@ -3,9 +3,11 @@ package registry
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 )
 
 var (
@@ -15,15 +17,43 @@ var (
 
 // Registry manages singleton instances of repositories and services
 type Registry struct {
-	service      *store.BlueskyService
-	sessionRepo  *store.SessionRepository
-	feedRepo     *store.FeedRepository
-	postRepo     *store.PostRepository
-	profileRepo  *store.ProfileRepository
-	snapshotRepo *store.SnapshotRepository
-	cacheRepo    *store.CacheRepository
-	initialized  bool
-	mu           sync.RWMutex
+	service      *bsky.BlueskyService
+	sessionRepo  *bsky.SessionRepository
+	feedRepo     *bsky.FeedRepository
+	postRepo     *bsky.PostRepository
+	profileRepo  *bsky.ProfileRepository
+	snapshotRepo bsky.SnapshotStore
+	cacheRepo    bsky.CacheStore
+	archiveRepo  *bsky.ArchiveRepository
+	actionRepo   *bsky.ActionRepository
+	reportRepo   *bsky.ReportRepository
+	labelRepo    *bsky.LabelRepository
+	stateRepo    *bsky.FollowerStateRepository
+	cursorRepo   *bsky.StreamCursorRepository
+	eventRepo    *bsky.StreamEventRepository
+	quotaRepo    *bsky.QuotaRepository
+	tagRepo      *bsky.TagRepository
+	noteRepo     *bsky.NoteRepository
+	readRepo     *bsky.ReadPositionRepository
+	draftRepo    *bsky.DraftRepository
+	// Narrow views consumed by command actions; default to service and cacheRepo
+	followerFetcher bsky.FollowerFetcher
+	profileFetcher  bsky.ProfileFetcher
+	graphWriter     bsky.GraphWriter
+	feedReader      bsky.FeedReader
+	postWriter      bsky.PostWriter
+	engagement      bsky.EngagementFetcher
+	notifications   bsky.NotificationFetcher
+	records         bsky.RecordFetcher
+	reporter        bsky.Reporter
+	preferences     bsky.PreferencesFetcher
+	prefsWriter     bsky.PreferencesWriter
+	notifySettings  bsky.NotificationSettings
+	appPasswords    bsky.AppPasswordManager
+	rateCache       bsky.RateCache
+	teamMode        bool
+	initialized     bool
+	mu              sync.RWMutex
 }
 
 // Get returns the singleton registry instance
@@ -36,6 +66,134 @@ func Get() *Registry {
 	return instance
 }
 
+// Dependencies holds the services and repositories wired into a registry by [New].
+// Nil narrow views default to Service and CacheRepo.
+type Dependencies struct {
+	Service         *bsky.BlueskyService
+	SessionRepo     *bsky.SessionRepository
+	FeedRepo        *bsky.FeedRepository
+	PostRepo        *bsky.PostRepository
+	ProfileRepo     *bsky.ProfileRepository
+	SnapshotRepo    bsky.SnapshotStore
+	CacheRepo       bsky.CacheStore
+	ArchiveRepo     *bsky.ArchiveRepository
+	ActionRepo      *bsky.ActionRepository
+	ReportRepo      *bsky.ReportRepository
+	LabelRepo       *bsky.LabelRepository
+	StateRepo       *bsky.FollowerStateRepository
+	CursorRepo      *bsky.StreamCursorRepository
+	EventRepo       *bsky.StreamEventRepository
+	QuotaRepo       *bsky.QuotaRepository
+	TagRepo         *bsky.TagRepository
+	NoteRepo        *bsky.NoteRepository
+	ReadRepo        *bsky.ReadPositionRepository
+	DraftRepo       *bsky.DraftRepository
+	FollowerFetcher bsky.FollowerFetcher
+	ProfileFetcher  bsky.ProfileFetcher
+	GraphWriter     bsky.GraphWriter
+	FeedReader      bsky.FeedReader
+	PostWriter      bsky.PostWriter
+	Engagement      bsky.EngagementFetcher
+	Notifications   bsky.NotificationFetcher
+	Records         bsky.RecordFetcher
+	Reporter        bsky.Reporter
+	Preferences     bsky.PreferencesFetcher
+	PrefsWriter     bsky.PreferencesWriter
+	NotifySettings  bsky.NotificationSettings
+	AppPasswords    bsky.AppPasswordManager
+	RateCache       bsky.RateCache
+	TeamMode        bool
+}
+
+// New returns an initialized registry backed by the given dependencies instead of the local databases.
+// Used to run command actions against fakes; the process-wide registry from [Get] is unaffected.
+func New(deps Dependencies) *Registry {
+	r := &Registry{
+		service:         deps.Service,
+		sessionRepo:     deps.SessionRepo,
+		feedRepo:        deps.FeedRepo,
+		postRepo:        deps.PostRepo,
+		profileRepo:     deps.ProfileRepo,
+		snapshotRepo:    deps.SnapshotRepo,
+		cacheRepo:       deps.CacheRepo,
+		archiveRepo:     deps.ArchiveRepo,
+		actionRepo:      deps.ActionRepo,
+		reportRepo:      deps.ReportRepo,
+		labelRepo:       deps.LabelRepo,
+		stateRepo:       deps.StateRepo,
+		cursorRepo:      deps.CursorRepo,
+		eventRepo:       deps.EventRepo,
+		quotaRepo:       deps.QuotaRepo,
+		tagRepo:         deps.TagRepo,
+		noteRepo:        deps.NoteRepo,
+		readRepo:        deps.ReadRepo,
+		draftRepo:       deps.DraftRepo,
+		followerFetcher: deps.FollowerFetcher,
+		profileFetcher:  deps.ProfileFetcher,
+		graphWriter:     deps.GraphWriter,
+		feedReader:      deps.FeedReader,
+		postWriter:      deps.PostWriter,
+		engagement:      deps.Engagement,
+		notifications:   deps.Notifications,
+		records:         deps.Records,
+		reporter:        deps.Reporter,
+		preferences:     deps.Preferences,
+		prefsWriter:     deps.PrefsWriter,
+		notifySettings:  deps.NotifySettings,
+		appPasswords:    deps.AppPasswords,
+		rateCache:       deps.RateCache,
+		teamMode:        deps.TeamMode,
+		initialized:     true,
+	}
+
+	if deps.Service != nil {
+		if r.followerFetcher == nil {
+			r.followerFetcher = deps.Service
+		}
+		if r.profileFetcher == nil {
+			r.profileFetcher = deps.Service
+		}
+		if r.graphWriter == nil {
+			r.graphWriter = deps.Service
+		}
+		if r.feedReader == nil {
+			r.feedReader = deps.Service
+		}
+		if r.postWriter == nil {
+			r.postWriter = deps.Service
+		}
+		if r.engagement == nil {
+			r.engagement = deps.Service
+		}
+		if r.notifications == nil {
+			r.notifications = deps.Service
+		}
+		if r.records == nil {
+			r.records = deps.Service
+		}
+		if r.reporter == nil {
+			r.reporter = deps.Service
+		}
+		if r.preferences == nil {
+			r.preferences = deps.Service
+		}
+		if r.prefsWriter == nil {
+			r.prefsWriter = deps.Service
+		}
+		if r.notifySettings == nil {
+			r.notifySettings = deps.Service
+		}
+		if r.appPasswords == nil {
+			r.appPasswords = deps.Service
+		}
+	}
+	if r.rateCache == nil && deps.CacheRepo != nil {
+		r.rateCache = deps.CacheRepo
+	}
+
+	return r
+}
+
 // Init initializes all repositories and runs database migrations
 // Must be called before using any repository or service
 func (r *Registry) Init(ctx context.Context) error {
@@ -46,7 +204,7 @@ func (r *Registry) Init(ctx context.Context) error {
 		return nil
 	}
 
-	sessionRepo, err := store.NewSessionRepository()
+	sessionRepo, err := bsky.NewSessionRepository()
 	if err != nil {
 		return &RegistryError{Op: "InitSessionRepo", Err: err}
 	}
@@ -55,7 +213,7 @@ func (r *Registry) Init(ctx context.Context) error {
 	}
 	r.sessionRepo = sessionRepo
 
-	feedRepo, err := store.NewFeedRepository()
+	feedRepo, err := bsky.NewFeedRepository()
 	if err != nil {
 		return &RegistryError{Op: "InitFeedRepo", Err: err}
 	}
@@ -64,16 +222,46 @@ func (r *Registry) Init(ctx context.Context) error {
 	}
 	r.feedRepo = feedRepo
 
-	postRepo, err := store.NewPostRepository()
+	cfg, err := config.Load()
+	if err != nil {
+		return &RegistryError{Op: "LoadConfig", Err: err}
+	}
+	driver, dbURL := cfg.DatabaseDriver()
+	dialect, err := bsky.ParseDialect(driver)
+	if err != nil {
+		return &RegistryError{Op: "LoadConfig", Err: err}
+	}
+	teamURL, err := cfg.TeamDatabaseURL()
+	if err != nil {
+		return &RegistryError{Op: "LoadConfig", Err: err}
+	}
+	timeouts, err := bsky.TimeoutsFromConfig(cfg.Timeouts)
+	if err != nil {
+		return &RegistryError{Op: "LoadConfig", Err: err}
+	}
+
+	// Team mode shares snapshots and analytics even when posts stay local
+	sharedURL := teamURL
+	if sharedURL == "" && dialect == bsky.DialectPostgres {
+		sharedURL = dbURL
+	}
+
+	var postRepo *bsky.PostRepository
+	if dialect == bsky.DialectPostgres {
+		postRepo, err = bsky.NewPostgresPostRepository(dbURL)
+	} else {
+		postRepo, err = bsky.NewPostRepository()
+	}
 	if err != nil {
 		return &RegistryError{Op: "InitPostRepo", Err: err}
 	}
 	if err := postRepo.Init(ctx); err != nil {
+		postRepo.Close()
 		return &RegistryError{Op: "InitPostRepo", Err: err}
 	}
 	r.postRepo = postRepo
 
-	profileRepo, err := store.NewProfileRepository()
+	profileRepo, err := bsky.NewProfileRepository()
 	if err != nil {
 		return &RegistryError{Op: "InitProfileRepo", Err: err}
 	}
@@ -82,45 +270,249 @@ func (r *Registry) Init(ctx context.Context) error {
 	}
 	r.profileRepo = profileRepo
 
-	snapshotRepo, err := store.NewSnapshotRepository()
+	var snapshotRepo bsky.SnapshotStore
+	var cacheRepo *bsky.CacheRepository
+	if sharedURL != "" {
+		snapshotRepo, err = bsky.NewPostgresSnapshotRepository(sharedURL)
+	} else {
+		snapshotRepo, err = bsky.NewSnapshotRepository()
+	}
 	if err != nil {
 		return &RegistryError{Op: "InitSnapshotRepo", Err: err}
 	}
 	if err := snapshotRepo.Init(ctx); err != nil {
-		return &RegistryError{Op: "InitSnapshotRepo", Err: err}
+		snapshotRepo.Close()
+		return &RegistryError{Op: "InitSnapshotRepo", Err: teamModeHint(teamURL, err)}
 	}
 	r.snapshotRepo = snapshotRepo
 
-	cacheRepo, err := store.NewCacheRepository()
+	if sharedURL != "" {
+		cacheRepo, err = bsky.NewPostgresCacheRepository(sharedURL)
+	} else {
+		cacheRepo, err = bsky.NewCacheRepository()
+	}
 	if err != nil {
 		return &RegistryError{Op: "InitCacheRepo", Err: err}
 	}
 	if err := cacheRepo.Init(ctx); err != nil {
-		return &RegistryError{Op: "InitCacheRepo", Err: err}
+		cacheRepo.Close()
+		return &RegistryError{Op: "InitCacheRepo", Err: teamModeHint(teamURL, err)}
 	}
+	cacheRepo.SetMaxSize(cfg.CacheMaxSize())
 	r.cacheRepo = cacheRepo
+	r.teamMode = teamURL != ""
+
+	archiveRepo, err := bsky.NewArchiveRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitArchiveRepo", Err: err}
+	}
+	if err := archiveRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitArchiveRepo", Err: err}
+	}
+	r.archiveRepo = archiveRepo
+
+	actionRepo, err := bsky.NewActionRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitActionRepo", Err: err}
+	}
+	if err := actionRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitActionRepo", Err: err}
+	}
+	r.actionRepo = actionRepo
+
+	reportRepo, err := bsky.NewReportRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitReportRepo", Err: err}
+	}
+	if err := reportRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitReportRepo", Err: err}
+	}
+	r.reportRepo = reportRepo
+
+	labelRepo, err := bsky.NewLabelRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitLabelRepo", Err: err}
+	}
+	if err := labelRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitLabelRepo", Err: err}
+	}
+	r.labelRepo = labelRepo
 
-	r.service = store.NewBlueskyService("")
+	stateRepo, err := bsky.NewFollowerStateRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitFollowerStateRepo", Err: err}
+	}
+	if err := stateRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitFollowerStateRepo", Err: err}
+	}
+	r.stateRepo = stateRepo
+
+	cursorRepo, err := bsky.NewStreamCursorRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitStreamCursorRepo", Err: err}
+	}
+	if err := cursorRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitStreamCursorRepo", Err: err}
+	}
+	r.cursorRepo = cursorRepo
+
+	eventRepo, err := bsky.NewStreamEventRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitStreamEventRepo", Err: err}
+	}
+	if err := eventRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitStreamEventRepo", Err: err}
+	}
+	r.eventRepo = eventRepo
+
+	quotaRepo, err := bsky.NewQuotaRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitQuotaRepo", Err: err}
+	}
+	if err := quotaRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitQuotaRepo", Err: err}
+	}
+	r.quotaRepo = quotaRepo
+
+	tagRepo, err := bsky.NewTagRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitTagRepo", Err: err}
+	}
+	if err := tagRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitTagRepo", Err: err}
+	}
+	r.tagRepo = tagRepo
+
+	noteRepo, err := bsky.NewNoteRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitNoteRepo", Err: err}
+	}
+	if err := noteRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitNoteRepo", Err: err}
+	}
+	r.noteRepo = noteRepo
+
+	readRepo, err := bsky.NewReadPositionRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitReadPositionRepo", Err: err}
+	}
+	if err := readRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitReadPositionRepo", Err: err}
+	}
+	r.readRepo = readRepo
 
-	if sessionRepo.HasValidSession(ctx) {
-		accessToken, err := sessionRepo.GetAccessToken(ctx)
-		if err == nil {
-			refreshToken, _ := sessionRepo.GetRefreshToken(ctx)
-			r.service.SetTokens(accessToken, refreshToken)
+	draftRepo, err := bsky.NewDraftRepository()
+	if err != nil {
+		return &RegistryError{Op: "InitDraftRepo", Err: err}
+	}
+	if err := draftRepo.Init(ctx); err != nil {
+		return &RegistryError{Op: "InitDraftRepo", Err: err}
+	}
+	r.draftRepo = draftRepo
 
-			if did, err := sessionRepo.GetDid(ctx); err == nil {
-				r.service.SetDid(did)
-			}
-			if handle, err := sessionRepo.GetHandle(ctx); err == nil {
-				r.service.SetHandle(handle)
-			}
+	if cfg.Network != nil {
+		transportOpts, err := bsky.TransportOptionsFromConfig(cfg.Network)
+		if err != nil {
+			return &RegistryError{Op: "InitTransport", Err: err}
+		}
+		transport, err := bsky.NewTransport(transportOpts)
+		if err != nil {
+			return &RegistryError{Op: "InitTransport", Err: err}
 		}
+		bsky.SetSharedTransport(transport)
+	}
+
+	r.service = bsky.NewBlueskyService("")
+	r.service.SetTimeouts(timeouts)
+	r.service.SetQuotaRecorder(quotaRepo)
+	r.followerFetcher = r.service
+	r.profileFetcher = r.service
+	r.graphWriter = r.service
+	r.feedReader = r.service
+	r.postWriter = r.service
+	r.engagement = r.service
+	r.notifications = r.service
+	r.records = r.service
+	r.reporter = r.service
+	r.preferences = r.service
+	r.prefsWriter = r.service
+	r.notifySettings = r.service
+	r.appPasswords = r.service
+	r.rateCache = r.cacheRepo
+
+	if err := r.loadSession(ctx); err != nil && !errors.Is(err, errNoSession) {
+		return err
 	}
 
 	r.initialized = true
 	return nil
 }
 
+// errNoSession reports that the session repository's current account has no usable session
+var errNoSession = errors.New("no valid session")
+
+// loadSession signs the service in as the session repository's current account, returning errNoSession and
+// leaving the service as it was when that account can't be signed in. Callers must hold r.mu.
+func (r *Registry) loadSession(ctx context.Context) error {
+	if !r.sessionRepo.HasValidSession(ctx) {
+		return errNoSession
+	}
+	accessToken, err := r.sessionRepo.GetAccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errNoSession, err)
+	}
+	refreshToken, _ := r.sessionRepo.GetRefreshToken(ctx)
+	r.service.SetTokens(accessToken, refreshToken)
+	r.service.SetTokenStore(r.sessionRepo)
+
+	oauth, err := r.sessionRepo.GetOAuth(ctx)
+	if err != nil {
+		return &RegistryError{Op: "InitOAuthSession", Err: err}
+	}
+	serviceURL, _ := r.sessionRepo.GetServiceURL(ctx)
+	if err := r.service.SetOAuth(serviceURL, oauth); err != nil {
+		return &RegistryError{Op: "InitOAuthSession", Err: err}
+	}
+
+	if did, err := r.sessionRepo.GetDid(ctx); err == nil {
+		r.service.SetDid(did)
+	}
+	if handle, err := r.sessionRepo.GetHandle(ctx); err == nil {
+		r.service.SetHandle(handle)
+	}
+	return nil
+}
+
+// UseAccount signs the service in as another signed-in account, given by DID or handle, for the rest of the
+// process. The default account is unchanged. An account whose session expired or can't be loaded is an error
+// rather than leaving the service signed in as the previous account.
+func (r *Registry) UseAccount(ctx context.Context, account string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.initialized {
+		return &RegistryError{Op: "UseAccount", Err: errors.New("registry not initialized")}
+	}
+	if r.sessionRepo == nil || r.service == nil {
+		return &RegistryError{Op: "UseAccount", Err: errors.New("session repository not available")}
+	}
+
+	previous, _ := r.sessionRepo.GetDid(ctx)
+	if err := r.sessionRepo.Use(ctx, account); err != nil {
+		return err
+	}
+	err := r.loadSession(ctx)
+	if errors.Is(err, errNoSession) {
+		// Point the repository back at the account the service is still signed in as, so refreshed tokens
+		// aren't stored under the wrong account
+		if previous != "" {
+			r.sessionRepo.Use(ctx, previous)
+		}
+		return &RegistryError{Op: "UseAccount", Err: fmt.Errorf("%s: %w; run 'skycli login' again", account, err)}
+	}
+	return err
+}
+
 // Close releases all repository and service resources
 func (r *Registry) Close() error {
 	r.mu.Lock()
@@ -170,6 +562,79 @@ func (r *Registry) Close() error {
 		}
 	}
 
+	if r.archiveRepo != nil {
+		if err := r.archiveRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.actionRepo != nil {
+		if err := r.actionRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.reportRepo != nil {
+		if err := r.reportRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.labelRepo != nil {
+		if err := r.labelRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.stateRepo != nil {
+		if err := r.stateRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.cursorRepo != nil {
+		if err := r.cursorRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.eventRepo != nil {
+		if err := r.eventRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.quotaRepo != nil {
+		if err := r.quotaRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.tagRepo != nil {
+		if err := r.tagRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.noteRepo != nil {
+		if err := r.noteRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.readRepo != nil {
+		if err := r.readRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.draftRepo != nil {
+		if err := r.draftRepo.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	r.teamMode = false
 	r.initialized = false
 
 	if len(errs) > 0 {
@@ -180,7 +645,7 @@ func (r *Registry) Close() error {
 }
 
 // GetService returns the BlueskyService singleton
-func (r *Registry) GetService() (*store.BlueskyService, error) {
+func (r *Registry) GetService() (*bsky.BlueskyService, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -195,8 +660,104 @@ func (r *Registry) GetService() (*store.BlueskyService, error) {
 	return r.service, nil
 }
 
+// GetFollowerFetcher returns the follower/follow graph client used by command actions
+func (r *Registry) GetFollowerFetcher() (bsky.FollowerFetcher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetFollowerFetcher", Err: errors.New("registry not initialized")}
+	}
+
+	if r.followerFetcher == nil {
+		return nil, &RegistryError{Op: "GetFollowerFetcher", Err: errors.New("follower fetcher not available")}
+	}
+
+	return r.followerFetcher, nil
+}
+
+// GetProfileFetcher returns the profile and activity client used by command actions
+func (r *Registry) GetProfileFetcher() (bsky.ProfileFetcher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetProfileFetcher", Err: errors.New("registry not initialized")}
+	}
+
+	if r.profileFetcher == nil {
+		return nil, &RegistryError{Op: "GetProfileFetcher", Err: errors.New("profile fetcher not available")}
+	}
+
+	return r.profileFetcher, nil
+}
+
+// GetGraphWriter returns the social graph writer used by command actions
+func (r *Registry) GetGraphWriter() (bsky.GraphWriter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetGraphWriter", Err: errors.New("registry not initialized")}
+	}
+
+	if r.graphWriter == nil {
+		return nil, &RegistryError{Op: "GetGraphWriter", Err: errors.New("graph writer not available")}
+	}
+
+	return r.graphWriter, nil
+}
+
+// GetFeedReader returns the timeline and feed reader used by command actions
+func (r *Registry) GetFeedReader() (bsky.FeedReader, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetFeedReader", Err: errors.New("registry not initialized")}
+	}
+
+	if r.feedReader == nil {
+		return nil, &RegistryError{Op: "GetFeedReader", Err: errors.New("feed reader not available")}
+	}
+
+	return r.feedReader, nil
+}
+
+// GetPostWriter returns the writer for likes, reposts, and replies used by command actions
+func (r *Registry) GetPostWriter() (bsky.PostWriter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetPostWriter", Err: errors.New("registry not initialized")}
+	}
+
+	if r.postWriter == nil {
+		return nil, &RegistryError{Op: "GetPostWriter", Err: errors.New("post writer not available")}
+	}
+
+	return r.postWriter, nil
+}
+
+// GetRateCache returns the post rate and activity cache used by command actions
+func (r *Registry) GetRateCache() (bsky.RateCache, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetRateCache", Err: errors.New("registry not initialized")}
+	}
+
+	if r.rateCache == nil {
+		return nil, &RegistryError{Op: "GetRateCache", Err: errors.New("rate cache not available")}
+	}
+
+	return r.rateCache, nil
+}
+
 // GetSessionRepo returns the SessionRepository singleton
-func (r *Registry) GetSessionRepo() (*store.SessionRepository, error) {
+func (r *Registry) GetSessionRepo() (*bsky.SessionRepository, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -212,7 +773,7 @@ func (r *Registry) GetSessionRepo() (*store.SessionRepository, error) {
 }
 
 // GetFeedRepo returns the FeedRepository singleton
-func (r *Registry) GetFeedRepo() (*store.FeedRepository, error) {
+func (r *Registry) GetFeedRepo() (*bsky.FeedRepository, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -228,7 +789,7 @@ func (r *Registry) GetFeedRepo() (*store.FeedRepository, error) {
 }
 
 // GetPostRepo returns the PostRepository singleton
-func (r *Registry) GetPostRepo() (*store.PostRepository, error) {
+func (r *Registry) GetPostRepo() (*bsky.PostRepository, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -244,7 +805,7 @@ func (r *Registry) GetPostRepo() (*store.PostRepository, error) {
 }
 
 // GetProfileRepo returns the ProfileRepository singleton
-func (r *Registry) GetProfileRepo() (*store.ProfileRepository, error) {
+func (r *Registry) GetProfileRepo() (*bsky.ProfileRepository, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -259,8 +820,8 @@ func (r *Registry) GetProfileRepo() (*store.ProfileRepository, error) {
 	return r.profileRepo, nil
 }
 
-// GetSnapshotRepo returns the SnapshotRepository singleton
-func (r *Registry) GetSnapshotRepo() (*store.SnapshotRepository, error) {
+// GetSnapshotRepo returns the snapshot store singleton (shared Postgres in team mode, local SQLite otherwise)
+func (r *Registry) GetSnapshotRepo() (bsky.SnapshotStore, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -275,8 +836,8 @@ func (r *Registry) GetSnapshotRepo() (*store.SnapshotRepository, error) {
 	return r.snapshotRepo, nil
 }
 
-// GetCacheRepo returns the CacheRepository singleton
-func (r *Registry) GetCacheRepo() (*store.CacheRepository, error) {
+// GetCacheRepo returns the cache store singleton (shared Postgres in team mode, local SQLite otherwise)
+func (r *Registry) GetCacheRepo() (bsky.CacheStore, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -291,6 +852,333 @@ func (r *Registry) GetCacheRepo() (*store.CacheRepository, error) {
 	return r.cacheRepo, nil
 }
 
+// GetArchiveRepo returns the ArchiveRepository singleton
+func (r *Registry) GetArchiveRepo() (*bsky.ArchiveRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetArchiveRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.archiveRepo == nil {
+		return nil, &RegistryError{Op: "GetArchiveRepo", Err: errors.New("archive repository not available")}
+	}
+
+	return r.archiveRepo, nil
+}
+
+// GetEngagementFetcher returns the post engagement client (likes, reposts) used by command actions
+func (r *Registry) GetEngagementFetcher() (bsky.EngagementFetcher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetEngagementFetcher", Err: errors.New("registry not initialized")}
+	}
+
+	if r.engagement == nil {
+		return nil, &RegistryError{Op: "GetEngagementFetcher", Err: errors.New("engagement fetcher not available")}
+	}
+
+	return r.engagement, nil
+}
+
+// GetNotificationFetcher returns the notifications client used by command actions
+func (r *Registry) GetNotificationFetcher() (bsky.NotificationFetcher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetNotificationFetcher", Err: errors.New("registry not initialized")}
+	}
+
+	if r.notifications == nil {
+		return nil, &RegistryError{Op: "GetNotificationFetcher", Err: errors.New("notification fetcher not available")}
+	}
+
+	return r.notifications, nil
+}
+
+// GetRecordFetcher returns the repository record client used by command actions
+func (r *Registry) GetRecordFetcher() (bsky.RecordFetcher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetRecordFetcher", Err: errors.New("registry not initialized")}
+	}
+
+	if r.records == nil {
+		return nil, &RegistryError{Op: "GetRecordFetcher", Err: errors.New("record fetcher not available")}
+	}
+
+	return r.records, nil
+}
+
+// GetActionRepo returns the undo log of follows and list additions made by skycli
+func (r *Registry) GetActionRepo() (*bsky.ActionRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetActionRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.actionRepo == nil {
+		return nil, &RegistryError{Op: "GetActionRepo", Err: errors.New("action repository not available")}
+	}
+
+	return r.actionRepo, nil
+}
+
+// GetReporter returns the moderation report client used by command actions
+func (r *Registry) GetReporter() (bsky.Reporter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetReporter", Err: errors.New("registry not initialized")}
+	}
+
+	if r.reporter == nil {
+		return nil, &RegistryError{Op: "GetReporter", Err: errors.New("reporter not available")}
+	}
+
+	return r.reporter, nil
+}
+
+// GetReportRepo returns the local log of filed moderation reports
+func (r *Registry) GetReportRepo() (*bsky.ReportRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetReportRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.reportRepo == nil {
+		return nil, &RegistryError{Op: "GetReportRepo", Err: errors.New("report repository not available")}
+	}
+
+	return r.reportRepo, nil
+}
+
+// GetPreferencesFetcher returns the app preferences client used by command actions
+func (r *Registry) GetPreferencesFetcher() (bsky.PreferencesFetcher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetPreferencesFetcher", Err: errors.New("registry not initialized")}
+	}
+
+	if r.preferences == nil {
+		return nil, &RegistryError{Op: "GetPreferencesFetcher", Err: errors.New("preferences fetcher not available")}
+	}
+
+	return r.preferences, nil
+}
+
+// GetPreferencesWriter returns the client that reads and replaces app preferences
+func (r *Registry) GetPreferencesWriter() (bsky.PreferencesWriter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetPreferencesWriter", Err: errors.New("registry not initialized")}
+	}
+
+	if r.prefsWriter == nil {
+		return nil, &RegistryError{Op: "GetPreferencesWriter", Err: errors.New("preferences writer not available")}
+	}
+
+	return r.prefsWriter, nil
+}
+
+// GetNotificationSettings returns the notification preferences client used by command actions
+func (r *Registry) GetNotificationSettings() (bsky.NotificationSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetNotificationSettings", Err: errors.New("registry not initialized")}
+	}
+
+	if r.notifySettings == nil {
+		return nil, &RegistryError{Op: "GetNotificationSettings", Err: errors.New("notification settings not available")}
+	}
+
+	return r.notifySettings, nil
+}
+
+// GetAppPasswordManager returns the app password client used by command actions
+func (r *Registry) GetAppPasswordManager() (bsky.AppPasswordManager, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetAppPasswordManager", Err: errors.New("registry not initialized")}
+	}
+
+	if r.appPasswords == nil {
+		return nil, &RegistryError{Op: "GetAppPasswordManager", Err: errors.New("app password manager not available")}
+	}
+
+	return r.appPasswords, nil
+}
+
+// GetLabelRepo returns the log of moderation labels already seen on the user's own content
+func (r *Registry) GetLabelRepo() (*bsky.LabelRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetLabelRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.labelRepo == nil {
+		return nil, &RegistryError{Op: "GetLabelRepo", Err: errors.New("label repository not available")}
+	}
+
+	return r.labelRepo, nil
+}
+
+// GetFollowerStateRepo returns the live follower set maintained by the daemon's stream task
+func (r *Registry) GetFollowerStateRepo() (*bsky.FollowerStateRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetFollowerStateRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.stateRepo == nil {
+		return nil, &RegistryError{Op: "GetFollowerStateRepo", Err: errors.New("follower state repository not available")}
+	}
+
+	return r.stateRepo, nil
+}
+
+// GetStreamCursorRepo returns the stored positions of the daemon's firehose subscriptions
+func (r *Registry) GetStreamCursorRepo() (*bsky.StreamCursorRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetStreamCursorRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.cursorRepo == nil {
+		return nil, &RegistryError{Op: "GetStreamCursorRepo", Err: errors.New("stream cursor repository not available")}
+	}
+
+	return r.cursorRepo, nil
+}
+
+// GetStreamEventRepo returns the log of firehose events the daemon's stream consumers have processed
+func (r *Registry) GetStreamEventRepo() (*bsky.StreamEventRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetStreamEventRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.eventRepo == nil {
+		return nil, &RegistryError{Op: "GetStreamEventRepo", Err: errors.New("stream event repository not available")}
+	}
+
+	return r.eventRepo, nil
+}
+
+// GetQuotaRepo returns the rate-limit budgets API hosts last reported
+func (r *Registry) GetQuotaRepo() (*bsky.QuotaRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetQuotaRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.quotaRepo == nil {
+		return nil, &RegistryError{Op: "GetQuotaRepo", Err: errors.New("quota repository not available")}
+	}
+
+	return r.quotaRepo, nil
+}
+
+// GetTagRepo returns the local tags on feeds, stored posts, and accounts
+func (r *Registry) GetTagRepo() (*bsky.TagRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetTagRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.tagRepo == nil {
+		return nil, &RegistryError{Op: "GetTagRepo", Err: errors.New("tag repository not available")}
+	}
+
+	return r.tagRepo, nil
+}
+
+// GetNoteRepo returns the local notes on accounts
+func (r *Registry) GetNoteRepo() (*bsky.NoteRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetNoteRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.noteRepo == nil {
+		return nil, &RegistryError{Op: "GetNoteRepo", Err: errors.New("note repository not available")}
+	}
+
+	return r.noteRepo, nil
+}
+
+// GetReadPositionRepo returns where the feed reader left off in each feed
+func (r *Registry) GetReadPositionRepo() (*bsky.ReadPositionRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetReadPositionRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.readRepo == nil {
+		return nil, &RegistryError{Op: "GetReadPositionRepo", Err: errors.New("read position repository not available")}
+	}
+
+	return r.readRepo, nil
+}
+
+// GetDraftRepo returns the post drafts saved from the compose screen
+func (r *Registry) GetDraftRepo() (*bsky.DraftRepository, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.initialized {
+		return nil, &RegistryError{Op: "GetDraftRepo", Err: errors.New("registry not initialized")}
+	}
+
+	if r.draftRepo == nil {
+		return nil, &RegistryError{Op: "GetDraftRepo", Err: errors.New("draft repository not available")}
+	}
+
+	return r.draftRepo, nil
+}
+
+// TeamMode reports whether snapshots and analytics are stored in the shared team database
+func (r *Registry) TeamMode() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.teamMode
+}
+
 // IsInitialized returns whether the registry has been initialized
 func (r *Registry) IsInitialized() bool {
 	r.mu.RLock()
@@ -298,6 +1186,14 @@ func (r *Registry) IsInitialized() bool {
 	return r.initialized
 }
 
+// teamModeHint explains how to fall back to local storage when the shared team database is unreachable
+func teamModeHint(teamURL string, err error) error {
+	if teamURL == "" {
+		return err
+	}
+	return fmt.Errorf("team database unavailable (remove the \"team\" section from the config file to use local storage): %w", err)
+}
+
 // RegistryError represents an error that occurred during registry operations
 type RegistryError struct {
 	Op  string
@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func TestNew_DefaultsNarrowViews(t *testing.T) {
+	service := bsky.NewBlueskyService("")
+	cacheRepo := &bsky.CacheRepository{}
+
+	reg := New(Dependencies{Service: service, CacheRepo: cacheRepo})
+
+	if !reg.IsInitialized() {
+		t.Fatal("expected registry built with New to be initialized")
+	}
+
+	fetcher, err := reg.GetFollowerFetcher()
+	if err != nil {
+		t.Fatalf("GetFollowerFetcher failed: %v", err)
+	}
+	if fetcher != bsky.FollowerFetcher(service) {
+		t.Error("expected follower fetcher to default to the service")
+	}
+
+	profiles, err := reg.GetProfileFetcher()
+	if err != nil {
+		t.Fatalf("GetProfileFetcher failed: %v", err)
+	}
+	if profiles != bsky.ProfileFetcher(service) {
+		t.Error("expected profile fetcher to default to the service")
+	}
+
+	rateCache, err := reg.GetRateCache()
+	if err != nil {
+		t.Fatalf("GetRateCache failed: %v", err)
+	}
+	if rateCache != bsky.RateCache(cacheRepo) {
+		t.Error("expected rate cache to default to the cache repository")
+	}
+}
+
+func TestNew_MissingDependencies(t *testing.T) {
+	reg := New(Dependencies{})
+
+	if _, err := reg.GetFollowerFetcher(); err == nil {
+		t.Error("expected error for missing follower fetcher")
+	}
+	if _, err := reg.GetService(); err == nil {
+		t.Error("expected error for missing service")
+	}
+	if _, err := reg.GetSnapshotRepo(); err == nil {
+		t.Error("expected error for missing snapshot repository")
+	}
+}
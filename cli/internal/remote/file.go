@@ -0,0 +1,107 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileBackend implements [Backend] on a local or mounted directory (NAS, Syncthing folder, etc.)
+type FileBackend struct {
+	root string
+}
+
+// NewFileBackend creates a file backend rooted at filepath.Join(root, prefix)
+func NewFileBackend(root, prefix string) (*FileBackend, error) {
+	if root == "" {
+		return nil, &RemoteError{Op: "NewFileBackend", Err: errors.New("path is required")}
+	}
+	return &FileBackend{root: filepath.Join(root, filepath.FromSlash(prefix))}, nil
+}
+
+// Name returns the backend identifier
+func (b *FileBackend) Name() string {
+	return "file"
+}
+
+// Put writes the object atomically via a temporary file and rename
+func (b *FileBackend) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	dest := filepath.Join(b.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".upload-*")
+	if err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	if err := tmp.Close(); err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	return nil
+}
+
+// Get opens the object for reading
+func (b *FileBackend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(b.root, filepath.FromSlash(key)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, &RemoteError{Op: "Get", Err: err}
+	}
+	return f, nil
+}
+
+// List walks the backend root and returns files whose slash-separated key starts with prefix
+func (b *FileBackend) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+
+	err := filepath.WalkDir(b.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, &RemoteError{Op: "List", Err: err}
+	}
+
+	return objects, nil
+}
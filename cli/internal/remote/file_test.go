@@ -0,0 +1,99 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+func TestFileBackend_PutGetList(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewFileBackend(t.TempDir(), "laptop")
+	if err != nil {
+		t.Fatalf("NewFileBackend failed: %v", err)
+	}
+
+	for _, key := range []string{"archive/a.db", "archive/b.db", "other/c.txt"} {
+		if err := b.Put(ctx, key, strings.NewReader("data:"+key), -1); err != nil {
+			t.Fatalf("Put(%s) failed: %v", key, err)
+		}
+	}
+
+	rc, err := b.Get(ctx, "archive/a.db")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "data:archive/a.db" {
+		t.Errorf("unexpected content: %q", data)
+	}
+
+	objects, err := b.List(ctx, "archive/")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 objects, got %d", len(objects))
+	}
+
+	latest, err := Latest(ctx, b, "archive/")
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if latest == nil || !strings.HasPrefix(latest.Key, "archive/") {
+		t.Errorf("unexpected latest object: %+v", latest)
+	}
+}
+
+func TestFileBackend_ListEmpty(t *testing.T) {
+	b, err := NewFileBackend(t.TempDir(), "missing")
+	if err != nil {
+		t.Fatalf("NewFileBackend failed: %v", err)
+	}
+
+	latest, err := Latest(context.Background(), b, "archive/")
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if latest != nil {
+		t.Errorf("expected no objects, got %+v", latest)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("nil config", func(t *testing.T) {
+		if _, err := New(nil); err == nil {
+			t.Error("expected error for missing storage config")
+		}
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		if _, err := New(&config.StorageConfig{Backend: "ftp"}); err == nil {
+			t.Error("expected error for unknown backend")
+		}
+	})
+
+	t.Run("s3 without credentials", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+		if _, err := New(&config.StorageConfig{Backend: "s3", Bucket: "b"}); err == nil {
+			t.Error("expected error when credentials are missing")
+		}
+	})
+
+	t.Run("s3 with credentials", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		b, err := New(&config.StorageConfig{Backend: "s3", Bucket: "b"})
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if b.Name() != "s3" {
+			t.Errorf("expected s3 backend, got %s", b.Name())
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+const defaultGitBranch = "main"
+
+// GitBackend implements [Backend] on a user-provided git repository.
+// Objects are files in a local clone; Put commits and pushes, Get and List pull first.
+// Authentication is whatever the local git is configured with (SSH agent, credential helper).
+type GitBackend struct {
+	remoteURL string
+	branch    string
+	dir       string
+	files     *FileBackend
+}
+
+// NewGitBackend creates a git backend for remoteURL. The clone lives at dir, defaulting to ~/.skycli/sync-repo.
+func NewGitBackend(remoteURL, branch, dir, prefix string) (*GitBackend, error) {
+	if remoteURL == "" {
+		return nil, &RemoteError{Op: "NewGitBackend", Err: errors.New("endpoint (git remote URL) is required")}
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, &RemoteError{Op: "NewGitBackend", Err: errors.New("git executable not found in PATH")}
+	}
+	if branch == "" {
+		branch = defaultGitBranch
+	}
+	if dir == "" {
+		configDir, err := config.GetConfigDir()
+		if err != nil {
+			return nil, &RemoteError{Op: "NewGitBackend", Err: err}
+		}
+		dir = filepath.Join(configDir, "sync-repo")
+	}
+
+	files, err := NewFileBackend(dir, prefix)
+	if err != nil {
+		return nil, &RemoteError{Op: "NewGitBackend", Err: err}
+	}
+
+	return &GitBackend{remoteURL: remoteURL, branch: branch, dir: dir, files: files}, nil
+}
+
+// Name returns the backend identifier
+func (b *GitBackend) Name() string {
+	return "git"
+}
+
+// Put writes the object into the clone, commits it, and pushes to the remote branch
+func (b *GitBackend) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if err := b.sync(ctx); err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	if err := b.files.Put(ctx, key, r, size); err != nil {
+		return err
+	}
+
+	if _, err := b.git(ctx, "add", "--all"); err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+
+	status, err := b.git(ctx, "status", "--porcelain")
+	if err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	if strings.TrimSpace(status) == "" {
+		return nil
+	}
+
+	if _, err := b.git(ctx, "commit", "--quiet", "-m", "skycli: update "+key); err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	if _, err := b.git(ctx, "push", "--quiet", "origin", "HEAD:"+b.branch); err != nil {
+		return &RemoteError{Op: "Put", Err: fmt.Errorf("push rejected (remote changed, try again): %w", err)}
+	}
+	return nil
+}
+
+// Get pulls the latest commit and opens the object from the clone
+func (b *GitBackend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := b.sync(ctx); err != nil {
+		return nil, &RemoteError{Op: "Get", Err: err}
+	}
+	return b.files.Get(ctx, key)
+}
+
+// List pulls the latest commit and lists files in the clone (the .git directory is skipped)
+func (b *GitBackend) List(ctx context.Context, prefix string) ([]Object, error) {
+	if err := b.sync(ctx); err != nil {
+		return nil, &RemoteError{Op: "List", Err: err}
+	}
+
+	objects, err := b.files.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := objects[:0]
+	for _, obj := range objects {
+		if obj.Key != ".git" && !strings.HasPrefix(obj.Key, ".git/") {
+			filtered = append(filtered, obj)
+		}
+	}
+	return filtered, nil
+}
+
+// sync clones the repository on first use, then fast-forwards to the remote branch.
+// An empty remote (no branch yet) is not an error.
+func (b *GitBackend) sync(ctx context.Context) error {
+	if _, err := os.Stat(filepath.Join(b.dir, ".git")); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(b.dir, 0700); err != nil {
+			return err
+		}
+		if _, err := b.git(ctx, "init", "--quiet", "-b", b.branch); err != nil {
+			return err
+		}
+		if _, err := b.git(ctx, "remote", "add", "origin", b.remoteURL); err != nil {
+			return err
+		}
+	}
+
+	if _, err := b.git(ctx, "fetch", "--quiet", "origin"); err != nil {
+		return err
+	}
+
+	remoteRef := "origin/" + b.branch
+	if _, err := b.git(ctx, "rev-parse", "--verify", "--quiet", remoteRef); err != nil {
+		return nil
+	}
+
+	if _, err := b.git(ctx, "merge", "--quiet", "--ff-only", remoteRef); err != nil {
+		return fmt.Errorf("local clone at %s has diverged from %s: %w", b.dir, remoteRef, err)
+	}
+	return nil
+}
+
+// git runs a git command in the clone directory and returns its stdout
+func (b *GitBackend) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = b.dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,91 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newBareRepo creates an empty bare repository to act as the sync remote
+func newBareRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	t.Setenv("GIT_AUTHOR_NAME", "skycli test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "skycli test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir := filepath.Join(t.TempDir(), "remote.git")
+	if out, err := exec.Command("git", "init", "--quiet", "--bare", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, out)
+	}
+	return dir
+}
+
+func TestGitBackend_PutGet(t *testing.T) {
+	remoteDir := newBareRepo(t)
+	ctx := context.Background()
+
+	laptop, err := NewGitBackend(remoteDir, "main", filepath.Join(t.TempDir(), "laptop"), "skycli")
+	if err != nil {
+		t.Fatalf("NewGitBackend failed: %v", err)
+	}
+
+	if _, err := laptop.Get(ctx, "state.json"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound on empty remote, got %v", err)
+	}
+
+	if err := laptop.Put(ctx, "state.json", strings.NewReader(`{"version":1}`), -1); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	desktop, err := NewGitBackend(remoteDir, "main", filepath.Join(t.TempDir(), "desktop"), "skycli")
+	if err != nil {
+		t.Fatalf("NewGitBackend failed: %v", err)
+	}
+
+	rc, err := desktop.Get(ctx, "state.json")
+	if err != nil {
+		t.Fatalf("Get from second clone failed: %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != `{"version":1}` {
+		t.Errorf("unexpected content: %s", data)
+	}
+
+	if err := desktop.Put(ctx, "state.json", strings.NewReader(`{"version":2}`), -1); err != nil {
+		t.Fatalf("Put from second clone failed: %v", err)
+	}
+
+	rc, err = laptop.Get(ctx, "state.json")
+	if err != nil {
+		t.Fatalf("Get after update failed: %v", err)
+	}
+	data, _ = io.ReadAll(rc)
+	rc.Close()
+	if string(data) != `{"version":2}` {
+		t.Errorf("expected first clone to pull update, got %s", data)
+	}
+
+	objects, err := laptop.List(ctx, "")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(objects) != 1 || objects[0].Key != "state.json" {
+		t.Errorf("unexpected objects: %+v", objects)
+	}
+}
+
+func TestNewGitBackend_RequiresRemote(t *testing.T) {
+	if _, err := NewGitBackend("", "", t.TempDir(), ""); err == nil {
+		t.Error("expected error without a remote URL")
+	}
+}
@@ -0,0 +1,129 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// ErrNotFound is returned (wrapped) by [Backend.Get] when the object does not exist
+var ErrNotFound = errors.New("object not found")
+
+// Backend defines the contract for remote object storage used to sync the local archive.
+// Keys are slash-separated paths relative to the configured prefix.
+type Backend interface {
+	// Name returns a short identifier for the backend (e.g., "s3", "file")
+	Name() string
+	// Put uploads size bytes read from r under key, replacing any existing object
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get opens the object stored under key; callers must close the returned reader
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// List returns all objects whose key starts with prefix
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Object describes a single stored object
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// New builds the [Backend] described by cfg.
+// S3 credentials are read from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and optionally AWS_SESSION_TOKEN;
+// the WebDAV password is read from SKYCLI_WEBDAV_PASSWORD.
+func New(cfg *config.StorageConfig) (Backend, error) {
+	if cfg == nil || cfg.Backend == "" {
+		return nil, &RemoteError{Op: "New", Err: errors.New("no storage backend configured (add a \"storage\" section to the config file)")}
+	}
+
+	switch cfg.Backend {
+	case "s3":
+		creds := Credentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+		return NewS3Backend(cfg.Endpoint, cfg.Region, cfg.Bucket, cfg.Prefix, creds)
+	case "file":
+		return NewFileBackend(cfg.Path, cfg.Prefix)
+	case "webdav":
+		return NewWebDAVBackend(cfg.Endpoint, cfg.Prefix, cfg.Username, os.Getenv("SKYCLI_WEBDAV_PASSWORD"))
+	case "git":
+		return NewGitBackend(cfg.Endpoint, cfg.Branch, cfg.Path, cfg.Prefix)
+	default:
+		return nil, &RemoteError{Op: "New", Err: errors.New("unknown storage backend: " + cfg.Backend)}
+	}
+}
+
+// Latest returns the most recently modified object under prefix, or nil if there are none.
+// Ties on modification time are broken by key so timestamped keys sort naturally.
+func Latest(ctx context.Context, b Backend, prefix string) (*Object, error) {
+	objects, err := b.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].LastModified.Equal(objects[j].LastModified) {
+			return objects[i].Key < objects[j].Key
+		}
+		return objects[i].LastModified.Before(objects[j].LastModified)
+	})
+
+	latest := objects[len(objects)-1]
+	return &latest, nil
+}
+
+// joinKey joins a prefix and key into a clean slash-separated object key.
+// A trailing slash on key is preserved so directory-style list prefixes keep their meaning.
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	joined := path.Join(prefix, key)
+	if strings.HasSuffix(key, "/") {
+		joined += "/"
+	}
+	return joined
+}
+
+// statusError converts a failed HTTP response into an error, wrapping [ErrNotFound] for 404s.
+// The response body is consumed and closed.
+func statusError(req *http.Request, resp *http.Response) error {
+	defer resp.Body.Close()
+	bodyText, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	err := fmt.Errorf("%s %s: %s - %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(bodyText)))
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
+}
+
+// RemoteError represents an error that occurred during remote storage operations
+type RemoteError struct {
+	Op  string
+	Err error
+}
+
+func (e *RemoteError) Error() string {
+	return "remote." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *RemoteError) Unwrap() error {
+	return e.Err
+}
+
+var _ error = &RemoteError{}
@@ -0,0 +1,322 @@
+package remote
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultS3Region  = "us-east-1"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	sigV4Algorithm   = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	amzShortDateForm = "20060102"
+)
+
+// Credentials holds the access keys used to sign S3 requests
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// S3Backend implements [Backend] against any S3-compatible object store (AWS, MinIO, R2, B2).
+// Requests use path-style addressing and are signed with AWS Signature Version 4.
+type S3Backend struct {
+	endpoint *url.URL
+	region   string
+	bucket   string
+	prefix   string
+	creds    Credentials
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Backend creates an S3 backend; endpoint defaults to AWS for the given region
+func NewS3Backend(endpoint, region, bucket, prefix string, creds Credentials) (*S3Backend, error) {
+	if bucket == "" {
+		return nil, &RemoteError{Op: "NewS3Backend", Err: errors.New("bucket is required")}
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return nil, &RemoteError{Op: "NewS3Backend", Err: errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")}
+	}
+	if region == "" {
+		region = defaultS3Region
+	}
+	if endpoint == "" {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, &RemoteError{Op: "NewS3Backend", Err: err}
+	}
+
+	return &S3Backend{
+		endpoint: u,
+		region:   region,
+		bucket:   bucket,
+		prefix:   strings.Trim(prefix, "/"),
+		creds:    creds,
+		client:   &http.Client{},
+		now:      time.Now,
+	}, nil
+}
+
+// Name returns the backend identifier
+func (b *S3Backend) Name() string {
+	return "s3"
+}
+
+// Put uploads an object with a streaming, unsigned payload so large archives are not buffered in memory
+func (b *S3Backend) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	req, err := b.newRequest(ctx, http.MethodPut, joinKey(b.prefix, key), nil, r)
+	if err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	req.ContentLength = size
+
+	resp, err := b.do(req)
+	if err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get downloads an object
+func (b *S3Backend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := b.newRequest(ctx, http.MethodGet, joinKey(b.prefix, key), nil, nil)
+	if err != nil {
+		return nil, &RemoteError{Op: "Get", Err: err}
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, &RemoteError{Op: "Get", Err: err}
+	}
+	return resp.Body, nil
+}
+
+// listBucketResult models the ListObjectsV2 XML response
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List enumerates objects under prefix using ListObjectsV2, following continuation tokens
+func (b *S3Backend) List(ctx context.Context, prefix string) ([]Object, error) {
+	fullPrefix := joinKey(b.prefix, prefix)
+	var objects []Object
+	token := ""
+
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", fullPrefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		req, err := b.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, &RemoteError{Op: "List", Err: err}
+		}
+
+		resp, err := b.do(req)
+		if err != nil {
+			return nil, &RemoteError{Op: "List", Err: err}
+		}
+
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, &RemoteError{Op: "List", Err: err}
+		}
+
+		for _, c := range result.Contents {
+			key := c.Key
+			if b.prefix != "" {
+				key = strings.TrimPrefix(strings.TrimPrefix(key, b.prefix), "/")
+			}
+			objects = append(objects, Object{Key: key, Size: c.Size, LastModified: c.LastModified})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	return objects, nil
+}
+
+// newRequest builds a signed path-style request for the bucket (key may be empty for bucket-level calls)
+func (b *S3Backend) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	u := *b.endpoint
+	u.Path = "/" + b.bucket
+	if key != "" {
+		u.Path += "/" + key
+	}
+	u.RawPath = encodePath(u.Path)
+	u.RawQuery = encodeQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	b.sign(req)
+	return req, nil
+}
+
+// do executes a request and converts non-2xx responses into errors
+func (b *S3Backend) do(req *http.Request) (*http.Response, error) {
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, statusError(req, resp)
+	}
+
+	return resp, nil
+}
+
+// sign adds AWS Signature Version 4 headers to req
+func (b *S3Backend) sign(req *http.Request) {
+	now := b.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	shortDate := now.Format(amzShortDateForm)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	if b.creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", b.creds.SessionToken)
+	}
+
+	signedHeaders, canonicalHeaders := canonicalizeHeaders(req)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := strings.Join([]string{shortDate, b.region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+b.creds.SecretAccessKey), shortDate)
+	signingKey = hmacSHA256(signingKey, b.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, b.creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalizeHeaders returns the signed header list and canonical header block for SigV4
+func canonicalizeHeaders(req *http.Request) (string, string) {
+	names := []string{"host"}
+	values := map[string]string{"host": req.URL.Host}
+
+	for name, vals := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "host" || !strings.HasPrefix(lower, "x-amz-") {
+			continue
+		}
+		names = append(names, lower)
+		values[lower] = strings.TrimSpace(strings.Join(vals, ","))
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + values[name] + "\n")
+	}
+
+	return strings.Join(names, ";"), canonical.String()
+}
+
+// encodePath URI-encodes each path segment per the SigV4 rules (slashes preserved)
+func encodePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		segments[i] = uriEncode(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+// encodeQuery produces a sorted, RFC 3986 encoded query string
+func encodeQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode encodes everything except RFC 3986 unreserved characters
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
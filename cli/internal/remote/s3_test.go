@@ -0,0 +1,131 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestS3Backend(t *testing.T, handler http.HandlerFunc) *S3Backend {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	b, err := NewS3Backend(server.URL, "us-west-2", "backups", "laptop", Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	if err != nil {
+		t.Fatalf("NewS3Backend failed: %v", err)
+	}
+	b.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return b
+}
+
+func TestS3Backend_Put(t *testing.T) {
+	var gotPath, gotBody, gotAuth, gotDate string
+	b := newTestS3Backend(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		gotDate = r.Header.Get("X-Amz-Date")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+	})
+
+	if err := b.Put(context.Background(), "archive/cache.db", strings.NewReader("hello"), 5); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	if gotPath != "/backups/laptop/archive/cache.db" {
+		t.Errorf("unexpected path: %s", gotPath)
+	}
+	if gotBody != "hello" {
+		t.Errorf("unexpected body: %q", gotBody)
+	}
+	if gotDate != "20250102T030405Z" {
+		t.Errorf("unexpected X-Amz-Date: %s", gotDate)
+	}
+	if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/20250102/us-west-2/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=") {
+		t.Errorf("unexpected Authorization header: %s", gotAuth)
+	}
+}
+
+func TestS3Backend_GetError(t *testing.T) {
+	b := newTestS3Backend(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+	})
+
+	_, err := b.Get(context.Background(), "archive/missing.db")
+	if err == nil {
+		t.Fatal("expected error for missing key")
+	}
+	if !strings.Contains(err.Error(), "NoSuchKey") {
+		t.Errorf("expected error to include response body, got %v", err)
+	}
+}
+
+func TestS3Backend_List(t *testing.T) {
+	calls := 0
+	b := newTestS3Backend(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("list-type") != "2" {
+			t.Errorf("expected ListObjectsV2 request, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("prefix") != "laptop/archive/" {
+			t.Errorf("unexpected prefix: %s", r.URL.Query().Get("prefix"))
+		}
+
+		if r.URL.Query().Get("continuation-token") == "" {
+			w.Write([]byte(`<ListBucketResult>
+				<Contents><Key>laptop/archive/a.db</Key><Size>10</Size><LastModified>2025-01-01T00:00:00.000Z</LastModified></Contents>
+				<IsTruncated>true</IsTruncated><NextContinuationToken>next</NextContinuationToken>
+			</ListBucketResult>`))
+			return
+		}
+		w.Write([]byte(`<ListBucketResult>
+			<Contents><Key>laptop/archive/b.db</Key><Size>20</Size><LastModified>2025-01-02T00:00:00.000Z</LastModified></Contents>
+			<IsTruncated>false</IsTruncated>
+		</ListBucketResult>`))
+	})
+
+	objects, err := b.List(context.Background(), "archive/")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 paginated calls, got %d", calls)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 objects, got %d", len(objects))
+	}
+	if objects[0].Key != "archive/a.db" || objects[1].Size != 20 {
+		t.Errorf("unexpected objects: %+v", objects)
+	}
+
+	latest, err := Latest(context.Background(), b, "archive/")
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if latest.Key != "archive/b.db" {
+		t.Errorf("expected latest archive/b.db, got %s", latest.Key)
+	}
+}
+
+func TestURIEncode(t *testing.T) {
+	tests := map[string]string{
+		"abc-_.~":   "abc-_.~",
+		"a b":       "a%20b",
+		"a/b":       "a%2Fb",
+		"cache+1=2": "cache%2B1%3D2",
+	}
+	for in, want := range tests {
+		if got := uriEncode(in); got != want {
+			t.Errorf("uriEncode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package remote
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// propfindBody requests only the properties needed to build [Object] values
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:resourcetype/><d:getcontentlength/><d:getlastmodified/></d:prop></d:propfind>`
+
+// WebDAVBackend implements [Backend] on a WebDAV server (Nextcloud, ownCloud, Apache mod_dav, etc.)
+type WebDAVBackend struct {
+	base     *url.URL
+	prefix   string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewWebDAVBackend creates a WebDAV backend rooted at endpoint; basic auth is used when username is set
+func NewWebDAVBackend(endpoint, prefix, username, password string) (*WebDAVBackend, error) {
+	if endpoint == "" {
+		return nil, &RemoteError{Op: "NewWebDAVBackend", Err: errors.New("endpoint is required")}
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, &RemoteError{Op: "NewWebDAVBackend", Err: err}
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	return &WebDAVBackend{
+		base:     u,
+		prefix:   strings.Trim(prefix, "/"),
+		username: username,
+		password: password,
+		client:   &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// Name returns the backend identifier
+func (b *WebDAVBackend) Name() string {
+	return "webdav"
+}
+
+// Put uploads an object, creating parent collections as needed
+func (b *WebDAVBackend) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	fullKey := joinKey(b.prefix, key)
+	if err := b.mkcolAll(ctx, path.Dir(fullKey)); err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+
+	req, err := b.newRequest(ctx, http.MethodPut, fullKey, r)
+	if err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return &RemoteError{Op: "Put", Err: err}
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get downloads an object
+func (b *WebDAVBackend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := b.newRequest(ctx, http.MethodGet, joinKey(b.prefix, key), nil)
+	if err != nil {
+		return nil, &RemoteError{Op: "Get", Err: err}
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, &RemoteError{Op: "Get", Err: err}
+	}
+	return resp.Body, nil
+}
+
+// multistatus models a PROPFIND response
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+				ContentLength int64  `xml:"getcontentlength"`
+				LastModified  string `xml:"getlastmodified"`
+			} `xml:"prop"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+// List walks collections with Depth: 1 PROPFIND requests (Depth: infinity is often disabled server-side)
+func (b *WebDAVBackend) List(ctx context.Context, prefix string) ([]Object, error) {
+	dir := joinKey(b.prefix, prefix)
+	if !strings.HasSuffix(prefix, "/") {
+		dir = path.Dir(dir)
+	}
+	if dir = strings.Trim(dir, "/"); dir == "." {
+		dir = ""
+	}
+
+	var objects []Object
+	pending := []string{dir}
+	seen := map[string]bool{}
+
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+
+		entries, err := b.propfind(ctx, current)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, &RemoteError{Op: "List", Err: err}
+		}
+
+		for _, entry := range entries {
+			if entry.isDir {
+				if entry.key != current {
+					pending = append(pending, entry.key)
+				}
+				continue
+			}
+
+			key := entry.key
+			if b.prefix != "" {
+				key = strings.TrimPrefix(strings.TrimPrefix(key, b.prefix), "/")
+			}
+			if strings.HasPrefix(key, prefix) {
+				objects = append(objects, Object{Key: key, Size: entry.size, LastModified: entry.modified})
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// davEntry is a single resource from a PROPFIND response
+type davEntry struct {
+	key      string
+	isDir    bool
+	size     int64
+	modified time.Time
+}
+
+// propfind lists the immediate children of a collection; keys are relative to the backend base URL
+func (b *WebDAVBackend) propfind(ctx context.Context, dir string) ([]davEntry, error) {
+	collection := dir
+	if collection != "" {
+		collection += "/"
+	}
+
+	req, err := b.newRequest(ctx, "PROPFIND", collection, strings.NewReader(propfindBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ms multistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, err
+	}
+
+	basePath := b.base.Path + "/"
+	entries := make([]davEntry, 0, len(ms.Responses))
+	for _, r := range ms.Responses {
+		href, err := url.Parse(r.Href)
+		if err != nil {
+			continue
+		}
+		key := strings.Trim(strings.TrimPrefix(href.Path, basePath), "/")
+
+		entry := davEntry{key: key}
+		for _, ps := range r.Propstat {
+			if ps.Prop.ResourceType.Collection != nil {
+				entry.isDir = true
+			}
+			if ps.Prop.ContentLength > 0 {
+				entry.size = ps.Prop.ContentLength
+			}
+			if t, err := http.ParseTime(ps.Prop.LastModified); err == nil {
+				entry.modified = t
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// mkcolAll creates each collection along dir, ignoring ones that already exist
+func (b *WebDAVBackend) mkcolAll(ctx context.Context, dir string) error {
+	dir = strings.Trim(dir, "/")
+	if dir == "" || dir == "." {
+		return nil
+	}
+
+	current := ""
+	for _, part := range strings.Split(dir, "/") {
+		current = joinKey(current, part)
+
+		req, err := b.newRequest(ctx, "MKCOL", current+"/", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := b.client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		// 405 Method Not Allowed means the collection already exists
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusMethodNotAllowed &&
+			(resp.StatusCode < 200 || resp.StatusCode > 299) {
+			return errors.New("MKCOL " + current + ": " + resp.Status)
+		}
+	}
+	return nil
+}
+
+// newRequest builds an authenticated request for key relative to the base URL
+func (b *WebDAVBackend) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	u := *b.base
+	u.Path = b.base.Path + "/" + key
+	u.RawPath = ""
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if b.username != "" {
+		req.SetBasicAuth(b.username, b.password)
+	}
+	return req, nil
+}
+
+// do executes a request and converts non-2xx responses into errors
+func (b *WebDAVBackend) do(req *http.Request) (*http.Response, error) {
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, statusError(req, resp)
+	}
+	return resp, nil
+}
@@ -0,0 +1,154 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDAV is a minimal in-memory WebDAV server supporting PUT, GET, MKCOL, and Depth: 1 PROPFIND
+type fakeDAV struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	dirs  map[string]bool
+}
+
+func (d *fakeDAV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if user, pass, ok := r.BasicAuth(); !ok || user != "me" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/dav")
+	switch r.Method {
+	case "MKCOL":
+		dir := strings.TrimSuffix(p, "/")
+		if d.dirs[dir] {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		d.dirs[dir] = true
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		d.files[p] = body
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		body, ok := d.files[p]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case "PROPFIND":
+		dir := strings.TrimSuffix(p, "/")
+		if dir != "" && !d.dirs[dir] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var names []string
+		for name := range d.files {
+			if path := strings.TrimPrefix(name, dir+"/"); path != name && !strings.Contains(path, "/") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		w.WriteHeader(http.StatusMultiStatus)
+		fmt.Fprint(w, `<?xml version="1.0"?><d:multistatus xmlns:d="DAV:">`)
+		fmt.Fprintf(w, `<d:response><d:href>/dav%s/</d:href><d:propstat><d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop></d:propstat></d:response>`, dir)
+		for sub := range d.dirs {
+			if rest := strings.TrimPrefix(sub, dir+"/"); rest != sub && !strings.Contains(rest, "/") {
+				fmt.Fprintf(w, `<d:response><d:href>/dav%s/</d:href><d:propstat><d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop></d:propstat></d:response>`, sub)
+			}
+		}
+		for _, name := range names {
+			fmt.Fprintf(w, `<d:response><d:href>/dav%s</d:href><d:propstat><d:prop><d:resourcetype/><d:getcontentlength>%d</d:getcontentlength><d:getlastmodified>%s</d:getlastmodified></d:prop></d:propstat></d:response>`,
+				name, len(d.files[name]), time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC).Format(http.TimeFormat))
+		}
+		fmt.Fprint(w, `</d:multistatus>`)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestWebDAVBackend(t *testing.T) {
+	dav := &fakeDAV{files: map[string][]byte{}, dirs: map[string]bool{}}
+	server := httptest.NewServer(dav)
+	defer server.Close()
+
+	b, err := NewWebDAVBackend(server.URL+"/dav/", "skycli", "me", "secret")
+	if err != nil {
+		t.Fatalf("NewWebDAVBackend failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := b.Put(ctx, "state.json", strings.NewReader(`{"version":1}`), 13); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := b.Put(ctx, "archive/cache.db", strings.NewReader("db"), 2); err != nil {
+		t.Fatalf("Put nested failed: %v", err)
+	}
+	if !dav.dirs["/skycli/archive"] {
+		t.Error("expected parent collections to be created")
+	}
+
+	rc, err := b.Get(ctx, "state.json")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != `{"version":1}` {
+		t.Errorf("unexpected content: %s", data)
+	}
+
+	if _, err := b.Get(ctx, "missing.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	objects, err := b.List(ctx, "archive/")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(objects) != 1 || objects[0].Key != "archive/cache.db" || objects[0].Size != 2 {
+		t.Errorf("unexpected objects: %+v", objects)
+	}
+	if objects[0].LastModified.IsZero() {
+		t.Error("expected LastModified to be parsed")
+	}
+
+	all, err := b.List(ctx, "")
+	if err != nil {
+		t.Fatalf("List all failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected 2 objects across collections, got %d", len(all))
+	}
+}
+
+func TestWebDAVBackend_Unauthorized(t *testing.T) {
+	dav := &fakeDAV{files: map[string][]byte{}, dirs: map[string]bool{}}
+	server := httptest.NewServer(dav)
+	defer server.Close()
+
+	b, err := NewWebDAVBackend(server.URL+"/dav", "", "me", "wrong")
+	if err != nil {
+		t.Fatalf("NewWebDAVBackend failed: %v", err)
+	}
+
+	if _, err := b.Get(context.Background(), "state.json"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected authorization error, got %v", err)
+	}
+}
@@ -0,0 +1,287 @@
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// DIDPrefix starts every generated DID, so seeded accounts are easy to tell apart from real ones
+const DIDPrefix = "did:plc:seed"
+
+// UserDID owns the generated snapshots when no account is given
+const UserDID = DIDPrefix + "user"
+
+// FeedSource marks the local feed generated posts are saved into
+const FeedSource = "seed"
+
+// PostWindow is how far back generated posts reach
+const PostWindow = 90 * 24 * time.Hour
+
+// SnapshotInterval separates consecutive generated snapshots
+const SnapshotInterval = 7 * 24 * time.Hour
+
+// churnRate is the share of followers lost per snapshot interval, and growthRate the share gained
+const (
+	churnRate  = 0.03
+	growthRate = 0.05
+)
+
+// rateLookbackDays matches the window the post-rate cache is computed over
+const rateLookbackDays = 30
+
+var (
+	adjectives = []string{"quiet", "bright", "rapid", "amber", "lunar", "coastal", "velvet", "rusty", "hidden", "electric", "gentle", "northern"}
+	nouns      = []string{"otter", "falcon", "harbor", "meadow", "comet", "lantern", "willow", "circuit", "pebble", "signal", "garden", "tide"}
+	words      = []string{"just", "shipped", "reading", "about", "the", "new", "release", "coffee", "thread", "morning", "weekend", "project", "photo", "music", "today", "really", "great", "idea", "working", "on", "walk", "city", "notes", "learned", "something"}
+	hashtags   = []string{"#art", "#golang", "#photography", "#bookclub", "#gardening", "#music"}
+)
+
+// Options sizes the generated data
+type Options struct {
+	UserDID   string    // account the follower snapshots belong to
+	Followers int       // followers in the latest snapshot
+	Posts     int       // posts authored by followers
+	Snapshots int       // follower snapshots, one SnapshotInterval apart and ending at Now
+	Seed      uint64    // makes the data reproducible; the same seed regenerates the same accounts and posts
+	Now       time.Time // reference time; defaults to the current time
+}
+
+// Snapshot is a generated follower snapshot with its entries
+type Snapshot struct {
+	Model   *bsky.SnapshotModel
+	Entries []*bsky.SnapshotEntry
+}
+
+// Data is a generated dataset. Posts have no feed; the caller assigns one.
+type Data struct {
+	Profiles   []bsky.ActorProfile // every generated account, including followers lost before the latest snapshot
+	Posts      []*bsky.PostModel
+	Snapshots  []Snapshot // oldest first
+	Activities []*bsky.ActivityCacheModel
+	PostRates  []*bsky.PostRateCacheModel
+}
+
+// account is a generated follower with the span it followed for
+type account struct {
+	profile    bsky.ActorProfile
+	followedAt time.Time
+	unfollowed time.Time // zero while still following
+}
+
+// Generate builds a consistent dataset: snapshots are views of the same follow history, posts come from the
+// followers, and the caches agree with the posts
+func Generate(opts Options) (*Data, error) {
+	if opts.Followers < 0 || opts.Posts < 0 || opts.Snapshots < 0 {
+		return nil, fmt.Errorf("seed sizes can't be negative")
+	}
+	if opts.Posts > 0 && opts.Followers == 0 {
+		return nil, fmt.Errorf("posts need at least one follower to author them")
+	}
+	if opts.UserDID == "" {
+		opts.UserDID = UserDID
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	opts.Now = opts.Now.UTC().Truncate(time.Second)
+
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+	accounts := generateAccounts(rng, opts)
+
+	data := &Data{}
+	for _, a := range accounts {
+		data.Profiles = append(data.Profiles, a.profile)
+	}
+
+	followers := accounts[:opts.Followers]
+	data.Posts = generatePosts(rng, followers, opts)
+	data.Snapshots = generateSnapshots(accounts, opts)
+	data.Activities, data.PostRates = generateCaches(rng, followers, data.Posts, opts)
+	return data, nil
+}
+
+// generateAccounts returns the current followers first, then those lost during the snapshot window.
+// Most followed before the window opened; the rest joined during it.
+func generateAccounts(rng *rand.Rand, opts Options) []account {
+	window := time.Duration(max(opts.Snapshots-1, 0)) * SnapshotInterval
+	start := opts.Now.Add(-window)
+
+	joined := int(float64(opts.Followers) * growthRate * float64(max(opts.Snapshots-1, 0)))
+	joined = min(joined, opts.Followers)
+	lost := int(float64(opts.Followers) * churnRate * float64(max(opts.Snapshots-1, 0)))
+
+	accounts := make([]account, opts.Followers+lost)
+	for i := range accounts {
+		a := account{profile: generateProfile(rng, i, opts.Now)}
+		switch {
+		case i < opts.Followers-joined:
+			a.followedAt = start.Add(-randDuration(rng, 2*365*24*time.Hour))
+		case i < opts.Followers:
+			a.followedAt = start.Add(randDuration(rng, window))
+		default:
+			a.followedAt = start.Add(-randDuration(rng, 365*24*time.Hour))
+			a.unfollowed = start.Add(randDuration(rng, window) + time.Second)
+		}
+		accounts[i] = a
+	}
+	return accounts
+}
+
+// generateProfile creates an account with heavy-tailed follower and post counts
+func generateProfile(rng *rand.Rand, i int, now time.Time) bsky.ActorProfile {
+	adjective, noun := adjectives[rng.IntN(len(adjectives))], nouns[rng.IntN(len(nouns))]
+	created := now.Add(-randDuration(rng, 3*365*24*time.Hour) - 30*24*time.Hour)
+
+	return bsky.ActorProfile{
+		Did:            DID(i),
+		Handle:         fmt.Sprintf("%s-%s-%d.test", adjective, noun, i),
+		DisplayName:    strings.ToUpper(adjective[:1]) + adjective[1:] + " " + strings.ToUpper(noun[:1]) + noun[1:],
+		Description:    fmt.Sprintf("Seeded account %d. Likes %s and %s.", i, nouns[rng.IntN(len(nouns))], nouns[rng.IntN(len(nouns))]),
+		FollowersCount: heavyTail(rng, 50_000),
+		FollowsCount:   heavyTail(rng, 5_000),
+		PostsCount:     heavyTail(rng, 20_000),
+		CreatedAt:      created.Format(time.RFC3339),
+		IndexedAt:      created.Format(time.RFC3339),
+	}
+}
+
+// generatePosts spreads posts over [PostWindow], skewed so a few followers write most of them
+func generatePosts(rng *rand.Rand, followers []account, opts Options) []*bsky.PostModel {
+	posts := make([]*bsky.PostModel, opts.Posts)
+	for i := range posts {
+		author := followers[int(float64(len(followers))*math.Pow(rng.Float64(), 3))].profile
+		posts[i] = &bsky.PostModel{
+			URI:       fmt.Sprintf("at://%s/app.bsky.feed.post/3seed%09d", author.Did, i),
+			AuthorDID: author.Did,
+			Text:      postText(rng),
+			IndexedAt: opts.Now.Add(-randDuration(rng, PostWindow)),
+			Engagement: &bsky.PostEngagement{
+				Likes:   heavyTail(rng, 500),
+				Reposts: heavyTail(rng, 100),
+				Replies: heavyTail(rng, 50),
+				Quotes:  heavyTail(rng, 10),
+			},
+		}
+	}
+	return posts
+}
+
+// generateSnapshots views the follow history at each snapshot time
+func generateSnapshots(accounts []account, opts Options) []Snapshot {
+	snapshots := make([]Snapshot, opts.Snapshots)
+	for k := range snapshots {
+		at := opts.Now.Add(-time.Duration(opts.Snapshots-1-k) * SnapshotInterval)
+		model := &bsky.SnapshotModel{
+			UserDid:      opts.UserDID,
+			SnapshotType: "followers",
+			ExpiresAt:    opts.Now.Add(30 * 24 * time.Hour),
+		}
+		model.SetID(fmt.Sprintf("seed-%d-%s", opts.Seed, at.Format("20060102")))
+		model.SetCreatedAt(at)
+
+		var entries []*bsky.SnapshotEntry
+		for _, a := range accounts {
+			if a.followedAt.After(at) || (!a.unfollowed.IsZero() && !a.unfollowed.After(at)) {
+				continue
+			}
+			entries = append(entries, &bsky.SnapshotEntry{
+				SnapshotID: model.ID(),
+				ActorDid:   a.profile.Did,
+				IndexedAt:  a.followedAt.Format(time.RFC3339),
+			})
+		}
+		model.TotalCount = len(entries)
+		snapshots[k] = Snapshot{Model: model, Entries: entries}
+	}
+	return snapshots
+}
+
+// generateCaches derives activity and post-rate entries for every follower from their posts. Followers without
+// posts either never posted or last posted before the post window, so inactivity filters have something to find.
+func generateCaches(rng *rand.Rand, followers []account, posts []*bsky.PostModel, opts Options) ([]*bsky.ActivityCacheModel, []*bsky.PostRateCacheModel) {
+	type history struct {
+		last   time.Time
+		recent int
+		total  int
+		daily  []int
+	}
+	histories := make(map[string]*history)
+	cutoff := opts.Now.AddDate(0, 0, -rateLookbackDays)
+	for _, post := range posts {
+		h := histories[post.AuthorDID]
+		if h == nil {
+			h = &history{daily: make([]int, bsky.PostRateDays)}
+			histories[post.AuthorDID] = h
+		}
+		h.total++
+		if day := int(opts.Now.Sub(post.IndexedAt) / (24 * time.Hour)); day >= 0 && day < bsky.PostRateDays {
+			h.daily[bsky.PostRateDays-1-day]++
+		}
+		if post.IndexedAt.After(h.last) {
+			h.last = post.IndexedAt
+		}
+		if post.IndexedAt.After(cutoff) {
+			h.recent++
+		}
+	}
+
+	activities := make([]*bsky.ActivityCacheModel, len(followers))
+	rates := make([]*bsky.PostRateCacheModel, len(followers))
+	for i, a := range followers {
+		h := histories[a.profile.Did]
+		if h == nil {
+			h = &history{daily: make([]int, bsky.PostRateDays)}
+			if rng.IntN(2) == 0 {
+				h.last = opts.Now.Add(-PostWindow - randDuration(rng, 365*24*time.Hour))
+			}
+		}
+
+		activities[i] = &bsky.ActivityCacheModel{ActorDid: a.profile.Did, LastPostDate: h.last, FetchedAt: opts.Now}
+		rates[i] = &bsky.PostRateCacheModel{
+			ActorDid:     a.profile.Did,
+			PostsPerDay:  float64(h.recent) / rateLookbackDays,
+			LastPostDate: h.last,
+			SampleSize:   min(h.total, 30),
+			Daily:        h.daily,
+			FetchedAt:    opts.Now,
+		}
+	}
+	return activities, rates
+}
+
+// DID returns the generated DID of the i-th account
+func DID(i int) string {
+	return fmt.Sprintf("%s%020d", DIDPrefix, i)
+}
+
+// postText strings together a short post, sometimes with a hashtag
+func postText(rng *rand.Rand) string {
+	n := 4 + rng.IntN(16)
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = words[rng.IntN(len(words))]
+	}
+	if rng.IntN(4) == 0 {
+		parts = append(parts, hashtags[rng.IntN(len(hashtags))])
+	}
+	text := strings.Join(parts, " ")
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// heavyTail draws a count from a power-law-like distribution capped at limit
+func heavyTail(rng *rand.Rand, limit int) int {
+	return int(float64(limit) * math.Pow(rng.Float64(), 4))
+}
+
+// randDuration returns a uniform duration in [0, d)
+func randDuration(rng *rand.Rand, d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rng.Int64N(int64(d)))
+}
@@ -0,0 +1,104 @@
+package seed
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	opts := Options{Followers: 200, Posts: 1000, Snapshots: 4, Seed: 7, Now: now}
+
+	data, err := Generate(opts)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	t.Run("Snapshots", func(t *testing.T) {
+		if len(data.Snapshots) != 4 {
+			t.Fatalf("expected 4 snapshots, got %d", len(data.Snapshots))
+		}
+		latest := data.Snapshots[3]
+		if latest.Model.TotalCount != 200 || len(latest.Entries) != 200 {
+			t.Errorf("latest snapshot has %d followers, want 200", latest.Model.TotalCount)
+		}
+		if !latest.Model.CreatedAt().Equal(now) || latest.Model.UserDid != UserDID {
+			t.Errorf("unexpected latest snapshot %+v", latest.Model)
+		}
+
+		first := make(map[string]bool)
+		for _, entry := range data.Snapshots[0].Entries {
+			first[entry.ActorDid] = true
+		}
+		var gained, kept int
+		for _, entry := range latest.Entries {
+			if first[entry.ActorDid] {
+				kept++
+			} else {
+				gained++
+			}
+		}
+		lost := len(first) - kept
+		if gained == 0 || lost == 0 {
+			t.Errorf("expected churn between the first and latest snapshot, gained %d and lost %d", gained, lost)
+		}
+	})
+
+	t.Run("Posts", func(t *testing.T) {
+		if len(data.Posts) != 1000 {
+			t.Fatalf("expected 1000 posts, got %d", len(data.Posts))
+		}
+		uris := make(map[string]bool)
+		for _, post := range data.Posts {
+			if !strings.HasPrefix(post.AuthorDID, DIDPrefix) || !strings.HasPrefix(post.URI, "at://"+post.AuthorDID) {
+				t.Fatalf("unexpected post %+v", post)
+			}
+			if post.IndexedAt.After(now) || post.IndexedAt.Before(now.Add(-PostWindow)) {
+				t.Errorf("post indexed outside the window: %s", post.IndexedAt)
+			}
+			uris[post.URI] = true
+		}
+		if len(uris) != len(data.Posts) {
+			t.Error("expected unique post URIs")
+		}
+	})
+
+	t.Run("Caches", func(t *testing.T) {
+		if len(data.Activities) != 200 || len(data.PostRates) != 200 {
+			t.Fatalf("expected cache entries for every follower, got %d and %d", len(data.Activities), len(data.PostRates))
+		}
+		var never int
+		for _, activity := range data.Activities {
+			if !activity.HasPosted() {
+				never++
+			}
+		}
+		if never == 0 || never == len(data.Activities) {
+			t.Errorf("expected a mix of active and never-posted followers, got %d never posted", never)
+		}
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		again, err := Generate(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again.Posts[500].URI != data.Posts[500].URI || again.Profiles[10].Handle != data.Profiles[10].Handle {
+			t.Error("expected the same seed to generate the same data")
+		}
+	})
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	if _, err := Generate(Options{Followers: -1}); err == nil {
+		t.Error("expected negative sizes to fail")
+	}
+	if _, err := Generate(Options{Posts: 10}); err == nil {
+		t.Error("expected posts without followers to fail")
+	}
+	data, err := Generate(Options{})
+	if err != nil || len(data.Profiles) != 0 || len(data.Snapshots) != 0 {
+		t.Errorf("expected an empty dataset, got %+v (%v)", data, err)
+	}
+}
@@ -8,10 +8,10 @@ import (
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stormlightlabs/skypanel/cli/internal/config"
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 )
 
-// EnsurePersistenceReady validates the persistence layer from package [store] is ready for use.
+// EnsurePersistenceReady validates the persistence layer from package [bsky] is ready for use.
 // On first run, automatically creates directories, database, and runs migrations; on subsequent runs, performs fast validation checks only.
 func EnsurePersistenceReady(ctx context.Context) error {
 	configDir, err := config.GetConfigDir()
@@ -73,7 +73,7 @@ func initializeDatabase(_ context.Context, dbPath string) error {
 	}
 	defer db.Close()
 
-	if err := store.RunMigrations(db); err != nil {
+	if err := bsky.RunMigrations(db); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
@@ -88,7 +88,7 @@ func validateMigrations(dbPath string) error {
 	}
 	defer db.Close()
 
-	status, err := store.GetMigrationStatus(db)
+	status, err := bsky.GetMigrationStatus(db)
 	if err != nil {
 		return fmt.Errorf("failed to check migration status: %w", err)
 	}
@@ -0,0 +1,382 @@
+package statesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/export"
+	"github.com/stormlightlabs/skypanel/cli/internal/imports"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// StateKey is the object key of the state document on the sync backend
+const StateKey = "state.json"
+
+// StateVersion is the current version of the state document. Version 2 added read positions, version 3 tags, and
+// version 4 notes; older clients refuse newer documents rather than push a merge that drops them.
+const StateVersion = 4
+
+// State is the non-secret local state (feed definitions, follower snapshots, feed reader positions, tags, and
+// notes on accounts) synced between machines. It is exchanged as a single JSON document; conflicts are resolved per record by timestamp
+// (see [Merge]). Deletions are not propagated.
+type State struct {
+	Version       int                       `json:"version"`
+	UpdatedAt     time.Time                 `json:"updatedAt"`
+	Feeds         []Feed                    `json:"feeds"`
+	Snapshots     []export.SnapshotDocument `json:"snapshots"`
+	ReadPositions []ReadPosition            `json:"readPositions,omitempty"`
+	Tags          []Tag                     `json:"tags,omitempty"`
+	Notes         []Note                    `json:"notes,omitempty"`
+}
+
+// Feed is the synced form of a [bsky.FeedModel]
+type Feed struct {
+	ID        string            `json:"id"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+	Name      string            `json:"name"`
+	Source    string            `json:"source"`
+	Params    map[string]string `json:"params,omitempty"`
+	IsLocal   bool              `json:"isLocal"`
+}
+
+// ReadPosition is the synced form of a [bsky.ReadPosition]
+type ReadPosition struct {
+	Feed      string    `json:"feed"`
+	Cursor    string    `json:"cursor,omitempty"`
+	PostURI   string    `json:"postUri,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Tag is the synced form of a [bsky.TagModel]
+type Tag struct {
+	Tag       string       `json:"tag"`
+	Kind      bsky.TagKind `json:"kind"`
+	EntityID  string       `json:"entityId"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// key identifies a tag on one entity
+func (t Tag) key() string {
+	return t.Tag + "\x00" + string(t.Kind) + "\x00" + t.EntityID
+}
+
+// Note is the synced form of a [bsky.NoteModel]
+type Note struct {
+	DID       string    `json:"did"`
+	Handle    string    `json:"handle,omitempty"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Stores are the local repositories holding synced state. Collect and Apply skip a nil ReadPositions, Tags, or
+// Notes.
+type Stores struct {
+	Feeds         *bsky.FeedRepository
+	Snapshots     bsky.SnapshotStore
+	ReadPositions *bsky.ReadPositionRepository
+	Tags          *bsky.TagRepository
+	Notes         *bsky.NoteRepository
+}
+
+// ApplyResult reports what [Apply] changed locally
+type ApplyResult struct {
+	FeedsAdded     int
+	FeedsUpdated   int
+	FeedsUndeleted int // in the trash here, but changed on another machine since they were deleted
+	FeedsTrashed   int // left in the trash, since they were deleted here after their last change
+	SnapshotsAdded int
+	// ReadPositionsUpdated counts positions added or moved to a more recent one
+	ReadPositionsUpdated int
+	TagsAdded            int
+	NotesAdded           int
+	NotesUpdated         int
+}
+
+// Collect reads the local state from the repositories
+func Collect(ctx context.Context, stores Stores) (*State, error) {
+	state := &State{Version: StateVersion, UpdatedAt: time.Now().UTC()}
+
+	feeds, err := stores.Feeds.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feeds: %w", err)
+	}
+	for _, model := range feeds {
+		feed := model.(*bsky.FeedModel)
+		state.Feeds = append(state.Feeds, Feed{
+			ID:        feed.ID(),
+			CreatedAt: feed.CreatedAt(),
+			UpdatedAt: feed.UpdatedAt(),
+			Name:      feed.Name,
+			Source:    feed.Source,
+			Params:    feed.Params,
+			IsLocal:   feed.IsLocal,
+		})
+	}
+
+	snapshots, err := stores.Snapshots.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	for _, model := range snapshots {
+		snapshot := model.(*bsky.SnapshotModel)
+		entries, err := stores.Snapshots.GetEntries(ctx, snapshot.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot entries: %w", err)
+		}
+		state.Snapshots = append(state.Snapshots, export.NewSnapshotDocument(snapshot, entries))
+	}
+
+	if stores.ReadPositions != nil {
+		positions, err := stores.ReadPositions.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list read positions: %w", err)
+		}
+		for _, position := range positions {
+			state.ReadPositions = append(state.ReadPositions, ReadPosition{
+				Feed:      position.Feed,
+				Cursor:    position.Cursor,
+				PostURI:   position.PostURI,
+				UpdatedAt: position.UpdatedAt,
+			})
+		}
+	}
+
+	if stores.Tags != nil {
+		tags, err := stores.Tags.All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+		for _, tag := range tags {
+			state.Tags = append(state.Tags, Tag{Tag: tag.Tag, Kind: tag.Kind, EntityID: tag.EntityID, CreatedAt: tag.CreatedAt})
+		}
+	}
+
+	if stores.Notes != nil {
+		notes, err := stores.Notes.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list notes: %w", err)
+		}
+		for _, note := range notes {
+			state.Notes = append(state.Notes, Note{
+				DID:       note.DID,
+				Handle:    note.Handle,
+				Note:      note.Note,
+				CreatedAt: note.CreatedAt,
+				UpdatedAt: note.UpdatedAt,
+			})
+		}
+	}
+
+	return state, nil
+}
+
+// Merge combines two states. Feeds with the same ID, read positions in the same feed, and notes on the same
+// account keep the most recently updated version; snapshots are unioned by ID and tags by tag and entity, keeping the earliest
+// time the tag was added. Either argument may be nil.
+func Merge(local, remote *State) *State {
+	merged := &State{Version: StateVersion, UpdatedAt: time.Now().UTC()}
+
+	feeds := make(map[string]Feed)
+	snapshots := make(map[string]export.SnapshotDocument)
+	positions := make(map[string]ReadPosition)
+	tags := make(map[string]Tag)
+	notes := make(map[string]Note)
+	for _, state := range []*State{remote, local} {
+		if state == nil {
+			continue
+		}
+		for _, feed := range state.Feeds {
+			if existing, ok := feeds[feed.ID]; !ok || feed.UpdatedAt.After(existing.UpdatedAt) {
+				feeds[feed.ID] = feed
+			}
+		}
+		for _, doc := range state.Snapshots {
+			if _, ok := snapshots[doc.Snapshot.ID]; !ok {
+				snapshots[doc.Snapshot.ID] = doc
+			}
+		}
+		for _, position := range state.ReadPositions {
+			if existing, ok := positions[position.Feed]; !ok || position.UpdatedAt.After(existing.UpdatedAt) {
+				positions[position.Feed] = position
+			}
+		}
+		for _, tag := range state.Tags {
+			if existing, ok := tags[tag.key()]; !ok || tag.CreatedAt.Before(existing.CreatedAt) {
+				tags[tag.key()] = tag
+			}
+		}
+		for _, note := range state.Notes {
+			if existing, ok := notes[note.DID]; !ok || note.UpdatedAt.After(existing.UpdatedAt) {
+				notes[note.DID] = note
+			}
+		}
+	}
+
+	for _, feed := range feeds {
+		merged.Feeds = append(merged.Feeds, feed)
+	}
+	sort.Slice(merged.Feeds, func(i, j int) bool { return merged.Feeds[i].ID < merged.Feeds[j].ID })
+
+	for _, doc := range snapshots {
+		merged.Snapshots = append(merged.Snapshots, doc)
+	}
+	sort.Slice(merged.Snapshots, func(i, j int) bool {
+		return merged.Snapshots[i].Snapshot.CreatedAt.Before(merged.Snapshots[j].Snapshot.CreatedAt)
+	})
+
+	for _, position := range positions {
+		merged.ReadPositions = append(merged.ReadPositions, position)
+	}
+	sort.Slice(merged.ReadPositions, func(i, j int) bool { return merged.ReadPositions[i].Feed < merged.ReadPositions[j].Feed })
+
+	for _, tag := range tags {
+		merged.Tags = append(merged.Tags, tag)
+	}
+	sort.Slice(merged.Tags, func(i, j int) bool { return merged.Tags[i].key() < merged.Tags[j].key() })
+
+	for _, note := range notes {
+		merged.Notes = append(merged.Notes, note)
+	}
+	sort.Slice(merged.Notes, func(i, j int) bool { return merged.Notes[i].DID < merged.Notes[j].DID })
+
+	return merged
+}
+
+// Apply writes incoming state into the local repositories. Feeds are only overwritten when the incoming
+// copy is newer. A feed in the local trash stays there unless the incoming copy was changed after it was
+// deleted, in which case it is restored from the trash. Snapshots and tags already present locally are left
+// alone, and read positions and notes are only overwritten by a more recent one.
+func Apply(ctx context.Context, incoming *State, stores Stores) (*ApplyResult, error) {
+	result := &ApplyResult{}
+	feedRepo, snapshotRepo := stores.Feeds, stores.Snapshots
+
+	for _, feed := range incoming.Feeds {
+		existing, err := feedRepo.GetIncludingDeleted(ctx, feed.ID)
+		if err != nil && !errors.Is(err, bsky.ErrNotFound) {
+			return result, fmt.Errorf("failed to look up feed %s: %w", feed.Name, err)
+		}
+		trashed := existing != nil && !existing.DeletedAt.IsZero()
+		switch {
+		case trashed && !feed.UpdatedAt.After(existing.DeletedAt):
+			// Deleted here after the incoming copy was last changed; leave it in the trash
+			result.FeedsTrashed++
+			continue
+		case existing != nil && !feed.UpdatedAt.After(existing.UpdatedAt()):
+			continue
+		}
+
+		model := &bsky.FeedModel{Name: feed.Name, Source: feed.Source, Params: feed.Params, IsLocal: feed.IsLocal}
+		model.SetID(feed.ID)
+		model.SetCreatedAt(feed.CreatedAt)
+		model.SetUpdatedAt(feed.UpdatedAt)
+
+		if err := feedRepo.Restore(ctx, model); err != nil {
+			return result, fmt.Errorf("failed to restore feed %s: %w", feed.Name, err)
+		}
+		switch {
+		case trashed:
+			// Changed on another machine after it was deleted here, so the change wins
+			if err := feedRepo.Undelete(ctx, feed.ID); err != nil {
+				return result, fmt.Errorf("failed to take feed %s out of the trash: %w", feed.Name, err)
+			}
+			result.FeedsUndeleted++
+		case existing != nil:
+			result.FeedsUpdated++
+		default:
+			result.FeedsAdded++
+		}
+	}
+
+	for _, doc := range incoming.Snapshots {
+		if existing, err := snapshotRepo.Get(ctx, doc.Snapshot.ID); err == nil && existing != nil {
+			continue
+		}
+
+		snapshot, entries, err := imports.SnapshotFromDocument(doc)
+		if err != nil {
+			return result, fmt.Errorf("invalid snapshot %s: %w", doc.Snapshot.ID, err)
+		}
+		if err := snapshotRepo.Import(ctx, snapshot, entries); err != nil {
+			return result, fmt.Errorf("failed to import snapshot %s: %w", doc.Snapshot.ID, err)
+		}
+		result.SnapshotsAdded++
+	}
+
+	if stores.ReadPositions != nil {
+		for _, position := range incoming.ReadPositions {
+			existing, err := stores.ReadPositions.Get(ctx, position.Feed)
+			if err != nil {
+				return result, fmt.Errorf("failed to look up read position in %s: %w", position.Feed, err)
+			}
+			if existing != nil && !position.UpdatedAt.After(existing.UpdatedAt) {
+				continue
+			}
+			model := &bsky.ReadPosition{Feed: position.Feed, Cursor: position.Cursor, PostURI: position.PostURI, UpdatedAt: position.UpdatedAt}
+			if err := stores.ReadPositions.Restore(ctx, model); err != nil {
+				return result, fmt.Errorf("failed to restore read position in %s: %w", position.Feed, err)
+			}
+			result.ReadPositionsUpdated++
+		}
+	}
+
+	if stores.Tags != nil && len(incoming.Tags) > 0 {
+		tags := make([]*bsky.TagModel, len(incoming.Tags))
+		for i, tag := range incoming.Tags {
+			tags[i] = &bsky.TagModel{Tag: tag.Tag, Kind: tag.Kind, EntityID: tag.EntityID, CreatedAt: tag.CreatedAt}
+		}
+		added, err := stores.Tags.Restore(ctx, tags)
+		if err != nil {
+			return result, fmt.Errorf("failed to restore tags: %w", err)
+		}
+		result.TagsAdded = int(added)
+	}
+
+	if stores.Notes != nil {
+		for _, note := range incoming.Notes {
+			existing, err := stores.Notes.Get(ctx, note.DID)
+			if err != nil && !errors.Is(err, bsky.ErrNotFound) {
+				return result, fmt.Errorf("failed to look up note on %s: %w", note.DID, err)
+			}
+			if existing != nil && !note.UpdatedAt.After(existing.UpdatedAt) {
+				continue
+			}
+			model := &bsky.NoteModel{DID: note.DID, Handle: note.Handle, Note: note.Note, CreatedAt: note.CreatedAt, UpdatedAt: note.UpdatedAt}
+			if err := stores.Notes.Restore(ctx, model); err != nil {
+				return result, fmt.Errorf("failed to restore note on %s: %w", note.DID, err)
+			}
+			if existing != nil {
+				result.NotesUpdated++
+			} else {
+				result.NotesAdded++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Decode reads a state document
+func Decode(r io.Reader) (*State, error) {
+	var state State
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %w", err)
+	}
+	if state.Version == 0 || state.Version > StateVersion {
+		return nil, fmt.Errorf("unsupported state version: %d", state.Version)
+	}
+	return &state, nil
+}
+
+// Encode writes the state document as indented JSON
+func (s *State) Encode(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
@@ -0,0 +1,460 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/export"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// newTestStores opens the synced repositories on a cache database under a temporary HOME
+func newTestStores(t *testing.T) Stores {
+	t.Helper()
+	_, cleanup := utils.SetupTestConfig(t)
+	t.Cleanup(cleanup)
+
+	ctx := context.Background()
+
+	feedRepo, err := bsky.NewFeedRepository()
+	if err != nil {
+		t.Fatalf("failed to create feed repository: %v", err)
+	}
+	t.Cleanup(func() { feedRepo.Close() })
+	if err := feedRepo.Init(ctx); err != nil {
+		t.Fatalf("feed Init failed: %v", err)
+	}
+
+	snapshotRepo, err := bsky.NewSnapshotRepository()
+	if err != nil {
+		t.Fatalf("failed to create snapshot repository: %v", err)
+	}
+	t.Cleanup(func() { snapshotRepo.Close() })
+	if err := snapshotRepo.Init(ctx); err != nil {
+		t.Fatalf("snapshot Init failed: %v", err)
+	}
+
+	positionRepo, err := bsky.NewReadPositionRepository()
+	if err != nil {
+		t.Fatalf("failed to create read position repository: %v", err)
+	}
+	t.Cleanup(func() { positionRepo.Close() })
+	if err := positionRepo.Init(ctx); err != nil {
+		t.Fatalf("read position Init failed: %v", err)
+	}
+
+	tagRepo, err := bsky.NewTagRepository()
+	if err != nil {
+		t.Fatalf("failed to create tag repository: %v", err)
+	}
+	t.Cleanup(func() { tagRepo.Close() })
+	if err := tagRepo.Init(ctx); err != nil {
+		t.Fatalf("tag Init failed: %v", err)
+	}
+
+	noteRepo, err := bsky.NewNoteRepository()
+	if err != nil {
+		t.Fatalf("failed to create note repository: %v", err)
+	}
+	t.Cleanup(func() { noteRepo.Close() })
+	if err := noteRepo.Init(ctx); err != nil {
+		t.Fatalf("note Init failed: %v", err)
+	}
+
+	return Stores{Feeds: feedRepo, Snapshots: snapshotRepo, ReadPositions: positionRepo, Tags: tagRepo, Notes: noteRepo}
+}
+
+func snapshotDoc(id string, createdAt time.Time) export.SnapshotDocument {
+	return export.SnapshotDocument{
+		Version: export.SnapshotFormatVersion,
+		Snapshot: export.SnapshotMeta{
+			ID:           id,
+			CreatedAt:    createdAt,
+			ExpiresAt:    createdAt.Add(24 * time.Hour),
+			UserDid:      "did:plc:me",
+			SnapshotType: "followers",
+			TotalCount:   1,
+		},
+		Entries: []export.SnapshotDocumentEntry{{ActorDid: "did:plc:follower"}},
+	}
+}
+
+func TestMerge(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	local := &State{
+		Version: StateVersion,
+		Feeds: []Feed{
+			{ID: "feed-1", Name: "local-newer", UpdatedAt: newer},
+			{ID: "feed-2", Name: "local-older", UpdatedAt: older},
+		},
+		Snapshots: []export.SnapshotDocument{snapshotDoc("snap-local", newer)},
+		ReadPositions: []ReadPosition{
+			{Feed: "timeline", Cursor: "local-newer", UpdatedAt: newer},
+			{Feed: "at://feed-a", Cursor: "local-older", UpdatedAt: older},
+		},
+		Tags: []Tag{
+			{Tag: "vip", Kind: bsky.TagFeed, EntityID: "feed-1", CreatedAt: newer},
+			{Tag: "vip", Kind: bsky.TagActor, EntityID: "did:plc:alice", CreatedAt: newer},
+		},
+		Notes: []Note{{DID: "did:plc:alice", Note: "local-older", UpdatedAt: older}},
+	}
+	remote := &State{
+		Version: StateVersion,
+		Feeds: []Feed{
+			{ID: "feed-1", Name: "remote-older", UpdatedAt: older},
+			{ID: "feed-2", Name: "remote-newer", UpdatedAt: newer},
+			{ID: "feed-3", Name: "remote-only", UpdatedAt: older},
+		},
+		Snapshots: []export.SnapshotDocument{snapshotDoc("snap-remote", older), snapshotDoc("snap-local", newer)},
+		ReadPositions: []ReadPosition{
+			{Feed: "timeline", Cursor: "remote-older", UpdatedAt: older},
+			{Feed: "at://feed-a", Cursor: "remote-newer", UpdatedAt: newer},
+		},
+		Tags: []Tag{
+			{Tag: "vip", Kind: bsky.TagFeed, EntityID: "feed-1", CreatedAt: older},
+			{Tag: "news", Kind: bsky.TagFeed, EntityID: "feed-3", CreatedAt: older},
+		},
+		Notes: []Note{
+			{DID: "did:plc:alice", Note: "remote-newer", UpdatedAt: newer},
+			{DID: "did:plc:bob", Note: "remote-only", UpdatedAt: older},
+		},
+	}
+
+	merged := Merge(local, remote)
+
+	names := map[string]string{}
+	for _, feed := range merged.Feeds {
+		names[feed.ID] = feed.Name
+	}
+	if names["feed-1"] != "local-newer" || names["feed-2"] != "remote-newer" || names["feed-3"] != "remote-only" {
+		t.Errorf("unexpected merged feeds: %v", names)
+	}
+
+	if len(merged.Snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(merged.Snapshots))
+	}
+	if merged.Snapshots[0].Snapshot.ID != "snap-remote" {
+		t.Errorf("expected snapshots ordered by creation time, got %s first", merged.Snapshots[0].Snapshot.ID)
+	}
+
+	if len(merged.ReadPositions) != 2 ||
+		merged.ReadPositions[0].Cursor != "remote-newer" || merged.ReadPositions[1].Cursor != "local-newer" {
+		t.Errorf("expected the newer read position per feed, got %+v", merged.ReadPositions)
+	}
+
+	if len(merged.Tags) != 3 {
+		t.Fatalf("expected the union of 3 tags, got %+v", merged.Tags)
+	}
+	for _, tag := range merged.Tags {
+		if tag.Tag == "vip" && tag.Kind == bsky.TagFeed && !tag.CreatedAt.Equal(older) {
+			t.Errorf("expected a tag on both sides to keep its earliest creation time, got %v", tag.CreatedAt)
+		}
+	}
+
+	if len(merged.Notes) != 2 || merged.Notes[0].Note != "remote-newer" || merged.Notes[1].Note != "remote-only" {
+		t.Errorf("expected the newer note per account, got %+v", merged.Notes)
+	}
+
+	if got := Merge(local, nil); len(got.Feeds) != 2 {
+		t.Errorf("expected merge with nil remote to keep local feeds, got %d", len(got.Feeds))
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	state := &State{Version: StateVersion, Feeds: []Feed{{ID: "feed-1", Name: "test"}}}
+
+	var buf bytes.Buffer
+	if err := state.Encode(&buf); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	decoded, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if len(decoded.Feeds) != 1 || decoded.Feeds[0].Name != "test" {
+		t.Errorf("unexpected decoded state: %+v", decoded)
+	}
+
+	if _, err := Decode(bytes.NewBufferString(`{"version": 42}`)); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	stores := newTestStores(t)
+	feedRepo := stores.Feeds
+
+	existing := &bsky.FeedModel{Name: "mine", Source: "local", IsLocal: true}
+	if err := feedRepo.Save(ctx, existing); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	incoming := &State{
+		Version: StateVersion,
+		Feeds: []Feed{
+			{ID: existing.ID(), Name: "stale", Source: "local", CreatedAt: existing.CreatedAt(), UpdatedAt: existing.UpdatedAt().Add(-time.Hour)},
+			{ID: "feed-from-laptop", Name: "laptop", Source: "local", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		},
+		Snapshots: []export.SnapshotDocument{snapshotDoc("snap-1", time.Now().Add(-48*time.Hour))},
+	}
+
+	result, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.FeedsAdded != 1 || result.FeedsUpdated != 0 || result.SnapshotsAdded != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	model, err := feedRepo.Get(ctx, existing.ID())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if model.(*bsky.FeedModel).Name != "mine" {
+		t.Error("older incoming feed should not overwrite local copy")
+	}
+
+	again, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("second Apply failed: %v", err)
+	}
+	if again.FeedsAdded != 0 || again.SnapshotsAdded != 0 {
+		t.Errorf("expected second apply to be a no-op, got %+v", again)
+	}
+
+	local, err := Collect(ctx, stores)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if len(local.Feeds) != 2 || len(local.Snapshots) != 1 {
+		t.Errorf("expected 2 feeds and 1 snapshot, got %d and %d", len(local.Feeds), len(local.Snapshots))
+	}
+}
+
+func TestApplyTrashedFeed(t *testing.T) {
+	ctx := context.Background()
+	stores := newTestStores(t)
+	feedRepo := stores.Feeds
+
+	stale := &bsky.FeedModel{Name: "stale", Source: "local", IsLocal: true}
+	edited := &bsky.FeedModel{Name: "edited", Source: "local", IsLocal: true}
+	for _, feed := range []*bsky.FeedModel{stale, edited} {
+		if err := feedRepo.Save(ctx, feed); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if err := feedRepo.Delete(ctx, feed.ID()); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+	}
+
+	incoming := &State{
+		Version: StateVersion,
+		Feeds: []Feed{
+			{ID: stale.ID(), Name: "remote stale", Source: "local", CreatedAt: stale.CreatedAt(), UpdatedAt: stale.UpdatedAt().Add(-time.Hour)},
+			{ID: edited.ID(), Name: "remote edited", Source: "local", CreatedAt: edited.CreatedAt(), UpdatedAt: time.Now().Add(time.Hour)},
+		},
+	}
+
+	result, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if *result != (ApplyResult{FeedsUndeleted: 1, FeedsTrashed: 1}) {
+		t.Errorf("expected one feed restored and one left in the trash, got %+v", result)
+	}
+
+	trash, err := feedRepo.Trash(ctx)
+	if err != nil {
+		t.Fatalf("Trash failed: %v", err)
+	}
+	if len(trash) != 1 || trash[0].ID() != stale.ID() || trash[0].Name != "stale" {
+		t.Errorf("expected only the stale feed in the trash, unchanged, got %+v", trash)
+	}
+	model, err := feedRepo.Get(ctx, edited.ID())
+	if err != nil {
+		t.Fatalf("expected the edited feed out of the trash: %v", err)
+	}
+	if model.(*bsky.FeedModel).Name != "remote edited" {
+		t.Errorf("expected the newer remote copy, got %q", model.(*bsky.FeedModel).Name)
+	}
+
+	again, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("second Apply failed: %v", err)
+	}
+	if again.FeedsAdded != 0 || again.FeedsUpdated != 0 || again.FeedsUndeleted != 0 {
+		t.Errorf("expected a second pull to change nothing, got %+v", again)
+	}
+}
+
+func TestApplyReadPositions(t *testing.T) {
+	ctx := context.Background()
+	stores := newTestStores(t)
+	positionRepo := stores.ReadPositions
+
+	if err := positionRepo.Save(ctx, bsky.ReadPositionTimeline, "local-cursor", "at://post/local"); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := positionRepo.Save(ctx, "at://feed-a", "stale-cursor", ""); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	now := time.Now().UTC()
+	incoming := &State{
+		Version: StateVersion,
+		ReadPositions: []ReadPosition{
+			{Feed: bsky.ReadPositionTimeline, Cursor: "remote-older", UpdatedAt: now.Add(-time.Hour)},
+			{Feed: "at://feed-a", Cursor: "remote-newer", PostURI: "at://post/remote", UpdatedAt: now.Add(time.Hour)},
+			{Feed: "at://feed-b", Cursor: "remote-only", UpdatedAt: now},
+		},
+	}
+
+	result, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.ReadPositionsUpdated != 2 {
+		t.Errorf("expected 2 read positions updated, got %+v", result)
+	}
+
+	local, err := Collect(ctx, stores)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	cursors := map[string]string{}
+	for _, position := range local.ReadPositions {
+		cursors[position.Feed] = position.Cursor
+	}
+	if cursors[bsky.ReadPositionTimeline] != "local-cursor" || cursors["at://feed-a"] != "remote-newer" || cursors["at://feed-b"] != "remote-only" {
+		t.Errorf("unexpected read positions after apply: %v", cursors)
+	}
+
+	feedA, err := positionRepo.Get(ctx, "at://feed-a")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !feedA.UpdatedAt.Equal(now.Add(time.Hour)) || feedA.PostURI != "at://post/remote" {
+		t.Errorf("expected the remote position with its timestamp, got %+v", feedA)
+	}
+
+	again, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("second Apply failed: %v", err)
+	}
+	if again.ReadPositionsUpdated != 0 {
+		t.Errorf("expected a second pull to change no read positions, got %+v", again)
+	}
+}
+
+func TestApplyTags(t *testing.T) {
+	ctx := context.Background()
+	stores := newTestStores(t)
+	tagRepo := stores.Tags
+
+	if _, err := tagRepo.Add(ctx, "vip", bsky.TagActor, "did:plc:alice"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	incoming := &State{
+		Version: StateVersion,
+		Tags: []Tag{
+			{Tag: "vip", Kind: bsky.TagActor, EntityID: "did:plc:alice", CreatedAt: createdAt},
+			{Tag: "vip", Kind: bsky.TagFeed, EntityID: "feed-1", CreatedAt: createdAt},
+		},
+	}
+
+	result, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.TagsAdded != 1 {
+		t.Errorf("expected 1 tag added, got %+v", result)
+	}
+
+	local, err := Collect(ctx, stores)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if len(local.Tags) != 2 {
+		t.Fatalf("expected 2 tags after apply, got %+v", local.Tags)
+	}
+	for _, tag := range local.Tags {
+		if tag.Kind == bsky.TagFeed && (tag.EntityID != "feed-1" || !tag.CreatedAt.Equal(createdAt)) {
+			t.Errorf("expected the synced feed tag with its creation time, got %+v", tag)
+		}
+	}
+
+	again, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("second Apply failed: %v", err)
+	}
+	if again.TagsAdded != 0 {
+		t.Errorf("expected a second pull to add no tags, got %+v", again)
+	}
+}
+
+func TestApplyNotes(t *testing.T) {
+	ctx := context.Background()
+	stores := newTestStores(t)
+	noteRepo := stores.Notes
+
+	for _, did := range []string{"did:plc:alice", "did:plc:bob"} {
+		if err := noteRepo.Set(ctx, did, "", "local"); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	incoming := &State{
+		Version: StateVersion,
+		Notes: []Note{
+			{DID: "did:plc:alice", Note: "remote older", CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-time.Hour)},
+			{DID: "did:plc:bob", Handle: "bob.test", Note: "remote newer", CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(time.Hour)},
+			{DID: "did:plc:carol", Note: "remote only", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+
+	result, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.NotesAdded != 1 || result.NotesUpdated != 1 {
+		t.Errorf("expected 1 note added and 1 updated, got %+v", result)
+	}
+
+	local, err := Collect(ctx, stores)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	notes := map[string]string{}
+	for _, note := range local.Notes {
+		notes[note.DID] = note.Note
+	}
+	if notes["did:plc:alice"] != "local" || notes["did:plc:bob"] != "remote newer" || notes["did:plc:carol"] != "remote only" {
+		t.Errorf("unexpected notes after apply: %v", notes)
+	}
+
+	bob, err := noteRepo.Get(ctx, "did:plc:bob")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if bob.Handle != "bob.test" || !bob.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected the remote note with its timestamp, got %+v", bob)
+	}
+
+	again, err := Apply(ctx, incoming, stores)
+	if err != nil {
+		t.Fatalf("second Apply failed: %v", err)
+	}
+	if again.NotesAdded != 0 || again.NotesUpdated != 0 {
+		t.Errorf("expected a second pull to change no notes, got %+v", again)
+	}
+}
@@ -0,0 +1,165 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/coder/websocket"
+)
+
+// DefaultEndpoint is the public Jetstream instance used when none is configured
+const DefaultEndpoint = "wss://jetstream2.us-east.bsky.network/subscribe"
+
+// maxMessageSize bounds a single Jetstream event; records are small, but the default 32KiB limit is not enough for long posts with facets and embeds
+const maxMessageSize = 1 << 20
+
+// Event is a single Jetstream message: a repo commit, or an identity or account update.
+// Identity and account events are delivered whatever collections a subscription asks for.
+type Event struct {
+	Did      string    `json:"did"`
+	TimeUS   int64     `json:"time_us"` // Unix microseconds; usable as a resume cursor
+	Kind     string    `json:"kind"`    // "commit", "identity", or "account"
+	Commit   *Commit   `json:"commit,omitempty"`
+	Identity *Identity `json:"identity,omitempty"`
+	Account  *Account  `json:"account,omitempty"`
+}
+
+// Commit describes a record created, updated, or deleted in the repo of [Event.Did]
+type Commit struct {
+	Rev        string          `json:"rev"`
+	Operation  string          `json:"operation"` // "create", "update", or "delete"
+	Collection string          `json:"collection"`
+	Rkey       string          `json:"rkey"`
+	Record     json.RawMessage `json:"record,omitempty"` // Absent for deletes
+	Cid        string          `json:"cid,omitempty"`
+}
+
+// Identity reports that an account's handle or DID document changed, such as after a PDS migration
+type Identity struct {
+	Did    string `json:"did"`
+	Handle string `json:"handle,omitempty"` // Absent when the account's handle no longer verifies
+	Seq    int64  `json:"seq"`
+	Time   string `json:"time"`
+}
+
+// Account reports an account becoming active or inactive on its PDS
+type Account struct {
+	Did    string `json:"did"`
+	Active bool   `json:"active"`
+	Status string `json:"status,omitempty"` // Why an inactive account is inactive: "deactivated", "deleted", "takendown", ...
+	Seq    int64  `json:"seq"`
+	Time   string `json:"time"`
+}
+
+// URI returns the AT URI of the committed record, or "" for non-commit events
+func (e Event) URI() string {
+	if e.Commit == nil {
+		return ""
+	}
+	return "at://" + e.Did + "/" + e.Commit.Collection + "/" + e.Commit.Rkey
+}
+
+// Key identifies the event independently of when it was delivered, so a replayed event can be recognized.
+// Commits are keyed by repo revision and record; identity and account events by their time.
+func (e Event) Key() string {
+	if e.Commit == nil {
+		return e.Did + "#" + e.Kind + "@" + strconv.FormatInt(e.TimeUS, 10)
+	}
+	return e.URI() + "#" + e.Commit.Operation + "@" + e.Commit.Rev
+}
+
+// Options selects which events a subscription receives
+type Options struct {
+	Endpoint    string   // Defaults to DefaultEndpoint
+	Collections []string // NSIDs such as app.bsky.feed.post; empty receives every collection
+	Dids        []string // Repos to follow; empty receives every repo
+	Cursor      int64    // Replay from this time_us; zero starts with live events
+}
+
+// StreamError reports a failed subscription
+type StreamError struct {
+	Op  string
+	Err error
+}
+
+func (e *StreamError) Error() string {
+	return "stream." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// Subscribe connects to Jetstream and calls handle for every event until ctx is done, the connection drops,
+// or handle fails. It returns the time_us of the last event handled, so callers can reconnect with
+// [Options.Cursor] without missing events. A canceled ctx is not an error.
+func Subscribe(ctx context.Context, opts Options, handle func(ctx context.Context, event Event) error) (int64, error) {
+	endpoint, err := subscribeURL(opts)
+	if err != nil {
+		return opts.Cursor, &StreamError{Op: "Subscribe", Err: err}
+	}
+
+	conn, _, err := websocket.Dial(ctx, endpoint, nil)
+	if err != nil {
+		if ctx.Err() != nil {
+			return opts.Cursor, nil
+		}
+		return opts.Cursor, &StreamError{Op: "Dial", Err: err}
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(maxMessageSize)
+
+	cursor := opts.Cursor
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				conn.Close(websocket.StatusNormalClosure, "")
+				return cursor, nil
+			}
+			return cursor, &StreamError{Op: "Read", Err: err}
+		}
+
+		var event Event
+		if err := json.Unmarshal(data, &event); err != nil {
+			return cursor, &StreamError{Op: "Decode", Err: err}
+		}
+
+		if err := handle(ctx, event); err != nil {
+			return cursor, &StreamError{Op: "Handle", Err: err}
+		}
+		cursor = event.TimeUS
+	}
+}
+
+// subscribeURL builds the Jetstream subscribe URL carrying opts as query parameters
+func subscribeURL(opts Options) (string, error) {
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return "", errors.New("jetstream endpoint must be a ws:// or wss:// URL: " + endpoint)
+	}
+
+	query := u.Query()
+	for _, collection := range opts.Collections {
+		query.Add("wantedCollections", collection)
+	}
+	for _, did := range opts.Dids {
+		query.Add("wantedDids", did)
+	}
+	if opts.Cursor > 0 {
+		query.Set("cursor", strconv.FormatInt(opts.Cursor, 10))
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
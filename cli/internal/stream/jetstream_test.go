@@ -0,0 +1,136 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coder/websocket"
+)
+
+func TestSubscribeURL(t *testing.T) {
+	got, err := subscribeURL(Options{
+		Collections: []string{"app.bsky.feed.post", "app.bsky.graph.follow"},
+		Dids:        []string{"did:plc:me"},
+		Cursor:      1700000000000000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := DefaultEndpoint + "?cursor=1700000000000000&wantedCollections=app.bsky.feed.post&wantedCollections=app.bsky.graph.follow&wantedDids=did%3Aplc%3Ame"
+	if got != want {
+		t.Errorf("subscribeURL() = %q, want %q", got, want)
+	}
+
+	if _, err := subscribeURL(Options{Endpoint: "https://jetstream.example.com"}); err == nil {
+		t.Error("expected error for non-websocket endpoint")
+	}
+}
+
+func TestEventKey(t *testing.T) {
+	create := Event{Did: "did:plc:me", TimeUS: 10, Kind: "commit", Commit: &Commit{Rev: "r1", Operation: "create", Collection: "app.bsky.feed.post", Rkey: "3k"}}
+	replayed := create
+	replayed.TimeUS = 20
+	if create.Key() != replayed.Key() {
+		t.Errorf("expected redelivered commit to keep its key, got %q and %q", create.Key(), replayed.Key())
+	}
+
+	remove := Event{Did: "did:plc:me", TimeUS: 10, Kind: "commit", Commit: &Commit{Rev: "r2", Operation: "delete", Collection: "app.bsky.feed.post", Rkey: "3k"}}
+	if create.Key() == remove.Key() {
+		t.Error("expected create and delete of a record to have different keys")
+	}
+	if got := (Event{Did: "did:plc:me", TimeUS: 10, Kind: "identity"}).Key(); got != "did:plc:me#identity@10" {
+		t.Errorf("unexpected identity key %q", got)
+	}
+}
+
+func TestEventURI(t *testing.T) {
+	event := Event{Did: "did:plc:me", Kind: "commit", Commit: &Commit{Collection: "app.bsky.feed.post", Rkey: "3k"}}
+	if got := event.URI(); got != "at://did:plc:me/app.bsky.feed.post/3k" {
+		t.Errorf("URI() = %q", got)
+	}
+	if got := (Event{Did: "did:plc:me", Kind: "identity"}).URI(); got != "" {
+		t.Errorf("expected empty URI for identity events, got %q", got)
+	}
+}
+
+// jetstreamServer serves messages over a websocket and then closes the connection
+func jetstreamServer(t *testing.T, messages ...string) (string, *string) {
+	t.Helper()
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Errorf("accept failed: %v", err)
+			return
+		}
+		defer conn.CloseNow()
+		for _, message := range messages {
+			if err := conn.Write(r.Context(), websocket.MessageText, []byte(message)); err != nil {
+				return
+			}
+		}
+		conn.Close(websocket.StatusGoingAway, "done")
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http"), &query
+}
+
+func TestSubscribe(t *testing.T) {
+	endpoint, query := jetstreamServer(t,
+		`{"did":"did:plc:a","time_us":10,"kind":"commit","commit":{"operation":"create","collection":"app.bsky.feed.post","rkey":"1","record":{"text":"hello"}}}`,
+		`{"did":"did:plc:b","time_us":20,"kind":"identity","identity":{"did":"did:plc:b","handle":"b.example.com","seq":7,"time":"2024-06-01T12:00:00Z"}}`,
+	)
+
+	var events []Event
+	cursor, err := Subscribe(context.Background(), Options{Endpoint: endpoint, Collections: []string{"app.bsky.feed.post"}, Cursor: 5},
+		func(ctx context.Context, event Event) error {
+			events = append(events, event)
+			return nil
+		})
+
+	var streamErr *StreamError
+	if !errors.As(err, &streamErr) || streamErr.Op != "Read" {
+		t.Fatalf("expected Read error when the server closes, got %v", err)
+	}
+	if cursor != 20 {
+		t.Errorf("expected cursor of last event, got %d", cursor)
+	}
+	if len(events) != 2 || events[0].URI() != "at://did:plc:a/app.bsky.feed.post/1" || string(events[0].Commit.Record) != `{"text":"hello"}` {
+		t.Errorf("unexpected events %+v", events)
+	}
+	if events[1].Identity == nil || events[1].Identity.Handle != "b.example.com" {
+		t.Errorf("expected identity details, got %+v", events[1].Identity)
+	}
+	if !strings.Contains(*query, "cursor=5") || !strings.Contains(*query, "wantedCollections=app.bsky.feed.post") {
+		t.Errorf("unexpected subscribe query %q", *query)
+	}
+}
+
+func TestSubscribe_HandlerError(t *testing.T) {
+	endpoint, _ := jetstreamServer(t,
+		`{"did":"did:plc:a","time_us":10,"kind":"account"}`,
+		`{"did":"did:plc:a","time_us":20,"kind":"account"}`,
+	)
+
+	handled := 0
+	cursor, err := Subscribe(context.Background(), Options{Endpoint: endpoint}, func(ctx context.Context, event Event) error {
+		handled++
+		if event.TimeUS == 20 {
+			return errors.New("storage full")
+		}
+		return nil
+	})
+
+	var streamErr *StreamError
+	if !errors.As(err, &streamErr) || streamErr.Op != "Handle" {
+		t.Fatalf("expected Handle error, got %v", err)
+	}
+	if handled != 2 || cursor != 10 {
+		t.Errorf("expected cursor to stop before the failed event, got handled=%d cursor=%d", handled, cursor)
+	}
+}
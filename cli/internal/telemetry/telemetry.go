@@ -0,0 +1,117 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName identifies skycli in exported traces
+const ServiceName = "skycli"
+
+// endpointEnvs are the standard variables that point the OTLP exporter at a collector
+var endpointEnvs = []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"}
+
+// Enabled reports whether trace export is configured in cfg or the environment
+func Enabled(cfg *config.TelemetryConfig) bool {
+	if cfg != nil && cfg.Endpoint != "" {
+		return true
+	}
+	return envEndpoint() != ""
+}
+
+// Setup installs a global tracer provider that batches spans to an OTLP/HTTP collector.
+// When telemetry is not enabled, spans go to the default no-op provider and the returned shutdown does nothing.
+// Call shutdown before exit to flush buffered spans.
+func Setup(ctx context.Context, cfg *config.TelemetryConfig, version string) (func(context.Context) error, error) {
+	if !Enabled(cfg) {
+		return func(context.Context) error { return nil }, nil
+	}
+	if cfg == nil {
+		cfg = &config.TelemetryConfig{}
+	}
+
+	var opts []otlptracehttp.Option
+	// The exporter reads OTEL_EXPORTER_OTLP_* itself; only fall back to the config file without them
+	if envEndpoint() == "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, &TelemetryError{Op: "NewExporter", Err: err}
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, &TelemetryError{Op: "NewResource", Err: err}
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// StartCommand starts the root span for one CLI command so its API calls and queries share a trace
+func StartCommand(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(ServiceName).Start(ctx, name)
+}
+
+// EndSpan marks the span failed when err is non-nil, then ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// envEndpoint returns the first OTLP endpoint set in the environment
+func envEndpoint() string {
+	for _, name := range endpointEnvs {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// TelemetryError represents an error that occurred while configuring trace export
+type TelemetryError struct {
+	Op  string
+	Err error
+}
+
+func (e *TelemetryError) Error() string {
+	return "telemetry." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *TelemetryError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,83 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	if Enabled(nil) {
+		t.Error("expected telemetry to be disabled without config or environment")
+	}
+	if !Enabled(&config.TelemetryConfig{Endpoint: "http://localhost:4318"}) {
+		t.Error("expected configured endpoint to enable telemetry")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+	if !Enabled(nil) {
+		t.Error("expected environment endpoint to enable telemetry")
+	}
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	before := otel.GetTracerProvider()
+	shutdown, err := Setup(context.Background(), nil, "test")
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("expected no-op shutdown, got %v", err)
+	}
+	if otel.GetTracerProvider() != before {
+		t.Error("expected global tracer provider to be left alone when disabled")
+	}
+}
+
+func TestSetup_Enabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	before := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(before) })
+
+	shutdown, err := Setup(context.Background(), &config.TelemetryConfig{Endpoint: "http://127.0.0.1:4318"}, "test")
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if _, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); !ok {
+		t.Errorf("expected SDK tracer provider, got %T", otel.GetTracerProvider())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	shutdown(ctx)
+}
+
+func TestEndSpan_RecordsError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	_, span := provider.Tracer("test").Start(context.Background(), "op")
+	EndSpan(span, errors.New("boom"))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 ended span, got %d", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error || spans[0].Status().Description != "boom" {
+		t.Errorf("expected error status, got %+v", spans[0].Status())
+	}
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+// ErrNoBrowser is returned when no command to open a browser is installed
+var ErrNoBrowser = errors.New("no browser opener found: install xdg-utils or open the link by hand")
+
+// browserTools returns the commands that open a URL in the default browser on goos, in order of preference.
+// Under WSL the Windows browser is reachable through wslview or explorer.exe.
+func browserTools(goos string, wsl bool) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"open"}}
+	case "windows":
+		return [][]string{{"rundll32", "url.dll,FileProtocolHandler"}}
+	default:
+		tools := [][]string{{"xdg-open"}}
+		if wsl {
+			tools = append(tools, []string{"wslview"}, []string{"explorer.exe"})
+		}
+		return tools
+	}
+}
+
+// OpenURL opens target in the default browser without waiting for it to close
+func OpenURL(target string) error {
+	for _, tool := range browserTools(runtime.GOOS, utils.IsWSL()) {
+		path, err := exec.LookPath(tool[0])
+		if err != nil {
+			continue
+		}
+		return exec.Command(path, append(tool[1:], target)...).Start()
+	}
+	return ErrNoBrowser
+}
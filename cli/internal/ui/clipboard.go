@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+// ErrNoClipboard is returned when no clipboard tool is installed and the terminal can't be asked instead
+var ErrNoClipboard = errors.New("no clipboard available: install pbcopy, wl-copy, xclip, or xsel, or use a terminal with OSC 52 support")
+
+// clipboardTools returns the commands that write stdin to the system clipboard on goos, in order of preference.
+// Under WSL the Windows clipboard is reachable through clip.exe when no Linux tool is installed.
+func clipboardTools(goos string, wsl bool) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	default:
+		tools := [][]string{
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+		}
+		if wsl {
+			tools = append(tools, []string{"clip.exe"})
+		}
+		return tools
+	}
+}
+
+// CopyToClipboard places text on the system clipboard using the platform's clipboard tool. Without one, it asks
+// the terminal to set the clipboard with an OSC 52 escape sequence, which also works over SSH.
+func CopyToClipboard(text string) error {
+	var errs []error
+	for _, tool := range clipboardTools(runtime.GOOS, utils.IsWSL()) {
+		path, err := exec.LookPath(tool[0])
+		if err != nil {
+			continue
+		}
+
+		cmd := exec.Command(path, tool[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w: %s", tool[0], err, strings.TrimSpace(string(output))))
+			continue
+		}
+		return nil
+	}
+
+	if StdoutIsTerminal() {
+		return writeOSC52(os.Stdout, text)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return ErrNoClipboard
+}
+
+// writeOSC52 asks the terminal on w to set the clipboard, wrapping the sequence for tmux and screen
+func writeOSC52(w io.Writer, text string) error {
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+	_, err := seq.WriteTo(w)
+	return err
+}
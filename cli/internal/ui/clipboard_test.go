@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCopyToClipboard(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake clipboard tool is a shell script")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "clipboard")
+	script := "#!/bin/sh\ncat > " + out + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "wl-copy"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake tool: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	if err := CopyToClipboard("at://did:plc:abc/app.bsky.feed.post/1"); err != nil {
+		t.Fatalf("CopyToClipboard failed: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil || string(data) != "at://did:plc:abc/app.bsky.feed.post/1" {
+		t.Errorf("unexpected clipboard contents %q (err %v)", data, err)
+	}
+}
+
+func TestCopyToClipboard_NoTool(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	if StdoutIsTerminal() {
+		t.Skip("stdout is a terminal, so OSC 52 would be used")
+	}
+	if err := CopyToClipboard("text"); err != ErrNoClipboard {
+		t.Errorf("expected ErrNoClipboard, got %v", err)
+	}
+}
+
+func TestWriteOSC52(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+
+	var buf bytes.Buffer
+	if err := writeOSC52(&buf, "hello"); err != nil {
+		t.Fatalf("writeOSC52 failed: %v", err)
+	}
+	// "hello" base64-encoded inside an OSC 52 clipboard sequence
+	if !strings.Contains(buf.String(), "]52;c;aGVsbG8=") {
+		t.Errorf("unexpected sequence %q", buf.String())
+	}
+}
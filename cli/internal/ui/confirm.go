@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrConfirmationRequired is returned when a destructive action needs confirmation but prompting is not possible
+var ErrConfirmationRequired = errors.New("confirmation required: re-run with --yes to proceed without a prompt")
+
+// ErrDeclined is returned when the user answers no to a confirmation prompt
+var ErrDeclined = errors.New("aborted")
+
+// ConfirmOptions controls how [Confirm] asks before a destructive action
+type ConfirmOptions struct {
+	Yes     bool      // proceed without prompting (--yes)
+	NoInput bool      // never prompt; fail unless Yes is set (--no-input)
+	In      io.Reader // answer source; defaults to os.Stdin
+	Out     io.Writer // prompt destination; defaults to os.Stderr
+}
+
+// Confirm asks a yes/no question and returns nil only on an explicit yes.
+// When Yes is set it returns nil without asking. It fails safe with [ErrConfirmationRequired] under
+// NoInput or when In is a file that is not a terminal, so scripts and pipes never proceed by accident.
+// Readers that are not files are treated as interactive.
+func Confirm(prompt string, opts ConfirmOptions) error {
+	if opts.Yes {
+		return nil
+	}
+	if opts.In == nil {
+		opts.In = os.Stdin
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stderr
+	}
+	if opts.NoInput || !isInteractive(opts.In) {
+		return ErrConfirmationRequired
+	}
+
+	fmt.Fprint(opts.Out, warning(prompt)+" "+TextStyle.Render("[y/N]")+" ")
+
+	answer, err := bufio.NewReader(opts.In).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return ErrDeclined
+	}
+}
+
+// isInteractive reports whether r can answer a prompt; files must be character devices (terminals)
+func isInteractive(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return true
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ErrNotInteractive is returned when a command needs to ask questions but input can't answer them
+var ErrNotInteractive = errors.New("interactive mode needs a terminal: run without --no-input and without piping input")
+
+// Prompter asks a series of questions on one input, keeping answers typed ahead between them
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter returns a prompter on opts.In and opts.Out, with the same defaults as [Confirm]. It fails with
+// [ErrNotInteractive] under NoInput or when In is a file that is not a terminal; Yes is ignored.
+func NewPrompter(opts ConfirmOptions) (*Prompter, error) {
+	if opts.In == nil {
+		opts.In = os.Stdin
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stderr
+	}
+	if opts.NoInput || !isInteractive(opts.In) {
+		return nil, ErrNotInteractive
+	}
+	return &Prompter{in: bufio.NewReader(opts.In), out: opts.Out}, nil
+}
+
+// Ask prints prompt and returns the answer without surrounding space. It returns [io.EOF] once input is closed.
+func (p *Prompter) Ask(prompt string) (string, error) {
+	return p.read(TextStyle.Render(prompt))
+}
+
+// Confirm asks a yes/no question and reports whether the answer was yes
+func (p *Prompter) Confirm(prompt string) (bool, error) {
+	answer, err := p.read(warning(prompt) + " " + TextStyle.Render("[y/N]"))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// read prints an already styled prompt and reads one line of answer
+func (p *Prompter) read(prompt string) (string, error) {
+	fmt.Fprint(p.out, prompt+" ")
+
+	answer, err := p.in.ReadString('\n')
+	if errors.Is(err, io.EOF) && answer != "" {
+		err = nil
+	}
+	return strings.TrimSpace(answer), err
+}
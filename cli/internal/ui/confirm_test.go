@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ConfirmOptions
+		input   string
+		wantErr error
+	}{
+		{"yes answer", ConfirmOptions{}, "y\n", nil},
+		{"full yes answer", ConfirmOptions{}, "YES\n", nil},
+		{"no answer", ConfirmOptions{}, "n\n", ErrDeclined},
+		{"empty answer defaults to no", ConfirmOptions{}, "\n", ErrDeclined},
+		{"closed input defaults to no", ConfirmOptions{}, "", ErrDeclined},
+		{"yes flag skips prompt", ConfirmOptions{Yes: true}, "", nil},
+		{"no-input fails safe", ConfirmOptions{NoInput: true}, "y\n", ErrConfirmationRequired},
+		{"yes flag wins over no-input", ConfirmOptions{Yes: true, NoInput: true}, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			tt.opts.In = strings.NewReader(tt.input)
+			tt.opts.Out = &out
+
+			err := Confirm("Delete everything?", tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Confirm() error = %v, want %v", err, tt.wantErr)
+			}
+
+			prompted := strings.Contains(out.String(), "Delete everything?")
+			if wantPrompt := !tt.opts.Yes && !tt.opts.NoInput; prompted != wantPrompt {
+				t.Errorf("expected prompt shown = %v, got output %q", wantPrompt, out.String())
+			}
+		})
+	}
+}
+
+func TestConfirm_NonTerminalFailsSafe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers")
+	if err := os.WriteFile(path, []byte("y\n"), 0600); err != nil {
+		t.Fatalf("failed to write answers: %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open answers: %v", err)
+	}
+	defer f.Close()
+
+	err = Confirm("Delete everything?", ConfirmOptions{In: f, Out: &bytes.Buffer{}})
+	if !errors.Is(err, ErrConfirmationRequired) {
+		t.Errorf("expected piped input to require --yes, got %v", err)
+	}
+}
+
+func TestPrompter(t *testing.T) {
+	var out bytes.Buffer
+	p, err := NewPrompter(ConfirmOptions{In: strings.NewReader(" n \ny\nlast"), Out: &out})
+	if err != nil {
+		t.Fatalf("NewPrompter failed: %v", err)
+	}
+
+	if answer, err := p.Ask("Action?"); err != nil || answer != "n" {
+		t.Errorf("Ask() = %q, %v; want \"n\"", answer, err)
+	}
+	if ok, err := p.Confirm("Block?"); err != nil || !ok {
+		t.Errorf("Confirm() = %v, %v; want true", ok, err)
+	}
+	if answer, err := p.Ask("Action?"); err != nil || answer != "last" {
+		t.Errorf("Ask() on an unterminated line = %q, %v; want \"last\"", answer, err)
+	}
+	if _, err := p.Ask("Action?"); !errors.Is(err, io.EOF) {
+		t.Errorf("Ask() on closed input error = %v, want io.EOF", err)
+	}
+	if !strings.Contains(out.String(), "Block?") {
+		t.Errorf("expected prompts in output, got %q", out.String())
+	}
+
+	if _, err := NewPrompter(ConfirmOptions{NoInput: true, Yes: true}); !errors.Is(err, ErrNotInteractive) {
+		t.Errorf("expected --no-input to refuse prompting, got %v", err)
+	}
+}
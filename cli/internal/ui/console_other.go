@@ -0,0 +1,9 @@
+//go:build !windows
+
+package ui
+
+// EnableANSI prepares the terminal for styled output. Terminals outside Windows understand escape codes already,
+// so it does nothing.
+func EnableANSI() (restore func()) {
+	return func() {}
+}
@@ -0,0 +1,40 @@
+//go:build windows
+
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"golang.org/x/sys/windows"
+)
+
+// EnableANSI turns on virtual terminal processing for stdout and stderr, so the Windows console (conhost, as
+// behind older PowerShell windows) renders colors and table borders instead of printing escape codes. Consoles
+// too old to support it get plain output. The returned function restores the previous console modes.
+func EnableANSI() (restore func()) {
+	var restores []func()
+	supported := true
+	for _, handle := range []windows.Handle{windows.Stdout, windows.Stderr} {
+		var mode uint32
+		if err := windows.GetConsoleMode(handle, &mode); err != nil {
+			continue // redirected to a file or pipe
+		}
+		if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+			continue
+		}
+		if err := windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+			supported = false
+			continue
+		}
+		restores = append(restores, func() { windows.SetConsoleMode(handle, mode) })
+	}
+
+	if !supported {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
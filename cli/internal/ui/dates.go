@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateStyle selects how timestamps render in tables and exports
+type DateStyle string
+
+const (
+	DatesDefault  DateStyle = ""         // each output keeps its own default
+	DatesRelative DateStyle = "relative" // "3 days ago"
+	DatesISO      DateStyle = "iso"      // RFC3339 in UTC
+	DatesLocal    DateStyle = "local"    // "2006-01-02 15:04" in the user's time zone
+)
+
+// localDateLayout is the layout of [DatesLocal]
+const localDateLayout = "2006-01-02 15:04"
+
+// dateStyle is the style chosen with --dates; [DatesDefault] lets each output pick
+var dateStyle DateStyle
+
+// location is the user's time zone, from --timezone or the config file
+var location = time.Local
+
+// SetLocation sets the time zone local dates are shown in and date flags are read in
+func SetLocation(loc *time.Location) {
+	location = loc
+}
+
+// Location returns the user's time zone
+func Location() *time.Location {
+	return location
+}
+
+// ParseDateStyle validates a --dates value; the empty string selects [DatesDefault]
+func ParseDateStyle(s string) (DateStyle, error) {
+	switch style := DateStyle(s); style {
+	case DatesDefault, DatesRelative, DatesISO, DatesLocal:
+		return style, nil
+	default:
+		return DatesDefault, fmt.Errorf("invalid --dates %q: expected relative, iso, or local", s)
+	}
+}
+
+// SetDateStyle sets the style [FormatDate] uses in place of each output's default
+func SetDateStyle(style DateStyle) {
+	dateStyle = style
+}
+
+// FormatDate renders t in the style chosen with --dates, or in fallback when none was chosen.
+// The zero time renders as the empty string so callers can pick their own placeholder.
+func FormatDate(t time.Time, fallback DateStyle) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	style := dateStyle
+	if style == DatesDefault {
+		style = fallback
+	}
+
+	switch style {
+	case DatesRelative:
+		return formatRelative(time.Since(t))
+	case DatesLocal:
+		return t.In(location).Format(localDateLayout)
+	default:
+		return t.UTC().Format(time.RFC3339)
+	}
+}
+
+// FormatDateString is [FormatDate] for an RFC3339 timestamp from the API, returned unchanged when it doesn't parse
+func FormatDateString(s string, fallback DateStyle) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	return FormatDate(t, fallback)
+}
+
+// formatRelative renders an elapsed duration as "< 1 hour ago", "X hours ago", or "X days ago"
+func formatRelative(elapsed time.Duration) string {
+	hours := elapsed.Hours()
+
+	switch {
+	case hours < 1:
+		return "< 1 hour ago"
+	case hours < 2:
+		return "1 hour ago"
+	case hours < 24:
+		return fmt.Sprintf("%d hours ago", int(hours))
+	case hours < 48:
+		return "1 day ago"
+	default:
+		return fmt.Sprintf("%d days ago", int(hours/24))
+	}
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateStyle(t *testing.T) {
+	for _, s := range []string{"", "relative", "iso", "local"} {
+		if style, err := ParseDateStyle(s); err != nil || string(style) != s {
+			t.Errorf("ParseDateStyle(%q) = %q, %v", s, style, err)
+		}
+	}
+	if _, err := ParseDateStyle("unix"); err == nil {
+		t.Error("expected an unknown style to be rejected")
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	defer SetDateStyle(DatesDefault)
+	at := time.Now().Add(-50 * time.Hour)
+
+	tests := []struct {
+		name     string
+		style    DateStyle
+		fallback DateStyle
+		want     string
+	}{
+		{"FallbackRelative", DatesDefault, DatesRelative, "2 days ago"},
+		{"FallbackISO", DatesDefault, DatesISO, at.UTC().Format(time.RFC3339)},
+		{"OverridesFallback", DatesLocal, DatesISO, at.Local().Format("2006-01-02 15:04")},
+		{"RelativeOverride", DatesRelative, DatesLocal, "2 days ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDateStyle(tt.style)
+			if got := FormatDate(at, tt.fallback); got != tt.want {
+				t.Errorf("FormatDate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := FormatDate(time.Time{}, DatesISO); got != "" {
+		t.Errorf("zero time should render empty, got %q", got)
+	}
+}
+
+func TestFormatRelative(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Minute: "< 1 hour ago",
+		90 * time.Minute: "1 hour ago",
+		5 * time.Hour:    "5 hours ago",
+		30 * time.Hour:   "1 day ago",
+		80 * time.Hour:   "3 days ago",
+	}
+	for elapsed, want := range tests {
+		if got := formatRelative(elapsed); got != want {
+			t.Errorf("formatRelative(%v) = %q, want %q", elapsed, got, want)
+		}
+	}
+}
+
+func TestFormatDateString(t *testing.T) {
+	defer SetDateStyle(DatesDefault)
+	SetDateStyle(DatesISO)
+	if got := FormatDateString("2026-10-13T11:30:00+02:00", DatesLocal); got != "2026-10-13T09:30:00Z" {
+		t.Errorf("FormatDateString() = %q", got)
+	}
+	if got := FormatDateString("yesterday", DatesISO); got != "yesterday" {
+		t.Errorf("unparseable input should pass through, got %q", got)
+	}
+}
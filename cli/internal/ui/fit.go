@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/rivo/uniseg"
+)
+
+// ColumnKeep is the priority of a column [FitTable] never drops, such as a handle or the table's key metric.
+// Columns not given a priority are kept too.
+const ColumnKeep = 0
+
+// minTruncatedWidth is the narrowest a column is shortened to, unless its header is wider
+const minTruncatedWidth = 8
+
+// wideTables turns off fitting tables to the terminal, set with --wide
+var wideTables bool
+
+// SetWideTables makes tables print every column at full width however narrow the terminal
+func SetWideTables(wide bool) {
+	wideTables = wide
+}
+
+// TableWidth returns the width tables are fit to: the terminal's, or $COLUMNS when its size can't be read. It is 0,
+// for no limit, when stdout isn't a terminal or --wide was given.
+func TableWidth() int {
+	if wideTables || !StdoutIsTerminal() {
+		return 0
+	}
+	if width, _, err := term.GetSize(os.Stdout.Fd()); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
+
+// FittedTable is a table cut down to fit a width
+type FittedTable struct {
+	Headers []string
+	Rows    [][]string
+	Hidden  []string // headers of the columns dropped, in table order
+}
+
+// FitTable fits a table to [TableWidth], see [FitColumns]
+func FitTable(headers []string, rows [][]string, priorities map[string]int, shorten ...string) FittedTable {
+	return FitColumns(headers, rows, priorities, shorten, TableWidth())
+}
+
+// FitColumns fits a table drawn with bordered, padded cells into width, 0 meaning any width. When shortening the
+// columns named in shorten, with an ellipsis but never below their header, isn't enough, columns are dropped by
+// priority, the highest number first and the rightmost of equals, until it is. Columns missing from priorities
+// are never dropped. A table still too wide once nothing more can go is left wider than width.
+func FitColumns(headers []string, rows [][]string, priorities map[string]int, shorten []string, width int) FittedTable {
+	fitted := FittedTable{Headers: headers, Rows: rows}
+	if width <= 0 || len(headers) == 0 {
+		return fitted
+	}
+
+	widths := make([]int, len(headers))
+	floors := make([]int, len(headers)) // narrowest each column can be shortened to
+	for col, header := range headers {
+		widths[col] = uniseg.StringWidth(header)
+		for _, row := range rows {
+			if col < len(row) {
+				widths[col] = max(widths[col], uniseg.StringWidth(row[col]))
+			}
+		}
+		floors[col] = widths[col]
+		if slices.Contains(shorten, header) {
+			floors[col] = min(widths[col], max(minTruncatedWidth, uniseg.StringWidth(header)))
+		}
+	}
+	shown := make([]bool, len(headers))
+	for col := range shown {
+		shown[col] = true
+	}
+	// Each cell has a space of padding on either side, and every column a border to its left, plus the last one
+	total := func(widths []int) int {
+		sum := 1
+		for col, w := range widths {
+			if shown[col] {
+				sum += w + 3
+			}
+		}
+		return sum
+	}
+
+	for total(floors) > width {
+		drop := -1
+		for col, header := range headers {
+			if shown[col] && priorities[header] > ColumnKeep && (drop < 0 || priorities[header] >= priorities[headers[drop]]) {
+				drop = col
+			}
+		}
+		if drop < 0 {
+			break
+		}
+		shown[drop] = false
+	}
+
+	for total(widths) > width {
+		widest := -1
+		for col := range headers {
+			if shown[col] && widths[col] > floors[col] && (widest < 0 || widths[col] > widths[widest]) {
+				widest = col
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest] = max(widths[widest]-(total(widths)-width), floors[widest])
+	}
+
+	fitted.Headers = nil
+	for col, header := range headers {
+		if shown[col] {
+			fitted.Headers = append(fitted.Headers, header)
+		} else {
+			fitted.Hidden = append(fitted.Hidden, header)
+		}
+	}
+	fitted.Rows = make([][]string, len(rows))
+	for i, row := range rows {
+		fitted.Rows[i] = make([]string, 0, len(fitted.Headers))
+		for col, cell := range row {
+			if col < len(shown) && shown[col] {
+				fitted.Rows[i] = append(fitted.Rows[i], truncateCell(cell, widths[col]))
+			}
+		}
+	}
+	return fitted
+}
+
+// Column returns the index of the fitted column with header, or -1 when it was dropped
+func (f FittedTable) Column(header string) int {
+	for col, h := range f.Headers {
+		if h == header {
+			return col
+		}
+	}
+	return -1
+}
+
+// HiddenNote prints which columns were dropped to fit the terminal, if any
+func (f FittedTable) HiddenNote() {
+	if len(f.Hidden) > 0 {
+		Infoln("Hidden to fit the terminal: %s. Use --wide to show every column.", strings.Join(f.Hidden, ", "))
+	}
+}
+
+// truncateCell shortens text to width columns, ending it with an ellipsis when cut
+func truncateCell(text string, width int) string {
+	if uniseg.StringWidth(text) <= width {
+		return text
+	}
+	var b strings.Builder
+	used := 0
+	state := -1
+	rest := text
+	for len(rest) > 0 {
+		var cluster string
+		var w int
+		cluster, rest, w, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+w > width-1 {
+			break
+		}
+		b.WriteString(cluster)
+		used += w
+	}
+	return b.String() + "…"
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/rivo/uniseg"
+)
+
+func TestFitColumns(t *testing.T) {
+	headers := []string{"Handle", "Display Name", "Followers", "Profile URL"}
+	rows := [][]string{
+		{"@alice.bsky.social", "Alice in Wonderland 🐇", "1204", "https://bsky.app/profile/alice.bsky.social"},
+		{"@bob.bsky.social", "bob", "88", "https://bsky.app/profile/bob.bsky.social"},
+	}
+	priorities := map[string]int{"Profile URL": 2, "Display Name": 1}
+	shorten := []string{"Display Name"}
+
+	// width returns how wide the fitted table draws
+	width := func(fit FittedTable) int {
+		total := 1
+		for col, header := range fit.Headers {
+			w := uniseg.StringWidth(header)
+			for _, row := range fit.Rows {
+				w = max(w, uniseg.StringWidth(row[col]))
+			}
+			total += w + 3
+		}
+		return total
+	}
+
+	for _, tc := range []struct {
+		name    string
+		width   int
+		headers []string
+		hidden  []string
+	}{
+		{"Unlimited", 0, headers, nil},
+		{"Fits", 120, headers, nil},
+		{"DropsURLFirst", 80, []string{"Handle", "Display Name", "Followers"}, []string{"Profile URL"}},
+		{"ShortensBeforeDropping", 50, []string{"Handle", "Display Name", "Followers"}, []string{"Profile URL"}},
+		{"KeepsHandleAndMetric", 20, []string{"Handle", "Followers"}, []string{"Display Name", "Profile URL"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fit := FitColumns(headers, rows, priorities, shorten, tc.width)
+			if !slices.Equal(fit.Headers, tc.headers) || !slices.Equal(fit.Hidden, tc.hidden) {
+				t.Fatalf("got headers %v hiding %v, want %v hiding %v", fit.Headers, fit.Hidden, tc.headers, tc.hidden)
+			}
+			if w := width(fit); tc.width > 0 && len(fit.Hidden) < 2 && w > tc.width {
+				t.Errorf("table is %d wide, want at most %d", w, tc.width)
+			}
+			if fit.Rows[0][0] != "@alice.bsky.social" {
+				t.Errorf("the handle should never be shortened, got %q", fit.Rows[0][0])
+			}
+		})
+	}
+
+	fit := FitColumns(headers, rows, priorities, shorten, 50)
+	if name := fit.Rows[0][fit.Column("Display Name")]; name != "Alice in Won…" || fit.Rows[1][1] != "bob" {
+		t.Errorf("unexpected display names %q and %q", name, fit.Rows[1][1])
+	}
+	if fit.Column("Profile URL") != -1 {
+		t.Error("Column should not find a dropped column")
+	}
+}
+
+func TestTruncateCell(t *testing.T) {
+	for _, tc := range []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"short", 8, "short"},
+		{"exactly8", 8, "exactly8"},
+		{"much too long", 8, "much to…"},
+		{"🇳🇿🇳🇿🇳🇿🇳🇿🇳🇿", 5, "🇳🇿🇳🇿…"},
+	} {
+		if got := truncateCell(tc.text, tc.width); got != tc.want {
+			t.Errorf("truncateCell(%q, %d) = %q, want %q", tc.text, tc.width, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ListFormat selects how follower and feed listings are laid out
+type ListFormat string
+
+const (
+	ListTable ListFormat = "table" // bordered columns
+	ListLines ListFormat = "lines" // a block of "Label: value" lines per record, for screen readers and narrow terminals
+)
+
+// listFormat is the layout chosen with --list-format
+var listFormat = ListTable
+
+// ParseListFormat validates a --list-format value; the empty string selects [ListTable]
+func ParseListFormat(s string) (ListFormat, error) {
+	switch format := ListFormat(s); format {
+	case "":
+		return ListTable, nil
+	case ListTable, ListLines:
+		return format, nil
+	default:
+		return ListTable, fmt.Errorf("invalid --list-format %q: expected table or lines", s)
+	}
+}
+
+// SetListFormat sets the layout listings use
+func SetListFormat(format ListFormat) {
+	listFormat = format
+}
+
+// ListAsLines reports whether listings should be laid out with [FormatLines] instead of a table
+func ListAsLines() bool {
+	return listFormat == ListLines
+}
+
+// FormatLines lays out rows as one block per record, each cell on its own line labeled with its header, and a
+// blank line between blocks. Empty cells are left out.
+func FormatLines(headers []string, rows [][]string) string {
+	var b strings.Builder
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		for col, value := range row {
+			if value == "" {
+				continue
+			}
+			if col < len(headers) && headers[col] != "" {
+				b.WriteString(headers[col])
+				b.WriteString(": ")
+			}
+			b.WriteString(value)
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
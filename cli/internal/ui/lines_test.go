@@ -0,0 +1,29 @@
+package ui
+
+import "testing"
+
+func TestFormatLines(t *testing.T) {
+	got := FormatLines([]string{"Handle", "Display Name", "Followers"}, [][]string{
+		{"@alice.bsky.social", "Alice", "120"},
+		{"@bob.bsky.social", "", "3"},
+	})
+	want := "Handle: @alice.bsky.social\nDisplay Name: Alice\nFollowers: 120\n\nHandle: @bob.bsky.social\nFollowers: 3"
+	if got != want {
+		t.Errorf("FormatLines =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := FormatLines([]string{"Handle"}, nil); got != "" {
+		t.Errorf("FormatLines of no rows = %q, want empty", got)
+	}
+}
+
+func TestParseListFormat(t *testing.T) {
+	for input, want := range map[string]ListFormat{"": ListTable, "table": ListTable, "lines": ListLines} {
+		if got, err := ParseListFormat(input); err != nil || got != want {
+			t.Errorf("ParseListFormat(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseListFormat("grid"); err == nil {
+		t.Error("ParseListFormat(grid) should fail")
+	}
+}
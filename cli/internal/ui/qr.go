@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"strings"
+
+	"rsc.io/qr"
+)
+
+// qrQuietZone is the light border around a QR code, in modules, that scanners need to find it
+const qrQuietZone = 2
+
+// QRCode renders text as a QR code for the terminal, two modules per character cell. Light modules are drawn as
+// blocks so the code scans on dark terminal backgrounds.
+func QRCode(text string) (string, error) {
+	code, err := qr.Encode(text, qr.M)
+	if err != nil {
+		return "", err
+	}
+
+	light := func(x, y int) bool {
+		return !code.Black(x, y)
+	}
+
+	var b strings.Builder
+	for y := -qrQuietZone; y < code.Size+qrQuietZone; y += 2 {
+		for x := -qrQuietZone; x < code.Size+qrQuietZone; x++ {
+			switch top, bottom := light(x, y), light(x, y+1) && y+1 < code.Size+qrQuietZone; {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
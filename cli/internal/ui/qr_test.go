@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestQRCode(t *testing.T) {
+	out, err := QRCode("192.168.1.20:41523/K7QX-M2PA-9TRB-XH4E")
+	if err != nil {
+		t.Fatalf("QRCode failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	width := utf8.RuneCountInString(lines[0])
+	// Two modules per line, so a square code is about twice as wide as it is tall
+	if len(lines) != (width+1)/2 {
+		t.Errorf("got %d lines of width %d", len(lines), width)
+	}
+	for i, line := range lines {
+		if utf8.RuneCountInString(line) != width {
+			t.Fatalf("line %d has width %d, want %d", i, utf8.RuneCountInString(line), width)
+		}
+	}
+	if strings.Trim(lines[0], "█") != "" {
+		t.Errorf("expected a light quiet zone on top, got %q", lines[0])
+	}
+}
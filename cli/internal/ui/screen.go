@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// Key is one key press read by [ReadKey]: a named key below, a control key such as "ctrl+s", or the typed
+// character
+type Key string
+
+const (
+	KeyUp       Key = "up"
+	KeyDown     Key = "down"
+	KeyLeft     Key = "left"
+	KeyRight    Key = "right"
+	KeyPageUp   Key = "pgup"
+	KeyPageDown Key = "pgdown"
+	KeyHome     Key = "home"
+	KeyEnd      Key = "end"
+	KeyEnter    Key = "enter"
+	KeyEscape   Key = "esc"
+	KeyBack     Key = "backspace"
+	KeyDelete   Key = "delete"
+	KeyTab      Key = "tab"
+	KeyCtrlC    Key = "ctrl+c"
+	KeyUnknown  Key = "unknown"
+)
+
+// escapeKeys maps CSI and SS3 sequences, without their modifiers, to keys
+var escapeKeys = map[string]Key{
+	"A": KeyUp, "B": KeyDown, "C": KeyRight, "D": KeyLeft, "H": KeyHome, "F": KeyEnd,
+	"5~": KeyPageUp, "6~": KeyPageDown, "1~": KeyHome, "4~": KeyEnd, "3~": KeyDelete,
+}
+
+// ReadKey reads one key press from a terminal in raw mode. An escape byte with nothing typed after it is the
+// Escape key; otherwise it starts a sequence for an arrow or paging key.
+func ReadKey(r *bufio.Reader) (Key, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return "", err
+	}
+
+	switch c {
+	case '\r', '\n':
+		return KeyEnter, nil
+	case 0x7f, 0x08:
+		return KeyBack, nil
+	case '\t':
+		return KeyTab, nil
+	case 0x1b:
+	default:
+		// Other control bytes are Ctrl with a letter: 0x01 is ctrl+a, 0x13 is ctrl+s
+		if c < 0x20 {
+			return Key("ctrl+" + string('a'+c-1)), nil
+		}
+		return Key(string(c)), nil
+	}
+
+	if r.Buffered() == 0 {
+		return KeyEscape, nil
+	}
+	intro, err := r.ReadByte()
+	if err != nil {
+		return KeyEscape, nil
+	}
+	if intro != '[' && intro != 'O' {
+		return KeyUnknown, nil
+	}
+
+	// Parameters are digits and semicolons; the sequence ends at the first other byte
+	var seq strings.Builder
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return KeyUnknown, nil
+		}
+		seq.WriteByte(b)
+		if (b < '0' || b > '9') && b != ';' {
+			break
+		}
+	}
+	// Keep the key's own number and final byte, dropping modifiers such as shift in "1;2A" or "5;2~"
+	code := seq.String()
+	final := code[len(code)-1:]
+	number, _, _ := strings.Cut(code[:len(code)-1], ";")
+	if final != "~" {
+		number = ""
+	}
+	if key, ok := escapeKeys[number+final]; ok {
+		return key, nil
+	}
+	return KeyUnknown, nil
+}
+
+// Screen is a full-screen terminal session: raw input read a key at a time, drawn on the alternate screen so the
+// shell's scrollback is left as it was
+type Screen struct {
+	in    *os.File
+	out   io.Writer
+	keys  *bufio.Reader
+	state *term.State
+}
+
+// OpenScreen switches the terminal behind in to raw mode and out to the alternate screen. It fails with
+// [ErrNotInteractive] when in is not a terminal. Close the screen to restore both.
+func OpenScreen(in *os.File, out io.Writer) (*Screen, error) {
+	if !term.IsTerminal(in.Fd()) {
+		return nil, ErrNotInteractive
+	}
+	state, err := term.MakeRaw(in.Fd())
+	if err != nil {
+		return nil, fmt.Errorf("failed to put terminal in raw mode: %w", err)
+	}
+
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	return &Screen{in: in, out: out, keys: bufio.NewReader(in), state: state}, nil
+}
+
+// Close leaves the alternate screen and restores the terminal mode
+func (s *Screen) Close() error {
+	fmt.Fprint(s.out, "\x1b[?25h\x1b[?1049l")
+	return term.Restore(s.in.Fd(), s.state)
+}
+
+// Size returns the terminal's width and height, or 80×24 when it can't be measured
+func (s *Screen) Size() (width, height int) {
+	width, height, err := term.GetSize(s.in.Fd())
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// ReadKey waits for the next key press
+func (s *Screen) ReadKey() (Key, error) {
+	return ReadKey(s.keys)
+}
+
+// Draw replaces the screen's contents with lines, one per terminal row
+func (s *Screen) Draw(lines []string) {
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	// Raw mode turns off output processing, so every line needs its own carriage return
+	b.WriteString(strings.Join(lines, "\r\n"))
+	fmt.Fprint(s.out, b.String())
+}
+
+// Prompt reads a line of text at the bottom of the screen, with the terminal back in line mode so typing can be
+// edited as usual. Callers treat an empty answer as cancelled.
+func (s *Screen) Prompt(prompt string) (string, error) {
+	_, height := s.Size()
+	if err := term.Restore(s.in.Fd(), s.state); err != nil {
+		return "", err
+	}
+	defer func() {
+		if state, err := term.MakeRaw(s.in.Fd()); err == nil {
+			s.state = state
+		}
+		fmt.Fprint(s.out, "\x1b[?25l")
+	}()
+
+	fmt.Fprintf(s.out, "\x1b[%d;1H\x1b[2K\x1b[?25h%s ", height, warning(prompt))
+	answer, err := s.keys.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(answer), nil
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReadKey(t *testing.T) {
+	input := "j\x1b[A\x1b[B\x1bOB\x1b[5~\x1b[6~\x1b[1;2A\x1b[6;5~\r\x7f\x03é\x1b[Z\x1b[C\x1b[D\x1b[3~\t\x13"
+	r := bufio.NewReader(strings.NewReader(input))
+
+	want := []Key{"j", KeyUp, KeyDown, KeyDown, KeyPageUp, KeyPageDown, KeyUp, KeyPageDown, KeyEnter, KeyBack, KeyCtrlC, "é", KeyUnknown, KeyRight, KeyLeft, KeyDelete, KeyTab, "ctrl+s"}
+	for i, expected := range want {
+		key, err := ReadKey(r)
+		if err != nil {
+			t.Fatalf("key %d: %v", i, err)
+		}
+		if key != expected {
+			t.Errorf("key %d = %q, want %q", i, key, expected)
+		}
+	}
+	if _, err := ReadKey(r); !errors.Is(err, io.EOF) {
+		t.Errorf("expected EOF after the last key, got %v", err)
+	}
+
+	// An escape with nothing after it is the Escape key itself
+	if key, err := ReadKey(bufio.NewReader(strings.NewReader("\x1b"))); err != nil || key != KeyEscape {
+		t.Errorf("lone escape = %q (%v), want %q", key, err, KeyEscape)
+	}
+}
@@ -0,0 +1,29 @@
+package ui
+
+import "strings"
+
+// sparkBlocks are the bar heights of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws counts as one block character each, scaled to the largest. Zero is the lowest block and any
+// other count at least the second, so a single post still shows; negative counts are unknown and left blank.
+func Sparkline(counts []int) string {
+	peak := 0
+	for _, n := range counts {
+		peak = max(peak, n)
+	}
+
+	var b strings.Builder
+	for _, n := range counts {
+		switch {
+		case n < 0:
+			b.WriteRune(' ')
+		case n == 0:
+			b.WriteRune(sparkBlocks[0])
+		default:
+			top := len(sparkBlocks) - 1
+			b.WriteRune(sparkBlocks[1+n*(top-1)/peak])
+		}
+	}
+	return b.String()
+}
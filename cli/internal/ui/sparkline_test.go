@@ -0,0 +1,20 @@
+package ui
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	for _, tc := range []struct {
+		counts []int
+		want   string
+	}{
+		{nil, ""},
+		{[]int{0, 0, 0}, "▁▁▁"},
+		{[]int{0, 1, 2, 3, 4, 5, 6}, "▁▃▄▅▆▇█"},
+		{[]int{1, 100}, "▂█"},
+		{[]int{-1, -1, 3, 0, 3}, "  █▁█"},
+	} {
+		if got := Sparkline(tc.counts); got != tc.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tc.counts, got, tc.want)
+		}
+	}
+}
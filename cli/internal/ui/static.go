@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/stormlightlabs/skypanel/cli/internal/store"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
 )
 
 // DisplayProfileHeader shows a formatted profile summary
-func DisplayProfileHeader(profile *store.ActorProfile) {
+func DisplayProfileHeader(profile *bsky.ActorProfile) {
 	Titleln("@%s", profile.Handle)
 	if profile.DisplayName != "" {
 		Subtitleln("%s", profile.DisplayName)
@@ -23,7 +23,7 @@ func DisplayProfileHeader(profile *store.ActorProfile) {
 }
 
 // DisplayFeed shows a formatted list of posts from a feed
-func DisplayFeed(feed []store.FeedViewPost, cursor string) {
+func DisplayFeed(feed []bsky.FeedViewPost, cursor string) {
 	if len(feed) == 0 {
 		Infoln("No posts found.")
 		return
@@ -50,11 +50,15 @@ func DisplayFeed(feed []store.FeedViewPost, cursor string) {
 
 		Infoln("  ❤️  %d | 🔁 %d | 💬 %d", post.LikeCount, post.RepostCount, post.ReplyCount)
 
+		if post.Viewer != nil && post.Viewer.ThreadMuted {
+			Infoln("  🔇 Thread muted")
+		}
+
 		if item.Reason != nil && item.Reason.By != nil {
 			Infoln("  ↻ Reposted by @%s", item.Reason.By.Handle)
 		}
 
-		Infoln("  Indexed: %s", post.IndexedAt)
+		Infoln("  Indexed: %s", FormatDateString(post.IndexedAt, DatesISO))
 		fmt.Println()
 	}
 
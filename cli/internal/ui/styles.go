@@ -2,6 +2,9 @@ package ui
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stormlightlabs/skypanel/cli/internal/utils"
@@ -31,6 +34,30 @@ var (
 	TableRowOddStyle  = newPStyle(0, 1).Foreground(lipgloss.Color("245"))
 )
 
+// quiet suppresses decorative output while stdout carries data for another program. It is atomic since batch jobs
+// set it while others print.
+var quiet atomic.Bool
+
+// SetQuiet suppresses titles, info, and success messages, and moves warnings and errors to stderr,
+// so stdout stays clean for piped output
+func SetQuiet(q bool) {
+	quiet.Store(q)
+}
+
+// StdoutIsTerminal reports whether stdout is attached to a terminal rather than a pipe or file
+func StdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// alertWriter is where warnings and errors go: stdout normally, stderr when quiet
+func alertWriter() io.Writer {
+	if quiet.Load() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
 func newStyle() lipgloss.Style {
 	return lipgloss.NewStyle()
 }
@@ -93,80 +120,110 @@ func errorBox(content string) string {
 
 // Success prints a formatted success message
 func Success(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Print(success(fmt.Sprintf(format, a...)))
 }
 
 // Successln prints a formatted success message with a newline
 func Successln(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Println(success(fmt.Sprintf(format, a...)))
 }
 
 // Error prints a formatted error message
 func Error(format string, a ...any) {
-	fmt.Print(errorMsg(fmt.Sprintf(format, a...)))
+	fmt.Fprint(alertWriter(), errorMsg(fmt.Sprintf(format, a...)))
 }
 
 // Errorln prints a formatted error message with a newline
 func Errorln(format string, a ...any) {
-	fmt.Println(errorMsg(fmt.Sprintf(format, a...)))
+	fmt.Fprintln(alertWriter(), errorMsg(fmt.Sprintf(format, a...)))
 }
 
 // Warning prints a formatted warning message
 func Warning(format string, a ...any) {
-	fmt.Print(warning(fmt.Sprintf(format, a...)))
+	fmt.Fprint(alertWriter(), warning(fmt.Sprintf(format, a...)))
 }
 
 // Warningln prints a formatted warning message with a newline
 func Warningln(format string, a ...any) {
-	fmt.Println(warning(fmt.Sprintf(format, a...)))
+	fmt.Fprintln(alertWriter(), warning(fmt.Sprintf(format, a...)))
 }
 
 // Info prints a formatted info message
 func Info(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Print(info(fmt.Sprintf(format, a...)))
 }
 
 // Infoln prints a formatted info message with a newline
 func Infoln(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Println(info(fmt.Sprintf(format, a...)))
 }
 
 // Title prints a formatted title
 func Title(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Print(title(fmt.Sprintf(format, a...)))
 }
 
 // Titleln prints a formatted title with a newline
 func Titleln(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Println(title(fmt.Sprintf(format, a...)))
 }
 
 // Subtitle prints a formatted subtitle
 func Subtitle(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Print(subtitle(fmt.Sprintf(format, a...)))
 }
 
 // Subtitleln prints a formatted subtitle with a newline
 func Subtitleln(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Println(subtitle(fmt.Sprintf(format, a...)))
 }
 
 // Box prints content in a styled box
 func Box(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Print(box(fmt.Sprintf(format, a...)))
 }
 
 // Boxln prints content in a styled box with a newline
 func Boxln(format string, a ...any) {
+	if quiet.Load() {
+		return
+	}
 	fmt.Println(box(fmt.Sprintf(format, a...)))
 }
 
 // ErrorBox prints error content in a styled error box
 func ErrorBox(format string, a ...any) {
-	fmt.Print(errorBox(fmt.Sprintf(format, a...)))
+	fmt.Fprint(alertWriter(), errorBox(fmt.Sprintf(format, a...)))
 }
 
 // ErrorBoxln prints error content in a styled error box with a newline
 func ErrorBoxln(format string, a ...any) {
-	fmt.Println(errorBox(fmt.Sprintf(format, a...)))
+	fmt.Fprintln(alertWriter(), errorBox(fmt.Sprintf(format, a...)))
 }
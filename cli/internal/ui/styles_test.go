@@ -283,6 +283,22 @@ func TestErrorBoxln(t *testing.T) {
 	}
 }
 
+func TestSetQuiet(t *testing.T) {
+	SetQuiet(true)
+	defer SetQuiet(false)
+
+	output := utils.CaptureOutput(func() {
+		Titleln("title")
+		Successln("done")
+		Infoln("info")
+		Warningln("careful")
+		Errorln("failed")
+	})
+	if output != "" {
+		t.Errorf("expected nothing on stdout while quiet, got: %q", output)
+	}
+}
+
 func TestPrintingFunctions(t *testing.T) {
 	t.Run("no args", func(t *testing.T) {
 		tests := []struct {
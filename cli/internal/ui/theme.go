@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+// Activity buckets an account by how recently it last posted
+type Activity int
+
+const (
+	ActivityUnknown  Activity = iota // the last post wasn't fetched
+	ActivityActive                   // posted in the last 7 days
+	ActivityRecent                   // posted in the last 30 days
+	ActivityInactive                 // posted longer ago, or never
+)
+
+const (
+	activeWithin = 7 * 24 * time.Hour
+	recentWithin = 30 * 24 * time.Hour
+)
+
+// ActivityOf buckets a last post date as of now; a zero date means the account never posted
+func ActivityOf(lastPost, now time.Time) Activity {
+	switch age := now.Sub(lastPost); {
+	case lastPost.IsZero():
+		return ActivityInactive
+	case age <= activeWithin:
+		return ActivityActive
+	case age <= recentWithin:
+		return ActivityRecent
+	default:
+		return ActivityInactive
+	}
+}
+
+// Theme colors the activity and verification indicators in account tables
+type Theme struct {
+	Plain    bool // no indicators at all
+	Active   lipgloss.Color
+	Recent   lipgloss.Color
+	Inactive lipgloss.Color // drawn faint as well
+}
+
+// DefaultTheme returns green, yellow, and red activity colors
+func DefaultTheme() Theme {
+	return Theme{
+		Active:   lipgloss.Color("#9bce8a"),
+		Recent:   lipgloss.Color(utils.ColorAccent),
+		Inactive: lipgloss.Color(utils.ColorError),
+	}
+}
+
+// theme is the table theme from the config file
+var theme = DefaultTheme()
+
+// SetTheme sets the colors account tables use
+func SetTheme(t Theme) {
+	theme = t
+}
+
+// hexColor matches the #rgb and #rrggbb forms lipgloss accepts
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ParseColor validates a theme color, a hex code such as #9bce8a or an ANSI color number from 0 to 255
+func ParseColor(s string) (lipgloss.Color, error) {
+	if hexColor.MatchString(s) {
+		return lipgloss.Color(s), nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(s), nil
+	}
+	return "", fmt.Errorf("invalid color %q: expected a hex code such as #9bce8a or an ANSI color number", s)
+}
+
+// Indicators reports whether account tables should color activity and embolden verified accounts: only on a
+// terminal, and not with a plain theme
+func Indicators() bool {
+	return !theme.Plain && StdoutIsTerminal()
+}
+
+// ActivityStyle colors style for activity, leaving it unchanged for [ActivityUnknown]
+func ActivityStyle(style lipgloss.Style, activity Activity) lipgloss.Style {
+	switch activity {
+	case ActivityActive:
+		return style.Foreground(theme.Active)
+	case ActivityRecent:
+		return style.Foreground(theme.Recent)
+	case ActivityInactive:
+		return style.Foreground(theme.Inactive).Faint(true)
+	default:
+		return style
+	}
+}
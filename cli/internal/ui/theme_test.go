@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivityOf(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		lastPost time.Time
+		want     Activity
+	}{
+		{now.Add(-time.Hour), ActivityActive},
+		{now.AddDate(0, 0, -7), ActivityActive},
+		{now.AddDate(0, 0, -8), ActivityRecent},
+		{now.AddDate(0, 0, -30), ActivityRecent},
+		{now.AddDate(0, 0, -31), ActivityInactive},
+		{time.Time{}, ActivityInactive},
+	} {
+		if got := ActivityOf(tc.lastPost, now); got != tc.want {
+			t.Errorf("ActivityOf(%v) = %d, want %d", tc.lastPost, got, tc.want)
+		}
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	for _, valid := range []string{"#9bce8a", "#fff", "2", "255"} {
+		if _, err := ParseColor(valid); err != nil {
+			t.Errorf("ParseColor(%q) failed: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "green", "#12345", "256", "-1"} {
+		if _, err := ParseColor(invalid); err == nil {
+			t.Errorf("ParseColor(%q) should fail", invalid)
+		}
+	}
+}
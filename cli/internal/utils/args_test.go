@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", input: "   ", want: nil},
+		{name: "words", input: "followers list  --limit 10", want: []string{"followers", "list", "--limit", "10"}},
+		{name: "double quotes", input: `search posts "hello world"`, want: []string{"search", "posts", "hello world"}},
+		{name: "single quotes keep backslashes", input: `search posts 'a\b'`, want: []string{"search", "posts", `a\b`}},
+		{name: "escaped space", input: `view profile a\ b`, want: []string{"view", "profile", "a b"}},
+		{name: "empty quoted arg", input: `search posts ""`, want: []string{"search", "posts", ""}},
+		{name: "unterminated quote", input: `search posts "oops`, wantErr: true},
+		{name: "trailing backslash", input: `search posts \`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitArgs(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package utils
 
 import (
+	"errors"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
@@ -9,6 +13,9 @@ import (
 
 var logger *log.Logger
 
+// logFile is the file opened by [ConfigureLogger], closed on reconfiguration
+var logFile *os.File
+
 const (
 	// Blue
 	ColorPrimary = "#31748f"
@@ -39,10 +46,88 @@ func InitLogger(level log.Level) *log.Logger {
 	styles.Levels[log.FatalLevel] = lipgloss.NewStyle().SetString("FATAL").Foreground(lipgloss.Color(ColorError)).Bold(true)
 
 	logger.SetStyles(styles)
+	log.SetDefault(logger)
 
 	return logger
 }
 
+// LogOptions selects the level, format, and destination of the global logger
+type LogOptions struct {
+	Level  string // debug, info, warn, or error; empty keeps the current level
+	Format string // text, json, or logfmt; empty means text
+	File   string // append to this file instead of stderr
+}
+
+// ConfigureLogger applies opts to the global logger in place so existing references pick up the change.
+// Logs written to a file or in a structured format carry full RFC 3339 timestamps for later parsing.
+func ConfigureLogger(opts LogOptions) error {
+	l := GetLogger()
+
+	if opts.Level != "" {
+		level, err := log.ParseLevel(opts.Level)
+		if err != nil {
+			return &LoggerError{Op: "ParseLevel", Err: err}
+		}
+		l.SetLevel(level)
+	}
+
+	switch strings.ToLower(opts.Format) {
+	case "", "text":
+		l.SetFormatter(log.TextFormatter)
+	case "json":
+		l.SetFormatter(log.JSONFormatter)
+	case "logfmt":
+		l.SetFormatter(log.LogfmtFormatter)
+	default:
+		return &LoggerError{Op: "ParseFormat", Err: errors.New("unknown log format: " + opts.Format)}
+	}
+
+	var out io.Writer = os.Stderr
+	var file *os.File
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return &LoggerError{Op: "OpenFile", Err: err}
+		}
+		file, out = f, f
+	}
+	CloseLogFile()
+	logFile = file
+	l.SetOutput(out)
+
+	if opts.File != "" || (opts.Format != "" && opts.Format != "text") {
+		l.SetTimeFormat(time.RFC3339)
+	} else {
+		l.SetTimeFormat("15:04:05")
+	}
+
+	return nil
+}
+
+// CloseLogFile closes the file opened by [ConfigureLogger], if any
+func CloseLogFile() error {
+	if logFile == nil {
+		return nil
+	}
+	err := logFile.Close()
+	logFile = nil
+	return err
+}
+
+// LoggerError represents an invalid logging configuration
+type LoggerError struct {
+	Op  string
+	Err error
+}
+
+func (e *LoggerError) Error() string {
+	return "logger." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *LoggerError) Unwrap() error {
+	return e.Err
+}
+
 // GetLogger returns the global logger instance
 func GetLogger() *log.Logger {
 	if logger == nil {
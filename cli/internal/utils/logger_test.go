@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -85,3 +88,66 @@ func TestLogger(t *testing.T) {
 		}
 	})
 }
+
+func TestConfigureLogger(t *testing.T) {
+	originalLogger := logger
+	defer func() {
+		CloseLogFile()
+		logger = originalLogger
+	}()
+
+	t.Run("JSONToFile", func(t *testing.T) {
+		InitLogger(log.InfoLevel)
+		path := filepath.Join(t.TempDir(), "skycli.log")
+
+		if err := ConfigureLogger(LogOptions{Level: "debug", Format: "json", File: path}); err != nil {
+			t.Fatalf("ConfigureLogger failed: %v", err)
+		}
+		GetLogger().Debug("batch fetched", "fetched", 3)
+		CloseLogFile()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read log file: %v", err)
+		}
+		var entry map[string]any
+		if err := json.Unmarshal(data, &entry); err != nil {
+			t.Fatalf("expected a JSON log line, got %q: %v", data, err)
+		}
+		if entry["msg"] != "batch fetched" || entry["level"] != "debug" || entry["fetched"] != float64(3) {
+			t.Errorf("unexpected log entry: %v", entry)
+		}
+		if _, ok := entry["time"]; !ok {
+			t.Error("expected a timestamp in the log entry")
+		}
+	})
+
+	t.Run("LevelFilters", func(t *testing.T) {
+		InitLogger(log.InfoLevel)
+		path := filepath.Join(t.TempDir(), "skycli.log")
+
+		if err := ConfigureLogger(LogOptions{Level: "warn", Format: "logfmt", File: path}); err != nil {
+			t.Fatalf("ConfigureLogger failed: %v", err)
+		}
+		GetLogger().Info("hidden")
+		GetLogger().Warn("shown")
+		CloseLogFile()
+
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(data), "hidden") || !strings.Contains(string(data), "msg=shown") {
+			t.Errorf("expected only warn-level logfmt output, got %q", data)
+		}
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		if err := ConfigureLogger(LogOptions{Level: "loud"}); err == nil {
+			t.Error("expected error for unknown level")
+		}
+		if err := ConfigureLogger(LogOptions{Format: "xml"}); err == nil {
+			t.Error("expected error for unknown format")
+		}
+		if err := ConfigureLogger(LogOptions{File: filepath.Join(t.TempDir(), "missing", "skycli.log")}); err == nil {
+			t.Error("expected error for unwritable log file")
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// IsWSL reports whether the process runs under the Windows Subsystem for Linux, where Windows programs such as
+// clip.exe can be called alongside Linux ones
+var IsWSL = sync.OnceValue(func() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	release, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	return err == nil && strings.Contains(strings.ToLower(string(release)), "microsoft")
+})
@@ -0,0 +1,52 @@
+package utils
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{input: "4096", want: 4096},
+		{input: "512KB", want: 512 << 10},
+		{input: "100 mb", want: 100 << 20},
+		{input: "1.5GiB", want: 3 << 29},
+		{input: "0", want: 0},
+		{input: "", wantErr: true},
+		{input: "MB", wantErr: true},
+		{input: "10TB", wantErr: true},
+		{input: "1.2.3MB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSize(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %d", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSize failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSize(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	for bytes, want := range map[int64]string{
+		512:         "512 B",
+		1536:        "1.5 KB",
+		300 << 20:   "300.0 MB",
+		5 << 30 / 2: "2.5 GB",
+	} {
+		if got := FormatSize(bytes); got != want {
+			t.Errorf("FormatSize(%d) = %q, want %q", bytes, got, want)
+		}
+	}
+}
@@ -51,8 +51,10 @@ func NewTestDB(t *testing.T) (*sql.DB, func()) {
 func SetupTestConfig(t *testing.T) (string, func()) {
 	tmpDir := t.TempDir()
 	originalHome := os.Getenv("HOME")
+	originalDir, dirSet := os.LookupEnv("SKYCLI_CONFIG_DIR")
 
 	os.Setenv("HOME", tmpDir)
+	os.Unsetenv("SKYCLI_CONFIG_DIR")
 
 	configDir := filepath.Join(tmpDir, ".skycli")
 	if err := os.MkdirAll(configDir, 0755); err != nil {
@@ -61,6 +63,9 @@ func SetupTestConfig(t *testing.T) (string, func()) {
 
 	cleanup := func() {
 		os.Setenv("HOME", originalHome)
+		if dirSet {
+			os.Setenv("SKYCLI_CONFIG_DIR", originalDir)
+		}
 	}
 
 	return configDir, cleanup
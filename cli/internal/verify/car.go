@@ -0,0 +1,108 @@
+package verify
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// CAR is a parsed CAR v1 archive: its root CIDs and its blocks keyed by CID string
+type CAR struct {
+	Roots  []CID
+	Blocks map[string][]byte
+}
+
+// ParseCAR reads a CAR v1 archive, such as the proof returned by com.atproto.sync.getRecord. Blocks are not checked
+// against their CIDs here; see [CAR.Check].
+func ParseCAR(data []byte) (*CAR, error) {
+	header, rest, err := carSection(data)
+	if err != nil {
+		return nil, fmt.Errorf("car header: %w", err)
+	}
+
+	decoded, err := DecodeCBOR(header)
+	if err != nil {
+		return nil, fmt.Errorf("car header: %w", err)
+	}
+	fields, ok := decoded.(map[string]any)
+	if !ok {
+		return nil, errors.New("car header: not a map")
+	}
+	if version, _ := fields["version"].(int64); version != 1 {
+		return nil, fmt.Errorf("car header: unsupported version %v", fields["version"])
+	}
+
+	car := &CAR{Blocks: make(map[string][]byte)}
+	roots, _ := fields["roots"].([]any)
+	for _, root := range roots {
+		cid, ok := root.(CID)
+		if !ok {
+			return nil, errors.New("car header: root is not a link")
+		}
+		car.Roots = append(car.Roots, cid)
+	}
+	if len(car.Roots) == 0 {
+		return nil, errors.New("car header: no roots")
+	}
+
+	for len(rest) > 0 {
+		var section []byte
+		if section, rest, err = carSection(rest); err != nil {
+			return nil, fmt.Errorf("car block: %w", err)
+		}
+		cid, n, err := DecodeCID(section)
+		if err != nil {
+			return nil, fmt.Errorf("car block: %w", err)
+		}
+		car.Blocks[cid.String()] = section[n:]
+	}
+	return car, nil
+}
+
+// carSection splits a varint length-prefixed section off the front of data
+func carSection(data []byte) ([]byte, []byte, error) {
+	size, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, nil, errors.New("invalid section length")
+	}
+	if size > uint64(len(data)-n) {
+		return nil, nil, errors.New("truncated section")
+	}
+	end := n + int(size)
+	return data[n:end], data[end:], nil
+}
+
+// Check reports the first block whose content doesn't hash to its CID
+func (c *CAR) Check() error {
+	for key, data := range c.Blocks {
+		cid, err := ParseCID(key)
+		if err != nil {
+			return err
+		}
+		ok, err := cid.Matches(data)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("block %s does not match its CID", key)
+		}
+	}
+	return nil
+}
+
+// Node decodes the DAG-CBOR block with the given CID
+func (c *CAR) Node(cid CID) (map[string]any, error) {
+	data, ok := c.Blocks[cid.String()]
+	if !ok {
+		return nil, fmt.Errorf("block %s missing from proof", cid)
+	}
+	decoded, err := DecodeCBOR(data)
+	if err != nil {
+		return nil, fmt.Errorf("block %s: %w", cid, err)
+	}
+	fields, ok := decoded.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("block %s is not a map", cid)
+	}
+	return fields, nil
+}
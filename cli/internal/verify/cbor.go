@@ -0,0 +1,274 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// cidTag is the CBOR tag DAG-CBOR uses for links
+const cidTag = 42
+
+// maxDepth bounds nesting while decoding, so a hostile block can't exhaust the stack
+const maxDepth = 64
+
+// DecodeCBOR decodes one DAG-CBOR value. Maps decode to map[string]any, arrays to []any, integers to int64,
+// byte strings to []byte, and links to [CID].
+func DecodeCBOR(data []byte) (any, error) {
+	d := &cborDecoder{data: data}
+	value, err := d.value(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("cbor: %d trailing bytes", len(d.data)-d.pos)
+	}
+	return value, nil
+}
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+// head reads a major type and its argument
+func (d *cborDecoder) head() (byte, byte, uint64, error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, 0, errors.New("cbor: unexpected end of data")
+	}
+	b := d.data[d.pos]
+	d.pos++
+	major, info := b>>5, b&0x1f
+
+	var size int
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, 0, fmt.Errorf("cbor: unsupported additional info %d", info)
+	}
+	if len(d.data)-d.pos < size {
+		return 0, 0, 0, errors.New("cbor: unexpected end of data")
+	}
+
+	var arg uint64
+	for _, c := range d.data[d.pos : d.pos+size] {
+		arg = arg<<8 | uint64(c)
+	}
+	d.pos += size
+	return major, info, arg, nil
+}
+
+// take returns the next n bytes
+func (d *cborDecoder) take(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, errors.New("cbor: unexpected end of data")
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+func (d *cborDecoder) value(depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, errors.New("cbor: nested too deeply")
+	}
+
+	major, info, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, errors.New("cbor: integer out of range")
+		}
+		return int64(arg), nil
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, errors.New("cbor: integer out of range")
+		}
+		return -1 - int64(arg), nil
+	case 2:
+		b, err := d.take(arg)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.Clone(b), nil
+	case 3:
+		b, err := d.take(arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case 4:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errors.New("cbor: array longer than data")
+		}
+		items := make([]any, arg)
+		for i := range items {
+			if items[i], err = d.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case 5:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errors.New("cbor: map longer than data")
+		}
+		fields := make(map[string]any, arg)
+		for range arg {
+			key, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			name, ok := key.(string)
+			if !ok {
+				return nil, errors.New("cbor: map key is not a string")
+			}
+			if fields[name], err = d.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return fields, nil
+	case 6:
+		if arg != cidTag {
+			return nil, fmt.Errorf("cbor: unsupported tag %d", arg)
+		}
+		inner, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := inner.([]byte)
+		if !ok || len(b) == 0 || b[0] != 0 {
+			return nil, errors.New("cbor: malformed link")
+		}
+		cid, n, err := DecodeCID(b[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n != len(b)-1 {
+			return nil, errors.New("cbor: trailing bytes in link")
+		}
+		return cid, nil
+	default:
+		switch {
+		case info == 20:
+			return false, nil
+		case info == 21:
+			return true, nil
+		case info == 22:
+			return nil, nil
+		case info == 27:
+			return math.Float64frombits(arg), nil
+		}
+		return nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+	}
+}
+
+// EncodeCBOR encodes a value as canonical DAG-CBOR: map keys sorted by length then bytes, integers in their
+// shortest form, and [CID] values as links. It accepts what [DecodeCBOR] and [FromJSON] return.
+func EncodeCBOR(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeValue(&buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeHead(buf *bytes.Buffer, major byte, arg uint64) {
+	switch {
+	case arg < 24:
+		buf.WriteByte(major<<5 | byte(arg))
+	case arg <= math.MaxUint8:
+		buf.WriteByte(major<<5 | 24)
+		buf.WriteByte(byte(arg))
+	case arg <= math.MaxUint16:
+		buf.WriteByte(major<<5 | 25)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(arg)))
+	case arg <= math.MaxUint32:
+		buf.WriteByte(major<<5 | 26)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(arg)))
+	default:
+		buf.WriteByte(major<<5 | 27)
+		buf.Write(binary.BigEndian.AppendUint64(nil, arg))
+	}
+}
+
+func encodeValue(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(0xf6)
+	case bool:
+		if v {
+			buf.WriteByte(0xf5)
+		} else {
+			buf.WriteByte(0xf4)
+		}
+	case int:
+		return encodeValue(buf, int64(v))
+	case int64:
+		if v >= 0 {
+			writeHead(buf, 0, uint64(v))
+		} else {
+			writeHead(buf, 1, uint64(-1-v))
+		}
+	case float64:
+		buf.WriteByte(0xfb)
+		buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(v)))
+	case []byte:
+		writeHead(buf, 2, uint64(len(v)))
+		buf.Write(v)
+	case string:
+		writeHead(buf, 3, uint64(len(v)))
+		buf.WriteString(v)
+	case CID:
+		link := append([]byte{0}, v.Bytes()...)
+		writeHead(buf, 6, cidTag)
+		writeHead(buf, 2, uint64(len(link)))
+		buf.Write(link)
+	case []any:
+		writeHead(buf, 4, uint64(len(v)))
+		for _, item := range v {
+			if err := encodeValue(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.SortFunc(keys, func(a, b string) int {
+			if len(a) != len(b) {
+				return len(a) - len(b)
+			}
+			return strings.Compare(a, b)
+		})
+
+		writeHead(buf, 5, uint64(len(v)))
+		for _, key := range keys {
+			writeHead(buf, 3, uint64(len(key)))
+			buf.WriteString(key)
+			if err := encodeValue(buf, v[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cbor: cannot encode %T", value)
+	}
+	return nil
+}
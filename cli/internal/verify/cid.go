@@ -0,0 +1,122 @@
+package verify
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Multicodec codes used by atproto repositories
+const (
+	CodecDagCBOR = 0x71
+	CodecRaw     = 0x55
+	hashSHA256   = 0x12
+)
+
+// base32Lower is the multibase "b" alphabet CIDv1 strings use
+var base32Lower = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// CID is a content identifier: a codec and a multihash of the content
+type CID struct {
+	Version uint64
+	Codec   uint64
+	Hash    []byte // multihash: hash code, digest length, digest
+}
+
+// SumCID returns the CIDv1 of data under codec, hashed with SHA-256 as atproto requires
+func SumCID(codec uint64, data []byte) CID {
+	digest := sha256.Sum256(data)
+	hash := append([]byte{hashSHA256, sha256.Size}, digest[:]...)
+	return CID{Version: 1, Codec: codec, Hash: hash}
+}
+
+// Defined reports whether the CID is set
+func (c CID) Defined() bool {
+	return len(c.Hash) > 0
+}
+
+// Equal reports whether two CIDs name the same content under the same codec
+func (c CID) Equal(other CID) bool {
+	return c.Version == other.Version && c.Codec == other.Codec && bytes.Equal(c.Hash, other.Hash)
+}
+
+// Bytes returns the binary form of the CID
+func (c CID) Bytes() []byte {
+	if c.Version == 0 {
+		return bytes.Clone(c.Hash)
+	}
+	b := binary.AppendUvarint(nil, c.Version)
+	b = binary.AppendUvarint(b, c.Codec)
+	return append(b, c.Hash...)
+}
+
+// String returns the base32 form of a CIDv1, as used in AT URIs and API responses
+func (c CID) String() string {
+	if !c.Defined() {
+		return ""
+	}
+	return "b" + base32Lower.EncodeToString(c.Bytes())
+}
+
+// Matches checks data against the CID by hashing it again. Only SHA-256 multihashes can be checked.
+func (c CID) Matches(data []byte) (bool, error) {
+	if len(c.Hash) < 2 || c.Hash[0] != hashSHA256 {
+		return false, fmt.Errorf("unsupported multihash in CID %s", c)
+	}
+	return c.Equal(SumCID(c.Codec, data)), nil
+}
+
+// ParseCID parses the base32 string form of a CIDv1
+func ParseCID(s string) (CID, error) {
+	if len(s) < 2 || s[0] != 'b' {
+		return CID{}, fmt.Errorf("unsupported CID encoding %q", s)
+	}
+	b, err := base32Lower.DecodeString(s[1:])
+	if err != nil {
+		return CID{}, fmt.Errorf("invalid CID %q: %w", s, err)
+	}
+	cid, n, err := DecodeCID(b)
+	if err != nil {
+		return CID{}, fmt.Errorf("invalid CID %q: %w", s, err)
+	}
+	if n != len(b) {
+		return CID{}, fmt.Errorf("invalid CID %q: trailing bytes", s)
+	}
+	return cid, nil
+}
+
+// DecodeCID reads a binary CID from the start of b and returns it with the number of bytes it used
+func DecodeCID(b []byte) (CID, int, error) {
+	// CIDv0 is a bare SHA-256 multihash
+	if len(b) >= 2 && b[0] == hashSHA256 && b[1] == sha256.Size {
+		if len(b) < 2+sha256.Size {
+			return CID{}, 0, errors.New("truncated CID")
+		}
+		return CID{Version: 0, Codec: 0x70, Hash: bytes.Clone(b[:2+sha256.Size])}, 2 + sha256.Size, nil
+	}
+
+	pos := 0
+	var fields [4]uint64
+	for i := range fields {
+		v, n := binary.Uvarint(b[pos:])
+		if n <= 0 {
+			return CID{}, 0, errors.New("truncated CID")
+		}
+		fields[i] = v
+		pos += n
+	}
+	version, codec, digestLen := fields[0], fields[1], fields[3]
+	if version != 1 {
+		return CID{}, 0, fmt.Errorf("unsupported CID version %d", version)
+	}
+	if digestLen > uint64(len(b)-pos) {
+		return CID{}, 0, errors.New("truncated CID")
+	}
+
+	hashStart := len(binary.AppendUvarint(nil, version)) + len(binary.AppendUvarint(nil, codec))
+	end := pos + int(digestLen)
+	return CID{Version: version, Codec: codec, Hash: bytes.Clone(b[hashStart:end])}, end, nil
+}
@@ -0,0 +1,105 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// FromJSON converts a record from its JSON form to the data model it was signed in: {"$link": ...} objects become
+// [CID] links, {"$bytes": ...} objects become byte strings, and numbers become integers, since the atproto data
+// model has no floats.
+func FromJSON(raw json.RawMessage) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return fromJSONValue(value)
+}
+
+func fromJSONValue(value any) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("number %s is not an integer", v)
+		}
+		return n, nil
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			converted, err := fromJSONValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = converted
+		}
+		return items, nil
+	case map[string]any:
+		if len(v) == 1 {
+			if link, ok := v["$link"].(string); ok {
+				return ParseCID(link)
+			}
+			if encoded, ok := v["$bytes"].(string); ok {
+				b, err := base64.RawStdEncoding.DecodeString(encoded)
+				if err != nil {
+					return nil, fmt.Errorf("invalid $bytes value: %w", err)
+				}
+				return b, nil
+			}
+		}
+		fields := make(map[string]any, len(v))
+		for key, item := range v {
+			converted, err := fromJSONValue(item)
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = converted
+		}
+		return fields, nil
+	default:
+		return v, nil
+	}
+}
+
+// ToJSON converts a value decoded by [DecodeCBOR] to its JSON form, the reverse of [FromJSON]: links become
+// {"$link": ...} objects and byte strings become {"$bytes": ...} objects
+func ToJSON(value any) any {
+	switch v := value.(type) {
+	case CID:
+		return map[string]any{"$link": v.String()}
+	case []byte:
+		return map[string]any{"$bytes": base64.RawStdEncoding.EncodeToString(v)}
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = ToJSON(item)
+		}
+		return items
+	case map[string]any:
+		fields := make(map[string]any, len(v))
+		for key, item := range v {
+			fields[key] = ToJSON(item)
+		}
+		return fields
+	default:
+		return v
+	}
+}
+
+// RecordCID recomputes the CID of a record from its JSON form
+func RecordCID(raw json.RawMessage) (CID, error) {
+	value, err := FromJSON(raw)
+	if err != nil {
+		return CID{}, err
+	}
+	data, err := EncodeCBOR(value)
+	if err != nil {
+		return CID{}, err
+	}
+	return SumCID(CodecDagCBOR, data), nil
+}
@@ -0,0 +1,118 @@
+package verify
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secpecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// Multicodec codes for the two key types atproto signs with
+const (
+	codecSecp256k1 = 0xe7
+	codecP256      = 0x1200
+)
+
+// base58Alphabet is the bitcoin alphabet multibase "z" uses
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// PublicKey is a repository signing key
+type PublicKey struct {
+	Curve string // "secp256k1" or "P-256"
+	key   []byte // compressed point
+}
+
+// ParseMultikey parses a multibase public key from a DID document's verification method, or a did:key
+func ParseMultikey(s string) (PublicKey, error) {
+	encoded := strings.TrimPrefix(s, "did:key:")
+	if !strings.HasPrefix(encoded, "z") {
+		return PublicKey{}, fmt.Errorf("unsupported key encoding %q", s)
+	}
+	b, err := decodeBase58(encoded[1:])
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("invalid key %q: %w", s, err)
+	}
+
+	codec, n := binary.Uvarint(b)
+	if n <= 0 {
+		return PublicKey{}, fmt.Errorf("invalid key %q", s)
+	}
+	key := b[n:]
+	switch codec {
+	case codecSecp256k1:
+		if _, err := secp256k1.ParsePubKey(key); err != nil {
+			return PublicKey{}, fmt.Errorf("invalid secp256k1 key: %w", err)
+		}
+		return PublicKey{Curve: "secp256k1", key: key}, nil
+	case codecP256:
+		if x, _ := elliptic.UnmarshalCompressed(elliptic.P256(), key); x == nil {
+			return PublicKey{}, errors.New("invalid P-256 key")
+		}
+		return PublicKey{Curve: "P-256", key: key}, nil
+	default:
+		return PublicKey{}, fmt.Errorf("unsupported key type 0x%x", codec)
+	}
+}
+
+// Verify checks a 64-byte compact signature over a SHA-256 digest. Like atproto, it rejects high-S signatures,
+// which are malleable copies of valid ones.
+func (k PublicKey) Verify(digest, sig []byte) bool {
+	if len(sig) != 64 {
+		return false
+	}
+
+	switch k.Curve {
+	case "secp256k1":
+		pub, err := secp256k1.ParsePubKey(k.key)
+		if err != nil {
+			return false
+		}
+		var r, s secp256k1.ModNScalar
+		if overflow := r.SetByteSlice(sig[:32]); overflow {
+			return false
+		}
+		if overflow := s.SetByteSlice(sig[32:]); overflow {
+			return false
+		}
+		if s.IsOverHalfOrder() {
+			return false
+		}
+		return secpecdsa.NewSignature(&r, &s).Verify(digest, pub)
+	case "P-256":
+		curve := elliptic.P256()
+		x, y := elliptic.UnmarshalCompressed(curve, k.key)
+		if x == nil {
+			return false
+		}
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if s.Cmp(new(big.Int).Rsh(curve.Params().N, 1)) > 0 {
+			return false
+		}
+		return ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, digest, r, s)
+	}
+	return false
+}
+
+// decodeBase58 decodes base58btc text
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range s {
+		i := strings.IndexRune(base58Alphabet, c)
+		if i < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(i)))
+	}
+
+	// Leading '1's stand for leading zero bytes
+	zeros := len(s) - len(strings.TrimLeft(s, "1"))
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
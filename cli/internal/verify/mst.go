@@ -0,0 +1,115 @@
+package verify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// ErrNotInTree means a key is absent from a repository's Merkle Search Tree
+var ErrNotInTree = errors.New("key not in tree")
+
+// LookupMST walks the Merkle Search Tree rooted at root for key ("collection/rkey") and returns the CID it maps
+// to. Every node on the path must be in car, as it is in a com.atproto.sync.getRecord proof.
+func LookupMST(car *CAR, root CID, key string) (CID, error) {
+	target := []byte(key)
+	node := root
+	for depth := 0; ; depth++ {
+		if depth > maxDepth {
+			return CID{}, errors.New("tree too deep")
+		}
+
+		fields, err := car.Node(node)
+		if err != nil {
+			return CID{}, err
+		}
+		entries, _ := fields["e"].([]any)
+
+		// Entries hold keys in order, each compressed against the one before it. A subtree sits left of the first
+		// entry ("l") and right of every entry ("t"); the target lives in the subtree just before the first larger key.
+		next, _ := fields["l"].(CID)
+		var prev []byte
+		for _, item := range entries {
+			entry, ok := item.(map[string]any)
+			if !ok {
+				return CID{}, fmt.Errorf("node %s: malformed entry", node)
+			}
+			prefix, _ := entry["p"].(int64)
+			suffix, _ := entry["k"].([]byte)
+			if prefix < 0 || int(prefix) > len(prev) {
+				return CID{}, fmt.Errorf("node %s: bad key prefix", node)
+			}
+			entryKey := append(bytes.Clone(prev[:prefix]), suffix...)
+
+			cmp := bytes.Compare(target, entryKey)
+			if cmp == 0 {
+				value, ok := entry["v"].(CID)
+				if !ok {
+					return CID{}, fmt.Errorf("node %s: entry has no value", node)
+				}
+				return value, nil
+			}
+			if cmp < 0 {
+				break
+			}
+			next, _ = entry["t"].(CID)
+			prev = entryKey
+		}
+
+		if !next.Defined() {
+			return CID{}, ErrNotInTree
+		}
+		node = next
+	}
+}
+
+// WalkMST calls fn for every key in the Merkle Search Tree rooted at root, in key order, stopping at the first
+// error. Unlike [LookupMST] it needs the whole tree in car, as in a full repository export.
+func WalkMST(car *CAR, root CID, fn func(key string, value CID) error) error {
+	return walkNode(car, root, 0, fn)
+}
+
+func walkNode(car *CAR, node CID, depth int, fn func(string, CID) error) error {
+	if depth > maxDepth {
+		return errors.New("tree too deep")
+	}
+
+	fields, err := car.Node(node)
+	if err != nil {
+		return err
+	}
+	if left, ok := fields["l"].(CID); ok {
+		if err := walkNode(car, left, depth+1, fn); err != nil {
+			return err
+		}
+	}
+
+	entries, _ := fields["e"].([]any)
+	var prev []byte
+	for _, item := range entries {
+		entry, ok := item.(map[string]any)
+		if !ok {
+			return fmt.Errorf("node %s: malformed entry", node)
+		}
+		prefix, _ := entry["p"].(int64)
+		suffix, _ := entry["k"].([]byte)
+		if prefix < 0 || int(prefix) > len(prev) {
+			return fmt.Errorf("node %s: bad key prefix", node)
+		}
+		prev = append(bytes.Clone(prev[:prefix]), suffix...)
+
+		value, ok := entry["v"].(CID)
+		if !ok {
+			return fmt.Errorf("node %s: entry has no value", node)
+		}
+		if err := fn(string(prev), value); err != nil {
+			return err
+		}
+		if right, ok := entry["t"].(CID); ok {
+			if err := walkNode(car, right, depth+1, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
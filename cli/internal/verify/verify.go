@@ -0,0 +1,339 @@
+// Package verify checks records against their repository: it fetches a signed proof from the record's PDS,
+// recomputes content hashes, and verifies the commit signature with the signing key in the account's DID document.
+package verify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+// DefaultPLCDirectory resolves did:plc identifiers
+const DefaultPLCDirectory = "https://plc.directory"
+
+// maxProofSize caps how much of a proof or DID document is read
+const maxProofSize = 8 << 20
+
+// Status sums up a verification
+type Status string
+
+// Statuses, from best to worst
+const (
+	StatusVerified     Status = "verified"     // every check passed
+	StatusUnverifiable Status = "unverifiable" // the proof or signing key couldn't be fetched or isn't supported
+	StatusInconsistent Status = "inconsistent" // the signed repository disagrees with the record as served
+	StatusTampered     Status = "tampered"     // a hash or signature doesn't match its content
+)
+
+// severity orders statuses so the worst failure wins
+var severity = map[Status]int{StatusVerified: 0, StatusUnverifiable: 1, StatusInconsistent: 2, StatusTampered: 3}
+
+// Check is one step of a verification
+type Check struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Result reports how a record held up against its repository
+type Result struct {
+	URI       string  `json:"uri"`
+	DID       string  `json:"did,omitempty"`
+	PDS       string  `json:"pds,omitempty"`
+	Commit    string  `json:"commit,omitempty"`
+	Rev       string  `json:"rev,omitempty"`
+	RecordCID string  `json:"recordCid,omitempty"` // the CID the signed repository holds for the record
+	Status    Status  `json:"status"`
+	Checks    []Check `json:"checks"`
+}
+
+// add records a check, lowering the status to failure when it didn't pass
+func (r *Result) add(name string, ok bool, failure Status, detail string) {
+	r.Checks = append(r.Checks, Check{Name: name, OK: ok, Detail: detail})
+	if !ok && severity[failure] > severity[r.Status] {
+		r.Status = failure
+	}
+}
+
+// VerifyError describes a failed lookup during verification
+type VerifyError struct {
+	Op  string
+	Err error
+}
+
+func (e *VerifyError) Error() string {
+	return "verify." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *VerifyError) Unwrap() error {
+	return e.Err
+}
+
+// Verifier fetches proofs and DID documents over HTTP
+type Verifier struct {
+	client       *http.Client
+	plcDirectory string
+}
+
+// NewVerifier creates a verifier that resolves did:plc identifiers through plcDirectory, or
+// [DefaultPLCDirectory] when it's empty
+func NewVerifier(plcDirectory string) *Verifier {
+	if plcDirectory == "" {
+		plcDirectory = DefaultPLCDirectory
+	}
+	return &Verifier{
+		client:       &http.Client{Transport: bsky.SharedTransport(), Timeout: 30 * time.Second},
+		plcDirectory: strings.TrimSuffix(plcDirectory, "/"),
+	}
+}
+
+// Verify checks a record, as served by the API, against the signed repository of the account at authority (a
+// DID or handle). served carries the CID and JSON value the caller was given; either may be empty to skip the
+// checks that need it.
+func (v *Verifier) Verify(ctx context.Context, authority, collection, rkey string, served bsky.Record) *Result {
+	result := &Result{URI: "at://" + authority + "/" + collection + "/" + rkey, Status: StatusVerified}
+
+	did := authority
+	if !strings.HasPrefix(did, "did:") {
+		resolved, err := v.ResolveHandle(ctx, authority)
+		if err != nil {
+			result.add("identity", false, StatusUnverifiable, err.Error())
+			return result
+		}
+		did = resolved
+	}
+	result.DID = did
+	result.URI = "at://" + did + "/" + collection + "/" + rkey
+
+	doc, err := v.ResolveDID(ctx, did)
+	if err != nil {
+		result.add("identity", false, StatusUnverifiable, err.Error())
+		return result
+	}
+	pds, key, err := repoEndpoints(doc)
+	if err != nil {
+		result.add("identity", false, StatusUnverifiable, err.Error())
+		return result
+	}
+	result.PDS = pds
+	result.add("identity", true, "", fmt.Sprintf("%s key, PDS %s", key.Curve, pds))
+
+	car, err := v.fetchProof(ctx, pds, did, collection, rkey)
+	if err != nil {
+		result.add("proof", false, StatusUnverifiable, err.Error())
+		return result
+	}
+
+	if err := car.Check(); err != nil {
+		result.add("blocks", false, StatusTampered, err.Error())
+		return result
+	}
+	result.add("blocks", true, "", fmt.Sprintf("%d blocks match their CIDs", len(car.Blocks)))
+
+	commit, err := car.Node(car.Roots[0])
+	if err != nil {
+		result.add("signature", false, StatusTampered, err.Error())
+		return result
+	}
+	result.Commit = car.Roots[0].String()
+	result.Rev, _ = commit["rev"].(string)
+
+	if commitDID, _ := commit["did"].(string); commitDID != did {
+		result.add("signature", false, StatusInconsistent, fmt.Sprintf("commit is for %q", commitDID))
+		return result
+	}
+	if err := checkSignature(commit, key); err != nil {
+		result.add("signature", false, StatusTampered, err.Error())
+		return result
+	}
+	result.add("signature", true, "", "commit "+result.Rev+" signed by the account's key")
+
+	root, _ := commit["data"].(CID)
+	recordCID, err := LookupMST(car, root, collection+"/"+rkey)
+	switch {
+	case errors.Is(err, ErrNotInTree):
+		result.add("record", false, StatusInconsistent, "record is not in the current repository; it may have been deleted")
+		return result
+	case err != nil:
+		result.add("record", false, StatusTampered, err.Error())
+		return result
+	}
+	result.RecordCID = recordCID.String()
+	result.add("record", true, "", "repository holds "+result.RecordCID)
+
+	if served.Cid != "" {
+		ok := served.Cid == result.RecordCID
+		detail := "served CID matches the repository"
+		if !ok {
+			detail = fmt.Sprintf("served CID %s, repository holds %s", served.Cid, result.RecordCID)
+		}
+		result.add("served cid", ok, StatusInconsistent, detail)
+	}
+
+	if len(served.Value) > 0 {
+		computed, err := RecordCID(served.Value)
+		switch {
+		case err != nil:
+			result.add("content", false, StatusUnverifiable, "can't re-encode served record: "+err.Error())
+		case computed.String() != result.RecordCID:
+			result.add("content", false, StatusTampered, fmt.Sprintf("served record hashes to %s, repository holds %s", computed, result.RecordCID))
+		default:
+			result.add("content", true, "", "served record hashes to the repository's CID")
+		}
+	}
+	return result
+}
+
+// checkSignature verifies a commit's signature over its unsigned DAG-CBOR encoding
+func checkSignature(commit map[string]any, key PublicKey) error {
+	sig, ok := commit["sig"].([]byte)
+	if !ok {
+		return errors.New("commit is unsigned")
+	}
+
+	unsigned := make(map[string]any, len(commit))
+	for k, value := range commit {
+		if k != "sig" {
+			unsigned[k] = value
+		}
+	}
+	data, err := EncodeCBOR(unsigned)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(data)
+	if !key.Verify(digest[:], sig) {
+		return errors.New("signature does not match the account's signing key")
+	}
+	return nil
+}
+
+// PDSEndpoint picks the PDS endpoint out of a DID document
+func PDSEndpoint(doc *bsky.DidDoc) (string, error) {
+	for _, service := range doc.Service {
+		if strings.HasSuffix(service.ID, "#atproto_pds") {
+			return strings.TrimSuffix(service.ServiceEndpoint, "/"), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no PDS in its DID document", doc.ID)
+}
+
+// repoEndpoints picks the PDS endpoint and repository signing key out of a DID document
+func repoEndpoints(doc *bsky.DidDoc) (string, PublicKey, error) {
+	pds, err := PDSEndpoint(doc)
+	if err != nil {
+		return "", PublicKey{}, err
+	}
+
+	for _, method := range doc.VerificationMethod {
+		if strings.HasSuffix(method.ID, "#atproto") {
+			key, err := ParseMultikey(method.PublicKeyMultibase)
+			return pds, key, err
+		}
+	}
+	return "", PublicKey{}, fmt.Errorf("%s has no signing key in its DID document", doc.ID)
+}
+
+// ResolveDID fetches the DID document for a did:plc or did:web identifier
+func (v *Verifier) ResolveDID(ctx context.Context, did string) (*bsky.DidDoc, error) {
+	var docURL string
+	switch {
+	case strings.HasPrefix(did, "did:plc:"):
+		docURL = v.plcDirectory + "/" + did
+	case strings.HasPrefix(did, "did:web:"):
+		// atproto only uses host-level did:web, so a ':' path separator is refused; a port is written as %3A
+		encoded := strings.TrimPrefix(did, "did:web:")
+		host, err := url.PathUnescape(encoded)
+		if err != nil || host == "" || strings.Contains(encoded, ":") {
+			return nil, &VerifyError{Op: "ResolveDID", Err: fmt.Errorf("unsupported did:web %q", did)}
+		}
+		docURL = "https://" + host + "/.well-known/did.json"
+	default:
+		return nil, &VerifyError{Op: "ResolveDID", Err: fmt.Errorf("unsupported DID method in %q", did)}
+	}
+
+	body, err := v.get(ctx, docURL)
+	if err != nil {
+		return nil, &VerifyError{Op: "ResolveDID", Err: err}
+	}
+
+	var doc bsky.DidDoc
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, &VerifyError{Op: "ResolveDID", Err: fmt.Errorf("invalid DID document: %w", err)}
+	}
+	if doc.ID != did {
+		return nil, &VerifyError{Op: "ResolveDID", Err: fmt.Errorf("DID document is for %q, not %q", doc.ID, did)}
+	}
+	return &doc, nil
+}
+
+// ResolveHandle finds the DID a handle claims, from its _atproto DNS record or its well-known HTTPS endpoint
+func (v *Verifier) ResolveHandle(ctx context.Context, handle string) (string, error) {
+	records, err := net.DefaultResolver.LookupTXT(ctx, "_atproto."+handle)
+	if err == nil {
+		for _, record := range records {
+			if did, ok := strings.CutPrefix(record, "did="); ok {
+				return did, nil
+			}
+		}
+	}
+
+	body, err := v.get(ctx, "https://"+handle+"/.well-known/atproto-did")
+	if err != nil {
+		return "", &VerifyError{Op: "ResolveHandle", Err: fmt.Errorf("can't resolve %s: %w", handle, err)}
+	}
+	did := strings.TrimSpace(string(body))
+	if !strings.HasPrefix(did, "did:") {
+		return "", &VerifyError{Op: "ResolveHandle", Err: fmt.Errorf("%s does not resolve to a DID", handle)}
+	}
+	return did, nil
+}
+
+// fetchProof downloads the CAR proof for a record with com.atproto.sync.getRecord
+func (v *Verifier) fetchProof(ctx context.Context, pds, did, collection, rkey string) (*CAR, error) {
+	query := url.Values{"did": {did}, "collection": {collection}, "rkey": {rkey}}
+	body, err := v.get(ctx, pds+"/xrpc/com.atproto.sync.getRecord?"+query.Encode())
+	if err != nil {
+		return nil, &VerifyError{Op: "fetchProof", Err: err}
+	}
+
+	car, err := ParseCAR(body)
+	if err != nil {
+		return nil, &VerifyError{Op: "fetchProof", Err: err}
+	}
+	return car, nil
+}
+
+// get fetches a URL and returns its body, treating non-2xx responses as errors
+func (v *Verifier) get(ctx context.Context, target string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProofSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("GET %s: %s: %s", target, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,310 @@
+package verify
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secpecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+const testDID = "did:plc:testaccount"
+
+func encodeBase58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	var out []byte
+	for n.Sign() > 0 {
+		mod := new(big.Int)
+		n.DivMod(n, radix, mod)
+		out = append([]byte{base58Alphabet[mod.Int64()]}, out...)
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append([]byte{'1'}, out...)
+	}
+	return string(out)
+}
+
+func multikey(codec uint64, key []byte) string {
+	return "z" + encodeBase58(append(binary.AppendUvarint(nil, codec), key...))
+}
+
+func mustEncode(t *testing.T, value any) []byte {
+	t.Helper()
+	data, err := EncodeCBOR(value)
+	if err != nil {
+		t.Fatalf("EncodeCBOR failed: %v", err)
+	}
+	return data
+}
+
+func storeRecord(cid string, value json.RawMessage) bsky.Record {
+	return bsky.Record{Cid: cid, Value: value}
+}
+
+func carSectionBytes(data []byte) []byte {
+	return append(binary.AppendUvarint(nil, uint64(len(data))), data...)
+}
+
+// testRepo is a signed single-node repository holding two records, served by a fake PDS and PLC directory
+type testRepo struct {
+	t       *testing.T
+	key     *secp256k1.PrivateKey
+	records map[string]json.RawMessage // by "collection/rkey"
+	tamper  func(blocks map[string][]byte)
+}
+
+func (r *testRepo) car() []byte {
+	r.t.Helper()
+	blocks := make(map[string][]byte)
+	cids := make(map[string]CID)
+	for key, raw := range r.records {
+		value, err := FromJSON(raw)
+		if err != nil {
+			r.t.Fatalf("FromJSON failed: %v", err)
+		}
+		data := mustEncode(r.t, value)
+		cid := SumCID(CodecDagCBOR, data)
+		blocks[cid.String()] = data
+		cids[key] = cid
+	}
+
+	// Keys sorted and prefix-compressed, as a real node would hold them
+	first, second := "app.bsky.feed.like/3kaaa", "app.bsky.feed.post/3kbbb"
+	node := map[string]any{
+		"l": nil,
+		"e": []any{
+			map[string]any{"p": int64(0), "k": []byte(first), "v": cids[first], "t": nil},
+			map[string]any{"p": int64(14), "k": []byte(second[14:]), "v": cids[second], "t": nil},
+		},
+	}
+	nodeData := mustEncode(r.t, node)
+	nodeCID := SumCID(CodecDagCBOR, nodeData)
+	blocks[nodeCID.String()] = nodeData
+
+	commit := map[string]any{"did": testDID, "version": int64(3), "data": nodeCID, "rev": "3kcccrev", "prev": nil}
+	digest := sha256.Sum256(mustEncode(r.t, commit))
+	sig := secpecdsa.Sign(r.key, digest[:])
+	rs, ss := sig.R(), sig.S()
+	rb, sb := rs.Bytes(), ss.Bytes()
+	commit["sig"] = append(rb[:], sb[:]...)
+	commitData := mustEncode(r.t, commit)
+	commitCID := SumCID(CodecDagCBOR, commitData)
+	blocks[commitCID.String()] = commitData
+
+	if r.tamper != nil {
+		r.tamper(blocks)
+	}
+
+	out := carSectionBytes(mustEncode(r.t, map[string]any{"version": int64(1), "roots": []any{commitCID}}))
+	for key, data := range blocks {
+		cid, _ := ParseCID(key)
+		out = append(out, carSectionBytes(append(cid.Bytes(), data...))...)
+	}
+	return out
+}
+
+func (r *testRepo) serve(signingKey string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/" + testDID:
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":                 testDID,
+				"verificationMethod": []any{map[string]any{"id": testDID + "#atproto", "publicKeyMultibase": signingKey}},
+				"service":            []any{map[string]any{"id": "#atproto_pds", "serviceEndpoint": server.URL}},
+			})
+		case "/xrpc/com.atproto.sync.getRecord":
+			if req.URL.Query().Get("did") != testDID {
+				http.NotFound(w, req)
+				return
+			}
+			w.Write(r.car())
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	return server
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey failed: %v", err)
+	}
+	return &testRepo{t: t, key: key, records: map[string]json.RawMessage{
+		"app.bsky.feed.like/3kaaa": json.RawMessage(`{"$type":"app.bsky.feed.like","createdAt":"2024-01-01T00:00:00Z","subject":{"cid":"bafyreigbtj4x7ip5legnfznufuopl4sg4knzc2cof6duas4b3q2fy6swua","uri":"at://did:plc:other/app.bsky.feed.post/1"}}`),
+		"app.bsky.feed.post/3kbbb": json.RawMessage(`{"$type":"app.bsky.feed.post","text":"hello","createdAt":"2024-01-02T00:00:00Z","facets":[{"index":{"byteStart":0,"byteEnd":5}}]}`),
+	}}
+}
+
+func TestSumCID_EmptyMap(t *testing.T) {
+	cid := SumCID(CodecDagCBOR, mustEncode(t, map[string]any{}))
+	if got, want := cid.String(), "bafyreigbtj4x7ip5legnfznufuopl4sg4knzc2cof6duas4b3q2fy6swua"; got != want {
+		t.Errorf("CID = %s, want %s", got, want)
+	}
+
+	parsed, err := ParseCID(cid.String())
+	if err != nil || !parsed.Equal(cid) {
+		t.Errorf("ParseCID round trip = %v, %v", parsed, err)
+	}
+}
+
+func TestCBOR_RoundTrip(t *testing.T) {
+	value, err := FromJSON(json.RawMessage(`{"zz":1,"a":[-300,true,null,"x"],"blob":{"$bytes":"aGk"},"ref":{"$link":"bafyreigbtj4x7ip5legnfznufuopl4sg4knzc2cof6duas4b3q2fy6swua"}}`))
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	data := mustEncode(t, value)
+
+	// Keys sort by length first
+	if !strings.HasPrefix(string(data[1:]), "\x61a") {
+		t.Errorf("expected key 'a' first, got % x", data[:4])
+	}
+
+	decoded, err := DecodeCBOR(data)
+	if err != nil {
+		t.Fatalf("DecodeCBOR failed: %v", err)
+	}
+	if again := mustEncode(t, decoded); string(again) != string(data) {
+		t.Errorf("re-encoding changed the bytes")
+	}
+	fields := decoded.(map[string]any)
+	if string(fields["blob"].([]byte)) != "hi" {
+		t.Errorf("blob = %v", fields["blob"])
+	}
+	if _, ok := fields["ref"].(CID); !ok {
+		t.Errorf("ref decoded as %T, want CID", fields["ref"])
+	}
+
+	if _, err := FromJSON(json.RawMessage(`{"n":1.5}`)); err == nil {
+		t.Error("expected floats to be refused")
+	}
+}
+
+func TestPublicKey_P256(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	key, err := ParseMultikey("did:key:" + multikey(codecP256, elliptic.MarshalCompressed(elliptic.P256(), priv.X, priv.Y)))
+	if err != nil {
+		t.Fatalf("ParseMultikey failed: %v", err)
+	}
+	if key.Curve != "P-256" {
+		t.Errorf("Curve = %q", key.Curve)
+	}
+
+	digest := sha256.Sum256([]byte("commit"))
+	r, s, err := ecdsa.Sign(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	n := elliptic.P256().Params().N
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+	}
+	sig := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	if !key.Verify(digest[:], sig) {
+		t.Error("expected low-S signature to verify")
+	}
+
+	high := append(r.FillBytes(make([]byte, 32)), new(big.Int).Sub(n, s).FillBytes(make([]byte, 32))...)
+	if key.Verify(digest[:], high) {
+		t.Error("expected high-S signature to be refused")
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	server := repo.serve(multikey(codecSecp256k1, repo.key.PubKey().SerializeCompressed()))
+	defer server.Close()
+	verifier := NewVerifier(server.URL)
+
+	post := repo.records["app.bsky.feed.post/3kbbb"]
+	postCID, err := RecordCID(post)
+	if err != nil {
+		t.Fatalf("RecordCID failed: %v", err)
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		for _, key := range []string{"app.bsky.feed.like/3kaaa", "app.bsky.feed.post/3kbbb"} {
+			collection, rkey, _ := strings.Cut(key, "/")
+			cid, _ := RecordCID(repo.records[key])
+			result := verifier.Verify(ctx, testDID, collection, rkey, storeRecord(cid.String(), repo.records[key]))
+			if result.Status != StatusVerified {
+				t.Errorf("%s: status %s, checks %+v", key, result.Status, result.Checks)
+			}
+			if result.RecordCID != cid.String() || result.Rev != "3kcccrev" {
+				t.Errorf("%s: unexpected result %+v", key, result)
+			}
+		}
+	})
+
+	t.Run("edited content", func(t *testing.T) {
+		edited := json.RawMessage(strings.Replace(string(post), "hello", "goodbye", 1))
+		result := verifier.Verify(ctx, testDID, "app.bsky.feed.post", "3kbbb", storeRecord(postCID.String(), edited))
+		if result.Status != StatusTampered {
+			t.Errorf("status %s, checks %+v", result.Status, result.Checks)
+		}
+	})
+
+	t.Run("stale cid", func(t *testing.T) {
+		result := verifier.Verify(ctx, testDID, "app.bsky.feed.post", "3kbbb", storeRecord("bafyreigbtj4x7ip5legnfznufuopl4sg4knzc2cof6duas4b3q2fy6swua", nil))
+		if result.Status != StatusInconsistent {
+			t.Errorf("status %s, checks %+v", result.Status, result.Checks)
+		}
+	})
+
+	t.Run("missing record", func(t *testing.T) {
+		result := verifier.Verify(ctx, testDID, "app.bsky.feed.post", "3kzzz", storeRecord("", nil))
+		if result.Status != StatusInconsistent {
+			t.Errorf("status %s, checks %+v", result.Status, result.Checks)
+		}
+	})
+
+	t.Run("altered block", func(t *testing.T) {
+		repo.tamper = func(blocks map[string][]byte) {
+			blocks[postCID.String()] = mustEncode(t, map[string]any{"text": "forged"})
+		}
+		defer func() { repo.tamper = nil }()
+
+		result := verifier.Verify(ctx, testDID, "app.bsky.feed.post", "3kbbb", storeRecord("", nil))
+		if result.Status != StatusTampered {
+			t.Errorf("status %s, checks %+v", result.Status, result.Checks)
+		}
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		other, _ := secp256k1.GeneratePrivateKey()
+		server := repo.serve(multikey(codecSecp256k1, other.PubKey().SerializeCompressed()))
+		defer server.Close()
+
+		result := NewVerifier(server.URL).Verify(ctx, testDID, "app.bsky.feed.post", "3kbbb", storeRecord("", nil))
+		if result.Status != StatusTampered {
+			t.Errorf("status %s, checks %+v", result.Status, result.Checks)
+		}
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		result := verifier.Verify(ctx, "did:plc:nobody", "app.bsky.feed.post", "3kbbb", storeRecord("", nil))
+		if result.Status != StatusUnverifiable {
+			t.Errorf("status %s, checks %+v", result.Status, result.Checks)
+		}
+	})
+}
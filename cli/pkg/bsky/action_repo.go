@@ -0,0 +1,172 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+)
+
+// Action types recorded in the undo log
+const (
+	ActionFollow  = "follow"
+	ActionBlock   = "block"
+	ActionListAdd = "list-add"
+	ActionLike    = "like"
+	ActionRepost  = "repost"
+	ActionReply   = "reply"
+	ActionPost    = "post"
+)
+
+// ActionModel is an account change made by skycli, kept so it can be reverted by deleting RecordURI
+type ActionModel struct {
+	id         string
+	createdAt  time.Time
+	Action     string // [ActionFollow], [ActionBlock], or [ActionListAdd]
+	SubjectDid string
+	RecordURI  string // at:// URI of the record created on the user's repo
+	UndoneAt   time.Time
+}
+
+func (m *ActionModel) ID() string               { return m.id }
+func (m *ActionModel) CreatedAt() time.Time     { return m.createdAt }
+func (m *ActionModel) UpdatedAt() time.Time     { return m.createdAt } // Actions are append-only
+func (m *ActionModel) SetID(id string)          { m.id = id }
+func (m *ActionModel) SetCreatedAt(t time.Time) { m.createdAt = t }
+func (m *ActionModel) SetUpdatedAt(t time.Time) {}
+
+// Undone reports whether the action has already been reverted
+func (m *ActionModel) Undone() bool {
+	return !m.UndoneAt.IsZero()
+}
+
+// ActionRepository stores the undo log of account actions in the local SQLite cache database
+type ActionRepository struct {
+	db *sql.DB
+}
+
+// NewActionRepository creates a new action repository with SQLite backend
+func NewActionRepository() (*ActionRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ActionRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *ActionRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *ActionRepository) Close() error {
+	return r.db.Close()
+}
+
+// Record appends an action to the log, assigning an ID and timestamp when unset
+func (r *ActionRepository) Record(ctx context.Context, action *ActionModel) (err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "ActionRepository.Record")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if action.ID() == "" {
+		action.SetID(GenerateUUID())
+		action.SetCreatedAt(time.Now())
+	}
+
+	query := `
+		INSERT INTO action_log (id, created_at, action, subject_did, record_uri, undone_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	var undoneAt any
+	if action.Undone() {
+		undoneAt = action.UndoneAt
+	}
+
+	if _, err = r.db.ExecContext(ctx, query,
+		action.ID(), action.CreatedAt(), action.Action, action.SubjectDid, action.RecordURI, undoneAt,
+	); err != nil {
+		return &RepositoryError{Op: "Record", Entity: "action", Err: err}
+	}
+	return nil
+}
+
+// Recent returns up to limit actions, newest first. Reverted actions are skipped unless includeUndone is set.
+// A limit of 0 returns every matching action.
+func (r *ActionRepository) Recent(ctx context.Context, limit int, includeUndone bool) (_ []*ActionModel, err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "ActionRepository.Recent")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	query := `SELECT id, created_at, action, subject_did, record_uri, undone_at FROM action_log`
+	if !includeUndone {
+		query += " WHERE undone_at IS NULL"
+	}
+	query += " ORDER BY created_at DESC"
+
+	var args []any
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Recent", Entity: "action", Err: err}
+	}
+	defer rows.Close()
+
+	var actions []*ActionModel
+	for rows.Next() {
+		var action ActionModel
+		var id string
+		var createdAt time.Time
+		var undoneAt sql.NullTime
+
+		if err := rows.Scan(&id, &createdAt, &action.Action, &action.SubjectDid, &action.RecordURI, &undoneAt); err != nil {
+			return nil, &RepositoryError{Op: "Recent", Entity: "action", Err: err}
+		}
+
+		action.SetID(id)
+		action.SetCreatedAt(createdAt)
+		if undoneAt.Valid {
+			action.UndoneAt = undoneAt.Time
+		}
+		actions = append(actions, &action)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &RepositoryError{Op: "Recent", Entity: "action", Err: err}
+	}
+	return actions, nil
+}
+
+// MarkUndone records that the action with id was reverted at the given time
+func (r *ActionRepository) MarkUndone(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE action_log SET undone_at = ? WHERE id = ?", at, id)
+	if err != nil {
+		return &RepositoryError{Op: "MarkUndone", Entity: "action", ID: id, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return &RepositoryError{Op: "MarkUndone", Entity: "action", ID: id, Err: err}
+	}
+	if rows == 0 {
+		return &RepositoryError{Op: "MarkUndone", Entity: "action", ID: id, Err: fmt.Errorf("action %w", ErrNotFound)}
+	}
+	return nil
+}
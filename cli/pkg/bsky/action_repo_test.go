@@ -0,0 +1,62 @@
+package bsky
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestActionRepository_RecordAndRecent(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &ActionRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	base := time.Now().Add(-time.Hour)
+	for i, did := range []string{"did:plc:old", "did:plc:mid", "did:plc:new"} {
+		action := &ActionModel{Action: ActionFollow, SubjectDid: did, RecordURI: "at://did:plc:me/app.bsky.graph.follow/" + did}
+		action.SetID(GenerateUUID())
+		action.SetCreatedAt(base.Add(time.Duration(i) * time.Minute))
+		if err := repo.Record(ctx, action); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	recent, err := repo.Recent(ctx, 2, false)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(recent) != 2 || recent[0].SubjectDid != "did:plc:new" || recent[1].SubjectDid != "did:plc:mid" {
+		t.Fatalf("expected newest two actions, got %+v", recent)
+	}
+
+	if err := repo.MarkUndone(ctx, recent[0].ID(), time.Now()); err != nil {
+		t.Fatalf("MarkUndone failed: %v", err)
+	}
+
+	pending, err := repo.Recent(ctx, 0, false)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(pending) != 2 || pending[0].SubjectDid != "did:plc:mid" {
+		t.Errorf("expected the undone action to be skipped, got %+v", pending)
+	}
+
+	all, err := repo.Recent(ctx, 0, true)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(all) != 3 || !all[0].Undone() {
+		t.Errorf("expected all actions with the newest undone, got %+v", all)
+	}
+
+	if err := repo.MarkUndone(ctx, "missing", time.Now()); err == nil {
+		t.Error("expected error marking a missing action")
+	}
+}
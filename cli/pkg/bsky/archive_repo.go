@@ -0,0 +1,127 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// ArchiveRepository manages whole-database copies of the local archive (feeds, posts, and snapshots)
+// so it can be backed up to and restored from remote storage.
+type ArchiveRepository struct {
+	db *sql.DB
+}
+
+// MergeResult reports how many rows were added by [ArchiveRepository.Merge]
+type MergeResult struct {
+	Feeds           int64
+	Posts           int64
+	Snapshots       int64
+	SnapshotEntries int64
+}
+
+// NewArchiveRepository creates a new archive repository with SQLite backend
+func NewArchiveRepository() (*ArchiveRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArchiveRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *ArchiveRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *ArchiveRepository) Close() error {
+	return r.db.Close()
+}
+
+// Dump writes a consistent, compacted copy of the database to dest using VACUUM INTO.
+// dest must not already exist.
+func (r *ArchiveRepository) Dump(ctx context.Context, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return &RepositoryError{Op: "Dump", Entity: "archive", Err: errors.New("destination already exists: " + dest)}
+	}
+
+	if _, err := r.db.ExecContext(ctx, "VACUUM INTO ?", dest); err != nil {
+		return &RepositoryError{Op: "Dump", Entity: "archive", Err: err}
+	}
+	return nil
+}
+
+// Merge imports feeds, posts, and snapshots from the archive database at src.
+//
+// Existing rows win: records already present locally (by primary key or post URI) are left untouched,
+// so pulling the same archive twice is a no-op. Feeds and posts trashed in the archive arrive trashed.
+func (r *ArchiveRepository) Merge(ctx context.Context, src string) (*MergeResult, error) {
+	if _, err := os.Stat(src); err != nil {
+		return nil, &RepositoryError{Op: "Merge", Entity: "archive", Err: err}
+	}
+
+	// ATTACH is connection-scoped, so every statement must run on the same connection
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Merge", Entity: "archive", Err: err}
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS incoming", src); err != nil {
+		return nil, &RepositoryError{Op: "Merge", Entity: "archive", Err: err}
+	}
+	defer conn.ExecContext(context.Background(), "DETACH DATABASE incoming")
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Merge", Entity: "archive", Err: err}
+	}
+	defer tx.Rollback()
+
+	result := &MergeResult{}
+	steps := []struct {
+		query string
+		count *int64
+	}{
+		{`INSERT OR IGNORE INTO feeds (id, created_at, updated_at, name, source, params, is_local, deleted_at)
+			SELECT id, created_at, updated_at, name, source, params, is_local, deleted_at FROM incoming.feeds`, &result.Feeds},
+		{`INSERT OR IGNORE INTO posts (id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at, deleted_at,
+				like_count, repost_count, reply_count, quote_count)
+			SELECT id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at, deleted_at,
+				like_count, repost_count, reply_count, quote_count FROM incoming.posts`, &result.Posts},
+		{`INSERT OR IGNORE INTO follower_snapshots (id, created_at, user_did, snapshot_type, total_count, expires_at)
+			SELECT id, created_at, user_did, snapshot_type, total_count, expires_at FROM incoming.follower_snapshots`, &result.Snapshots},
+		{`INSERT OR IGNORE INTO follower_snapshot_entries (snapshot_id, actor_did, indexed_at)
+			SELECT snapshot_id, actor_did, indexed_at FROM incoming.follower_snapshot_entries`, &result.SnapshotEntries},
+	}
+
+	for _, step := range steps {
+		res, err := tx.ExecContext(ctx, step.query)
+		if err != nil {
+			return nil, &RepositoryError{Op: "Merge", Entity: "archive", Err: err}
+		}
+		if *step.count, err = res.RowsAffected(); err != nil {
+			return nil, &RepositoryError{Op: "Merge", Entity: "archive", Err: err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, &RepositoryError{Op: "Merge", Entity: "archive", Err: err}
+	}
+
+	return result, nil
+}
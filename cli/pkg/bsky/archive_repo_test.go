@@ -0,0 +1,175 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newFileArchiveRepo opens an archive repository on a file database; ATTACH and VACUUM INTO
+// need a real file rather than a per-connection in-memory database.
+func newFileArchiveRepo(t *testing.T, name string) *ArchiveRepository {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), name))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	repo := &ArchiveRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	return repo
+}
+
+func seedArchive(t *testing.T, db *sql.DB, feedID, postURI, snapshotID string) {
+	t.Helper()
+	now := time.Now()
+
+	if _, err := db.Exec(`INSERT INTO feeds (id, created_at, updated_at, name, source, params, is_local) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		feedID, now, now, "feed-"+feedID, "at://did:plc:test/app.bsky.feed.generator/x", "{}", true); err != nil {
+		t.Fatalf("failed to insert feed: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO posts (id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		"post-"+feedID, now, now, postURI, "did:plc:author", "hello", feedID, now); err != nil {
+		t.Fatalf("failed to insert post: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO follower_snapshots (id, created_at, user_did, snapshot_type, total_count, expires_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		snapshotID, now, "did:plc:me", "followers", 1, now.Add(24*time.Hour)); err != nil {
+		t.Fatalf("failed to insert snapshot: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO follower_snapshot_entries (snapshot_id, actor_did, indexed_at) VALUES (?, ?, ?)`,
+		snapshotID, "did:plc:follower", now.Format(time.RFC3339)); err != nil {
+		t.Fatalf("failed to insert snapshot entry: %v", err)
+	}
+}
+
+func TestArchiveRepository_Dump(t *testing.T) {
+	repo := newFileArchiveRepo(t, "local.db")
+	seedArchive(t, repo.db, "feed-1", "at://did:plc:author/app.bsky.feed.post/1", "snap-1")
+
+	dest := filepath.Join(t.TempDir(), "dump.db")
+	if err := repo.Dump(context.Background(), dest); err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+
+	dumped, err := sql.Open("sqlite3", dest)
+	if err != nil {
+		t.Fatalf("failed to open dump: %v", err)
+	}
+	defer dumped.Close()
+
+	var count int
+	if err := dumped.QueryRow("SELECT COUNT(*) FROM posts").Scan(&count); err != nil {
+		t.Fatalf("failed to query dump: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 post in dump, got %d", count)
+	}
+
+	if err := repo.Dump(context.Background(), dest); err == nil {
+		t.Error("expected error when dump destination already exists")
+	}
+}
+
+func TestArchiveRepository_Merge(t *testing.T) {
+	ctx := context.Background()
+
+	remote := newFileArchiveRepo(t, "remote.db")
+	seedArchive(t, remote.db, "feed-1", "at://did:plc:author/app.bsky.feed.post/1", "snap-1")
+	seedArchive(t, remote.db, "feed-2", "at://did:plc:author/app.bsky.feed.post/2", "snap-2")
+	if _, err := remote.db.Exec("UPDATE posts SET like_count = 5, repost_count = 2, reply_count = 1, quote_count = 3 WHERE feed_id = ?",
+		"feed-2"); err != nil {
+		t.Fatalf("failed to set engagement counts: %v", err)
+	}
+
+	dump := filepath.Join(t.TempDir(), "remote-dump.db")
+	if err := remote.Dump(ctx, dump); err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+
+	local := newFileArchiveRepo(t, "local.db")
+	seedArchive(t, local.db, "feed-1", "at://did:plc:author/app.bsky.feed.post/1", "snap-1")
+
+	result, err := local.Merge(ctx, dump)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	if result.Feeds != 1 || result.Posts != 1 || result.Snapshots != 1 || result.SnapshotEntries != 1 {
+		t.Errorf("unexpected merge result: %+v", result)
+	}
+
+	var count int
+	if err := local.db.QueryRow("SELECT COUNT(*) FROM posts").Scan(&count); err != nil {
+		t.Fatalf("failed to count posts: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 posts after merge, got %d", count)
+	}
+
+	var likes, reposts, replies, quotes sql.NullInt64
+	if err := local.db.QueryRow("SELECT like_count, repost_count, reply_count, quote_count FROM posts WHERE uri = ?",
+		"at://did:plc:author/app.bsky.feed.post/2").Scan(&likes, &reposts, &replies, &quotes); err != nil {
+		t.Fatalf("failed to read merged post: %v", err)
+	}
+	if likes.Int64 != 5 || reposts.Int64 != 2 || replies.Int64 != 1 || quotes.Int64 != 3 {
+		t.Errorf("expected engagement counts 5/2/1/3 on merged post, got %v/%v/%v/%v", likes, reposts, replies, quotes)
+	}
+
+	again, err := local.Merge(ctx, dump)
+	if err != nil {
+		t.Fatalf("second Merge failed: %v", err)
+	}
+	if again.Feeds != 0 || again.Posts != 0 || again.Snapshots != 0 || again.SnapshotEntries != 0 {
+		t.Errorf("expected repeated merge to be a no-op, got %+v", again)
+	}
+}
+
+func TestArchiveRepository_MergeKeepsTrash(t *testing.T) {
+	ctx := context.Background()
+
+	remote := newFileArchiveRepo(t, "remote.db")
+	seedArchive(t, remote.db, "feed-1", "at://did:plc:author/app.bsky.feed.post/1", "snap-1")
+	deletedAt := time.Now().Add(-time.Hour)
+	if _, err := remote.db.Exec("UPDATE feeds SET deleted_at = ?", deletedAt); err != nil {
+		t.Fatalf("failed to trash feed: %v", err)
+	}
+	if _, err := remote.db.Exec("UPDATE posts SET deleted_at = ?", deletedAt); err != nil {
+		t.Fatalf("failed to trash post: %v", err)
+	}
+
+	dump := filepath.Join(t.TempDir(), "remote-dump.db")
+	if err := remote.Dump(ctx, dump); err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+
+	local := newFileArchiveRepo(t, "local.db")
+	if _, err := local.Merge(ctx, dump); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	for _, table := range []string{"feeds", "posts"} {
+		var live int
+		if err := local.db.QueryRow("SELECT COUNT(*) FROM " + table + " WHERE deleted_at IS NULL").Scan(&live); err != nil {
+			t.Fatalf("failed to count %s: %v", table, err)
+		}
+		if live != 0 {
+			t.Errorf("expected trashed %s to stay trashed, got %d live", table, live)
+		}
+	}
+}
+
+func TestArchiveRepository_MergeMissingSource(t *testing.T) {
+	repo := newFileArchiveRepo(t, "local.db")
+
+	if _, err := repo.Merge(context.Background(), filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Error("expected error for missing source archive")
+	}
+}
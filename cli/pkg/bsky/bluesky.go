@@ -0,0 +1,1959 @@
+package bsky
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/aturi"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+const defaultServiceURL = "https://bsky.social"
+
+// BlueskyModerationDID is the DID of Bluesky's own moderation service, the default destination for reports
+const BlueskyModerationDID = "did:plc:ar7c4by46qjdydhdevvrndac"
+
+// BlueskyService implements the [Service] interface for AT Protocol / Bluesky API
+type BlueskyService struct {
+	baseURL       string
+	client        *http.Client
+	timeouts      Timeouts
+	accessToken   string
+	refreshToken  string
+	tokenIssuedAt time.Time
+	tokenExpiry   time.Time
+	clockSkew     time.Duration
+	authenticated bool
+	did           string
+	handle        string
+	tokenStore    TokenStore
+	oauth         *oauthState // set for sessions signed in with OAuth rather than a password
+	quotas        QuotaRecorder
+	retry         RetryPolicy
+	exhausted     map[string]time.Time // rate-limit reset by host, while its budget is used up
+	concurrency   *ConcurrencyController
+	profiles      *memo[*ActorProfile]          // GetProfile by actor
+	feeds         *memo[*GetAuthorFeedResponse] // first GetAuthorFeed page by actor and limit
+	mu            sync.RWMutex                  // guards the session fields above
+	refreshMu     sync.Mutex                    // serializes token refreshes
+}
+
+// NewBlueskyService creates a new Bluesky service client
+func NewBlueskyService(serviceURL string) *BlueskyService {
+	if serviceURL == "" {
+		serviceURL = defaultServiceURL
+	}
+
+	return &BlueskyService{
+		baseURL:       serviceURL,
+		client:        &http.Client{Transport: SharedTransport()},
+		timeouts:      DefaultTimeouts(),
+		retry:         DefaultRetryPolicy(),
+		concurrency:   NewConcurrencyController(ConcurrencyOptions{}),
+		profiles:      newMemo[*ActorProfile](memoTTL),
+		feeds:         newMemo[*GetAuthorFeedResponse](memoTTL),
+		authenticated: false,
+	}
+}
+
+// SetTimeouts replaces the per-endpoint request timeouts
+func (s *BlueskyService) SetTimeouts(timeouts Timeouts) {
+	s.timeouts = timeouts
+}
+
+// SetTransport replaces the HTTP transport, e.g. to route requests through a different proxy
+func (s *BlueskyService) SetTransport(rt http.RoundTripper) {
+	s.client.Transport = rt
+}
+
+// Transport returns the HTTP transport used for API requests
+func (s *BlueskyService) Transport() http.RoundTripper {
+	return s.client.Transport
+}
+
+// Concurrency returns the controller shared by the Batch* helpers
+func (s *BlueskyService) Concurrency() *ConcurrencyController {
+	return s.concurrency
+}
+
+// Timeouts returns the per-endpoint request timeouts
+func (s *BlueskyService) Timeouts() Timeouts {
+	return s.timeouts
+}
+
+// Name returns the service identifier
+func (s *BlueskyService) Name() ServiceIdentifier {
+	return "Bluesky"
+}
+
+// BaseURL returns the root endpoint of the remote API
+func (s *BlueskyService) BaseURL() string {
+	return s.baseURL
+}
+
+// Authenticated reports whether the client is currently authorized
+func (s *BlueskyService) Authenticated() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.authenticated && s.accessToken != ""
+}
+
+// Authenticate establishes credentials with the service. Credentials are either a map with "identifier" and
+// "password" keys, for a handle and app password, or [OAuthCredentials] to sign in through the account's
+// authorization server in a browser.
+func (s *BlueskyService) Authenticate(ctx context.Context, credentials any) error {
+	if creds, ok := credentials.(OAuthCredentials); ok {
+		return s.authenticateOAuth(ctx, creds)
+	}
+
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Auth))
+	defer cancel()
+
+	creds, ok := credentials.(map[string]string)
+	if !ok {
+		return errors.New("credentials must be map[string]string with identifier and password")
+	}
+
+	identifier, ok := creds["identifier"]
+	if !ok {
+		return errors.New("identifier required in credentials")
+	}
+
+	password, ok := creds["password"]
+	if !ok {
+		return errors.New("password required in credentials")
+	}
+
+	body := map[string]string{
+		"identifier": identifier,
+		"password":   password,
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		s.baseURL+"/xrpc/com.atproto.server.createSession",
+		bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("authentication failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var session CreateSessionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.acceptIssuedToken(session.AccessJwt); err != nil {
+		return err
+	}
+
+	s.oauth = nil
+	s.accessToken = session.AccessJwt
+	s.refreshToken = session.RefreshJwt
+	s.did = session.Did
+	s.handle = session.Handle
+	s.authenticated = true
+
+	// Profiles carry viewer state that depends on the session
+	s.profiles.reset()
+	s.feeds.reset()
+
+	return nil
+}
+
+// Request performs a generic API request with automatic token refresh
+func (s *BlueskyService) Request(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	if !s.Authenticated() {
+		return nil, errors.New("service not authenticated")
+	}
+
+	accessToken := s.GetAccessToken()
+	if s.shouldRefreshToken() {
+		if err := s.refreshAccessToken(ctx, accessToken); err != nil {
+			return nil, fmt.Errorf("token refresh failed: %w", err)
+		}
+		accessToken = s.GetAccessToken()
+	}
+
+	// Buffer the body so it can be replayed if the first attempt is rejected with a 401
+	body, err := rewindable(body)
+	if err != nil {
+		return nil, err
+	}
+
+	url := s.baseURL + path
+	if !strings.HasPrefix(path, "/") {
+		url = s.baseURL + "/" + path
+	}
+
+	// Endpoint methods set their own deadline; direct callers get the default one
+	cancel := context.CancelFunc(func() {})
+	if _, ok := ctx.Deadline(); !ok {
+		ctx, cancel = withTimeout(ctx, s.timeouts.Default)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := s.authorize(req, accessToken); err != nil {
+		cancel()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if labelers, ok := ctx.Value(acceptLabelersKey{}).([]string); ok && len(labelers) > 0 {
+		req.Header.Set("atproto-accept-labelers", strings.Join(labelers, ","))
+	}
+
+	resp, err := s.send(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// An OAuth session's PDS may first ask for a DPoP nonce, which needs a new proof but no new token
+	if s.needsDPoPNonce(req, resp) {
+		resp.Body.Close()
+
+		retry, err := cloneRequest(req)
+		if err == nil {
+			err = s.authorize(retry, accessToken)
+		}
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		resp, err = s.send(retry)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		s.needsDPoPNonce(req, resp)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+
+		if err := s.refreshAccessToken(ctx, accessToken); err != nil {
+			cancel()
+			return nil, fmt.Errorf("auth refresh failed after 401: %w", err)
+		}
+
+		retry, err := cloneRequest(req)
+		if err == nil {
+			err = s.authorize(retry, s.GetAccessToken())
+		}
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		resp, err = s.send(retry)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// HealthCheck verifies connectivity to the service
+func (s *BlueskyService) HealthCheck(ctx context.Context) error {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Health))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", s.baseURL+"/xrpc/_health", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Close releases resources (no-op for HTTP client)
+func (s *BlueskyService) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.authenticated = false
+	s.oauth = nil
+	s.accessToken = ""
+	s.refreshToken = ""
+	s.did = ""
+	s.handle = ""
+	s.tokenIssuedAt, s.tokenExpiry = time.Time{}, time.Time{}
+	return nil
+}
+
+// GetTimeline fetches the authenticated user's home timeline
+func (s *BlueskyService) GetTimeline(ctx context.Context, limit int, cursor string) (*GetTimelineResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Feed))
+	defer cancel()
+
+	url := fmt.Sprintf("/xrpc/app.bsky.feed.getTimeline?limit=%d", limit)
+	if cursor != "" {
+		url += "&cursor=" + cursor
+	}
+
+	resp, err := s.Request(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getTimeline failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var timeline GetTimelineResponse
+	if err := json.NewDecoder(resp.Body).Decode(&timeline); err != nil {
+		return nil, err
+	}
+
+	return &timeline, nil
+}
+
+// GetFeed fetches a page of the custom feed whose generator is at the given AT URI
+func (s *BlueskyService) GetFeed(ctx context.Context, feed string, limit int, cursor string) (*GetFeedResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Feed))
+	defer cancel()
+
+	url := fmt.Sprintf("/xrpc/app.bsky.feed.getFeed?feed=%s&limit=%d", feed, limit)
+	if cursor != "" {
+		url += "&cursor=" + cursor
+	}
+
+	resp, err := s.Request(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getFeed failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var page GetFeedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// GetAuthorFeed fetches posts by a specific author. First pages are memoized for the run, so enrichment passes
+// asking for the same actor share one request.
+func (s *BlueskyService) GetAuthorFeed(ctx context.Context, actor string, limit int, cursor string) (*GetAuthorFeedResponse, error) {
+	if cursor != "" {
+		return s.fetchAuthorFeed(ctx, actor, limit, cursor)
+	}
+
+	feed, err := s.feeds.do(ctx, fmt.Sprintf("%s|%d", actor, limit), func() (*GetAuthorFeedResponse, error) {
+		return s.fetchAuthorFeed(ctx, actor, limit, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	clone := *feed
+	clone.Feed = slices.Clone(feed.Feed)
+	return &clone, nil
+}
+
+// fetchAuthorFeed requests a page of an author's posts
+func (s *BlueskyService) fetchAuthorFeed(ctx context.Context, actor string, limit int, cursor string) (*GetAuthorFeedResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Feed))
+	defer cancel()
+
+	url := fmt.Sprintf("/xrpc/app.bsky.feed.getAuthorFeed?actor=%s&limit=%d", actor, limit)
+	if cursor != "" {
+		url += "&cursor=" + cursor
+	}
+
+	resp, err := s.Request(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getAuthorFeed failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var feed GetAuthorFeedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+// GetActorLikes fetches a page of posts liked by actor. The AppView only answers this for the signed-in account.
+func (s *BlueskyService) GetActorLikes(ctx context.Context, actor string, limit int, cursor string) (*GetActorLikesResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Feed))
+	defer cancel()
+
+	url := fmt.Sprintf("/xrpc/app.bsky.feed.getActorLikes?actor=%s&limit=%d", actor, limit)
+	if cursor != "" {
+		url += "&cursor=" + cursor
+	}
+
+	resp, err := s.Request(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getActorLikes failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var likes GetActorLikesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&likes); err != nil {
+		return nil, err
+	}
+
+	return &likes, nil
+}
+
+// GetLikes fetches a page of accounts that liked the post at uri
+func (s *BlueskyService) GetLikes(ctx context.Context, uri string, limit int, cursor string) (*GetLikesResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Feed))
+	defer cancel()
+
+	if uri == "" {
+		return nil, fmt.Errorf("post URI is required")
+	}
+
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	params := fmt.Sprintf("uri=%s&limit=%d", url.QueryEscape(uri), limit)
+	if cursor != "" {
+		params += "&cursor=" + url.QueryEscape(cursor)
+	}
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/app.bsky.feed.getLikes?"+params, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getLikes failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result GetLikesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetRepostedBy fetches a page of accounts that reposted the post at uri
+func (s *BlueskyService) GetRepostedBy(ctx context.Context, uri string, limit int, cursor string) (*GetRepostedByResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Feed))
+	defer cancel()
+
+	if uri == "" {
+		return nil, fmt.Errorf("post URI is required")
+	}
+
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	params := fmt.Sprintf("uri=%s&limit=%d", url.QueryEscape(uri), limit)
+	if cursor != "" {
+		params += "&cursor=" + url.QueryEscape(cursor)
+	}
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/app.bsky.feed.getRepostedBy?"+params, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getRepostedBy failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result GetRepostedByResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListNotifications fetches a page of the authenticated user's notifications, newest first
+func (s *BlueskyService) ListNotifications(ctx context.Context, limit int, cursor string) (*ListNotificationsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Feed))
+	defer cancel()
+
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	params := fmt.Sprintf("limit=%d", limit)
+	if cursor != "" {
+		params += "&cursor=" + url.QueryEscape(cursor)
+	}
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/app.bsky.notification.listNotifications?"+params, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("listNotifications failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result ListNotificationsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListAppPasswords fetches the app passwords of the authenticated account
+func (s *BlueskyService) ListAppPasswords(ctx context.Context) (*ListAppPasswordsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/com.atproto.server.listAppPasswords", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("listAppPasswords failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result ListAppPasswordsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// RevokeAppPassword revokes the app password with the given name, ending every session created with it
+func (s *BlueskyService) RevokeAppPassword(ctx context.Context, name string) error {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.Request(ctx, "POST", "/xrpc/com.atproto.server.revokeAppPassword", bytes.NewReader(body), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("revokeAppPassword failed: %s - %s", resp.Status, string(bodyText))
+	}
+	return nil
+}
+
+// GetNotificationPreferences fetches the authenticated user's notification settings per category
+func (s *BlueskyService) GetNotificationPreferences(ctx context.Context) (*NotificationPreferences, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Profile))
+	defer cancel()
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/app.bsky.notification.getPreferences", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getPreferences failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result NotificationPreferences
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// PutNotificationPreferences replaces the settings of the given notification categories, leaving the others
+// untouched, and returns the settings as saved
+func (s *BlueskyService) PutNotificationPreferences(ctx context.Context, categories map[string]map[string]any) (*NotificationPreferences, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	body, err := json.Marshal(categories)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Request(ctx, "POST", "/xrpc/app.bsky.notification.putPreferencesV2", bytes.NewReader(body), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("putPreferencesV2 failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result NotificationPreferences
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// PutNotificationPriority turns priority notifications on or off: when on, only accounts the user follows notify
+func (s *BlueskyService) PutNotificationPriority(ctx context.Context, priority bool) error {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	body, err := json.Marshal(map[string]bool{"priority": priority})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.Request(ctx, "POST", "/xrpc/app.bsky.notification.putPreferences", bytes.NewReader(body), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("putPreferences failed: %s - %s", resp.Status, string(bodyText))
+	}
+	return nil
+}
+
+// GetPreferences fetches the authenticated user's app preferences
+func (s *BlueskyService) GetPreferences(ctx context.Context) (*GetPreferencesResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Profile))
+	defer cancel()
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/app.bsky.actor.getPreferences", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getPreferences failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result GetPreferencesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// PutPreferences replaces the authenticated user's app preferences. The whole list is written, so callers change
+// the result of [BlueskyService.GetPreferences] rather than sending only what they edited.
+func (s *BlueskyService) PutPreferences(ctx context.Context, preferences []map[string]any) error {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	if preferences == nil {
+		preferences = []map[string]any{}
+	}
+	body, err := json.Marshal(map[string]any{"preferences": preferences})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.Request(ctx, "POST", "/xrpc/app.bsky.actor.putPreferences", bytes.NewReader(body), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("putPreferences failed: %s - %s", resp.Status, string(bodyText))
+	}
+	return nil
+}
+
+type acceptLabelersKey struct{}
+
+// WithAcceptLabelers returns a context whose requests ask the AppView to apply labels from the given labelers,
+// so views fetched with it carry those labels alongside Bluesky's own
+func WithAcceptLabelers(ctx context.Context, labelers []string) context.Context {
+	return context.WithValue(ctx, acceptLabelersKey{}, labelers)
+}
+
+// GetFollows fetches the list of accounts that an actor follows.
+// Limit must be between 1-100 (API enforced); defaults to 50 if not specified.
+func (s *BlueskyService) GetFollows(ctx context.Context, actor string, limit int, cursor string) (*GetFollowsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Graph))
+	defer cancel()
+
+	if actor == "" {
+		return nil, fmt.Errorf("actor is required")
+	}
+
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	params := fmt.Sprintf("actor=%s&limit=%d", actor, limit)
+	if cursor != "" {
+		params += "&cursor=" + cursor
+	}
+
+	url := "/xrpc/app.bsky.graph.getFollows?" + params
+
+	resp, err := s.Request(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getFollows failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var follows GetFollowsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&follows); err != nil {
+		return nil, err
+	}
+
+	return &follows, nil
+}
+
+// GetRelationships fetches how each of others relates to actor, including the URIs of follow records
+// in both directions. The API accepts up to 30 others per call.
+func (s *BlueskyService) GetRelationships(ctx context.Context, actor string, others []string) (*GetRelationshipsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Graph))
+	defer cancel()
+
+	params := url.Values{"actor": {actor}}
+	for _, other := range others {
+		params.Add("others", other)
+	}
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/app.bsky.graph.getRelationships?"+params.Encode(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getRelationships failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result GetRelationshipsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetFollowers fetches the list of accounts that follow an actor.
+// Limit must be between 1-100 (API enforced); defaults to 50 if not specified.
+func (s *BlueskyService) GetFollowers(ctx context.Context, actor string, limit int, cursor string) (*GetFollowersResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Graph))
+	defer cancel()
+
+	if actor == "" {
+		return nil, fmt.Errorf("actor is required")
+	}
+
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	params := fmt.Sprintf("actor=%s&limit=%d", actor, limit)
+	if cursor != "" {
+		params += "&cursor=" + cursor
+	}
+
+	url := "/xrpc/app.bsky.graph.getFollowers?" + params
+
+	resp, err := s.Request(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getFollowers failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var followers GetFollowersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&followers); err != nil {
+		return nil, err
+	}
+
+	return &followers, nil
+}
+
+// GetList fetches a list and a page of its members by AT URI.
+// Limit must be between 1-100 (API enforced); defaults to 50 if not specified.
+func (s *BlueskyService) GetList(ctx context.Context, list string, limit int, cursor string) (*GetListResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Graph))
+	defer cancel()
+
+	if list == "" {
+		return nil, fmt.Errorf("list is required")
+	}
+
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	params := fmt.Sprintf("list=%s&limit=%d", url.QueryEscape(list), limit)
+	if cursor != "" {
+		params += "&cursor=" + url.QueryEscape(cursor)
+	}
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/app.bsky.graph.getList?"+params, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getList failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result GetListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListRecords fetches a page of the records in one collection of repo, newest first
+func (s *BlueskyService) ListRecords(ctx context.Context, repo, collection string, limit int, cursor string) (*ListRecordsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	params := url.Values{"repo": {repo}, "collection": {collection}, "limit": {strconv.Itoa(limit)}}
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/com.atproto.repo.listRecords?"+params.Encode(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("listRecords failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result ListRecordsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetRecord fetches the record with key rkey in one collection of repo
+func (s *BlueskyService) GetRecord(ctx context.Context, repo, collection, rkey string) (*Record, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	params := url.Values{"repo": {repo}, "collection": {collection}, "rkey": {rkey}}
+
+	resp, err := s.Request(ctx, "GET", "/xrpc/com.atproto.repo.getRecord?"+params.Encode(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getRecord failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result Record
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateRecord writes a record to the authenticated user's repository via com.atproto.repo.createRecord
+func (s *BlueskyService) CreateRecord(ctx context.Context, collection string, record any) (*CreateRecordResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	body, err := json.Marshal(map[string]any{
+		"repo":       s.GetDid(),
+		"collection": collection,
+		"record":     record,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Request(ctx, "POST", "/xrpc/com.atproto.repo.createRecord", bytes.NewReader(body), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("createRecord failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result CreateRecordResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// AddToList adds subject (a DID) to the list at the given AT URI by creating an app.bsky.graph.listitem record
+func (s *BlueskyService) AddToList(ctx context.Context, list, subject string) (*CreateRecordResponse, error) {
+	return s.CreateRecord(ctx, "app.bsky.graph.listitem", map[string]any{
+		"$type":     "app.bsky.graph.listitem",
+		"subject":   subject,
+		"list":      list,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Follow follows subject (a DID) by creating an app.bsky.graph.follow record
+func (s *BlueskyService) Follow(ctx context.Context, subject string) (*CreateRecordResponse, error) {
+	return s.CreateRecord(ctx, "app.bsky.graph.follow", map[string]any{
+		"$type":     "app.bsky.graph.follow",
+		"subject":   subject,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Block blocks subject (a DID) by creating an app.bsky.graph.block record
+func (s *BlueskyService) Block(ctx context.Context, subject string) (*CreateRecordResponse, error) {
+	return s.CreateRecord(ctx, "app.bsky.graph.block", map[string]any{
+		"$type":     "app.bsky.graph.block",
+		"subject":   subject,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Like likes the post with the given URI and CID by creating an app.bsky.feed.like record
+func (s *BlueskyService) Like(ctx context.Context, uri, cid string) (*CreateRecordResponse, error) {
+	return s.CreateRecord(ctx, "app.bsky.feed.like", map[string]any{
+		"$type":     "app.bsky.feed.like",
+		"subject":   PostRef{Uri: uri, Cid: cid},
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Repost reposts the post with the given URI and CID by creating an app.bsky.feed.repost record
+func (s *BlueskyService) Repost(ctx context.Context, uri, cid string) (*CreateRecordResponse, error) {
+	return s.CreateRecord(ctx, "app.bsky.feed.repost", map[string]any{
+		"$type":     "app.bsky.feed.repost",
+		"subject":   PostRef{Uri: uri, Cid: cid},
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Reply posts text as a reply to parent in the thread started by root
+func (s *BlueskyService) Reply(ctx context.Context, text string, parent, root PostRef) (*CreateRecordResponse, error) {
+	return s.CreatePost(ctx, NewPost{Text: text, Reply: &ReplyRefs{Root: &root, Parent: &parent}})
+}
+
+// CreatePost publishes a post as the signed-in user, with its facets, languages, and any images or link card
+func (s *BlueskyService) CreatePost(ctx context.Context, post NewPost) (*CreateRecordResponse, error) {
+	if len(post.Images) > 0 && post.External != nil {
+		return nil, errors.New("a post can have images or a link card, not both")
+	}
+
+	record := map[string]any{
+		"$type":     "app.bsky.feed.post",
+		"text":      post.Text,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	}
+	if len(post.Facets) > 0 {
+		record["facets"] = post.Facets
+	}
+	if post.Reply != nil {
+		record["reply"] = post.Reply
+	}
+	if len(post.Langs) > 0 {
+		record["langs"] = post.Langs
+	}
+	switch {
+	case len(post.Images) > 0:
+		images := make([]map[string]any, 0, len(post.Images))
+		for _, image := range post.Images {
+			entry := map[string]any{"image": image.Blob, "alt": image.Alt}
+			if image.Width > 0 && image.Height > 0 {
+				entry["aspectRatio"] = map[string]int{"width": image.Width, "height": image.Height}
+			}
+			images = append(images, entry)
+		}
+		record["embed"] = map[string]any{"$type": "app.bsky.embed.images", "images": images}
+	case post.External != nil:
+		external := map[string]any{
+			"uri":         post.External.URI,
+			"title":       post.External.Title,
+			"description": post.External.Description,
+		}
+		if post.External.Thumb != nil {
+			external["thumb"] = post.External.Thumb
+		}
+		record["embed"] = map[string]any{"$type": "app.bsky.embed.external", "external": external}
+	}
+	return s.CreateRecord(ctx, "app.bsky.feed.post", record)
+}
+
+// UploadBlob uploads media, such as a post's image, via com.atproto.repo.uploadBlob. The PDS keeps the blob only
+// if a record refers to it soon after.
+func (s *BlueskyService) UploadBlob(ctx context.Context, data []byte, mimeType string) (*Blob, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	resp, err := s.Request(ctx, "POST", "/xrpc/com.atproto.repo.uploadBlob", bytes.NewReader(data), map[string]string{"Content-Type": mimeType})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("uploadBlob failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result struct {
+		Blob Blob `json:"blob"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result.Blob, nil
+}
+
+// DeleteRecord removes the record at the given AT URI from the authenticated user's repository
+func (s *BlueskyService) DeleteRecord(ctx context.Context, uri string) error {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	ref, err := aturi.ParseAtURI(uri)
+	if err != nil {
+		return err
+	}
+	if !ref.IsRecord() {
+		return fmt.Errorf("invalid record URI %q: missing collection and record key", uri)
+	}
+	if ref.Authority != s.GetDid() {
+		return fmt.Errorf("record %q is not in your repository", uri)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"repo":       ref.Authority,
+		"collection": ref.Collection,
+		"rkey":       ref.Rkey,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.Request(ctx, "POST", "/xrpc/com.atproto.repo.deleteRecord", bytes.NewReader(body), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("deleteRecord failed: %s - %s", resp.Status, string(bodyText))
+	}
+	return nil
+}
+
+// MuteThread silences notifications from the thread whose root post is at root
+func (s *BlueskyService) MuteThread(ctx context.Context, root string) error {
+	return s.threadMute(ctx, "app.bsky.graph.muteThread", root)
+}
+
+// UnmuteThread restores notifications from the thread whose root post is at root
+func (s *BlueskyService) UnmuteThread(ctx context.Context, root string) error {
+	return s.threadMute(ctx, "app.bsky.graph.unmuteThread", root)
+}
+
+// threadMute calls one of the thread mute procedures with the thread root
+func (s *BlueskyService) threadMute(ctx context.Context, method, root string) error {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"root": root})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.Request(ctx, "POST", "/xrpc/"+method, bytes.NewReader(body), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s failed: %s - %s", strings.TrimPrefix(method, "app.bsky.graph."), resp.Status, string(bodyText))
+	}
+	return nil
+}
+
+// CreateReport files a moderation report about subject with the moderation service whose DID is given,
+// proxied through the user's PDS. Build subject with [AccountSubject] or [RecordSubject].
+func (s *BlueskyService) CreateReport(ctx context.Context, service, reasonType, reason string, subject map[string]any) (*CreateReportResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Default))
+	defer cancel()
+
+	payload := map[string]any{
+		"reasonType": reasonType,
+		"subject":    subject,
+	}
+	if reason != "" {
+		payload["reason"] = reason
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var headers map[string]string
+	if service != "" {
+		headers = map[string]string{"atproto-proxy": service + "#atproto_labeler"}
+	}
+
+	resp, err := s.Request(ctx, "POST", "/xrpc/com.atproto.moderation.createReport", bytes.NewReader(body), headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("createReport failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result CreateReportResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// AccountSubject is a report subject for a whole account
+func AccountSubject(did string) map[string]any {
+	return map[string]any{"$type": "com.atproto.admin.defs#repoRef", "did": did}
+}
+
+// RecordSubject is a report subject for a single record, such as a post
+func RecordSubject(uri, cid string) map[string]any {
+	return map[string]any{"$type": "com.atproto.repo.strongRef", "uri": uri, "cid": cid}
+}
+
+// GetProfile fetches detailed profile information for an actor.
+// Actor can be a DID or handle (e.g., "did:plc:..." or "alice.bsky.social").
+// Profiles are memoized for the run, so repeated and concurrent lookups of one actor share a request.
+func (s *BlueskyService) GetProfile(ctx context.Context, actor string) (*ActorProfile, error) {
+	profile, err := s.profiles.do(ctx, actor, func() (*ActorProfile, error) {
+		return s.fetchProfile(ctx, actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clone := *profile
+	return &clone, nil
+}
+
+// fetchProfile requests an actor's profile
+func (s *BlueskyService) fetchProfile(ctx context.Context, actor string) (*ActorProfile, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Profile))
+	defer cancel()
+
+	url := fmt.Sprintf("/xrpc/app.bsky.actor.getProfile?actor=%s", actor)
+
+	resp, err := s.Request(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getProfile failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var profile ActorProfile
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// SearchActors searches for actors (users) matching the query string.
+func (s *BlueskyService) SearchActors(ctx context.Context, query string, limit int, cursor string) (*SearchActorsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Search))
+	defer cancel()
+
+	urlPath := fmt.Sprintf("/xrpc/app.bsky.actor.searchActors?q=%s&limit=%d", strings.ReplaceAll(query, " ", "+"), limit)
+	if cursor != "" {
+		urlPath += "&cursor=" + cursor
+	}
+
+	resp, err := s.Request(ctx, "GET", urlPath, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("searchActors failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result SearchActorsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SearchPostsOptions narrows and pages a [BlueskyService.SearchPosts] query
+type SearchPostsOptions struct {
+	Author string    // DID or handle whose posts to search; empty searches everyone's
+	Limit  int       // posts per page
+	Cursor string    // cursor of the page to fetch; empty for the first
+	Since  time.Time // when non-zero, only posts created at or after it
+	Until  time.Time // when non-zero, only posts created before it
+}
+
+// SearchPosts searches for posts matching the query string returning feed view posts with pagination support
+func (s *BlueskyService) SearchPosts(ctx context.Context, query string, opts SearchPostsOptions) (*SearchPostsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Search))
+	defer cancel()
+
+	urlPath := fmt.Sprintf("/xrpc/app.bsky.feed.searchPosts?q=%s&limit=%d", strings.ReplaceAll(query, " ", "+"), opts.Limit)
+	if opts.Cursor != "" {
+		urlPath += "&cursor=" + opts.Cursor
+	}
+	if opts.Author != "" {
+		urlPath += "&author=" + url.QueryEscape(opts.Author)
+	}
+	if !opts.Since.IsZero() {
+		urlPath += "&since=" + url.QueryEscape(opts.Since.UTC().Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		urlPath += "&until=" + url.QueryEscape(opts.Until.UTC().Format(time.RFC3339))
+	}
+
+	resp, err := s.Request(ctx, "GET", urlPath, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("searchPosts failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result SearchPostsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetPosts fetches specific posts by their AT URIs.
+// Accepts a slice of URIs and returns the corresponding posts.
+func (s *BlueskyService) GetPosts(ctx context.Context, uris []string) (*GetPostsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Feed))
+	defer cancel()
+
+	if len(uris) == 0 {
+		return &GetPostsResponse{Posts: []FeedViewPost{}}, nil
+	}
+
+	url := "/xrpc/app.bsky.feed.getPosts?"
+	for i, uri := range uris {
+		if i > 0 {
+			url += "&"
+		}
+		url += "uris=" + uri
+	}
+
+	resp, err := s.Request(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getPosts failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result GetPostsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetPostThread fetches the thread around the post at uri, with replies up to depth levels down
+func (s *BlueskyService) GetPostThread(ctx context.Context, uri string, depth int) (*GetPostThreadResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Feed))
+	defer cancel()
+
+	url := fmt.Sprintf("/xrpc/app.bsky.feed.getPostThread?uri=%s&depth=%d", uri, depth)
+
+	resp, err := s.Request(ctx, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("getPostThread failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var result GetPostThreadResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SetTokens allows external code to set tokens (e.g., from SessionRepository)
+func (s *BlueskyService) SetTokens(accessToken, refreshToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessToken = accessToken
+	s.refreshToken = refreshToken
+	s.authenticated = true
+	s.tokenIssuedAt, s.tokenExpiry = time.Time{}, time.Time{}
+
+	if info, err := ParseToken(accessToken); err == nil {
+		s.tokenIssuedAt, s.tokenExpiry = info.IssuedAt, info.Expiry
+	}
+}
+
+// GetLastPostDate fetches the most recent post date for an actor.
+func (s *BlueskyService) GetLastPostDate(ctx context.Context, actor string) (time.Time, error) {
+	feed, err := s.GetAuthorFeed(ctx, actor, 1, "")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(feed.Feed) == 0 {
+		return time.Time{}, nil
+	}
+
+	indexedAt := feed.Feed[0].Post.IndexedAt
+	lastPost, err := time.Parse(time.RFC3339, indexedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse indexedAt: %w", err)
+	}
+
+	return lastPost, nil
+}
+
+// BatchGetLastPostDates fetches last post dates for multiple actors concurrently, as a map of actor DID/handle to their last post date..
+// Requests share the service's adaptive [ConcurrencyController]; a positive maxConcurrent also caps this call.
+// Actors that can't be fetched, even after retries, are left out; a context from [WithBatchReport] records why.
+func (s *BlueskyService) BatchGetLastPostDates(ctx context.Context, actors []string, maxConcurrent int) map[string]time.Time {
+	results := make(map[string]time.Time)
+	resultsMu := &sync.Mutex{}
+	sem := callLimit(maxConcurrent)
+	var wg sync.WaitGroup
+
+	report := batchReport(ctx)
+	logger := utils.GetLogger()
+	logger.Debug("batch fetching last post dates", "actors", len(actors), "limit", maxConcurrent, "concurrency", s.concurrency.Limit())
+
+	for _, actor := range actors {
+		wg.Add(1)
+		go func(a string) {
+			defer wg.Done()
+
+			release, err := s.acquire(ctx, sem)
+			if err != nil {
+				report.record(a, err)
+				return
+			}
+			defer release()
+
+			lastPost, err := s.GetLastPostDate(ctx, a)
+			if err != nil {
+				logger.Debug("batch fetch failed", "actor", a, "error", err)
+				report.record(a, err)
+				return
+			}
+
+			report.record(a, nil)
+			resultsMu.Lock()
+			results[a] = lastPost
+			resultsMu.Unlock()
+		}(actor)
+	}
+
+	wg.Wait()
+	logger.Debug("batch fetched last post dates", "requested", len(actors), "fetched", len(results), "failed", len(actors)-len(results))
+	return results
+}
+
+// BatchGetProfiles fetches full profiles for multiple actors concurrently, as a map of actor DID/handle to their full ActorProfile.
+// Requests share the service's adaptive [ConcurrencyController]; a positive maxConcurrent also caps this call.
+// Actors that can't be fetched, even after retries, are left out; a context from [WithBatchReport] records why.
+func (s *BlueskyService) BatchGetProfiles(ctx context.Context, actors []string, maxConcurrent int) map[string]*ActorProfile {
+	results := make(map[string]*ActorProfile)
+	resultsMu := &sync.Mutex{}
+	sem := callLimit(maxConcurrent)
+	var wg sync.WaitGroup
+
+	report := batchReport(ctx)
+	logger := utils.GetLogger()
+	logger.Debug("batch fetching profiles", "actors", len(actors), "limit", maxConcurrent, "concurrency", s.concurrency.Limit())
+
+	for _, actor := range actors {
+		wg.Add(1)
+		go func(a string) {
+			defer wg.Done()
+
+			release, err := s.acquire(ctx, sem)
+			if err != nil {
+				report.record(a, err)
+				return
+			}
+			defer release()
+
+			profile, err := s.GetProfile(ctx, a)
+			if err != nil {
+				logger.Debug("batch fetch failed", "actor", a, "error", err)
+				report.record(a, err)
+				return
+			}
+
+			report.record(a, nil)
+			resultsMu.Lock()
+			results[a] = profile
+			resultsMu.Unlock()
+		}(actor)
+	}
+
+	wg.Wait()
+	logger.Debug("batch fetched profiles", "requested", len(actors), "fetched", len(results), "failed", len(actors)-len(results))
+	return results
+}
+
+// callLimit returns a semaphore capping one batch call at maxConcurrent, or nil when it has no cap of its own
+func callLimit(maxConcurrent int) chan struct{} {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	return make(chan struct{}, maxConcurrent)
+}
+
+// acquire waits for a slot of the call's own cap, when it has one, and then of the shared controller,
+// returning the function that frees both
+func (s *BlueskyService) acquire(ctx context.Context, sem chan struct{}) (func(), error) {
+	if sem != nil {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err := s.concurrency.Acquire(ctx); err != nil {
+		if sem != nil {
+			<-sem
+		}
+		return nil, err
+	}
+	return func() {
+		s.concurrency.Release()
+		if sem != nil {
+			<-sem
+		}
+	}, nil
+}
+
+// PostRate holds posting frequency metrics for an actor
+type PostRate struct {
+	PostsPerDay  float64
+	LastPostDate time.Time
+	SampleSize   int
+	Daily        []int // posts in each of the last [PostRateDays] days, oldest first; -1 for days before the sample
+}
+
+// PostRateDays is how many days [PostRate.Daily] counts
+const PostRateDays = 7
+
+// dailyPostCounts counts posts in each of the [PostRateDays] 24-hour windows ending at now, oldest first. When the
+// sample was full, older posts may have been cut off, so windows that end before the oldest sampled post are -1.
+func dailyPostCounts(indexed []time.Time, now time.Time, full bool) []int {
+	daily := make([]int, PostRateDays)
+	var oldest time.Time
+	for _, t := range indexed {
+		if oldest.IsZero() || t.Before(oldest) {
+			oldest = t
+		}
+		age := now.Sub(t)
+		if age < 0 {
+			age = 0
+		}
+		if day := int(age / (24 * time.Hour)); day < PostRateDays {
+			daily[PostRateDays-1-day]++
+		}
+	}
+
+	if full && !oldest.IsZero() {
+		for i := range daily {
+			end := now.Add(-time.Duration(PostRateDays-1-i) * 24 * time.Hour)
+			if !end.After(oldest) {
+				daily[i] = -1
+			}
+		}
+	}
+	return daily
+}
+
+// BatchGetPostRates calculates posting rates for multiple actors concurrently, as a map of actor DID/handle to their [PostRate] metrics.
+//
+// Samples recent posts from each actor and calculates posts per day over the lookback period.
+// Requests share the service's adaptive [ConcurrencyController]; a positive maxConcurrent also caps this call.
+// Actors that can't be fetched, even after retries, are left out; a context from [WithBatchReport] records why.
+func (s *BlueskyService) BatchGetPostRates(ctx context.Context, actors []string, sampleSize int, lookbackDays int, maxConcurrent int, progressFn func(current, total int)) map[string]*PostRate {
+	results := make(map[string]*PostRate)
+	resultsMu := &sync.Mutex{}
+	sem := callLimit(maxConcurrent)
+	var wg sync.WaitGroup
+
+	report := batchReport(ctx)
+	logger := utils.GetLogger()
+	logger.Debug("batch fetching post rates", "actors", len(actors), "limit", maxConcurrent, "concurrency", s.concurrency.Limit())
+
+	completed := 0
+	completedMu := &sync.Mutex{}
+	total := len(actors)
+
+	for _, actor := range actors {
+		wg.Add(1)
+		go func(a string) {
+			defer wg.Done()
+
+			release, err := s.acquire(ctx, sem)
+			if err != nil {
+				report.record(a, err)
+				return
+			}
+			defer release()
+
+			feed, err := s.GetAuthorFeed(ctx, a, sampleSize, "")
+			if err != nil {
+				logger.Debug("batch fetch failed", "actor", a, "error", err)
+				report.record(a, err)
+				return
+			}
+
+			if len(feed.Feed) == 0 {
+				report.record(a, nil)
+				resultsMu.Lock()
+				results[a] = &PostRate{
+					PostsPerDay:  0,
+					LastPostDate: time.Time{},
+					SampleSize:   0,
+					Daily:        make([]int, PostRateDays),
+				}
+				resultsMu.Unlock()
+
+				completedMu.Lock()
+				completed++
+				if progressFn != nil {
+					progressFn(completed, total)
+				}
+				completedMu.Unlock()
+				return
+			}
+
+			lastPost, err := time.Parse(time.RFC3339, feed.Feed[0].Post.IndexedAt)
+			if err != nil {
+				report.record(a, fmt.Errorf("failed to parse indexedAt: %w", err))
+				return
+			}
+
+			now := time.Now()
+			cutoffTime := now.AddDate(0, 0, -lookbackDays)
+			recentPosts := 0
+			indexed := make([]time.Time, 0, len(feed.Feed))
+			for _, post := range feed.Feed {
+				indexedAt, err := time.Parse(time.RFC3339, post.Post.IndexedAt)
+				if err != nil {
+					continue
+				}
+				indexed = append(indexed, indexedAt)
+				if indexedAt.After(cutoffTime) {
+					recentPosts++
+				}
+			}
+
+			postsPerDay := float64(recentPosts) / float64(lookbackDays)
+
+			report.record(a, nil)
+			resultsMu.Lock()
+			results[a] = &PostRate{
+				PostsPerDay:  postsPerDay,
+				LastPostDate: lastPost,
+				SampleSize:   len(feed.Feed),
+				Daily:        dailyPostCounts(indexed, now, len(feed.Feed) >= sampleSize),
+			}
+			resultsMu.Unlock()
+
+			completedMu.Lock()
+			completed++
+			if progressFn != nil {
+				progressFn(completed, total)
+			}
+			completedMu.Unlock()
+		}(actor)
+	}
+
+	wg.Wait()
+	logger.Debug("batch fetched post rates", "requested", len(actors), "fetched", len(results), "failed", len(actors)-len(results))
+	return results
+}
+
+// GetAccessToken returns the current access token
+func (s *BlueskyService) GetAccessToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.accessToken
+}
+
+// GetRefreshToken returns the current refresh token
+func (s *BlueskyService) GetRefreshToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.refreshToken
+}
+
+// GetDid returns the authenticated user's DID
+func (s *BlueskyService) GetDid() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.did
+}
+
+// GetHandle returns the authenticated user's handle
+func (s *BlueskyService) GetHandle() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.handle
+}
+
+// SetDid sets the authenticated user's DID, dropping cached profiles and feeds when it changes
+func (s *BlueskyService) SetDid(did string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.did != "" && s.did != did {
+		// Profiles carry viewer state that depends on the session
+		s.profiles.reset()
+		s.feeds.reset()
+	}
+	s.did = did
+}
+
+// SetHandle sets the authenticated user's handle
+func (s *BlueskyService) SetHandle(handle string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handle = handle
+}
+
+// shouldRefreshToken checks if the token is 90% through its lifetime or within [TokenLeeway] of expiry,
+// measured against the PDS clock when the skew is known
+func (s *BlueskyService) shouldRefreshToken() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.tokenExpiry.IsZero() {
+		return false
+	}
+
+	token := TokenInfo{IssuedAt: s.tokenIssuedAt, Expiry: s.tokenExpiry}
+	return !time.Now().Add(s.clockSkew).Before(token.RefreshAt())
+}
+
+// acceptIssuedToken validates the scope and audience of an access token just issued by the PDS and records its lifetime.
+// Its validity window is trusted as current on the PDS clock, and the gap between its iat and the local clock
+// is kept as the clock skew. Opaque tokens are accepted as-is. Callers must hold s.mu.
+func (s *BlueskyService) acceptIssuedToken(accessToken string) error {
+	s.tokenIssuedAt, s.tokenExpiry = time.Time{}, time.Time{}
+
+	info, err := ParseToken(accessToken)
+	if err != nil {
+		return nil
+	}
+	if !info.IssuedAt.IsZero() {
+		s.clockSkew = time.Until(info.IssuedAt)
+	}
+	if err := info.validateClaims(); err != nil {
+		return err
+	}
+
+	s.tokenIssuedAt, s.tokenExpiry = info.IssuedAt, info.Expiry
+	return nil
+}
+
+// ClockSkew returns how far the PDS clock runs ahead of the local clock, as observed on the last issued token
+func (s *BlueskyService) ClockSkew() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clockSkew
+}
+
+// refreshAccessToken exchanges the refresh token for a new session, replacing staleToken.
+// Concurrent callers are serialized, and a caller whose stale token was already replaced returns without
+// refreshing again, since the PDS rotates refresh tokens and a second exchange would be rejected.
+// The new tokens are saved to the token store when one is set.
+func (s *BlueskyService) refreshAccessToken(ctx context.Context, staleToken string) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	s.mu.RLock()
+	current, refreshToken := s.accessToken, s.refreshToken
+	s.mu.RUnlock()
+	if current != staleToken {
+		return nil
+	}
+
+	if oauth := s.currentOAuth(); oauth != nil {
+		tokens, err := s.refreshOAuth(ctx, oauth, refreshToken)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		s.acceptOAuthTokens(tokens)
+		tokenStore := s.tokenStore
+		s.mu.Unlock()
+		return s.storeTokens(ctx, tokenStore, tokens.AccessToken, tokens.RefreshToken)
+	}
+
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Auth))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.baseURL+"/xrpc/com.atproto.server.refreshSession", nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+refreshToken)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyText, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("token refresh failed: %s - %s", resp.Status, string(bodyText))
+	}
+
+	var session CreateSessionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if err := s.acceptIssuedToken(session.AccessJwt); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.accessToken = session.AccessJwt
+	s.refreshToken = session.RefreshJwt
+	tokenStore := s.tokenStore
+	s.mu.Unlock()
+
+	return s.storeTokens(ctx, tokenStore, session.AccessJwt, session.RefreshJwt)
+}
+
+// storeTokens saves refreshed tokens to tokenStore, if there is one
+func (s *BlueskyService) storeTokens(ctx context.Context, tokenStore TokenStore, accessToken, refreshToken string) error {
+	if tokenStore == nil {
+		return nil
+	}
+	if err := tokenStore.UpdateTokens(ctx, accessToken, refreshToken); err != nil {
+		return fmt.Errorf("failed to persist refreshed tokens: %w", err)
+	}
+	return nil
+}
+
+// SetTokenStore saves tokens obtained by automatic refresh to store, so later runs resume the rotated session
+func (s *BlueskyService) SetTokenStore(store TokenStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenStore = store
+}
+
+// SetQuotaRecorder reports the rate-limit headers of every response to recorder
+func (s *BlueskyService) SetQuotaRecorder(recorder QuotaRecorder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quotas = recorder
+}
+
+// recordQuota passes the rate-limit budget reported by resp to the quota recorder, if any
+func (s *BlueskyService) recordQuota(req *http.Request, resp *http.Response) {
+	quota, ok := ParseQuota(req.URL.Host, resp.Header, time.Now())
+	if !ok {
+		return
+	}
+	s.noteQuota(quota)
+
+	s.mu.RLock()
+	recorder := s.quotas
+	s.mu.RUnlock()
+	if recorder != nil {
+		recorder.RecordQuota(quota)
+	}
+}
+
+// rewindable returns body as a reader that [http.NewRequest] can replay through GetBody
+func rewindable(body io.Reader) (io.Reader, error) {
+	switch body.(type) {
+	case nil, *bytes.Buffer, *bytes.Reader, *strings.Reader:
+		return body, nil
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// cloneRequest copies req with a fresh body so it can be sent again
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
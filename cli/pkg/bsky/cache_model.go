@@ -1,4 +1,4 @@
-package store
+package bsky
 
 import "time"
 
@@ -9,6 +9,7 @@ type PostRateCacheModel struct {
 	PostsPerDay  float64
 	LastPostDate time.Time
 	SampleSize   int
+	Daily        []int // see [PostRate.Daily]; nil for entries cached before daily counts were kept
 	FetchedAt    time.Time
 	ExpiresAt    time.Time
 }
@@ -0,0 +1,741 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CacheRepository manages post rate and activity caches on SQLite or PostgreSQL.
+//
+// Provides methods for storing and retrieving expensive computation results stored as [PostRateCacheModel] or [ActivityCacheModel].
+type CacheRepository struct {
+	db      *sql.DB
+	dialect Dialect
+	maxSize int64 // estimated bytes the cache tables may use; 0 means unlimited
+
+	sizeMu   sync.Mutex
+	measured bool  // whether size holds a measurement, kept current by saves since
+	size     int64 // running estimate of the cache tables' bytes, from the last [CacheRepository.Usage]
+	saves    int   // saves since size was last measured
+}
+
+// NewCacheRepository creates a new cache repository with SQLite backend
+func NewCacheRepository() (*CacheRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CacheRepository{db: db}, nil
+}
+
+// NewPostgresCacheRepository creates a new cache repository backed by the PostgreSQL database at dsn
+func NewPostgresCacheRepository(dsn string) (*CacheRepository, error) {
+	db, err := openDB(DialectPostgres, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CacheRepository{db: db, dialect: DialectPostgres}, nil
+}
+
+// Init ensures database schema is initialized via the dialect's migrations
+func (r *CacheRepository) Init(ctx context.Context) error {
+	if r.dialect == DialectPostgres {
+		return RunDialectMigrations(r.db, r.dialect)
+	}
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *CacheRepository) Close() error {
+	return r.db.Close()
+}
+
+// GetPostRate retrieves cached post rate for an actor
+func (r *CacheRepository) GetPostRate(ctx context.Context, actorDid string) (*PostRateCacheModel, error) {
+	query := `
+		SELECT actor_did, posts_per_day, last_post_date, sample_size, daily_posts, fetched_at, expires_at
+		FROM cached_post_rates
+		WHERE actor_did = ? AND expires_at > ?
+	`
+
+	var cache PostRateCacheModel
+	var lastPostDate sql.NullTime
+	var daily sql.NullString
+
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(query), actorDid, time.Now()).Scan(
+		&cache.ActorDid,
+		&cache.PostsPerDay,
+		&lastPostDate,
+		&cache.SampleSize,
+		&daily,
+		&cache.FetchedAt,
+		&cache.ExpiresAt,
+	)
+
+	if lastPostDate.Valid {
+		cache.LastPostDate = lastPostDate.Time
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, &RepositoryError{Op: "GetPostRate", Entity: "cache entry", Err: err}
+	}
+	cache.Daily = decodeDailyPosts(daily)
+
+	return &cache, nil
+}
+
+// GetPostRates retrieves cached post rates for multiple actors in a single query,
+// as a map of actorDid -> PostRateCacheModel for found entries.
+func (r *CacheRepository) GetPostRates(ctx context.Context, actorDids []string) (_ map[string]*PostRateCacheModel, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "CacheRepository.GetPostRates", attribute.Int("db.batch.size", len(actorDids)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if len(actorDids) == 0 {
+		return make(map[string]*PostRateCacheModel), nil
+	}
+
+	query := `
+		SELECT actor_did, posts_per_day, last_post_date, sample_size, daily_posts, fetched_at, expires_at
+		FROM cached_post_rates
+		WHERE actor_did IN (` + buildPlaceholders(len(actorDids)) + `) AND expires_at > ?
+	`
+
+	args := make([]interface{}, len(actorDids)+1)
+	for i, did := range actorDids {
+		args[i] = did
+	}
+	args[len(actorDids)] = time.Now()
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, &RepositoryError{Op: "GetPostRates", Entity: "cache entry", Err: err}
+	}
+	defer rows.Close()
+
+	result := make(map[string]*PostRateCacheModel)
+	for rows.Next() {
+		var cache PostRateCacheModel
+		var lastPostDate sql.NullTime
+		var daily sql.NullString
+
+		err := rows.Scan(
+			&cache.ActorDid,
+			&cache.PostsPerDay,
+			&lastPostDate,
+			&cache.SampleSize,
+			&daily,
+			&cache.FetchedAt,
+			&cache.ExpiresAt,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "GetPostRates", Entity: "cache entry", Err: err}
+		}
+
+		if lastPostDate.Valid {
+			cache.LastPostDate = lastPostDate.Time
+		}
+		cache.Daily = decodeDailyPosts(daily)
+
+		result[cache.ActorDid] = &cache
+	}
+
+	return result, rows.Err()
+}
+
+// SavePostRate saves or updates a post rate cache entry
+func (r *CacheRepository) SavePostRate(ctx context.Context, cache *PostRateCacheModel) error {
+	if cache.FetchedAt.IsZero() {
+		cache.FetchedAt = time.Now()
+	}
+	if cache.ExpiresAt.IsZero() {
+		cache.ExpiresAt = time.Now().Add(24 * time.Hour)
+	}
+
+	query := `
+		INSERT INTO cached_post_rates (actor_did, posts_per_day, last_post_date, sample_size, daily_posts, fetched_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(actor_did) DO UPDATE SET
+			posts_per_day = excluded.posts_per_day,
+			last_post_date = excluded.last_post_date,
+			sample_size = excluded.sample_size,
+			daily_posts = excluded.daily_posts,
+			fetched_at = excluded.fetched_at,
+			expires_at = excluded.expires_at
+	`
+
+	var lastPostDate interface{}
+	if !cache.LastPostDate.IsZero() {
+		lastPostDate = cache.LastPostDate
+	}
+
+	_, err := r.db.ExecContext(ctx, r.dialect.Rebind(query),
+		cache.ActorDid,
+		cache.PostsPerDay,
+		lastPostDate,
+		cache.SampleSize,
+		encodeDailyPosts(cache.Daily),
+		cache.FetchedAt,
+		cache.ExpiresAt,
+	)
+
+	if err != nil {
+		return &RepositoryError{Op: "SavePostRate", Entity: "cache entry", Err: err}
+	}
+
+	return r.enforceMaxSize(ctx, cacheEntryBytes(cache.ActorDid))
+}
+
+// SavePostRates saves multiple post rate cache entries in a transaction
+func (r *CacheRepository) SavePostRates(ctx context.Context, caches []*PostRateCacheModel) (err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "CacheRepository.SavePostRates", attribute.Int("db.batch.size", len(caches)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if len(caches) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &RepositoryError{Op: "SavePostRates", Entity: "cache entry", Err: err}
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, r.dialect.Rebind(`
+		INSERT INTO cached_post_rates (actor_did, posts_per_day, last_post_date, sample_size, daily_posts, fetched_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(actor_did) DO UPDATE SET
+			posts_per_day = excluded.posts_per_day,
+			last_post_date = excluded.last_post_date,
+			sample_size = excluded.sample_size,
+			daily_posts = excluded.daily_posts,
+			fetched_at = excluded.fetched_at,
+			expires_at = excluded.expires_at
+	`))
+	if err != nil {
+		return &RepositoryError{Op: "SavePostRates", Entity: "cache entry", Err: err}
+	}
+	defer stmt.Close()
+
+	var added int64
+	for _, cache := range caches {
+		if cache.FetchedAt.IsZero() {
+			cache.FetchedAt = time.Now()
+		}
+		if cache.ExpiresAt.IsZero() {
+			cache.ExpiresAt = time.Now().Add(24 * time.Hour)
+		}
+
+		var lastPostDate interface{}
+		if !cache.LastPostDate.IsZero() {
+			lastPostDate = cache.LastPostDate
+		}
+
+		_, err := stmt.ExecContext(ctx,
+			cache.ActorDid,
+			cache.PostsPerDay,
+			lastPostDate,
+			cache.SampleSize,
+			encodeDailyPosts(cache.Daily),
+			cache.FetchedAt,
+			cache.ExpiresAt,
+		)
+		if err != nil {
+			return &RepositoryError{Op: "SavePostRates", Entity: "cache entry", Err: err}
+		}
+		added += cacheEntryBytes(cache.ActorDid)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &RepositoryError{Op: "SavePostRates", Entity: "cache entry", Err: err}
+	}
+
+	return r.enforceMaxSize(ctx, added)
+}
+
+// encodeDailyPosts stores daily post counts as a JSON list, or NULL when there are none
+func encodeDailyPosts(daily []int) any {
+	if daily == nil {
+		return nil
+	}
+	data, err := json.Marshal(daily)
+	if err != nil {
+		return nil
+	}
+	return string(data)
+}
+
+// decodeDailyPosts reads the counts [encodeDailyPosts] stored, or nil for entries without them
+func decodeDailyPosts(daily sql.NullString) []int {
+	if !daily.Valid {
+		return nil
+	}
+	var counts []int
+	if err := json.Unmarshal([]byte(daily.String), &counts); err != nil {
+		return nil
+	}
+	return counts
+}
+
+// DeletePostRate removes a post rate cache entry
+func (r *CacheRepository) DeletePostRate(ctx context.Context, actorDid string) error {
+	query := "DELETE FROM cached_post_rates WHERE actor_did = ?"
+	_, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), actorDid)
+	if err != nil {
+		return &RepositoryError{Op: "DeletePostRate", Entity: "cache entry", Err: err}
+	}
+	return nil
+}
+
+// GetActivity retrieves cached activity data for an actor
+func (r *CacheRepository) GetActivity(ctx context.Context, actorDid string) (*ActivityCacheModel, error) {
+	query := `
+		SELECT actor_did, last_post_date, fetched_at, expires_at
+		FROM cached_activity
+		WHERE actor_did = ? AND expires_at > ?
+	`
+
+	var cache ActivityCacheModel
+	var lastPostDate sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(query), actorDid, time.Now()).Scan(
+		&cache.ActorDid,
+		&lastPostDate,
+		&cache.FetchedAt,
+		&cache.ExpiresAt,
+	)
+
+	if lastPostDate.Valid {
+		cache.LastPostDate = lastPostDate.Time
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, &RepositoryError{Op: "GetActivity", Entity: "cache entry", Err: err}
+	}
+
+	return &cache, nil
+}
+
+// GetActivities retrieves cached activity data for multiple actors in a single query,
+// as a map of actorDid -> ActivityCacheModel for found entries.
+func (r *CacheRepository) GetActivities(ctx context.Context, actorDids []string) (_ map[string]*ActivityCacheModel, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "CacheRepository.GetActivities", attribute.Int("db.batch.size", len(actorDids)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if len(actorDids) == 0 {
+		return make(map[string]*ActivityCacheModel), nil
+	}
+
+	query := `
+		SELECT actor_did, last_post_date, fetched_at, expires_at
+		FROM cached_activity
+		WHERE actor_did IN (` + buildPlaceholders(len(actorDids)) + `) AND expires_at > ?
+	`
+
+	args := make([]interface{}, len(actorDids)+1)
+	for i, did := range actorDids {
+		args[i] = did
+	}
+	args[len(actorDids)] = time.Now()
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, &RepositoryError{Op: "GetActivities", Entity: "cache entry", Err: err}
+	}
+	defer rows.Close()
+
+	result := make(map[string]*ActivityCacheModel)
+	for rows.Next() {
+		var cache ActivityCacheModel
+		var lastPostDate sql.NullTime
+
+		err := rows.Scan(
+			&cache.ActorDid,
+			&lastPostDate,
+			&cache.FetchedAt,
+			&cache.ExpiresAt,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "GetActivities", Entity: "cache entry", Err: err}
+		}
+
+		if lastPostDate.Valid {
+			cache.LastPostDate = lastPostDate.Time
+		}
+
+		result[cache.ActorDid] = &cache
+	}
+
+	return result, rows.Err()
+}
+
+// SaveActivity saves or updates an activity cache entry
+func (r *CacheRepository) SaveActivity(ctx context.Context, cache *ActivityCacheModel) error {
+	if cache.FetchedAt.IsZero() {
+		cache.FetchedAt = time.Now()
+	}
+	if cache.ExpiresAt.IsZero() {
+		cache.ExpiresAt = time.Now().Add(24 * time.Hour)
+	}
+
+	query := `
+		INSERT INTO cached_activity (actor_did, last_post_date, fetched_at, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(actor_did) DO UPDATE SET
+			last_post_date = excluded.last_post_date,
+			fetched_at = excluded.fetched_at,
+			expires_at = excluded.expires_at
+	`
+
+	var lastPostDate interface{}
+	if !cache.LastPostDate.IsZero() {
+		lastPostDate = cache.LastPostDate
+	}
+
+	_, err := r.db.ExecContext(ctx, r.dialect.Rebind(query),
+		cache.ActorDid,
+		lastPostDate,
+		cache.FetchedAt,
+		cache.ExpiresAt,
+	)
+
+	if err != nil {
+		return &RepositoryError{Op: "SaveActivity", Entity: "cache entry", Err: err}
+	}
+
+	return r.enforceMaxSize(ctx, cacheEntryBytes(cache.ActorDid))
+}
+
+// SaveActivities saves multiple activity cache entries in a transaction
+func (r *CacheRepository) SaveActivities(ctx context.Context, caches []*ActivityCacheModel) (err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "CacheRepository.SaveActivities", attribute.Int("db.batch.size", len(caches)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if len(caches) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &RepositoryError{Op: "SaveActivities", Entity: "cache entry", Err: err}
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, r.dialect.Rebind(`
+		INSERT INTO cached_activity (actor_did, last_post_date, fetched_at, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(actor_did) DO UPDATE SET
+			last_post_date = excluded.last_post_date,
+			fetched_at = excluded.fetched_at,
+			expires_at = excluded.expires_at
+	`))
+	if err != nil {
+		return &RepositoryError{Op: "SaveActivities", Entity: "cache entry", Err: err}
+	}
+	defer stmt.Close()
+
+	var added int64
+	for _, cache := range caches {
+		if cache.FetchedAt.IsZero() {
+			cache.FetchedAt = time.Now()
+		}
+		if cache.ExpiresAt.IsZero() {
+			cache.ExpiresAt = time.Now().Add(24 * time.Hour)
+		}
+
+		var lastPostDate interface{}
+		if !cache.LastPostDate.IsZero() {
+			lastPostDate = cache.LastPostDate
+		}
+
+		_, err := stmt.ExecContext(ctx,
+			cache.ActorDid,
+			lastPostDate,
+			cache.FetchedAt,
+			cache.ExpiresAt,
+		)
+		if err != nil {
+			return &RepositoryError{Op: "SaveActivities", Entity: "cache entry", Err: err}
+		}
+		added += cacheEntryBytes(cache.ActorDid)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &RepositoryError{Op: "SaveActivities", Entity: "cache entry", Err: err}
+	}
+
+	return r.enforceMaxSize(ctx, added)
+}
+
+// DeleteActivity removes an activity cache entry
+func (r *CacheRepository) DeleteActivity(ctx context.Context, actorDid string) error {
+	query := "DELETE FROM cached_activity WHERE actor_did = ?"
+	_, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), actorDid)
+	if err != nil {
+		return &RepositoryError{Op: "DeleteActivity", Entity: "cache entry", Err: err}
+	}
+	return nil
+}
+
+// DeleteExpiredPostRates removes all expired post rate cache entries
+func (r *CacheRepository) DeleteExpiredPostRates(ctx context.Context) (int64, error) {
+	query := "DELETE FROM cached_post_rates WHERE expires_at < ?"
+	result, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), time.Now())
+	if err != nil {
+		return 0, &RepositoryError{Op: "DeleteExpiredPostRates", Entity: "cache entry", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &RepositoryError{Op: "DeleteExpiredPostRates", Entity: "cache entry", Err: err}
+	}
+
+	return rows, nil
+}
+
+// DeleteExpiredActivities removes all expired activity cache entries
+func (r *CacheRepository) DeleteExpiredActivities(ctx context.Context) (int64, error) {
+	query := "DELETE FROM cached_activity WHERE expires_at < ?"
+	result, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), time.Now())
+	if err != nil {
+		return 0, &RepositoryError{Op: "DeleteExpiredActivities", Entity: "cache entry", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &RepositoryError{Op: "DeleteExpiredActivities", Entity: "cache entry", Err: err}
+	}
+
+	return rows, nil
+}
+
+// cacheRowBytes approximates the storage of one cache row beyond its DID: the numeric and timestamp columns,
+// page overhead, and the fetched_at and expires_at index entries
+const cacheRowBytes = 160
+
+// cacheEntryBytes estimates the storage of one cache row, counting its DID twice since the primary key index
+// repeats it
+func cacheEntryBytes(did string) int64 {
+	return cacheRowBytes + 2*int64(len(did))
+}
+
+// cacheMeasureEvery is how many saves trust the running size estimate before the tables are measured again,
+// picking up writes by other processes sharing the database
+const cacheMeasureEvery = 256
+
+// cacheEvictPercent is how far below the size bound, as a percentage of it, eviction shrinks the tables so the
+// next saves don't evict again straight away
+const cacheEvictPercent = 90
+
+// evictChunkSize bounds the DIDs deleted per statement, well under SQLite's variable limit
+const evictChunkSize = 500
+
+// cacheTables are the tables the size limit covers
+var cacheTables = []string{"cached_post_rates", "cached_activity"}
+
+// CacheUsage is the estimated storage used by the cache tables
+type CacheUsage struct {
+	PostRates  int
+	Activities int
+	Bytes      int64
+}
+
+// SetMaxSize bounds the estimated bytes the cache tables may use; saves past the bound evict the oldest entries.
+// Zero removes the bound.
+func (r *CacheRepository) SetMaxSize(bytes int64) {
+	r.maxSize = max(bytes, 0)
+}
+
+// MaxSize returns the bound set by [CacheRepository.SetMaxSize]
+func (r *CacheRepository) MaxSize() int64 {
+	return r.maxSize
+}
+
+// Usage estimates the storage used by the cache tables from their row counts and DID lengths.
+// Each DID counts twice since the primary key index repeats it.
+func (r *CacheRepository) Usage(ctx context.Context) (*CacheUsage, error) {
+	usage := &CacheUsage{}
+	for _, table := range cacheTables {
+		var rows int
+		var didBytes int64
+		query := "SELECT COUNT(*), COALESCE(SUM(LENGTH(actor_did)), 0) FROM " + table
+		if err := r.db.QueryRowContext(ctx, query).Scan(&rows, &didBytes); err != nil {
+			return nil, &RepositoryError{Op: "Usage", Entity: "cache entry", Err: err}
+		}
+
+		if table == "cached_post_rates" {
+			usage.PostRates = rows
+		} else {
+			usage.Activities = rows
+		}
+		usage.Bytes += int64(rows)*cacheRowBytes + 2*didBytes
+	}
+	return usage, nil
+}
+
+// Evict shrinks the cache tables to at most maxBytes, first dropping expired entries and then the least recently
+// fetched ones across both tables. Returns the number of entries removed.
+func (r *CacheRepository) Evict(ctx context.Context, maxBytes int64) (removed int64, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "CacheRepository.Evict", attribute.Int64("cache.max_bytes", maxBytes))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	usage, err := r.Usage(ctx)
+	if err != nil || usage.Bytes <= maxBytes {
+		return 0, err
+	}
+
+	for _, deleteExpired := range []func(context.Context) (int64, error){r.DeleteExpiredPostRates, r.DeleteExpiredActivities} {
+		n, err := deleteExpired(ctx)
+		if err != nil {
+			return removed, err
+		}
+		removed += n
+	}
+
+	if usage, err = r.Usage(ctx); err != nil || usage.Bytes <= maxBytes {
+		return removed, err
+	}
+
+	victims, err := r.oldestEntries(ctx, usage.Bytes-maxBytes)
+	if err != nil {
+		return removed, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return removed, &RepositoryError{Op: "Evict", Entity: "cache entry", Err: err}
+	}
+	defer tx.Rollback()
+
+	var evicted int64
+	for table, dids := range victims {
+		for chunk := range slices.Chunk(dids, evictChunkSize) {
+			args := make([]interface{}, len(chunk))
+			for i, did := range chunk {
+				args[i] = did
+			}
+			query := "DELETE FROM " + table + " WHERE actor_did IN (" + buildPlaceholders(len(chunk)) + ")"
+			result, err := tx.ExecContext(ctx, r.dialect.Rebind(query), args...)
+			if err != nil {
+				return removed, &RepositoryError{Op: "Evict", Entity: "cache entry", Err: err}
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return removed, &RepositoryError{Op: "Evict", Entity: "cache entry", Err: err}
+			}
+			evicted += n
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return removed, &RepositoryError{Op: "Evict", Entity: "cache entry", Err: err}
+	}
+	return removed + evicted, nil
+}
+
+// oldestEntries picks the least recently fetched entries across the cache tables until their estimated size
+// reaches excess, as table name to DIDs
+func (r *CacheRepository) oldestEntries(ctx context.Context, excess int64) (map[string][]string, error) {
+	query := `
+		SELECT 'cached_post_rates', actor_did, fetched_at FROM cached_post_rates
+		UNION ALL
+		SELECT 'cached_activity', actor_did, fetched_at FROM cached_activity
+		ORDER BY fetched_at
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Evict", Entity: "cache entry", Err: err}
+	}
+	defer rows.Close()
+
+	victims := make(map[string][]string)
+	var freed int64
+	for freed < excess && rows.Next() {
+		var table, did string
+		var fetchedAt any // only ordered by; the union loses SQLite's column type
+		if err := rows.Scan(&table, &did, &fetchedAt); err != nil {
+			return nil, &RepositoryError{Op: "Evict", Entity: "cache entry", Err: err}
+		}
+		victims[table] = append(victims[table], did)
+		freed += cacheEntryBytes(did)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &RepositoryError{Op: "Evict", Entity: "cache entry", Err: err}
+	}
+	return victims, nil
+}
+
+// enforceMaxSize evicts after a save of about added bytes once the tables outgrow the bound set by
+// [CacheRepository.SetMaxSize]. Rather than measuring the tables on every save, it keeps a running estimate that
+// only errs high within this process (an upsert counts as a new row) and is measured again every
+// [cacheMeasureEvery] saves.
+func (r *CacheRepository) enforceMaxSize(ctx context.Context, added int64) error {
+	if r.maxSize <= 0 {
+		return nil
+	}
+
+	r.sizeMu.Lock()
+	defer r.sizeMu.Unlock()
+
+	r.saves++
+	if !r.measured || r.saves >= cacheMeasureEvery {
+		usage, err := r.Usage(ctx)
+		if err != nil {
+			return err
+		}
+		r.size, r.measured, r.saves = usage.Bytes, true, 0
+	} else {
+		r.size += added
+	}
+	if r.size <= r.maxSize {
+		return nil
+	}
+
+	target := r.maxSize / 100 * cacheEvictPercent
+	if _, err := r.Evict(ctx, target); err != nil {
+		r.measured = false
+		return err
+	}
+	r.size = target // Evict leaves at most target
+	return nil
+}
+
+// buildPlaceholders generates SQL placeholder string for IN queries.
+//
+// Example: buildPlaceholders(3) returns "?,?,?"
+func buildPlaceholders(count int) string {
+	if count == 0 {
+		return ""
+	}
+
+	placeholders := "?"
+	for i := 1; i < count; i++ {
+		placeholders += ",?"
+	}
+	return placeholders
+}
@@ -1,7 +1,9 @@
-package store
+package bsky
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -45,6 +47,7 @@ func TestCacheRepository_SaveAndGetPostRate(t *testing.T) {
 		PostsPerDay:  2.5,
 		LastPostDate: time.Now().Add(-2 * time.Hour),
 		SampleSize:   30,
+		Daily:        []int{-1, -1, 4, 0, 7, 2, 1},
 	}
 
 	err := repo.SavePostRate(context.Background(), cache)
@@ -77,6 +80,9 @@ func TestCacheRepository_SaveAndGetPostRate(t *testing.T) {
 	if retrieved.SampleSize != 30 {
 		t.Errorf("expected SampleSize 30, got %d", retrieved.SampleSize)
 	}
+	if !slices.Equal(retrieved.Daily, cache.Daily) {
+		t.Errorf("expected Daily %v, got %v", cache.Daily, retrieved.Daily)
+	}
 }
 
 func TestCacheRepository_GetPostRate_NotFound(t *testing.T) {
@@ -514,3 +520,153 @@ func TestCacheRepository_Close(t *testing.T) {
 		t.Errorf("Close failed: %v", err)
 	}
 }
+
+func TestCacheRepository_Evict(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := &CacheRepository{db: db}
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now()
+	var rates []*PostRateCacheModel
+	var activities []*ActivityCacheModel
+	for i := range 4 {
+		rates = append(rates, &PostRateCacheModel{
+			ActorDid:  fmt.Sprintf("did:plc:rate%d", i),
+			FetchedAt: now.Add(time.Duration(-2*i) * time.Hour),
+			ExpiresAt: now.Add(time.Hour),
+		})
+		activities = append(activities, &ActivityCacheModel{
+			ActorDid:  fmt.Sprintf("did:plc:seen%d", i),
+			FetchedAt: now.Add(time.Duration(-2*i-1) * time.Hour),
+			ExpiresAt: now.Add(time.Hour),
+		})
+	}
+	activities[0].ExpiresAt = now.Add(-time.Minute)
+
+	if err := repo.SavePostRates(ctx, rates); err != nil {
+		t.Fatalf("SavePostRates failed: %v", err)
+	}
+	if err := repo.SaveActivities(ctx, activities); err != nil {
+		t.Fatalf("SaveActivities failed: %v", err)
+	}
+
+	usage, err := repo.Usage(ctx)
+	if err != nil {
+		t.Fatalf("Usage failed: %v", err)
+	}
+	rowBytes := int64(cacheRowBytes + 2*len("did:plc:rate0"))
+	if usage.PostRates != 4 || usage.Activities != 4 || usage.Bytes != 8*rowBytes {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+
+	removed, err := repo.Evict(ctx, 5*rowBytes)
+	if err != nil {
+		t.Fatalf("Evict failed: %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("removed %d entries, want the expired one and the two oldest", removed)
+	}
+
+	for did, kept := range map[string]bool{
+		"did:plc:rate0": true, "did:plc:rate1": true, "did:plc:rate2": true,
+		"did:plc:seen1": true, "did:plc:seen2": true,
+		"did:plc:seen0": false, "did:plc:rate3": false, "did:plc:seen3": false,
+	} {
+		var count int
+		if err := db.QueryRow("SELECT (SELECT COUNT(*) FROM cached_post_rates WHERE actor_did = ?) + (SELECT COUNT(*) FROM cached_activity WHERE actor_did = ?)", did, did).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		if (count == 1) != kept {
+			t.Errorf("%s kept = %v, want %v", did, count == 1, kept)
+		}
+	}
+
+	if removed, err := repo.Evict(ctx, 5*rowBytes); err != nil || removed != 0 {
+		t.Errorf("expected no eviction under the limit, removed %d (%v)", removed, err)
+	}
+}
+
+func TestCacheRepository_MaxSize(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := &CacheRepository{db: db}
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	rowBytes := int64(cacheRowBytes + 2*len("did:plc:a"))
+	repo.SetMaxSize(2 * rowBytes)
+
+	now := time.Now()
+	for i, did := range []string{"did:plc:a", "did:plc:b", "did:plc:c"} {
+		cache := &ActivityCacheModel{ActorDid: did, FetchedAt: now.Add(time.Duration(i) * time.Minute)}
+		if err := repo.SaveActivity(ctx, cache); err != nil {
+			t.Fatalf("SaveActivity failed: %v", err)
+		}
+	}
+
+	if cache, err := repo.GetActivity(ctx, "did:plc:a"); err != nil || cache != nil {
+		t.Errorf("expected the oldest entry to be evicted, got %+v (%v)", cache, err)
+	}
+	if cache, err := repo.GetActivity(ctx, "did:plc:c"); err != nil || cache == nil {
+		t.Errorf("expected the newest entry to be kept (%v)", err)
+	}
+}
+
+func TestCacheRepository_MaxSizeEstimate(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := &CacheRepository{db: db}
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	rowBytes := cacheEntryBytes("did:plc:a")
+	repo.SetMaxSize(5 * rowBytes)
+
+	countActivities := func() int {
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM cached_activity").Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if err := repo.SaveActivity(ctx, &ActivityCacheModel{ActorDid: "did:plc:a"}); err != nil {
+		t.Fatalf("SaveActivity failed: %v", err)
+	}
+
+	// Rows written behind the repository's back, as by another process, aren't seen until the next measurement
+	old := time.Now().Add(-time.Hour)
+	for i := range 10 {
+		if _, err := db.Exec("INSERT INTO cached_activity (actor_did, fetched_at, expires_at) VALUES (?, ?, ?)",
+			fmt.Sprintf("did:plc:x%d", i), old, time.Now().Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.SaveActivity(ctx, &ActivityCacheModel{ActorDid: "did:plc:b"}); err != nil {
+		t.Fatalf("SaveActivity failed: %v", err)
+	}
+	if count := countActivities(); count != 12 {
+		t.Fatalf("expected the save to trust its estimate and keep all 12 entries, got %d", count)
+	}
+
+	// Skip ahead to the save that measures the tables again; the estimate alone is still under the bound
+	repo.saves = cacheMeasureEvery - 1
+	if err := repo.SaveActivity(ctx, &ActivityCacheModel{ActorDid: "did:plc:c"}); err != nil {
+		t.Fatalf("SaveActivity failed: %v", err)
+	}
+	if count := countActivities(); count > 4 {
+		t.Errorf("expected the periodic measurement to evict below the bound, got %d entries", count)
+	}
+	if cache, err := repo.GetActivity(ctx, "did:plc:c"); err != nil || cache == nil {
+		t.Errorf("expected the newest entry to be kept (%v)", err)
+	}
+}
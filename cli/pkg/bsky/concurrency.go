@@ -0,0 +1,160 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// InitialConcurrency is the number of requests a new controller allows in flight before it has any feedback
+const InitialConcurrency = 10
+
+// MaxConcurrency is the default ceiling a controller raises its limit to
+const MaxConcurrency = 32
+
+// ConcurrencyOptions tunes a [ConcurrencyController]. Zero values take the defaults.
+type ConcurrencyOptions struct {
+	Initial      int           // starting limit; defaults to 10
+	Min          int           // defaults to 1
+	Max          int           // defaults to 32
+	Backoff      float64       // factor applied to the limit on overload; defaults to 0.5
+	LatencySpike float64       // a response this many times slower than usual counts as overload; defaults to 3
+	Cooldown     time.Duration // minimum time between decreases, so one burst of failures backs off once; defaults to 1s
+}
+
+// latencySmoothing weighs each new latency sample in the moving average
+const latencySmoothing = 0.2
+
+// latencyWarmup is the number of samples taken before latency spikes count as overload
+const latencyWarmup = 5
+
+// ConcurrencyController limits in-flight API requests with additive increase, multiplicative decrease (AIMD).
+// Each fast, successful response raises the limit by 1/limit, about one slot per round of requests; a 429, 5xx,
+// transport error, or latency spike multiplies it by the backoff factor. One controller is shared by every batch
+// helper of a service, so concurrent batches split one budget.
+type ConcurrencyController struct {
+	opts         ConcurrencyOptions
+	mu           sync.Mutex
+	limit        float64
+	inFlight     int
+	latency      time.Duration // moving average of successful response latency
+	samples      int
+	lastDecrease time.Time
+	wake         chan struct{} // closed and replaced whenever a slot may have opened
+}
+
+// NewConcurrencyController creates a controller starting at opts.Initial
+func NewConcurrencyController(opts ConcurrencyOptions) *ConcurrencyController {
+	if opts.Min <= 0 {
+		opts.Min = 1
+	}
+	if opts.Max <= 0 {
+		opts.Max = MaxConcurrency
+	}
+	opts.Max = max(opts.Max, opts.Min)
+	if opts.Initial <= 0 {
+		opts.Initial = InitialConcurrency
+	}
+	opts.Initial = min(max(opts.Initial, opts.Min), opts.Max)
+	if opts.Backoff <= 0 || opts.Backoff >= 1 {
+		opts.Backoff = 0.5
+	}
+	if opts.LatencySpike <= 1 {
+		opts.LatencySpike = 3
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = time.Second
+	}
+	return &ConcurrencyController{opts: opts, limit: float64(opts.Initial), wake: make(chan struct{})}
+}
+
+// Limit returns the current number of requests allowed in flight
+func (c *ConcurrencyController) Limit() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return int(c.limit)
+}
+
+// Acquire waits for a free slot or for ctx to be done
+func (c *ConcurrencyController) Acquire(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+		if c.inFlight < int(c.limit) {
+			c.inFlight++
+			c.mu.Unlock()
+			return nil
+		}
+		wake := c.wake
+		c.mu.Unlock()
+
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release frees a slot taken by [ConcurrencyController.Acquire]
+func (c *ConcurrencyController) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+	c.broadcast()
+}
+
+// Observe adjusts the limit from one response's status and latency, or the error that replaced it.
+// Requests canceled by their caller say nothing about the server and are ignored.
+func (c *ConcurrencyController) Observe(status int, latency time.Duration, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	overloaded := err != nil || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+	if !overloaded {
+		spike := c.samples >= latencyWarmup && float64(latency) > c.opts.LatencySpike*float64(c.latency)
+		c.recordLatency(latency)
+		overloaded = spike
+	}
+
+	if overloaded {
+		c.decrease(time.Now())
+		return
+	}
+
+	before := int(c.limit)
+	c.limit = min(c.limit+1/c.limit, float64(c.opts.Max))
+	if int(c.limit) > before {
+		c.broadcast()
+	}
+}
+
+// recordLatency folds a sample into the moving average
+func (c *ConcurrencyController) recordLatency(latency time.Duration) {
+	if c.samples == 0 {
+		c.latency = latency
+	} else {
+		c.latency = time.Duration((1-latencySmoothing)*float64(c.latency) + latencySmoothing*float64(latency))
+	}
+	c.samples++
+}
+
+// decrease backs the limit off unless it already did within the cooldown
+func (c *ConcurrencyController) decrease(now time.Time) {
+	if now.Sub(c.lastDecrease) < c.opts.Cooldown {
+		return
+	}
+	c.limit = max(c.limit*c.opts.Backoff, float64(c.opts.Min))
+	c.lastDecrease = now
+}
+
+// broadcast wakes every waiter to recheck for a free slot; callers hold mu
+func (c *ConcurrencyController) broadcast() {
+	close(c.wake)
+	c.wake = make(chan struct{})
+}
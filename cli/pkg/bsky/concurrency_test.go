@@ -0,0 +1,124 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyController(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		c := NewConcurrencyController(ConcurrencyOptions{})
+		if got := c.Limit(); got != InitialConcurrency {
+			t.Errorf("limit = %d, want %d", got, InitialConcurrency)
+		}
+	})
+
+	t.Run("AdditiveIncrease", func(t *testing.T) {
+		c := NewConcurrencyController(ConcurrencyOptions{Initial: 4, Max: 6})
+		for range 5 {
+			c.Observe(http.StatusOK, 10*time.Millisecond, nil)
+		}
+		if got := c.Limit(); got != 5 {
+			t.Errorf("limit after a round of successes = %d, want 5", got)
+		}
+		for range 100 {
+			c.Observe(http.StatusOK, 10*time.Millisecond, nil)
+		}
+		if got := c.Limit(); got != 6 {
+			t.Errorf("limit = %d, want the max of 6", got)
+		}
+	})
+
+	t.Run("MultiplicativeDecrease", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			status int
+			err    error
+		}{
+			{"TooManyRequests", http.StatusTooManyRequests, nil},
+			{"ServerError", http.StatusBadGateway, nil},
+			{"TransportError", 0, errors.New("connection reset")},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				c := NewConcurrencyController(ConcurrencyOptions{Initial: 16})
+				c.Observe(tc.status, 10*time.Millisecond, tc.err)
+				if got := c.Limit(); got != 8 {
+					t.Errorf("limit = %d, want 8", got)
+				}
+			})
+		}
+	})
+
+	t.Run("ClientErrorsDoNotBackOff", func(t *testing.T) {
+		c := NewConcurrencyController(ConcurrencyOptions{Initial: 16})
+		c.Observe(http.StatusNotFound, 10*time.Millisecond, nil)
+		c.Observe(0, 10*time.Millisecond, context.Canceled)
+		if got := c.Limit(); got != 16 {
+			t.Errorf("limit = %d, want 16", got)
+		}
+	})
+
+	t.Run("Cooldown", func(t *testing.T) {
+		c := NewConcurrencyController(ConcurrencyOptions{Initial: 16, Cooldown: time.Hour})
+		for range 5 {
+			c.Observe(http.StatusTooManyRequests, 10*time.Millisecond, nil)
+		}
+		if got := c.Limit(); got != 8 {
+			t.Errorf("limit = %d, want a single backoff to 8", got)
+		}
+	})
+
+	t.Run("Floor", func(t *testing.T) {
+		c := NewConcurrencyController(ConcurrencyOptions{Initial: 4, Min: 2, Cooldown: time.Nanosecond})
+		for range 5 {
+			time.Sleep(time.Millisecond)
+			c.Observe(http.StatusServiceUnavailable, 10*time.Millisecond, nil)
+		}
+		if got := c.Limit(); got != 2 {
+			t.Errorf("limit = %d, want the min of 2", got)
+		}
+	})
+
+	t.Run("LatencySpike", func(t *testing.T) {
+		c := NewConcurrencyController(ConcurrencyOptions{Initial: 16, Max: 16})
+		for range latencyWarmup {
+			c.Observe(http.StatusOK, 100*time.Millisecond, nil)
+		}
+		c.Observe(http.StatusOK, 250*time.Millisecond, nil)
+		if got := c.Limit(); got != 16 {
+			t.Fatalf("limit = %d after a modest slowdown, want 16", got)
+		}
+		c.Observe(http.StatusOK, time.Second, nil)
+		if got := c.Limit(); got != 8 {
+			t.Errorf("limit = %d after a spike, want 8", got)
+		}
+	})
+
+	t.Run("Acquire", func(t *testing.T) {
+		c := NewConcurrencyController(ConcurrencyOptions{Initial: 1})
+		if err := c.Acquire(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := c.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the second acquire to wait until the deadline, got %v", err)
+		}
+
+		acquired := make(chan error, 1)
+		go func() { acquired <- c.Acquire(context.Background()) }()
+		c.Release()
+		select {
+		case err := <-acquired:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("release didn't wake the waiting acquire")
+		}
+	})
+}
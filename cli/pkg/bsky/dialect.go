@@ -0,0 +1,88 @@
+package bsky
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Dialect identifies the SQL database a repository talks to.
+// Queries are written with SQLite-style ? placeholders and rewritten per dialect by [Dialect.Rebind].
+type Dialect string
+
+const (
+	DialectSQLite   Dialect = "sqlite"
+	DialectPostgres Dialect = "postgres"
+)
+
+// ParseDialect converts a configured driver name into a [Dialect]. An empty name selects SQLite.
+func ParseDialect(name string) (Dialect, error) {
+	switch strings.ToLower(name) {
+	case "", "sqlite", "sqlite3":
+		return DialectSQLite, nil
+	case "postgres", "postgresql":
+		return DialectPostgres, nil
+	default:
+		return "", fmt.Errorf("unsupported database driver: %s", name)
+	}
+}
+
+// DriverName returns the database/sql driver registered for the dialect
+func (d Dialect) DriverName() string {
+	if d == DialectPostgres {
+		return "postgres"
+	}
+	return "sqlite3"
+}
+
+// Rebind rewrites ? placeholders into the dialect's bind syntax ($1, $2, ... for Postgres).
+// Placeholders inside quoted literals are left untouched.
+func (d Dialect) Rebind(query string) string {
+	if d != DialectPostgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	n := 0
+	var quote rune
+	for _, c := range query {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// migrationsDir returns the embedded directory holding the dialect's migrations
+func (d Dialect) migrationsDir() string {
+	if d == DialectPostgres {
+		return "migrations/postgres"
+	}
+	return "migrations"
+}
+
+// openDB opens a connection pool for the dialect
+func openDB(dialect Dialect, dsn string) (*sql.DB, error) {
+	if dsn == "" {
+		return nil, errors.New("database connection string is empty")
+	}
+	return sql.Open(dialect.DriverName(), dsn)
+}
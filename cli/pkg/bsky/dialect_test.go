@@ -0,0 +1,62 @@
+package bsky
+
+import "testing"
+
+func TestDialect_Rebind(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		want    string
+	}{
+		{"sqlite unchanged", DialectSQLite, "SELECT * FROM posts WHERE id = ? AND feed_id = ?", "SELECT * FROM posts WHERE id = ? AND feed_id = ?"},
+		{"zero value unchanged", "", "DELETE FROM posts WHERE id = ?", "DELETE FROM posts WHERE id = ?"},
+		{"postgres numbered", DialectPostgres, "SELECT * FROM posts WHERE id = ? AND feed_id = ?", "SELECT * FROM posts WHERE id = $1 AND feed_id = $2"},
+		{"postgres IN list", DialectPostgres, "WHERE actor_did IN (?,?,?) AND expires_at > ?", "WHERE actor_did IN ($1,$2,$3) AND expires_at > $4"},
+		{"postgres quoted literal", DialectPostgres, "SELECT '?' AS q, text FROM posts WHERE id = ?", "SELECT '?' AS q, text FROM posts WHERE id = $1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dialect.Rebind(tt.query); got != tt.want {
+				t.Errorf("Rebind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDialect(t *testing.T) {
+	for _, name := range []string{"", "sqlite", "SQLite3"} {
+		if d, err := ParseDialect(name); err != nil || d != DialectSQLite {
+			t.Errorf("ParseDialect(%q) = %q, %v; want sqlite", name, d, err)
+		}
+	}
+	for _, name := range []string{"postgres", "PostgreSQL"} {
+		if d, err := ParseDialect(name); err != nil || d != DialectPostgres {
+			t.Errorf("ParseDialect(%q) = %q, %v; want postgres", name, d, err)
+		}
+	}
+	if _, err := ParseDialect("mysql"); err == nil {
+		t.Error("expected error for unsupported driver")
+	}
+}
+
+func TestLoadMigrations_Postgres(t *testing.T) {
+	up, err := loadMigrations(DialectPostgres, "up")
+	if err != nil {
+		t.Fatalf("loadMigrations failed: %v", err)
+	}
+	down, err := loadMigrations(DialectPostgres, "down")
+	if err != nil {
+		t.Fatalf("loadMigrations failed: %v", err)
+	}
+	if len(up) == 0 || len(up) != len(down) {
+		t.Errorf("expected matching up/down postgres migrations, got %d up and %d down", len(up), len(down))
+	}
+}
+
+func TestNewPostgresSnapshotRepository_EmptyDSN(t *testing.T) {
+	if _, err := NewPostgresSnapshotRepository(""); err == nil {
+		t.Error("expected error for empty connection string")
+	}
+}
@@ -0,0 +1,17 @@
+// Package bsky is skycli's Bluesky client and local storage, usable from other Go programs.
+//
+// The package has three layers:
+//
+//   - [BlueskyService] talks to a PDS or AppView over XRPC, with per-endpoint timeouts, token refresh, and rate
+//     limit handling. Commands depend on the narrow fetcher interfaces it implements ([ProfileFetcher],
+//     [FollowerFetcher], [RecordFetcher], and so on), which are easy to fake in tests.
+//   - [Paginator] walks cursor-paginated endpoints; the *Pages functions such as [FollowerPages] and
+//     [RecordPages] adapt service calls to it.
+//   - Repositories ([PostRepository], [SnapshotRepository], [SessionRepository], ...) persist models such as
+//     [PostModel] and [SnapshotModel] to the SQLite cache shared with skycli, or to Postgres where a Postgres
+//     constructor exists. [RunMigrations] brings a database up to date.
+//
+// Exported names follow semantic versioning with the rest of the module: they are not removed or changed
+// incompatibly outside a major release. Unexported helpers and the database schema may change at any time;
+// use the repositories rather than querying tables directly.
+package bsky
@@ -0,0 +1,160 @@
+package bsky
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// dpopSigner creates DPoP proofs (RFC 9449) binding OAuth tokens to one P-256 key, and keeps the latest nonce each
+// server asked for
+type dpopSigner struct {
+	key    *ecdsa.PrivateKey
+	mu     sync.Mutex
+	nonces map[string]string // by origin
+}
+
+// newDPoPSigner returns a signer for key, or for a fresh key when key is nil
+func newDPoPSigner(key *ecdsa.PrivateKey) (*dpopSigner, error) {
+	if key == nil {
+		generated, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, &OAuthError{Op: "GenerateKey", Err: err}
+		}
+		key = generated
+	}
+	return &dpopSigner{key: key, nonces: make(map[string]string)}, nil
+}
+
+// MarshalDPoPKey encodes a DPoP private key as base64 SEC 1 DER, the form kept in [OAuthSession]
+func MarshalDPoPKey(key *ecdsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", &OAuthError{Op: "MarshalDPoPKey", Err: err}
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+// ParseDPoPKey decodes a key encoded by [MarshalDPoPKey]
+func ParseDPoPKey(encoded string) (*ecdsa.PrivateKey, error) {
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, &OAuthError{Op: "ParseDPoPKey", Err: err}
+	}
+	key, err := x509.ParseECPrivateKey(der)
+	if err != nil {
+		return nil, &OAuthError{Op: "ParseDPoPKey", Err: err}
+	}
+	if key.Curve != elliptic.P256() {
+		return nil, &OAuthError{Op: "ParseDPoPKey", Err: errors.New("DPoP key must be P-256")}
+	}
+	return key, nil
+}
+
+// proof returns a DPoP proof for a request to target. accessToken, when set, is bound to the proof through its
+// hash, as resource servers require.
+func (d *dpopSigner) proof(method, target, accessToken string) (string, error) {
+	htu, err := url.Parse(target)
+	if err != nil {
+		return "", &OAuthError{Op: "DPoPProof", Err: err}
+	}
+	origin := htu.Scheme + "://" + htu.Host
+	htu.RawQuery, htu.Fragment = "", ""
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", &OAuthError{Op: "DPoPProof", Err: err}
+	}
+
+	claims := map[string]any{
+		"jti": base64.RawURLEncoding.EncodeToString(jti),
+		"htm": method,
+		"htu": htu.String(),
+		"iat": time.Now().Unix(),
+	}
+	if nonce := d.nonce(origin); nonce != "" {
+		claims["nonce"] = nonce
+	}
+	if accessToken != "" {
+		sum := sha256.Sum256([]byte(accessToken))
+		claims["ath"] = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+
+	jwk, err := d.publicJWK()
+	if err != nil {
+		return "", err
+	}
+	header := map[string]any{"typ": "dpop+jwt", "alg": "ES256", "jwk": jwk}
+	return d.sign(header, claims)
+}
+
+// publicJWK returns the signer's public key as a JWK
+func (d *dpopSigner) publicJWK() (map[string]string, error) {
+	public, err := d.key.PublicKey.ECDH()
+	if err != nil {
+		return nil, &OAuthError{Op: "DPoPProof", Err: err}
+	}
+	point := public.Bytes() // uncompressed: 0x04 || x || y
+	return map[string]string{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(point[1:33]),
+		"y":   base64.RawURLEncoding.EncodeToString(point[33:]),
+	}, nil
+}
+
+// sign encodes header and claims as a compact ES256 JWS
+func (d *dpopSigner) sign(header, claims map[string]any) (string, error) {
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", &OAuthError{Op: "DPoPProof", Err: err}
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", &OAuthError{Op: "DPoPProof", Err: err}
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(input))
+	r, s, err := ecdsa.Sign(rand.Reader, d.key, digest[:])
+	if err != nil {
+		return "", &OAuthError{Op: "DPoPProof", Err: err}
+	}
+
+	// JWS uses the fixed-width r || s form rather than ASN.1
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// nonce returns the last nonce the server at origin sent
+func (d *dpopSigner) nonce(origin string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.nonces[origin]
+}
+
+// saveNonce records a DPoP-Nonce header sent by the server at target, reporting whether it changed
+func (d *dpopSigner) saveNonce(target, nonce string) bool {
+	if nonce == "" {
+		return false
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	origin := u.Scheme + "://" + u.Host
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	changed := d.nonces[origin] != nonce
+	d.nonces[origin] = nonce
+	return changed
+}
@@ -0,0 +1,153 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// DraftModel is an unpublished post saved from the compose screen
+type DraftModel struct {
+	ID        string
+	Text      string
+	Images    []DraftImage
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DraftImage is an image attached to a draft. Only the path is kept; the file is read again when the post is published.
+type DraftImage struct {
+	Path string `json:"path"`
+	Alt  string `json:"alt,omitempty"`
+}
+
+// DraftRepository persists post drafts in the local SQLite cache database
+type DraftRepository struct {
+	db *sql.DB
+}
+
+// NewDraftRepository creates a new draft repository with SQLite backend
+func NewDraftRepository() (*DraftRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DraftRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *DraftRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *DraftRepository) Close() error {
+	return r.db.Close()
+}
+
+// Save stores a draft, giving it an ID first if it is new
+func (r *DraftRepository) Save(ctx context.Context, draft *DraftModel) error {
+	images, err := json.Marshal(draft.Images)
+	if err != nil {
+		return &RepositoryError{Op: "Save", Entity: "draft", ID: draft.ID, Err: err}
+	}
+	if draft.Images == nil {
+		images = []byte("[]")
+	}
+
+	now := time.Now()
+	if draft.ID == "" {
+		draft.ID = GenerateUUID()
+		draft.CreatedAt = now
+	}
+	draft.UpdatedAt = now
+
+	query := `
+		INSERT INTO drafts (id, text, images, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET text = excluded.text, images = excluded.images, updated_at = excluded.updated_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, draft.ID, draft.Text, string(images), draft.CreatedAt, draft.UpdatedAt); err != nil {
+		return &RepositoryError{Op: "Save", Entity: "draft", ID: draft.ID, Err: err}
+	}
+	return nil
+}
+
+// Get retrieves a draft by ID
+func (r *DraftRepository) Get(ctx context.Context, id string) (*DraftModel, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT id, text, images, created_at, updated_at FROM drafts WHERE id = ?", id)
+	draft, err := scanDraft(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &RepositoryError{Op: "Get", Entity: "draft", ID: id, Err: fmt.Errorf("draft %w", ErrNotFound)}
+	}
+	if err != nil {
+		return nil, &RepositoryError{Op: "Get", Entity: "draft", ID: id, Err: err}
+	}
+	return draft, nil
+}
+
+// List retrieves every draft, most recently saved first
+func (r *DraftRepository) List(ctx context.Context) ([]*DraftModel, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, text, images, created_at, updated_at FROM drafts ORDER BY updated_at DESC, id")
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "draft", Err: err}
+	}
+	defer rows.Close()
+
+	var drafts []*DraftModel
+	for rows.Next() {
+		draft, err := scanDraft(rows)
+		if err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "draft", Err: err}
+		}
+		drafts = append(drafts, draft)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "draft", Err: err}
+	}
+	return drafts, nil
+}
+
+// Delete removes a draft
+func (r *DraftRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM drafts WHERE id = ?", id)
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "draft", ID: id, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "draft", ID: id, Err: err}
+	}
+	if rows == 0 {
+		return &RepositoryError{Op: "Delete", Entity: "draft", ID: id, Err: fmt.Errorf("draft %w", ErrNotFound)}
+	}
+	return nil
+}
+
+// scanDraft reads one drafts row
+func scanDraft(row interface{ Scan(...any) error }) (*DraftModel, error) {
+	var draft DraftModel
+	var images string
+	if err := row.Scan(&draft.ID, &draft.Text, &images, &draft.CreatedAt, &draft.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(images), &draft.Images); err != nil {
+		return nil, fmt.Errorf("draft %s has unreadable images: %w", draft.ID, err)
+	}
+	return &draft, nil
+}
@@ -0,0 +1,65 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestDraftRepository(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &DraftRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if _, err := repo.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of a missing draft: got %v, want ErrNotFound", err)
+	}
+
+	first := &DraftModel{Text: "half a thought"}
+	if err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if first.ID == "" {
+		t.Fatal("Save should give a new draft an ID")
+	}
+
+	second := &DraftModel{Text: "look at this", Images: []DraftImage{{Path: "/tmp/cat.jpg", Alt: "a cat"}}}
+	if err := repo.Save(ctx, second); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	first.Text = "a whole thought"
+	if err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	drafts, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(drafts) != 2 || drafts[0].ID != first.ID || drafts[0].Text != "a whole thought" || len(drafts[0].Images) != 0 {
+		t.Errorf("expected the updated draft first, got %+v", drafts)
+	}
+
+	got, err := repo.Get(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(got.Images) != 1 || got.Images[0] != (DraftImage{Path: "/tmp/cat.jpg", Alt: "a cat"}) {
+		t.Errorf("images = %+v", got.Images)
+	}
+
+	if err := repo.Delete(ctx, second.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Delete(ctx, second.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete: got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,101 @@
+package bsky
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ErrNotFound is wrapped by repository errors for records that don't exist
+var ErrNotFound = errors.New("not found")
+
+// ErrorCode classifies a repository failure for callers and machine-readable output
+type ErrorCode string
+
+const (
+	CodeNotFound      ErrorCode = "not_found"
+	CodeAlreadyExists ErrorCode = "already_exists"
+	CodeConstraint    ErrorCode = "constraint_violation"
+	CodeCorrupt       ErrorCode = "database_corrupt"
+	CodeBusy          ErrorCode = "database_busy"
+	CodeDatabase      ErrorCode = "database_error"
+)
+
+// SQLSTATE values repository errors are reported with; SQLite result codes are mapped onto the same values
+const (
+	StateNoData           = "02000"
+	StateUniqueViolation  = "23505"
+	StateForeignKey       = "23503"
+	StateNotNull          = "23502"
+	StateCheck            = "23514"
+	StateIntegrity        = "23000"
+	StateLockNotAvailable = "55P03"
+	StateIOError          = "58030"
+	StateDataCorrupted    = "XX001"
+)
+
+// RepositoryError represents an error that occurred during repository operations
+type RepositoryError struct {
+	Op     string
+	Entity string // kind of record, e.g. "post" or "snapshot"
+	ID     string // ID of the record the operation concerned, when there was one
+	Err    error
+}
+
+func (e *RepositoryError) Error() string {
+	return "repository." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *RepositoryError) Unwrap() error {
+	return e.Err
+}
+
+// SQLState returns the SQLSTATE of the underlying failure: the server's code for Postgres, the equivalent of
+// SQLite's result code, [StateNoData] for missing records, or "" when the failure didn't come from the database
+func (e *RepositoryError) SQLState() string {
+	return sqlState(e.Err)
+}
+
+// Code classifies the failure from its SQLSTATE
+func (e *RepositoryError) Code() ErrorCode {
+	state := e.SQLState()
+	switch {
+	case state == StateNoData:
+		return CodeNotFound
+	case state == StateUniqueViolation:
+		return CodeAlreadyExists
+	case strings.HasPrefix(state, "23"):
+		return CodeConstraint
+	case state == StateDataCorrupted || state == "XX002":
+		return CodeCorrupt
+	case state == StateLockNotAvailable || state == "40P01" || state == "40001":
+		return CodeBusy
+	default:
+		return CodeDatabase
+	}
+}
+
+// ErrorCodeOf returns the code of the repository error in err's chain, or "" when there is none
+func ErrorCodeOf(err error) ErrorCode {
+	var repoErr *RepositoryError
+	if errors.As(err, &repoErr) {
+		return repoErr.Code()
+	}
+	return ""
+}
+
+// sqlState maps a database failure to its SQLSTATE
+func sqlState(err error) string {
+	if errors.Is(err, ErrNotFound) || errors.Is(err, sql.ErrNoRows) {
+		return StateNoData
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+
+	return sqliteState(err)
+}
@@ -0,0 +1,38 @@
+//go:build cgo
+
+package bsky
+
+import (
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteState maps a SQLite result code to its SQLSTATE, or "" when err isn't a SQLite failure
+func sqliteState(err error) string {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return ""
+	}
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+		return StateUniqueViolation
+	case sqlite3.ErrConstraintForeignKey:
+		return StateForeignKey
+	case sqlite3.ErrConstraintNotNull:
+		return StateNotNull
+	case sqlite3.ErrConstraintCheck:
+		return StateCheck
+	}
+	switch sqliteErr.Code {
+	case sqlite3.ErrConstraint:
+		return StateIntegrity
+	case sqlite3.ErrCorrupt, sqlite3.ErrNotADB:
+		return StateDataCorrupted
+	case sqlite3.ErrBusy, sqlite3.ErrLocked:
+		return StateLockNotAvailable
+	case sqlite3.ErrIoErr, sqlite3.ErrCantOpen, sqlite3.ErrFull:
+		return StateIOError
+	}
+	return ""
+}
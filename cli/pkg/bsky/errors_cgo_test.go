@@ -0,0 +1,36 @@
+//go:build cgo
+
+package bsky
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestSQLiteState(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"unique", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}, StateUniqueViolation},
+		{"not null", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintNotNull}, StateNotNull},
+		{"other constraint", sqlite3.Error{Code: sqlite3.ErrConstraint}, StateIntegrity},
+		{"corrupt", sqlite3.Error{Code: sqlite3.ErrCorrupt}, StateDataCorrupted},
+		{"busy", sqlite3.Error{Code: sqlite3.ErrBusy}, StateLockNotAvailable},
+		{"disk full", sqlite3.Error{Code: sqlite3.ErrFull}, StateIOError},
+		{"not sqlite", errors.New("boom"), ""},
+	}
+	for _, tt := range tests {
+		if got := sqliteState(tt.err); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	err := &RepositoryError{Op: "Save", Entity: "post", Err: sqlite3.Error{Code: sqlite3.ErrBusy}}
+	if err.Code() != CodeBusy {
+		t.Errorf("expected a busy SQLite error to be %s, got %s", CodeBusy, err.Code())
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !cgo
+
+package bsky
+
+// sqliteState has nothing to map without cgo: the SQLite driver is then a stub that fails to open any database,
+// and its result codes aren't defined
+func sqliteState(err error) string {
+	return ""
+}
@@ -0,0 +1,56 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestRepositoryError_Code(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	_, err := repo.Get(context.Background(), "missing")
+	var repoErr *RepositoryError
+	if !errors.As(err, &repoErr) || repoErr.Entity != "post" || repoErr.ID != "missing" || repoErr.SQLState() != StateNoData || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("unexpected not found error %#v", err)
+	}
+	if ErrorCodeOf(err) != CodeNotFound {
+		t.Errorf("expected %s, got %s", CodeNotFound, ErrorCodeOf(err))
+	}
+
+	if _, err := db.Exec(`CREATE TABLE pairs (k TEXT PRIMARY KEY)`); err != nil {
+		t.Fatal(err)
+	}
+	db.Exec(`INSERT INTO pairs (k) VALUES ('a')`)
+	_, dupErr := db.Exec(`INSERT INTO pairs (k) VALUES ('a')`)
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"sqlite duplicate", dupErr, CodeAlreadyExists},
+		{"postgres duplicate", &pq.Error{Code: "23505"}, CodeAlreadyExists},
+		{"postgres foreign key", &pq.Error{Code: "23503"}, CodeConstraint},
+		{"other", errors.New("boom"), CodeDatabase},
+	}
+	for _, tt := range tests {
+		err := &RepositoryError{Op: "Save", Entity: "post", Err: tt.err}
+		if got := err.Code(); got != tt.want {
+			t.Errorf("%s: got %s, want %s (state %q)", tt.name, got, tt.want, err.SQLState())
+		}
+	}
+
+	if ErrorCodeOf(errors.New("not a repository error")) != "" {
+		t.Error("expected no code outside repository errors")
+	}
+}
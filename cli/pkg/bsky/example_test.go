@@ -0,0 +1,40 @@
+package bsky_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+)
+
+func ExampleBlueskyService() {
+	ctx := context.Background()
+	service := bsky.NewBlueskyService("https://bsky.social")
+	if err := service.Authenticate(ctx, map[string]string{"identifier": "you.bsky.social", "password": "app-password"}); err != nil {
+		log.Fatal(err)
+	}
+
+	profile, err := service.GetProfile(ctx, "bsky.app")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(profile.Handle, profile.FollowersCount)
+}
+
+func ExamplePaginator() {
+	ctx := context.Background()
+	service := bsky.NewBlueskyService("")
+	service.SetTokens("access-jwt", "refresh-jwt")
+
+	followers := bsky.NewPaginator(bsky.FollowerPages(service, "bsky.app"), bsky.PaginatorOptions{MaxItems: 500})
+	for followers.HasNext() {
+		page, err := followers.Next(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, follower := range page {
+			fmt.Println(follower.Handle)
+		}
+	}
+}
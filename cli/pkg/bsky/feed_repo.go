@@ -0,0 +1,376 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// FeedRepository implements Repository for FeedModel using SQLite
+type FeedRepository struct {
+	db *sql.DB
+}
+
+// NewFeedRepository creates a new feed repository with SQLite backend
+func NewFeedRepository() (*FeedRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FeedRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *FeedRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *FeedRepository) Close() error {
+	return r.db.Close()
+}
+
+// Get retrieves a feed by ID
+func (r *FeedRepository) Get(ctx context.Context, id string) (Model, error) {
+	query := `
+		SELECT id, created_at, updated_at, name, source, params, is_local
+		FROM feeds
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	var feed FeedModel
+	var paramsJSON string
+	var feedID string
+	var createdAt, updatedAt time.Time
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&feedID,
+		&createdAt,
+		&updatedAt,
+		&feed.Name,
+		&feed.Source,
+		&paramsJSON,
+		&feed.IsLocal,
+	)
+
+	feed.SetID(feedID)
+	feed.SetCreatedAt(createdAt)
+	feed.SetUpdatedAt(updatedAt)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &RepositoryError{Op: "Get", Entity: "feed", ID: id, Err: fmt.Errorf("feed %w", ErrNotFound)}
+		}
+		return nil, &RepositoryError{Op: "Get", Entity: "feed", ID: id, Err: err}
+	}
+
+	if err := json.Unmarshal([]byte(paramsJSON), &feed.Params); err != nil {
+		return nil, &RepositoryError{Op: "UnmarshalParams", Entity: "feed", ID: id, Err: err}
+	}
+
+	return &feed, nil
+}
+
+// GetIncludingDeleted retrieves a feed by ID whether or not it is in the trash; DeletedAt is set for a trashed feed
+func (r *FeedRepository) GetIncludingDeleted(ctx context.Context, id string) (*FeedModel, error) {
+	query := `
+		SELECT id, created_at, updated_at, name, source, params, is_local, deleted_at
+		FROM feeds
+		WHERE id = ?
+	`
+
+	var feed FeedModel
+	var paramsJSON string
+	var feedID string
+	var createdAt, updatedAt time.Time
+	var deletedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&feedID,
+		&createdAt,
+		&updatedAt,
+		&feed.Name,
+		&feed.Source,
+		&paramsJSON,
+		&feed.IsLocal,
+		&deletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &RepositoryError{Op: "GetIncludingDeleted", Entity: "feed", ID: id, Err: fmt.Errorf("feed %w", ErrNotFound)}
+		}
+		return nil, &RepositoryError{Op: "GetIncludingDeleted", Entity: "feed", ID: id, Err: err}
+	}
+
+	feed.SetID(feedID)
+	feed.SetCreatedAt(createdAt)
+	feed.SetUpdatedAt(updatedAt)
+	feed.DeletedAt = deletedAt.Time
+
+	if err := json.Unmarshal([]byte(paramsJSON), &feed.Params); err != nil {
+		return nil, &RepositoryError{Op: "UnmarshalParams", Entity: "feed", ID: id, Err: err}
+	}
+
+	return &feed, nil
+}
+
+// List retrieves all feeds not in the trash
+func (r *FeedRepository) List(ctx context.Context) ([]Model, error) {
+	query := `
+		SELECT id, created_at, updated_at, name, source, params, is_local
+		FROM feeds
+		WHERE deleted_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "feed", Err: err}
+	}
+	defer rows.Close()
+
+	var feeds []Model
+	for rows.Next() {
+		var feed FeedModel
+		var paramsJSON string
+		var feedID string
+		var createdAt, updatedAt time.Time
+
+		err := rows.Scan(
+			&feedID,
+			&createdAt,
+			&updatedAt,
+			&feed.Name,
+			&feed.Source,
+			&paramsJSON,
+			&feed.IsLocal,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "feed", Err: err}
+		}
+
+		feed.SetID(feedID)
+		feed.SetCreatedAt(createdAt)
+		feed.SetUpdatedAt(updatedAt)
+
+		if err := json.Unmarshal([]byte(paramsJSON), &feed.Params); err != nil {
+			return nil, &RepositoryError{Op: "UnmarshalParams", Entity: "feed", Err: err}
+		}
+
+		feeds = append(feeds, &feed)
+	}
+
+	return feeds, rows.Err()
+}
+
+// Save creates or updates a feed
+func (r *FeedRepository) Save(ctx context.Context, model Model) error {
+	feed, ok := model.(*FeedModel)
+	if !ok {
+		return &RepositoryError{Op: "Save", Entity: "feed", Err: errors.New("invalid model type: expected *FeedModel")}
+	}
+
+	if feed.ID() == "" {
+		feed.SetID(GenerateUUID())
+		feed.SetCreatedAt(time.Now())
+	}
+	feed.SetUpdatedAt(time.Now())
+
+	paramsJSON, err := json.Marshal(feed.Params)
+	if err != nil {
+		return &RepositoryError{Op: "MarshalParams", Entity: "feed", Err: err}
+	}
+
+	query := `
+		INSERT INTO feeds (id, created_at, updated_at, name, source, params, is_local)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			updated_at = excluded.updated_at,
+			name = excluded.name,
+			source = excluded.source,
+			params = excluded.params,
+			is_local = excluded.is_local
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		feed.ID(),
+		feed.CreatedAt(),
+		feed.UpdatedAt(),
+		feed.Name,
+		feed.Source,
+		string(paramsJSON),
+		feed.IsLocal,
+	)
+
+	if err != nil {
+		return &RepositoryError{Op: "Save", Entity: "feed", ID: feed.ID(), Err: err}
+	}
+
+	return nil
+}
+
+// Restore writes a feed exactly as given, keeping its ID and timestamps (used when syncing state between machines).
+// A feed in the trash stays there; see [FeedRepository.Undelete].
+func (r *FeedRepository) Restore(ctx context.Context, feed *FeedModel) error {
+	if feed.ID() == "" {
+		return &RepositoryError{Op: "Restore", Entity: "feed", ID: feed.ID(), Err: errors.New("feed ID is required")}
+	}
+
+	paramsJSON, err := json.Marshal(feed.Params)
+	if err != nil {
+		return &RepositoryError{Op: "MarshalParams", Entity: "feed", ID: feed.ID(), Err: err}
+	}
+
+	query := `
+		INSERT INTO feeds (id, created_at, updated_at, name, source, params, is_local)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			updated_at = excluded.updated_at,
+			name = excluded.name,
+			source = excluded.source,
+			params = excluded.params,
+			is_local = excluded.is_local
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		feed.ID(),
+		feed.CreatedAt(),
+		feed.UpdatedAt(),
+		feed.Name,
+		feed.Source,
+		string(paramsJSON),
+		feed.IsLocal,
+	)
+	if err != nil {
+		return &RepositoryError{Op: "Restore", Entity: "feed", ID: feed.ID(), Err: err}
+	}
+
+	return nil
+}
+
+// Delete moves a feed to the trash, from which [FeedRepository.Undelete] brings it back
+func (r *FeedRepository) Delete(ctx context.Context, id string) error {
+	query := "UPDATE feeds SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL"
+	result, err := r.db.ExecContext(ctx, query, time.Now().UTC(), id)
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "feed", ID: id, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "feed", ID: id, Err: err}
+	}
+
+	if rows == 0 {
+		return &RepositoryError{Op: "Delete", Entity: "feed", ID: id, Err: fmt.Errorf("feed %w", ErrNotFound)}
+	}
+
+	return nil
+}
+
+// Trash retrieves the feeds in the trash, most recently deleted first
+func (r *FeedRepository) Trash(ctx context.Context) ([]*FeedModel, error) {
+	query := `
+		SELECT id, created_at, updated_at, name, source, params, is_local, deleted_at
+		FROM feeds
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Trash", Entity: "feed", Err: err}
+	}
+	defer rows.Close()
+
+	var feeds []*FeedModel
+	for rows.Next() {
+		var feed FeedModel
+		var paramsJSON string
+		var feedID string
+		var createdAt, updatedAt time.Time
+
+		err := rows.Scan(
+			&feedID,
+			&createdAt,
+			&updatedAt,
+			&feed.Name,
+			&feed.Source,
+			&paramsJSON,
+			&feed.IsLocal,
+			&feed.DeletedAt,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "Trash", Entity: "feed", Err: err}
+		}
+
+		feed.SetID(feedID)
+		feed.SetCreatedAt(createdAt)
+		feed.SetUpdatedAt(updatedAt)
+
+		if err := json.Unmarshal([]byte(paramsJSON), &feed.Params); err != nil {
+			return nil, &RepositoryError{Op: "UnmarshalParams", Entity: "feed", ID: feedID, Err: err}
+		}
+
+		feeds = append(feeds, &feed)
+	}
+
+	return feeds, rows.Err()
+}
+
+// Undelete takes a feed back out of the trash
+func (r *FeedRepository) Undelete(ctx context.Context, id string) error {
+	query := "UPDATE feeds SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL"
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return &RepositoryError{Op: "Undelete", Entity: "feed", ID: id, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return &RepositoryError{Op: "Undelete", Entity: "feed", ID: id, Err: err}
+	}
+
+	if rows == 0 {
+		return &RepositoryError{Op: "Undelete", Entity: "feed", ID: id, Err: fmt.Errorf("feed in trash %w", ErrNotFound)}
+	}
+
+	return nil
+}
+
+// EmptyTrash permanently removes the feeds deleted before the given time, or every feed in the trash when before is
+// zero, and returns how many were removed
+func (r *FeedRepository) EmptyTrash(ctx context.Context, before time.Time) (int64, error) {
+	query := "DELETE FROM feeds WHERE deleted_at IS NOT NULL"
+	var args []any
+	if !before.IsZero() {
+		query += " AND deleted_at < ?"
+		args = append(args, before.UTC())
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, &RepositoryError{Op: "EmptyTrash", Entity: "feed", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &RepositoryError{Op: "EmptyTrash", Entity: "feed", Err: err}
+	}
+	return rows, nil
+}
@@ -1,7 +1,8 @@
-package store
+package bsky
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -253,6 +254,77 @@ func TestFeedRepository_Delete_NotFound(t *testing.T) {
 	}
 }
 
+// TestFeedRepository_Trash moves a feed to the trash, restores it, and empties the trash
+func TestFeedRepository_Trash(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := &FeedRepository{db: db}
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	kept := &FeedModel{Name: "Kept", Source: "timeline", IsLocal: true}
+	trashed := &FeedModel{Name: "Trashed", Source: "following", Params: map[string]string{"limit": "50"}, IsLocal: true}
+	for _, feed := range []*FeedModel{kept, trashed} {
+		if err := repo.Save(ctx, feed); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	if err := repo.Delete(ctx, trashed.ID()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Delete(ctx, trashed.ID()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleting a feed twice: got %v, want ErrNotFound", err)
+	}
+
+	feeds, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(feeds) != 1 || feeds[0].ID() != kept.ID() {
+		t.Errorf("List returned %d feeds, want only the kept one", len(feeds))
+	}
+
+	trash, err := repo.Trash(ctx)
+	if err != nil {
+		t.Fatalf("Trash failed: %v", err)
+	}
+	if len(trash) != 1 || trash[0].ID() != trashed.ID() {
+		t.Fatalf("Trash returned %d feeds, want the trashed one", len(trash))
+	}
+	if trash[0].DeletedAt.IsZero() || trash[0].Params["limit"] != "50" {
+		t.Errorf("Trash returned %+v, want DeletedAt and params set", trash[0])
+	}
+
+	if err := repo.Undelete(ctx, trashed.ID()); err != nil {
+		t.Fatalf("Undelete failed: %v", err)
+	}
+	if _, err := repo.Get(ctx, trashed.ID()); err != nil {
+		t.Errorf("Get after Undelete failed: %v", err)
+	}
+	if err := repo.Undelete(ctx, kept.ID()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("undeleting a feed not in the trash: got %v, want ErrNotFound", err)
+	}
+
+	if err := repo.Delete(ctx, trashed.ID()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	removed, err := repo.EmptyTrash(ctx, time.Now().Add(-time.Hour))
+	if err != nil || removed != 0 {
+		t.Errorf("EmptyTrash before the deletion = %d, %v; want 0", removed, err)
+	}
+	removed, err = repo.EmptyTrash(ctx, time.Time{})
+	if err != nil || removed != 1 {
+		t.Errorf("EmptyTrash = %d, %v; want 1", removed, err)
+	}
+	if err := repo.Undelete(ctx, trashed.ID()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("undeleting an emptied feed: got %v, want ErrNotFound", err)
+	}
+}
+
 // TestFeedRepository_InvalidModelType verifies type checking on Save
 func TestFeedRepository_InvalidModelType(t *testing.T) {
 	db, cleanup := utils.NewTestDB(t)
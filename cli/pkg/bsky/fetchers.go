@@ -0,0 +1,252 @@
+package bsky
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+// FollowerFetcher pages through an actor's social graph on behalf of the signed-in user.
+// Implemented by [BlueskyService].
+type FollowerFetcher interface {
+	Authenticated() bool
+	GetDid() string
+	GetFollowers(ctx context.Context, actor string, limit int, cursor string) (*GetFollowersResponse, error)
+	GetFollows(ctx context.Context, actor string, limit int, cursor string) (*GetFollowsResponse, error)
+	GetList(ctx context.Context, list string, limit int, cursor string) (*GetListResponse, error)
+	GetRelationships(ctx context.Context, actor string, others []string) (*GetRelationshipsResponse, error)
+}
+
+// EngagementFetcher loads an author's posts and likes, and who liked or reposted a post.
+// Implemented by [BlueskyService].
+type EngagementFetcher interface {
+	GetAuthorFeed(ctx context.Context, actor string, limit int, cursor string) (*GetAuthorFeedResponse, error)
+	GetLikes(ctx context.Context, uri string, limit int, cursor string) (*GetLikesResponse, error)
+	GetRepostedBy(ctx context.Context, uri string, limit int, cursor string) (*GetRepostedByResponse, error)
+	GetActorLikes(ctx context.Context, actor string, limit int, cursor string) (*GetActorLikesResponse, error)
+}
+
+// FeedReader loads the home timeline, custom feeds, and the threads around their posts.
+// Implemented by [BlueskyService].
+type FeedReader interface {
+	Authenticated() bool
+	GetTimeline(ctx context.Context, limit int, cursor string) (*GetTimelineResponse, error)
+	GetFeed(ctx context.Context, feed string, limit int, cursor string) (*GetFeedResponse, error)
+	GetPostThread(ctx context.Context, uri string, depth int) (*GetPostThreadResponse, error)
+}
+
+// RecordFetcher reads records straight from repositories and loads the posts they point at.
+// Implemented by [BlueskyService].
+type RecordFetcher interface {
+	Authenticated() bool
+	GetDid() string
+	ListRecords(ctx context.Context, repo, collection string, limit int, cursor string) (*ListRecordsResponse, error)
+	GetRecord(ctx context.Context, repo, collection, rkey string) (*Record, error)
+	GetPosts(ctx context.Context, uris []string) (*GetPostsResponse, error)
+}
+
+// NotificationFetcher pages through the signed-in user's notifications.
+// Implemented by [BlueskyService].
+type NotificationFetcher interface {
+	Authenticated() bool
+	ListNotifications(ctx context.Context, limit int, cursor string) (*ListNotificationsResponse, error)
+}
+
+// PreferencesFetcher reads the signed-in user's app preferences.
+// Implemented by [BlueskyService].
+type PreferencesFetcher interface {
+	GetPreferences(ctx context.Context) (*GetPreferencesResponse, error)
+}
+
+// PreferencesWriter reads and replaces the signed-in user's app preferences.
+// Implemented by [BlueskyService].
+type PreferencesWriter interface {
+	PreferencesFetcher
+	PutPreferences(ctx context.Context, preferences []map[string]any) error
+}
+
+// NotificationSettings reads and changes which notifications the signed-in user receives.
+// Implemented by [BlueskyService].
+type NotificationSettings interface {
+	GetNotificationPreferences(ctx context.Context) (*NotificationPreferences, error)
+	PutNotificationPreferences(ctx context.Context, categories map[string]map[string]any) (*NotificationPreferences, error)
+	PutNotificationPriority(ctx context.Context, priority bool) error
+}
+
+// AppPasswordManager lists and revokes the signed-in account's app passwords.
+// Implemented by [BlueskyService].
+type AppPasswordManager interface {
+	ListAppPasswords(ctx context.Context) (*ListAppPasswordsResponse, error)
+	RevokeAppPassword(ctx context.Context, name string) error
+}
+
+// Reporter files moderation reports on behalf of the signed-in user.
+// Implemented by [BlueskyService].
+type Reporter interface {
+	CreateReport(ctx context.Context, service, reasonType, reason string, subject map[string]any) (*CreateReportResponse, error)
+}
+
+// GraphWriter changes the signed-in user's social graph: follows, blocks, list memberships, thread mutes, and their removal.
+// Implemented by [BlueskyService].
+type GraphWriter interface {
+	Follow(ctx context.Context, subject string) (*CreateRecordResponse, error)
+	Block(ctx context.Context, subject string) (*CreateRecordResponse, error)
+	AddToList(ctx context.Context, list, subject string) (*CreateRecordResponse, error)
+	DeleteRecord(ctx context.Context, uri string) error
+	MuteThread(ctx context.Context, root string) error
+	UnmuteThread(ctx context.Context, root string) error
+}
+
+// PostWriter publishes, likes, reposts, and replies to posts as the signed-in user.
+// Implemented by [BlueskyService].
+type PostWriter interface {
+	CreatePost(ctx context.Context, post NewPost) (*CreateRecordResponse, error)
+	UploadBlob(ctx context.Context, data []byte, mimeType string) (*Blob, error)
+	Like(ctx context.Context, uri, cid string) (*CreateRecordResponse, error)
+	Repost(ctx context.Context, uri, cid string) (*CreateRecordResponse, error)
+	Reply(ctx context.Context, text string, parent, root PostRef) (*CreateRecordResponse, error)
+}
+
+// ProfileFetcher loads actor profiles and derives posting activity from their feeds.
+// Implemented by [BlueskyService].
+//
+// Batch methods leave out actors whose fetch failed, so a missing key means the data is unknown rather than empty.
+type ProfileFetcher interface {
+	GetProfile(ctx context.Context, actor string) (*ActorProfile, error)
+	BatchGetProfiles(ctx context.Context, actors []string, maxConcurrent int) map[string]*ActorProfile
+	BatchGetLastPostDates(ctx context.Context, actors []string, maxConcurrent int) map[string]time.Time
+	BatchGetPostRates(ctx context.Context, actors []string, sampleSize int, lookbackDays int, maxConcurrent int, progressFn func(current, total int)) map[string]*PostRate
+}
+
+// RateCache memoizes post rates and last post dates between runs.
+// Implemented by [CacheRepository].
+type RateCache interface {
+	GetPostRates(ctx context.Context, actorDids []string) (map[string]*PostRateCacheModel, error)
+	SavePostRates(ctx context.Context, caches []*PostRateCacheModel) error
+	GetActivities(ctx context.Context, actorDids []string) (map[string]*ActivityCacheModel, error)
+	SaveActivities(ctx context.Context, caches []*ActivityCacheModel) error
+}
+
+// BatchGetPostRatesCached calculates posting rates for multiple actors with caching support.
+//
+// Checks cache first, falls back to API for cache misses, and saves results to cache.
+// If refresh is true, bypasses cache and refetches all data from API.
+//
+// TODO: Implement per-item TTL for more efficient cache invalidation.
+// FIXME: this function signature is ridiculous
+func BatchGetPostRatesCached(ctx context.Context, profiles ProfileFetcher, cacheRepo RateCache, actors []string, sampleSize int, lookbackDays int, maxConcurrent int, refresh bool, progressFn func(current, total int)) map[string]*PostRate {
+	results := make(map[string]*PostRate)
+
+	// If not refreshing, try to load from cache
+	var actorsToFetch []string
+	if !refresh {
+		cached, err := cacheRepo.GetPostRates(ctx, actors)
+		if err == nil {
+			for _, actor := range actors {
+				// Entries cached without daily counts are refetched so every row can draw them
+				if cache, ok := cached[actor]; ok && cache.IsFresh() && cache.Daily != nil {
+					results[actor] = &PostRate{
+						PostsPerDay:  cache.PostsPerDay,
+						LastPostDate: cache.LastPostDate,
+						SampleSize:   cache.SampleSize,
+						Daily:        cache.Daily,
+					}
+				} else {
+					actorsToFetch = append(actorsToFetch, actor)
+				}
+			}
+		} else {
+			actorsToFetch = actors
+		}
+	} else {
+		actorsToFetch = actors
+	}
+
+	utils.GetLogger().Debug("post rate cache lookup", "actors", len(actors), "hits", len(results), "misses", len(actorsToFetch), "refresh", refresh)
+
+	if len(actorsToFetch) > 0 {
+		apiResults := profiles.BatchGetPostRates(ctx, actorsToFetch, sampleSize, lookbackDays, maxConcurrent, progressFn)
+		maps.Copy(results, apiResults)
+
+		var cacheModels []*PostRateCacheModel
+		for actor, postRate := range apiResults {
+			cacheModels = append(cacheModels, &PostRateCacheModel{
+				ActorDid:     actor,
+				PostsPerDay:  postRate.PostsPerDay,
+				LastPostDate: postRate.LastPostDate,
+				SampleSize:   postRate.SampleSize,
+				Daily:        postRate.Daily,
+			})
+		}
+
+		if len(cacheModels) > 0 {
+			// Cache save is non-critical, so a failure is logged rather than returned
+			if err := cacheRepo.SavePostRates(ctx, cacheModels); err != nil {
+				utils.GetLogger().Warn("failed to cache post rates", "count", len(cacheModels), "error", err)
+			}
+		}
+	}
+
+	return results
+}
+
+// BatchGetLastPostDatesCached fetches last post dates for multiple actors with caching support.
+//
+// Checks cache first, falls back to API for cache misses, and saves results to cache.
+// If refresh is true, bypasses cache and refetches all data from API.
+// Actors who never posted map to the zero time; actors whose fetch failed are left out and not cached.
+//
+// TODO: Implement per-item TTL for more efficient cache invalidation.
+// FIXME: this function signature is ridiculous
+func BatchGetLastPostDatesCached(ctx context.Context, profiles ProfileFetcher, cacheRepo RateCache, actors []string, maxConcurrent int, refresh bool) map[string]time.Time {
+	results := make(map[string]time.Time)
+
+	var actorsToFetch []string
+	if !refresh {
+		cached, err := cacheRepo.GetActivities(ctx, actors)
+		if err == nil {
+			for _, actor := range actors {
+				if cache, ok := cached[actor]; ok && cache.IsFresh() {
+					results[actor] = cache.LastPostDate
+				} else {
+					actorsToFetch = append(actorsToFetch, actor)
+				}
+			}
+		} else {
+			actorsToFetch = actors
+		}
+	} else {
+		actorsToFetch = actors
+	}
+
+	utils.GetLogger().Debug("activity cache lookup", "actors", len(actors), "hits", len(actors)-len(actorsToFetch), "misses", len(actorsToFetch), "refresh", refresh)
+
+	if len(actorsToFetch) > 0 {
+		apiResults := profiles.BatchGetLastPostDates(ctx, actorsToFetch, maxConcurrent)
+		maps.Copy(results, apiResults)
+
+		var cacheModels []*ActivityCacheModel
+		for _, actor := range actorsToFetch {
+			lastPostDate, fetched := apiResults[actor]
+			if !fetched {
+				continue
+			}
+			cacheModels = append(cacheModels, &ActivityCacheModel{
+				ActorDid:     actor,
+				LastPostDate: lastPostDate,
+				FetchedAt:    time.Now(),
+				ExpiresAt:    time.Now().Add(24 * time.Hour),
+			})
+		}
+
+		if len(cacheModels) > 0 {
+			if err := cacheRepo.SaveActivities(ctx, cacheModels); err != nil {
+				utils.GetLogger().Warn("failed to cache activity", "count", len(cacheModels), "error", err)
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,181 @@
+package bsky
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fakeProfileFetcher struct {
+	lastPostDates map[string]time.Time
+	postRates     map[string]*PostRate
+	requested     []string
+}
+
+func (f *fakeProfileFetcher) GetProfile(ctx context.Context, actor string) (*ActorProfile, error) {
+	return &ActorProfile{Did: actor}, nil
+}
+
+func (f *fakeProfileFetcher) BatchGetProfiles(ctx context.Context, actors []string, maxConcurrent int) map[string]*ActorProfile {
+	return map[string]*ActorProfile{}
+}
+
+func (f *fakeProfileFetcher) BatchGetLastPostDates(ctx context.Context, actors []string, maxConcurrent int) map[string]time.Time {
+	f.requested = append(f.requested, actors...)
+	results := make(map[string]time.Time)
+	for _, actor := range actors {
+		if date, ok := f.lastPostDates[actor]; ok {
+			results[actor] = date
+		}
+	}
+	return results
+}
+
+func (f *fakeProfileFetcher) BatchGetPostRates(ctx context.Context, actors []string, sampleSize int, lookbackDays int, maxConcurrent int, progressFn func(current, total int)) map[string]*PostRate {
+	f.requested = append(f.requested, actors...)
+	results := make(map[string]*PostRate)
+	for _, actor := range actors {
+		if rate, ok := f.postRates[actor]; ok {
+			results[actor] = rate
+		}
+	}
+	return results
+}
+
+type fakeRateCache struct {
+	postRates  map[string]*PostRateCacheModel
+	activities map[string]*ActivityCacheModel
+}
+
+func newFakeRateCache() *fakeRateCache {
+	return &fakeRateCache{postRates: map[string]*PostRateCacheModel{}, activities: map[string]*ActivityCacheModel{}}
+}
+
+func (c *fakeRateCache) GetPostRates(ctx context.Context, actorDids []string) (map[string]*PostRateCacheModel, error) {
+	return c.postRates, nil
+}
+
+func (c *fakeRateCache) SavePostRates(ctx context.Context, caches []*PostRateCacheModel) error {
+	for _, cache := range caches {
+		c.postRates[cache.ActorDid] = cache
+	}
+	return nil
+}
+
+func (c *fakeRateCache) GetActivities(ctx context.Context, actorDids []string) (map[string]*ActivityCacheModel, error) {
+	return c.activities, nil
+}
+
+func (c *fakeRateCache) SaveActivities(ctx context.Context, caches []*ActivityCacheModel) error {
+	for _, cache := range caches {
+		c.activities[cache.ActorDid] = cache
+	}
+	return nil
+}
+
+func TestServiceImplementsFetchers(t *testing.T) {
+	var _ FollowerFetcher = (*BlueskyService)(nil)
+	var _ ProfileFetcher = (*BlueskyService)(nil)
+	var _ FeedReader = (*BlueskyService)(nil)
+	var _ PostWriter = (*BlueskyService)(nil)
+	var _ RateCache = (*CacheRepository)(nil)
+}
+
+func TestBatchGetLastPostDatesCached(t *testing.T) {
+	ctx := context.Background()
+	posted := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+	t.Run("UsesFreshCacheEntries", func(t *testing.T) {
+		cache := newFakeRateCache()
+		cache.activities["did:plc:cached"] = &ActivityCacheModel{ActorDid: "did:plc:cached", LastPostDate: posted, ExpiresAt: time.Now().Add(time.Hour)}
+		fetcher := &fakeProfileFetcher{lastPostDates: map[string]time.Time{"did:plc:fresh": posted}}
+
+		results := BatchGetLastPostDatesCached(ctx, fetcher, cache, []string{"did:plc:cached", "did:plc:fresh"}, 2, false)
+
+		if len(fetcher.requested) != 1 || fetcher.requested[0] != "did:plc:fresh" {
+			t.Errorf("expected only cache miss to be fetched, got %v", fetcher.requested)
+		}
+		if !results["did:plc:cached"].Equal(posted) || !results["did:plc:fresh"].Equal(posted) {
+			t.Errorf("unexpected results: %v", results)
+		}
+		if _, ok := cache.activities["did:plc:fresh"]; !ok {
+			t.Error("expected fetched activity to be cached")
+		}
+	})
+
+	t.Run("RecordsActorsWithoutPosts", func(t *testing.T) {
+		cache := newFakeRateCache()
+		cache.activities["did:plc:cached"] = &ActivityCacheModel{ActorDid: "did:plc:cached", ExpiresAt: time.Now().Add(time.Hour)}
+		fetcher := &fakeProfileFetcher{lastPostDates: map[string]time.Time{"did:plc:silent": {}}}
+
+		results := BatchGetLastPostDatesCached(ctx, fetcher, cache, []string{"did:plc:silent", "did:plc:cached"}, 2, false)
+
+		for _, actor := range []string{"did:plc:silent", "did:plc:cached"} {
+			date, ok := results[actor]
+			if !ok || !date.IsZero() {
+				t.Errorf("expected zero time for %s without posts, got %v (present=%v)", actor, date, ok)
+			}
+		}
+	})
+
+	t.Run("LeavesOutFailedFetches", func(t *testing.T) {
+		cache := newFakeRateCache()
+		fetcher := &fakeProfileFetcher{}
+
+		results := BatchGetLastPostDatesCached(ctx, fetcher, cache, []string{"did:plc:broken"}, 2, false)
+
+		if _, ok := results["did:plc:broken"]; ok {
+			t.Errorf("expected a failed fetch to be absent, got %v", results)
+		}
+		if _, ok := cache.activities["did:plc:broken"]; ok {
+			t.Error("expected a failed fetch not to be cached as never posted")
+		}
+	})
+
+	t.Run("RefreshBypassesCache", func(t *testing.T) {
+		cache := newFakeRateCache()
+		cache.activities["did:plc:cached"] = &ActivityCacheModel{ActorDid: "did:plc:cached", LastPostDate: posted, ExpiresAt: time.Now().Add(time.Hour)}
+		fetcher := &fakeProfileFetcher{}
+
+		BatchGetLastPostDatesCached(ctx, fetcher, cache, []string{"did:plc:cached"}, 2, true)
+
+		if len(fetcher.requested) != 1 {
+			t.Errorf("expected refresh to refetch cached actor, got %v", fetcher.requested)
+		}
+	})
+}
+
+func TestBatchGetPostRatesCached(t *testing.T) {
+	ctx := context.Background()
+	cache := newFakeRateCache()
+	daily := []int{0, 1, 0, 3, 0, 0, 2}
+	cache.postRates["did:plc:cached"] = &PostRateCacheModel{ActorDid: "did:plc:cached", PostsPerDay: 2, Daily: daily, ExpiresAt: time.Now().Add(time.Hour)}
+	cache.postRates["did:plc:stale"] = &PostRateCacheModel{ActorDid: "did:plc:stale", PostsPerDay: 9, Daily: daily, ExpiresAt: time.Now().Add(-time.Hour)}
+	cache.postRates["did:plc:nodaily"] = &PostRateCacheModel{ActorDid: "did:plc:nodaily", PostsPerDay: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	fetcher := &fakeProfileFetcher{postRates: map[string]*PostRate{
+		"did:plc:stale":   {PostsPerDay: 0.5, SampleSize: 3, Daily: daily},
+		"did:plc:nodaily": {PostsPerDay: 1, SampleSize: 30, Daily: daily},
+	}}
+
+	results := BatchGetPostRatesCached(ctx, fetcher, cache, []string{"did:plc:cached", "did:plc:stale", "did:plc:nodaily"}, 30, 30, 2, false, nil)
+
+	if !slices.Equal(fetcher.requested, []string{"did:plc:stale", "did:plc:nodaily"}) {
+		t.Errorf("expected the stale entry and the one without daily counts to be fetched, got %v", fetcher.requested)
+	}
+	if results["did:plc:cached"].PostsPerDay != 2 {
+		t.Errorf("expected cached rate 2, got %v", results["did:plc:cached"].PostsPerDay)
+	}
+	if !slices.Equal(results["did:plc:cached"].Daily, daily) {
+		t.Errorf("expected cached daily counts %v, got %v", daily, results["did:plc:cached"].Daily)
+	}
+	if !slices.Equal(cache.postRates["did:plc:nodaily"].Daily, daily) {
+		t.Error("expected daily counts to be written back to the cache")
+	}
+	if results["did:plc:stale"].PostsPerDay != 0.5 {
+		t.Errorf("expected refetched rate 0.5, got %v", results["did:plc:stale"].PostsPerDay)
+	}
+	if cache.postRates["did:plc:stale"].PostsPerDay != 0.5 {
+		t.Error("expected refetched rate to be written back to the cache")
+	}
+}
@@ -0,0 +1,211 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+)
+
+// FollowerTracking reports when a user's live follower set was loaded and last confirmed by the stream
+type FollowerTracking struct {
+	SeededAt    time.Time
+	HeartbeatAt time.Time
+}
+
+// Live reports whether the stream confirmed the follower set within maxAge of now
+func (t *FollowerTracking) Live(now time.Time, maxAge time.Duration) bool {
+	return t != nil && now.Sub(t.HeartbeatAt) <= maxAge
+}
+
+// FollowerStateRepository keeps a user's current followers up to date between snapshots, fed by the firehose
+type FollowerStateRepository struct {
+	db *sql.DB
+}
+
+// NewFollowerStateRepository creates a new follower state repository with SQLite backend
+func NewFollowerStateRepository() (*FollowerStateRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FollowerStateRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *FollowerStateRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *FollowerStateRepository) Close() error {
+	return r.db.Close()
+}
+
+// Seed replaces userDid's follower set with followers fetched from the API and starts tracking at the given time
+func (r *FollowerStateRepository) Seed(ctx context.Context, userDid string, followers []string, at time.Time) (err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "FollowerStateRepository.Seed")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &RepositoryError{Op: "Seed", Entity: "follower state", Err: err}
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM follower_state WHERE user_did = ?", userDid); err != nil {
+		return &RepositoryError{Op: "Seed", Entity: "follower state", Err: err}
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT OR IGNORE INTO follower_state (user_did, actor_did, follow_uri, followed_at)
+		VALUES (?, ?, '', ?)
+	`)
+	if err != nil {
+		return &RepositoryError{Op: "Seed", Entity: "follower state", Err: err}
+	}
+	defer stmt.Close()
+
+	for _, did := range followers {
+		if _, err = stmt.ExecContext(ctx, userDid, did, at); err != nil {
+			return &RepositoryError{Op: "Seed", Entity: "follower state", Err: err}
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, `
+		INSERT INTO follower_tracking (user_did, seeded_at, heartbeat_at) VALUES (?, ?, ?)
+		ON CONFLICT(user_did) DO UPDATE SET seeded_at = excluded.seeded_at, heartbeat_at = excluded.heartbeat_at
+	`, userDid, at, at); err != nil {
+		return &RepositoryError{Op: "Seed", Entity: "follower state", Err: err}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return &RepositoryError{Op: "Seed", Entity: "follower state", Err: err}
+	}
+	return nil
+}
+
+// AddFollower records actorDid following userDid through followURI.
+// It reports false when actorDid was already a follower, filling in the follow URI if it was unknown.
+func (r *FollowerStateRepository) AddFollower(ctx context.Context, userDid, actorDid, followURI string, at time.Time) (bool, error) {
+	uri, ok, err := r.FollowURI(ctx, userDid, actorDid)
+	if err != nil {
+		return false, err
+	}
+
+	if ok {
+		if uri == "" && followURI != "" {
+			if _, err := r.db.ExecContext(ctx, "UPDATE follower_state SET follow_uri = ? WHERE user_did = ? AND actor_did = ?", followURI, userDid, actorDid); err != nil {
+				return false, &RepositoryError{Op: "AddFollower", Entity: "follower state", Err: err}
+			}
+		}
+		return false, nil
+	}
+
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO follower_state (user_did, actor_did, follow_uri, followed_at) VALUES (?, ?, ?, ?)
+	`, userDid, actorDid, followURI, at); err != nil {
+		return false, &RepositoryError{Op: "AddFollower", Entity: "follower state", Err: err}
+	}
+	return true, nil
+}
+
+// RemoveFollowRecord removes the follower whose follow record is followURI, returning their DID, or "" when
+// no tracked follower has that record
+func (r *FollowerStateRepository) RemoveFollowRecord(ctx context.Context, userDid, followURI string) (string, error) {
+	var actorDid string
+	err := r.db.QueryRowContext(ctx, "SELECT actor_did FROM follower_state WHERE user_did = ? AND follow_uri = ?", userDid, followURI).Scan(&actorDid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", &RepositoryError{Op: "RemoveFollowRecord", Entity: "follower state", Err: err}
+	}
+
+	if err := r.RemoveFollower(ctx, userDid, actorDid); err != nil {
+		return "", err
+	}
+	return actorDid, nil
+}
+
+// RemoveFollower removes actorDid from userDid's followers
+func (r *FollowerStateRepository) RemoveFollower(ctx context.Context, userDid, actorDid string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM follower_state WHERE user_did = ? AND actor_did = ?", userDid, actorDid); err != nil {
+		return &RepositoryError{Op: "RemoveFollower", Entity: "follower state", Err: err}
+	}
+	return nil
+}
+
+// FollowURI returns the follow record URI of a tracked follower. ok is false when actorDid isn't a follower;
+// the URI is empty for followers seeded from the API.
+func (r *FollowerStateRepository) FollowURI(ctx context.Context, userDid, actorDid string) (uri string, ok bool, err error) {
+	err = r.db.QueryRowContext(ctx, "SELECT follow_uri FROM follower_state WHERE user_did = ? AND actor_did = ?", userDid, actorDid).Scan(&uri)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, &RepositoryError{Op: "FollowURI", Entity: "follower state", Err: err}
+	}
+	return uri, true, nil
+}
+
+// Followers returns the DIDs of userDid's tracked followers
+func (r *FollowerStateRepository) Followers(ctx context.Context, userDid string) (_ []string, err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "FollowerStateRepository.Followers")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(ctx, "SELECT actor_did FROM follower_state WHERE user_did = ? ORDER BY followed_at DESC, actor_did", userDid)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Followers", Entity: "follower state", Err: err}
+	}
+	defer rows.Close()
+
+	var dids []string
+	for rows.Next() {
+		var did string
+		if err := rows.Scan(&did); err != nil {
+			return nil, &RepositoryError{Op: "Followers", Entity: "follower state", Err: err}
+		}
+		dids = append(dids, did)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &RepositoryError{Op: "Followers", Entity: "follower state", Err: err}
+	}
+	return dids, nil
+}
+
+// Heartbeat marks userDid's follower set as current as of at
+func (r *FollowerStateRepository) Heartbeat(ctx context.Context, userDid string, at time.Time) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE follower_tracking SET heartbeat_at = ? WHERE user_did = ?", at, userDid); err != nil {
+		return &RepositoryError{Op: "Heartbeat", Entity: "follower state", Err: err}
+	}
+	return nil
+}
+
+// Tracking returns the tracking state of userDid's followers, or nil if they were never seeded
+func (r *FollowerStateRepository) Tracking(ctx context.Context, userDid string) (*FollowerTracking, error) {
+	var tracking FollowerTracking
+	err := r.db.QueryRowContext(ctx, "SELECT seeded_at, heartbeat_at FROM follower_tracking WHERE user_did = ?", userDid).
+		Scan(&tracking.SeededAt, &tracking.HeartbeatAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &RepositoryError{Op: "Tracking", Entity: "follower state", Err: err}
+	}
+	return &tracking, nil
+}
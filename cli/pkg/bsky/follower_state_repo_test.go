@@ -0,0 +1,73 @@
+package bsky
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestFollowerStateRepository(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &FollowerStateRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	tracking, err := repo.Tracking(ctx, "did:plc:me")
+	if err != nil || tracking != nil {
+		t.Fatalf("expected no tracking before seeding, got %+v (err %v)", tracking, err)
+	}
+
+	seededAt := time.Now().Add(-time.Hour)
+	if err := repo.Seed(ctx, "did:plc:me", []string{"did:plc:a", "did:plc:b"}, seededAt); err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+
+	added, err := repo.AddFollower(ctx, "did:plc:me", "did:plc:c", "at://did:plc:c/app.bsky.graph.follow/1", time.Now())
+	if err != nil || !added {
+		t.Fatalf("expected new follower to be added, got %v (err %v)", added, err)
+	}
+	added, err = repo.AddFollower(ctx, "did:plc:me", "did:plc:a", "at://did:plc:a/app.bsky.graph.follow/2", time.Now())
+	if err != nil || added {
+		t.Fatalf("expected existing follower not to be re-added, got %v (err %v)", added, err)
+	}
+	if uri, ok, _ := repo.FollowURI(ctx, "did:plc:me", "did:plc:a"); !ok || uri != "at://did:plc:a/app.bsky.graph.follow/2" {
+		t.Errorf("expected follow URI to be backfilled, got %q", uri)
+	}
+
+	did, err := repo.RemoveFollowRecord(ctx, "did:plc:me", "at://did:plc:c/app.bsky.graph.follow/1")
+	if err != nil || did != "did:plc:c" {
+		t.Fatalf("expected did:plc:c to be removed by record, got %q (err %v)", did, err)
+	}
+	if did, _ := repo.RemoveFollowRecord(ctx, "did:plc:me", "at://did:plc:x/app.bsky.graph.follow/9"); did != "" {
+		t.Errorf("expected unknown record to match nobody, got %q", did)
+	}
+	if err := repo.RemoveFollower(ctx, "did:plc:me", "did:plc:b"); err != nil {
+		t.Fatalf("RemoveFollower failed: %v", err)
+	}
+
+	followers, err := repo.Followers(ctx, "did:plc:me")
+	if err != nil {
+		t.Fatalf("Followers failed: %v", err)
+	}
+	if len(followers) != 1 || followers[0] != "did:plc:a" {
+		t.Errorf("unexpected followers %v", followers)
+	}
+
+	now := time.Now()
+	if err := repo.Heartbeat(ctx, "did:plc:me", now); err != nil {
+		t.Fatalf("Heartbeat failed: %v", err)
+	}
+	tracking, err = repo.Tracking(ctx, "did:plc:me")
+	if err != nil || tracking == nil {
+		t.Fatalf("Tracking failed: %v", err)
+	}
+	if !tracking.SeededAt.Equal(seededAt) || !tracking.Live(now.Add(time.Minute), 5*time.Minute) || tracking.Live(now.Add(time.Hour), 5*time.Minute) {
+		t.Errorf("unexpected tracking %+v", tracking)
+	}
+}
@@ -0,0 +1,167 @@
+package bsky
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TokenLeeway absorbs clock drift between this machine and the PDS when checking token times
+const TokenLeeway = 30 * time.Second
+
+// Scopes the PDS issues on session JWTs, and the one OAuth tokens share
+const (
+	ScopeAccess           = "com.atproto.access"
+	ScopeAppPass          = "com.atproto.appPass"
+	ScopeAppPassPrivilege = "com.atproto.appPassPrivileged"
+	ScopeRefresh          = "com.atproto.refresh"
+	ScopeOAuth            = "atproto" // one of the space-separated scopes of every OAuth token
+)
+
+// jwtClaims holds the registered and atproto claims read from a session JWT
+type jwtClaims struct {
+	Sub   string   `json:"sub"`
+	Aud   audience `json:"aud"`
+	Scope string   `json:"scope"`
+	Iat   int64    `json:"iat"`
+	Nbf   int64    `json:"nbf"`
+	Exp   int64    `json:"exp"`
+}
+
+// audience decodes the aud claim, which may be a single string or an array
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// TokenInfo describes a session JWT without verifying its signature
+type TokenInfo struct {
+	Subject   string
+	Audience  []string
+	Scope     string
+	IssuedAt  time.Time
+	NotBefore time.Time
+	Expiry    time.Time
+}
+
+// ParseToken decodes the claims of a JWT; the signature is not checked since only the PDS holds the key
+func ParseToken(token string) (*TokenInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, &TokenError{Op: "Parse", Err: errors.New("invalid JWT format")}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, &TokenError{Op: "Parse", Err: err}
+	}
+
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, &TokenError{Op: "Parse", Err: err}
+	}
+
+	if claims.Exp == 0 {
+		return nil, &TokenError{Op: "Parse", Err: errors.New("no exp claim in JWT")}
+	}
+
+	info := &TokenInfo{
+		Subject:  claims.Sub,
+		Audience: claims.Aud,
+		Scope:    claims.Scope,
+		Expiry:   time.Unix(claims.Exp, 0),
+	}
+	if claims.Iat != 0 {
+		info.IssuedAt = time.Unix(claims.Iat, 0)
+	}
+	if claims.Nbf != 0 {
+		info.NotBefore = time.Unix(claims.Nbf, 0)
+	}
+	return info, nil
+}
+
+// Lifetime returns the span between issue and expiry, or zero when iat is absent
+func (t *TokenInfo) Lifetime() time.Duration {
+	if t.IssuedAt.IsZero() {
+		return 0
+	}
+	return t.Expiry.Sub(t.IssuedAt)
+}
+
+// Expired reports whether the token is past its expiry at now, allowing [TokenLeeway]
+func (t *TokenInfo) Expired(now time.Time) bool {
+	return now.After(t.Expiry.Add(TokenLeeway))
+}
+
+// RefreshAt returns when an access token should be renewed: the last tenth of its lifetime, but no later than
+// [TokenLeeway] before expiry so a slow clock cannot let the token lapse mid-request
+func (t *TokenInfo) RefreshAt() time.Time {
+	margin := t.Lifetime() / 10
+	if margin < TokenLeeway {
+		margin = TokenLeeway
+	}
+	return t.Expiry.Add(-margin)
+}
+
+// ValidateAccess checks the claims of an access token where present: the scope must grant API access,
+// the audience must be a DID, and the token must not be expired or not yet valid beyond [TokenLeeway]
+func (t *TokenInfo) ValidateAccess(now time.Time) error {
+	if err := t.validateClaims(); err != nil {
+		return err
+	}
+	if !t.NotBefore.IsZero() && now.Add(TokenLeeway).Before(t.NotBefore) {
+		return &TokenError{Op: "ValidateAccess", Err: errors.New("token not valid until " + t.NotBefore.Format(time.RFC3339))}
+	}
+	if t.Expired(now) {
+		return &TokenError{Op: "ValidateAccess", Err: errors.New("token expired at " + t.Expiry.Format(time.RFC3339))}
+	}
+	return nil
+}
+
+// validateClaims checks the scope and audience of an access token, ignoring its validity window. OAuth tokens
+// carry a list of scopes that must include [ScopeOAuth].
+func (t *TokenInfo) validateClaims() error {
+	switch {
+	case t.Scope == "", t.Scope == ScopeAccess, t.Scope == ScopeAppPass, t.Scope == ScopeAppPassPrivilege:
+	case slices.Contains(strings.Fields(t.Scope), ScopeOAuth):
+	case t.Scope == ScopeRefresh:
+		return &TokenError{Op: "ValidateAccess", Err: errors.New("refresh token supplied as access token")}
+	default:
+		return &TokenError{Op: "ValidateAccess", Err: errors.New("unexpected token scope: " + t.Scope)}
+	}
+
+	for _, aud := range t.Audience {
+		if !strings.HasPrefix(aud, "did:") {
+			return &TokenError{Op: "ValidateAccess", Err: errors.New("token audience is not a DID: " + aud)}
+		}
+	}
+	return nil
+}
+
+// TokenError represents a malformed or unusable session token
+type TokenError struct {
+	Op  string
+	Err error
+}
+
+func (e *TokenError) Error() string {
+	return "token." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *TokenError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,134 @@
+package bsky
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// makeJWT builds an unsigned JWT carrying claims
+func makeJWT(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("failed to marshal claims: %v", err)
+	}
+	return "eyJhbGciOiJFUzI1NksifQ." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+func TestParseToken_Claims(t *testing.T) {
+	now := time.Unix(1760000000, 0)
+	token := makeJWT(t, map[string]any{
+		"sub":   "did:plc:abc",
+		"aud":   "did:web:pds.example.com",
+		"scope": ScopeAccess,
+		"iat":   now.Unix(),
+		"exp":   now.Add(2 * time.Hour).Unix(),
+	})
+
+	info, err := ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+	if info.Subject != "did:plc:abc" || info.Scope != ScopeAccess {
+		t.Errorf("unexpected claims: %+v", info)
+	}
+	if len(info.Audience) != 1 || info.Audience[0] != "did:web:pds.example.com" {
+		t.Errorf("expected single audience, got %v", info.Audience)
+	}
+	if info.Lifetime() != 2*time.Hour {
+		t.Errorf("expected 2h lifetime, got %v", info.Lifetime())
+	}
+	if want := now.Add(108 * time.Minute); !info.RefreshAt().Equal(want) {
+		t.Errorf("expected refresh at %v, got %v", want, info.RefreshAt())
+	}
+
+	multi, err := ParseToken(makeJWT(t, map[string]any{"aud": []string{"did:web:a", "did:web:b"}, "exp": now.Unix()}))
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+	if len(multi.Audience) != 2 {
+		t.Errorf("expected two audiences, got %v", multi.Audience)
+	}
+	if !multi.RefreshAt().Equal(now.Add(-TokenLeeway)) {
+		t.Errorf("expected refresh at leeway before expiry without iat, got %v", multi.RefreshAt())
+	}
+}
+
+func TestTokenInfo_ValidateAccess(t *testing.T) {
+	now := time.Unix(1760000000, 0)
+	tests := []struct {
+		name    string
+		info    TokenInfo
+		wantErr bool
+	}{
+		{"valid", TokenInfo{Scope: ScopeAccess, Audience: []string{"did:web:pds"}, Expiry: now.Add(time.Hour)}, false},
+		{"app password", TokenInfo{Scope: ScopeAppPass, Expiry: now.Add(time.Hour)}, false},
+		{"expired within leeway", TokenInfo{Expiry: now.Add(-10 * time.Second)}, false},
+		{"expired beyond leeway", TokenInfo{Expiry: now.Add(-time.Minute)}, true},
+		{"not yet valid within leeway", TokenInfo{NotBefore: now.Add(10 * time.Second), Expiry: now.Add(time.Hour)}, false},
+		{"not yet valid beyond leeway", TokenInfo{NotBefore: now.Add(time.Minute), Expiry: now.Add(time.Hour)}, true},
+		{"refresh scope", TokenInfo{Scope: ScopeRefresh, Expiry: now.Add(time.Hour)}, true},
+		{"unknown scope", TokenInfo{Scope: "com.example.other", Expiry: now.Add(time.Hour)}, true},
+		{"non-DID audience", TokenInfo{Audience: []string{"https://pds.example.com"}, Expiry: now.Add(time.Hour)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.info.ValidateAccess(now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBlueskyService_AuthenticateClockSkew(t *testing.T) {
+	// The PDS clock runs an hour ahead, so the token looks like it was issued in the future
+	serverNow := time.Now().Add(time.Hour)
+	accessJwt := makeJWT(t, map[string]any{
+		"scope": ScopeAccess,
+		"aud":   "did:web:pds.example.com",
+		"iat":   serverNow.Unix(),
+		"exp":   serverNow.Add(2 * time.Hour).Unix(),
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"accessJwt":%q,"refreshJwt":"refresh","did":"did:plc:abc","handle":"test.bsky.social"}`, accessJwt)
+	}))
+	defer server.Close()
+
+	svc := NewBlueskyService(server.URL)
+	if err := svc.Authenticate(context.Background(), map[string]string{"identifier": "test", "password": "pw"}); err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+
+	if skew := svc.ClockSkew(); skew < 59*time.Minute || skew > 61*time.Minute {
+		t.Errorf("expected about an hour of clock skew, got %v", skew)
+	}
+	if svc.shouldRefreshToken() {
+		t.Error("fresh token should not need refreshing once skew is accounted for")
+	}
+}
+
+func TestBlueskyService_AuthenticateRejectsRefreshScope(t *testing.T) {
+	accessJwt := makeJWT(t, map[string]any{"scope": ScopeRefresh, "exp": time.Now().Add(time.Hour).Unix()})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"accessJwt":%q,"refreshJwt":"refresh","did":"did:plc:abc","handle":"test.bsky.social"}`, accessJwt)
+	}))
+	defer server.Close()
+
+	svc := NewBlueskyService(server.URL)
+	if err := svc.Authenticate(context.Background(), map[string]string{"identifier": "test", "password": "pw"}); err == nil {
+		t.Fatal("expected refresh-scoped access token to be rejected")
+	}
+	if svc.Authenticated() {
+		t.Error("service should not be authenticated with a rejected token")
+	}
+}
@@ -0,0 +1,79 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+)
+
+// LabelRepository remembers which moderation labels on the user's own content have already been reported,
+// so self-checks only alert on new ones
+type LabelRepository struct {
+	db *sql.DB
+}
+
+// NewLabelRepository creates a new label repository with SQLite backend
+func NewLabelRepository() (*LabelRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabelRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *LabelRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *LabelRepository) Close() error {
+	return r.db.Close()
+}
+
+// Observe records labels as seen at the given time and returns the ones not seen before.
+// Labels are identified by source, subject URI, and value.
+func (r *LabelRepository) Observe(ctx context.Context, labels []Label, at time.Time) (_ []Label, err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "LabelRepository.Observe")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Observe", Entity: "label", Err: err}
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT OR IGNORE INTO label_log (src, uri, val, cts, first_seen)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	var fresh []Label
+	for _, label := range labels {
+		result, err := tx.ExecContext(ctx, query, label.Src, label.Uri, label.Val, label.Cts, at)
+		if err != nil {
+			return nil, &RepositoryError{Op: "Observe", Entity: "label", Err: err}
+		}
+		if inserted, err := result.RowsAffected(); err == nil && inserted > 0 {
+			fresh = append(fresh, label)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, &RepositoryError{Op: "Observe", Entity: "label", Err: err}
+	}
+	return fresh, nil
+}
@@ -0,0 +1,47 @@
+package bsky
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestLabelRepository_Observe(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &LabelRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	spam := Label{Src: "did:plc:mod", Uri: "did:plc:me", Val: "spam", Cts: "2026-01-01T00:00:00Z"}
+	rude := Label{Src: "did:plc:mod", Uri: "at://did:plc:me/app.bsky.feed.post/1", Val: "rude"}
+
+	fresh, err := repo.Observe(ctx, []Label{spam}, time.Now())
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if len(fresh) != 1 || fresh[0].Val != "spam" {
+		t.Errorf("expected spam label to be new, got %+v", fresh)
+	}
+
+	fresh, err = repo.Observe(ctx, []Label{spam, rude}, time.Now())
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if len(fresh) != 1 || fresh[0].Val != "rude" {
+		t.Errorf("expected only rude label to be new, got %+v", fresh)
+	}
+
+	fresh, err = repo.Observe(ctx, []Label{spam, rude}, time.Now())
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if len(fresh) != 0 {
+		t.Errorf("expected no new labels, got %+v", fresh)
+	}
+}
@@ -0,0 +1,104 @@
+package bsky
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoTTL bounds how long a memoized response is reused, so long-lived processes such as the shell and daemon
+// still see changes
+const memoTTL = 5 * time.Minute
+
+// memoSweepMin is the entry count below which expired entries are left in place
+const memoSweepMin = 1024
+
+// memo collapses repeated and concurrent lookups of the same key within a run into one call.
+// Failed calls aren't memoized.
+type memo[V any] struct {
+	mu      sync.Mutex
+	entries map[string]*memoEntry[V]
+	ttl     time.Duration
+	sweepAt int
+}
+
+// memoEntry is one call's result; done closes once value and err are set
+type memoEntry[V any] struct {
+	done    chan struct{}
+	value   V
+	err     error
+	expires time.Time
+}
+
+// newMemo creates a memo whose entries live for ttl
+func newMemo[V any](ttl time.Duration) *memo[V] {
+	return &memo[V]{entries: make(map[string]*memoEntry[V]), ttl: ttl, sweepAt: memoSweepMin}
+}
+
+// do returns the memoized value for key, or calls fn while concurrent callers for key wait for its result
+func (m *memo[V]) do(ctx context.Context, key string, fn func() (V, error)) (V, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	entry, ok := m.entries[key]
+	if ok && !entry.expired(now) {
+		m.mu.Unlock()
+
+		select {
+		case <-entry.done:
+			return entry.value, entry.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+
+	entry = &memoEntry[V]{done: make(chan struct{})}
+	m.entries[key] = entry
+	m.sweep(now)
+	m.mu.Unlock()
+
+	entry.value, entry.err = fn()
+	entry.expires = time.Now().Add(m.ttl)
+	close(entry.done)
+
+	if entry.err != nil {
+		m.mu.Lock()
+		if m.entries[key] == entry {
+			delete(m.entries, key)
+		}
+		m.mu.Unlock()
+	}
+	return entry.value, entry.err
+}
+
+// reset drops every memoized value, e.g. once a new session changes what responses contain
+func (m *memo[V]) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = make(map[string]*memoEntry[V])
+	m.sweepAt = memoSweepMin
+}
+
+// sweep drops expired entries once the map has doubled since the last sweep; callers hold mu
+func (m *memo[V]) sweep(now time.Time) {
+	if len(m.entries) < m.sweepAt {
+		return
+	}
+	for key, entry := range m.entries {
+		if entry.expired(now) {
+			delete(m.entries, key)
+		}
+	}
+	m.sweepAt = max(2*len(m.entries), memoSweepMin)
+}
+
+// expired reports whether a finished entry has outlived its TTL; in-flight entries never expire
+func (e *memoEntry[V]) expired(now time.Time) bool {
+	select {
+	case <-e.done:
+		return now.After(e.expires)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,97 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("CollapsesConcurrentCalls", func(t *testing.T) {
+		m := newMemo[int](time.Minute)
+		var calls atomic.Int32
+		release := make(chan struct{})
+
+		var wg sync.WaitGroup
+		results := make([]int, 8)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = m.do(ctx, "alice", func() (int, error) {
+					calls.Add(1)
+					<-release
+					return 42, nil
+				})
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if got := calls.Load(); got != 1 {
+			t.Errorf("fn called %d times, want 1", got)
+		}
+		for _, result := range results {
+			if result != 42 {
+				t.Errorf("result = %d, want 42", result)
+			}
+		}
+
+		if v, _ := m.do(ctx, "alice", func() (int, error) { return 0, nil }); v != 42 || calls.Load() != 1 {
+			t.Errorf("expected the memoized value, got %d", v)
+		}
+	})
+
+	t.Run("ErrorsAreNotMemoized", func(t *testing.T) {
+		m := newMemo[int](time.Minute)
+		if _, err := m.do(ctx, "bob", func() (int, error) { return 0, errors.New("boom") }); err == nil {
+			t.Fatal("expected the error")
+		}
+		if v, err := m.do(ctx, "bob", func() (int, error) { return 7, nil }); err != nil || v != 7 {
+			t.Errorf("expected a retry after the error, got %d (%v)", v, err)
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		m := newMemo[int](time.Millisecond)
+		m.do(ctx, "carol", func() (int, error) { return 1, nil })
+		time.Sleep(5 * time.Millisecond)
+		if v, _ := m.do(ctx, "carol", func() (int, error) { return 2, nil }); v != 2 {
+			t.Errorf("expected a fresh call after the TTL, got %d", v)
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		m := newMemo[int](time.Minute)
+		m.do(ctx, "dave", func() (int, error) { return 1, nil })
+		m.reset()
+		if v, _ := m.do(ctx, "dave", func() (int, error) { return 2, nil }); v != 2 {
+			t.Errorf("expected a fresh call after reset, got %d", v)
+		}
+	})
+
+	t.Run("WaiterCanceled", func(t *testing.T) {
+		m := newMemo[int](time.Minute)
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+		go m.do(ctx, "erin", func() (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+		<-started
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := m.do(canceled, "erin", func() (int, error) { return 2, nil }); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the waiter to stop with its context, got %v", err)
+		}
+	})
+}
@@ -1,4 +1,4 @@
-package store
+package bsky
 
 import (
 	"database/sql"
@@ -9,7 +9,7 @@ import (
 	"strings"
 )
 
-//go:embed migrations/*.sql
+//go:embed migrations/*.sql migrations/postgres/*.sql
 var migrationFiles embed.FS
 
 type migration struct {
@@ -26,10 +26,16 @@ type MigrationStatus struct {
 	IsUpToDate     bool
 }
 
-// RunMigrations executes all pending up migrations in order.
+// RunMigrations executes all pending up migrations in order against a SQLite database.
 // Creates a schema_migrations table to track applied migrations.
 func RunMigrations(db *sql.DB) error {
-	if err := createMigrationsTable(db); err != nil {
+	return RunDialectMigrations(db, DialectSQLite)
+}
+
+// RunDialectMigrations executes all pending up migrations for the given dialect in order.
+// Each dialect has its own migration set since column types and constraints differ.
+func RunDialectMigrations(db *sql.DB, dialect Dialect) error {
+	if err := createMigrationsTable(db, dialect); err != nil {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
@@ -38,7 +44,7 @@ func RunMigrations(db *sql.DB) error {
 		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
-	migrations, err := loadMigrations("up")
+	migrations, err := loadMigrations(dialect, "up")
 	if err != nil {
 		return fmt.Errorf("failed to load migrations: %w", err)
 	}
@@ -52,7 +58,7 @@ func RunMigrations(db *sql.DB) error {
 			return fmt.Errorf("failed to execute migration %d: %w", m.Version, err)
 		}
 
-		if err := recordMigration(db, m.Version); err != nil {
+		if err := recordMigration(db, dialect, m.Version); err != nil {
 			return fmt.Errorf("failed to record migration %d: %w", m.Version, err)
 		}
 	}
@@ -60,15 +66,20 @@ func RunMigrations(db *sql.DB) error {
 	return nil
 }
 
-// Rollback executes down migrations back to the specified version.
+// Rollback executes down migrations on a SQLite database back to the specified version.
 // If version is 0, rolls back all migrations.
 func Rollback(db *sql.DB, targetVersion int) error {
+	return RollbackDialect(db, DialectSQLite, targetVersion)
+}
+
+// RollbackDialect executes the dialect's down migrations back to the specified version.
+func RollbackDialect(db *sql.DB, dialect Dialect, targetVersion int) error {
 	applied, err := getAppliedMigrations(db)
 	if err != nil {
 		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
-	migrations, err := loadMigrations("down")
+	migrations, err := loadMigrations(dialect, "down")
 	if err != nil {
 		return fmt.Errorf("failed to load migrations: %w", err)
 	}
@@ -83,7 +94,7 @@ func Rollback(db *sql.DB, targetVersion int) error {
 			return fmt.Errorf("failed to rollback migration %d: %w", m.Version, err)
 		}
 
-		if err := removeMigration(db, m.Version); err != nil {
+		if err := removeMigration(db, dialect, m.Version); err != nil {
 			return fmt.Errorf("failed to remove migration record %d: %w", m.Version, err)
 		}
 	}
@@ -91,9 +102,10 @@ func Rollback(db *sql.DB, targetVersion int) error {
 	return nil
 }
 
-// loadMigrations reads all migration files of the specified direction (up/down)
-func loadMigrations(direction string) ([]migration, error) {
-	entries, err := migrationFiles.ReadDir("migrations")
+// loadMigrations reads all migration files for a dialect of the specified direction (up/down)
+func loadMigrations(dialect Dialect, direction string) ([]migration, error) {
+	dir := dialect.migrationsDir()
+	entries, err := migrationFiles.ReadDir(dir)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +118,7 @@ func loadMigrations(direction string) ([]migration, error) {
 			continue
 		}
 
-		content, err := migrationFiles.ReadFile("migrations/" + entry.Name())
+		content, err := migrationFiles.ReadFile(dir + "/" + entry.Name())
 		if err != nil {
 			return nil, err
 		}
@@ -136,11 +148,16 @@ func loadMigrations(direction string) ([]migration, error) {
 }
 
 // createMigrationsTable creates the schema_migrations tracking table
-func createMigrationsTable(db *sql.DB) error {
+func createMigrationsTable(db *sql.DB, dialect Dialect) error {
+	timestampType := "DATETIME"
+	if dialect == DialectPostgres {
+		timestampType = "TIMESTAMPTZ"
+	}
+
 	query := `
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			version INTEGER PRIMARY KEY,
-			applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+			applied_at ` + timestampType + ` NOT NULL DEFAULT CURRENT_TIMESTAMP
 		)
 	`
 	_, err := db.Exec(query)
@@ -174,21 +191,26 @@ func executeMigration(db *sql.DB, m migration) error {
 }
 
 // recordMigration adds a migration to the schema_migrations table
-func recordMigration(db *sql.DB, version int) error {
-	_, err := db.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version)
+func recordMigration(db *sql.DB, dialect Dialect, version int) error {
+	_, err := db.Exec(dialect.Rebind("INSERT INTO schema_migrations (version) VALUES (?)"), version)
 	return err
 }
 
 // removeMigration removes a migration from the schema_migrations table
-func removeMigration(db *sql.DB, version int) error {
-	_, err := db.Exec("DELETE FROM schema_migrations WHERE version = ?", version)
+func removeMigration(db *sql.DB, dialect Dialect, version int) error {
+	_, err := db.Exec(dialect.Rebind("DELETE FROM schema_migrations WHERE version = ?"), version)
 	return err
 }
 
-// GetMigrationStatus returns the current migration state of the database.
+// GetMigrationStatus returns the current migration state of a SQLite database.
 // Returns the highest applied version, latest available version, and pending migration count.
 func GetMigrationStatus(db *sql.DB) (*MigrationStatus, error) {
-	if err := createMigrationsTable(db); err != nil {
+	return GetDialectMigrationStatus(db, DialectSQLite)
+}
+
+// GetDialectMigrationStatus returns the migration state of a database using the given dialect's migrations.
+func GetDialectMigrationStatus(db *sql.DB, dialect Dialect) (*MigrationStatus, error) {
+	if err := createMigrationsTable(db, dialect); err != nil {
 		return nil, fmt.Errorf("failed to ensure migrations table: %w", err)
 	}
 
@@ -197,7 +219,7 @@ func GetMigrationStatus(db *sql.DB) (*MigrationStatus, error) {
 		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
-	migrations, err := loadMigrations("up")
+	migrations, err := loadMigrations(dialect, "up")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load migrations: %w", err)
 	}
@@ -1,4 +1,4 @@
-package store
+package bsky
 
 import (
 	"database/sql"
@@ -21,8 +21,8 @@ func TestRunMigrations(t *testing.T) {
 		t.Fatalf("schema_migrations table not found: %v", err)
 	}
 
-	if count != 4 {
-		t.Errorf("expected 4 migrations applied, got %d", count)
+	if count != 19 {
+		t.Errorf("expected 19 migrations applied, got %d", count)
 	}
 
 	err = db.QueryRow("SELECT COUNT(*) FROM feeds").Scan(&count)
@@ -59,8 +59,8 @@ func TestRunMigrations_Idempotent(t *testing.T) {
 		t.Fatalf("failed to query migrations: %v", err)
 	}
 
-	if count != 4 {
-		t.Errorf("expected 4 migrations, got %d", count)
+	if count != 19 {
+		t.Errorf("expected 19 migrations, got %d", count)
 	}
 }
 
@@ -146,7 +146,7 @@ func TestMigrationOrdering(t *testing.T) {
 	}
 	defer rows.Close()
 
-	expectedVersions := []int{1, 2, 3, 4}
+	expectedVersions := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}
 	var actualVersions []int
 
 	for rows.Next() {
@@ -172,7 +172,7 @@ func TestGetAppliedMigrations(t *testing.T) {
 	db, cleanup := utils.NewTestDB(t)
 	defer cleanup()
 
-	if err := createMigrationsTable(db); err != nil {
+	if err := createMigrationsTable(db, DialectSQLite); err != nil {
 		t.Fatalf("failed to create migrations table: %v", err)
 	}
 
@@ -198,13 +198,13 @@ func TestGetAppliedMigrations(t *testing.T) {
 }
 
 func TestLoadMigrations(t *testing.T) {
-	upMigrations, err := loadMigrations("up")
+	upMigrations, err := loadMigrations(DialectSQLite, "up")
 	if err != nil {
 		t.Fatalf("failed to load up migrations: %v", err)
 	}
 
-	if len(upMigrations) != 4 {
-		t.Errorf("expected 4 up migrations, got %d", len(upMigrations))
+	if len(upMigrations) != 19 {
+		t.Errorf("expected 19 up migrations, got %d", len(upMigrations))
 	}
 
 	for i := 1; i < len(upMigrations); i++ {
@@ -213,13 +213,13 @@ func TestLoadMigrations(t *testing.T) {
 		}
 	}
 
-	downMigrations, err := loadMigrations("down")
+	downMigrations, err := loadMigrations(DialectSQLite, "down")
 	if err != nil {
 		t.Fatalf("failed to load down migrations: %v", err)
 	}
 
-	if len(downMigrations) != 4 {
-		t.Errorf("expected 4 down migrations, got %d", len(downMigrations))
+	if len(downMigrations) != 19 {
+		t.Errorf("expected 19 down migrations, got %d", len(downMigrations))
 	}
 }
 
@@ -248,11 +248,11 @@ func TestRecordAndRemoveMigration(t *testing.T) {
 	db, cleanup := utils.NewTestDB(t)
 	defer cleanup()
 
-	if err := createMigrationsTable(db); err != nil {
+	if err := createMigrationsTable(db, DialectSQLite); err != nil {
 		t.Fatalf("failed to create migrations table: %v", err)
 	}
 
-	if err := recordMigration(db, 42); err != nil {
+	if err := recordMigration(db, DialectSQLite, 42); err != nil {
 		t.Fatalf("recordMigration failed: %v", err)
 	}
 
@@ -265,7 +265,7 @@ func TestRecordAndRemoveMigration(t *testing.T) {
 		t.Error("migration 42 should be recorded")
 	}
 
-	if err := removeMigration(db, 42); err != nil {
+	if err := removeMigration(db, DialectSQLite, 42); err != nil {
 		t.Fatalf("removeMigration failed: %v", err)
 	}
 
@@ -319,7 +319,7 @@ func TestCreateMigrationsTable(t *testing.T) {
 	db, cleanup := utils.NewTestDB(t)
 	defer cleanup()
 
-	if err := createMigrationsTable(db); err != nil {
+	if err := createMigrationsTable(db, DialectSQLite); err != nil {
 		t.Fatalf("createMigrationsTable failed: %v", err)
 	}
 
@@ -0,0 +1,11 @@
+-- Account actions taken by skycli (follows, list additions) so they can be undone
+CREATE TABLE IF NOT EXISTS action_log (
+    id TEXT PRIMARY KEY,
+    created_at DATETIME NOT NULL,
+    action TEXT NOT NULL,
+    subject_did TEXT NOT NULL,
+    record_uri TEXT NOT NULL,
+    undone_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_action_log_created ON action_log(created_at);
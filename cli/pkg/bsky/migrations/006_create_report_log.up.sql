@@ -0,0 +1,13 @@
+-- Moderation reports filed with skycli
+CREATE TABLE IF NOT EXISTS report_log (
+    id TEXT PRIMARY KEY,
+    created_at DATETIME NOT NULL,
+    report_id INTEGER NOT NULL,
+    subject_type TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    reason_type TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    service TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_log_created ON report_log(created_at);
@@ -0,0 +1,9 @@
+-- Moderation labels seen on the user's own profile and posts
+CREATE TABLE IF NOT EXISTS label_log (
+    src TEXT NOT NULL,
+    uri TEXT NOT NULL,
+    val TEXT NOT NULL,
+    cts TEXT NOT NULL DEFAULT '',
+    first_seen DATETIME NOT NULL,
+    PRIMARY KEY (src, uri, val)
+);
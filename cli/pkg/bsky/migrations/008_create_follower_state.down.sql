@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS follower_tracking;
+DROP TABLE IF EXISTS follower_state;
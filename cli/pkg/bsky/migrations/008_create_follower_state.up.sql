@@ -0,0 +1,17 @@
+-- Live follower set maintained from the firehose by the daemon's stream task
+CREATE TABLE IF NOT EXISTS follower_state (
+    user_did TEXT NOT NULL,
+    actor_did TEXT NOT NULL,
+    follow_uri TEXT NOT NULL DEFAULT '', -- Empty for followers loaded from the API, whose follow record is unknown
+    followed_at DATETIME NOT NULL,
+    PRIMARY KEY (user_did, actor_did)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follower_state_uri ON follower_state(follow_uri);
+
+-- When each user's follower set was loaded from the API and last confirmed current by the stream
+CREATE TABLE IF NOT EXISTS follower_tracking (
+    user_did TEXT PRIMARY KEY,
+    seeded_at DATETIME NOT NULL,
+    heartbeat_at DATETIME NOT NULL
+);
@@ -0,0 +1,6 @@
+-- Last firehose event processed by each stream subscription, for resuming after downtime
+CREATE TABLE IF NOT EXISTS stream_cursors (
+    name TEXT PRIMARY KEY,
+    cursor INTEGER NOT NULL, -- Jetstream time_us
+    updated_at DATETIME NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_stream_events_time;
+DROP TABLE IF EXISTS stream_events;
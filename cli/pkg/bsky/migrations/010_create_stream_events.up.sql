@@ -0,0 +1,14 @@
+-- Firehose events each stream consumer has processed, in processing order, so replays are handled once
+CREATE TABLE IF NOT EXISTS stream_events (
+    seq INTEGER PRIMARY KEY AUTOINCREMENT,
+    consumer TEXT NOT NULL,
+    event_key TEXT NOT NULL,
+    did TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    uri TEXT NOT NULL DEFAULT '',
+    time_us INTEGER NOT NULL,
+    processed_at DATETIME NOT NULL,
+    UNIQUE(consumer, event_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stream_events_time ON stream_events(time_us);
@@ -0,0 +1,9 @@
+-- Latest rate-limit headers seen from each API host, so quota can be tracked across runs
+CREATE TABLE IF NOT EXISTS rate_limits (
+    host TEXT PRIMARY KEY,
+    quota INTEGER NOT NULL,     -- requests allowed per window
+    remaining INTEGER NOT NULL, -- requests left in the window when observed
+    window_seconds INTEGER NOT NULL DEFAULT 0,
+    reset_at DATETIME NOT NULL,
+    observed_at DATETIME NOT NULL
+);
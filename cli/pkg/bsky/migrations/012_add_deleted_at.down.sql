@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_posts_deleted_at;
+DROP INDEX IF EXISTS idx_feeds_deleted_at;
+
+ALTER TABLE posts DROP COLUMN deleted_at;
+ALTER TABLE feeds DROP COLUMN deleted_at;
@@ -0,0 +1,6 @@
+-- Deleting a feed or post moves it to the trash; rows leave the table only when the trash is emptied
+ALTER TABLE feeds ADD COLUMN deleted_at DATETIME;
+ALTER TABLE posts ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_feeds_deleted_at ON feeds(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON posts(deleted_at);
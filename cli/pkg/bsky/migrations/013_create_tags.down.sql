@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tags_entity;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,10 @@
+-- Local tags on feeds, stored posts, and accounts, for grouping them into segments
+CREATE TABLE IF NOT EXISTS tags (
+    tag TEXT NOT NULL,
+    kind TEXT NOT NULL,      -- feed, post, or actor
+    entity_id TEXT NOT NULL, -- feed ID, post AT URI, or account DID
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (tag, kind, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tags_entity ON tags(kind, entity_id);
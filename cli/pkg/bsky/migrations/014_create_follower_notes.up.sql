@@ -0,0 +1,8 @@
+-- Local notes on accounts, such as followers, kept for community management
+CREATE TABLE IF NOT EXISTS follower_notes (
+    did TEXT PRIMARY KEY,
+    handle TEXT NOT NULL DEFAULT '', -- handle when the note was written, for listing notes offline
+    note TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
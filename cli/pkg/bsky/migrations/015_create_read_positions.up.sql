@@ -0,0 +1,7 @@
+-- Where the feed reader left off in each feed, so the next session resumes there
+CREATE TABLE IF NOT EXISTS read_positions (
+    feed TEXT PRIMARY KEY, -- "timeline" or a feed generator AT URI
+    cursor TEXT NOT NULL DEFAULT '', -- cursor of the page being read; empty for the first page
+    post_uri TEXT NOT NULL DEFAULT '', -- post selected on that page
+    updated_at DATETIME NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_drafts_updated_at;
+DROP TABLE IF EXISTS drafts;
@@ -0,0 +1,10 @@
+-- Posts saved from the compose screen to finish and publish later
+CREATE TABLE IF NOT EXISTS drafts (
+    id TEXT PRIMARY KEY,
+    text TEXT NOT NULL DEFAULT '',
+    images TEXT NOT NULL DEFAULT '[]', -- JSON list of attached image paths and their alt text
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_drafts_updated_at ON drafts(updated_at);
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS posts_fts_after_insert;
+DROP TRIGGER IF EXISTS posts_fts_after_update;
+DROP TRIGGER IF EXISTS posts_fts_before_update;
+DROP TRIGGER IF EXISTS posts_fts_before_delete;
+
+DROP TABLE IF EXISTS posts_fts;
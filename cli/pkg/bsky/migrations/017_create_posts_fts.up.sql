@@ -0,0 +1,23 @@
+-- Full-text index over post text for `search local`. It reads text from posts by rowid rather than keeping a copy,
+-- and triggers keep it in step. The default go-sqlite3 build has FTS4 but not FTS5 (that needs the sqlite_fts5
+-- build tag), so this is an FTS4 table.
+CREATE VIRTUAL TABLE IF NOT EXISTS posts_fts USING fts4(content="posts", text, tokenize=unicode61 "remove_diacritics=2");
+
+-- External content tables read the old text when deleting, so removals run before the row changes
+CREATE TRIGGER IF NOT EXISTS posts_fts_before_delete BEFORE DELETE ON posts BEGIN
+    DELETE FROM posts_fts WHERE docid = old.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_before_update BEFORE UPDATE OF text ON posts BEGIN
+    DELETE FROM posts_fts WHERE docid = old.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_after_update AFTER UPDATE OF text ON posts BEGIN
+    INSERT INTO posts_fts(docid, text) VALUES (new.rowid, new.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_after_insert AFTER INSERT ON posts BEGIN
+    INSERT INTO posts_fts(docid, text) VALUES (new.rowid, new.text);
+END;
+
+INSERT INTO posts_fts(posts_fts) VALUES ('rebuild');
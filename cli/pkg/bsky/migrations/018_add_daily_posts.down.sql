@@ -0,0 +1 @@
+ALTER TABLE cached_post_rates DROP COLUMN daily_posts;
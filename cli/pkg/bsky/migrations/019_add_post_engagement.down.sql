@@ -0,0 +1,4 @@
+ALTER TABLE posts DROP COLUMN quote_count;
+ALTER TABLE posts DROP COLUMN reply_count;
+ALTER TABLE posts DROP COLUMN repost_count;
+ALTER TABLE posts DROP COLUMN like_count;
@@ -0,0 +1,5 @@
+-- Like, repost, reply, and quote counts from when a post was last fetched; NULL for posts stored without them
+ALTER TABLE posts ADD COLUMN like_count INTEGER;
+ALTER TABLE posts ADD COLUMN repost_count INTEGER;
+ALTER TABLE posts ADD COLUMN reply_count INTEGER;
+ALTER TABLE posts ADD COLUMN quote_count INTEGER;
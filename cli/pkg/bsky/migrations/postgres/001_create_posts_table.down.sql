@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_posts_indexed_at;
+DROP INDEX IF EXISTS idx_posts_author_did;
+DROP INDEX IF EXISTS idx_posts_feed_id;
+DROP TABLE IF EXISTS posts;
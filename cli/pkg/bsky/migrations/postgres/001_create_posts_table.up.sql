@@ -0,0 +1,15 @@
+-- Feeds stay in the local SQLite database, so feed_id is not a foreign key here
+CREATE TABLE IF NOT EXISTS posts (
+    id TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    uri TEXT NOT NULL UNIQUE,
+    author_did TEXT NOT NULL,
+    text TEXT NOT NULL,
+    feed_id TEXT NOT NULL,
+    indexed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_posts_feed_id ON posts(feed_id);
+CREATE INDEX IF NOT EXISTS idx_posts_author_did ON posts(author_did);
+CREATE INDEX IF NOT EXISTS idx_posts_indexed_at ON posts(indexed_at DESC);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS cached_activity;
+DROP TABLE IF EXISTS cached_post_rates;
+DROP TABLE IF EXISTS follower_snapshot_entries;
+DROP TABLE IF EXISTS follower_snapshots;
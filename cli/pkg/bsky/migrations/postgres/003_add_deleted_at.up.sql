@@ -0,0 +1,4 @@
+-- Deleting a post moves it to the trash; rows leave the table only when the trash is emptied
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON posts(deleted_at);
@@ -0,0 +1,2 @@
+-- Full-text index over post text for `search local`, matching the expression PostRepository.SearchText queries
+CREATE INDEX IF NOT EXISTS idx_posts_text_search ON posts USING GIN (to_tsvector('simple', text));
@@ -0,0 +1,2 @@
+-- Posts in each of the last 7 days, as a JSON list, for the sparkline in follower tables
+ALTER TABLE cached_post_rates ADD COLUMN IF NOT EXISTS daily_posts TEXT;
@@ -0,0 +1,4 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS quote_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS reply_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS repost_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS like_count;
@@ -0,0 +1,5 @@
+-- Like, repost, reply, and quote counts from when a post was last fetched; NULL for posts stored without them
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS like_count INTEGER;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS repost_count INTEGER;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reply_count INTEGER;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS quote_count INTEGER;
@@ -0,0 +1,179 @@
+package bsky
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines a generic persistence contract.
+type Repository interface {
+	Init(ctx context.Context) error
+	Close() error
+	Get(ctx context.Context, id string) (Model, error)
+	List(ctx context.Context) ([]Model, error)
+	Save(ctx context.Context, feed Model) error
+	Delete(ctx context.Context, id string) error
+}
+
+// SnapshotStore persists follower/following snapshots and their entries.
+// Implemented by [SnapshotRepository] for both SQLite and PostgreSQL.
+type SnapshotStore interface {
+	Repository
+	FindByUserAndType(ctx context.Context, userDid, snapshotType string) (*SnapshotModel, error)
+	FindByUserTypeAndDate(ctx context.Context, userDid, snapshotType string, date time.Time) (*SnapshotModel, error)
+	SaveEntry(ctx context.Context, entry *SnapshotEntry) error
+	SaveEntries(ctx context.Context, entries []*SnapshotEntry) error
+	GetEntries(ctx context.Context, snapshotID string) ([]*SnapshotEntry, error)
+	GetActorDids(ctx context.Context, snapshotID string) ([]string, error)
+	FirstSeen(ctx context.Context, userDid, snapshotType string) (map[string]time.Time, error)
+	Import(ctx context.Context, snapshot *SnapshotModel, entries []*SnapshotEntry) error
+	DeleteExpiredSnapshots(ctx context.Context) (int64, error)
+}
+
+// CacheStore persists post rate and activity analytics.
+// Implemented by [CacheRepository] for both SQLite and PostgreSQL.
+type CacheStore interface {
+	RateCache
+	Init(ctx context.Context) error
+	Close() error
+	GetPostRate(ctx context.Context, actorDid string) (*PostRateCacheModel, error)
+	SavePostRate(ctx context.Context, cache *PostRateCacheModel) error
+	DeletePostRate(ctx context.Context, actorDid string) error
+	GetActivity(ctx context.Context, actorDid string) (*ActivityCacheModel, error)
+	SaveActivity(ctx context.Context, cache *ActivityCacheModel) error
+	DeleteActivity(ctx context.Context, actorDid string) error
+	DeleteExpiredPostRates(ctx context.Context) (int64, error)
+	DeleteExpiredActivities(ctx context.Context) (int64, error)
+	SetMaxSize(bytes int64)
+	Usage(ctx context.Context) (*CacheUsage, error)
+	Evict(ctx context.Context, maxBytes int64) (int64, error)
+}
+
+// TokenStore persists session tokens rotated by an automatic refresh.
+// Implemented by [SessionRepository].
+type TokenStore interface {
+	UpdateTokens(ctx context.Context, accessToken, refreshToken string) error
+}
+
+// QuotaRecorder receives the rate-limit budget reported by API responses.
+// Implemented by [QuotaRepository].
+type QuotaRecorder interface {
+	RecordQuota(quota *QuotaModel)
+}
+
+// Model is the base interface for any persisted domain object.
+type Model interface {
+	ID() string
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+}
+
+// Generates a new v4 [uuid.UUID] and converts it to a string
+func GenerateUUID() string {
+	return uuid.New().String()
+}
+
+// FeedModel represents a logical feed (local or remote).
+type FeedModel struct {
+	id        string
+	createdAt time.Time
+	updatedAt time.Time
+	Name      string
+	Source    string
+	Params    map[string]string
+	IsLocal   bool
+	// DeletedAt is when the feed was moved to the trash; zero for feeds not in it
+	DeletedAt time.Time `json:",omitzero"`
+}
+
+func (m *FeedModel) ID() string               { return m.id }
+func (m *FeedModel) CreatedAt() time.Time     { return m.createdAt }
+func (m *FeedModel) UpdatedAt() time.Time     { return m.updatedAt }
+func (m *FeedModel) SetID(id string)          { m.id = id }
+func (m *FeedModel) SetCreatedAt(t time.Time) { m.createdAt = t }
+func (m *FeedModel) SetUpdatedAt(t time.Time) { m.updatedAt = t }
+func (m *FeedModel) TouchUpdatedAt()          { m.updatedAt = time.Now() }
+
+// PostModel represents a single cached or fetched post.
+type PostModel struct {
+	id        string
+	createdAt time.Time
+	updatedAt time.Time
+	URI       string
+	AuthorDID string
+	Text      string
+	FeedID    string
+	IndexedAt time.Time
+	// DeletedAt is when the post was moved to the trash; zero for posts not in it
+	DeletedAt time.Time `json:",omitzero"`
+	// Engagement is the post's counts when it was last fetched; nil when they weren't recorded, as for posts
+	// imported from a repository export
+	Engagement *PostEngagement `json:",omitempty"`
+}
+
+// PostEngagement counts the interactions with a post
+type PostEngagement struct {
+	Likes   int
+	Reposts int
+	Replies int
+	Quotes  int
+}
+
+// Total returns the likes, reposts, replies, and quotes together
+func (e PostEngagement) Total() int {
+	return e.Likes + e.Reposts + e.Replies + e.Quotes
+}
+
+func (m *PostModel) ID() string               { return m.id }
+func (m *PostModel) CreatedAt() time.Time     { return m.createdAt }
+func (m *PostModel) UpdatedAt() time.Time     { return m.updatedAt }
+func (m *PostModel) SetID(id string)          { m.id = id }
+func (m *PostModel) SetCreatedAt(t time.Time) { m.createdAt = t }
+func (m *PostModel) SetUpdatedAt(t time.Time) { m.updatedAt = t }
+func (m *PostModel) TouchUpdatedAt()          { m.updatedAt = time.Now() }
+
+// NewPostModel converts a post fetched from the API into a model stored under feedID
+func NewPostModel(feedID string, post *PostView) *PostModel {
+	model := &PostModel{
+		URI:        post.Uri,
+		FeedID:     feedID,
+		Engagement: &PostEngagement{Likes: post.LikeCount, Reposts: post.RepostCount, Replies: post.ReplyCount, Quotes: post.QuoteCount},
+	}
+	if post.Author != nil {
+		model.AuthorDID = post.Author.Did
+	}
+	if record, ok := post.Record.(map[string]any); ok {
+		if text, ok := record["text"].(string); ok {
+			model.Text = text
+		}
+	}
+	if indexedAt, err := time.Parse(time.RFC3339, post.IndexedAt); err == nil {
+		model.IndexedAt = indexedAt
+	}
+	return model
+}
+
+// SessionModel represents a user session and API context.
+type SessionModel struct {
+	id         string
+	createdAt  time.Time
+	updatedAt  time.Time
+	Handle     string
+	Token      string
+	ServiceURL string
+	IsValid    bool
+	// AppPassword names the app password the session was created with; empty when unknown
+	AppPassword string
+	// OAuth is set for sessions created by OAuth, whose tokens only work with its DPoP key
+	OAuth *OAuthSession
+}
+
+func (m *SessionModel) ID() string               { return m.id }
+func (m *SessionModel) CreatedAt() time.Time     { return m.createdAt }
+func (m *SessionModel) UpdatedAt() time.Time     { return m.updatedAt }
+func (m *SessionModel) SetID(id string)          { m.id = id }
+func (m *SessionModel) SetCreatedAt(t time.Time) { m.createdAt = t }
+func (m *SessionModel) SetUpdatedAt(t time.Time) { m.updatedAt = t }
+func (m *SessionModel) TouchUpdatedAt()          { m.updatedAt = time.Now() }
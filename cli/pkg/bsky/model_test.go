@@ -1,4 +1,4 @@
-package store
+package bsky
 
 import (
 	"testing"
@@ -0,0 +1,160 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// NoteModel is the local note kept on one account, such as a follower
+type NoteModel struct {
+	DID       string
+	Handle    string // the account's handle when the note was last written, if known
+	Note      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NoteRepository persists notes on accounts in the SQLite cache database
+type NoteRepository struct {
+	db *sql.DB
+}
+
+// NewNoteRepository creates a new note repository with SQLite backend
+func NewNoteRepository() (*NoteRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NoteRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *NoteRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *NoteRepository) Close() error {
+	return r.db.Close()
+}
+
+// Get retrieves the note on an account by DID
+func (r *NoteRepository) Get(ctx context.Context, did string) (*NoteModel, error) {
+	query := "SELECT did, handle, note, created_at, updated_at FROM follower_notes WHERE did = ?"
+
+	var note NoteModel
+	err := r.db.QueryRowContext(ctx, query, did).Scan(&note.DID, &note.Handle, &note.Note, &note.CreatedAt, &note.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, &RepositoryError{Op: "Get", Entity: "note", ID: did, Err: fmt.Errorf("note %w", ErrNotFound)}
+	}
+	if err != nil {
+		return nil, &RepositoryError{Op: "Get", Entity: "note", ID: did, Err: err}
+	}
+	return &note, nil
+}
+
+// List retrieves every note, most recently updated first
+func (r *NoteRepository) List(ctx context.Context) ([]*NoteModel, error) {
+	query := "SELECT did, handle, note, created_at, updated_at FROM follower_notes ORDER BY updated_at DESC, did"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "note", Err: err}
+	}
+	defer rows.Close()
+
+	var notes []*NoteModel
+	for rows.Next() {
+		var note NoteModel
+		if err := rows.Scan(&note.DID, &note.Handle, &note.Note, &note.CreatedAt, &note.UpdatedAt); err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "note", Err: err}
+		}
+		notes = append(notes, &note)
+	}
+	return notes, rows.Err()
+}
+
+// Set replaces the note on an account, creating it if there is none. An empty handle keeps the one stored.
+func (r *NoteRepository) Set(ctx context.Context, did, handle, note string) error {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return &RepositoryError{Op: "Set", Entity: "note", ID: did, Err: errors.New("note can't be empty")}
+	}
+
+	query := `
+		INSERT INTO follower_notes (did, handle, note, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(did) DO UPDATE SET
+			handle = CASE WHEN excluded.handle != '' THEN excluded.handle ELSE follower_notes.handle END,
+			note = excluded.note,
+			updated_at = excluded.updated_at
+	`
+	now := time.Now().UTC()
+	if _, err := r.db.ExecContext(ctx, query, did, handle, note, now, now); err != nil {
+		return &RepositoryError{Op: "Set", Entity: "note", ID: did, Err: err}
+	}
+	return nil
+}
+
+// Restore stores a note exactly as given, keeping its CreatedAt and UpdatedAt (used when syncing state between
+// machines). An empty handle keeps the one stored.
+func (r *NoteRepository) Restore(ctx context.Context, note *NoteModel) error {
+	query := `
+		INSERT INTO follower_notes (did, handle, note, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(did) DO UPDATE SET
+			handle = CASE WHEN excluded.handle != '' THEN excluded.handle ELSE follower_notes.handle END,
+			note = excluded.note,
+			created_at = excluded.created_at,
+			updated_at = excluded.updated_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, note.DID, note.Handle, note.Note, note.CreatedAt, note.UpdatedAt); err != nil {
+		return &RepositoryError{Op: "Restore", Entity: "note", ID: note.DID, Err: err}
+	}
+	return nil
+}
+
+// Append adds a line to the note on an account, creating it if there is none
+func (r *NoteRepository) Append(ctx context.Context, did, handle, line string) error {
+	existing, err := r.Get(ctx, did)
+	if errors.Is(err, ErrNotFound) {
+		return r.Set(ctx, did, handle, line)
+	}
+	if err != nil {
+		return err
+	}
+	return r.Set(ctx, did, handle, existing.Note+"\n"+strings.TrimSpace(line))
+}
+
+// Delete removes the note on an account
+func (r *NoteRepository) Delete(ctx context.Context, did string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM follower_notes WHERE did = ?", did)
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "note", ID: did, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "note", ID: did, Err: err}
+	}
+	if rows == 0 {
+		return &RepositoryError{Op: "Delete", Entity: "note", ID: did, Err: fmt.Errorf("note %w", ErrNotFound)}
+	}
+	return nil
+}
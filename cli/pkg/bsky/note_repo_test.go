@@ -0,0 +1,84 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestNoteRepository(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &NoteRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if _, err := repo.Get(ctx, "did:plc:alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get before Set: got %v, want ErrNotFound", err)
+	}
+	if err := repo.Set(ctx, "did:plc:alice", "", "  "); err == nil {
+		t.Error("expected an error setting an empty note")
+	}
+
+	if err := repo.Set(ctx, "did:plc:alice", "alice.bsky.social", "met at conf"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := repo.Append(ctx, "did:plc:alice", "", "asked about the API"); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := repo.Append(ctx, "did:plc:bob", "", "press contact"); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	note, err := repo.Get(ctx, "did:plc:alice")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if note.Note != "met at conf\nasked about the API" || note.Handle != "alice.bsky.social" {
+		t.Errorf("note = %q by %q", note.Note, note.Handle)
+	}
+	if note.UpdatedAt.Before(note.CreatedAt) {
+		t.Errorf("updated %v before created %v", note.UpdatedAt, note.CreatedAt)
+	}
+
+	if err := repo.Set(ctx, "did:plc:alice", "", "speaker"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	notes, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(notes) != 2 || notes[0].DID != "did:plc:alice" || notes[0].Note != "speaker" || notes[0].Handle != "alice.bsky.social" {
+		t.Errorf("unexpected notes %+v", notes)
+	}
+
+	restored := &NoteModel{
+		DID:       "did:plc:bob",
+		Note:      "synced",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	if err := repo.Restore(ctx, restored); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	note, err = repo.Get(ctx, "did:plc:bob")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if note.Note != "synced" || !note.CreatedAt.Equal(restored.CreatedAt) || !note.UpdatedAt.Equal(restored.UpdatedAt) {
+		t.Errorf("expected the restored note with its timestamps, got %+v", note)
+	}
+
+	if err := repo.Delete(ctx, "did:plc:bob"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Delete(ctx, "did:plc:bob"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleting a missing note: got %v, want ErrNotFound", err)
+	}
+}
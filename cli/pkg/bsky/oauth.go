@@ -0,0 +1,496 @@
+package bsky
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// OAuthScope is the scope requested at login: the account's identity plus the API access an app password grants
+const OAuthScope = "atproto transition:generic"
+
+// oauthCallbackPath is where the authorization server sends the browser back to
+const oauthCallbackPath = "/callback"
+
+// OAuthCredentials selects the OAuth path of [BlueskyService.Authenticate]. The caller resolves the account's
+// identity, since the PDS in its DID document names the authorization server to sign in with.
+type OAuthCredentials struct {
+	Handle string // shown to the authorization server as a login hint, and kept as the session's handle
+	DID    string // the account expected to sign in
+	PDSURL string // the account's PDS, which serves the API once signed in
+	Listen string // loopback address for the redirect back from the browser; defaults to 127.0.0.1:0
+
+	// Authorize sends the user to authURL, e.g. by opening a browser, and returns without waiting for them
+	Authorize func(authURL string) error
+}
+
+// OAuthSession is what an OAuth session keeps besides its tokens: where to refresh them, and the DPoP key they
+// are bound to. Tokens are useless without the key, so it is stored as carefully as they are.
+type OAuthSession struct {
+	Issuer        string
+	TokenEndpoint string
+	ClientID      string
+	DPoPKey       string // see [MarshalDPoPKey]
+}
+
+// OAuthError represents a failed step of an OAuth login or refresh
+type OAuthError struct {
+	Op  string
+	Err error
+}
+
+func (e *OAuthError) Error() string {
+	return "oauth." + e.Op + ": " + e.Err.Error()
+}
+
+func (e *OAuthError) Unwrap() error {
+	return e.Err
+}
+
+// oauthState is an OAuth session in use by the service
+type oauthState struct {
+	session OAuthSession
+	signer  *dpopSigner
+}
+
+// oauthResourceMetadata is a PDS's /.well-known/oauth-protected-resource document
+type oauthResourceMetadata struct {
+	AuthorizationServers []string `json:"authorization_servers"`
+}
+
+// oauthServerMetadata is an authorization server's /.well-known/oauth-authorization-server document
+type oauthServerMetadata struct {
+	Issuer                             string   `json:"issuer"`
+	AuthorizationEndpoint              string   `json:"authorization_endpoint"`
+	TokenEndpoint                      string   `json:"token_endpoint"`
+	PushedAuthorizationRequestEndpoint string   `json:"pushed_authorization_request_endpoint"`
+	DPoPSigningAlgValuesSupported      []string `json:"dpop_signing_alg_values_supported"`
+}
+
+// oauthTokenResponse is a token endpoint's answer to a code exchange or refresh
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+	Sub          string `json:"sub"`
+}
+
+// oauthErrorResponse is the error body of an OAuth endpoint
+type oauthErrorResponse struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// oauthCallback is what the browser brought back to the redirect listener
+type oauthCallback struct {
+	code string
+	err  error
+}
+
+// authenticateOAuth signs in through the account's authorization server with a loopback client: it pushes an
+// authorization request bound to a new DPoP key, sends the user to approve it, waits for the browser to come back
+// to a local listener, and exchanges the code for DPoP-bound tokens. ctx bounds the whole login, including the
+// time the user takes to approve it.
+func (s *BlueskyService) authenticateOAuth(ctx context.Context, creds OAuthCredentials) error {
+	if creds.DID == "" || creds.PDSURL == "" {
+		return &OAuthError{Op: "Authenticate", Err: errors.New("DID and PDS URL required")}
+	}
+	if creds.Authorize == nil {
+		return &OAuthError{Op: "Authenticate", Err: errors.New("no way to send the user to the authorization server")}
+	}
+	pds := strings.TrimSuffix(creds.PDSURL, "/")
+
+	server, err := s.oauthServer(ctx, pds)
+	if err != nil {
+		return err
+	}
+
+	listen := creds.Listen
+	if listen == "" {
+		listen = "127.0.0.1:0"
+	}
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return &OAuthError{Op: "Listen", Err: err}
+	}
+	defer listener.Close()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	redirectURI := fmt.Sprintf("http://127.0.0.1:%d%s", port, oauthCallbackPath)
+	// A loopback client needs no registration: its ID names the redirect and scope it will use
+	clientID := "http://localhost?" + url.Values{"redirect_uri": {redirectURI}, "scope": {OAuthScope}}.Encode()
+
+	signer, err := newDPoPSigner(nil)
+	if err != nil {
+		return err
+	}
+	verifier, err := randomToken(32)
+	if err != nil {
+		return &OAuthError{Op: "Authenticate", Err: err}
+	}
+	state, err := randomToken(16)
+	if err != nil {
+		return &OAuthError{Op: "Authenticate", Err: err}
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	form := url.Values{
+		"client_id":             {clientID},
+		"response_type":         {"code"},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {OAuthScope},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	if creds.Handle != "" {
+		form.Set("login_hint", creds.Handle)
+	}
+	var pushed struct {
+		RequestURI string `json:"request_uri"`
+	}
+	if err := s.oauthPost(ctx, signer, "PushAuthorization", server.PushedAuthorizationRequestEndpoint, form, &pushed); err != nil {
+		return err
+	}
+	if pushed.RequestURI == "" {
+		return &OAuthError{Op: "PushAuthorization", Err: errors.New("no request_uri in response")}
+	}
+
+	callbacks := make(chan oauthCallback, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(oauthCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("state") != state {
+			http.Error(w, "Unknown or expired login request.", http.StatusBadRequest)
+			return
+		}
+
+		var result oauthCallback
+		switch {
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("authorization refused: %s %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("iss") != server.Issuer:
+			result.err = fmt.Errorf("authorization came back from %q, not %q", query.Get("iss"), server.Issuer)
+		case query.Get("code") == "":
+			result.err = errors.New("no authorization code in redirect")
+		default:
+			result.code = query.Get("code")
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if result.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Sign-in failed: %v\n", result.err)
+		} else {
+			fmt.Fprintln(w, "Signed in. You can close this window and return to skycli.")
+		}
+		select {
+		case callbacks <- result:
+		default:
+		}
+	})
+	callbackServer := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go callbackServer.Serve(listener)
+	defer callbackServer.Close()
+
+	authURL := server.AuthorizationEndpoint + "?" + url.Values{"client_id": {clientID}, "request_uri": {pushed.RequestURI}}.Encode()
+	if err := creds.Authorize(authURL); err != nil {
+		return &OAuthError{Op: "Authorize", Err: err}
+	}
+
+	var callback oauthCallback
+	select {
+	case callback = <-callbacks:
+	case <-ctx.Done():
+		return &OAuthError{Op: "Authorize", Err: fmt.Errorf("no answer from the browser: %w", ctx.Err())}
+	}
+	if callback.err != nil {
+		return &OAuthError{Op: "Authorize", Err: callback.err}
+	}
+
+	var tokens oauthTokenResponse
+	err = s.oauthPost(ctx, signer, "ExchangeCode", server.TokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {callback.code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+		"client_id":     {clientID},
+	}, &tokens)
+	if err != nil {
+		return err
+	}
+	if err := checkOAuthTokens(&tokens, creds.DID); err != nil {
+		return &OAuthError{Op: "ExchangeCode", Err: err}
+	}
+
+	key, err := MarshalDPoPKey(signer.key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.baseURL = pds
+	s.oauth = &oauthState{
+		session: OAuthSession{Issuer: server.Issuer, TokenEndpoint: server.TokenEndpoint, ClientID: clientID, DPoPKey: key},
+		signer:  signer,
+	}
+	s.acceptOAuthTokens(&tokens)
+	s.did = tokens.Sub
+	s.handle = creds.Handle
+	s.authenticated = true
+
+	// Profiles carry viewer state that depends on the session
+	s.profiles.reset()
+	s.feeds.reset()
+
+	return nil
+}
+
+// oauthServer finds the authorization server that issues tokens for the PDS and checks it supports what the login
+// needs: pushed authorization requests and ES256 DPoP proofs
+func (s *BlueskyService) oauthServer(ctx context.Context, pds string) (*oauthServerMetadata, error) {
+	var resource oauthResourceMetadata
+	if err := s.oauthGet(ctx, pds+"/.well-known/oauth-protected-resource", &resource); err != nil {
+		return nil, &OAuthError{Op: "DiscoverResource", Err: err}
+	}
+	if len(resource.AuthorizationServers) == 0 {
+		return nil, &OAuthError{Op: "DiscoverResource", Err: fmt.Errorf("%s names no authorization server", pds)}
+	}
+	issuer := strings.TrimSuffix(resource.AuthorizationServers[0], "/")
+
+	var server oauthServerMetadata
+	if err := s.oauthGet(ctx, issuer+"/.well-known/oauth-authorization-server", &server); err != nil {
+		return nil, &OAuthError{Op: "DiscoverServer", Err: err}
+	}
+	switch {
+	case server.Issuer != issuer:
+		return nil, &OAuthError{Op: "DiscoverServer", Err: fmt.Errorf("metadata at %s is for issuer %q", issuer, server.Issuer)}
+	case server.PushedAuthorizationRequestEndpoint == "":
+		return nil, &OAuthError{Op: "DiscoverServer", Err: errors.New("authorization server doesn't accept pushed authorization requests")}
+	case server.AuthorizationEndpoint == "" || server.TokenEndpoint == "":
+		return nil, &OAuthError{Op: "DiscoverServer", Err: errors.New("authorization server metadata lacks an endpoint")}
+	case !slices.Contains(server.DPoPSigningAlgValuesSupported, "ES256"):
+		return nil, &OAuthError{Op: "DiscoverServer", Err: errors.New("authorization server doesn't accept ES256 DPoP proofs")}
+	}
+	return &server, nil
+}
+
+// oauthGet fetches a metadata document
+func (s *BlueskyService) oauthGet(ctx context.Context, target string, out any) error {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Auth))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("GET %s: %s - %s", target, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// oauthPost sends a form with a DPoP proof to an authorization server endpoint and decodes the JSON answer.
+// When the server asks for a DPoP nonce, the request is sent once more with it.
+func (s *BlueskyService) oauthPost(ctx context.Context, signer *dpopSigner, op, endpoint string, form url.Values, out any) error {
+	ctx, cancel := withTimeout(ctx, s.timeouts.or(s.timeouts.Auth))
+	defer cancel()
+
+	for attempt := 0; ; attempt++ {
+		proof, err := signer.proof(http.MethodPost, endpoint, "")
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+		if err != nil {
+			return &OAuthError{Op: op, Err: err}
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("DPoP", proof)
+
+		resp, err := s.do(req)
+		if err != nil {
+			return &OAuthError{Op: op, Err: err}
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+		if err != nil {
+			return &OAuthError{Op: op, Err: err}
+		}
+		newNonce := signer.saveNonce(endpoint, resp.Header.Get("DPoP-Nonce"))
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if err := json.Unmarshal(body, out); err != nil {
+				return &OAuthError{Op: op, Err: err}
+			}
+			return nil
+		}
+
+		var failure oauthErrorResponse
+		_ = json.Unmarshal(body, &failure)
+		if failure.Error == "use_dpop_nonce" && newNonce && attempt == 0 {
+			continue
+		}
+		if failure.Error == "" {
+			return &OAuthError{Op: op, Err: fmt.Errorf("%s - %s", resp.Status, strings.TrimSpace(string(body)))}
+		}
+		return &OAuthError{Op: op, Err: fmt.Errorf("%s: %s %s", resp.Status, failure.Error, failure.Description)}
+	}
+}
+
+// refreshOAuth exchanges the refresh token at the authorization server that issued it
+func (s *BlueskyService) refreshOAuth(ctx context.Context, oauth *oauthState, refreshToken string) (*oauthTokenResponse, error) {
+	var tokens oauthTokenResponse
+	err := s.oauthPost(ctx, oauth.signer, "Refresh", oauth.session.TokenEndpoint, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {oauth.session.ClientID},
+	}, &tokens)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOAuthTokens(&tokens, s.GetDid()); err != nil {
+		return nil, &OAuthError{Op: "Refresh", Err: err}
+	}
+	return &tokens, nil
+}
+
+// checkOAuthTokens makes sure a token response is DPoP-bound, carries the atproto scope, and is for did
+func checkOAuthTokens(tokens *oauthTokenResponse, did string) error {
+	switch {
+	case tokens.AccessToken == "" || tokens.RefreshToken == "":
+		return errors.New("token response lacks a token")
+	case !strings.EqualFold(tokens.TokenType, "DPoP"):
+		return fmt.Errorf("expected a DPoP token, got %q", tokens.TokenType)
+	case !slices.Contains(strings.Fields(tokens.Scope), ScopeOAuth):
+		return fmt.Errorf("token scope %q lacks %s", tokens.Scope, ScopeOAuth)
+	case did != "" && tokens.Sub != did:
+		return fmt.Errorf("signed in as %s, not %s", tokens.Sub, did)
+	}
+	return nil
+}
+
+// acceptOAuthTokens installs tokens from the authorization server, timing their refresh by expires_in since OAuth
+// access tokens need not be readable JWTs. Callers must hold s.mu.
+func (s *BlueskyService) acceptOAuthTokens(tokens *oauthTokenResponse) {
+	s.accessToken = tokens.AccessToken
+	s.refreshToken = tokens.RefreshToken
+	s.tokenIssuedAt, s.tokenExpiry = time.Time{}, time.Time{}
+	if tokens.ExpiresIn > 0 {
+		s.tokenIssuedAt = time.Now().Add(s.clockSkew)
+		s.tokenExpiry = s.tokenIssuedAt.Add(time.Duration(tokens.ExpiresIn) * time.Second)
+	}
+}
+
+// SetOAuth resumes a stored OAuth session: API requests go to the PDS at pdsURL with DPoP proofs signed by the
+// session's key, and refreshes go to its authorization server. Call it alongside [BlueskyService.SetTokens].
+// A nil session resumes a password session instead, on the default service.
+func (s *BlueskyService) SetOAuth(pdsURL string, session *OAuthSession) error {
+	if session == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.baseURL = defaultServiceURL
+		s.oauth = nil
+		return nil
+	}
+
+	key, err := ParseDPoPKey(session.DPoPKey)
+	if err != nil {
+		return err
+	}
+	signer, err := newDPoPSigner(key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pdsURL != "" {
+		s.baseURL = strings.TrimSuffix(pdsURL, "/")
+	}
+	s.oauth = &oauthState{session: *session, signer: signer}
+	return nil
+}
+
+// OAuthSession returns the OAuth details of the current session, or nil when it was created with a password
+func (s *BlueskyService) OAuthSession() *OAuthSession {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.oauth == nil {
+		return nil
+	}
+	session := s.oauth.session
+	return &session
+}
+
+// currentOAuth returns the OAuth state in use, if any
+func (s *BlueskyService) currentOAuth() *oauthState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.oauth
+}
+
+// authorize sets the credentials of an API request: a bearer token for password sessions, or a DPoP-bound token
+// with a fresh proof for OAuth sessions
+func (s *BlueskyService) authorize(req *http.Request, accessToken string) error {
+	oauth := s.currentOAuth()
+	if oauth == nil {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		return nil
+	}
+
+	proof, err := oauth.signer.proof(req.Method, req.URL.String(), accessToken)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "DPoP "+accessToken)
+	req.Header.Set("DPoP", proof)
+	return nil
+}
+
+// needsDPoPNonce records the DPoP nonce a PDS response carries and reports whether the request was refused only
+// for lacking it, in which case it can be sent again as is
+func (s *BlueskyService) needsDPoPNonce(req *http.Request, resp *http.Response) bool {
+	oauth := s.currentOAuth()
+	if oauth == nil {
+		return false
+	}
+	changed := oauth.signer.saveNonce(req.URL.String(), resp.Header.Get("DPoP-Nonce"))
+	return changed && resp.StatusCode == http.StatusUnauthorized &&
+		strings.Contains(resp.Header.Get("WWW-Authenticate"), "use_dpop_nonce")
+}
+
+// randomToken returns n random bytes, base64url encoded
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,306 @@
+package bsky
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// serverNonce is the DPoP nonce the fake server hands out; like a real PDS it keeps one per origin
+const serverNonce = "nonce-1"
+
+// fakeAuthServer is a PDS that is its own authorization server. It demands a DPoP nonce before anything else, as
+// real servers do, and checks every proof.
+type fakeAuthServer struct {
+	t         *testing.T
+	server    *httptest.Server
+	mu        sync.Mutex
+	challenge string
+	redirect  string
+	state     string
+	access    string // the access token the PDS currently accepts
+	refreshes int
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	f := &fakeAuthServer{t: t}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/oauth-protected-resource", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"authorization_servers": []string{f.server.URL}})
+	})
+	mux.HandleFunc("GET /.well-known/oauth-authorization-server", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                f.server.URL,
+			"authorization_endpoint":                f.server.URL + "/oauth/authorize",
+			"token_endpoint":                        f.server.URL + "/oauth/token",
+			"pushed_authorization_request_endpoint": f.server.URL + "/oauth/par",
+			"dpop_signing_alg_values_supported":     []string{"ES256"},
+		})
+	})
+	mux.HandleFunc("POST /oauth/par", func(w http.ResponseWriter, r *http.Request) {
+		if !f.checkProof(w, r, serverNonce, "") {
+			return
+		}
+		r.ParseForm()
+		if r.Form.Get("code_challenge_method") != "S256" || !strings.HasPrefix(r.Form.Get("client_id"), "http://localhost?") {
+			t.Errorf("unexpected authorization request %v", r.Form)
+		}
+		if r.Form.Get("login_hint") != "alice.test" {
+			t.Errorf("login_hint = %q", r.Form.Get("login_hint"))
+		}
+		f.mu.Lock()
+		f.challenge, f.redirect, f.state = r.Form.Get("code_challenge"), r.Form.Get("redirect_uri"), r.Form.Get("state")
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"request_uri": "urn:ietf:params:oauth:request_uri:1", "expires_in": 60})
+	})
+	mux.HandleFunc("POST /oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		if !f.checkProof(w, r, serverNonce, "") {
+			return
+		}
+		r.ParseForm()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		switch r.Form.Get("grant_type") {
+		case "authorization_code":
+			sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+			if r.Form.Get("code") != "code-1" || base64.RawURLEncoding.EncodeToString(sum[:]) != f.challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+			f.access = "access-1"
+			f.writeTokens(w, "access-1", "refresh-1")
+		case "refresh_token":
+			if r.Form.Get("refresh_token") != "refresh-1" {
+				t.Errorf("refresh with %q", r.Form.Get("refresh_token"))
+			}
+			f.refreshes++
+			f.access = "access-2"
+			f.writeTokens(w, "access-2", "refresh-2")
+		}
+	})
+	mux.HandleFunc("GET /xrpc/app.bsky.actor.getProfile", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		access := f.access
+		f.mu.Unlock()
+		if !f.checkProof(w, r, serverNonce, access) {
+			return
+		}
+		if r.Header.Get("Authorization") != "DPoP "+access {
+			w.Header().Set("WWW-Authenticate", `DPoP error="invalid_token"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"did": "did:plc:alice", "handle": "alice.test"})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeAuthServer) writeTokens(w http.ResponseWriter, access, refresh string) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token": access, "token_type": "DPoP", "refresh_token": refresh,
+		"expires_in": 3600, "scope": OAuthScope, "sub": "did:plc:alice",
+	})
+}
+
+// checkProof verifies the request's DPoP proof, asking for nonce first when the proof lacks it. Resource requests
+// must also bind accessToken through ath.
+func (f *fakeAuthServer) checkProof(w http.ResponseWriter, r *http.Request, nonce, accessToken string) bool {
+	parts := strings.Split(r.Header.Get("DPoP"), ".")
+	if len(parts) != 3 {
+		f.t.Errorf("%s: missing DPoP proof", r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+		return false
+	}
+	var header struct {
+		Typ string            `json:"typ"`
+		Alg string            `json:"alg"`
+		JWK map[string]string `json:"jwk"`
+	}
+	var claims map[string]any
+	headerJSON, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	json.Unmarshal(headerJSON, &header)
+	json.Unmarshal(claimsJSON, &claims)
+
+	if header.Typ != "dpop+jwt" || header.Alg != "ES256" || !verifyES256(header.JWK, parts[0]+"."+parts[1], signature) {
+		f.t.Errorf("%s: invalid DPoP proof header %+v", r.URL.Path, header)
+	}
+	if claims["htm"] != r.Method || claims["htu"] != f.server.URL+r.URL.Path {
+		f.t.Errorf("%s: proof is for %v %v", r.URL.Path, claims["htm"], claims["htu"])
+	}
+	if accessToken != "" && r.Header.Get("Authorization") == "DPoP "+accessToken {
+		sum := sha256.Sum256([]byte(accessToken))
+		if claims["ath"] != base64.RawURLEncoding.EncodeToString(sum[:]) {
+			f.t.Errorf("%s: proof doesn't bind the access token", r.URL.Path)
+		}
+	}
+
+	if claims["nonce"] == nonce {
+		return true
+	}
+	w.Header().Set("DPoP-Nonce", nonce)
+	if accessToken != "" {
+		w.Header().Set("WWW-Authenticate", `DPoP error="use_dpop_nonce"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	} else {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	json.NewEncoder(w).Encode(map[string]string{"error": "use_dpop_nonce"})
+	return false
+}
+
+// verifyES256 checks a JWS signature against a P-256 public JWK
+func verifyES256(jwk map[string]string, input string, signature []byte) bool {
+	x, errX := base64.RawURLEncoding.DecodeString(jwk["x"])
+	y, errY := base64.RawURLEncoding.DecodeString(jwk["y"])
+	if errX != nil || errY != nil || len(signature) != 64 {
+		return false
+	}
+	key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	digest := sha256.Sum256([]byte(input))
+	return ecdsa.Verify(key, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:]))
+}
+
+func TestBlueskyService_AuthenticateOAuth(t *testing.T) {
+	fake := newFakeAuthServer(t)
+	ctx := context.Background()
+
+	svc := NewBlueskyService("")
+	err := svc.Authenticate(ctx, OAuthCredentials{
+		Handle: "alice.test",
+		DID:    "did:plc:alice",
+		PDSURL: fake.server.URL,
+		Authorize: func(authURL string) error {
+			parsed, err := url.Parse(authURL)
+			if err != nil || parsed.Query().Get("request_uri") == "" {
+				t.Errorf("unexpected authorization URL %q", authURL)
+			}
+			// Play the browser coming back after approval
+			fake.mu.Lock()
+			redirect := fake.redirect + "?" + url.Values{"code": {"code-1"}, "state": {fake.state}, "iss": {fake.server.URL}}.Encode()
+			fake.mu.Unlock()
+			resp, err := http.Get(redirect)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("callback answered %s", resp.Status)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+
+	if !svc.Authenticated() || svc.GetDid() != "did:plc:alice" || svc.GetHandle() != "alice.test" {
+		t.Errorf("unexpected session: authenticated %v, did %q, handle %q", svc.Authenticated(), svc.GetDid(), svc.GetHandle())
+	}
+	if svc.BaseURL() != fake.server.URL {
+		t.Errorf("API should go to the PDS, got %s", svc.BaseURL())
+	}
+	session := svc.OAuthSession()
+	if session == nil || session.Issuer != fake.server.URL || session.TokenEndpoint != fake.server.URL+"/oauth/token" {
+		t.Fatalf("unexpected OAuth session %+v", session)
+	}
+
+	resp, err := svc.Request(ctx, http.MethodGet, "/xrpc/app.bsky.actor.getProfile?actor=alice.test", nil, nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("request with DPoP token answered %s", resp.Status)
+	}
+
+	t.Run("resume and refresh", func(t *testing.T) {
+		fake.mu.Lock()
+		fake.access = "rotated-elsewhere" // the stored access token is no longer accepted
+		fake.mu.Unlock()
+
+		resumed := NewBlueskyService("")
+		resumed.SetTokens("access-1", "refresh-1")
+		if err := resumed.SetOAuth(fake.server.URL, session); err != nil {
+			t.Fatalf("SetOAuth failed: %v", err)
+		}
+		tokens := &recordingTokenStore{}
+		resumed.SetTokenStore(tokens)
+		resumed.SetDid("did:plc:alice")
+
+		resp, err := resumed.Request(ctx, http.MethodGet, "/xrpc/app.bsky.actor.getProfile?actor=alice.test", nil, nil)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("request after refresh answered %s", resp.Status)
+		}
+		if fake.refreshes != 1 || len(tokens.updates) != 1 || tokens.updates[0] != [2]string{"access-2", "refresh-2"} {
+			t.Errorf("expected one stored refresh, got %d refreshes and updates %v", fake.refreshes, tokens.updates)
+		}
+	})
+}
+
+func TestBlueskyService_AuthenticateOAuth_Refused(t *testing.T) {
+	fake := newFakeAuthServer(t)
+
+	svc := NewBlueskyService("")
+	err := svc.Authenticate(context.Background(), OAuthCredentials{
+		Handle: "alice.test",
+		DID:    "did:plc:alice",
+		PDSURL: fake.server.URL,
+		Authorize: func(authURL string) error {
+			fake.mu.Lock()
+			redirect := fake.redirect + "?" + url.Values{"error": {"access_denied"}, "state": {fake.state}, "iss": {fake.server.URL}}.Encode()
+			fake.mu.Unlock()
+			resp, err := http.Get(redirect)
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("expected the refusal to be reported, got %v", err)
+	}
+	if svc.Authenticated() {
+		t.Error("service should not be authenticated after a refused login")
+	}
+}
+
+func TestDPoPKeyRoundTrip(t *testing.T) {
+	signer, err := newDPoPSigner(nil)
+	if err != nil {
+		t.Fatalf("newDPoPSigner failed: %v", err)
+	}
+	encoded, err := MarshalDPoPKey(signer.key)
+	if err != nil {
+		t.Fatalf("MarshalDPoPKey failed: %v", err)
+	}
+	key, err := ParseDPoPKey(encoded)
+	if err != nil {
+		t.Fatalf("ParseDPoPKey failed: %v", err)
+	}
+	if !key.Equal(signer.key) {
+		t.Error("key changed in a round trip")
+	}
+	if _, err := ParseDPoPKey("not a key"); err == nil {
+		t.Error("expected an error for a malformed key")
+	}
+}
@@ -0,0 +1,253 @@
+package bsky
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxPageSize is the largest page the AppView accepts for cursor-paginated endpoints
+const maxPageSize = 100
+
+// PageFunc fetches up to limit items starting at cursor and returns them with the next cursor ("" when exhausted)
+type PageFunc[T any] func(ctx context.Context, limit int, cursor string) ([]T, string, error)
+
+// PaginatorOptions configures a [Paginator].
+type PaginatorOptions struct {
+	PageSize int                     // items requested per page (default and ceiling 100)
+	Cursor   string                  // cursor to resume from
+	MaxItems int                     // stop once this many items are collected (0 = no cap)
+	Cap      int                     // fail with a [CapError] once more than this many items turn up (0 = no cap)
+	Interval time.Duration           // minimum delay between page requests
+	Progress func(page, fetched int) // called after each page with the running item count
+}
+
+// CapError reports that a [Paginator] found more items than its safety cap allows
+type CapError struct {
+	Cap int
+}
+
+func (e *CapError) Error() string {
+	return fmt.Sprintf("more than %d results, over the safety cap", e.Cap)
+}
+
+// Paginator walks a cursor-paginated endpoint one page at a time.
+type Paginator[T any] struct {
+	fetch     PageFunc[T]
+	opts      PaginatorOptions
+	cursor    string
+	page      int
+	fetched   int
+	done      bool
+	lastFetch time.Time
+}
+
+// NewPaginator creates a paginator over fetch
+func NewPaginator[T any](fetch PageFunc[T], opts PaginatorOptions) *Paginator[T] {
+	if opts.PageSize <= 0 || opts.PageSize > maxPageSize {
+		opts.PageSize = maxPageSize
+	}
+	return &Paginator[T]{fetch: fetch, opts: opts, cursor: opts.Cursor}
+}
+
+// HasNext reports whether another page may be fetched
+func (p *Paginator[T]) HasNext() bool {
+	return !p.done
+}
+
+// Cursor returns the cursor for the page after the last one fetched ("" when exhausted)
+func (p *Paginator[T]) Cursor() string {
+	return p.cursor
+}
+
+// Next fetches the next page, waiting out the configured interval first.
+// The final page is trimmed so the total never exceeds MaxItems.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	if p.opts.Interval > 0 && !p.lastFetch.IsZero() {
+		if wait := p.opts.Interval - time.Since(p.lastFetch); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	limit := p.opts.PageSize
+	if p.opts.MaxItems > 0 {
+		limit = min(limit, p.opts.MaxItems-p.fetched)
+	}
+
+	p.lastFetch = time.Now()
+	items, next, err := p.fetch(ctx, limit, p.cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.opts.MaxItems > 0 && p.fetched+len(items) > p.opts.MaxItems {
+		items = items[:p.opts.MaxItems-p.fetched]
+	}
+
+	p.page++
+	p.fetched += len(items)
+	p.cursor = next
+	if p.opts.Cap > 0 && p.fetched > p.opts.Cap {
+		p.done = true
+		return nil, &CapError{Cap: p.opts.Cap}
+	}
+	p.done = next == "" || (p.opts.MaxItems > 0 && p.fetched >= p.opts.MaxItems)
+
+	if p.opts.Progress != nil {
+		p.opts.Progress(p.page, p.fetched)
+	}
+
+	return items, nil
+}
+
+// All drains the paginator and returns every remaining item
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.HasNext() {
+		items, err := p.Next(ctx)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// FollowerPages pages through the accounts following actor
+func FollowerPages(fetcher FollowerFetcher, actor string) PageFunc[ActorProfile] {
+	return func(ctx context.Context, limit int, cursor string) ([]ActorProfile, string, error) {
+		response, err := fetcher.GetFollowers(ctx, actor, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Followers, response.Cursor, nil
+	}
+}
+
+// FollowPages pages through the accounts actor follows
+func FollowPages(fetcher FollowerFetcher, actor string) PageFunc[ActorProfile] {
+	return func(ctx context.Context, limit int, cursor string) ([]ActorProfile, string, error) {
+		response, err := fetcher.GetFollows(ctx, actor, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Follows, response.Cursor, nil
+	}
+}
+
+// ListMemberPages pages through the accounts on a list
+func ListMemberPages(fetcher FollowerFetcher, list string) PageFunc[ActorProfile] {
+	return func(ctx context.Context, limit int, cursor string) ([]ActorProfile, string, error) {
+		response, err := fetcher.GetList(ctx, list, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		members := make([]ActorProfile, len(response.Items))
+		for i, item := range response.Items {
+			members[i] = item.Subject
+		}
+		return members, response.Cursor, nil
+	}
+}
+
+// TimelinePages pages through the authenticated user's home timeline
+func TimelinePages(s FeedReader) PageFunc[FeedViewPost] {
+	return func(ctx context.Context, limit int, cursor string) ([]FeedViewPost, string, error) {
+		response, err := s.GetTimeline(ctx, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Feed, response.Cursor, nil
+	}
+}
+
+// CustomFeedPages pages through the custom feed whose generator is at feed
+func CustomFeedPages(s FeedReader, feed string) PageFunc[FeedViewPost] {
+	return func(ctx context.Context, limit int, cursor string) ([]FeedViewPost, string, error) {
+		response, err := s.GetFeed(ctx, feed, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Feed, response.Cursor, nil
+	}
+}
+
+// AuthorFeedPages pages through posts by actor
+func AuthorFeedPages(s EngagementFetcher, actor string) PageFunc[FeedViewPost] {
+	return func(ctx context.Context, limit int, cursor string) ([]FeedViewPost, string, error) {
+		response, err := s.GetAuthorFeed(ctx, actor, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Feed, response.Cursor, nil
+	}
+}
+
+// ActorLikesPages pages through the posts actor liked
+func ActorLikesPages(s EngagementFetcher, actor string) PageFunc[FeedViewPost] {
+	return func(ctx context.Context, limit int, cursor string) ([]FeedViewPost, string, error) {
+		response, err := s.GetActorLikes(ctx, actor, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Feed, response.Cursor, nil
+	}
+}
+
+// RecordPages pages through the records in one collection of repo
+func RecordPages(fetcher RecordFetcher, repo, collection string) PageFunc[Record] {
+	return func(ctx context.Context, limit int, cursor string) ([]Record, string, error) {
+		response, err := fetcher.ListRecords(ctx, repo, collection, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Records, response.Cursor, nil
+	}
+}
+
+// LikerPages pages through the accounts that liked the post at uri
+func LikerPages(fetcher EngagementFetcher, uri string) PageFunc[ActorProfile] {
+	return func(ctx context.Context, limit int, cursor string) ([]ActorProfile, string, error) {
+		response, err := fetcher.GetLikes(ctx, uri, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		actors := make([]ActorProfile, len(response.Likes))
+		for i, like := range response.Likes {
+			actors[i] = like.Actor
+		}
+		return actors, response.Cursor, nil
+	}
+}
+
+// RepostPages pages through the accounts that reposted the post at uri
+func RepostPages(fetcher EngagementFetcher, uri string) PageFunc[ActorProfile] {
+	return func(ctx context.Context, limit int, cursor string) ([]ActorProfile, string, error) {
+		response, err := fetcher.GetRepostedBy(ctx, uri, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.RepostedBy, response.Cursor, nil
+	}
+}
+
+// NotificationPages pages through the signed-in user's notifications, newest first
+func NotificationPages(fetcher NotificationFetcher) PageFunc[Notification] {
+	return func(ctx context.Context, limit int, cursor string) ([]Notification, string, error) {
+		response, err := fetcher.ListNotifications(ctx, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Notifications, response.Cursor, nil
+	}
+}
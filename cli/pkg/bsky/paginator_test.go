@@ -0,0 +1,283 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// numberPages serves the integers [0, total) with cursors holding the next offset, recording each requested limit
+func numberPages(total int, limits *[]int) PageFunc[int] {
+	return func(ctx context.Context, limit int, cursor string) ([]int, string, error) {
+		*limits = append(*limits, limit)
+		start := 0
+		if cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+		end := min(start+limit, total)
+
+		items := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			items = append(items, i)
+		}
+
+		next := ""
+		if end < total {
+			next = strconv.Itoa(end)
+		}
+		return items, next, nil
+	}
+}
+
+func TestPaginator_All(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int
+		opts       PaginatorOptions
+		wantCount  int
+		wantLimits []int
+		wantCursor string
+	}{
+		{name: "SinglePage", total: 5, opts: PaginatorOptions{PageSize: 10}, wantCount: 5, wantLimits: []int{10}},
+		{name: "MultiplePages", total: 25, opts: PaginatorOptions{PageSize: 10}, wantCount: 25, wantLimits: []int{10, 10, 10}},
+		{name: "DefaultPageSize", total: 150, wantCount: 150, wantLimits: []int{100, 100}},
+		{name: "PageSizeCapped", total: 150, opts: PaginatorOptions{PageSize: 500}, wantCount: 150, wantLimits: []int{100, 100}},
+		{name: "MaxItemsShrinksLastRequest", total: 100, opts: PaginatorOptions{PageSize: 10, MaxItems: 25}, wantCount: 25, wantLimits: []int{10, 10, 5}, wantCursor: "25"},
+		{name: "ResumeFromCursor", total: 30, opts: PaginatorOptions{PageSize: 10, Cursor: "20"}, wantCount: 10, wantLimits: []int{10}},
+		{name: "Empty", total: 0, opts: PaginatorOptions{PageSize: 10}, wantCount: 0, wantLimits: []int{10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var limits []int
+			paginator := NewPaginator(numberPages(tt.total, &limits), tt.opts)
+
+			items, err := paginator.All(context.Background())
+			if err != nil {
+				t.Fatalf("All failed: %v", err)
+			}
+			if len(items) != tt.wantCount {
+				t.Errorf("expected %d items, got %d", tt.wantCount, len(items))
+			}
+			if len(limits) != len(tt.wantLimits) {
+				t.Fatalf("expected requests %v, got %v", tt.wantLimits, limits)
+			}
+			for i := range limits {
+				if limits[i] != tt.wantLimits[i] {
+					t.Errorf("request %d: expected limit %d, got %d", i, tt.wantLimits[i], limits[i])
+				}
+			}
+			if paginator.Cursor() != tt.wantCursor {
+				t.Errorf("expected cursor %q, got %q", tt.wantCursor, paginator.Cursor())
+			}
+			if paginator.HasNext() {
+				t.Error("expected paginator to be exhausted")
+			}
+		})
+	}
+}
+
+func TestPaginator_Progress(t *testing.T) {
+	var limits []int
+	var pages, fetched []int
+	paginator := NewPaginator(numberPages(25, &limits), PaginatorOptions{
+		PageSize: 10,
+		Progress: func(page, total int) {
+			pages = append(pages, page)
+			fetched = append(fetched, total)
+		},
+	})
+
+	if _, err := paginator.All(context.Background()); err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+
+	wantFetched := []int{10, 20, 25}
+	if len(fetched) != len(wantFetched) {
+		t.Fatalf("expected progress %v, got %v", wantFetched, fetched)
+	}
+	for i := range wantFetched {
+		if pages[i] != i+1 || fetched[i] != wantFetched[i] {
+			t.Errorf("progress %d: expected (%d, %d), got (%d, %d)", i, i+1, wantFetched[i], pages[i], fetched[i])
+		}
+	}
+}
+
+func TestPaginator_Error(t *testing.T) {
+	calls := 0
+	paginator := NewPaginator(func(ctx context.Context, limit int, cursor string) ([]int, string, error) {
+		calls++
+		if calls == 2 {
+			return nil, "", errors.New("rate limited")
+		}
+		return []int{calls}, "next", nil
+	}, PaginatorOptions{})
+
+	items, err := paginator.All(context.Background())
+	if err == nil {
+		t.Fatal("expected error from second page")
+	}
+	if len(items) != 1 {
+		t.Errorf("expected items from pages before the error, got %v", items)
+	}
+}
+
+func TestPaginator_Cap(t *testing.T) {
+	var limits []int
+	items, err := NewPaginator(numberPages(30, &limits), PaginatorOptions{PageSize: 10, Cap: 25}).All(context.Background())
+	var capErr *CapError
+	if !errors.As(err, &capErr) || capErr.Cap != 25 {
+		t.Fatalf("expected a CapError for 25, got %v", err)
+	}
+	if len(items) != 20 || len(limits) != 3 {
+		t.Errorf("expected to stop on the page that crossed the cap, got %d items after %d requests", len(items), len(limits))
+	}
+
+	limits = nil
+	items, err = NewPaginator(numberPages(25, &limits), PaginatorOptions{PageSize: 10, Cap: 25}).All(context.Background())
+	if err != nil || len(items) != 25 {
+		t.Errorf("a total equal to the cap should be allowed, got %d items (%v)", len(items), err)
+	}
+
+	limits = nil
+	items, err = NewPaginator(numberPages(100, &limits), PaginatorOptions{PageSize: 10, MaxItems: 30, Cap: 25}).All(context.Background())
+	if !errors.As(err, &capErr) {
+		t.Errorf("MaxItems above the cap should still fail, got %d items (%v)", len(items), err)
+	}
+}
+
+func TestPaginator_Interval(t *testing.T) {
+	var limits []int
+	interval := 20 * time.Millisecond
+	paginator := NewPaginator(numberPages(3, &limits), PaginatorOptions{PageSize: 1, Interval: interval})
+
+	start := time.Now()
+	if _, err := paginator.All(context.Background()); err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("expected at least %v between 3 pages, took %v", 2*interval, elapsed)
+	}
+}
+
+func TestPaginator_IntervalHonorsContext(t *testing.T) {
+	var limits []int
+	paginator := NewPaginator(numberPages(3, &limits), PaginatorOptions{PageSize: 1, Interval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := paginator.Next(ctx); err != nil {
+		t.Fatalf("first Next failed: %v", err)
+	}
+	cancel()
+
+	if _, err := paginator.Next(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled while waiting, got %v", err)
+	}
+}
+
+func TestFollowerPages(t *testing.T) {
+	graph := &stubGraph{followers: []ActorProfile{{Did: "did:plc:a"}, {Did: "did:plc:b"}}}
+
+	profiles, err := NewPaginator(FollowerPages(graph, "did:plc:me"), PaginatorOptions{PageSize: 1}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+	if len(profiles) != 2 || profiles[1].Did != "did:plc:b" {
+		t.Errorf("unexpected followers: %+v", profiles)
+	}
+}
+
+func TestListMemberPages(t *testing.T) {
+	graph := &stubGraph{followers: []ActorProfile{{Did: "did:plc:a"}, {Did: "did:plc:b"}, {Did: "did:plc:c"}}}
+
+	members, err := NewPaginator(ListMemberPages(graph, "at://did:plc:me/app.bsky.graph.list/vip"), PaginatorOptions{PageSize: 2}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+	if len(members) != 3 || members[2].Did != "did:plc:c" {
+		t.Errorf("unexpected members: %+v", members)
+	}
+}
+
+func TestLikerPages(t *testing.T) {
+	graph := &stubGraph{followers: []ActorProfile{{Did: "did:plc:a"}, {Did: "did:plc:b"}, {Did: "did:plc:c"}}}
+
+	likers, err := NewPaginator(LikerPages(graph, "at://did:plc:me/app.bsky.feed.post/1"), PaginatorOptions{PageSize: 2}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+	if len(likers) != 3 || likers[0].Did != "did:plc:a" || likers[2].Did != "did:plc:c" {
+		t.Errorf("unexpected likers: %+v", likers)
+	}
+}
+
+// stubGraph is a [FollowerFetcher] and [EngagementFetcher] serving followers as members, likers, and reposters
+type stubGraph struct {
+	followers []ActorProfile
+}
+
+func (g *stubGraph) Authenticated() bool { return true }
+func (g *stubGraph) GetDid() string      { return "did:plc:me" }
+
+func (g *stubGraph) GetFollowers(ctx context.Context, actor string, limit int, cursor string) (*GetFollowersResponse, error) {
+	start, _ := strconv.Atoi(cursor)
+	end := min(start+limit, len(g.followers))
+	next := ""
+	if end < len(g.followers) {
+		next = strconv.Itoa(end)
+	}
+	return &GetFollowersResponse{Followers: g.followers[start:end], Cursor: next}, nil
+}
+
+func (g *stubGraph) GetFollows(ctx context.Context, actor string, limit int, cursor string) (*GetFollowsResponse, error) {
+	return &GetFollowsResponse{}, nil
+}
+
+func (g *stubGraph) GetRelationships(ctx context.Context, actor string, others []string) (*GetRelationshipsResponse, error) {
+	return &GetRelationshipsResponse{Actor: actor}, nil
+}
+
+func (g *stubGraph) GetList(ctx context.Context, list string, limit int, cursor string) (*GetListResponse, error) {
+	start, _ := strconv.Atoi(cursor)
+	end := min(start+limit, len(g.followers))
+	next := ""
+	if end < len(g.followers) {
+		next = strconv.Itoa(end)
+	}
+	items := make([]ListItemView, 0, end-start)
+	for _, profile := range g.followers[start:end] {
+		items = append(items, ListItemView{Subject: profile})
+	}
+	return &GetListResponse{List: ListView{Uri: list}, Items: items, Cursor: next}, nil
+}
+
+func (g *stubGraph) GetAuthorFeed(ctx context.Context, actor string, limit int, cursor string) (*GetAuthorFeedResponse, error) {
+	return &GetAuthorFeedResponse{}, nil
+}
+
+func (g *stubGraph) GetActorLikes(ctx context.Context, actor string, limit int, cursor string) (*GetActorLikesResponse, error) {
+	return &GetActorLikesResponse{}, nil
+}
+
+func (g *stubGraph) GetLikes(ctx context.Context, uri string, limit int, cursor string) (*GetLikesResponse, error) {
+	page, err := g.GetFollowers(ctx, "", limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+	likes := make([]Like, len(page.Followers))
+	for i, profile := range page.Followers {
+		likes[i] = Like{Actor: profile}
+	}
+	return &GetLikesResponse{Uri: uri, Likes: likes, Cursor: page.Cursor}, nil
+}
+
+func (g *stubGraph) GetRepostedBy(ctx context.Context, uri string, limit int, cursor string) (*GetRepostedByResponse, error) {
+	page, err := g.GetFollowers(ctx, "", limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return &GetRepostedByResponse{Uri: uri, RepostedBy: page.Followers, Cursor: page.Cursor}, nil
+}
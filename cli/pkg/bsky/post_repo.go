@@ -0,0 +1,826 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PostRepository implements Repository for PostModel on SQLite or PostgreSQL with batch operations
+type PostRepository struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// NewPostRepository creates a new post repository with SQLite backend
+func NewPostRepository() (*PostRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostRepository{db: db}, nil
+}
+
+// NewSQLitePostRepository creates a new post repository backed by the SQLite database file at path
+func NewSQLitePostRepository(path string) (*PostRepository, error) {
+	db, err := openDB(DialectSQLite, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostRepository{db: db, dialect: DialectSQLite}, nil
+}
+
+// NewPostgresPostRepository creates a new post repository backed by the PostgreSQL database at dsn
+func NewPostgresPostRepository(dsn string) (*PostRepository, error) {
+	db, err := openDB(DialectPostgres, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostRepository{db: db, dialect: DialectPostgres}, nil
+}
+
+// Init ensures database schema is initialized via the dialect's migrations
+func (r *PostRepository) Init(ctx context.Context) error {
+	if r.dialect == DialectPostgres {
+		return RunDialectMigrations(r.db, r.dialect)
+	}
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *PostRepository) Close() error {
+	return r.db.Close()
+}
+
+// Get retrieves a post by ID
+func (r *PostRepository) Get(ctx context.Context, id string) (Model, error) {
+	query := `
+		SELECT id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at,
+			like_count, repost_count, reply_count, quote_count
+		FROM posts
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	var post PostModel
+	var postID string
+	var createdAt, updatedAt time.Time
+	var engagement nullEngagement
+
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(query), id).Scan(
+		&postID,
+		&createdAt,
+		&updatedAt,
+		&post.URI,
+		&post.AuthorDID,
+		&post.Text,
+		&post.FeedID,
+		&post.IndexedAt,
+		&engagement.likes,
+		&engagement.reposts,
+		&engagement.replies,
+		&engagement.quotes,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &RepositoryError{Op: "Get", Entity: "post", ID: id, Err: fmt.Errorf("post %w", ErrNotFound)}
+		}
+		return nil, &RepositoryError{Op: "Get", Entity: "post", ID: id, Err: err}
+	}
+
+	post.SetID(postID)
+	post.SetCreatedAt(createdAt)
+	post.SetUpdatedAt(updatedAt)
+	post.Engagement = engagement.counts()
+
+	return &post, nil
+}
+
+// List retrieves all posts not in the trash ordered by indexed_at descending
+func (r *PostRepository) List(ctx context.Context) ([]Model, error) {
+	query := `
+		SELECT id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at,
+			like_count, repost_count, reply_count, quote_count
+		FROM posts
+		WHERE deleted_at IS NULL
+		ORDER BY indexed_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query))
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "post", Err: err}
+	}
+	defer rows.Close()
+
+	var posts []Model
+	for rows.Next() {
+		var post PostModel
+		var postID string
+		var createdAt, updatedAt time.Time
+		var engagement nullEngagement
+
+		err := rows.Scan(
+			&postID,
+			&createdAt,
+			&updatedAt,
+			&post.URI,
+			&post.AuthorDID,
+			&post.Text,
+			&post.FeedID,
+			&post.IndexedAt,
+			&engagement.likes,
+			&engagement.reposts,
+			&engagement.replies,
+			&engagement.quotes,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "post", Err: err}
+		}
+
+		post.SetID(postID)
+		post.SetCreatedAt(createdAt)
+		post.SetUpdatedAt(updatedAt)
+		post.Engagement = engagement.counts()
+
+		posts = append(posts, &post)
+	}
+
+	return posts, rows.Err()
+}
+
+// Save creates or updates a post
+func (r *PostRepository) Save(ctx context.Context, model Model) error {
+	post, ok := model.(*PostModel)
+	if !ok {
+		return &RepositoryError{Op: "Save", Entity: "post", Err: errors.New("invalid model type: expected *PostModel")}
+	}
+
+	if post.ID() == "" {
+		post.SetID(GenerateUUID())
+		post.SetCreatedAt(time.Now())
+	}
+	post.SetUpdatedAt(time.Now())
+
+	query := `
+		INSERT INTO posts (id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at,
+			like_count, repost_count, reply_count, quote_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(uri) DO UPDATE SET
+			updated_at = excluded.updated_at,
+			text = excluded.text,
+			feed_id = excluded.feed_id,
+			like_count = COALESCE(excluded.like_count, posts.like_count),
+			repost_count = COALESCE(excluded.repost_count, posts.repost_count),
+			reply_count = COALESCE(excluded.reply_count, posts.reply_count),
+			quote_count = COALESCE(excluded.quote_count, posts.quote_count)
+	`
+
+	likes, reposts, replies, quotes := engagementValues(post.Engagement)
+	_, err := r.db.ExecContext(ctx, r.dialect.Rebind(query),
+		post.ID(),
+		post.CreatedAt(),
+		post.UpdatedAt(),
+		post.URI,
+		post.AuthorDID,
+		post.Text,
+		post.FeedID,
+		post.IndexedAt,
+		likes,
+		reposts,
+		replies,
+		quotes,
+	)
+
+	if err != nil {
+		return &RepositoryError{Op: "Save", Entity: "post", ID: post.ID(), Err: err}
+	}
+
+	return nil
+}
+
+// nullEngagement scans engagement counts, which are NULL for posts stored without them
+type nullEngagement struct {
+	likes, reposts, replies, quotes sql.NullInt64
+}
+
+// counts returns the scanned counts, or nil when they weren't recorded
+func (n nullEngagement) counts() *PostEngagement {
+	if !n.likes.Valid {
+		return nil
+	}
+	return &PostEngagement{Likes: int(n.likes.Int64), Reposts: int(n.reposts.Int64), Replies: int(n.replies.Int64), Quotes: int(n.quotes.Int64)}
+}
+
+// engagementValues returns the columns to store for e; without counts they are NULL, so saving the post again
+// keeps counts recorded earlier
+func engagementValues(e *PostEngagement) (likes, reposts, replies, quotes any) {
+	if e == nil {
+		return nil, nil, nil, nil
+	}
+	return e.Likes, e.Reposts, e.Replies, e.Quotes
+}
+
+// Delete moves a post to the trash, from which [PostRepository.Undelete] brings it back
+func (r *PostRepository) Delete(ctx context.Context, id string) error {
+	query := "UPDATE posts SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL"
+	result, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), time.Now().UTC(), id)
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "post", ID: id, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "post", ID: id, Err: err}
+	}
+
+	if rows == 0 {
+		return &RepositoryError{Op: "Delete", Entity: "post", ID: id, Err: fmt.Errorf("post %w", ErrNotFound)}
+	}
+
+	return nil
+}
+
+// DeleteByFeedID moves every post stored under a feed to the trash and returns how many were moved
+func (r *PostRepository) DeleteByFeedID(ctx context.Context, feedID string) (int64, error) {
+	query := "UPDATE posts SET deleted_at = ? WHERE feed_id = ? AND deleted_at IS NULL"
+	result, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), time.Now().UTC(), feedID)
+	if err != nil {
+		return 0, &RepositoryError{Op: "DeleteByFeedID", Entity: "post", ID: feedID, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &RepositoryError{Op: "DeleteByFeedID", Entity: "post", ID: feedID, Err: err}
+	}
+	return rows, nil
+}
+
+// Trash retrieves posts in the trash, most recently deleted first, leaving out posts stored under skipFeeds; limit
+// caps the rows returned and 0 returns all
+func (r *PostRepository) Trash(ctx context.Context, limit int, skipFeeds ...string) ([]*PostModel, error) {
+	query := `SELECT id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at, deleted_at,
+		like_count, repost_count, reply_count, quote_count FROM posts
+		WHERE deleted_at IS NOT NULL`
+	var args []any
+	if len(skipFeeds) > 0 {
+		query += " AND feed_id NOT IN (" + buildPlaceholders(len(skipFeeds)) + ")"
+		for _, id := range skipFeeds {
+			args = append(args, id)
+		}
+	}
+	query += " ORDER BY deleted_at DESC, indexed_at DESC"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Trash", Entity: "post", Err: err}
+	}
+	defer rows.Close()
+
+	var posts []*PostModel
+	for rows.Next() {
+		var post PostModel
+		var postID string
+		var createdAt, updatedAt time.Time
+		var engagement nullEngagement
+
+		err := rows.Scan(
+			&postID,
+			&createdAt,
+			&updatedAt,
+			&post.URI,
+			&post.AuthorDID,
+			&post.Text,
+			&post.FeedID,
+			&post.IndexedAt,
+			&post.DeletedAt,
+			&engagement.likes,
+			&engagement.reposts,
+			&engagement.replies,
+			&engagement.quotes,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "Trash", Entity: "post", Err: err}
+		}
+
+		post.SetID(postID)
+		post.SetCreatedAt(createdAt)
+		post.SetUpdatedAt(updatedAt)
+		post.Engagement = engagement.counts()
+
+		posts = append(posts, &post)
+	}
+
+	return posts, rows.Err()
+}
+
+// CountTrash returns the number of posts in the trash for each feed, keyed by feed ID
+func (r *PostRepository) CountTrash(ctx context.Context) (map[string]int, error) {
+	query := "SELECT feed_id, COUNT(*) FROM posts WHERE deleted_at IS NOT NULL GROUP BY feed_id"
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query))
+	if err != nil {
+		return nil, &RepositoryError{Op: "CountTrash", Entity: "post", Err: err}
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var feedID string
+		var count int
+		if err := rows.Scan(&feedID, &count); err != nil {
+			return nil, &RepositoryError{Op: "CountTrash", Entity: "post", Err: err}
+		}
+		counts[feedID] = count
+	}
+	return counts, rows.Err()
+}
+
+// Undelete takes a post back out of the trash
+func (r *PostRepository) Undelete(ctx context.Context, id string) error {
+	query := "UPDATE posts SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL"
+	result, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), id)
+	if err != nil {
+		return &RepositoryError{Op: "Undelete", Entity: "post", ID: id, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return &RepositoryError{Op: "Undelete", Entity: "post", ID: id, Err: err}
+	}
+
+	if rows == 0 {
+		return &RepositoryError{Op: "Undelete", Entity: "post", ID: id, Err: fmt.Errorf("post in trash %w", ErrNotFound)}
+	}
+
+	return nil
+}
+
+// UndeleteByFeedID takes every post stored under a feed back out of the trash and returns how many were restored
+func (r *PostRepository) UndeleteByFeedID(ctx context.Context, feedID string) (int64, error) {
+	query := "UPDATE posts SET deleted_at = NULL WHERE feed_id = ? AND deleted_at IS NOT NULL"
+	result, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), feedID)
+	if err != nil {
+		return 0, &RepositoryError{Op: "UndeleteByFeedID", Entity: "post", ID: feedID, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &RepositoryError{Op: "UndeleteByFeedID", Entity: "post", ID: feedID, Err: err}
+	}
+	return rows, nil
+}
+
+// EmptyTrash permanently removes the posts deleted before the given time, or every post in the trash when before is
+// zero, and returns how many were removed
+func (r *PostRepository) EmptyTrash(ctx context.Context, before time.Time) (int64, error) {
+	query := "DELETE FROM posts WHERE deleted_at IS NOT NULL"
+	var args []any
+	if !before.IsZero() {
+		query += " AND deleted_at < ?"
+		args = append(args, before.UTC())
+	}
+
+	result, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return 0, &RepositoryError{Op: "EmptyTrash", Entity: "post", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &RepositoryError{Op: "EmptyTrash", Entity: "post", Err: err}
+	}
+	return rows, nil
+}
+
+// BatchSave efficiently saves multiple posts in a single transaction
+func (r *PostRepository) BatchSave(ctx context.Context, posts []*PostModel) (err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "PostRepository.BatchSave", attribute.Int("db.batch.size", len(posts)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if len(posts) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &RepositoryError{Op: "BatchSave", Entity: "post", Err: err}
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO posts (id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at,
+			like_count, repost_count, reply_count, quote_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(uri) DO UPDATE SET
+			updated_at = excluded.updated_at,
+			text = excluded.text,
+			feed_id = excluded.feed_id,
+			like_count = COALESCE(excluded.like_count, posts.like_count),
+			repost_count = COALESCE(excluded.repost_count, posts.repost_count),
+			reply_count = COALESCE(excluded.reply_count, posts.reply_count),
+			quote_count = COALESCE(excluded.quote_count, posts.quote_count)
+	`
+
+	stmt, err := tx.PrepareContext(ctx, r.dialect.Rebind(query))
+	if err != nil {
+		return &RepositoryError{Op: "BatchSave", Entity: "post", Err: err}
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, post := range posts {
+		if post.ID() == "" {
+			post.SetID(GenerateUUID())
+			post.SetCreatedAt(now)
+		}
+		post.SetUpdatedAt(now)
+
+		likes, reposts, replies, quotes := engagementValues(post.Engagement)
+		_, err := stmt.ExecContext(ctx,
+			post.ID(),
+			post.CreatedAt(),
+			post.UpdatedAt(),
+			post.URI,
+			post.AuthorDID,
+			post.Text,
+			post.FeedID,
+			post.IndexedAt,
+			likes,
+			reposts,
+			replies,
+			quotes,
+		)
+		if err != nil {
+			return &RepositoryError{Op: "BatchSave", Entity: "post", Err: err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &RepositoryError{Op: "BatchSave", Entity: "post", Err: err}
+	}
+
+	return nil
+}
+
+// QueryByFeedID retrieves posts for a specific feed with pagination
+func (r *PostRepository) QueryByFeedID(ctx context.Context, feedID string, limit, offset int) (_ []*PostModel, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "PostRepository.QueryByFeedID")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	query := `
+		SELECT id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at,
+			like_count, repost_count, reply_count, quote_count
+		FROM posts
+		WHERE feed_id = ? AND deleted_at IS NULL
+		ORDER BY indexed_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), feedID, limit, offset)
+	if err != nil {
+		return nil, &RepositoryError{Op: "QueryByFeedID", Entity: "post", Err: err}
+	}
+	defer rows.Close()
+
+	var posts []*PostModel
+	for rows.Next() {
+		var post PostModel
+		var postID string
+		var createdAt, updatedAt time.Time
+		var engagement nullEngagement
+
+		err := rows.Scan(
+			&postID,
+			&createdAt,
+			&updatedAt,
+			&post.URI,
+			&post.AuthorDID,
+			&post.Text,
+			&post.FeedID,
+			&post.IndexedAt,
+			&engagement.likes,
+			&engagement.reposts,
+			&engagement.replies,
+			&engagement.quotes,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "QueryByFeedID", Entity: "post", Err: err}
+		}
+
+		post.SetID(postID)
+		post.SetCreatedAt(createdAt)
+		post.SetUpdatedAt(updatedAt)
+		post.Engagement = engagement.counts()
+
+		posts = append(posts, &post)
+	}
+
+	return posts, rows.Err()
+}
+
+// CountByFeedID returns the total number of posts for a feed
+func (r *PostRepository) CountByFeedID(ctx context.Context, feedID string) (int, error) {
+	query := "SELECT COUNT(*) FROM posts WHERE feed_id = ? AND deleted_at IS NULL"
+
+	var count int
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(query), feedID).Scan(&count)
+	if err != nil {
+		return 0, &RepositoryError{Op: "CountByFeedID", Entity: "post", Err: err}
+	}
+
+	return count, nil
+}
+
+// ExistingURIs reports which of uris are already stored, as a set of the stored URIs. Posts in the trash count as
+// stored, so fetching again does not bring them back.
+func (r *PostRepository) ExistingURIs(ctx context.Context, uris []string) (map[string]bool, error) {
+	feeds, err := r.StoredFeedIDs(ctx, uris)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(feeds))
+	for uri := range feeds {
+		existing[uri] = true
+	}
+	return existing, nil
+}
+
+// StoredFeedIDs returns the feed each of uris is stored under, keyed by URI and leaving out URIs not stored; posts
+// in the trash are included
+func (r *PostRepository) StoredFeedIDs(ctx context.Context, uris []string) (map[string]string, error) {
+	feeds := make(map[string]string)
+	if len(uris) == 0 {
+		return feeds, nil
+	}
+
+	args := make([]any, len(uris))
+	for i, uri := range uris {
+		args[i] = uri
+	}
+
+	query := "SELECT uri, feed_id FROM posts WHERE uri IN (" + buildPlaceholders(len(uris)) + ")"
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, &RepositoryError{Op: "StoredFeedIDs", Entity: "post", Err: err}
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var uri, feedID string
+		if err := rows.Scan(&uri, &feedID); err != nil {
+			return nil, &RepositoryError{Op: "StoredFeedIDs", Entity: "post", Err: err}
+		}
+		feeds[uri] = feedID
+	}
+	return feeds, rows.Err()
+}
+
+// PostQuery filters stored posts; zero-valued fields are ignored and posts in the trash never match
+type PostQuery struct {
+	FeedIDs  []string  // restrict to posts from these feeds
+	Author   string    // author DID
+	Since    time.Time // posts indexed at or after this time
+	Contains string    // case-insensitive substring of the post text
+	Terms    []string  // case-insensitive words that must all appear in the post text, in any order
+	Tagged   *TagSet   // restrict to posts that are tagged, stored under tagged feeds, or by tagged authors
+	Limit    int       // maximum rows returned by Query; 0 returns all
+}
+
+// where builds the WHERE clause and arguments for q
+func (q PostQuery) where() (string, []any) {
+	clauses := []string{"deleted_at IS NULL"}
+	var args []any
+
+	if len(q.FeedIDs) > 0 {
+		clauses = append(clauses, "feed_id IN ("+buildPlaceholders(len(q.FeedIDs))+")")
+		for _, id := range q.FeedIDs {
+			args = append(args, id)
+		}
+	}
+	if q.Author != "" {
+		clauses = append(clauses, "author_did = ?")
+		args = append(args, q.Author)
+	}
+	if !q.Since.IsZero() {
+		clauses = append(clauses, "indexed_at >= ?")
+		args = append(args, q.Since.UTC())
+	}
+	if q.Tagged != nil {
+		clauses = append(clauses, q.Tagged.where(&args))
+	}
+	for _, text := range append([]string{q.Contains}, q.Terms...) {
+		if text == "" {
+			continue
+		}
+		clauses = append(clauses, `LOWER(text) LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+likeEscaper.Replace(strings.ToLower(text))+"%")
+	}
+
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// where builds the clause matching posts in s, appending its arguments to args; an empty set matches nothing
+func (s *TagSet) where(args *[]any) string {
+	var matches []string
+	for _, match := range []struct {
+		column string
+		values []string
+	}{{"feed_id", s.Feeds}, {"uri", s.Posts}, {"author_did", s.Actors}} {
+		if len(match.values) == 0 {
+			continue
+		}
+		matches = append(matches, match.column+" IN ("+buildPlaceholders(len(match.values))+")")
+		for _, value := range match.values {
+			*args = append(*args, value)
+		}
+	}
+	if len(matches) == 0 {
+		return "1 = 0"
+	}
+	return "(" + strings.Join(matches, " OR ") + ")"
+}
+
+// likeEscaper escapes LIKE wildcards so search text matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// Query retrieves stored posts matching q ordered by indexed_at descending
+func (r *PostRepository) Query(ctx context.Context, q PostQuery) (_ []*PostModel, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "PostRepository.Query")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	where, args := q.where()
+	query := `SELECT id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at,
+		like_count, repost_count, reply_count, quote_count FROM posts` +
+		where + " ORDER BY indexed_at DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Query", Entity: "post", Err: err}
+	}
+	defer rows.Close()
+
+	var posts []*PostModel
+	for rows.Next() {
+		var post PostModel
+		var postID string
+		var createdAt, updatedAt time.Time
+		var engagement nullEngagement
+
+		err := rows.Scan(
+			&postID,
+			&createdAt,
+			&updatedAt,
+			&post.URI,
+			&post.AuthorDID,
+			&post.Text,
+			&post.FeedID,
+			&post.IndexedAt,
+			&engagement.likes,
+			&engagement.reposts,
+			&engagement.replies,
+			&engagement.quotes,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "Query", Entity: "post", Err: err}
+		}
+
+		post.SetID(postID)
+		post.SetCreatedAt(createdAt)
+		post.SetUpdatedAt(updatedAt)
+		post.Engagement = engagement.counts()
+
+		posts = append(posts, &post)
+	}
+
+	return posts, rows.Err()
+}
+
+// Count returns the number of stored posts matching q, ignoring its Limit
+func (r *PostRepository) Count(ctx context.Context, q PostQuery) (count int, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "PostRepository.Count")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	where, args := q.where()
+	err = r.db.QueryRowContext(ctx, r.dialect.Rebind("SELECT COUNT(*) FROM posts"+where), args...).Scan(&count)
+	if err != nil {
+		return 0, &RepositoryError{Op: "Count", Entity: "post", Err: err}
+	}
+
+	return count, nil
+}
+
+// AuthorStats summarizes the stored posts of a single author
+type AuthorStats struct {
+	AuthorDID     string
+	Posts         int
+	FirstPost     time.Time
+	LastPost      time.Time
+	AvgTextLength float64
+}
+
+// AuthorStats aggregates posts matching q by author, most prolific first; q.Limit caps the number of authors
+func (r *PostRepository) AuthorStats(ctx context.Context, q PostQuery) (_ []*AuthorStats, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "PostRepository.AuthorStats")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	where, args := q.where()
+	query := `SELECT author_did, COUNT(*), MIN(indexed_at), MAX(indexed_at), AVG(LENGTH(text)) FROM posts` +
+		where + " GROUP BY author_did ORDER BY COUNT(*) DESC, author_did"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, &RepositoryError{Op: "AuthorStats", Entity: "post", Err: err}
+	}
+	defer rows.Close()
+
+	var stats []*AuthorStats
+	for rows.Next() {
+		var s AuthorStats
+		var first, last aggregateTime
+		if err := rows.Scan(&s.AuthorDID, &s.Posts, &first, &last, &s.AvgTextLength); err != nil {
+			return nil, &RepositoryError{Op: "AuthorStats", Entity: "post", Err: err}
+		}
+		s.FirstPost = first.Time
+		s.LastPost = last.Time
+		stats = append(stats, &s)
+	}
+
+	return stats, rows.Err()
+}
+
+// aggregateTimeLayouts are the text forms SQLite stores timestamps in, as written by the SQLite driver, tried in order
+var aggregateTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// aggregateTime scans MIN/MAX of a timestamp column, which SQLite returns as text since aggregates lose the column type
+type aggregateTime struct {
+	time.Time
+}
+
+func (t *aggregateTime) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		t.Time = time.Time{}
+		return nil
+	case time.Time:
+		t.Time = v
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	default:
+		return fmt.Errorf("cannot scan %T into time", value)
+	}
+}
+
+func (t *aggregateTime) parse(s string) error {
+	s = strings.TrimSuffix(s, "Z")
+	for _, layout := range aggregateTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as time", s)
+}
@@ -0,0 +1,945 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+// TestPostRepository_Init verifies repository initialization
+func TestPostRepository_Init(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+
+	err := repo.Init(context.Background())
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM posts").Scan(&count)
+	if err != nil {
+		t.Errorf("posts table not created: %v", err)
+	}
+}
+
+// TestPostRepository_Save creates a new post
+func TestPostRepository_Save(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	post := &PostModel{
+		URI:       "at://did:plc:test/app.bsky.feed.post/123",
+		AuthorDID: "did:plc:test",
+		Text:      "Hello, world!",
+		FeedID:    "feed-123",
+		IndexedAt: time.Now(),
+	}
+
+	err := repo.Save(context.Background(), post)
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if post.ID() == "" {
+		t.Error("expected ID to be set after Save")
+	}
+	if post.CreatedAt().IsZero() {
+		t.Error("expected CreatedAt to be set after Save")
+	}
+	if post.UpdatedAt().IsZero() {
+		t.Error("expected UpdatedAt to be set after Save")
+	}
+}
+
+// TestPostRepository_Get retrieves a post by ID
+func TestPostRepository_Get(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	indexedTime := time.Now()
+	post := &PostModel{
+		URI:       "at://did:plc:test/app.bsky.feed.post/456",
+		AuthorDID: "did:plc:author",
+		Text:      "Test post content",
+		FeedID:    "feed-456",
+		IndexedAt: indexedTime,
+	}
+
+	if err := repo.Save(context.Background(), post); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	retrieved, err := repo.Get(context.Background(), post.ID())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	retrievedPost, ok := retrieved.(*PostModel)
+	if !ok {
+		t.Fatal("expected *PostModel")
+	}
+
+	if retrievedPost.URI != "at://did:plc:test/app.bsky.feed.post/456" {
+		t.Errorf("expected URI 'at://did:plc:test/app.bsky.feed.post/456', got %s", retrievedPost.URI)
+	}
+	if retrievedPost.AuthorDID != "did:plc:author" {
+		t.Errorf("expected AuthorDID 'did:plc:author', got %s", retrievedPost.AuthorDID)
+	}
+	if retrievedPost.Text != "Test post content" {
+		t.Errorf("expected Text 'Test post content', got %s", retrievedPost.Text)
+	}
+	if retrievedPost.FeedID != "feed-456" {
+		t.Errorf("expected FeedID 'feed-456', got %s", retrievedPost.FeedID)
+	}
+}
+
+// TestPostRepository_Get_NotFound verifies error on missing post
+func TestPostRepository_Get_NotFound(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	_, err := repo.Get(context.Background(), "nonexistent-id")
+	if err == nil {
+		t.Error("expected error for nonexistent post")
+	}
+}
+
+// TestPostRepository_List retrieves all posts ordered by indexed_at DESC
+func TestPostRepository_List(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now()
+	post1 := &PostModel{
+		URI:       "at://test/post1",
+		AuthorDID: "did:plc:1",
+		Text:      "Post 1",
+		FeedID:    "feed-1",
+		IndexedAt: now.Add(-2 * time.Hour),
+	}
+	post2 := &PostModel{
+		URI:       "at://test/post2",
+		AuthorDID: "did:plc:2",
+		Text:      "Post 2",
+		FeedID:    "feed-1",
+		IndexedAt: now,
+	}
+
+	if err := repo.Save(context.Background(), post1); err != nil {
+		t.Fatalf("Save post1 failed: %v", err)
+	}
+	if err := repo.Save(context.Background(), post2); err != nil {
+		t.Fatalf("Save post2 failed: %v", err)
+	}
+
+	posts, err := repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if len(posts) != 2 {
+		t.Errorf("expected 2 posts, got %d", len(posts))
+	}
+
+	// List should order by indexed_at DESC, so post2 should be first
+	if p, ok := posts[0].(*PostModel); ok {
+		if p.Text != "Post 2" {
+			t.Errorf("expected first post to be 'Post 2', got %s", p.Text)
+		}
+	}
+}
+
+// TestPostRepository_Update modifies an existing post
+func TestPostRepository_Update(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	post := &PostModel{
+		URI:       "at://test/updatepost",
+		AuthorDID: "did:plc:author",
+		Text:      "Original text",
+		FeedID:    "feed-1",
+		IndexedAt: time.Now(),
+	}
+
+	if err := repo.Save(context.Background(), post); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	originalCreatedAt := post.CreatedAt()
+
+	post.Text = "Updated text"
+
+	if err := repo.Save(context.Background(), post); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if post.CreatedAt() != originalCreatedAt {
+		t.Error("CreatedAt should not change on update")
+	}
+
+	retrieved, err := repo.Get(context.Background(), post.ID())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	updatedPost := retrieved.(*PostModel)
+	if updatedPost.Text != "Updated text" {
+		t.Errorf("expected Text 'Updated text', got %s", updatedPost.Text)
+	}
+}
+
+// TestPostRepository_Delete removes a post
+func TestPostRepository_Delete(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	post := &PostModel{
+		URI:       "at://test/deletepost",
+		AuthorDID: "did:plc:author",
+		Text:      "To be deleted",
+		FeedID:    "feed-1",
+		IndexedAt: time.Now(),
+	}
+
+	if err := repo.Save(context.Background(), post); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	err := repo.Delete(context.Background(), post.ID())
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	_, err = repo.Get(context.Background(), post.ID())
+	if err == nil {
+		t.Error("expected error when getting deleted post")
+	}
+}
+
+// TestPostRepository_Delete_NotFound verifies error on deleting nonexistent post
+func TestPostRepository_Delete_NotFound(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	err := repo.Delete(context.Background(), "nonexistent-id")
+	if err == nil {
+		t.Error("expected error when deleting nonexistent post")
+	}
+}
+
+// TestPostRepository_Trash moves posts to the trash one at a time and by feed, and brings them back
+func TestPostRepository_Trash(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := &PostRepository{db: db}
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now()
+	posts := []*PostModel{
+		{URI: "at://test/trash/1", AuthorDID: "did:plc:a", Text: "first", FeedID: "feed-1", IndexedAt: now},
+		{URI: "at://test/trash/2", AuthorDID: "did:plc:a", Text: "second", FeedID: "feed-1", IndexedAt: now},
+		{URI: "at://test/trash/3", AuthorDID: "did:plc:b", Text: "third", FeedID: "feed-2", IndexedAt: now},
+	}
+	if err := repo.BatchSave(ctx, posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	if err := repo.Delete(ctx, posts[2].ID()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	moved, err := repo.DeleteByFeedID(ctx, "feed-1")
+	if err != nil || moved != 2 {
+		t.Fatalf("DeleteByFeedID = %d, %v; want 2", moved, err)
+	}
+
+	if count, err := repo.Count(ctx, PostQuery{}); err != nil || count != 0 {
+		t.Errorf("Count = %d, %v; want 0 with every post in the trash", count, err)
+	}
+	if count, err := repo.CountByFeedID(ctx, "feed-1"); err != nil || count != 0 {
+		t.Errorf("CountByFeedID = %d, %v; want 0", count, err)
+	}
+	if existing, err := repo.ExistingURIs(ctx, []string{posts[0].URI}); err != nil || !existing[posts[0].URI] {
+		t.Errorf("ExistingURIs = %v, %v; want trashed posts counted as stored", existing, err)
+	}
+
+	trash, err := repo.Trash(ctx, 0)
+	if err != nil {
+		t.Fatalf("Trash failed: %v", err)
+	}
+	if len(trash) != 3 || trash[0].DeletedAt.IsZero() {
+		t.Errorf("Trash returned %d posts, want 3 with DeletedAt set", len(trash))
+	}
+	if limited, err := repo.Trash(ctx, 1); err != nil || len(limited) != 1 {
+		t.Errorf("Trash with limit 1 returned %d posts, %v", len(limited), err)
+	}
+	if loose, err := repo.Trash(ctx, 0, "feed-1"); err != nil || len(loose) != 1 || loose[0].ID() != posts[2].ID() {
+		t.Errorf("Trash skipping feed-1 returned %d posts, %v; want only the post from feed-2", len(loose), err)
+	}
+
+	counts, err := repo.CountTrash(ctx)
+	if err != nil {
+		t.Fatalf("CountTrash failed: %v", err)
+	}
+	if counts["feed-1"] != 2 || counts["feed-2"] != 1 {
+		t.Errorf("CountTrash = %v, want feed-1:2 feed-2:1", counts)
+	}
+
+	restored, err := repo.UndeleteByFeedID(ctx, "feed-1")
+	if err != nil || restored != 2 {
+		t.Errorf("UndeleteByFeedID = %d, %v; want 2", restored, err)
+	}
+	if err := repo.Undelete(ctx, posts[2].ID()); err != nil {
+		t.Fatalf("Undelete failed: %v", err)
+	}
+	if count, err := repo.Count(ctx, PostQuery{}); err != nil || count != 3 {
+		t.Errorf("Count after restoring = %d, %v; want 3", count, err)
+	}
+
+	if err := repo.Delete(ctx, posts[0].ID()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	removed, err := repo.EmptyTrash(ctx, time.Time{})
+	if err != nil || removed != 1 {
+		t.Errorf("EmptyTrash = %d, %v; want 1", removed, err)
+	}
+	if err := repo.Undelete(ctx, posts[0].ID()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("undeleting an emptied post: got %v, want ErrNotFound", err)
+	}
+}
+
+// TestPostRepository_InvalidModelType verifies type checking on Save
+func TestPostRepository_InvalidModelType(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	invalidModel := &FeedModel{Name: "test", Source: "timeline", IsLocal: true}
+
+	err := repo.Save(context.Background(), invalidModel)
+	if err == nil {
+		t.Error("expected error when saving invalid model type")
+	}
+}
+
+// TestPostRepository_BatchSave inserts multiple posts efficiently
+func TestPostRepository_BatchSave(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now()
+	posts := []*PostModel{
+		{URI: "at://test/batch1", AuthorDID: "did:plc:1", Text: "Batch 1", FeedID: "feed-1", IndexedAt: now},
+		{URI: "at://test/batch2", AuthorDID: "did:plc:2", Text: "Batch 2", FeedID: "feed-1", IndexedAt: now},
+		{URI: "at://test/batch3", AuthorDID: "did:plc:3", Text: "Batch 3", FeedID: "feed-2", IndexedAt: now},
+	}
+
+	err := repo.BatchSave(context.Background(), posts)
+	if err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	for _, post := range posts {
+		if post.ID() == "" {
+			t.Error("expected ID to be set after BatchSave")
+		}
+	}
+
+	allPosts, err := repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if len(allPosts) != 3 {
+		t.Errorf("expected 3 posts, got %d", len(allPosts))
+	}
+}
+
+func TestPostRepository_StoredFeedIDs(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	posts := []*PostModel{
+		{URI: "at://test/a", AuthorDID: "did:plc:1", FeedID: "feed-1", IndexedAt: time.Now()},
+		{URI: "at://test/b", AuthorDID: "did:plc:1", FeedID: "feed-2", IndexedAt: time.Now()},
+	}
+	if err := repo.BatchSave(context.Background(), posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	feeds, err := repo.StoredFeedIDs(context.Background(), []string{"at://test/a", "at://test/b", "at://test/missing"})
+	if err != nil {
+		t.Fatalf("StoredFeedIDs failed: %v", err)
+	}
+	if len(feeds) != 2 || feeds["at://test/a"] != "feed-1" || feeds["at://test/b"] != "feed-2" {
+		t.Errorf("unexpected feeds %v", feeds)
+	}
+
+	existing, err := repo.ExistingURIs(context.Background(), []string{"at://test/b", "at://test/missing"})
+	if err != nil || len(existing) != 1 || !existing["at://test/b"] {
+		t.Errorf("ExistingURIs = %v, %v", existing, err)
+	}
+}
+
+// TestPostRepository_BatchSave_Empty verifies handling of empty slice
+func TestPostRepository_BatchSave_Empty(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	err := repo.BatchSave(context.Background(), []*PostModel{})
+	if err != nil {
+		t.Errorf("BatchSave with empty slice should not error: %v", err)
+	}
+}
+
+// TestPostRepository_URIConflict verifies ON CONFLICT behavior for duplicate URIs
+func TestPostRepository_URIConflict(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	post1 := &PostModel{
+		URI:       "at://test/conflict",
+		AuthorDID: "did:plc:author",
+		Text:      "Original text",
+		FeedID:    "feed-1",
+		IndexedAt: time.Now(),
+	}
+
+	if err := repo.Save(context.Background(), post1); err != nil {
+		t.Fatalf("Save post1 failed: %v", err)
+	}
+
+	post2 := &PostModel{
+		URI:       "at://test/conflict",
+		AuthorDID: "did:plc:author",
+		Text:      "Updated text",
+		FeedID:    "feed-2",
+		IndexedAt: time.Now(),
+	}
+
+	if err := repo.Save(context.Background(), post2); err != nil {
+		t.Fatalf("Save post2 failed: %v", err)
+	}
+
+	retrieved, err := repo.Get(context.Background(), post1.ID())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	retrievedPost := retrieved.(*PostModel)
+	if retrievedPost.Text != "Updated text" {
+		t.Errorf("expected Text 'Updated text', got %s", retrievedPost.Text)
+	}
+	if retrievedPost.FeedID != "feed-2" {
+		t.Errorf("expected FeedID 'feed-2', got %s", retrievedPost.FeedID)
+	}
+}
+
+// TestPostRepository_Engagement stores engagement counts and keeps them when the post is saved again without any
+func TestPostRepository_Engagement(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	counts := &PostEngagement{Likes: 12, Reposts: 3, Replies: 2, Quotes: 1}
+	posts := []*PostModel{
+		{URI: "at://test/counted", AuthorDID: "did:plc:author", FeedID: "feed-1", IndexedAt: time.Now(), Engagement: counts},
+		{URI: "at://test/imported", AuthorDID: "did:plc:author", FeedID: "feed-1", IndexedAt: time.Now()},
+	}
+	if err := repo.BatchSave(ctx, posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+	if err := repo.Save(ctx, &PostModel{URI: "at://test/counted", AuthorDID: "did:plc:author", Text: "edited", FeedID: "feed-1"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	stored, err := repo.QueryByFeedID(ctx, "feed-1", 10, 0)
+	if err != nil {
+		t.Fatalf("QueryByFeedID failed: %v", err)
+	}
+	for _, post := range stored {
+		switch post.URI {
+		case "at://test/counted":
+			if post.Engagement == nil || *post.Engagement != *counts || post.Engagement.Total() != 18 {
+				t.Errorf("expected counts %+v to be kept, got %+v", counts, post.Engagement)
+			}
+		case "at://test/imported":
+			if post.Engagement != nil {
+				t.Errorf("expected no counts for a post stored without them, got %+v", post.Engagement)
+			}
+		}
+	}
+}
+
+// TestPostRepository_QueryByFeedID retrieves posts for a specific feed with pagination
+func TestPostRepository_QueryByFeedID(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now()
+	posts := []*PostModel{
+		{URI: "at://test/f1p1", AuthorDID: "did:plc:1", Text: "Feed 1 Post 1", FeedID: "feed-1", IndexedAt: now.Add(-3 * time.Hour)},
+		{URI: "at://test/f1p2", AuthorDID: "did:plc:2", Text: "Feed 1 Post 2", FeedID: "feed-1", IndexedAt: now.Add(-2 * time.Hour)},
+		{URI: "at://test/f1p3", AuthorDID: "did:plc:3", Text: "Feed 1 Post 3", FeedID: "feed-1", IndexedAt: now.Add(-1 * time.Hour)},
+		{URI: "at://test/f2p1", AuthorDID: "did:plc:4", Text: "Feed 2 Post 1", FeedID: "feed-2", IndexedAt: now},
+	}
+
+	if err := repo.BatchSave(context.Background(), posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	feed1Posts, err := repo.QueryByFeedID(context.Background(), "feed-1", 10, 0)
+	if err != nil {
+		t.Fatalf("QueryByFeedID failed: %v", err)
+	}
+
+	if len(feed1Posts) != 3 {
+		t.Errorf("expected 3 posts for feed-1, got %d", len(feed1Posts))
+	}
+
+	// Posts should be ordered by indexed_at DESC
+	if feed1Posts[0].Text != "Feed 1 Post 3" {
+		t.Errorf("expected first post to be 'Feed 1 Post 3', got %s", feed1Posts[0].Text)
+	}
+}
+
+// TestPostRepository_QueryByFeedID_Pagination verifies pagination behavior
+func TestPostRepository_QueryByFeedID_Pagination(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now()
+	posts := []*PostModel{
+		{URI: "at://test/p1", AuthorDID: "did:plc:1", Text: "Post 1", FeedID: "feed-1", IndexedAt: now.Add(-4 * time.Hour)},
+		{URI: "at://test/p2", AuthorDID: "did:plc:2", Text: "Post 2", FeedID: "feed-1", IndexedAt: now.Add(-3 * time.Hour)},
+		{URI: "at://test/p3", AuthorDID: "did:plc:3", Text: "Post 3", FeedID: "feed-1", IndexedAt: now.Add(-2 * time.Hour)},
+		{URI: "at://test/p4", AuthorDID: "did:plc:4", Text: "Post 4", FeedID: "feed-1", IndexedAt: now.Add(-1 * time.Hour)},
+		{URI: "at://test/p5", AuthorDID: "did:plc:5", Text: "Post 5", FeedID: "feed-1", IndexedAt: now},
+	}
+
+	if err := repo.BatchSave(context.Background(), posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	page1, err := repo.QueryByFeedID(context.Background(), "feed-1", 2, 0)
+	if err != nil {
+		t.Fatalf("QueryByFeedID page 1 failed: %v", err)
+	}
+
+	if len(page1) != 2 {
+		t.Errorf("expected 2 posts in page 1, got %d", len(page1))
+	}
+	if page1[0].Text != "Post 5" {
+		t.Errorf("expected first post 'Post 5', got %s", page1[0].Text)
+	}
+
+	page2, err := repo.QueryByFeedID(context.Background(), "feed-1", 2, 2)
+	if err != nil {
+		t.Fatalf("QueryByFeedID page 2 failed: %v", err)
+	}
+
+	if len(page2) != 2 {
+		t.Errorf("expected 2 posts in page 2, got %d", len(page2))
+	}
+	if page2[0].Text != "Post 3" {
+		t.Errorf("expected first post in page 2 'Post 3', got %s", page2[0].Text)
+	}
+}
+
+// TestPostRepository_CountByFeedID counts posts for a feed
+func TestPostRepository_CountByFeedID(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now()
+	posts := []*PostModel{
+		{URI: "at://test/c1", AuthorDID: "did:plc:1", Text: "Count 1", FeedID: "feed-1", IndexedAt: now},
+		{URI: "at://test/c2", AuthorDID: "did:plc:2", Text: "Count 2", FeedID: "feed-1", IndexedAt: now},
+		{URI: "at://test/c3", AuthorDID: "did:plc:3", Text: "Count 3", FeedID: "feed-2", IndexedAt: now},
+	}
+
+	if err := repo.BatchSave(context.Background(), posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	count1, err := repo.CountByFeedID(context.Background(), "feed-1")
+	if err != nil {
+		t.Fatalf("CountByFeedID feed-1 failed: %v", err)
+	}
+
+	if count1 != 2 {
+		t.Errorf("expected count 2 for feed-1, got %d", count1)
+	}
+
+	count2, err := repo.CountByFeedID(context.Background(), "feed-2")
+	if err != nil {
+		t.Fatalf("CountByFeedID feed-2 failed: %v", err)
+	}
+
+	if count2 != 1 {
+		t.Errorf("expected count 1 for feed-2, got %d", count2)
+	}
+
+	count3, err := repo.CountByFeedID(context.Background(), "nonexistent")
+	if err != nil {
+		t.Fatalf("CountByFeedID nonexistent failed: %v", err)
+	}
+
+	if count3 != 0 {
+		t.Errorf("expected count 0 for nonexistent feed, got %d", count3)
+	}
+}
+
+// TestPostRepository_Query verifies filtering by feed, author, date, and text
+func TestPostRepository_Query(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now().UTC()
+	posts := []*PostModel{
+		{URI: "at://test/q1", AuthorDID: "did:plc:alice", Text: "Golang release notes", FeedID: "feed-1", IndexedAt: now.Add(-72 * time.Hour)},
+		{URI: "at://test/q2", AuthorDID: "did:plc:bob", Text: "Notes on the new Go release", FeedID: "feed-1", IndexedAt: now.Add(-2 * time.Hour)},
+		{URI: "at://test/q3", AuthorDID: "did:plc:alice", Text: "100% coverage_report", FeedID: "feed-2", IndexedAt: now.Add(-1 * time.Hour)},
+		{URI: "at://test/q4", AuthorDID: "did:plc:carol", Text: "Coffee", FeedID: "feed-3", IndexedAt: now},
+	}
+	if err := repo.BatchSave(context.Background(), posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query PostQuery
+		want  []string
+	}{
+		{"no filters", PostQuery{}, []string{"at://test/q4", "at://test/q3", "at://test/q2", "at://test/q1"}},
+		{"feeds", PostQuery{FeedIDs: []string{"feed-1", "feed-2"}}, []string{"at://test/q3", "at://test/q2", "at://test/q1"}},
+		{"author", PostQuery{Author: "did:plc:alice"}, []string{"at://test/q3", "at://test/q1"}},
+		{"since", PostQuery{Since: now.Add(-3 * time.Hour)}, []string{"at://test/q4", "at://test/q3", "at://test/q2"}},
+		{"contains is case-insensitive", PostQuery{Contains: "RELEASE NOTES"}, []string{"at://test/q1"}},
+		{"terms match in any order", PostQuery{Terms: []string{"notes", "release"}}, []string{"at://test/q2", "at://test/q1"}},
+		{"wildcards match literally", PostQuery{Contains: "0% coverage_"}, []string{"at://test/q3"}},
+		{"underscore is not a wildcard", PostQuery{Contains: "golang_"}, nil},
+		{"combined", PostQuery{Author: "did:plc:alice", Terms: []string{"golang"}}, []string{"at://test/q1"}},
+		{"limit", PostQuery{Limit: 2}, []string{"at://test/q4", "at://test/q3"}},
+		{"tagged feeds, posts, or authors", PostQuery{Tagged: &TagSet{Feeds: []string{"feed-3"}, Posts: []string{"at://test/q1"}, Actors: []string{"did:plc:bob"}}}, []string{"at://test/q4", "at://test/q2", "at://test/q1"}},
+		{"tagged and filtered", PostQuery{Tagged: &TagSet{Actors: []string{"did:plc:alice"}}, FeedIDs: []string{"feed-2"}}, []string{"at://test/q3"}},
+		{"empty tag matches nothing", PostQuery{Tagged: &TagSet{}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.Query(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d posts, got %d", len(tt.want), len(got))
+			}
+			for i, post := range got {
+				if post.URI != tt.want[i] {
+					t.Errorf("post %d: expected %s, got %s", i, tt.want[i], post.URI)
+				}
+			}
+
+			count, err := repo.Count(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("Count failed: %v", err)
+			}
+			if tt.query.Limit == 0 && count != len(tt.want) {
+				t.Errorf("expected count %d, got %d", len(tt.want), count)
+			}
+		})
+	}
+}
+
+// TestPostRepository_Count_IgnoresLimit verifies Count reports every match regardless of Limit
+func TestPostRepository_Count_IgnoresLimit(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now()
+	posts := []*PostModel{
+		{URI: "at://test/l1", AuthorDID: "did:plc:1", Text: "one", FeedID: "feed-1", IndexedAt: now},
+		{URI: "at://test/l2", AuthorDID: "did:plc:1", Text: "two", FeedID: "feed-1", IndexedAt: now},
+		{URI: "at://test/l3", AuthorDID: "did:plc:1", Text: "three", FeedID: "feed-1", IndexedAt: now},
+	}
+	if err := repo.BatchSave(context.Background(), posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	count, err := repo.Count(context.Background(), PostQuery{Author: "did:plc:1", Limit: 1})
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected count 3, got %d", count)
+	}
+}
+
+// TestPostRepository_AuthorStats verifies per-author counts, date ranges, and text lengths
+func TestPostRepository_AuthorStats(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	base := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	posts := []*PostModel{
+		{URI: "at://test/a1", AuthorDID: "did:plc:alice", Text: "ab", FeedID: "feed-1", IndexedAt: base},
+		{URI: "at://test/a2", AuthorDID: "did:plc:alice", Text: "abcd", FeedID: "feed-1", IndexedAt: base.Add(48 * time.Hour)},
+		{URI: "at://test/a3", AuthorDID: "did:plc:alice", Text: "abcdef", FeedID: "feed-2", IndexedAt: base.Add(24 * time.Hour)},
+		{URI: "at://test/b1", AuthorDID: "did:plc:bob", Text: "hello", FeedID: "feed-2", IndexedAt: base.Add(time.Hour)},
+	}
+	if err := repo.BatchSave(context.Background(), posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	stats, err := repo.AuthorStats(context.Background(), PostQuery{})
+	if err != nil {
+		t.Fatalf("AuthorStats failed: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 authors, got %d", len(stats))
+	}
+
+	alice := stats[0]
+	if alice.AuthorDID != "did:plc:alice" || alice.Posts != 3 {
+		t.Errorf("expected alice first with 3 posts, got %s with %d", alice.AuthorDID, alice.Posts)
+	}
+	if !alice.FirstPost.Equal(base) || !alice.LastPost.Equal(base.Add(48*time.Hour)) {
+		t.Errorf("unexpected date range %v - %v", alice.FirstPost, alice.LastPost)
+	}
+	if alice.AvgTextLength != 4 {
+		t.Errorf("expected average text length 4, got %v", alice.AvgTextLength)
+	}
+
+	filtered, err := repo.AuthorStats(context.Background(), PostQuery{FeedIDs: []string{"feed-2"}, Limit: 1})
+	if err != nil {
+		t.Fatalf("AuthorStats with filters failed: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].Posts != 1 {
+		t.Errorf("expected one author with one post in feed-2, got %+v", filtered)
+	}
+}
+
+// TestPostRepository_Close verifies repository cleanup
+func TestPostRepository_Close(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &PostRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	err := repo.Close()
+	if err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
+
+// TestNewSQLitePostRepository opens a repository at an explicit path instead of the config directory
+func TestNewSQLitePostRepository(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	defer cleanup()
+	path := filepath.Join(t.TempDir(), "posts.db")
+
+	repo, err := NewSQLitePostRepository(path)
+	if err != nil {
+		t.Fatalf("NewSQLitePostRepository failed: %v", err)
+	}
+	defer repo.Close()
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	post := &PostModel{URI: "at://did:plc:a/app.bsky.feed.post/1", AuthorDID: "did:plc:a", FeedID: "feed", IndexedAt: time.Now()}
+	if err := repo.BatchSave(context.Background(), []*PostModel{post}); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+	if count, err := repo.CountByFeedID(context.Background(), "feed"); err != nil || count != 1 {
+		t.Errorf("expected 1 post in %s, got %d (%v)", path, count, err)
+	}
+}
+
+// TestPostRepository_SearchText finds posts by their words through the full-text index
+func TestPostRepository_SearchText(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := &PostRepository{db: db}
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now()
+	posts := []*PostModel{
+		{URI: "at://did:plc:a/app.bsky.feed.post/1", AuthorDID: "did:plc:a", Text: "Deploying the new Café release today", FeedID: "feed-1", IndexedAt: now.Add(-2 * time.Hour)},
+		{URI: "at://did:plc:a/app.bsky.feed.post/2", AuthorDID: "did:plc:a", Text: "The release notes are up", FeedID: "feed-1", IndexedAt: now.Add(-time.Hour)},
+		{URI: "at://did:plc:b/app.bsky.feed.post/3", AuthorDID: "did:plc:b", Text: "Notes on gardening", FeedID: "feed-1", IndexedAt: now},
+	}
+	if err := repo.BatchSave(ctx, posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	uris := func(matches []*PostMatch) []string {
+		var out []string
+		for _, match := range matches {
+			out = append(out, match.Post.URI[len(match.Post.URI)-1:])
+		}
+		return out
+	}
+
+	for _, tc := range []struct {
+		query string
+		want  []string
+	}{
+		{"release", []string{"2", "1"}},
+		{"RELEASE cafe", []string{"1"}},
+		{"deploy*", []string{"1"}},
+		{`"release notes"`, []string{"2"}},
+		{"notes", []string{"3", "2"}},
+		{"missing", nil},
+		{`release OR "notes`, nil},
+	} {
+		matches, err := repo.SearchText(ctx, tc.query, 10, 0)
+		if err != nil {
+			t.Fatalf("SearchText(%q) failed: %v", tc.query, err)
+		}
+		if got := uris(matches); !slices.Equal(got, tc.want) {
+			t.Errorf("SearchText(%q) found %v, want %v", tc.query, got, tc.want)
+		}
+	}
+
+	matches, err := repo.SearchText(ctx, "release", 1, 1)
+	if err != nil || len(matches) != 1 || matches[0].Post.URI != posts[0].URI {
+		t.Fatalf("expected the second match on its own page, got %v (%v)", matches, err)
+	}
+	if want := "Deploying the new Café " + SnippetStart + "release" + SnippetEnd + " today"; matches[0].Snippet != want {
+		t.Errorf("unexpected snippet %q", matches[0].Snippet)
+	}
+
+	// Edits and deletions reach the index through its triggers
+	posts[1].Text = "Changelog is up"
+	if err := repo.BatchSave(ctx, posts[1:2]); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+	if err := repo.Delete(ctx, posts[2].ID()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if matches, err := repo.SearchText(ctx, "notes", 0, 0); err != nil || len(matches) != 0 {
+		t.Errorf("expected edited and trashed posts to stop matching, got %v (%v)", uris(matches), err)
+	}
+	if matches, err := repo.SearchText(ctx, "changelog", 0, 0); err != nil || len(matches) != 1 {
+		t.Errorf("expected the edited text to match, got %v (%v)", uris(matches), err)
+	}
+
+	if _, err := repo.SearchText(ctx, `"" *`, 10, 0); err == nil {
+		t.Error("expected a query without words to be refused")
+	}
+}
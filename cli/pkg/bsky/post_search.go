@@ -0,0 +1,165 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Markers around the matched words in a [PostMatch] snippet. Control characters never appear in post text, so
+// callers can swap them for styling or markup.
+const (
+	SnippetStart = "\x02"
+	SnippetEnd   = "\x03"
+)
+
+// snippetWords is roughly how many words of text a snippet holds around the matches
+const snippetWords = 16
+
+// PostMatch is a stored post found by [PostRepository.SearchText]
+type PostMatch struct {
+	Post    *PostModel
+	Snippet string // the text around the matches, each wrapped in [SnippetStart] and [SnippetEnd]
+}
+
+// SearchText finds stored posts whose text has every word of query, newest first, skipping offset matches and
+// returning at most limit (0 returns all). Words match case- and accent-insensitively; "double quotes" match a
+// phrase, and a trailing * matches a prefix, as in deploy*. Posts in the trash never match.
+func (r *PostRepository) SearchText(ctx context.Context, query string, limit, offset int) (_ []*PostMatch, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "PostRepository.SearchText", attribute.Int("db.limit", limit))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, &RepositoryError{Op: "SearchText", Entity: "post", Err: errors.New("search query has no words")}
+	}
+
+	var sqlQuery string
+	var args []any
+	if r.dialect == DialectPostgres {
+		// A phrase's words must follow each other (<->), and a prefix ends in :*
+		tsquery := make([]string, len(terms))
+		for i, term := range terms {
+			tsquery[i] = strings.Join(strings.Fields(strings.TrimSuffix(term, "*")), " <-> ")
+			if strings.HasSuffix(term, "*") {
+				tsquery[i] += ":*"
+			}
+		}
+		sqlQuery = `
+			SELECT id, created_at, updated_at, uri, author_did, text, feed_id, indexed_at,
+				like_count, repost_count, reply_count, quote_count,
+				ts_headline('simple', text, to_tsquery('simple', ?), ?)
+			FROM posts
+			WHERE to_tsvector('simple', text) @@ to_tsquery('simple', ?) AND deleted_at IS NULL
+			ORDER BY indexed_at DESC`
+		match := strings.Join(tsquery, " & ")
+		options := "StartSel=" + SnippetStart + ", StopSel=" + SnippetEnd + ", MaxWords=" + strconv.Itoa(snippetWords) + ", MinWords=" + strconv.Itoa(snippetWords/2) + ", MaxFragments=2, FragmentDelimiter=…"
+		args = append(args, match, options, match)
+	} else {
+		// Each term is a quoted phrase, which FTS4 reads as a prefix query when it ends in *
+		match := make([]string, len(terms))
+		for i, term := range terms {
+			match[i] = `"` + term + `"`
+		}
+		sqlQuery = `
+			SELECT p.id, p.created_at, p.updated_at, p.uri, p.author_did, p.text, p.feed_id, p.indexed_at,
+				p.like_count, p.repost_count, p.reply_count, p.quote_count,
+				snippet(posts_fts, ?, ?, '…', -1, ?)
+			FROM posts_fts
+			JOIN posts p ON p.rowid = posts_fts.docid
+			WHERE posts_fts MATCH ? AND p.deleted_at IS NULL
+			ORDER BY p.indexed_at DESC`
+		args = append(args, SnippetStart, SnippetEnd, snippetWords, strings.Join(match, " "))
+	}
+
+	switch {
+	case limit > 0:
+		sqlQuery += " LIMIT ?"
+		args = append(args, limit)
+	case offset > 0 && r.dialect != DialectPostgres:
+		sqlQuery += " LIMIT -1" // SQLite only takes OFFSET after a LIMIT
+	}
+	if offset > 0 {
+		sqlQuery += " OFFSET ?"
+		args = append(args, offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(sqlQuery), args...)
+	if err != nil {
+		return nil, &RepositoryError{Op: "SearchText", Entity: "post", Err: err}
+	}
+	defer rows.Close()
+
+	var matches []*PostMatch
+	for rows.Next() {
+		var post PostModel
+		var postID, snippet string
+		var createdAt, updatedAt time.Time
+		var engagement nullEngagement
+
+		err := rows.Scan(
+			&postID,
+			&createdAt,
+			&updatedAt,
+			&post.URI,
+			&post.AuthorDID,
+			&post.Text,
+			&post.FeedID,
+			&post.IndexedAt,
+			&engagement.likes,
+			&engagement.reposts,
+			&engagement.replies,
+			&engagement.quotes,
+			&snippet,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "SearchText", Entity: "post", Err: err}
+		}
+
+		post.SetID(postID)
+		post.SetCreatedAt(createdAt)
+		post.SetUpdatedAt(updatedAt)
+		post.Engagement = engagement.counts()
+
+		matches = append(matches, &PostMatch{Post: &post, Snippet: snippet})
+	}
+
+	return matches, rows.Err()
+}
+
+// searchTerms splits a search query into words and "quoted phrases". Punctuation separates words, as it does
+// for the full-text tokenizers, so it can't be read as query syntax. A term keeps a trailing * when it asks for a
+// prefix.
+func searchTerms(query string) []string {
+	var terms []string
+	add := func(term string) {
+		prefix := strings.HasSuffix(term, "*")
+		term = strings.Join(strings.FieldsFunc(term, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+		}), " ")
+		if term == "" {
+			return
+		}
+		if prefix {
+			term += "*"
+		}
+		terms = append(terms, term)
+	}
+
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			add(part) // inside quotes: one phrase
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			add(word)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,189 @@
+package bsky
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// openTestPostgres connects to the database in SKYCLI_TEST_POSTGRES_URL, skipping the test when unset.
+// Tables are dropped afterwards, so point it at a throwaway database.
+func openTestPostgres(t *testing.T) (*SnapshotRepository, *CacheRepository, *PostRepository) {
+	t.Helper()
+	dsn := os.Getenv("SKYCLI_TEST_POSTGRES_URL")
+	if dsn == "" {
+		t.Skip("SKYCLI_TEST_POSTGRES_URL not set")
+	}
+
+	ctx := context.Background()
+
+	snapshotRepo, err := NewPostgresSnapshotRepository(dsn)
+	if err != nil {
+		t.Fatalf("failed to create snapshot repository: %v", err)
+	}
+	if err := snapshotRepo.Init(ctx); err != nil {
+		t.Fatalf("snapshot Init failed: %v", err)
+	}
+
+	cacheRepo, err := NewPostgresCacheRepository(dsn)
+	if err != nil {
+		t.Fatalf("failed to create cache repository: %v", err)
+	}
+	if err := cacheRepo.Init(ctx); err != nil {
+		t.Fatalf("cache Init failed: %v", err)
+	}
+
+	postRepo, err := NewPostgresPostRepository(dsn)
+	if err != nil {
+		t.Fatalf("failed to create post repository: %v", err)
+	}
+	if err := postRepo.Init(ctx); err != nil {
+		t.Fatalf("post Init failed: %v", err)
+	}
+
+	t.Cleanup(func() {
+		snapshotRepo.db.Exec("DROP TABLE IF EXISTS follower_snapshot_entries, follower_snapshots, cached_post_rates, cached_activity, posts, schema_migrations")
+		snapshotRepo.Close()
+		cacheRepo.Close()
+		postRepo.Close()
+	})
+	return snapshotRepo, cacheRepo, postRepo
+}
+
+func TestRepositoriesImplementStores(t *testing.T) {
+	var _ SnapshotStore = (*SnapshotRepository)(nil)
+	var _ CacheStore = (*CacheRepository)(nil)
+	var _ Repository = (*PostRepository)(nil)
+}
+
+func TestPostgresSnapshotRepository(t *testing.T) {
+	snapshotRepo, _, _ := openTestPostgres(t)
+	ctx := context.Background()
+
+	snapshot := &SnapshotModel{UserDid: "did:plc:brand", SnapshotType: "followers", TotalCount: 2}
+	if err := snapshotRepo.Save(ctx, snapshot); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	entries := []*SnapshotEntry{
+		{SnapshotID: snapshot.ID(), ActorDid: "did:plc:a", IndexedAt: "2024-01-01T00:00:00Z"},
+		{SnapshotID: snapshot.ID(), ActorDid: "did:plc:b"},
+	}
+	if err := snapshotRepo.SaveEntries(ctx, entries); err != nil {
+		t.Fatalf("SaveEntries failed: %v", err)
+	}
+
+	found, err := snapshotRepo.FindByUserAndType(ctx, "did:plc:brand", "followers")
+	if err != nil {
+		t.Fatalf("FindByUserAndType failed: %v", err)
+	}
+	if found == nil || found.ID() != snapshot.ID() {
+		t.Fatalf("expected to find saved snapshot, got %+v", found)
+	}
+
+	dids, err := snapshotRepo.GetActorDids(ctx, snapshot.ID())
+	if err != nil {
+		t.Fatalf("GetActorDids failed: %v", err)
+	}
+	if len(dids) != 2 {
+		t.Errorf("expected 2 actor DIDs, got %d", len(dids))
+	}
+
+	imported := &SnapshotModel{UserDid: "did:plc:brand", SnapshotType: "following", TotalCount: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	imported.SetID("imported-1")
+	imported.SetCreatedAt(time.Now().Add(-48 * time.Hour))
+	if err := snapshotRepo.Import(ctx, imported, entries[:1]); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if err := snapshotRepo.Import(ctx, imported, entries[:1]); err == nil {
+		t.Error("expected error importing duplicate snapshot")
+	}
+
+	if err := snapshotRepo.Delete(ctx, snapshot.ID()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if entries, _ := snapshotRepo.GetEntries(ctx, snapshot.ID()); len(entries) != 0 {
+		t.Errorf("expected entries to cascade delete, got %d", len(entries))
+	}
+}
+
+func TestPostgresCacheRepository(t *testing.T) {
+	_, cacheRepo, _ := openTestPostgres(t)
+	ctx := context.Background()
+
+	rates := []*PostRateCacheModel{
+		{ActorDid: "did:plc:a", PostsPerDay: 1.5, LastPostDate: time.Now(), SampleSize: 30},
+		{ActorDid: "did:plc:b", PostsPerDay: 0.1, SampleSize: 3},
+	}
+	if err := cacheRepo.SavePostRates(ctx, rates); err != nil {
+		t.Fatalf("SavePostRates failed: %v", err)
+	}
+
+	got, err := cacheRepo.GetPostRates(ctx, []string{"did:plc:a", "did:plc:b", "did:plc:missing"})
+	if err != nil {
+		t.Fatalf("GetPostRates failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 post rates, got %d", len(got))
+	}
+	if !got["did:plc:b"].LastPostDate.IsZero() {
+		t.Error("expected NULL last post date to scan as zero time")
+	}
+
+	if err := cacheRepo.SaveActivity(ctx, &ActivityCacheModel{ActorDid: "did:plc:a", LastPostDate: time.Now()}); err != nil {
+		t.Fatalf("SaveActivity failed: %v", err)
+	}
+	activity, err := cacheRepo.GetActivity(ctx, "did:plc:a")
+	if err != nil {
+		t.Fatalf("GetActivity failed: %v", err)
+	}
+	if activity == nil || !activity.HasPosted() {
+		t.Errorf("expected cached activity, got %+v", activity)
+	}
+
+	if err := cacheRepo.SavePostRate(ctx, &PostRateCacheModel{ActorDid: "did:plc:old", ExpiresAt: time.Now().Add(-time.Hour), FetchedAt: time.Now()}); err != nil {
+		t.Fatalf("SavePostRate failed: %v", err)
+	}
+	deleted, err := cacheRepo.DeleteExpiredPostRates(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpiredPostRates failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 expired post rate deleted, got %d", deleted)
+	}
+}
+
+func TestPostgresPostRepository(t *testing.T) {
+	_, _, postRepo := openTestPostgres(t)
+	ctx := context.Background()
+
+	posts := []*PostModel{
+		{URI: "at://did:plc:a/app.bsky.feed.post/1", AuthorDID: "did:plc:a", Text: "first", FeedID: "feed-1", IndexedAt: time.Now().Add(-time.Hour)},
+		{URI: "at://did:plc:a/app.bsky.feed.post/2", AuthorDID: "did:plc:a", Text: "second", FeedID: "feed-1", IndexedAt: time.Now()},
+	}
+	if err := postRepo.BatchSave(ctx, posts); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	posts[0].Text = "edited"
+	if err := postRepo.Save(ctx, posts[0]); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	count, err := postRepo.CountByFeedID(ctx, "feed-1")
+	if err != nil {
+		t.Fatalf("CountByFeedID failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 posts, got %d", count)
+	}
+
+	page, err := postRepo.QueryByFeedID(ctx, "feed-1", 1, 1)
+	if err != nil {
+		t.Fatalf("QueryByFeedID failed: %v", err)
+	}
+	if len(page) != 1 || page[0].Text != "edited" {
+		t.Errorf("expected second page to hold the edited older post, got %+v", page)
+	}
+}
@@ -1,9 +1,11 @@
-package store
+package bsky
 
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -72,9 +74,9 @@ func (r *ProfileRepository) Get(ctx context.Context, id string) (Model, error) {
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, &RepositoryError{Op: "Get", Err: errors.New("profile not found")}
+			return nil, &RepositoryError{Op: "Get", Entity: "profile", ID: id, Err: fmt.Errorf("profile %w", ErrNotFound)}
 		}
-		return nil, &RepositoryError{Op: "Get", Err: err}
+		return nil, &RepositoryError{Op: "Get", Entity: "profile", ID: id, Err: err}
 	}
 
 	return &profile, nil
@@ -107,7 +109,7 @@ func (r *ProfileRepository) GetByDid(ctx context.Context, did string) (*ProfileM
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
-		return nil, &RepositoryError{Op: "GetByDid", Err: err}
+		return nil, &RepositoryError{Op: "GetByDid", Entity: "profile", ID: did, Err: err}
 	}
 
 	profile.SetID(profileID)
@@ -128,7 +130,7 @@ func (r *ProfileRepository) List(ctx context.Context) ([]Model, error) {
 
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, &RepositoryError{Op: "List", Err: err}
+		return nil, &RepositoryError{Op: "List", Entity: "profile", Err: err}
 	}
 	defer rows.Close()
 
@@ -148,7 +150,7 @@ func (r *ProfileRepository) List(ctx context.Context) ([]Model, error) {
 			&fetchedAt,
 		)
 		if err != nil {
-			return nil, &RepositoryError{Op: "List", Err: err}
+			return nil, &RepositoryError{Op: "List", Entity: "profile", Err: err}
 		}
 
 		profile.SetID(profileID)
@@ -166,7 +168,7 @@ func (r *ProfileRepository) List(ctx context.Context) ([]Model, error) {
 func (r *ProfileRepository) Save(ctx context.Context, model Model) error {
 	profile, ok := model.(*ProfileModel)
 	if !ok {
-		return &RepositoryError{Op: "Save", Err: errors.New("invalid model type: expected *ProfileModel")}
+		return &RepositoryError{Op: "Save", Entity: "profile", Err: errors.New("invalid model type: expected *ProfileModel")}
 	}
 
 	if profile.ID() == "" {
@@ -200,27 +202,117 @@ func (r *ProfileRepository) Save(ctx context.Context, model Model) error {
 	)
 
 	if err != nil {
-		return &RepositoryError{Op: "Save", Err: err}
+		return &RepositoryError{Op: "Save", Entity: "profile", ID: profile.ID(), Err: err}
 	}
 
 	return nil
 }
 
+// BatchSave upserts multiple profiles by DID in a single transaction
+func (r *ProfileRepository) BatchSave(ctx context.Context, profiles []*ProfileModel) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &RepositoryError{Op: "BatchSave", Entity: "profile", Err: err}
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO profiles (id, created_at, updated_at, did, handle, data_json, fetched_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(did) DO UPDATE SET
+			updated_at = excluded.updated_at,
+			handle = excluded.handle,
+			data_json = excluded.data_json,
+			fetched_at = excluded.fetched_at
+	`)
+	if err != nil {
+		return &RepositoryError{Op: "BatchSave", Entity: "profile", Err: err}
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, profile := range profiles {
+		if profile.ID() == "" {
+			profile.SetID(GenerateUUID())
+			profile.SetCreatedAt(now)
+		}
+		profile.SetUpdatedAt(now)
+		if profile.FetchedAt.IsZero() {
+			profile.FetchedAt = now
+		}
+
+		_, err := stmt.ExecContext(ctx,
+			profile.ID(),
+			profile.CreatedAt(),
+			profile.UpdatedAt(),
+			profile.Did,
+			profile.Handle,
+			profile.DataJSON,
+			profile.FetchedAt,
+		)
+		if err != nil {
+			return &RepositoryError{Op: "BatchSave", Entity: "profile", Err: err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &RepositoryError{Op: "BatchSave", Entity: "profile", Err: err}
+	}
+
+	return nil
+}
+
+// UpdateHandle changes the handle of a cached profile, in both the handle column and the stored profile data.
+// It reports whether a cached profile had a different handle; profiles not in the cache are left alone.
+func (r *ProfileRepository) UpdateHandle(ctx context.Context, did, handle string) (bool, error) {
+	profile, err := r.GetByDid(ctx, did)
+	if err != nil {
+		return false, err
+	}
+	if profile == nil || profile.Handle == handle {
+		return false, nil
+	}
+
+	dataJSON := profile.DataJSON
+	if dataJSON != "" {
+		var data map[string]any
+		if err := json.Unmarshal([]byte(dataJSON), &data); err != nil {
+			return false, &RepositoryError{Op: "UpdateHandle", Entity: "profile", Err: err}
+		}
+		data["handle"] = handle
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return false, &RepositoryError{Op: "UpdateHandle", Entity: "profile", Err: err}
+		}
+		dataJSON = string(encoded)
+	}
+
+	query := "UPDATE profiles SET handle = ?, data_json = ?, updated_at = ? WHERE did = ?"
+	if _, err := r.db.ExecContext(ctx, query, handle, dataJSON, time.Now(), did); err != nil {
+		return false, &RepositoryError{Op: "UpdateHandle", Entity: "profile", Err: err}
+	}
+	return true, nil
+}
+
 // Delete removes a profile by ID
 func (r *ProfileRepository) Delete(ctx context.Context, id string) error {
 	query := "DELETE FROM profiles WHERE id = ?"
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		return &RepositoryError{Op: "Delete", Err: err}
+		return &RepositoryError{Op: "Delete", Entity: "profile", ID: id, Err: err}
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return &RepositoryError{Op: "Delete", Err: err}
+		return &RepositoryError{Op: "Delete", Entity: "profile", ID: id, Err: err}
 	}
 
 	if rows == 0 {
-		return &RepositoryError{Op: "Delete", Err: errors.New("profile not found")}
+		return &RepositoryError{Op: "Delete", Entity: "profile", ID: id, Err: fmt.Errorf("profile %w", ErrNotFound)}
 	}
 
 	return nil
@@ -231,16 +323,16 @@ func (r *ProfileRepository) DeleteByDid(ctx context.Context, did string) error {
 	query := "DELETE FROM profiles WHERE did = ?"
 	result, err := r.db.ExecContext(ctx, query, did)
 	if err != nil {
-		return &RepositoryError{Op: "DeleteByDid", Err: err}
+		return &RepositoryError{Op: "DeleteByDid", Entity: "profile", ID: did, Err: err}
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return &RepositoryError{Op: "DeleteByDid", Err: err}
+		return &RepositoryError{Op: "DeleteByDid", Entity: "profile", ID: did, Err: err}
 	}
 
 	if rows == 0 {
-		return &RepositoryError{Op: "DeleteByDid", Err: errors.New("profile not found")}
+		return &RepositoryError{Op: "DeleteByDid", Entity: "profile", ID: did, Err: fmt.Errorf("profile %w", ErrNotFound)}
 	}
 
 	return nil
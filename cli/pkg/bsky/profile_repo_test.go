@@ -1,7 +1,8 @@
-package store
+package bsky
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -215,6 +216,46 @@ func TestProfileRepository_Upsert_ByDid(t *testing.T) {
 	}
 }
 
+func TestProfileRepository_BatchSave(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := &ProfileRepository{db: db}
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	existing := &ProfileModel{Did: "did:plc:alice", Handle: "old.bsky.social", DataJSON: "{}"}
+	if err := repo.Save(ctx, existing); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	profiles := []*ProfileModel{
+		{Did: "did:plc:alice", Handle: "alice.bsky.social", DataJSON: "{}"},
+		{Did: "did:plc:bob", Handle: "bob.bsky.social", DataJSON: "{}"},
+	}
+	if err := repo.BatchSave(ctx, profiles); err != nil {
+		t.Fatalf("BatchSave failed: %v", err)
+	}
+
+	models, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("expected 2 profiles after upserting by DID, got %d", len(models))
+	}
+
+	alice, err := repo.GetByDid(ctx, "did:plc:alice")
+	if err != nil {
+		t.Fatalf("GetByDid failed: %v", err)
+	}
+	if alice.Handle != "alice.bsky.social" {
+		t.Errorf("expected the batch to update the handle, got %s", alice.Handle)
+	}
+}
+
 func TestProfileRepository_List(t *testing.T) {
 	db, cleanup := utils.NewTestDB(t)
 	defer cleanup()
@@ -406,3 +447,47 @@ func TestProfileRepository_Close(t *testing.T) {
 		t.Errorf("Close failed: %v", err)
 	}
 }
+
+func TestProfileRepository_UpdateHandle(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &ProfileRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	profile := &ProfileModel{
+		Did:      "did:plc:test123",
+		Handle:   "alice.bsky.social",
+		DataJSON: `{"did":"did:plc:test123","handle":"alice.bsky.social","displayName":"Alice"}`,
+	}
+	if err := repo.Save(ctx, profile); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	updated, err := repo.UpdateHandle(ctx, "did:plc:test123", "alice.example.com")
+	if err != nil || !updated {
+		t.Fatalf("UpdateHandle() = %v, %v; want true", updated, err)
+	}
+
+	got, err := repo.GetByDid(ctx, "did:plc:test123")
+	if err != nil {
+		t.Fatalf("GetByDid failed: %v", err)
+	}
+	var data ActorProfile
+	if err := json.Unmarshal([]byte(got.DataJSON), &data); err != nil {
+		t.Fatalf("invalid profile data: %v", err)
+	}
+	if got.Handle != "alice.example.com" || data.Handle != "alice.example.com" || data.DisplayName != "Alice" {
+		t.Errorf("expected handle to change in column and data, got %q and %+v", got.Handle, data)
+	}
+
+	if updated, _ := repo.UpdateHandle(ctx, "did:plc:test123", "alice.example.com"); updated {
+		t.Error("expected unchanged handle not to be reported as updated")
+	}
+	if updated, err := repo.UpdateHandle(ctx, "did:plc:unknown", "bob.example.com"); err != nil || updated {
+		t.Errorf("expected uncached profile to be ignored, got %v (err %v)", updated, err)
+	}
+}
@@ -0,0 +1,191 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+)
+
+// QuotaModel is the rate-limit budget an API host last reported
+type QuotaModel struct {
+	Host       string
+	Limit      int           // requests allowed per window
+	Remaining  int           // requests left in the window when observed
+	Window     time.Duration // length of the window from the policy header, or 0 if not reported
+	ResetAt    time.Time     // when the window resets
+	ObservedAt time.Time
+}
+
+// RemainingAt returns the requests left at now, a full budget once the observed window has reset
+func (q *QuotaModel) RemainingAt(now time.Time) int {
+	if !now.Before(q.ResetAt) {
+		return q.Limit
+	}
+	return q.Remaining
+}
+
+// ShareAt returns the fraction of the budget left at now
+func (q *QuotaModel) ShareAt(now time.Time) float64 {
+	if q.Limit <= 0 {
+		return 1
+	}
+	return float64(q.RemainingAt(now)) / float64(q.Limit)
+}
+
+// resetEpochFloor separates RateLimit-Reset values sent as Unix timestamps, as the AppView and PDS do, from
+// delta-seconds as in the IETF RateLimit header draft
+const resetEpochFloor = 1_000_000_000
+
+// ParseQuota reads the RateLimit-* headers of a response from host, reporting false when they are absent
+func ParseQuota(host string, header http.Header, now time.Time) (*QuotaModel, bool) {
+	limit, err := strconv.Atoi(header.Get("RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return nil, false
+	}
+	remaining, err := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	if err != nil {
+		return nil, false
+	}
+
+	quota := &QuotaModel{Host: host, Limit: limit, Remaining: max(remaining, 0), ObservedAt: now}
+
+	// Policy is "<limit>;w=<seconds>", possibly with more parameters
+	for _, param := range strings.Split(header.Get("RateLimit-Policy"), ";")[1:] {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(param), "w="); ok {
+			if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+				quota.Window = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	if reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		if reset >= resetEpochFloor {
+			quota.ResetAt = time.Unix(reset, 0)
+		} else {
+			quota.ResetAt = now.Add(time.Duration(reset) * time.Second)
+		}
+	} else {
+		quota.ResetAt = now.Add(quota.Window)
+	}
+	return quota, true
+}
+
+// QuotaRepository tracks the latest rate-limit budget of each API host in the local SQLite cache database.
+// [QuotaRepository.RecordQuota] only buffers observations, so requests never wait on the database;
+// [QuotaRepository.Flush] persists them.
+type QuotaRepository struct {
+	db      *sql.DB
+	mu      sync.Mutex
+	pending map[string]*QuotaModel
+}
+
+// NewQuotaRepository creates a new quota repository with SQLite backend
+func NewQuotaRepository() (*QuotaRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotaRepository{db: db, pending: make(map[string]*QuotaModel)}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *QuotaRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close persists buffered observations and releases the database connection
+func (r *QuotaRepository) Close() error {
+	return errors.Join(r.Flush(context.Background()), r.db.Close())
+}
+
+// RecordQuota buffers an observation, keeping only the newest per host
+func (r *QuotaRepository) RecordQuota(quota *QuotaModel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, ok := r.pending[quota.Host]; ok && current.ObservedAt.After(quota.ObservedAt) {
+		return
+	}
+	r.pending[quota.Host] = quota
+}
+
+// Flush writes buffered observations, replacing each host's stored budget
+func (r *QuotaRepository) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[string]*QuotaModel)
+	r.mu.Unlock()
+
+	for _, quota := range pending {
+		if err := r.Save(ctx, quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Save stores a host's budget, replacing any earlier observation
+func (r *QuotaRepository) Save(ctx context.Context, quota *QuotaModel) error {
+	query := `
+		INSERT INTO rate_limits (host, quota, remaining, window_seconds, reset_at, observed_at) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(host) DO UPDATE SET
+			quota = excluded.quota, remaining = excluded.remaining, window_seconds = excluded.window_seconds,
+			reset_at = excluded.reset_at, observed_at = excluded.observed_at
+		WHERE excluded.observed_at >= rate_limits.observed_at
+	`
+	_, err := r.db.ExecContext(ctx, query, quota.Host, quota.Limit, quota.Remaining, int64(quota.Window/time.Second), quota.ResetAt, quota.ObservedAt)
+	if err != nil {
+		return &RepositoryError{Op: "Save", Entity: "quota", Err: err}
+	}
+	return nil
+}
+
+// List returns the latest budget of every host, buffered observations included, ordered by host
+func (r *QuotaRepository) List(ctx context.Context) (_ []*QuotaModel, err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "QuotaRepository.List")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := r.Flush(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT host, quota, remaining, window_seconds, reset_at, observed_at FROM rate_limits ORDER BY host")
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "quota", Err: err}
+	}
+	defer rows.Close()
+
+	var quotas []*QuotaModel
+	for rows.Next() {
+		var quota QuotaModel
+		var window int64
+		if err := rows.Scan(&quota.Host, &quota.Limit, &quota.Remaining, &window, &quota.ResetAt, &quota.ObservedAt); err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "quota", Err: err}
+		}
+		quota.Window = time.Duration(window) * time.Second
+		quotas = append(quotas, &quota)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "quota", Err: err}
+	}
+	return quotas, nil
+}
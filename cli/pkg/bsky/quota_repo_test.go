@@ -0,0 +1,99 @@
+package bsky
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestParseQuota(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	t.Run("EpochReset", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("RateLimit-Limit", "3000")
+		header.Set("RateLimit-Remaining", "2990")
+		header.Set("RateLimit-Reset", "1700000120")
+		header.Set("RateLimit-Policy", "3000;w=300")
+
+		quota, ok := ParseQuota("bsky.social", header, now)
+		if !ok {
+			t.Fatal("expected quota headers to parse")
+		}
+		if quota.Limit != 3000 || quota.Remaining != 2990 || quota.Window != 5*time.Minute {
+			t.Errorf("unexpected quota %+v", quota)
+		}
+		if !quota.ResetAt.Equal(now.Add(2 * time.Minute)) {
+			t.Errorf("reset = %s, want %s", quota.ResetAt, now.Add(2*time.Minute))
+		}
+		if got := quota.RemainingAt(now); got != 2990 {
+			t.Errorf("remaining before reset = %d", got)
+		}
+		if got := quota.RemainingAt(now.Add(3 * time.Minute)); got != 3000 {
+			t.Errorf("remaining after reset = %d, want the full limit", got)
+		}
+	})
+
+	t.Run("DeltaReset", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("RateLimit-Limit", "100")
+		header.Set("RateLimit-Remaining", "10")
+		header.Set("RateLimit-Reset", "30")
+
+		quota, ok := ParseQuota("pds.example.com", header, now)
+		if !ok || !quota.ResetAt.Equal(now.Add(30*time.Second)) {
+			t.Fatalf("expected reset 30s from now, got %+v", quota)
+		}
+		if share := quota.ShareAt(now); share != 0.1 {
+			t.Errorf("share = %v, want 0.1", share)
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		if _, ok := ParseQuota("bsky.social", http.Header{}, now); ok {
+			t.Error("expected no quota without headers")
+		}
+	})
+}
+
+func TestQuotaRepository(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &QuotaRepository{db: db, pending: make(map[string]*QuotaModel)}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	repo.RecordQuota(&QuotaModel{Host: "bsky.social", Limit: 3000, Remaining: 2000, ResetAt: now.Add(time.Minute), ObservedAt: now})
+	repo.RecordQuota(&QuotaModel{Host: "bsky.social", Limit: 3000, Remaining: 2500, ResetAt: now.Add(time.Minute), ObservedAt: now.Add(-time.Second)})
+	repo.RecordQuota(&QuotaModel{Host: "pds.example.com", Limit: 5000, Remaining: 4990, Window: time.Hour, ResetAt: now.Add(time.Hour), ObservedAt: now})
+
+	quotas, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(quotas) != 2 {
+		t.Fatalf("expected 2 hosts, got %d", len(quotas))
+	}
+	if quotas[0].Host != "bsky.social" || quotas[0].Remaining != 2000 {
+		t.Errorf("expected the newest bsky.social observation, got %+v", quotas[0])
+	}
+	if quotas[1].Window != time.Hour {
+		t.Errorf("expected window to round-trip, got %s", quotas[1].Window)
+	}
+
+	// An older observation saved later, as from a concurrent run, doesn't replace a newer one
+	if err := repo.Save(ctx, &QuotaModel{Host: "bsky.social", Limit: 3000, Remaining: 2999, ResetAt: now, ObservedAt: now.Add(-time.Hour)}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	quotas, _ = repo.List(ctx)
+	if quotas[0].Remaining != 2000 {
+		t.Errorf("expected stale observation to be ignored, got %+v", quotas[0])
+	}
+}
@@ -0,0 +1,126 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// ReadPositionTimeline is the feed name the home timeline's position is stored under
+const ReadPositionTimeline = "timeline"
+
+// ReadPosition is where the feed reader left off in one feed
+type ReadPosition struct {
+	Feed      string // [ReadPositionTimeline] or a feed generator AT URI
+	Cursor    string // cursor of the page being read; "" for the first page
+	PostURI   string // post selected on that page
+	UpdatedAt time.Time
+}
+
+// ReadPositionRepository persists feed reader positions in the local SQLite cache database
+type ReadPositionRepository struct {
+	db *sql.DB
+}
+
+// NewReadPositionRepository creates a new read position repository with SQLite backend
+func NewReadPositionRepository() (*ReadPositionRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReadPositionRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *ReadPositionRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *ReadPositionRepository) Close() error {
+	return r.db.Close()
+}
+
+// Get returns the stored position in a feed, or nil if it has none
+func (r *ReadPositionRepository) Get(ctx context.Context, feed string) (*ReadPosition, error) {
+	position := ReadPosition{Feed: feed}
+	err := r.db.QueryRowContext(ctx, "SELECT cursor, post_uri, updated_at FROM read_positions WHERE feed = ?", feed).
+		Scan(&position.Cursor, &position.PostURI, &position.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &RepositoryError{Op: "Get", Entity: "read position", ID: feed, Err: err}
+	}
+	return &position, nil
+}
+
+// Save stores the position in a feed, replacing any earlier one
+func (r *ReadPositionRepository) Save(ctx context.Context, feed, cursor, postURI string) error {
+	query := `
+		INSERT INTO read_positions (feed, cursor, post_uri, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(feed) DO UPDATE SET cursor = excluded.cursor, post_uri = excluded.post_uri, updated_at = excluded.updated_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, feed, cursor, postURI, time.Now()); err != nil {
+		return &RepositoryError{Op: "Save", Entity: "read position", ID: feed, Err: err}
+	}
+	return nil
+}
+
+// List returns every stored position, by feed
+func (r *ReadPositionRepository) List(ctx context.Context) ([]*ReadPosition, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT feed, cursor, post_uri, updated_at FROM read_positions ORDER BY feed")
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "read position", Err: err}
+	}
+	defer rows.Close()
+
+	var positions []*ReadPosition
+	for rows.Next() {
+		var position ReadPosition
+		if err := rows.Scan(&position.Feed, &position.Cursor, &position.PostURI, &position.UpdatedAt); err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "read position", Err: err}
+		}
+		positions = append(positions, &position)
+	}
+	return positions, rows.Err()
+}
+
+// Restore stores a position exactly as given, keeping its UpdatedAt (used when syncing state between machines)
+func (r *ReadPositionRepository) Restore(ctx context.Context, position *ReadPosition) error {
+	query := `
+		INSERT INTO read_positions (feed, cursor, post_uri, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(feed) DO UPDATE SET cursor = excluded.cursor, post_uri = excluded.post_uri, updated_at = excluded.updated_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, position.Feed, position.Cursor, position.PostURI, position.UpdatedAt); err != nil {
+		return &RepositoryError{Op: "Restore", Entity: "read position", ID: position.Feed, Err: err}
+	}
+	return nil
+}
+
+// Delete forgets the position in a feed so reading starts from the newest posts again.
+// It reports whether a position was stored.
+func (r *ReadPositionRepository) Delete(ctx context.Context, feed string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM read_positions WHERE feed = ?", feed)
+	if err != nil {
+		return false, &RepositoryError{Op: "Delete", Entity: "read position", ID: feed, Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, &RepositoryError{Op: "Delete", Entity: "read position", ID: feed, Err: err}
+	}
+	return rows > 0, nil
+}
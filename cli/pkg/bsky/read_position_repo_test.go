@@ -0,0 +1,58 @@
+package bsky
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestReadPositionRepository(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &ReadPositionRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if position, err := repo.Get(ctx, ReadPositionTimeline); err != nil || position != nil {
+		t.Fatalf("expected no position, got %+v (err %v)", position, err)
+	}
+
+	if err := repo.Save(ctx, ReadPositionTimeline, "", "at://did:plc:a/app.bsky.feed.post/1"); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := repo.Save(ctx, ReadPositionTimeline, "cursor-2", "at://did:plc:b/app.bsky.feed.post/2"); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	position, err := repo.Get(ctx, ReadPositionTimeline)
+	if err != nil || position == nil {
+		t.Fatalf("Get failed: %+v (err %v)", position, err)
+	}
+	if position.Cursor != "cursor-2" || position.PostURI != "at://did:plc:b/app.bsky.feed.post/2" || position.UpdatedAt.IsZero() {
+		t.Errorf("expected the latest position, got %+v", position)
+	}
+
+	synced := &ReadPosition{Feed: "at://did:plc:c/app.bsky.feed.generator/art", Cursor: "c", UpdatedAt: position.UpdatedAt.Add(-time.Hour)}
+	if err := repo.Restore(ctx, synced); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	positions, err := repo.List(ctx)
+	if err != nil || len(positions) != 2 {
+		t.Fatalf("expected 2 positions, got %d (err %v)", len(positions), err)
+	}
+	if got := positions[0]; got.Feed != synced.Feed || !got.UpdatedAt.Equal(synced.UpdatedAt) {
+		t.Errorf("expected the restored position to keep its time, got %+v", got)
+	}
+
+	if deleted, err := repo.Delete(ctx, ReadPositionTimeline); err != nil || !deleted {
+		t.Errorf("expected the position to be deleted, got %v (err %v)", deleted, err)
+	}
+	if deleted, _ := repo.Delete(ctx, ReadPositionTimeline); deleted {
+		t.Error("expected second delete to report nothing deleted")
+	}
+}
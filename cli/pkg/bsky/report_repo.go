@@ -0,0 +1,132 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+)
+
+// ReportModel is a moderation report filed through skycli
+type ReportModel struct {
+	id          string
+	createdAt   time.Time
+	ReportID    int64  // ID assigned by the moderation service
+	SubjectType string // "account" or "post"
+	Subject     string // DID for accounts, AT URI for posts
+	ReasonType  string // com.atproto.moderation.defs#reason* token
+	Details     string
+	Service     string // DID of the moderation service the report was sent to
+}
+
+func (m *ReportModel) ID() string               { return m.id }
+func (m *ReportModel) CreatedAt() time.Time     { return m.createdAt }
+func (m *ReportModel) UpdatedAt() time.Time     { return m.createdAt } // Reports are append-only
+func (m *ReportModel) SetID(id string)          { m.id = id }
+func (m *ReportModel) SetCreatedAt(t time.Time) { m.createdAt = t }
+func (m *ReportModel) SetUpdatedAt(t time.Time) {}
+
+// ReportRepository keeps a local log of filed moderation reports in the SQLite cache database
+type ReportRepository struct {
+	db *sql.DB
+}
+
+// NewReportRepository creates a new report repository with SQLite backend
+func NewReportRepository() (*ReportRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *ReportRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *ReportRepository) Close() error {
+	return r.db.Close()
+}
+
+// Record appends a filed report to the log, assigning an ID and timestamp when unset
+func (r *ReportRepository) Record(ctx context.Context, report *ReportModel) (err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "ReportRepository.Record")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if report.ID() == "" {
+		report.SetID(GenerateUUID())
+		report.SetCreatedAt(time.Now())
+	}
+
+	query := `
+		INSERT INTO report_log (id, created_at, report_id, subject_type, subject, reason_type, details, service)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	if _, err = r.db.ExecContext(ctx, query,
+		report.ID(), report.CreatedAt(), report.ReportID, report.SubjectType, report.Subject,
+		report.ReasonType, report.Details, report.Service,
+	); err != nil {
+		return &RepositoryError{Op: "Record", Entity: "report", Err: err}
+	}
+	return nil
+}
+
+// List returns up to limit filed reports, newest first. A limit of 0 returns all of them.
+func (r *ReportRepository) List(ctx context.Context, limit int) (_ []*ReportModel, err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "ReportRepository.List")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	query := `
+		SELECT id, created_at, report_id, subject_type, subject, reason_type, details, service
+		FROM report_log
+		ORDER BY created_at DESC
+	`
+
+	var args []any
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "report", Err: err}
+	}
+	defer rows.Close()
+
+	var reports []*ReportModel
+	for rows.Next() {
+		var report ReportModel
+		var id string
+		var createdAt time.Time
+
+		if err := rows.Scan(&id, &createdAt, &report.ReportID, &report.SubjectType, &report.Subject,
+			&report.ReasonType, &report.Details, &report.Service); err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "report", Err: err}
+		}
+
+		report.SetID(id)
+		report.SetCreatedAt(createdAt)
+		reports = append(reports, &report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "report", Err: err}
+	}
+	return reports, nil
+}
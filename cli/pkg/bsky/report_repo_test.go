@@ -0,0 +1,51 @@
+package bsky
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestReportRepository_RecordAndList(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &ReportRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	older := &ReportModel{ReportID: 7, SubjectType: "account", Subject: "did:plc:spam", ReasonType: "com.atproto.moderation.defs#reasonSpam", Service: "did:plc:mod"}
+	older.SetID(GenerateUUID())
+	older.SetCreatedAt(time.Now().Add(-time.Hour))
+	if err := repo.Record(ctx, older); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	newer := &ReportModel{ReportID: 8, SubjectType: "post", Subject: "at://did:plc:x/app.bsky.feed.post/1", ReasonType: "com.atproto.moderation.defs#reasonRude", Details: "harassment", Service: "did:plc:mod"}
+	if err := repo.Record(ctx, newer); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if newer.ID() == "" || newer.CreatedAt().IsZero() {
+		t.Error("expected Record to assign an ID and timestamp")
+	}
+
+	reports, err := repo.List(ctx, 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(reports) != 2 || reports[0].ReportID != 8 || reports[0].Details != "harassment" || reports[1].Subject != "did:plc:spam" {
+		t.Errorf("unexpected reports %+v", reports)
+	}
+
+	limited, err := repo.List(ctx, 1)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(limited) != 1 || limited[0].ReportID != 8 {
+		t.Errorf("expected only the newest report, got %+v", limited)
+	}
+}
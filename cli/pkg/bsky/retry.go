@@ -0,0 +1,325 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+// RetryPolicy controls how [BlueskyService.Request] retries rate-limited and failed requests
+type RetryPolicy struct {
+	MaxAttempts int           // tries including the first; 1 disables retries
+	BaseDelay   time.Duration // backoff before the first retry, doubled for each one after
+	MaxDelay    time.Duration // longest single wait, including one a server asks for; longer waits fail instead
+}
+
+// DefaultRetryPolicy tries a request up to four times, backing off from 250ms, and waits at most 30s at a time
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 4, BaseDelay: 250 * time.Millisecond, MaxDelay: 30 * time.Second}
+}
+
+// RetryEvent describes one retry of a request
+type RetryEvent struct {
+	Path    string
+	Attempt int           // the attempt that failed, from 1
+	Status  int           // response status, or 0 after a transport error
+	Wait    time.Duration // delay before the next attempt
+}
+
+type retryObserverKey struct{}
+
+// WithRetryObserver returns a context whose requests report each retry to observe, after any observer ctx
+// already carries
+func WithRetryObserver(ctx context.Context, observe func(RetryEvent)) context.Context {
+	if previous, ok := ctx.Value(retryObserverKey{}).(func(RetryEvent)); ok {
+		next := observe
+		observe = func(event RetryEvent) {
+			previous(event)
+			next(event)
+		}
+	}
+	return context.WithValue(ctx, retryObserverKey{}, observe)
+}
+
+// SetRetryPolicy replaces the policy for retrying rate-limited and failed requests
+func (s *BlueskyService) SetRetryPolicy(policy RetryPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retry = policy
+}
+
+// send performs req, retrying a 429 and, for requests safe to repeat, a transient 5xx or transport error. Waits
+// follow the server's Retry-After or rate-limit reset when it gives one, else jittered exponential backoff.
+// A request is never retried past its deadline; the last response or error is returned instead.
+func (s *BlueskyService) send(req *http.Request) (*http.Response, error) {
+	s.mu.RLock()
+	policy := s.retry
+	s.mu.RUnlock()
+
+	if err := s.waitForQuota(req); err != nil {
+		return nil, err
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := s.do(req)
+		if !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+		wait, ok := policy.wait(attempt, resp, time.Now())
+		if deadline, has := ctx.Deadline(); ok && has && time.Until(deadline) < wait {
+			ok = false
+		}
+		if !ok {
+			return resp, err
+		}
+
+		event := RetryEvent{Path: req.URL.Path, Attempt: attempt, Wait: wait}
+		if resp != nil {
+			event.Status = resp.StatusCode
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		utils.GetLogger().Debug("retrying request", "path", event.Path, "attempt", attempt, "status", event.Status, "error", err, "wait", wait)
+		if observe, ok := ctx.Value(retryObserverKey{}).(func(RetryEvent)); ok {
+			observe(event)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+
+		if req, err = cloneRequest(req); err != nil {
+			return nil, err
+		}
+		// A DPoP proof is single-use, so an OAuth session's retry needs a fresh one
+		if req.Header.Get("DPoP") != "" {
+			_, token, _ := strings.Cut(req.Header.Get("Authorization"), " ")
+			if err := s.authorize(req, token); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// shouldRetry reports whether a request's outcome is worth another attempt. Only a 429 promises the request
+// wasn't acted on, so other failures are retried only for methods that are safe to repeat.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) && idempotent(req.Method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(req.Method)
+	}
+	return false
+}
+
+// idempotent reports whether repeating a request with method can't duplicate its effect
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// wait returns the delay before retrying after attempt failed with resp (nil after a transport error), or false
+// once the attempts are used up or the server asks for a longer wait than the policy allows
+func (p RetryPolicy) wait(attempt int, resp *http.Response, now time.Time) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+	if resp != nil {
+		if hint, ok := serverDelay(resp, now); ok {
+			return hint, hint <= p.MaxDelay
+		}
+	}
+
+	backoff := min(p.BaseDelay<<(attempt-1), p.MaxDelay)
+	if backoff <= 0 {
+		return 0, true
+	}
+	// Equal jitter: at least half the backoff, so retries of a burst spread out without collapsing to zero
+	return backoff/2 + rand.N(backoff/2+1), true
+}
+
+// serverDelay reads how long a response asks clients to wait: its Retry-After, or for an exhausted rate limit the
+// time until the window resets
+func serverDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if value := strings.TrimSpace(resp.Header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return max(time.Duration(seconds)*time.Second, 0), true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(at.Sub(now), 0), true
+		}
+	}
+	if quota, ok := ParseQuota(resp.Request.URL.Host, resp.Header, now); ok && quota.Remaining == 0 && quota.ResetAt.After(now) {
+		return quota.ResetAt.Sub(now), true
+	}
+	return 0, false
+}
+
+// noteQuota remembers when a host's rate limit resets once a response reports it used up, so the next request
+// waits for the reset instead of being refused
+func (s *BlueskyService) noteQuota(quota *QuotaModel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if quota.Remaining > 0 {
+		delete(s.exhausted, quota.Host)
+		return
+	}
+	if s.exhausted == nil {
+		s.exhausted = make(map[string]time.Time)
+	}
+	s.exhausted[quota.Host] = quota.ResetAt
+}
+
+// waitForQuota holds req until its host's exhausted rate limit resets. Waits longer than the retry policy's
+// MaxDelay, or past the request's deadline, are skipped and left to the server to refuse.
+func (s *BlueskyService) waitForQuota(req *http.Request) error {
+	s.mu.RLock()
+	resetAt, ok := s.exhausted[req.URL.Host]
+	maxDelay := s.retry.MaxDelay
+	s.mu.RUnlock()
+
+	wait := time.Until(resetAt)
+	if !ok || wait <= 0 || wait > maxDelay {
+		return nil
+	}
+	if deadline, has := req.Context().Deadline(); has && time.Until(deadline) < wait {
+		return nil
+	}
+
+	utils.GetLogger().Debug("rate limit exhausted, waiting for reset", "host", req.URL.Host, "wait", wait)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// BatchReport tallies how the batch helpers fared with the API for a context from [WithBatchReport]: how many
+// actors were requested and fetched, how many requests were retried, and why the rest failed
+type BatchReport struct {
+	mu        sync.Mutex
+	requested int
+	fetched   int
+	retries   int
+	failed    map[string]error // by actor
+}
+
+type batchReportKey struct{}
+
+// WithBatchReport returns a context whose batch helper calls, and retries of their requests, are tallied in the
+// returned report
+func WithBatchReport(ctx context.Context) (context.Context, *BatchReport) {
+	report := &BatchReport{failed: make(map[string]error)}
+	ctx = WithRetryObserver(ctx, func(RetryEvent) {
+		report.mu.Lock()
+		report.retries++
+		report.mu.Unlock()
+	})
+	return context.WithValue(ctx, batchReportKey{}, report), report
+}
+
+// batchReport returns the report ctx tallies into, or nil
+func batchReport(ctx context.Context) *BatchReport {
+	report, _ := ctx.Value(batchReportKey{}).(*BatchReport)
+	return report
+}
+
+// record tallies one actor of a batch; a nil report ignores it
+func (r *BatchReport) record(actor string, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requested++
+	if err != nil {
+		r.failed[actor] = err
+		return
+	}
+	r.fetched++
+}
+
+// Requested returns the number of actors the batch helpers asked the API for
+func (r *BatchReport) Requested() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requested
+}
+
+// Retries returns the number of requests retried
+func (r *BatchReport) Retries() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.retries
+}
+
+// Failed returns the error for each actor that couldn't be fetched
+func (r *BatchReport) Failed() map[string]error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	failed := make(map[string]error, len(r.failed))
+	for actor, err := range r.failed {
+		failed[actor] = err
+	}
+	return failed
+}
+
+// String summarizes the report, e.g. "fetched 98 of 100 after 3 retries; 2 failed (429 Too Many Requests ×2)"
+func (r *BatchReport) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summary := fmt.Sprintf("fetched %d of %d", r.fetched, r.requested)
+	switch {
+	case r.retries == 1:
+		summary += " after 1 retry"
+	case r.retries > 1:
+		summary += fmt.Sprintf(" after %d retries", r.retries)
+	}
+	if len(r.failed) == 0 {
+		return summary
+	}
+
+	counts := make(map[string]int)
+	for _, err := range r.failed {
+		counts[err.Error()]++
+	}
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	slices.SortFunc(reasons, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	if len(reasons) > 3 {
+		reasons = reasons[:3]
+	}
+	for i, reason := range reasons {
+		reasons[i] = fmt.Sprintf("%s ×%d", reason, counts[reason])
+	}
+	return fmt.Sprintf("%s; %d failed (%s)", summary, len(r.failed), strings.Join(reasons, ", "))
+}
@@ -0,0 +1,168 @@
+package bsky
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	fast := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+
+	// newServer answers each request with the next status, then 200 once they run out
+	newServer := func(t *testing.T, header http.Header, statuses ...int) (*BlueskyService, *int) {
+		var mu sync.Mutex
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			if calls <= len(statuses) {
+				for name, values := range header {
+					w.Header()[name] = values
+				}
+				w.WriteHeader(statuses[calls-1])
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+
+		svc := NewBlueskyService(server.URL)
+		svc.SetTokens("test-access-token", "test-refresh-token")
+		svc.SetRetryPolicy(fast)
+		return svc, &calls
+	}
+
+	t.Run("RateLimitedThenSucceeds", func(t *testing.T) {
+		svc, calls := newServer(t, http.Header{"Retry-After": {"0"}}, http.StatusTooManyRequests, http.StatusTooManyRequests)
+		var events []RetryEvent
+		ctx := WithRetryObserver(context.Background(), func(event RetryEvent) { events = append(events, event) })
+
+		resp, err := svc.Request(ctx, "POST", "/xrpc/com.atproto.repo.createRecord", strings.NewReader("{}"), nil)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || *calls != 3 {
+			t.Errorf("got %d after %d calls, want 200 after 3", resp.StatusCode, *calls)
+		}
+		if len(events) != 2 || events[1].Attempt != 2 || events[1].Status != http.StatusTooManyRequests {
+			t.Errorf("unexpected retry events %+v", events)
+		}
+	})
+
+	t.Run("ServerErrorsRetriedOnlyWhenSafe", func(t *testing.T) {
+		svc, calls := newServer(t, nil, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+		resp, err := svc.Request(context.Background(), "GET", "/xrpc/app.bsky.feed.getTimeline", nil, nil)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadGateway || *calls != fast.MaxAttempts {
+			t.Errorf("got %d after %d calls, want 502 after %d", resp.StatusCode, *calls, fast.MaxAttempts)
+		}
+
+		svc, calls = newServer(t, nil, http.StatusBadGateway)
+		resp, err = svc.Request(context.Background(), "POST", "/xrpc/com.atproto.repo.createRecord", strings.NewReader("{}"), nil)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadGateway || *calls != 1 {
+			t.Errorf("a POST that failed with 502 may have been applied; got %d calls, want 1", *calls)
+		}
+	})
+
+	t.Run("LongRetryAfterFailsFast", func(t *testing.T) {
+		svc, calls := newServer(t, http.Header{"Retry-After": {"120"}}, http.StatusTooManyRequests)
+		resp, err := svc.Request(context.Background(), "GET", "/xrpc/app.bsky.feed.getTimeline", nil, nil)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusTooManyRequests || *calls != 1 {
+			t.Errorf("got %d after %d calls, want the 429 returned without waiting", resp.StatusCode, *calls)
+		}
+	})
+
+	t.Run("Wait", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 10 * time.Second}
+		now := time.Now()
+		req := httptest.NewRequest("GET", "https://bsky.social/xrpc/app.bsky.feed.getTimeline", nil)
+
+		for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond} {
+			wait, ok := policy.wait(attempt, nil, now)
+			if !ok || wait < want/2 || wait > want {
+				t.Errorf("attempt %d: wait %v, want between %v and %v", attempt, wait, want/2, want)
+			}
+		}
+		if _, ok := policy.wait(5, nil, now); ok {
+			t.Error("expected no retry once the attempts are used up")
+		}
+
+		reset := &http.Response{Request: req, Header: http.Header{
+			"Ratelimit-Limit":     {"3000"},
+			"Ratelimit-Remaining": {"0"},
+			"Ratelimit-Reset":     {strconv.FormatInt(now.Add(4*time.Second).Unix(), 10)},
+		}}
+		if wait, ok := policy.wait(1, reset, now); !ok || wait < 3*time.Second || wait > 4*time.Second {
+			t.Errorf("wait %v, want until the rate limit resets", wait)
+		}
+
+		date := &http.Response{Request: req, Header: http.Header{"Retry-After": {now.Add(2 * time.Second).UTC().Format(http.TimeFormat)}}}
+		if wait, ok := policy.wait(1, date, now); !ok || wait < time.Second || wait > 2*time.Second {
+			t.Errorf("wait %v, want until the Retry-After date", wait)
+		}
+	})
+}
+
+func TestBatchReport(t *testing.T) {
+	var mu sync.Mutex
+	limited := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := r.URL.Query().Get("actor")
+		switch actor {
+		case "did:plc:gone":
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "InvalidRequest", "message": "Profile not found"})
+			return
+		case "did:plc:busy":
+			mu.Lock()
+			first := !limited
+			limited = true
+			mu.Unlock()
+			if first {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		}
+		json.NewEncoder(w).Encode(ActorProfile{Did: actor, Handle: strings.TrimPrefix(actor, "did:plc:") + ".bsky.social"})
+	}))
+	defer server.Close()
+
+	svc := NewBlueskyService(server.URL)
+	svc.SetTokens("test-access-token", "test-refresh-token")
+	svc.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second})
+
+	ctx, report := WithBatchReport(context.Background())
+	profiles := svc.BatchGetProfiles(ctx, []string{"did:plc:alice", "did:plc:busy", "did:plc:gone"}, 0)
+	if len(profiles) != 2 || profiles["did:plc:busy"] == nil {
+		t.Fatalf("expected alice and busy fetched, got %v", profiles)
+	}
+
+	failed := report.Failed()
+	if report.Requested() != 3 || report.Retries() != 1 || len(failed) != 1 || failed["did:plc:gone"] == nil {
+		t.Errorf("unexpected report: requested %d, retries %d, failed %v", report.Requested(), report.Retries(), failed)
+	}
+	if summary := report.String(); !strings.HasPrefix(summary, "fetched 2 of 3 after 1 retry; 1 failed (") {
+		t.Errorf("unexpected summary %q", summary)
+	}
+}
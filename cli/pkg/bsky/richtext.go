@@ -0,0 +1,155 @@
+package bsky
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// MaxPostGraphemes is the longest post text Bluesky accepts, counted in graphemes (user-perceived characters)
+const MaxPostGraphemes = 300
+
+// maxTagGraphemes is the longest hashtag Bluesky accepts, without the #
+const maxTagGraphemes = 64
+
+// Facet feature types from the app.bsky.richtext.facet lexicon
+const (
+	FacetMention = "app.bsky.richtext.facet#mention"
+	FacetLink    = "app.bsky.richtext.facet#link"
+	FacetTag     = "app.bsky.richtext.facet#tag"
+)
+
+// Facet marks a byte range of post text as a mention, link, or hashtag so apps render it as one
+type Facet struct {
+	Index    FacetIndex     `json:"index"`
+	Features []FacetFeature `json:"features"`
+}
+
+// FacetIndex is a range of a post's text in UTF-8 bytes, end exclusive
+type FacetIndex struct {
+	ByteStart int `json:"byteStart"`
+	ByteEnd   int `json:"byteEnd"`
+}
+
+// FacetFeature is what a facet's text stands for: an account for a mention, a URL for a link, or a tag
+type FacetFeature struct {
+	Type string `json:"$type"`
+	Did  string `json:"did,omitempty"`
+	Uri  string `json:"uri,omitempty"`
+	Tag  string `json:"tag,omitempty"`
+}
+
+// TextSpan is a mention, link, or hashtag found in post text, before mentions are resolved to DIDs
+type TextSpan struct {
+	Type  string // [FacetMention], [FacetLink], or [FacetTag]
+	Start int    // byte offset of the span, including its @ or #
+	End   int
+	Value string // the handle without @, the URL, or the tag without #
+}
+
+var (
+	mentionPattern = regexp.MustCompile(`(?:^|[\s(])@([a-zA-Z0-9][a-zA-Z0-9.-]*[a-zA-Z0-9])`)
+	linkPattern    = regexp.MustCompile(`(?:^|[\s(])(https?://[^\s]+)`)
+	tagPattern     = regexp.MustCompile(`(?:^|\s)[#＃]([^\s#＃]+)`)
+)
+
+// GraphemeLength counts the graphemes in text, the unit Bluesky's post length limit uses. An emoji built from
+// several code points, such as a flag, counts once.
+func GraphemeLength(text string) int {
+	return uniseg.GraphemeClusterCount(text)
+}
+
+// FindTextSpans finds the mentions, links, and hashtags in post text, in the order they appear. Mentions must look
+// like a full handle (alice.bsky.social), and trailing punctuation is left out of links and tags.
+func FindTextSpans(text string) []TextSpan {
+	var spans []TextSpan
+
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		handle := text[m[2]:m[3]]
+		if !looksLikeHandle(handle) {
+			continue
+		}
+		spans = append(spans, TextSpan{Type: FacetMention, Start: m[2] - 1, End: m[3], Value: strings.ToLower(handle)})
+	}
+
+	for _, m := range linkPattern.FindAllStringSubmatchIndex(text, -1) {
+		link := trimTrailingPunctuation(text[m[2]:m[3]])
+		if strings.HasSuffix(link, ")") && !strings.Contains(link, "(") {
+			link = strings.TrimSuffix(link, ")")
+		}
+		if strings.TrimPrefix(strings.TrimPrefix(link, "http://"), "https://") == "" {
+			continue
+		}
+		spans = append(spans, TextSpan{Type: FacetLink, Start: m[2], End: m[2] + len(link), Value: link})
+	}
+
+	for _, m := range tagPattern.FindAllStringSubmatchIndex(text, -1) {
+		tag := trimTrailingPunctuation(text[m[2]:m[3]])
+		if tag == "" || GraphemeLength(tag) > maxTagGraphemes || strings.IndexFunc(tag, isNotDigit) < 0 {
+			continue
+		}
+		// The # is one byte, or three for the full-width ＃
+		start := m[2] - 1
+		if text[start] != '#' {
+			start = m[2] - len("＃")
+		}
+		spans = append(spans, TextSpan{Type: FacetTag, Start: start, End: m[2] + len(tag), Value: tag})
+	}
+
+	slices.SortFunc(spans, func(a, b TextSpan) int { return a.Start - b.Start })
+	return spans
+}
+
+// Facets turns the spans found in text into facets. resolve looks up the DID of a mentioned handle; mentions it
+// can't resolve are left as plain text, as the Bluesky app does.
+func Facets(ctx context.Context, spans []TextSpan, resolve func(ctx context.Context, handle string) (string, error)) []Facet {
+	var facets []Facet
+	for _, span := range spans {
+		feature := FacetFeature{Type: span.Type}
+		switch span.Type {
+		case FacetMention:
+			did, err := resolve(ctx, span.Value)
+			if err != nil || did == "" {
+				continue
+			}
+			feature.Did = did
+		case FacetLink:
+			feature.Uri = span.Value
+		case FacetTag:
+			feature.Tag = span.Value
+		}
+		facets = append(facets, Facet{Index: FacetIndex{ByteStart: span.Start, ByteEnd: span.End}, Features: []FacetFeature{feature}})
+	}
+	return facets
+}
+
+// looksLikeHandle reports whether s has the shape of a domain handle: dot-separated labels ending in a letter
+func looksLikeHandle(s string) bool {
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+	}
+	tld := labels[len(labels)-1]
+	return unicode.IsLetter(rune(tld[0]))
+}
+
+// trimTrailingPunctuation drops the punctuation that ends a sentence, so "see https://example.com." links without the
+// period
+func trimTrailingPunctuation(s string) string {
+	return strings.TrimRightFunc(s, func(r rune) bool {
+		return strings.ContainsRune(`.,;:!?"'`, r)
+	})
+}
+
+func isNotDigit(r rune) bool {
+	return !unicode.IsDigit(r)
+}
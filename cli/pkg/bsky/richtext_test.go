@@ -0,0 +1,92 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGraphemeLength(t *testing.T) {
+	tests := map[string]int{
+		"":             0,
+		"hello":        5,
+		"café":         4,
+		"🇳🇿 flag":      6,
+		"👩‍👩‍👧 family": 8,
+	}
+	for text, want := range tests {
+		if got := GraphemeLength(text); got != want {
+			t.Errorf("GraphemeLength(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestFindTextSpans(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []TextSpan
+	}{
+		{
+			name: "mention link and tag",
+			text: "hi @Alice.bsky.social see https://example.com/a?b=1. #golang!",
+			want: []TextSpan{
+				{Type: FacetMention, Start: 3, End: 21, Value: "alice.bsky.social"},
+				{Type: FacetLink, Start: 26, End: 51, Value: "https://example.com/a?b=1"},
+				{Type: FacetTag, Start: 53, End: 60, Value: "golang"},
+			},
+		},
+		{
+			name: "byte offsets after multibyte text",
+			text: "café #día",
+			want: []TextSpan{{Type: FacetTag, Start: 6, End: 11, Value: "día"}},
+		},
+		{
+			name: "full-width hash",
+			text: "＃tag",
+			want: []TextSpan{{Type: FacetTag, Start: 0, End: 6, Value: "tag"}},
+		},
+		{
+			name: "link in parentheses",
+			text: "(docs: https://example.com/x)",
+			want: []TextSpan{{Type: FacetLink, Start: 7, End: 28, Value: "https://example.com/x"}},
+		},
+		{
+			name: "not facets",
+			text: "email bob@example.com, @alice, #123, a#b, and https://",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindTextSpans(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindTextSpans(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+			for _, span := range got {
+				if span.Type == FacetLink && tt.text[span.Start:span.End] != span.Value {
+					t.Errorf("span %+v covers %q", span, tt.text[span.Start:span.End])
+				}
+			}
+		})
+	}
+}
+
+func TestFacets(t *testing.T) {
+	text := "@alice.bsky.social and @ghost.example.com #go"
+	resolve := func(ctx context.Context, handle string) (string, error) {
+		if handle == "alice.bsky.social" {
+			return "did:plc:alice", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	facets := Facets(context.Background(), FindTextSpans(text), resolve)
+	want := []Facet{
+		{Index: FacetIndex{ByteStart: 0, ByteEnd: 18}, Features: []FacetFeature{{Type: FacetMention, Did: "did:plc:alice"}}},
+		{Index: FacetIndex{ByteStart: 42, ByteEnd: 45}, Features: []FacetFeature{{Type: FacetTag, Tag: "go"}}},
+	}
+	if !reflect.DeepEqual(facets, want) {
+		t.Errorf("Facets = %+v, want %+v", facets, want)
+	}
+}
@@ -0,0 +1,609 @@
+package bsky
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Enumeration of [Service] implementations
+type ServiceID = int32
+
+// String representation of [ServiceID] (e.g., "Bluesky", "GitHub", "WeatherAPI")
+type ServiceIdentifier = string
+
+// Service defines the general contract for any API service client.
+// It abstracts away protocol details and provides lifecycle, request, and authentication semantics.
+type Service interface {
+	// Name returns the service identifier
+	Name() ServiceIdentifier
+	// BaseURL returns the root endpoint of the remote API.
+	BaseURL() string
+	// Authenticated reports whether the client is currently authorized.
+	Authenticated() bool
+	// Authenticate establishes credentials with the service (token, key, etc.).
+	Authenticate(ctx context.Context, credentials any) error
+	// Request performs a generic API request and returns the raw response.
+	// Implementations may wrap or replace [http.Client] as needed.
+	Request(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error)
+	// HealthCheck verifies connectivity and minimal readiness of the remote API.
+	HealthCheck(ctx context.Context) error
+	// Close releases underlying network or session resources.
+	Close() error
+}
+
+// CreateSessionResponse models response from com.atproto.server.createSession.
+// Returns authentication tokens and user metadata after successful login.
+type CreateSessionResponse struct {
+	Did             string  `json:"did"`
+	DidDoc          *DidDoc `json:"didDoc,omitempty"`
+	Handle          string  `json:"handle"`
+	Email           string  `json:"email,omitempty"`
+	EmailConfirmed  bool    `json:"emailConfirmed"`
+	EmailAuthFactor bool    `json:"emailAuthFactor"`
+	AccessJwt       string  `json:"accessJwt"`
+	RefreshJwt      string  `json:"refreshJwt"`
+	Active          bool    `json:"active"`
+	Status          string  `json:"status,omitempty"`
+}
+
+// DidDoc represents a DID document as per W3C DID specification.
+// Contains verification methods and service endpoints for the DID.
+type DidDoc struct {
+	Context            []string             `json:"@context"`
+	ID                 string               `json:"id"`
+	AlsoKnownAs        []string             `json:"alsoKnownAs,omitempty"`
+	VerificationMethod []VerificationMethod `json:"verificationMethod,omitempty"`
+	Service            []DidService         `json:"service,omitempty"`
+}
+
+// VerificationMethod represents a cryptographic public key for DID verification
+type VerificationMethod struct {
+	ID                 string `json:"id"`
+	Type               string `json:"type"`
+	Controller         string `json:"controller"`
+	PublicKeyMultibase string `json:"publicKeyMultibase,omitempty"`
+}
+
+// DidService represents a service endpoint in the DID document (e.g., PDS endpoint).
+type DidService struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	ServiceEndpoint string `json:"serviceEndpoint"`
+}
+
+// Record is one record in a repository, as returned by com.atproto.repo.getRecord and listRecords.
+// Its value is left undecoded since its shape depends on the collection.
+type Record struct {
+	Uri   string          `json:"uri"`
+	Cid   string          `json:"cid"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ListRecordsResponse models response from com.atproto.repo.listRecords, a page of one collection's records
+type ListRecordsResponse struct {
+	Cursor  string   `json:"cursor,omitempty"`
+	Records []Record `json:"records"`
+}
+
+// CreateRecordResponse models response from com.atproto.repo.createRecord.
+// Returns the URI and CID of the newly created record along with commit metadata.
+type CreateRecordResponse struct {
+	Uri    string `json:"uri"`
+	Cid    string `json:"cid"`
+	Commit struct {
+		Cid string `json:"cid"`
+		Rev string `json:"rev"`
+	} `json:"commit"`
+	ValidationStatus string `json:"validationStatus,omitempty"`
+}
+
+// Blob is a reference to media uploaded with com.atproto.repo.uploadBlob, for embedding in a record
+type Blob struct {
+	Type     string  `json:"$type"`
+	Ref      BlobRef `json:"ref"`
+	MimeType string  `json:"mimeType"`
+	Size     int     `json:"size"`
+}
+
+// BlobRef is the content link inside a [Blob]
+type BlobRef struct {
+	Link string `json:"$link"`
+}
+
+// Limits on the images of one post
+const (
+	MaxPostImages = 4
+	MaxImageBytes = 1_000_000
+)
+
+// PostImage is an uploaded image attached to a new post
+type PostImage struct {
+	Blob   *Blob
+	Alt    string
+	Width  int // pixel size, or 0 when unknown; apps use it to lay the image out before it loads
+	Height int
+}
+
+// MaxPostLangs is the most languages a post can be tagged with
+const MaxPostLangs = 3
+
+// ExternalEmbed is a link card attached to a new post
+type ExternalEmbed struct {
+	URI         string
+	Title       string
+	Description string
+	Thumb       *Blob // optional preview image
+}
+
+// NewPost is the content of a post to publish with [BlueskyService.CreatePost]
+type NewPost struct {
+	Text     string
+	Facets   []Facet
+	Images   []PostImage    // at most [MaxPostImages]
+	External *ExternalEmbed // a link card; a post has images or a link card, not both
+	Reply    *ReplyRefs     // set to publish a reply
+	Langs    []string       // BCP-47 tags of the languages of Text, at most [MaxPostLangs]
+}
+
+// CreateReportResponse models response from com.atproto.moderation.createReport
+type CreateReportResponse struct {
+	ID         int64          `json:"id"`
+	ReasonType string         `json:"reasonType"`
+	Reason     string         `json:"reason,omitempty"`
+	Subject    map[string]any `json:"subject"`
+	ReportedBy string         `json:"reportedBy"`
+	CreatedAt  string         `json:"createdAt"`
+}
+
+// GetTimelineResponse models response from app.bsky.feed.getTimeline.
+// Returns the authenticated user's home timeline feed with pagination support.
+type GetTimelineResponse struct {
+	Cursor string         `json:"cursor,omitempty"`
+	Feed   []FeedViewPost `json:"feed"`
+}
+
+// GetFeedResponse models response from app.bsky.feed.getFeed: a page of a custom feed's posts
+type GetFeedResponse struct {
+	Cursor string         `json:"cursor,omitempty"`
+	Feed   []FeedViewPost `json:"feed"`
+}
+
+// GetAuthorFeedResponse models response from app.bsky.feed.getAuthorFeed.
+// Returns posts by a specific author with optional reply context.
+type GetAuthorFeedResponse struct {
+	Cursor string         `json:"cursor,omitempty"`
+	Feed   []FeedViewPost `json:"feed"`
+}
+
+// GetActorLikesResponse is a page of posts an account liked, newest like first
+type GetActorLikesResponse struct {
+	Cursor string         `json:"cursor,omitempty"`
+	Feed   []FeedViewPost `json:"feed"`
+}
+
+// GetFollowsResponse models response from app.bsky.graph.getFollows.
+// Returns list of accounts that a given actor follows.
+type GetFollowsResponse struct {
+	Subject *ActorProfile  `json:"subject,omitempty"`
+	Cursor  string         `json:"cursor,omitempty"`
+	Follows []ActorProfile `json:"follows"`
+}
+
+// GetFollowersResponse models response from app.bsky.graph.getFollowers.
+// Returns list of accounts that follow a given actor.
+type GetFollowersResponse struct {
+	Subject   *ActorProfile  `json:"subject,omitempty"`
+	Cursor    string         `json:"cursor,omitempty"`
+	Followers []ActorProfile `json:"followers"`
+}
+
+// Relationship describes how another actor relates to the queried actor (app.bsky.graph.defs#relationship)
+type Relationship struct {
+	Did        string `json:"did"`
+	Following  string `json:"following,omitempty"`  // URI of the actor's follow of Did
+	FollowedBy string `json:"followedBy,omitempty"` // URI of Did's follow of the actor
+	NotFound   bool   `json:"notFound,omitempty"`
+}
+
+// GetRelationshipsResponse models response from app.bsky.graph.getRelationships
+type GetRelationshipsResponse struct {
+	Actor         string         `json:"actor"`
+	Relationships []Relationship `json:"relationships"`
+}
+
+// ListView describes a curation or moderation list (app.bsky.graph.defs#listView)
+type ListView struct {
+	Uri           string        `json:"uri"`
+	Cid           string        `json:"cid"`
+	Creator       *ActorProfile `json:"creator,omitempty"`
+	Name          string        `json:"name"`
+	Purpose       string        `json:"purpose"`
+	Description   string        `json:"description,omitempty"`
+	ListItemCount int           `json:"listItemCount,omitempty"`
+	IndexedAt     string        `json:"indexedAt,omitempty"`
+}
+
+// ListItemView is a single membership record of a list
+type ListItemView struct {
+	Uri     string       `json:"uri"`
+	Subject ActorProfile `json:"subject"`
+}
+
+// GetListResponse models response from app.bsky.graph.getList.
+// Returns the list itself and a page of its members.
+type GetListResponse struct {
+	Cursor string         `json:"cursor,omitempty"`
+	List   ListView       `json:"list"`
+	Items  []ListItemView `json:"items"`
+}
+
+// Like is a single like on a post, as returned by app.bsky.feed.getLikes
+type Like struct {
+	Actor     ActorProfile `json:"actor"`
+	CreatedAt string       `json:"createdAt"`
+	IndexedAt string       `json:"indexedAt"`
+}
+
+// GetLikesResponse models response from app.bsky.feed.getLikes.
+// Returns a page of accounts that liked a post.
+type GetLikesResponse struct {
+	Uri    string `json:"uri"`
+	Cursor string `json:"cursor,omitempty"`
+	Likes  []Like `json:"likes"`
+}
+
+// GetRepostedByResponse models response from app.bsky.feed.getRepostedBy.
+// Returns a page of accounts that reposted a post.
+type GetRepostedByResponse struct {
+	Uri        string         `json:"uri"`
+	Cursor     string         `json:"cursor,omitempty"`
+	RepostedBy []ActorProfile `json:"repostedBy"`
+}
+
+// Notification is a single item from app.bsky.notification.listNotifications
+type Notification struct {
+	Uri           string       `json:"uri"`
+	Cid           string       `json:"cid"`
+	Author        ActorProfile `json:"author"`
+	Reason        string       `json:"reason"` // like, repost, follow, mention, reply, quote, ...
+	ReasonSubject string       `json:"reasonSubject,omitempty"`
+	Record        any          `json:"record"`
+	IsRead        bool         `json:"isRead"`
+	IndexedAt     string       `json:"indexedAt"`
+}
+
+// ListNotificationsResponse models response from app.bsky.notification.listNotifications.
+// Notifications are returned newest first.
+type ListNotificationsResponse struct {
+	Cursor        string         `json:"cursor,omitempty"`
+	Notifications []Notification `json:"notifications"`
+	SeenAt        string         `json:"seenAt,omitempty"`
+	Priority      bool           `json:"priority,omitempty"` // whether the user only gets notifications from accounts they follow
+}
+
+// AppPassword describes an app password of the account, from com.atproto.server.listAppPasswords
+type AppPassword struct {
+	Name       string `json:"name"`
+	CreatedAt  string `json:"createdAt"`
+	Privileged bool   `json:"privileged,omitempty"` // can also read and send direct messages
+}
+
+// ListAppPasswordsResponse models response from com.atproto.server.listAppPasswords
+type ListAppPasswordsResponse struct {
+	Passwords []AppPassword `json:"passwords"`
+}
+
+// NotificationPreferences models responses from app.bsky.notification.getPreferences and putPreferencesV2.
+// Each category (like, reply, follow, ...) holds its own settings, such as include ("all" or "follows"), list,
+// and push; they are kept undecoded so categories added by the server show up without changes here.
+type NotificationPreferences struct {
+	Preferences map[string]map[string]any `json:"preferences"`
+}
+
+// GetPreferencesResponse models response from app.bsky.actor.getPreferences.
+// Preferences are a union keyed by $type and kept undecoded.
+type GetPreferencesResponse struct {
+	Preferences []map[string]any `json:"preferences"`
+}
+
+// Labelers returns the DIDs of the labelers the user subscribes to (app.bsky.actor.defs#labelersPref)
+func (r *GetPreferencesResponse) Labelers() []string {
+	var dids []string
+	for _, pref := range r.Preferences {
+		if pref["$type"] != "app.bsky.actor.defs#labelersPref" {
+			continue
+		}
+		labelers, _ := pref["labelers"].([]any)
+		for _, labeler := range labelers {
+			entry, _ := labeler.(map[string]any)
+			if did, ok := entry["did"].(string); ok && did != "" {
+				dids = append(dids, did)
+			}
+		}
+	}
+	return dids
+}
+
+// Preference types holding saved feeds: the current list of items, and the older pinned/saved URI lists the app
+// still keeps in step with it
+const (
+	savedFeedsPrefV2Type = "app.bsky.actor.defs#savedFeedsPrefV2"
+	savedFeedsPrefType   = "app.bsky.actor.defs#savedFeedsPref"
+)
+
+// Kinds of saved feed items
+const (
+	SavedFeedFeed     = "feed"     // a feed generator, by AT URI
+	SavedFeedList     = "list"     // a list shown as a feed, by AT URI
+	SavedFeedTimeline = "timeline" // the Following timeline, with value "following"
+)
+
+// SavedFeed is an item of the user's saved feeds (app.bsky.actor.defs#savedFeed). Pinned items are the tabs on
+// the app's home screen, in order.
+type SavedFeed struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	Pinned bool   `json:"pinned"`
+}
+
+// SavedFeeds returns the user's saved feeds in order. Accounts that never had the current preference are read from
+// the older one, pinned feeds first.
+func (r *GetPreferencesResponse) SavedFeeds() []SavedFeed {
+	for _, pref := range r.Preferences {
+		if pref["$type"] != savedFeedsPrefV2Type {
+			continue
+		}
+		items, _ := pref["items"].([]any)
+		feeds := make([]SavedFeed, 0, len(items))
+		for _, item := range items {
+			entry, _ := item.(map[string]any)
+			feed := SavedFeed{}
+			feed.ID, _ = entry["id"].(string)
+			feed.Type, _ = entry["type"].(string)
+			feed.Value, _ = entry["value"].(string)
+			feed.Pinned, _ = entry["pinned"].(bool)
+			if feed.Value != "" {
+				feeds = append(feeds, feed)
+			}
+		}
+		return feeds
+	}
+
+	var feeds []SavedFeed
+	for _, pref := range r.Preferences {
+		if pref["$type"] != savedFeedsPrefType {
+			continue
+		}
+		pinned := map[string]bool{}
+		for _, key := range []string{"pinned", "saved"} {
+			uris, _ := pref[key].([]any)
+			for _, value := range uris {
+				uri, _ := value.(string)
+				if uri == "" || pinned[uri] {
+					continue
+				}
+				if key == "pinned" {
+					pinned[uri] = true
+				}
+				feeds = append(feeds, SavedFeed{Type: SavedFeedType(uri), Value: uri, Pinned: key == "pinned"})
+			}
+		}
+	}
+	return feeds
+}
+
+// SetSavedFeeds replaces the user's saved feeds, leaving other preferences untouched. The older pinned/saved
+// preference is rewritten to match when the account still has one.
+func (r *GetPreferencesResponse) SetSavedFeeds(feeds []SavedFeed) {
+	items := make([]any, len(feeds))
+	var pinned, saved []any
+	for i, feed := range feeds {
+		items[i] = map[string]any{"id": feed.ID, "type": feed.Type, "value": feed.Value, "pinned": feed.Pinned}
+		if feed.Type == SavedFeedTimeline {
+			continue
+		}
+		saved = append(saved, feed.Value)
+		if feed.Pinned {
+			pinned = append(pinned, feed.Value)
+		}
+	}
+
+	found := false
+	for _, pref := range r.Preferences {
+		switch pref["$type"] {
+		case savedFeedsPrefV2Type:
+			pref["items"] = items
+			found = true
+		case savedFeedsPrefType:
+			pref["pinned"] = append([]any{}, pinned...)
+			pref["saved"] = append([]any{}, saved...)
+		}
+	}
+	if !found {
+		r.Preferences = append(r.Preferences, map[string]any{"$type": savedFeedsPrefV2Type, "items": items})
+	}
+}
+
+// SavedFeedType returns the saved feed item type for a value: timeline for "following", list for list URIs, and
+// feed otherwise
+func SavedFeedType(value string) string {
+	switch {
+	case value == "following":
+		return SavedFeedTimeline
+	case strings.Contains(value, "/app.bsky.graph.list/"):
+		return SavedFeedList
+	default:
+		return SavedFeedFeed
+	}
+}
+
+// FeedViewPost represents a single item in a feed, containing the post and optional context.
+// Includes repost reasoning and reply threading context when applicable.
+type FeedViewPost struct {
+	Post   *PostView   `json:"post"`
+	Reason *ReasonView `json:"reason,omitempty"`
+	Reply  *ReplyRefs  `json:"reply,omitempty"`
+}
+
+// PostView represents a post with full metadata including engagement metrics.
+// Contains author info, content, embeds, and viewer-specific state.
+type PostView struct {
+	Uri           string        `json:"uri"`
+	Cid           string        `json:"cid"`
+	Author        *ActorProfile `json:"author"`
+	Record        any           `json:"record"`
+	Embed         any           `json:"embed,omitempty"`
+	ReplyCount    int           `json:"replyCount"`
+	RepostCount   int           `json:"repostCount"`
+	LikeCount     int           `json:"likeCount"`
+	QuoteCount    int           `json:"quoteCount"`
+	BookmarkCount int           `json:"bookmarkCount,omitempty"`
+	IndexedAt     string        `json:"indexedAt"`
+	Viewer        *ViewerState  `json:"viewer,omitempty"`
+	Labels        []Label       `json:"labels,omitempty"`
+}
+
+// ReasonView indicates why a post appears in the feed (e.g., repost by followed user)
+type ReasonView struct {
+	Type      string        `json:"$type"`
+	By        *ActorProfile `json:"by,omitempty"`
+	IndexedAt string        `json:"indexedAt,omitempty"`
+}
+
+// ReplyRefs contains references to the root and parent posts in a thread
+type ReplyRefs struct {
+	Root   *PostRef `json:"root"`
+	Parent *PostRef `json:"parent"`
+}
+
+// PostRef is a minimal reference to a post (URI and CID only)
+type PostRef struct {
+	Uri string `json:"uri"`
+	Cid string `json:"cid"`
+}
+
+// ActorProfile represents a user's profile with social graph metadata.
+// Includes display information, verification status, and viewer's relationship to the actor.
+type ActorProfile struct {
+	Did            string        `json:"did"`
+	Handle         string        `json:"handle"`
+	DisplayName    string        `json:"displayName,omitempty"`
+	Description    string        `json:"description,omitempty"`
+	Avatar         string        `json:"avatar,omitempty"`
+	Banner         string        `json:"banner,omitempty"`
+	FollowersCount int           `json:"followersCount,omitempty"`
+	FollowsCount   int           `json:"followsCount,omitempty"`
+	PostsCount     int           `json:"postsCount,omitempty"`
+	Associated     *Associated   `json:"associated,omitempty"`
+	Viewer         *ViewerState  `json:"viewer,omitempty"`
+	Labels         []Label       `json:"labels,omitempty"`
+	CreatedAt      string        `json:"createdAt,omitempty"`
+	IndexedAt      string        `json:"indexedAt,omitempty"`
+	Verification   *Verification `json:"verification,omitempty"`
+	Status         *ActorStatus  `json:"status,omitempty"`
+}
+
+// Associated contains chat and subscription preferences for an actor
+type Associated struct {
+	Chat                 *ChatSettings         `json:"chat,omitempty"`
+	ActivitySubscription *SubscriptionSettings `json:"activitySubscription,omitempty"`
+}
+
+// ChatSettings defines who can send chat messages to this actor
+type ChatSettings struct {
+	AllowIncoming string `json:"allowIncoming"` // "all", "following", "none"
+}
+
+// SubscriptionSettings defines who can subscribe to activity updates
+type SubscriptionSettings struct {
+	AllowSubscriptions string `json:"allowSubscriptions"` // "all", "followers", "none"
+}
+
+// ViewerState represents the current user's relationship with an actor or post.
+// Tracks muting, blocking, following, and engagement state.
+type ViewerState struct {
+	Muted             bool   `json:"muted"`
+	BlockedBy         bool   `json:"blockedBy"`
+	Blocking          string `json:"blocking,omitempty"`
+	Following         string `json:"following,omitempty"`
+	FollowedBy        string `json:"followedBy,omitempty"`
+	Bookmarked        bool   `json:"bookmarked,omitempty"`
+	ThreadMuted       bool   `json:"threadMuted,omitempty"`
+	EmbeddingDisabled bool   `json:"embeddingDisabled,omitempty"`
+	Pinned            bool   `json:"pinned,omitempty"`
+	Like              string `json:"like,omitempty"`
+	Repost            string `json:"repost,omitempty"`
+}
+
+// Label represents a content label applied to a post or actor (e.g., for moderation)
+type Label struct {
+	Src string `json:"src"`
+	Uri string `json:"uri"`
+	Cid string `json:"cid,omitempty"`
+	Val string `json:"val"`
+	Cts string `json:"cts"`           // created timestamp
+	Exp string `json:"exp,omitempty"` // expiration
+	Sig []byte `json:"sig,omitempty"`
+}
+
+// Verification contains verification status and trusted verifier information
+type Verification struct {
+	Verifications         []VerificationRecord `json:"verifications,omitempty"`
+	VerifiedStatus        string               `json:"verifiedStatus,omitempty"`
+	TrustedVerifierStatus string               `json:"trustedVerifierStatus,omitempty"`
+}
+
+// VerificationRecord represents a single verification from a trusted verifier
+type VerificationRecord struct {
+	Issuer    string `json:"issuer"`
+	Uri       string `json:"uri"`
+	IsValid   bool   `json:"isValid"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// ActorStatus represents live status (e.g., streaming status) for an actor
+type ActorStatus struct {
+	Record    any    `json:"record,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Embed     any    `json:"embed,omitempty"`
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	IsActive  bool   `json:"isActive"`
+}
+
+// SearchActorsResponse models response from app.bsky.actor.searchActors matching the search query with pagination support.
+type SearchActorsResponse struct {
+	Cursor string         `json:"cursor,omitempty"`
+	Actors []ActorProfile `json:"actors"`
+}
+
+// SearchPostsResponse models response from app.bsky.feed.searchPosts matching the search query with pagination support.
+type SearchPostsResponse struct {
+	Cursor string         `json:"cursor,omitempty"`
+	Posts  []FeedViewPost `json:"posts"`
+}
+
+// GetPostsResponse models response from app.bsky.feed.getPosts.
+type GetPostsResponse struct {
+	Posts []FeedViewPost `json:"posts"`
+}
+
+// GetPostThreadResponse models response from app.bsky.feed.getPostThread
+type GetPostThreadResponse struct {
+	Thread *ThreadViewPost `json:"thread"`
+}
+
+// ThreadViewPost is one post in a thread with its parent chain and replies.
+// Posts that were deleted or are hidden by a block come back with NotFound or Blocked set and no Post.
+type ThreadViewPost struct {
+	Type     string            `json:"$type"`
+	Uri      string            `json:"uri,omitempty"`
+	Post     *PostView         `json:"post,omitempty"`
+	Parent   *ThreadViewPost   `json:"parent,omitempty"`
+	Replies  []*ThreadViewPost `json:"replies,omitempty"`
+	NotFound bool              `json:"notFound,omitempty"`
+	Blocked  bool              `json:"blocked,omitempty"`
+}
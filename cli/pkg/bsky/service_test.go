@@ -1,6 +1,7 @@
-package store
+package bsky
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -140,3 +141,79 @@ func TestLabel(t *testing.T) {
 		t.Errorf("expected Src 'did:plc:moderator', got %s", label.Src)
 	}
 }
+
+func TestGetPreferencesResponse_Labelers(t *testing.T) {
+	var response GetPreferencesResponse
+	data := `{"preferences": [
+		{"$type": "app.bsky.actor.defs#adultContentPref", "enabled": false},
+		{"$type": "app.bsky.actor.defs#labelersPref", "labelers": [{"did": "did:plc:one"}, {"did": "did:plc:two"}]}
+	]}`
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	labelers := response.Labelers()
+	if len(labelers) != 2 || labelers[0] != "did:plc:one" || labelers[1] != "did:plc:two" {
+		t.Errorf("unexpected labelers %v", labelers)
+	}
+
+	if got := (&GetPreferencesResponse{}).Labelers(); len(got) != 0 {
+		t.Errorf("expected no labelers without a labelersPref, got %v", got)
+	}
+}
+
+func TestGetPreferencesResponse_SavedFeeds(t *testing.T) {
+	const discover = "at://did:plc:feeds/app.bsky.feed.generator/whats-hot"
+	const list = "at://did:plc:me/app.bsky.graph.list/3kfriends"
+
+	t.Run("current preference", func(t *testing.T) {
+		var response GetPreferencesResponse
+		data := `{"preferences": [
+			{"$type": "app.bsky.actor.defs#savedFeedsPrefV2", "items": [
+				{"id": "a", "type": "timeline", "value": "following", "pinned": true},
+				{"id": "b", "type": "feed", "value": "` + discover + `", "pinned": false}
+			]},
+			{"$type": "app.bsky.actor.defs#savedFeedsPref", "pinned": [], "saved": ["` + discover + `"]},
+			{"$type": "app.bsky.actor.defs#adultContentPref", "enabled": false}
+		]}`
+		if err := json.Unmarshal([]byte(data), &response); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+
+		feeds := response.SavedFeeds()
+		if len(feeds) != 2 || !feeds[0].Pinned || feeds[0].Type != SavedFeedTimeline || feeds[1].Value != discover {
+			t.Fatalf("unexpected saved feeds %+v", feeds)
+		}
+
+		feeds[1].Pinned = true
+		response.SetSavedFeeds(append(feeds, SavedFeed{ID: "c", Type: SavedFeedList, Value: list}))
+		if got := response.SavedFeeds(); len(got) != 3 || !got[1].Pinned || got[2].Type != SavedFeedList {
+			t.Errorf("unexpected saved feeds after update %+v", got)
+		}
+		legacy := response.Preferences[1]
+		if pinned := legacy["pinned"].([]any); len(pinned) != 1 || pinned[0] != discover {
+			t.Errorf("expected the older preference to pin %s, got %v", discover, legacy["pinned"])
+		}
+		if saved := legacy["saved"].([]any); len(saved) != 2 {
+			t.Errorf("expected the older preference to save both feeds, got %v", saved)
+		}
+		if len(response.Preferences) != 3 || response.Preferences[2]["enabled"] != false {
+			t.Errorf("expected other preferences untouched, got %v", response.Preferences)
+		}
+	})
+
+	t.Run("older preference only", func(t *testing.T) {
+		response := GetPreferencesResponse{Preferences: []map[string]any{
+			{"$type": "app.bsky.actor.defs#savedFeedsPref", "pinned": []any{list}, "saved": []any{discover, list}},
+		}}
+		feeds := response.SavedFeeds()
+		if len(feeds) != 2 || feeds[0].Value != list || !feeds[0].Pinned || feeds[0].Type != SavedFeedList || feeds[1].Pinned {
+			t.Fatalf("unexpected saved feeds %+v", feeds)
+		}
+
+		response.SetSavedFeeds(feeds)
+		if len(response.Preferences) != 2 || response.Preferences[1]["$type"] != "app.bsky.actor.defs#savedFeedsPrefV2" {
+			t.Errorf("expected the current preference to be added, got %v", response.Preferences)
+		}
+	})
+}
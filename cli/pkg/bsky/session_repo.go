@@ -0,0 +1,359 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// SessionRepository implements Repository for SessionModel.
+// Persists session data to ~/.skycli/.config.json with encrypted tokens. Several accounts can be signed in at
+// once: one is the default, and [SessionRepository.Use] picks another for a single run.
+type SessionRepository struct {
+	config *config.Config
+	active *config.SessionConfig // account picked with Use; nil means the default
+}
+
+// NewSessionRepository creates a new session repository instance
+func NewSessionRepository() (*SessionRepository, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionRepository{config: cfg}, nil
+}
+
+// Init ensures the config directory exists and loads existing configuration
+func (r *SessionRepository) Init(ctx context.Context) error {
+	return config.EnsureConfigDir()
+}
+
+// Close is a no-op for file-based storage
+func (r *SessionRepository) Close() error {
+	return nil
+}
+
+// current returns the session commands run as: the account picked with Use, else the default
+func (r *SessionRepository) current() *config.SessionConfig {
+	if r.active != nil {
+		return r.active
+	}
+	return r.config.Session
+}
+
+// accounts returns every signed-in account, the default first
+func (r *SessionRepository) accounts() []*config.SessionConfig {
+	var all []*config.SessionConfig
+	if r.config.Session != nil {
+		all = append(all, r.config.Session)
+	}
+	return append(all, r.config.Accounts...)
+}
+
+// find returns the signed-in account with the given DID or handle, or nil
+func (r *SessionRepository) find(account string) *config.SessionConfig {
+	account = strings.TrimPrefix(account, "@")
+	for _, stored := range r.accounts() {
+		if stored.Did == account || strings.EqualFold(stored.Handle, account) {
+			return stored
+		}
+	}
+	return nil
+}
+
+// Get retrieves the signed-in account with the given DID or handle, or the current session when id is empty
+func (r *SessionRepository) Get(ctx context.Context, id string) (Model, error) {
+	stored := r.current()
+	if id != "" {
+		stored = r.find(id)
+	}
+	if stored == nil {
+		return nil, errors.New("no active session")
+	}
+	return sessionModel(stored)
+}
+
+// List returns every signed-in account, the default first
+func (r *SessionRepository) List(ctx context.Context) ([]Model, error) {
+	models := []Model{}
+	for _, stored := range r.accounts() {
+		session, err := sessionModel(stored)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, session)
+	}
+	return models, nil
+}
+
+// sessionModel decrypts a stored account into a [SessionModel]
+func sessionModel(stored *config.SessionConfig) (*SessionModel, error) {
+	accessToken, err := stored.GetAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := stored.GetRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &SessionModel{
+		Handle:      stored.Handle,
+		Token:       accessToken + "|" + refreshToken,
+		ServiceURL:  stored.ServiceURL,
+		IsValid:     true,
+		AppPassword: stored.AppPassword,
+	}
+	if session.OAuth, err = oauthSession(stored); err != nil {
+		return nil, err
+	}
+	session.SetID(stored.Did)
+	session.SetCreatedAt(time.Now()) // TODO: store creation time
+	session.SetUpdatedAt(time.Now())
+
+	return session, nil
+}
+
+// Save persists a session with encrypted tokens to ~/.skycli/.config.json and makes its account the default.
+// Signing in to another account keeps the previous default signed in.
+func (r *SessionRepository) Save(ctx context.Context, model Model) error {
+	session, ok := model.(*SessionModel)
+	if !ok {
+		return errors.New("invalid model type: expected *SessionModel")
+	}
+
+	var accessToken, refreshToken string
+	parts := splitToken(session.Token)
+	if len(parts) == 2 {
+		accessToken = parts[0]
+		refreshToken = parts[1]
+	} else {
+		accessToken = session.Token
+	}
+
+	sessionConfig := &config.SessionConfig{
+		Handle:      session.Handle,
+		Did:         session.ID(),
+		ServiceURL:  session.ServiceURL,
+		Email:       "", // TODO: add Email field to SessionModel
+		AppPassword: session.AppPassword,
+	}
+
+	if err := sessionConfig.SetAccessToken(accessToken); err != nil {
+		return err
+	}
+
+	if err := sessionConfig.SetRefreshToken(refreshToken); err != nil {
+		return err
+	}
+
+	if session.OAuth != nil {
+		sessionConfig.OAuth = &config.OAuthConfig{
+			Issuer:        session.OAuth.Issuer,
+			TokenEndpoint: session.OAuth.TokenEndpoint,
+			ClientID:      session.OAuth.ClientID,
+		}
+		if err := sessionConfig.OAuth.SetDPoPKey(session.OAuth.DPoPKey); err != nil {
+			return err
+		}
+	}
+
+	r.makeDefault(sessionConfig)
+	return r.config.Save()
+}
+
+// makeDefault puts an account in the default slot, moving the previous default to the front of the others and
+// replacing any stored session for the same DID
+func (r *SessionRepository) makeDefault(session *config.SessionConfig) {
+	r.config.Accounts = slices.DeleteFunc(r.config.Accounts, func(other *config.SessionConfig) bool {
+		return other.Did == session.Did
+	})
+	if previous := r.config.Session; previous != nil && previous.Did != session.Did {
+		r.config.Accounts = append([]*config.SessionConfig{previous}, r.config.Accounts...)
+	}
+	r.config.Session = session
+	r.active = nil
+}
+
+// Delete signs out the account with the given DID or handle, or the current session when id is empty. Removing
+// the default promotes the most recently used of the other accounts.
+func (r *SessionRepository) Delete(ctx context.Context, id string) error {
+	target := r.current()
+	if id != "" {
+		target = r.find(id)
+	}
+	if target == nil {
+		return fmt.Errorf("no signed-in account %q: %w", id, ErrNotFound)
+	}
+
+	if target == r.config.Session {
+		r.config.Session = nil
+		if len(r.config.Accounts) > 0 {
+			r.config.Session, r.config.Accounts = r.config.Accounts[0], r.config.Accounts[1:]
+		}
+	} else {
+		r.config.Accounts = slices.DeleteFunc(r.config.Accounts, func(other *config.SessionConfig) bool { return other == target })
+	}
+	if r.active == target {
+		r.active = nil
+	}
+	return r.config.Save()
+}
+
+// Use runs the rest of this process as the signed-in account with the given DID or handle, leaving the default
+// unchanged
+func (r *SessionRepository) Use(ctx context.Context, account string) error {
+	stored := r.find(account)
+	if stored == nil {
+		return fmt.Errorf("no signed-in account %q: %w", account, ErrNotFound)
+	}
+	r.active = stored
+	return nil
+}
+
+// Switch makes the signed-in account with the given DID or handle the default for later runs
+func (r *SessionRepository) Switch(ctx context.Context, account string) error {
+	stored := r.find(account)
+	if stored == nil {
+		return fmt.Errorf("no signed-in account %q: %w", account, ErrNotFound)
+	}
+	r.makeDefault(stored)
+	return r.config.Save()
+}
+
+// GetAccessToken returns the decrypted access token for the current session
+func (r *SessionRepository) GetAccessToken(ctx context.Context) (string, error) {
+	session := r.current()
+	if session == nil {
+		return "", errors.New("no active session")
+	}
+	return session.GetAccessToken()
+}
+
+// GetRefreshToken returns the decrypted refresh token for the current session
+func (r *SessionRepository) GetRefreshToken(ctx context.Context) (string, error) {
+	session := r.current()
+	if session == nil {
+		return "", errors.New("no active session")
+	}
+	return session.GetRefreshToken()
+}
+
+// UpdateTokens updates both access and refresh tokens for the current session
+func (r *SessionRepository) UpdateTokens(ctx context.Context, accessToken, refreshToken string) error {
+	session := r.current()
+	if session == nil {
+		return errors.New("no active session")
+	}
+
+	if err := session.SetAccessToken(accessToken); err != nil {
+		return err
+	}
+
+	if err := session.SetRefreshToken(refreshToken); err != nil {
+		return err
+	}
+
+	return r.config.Save()
+}
+
+// GetOAuth returns the OAuth details of the current session, or nil when it was created with a password
+func (r *SessionRepository) GetOAuth(ctx context.Context) (*OAuthSession, error) {
+	session := r.current()
+	if session == nil {
+		return nil, errors.New("no active session")
+	}
+	return oauthSession(session)
+}
+
+// oauthSession decrypts the OAuth details of a stored account, or returns nil for a password session
+func oauthSession(session *config.SessionConfig) (*OAuthSession, error) {
+	stored := session.OAuth
+	if stored == nil {
+		return nil, nil
+	}
+
+	key, err := stored.GetDPoPKey()
+	if err != nil {
+		return nil, err
+	}
+	return &OAuthSession{Issuer: stored.Issuer, TokenEndpoint: stored.TokenEndpoint, ClientID: stored.ClientID, DPoPKey: key}, nil
+}
+
+// GetServiceURL returns the service the current session was created with
+func (r *SessionRepository) GetServiceURL(ctx context.Context) (string, error) {
+	session := r.current()
+	if session == nil {
+		return "", errors.New("no active session")
+	}
+	return session.ServiceURL, nil
+}
+
+// HasValidSession checks if there is an active session
+func (r *SessionRepository) HasValidSession(ctx context.Context) bool {
+	session := r.current()
+	return session != nil && session.EncryptedAccess != ""
+}
+
+// GetDid returns the DID for the current session
+func (r *SessionRepository) GetDid(ctx context.Context) (string, error) {
+	session := r.current()
+	if session == nil {
+		return "", errors.New("no active session")
+	}
+	return session.Did, nil
+}
+
+// GetHandle returns the handle for the current session
+func (r *SessionRepository) GetHandle(ctx context.Context) (string, error) {
+	session := r.current()
+	if session == nil {
+		return "", errors.New("no active session")
+	}
+	return session.Handle, nil
+}
+
+// UpdateHandle changes the handle stored for the current session, such as after the account changed it elsewhere
+func (r *SessionRepository) UpdateHandle(ctx context.Context, handle string) error {
+	session := r.current()
+	if session == nil {
+		return errors.New("no active session")
+	}
+	session.Handle = handle
+	return r.config.Save()
+}
+
+// UpdateAppPassword records the name of the app password the current session was created with
+func (r *SessionRepository) UpdateAppPassword(ctx context.Context, name string) error {
+	session := r.current()
+	if session == nil {
+		return errors.New("no active session")
+	}
+	session.AppPassword = name
+	return r.config.Save()
+}
+
+// splitToken splits a combined token string (accessToken|refreshToken)
+func splitToken(token string) []string {
+	result := []string{}
+	current := ""
+	for _, ch := range token {
+		if ch == '|' {
+			result = append(result, current)
+			current = ""
+		} else {
+			current += string(ch)
+		}
+	}
+	if current != "" {
+		result = append(result, current)
+	}
+	return result
+}
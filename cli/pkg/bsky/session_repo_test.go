@@ -1,7 +1,8 @@
-package store
+package bsky
 
 import (
 	"context"
+	"errors"
 	"os"
 	"strings"
 	"testing"
@@ -437,6 +438,44 @@ func TestUpdateTokens_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateAppPassword_Success(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	defer cleanup()
+
+	repo, err := NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+
+	ctx := context.Background()
+
+	if err := repo.UpdateAppPassword(ctx, "skycli"); err == nil {
+		t.Error("expected an error without a session")
+	}
+
+	session := &SessionModel{Handle: "test.bsky.social", Token: "access|refresh", ServiceURL: "https://bsky.social", AppPassword: "laptop"}
+	session.SetID("did:plc:test123")
+	if err := repo.Save(ctx, session); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if err := repo.UpdateAppPassword(ctx, "skycli"); err != nil {
+		t.Fatalf("UpdateAppPassword failed: %v", err)
+	}
+
+	reloaded, err := NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+	model, err := reloaded.Get(ctx, "did:plc:test123")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got := model.(*SessionModel).AppPassword; got != "skycli" {
+		t.Errorf("AppPassword = %q, want skycli", got)
+	}
+}
+
 // TestHasValidSession_NoSession verifies HasValidSession returns false when no session
 func TestHasValidSession_NoSession(t *testing.T) {
 	_, cleanup := utils.SetupTestConfig(t)
@@ -695,3 +734,195 @@ func TestSave_WithSingleToken(t *testing.T) {
 		t.Fatal("expected non-nil session")
 	}
 }
+
+// TestUpdateHandle verifies the session handle is replaced and persisted
+func TestUpdateHandle(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	defer cleanup()
+
+	repo, err := NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := repo.UpdateHandle(ctx, "new.example.com"); err == nil {
+		t.Error("expected error for no session, got nil")
+	}
+
+	session := &SessionModel{Handle: "old.bsky.social", Token: "access_token|refresh_token", ServiceURL: "https://bsky.social", IsValid: true}
+	session.SetID("did:plc:test123")
+	if err := repo.Save(ctx, session); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if err := repo.UpdateHandle(ctx, "new.example.com"); err != nil {
+		t.Fatalf("UpdateHandle failed: %v", err)
+	}
+
+	reloaded, err := NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+	if handle, _ := reloaded.GetHandle(ctx); handle != "new.example.com" {
+		t.Errorf("expected persisted handle new.example.com, got %s", handle)
+	}
+	if token, _ := reloaded.GetAccessToken(ctx); token != "access_token" {
+		t.Errorf("expected tokens to be kept, got %q", token)
+	}
+}
+
+// TestSaveAndGetOAuth verifies the OAuth details and DPoP key survive a reload and a token refresh
+func TestSaveAndGetOAuth(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	defer cleanup()
+
+	repo, err := NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+
+	ctx := context.Background()
+	signer, err := newDPoPSigner(nil)
+	if err != nil {
+		t.Fatalf("newDPoPSigner failed: %v", err)
+	}
+	key, err := MarshalDPoPKey(signer.key)
+	if err != nil {
+		t.Fatalf("MarshalDPoPKey failed: %v", err)
+	}
+
+	session := &SessionModel{
+		Handle:     "test.example.com",
+		Token:      "access_token|refresh_token",
+		ServiceURL: "https://pds.example.com",
+		IsValid:    true,
+		OAuth: &OAuthSession{
+			Issuer:        "https://auth.example.com",
+			TokenEndpoint: "https://auth.example.com/oauth/token",
+			ClientID:      "http://localhost?redirect_uri=http%3A%2F%2F127.0.0.1%3A1234%2Fcallback",
+			DPoPKey:       key,
+		},
+	}
+	session.SetID("did:plc:test123")
+	if err := repo.Save(ctx, session); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := repo.UpdateTokens(ctx, "new_access", "new_refresh"); err != nil {
+		t.Fatalf("UpdateTokens failed: %v", err)
+	}
+
+	reloaded, err := NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+	oauth, err := reloaded.GetOAuth(ctx)
+	if err != nil {
+		t.Fatalf("GetOAuth failed: %v", err)
+	}
+	if oauth == nil || oauth.Issuer != session.OAuth.Issuer || oauth.TokenEndpoint != session.OAuth.TokenEndpoint || oauth.ClientID != session.OAuth.ClientID {
+		t.Fatalf("unexpected OAuth session %+v", oauth)
+	}
+	if oauth.DPoPKey != key {
+		t.Error("DPoP key changed in storage")
+	}
+	if serviceURL, _ := reloaded.GetServiceURL(ctx); serviceURL != "https://pds.example.com" {
+		t.Errorf("expected service URL https://pds.example.com, got %s", serviceURL)
+	}
+
+	session.OAuth = nil
+	if err := repo.Save(ctx, session); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if oauth, err := repo.GetOAuth(ctx); err != nil || oauth != nil {
+		t.Errorf("expected no OAuth details for a password session, got %+v, %v", oauth, err)
+	}
+}
+
+// TestMultipleAccounts verifies logins add accounts, Use picks one for a run, and Switch and Delete change the default
+func TestMultipleAccounts(t *testing.T) {
+	_, cleanup := utils.SetupTestConfig(t)
+	defer cleanup()
+
+	repo, err := NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, handle := range []string{"alice.bsky.social", "bob.bsky.social", "carol.bsky.social"} {
+		session := &SessionModel{Handle: handle, Token: handle + "_access|" + handle + "_refresh", ServiceURL: "https://bsky.social", IsValid: true}
+		session.SetID("did:plc:" + strings.TrimSuffix(handle, ".bsky.social"))
+		if err := repo.Save(ctx, session); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	handles := func(repo *SessionRepository) []string {
+		t.Helper()
+		models, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		var handles []string
+		for _, model := range models {
+			handles = append(handles, model.(*SessionModel).Handle)
+		}
+		return handles
+	}
+	if got := strings.Join(handles(repo), ","); got != "carol.bsky.social,bob.bsky.social,alice.bsky.social" {
+		t.Fatalf("expected the newest login as default and the others most recent first, got %s", got)
+	}
+
+	if err := repo.Use(ctx, "@Bob.bsky.social"); err != nil {
+		t.Fatalf("Use failed: %v", err)
+	}
+	if err := repo.UpdateTokens(ctx, "bob_rotated", "bob_refresh2"); err != nil {
+		t.Fatalf("UpdateTokens failed: %v", err)
+	}
+	if did, _ := repo.GetDid(ctx); did != "did:plc:bob" {
+		t.Errorf("expected Use to pick bob, got %s", did)
+	}
+	if err := repo.Use(ctx, "dave.bsky.social"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Use of an unknown account: got %v, want ErrNotFound", err)
+	}
+
+	reloaded, err := NewSessionRepository()
+	if err != nil {
+		t.Fatalf("NewSessionRepository failed: %v", err)
+	}
+	if handle, _ := reloaded.GetHandle(ctx); handle != "carol.bsky.social" {
+		t.Errorf("Use should leave the default alone, got %s", handle)
+	}
+	if err := reloaded.Switch(ctx, "did:plc:bob"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+	if token, _ := reloaded.GetAccessToken(ctx); token != "bob_rotated" {
+		t.Errorf("expected the tokens refreshed during Use to be kept, got %q", token)
+	}
+	if got := strings.Join(handles(reloaded), ","); got != "bob.bsky.social,carol.bsky.social,alice.bsky.social" {
+		t.Errorf("unexpected order after Switch: %s", got)
+	}
+
+	// Logging in again replaces the stored session instead of adding a second one
+	again := &SessionModel{Handle: "alice.bsky.social", Token: "new_access|new_refresh", IsValid: true}
+	again.SetID("did:plc:alice")
+	if err := reloaded.Save(ctx, again); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if got := strings.Join(handles(reloaded), ","); got != "alice.bsky.social,bob.bsky.social,carol.bsky.social" {
+		t.Errorf("unexpected order after logging in again: %s", got)
+	}
+
+	if err := reloaded.Delete(ctx, ""); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := reloaded.Delete(ctx, "carol.bsky.social"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if got := strings.Join(handles(reloaded), ","); got != "bob.bsky.social" {
+		t.Errorf("expected removing the default to promote bob, got %s", got)
+	}
+	if err := reloaded.Delete(ctx, "carol.bsky.social"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete: got %v, want ErrNotFound", err)
+	}
+}
@@ -1,14 +1,14 @@
-package store
+package bsky
 
 import "time"
 
-// SnapshotModel represents a follower or following snapshot with metadata.
+// SnapshotModel represents a follower, following, or block list snapshot with metadata.
 // Stores snapshot metadata with TTL support (24 hours default).
 type SnapshotModel struct {
 	id           string
 	createdAt    time.Time
 	UserDid      string
-	SnapshotType string // "followers" or "following"
+	SnapshotType string // "followers", "following", or "blocks" (imported from an account export)
 	TotalCount   int
 	ExpiresAt    time.Time
 }
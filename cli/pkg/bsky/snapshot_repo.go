@@ -0,0 +1,498 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SnapshotRepository implements [SnapshotStore] on SQLite or PostgreSQL.
+// Manages follower/following snapshots with entries for diff and historical comparison.
+type SnapshotRepository struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// NewSnapshotRepository creates a new snapshot repository with SQLite backend
+func NewSnapshotRepository() (*SnapshotRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SnapshotRepository{db: db}, nil
+}
+
+// NewSQLiteSnapshotRepository creates a new snapshot repository backed by the SQLite database file at path
+func NewSQLiteSnapshotRepository(path string) (*SnapshotRepository, error) {
+	db, err := openDB(DialectSQLite, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SnapshotRepository{db: db, dialect: DialectSQLite}, nil
+}
+
+// NewPostgresSnapshotRepository creates a new snapshot repository backed by the PostgreSQL database at dsn
+func NewPostgresSnapshotRepository(dsn string) (*SnapshotRepository, error) {
+	db, err := openDB(DialectPostgres, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SnapshotRepository{db: db, dialect: DialectPostgres}, nil
+}
+
+// Init ensures database schema is initialized via the dialect's migrations
+func (r *SnapshotRepository) Init(ctx context.Context) error {
+	if r.dialect == DialectPostgres {
+		return RunDialectMigrations(r.db, r.dialect)
+	}
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *SnapshotRepository) Close() error {
+	return r.db.Close()
+}
+
+// Get retrieves a snapshot by ID
+func (r *SnapshotRepository) Get(ctx context.Context, id string) (Model, error) {
+	query := `
+		SELECT id, created_at, user_did, snapshot_type, total_count, expires_at
+		FROM follower_snapshots
+		WHERE id = ?
+	`
+
+	var snapshot SnapshotModel
+	var snapshotID string
+	var createdAt, expiresAt time.Time
+
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(query), id).Scan(
+		&snapshotID,
+		&createdAt,
+		&snapshot.UserDid,
+		&snapshot.SnapshotType,
+		&snapshot.TotalCount,
+		&expiresAt,
+	)
+
+	snapshot.SetID(snapshotID)
+	snapshot.SetCreatedAt(createdAt)
+	snapshot.ExpiresAt = expiresAt
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &RepositoryError{Op: "Get", Entity: "snapshot", ID: id, Err: fmt.Errorf("snapshot %w", ErrNotFound)}
+		}
+		return nil, &RepositoryError{Op: "Get", Entity: "snapshot", ID: id, Err: err}
+	}
+
+	return &snapshot, nil
+}
+
+// List retrieves all snapshots ordered by creation date (newest first)
+func (r *SnapshotRepository) List(ctx context.Context) ([]Model, error) {
+	query := `
+		SELECT id, created_at, user_did, snapshot_type, total_count, expires_at
+		FROM follower_snapshots
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query))
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "snapshot", Err: err}
+	}
+	defer rows.Close()
+
+	var snapshots []Model
+	for rows.Next() {
+		var snapshot SnapshotModel
+		var snapshotID string
+		var createdAt, expiresAt time.Time
+
+		err := rows.Scan(
+			&snapshotID,
+			&createdAt,
+			&snapshot.UserDid,
+			&snapshot.SnapshotType,
+			&snapshot.TotalCount,
+			&expiresAt,
+		)
+		if err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "snapshot", Err: err}
+		}
+
+		snapshot.SetID(snapshotID)
+		snapshot.SetCreatedAt(createdAt)
+		snapshot.ExpiresAt = expiresAt
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
+
+// Save creates a new snapshot (snapshots are immutable, no updates)
+func (r *SnapshotRepository) Save(ctx context.Context, model Model) (err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "SnapshotRepository.Save")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	snapshot, ok := model.(*SnapshotModel)
+	if !ok {
+		return &RepositoryError{Op: "Save", Entity: "snapshot", Err: errors.New("invalid model type: expected *SnapshotModel")}
+	}
+
+	if snapshot.ID() == "" {
+		snapshot.SetID(GenerateUUID())
+		snapshot.SetCreatedAt(time.Now())
+	}
+
+	if snapshot.ExpiresAt.IsZero() {
+		snapshot.ExpiresAt = time.Now().Add(24 * time.Hour)
+	}
+
+	query := `
+		INSERT INTO follower_snapshots (id, created_at, user_did, snapshot_type, total_count, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, r.dialect.Rebind(query),
+		snapshot.ID(),
+		snapshot.CreatedAt(),
+		snapshot.UserDid,
+		snapshot.SnapshotType,
+		snapshot.TotalCount,
+		snapshot.ExpiresAt,
+	)
+
+	if err != nil {
+		return &RepositoryError{Op: "Save", Entity: "snapshot", ID: snapshot.ID(), Err: err}
+	}
+
+	return nil
+}
+
+// Delete removes a snapshot by ID (cascade deletes entries)
+func (r *SnapshotRepository) Delete(ctx context.Context, id string) error {
+	query := "DELETE FROM follower_snapshots WHERE id = ?"
+	result, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), id)
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "snapshot", ID: id, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return &RepositoryError{Op: "Delete", Entity: "snapshot", ID: id, Err: err}
+	}
+
+	if rows == 0 {
+		return &RepositoryError{Op: "Delete", Entity: "snapshot", ID: id, Err: fmt.Errorf("snapshot %w", ErrNotFound)}
+	}
+
+	return nil
+}
+
+// FindByUserAndType retrieves the most recent fresh snapshot for a user and type.
+func (r *SnapshotRepository) FindByUserAndType(ctx context.Context, userDid, snapshotType string) (_ *SnapshotModel, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "SnapshotRepository.FindByUserAndType", attribute.String("snapshot.type", snapshotType))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	query := `
+		SELECT id, created_at, user_did, snapshot_type, total_count, expires_at
+		FROM follower_snapshots
+		WHERE user_did = ? AND snapshot_type = ? AND expires_at > ?
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var snapshot SnapshotModel
+	var snapshotID string
+	var createdAt, expiresAt time.Time
+
+	err = r.db.QueryRowContext(ctx, r.dialect.Rebind(query), userDid, snapshotType, time.Now()).Scan(
+		&snapshotID,
+		&createdAt,
+		&snapshot.UserDid,
+		&snapshot.SnapshotType,
+		&snapshot.TotalCount,
+		&expiresAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, &RepositoryError{Op: "FindByUserAndType", Entity: "snapshot", Err: err}
+	}
+
+	snapshot.SetID(snapshotID)
+	snapshot.SetCreatedAt(createdAt)
+	snapshot.ExpiresAt = expiresAt
+
+	return &snapshot, nil
+}
+
+// FindByUserTypeAndDate retrieves a snapshot for a user, type, and specific date, closest to (but not after) the specified date.
+func (r *SnapshotRepository) FindByUserTypeAndDate(ctx context.Context, userDid, snapshotType string, date time.Time) (_ *SnapshotModel, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "SnapshotRepository.FindByUserTypeAndDate", attribute.String("snapshot.type", snapshotType))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	query := `
+		SELECT id, created_at, user_did, snapshot_type, total_count, expires_at
+		FROM follower_snapshots
+		WHERE user_did = ? AND snapshot_type = ? AND created_at <= ?
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var snapshot SnapshotModel
+	var snapshotID string
+	var createdAt, expiresAt time.Time
+
+	err = r.db.QueryRowContext(ctx, r.dialect.Rebind(query), userDid, snapshotType, date).Scan(
+		&snapshotID,
+		&createdAt,
+		&snapshot.UserDid,
+		&snapshot.SnapshotType,
+		&snapshot.TotalCount,
+		&expiresAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, &RepositoryError{Op: "FindByUserTypeAndDate", Entity: "snapshot", Err: err}
+	}
+
+	snapshot.SetID(snapshotID)
+	snapshot.SetCreatedAt(createdAt)
+	snapshot.ExpiresAt = expiresAt
+	return &snapshot, nil
+}
+
+// SaveEntry saves a single snapshot entry
+func (r *SnapshotRepository) SaveEntry(ctx context.Context, entry *SnapshotEntry) error {
+	query := `
+		INSERT INTO follower_snapshot_entries (snapshot_id, actor_did, indexed_at)
+		VALUES (?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), entry.SnapshotID, entry.ActorDid, entry.IndexedAt)
+	if err != nil {
+		return &RepositoryError{Op: "SaveEntry", Entity: "snapshot", Err: err}
+	}
+	return nil
+}
+
+// SaveEntries saves multiple snapshot entries in a transaction for efficiency
+func (r *SnapshotRepository) SaveEntries(ctx context.Context, entries []*SnapshotEntry) (err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "SnapshotRepository.SaveEntries", attribute.Int("db.batch.size", len(entries)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &RepositoryError{Op: "SaveEntries", Entity: "snapshot", Err: err}
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, r.dialect.Rebind(`
+		INSERT INTO follower_snapshot_entries (snapshot_id, actor_did, indexed_at)
+		VALUES (?, ?, ?)
+	`))
+	if err != nil {
+		return &RepositoryError{Op: "SaveEntries", Entity: "snapshot", Err: err}
+	}
+	defer stmt.Close()
+
+	for _, entry := range entries {
+		_, err := stmt.ExecContext(ctx, entry.SnapshotID, entry.ActorDid, entry.IndexedAt)
+		if err != nil {
+			return &RepositoryError{Op: "SaveEntries", Entity: "snapshot", Err: err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &RepositoryError{Op: "SaveEntries", Entity: "snapshot", Err: err}
+	}
+	return nil
+}
+
+// GetEntries retrieves all entries for a snapshot
+func (r *SnapshotRepository) GetEntries(ctx context.Context, snapshotID string) (_ []*SnapshotEntry, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "SnapshotRepository.GetEntries")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	query := `
+		SELECT snapshot_id, actor_did, indexed_at
+		FROM follower_snapshot_entries
+		WHERE snapshot_id = ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), snapshotID)
+	if err != nil {
+		return nil, &RepositoryError{Op: "GetEntries", Entity: "snapshot", Err: err}
+	}
+	defer rows.Close()
+
+	var entries []*SnapshotEntry
+	for rows.Next() {
+		var entry SnapshotEntry
+		err := rows.Scan(&entry.SnapshotID, &entry.ActorDid, &entry.IndexedAt)
+		if err != nil {
+			return nil, &RepositoryError{Op: "GetEntries", Entity: "snapshot", Err: err}
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+// GetActorDids retrieves just the actor DIDs for a snapshot (efficient for diffs)
+func (r *SnapshotRepository) GetActorDids(ctx context.Context, snapshotID string) (_ []string, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "SnapshotRepository.GetActorDids")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	query := `
+		SELECT actor_did
+		FROM follower_snapshot_entries
+		WHERE snapshot_id = ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), snapshotID)
+	if err != nil {
+		return nil, &RepositoryError{Op: "GetActorDids", Entity: "snapshot", Err: err}
+	}
+	defer rows.Close()
+
+	var dids []string
+	for rows.Next() {
+		var did string
+		err := rows.Scan(&did)
+		if err != nil {
+			return nil, &RepositoryError{Op: "GetActorDids", Entity: "snapshot", Err: err}
+		}
+		dids = append(dids, did)
+	}
+	return dids, rows.Err()
+}
+
+// FirstSeen returns, for every actor in userDid's snapshots of snapshotType, when the oldest snapshot holding it was
+// taken. Expired snapshots are gone, so this is only as early as the oldest snapshot kept.
+func (r *SnapshotRepository) FirstSeen(ctx context.Context, userDid, snapshotType string) (_ map[string]time.Time, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "SnapshotRepository.FirstSeen")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	query := `
+		SELECT e.actor_did, s.created_at
+		FROM follower_snapshot_entries e
+		JOIN follower_snapshots s ON s.id = e.snapshot_id
+		WHERE s.user_did = ? AND s.snapshot_type = ?
+		ORDER BY s.created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), userDid, snapshotType)
+	if err != nil {
+		return nil, &RepositoryError{Op: "FirstSeen", Entity: "snapshot", Err: err}
+	}
+	defer rows.Close()
+
+	seen := make(map[string]time.Time)
+	for rows.Next() {
+		var did string
+		var createdAt time.Time
+		if err := rows.Scan(&did, &createdAt); err != nil {
+			return nil, &RepositoryError{Op: "FirstSeen", Entity: "snapshot", Err: err}
+		}
+		if _, ok := seen[did]; !ok {
+			seen[did] = createdAt
+		}
+	}
+	return seen, rows.Err()
+}
+
+// Import saves a snapshot and its entries in one transaction, keeping the snapshot's existing ID and timestamps.
+// Fails if a snapshot with the same ID is already stored so re-imports never duplicate history.
+func (r *SnapshotRepository) Import(ctx context.Context, snapshot *SnapshotModel, entries []*SnapshotEntry) (err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "SnapshotRepository.Import", attribute.Int("db.batch.size", len(entries)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if snapshot.ID() == "" {
+		return &RepositoryError{Op: "Import", Entity: "snapshot", Err: errors.New("snapshot ID is required")}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &RepositoryError{Op: "Import", Entity: "snapshot", Err: err}
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRowContext(ctx, r.dialect.Rebind("SELECT COUNT(*) FROM follower_snapshots WHERE id = ?"), snapshot.ID()).Scan(&exists)
+	if err != nil {
+		return &RepositoryError{Op: "Import", Entity: "snapshot", Err: err}
+	}
+	if exists > 0 {
+		return &RepositoryError{Op: "Import", Entity: "snapshot", Err: errors.New("snapshot already exists: " + snapshot.ID())}
+	}
+
+	_, err = tx.ExecContext(ctx, r.dialect.Rebind(`
+		INSERT INTO follower_snapshots (id, created_at, user_did, snapshot_type, total_count, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`), snapshot.ID(), snapshot.CreatedAt(), snapshot.UserDid, snapshot.SnapshotType, snapshot.TotalCount, snapshot.ExpiresAt)
+	if err != nil {
+		return &RepositoryError{Op: "Import", Entity: "snapshot", Err: err}
+	}
+
+	stmt, err := tx.PrepareContext(ctx, r.dialect.Rebind(`
+		INSERT INTO follower_snapshot_entries (snapshot_id, actor_did, indexed_at)
+		VALUES (?, ?, ?)
+	`))
+	if err != nil {
+		return &RepositoryError{Op: "Import", Entity: "snapshot", Err: err}
+	}
+	defer stmt.Close()
+
+	for _, entry := range entries {
+		if _, err := stmt.ExecContext(ctx, snapshot.ID(), entry.ActorDid, entry.IndexedAt); err != nil {
+			return &RepositoryError{Op: "Import", Entity: "snapshot", Err: err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &RepositoryError{Op: "Import", Entity: "snapshot", Err: err}
+	}
+	return nil
+}
+
+// DeleteExpiredSnapshots removes all expired snapshots and their entries
+func (r *SnapshotRepository) DeleteExpiredSnapshots(ctx context.Context) (_ int64, err error) {
+	ctx, span := r.dialect.startDBSpan(ctx, "SnapshotRepository.DeleteExpiredSnapshots")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	query := "DELETE FROM follower_snapshots WHERE expires_at < ?"
+	result, err := r.db.ExecContext(ctx, r.dialect.Rebind(query), time.Now())
+	if err != nil {
+		return 0, &RepositoryError{Op: "DeleteExpiredSnapshots", Entity: "snapshot", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &RepositoryError{Op: "DeleteExpiredSnapshots", Entity: "snapshot", Err: err}
+	}
+	return rows, nil
+}
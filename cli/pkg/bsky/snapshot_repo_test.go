@@ -1,4 +1,4 @@
-package store
+package bsky
 
 import (
 	"context"
@@ -565,6 +565,55 @@ func TestSnapshotRepository_IsFresh(t *testing.T) {
 	}
 }
 
+func TestSnapshotRepository_Import(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &SnapshotRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	createdAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	snapshot := &SnapshotModel{
+		UserDid:      "did:plc:testuser",
+		SnapshotType: "followers",
+		TotalCount:   2,
+		ExpiresAt:    createdAt.Add(24 * time.Hour),
+	}
+	snapshot.SetID("snapshot-from-other-machine")
+	snapshot.SetCreatedAt(createdAt)
+
+	entries := []*SnapshotEntry{
+		{ActorDid: "did:plc:follower1", IndexedAt: "2024-01-15T10:00:00Z"},
+		{ActorDid: "did:plc:follower2", IndexedAt: "2024-01-15T11:00:00Z"},
+	}
+
+	if err := repo.Import(context.Background(), snapshot, entries); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	model, err := repo.Get(context.Background(), "snapshot-from-other-machine")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !model.CreatedAt().Equal(createdAt) {
+		t.Errorf("expected created_at %v to be preserved, got %v", createdAt, model.CreatedAt())
+	}
+
+	dids, err := repo.GetActorDids(context.Background(), "snapshot-from-other-machine")
+	if err != nil {
+		t.Fatalf("GetActorDids failed: %v", err)
+	}
+	if len(dids) != 2 {
+		t.Errorf("expected 2 entries, got %d", len(dids))
+	}
+
+	if err := repo.Import(context.Background(), snapshot, entries); err == nil {
+		t.Error("expected error when importing an existing snapshot ID")
+	}
+}
+
 func TestSnapshotRepository_Close(t *testing.T) {
 	db, cleanup := utils.NewTestDB(t)
 	defer cleanup()
@@ -579,3 +628,52 @@ func TestSnapshotRepository_Close(t *testing.T) {
 		t.Errorf("Close failed: %v", err)
 	}
 }
+
+func TestSnapshotRepository_FirstSeen(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &SnapshotRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	older := time.Now().Add(-72 * time.Hour).UTC().Truncate(time.Second)
+	newer := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	for _, snap := range []struct {
+		created time.Time
+		kind    string
+		actors  []string
+	}{
+		{newer, "followers", []string{"did:plc:old", "did:plc:new"}},
+		{older, "followers", []string{"did:plc:old"}},
+		{older, "following", []string{"did:plc:new"}},
+	} {
+		snapshot := &SnapshotModel{UserDid: "did:plc:me", SnapshotType: snap.kind, TotalCount: len(snap.actors)}
+		snapshot.SetID(GenerateUUID())
+		snapshot.SetCreatedAt(snap.created)
+		snapshot.ExpiresAt = time.Now().Add(24 * time.Hour)
+		if err := repo.Save(context.Background(), snapshot); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		for _, actor := range snap.actors {
+			if err := repo.SaveEntry(context.Background(), &SnapshotEntry{SnapshotID: snapshot.ID(), ActorDid: actor}); err != nil {
+				t.Fatalf("SaveEntry failed: %v", err)
+			}
+		}
+	}
+
+	seen, err := repo.FirstSeen(context.Background(), "did:plc:me", "followers")
+	if err != nil {
+		t.Fatalf("FirstSeen failed: %v", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected 2 actors, got %v", seen)
+	}
+	if !seen["did:plc:old"].Equal(older) {
+		t.Errorf("expected did:plc:old first seen at %v, got %v", older, seen["did:plc:old"])
+	}
+	if !seen["did:plc:new"].Equal(newer) {
+		t.Errorf("expected did:plc:new first seen at %v (following snapshots ignored), got %v", newer, seen["did:plc:new"])
+	}
+}
@@ -0,0 +1,127 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+)
+
+// StreamCursor is the position a stream subscription reached, as a Jetstream time_us
+type StreamCursor struct {
+	Name      string
+	Cursor    int64
+	UpdatedAt time.Time
+}
+
+// Time returns the event time the cursor points at
+func (c *StreamCursor) Time() time.Time {
+	return time.UnixMicro(c.Cursor)
+}
+
+// StreamCursorRepository persists stream subscription cursors in the local SQLite cache database
+type StreamCursorRepository struct {
+	db *sql.DB
+}
+
+// NewStreamCursorRepository creates a new stream cursor repository with SQLite backend
+func NewStreamCursorRepository() (*StreamCursorRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamCursorRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *StreamCursorRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *StreamCursorRepository) Close() error {
+	return r.db.Close()
+}
+
+// Get returns the stored cursor of a subscription, or nil if it has none
+func (r *StreamCursorRepository) Get(ctx context.Context, name string) (*StreamCursor, error) {
+	cursor := StreamCursor{Name: name}
+	err := r.db.QueryRowContext(ctx, "SELECT cursor, updated_at FROM stream_cursors WHERE name = ?", name).
+		Scan(&cursor.Cursor, &cursor.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &RepositoryError{Op: "Get", Entity: "stream cursor", Err: err}
+	}
+	return &cursor, nil
+}
+
+// Save stores a subscription's cursor, replacing any earlier one. Zero cursors are ignored.
+func (r *StreamCursorRepository) Save(ctx context.Context, name string, cursor int64) error {
+	if cursor <= 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO stream_cursors (name, cursor, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET cursor = excluded.cursor, updated_at = excluded.updated_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, name, cursor, time.Now()); err != nil {
+		return &RepositoryError{Op: "Save", Entity: "stream cursor", Err: err}
+	}
+	return nil
+}
+
+// List returns every stored cursor ordered by subscription name
+func (r *StreamCursorRepository) List(ctx context.Context) (_ []*StreamCursor, err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "StreamCursorRepository.List")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(ctx, "SELECT name, cursor, updated_at FROM stream_cursors ORDER BY name")
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "stream cursor", Err: err}
+	}
+	defer rows.Close()
+
+	var cursors []*StreamCursor
+	for rows.Next() {
+		var cursor StreamCursor
+		if err := rows.Scan(&cursor.Name, &cursor.Cursor, &cursor.UpdatedAt); err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "stream cursor", Err: err}
+		}
+		cursors = append(cursors, &cursor)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "stream cursor", Err: err}
+	}
+	return cursors, nil
+}
+
+// Delete forgets a subscription's cursor so it next starts with live events.
+// It reports whether a cursor was stored.
+func (r *StreamCursorRepository) Delete(ctx context.Context, name string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM stream_cursors WHERE name = ?", name)
+	if err != nil {
+		return false, &RepositoryError{Op: "Delete", Entity: "stream cursor", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, &RepositoryError{Op: "Delete", Entity: "stream cursor", Err: err}
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,52 @@
+package bsky
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestStreamCursorRepository(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &StreamCursorRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if cursor, err := repo.Get(ctx, "archive"); err != nil || cursor != nil {
+		t.Fatalf("expected no cursor, got %+v (err %v)", cursor, err)
+	}
+
+	for _, value := range []int64{100, 0, 200} {
+		if err := repo.Save(ctx, "archive", value); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	if err := repo.Save(ctx, "followers", 50); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	cursor, err := repo.Get(ctx, "archive")
+	if err != nil || cursor == nil || cursor.Cursor != 200 || cursor.UpdatedAt.IsZero() {
+		t.Fatalf("expected latest non-zero cursor, got %+v (err %v)", cursor, err)
+	}
+
+	cursors, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(cursors) != 2 || cursors[0].Name != "archive" || cursors[1].Cursor != 50 {
+		t.Errorf("unexpected cursors %+v", cursors)
+	}
+
+	if deleted, err := repo.Delete(ctx, "followers"); err != nil || !deleted {
+		t.Errorf("expected followers cursor to be deleted, got %v (err %v)", deleted, err)
+	}
+	if deleted, _ := repo.Delete(ctx, "followers"); deleted {
+		t.Error("expected second delete to report nothing deleted")
+	}
+}
@@ -0,0 +1,148 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+)
+
+// StreamEventModel is a firehose event processed by a stream consumer.
+// Seq orders events across consumers in the order they were processed.
+type StreamEventModel struct {
+	Seq         int64
+	Consumer    string
+	Key         string // Identifies the event across redeliveries
+	Did         string
+	Kind        string
+	URI         string // Record URI of commit events
+	TimeUS      int64
+	ProcessedAt time.Time
+}
+
+// StreamEventRepository records which firehose events each stream consumer has processed, so events replayed
+// after a restart or backfill are skipped
+type StreamEventRepository struct {
+	db *sql.DB
+}
+
+// NewStreamEventRepository creates a new stream event repository with SQLite backend
+func NewStreamEventRepository() (*StreamEventRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamEventRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *StreamEventRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *StreamEventRepository) Close() error {
+	return r.db.Close()
+}
+
+// Processed reports whether consumer already processed the event with the given key
+func (r *StreamEventRepository) Processed(ctx context.Context, consumer, key string) (bool, error) {
+	var seq int64
+	err := r.db.QueryRowContext(ctx, "SELECT seq FROM stream_events WHERE consumer = ? AND event_key = ?", consumer, key).Scan(&seq)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, &RepositoryError{Op: "Processed", Entity: "stream event", Err: err}
+	}
+	return true, nil
+}
+
+// Record marks an event as processed by its consumer, assigning its sequence number and processing time.
+// It reports false, leaving the model unchanged, when the consumer had already processed the event.
+func (r *StreamEventRepository) Record(ctx context.Context, event *StreamEventModel) (bool, error) {
+	processedAt := time.Now()
+	result, err := r.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO stream_events (consumer, event_key, did, kind, uri, time_us, processed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, event.Consumer, event.Key, event.Did, event.Kind, event.URI, event.TimeUS, processedAt)
+	if err != nil {
+		return false, &RepositoryError{Op: "Record", Entity: "stream event", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, &RepositoryError{Op: "Record", Entity: "stream event", Err: err}
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	seq, err := result.LastInsertId()
+	if err != nil {
+		return false, &RepositoryError{Op: "Record", Entity: "stream event", Err: err}
+	}
+	event.Seq = seq
+	event.ProcessedAt = processedAt
+	return true, nil
+}
+
+// After returns up to limit events with a sequence number greater than seq, oldest first.
+// An empty consumer returns events of every consumer.
+func (r *StreamEventRepository) After(ctx context.Context, consumer string, seq int64, limit int) (_ []*StreamEventModel, err error) {
+	ctx, span := DialectSQLite.startDBSpan(ctx, "StreamEventRepository.After")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT seq, consumer, event_key, did, kind, uri, time_us, processed_at
+		FROM stream_events
+		WHERE seq > ? AND (? = '' OR consumer = ?)
+		ORDER BY seq
+		LIMIT ?
+	`, seq, consumer, consumer, limit)
+	if err != nil {
+		return nil, &RepositoryError{Op: "After", Entity: "stream event", Err: err}
+	}
+	defer rows.Close()
+
+	var events []*StreamEventModel
+	for rows.Next() {
+		var event StreamEventModel
+		if err := rows.Scan(&event.Seq, &event.Consumer, &event.Key, &event.Did, &event.Kind, &event.URI, &event.TimeUS, &event.ProcessedAt); err != nil {
+			return nil, &RepositoryError{Op: "After", Entity: "stream event", Err: err}
+		}
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &RepositoryError{Op: "After", Entity: "stream event", Err: err}
+	}
+	return events, nil
+}
+
+// Prune forgets events that happened before the given time_us, returning how many were removed.
+// Sequence numbers are never reused.
+func (r *StreamEventRepository) Prune(ctx context.Context, before int64) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM stream_events WHERE time_us < ?", before)
+	if err != nil {
+		return 0, &RepositoryError{Op: "Prune", Entity: "stream event", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &RepositoryError{Op: "Prune", Entity: "stream event", Err: err}
+	}
+	return rows, nil
+}
@@ -0,0 +1,69 @@
+package bsky
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestStreamEventRepository(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &StreamEventRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	events := []*StreamEventModel{
+		{Consumer: "archive", Key: "a", Did: "did:plc:a", Kind: "commit", URI: "at://did:plc:a/app.bsky.feed.post/1", TimeUS: 10},
+		{Consumer: "followers", Key: "a", Did: "did:plc:a", Kind: "commit", TimeUS: 10},
+		{Consumer: "archive", Key: "b", Did: "did:plc:b", Kind: "identity", TimeUS: 30},
+	}
+	for _, event := range events {
+		if recorded, err := repo.Record(ctx, event); err != nil || !recorded {
+			t.Fatalf("Record(%s/%s) = %v, %v", event.Consumer, event.Key, recorded, err)
+		}
+	}
+	if events[0].Seq == 0 || events[1].Seq <= events[0].Seq || events[0].ProcessedAt.IsZero() {
+		t.Errorf("expected increasing sequence numbers, got %d and %d", events[0].Seq, events[1].Seq)
+	}
+
+	replay := &StreamEventModel{Consumer: "archive", Key: "a", Did: "did:plc:a", Kind: "commit", TimeUS: 20}
+	if recorded, err := repo.Record(ctx, replay); err != nil || recorded || replay.Seq != 0 {
+		t.Errorf("expected replayed event to be ignored, got %v (seq %d, err %v)", recorded, replay.Seq, err)
+	}
+	if processed, _ := repo.Processed(ctx, "archive", "a"); !processed {
+		t.Error("expected archive to have processed a")
+	}
+	if processed, _ := repo.Processed(ctx, "followers", "b"); processed {
+		t.Error("expected followers not to have processed b")
+	}
+
+	archived, err := repo.After(ctx, "archive", 0, 10)
+	if err != nil {
+		t.Fatalf("After failed: %v", err)
+	}
+	if len(archived) != 2 || archived[0].URI != events[0].URI || archived[1].Key != "b" {
+		t.Errorf("unexpected archive events %+v", archived)
+	}
+	if all, _ := repo.After(ctx, "", events[0].Seq, 10); len(all) != 2 {
+		t.Errorf("expected 2 events after the first, got %d", len(all))
+	}
+
+	last := events[2].Seq
+	pruned, err := repo.Prune(ctx, 20)
+	if err != nil || pruned != 2 {
+		t.Fatalf("Prune() = %d, %v; want 2", pruned, err)
+	}
+	if processed, _ := repo.Processed(ctx, "archive", "a"); processed {
+		t.Error("expected pruned event to be forgotten")
+	}
+
+	again := &StreamEventModel{Consumer: "archive", Key: "c", Did: "did:plc:c", Kind: "commit", TimeUS: 40}
+	if _, err := repo.Record(ctx, again); err != nil || again.Seq <= last {
+		t.Errorf("expected sequence numbers not to be reused, got %d after %d", again.Seq, last)
+	}
+}
@@ -0,0 +1,315 @@
+package bsky
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// TagKind is the kind of local entity a tag is attached to
+type TagKind string
+
+const (
+	TagFeed  TagKind = "feed"  // a saved feed, by ID
+	TagPost  TagKind = "post"  // a stored post, by AT URI
+	TagActor TagKind = "actor" // an account such as a follower, by DID
+)
+
+// TagModel attaches a tag to one feed, post, or account
+type TagModel struct {
+	Tag       string
+	Kind      TagKind
+	EntityID  string
+	CreatedAt time.Time
+}
+
+// TagCount is how many entities of each kind carry a tag
+type TagCount struct {
+	Tag    string
+	Feeds  int
+	Posts  int
+	Actors int
+}
+
+// TagSet holds the entities carrying a tag, by kind
+type TagSet struct {
+	Feeds  []string // feed IDs
+	Posts  []string // post AT URIs
+	Actors []string // account DIDs
+}
+
+// NormalizeTag lowercases a tag and drops a leading #, so #VIP and vip are the same tag. Tags can't be empty or
+// contain spaces or commas.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	if tag == "" {
+		return "", errors.New("tag can't be empty")
+	}
+	if strings.IndexFunc(tag, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) >= 0 {
+		return "", fmt.Errorf("tag %q can't contain spaces or commas", tag)
+	}
+	return tag, nil
+}
+
+// TagRepository persists local tags in the SQLite cache database
+type TagRepository struct {
+	db *sql.DB
+}
+
+// NewTagRepository creates a new tag repository with SQLite backend
+func NewTagRepository() (*TagRepository, error) {
+	dbPath, err := config.GetCacheDB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TagRepository{db: db}, nil
+}
+
+// Init ensures database schema is initialized via migrations
+func (r *TagRepository) Init(ctx context.Context) error {
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return RunMigrations(r.db)
+}
+
+// Close releases database connection
+func (r *TagRepository) Close() error {
+	return r.db.Close()
+}
+
+// Add tags entities of one kind, skipping those already tagged, and returns how many were newly tagged
+func (r *TagRepository) Add(ctx context.Context, tag string, kind TagKind, ids ...string) (int64, error) {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return 0, &RepositoryError{Op: "Add", Entity: "tag", Err: err}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, &RepositoryError{Op: "Add", Entity: "tag", ID: tag, Err: err}
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, "INSERT OR IGNORE INTO tags (tag, kind, entity_id, created_at) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		return 0, &RepositoryError{Op: "Add", Entity: "tag", ID: tag, Err: err}
+	}
+	defer stmt.Close()
+
+	var added int64
+	now := time.Now().UTC()
+	for _, id := range ids {
+		result, err := stmt.ExecContext(ctx, tag, string(kind), id, now)
+		if err != nil {
+			return 0, &RepositoryError{Op: "Add", Entity: "tag", ID: tag, Err: err}
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, &RepositoryError{Op: "Add", Entity: "tag", ID: tag, Err: err}
+		}
+		added += rows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, &RepositoryError{Op: "Add", Entity: "tag", ID: tag, Err: err}
+	}
+	return added, nil
+}
+
+// Remove untags entities of one kind and returns how many carried the tag
+func (r *TagRepository) Remove(ctx context.Context, tag string, kind TagKind, ids ...string) (int64, error) {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return 0, &RepositoryError{Op: "Remove", Entity: "tag", Err: err}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	args := []any{tag, string(kind)}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	query := "DELETE FROM tags WHERE tag = ? AND kind = ? AND entity_id IN (" + buildPlaceholders(len(ids)) + ")"
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, &RepositoryError{Op: "Remove", Entity: "tag", ID: tag, Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &RepositoryError{Op: "Remove", Entity: "tag", ID: tag, Err: err}
+	}
+	return rows, nil
+}
+
+// List retrieves the entities carrying a tag, grouped by kind and oldest first
+func (r *TagRepository) List(ctx context.Context, tag string) ([]*TagModel, error) {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "tag", Err: err}
+	}
+
+	query := "SELECT tag, kind, entity_id, created_at FROM tags WHERE tag = ? ORDER BY kind, created_at, entity_id"
+	rows, err := r.db.QueryContext(ctx, query, tag)
+	if err != nil {
+		return nil, &RepositoryError{Op: "List", Entity: "tag", ID: tag, Err: err}
+	}
+	defer rows.Close()
+
+	var tags []*TagModel
+	for rows.Next() {
+		var model TagModel
+		var kind string
+		if err := rows.Scan(&model.Tag, &kind, &model.EntityID, &model.CreatedAt); err != nil {
+			return nil, &RepositoryError{Op: "List", Entity: "tag", ID: tag, Err: err}
+		}
+		model.Kind = TagKind(kind)
+		tags = append(tags, &model)
+	}
+	return tags, rows.Err()
+}
+
+// All retrieves every tag on every entity, in tag order
+func (r *TagRepository) All(ctx context.Context) ([]*TagModel, error) {
+	query := "SELECT tag, kind, entity_id, created_at FROM tags ORDER BY tag, kind, entity_id"
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &RepositoryError{Op: "All", Entity: "tag", Err: err}
+	}
+	defer rows.Close()
+
+	var tags []*TagModel
+	for rows.Next() {
+		var model TagModel
+		var kind string
+		if err := rows.Scan(&model.Tag, &kind, &model.EntityID, &model.CreatedAt); err != nil {
+			return nil, &RepositoryError{Op: "All", Entity: "tag", Err: err}
+		}
+		model.Kind = TagKind(kind)
+		tags = append(tags, &model)
+	}
+	return tags, rows.Err()
+}
+
+// Restore stores tags exactly as given, keeping their CreatedAt and skipping those already present (used when
+// syncing state between machines). It returns how many were newly tagged.
+func (r *TagRepository) Restore(ctx context.Context, tags []*TagModel) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, &RepositoryError{Op: "Restore", Entity: "tag", Err: err}
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, "INSERT OR IGNORE INTO tags (tag, kind, entity_id, created_at) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		return 0, &RepositoryError{Op: "Restore", Entity: "tag", Err: err}
+	}
+	defer stmt.Close()
+
+	var added int64
+	for _, model := range tags {
+		tag, err := NormalizeTag(model.Tag)
+		if err != nil {
+			return 0, &RepositoryError{Op: "Restore", Entity: "tag", Err: err}
+		}
+		result, err := stmt.ExecContext(ctx, tag, string(model.Kind), model.EntityID, model.CreatedAt)
+		if err != nil {
+			return 0, &RepositoryError{Op: "Restore", Entity: "tag", ID: tag, Err: err}
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, &RepositoryError{Op: "Restore", Entity: "tag", ID: tag, Err: err}
+		}
+		added += rows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, &RepositoryError{Op: "Restore", Entity: "tag", Err: err}
+	}
+	return added, nil
+}
+
+// TagsOf returns the tags on one entity, in tag order
+func (r *TagRepository) TagsOf(ctx context.Context, kind TagKind, id string) ([]string, error) {
+	query := "SELECT tag FROM tags WHERE kind = ? AND entity_id = ? ORDER BY tag"
+	rows, err := r.db.QueryContext(ctx, query, string(kind), id)
+	if err != nil {
+		return nil, &RepositoryError{Op: "TagsOf", Entity: "tag", ID: id, Err: err}
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, &RepositoryError{Op: "TagsOf", Entity: "tag", ID: id, Err: err}
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// Counts returns every tag in use with how many feeds, posts, and accounts carry it, in tag order
+func (r *TagRepository) Counts(ctx context.Context) ([]*TagCount, error) {
+	query := `
+		SELECT tag,
+			SUM(CASE WHEN kind = 'feed' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN kind = 'post' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN kind = 'actor' THEN 1 ELSE 0 END)
+		FROM tags
+		GROUP BY tag
+		ORDER BY tag
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &RepositoryError{Op: "Counts", Entity: "tag", Err: err}
+	}
+	defer rows.Close()
+
+	var counts []*TagCount
+	for rows.Next() {
+		var count TagCount
+		if err := rows.Scan(&count.Tag, &count.Feeds, &count.Posts, &count.Actors); err != nil {
+			return nil, &RepositoryError{Op: "Counts", Entity: "tag", Err: err}
+		}
+		counts = append(counts, &count)
+	}
+	return counts, rows.Err()
+}
+
+// Tagged returns the entities carrying a tag; a tag nothing carries gives an empty set
+func (r *TagRepository) Tagged(ctx context.Context, tag string) (*TagSet, error) {
+	models, err := r.List(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &TagSet{}
+	for _, model := range models {
+		switch model.Kind {
+		case TagFeed:
+			set.Feeds = append(set.Feeds, model.EntityID)
+		case TagPost:
+			set.Posts = append(set.Posts, model.EntityID)
+		case TagActor:
+			set.Actors = append(set.Actors, model.EntityID)
+		}
+	}
+	return set, nil
+}
@@ -0,0 +1,107 @@
+package bsky
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	for input, want := range map[string]string{"vip": "vip", " #VIP ": "vip", "Conf-2026": "conf-2026"} {
+		if got, err := NormalizeTag(input); err != nil || got != want {
+			t.Errorf("NormalizeTag(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "#", "two words", "a,b"} {
+		if _, err := NormalizeTag(input); err == nil {
+			t.Errorf("NormalizeTag(%q) succeeded, want an error", input)
+		}
+	}
+}
+
+func TestTagRepository(t *testing.T) {
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+
+	repo := &TagRepository{db: db}
+	ctx := context.Background()
+	if err := repo.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if added, err := repo.Add(ctx, "VIP", TagActor, "did:plc:alice", "did:plc:bob"); err != nil || added != 2 {
+		t.Fatalf("Add = %d, %v; want 2", added, err)
+	}
+	if added, err := repo.Add(ctx, "#vip", TagActor, "did:plc:alice"); err != nil || added != 0 {
+		t.Errorf("adding an existing tag = %d, %v; want 0", added, err)
+	}
+	if _, err := repo.Add(ctx, "vip", TagFeed, "feed-1"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := repo.Add(ctx, "conf", TagPost, "at://did:plc:alice/app.bsky.feed.post/1"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	set, err := repo.Tagged(ctx, "vip")
+	if err != nil {
+		t.Fatalf("Tagged failed: %v", err)
+	}
+	if len(set.Actors) != 2 || len(set.Feeds) != 1 || len(set.Posts) != 0 {
+		t.Errorf("Tagged(vip) = %+v, want 2 actors and 1 feed", set)
+	}
+
+	counts, err := repo.Counts(ctx)
+	if err != nil {
+		t.Fatalf("Counts failed: %v", err)
+	}
+	if len(counts) != 2 || counts[0].Tag != "conf" || counts[0].Posts != 1 || counts[1].Actors != 2 || counts[1].Feeds != 1 {
+		t.Errorf("unexpected counts %+v %+v", counts[0], counts[1])
+	}
+
+	if _, err := repo.Add(ctx, "conf", TagActor, "did:plc:alice"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if tags, err := repo.TagsOf(ctx, TagActor, "did:plc:alice"); err != nil || len(tags) != 2 || tags[0] != "conf" || tags[1] != "vip" {
+		t.Errorf("TagsOf = %v, %v; want [conf vip]", tags, err)
+	}
+
+	if removed, err := repo.Remove(ctx, "vip", TagActor, "did:plc:bob", "did:plc:carol"); err != nil || removed != 1 {
+		t.Errorf("Remove = %d, %v; want 1", removed, err)
+	}
+	models, err := repo.List(ctx, "vip")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(models) != 2 || models[0].Kind != TagActor || models[0].EntityID != "did:plc:alice" || models[0].CreatedAt.IsZero() {
+		t.Errorf("unexpected tagged entities after Remove: %+v", models)
+	}
+
+	all, err := repo.All(ctx)
+	if err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+	if len(all) != 4 || all[0].Tag != "conf" || all[3].Tag != "vip" || all[3].Kind != TagFeed {
+		t.Errorf("unexpected tags from All: %+v", all)
+	}
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	restored := []*TagModel{
+		{Tag: "vip", Kind: TagActor, EntityID: "did:plc:alice", CreatedAt: createdAt},
+		{Tag: "vip", Kind: TagActor, EntityID: "did:plc:dave", CreatedAt: createdAt},
+	}
+	if added, err := repo.Restore(ctx, restored); err != nil || added != 1 {
+		t.Errorf("Restore = %d, %v; want 1", added, err)
+	}
+	if models, err := repo.List(ctx, "vip"); err != nil || len(models) != 3 || !models[0].CreatedAt.Equal(createdAt) || models[0].EntityID != "did:plc:dave" {
+		t.Errorf("expected the restored tag to keep its creation time, got %+v, %v", models, err)
+	}
+
+	if empty, err := repo.Tagged(ctx, "nobody"); err != nil || len(empty.Actors)+len(empty.Feeds)+len(empty.Posts) != 0 {
+		t.Errorf("Tagged(nobody) = %+v, %v; want an empty set", empty, err)
+	}
+	if _, err := repo.Add(ctx, "two words", TagFeed, "feed-1"); err == nil {
+		t.Error("expected an invalid tag to be rejected")
+	}
+}
@@ -0,0 +1,103 @@
+package bsky
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// Timeouts bounds each class of API request through a context deadline. Zero fields fall back to Default.
+type Timeouts struct {
+	Default time.Duration
+	Auth    time.Duration
+	Health  time.Duration
+	Feed    time.Duration
+	Graph   time.Duration
+	Profile time.Duration
+	Search  time.Duration
+}
+
+// DefaultTimeouts returns the built-in request timeouts: quick health checks, generous limits for large pages
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Default: 30 * time.Second,
+		Auth:    15 * time.Second,
+		Health:  5 * time.Second,
+		Feed:    60 * time.Second,
+		Graph:   60 * time.Second,
+		Profile: 30 * time.Second,
+		Search:  30 * time.Second,
+	}
+}
+
+// UniformTimeouts applies d to every endpoint class
+func UniformTimeouts(d time.Duration) Timeouts {
+	return Timeouts{Default: d, Auth: d, Health: d, Feed: d, Graph: d, Profile: d, Search: d}
+}
+
+// TimeoutsFromConfig overlays configured durations on [DefaultTimeouts]
+func TimeoutsFromConfig(cfg *config.TimeoutConfig) (Timeouts, error) {
+	timeouts := DefaultTimeouts()
+	if cfg == nil {
+		return timeouts, nil
+	}
+
+	fields := []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"default", cfg.Default, &timeouts.Default},
+		{"auth", cfg.Auth, &timeouts.Auth},
+		{"health", cfg.Health, &timeouts.Health},
+		{"feed", cfg.Feed, &timeouts.Feed},
+		{"graph", cfg.Graph, &timeouts.Graph},
+		{"profile", cfg.Profile, &timeouts.Profile},
+		{"search", cfg.Search, &timeouts.Search},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(field.value)
+		if err != nil {
+			return Timeouts{}, fmt.Errorf("invalid %s timeout %q: %w", field.name, field.value, err)
+		}
+		if d <= 0 {
+			return Timeouts{}, fmt.Errorf("invalid %s timeout %q: must be positive", field.name, field.value)
+		}
+		*field.dst = d
+	}
+	return timeouts, nil
+}
+
+// or returns d, falling back to Default when d is unset
+func (t Timeouts) or(d time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return t.Default
+}
+
+// withTimeout derives a context that expires after d; an earlier deadline already on ctx still wins
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// cancelOnClose releases a request's timeout context once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,91 @@
+package bsky
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+func TestTimeoutsFromConfig(t *testing.T) {
+	t.Run("nil config keeps defaults", func(t *testing.T) {
+		timeouts, err := TimeoutsFromConfig(nil)
+		if err != nil {
+			t.Fatalf("TimeoutsFromConfig failed: %v", err)
+		}
+		if timeouts != DefaultTimeouts() {
+			t.Errorf("expected defaults, got %+v", timeouts)
+		}
+	})
+
+	t.Run("overrides configured endpoints", func(t *testing.T) {
+		timeouts, err := TimeoutsFromConfig(&config.TimeoutConfig{Health: "2s", Graph: "2m"})
+		if err != nil {
+			t.Fatalf("TimeoutsFromConfig failed: %v", err)
+		}
+		if timeouts.Health != 2*time.Second || timeouts.Graph != 2*time.Minute {
+			t.Errorf("expected overrides to apply, got %+v", timeouts)
+		}
+		if timeouts.Feed != DefaultTimeouts().Feed {
+			t.Errorf("expected unset feed timeout to keep default, got %v", timeouts.Feed)
+		}
+	})
+
+	for _, value := range []string{"soon", "-5s", "0s"} {
+		t.Run("rejects "+value, func(t *testing.T) {
+			if _, err := TimeoutsFromConfig(&config.TimeoutConfig{Search: value}); err == nil {
+				t.Errorf("expected error for %q", value)
+			}
+		})
+	}
+}
+
+func TestBlueskyService_EndpointTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"feed":[]}`))
+	}))
+	defer server.Close()
+
+	svc := NewBlueskyService(server.URL)
+	svc.SetTokens("test-access-token", "test-refresh-token")
+	svc.SetTimeouts(Timeouts{Default: time.Second, Health: 10 * time.Millisecond})
+
+	err := svc.HealthCheck(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected health check to hit its deadline, got %v", err)
+	}
+
+	if _, err := svc.GetTimeline(context.Background(), 10, ""); err != nil {
+		t.Errorf("expected timeline to fall back to the default timeout, got %v", err)
+	}
+}
+
+func TestBlueskyService_Request_DefaultTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	svc := NewBlueskyService(server.URL)
+	svc.SetTokens("test-access-token", "test-refresh-token")
+	svc.SetTimeouts(UniformTimeouts(10 * time.Millisecond))
+
+	if _, err := svc.Request(context.Background(), "GET", "/test", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected request without deadline to use the default timeout, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := svc.Request(ctx, "GET", "/test", nil, nil)
+	if err != nil {
+		t.Fatalf("expected caller deadline to take precedence, got %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,163 @@
+package bsky
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const redacted = "[REDACTED]"
+
+// rateLimitHeaders are the response headers the AppView and PDS use to report rate limiting
+var rateLimitHeaders = []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"}
+
+// secretFields matches JSON string fields that carry credentials
+var secretFields = regexp.MustCompile(`"(accessJwt|refreshJwt|password)"(\s*:\s*)"[^"]*"`)
+
+// TracingTransport logs a sanitized line for every API call and optionally appends full exchanges to a trace file.
+// Tokens and passwords are redacted from URLs, headers, and bodies.
+type TracingTransport struct {
+	base      http.RoundTripper
+	logger    *log.Logger
+	tracePath string
+	mu        sync.Mutex
+}
+
+// NewTracingTransport wraps base; tracePath may be empty to skip body dumps
+func NewTracingTransport(base http.RoundTripper, logger *log.Logger, tracePath string) *TracingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &TracingTransport{base: base, logger: logger, tracePath: tracePath}
+}
+
+// RoundTrip performs the request and records it
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if t.tracePath != "" && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = body
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start).Round(time.Millisecond)
+
+	target := sanitizeURL(req.URL)
+	if err != nil {
+		t.logger.Warn("http", "method", req.Method, "url", target, "duration", duration, "error", err)
+		return nil, err
+	}
+
+	keyvals := []any{"method", req.Method, "url", target, "status", resp.StatusCode, "duration", duration}
+	for _, header := range rateLimitHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			keyvals = append(keyvals, strings.ToLower(header), value)
+		}
+	}
+	t.logger.Info("http", keyvals...)
+
+	if t.tracePath != "" {
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+		if err := t.dump(req, reqBody, resp, respBody, duration); err != nil {
+			t.logger.Warn("failed to write HTTP trace", "path", t.tracePath, "error", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// dump appends one sanitized request/response exchange to the trace file
+func (t *TracingTransport) dump(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, duration time.Duration) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s %s %s (%s)\n", time.Now().Format(time.RFC3339), req.Method, sanitizeURL(req.URL), duration)
+	writeHeaders(&b, req.Header)
+	if len(reqBody) > 0 {
+		b.WriteString("\n")
+		b.Write(redactBody(reqBody))
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "--- %s\n", resp.Status)
+	writeHeaders(&b, resp.Header)
+	b.WriteString("\n")
+	b.Write(redactBody(respBody))
+	b.WriteString("\n\n")
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, err := os.OpenFile(t.tracePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(b.String())
+	return err
+}
+
+// writeHeaders writes headers one per line with credentials redacted
+func writeHeaders(b *strings.Builder, headers http.Header) {
+	for name, values := range headers {
+		value := strings.Join(values, ", ")
+		if isSecretName(name) || strings.EqualFold(name, "Set-Cookie") {
+			value = redacted
+		}
+		fmt.Fprintf(b, "%s: %s\n", name, value)
+	}
+}
+
+// sanitizeURL renders u with credential-like query parameters and userinfo redacted
+func sanitizeURL(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+
+	query := clean.Query()
+	changed := false
+	for key := range query {
+		if isSecretName(key) {
+			query.Set(key, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		clean.RawQuery = query.Encode()
+	}
+	return clean.String()
+}
+
+// redactBody masks credential fields in a JSON body
+func redactBody(body []byte) []byte {
+	return secretFields.ReplaceAll(body, []byte(`"$1"$2"`+redacted+`"`))
+}
+
+// isSecretName reports whether a header or parameter name likely carries a credential
+func isSecretName(name string) bool {
+	name = strings.ToLower(name)
+	for _, marker := range []string{"authorization", "token", "password", "secret", "jwt", "cookie"} {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
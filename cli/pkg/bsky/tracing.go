@@ -0,0 +1,59 @@
+package bsky
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName scopes spans from this package; spans are dropped unless a tracer provider is installed
+const tracerName = "github.com/stormlightlabs/skypanel/cli/pkg/bsky"
+
+// startSpan starts a span of the given kind as a child of any span already in ctx
+func startSpan(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// startDBSpan starts a span for a repository operation against the dialect's database
+func (d Dialect) startDBSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	system := "sqlite"
+	if d == DialectPostgres {
+		system = "postgresql"
+	}
+	return startSpan(ctx, name, trace.SpanKindClient, append(attrs, attribute.String("db.system", system))...)
+}
+
+// do sends req inside a client span carrying the method, path, and status code, records the rate-limit budget
+// the response reports, and feeds its status and latency to the concurrency controller
+func (s *BlueskyService) do(req *http.Request) (*http.Response, error) {
+	ctx, span := startSpan(req.Context(), "bluesky "+req.URL.Path, trace.SpanKindClient,
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.path", req.URL.Path),
+		attribute.String("server.address", req.URL.Host),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now()
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		s.concurrency.Observe(0, time.Since(start), err)
+		telemetry.EndSpan(span, err)
+		return nil, err
+	}
+
+	s.concurrency.Observe(resp.StatusCode, time.Since(start), nil)
+	s.recordQuota(req, resp)
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	span.End()
+	return resp, nil
+}
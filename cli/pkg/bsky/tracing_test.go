@@ -0,0 +1,106 @@
+package bsky
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs an in-memory tracer provider for the duration of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	before, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(before)
+		otel.SetTextMapPropagator(propagator)
+	})
+	return recorder
+}
+
+// spanAttr returns the value of key on span, or an empty value if missing
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestBlueskyService_Spans(t *testing.T) {
+	recorder := recordSpans(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Traceparent") == "" {
+			t.Error("expected trace context to be propagated")
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"feed":[]}`))
+	}))
+	defer server.Close()
+
+	svc := NewBlueskyService(server.URL)
+	svc.SetTokens("access", "refresh")
+	if _, err := svc.GetTimeline(context.Background(), 10, ""); err != nil {
+		t.Fatalf("GetTimeline failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "bluesky /xrpc/app.bsky.feed.getTimeline" {
+		t.Errorf("unexpected span name %q", span.Name())
+	}
+	if got := spanAttr(span, "http.response.status_code").AsInt64(); got != http.StatusOK {
+		t.Errorf("expected status code attribute 200, got %d", got)
+	}
+	if got := spanAttr(span, "http.request.method").AsString(); got != http.MethodGet {
+		t.Errorf("expected method attribute GET, got %q", got)
+	}
+}
+
+func TestSnapshotRepository_Spans(t *testing.T) {
+	recorder := recordSpans(t)
+
+	db, cleanup := utils.NewTestDB(t)
+	defer cleanup()
+	repo := &SnapshotRepository{db: db}
+	if err := repo.Init(context.Background()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if _, err := repo.GetActorDids(context.Background(), "missing"); err != nil {
+		t.Fatalf("GetActorDids failed: %v", err)
+	}
+	repo.db.Close()
+	if _, err := repo.GetEntries(context.Background(), "missing"); err == nil {
+		t.Fatal("expected error from closed database")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name() != "SnapshotRepository.GetActorDids" || spans[1].Name() != "SnapshotRepository.GetEntries" {
+		t.Errorf("unexpected span names %q, %q", spans[0].Name(), spans[1].Name())
+	}
+	if got := spanAttr(spans[0], "db.system").AsString(); got != "sqlite" {
+		t.Errorf("expected db.system sqlite, got %q", got)
+	}
+	if len(spans[1].Events()) == 0 || spans[1].Status().Description == "" {
+		t.Error("expected failed query to record an error on its span")
+	}
+}
@@ -0,0 +1,168 @@
+package bsky
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+// defaultMaxIdleConnsPerHost matches the ceiling of the adaptive concurrency controller,
+// so every in-flight request can reuse a warm connection instead of redialing.
+const defaultMaxIdleConnsPerHost = MaxConcurrency
+
+var (
+	transportMu     sync.Mutex
+	sharedTransport http.RoundTripper
+)
+
+// TransportOptions configures the HTTP transport built by [NewTransport].
+type TransportOptions struct {
+	Proxy               string // http, https, socks5, or socks5h URL; empty uses HTTPS_PROXY/HTTP_PROXY/NO_PROXY
+	CAFile              string // PEM file of extra root certificates trusted alongside the system pool
+	InsecureSkipVerify  bool
+	MinTLSVersion       uint16 // tls.VersionTLS12 when zero
+	DisableHTTP2        bool
+	MaxIdleConnsPerHost int
+}
+
+// TransportOptionsFromConfig converts the network section of the config file into [TransportOptions]
+func TransportOptionsFromConfig(cfg *config.NetworkConfig) (TransportOptions, error) {
+	if cfg == nil {
+		return TransportOptions{}, nil
+	}
+
+	opts := TransportOptions{
+		Proxy:               cfg.Proxy,
+		CAFile:              cfg.CAFile,
+		InsecureSkipVerify:  cfg.InsecureSkipVerify,
+		DisableHTTP2:        cfg.DisableHTTP2,
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+	}
+
+	switch cfg.MinTLSVersion {
+	case "", "1.2":
+		opts.MinTLSVersion = tls.VersionTLS12
+	case "1.3":
+		opts.MinTLSVersion = tls.VersionTLS13
+	default:
+		return TransportOptions{}, fmt.Errorf("unsupported minimum TLS version: %s", cfg.MinTLSVersion)
+	}
+
+	return opts, nil
+}
+
+// NewTransport builds a keep-alive HTTP transport that attempts HTTP/2 unless disabled
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	proxy := http.ProxyFromEnvironment
+	if opts.Proxy != "" {
+		proxyURL, err := parseProxyURL(opts.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         opts.MinTLSVersion,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+	if tlsConfig.MinVersion == 0 {
+		tlsConfig.MinVersion = tls.VersionTLS12
+	}
+	if opts.CAFile != "" {
+		pool, err := loadCertPool(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	idlePerHost := opts.MaxIdleConnsPerHost
+	if idlePerHost <= 0 {
+		idlePerHost = defaultMaxIdleConnsPerHost
+	}
+
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     !opts.DisableHTTP2,
+		MaxIdleConns:          idlePerHost * 2,
+		MaxIdleConnsPerHost:   idlePerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	if opts.DisableHTTP2 {
+		// A non-nil empty map stops net/http from negotiating h2
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport, nil
+}
+
+// SharedTransport returns the transport handed to new API clients, building a default one on first use
+func SharedTransport() http.RoundTripper {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+
+	if sharedTransport == nil {
+		// Default options cannot fail: no proxy URL or CA file to parse
+		sharedTransport, _ = NewTransport(TransportOptions{})
+	}
+	return sharedTransport
+}
+
+// SetSharedTransport replaces the transport handed to API clients created afterwards
+func SetSharedTransport(rt http.RoundTripper) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	sharedTransport = rt
+}
+
+// parseProxyURL validates a proxy URL. SOCKS5 proxies resolve hostnames remotely (as socks5h), so DNS
+// lookups do not leak around Tor.
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https, socks5, or socks5h)", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("proxy URL missing host: %s", raw)
+	}
+	return proxyURL, nil
+}
+
+// loadCertPool returns the system roots plus the certificates in the PEM file at path
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file: %s", path)
+	}
+	return pool, nil
+}
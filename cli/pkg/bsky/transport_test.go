@@ -0,0 +1,224 @@
+package bsky
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stormlightlabs/skypanel/cli/internal/config"
+)
+
+func TestNewTransport_Defaults(t *testing.T) {
+	transport, err := NewTransport(TransportOptions{})
+	if err != nil {
+		t.Fatalf("NewTransport failed: %v", err)
+	}
+
+	if !transport.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be attempted by default")
+	}
+	if transport.MaxIdleConnsPerHost != defaultMaxIdleConnsPerHost {
+		t.Errorf("expected %d idle connections per host, got %d", defaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	}
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("expected TLS 1.2 minimum, got %x", transport.TLSClientConfig.MinVersion)
+	}
+}
+
+func TestNewTransport_DisableHTTP2(t *testing.T) {
+	transport, err := NewTransport(TransportOptions{DisableHTTP2: true})
+	if err != nil {
+		t.Fatalf("NewTransport failed: %v", err)
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Error("expected HTTP/2 negotiation to be disabled")
+	}
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	transport, err := NewTransport(TransportOptions{Proxy: proxy.URL})
+	if err != nil {
+		t.Fatalf("NewTransport failed: %v", err)
+	}
+
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get("http://api.example.invalid/xrpc/_health")
+	if err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if proxied != "http://api.example.invalid/xrpc/_health" {
+		t.Errorf("expected request to reach the proxy, got %q", proxied)
+	}
+}
+
+// serveSOCKS5 accepts one no-auth SOCKS5 CONNECT on ln, reports the requested host:port, and relays to dial
+func serveSOCKS5(ln net.Listener, dial string, requested chan<- string) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	greeting := make([]byte, 2)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		return
+	}
+	io.ReadFull(conn, make([]byte, greeting[1]))
+	conn.Write([]byte{5, 0})
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return
+	}
+	var host string
+	switch header[3] {
+	case 3:
+		length := make([]byte, 1)
+		io.ReadFull(conn, length)
+		name := make([]byte, length[0])
+		io.ReadFull(conn, name)
+		host = string(name)
+	case 1:
+		ip := make([]byte, 4)
+		io.ReadFull(conn, ip)
+		host = net.IP(ip).String()
+	}
+	portBytes := make([]byte, 2)
+	io.ReadFull(conn, portBytes)
+	requested <- net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(portBytes))))
+
+	upstream, err := net.Dial("tcp", dial)
+	if err != nil {
+		conn.Write([]byte{5, 1, 0, 1, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer upstream.Close()
+	conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+
+	go io.Copy(upstream, conn)
+	io.Copy(conn, upstream)
+}
+
+func TestNewTransport_SOCKS5(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	requested := make(chan string, 1)
+	go serveSOCKS5(ln, server.Listener.Addr().String(), requested)
+
+	transport, err := NewTransport(TransportOptions{Proxy: "socks5://" + ln.Addr().String()})
+	if err != nil {
+		t.Fatalf("NewTransport failed: %v", err)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Get("http://bsky.onion.invalid:8080/xrpc/_health")
+	if err != nil {
+		t.Fatalf("request through SOCKS5 proxy failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// The hostname reaches the proxy unresolved, so DNS never leaks outside it
+	if got := <-requested; got != "bsky.onion.invalid:8080" {
+		t.Errorf("expected proxy to receive unresolved host, got %q", got)
+	}
+}
+
+func TestNewTransport_CAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	untrusted, err := NewTransport(TransportOptions{})
+	if err != nil {
+		t.Fatalf("NewTransport failed: %v", err)
+	}
+	if _, err := (&http.Client{Transport: untrusted}).Get(server.URL); err == nil {
+		t.Error("expected unknown certificate authority to be rejected")
+	}
+
+	trusted, err := NewTransport(TransportOptions{CAFile: caFile})
+	if err != nil {
+		t.Fatalf("NewTransport failed: %v", err)
+	}
+	resp, err := (&http.Client{Transport: trusted}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected CA file to be trusted: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewTransport_Errors(t *testing.T) {
+	for _, proxy := range []string{"://bad", "ftp://proxy.example.com", "socks5://"} {
+		if _, err := NewTransport(TransportOptions{Proxy: proxy}); err == nil {
+			t.Errorf("expected error for proxy %q", proxy)
+		}
+	}
+
+	emptyCA := filepath.Join(t.TempDir(), "empty.pem")
+	os.WriteFile(emptyCA, []byte("not a certificate"), 0600)
+	if _, err := NewTransport(TransportOptions{CAFile: emptyCA}); err == nil {
+		t.Error("expected error for CA file without certificates")
+	}
+}
+
+func TestTransportOptionsFromConfig(t *testing.T) {
+	opts, err := TransportOptionsFromConfig(&config.NetworkConfig{MinTLSVersion: "1.3", MaxIdleConnsPerHost: 20})
+	if err != nil {
+		t.Fatalf("TransportOptionsFromConfig failed: %v", err)
+	}
+	if opts.MinTLSVersion != tls.VersionTLS13 || opts.MaxIdleConnsPerHost != 20 {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	if _, err := TransportOptionsFromConfig(&config.NetworkConfig{MinTLSVersion: "1.0"}); err == nil {
+		t.Error("expected error for unsupported TLS version")
+	}
+}
+
+func TestSharedTransport(t *testing.T) {
+	t.Cleanup(func() { SetSharedTransport(nil) })
+
+	first := NewBlueskyService("")
+	second := NewBlueskyService("")
+	if first.client.Transport != second.client.Transport {
+		t.Error("expected services to share one transport")
+	}
+
+	custom := &http.Transport{}
+	SetSharedTransport(custom)
+	if NewBlueskyService("").client.Transport != custom {
+		t.Error("expected new services to use the replaced transport")
+	}
+}
@@ -0,0 +1,51 @@
+# Builds skycli and checks the behavior Windows users depend on from PowerShell: the config directory
+# override (with a space in the path), first-run setup, uncolored output when piped, and the --crlf export flag.
+# Runs under both PowerShell 7 (pwsh) and Windows PowerShell 5.1.
+
+$ErrorActionPreference = 'Stop'
+
+$root = Split-Path -Parent $PSScriptRoot
+$work = Join-Path ([System.IO.Path]::GetTempPath()) ("skycli smoke " + [guid]::NewGuid())
+$bin = Join-Path $work 'skycli.exe'
+New-Item -ItemType Directory -Path $work | Out-Null
+
+function Invoke-Skycli {
+    # Windows PowerShell turns stderr lines into errors, which Stop would throw on; check the exit code instead
+    $ErrorActionPreference = 'Continue'
+    $output = & $bin @args 2>&1 | Out-String
+    if ($LASTEXITCODE -ne 0) {
+        throw "skycli $args exited with $LASTEXITCODE`n$output"
+    }
+    return $output
+}
+
+try {
+    go -C $root build -o $bin ./cli/cmd
+    if ($LASTEXITCODE -ne 0) { throw 'go build failed' }
+
+    $env:SKYCLI_CONFIG_DIR = Join-Path $work 'config dir'
+
+    $version = Invoke-Skycli --version
+    Write-Host $version.Trim()
+
+    Invoke-Skycli setup | Out-Null
+    if (-not (Test-Path (Join-Path $env:SKYCLI_CONFIG_DIR 'cache.db'))) {
+        throw "setup did not create cache.db under $env:SKYCLI_CONFIG_DIR"
+    }
+
+    $help = Invoke-Skycli --help
+    if ($help.Contains([char]27)) {
+        throw 'help output piped to PowerShell contains ANSI escape codes'
+    }
+
+    $exportHelp = Invoke-Skycli export feed --help
+    if ($exportHelp -notmatch '--crlf') {
+        throw 'export feed --help does not list --crlf'
+    }
+
+    Write-Host 'PowerShell smoke test passed'
+}
+finally {
+    Remove-Item Env:SKYCLI_CONFIG_DIR -ErrorAction SilentlyContinue
+    Remove-Item -Recurse -Force $work -ErrorAction SilentlyContinue
+}